@@ -125,6 +125,7 @@ const (
 	SortPolicyHybrid   = types.SortPolicyHybrid
 	SortPolicyPriority = types.SortPolicyPriority
 	SortPolicyOldest   = types.SortPolicyOldest
+	SortPolicyVotes    = types.SortPolicyVotes
 )
 
 // EventType constants
@@ -140,4 +141,11 @@ const (
 	EventLabelAdded        = types.EventLabelAdded
 	EventLabelRemoved      = types.EventLabelRemoved
 	EventCompacted         = types.EventCompacted
+	EventReactionAdded     = types.EventReactionAdded
+	EventReactionRemoved   = types.EventReactionRemoved
+	EventLocked            = types.EventLocked
+	EventUnlocked          = types.EventUnlocked
+	EventHandedOff         = types.EventHandedOff
+	EventAcknowledged      = types.EventAcknowledged
+	EventEscalated         = types.EventEscalated
 )
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/audit"
+	"github.com/steveyegge/beads/internal/erasure"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var actorCmd = &cobra.Command{
+	Use:     "actor",
+	GroupID: "maint",
+	Short:   "Manage the people recorded in the database",
+}
+
+var actorEraseCmd = &cobra.Command{
+	Use:   "erase <name> [alias...]",
+	Short: "Replace a person's name with a pseudonym everywhere it is recorded",
+	Long: `Replace a person's actor name, and any aliases such as an email address,
+with a pseudonym in every record of who did what, for honoring erasure
+requests (e.g. under GDPR):
+
+  - assignee, owner, created-by, sender, and actor fields of issues and wisps
+  - comment authors, dependency creators, and reactions
+  - the events audit trail, including issue snapshots recorded in events
+  - interactions, the intent log, and compaction snapshots
+  - away periods (bd availability) and who recorded them
+  - lock holders (bd lock)
+  - creators of saved alerts (bd alert) and external keys (bd alias)
+  - who resolved tracker sync conflicts
+  - milestone creators and who took their baselines
+  - assignees and creators of recurring issues (bd recur)
+  - .beads/interactions.jsonl
+
+Names are matched exactly. With --mentions, whole-word mentions in titles,
+descriptions, design, acceptance criteria, notes, close reasons, comments,
+away notes, and event values are replaced as well (case-insensitive; "bob" matches
+"@bob" but not "bobcat").
+
+The pseudonym defaults to a random "erased-xxxxxxxx" name, so the records
+stay attributable to one (unknown) person. Use --as to choose it.
+
+Without --force, erase shows what it would change and makes no changes.
+
+History: erase rewrites the current data only. Earlier Dolt commits still
+contain the names. --squash-history replaces this branch's history with a
+single commit and garbage-collects the old versions. Other branches, Dolt
+remotes, and other clones keep the old history until they are deleted,
+force-pushed over ('bd dolt push --force'), or re-cloned, and git history
+of .beads/interactions.jsonl must be rewritten separately.
+
+Examples:
+  bd actor erase bob bob@example.com              # Preview
+  bd actor erase bob bob@example.com --force      # Erase
+  bd actor erase bob --mentions --as former-user --force
+  bd actor erase bob --force --squash-history     # Also drop old history`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runActorErase,
+}
+
+func init() {
+	actorEraseCmd.Flags().String("as", "", "Pseudonym to use (default: random erased-xxxxxxxx)")
+	actorEraseCmd.Flags().Bool("mentions", false, "Also replace mentions in issue text, comments, and event values")
+	actorEraseCmd.Flags().BoolP("force", "f", false, "Make the changes (without this flag, only previews)")
+	actorEraseCmd.Flags().Bool("dry-run", false, "Preview what would change without making changes")
+	actorEraseCmd.Flags().Bool("squash-history", false, "Squash this branch's Dolt history into one commit after erasing")
+	actorCmd.AddCommand(actorEraseCmd)
+	rootCmd.AddCommand(actorCmd)
+}
+
+// actorEraseResult is the --json output of bd actor erase.
+type actorEraseResult struct {
+	*dolt.ActorErasureReport
+	Names           []string `json:"names"`
+	DryRun          bool     `json:"dry_run,omitempty"`
+	AuditEntries    int      `json:"audit_entries"`
+	HistorySquashed bool     `json:"history_squashed,omitempty"`
+	OtherBranches   []string `json:"other_branches,omitempty"`
+}
+
+func runActorErase(cmd *cobra.Command, args []string) {
+	pseudonym, _ := cmd.Flags().GetString("as")
+	mentions, _ := cmd.Flags().GetBool("mentions")
+	force, _ := cmd.Flags().GetBool("force")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	squash, _ := cmd.Flags().GetBool("squash-history")
+	dryRun = dryRun || !force
+	if !dryRun {
+		CheckReadonly("actor erase")
+	}
+
+	pseudonym = strings.TrimSpace(pseudonym)
+	if pseudonym == "" {
+		pseudonym = erasure.Pseudonym()
+	}
+	e := erasure.New(args, pseudonym)
+	if len(e.Names()) == 0 {
+		FatalErrorRespectJSON("no names given")
+	}
+	if e.Matches(pseudonym) {
+		FatalErrorRespectJSON("pseudonym %q is one of the names being erased", pseudonym)
+	}
+
+	ctx := rootCtx
+	report, err := store.EraseActor(ctx, e, mentions, dryRun)
+	if err != nil {
+		FatalErrorRespectJSON("erasing %s: %v", strings.Join(e.Names(), ", "), err)
+	}
+	auditEntries, err := audit.Rewrite(func(entry *audit.Entry) bool {
+		return eraseAuditEntry(e, entry, mentions)
+	}, dryRun)
+	if err != nil {
+		FatalErrorRespectJSON("rewriting %s: %v", audit.FileName, err)
+	}
+
+	result := actorEraseResult{
+		ActorErasureReport: report,
+		Names:              e.Names(),
+		DryRun:             dryRun,
+		AuditEntries:       auditEntries,
+	}
+	if !dryRun && squash {
+		msg := "bd: squash history after actor erasure"
+		result.OtherBranches, err = store.SquashHistory(ctx, msg)
+		if err != nil {
+			FatalErrorRespectJSON("squashing history: %v", err)
+		}
+		result.HistorySquashed = true
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	printActorErase(result, force)
+}
+
+// eraseAuditEntry rewrites one interactions.jsonl entry, reporting whether
+// it changed.
+func eraseAuditEntry(e *erasure.Eraser, entry *audit.Entry, mentions bool) bool {
+	before, _ := json.Marshal(entry)
+	entry.Actor = e.Actor(entry.Actor)
+	if mentions {
+		entry.Prompt, _ = e.Text(entry.Prompt)
+		entry.Response, _ = e.Text(entry.Response)
+		entry.Error, _ = e.Text(entry.Error)
+		entry.Reason, _ = e.Text(entry.Reason)
+	}
+	for k, v := range entry.Extra {
+		entry.Extra[k] = e.Value(v, mentions)
+	}
+	after, _ := json.Marshal(entry)
+	return !bytes.Equal(before, after)
+}
+
+func printActorErase(result actorEraseResult, force bool) {
+	names := strings.Join(result.Names, ", ")
+	total := result.Rows + result.AuditEntries
+	if total == 0 {
+		fmt.Printf("No records of %s found\n", names)
+		return
+	}
+
+	if result.DryRun {
+		fmt.Println(ui.RenderWarn("DRY RUN - no changes will be made"))
+		fmt.Printf("Would replace %s with %s in %d record(s):\n", names, ui.RenderAccent(result.Replacement), total)
+	} else {
+		fmt.Printf("%s Replaced %s with %s in %d record(s):\n", ui.RenderPass("✓"), names, ui.RenderAccent(result.Replacement), total)
+	}
+	for _, c := range result.Changes {
+		fmt.Printf("  %-32s %d\n", c.Table+"."+c.Column, c.Rows)
+	}
+	if result.AuditEntries > 0 {
+		fmt.Printf("  %-32s %d\n", audit.FileName, result.AuditEntries)
+	}
+	if len(result.IssueIDs) > 0 {
+		fmt.Printf("\nAffected issues (%d): %s\n", len(result.IssueIDs), strings.Join(result.IssueIDs, ", "))
+	}
+
+	fmt.Println()
+	switch {
+	case result.DryRun && !force:
+		fmt.Println(ui.RenderMuted("Use --force to erase."))
+	case result.HistorySquashed:
+		fmt.Printf("%s Squashed this branch's Dolt history into one commit.\n", ui.RenderPass("✓"))
+		if len(result.OtherBranches) > 0 {
+			fmt.Printf("%s Branches still holding the old history: %s\n", ui.RenderWarn("⚠"), strings.Join(result.OtherBranches, ", "))
+		}
+		fmt.Println(ui.RenderMuted("Remotes and other clones keep the old history until force-pushed over (bd dolt push --force) or re-cloned."))
+	case !result.DryRun:
+		fmt.Println(ui.RenderMuted("Earlier Dolt commits still contain the names; use --squash-history to remove them."))
+	}
+}
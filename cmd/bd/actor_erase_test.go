@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/audit"
+	"github.com/steveyegge/beads/internal/erasure"
+)
+
+func TestEraseAuditEntry(t *testing.T) {
+	e := erasure.New([]string{"bob"}, "erased-1")
+
+	entry := &audit.Entry{Kind: "llm_call", Actor: "bob", Prompt: "summarize bob's notes", Extra: map[string]any{"reviewer": "bob"}}
+	if !eraseAuditEntry(e, entry, false) {
+		t.Fatal("expected entry to change")
+	}
+	if entry.Actor != "erased-1" || entry.Extra["reviewer"] != "erased-1" || entry.Prompt != "summarize bob's notes" {
+		t.Errorf("without mentions: %+v", entry)
+	}
+	if !eraseAuditEntry(e, entry, true) || entry.Prompt != "summarize erased-1's notes" {
+		t.Errorf("with mentions: prompt = %q", entry.Prompt)
+	}
+
+	other := &audit.Entry{Kind: "tool_call", Actor: "alice"}
+	if eraseAuditEntry(e, other, true) {
+		t.Error("unrelated entry reported as changed")
+	}
+}
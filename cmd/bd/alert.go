@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var alertCmd = &cobra.Command{
+	Use:     "alert",
+	GroupID: "issues",
+	Short:   "Saved queries that notify when their matches change",
+	Long: `Alerts are saved 'bd query' expressions, such as open P0s older than two
+days. 'bd alert check' evaluates them and notifies when an alert's result
+set becomes non-empty or changes; an alert whose matches stay the same is
+quiet. Run 'bd alert check' on a schedule (cron, CI), or keep it running
+with --every.
+
+Each notification runs the .beads/hooks/on_alert hook with the first
+(most urgent) matching issue ID and "alert" as arguments, that issue's
+JSON on stdin, and these environment variables, so the hook can post to
+the alert's target:
+
+  BD_ALERT_NAME     The alert's name
+  BD_ALERT_NOTIFY   Its --notify target, e.g. slack:#oncall
+  BD_ALERT_QUERY    Its query
+  BD_ALERT_COUNT    Number of matching issues
+  BD_ALERT_IDS      Matching issue IDs, space-separated
+  BD_ALERT_NEW      IDs that didn't match at the last check
+
+Alerts are stored in the database, so everyone sharing it shares them.
+
+Examples:
+  bd alert add p0-stale --query 'priority=0 and status=open and age>2d' --notify slack:#oncall
+  bd alert list
+  bd alert check --dry-run
+  bd alert check --every 5m
+  bd alert remove p0-stale`,
+}
+
+var alertAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or replace an alert",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("alert add")
+		expr, _ := cmd.Flags().GetString("query")
+		notify, _ := cmd.Flags().GetString("notify")
+		if strings.TrimSpace(expr) == "" {
+			FatalErrorCode(exitValidation, "--query is required")
+		}
+		if _, err := query.EvaluateAt(expr, time.Now()); err != nil {
+			FatalErrorCode(exitValidation, "invalid --query: %v", err)
+		}
+
+		a := &types.Alert{Name: args[0], Query: expr, Notify: strings.TrimSpace(notify), CreatedBy: actor}
+		if err := store.SaveAlert(rootCtx, a); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(a)
+			return
+		}
+		fmt.Printf("%s Added alert %s: %s\n", ui.RenderPass("✓"), a.Name, a.Query)
+	},
+}
+
+var alertListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List alerts and their last check",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		alerts, err := store.GetAlerts(rootCtx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			if alerts == nil {
+				alerts = []*types.Alert{}
+			}
+			outputJSON(alerts)
+			return
+		}
+		if len(alerts) == 0 {
+			fmt.Println("No alerts (add one with 'bd alert add')")
+			return
+		}
+		for _, a := range alerts {
+			notify := a.Notify
+			if notify == "" {
+				notify = "(hook only)"
+			}
+			status := "never checked"
+			if a.LastCheckedAt != nil {
+				status = fmt.Sprintf("%d matching at %s", len(a.LastIDs), a.LastCheckedAt.Local().Format("2006-01-02 15:04"))
+			}
+			fmt.Printf("%s  %s → %s %s\n", ui.RenderBold(a.Name), a.Query, notify, ui.RenderMuted("("+status+")"))
+		}
+	},
+}
+
+var alertRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove an alert",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("alert remove")
+		removed, err := store.RemoveAlert(rootCtx, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if !removed {
+			FatalErrorRespectJSON("no alert named %q", args[0])
+		}
+		if jsonOutput {
+			outputJSON(map[string]string{"removed": args[0]})
+			return
+		}
+		fmt.Printf("%s Removed alert %s\n", ui.RenderPass("✓"), args[0])
+	},
+}
+
+var alertCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Evaluate alerts and notify those whose matches changed",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		every, _ := cmd.Flags().GetDuration("every")
+		if !dryRun {
+			CheckReadonly("alert check")
+		}
+		if every <= 0 {
+			runAlertCheck(rootCtx, dryRun)
+			return
+		}
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		for {
+			runAlertCheck(rootCtx, dryRun)
+			select {
+			case <-rootCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+func init() {
+	alertAddCmd.Flags().String("query", "", "bd query expression, e.g. 'priority=0 and status=open and age>2d'")
+	alertAddCmd.Flags().String("notify", "", "Target passed to the on_alert hook, e.g. slack:#oncall")
+	alertCheckCmd.Flags().Bool("dry-run", false, "Show what would fire without notifying or recording")
+	alertCheckCmd.Flags().Duration("every", 0, "Keep running, checking at this interval (e.g. 5m)")
+	alertCmd.AddCommand(alertAddCmd, alertListCmd, alertRemoveCmd, alertCheckCmd)
+	rootCmd.AddCommand(alertCmd)
+}
+
+// alertFiring is one notification bd alert check sends.
+type alertFiring struct {
+	Name   string   `json:"name"`
+	Notify string   `json:"notify,omitempty"`
+	IDs    []string `json:"ids"`
+	New    []string `json:"new"`
+
+	issue *types.Issue // Most urgent match, for the hook
+}
+
+// alertChange compares an alert's sorted matches with those at its last
+// check. It fires when there are matches and they differ; added lists the
+// new ones.
+func alertChange(last, ids []string) (fire bool, added []string) {
+	if len(ids) == 0 || slices.Equal(last, ids) {
+		return false, nil
+	}
+	added = []string{}
+	for _, id := range ids {
+		if !slices.Contains(last, id) {
+			added = append(added, id)
+		}
+	}
+	return true, added
+}
+
+// queryMatches runs a query the way bd query does, for alerts and batch
+// --filter: closed issues are left out unless the query filters on status.
+// Matches come back most urgent first.
+func queryMatches(ctx context.Context, expr string, now time.Time) ([]*types.Issue, error) {
+	node, err := query.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	result, err := query.NewEvaluator(now).Evaluate(node)
+	if err != nil {
+		return nil, err
+	}
+	if result.Filter.Status == nil && !hasExplicitStatusFilter(node) {
+		result.Filter.ExcludeStatus = append(result.Filter.ExcludeStatus, types.StatusClosed)
+	}
+	issues, err := store.SearchIssues(ctx, "", result.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if result.RequiresPredicate && result.Predicate != nil {
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+		labels, err := store.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		matched := issues[:0]
+		for _, issue := range issues {
+			issue.Labels = labels[issue.ID]
+			if result.Predicate(issue) {
+				matched = append(matched, issue)
+			}
+		}
+		issues = matched
+	}
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Priority != issues[j].Priority {
+			return issues[i].Priority < issues[j].Priority
+		}
+		return issues[i].ID < issues[j].ID
+	})
+	return issues, nil
+}
+
+// runAlertCheck evaluates every alert once, notifies those that fire, and
+// records each alert's matches.
+func runAlertCheck(ctx context.Context, dryRun bool) {
+	alerts, err := store.GetAlerts(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	now := time.Now()
+	hookReady := hookRunner != nil && hookRunner.HookExists(hooks.EventAlert)
+	warned := false
+	fired := []alertFiring{}
+	for _, a := range alerts {
+		issues, err := queryMatches(ctx, a.Query, now)
+		if err != nil {
+			WarnError("alert %s: %v", a.Name, err)
+			continue
+		}
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+		sort.Strings(ids)
+		fire, added := alertChange(a.LastIDs, ids)
+		if fire {
+			f := alertFiring{Name: a.Name, Notify: a.Notify, IDs: ids, New: added, issue: issues[0]}
+			if !dryRun && hookReady {
+				if err := hookRunner.RunSyncEnv(hooks.EventAlert, f.issue,
+					"BD_ALERT_NAME="+a.Name,
+					"BD_ALERT_NOTIFY="+a.Notify,
+					"BD_ALERT_QUERY="+a.Query,
+					"BD_ALERT_COUNT="+strconv.Itoa(len(ids)),
+					"BD_ALERT_IDS="+strings.Join(ids, " "),
+					"BD_ALERT_NEW="+strings.Join(added, " ")); err != nil {
+					// Not recorded, so the next check retries it
+					WarnError("on_alert hook failed for %s: %v", a.Name, err)
+					continue
+				}
+			} else if !dryRun && !warned {
+				WarnError("no executable .beads/hooks/on_alert hook; alerts are only printed")
+				warned = true
+			}
+			fired = append(fired, f)
+		}
+		if !dryRun {
+			if err := store.RecordAlertCheck(ctx, a.Name, ids, now.UTC(), fire); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(fired)
+		return
+	}
+	if len(fired) == 0 {
+		fmt.Printf("No alerts fired (%d checked)\n", len(alerts))
+		return
+	}
+	for _, f := range fired {
+		target := f.Notify
+		if target == "" {
+			target = "on_alert"
+		}
+		fmt.Printf("%s %s → %s: %d matching, %d new (%s)\n", ui.RenderWarn("🔔"), ui.RenderBold(f.Name),
+			target, len(f.IDs), len(f.New), strings.Join(f.IDs, ", "))
+	}
+	if dryRun {
+		fmt.Println(ui.RenderMuted("(dry run: nothing notified)"))
+	}
+}
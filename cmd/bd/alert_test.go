@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestAlertChange(t *testing.T) {
+	tests := []struct {
+		name      string
+		last, ids []string
+		fire      bool
+		added     []string
+	}{
+		{"first matches", nil, []string{"bd-1", "bd-2"}, true, []string{"bd-1", "bd-2"}},
+		{"unchanged", []string{"bd-1"}, []string{"bd-1"}, false, nil},
+		{"one more", []string{"bd-1"}, []string{"bd-1", "bd-3"}, true, []string{"bd-3"}},
+		{"one fewer", []string{"bd-1", "bd-3"}, []string{"bd-3"}, true, []string{}},
+		{"cleared", []string{"bd-1"}, nil, false, nil},
+		{"still empty", nil, nil, false, nil},
+	}
+	for _, tt := range tests {
+		fire, added := alertChange(tt.last, tt.ids)
+		if fire != tt.fire || !stringSlicesEqual(added, tt.added) {
+			t.Errorf("%s: alertChange = %v, %v; want %v, %v", tt.name, fire, added, tt.fire, tt.added)
+		}
+	}
+}
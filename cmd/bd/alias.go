@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:     "alias",
+	GroupID: "issues",
+	Short:   "Manage external keys that alias issues (JIRA-123, GH#456)",
+	Long: `Map identifiers from other systems to issues, so cross-references such as
+JIRA-123, LIN-789 or GH#456 keep working in beads: every command that takes
+an issue ID accepts an alias too (bd show JIRA-123).
+
+The Jira, Linear and GitLab integrations record the key of each issue they
+sync (GitLab's as GL#<iid>); set <tracker>.external_keys to false to turn
+that off. Aliases are exported with their issues and travel with federation
+sync. A real issue ID always wins over an alias with the same text.
+
+Examples:
+  bd alias add bd-42 JIRA-123 GH#456   # Alias bd-42
+  bd alias list bd-42                  # Aliases of one issue
+  bd alias list --source jira          # Every alias from Jira
+  bd alias remove GH#456`,
+}
+
+var aliasAddCmd = &cobra.Command{
+	Use:   "add <issue-id> <key>...",
+	Short: "Make keys aliases of an issue",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("alias add")
+		force, _ := cmd.Flags().GetBool("force")
+		source, _ := cmd.Flags().GetString("source")
+		ctx := rootCtx
+
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		added := []*types.ExternalKey{}
+		for _, key := range args[1:] {
+			if err := dolt.ValidateExternalKey(key); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			if issues, err := store.GetIssuesByIDs(ctx, []string{key}); err == nil && len(issues) > 0 {
+				FatalErrorCode(exitValidation, "%s is an issue ID; an alias with that text would never be used", key)
+			}
+			if current, err := store.ResolveExternalKey(ctx, key); err == nil && current != issueID && !force {
+				FatalErrorCode(exitConflict, "%s is already an alias of %s (use --force to move it)", key, current)
+			}
+			if err := store.SetExternalKey(ctx, issueID, key, source, actor); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			added = append(added, &types.ExternalKey{Key: key, IssueID: issueID, Source: source})
+		}
+		if jsonOutput {
+			outputJSON(added)
+			return
+		}
+		for _, k := range added {
+			fmt.Printf("%s %s now resolves to %s\n", ui.RenderPass("✓"), k.Key, issueID)
+		}
+	},
+}
+
+var aliasRemoveCmd = &cobra.Command{
+	Use:   "remove <key>...",
+	Short: "Remove aliases",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("alias remove")
+		ctx := rootCtx
+		removed := []string{}
+		for _, key := range args {
+			ok, err := store.RemoveExternalKey(ctx, key, actor)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			if !ok {
+				WarnError("%s is not an alias", key)
+				continue
+			}
+			removed = append(removed, key)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"removed": removed})
+			return
+		}
+		for _, key := range removed {
+			fmt.Printf("%s Removed alias %s\n", ui.RenderPass("✓"), key)
+		}
+	},
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:   "list [issue-id]",
+	Short: "List aliases, of one issue or all",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		source, _ := cmd.Flags().GetString("source")
+		ctx := rootCtx
+
+		var keys []*types.ExternalKey
+		var err error
+		if len(args) == 1 {
+			issueID, resolveErr := utils.ResolvePartialID(ctx, store, args[0])
+			if resolveErr != nil {
+				FatalErrorRespectJSON("resolving %s: %v", args[0], resolveErr)
+			}
+			keys, err = store.GetExternalKeys(ctx, issueID)
+			if source != "" {
+				keys = filterExternalKeys(keys, source)
+			}
+		} else {
+			keys, err = store.ListExternalKeys(ctx, source)
+		}
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			if keys == nil {
+				keys = []*types.ExternalKey{}
+			}
+			outputJSON(keys)
+			return
+		}
+		if len(keys) == 0 {
+			fmt.Println("No aliases")
+			return
+		}
+		width := 0
+		for _, k := range keys {
+			width = max(width, len(k.Key))
+		}
+		for _, k := range keys {
+			fmt.Printf("%-*s  %s  %s\n", width, k.Key, ui.RenderID(k.IssueID), ui.RenderMuted(k.Source))
+		}
+	},
+}
+
+// filterExternalKeys keeps the keys from source.
+func filterExternalKeys(keys []*types.ExternalKey, source string) []*types.ExternalKey {
+	var kept []*types.ExternalKey
+	for _, k := range keys {
+		if k.Source == source {
+			kept = append(kept, k)
+		}
+	}
+	return kept
+}
+
+func init() {
+	aliasAddCmd.Flags().Bool("force", false, "Move keys that already alias another issue")
+	aliasAddCmd.Flags().String("source", "manual", "System the keys come from, e.g. jira")
+	aliasListCmd.Flags().String("source", "", "Only list keys from this system")
+	aliasCmd.AddCommand(aliasAddCmd, aliasRemoveCmd, aliasListCmd)
+	rootCmd.AddCommand(aliasCmd)
+}
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/resolution"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+var applyCmd = &cobra.Command{
+	Use:     "apply <plan.yaml>",
+	GroupID: "issues",
+	Short:   "Reconcile issues with a declarative YAML plan",
+	Long: `Reconcile the database with a YAML plan describing epics, issues and
+dependencies, kubectl-style: issues missing from the database are created,
+issues whose declared fields drifted are updated, and issues the plan used
+to manage but no longer lists are reported as orphans.
+
+Plan format:
+  name: auth-rework            # Managed issues get the label plan:auth-rework
+  issues:
+    - key: auth                # Stable key, unique within the plan
+      title: Auth rework
+      type: epic
+      priority: 1
+      labels: [auth]           # Exact label set (plus the plan label)
+      description: |
+        Rework authentication.
+      children:                # Nested issues get a parent-child dependency
+        - key: token-api
+          title: Token API
+        - key: login-ui
+          title: Login UI
+          depends_on: [token-api, bd-12]   # Plan keys or existing issue IDs
+    - key: legacy
+      id: bd-7                 # Adopt an existing issue
+
+Only declared fields are managed: omit a field to leave it alone. Other
+fields: status, assignee, estimate (minutes), external_ref, design,
+acceptance_criteria, notes, parent (key or issue ID).
+
+All changes are applied in one transaction. Use --dry-run to see the diff
+without writing, and --prune to close orphaned issues.
+
+Use "-" to read the plan from stdin.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		prune, _ := cmd.Flags().GetBool("prune")
+		if !dryRun {
+			CheckReadonly("apply")
+		}
+		ctx := rootCtx
+
+		data, err := readPlanFile(args[0])
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		plan, entries, err := parsePlan(data)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		state, err := loadPlanState(ctx, plan, entries)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		actions, err := buildPlanActions(plan, entries, state, loadEditDocRules(ctx, false), prune)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if !dryRun {
+			if err := executePlanActions(ctx, plan, actions); err != nil {
+				FatalErrorRespectJSON("applying plan: %v", err)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"plan":    plan.Name,
+				"dry_run": dryRun,
+				"actions": actions,
+			})
+			return
+		}
+		printPlanActions(plan, actions, dryRun)
+	},
+}
+
+func init() {
+	applyCmd.Flags().Bool("dry-run", false, "Show what would change without writing")
+	applyCmd.Flags().Bool("prune", false, "Close managed issues that are no longer in the plan")
+	rootCmd.AddCommand(applyCmd)
+}
+
+// readPlanFile reads a plan from path, or stdin for "-".
+func readPlanFile(path string) ([]byte, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("reading plan from stdin: %w", err)
+		}
+		return data, nil
+	}
+	// #nosec G304 -- plan path is provided by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading plan: %w", err)
+	}
+	return data, nil
+}
+
+// loadPlanState loads the issues a plan refers to: issues carrying its
+// label, adopted issues, and dependency targets given as issue IDs.
+func loadPlanState(ctx context.Context, plan *planFile, entries []*planIssue) (*planState, error) {
+	state := &planState{
+		Managed: make(map[string]*types.Issue),
+		ByID:    make(map[string]*types.Issue),
+		RefIDs:  make(map[string]string),
+	}
+
+	managed, err := store.SearchIssues(ctx, "", types.IssueFilter{Labels: []string{planLabelPrefix + plan.Name}})
+	if err != nil {
+		return nil, fmt.Errorf("loading issues for plan %s: %w", plan.Name, err)
+	}
+	ids := make([]string, 0, len(managed))
+	for _, issue := range managed {
+		ids = append(ids, issue.ID)
+		key := planKeyOf(issue)
+		if key == "" {
+			continue // Labelled by hand; not tracked by key
+		}
+		if other, dup := state.Managed[key]; dup {
+			return nil, fmt.Errorf("issues %s and %s both have plan key %q", other.ID, issue.ID, key)
+		}
+		state.Managed[key] = issue
+	}
+
+	keys := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		keys[e.Key] = true
+	}
+	var refs []string
+	for _, e := range entries {
+		if e.ID != "" {
+			refs = append(refs, e.ID)
+		}
+		if e.Parent != "" && !keys[e.Parent] {
+			refs = append(refs, e.Parent)
+		}
+		if e.DependsOn != nil {
+			for _, ref := range *e.DependsOn {
+				if !keys[ref] {
+					refs = append(refs, ref)
+				}
+			}
+		}
+	}
+	for _, ref := range refs {
+		if _, done := state.RefIDs[ref]; done {
+			continue
+		}
+		id, err := utils.ResolvePartialID(ctx, store, ref)
+		if err != nil {
+			return nil, fmt.Errorf("resolving %s: %w", ref, err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("loading %s: %w", id, err)
+		}
+		state.RefIDs[ref] = id
+		state.ByID[id] = issue
+		ids = append(ids, id)
+	}
+
+	if state.Labels, err = store.GetLabelsForIssues(ctx, ids); err != nil {
+		return nil, fmt.Errorf("loading labels: %w", err)
+	}
+	if state.Deps, err = store.GetDependencyRecordsForIssues(ctx, ids); err != nil {
+		return nil, fmt.Errorf("loading dependencies: %w", err)
+	}
+	return state, nil
+}
+
+// hasPlanWrites reports whether any action changes the database.
+func hasPlanWrites(actions []*planAction) bool {
+	for _, a := range actions {
+		if a.Action == "create" || a.Action == "update" || a.Action == "prune" {
+			return true
+		}
+	}
+	return false
+}
+
+// executePlanActions applies the actions in one transaction: creates first
+// (so dependencies can refer to new issues), then updates, dependencies,
+// and prunes. Created issue IDs are filled into the actions.
+func executePlanActions(ctx context.Context, plan *planFile, actions []*planAction) error {
+	if !hasPlanWrites(actions) {
+		return nil
+	}
+	created := make(map[string]string) // Plan key -> new issue ID
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		for _, a := range actions {
+			if a.Action != "create" {
+				continue
+			}
+			if err := createPlanIssue(ctx, tx, a); err != nil {
+				return fmt.Errorf("creating %s: %w", a.Key, err)
+			}
+			created[a.Key] = a.ID
+		}
+
+		for _, a := range actions {
+			if a.Action != "update" {
+				continue
+			}
+			if len(a.fields.Updates) > 0 {
+				if err := tx.UpdateIssue(ctx, a.ID, a.fields.Updates, actor); err != nil {
+					return fmt.Errorf("updating %s: %w", a.ID, err)
+				}
+			}
+			for _, l := range a.fields.AddLabels {
+				if err := tx.AddLabel(ctx, a.ID, l, actor); err != nil {
+					return fmt.Errorf("labelling %s: %w", a.ID, err)
+				}
+			}
+			for _, l := range a.fields.RemoveLabels {
+				if err := tx.RemoveLabel(ctx, a.ID, l, actor); err != nil {
+					return fmt.Errorf("unlabelling %s: %w", a.ID, err)
+				}
+			}
+		}
+
+		targetID := func(target string) string {
+			if id, ok := created[target]; ok {
+				return id
+			}
+			return target
+		}
+		for _, a := range actions {
+			for _, d := range a.RemoveDeps {
+				if err := tx.RemoveDependency(ctx, a.ID, d.Target, actor); err != nil {
+					return fmt.Errorf("removing dependency %s -> %s: %w", a.ID, d.Target, err)
+				}
+			}
+			for _, d := range a.AddDeps {
+				dep := &types.Dependency{IssueID: a.ID, DependsOnID: targetID(d.Target), Type: d.Type}
+				if err := tx.AddDependency(ctx, dep, actor); err != nil {
+					return fmt.Errorf("adding dependency %s -> %s: %w", a.ID, dep.DependsOnID, err)
+				}
+			}
+		}
+
+		for _, a := range actions {
+			if a.Action != "prune" {
+				continue
+			}
+			if err := tx.CloseIssue(ctx, a.ID, resolution.Resolution{Category: resolution.Obsolete, Note: "removed from plan " + plan.Name}.String(), actor, ""); err != nil {
+				return fmt.Errorf("closing %s: %w", a.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// Nothing was written; don't report IDs that don't exist.
+		for _, a := range actions {
+			if a.Action == "create" {
+				a.ID = ""
+			}
+		}
+		return err
+	}
+
+	// Show dependency targets by ID now that new issues have one.
+	for _, a := range actions {
+		for _, d := range a.AddDeps {
+			if id, ok := created[d.Target]; ok {
+				d.Target = id
+			}
+		}
+	}
+	return nil
+}
+
+// createPlanIssue creates the issue for a "create" action within tx.
+func createPlanIssue(ctx context.Context, tx storage.Transaction, a *planAction) error {
+	d := a.desired
+	priority, _ := validation.ValidatePriority(d.Priority) // Validated when the plan was built
+	metadata, err := withPlanKey(nil, a.Key)
+	if err != nil {
+		return err
+	}
+	issue := &types.Issue{
+		Title:              d.Title,
+		Description:        d.Description,
+		Design:             d.Design,
+		AcceptanceCriteria: d.AcceptanceCriteria,
+		Notes:              d.Notes,
+		Status:             types.StatusOpen,
+		Priority:           priority,
+		IssueType:          types.IssueType(d.Type),
+		Assignee:           d.Assignee,
+		EstimatedMinutes:   d.Estimate,
+		Metadata:           metadata,
+		CreatedBy:          actor,
+	}
+	if d.ExternalRef != "" {
+		ref := d.ExternalRef
+		issue.ExternalRef = &ref
+	}
+	if err := tx.CreateIssue(ctx, issue, actor); err != nil {
+		return err
+	}
+	a.ID = issue.ID
+	for _, l := range d.Labels {
+		if err := tx.AddLabel(ctx, issue.ID, l, actor); err != nil {
+			return err
+		}
+	}
+	// New issues start open; move them to the declared status afterwards so
+	// closed_at and the audit trail are handled as for any status change.
+	if d.Status != string(types.StatusOpen) {
+		if err := tx.UpdateIssue(ctx, issue.ID, map[string]interface{}{"status": d.Status}, actor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printPlanActions prints the plan diff.
+func printPlanActions(plan *planFile, actions []*planAction, dryRun bool) {
+	counts := make(map[string]int)
+	for _, a := range actions {
+		counts[a.Action]++
+		ref := a.Key
+		if a.ID != "" {
+			ref = fmt.Sprintf("%s (%s)", a.ID, a.Key)
+		}
+		switch a.Action {
+		case "create":
+			fmt.Printf("%s %s %q\n", ui.RenderPass("+ create"), ref, a.Title)
+		case "update":
+			fmt.Printf("%s %s %q\n", ui.RenderWarn("~ update"), ref, a.Title)
+		case "orphan":
+			fmt.Printf("%s %s %q %s\n", ui.RenderFail("? orphan"), ref, a.Title, ui.RenderMuted("(not in plan; --prune closes it)"))
+		case "prune":
+			fmt.Printf("%s %s %q\n", ui.RenderFail("- prune "), ref, a.Title)
+		default:
+			continue
+		}
+		for _, c := range a.Changes {
+			fmt.Printf("    %s\n", c)
+		}
+	}
+
+	summary := fmt.Sprintf("%d to create, %d to update, %d unchanged", counts["create"], counts["update"], counts["unchanged"])
+	if !dryRun {
+		summary = fmt.Sprintf("%d created, %d updated, %d unchanged", counts["create"], counts["update"], counts["unchanged"])
+	}
+	if counts["prune"] > 0 {
+		summary += fmt.Sprintf(", %d pruned", counts["prune"])
+	}
+	if counts["orphan"] > 0 {
+		summary += fmt.Sprintf(", %d orphaned", counts["orphan"])
+	}
+	prefix := ui.RenderPass("✓")
+	if dryRun {
+		prefix = ui.RenderAccent("Dry run:")
+	}
+	fmt.Printf("\n%s plan %s: %s\n", prefix, plan.Name, summary)
+}
@@ -0,0 +1,447 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// A plan file declares a set of issues by stable keys:
+//
+//	name: auth-rework
+//	issues:
+//	  - key: auth
+//	    title: Auth rework
+//	    type: epic
+//	    children:
+//	      - key: token-api
+//	        title: Token API
+//	      - key: login-ui
+//	        title: Login UI
+//	        depends_on: [token-api]
+//
+// Issues a plan creates or adopts carry the label "plan:<name>" and their key
+// in metadata ("plan_key"), which is how later applies find them again.
+// Only fields a plan entry declares are managed; everything else (comments,
+// fields edited by hand that the plan leaves out) is left alone.
+
+// planLabelPrefix prefixes the label marking issues managed by a plan.
+const planLabelPrefix = "plan:"
+
+// planKeyMetadata is the issue metadata field holding the issue's plan key.
+const planKeyMetadata = "plan_key"
+
+// planNamePattern restricts plan names and keys to label-safe characters.
+var planNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+type planFile struct {
+	Name   string       `yaml:"name"`
+	Issues []*planIssue `yaml:"issues"`
+}
+
+// planIssue is one plan entry. Pointer and nil-able fields are unmanaged
+// when absent.
+type planIssue struct {
+	Key                string       `yaml:"key"`
+	ID                 string       `yaml:"id"` // Adopt an existing issue
+	Title              string       `yaml:"title"`
+	Type               *string      `yaml:"type"`
+	Status             *string      `yaml:"status"`
+	Priority           *string      `yaml:"priority"`
+	Assignee           *string      `yaml:"assignee"`
+	Labels             *[]string    `yaml:"labels"`
+	Estimate           *int         `yaml:"estimate"`
+	ExternalRef        *string      `yaml:"external_ref"`
+	Description        *string      `yaml:"description"`
+	Design             *string      `yaml:"design"`
+	AcceptanceCriteria *string      `yaml:"acceptance_criteria"`
+	Notes              *string      `yaml:"notes"`
+	Parent             string       `yaml:"parent"`     // Plan key or issue ID
+	DependsOn          *[]string    `yaml:"depends_on"` // Plan keys or issue IDs this issue is blocked by
+	Children           []*planIssue `yaml:"children"`
+}
+
+// parsePlan parses a plan file and returns its entries, flattened, with
+// nested children pointing at their parent.
+func parsePlan(data []byte) (*planFile, []*planIssue, error) {
+	var plan planFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&plan); err != nil {
+		return nil, nil, fmt.Errorf("invalid plan: %w", err)
+	}
+	if !planNamePattern.MatchString(plan.Name) {
+		return nil, nil, fmt.Errorf("invalid plan: name %q must be letters, digits, '.', '_' or '-'", plan.Name)
+	}
+
+	var entries []*planIssue
+	var problems []string
+	keys := make(map[string]bool)
+	var walk func(list []*planIssue, parent string)
+	walk = func(list []*planIssue, parent string) {
+		for _, e := range list {
+			switch {
+			case !planNamePattern.MatchString(e.Key):
+				problems = append(problems, fmt.Sprintf("key %q: must be letters, digits, '.', '_' or '-'", e.Key))
+			case keys[e.Key]:
+				problems = append(problems, fmt.Sprintf("key %q: duplicate", e.Key))
+			}
+			keys[e.Key] = true
+			if parent != "" {
+				if e.Parent != "" && e.Parent != parent {
+					problems = append(problems, fmt.Sprintf("%s: parent %q conflicts with enclosing %q", e.Key, e.Parent, parent))
+				}
+				e.Parent = parent
+			}
+			entries = append(entries, e)
+			walk(e.Children, e.Key)
+		}
+	}
+	walk(plan.Issues, "")
+	for _, e := range entries {
+		if e.Parent == e.Key {
+			problems = append(problems, fmt.Sprintf("%s: cannot be its own parent", e.Key))
+		}
+		if e.DependsOn != nil && slices.Contains(*e.DependsOn, e.Key) {
+			problems = append(problems, fmt.Sprintf("%s: cannot depend on itself", e.Key))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, nil, fmt.Errorf("invalid plan:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return &plan, entries, nil
+}
+
+// overlay returns base with the entry's declared fields applied.
+func (e *planIssue) overlay(base *issueEditDoc, planLabel string) *issueEditDoc {
+	doc := *base
+	doc.Title = strings.TrimSpace(e.Title)
+	setStr := func(dst *string, src *string) {
+		if src != nil {
+			*dst = strings.TrimSpace(*src)
+		}
+	}
+	setText := func(dst *string, src *string) {
+		if src != nil {
+			*dst = *src
+		}
+	}
+	setStr(&doc.Type, e.Type)
+	setStr(&doc.Status, e.Status)
+	setStr(&doc.Priority, e.Priority)
+	setStr(&doc.Assignee, e.Assignee)
+	setStr(&doc.ExternalRef, e.ExternalRef)
+	setText(&doc.Description, e.Description)
+	setText(&doc.Design, e.Design)
+	setText(&doc.AcceptanceCriteria, e.AcceptanceCriteria)
+	setText(&doc.Notes, e.Notes)
+	if e.Estimate != nil {
+		est := *e.Estimate
+		doc.Estimate = &est
+	}
+	if e.Labels != nil {
+		doc.Labels = slices.Clone(*e.Labels)
+	} else {
+		doc.Labels = slices.Clone(base.Labels)
+	}
+	if !slices.Contains(doc.Labels, planLabel) {
+		doc.Labels = append(doc.Labels, planLabel)
+	}
+	return &doc
+}
+
+// planState is the database state a plan is reconciled against.
+type planState struct {
+	Managed map[string]*types.Issue        // Plan key -> issue carrying the plan label
+	ByID    map[string]*types.Issue        // Adopted issues and dependency targets, by ID
+	RefIDs  map[string]string              // Non-key references -> resolved issue ID
+	Labels  map[string][]string            // Issue ID -> labels
+	Deps    map[string][]*types.Dependency // Issue ID -> dependency records
+}
+
+// planDep is a dependency edge to reconcile. Target is an issue ID, or a
+// plan key for an issue the plan creates.
+type planDep struct {
+	Type   types.DependencyType `json:"type"`
+	Target string               `json:"target"`
+}
+
+// planAction is what applying a plan does to one issue.
+type planAction struct {
+	Action     string     `json:"action"` // create, update, unchanged, orphan, prune
+	Key        string     `json:"key"`
+	ID         string     `json:"id,omitempty"`
+	Title      string     `json:"title"`
+	Changes    []string   `json:"changes,omitempty"` // Human-readable field changes
+	AddDeps    []*planDep `json:"add_deps,omitempty"`
+	RemoveDeps []*planDep `json:"remove_deps,omitempty"`
+
+	desired *issueEditDoc
+	fields  *editDocChanges
+}
+
+// hasDepChanges reports whether the action adds or removes dependencies.
+func (a *planAction) hasDepChanges() bool {
+	return len(a.AddDeps) > 0 || len(a.RemoveDeps) > 0
+}
+
+// buildPlanActions computes the actions that reconcile state with the plan.
+// Orphans (managed issues no longer in the plan) become "prune" actions
+// with prune, "orphan" reports otherwise; closed orphans are ignored.
+func buildPlanActions(plan *planFile, entries []*planIssue, state *planState, rules editDocRules, prune bool) ([]*planAction, error) {
+	planLabel := planLabelPrefix + plan.Name
+	keys := make(map[string]*planIssue, len(entries))
+	for _, e := range entries {
+		keys[e.Key] = e
+	}
+	claimed := make(map[string]string) // Issue ID -> key
+	resolve := func(ref string) (string, error) {
+		if _, ok := keys[ref]; ok {
+			return ref, nil
+		}
+		if id, ok := state.RefIDs[ref]; ok {
+			return id, nil
+		}
+		return "", fmt.Errorf("unknown plan key or issue %q", ref)
+	}
+
+	var actions []*planAction
+	var problems []string
+	keyIDs := make(map[string]string) // Plan key -> existing issue ID
+	for _, e := range entries {
+		current := state.Managed[e.Key]
+		if e.ID != "" {
+			current = state.ByID[state.RefIDs[e.ID]]
+			if current == nil {
+				problems = append(problems, fmt.Sprintf("%s: issue %s not found", e.Key, e.ID))
+				continue
+			}
+		}
+		if current != nil {
+			if other, dup := claimed[current.ID]; dup {
+				problems = append(problems, fmt.Sprintf("%s: issue %s is already managed as %q", e.Key, current.ID, other))
+				continue
+			}
+			claimed[current.ID] = e.Key
+			keyIDs[e.Key] = current.ID
+		}
+	}
+
+	for _, e := range entries {
+		current := state.Managed[e.Key]
+		if e.ID != "" {
+			current = state.ByID[state.RefIDs[e.ID]]
+		}
+		if current == nil && e.ID != "" {
+			continue // Reported above
+		}
+
+		var base *issueEditDoc
+		if current != nil {
+			base = editDocFromIssue(current, state.Labels[current.ID])
+		} else {
+			base = &issueEditDoc{Status: string(types.StatusOpen), Priority: "P2", Type: string(types.TypeTask)}
+		}
+		desired := e.overlay(base, planLabel)
+		if err := rules.validate(desired); err != nil {
+			for _, p := range strings.Split(err.Error(), "\n") {
+				problems = append(problems, e.Key+": "+p)
+			}
+			continue
+		}
+
+		a := &planAction{Key: e.Key, Title: desired.Title, desired: desired}
+		if current == nil {
+			a.Action = "create"
+		} else {
+			a.ID = current.ID
+			a.fields = diffEditDocs(base, desired)
+			if planKeyOf(current) != e.Key {
+				md, err := withPlanKey(current.Metadata, e.Key)
+				if err != nil {
+					problems = append(problems, fmt.Sprintf("%s: %v", e.Key, err))
+					continue
+				}
+				a.fields.Updates["metadata"] = md
+			}
+			a.Changes = describeDocChanges(base, desired, a.fields)
+		}
+
+		// Dependencies: parent-child when a parent is set, blocks when declared.
+		var existing []*types.Dependency
+		if current != nil {
+			existing = state.Deps[current.ID]
+		}
+		targetID := func(ref string) string {
+			if id, ok := keyIDs[ref]; ok {
+				return id
+			}
+			return ref
+		}
+		reconcile := func(depType types.DependencyType, refs []string) {
+			want := make(map[string]bool)
+			for _, ref := range refs {
+				target, err := resolve(ref)
+				if err != nil {
+					problems = append(problems, fmt.Sprintf("%s: %v", e.Key, err))
+					continue
+				}
+				want[targetID(target)] = true
+			}
+			have := make(map[string]bool)
+			for _, d := range existing {
+				if d.Type != depType {
+					continue
+				}
+				have[d.DependsOnID] = true
+				if !want[d.DependsOnID] {
+					a.RemoveDeps = append(a.RemoveDeps, &planDep{Type: depType, Target: d.DependsOnID})
+				}
+			}
+			targets := make([]string, 0, len(want))
+			for t := range want {
+				targets = append(targets, t)
+			}
+			sort.Strings(targets)
+			for _, t := range targets {
+				if !have[t] {
+					a.AddDeps = append(a.AddDeps, &planDep{Type: depType, Target: t})
+				}
+			}
+		}
+		if e.Parent != "" {
+			reconcile(types.DepParentChild, []string{e.Parent})
+		}
+		if e.DependsOn != nil {
+			reconcile(types.DepBlocks, *e.DependsOn)
+		}
+		for _, d := range a.AddDeps {
+			a.Changes = append(a.Changes, fmt.Sprintf("+%s %s", d.Type, d.Target))
+		}
+		for _, d := range a.RemoveDeps {
+			a.Changes = append(a.Changes, fmt.Sprintf("-%s %s", d.Type, d.Target))
+		}
+
+		if a.Action == "" {
+			a.Action = "unchanged"
+			if !a.fields.Empty() || a.hasDepChanges() {
+				a.Action = "update"
+			}
+		}
+		actions = append(actions, a)
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid plan:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	orphanKeys := make([]string, 0)
+	for key, issue := range state.Managed {
+		if _, ok := keys[key]; !ok && claimed[issue.ID] == "" && issue.Status != types.StatusClosed {
+			orphanKeys = append(orphanKeys, key)
+		}
+	}
+	sort.Strings(orphanKeys)
+	for _, key := range orphanKeys {
+		issue := state.Managed[key]
+		action := "orphan"
+		if prune {
+			action = "prune"
+		}
+		actions = append(actions, &planAction{Action: action, Key: key, ID: issue.ID, Title: issue.Title})
+	}
+	return actions, nil
+}
+
+// describeDocChanges lists changed fields as "field: old → new"; text fields
+// only report their line counts.
+func describeDocChanges(old, desired *issueEditDoc, c *editDocChanges) []string {
+	var out []string
+	scalar := func(name, key, from, to string) {
+		if _, ok := c.Updates[key]; ok {
+			out = append(out, fmt.Sprintf("%s: %s → %s", name, displayFieldValue(from), displayFieldValue(to)))
+		}
+	}
+	scalar("title", "title", old.Title, desired.Title)
+	scalar("status", "status", old.Status, desired.Status)
+	if _, ok := c.Updates["priority"]; ok {
+		from, _ := validation.ValidatePriority(old.Priority)
+		to, _ := validation.ValidatePriority(desired.Priority)
+		out = append(out, fmt.Sprintf("priority: P%d → P%d", from, to))
+	}
+	scalar("type", "issue_type", old.Type, desired.Type)
+	scalar("assignee", "assignee", old.Assignee, desired.Assignee)
+	scalar("external_ref", "external_ref", old.ExternalRef, desired.ExternalRef)
+	if _, ok := c.Updates["estimated_minutes"]; ok {
+		out = append(out, fmt.Sprintf("estimate: %s → %s",
+			displayFieldValue(formatEstimate(old.Estimate)), displayFieldValue(formatEstimate(desired.Estimate))))
+	}
+	for _, f := range []struct{ name, from, to string }{
+		{"description", old.Description, desired.Description},
+		{"design", old.Design, desired.Design},
+		{"acceptance_criteria", old.AcceptanceCriteria, desired.AcceptanceCriteria},
+		{"notes", old.Notes, desired.Notes},
+	} {
+		if _, ok := c.Updates[f.name]; ok {
+			out = append(out, fmt.Sprintf("%s: changed (%d → %d lines)", f.name, countLines(f.from), countLines(f.to)))
+		}
+	}
+	for _, l := range c.AddLabels {
+		out = append(out, "+label "+l)
+	}
+	for _, l := range c.RemoveLabels {
+		out = append(out, "-label "+l)
+	}
+	return out
+}
+
+func countLines(s string) int {
+	if s == "" {
+		return 0
+	}
+	return strings.Count(strings.TrimSuffix(s, "\n"), "\n") + 1
+}
+
+// planKeyOf returns the plan key recorded in an issue's metadata.
+func planKeyOf(issue *types.Issue) string {
+	if len(issue.Metadata) == 0 {
+		return ""
+	}
+	var md map[string]json.RawMessage
+	if json.Unmarshal(issue.Metadata, &md) != nil {
+		return ""
+	}
+	var key string
+	_ = json.Unmarshal(md[planKeyMetadata], &key) // Missing or non-string key reads as ""
+	return key
+}
+
+// withPlanKey returns metadata with the plan key set, keeping other fields.
+func withPlanKey(metadata json.RawMessage, key string) (json.RawMessage, error) {
+	return withMetadataKey(metadata, planKeyMetadata, key)
+}
+
+// withMetadataKey returns metadata with key set to value, keeping other
+// fields. Metadata that is set but isn't a JSON object is an error.
+func withMetadataKey(metadata json.RawMessage, key string, value interface{}) (json.RawMessage, error) {
+	md := make(map[string]json.RawMessage)
+	if len(bytes.TrimSpace(metadata)) > 0 && string(bytes.TrimSpace(metadata)) != "null" {
+		if err := json.Unmarshal(metadata, &md); err != nil {
+			return nil, fmt.Errorf("issue metadata is not a JSON object; cannot record %s", key)
+		}
+	}
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	md[key] = valueJSON
+	return json.Marshal(md)
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const testPlanYAML = `
+name: auth
+issues:
+  - key: epic
+    title: Auth rework
+    type: epic
+    priority: 1
+    children:
+      - key: api
+        title: Token API
+        labels: [backend]
+      - key: ui
+        title: Login UI
+        depends_on: [api, bd-ext]
+`
+
+func TestParsePlan(t *testing.T) {
+	plan, entries, err := parsePlan([]byte(testPlanYAML))
+	if err != nil {
+		t.Fatalf("parsePlan: %v", err)
+	}
+	if plan.Name != "auth" || len(entries) != 3 {
+		t.Fatalf("name=%q entries=%d", plan.Name, len(entries))
+	}
+	if entries[1].Parent != "epic" || entries[2].Parent != "epic" {
+		t.Errorf("children should get the enclosing parent: %q %q", entries[1].Parent, entries[2].Parent)
+	}
+
+	for name, input := range map[string]string{
+		"bad name":      "name: 'has space'\nissues: []\n",
+		"duplicate key": "name: p\nissues:\n  - {key: a, title: A}\n  - {key: a, title: B}\n",
+		"unknown field": "name: p\nissues:\n  - {key: a, title: A, prioirty: 1}\n",
+		"self dep":      "name: p\nissues:\n  - {key: a, title: A, depends_on: [a]}\n",
+		"parent clash":  "name: p\nissues:\n  - key: a\n    title: A\n    children:\n      - {key: b, title: B, parent: c}\n",
+	} {
+		if _, _, err := parsePlan([]byte(input)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func planIssueWithKey(id, key string, mutate func(*types.Issue)) *types.Issue {
+	md, _ := withPlanKey(nil, key)
+	issue := &types.Issue{ID: id, Title: key, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Metadata: md}
+	if mutate != nil {
+		mutate(issue)
+	}
+	return issue
+}
+
+func actionsByKey(actions []*planAction) map[string]*planAction {
+	m := make(map[string]*planAction)
+	for _, a := range actions {
+		m[a.Key] = a
+	}
+	return m
+}
+
+func TestBuildPlanActions(t *testing.T) {
+	plan, entries, err := parsePlan([]byte(testPlanYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := &planState{
+		Managed: map[string]*types.Issue{
+			// Matches the plan except priority
+			"epic": planIssueWithKey("bd-1", "epic", func(i *types.Issue) {
+				i.Title = "Auth rework"
+				i.IssueType = types.TypeEpic
+			}),
+			// Matches the plan exactly
+			"api": planIssueWithKey("bd-2", "api", func(i *types.Issue) { i.Title = "Token API" }),
+			// No longer in the plan
+			"old": planIssueWithKey("bd-3", "old", nil),
+		},
+		ByID:   map[string]*types.Issue{"bd-ext": {ID: "bd-ext"}},
+		RefIDs: map[string]string{"bd-ext": "bd-ext"},
+		Labels: map[string][]string{
+			"bd-1": {"plan:auth"},
+			"bd-2": {"plan:auth", "backend"},
+			"bd-3": {"plan:auth"},
+		},
+		Deps: map[string][]*types.Dependency{
+			"bd-2": {{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepParentChild}},
+		},
+	}
+
+	actions, err := buildPlanActions(plan, entries, state, editDocRules{}, false)
+	if err != nil {
+		t.Fatalf("buildPlanActions: %v", err)
+	}
+	byKey := actionsByKey(actions)
+
+	if a := byKey["epic"]; a.Action != "update" || a.fields.Updates["priority"] != 1 || len(a.fields.Updates) != 1 {
+		t.Errorf("epic: action=%s updates=%v", a.Action, a.fields.Updates)
+	}
+	if a := byKey["api"]; a.Action != "unchanged" {
+		t.Errorf("api: action=%s changes=%v", a.Action, a.Changes)
+	}
+	ui := byKey["ui"]
+	if ui.Action != "create" {
+		t.Fatalf("ui: action=%s", ui.Action)
+	}
+	var deps []string
+	for _, d := range ui.AddDeps {
+		deps = append(deps, string(d.Type)+":"+d.Target)
+	}
+	// Existing plan issues are referenced by ID, new ones by key
+	if got := strings.Join(deps, ","); got != "parent-child:bd-1,blocks:bd-2,blocks:bd-ext" {
+		t.Errorf("ui deps = %s", got)
+	}
+	if !strings.Contains(strings.Join(ui.desired.Labels, ","), "plan:auth") {
+		t.Errorf("created issue should carry the plan label: %v", ui.desired.Labels)
+	}
+	if a := byKey["old"]; a == nil || a.Action != "orphan" {
+		t.Errorf("old: %+v", a)
+	}
+
+	// --prune turns orphans into prunes; closed orphans are left alone
+	state.Managed["done"] = planIssueWithKey("bd-4", "done", func(i *types.Issue) { i.Status = types.StatusClosed })
+	actions, err = buildPlanActions(plan, entries, state, editDocRules{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byKey = actionsByKey(actions)
+	if byKey["old"].Action != "prune" || byKey["done"] != nil {
+		t.Errorf("old=%+v done=%+v", byKey["old"], byKey["done"])
+	}
+}
+
+func TestBuildPlanActionsDeclaredDeps(t *testing.T) {
+	plan, entries, err := parsePlan([]byte("name: p\nissues:\n  - {key: a, title: A, depends_on: []}\n  - {key: b, title: B}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := &planState{
+		Managed: map[string]*types.Issue{
+			"a": planIssueWithKey("bd-1", "a", func(i *types.Issue) { i.Title = "A" }),
+			"b": planIssueWithKey("bd-2", "b", func(i *types.Issue) { i.Title = "B" }),
+		},
+		Labels: map[string][]string{"bd-1": {"plan:p"}, "bd-2": {"plan:p"}},
+		Deps: map[string][]*types.Dependency{
+			"bd-1": {{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks}},
+			"bd-2": {{IssueID: "bd-2", DependsOnID: "bd-9", Type: types.DepBlocks}},
+		},
+	}
+	actions, err := buildPlanActions(plan, entries, state, editDocRules{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byKey := actionsByKey(actions)
+	// Declared (empty) depends_on is exact: the existing edge goes.
+	if a := byKey["a"]; a.Action != "update" || len(a.RemoveDeps) != 1 || a.RemoveDeps[0].Target != "bd-2" {
+		t.Errorf("a: %+v", a)
+	}
+	// Undeclared depends_on is unmanaged.
+	if a := byKey["b"]; a.Action != "unchanged" {
+		t.Errorf("b: %+v", a)
+	}
+}
+
+func TestBuildPlanActionsAdopt(t *testing.T) {
+	plan, entries, err := parsePlan([]byte("name: p\nissues:\n  - {key: legacy, id: bd-7, title: Legacy}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing := &types.Issue{ID: "bd-7", Title: "Legacy", Status: types.StatusOpen, Priority: 2,
+		IssueType: types.TypeTask, Metadata: json.RawMessage(`{"team":"core"}`)}
+	state := &planState{
+		Managed: map[string]*types.Issue{},
+		ByID:    map[string]*types.Issue{"bd-7": existing},
+		RefIDs:  map[string]string{"bd-7": "bd-7"},
+	}
+	actions, err := buildPlanActions(plan, entries, state, editDocRules{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := actions[0]
+	if a.Action != "update" || a.ID != "bd-7" {
+		t.Fatalf("action=%s id=%s", a.Action, a.ID)
+	}
+	if strings.Join(a.fields.AddLabels, ",") != "plan:p" {
+		t.Errorf("add labels = %v", a.fields.AddLabels)
+	}
+	md, _ := a.fields.Updates["metadata"].(json.RawMessage)
+	if planKeyOf(&types.Issue{Metadata: md}) != "legacy" || !strings.Contains(string(md), `"team":"core"`) {
+		t.Errorf("metadata = %s", md)
+	}
+}
+
+func TestBuildPlanActionsInvalid(t *testing.T) {
+	plan, entries, err := parsePlan([]byte("name: p\nissues:\n  - {key: a, title: A, priority: urgent, depends_on: [nope]}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := &planState{Managed: map[string]*types.Issue{}}
+	_, err = buildPlanActions(plan, entries, state, editDocRules{}, false)
+	if err == nil || !strings.Contains(err.Error(), "a: priority:") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestWithPlanKey(t *testing.T) {
+	if _, err := withPlanKey(json.RawMessage(`[1]`), "k"); err == nil {
+		t.Error("non-object metadata should be rejected")
+	}
+	md, err := withPlanKey(json.RawMessage(`null`), "k")
+	if err != nil || planKeyOf(&types.Issue{Metadata: md}) != "k" {
+		t.Errorf("md=%s err=%v", md, err)
+	}
+}
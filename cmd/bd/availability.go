@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var availabilityCmd = &cobra.Command{
+	Use:     "availability",
+	GroupID: "views",
+	Short:   "Record when people are away",
+	Long: `Record the days people are away, so planning doesn't count on them.
+
+Away periods are whole days, shared through the database like issues. They
+are used by:
+  bd ready --mine   warns when you are marked away today
+  bd ready          notes ready issues whose assignee is away today
+  bd blocked        flags blocked issues whose assignee is away today
+  bd timeline       skips the assignee's days away when projecting dates
+
+People are matched to assignees by name. Without a subcommand, lists
+current and upcoming periods.
+
+Examples:
+  bd availability set alice --off 2025-07-01..2025-07-14 --note vacation
+  bd availability set bob --off 2025-07-04
+  bd availability list alice
+  bd availability clear alice --off 2025-07-10   # Periods overlapping that day
+  bd availability clear alice                    # All of alice's periods`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		listAvailability("", false)
+	},
+}
+
+var availabilitySetCmd = &cobra.Command{
+	Use:   "set <person> --off <from>..<to>",
+	Short: "Mark a person away for a date or date range",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("availability set")
+		ctx := rootCtx
+		off, _ := cmd.Flags().GetString("off")
+		note, _ := cmd.Flags().GetString("note")
+		if off == "" {
+			FatalErrorRespectJSON("--off is required (YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD)")
+		}
+		from, to, err := parseDateRange(off)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		person := strings.TrimSpace(args[0])
+		if person == "" {
+			FatalErrorRespectJSON("person must not be empty")
+		}
+
+		u := &types.Unavailability{Person: person, Start: from, End: to, Note: note, CreatedBy: actor}
+		if err := store.SetUnavailability(ctx, u); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(u)
+			return
+		}
+		fmt.Printf("%s %s is away %s\n", ui.RenderPass("✓"), person, formatDateRange(from, to))
+	},
+}
+
+var availabilityListCmd = &cobra.Command{
+	Use:   "list [person]",
+	Short: "List current and upcoming away periods",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+		person := ""
+		if len(args) == 1 {
+			person = args[0]
+		}
+		listAvailability(person, all)
+	},
+}
+
+var availabilityClearCmd = &cobra.Command{
+	Use:   "clear <person>",
+	Short: "Remove a person's away periods",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("availability clear")
+		ctx := rootCtx
+		off, _ := cmd.Flags().GetString("off")
+		var from, to string
+		if off != "" {
+			var err error
+			if from, to, err = parseDateRange(off); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+		}
+		removed, err := store.RemoveUnavailability(ctx, args[0], from, to)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"person": args[0], "removed": removed})
+			return
+		}
+		if removed == 0 {
+			fmt.Printf("No away periods to clear for %s\n", args[0])
+			return
+		}
+		fmt.Printf("%s Cleared %d away period(s) for %s\n", ui.RenderPass("✓"), removed, args[0])
+	},
+}
+
+// listAvailability prints person's away periods (everyone's when empty),
+// only those not yet over unless all is set.
+func listAvailability(person string, all bool) {
+	since := todayDate()
+	if all {
+		since = ""
+	}
+	periods, err := store.GetUnavailability(rootCtx, person, since)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if jsonOutput {
+		if periods == nil {
+			periods = []*types.Unavailability{}
+		}
+		outputJSON(periods)
+		return
+	}
+	if len(periods) == 0 {
+		fmt.Println("No one is marked away")
+		return
+	}
+	day := todayDate()
+	for _, u := range periods {
+		line := fmt.Sprintf("  %-16s %s", u.Person, formatDateRange(u.Start, u.End))
+		if u.Covers(day) {
+			line += " " + ui.RenderWarn("(away now)")
+		}
+		if u.Note != "" {
+			line += " " + ui.RenderMuted(u.Note)
+		}
+		fmt.Println(line)
+	}
+}
+
+// parseDateRange parses "YYYY-MM-DD" or "YYYY-MM-DD..YYYY-MM-DD" into
+// inclusive start and end dates.
+func parseDateRange(s string) (from, to string, err error) {
+	from, to, found := strings.Cut(strings.TrimSpace(s), "..")
+	if !found {
+		to = from
+	}
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	for _, d := range []string{from, to} {
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return "", "", fmt.Errorf("invalid date %q in %q (use YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD)", d, s)
+		}
+	}
+	if to < from {
+		return "", "", fmt.Errorf("date range %q ends before it starts", s)
+	}
+	return from, to, nil
+}
+
+func formatDateRange(from, to string) string {
+	if from == to {
+		return "on " + from
+	}
+	return "from " + from + " to " + to
+}
+
+// todayDate returns the local date as YYYY-MM-DD.
+func todayDate() string {
+	return time.Now().Format("2006-01-02")
+}
+
+// awayToday returns the period covering today for each person who is away,
+// keyed by person. It is best effort: nil if availability can't be read.
+func awayToday(ctx context.Context, s *dolt.DoltStore) map[string]*types.Unavailability {
+	day := todayDate()
+	periods, err := s.GetUnavailability(ctx, "", day)
+	if err != nil {
+		return nil
+	}
+	away := make(map[string]*types.Unavailability)
+	for _, u := range periods {
+		if u.Covers(day) {
+			away[u.Person] = u
+		}
+	}
+	return away
+}
+
+// awayNote describes when an away person is back, for annotating issues
+// assigned to them.
+func awayNote(u *types.Unavailability) string {
+	return fmt.Sprintf("%s is away until %s", u.Person, u.End)
+}
+
+// daysOff expands away periods into each person's days off (YYYY-MM-DD).
+func daysOff(periods []*types.Unavailability) map[string][]string {
+	out := make(map[string][]string)
+	for _, u := range periods {
+		start, err1 := time.Parse("2006-01-02", u.Start)
+		end, err2 := time.Parse("2006-01-02", u.End)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			out[u.Person] = append(out[u.Person], d.Format("2006-01-02"))
+		}
+	}
+	return out
+}
+
+func init() {
+	availabilitySetCmd.Flags().String("off", "", "Date or inclusive date range away (YYYY-MM-DD or YYYY-MM-DD..YYYY-MM-DD)")
+	availabilitySetCmd.Flags().String("note", "", "Note, e.g. the reason")
+	availabilityListCmd.Flags().Bool("all", false, "Include periods that are over")
+	availabilityClearCmd.Flags().String("off", "", "Only clear periods overlapping this date or range")
+	availabilityCmd.AddCommand(availabilitySetCmd, availabilityListCmd, availabilityClearCmd)
+	rootCmd.AddCommand(availabilityCmd)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		in       string
+		from, to string
+		wantErr  bool
+	}{
+		{"2025-07-01..2025-07-14", "2025-07-01", "2025-07-14", false},
+		{" 2025-07-04 ", "2025-07-04", "2025-07-04", false},
+		{"2025-07-01 .. 2025-07-02", "2025-07-01", "2025-07-02", false},
+		{"2025-07-14..2025-07-01", "", "", true},
+		{"2025-7-1", "", "", true},
+		{"2025-07-01..", "", "", true},
+		{"next week", "", "", true},
+	}
+	for _, tt := range tests {
+		from, to, err := parseDateRange(tt.in)
+		if (err != nil) != tt.wantErr || from != tt.from || to != tt.to {
+			t.Errorf("parseDateRange(%q) = %q, %q, %v", tt.in, from, to, err)
+		}
+	}
+}
+
+func TestDaysOff(t *testing.T) {
+	got := daysOff([]*types.Unavailability{
+		{Person: "alice", Start: "2025-06-30", End: "2025-07-02"},
+		{Person: "alice", Start: "2025-07-10", End: "2025-07-10"},
+		{Person: "bob", Start: "bad", End: "2025-07-10"},
+	})
+	want := map[string][]string{"alice": {"2025-06-30", "2025-07-01", "2025-07-02", "2025-07-10"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("daysOff = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// issueWriter is the part of the store that issue updates write through,
+// so the same code can run against the store or inside a transaction.
+type issueWriter interface {
+	GetIssue(ctx context.Context, id string) (*types.Issue, error)
+	UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error
+	AddLabel(ctx context.Context, issueID, label, actor string) error
+	RemoveLabel(ctx context.Context, issueID, label, actor string) error
+	GetLabels(ctx context.Context, issueID string) ([]string, error)
+	AddDependency(ctx context.Context, dep *types.Dependency, actor string) error
+	RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error
+	GetDependencyRecords(ctx context.Context, issueID string) ([]*types.Dependency, error)
+}
+
+// registerBatchFlags adds the --batch flags to a command that writes issues.
+func registerBatchFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("batch", false, "Change every matching issue in one transaction (IDs, --filter, or '-' for IDs on stdin)")
+	cmd.Flags().String("filter", "", "With --batch, also change the issues matching this bd query expression")
+	cmd.Flags().Bool("dry-run", false, "With --batch, list the issues that would change without changing them")
+}
+
+// batchFlags reads the --batch flags, refusing --filter and --dry-run
+// without --batch.
+func batchFlags(cmd *cobra.Command) (batch bool, filter string, dryRun bool) {
+	batch, _ = cmd.Flags().GetBool("batch")
+	filter, _ = cmd.Flags().GetString("filter")
+	dryRun, _ = cmd.Flags().GetBool("dry-run")
+	if !batch && (cmd.Flags().Changed("filter") || dryRun) {
+		FatalErrorCode(exitValidation, "--filter and --dry-run require --batch")
+	}
+	return batch, filter, dryRun
+}
+
+// batchTargets resolves the issues a batch applies to: the IDs in args,
+// the IDs on stdin when args include "-", and the matches of filter. Each
+// issue is listed once, in that order.
+func batchTargets(ctx context.Context, args []string, filter string, stdin io.Reader) ([]*types.Issue, error) {
+	var ids []string
+	for _, arg := range args {
+		if arg != stdinDocArg {
+			ids = append(ids, arg)
+			continue
+		}
+		stdinIDs, err := readBatchIDs(stdin)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, stdinIDs...)
+	}
+	if len(ids) == 0 && strings.TrimSpace(filter) == "" {
+		return nil, fmt.Errorf("--batch needs issue IDs, '-' to read them from stdin, or --filter")
+	}
+
+	seen := make(map[string]bool)
+	var issues []*types.Issue
+	for _, id := range ids {
+		if needsRouting(id) {
+			return nil, fmt.Errorf("%s is in another rig; --batch only changes issues in this database", id)
+		}
+		resolved, err := utils.ResolvePartialID(ctx, store, id)
+		if err != nil {
+			return nil, fmt.Errorf("resolving %s: %w", id, err)
+		}
+		if seen[resolved] {
+			continue
+		}
+		issue, err := store.GetIssue(ctx, resolved)
+		if err != nil {
+			return nil, fmt.Errorf("getting %s: %w", resolved, err)
+		}
+		if issue == nil {
+			return nil, fmt.Errorf("issue %s not found", resolved)
+		}
+		seen[resolved] = true
+		issues = append(issues, issue)
+	}
+	if strings.TrimSpace(filter) != "" {
+		matches, err := queryMatches(ctx, filter, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("invalid --filter: %w", err)
+		}
+		for _, issue := range matches {
+			if !seen[issue.ID] {
+				seen[issue.ID] = true
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues, nil
+}
+
+// readBatchIDs reads issue IDs separated by whitespace, as printed by
+// 'bd list --porcelain | cut -f1' or 'jq -r .[].id'. Text after a # on a line
+// is a comment.
+func readBatchIDs(r io.Reader) ([]string, error) {
+	var ids []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		ids = append(ids, strings.Fields(line)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading issue IDs from stdin: %w", err)
+	}
+	return ids, nil
+}
+
+// printBatchPlan shows what a --dry-run batch would change.
+func printBatchPlan(verb string, issues []*types.Issue) {
+	if jsonOutput {
+		outputJSON(map[string]interface{}{"dry_run": true, "issues": issues})
+		return
+	}
+	if len(issues) == 0 {
+		fmt.Printf("No issues to %s\n", verb)
+		return
+	}
+	fmt.Printf("Would %s %d issue(s):\n", verb, len(issues))
+	for _, issue := range issues {
+		fmt.Printf("  %s %s %s\n", issue.ID, ui.RenderMuted("["+string(issue.Status)+"]"), issue.Title)
+	}
+}
@@ -0,0 +1,95 @@
+//go:build cgo
+
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/writepolicy"
+)
+
+func TestReadBatchIDs(t *testing.T) {
+	ids, err := readBatchIDs(strings.NewReader("bd-1 bd-2\n# done last sprint\n\nbd-3\t# flaky\n"))
+	if err != nil {
+		t.Fatalf("readBatchIDs: %v", err)
+	}
+	if want := []string{"bd-1", "bd-2", "bd-3"}; !slices.Equal(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	originalStore, originalRootCtx := store, rootCtx
+	defer func() { store, rootCtx = originalStore, originalRootCtx }()
+	ctx := context.Background()
+	rootCtx = ctx
+	store = newTestStoreWithPrefix(t, filepath.Join(t.TempDir(), "test.db"), "test")
+
+	var ids []string
+	for _, p := range []int{0, 2, 2} {
+		issue := &types.Issue{Title: "sprint work", Status: types.StatusOpen, Priority: p, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+			t.Fatalf("CreateIssue: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+
+	t.Run("targets", func(t *testing.T) {
+		issues, err := batchTargets(ctx, []string{ids[0], "-"}, "priority=2", strings.NewReader(ids[1]+"\n"+ids[0]+"\n"))
+		if err != nil {
+			t.Fatalf("batchTargets: %v", err)
+		}
+		var got []string
+		for _, issue := range issues {
+			got = append(got, issue.ID)
+		}
+		if !slices.Equal(got, ids) {
+			t.Errorf("targets = %v, want %v (each once, IDs before filter matches)", got, ids)
+		}
+		if _, err := batchTargets(ctx, nil, "", nil); err == nil {
+			t.Error("a batch without IDs or --filter should fail")
+		}
+		if _, err := batchTargets(ctx, []string{"test-nope"}, "", nil); err == nil {
+			t.Error("a batch naming a missing issue should fail")
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		runBatchUpdate(ctx, nil, "priority=2", false, map[string]interface{}{"add_labels": []string{"sprint-9"}, "assignee": "ana"}, nil, nil)
+		for _, id := range ids[1:] {
+			issue, _ := store.GetIssue(ctx, id)
+			labels, _ := store.GetLabels(ctx, id)
+			if issue.Assignee != "ana" || !slices.Contains(labels, "sprint-9") {
+				t.Errorf("%s: assignee %q, labels %v after batch update", id, issue.Assignee, labels)
+			}
+		}
+		if issue, _ := store.GetIssue(ctx, ids[0]); issue.Assignee != "" {
+			t.Errorf("%s doesn't match the filter but was updated", ids[0])
+		}
+	})
+
+	t.Run("close", func(t *testing.T) {
+		rules, err := writepolicy.CloseRules()
+		if err != nil {
+			t.Fatalf("closeRules: %v", err)
+		}
+		runBatchClose(ctx, nil, "label=sprint-9", true, false, "Closed", "", "", "", rules, nil)
+		if issue, _ := store.GetIssue(ctx, ids[1]); issue.Status == types.StatusClosed {
+			t.Fatal("--dry-run closed an issue")
+		}
+		runBatchClose(ctx, nil, "label=sprint-9", false, false, "Shipped", "", "", "", rules, nil)
+		for _, id := range ids[1:] {
+			if issue, _ := store.GetIssue(ctx, id); issue.Status != types.StatusClosed || issue.CloseReason != "Shipped" {
+				t.Errorf("%s: status %s, reason %q after batch close", id, issue.Status, issue.CloseReason)
+			}
+		}
+		if issue, _ := store.GetIssue(ctx, ids[0]); issue.Status == types.StatusClosed {
+			t.Errorf("%s doesn't match the filter but was closed", ids[0])
+		}
+	})
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var capabilitiesCmd = &cobra.Command{
+	Use:     "capabilities",
+	GroupID: "setup",
+	Short:   "Show which features work in this build and environment",
+	Long: `Show which optional features this bd binary and environment support,
+and how to enable the ones that are unavailable:
+
+  storage           Issue storage on a Dolt sql-server
+  history           Dolt history (bd history, bd diff, bd restore, bd vc, bd branch)
+  federation        Peer-to-peer sync (needs a cgo build)
+  sqlite-migration  Importing a legacy SQLite database (needs a cgo build)
+  doctor-db-checks  bd doctor's database checks (needs a cgo build)
+
+Dolt is the only storage backend, so there is no fallback when the server
+is unreachable: every command that reads or writes issues needs it.
+
+Examples:
+  bd capabilities
+  bd capabilities --json`,
+	Run: runCapabilities,
+}
+
+func init() {
+	rootCmd.AddCommand(capabilitiesCmd)
+}
+
+// Optional features whose availability depends on the build or environment.
+const (
+	capStorage         = "storage"
+	capHistory         = "history"
+	capFederation      = "federation"
+	capSQLiteMigration = "sqlite-migration"
+	capDoctorDB        = "doctor-db-checks"
+)
+
+// cgoHint says how to get a bd binary built with cgo.
+const cgoHint = "use a release binary from GitHub, or rebuild bd with CGO_ENABLED=1"
+
+// cgoCommands maps top-level commands that exist only in cgo builds to
+// the capability they provide.
+var cgoCommands = map[string]string{
+	"federation": capFederation,
+}
+
+// capability is an optional feature and whether it is available.
+type capability struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Available   bool     `json:"available"`
+	Detail      string   `json:"detail,omitempty"` // Current state, or why it is unavailable
+	Hint        string   `json:"hint,omitempty"`   // How to make it available
+	Commands    []string `json:"commands,omitempty"`
+
+	needsServer bool // Unavailable only because the Dolt server is
+}
+
+// environment is what bd found about the build and the Dolt server.
+type environment struct {
+	CGO        bool   // Built with cgo (needed for federation)
+	DoltBinary string // Path to the dolt binary, empty if not on PATH
+	ServerAddr string // host:port of the configured sql-server
+	ServerUp   bool   // A server is listening at ServerAddr
+	Loopback   bool   // ServerAddr is on this machine
+}
+
+// probeEnvironment inspects the environment for the given server.
+func probeEnvironment(host string, port int) environment {
+	env := environment{CGO: cgoEnabled, ServerAddr: net.JoinHostPort(host, strconv.Itoa(port))}
+	if path, err := exec.LookPath("dolt"); err == nil {
+		env.DoltBinary = path
+	}
+	if conn, err := net.DialTimeout("tcp", env.ServerAddr, 500*time.Millisecond); err == nil {
+		_ = conn.Close()
+		env.ServerUp = true
+	}
+	ip := net.ParseIP(host)
+	env.Loopback = strings.EqualFold(host, "localhost") || (ip != nil && ip.IsLoopback())
+	return env
+}
+
+// probeWorkspaceEnvironment probes the server configured for the current
+// workspace (defaults outside one), and reports whether bd may start it.
+func probeWorkspaceEnvironment() (environment, bool) {
+	cfg := &configfile.Config{}
+	if beadsDir := beads.FindBeadsDir(); beadsDir != "" {
+		if loaded, err := configfile.Load(beadsDir); err == nil && loaded != nil {
+			cfg = loaded
+		}
+	}
+	host, port := (&dolt.Config{ServerHost: cfg.GetDoltServerHost(), ServerPort: cfg.GetDoltServerPort()}).ServerAddress()
+	return probeEnvironment(host, port), cfg.GetDoltAutoStart()
+}
+
+// detectCapabilities reports each optional feature's availability in env.
+// autoStart is whether bd may start a local server.
+func detectCapabilities(env environment, autoStart bool) []capability {
+	storage := capability{
+		Name:        capStorage,
+		Description: "Issue storage on a Dolt sql-server",
+		Available:   true,
+	}
+	switch {
+	case env.ServerUp:
+		storage.Detail = "server running at " + env.ServerAddr
+	case autoStart && env.Loopback && env.DoltBinary != "":
+		storage.Detail = "bd starts a local server at " + env.ServerAddr + " when needed"
+	default:
+		storage.Available, storage.needsServer = false, true
+		storage.Detail = "no Dolt server is listening at " + env.ServerAddr
+		switch {
+		case !autoStart:
+			storage.Hint = "start your server, or point bd at it with dolt_server_host/dolt_server_port in .beads/metadata.json"
+		case !env.Loopback:
+			storage.Hint = "start the server at " + env.ServerAddr + "; bd only starts servers on this machine"
+		default:
+			storage.Hint = "install dolt (https://docs.dolthub.com/introduction/installation) or run 'bd dolt start'"
+		}
+	}
+
+	// needs marks c unavailable when the build lacks cgo (if needsCGO) or
+	// the server is unavailable.
+	needs := func(c capability, needsCGO bool) capability {
+		c.Available = true
+		switch {
+		case needsCGO && !env.CGO:
+			c.Available, c.Detail, c.Hint = false, "bd was built without cgo", cgoHint
+		case !storage.Available:
+			c.Available, c.Detail, c.Hint, c.needsServer = false, "needs the Dolt server", storage.Hint, true
+		}
+		return c
+	}
+	return []capability{
+		storage,
+		needs(capability{
+			Name:        capHistory,
+			Description: "Dolt history: past versions, diffs, restores, and branches",
+			Commands:    []string{"bd history", "bd diff", "bd restore", "bd vc", "bd branch"},
+		}, false),
+		needs(capability{
+			Name:        capFederation,
+			Description: "Peer-to-peer sync between Dolt databases",
+			Commands:    []string{"bd federation"},
+		}, true),
+		needs(capability{
+			Name:        capSQLiteMigration,
+			Description: "Importing a legacy SQLite database into Dolt",
+			Commands:    []string{"bd migrate --to-dolt"},
+		}, true),
+		needs(capability{
+			Name:        capDoctorDB,
+			Description: "Database checks in bd doctor",
+			Commands:    []string{"bd doctor"},
+		}, true),
+	}
+}
+
+// topLevelCommand returns the child of the root command that cmd is, or
+// is under.
+func topLevelCommand(cmd *cobra.Command) *cobra.Command {
+	for cmd.HasParent() && cmd.Parent().HasParent() {
+		cmd = cmd.Parent()
+	}
+	return cmd
+}
+
+// requireBuildCapabilities exits with an explanation when cmd needs a
+// feature this build lacks, before opening the store could fail with a
+// less useful error.
+func requireBuildCapabilities(cmd *cobra.Command) {
+	if cgoEnabled {
+		return
+	}
+	name := topLevelCommand(cmd).Name()
+	if capName, ok := cgoCommands[name]; ok {
+		FatalErrorWithHint(fmt.Sprintf("'bd %s' is unavailable: bd was built without cgo, which %s requires", name, capName), cgoHint)
+	}
+}
+
+func runCapabilities(_ *cobra.Command, _ []string) {
+	env, autoStart := probeWorkspaceEnvironment()
+	caps := detectCapabilities(env, autoStart)
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"cgo":          env.CGO,
+			"server":       env.ServerAddr,
+			"capabilities": caps,
+		})
+		return
+	}
+
+	cgo := "yes"
+	if !env.CGO {
+		cgo = "no"
+	}
+	fmt.Printf("\n%s Capabilities %s\n\n", ui.RenderAccent("⚙"), ui.RenderMuted("(built with cgo: "+cgo+")"))
+	for _, c := range caps {
+		mark := ui.RenderPass("✓")
+		if !c.Available {
+			mark = ui.RenderWarn("✗")
+		}
+		fmt.Printf("  %s %-17s %s\n", mark, c.Name, c.Description)
+		if c.Detail != "" {
+			fmt.Printf("    %-17s %s\n", "", ui.RenderMuted(c.Detail))
+		}
+		if !c.Available && c.Hint != "" {
+			fmt.Printf("    %-17s Hint: %s\n", "", c.Hint)
+		}
+	}
+	fmt.Println()
+}
+
+// unavailableWithoutServer lists, one per line, the capabilities that are
+// unavailable because the Dolt server is.
+func unavailableWithoutServer(caps []capability) []string {
+	var lines []string
+	for _, c := range caps {
+		if !c.needsServer {
+			continue
+		}
+		line := c.Description
+		if len(c.Commands) > 0 {
+			line += " (" + strings.Join(c.Commands, ", ") + ")"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
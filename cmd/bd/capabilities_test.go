@@ -0,0 +1,76 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestDetectCapabilities(t *testing.T) {
+	available := func(caps []capability) map[string]bool {
+		m := make(map[string]bool)
+		for _, c := range caps {
+			m[c.Name] = c.Available
+		}
+		return m
+	}
+	tests := []struct {
+		name      string
+		env       environment
+		autoStart bool
+		want      map[string]bool
+	}{
+		{"cgo with server", environment{CGO: true, ServerUp: true},
+			false, map[string]bool{capStorage: true, capHistory: true, capFederation: true, capSQLiteMigration: true, capDoctorDB: true}},
+		{"no cgo", environment{ServerUp: true},
+			false, map[string]bool{capStorage: true, capHistory: true, capFederation: false, capSQLiteMigration: false, capDoctorDB: false}},
+		{"auto-start", environment{CGO: true, Loopback: true, DoltBinary: "/usr/bin/dolt"},
+			true, map[string]bool{capStorage: true, capHistory: true, capFederation: true, capSQLiteMigration: true, capDoctorDB: true}},
+		{"no server", environment{CGO: true, Loopback: true},
+			true, map[string]bool{capStorage: false, capHistory: false, capFederation: false, capSQLiteMigration: false, capDoctorDB: false}},
+		{"remote server down", environment{CGO: true, DoltBinary: "/usr/bin/dolt"},
+			true, map[string]bool{capStorage: false, capHistory: false, capFederation: false, capSQLiteMigration: false, capDoctorDB: false}},
+	}
+	for _, tt := range tests {
+		caps := detectCapabilities(tt.env, tt.autoStart)
+		if got := available(caps); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: availability = %v, want %v", tt.name, got, tt.want)
+		}
+		for _, c := range caps {
+			if !c.Available && c.Hint == "" {
+				t.Errorf("%s: %s is unavailable without a hint", tt.name, c.Name)
+			}
+		}
+	}
+}
+
+func TestUnavailableWithoutServer(t *testing.T) {
+	// Without cgo, federation is unavailable whether or not the server is,
+	// so only storage and history are blamed on the server.
+	caps := detectCapabilities(environment{Loopback: true}, true)
+	got := unavailableWithoutServer(caps)
+	want := []string{
+		"Issue storage on a Dolt sql-server",
+		"Dolt history: past versions, diffs, restores, and branches (bd history, bd diff, bd restore, bd vc, bd branch)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unavailableWithoutServer = %q, want %q", got, want)
+	}
+	if got := unavailableWithoutServer(detectCapabilities(environment{ServerUp: true}, false)); len(got) != 0 {
+		t.Errorf("unavailableWithoutServer with server up = %q, want none", got)
+	}
+}
+
+func TestTopLevelCommand(t *testing.T) {
+	root := &cobra.Command{Use: "bd"}
+	parent := &cobra.Command{Use: "federation"}
+	child := &cobra.Command{Use: "sync"}
+	root.AddCommand(parent)
+	parent.AddCommand(child)
+	for _, cmd := range []*cobra.Command{parent, child} {
+		if got := topLevelCommand(cmd); got != parent {
+			t.Errorf("topLevelCommand(%s) = %s, want federation", cmd.Name(), got.Name())
+		}
+	}
+}
@@ -1,16 +1,21 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/resolution"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/writepolicy"
 )
 
 var closeCmd = &cobra.Command{
@@ -20,13 +25,44 @@ var closeCmd = &cobra.Command{
 	Long: `Close one or more issues.
 
 If no issue ID is provided, closes the last touched issue (from most recent
-create, update, show, or close operation).`,
+create, update, show, or close operation).
+
+--category records how the issue was resolved, so bd stats can count it:
+completed, wontfix, duplicate, obsolete, or superseded-by:<id> (which also
+links the issue to its replacement). The category prefixes the reason, as
+in "wontfix: can't reproduce"; a reason written that way counts too.
+close.categories sets the allowed categories, and
+close.require-category-max-priority requires one for urgent issues.
+
+--evidence-url and --evidence-file attach verification evidence, such as a
+CI run or a JUnit report (files up to 1 MiB are stored in the database);
+see it with 'bd evidence'. close.require-evidence-types (e.g. [bug]) makes
+bd close refuse those issue types without evidence, unless they are closed
+as wontfix, duplicate, obsolete or superseded-by.
+
+--outcome tags how the work turned out (shipped, reverted, abandoned; see
+close.outcomes) for 'bd report retro'. Change it later with 'bd outcome'.
+
+--batch closes many issues in one transaction, and so one Dolt commit: the
+IDs given as arguments, the IDs read from stdin for "-", and the issues
+matching --filter (a bd query expression). Issues that can't be closed are
+reported and skipped; if any close fails, none are closed. --dry-run lists
+the issues without closing them.
+
+Examples:
+  bd close bd-12 --reason "Shipped in v2"
+  bd close bd-12 --category wontfix --reason "Works as intended"
+  bd close bd-12 --category superseded-by:bd-40
+  bd close bd-12 --outcome shipped
+  bd close bd-12 --evidence-url https://ci.example.com/runs/812 --evidence-file junit.xml
+  bd close --batch --filter 'label=sprint-9 and status=in_progress' --reason "Sprint 9" --dry-run`,
 	Args: cobra.MinimumNArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("close")
+		batch, filter, dryRun := batchFlags(cmd)
 
 		// If no IDs provided, use last touched issue
-		if len(args) == 0 {
+		if len(args) == 0 && !batch {
 			lastTouched := GetLastTouchedID()
 			if lastTouched == "" {
 				FatalErrorRespectJSON("no issue ID provided and no last touched issue")
@@ -42,9 +78,6 @@ create, update, show, or close operation).`,
 			// Check -m alias (git commit convention)
 			reason, _ = cmd.Flags().GetString("message")
 		}
-		if reason == "" {
-			reason = "Closed"
-		}
 		force, _ := cmd.Flags().GetBool("force")
 		continueFlag, _ := cmd.Flags().GetBool("continue")
 		noAuto, _ := cmd.Flags().GetBool("no-auto")
@@ -58,6 +91,33 @@ create, update, show, or close operation).`,
 
 		ctx := rootCtx
 
+		// --category prefixes the reason: "wontfix: can't reproduce"
+		rules, err := writepolicy.CloseRules()
+		if err != nil {
+			FatalErrorCode(exitValidation, "%v", err)
+		}
+		var supersededBy string
+		if category, _ := cmd.Flags().GetString("category"); category != "" {
+			if reason, supersededBy, err = categorizeCloseReason(ctx, rules, reason, category); err != nil {
+				FatalErrorCode(exitValidation, "%v", err)
+			}
+		}
+		if reason == "" {
+			reason = "Closed"
+		}
+		evidenceURLs, _ := cmd.Flags().GetStringArray("evidence-url")
+		evidenceFiles, _ := cmd.Flags().GetStringArray("evidence-file")
+		evidence, err := readCloseEvidence(evidenceURLs, evidenceFiles)
+		if err != nil {
+			FatalErrorCode(exitValidation, "%v", err)
+		}
+		outcome, _ := cmd.Flags().GetString("outcome")
+		if outcome != "" {
+			if outcome, err = parseOutcome(outcome); err != nil {
+				FatalErrorCode(exitValidation, "%v", err)
+			}
+		}
+
 		// --continue only works with a single issue
 		if continueFlag && len(args) > 1 {
 			FatalErrorRespectJSON("--continue only works when closing a single issue")
@@ -68,6 +128,14 @@ create, update, show, or close operation).`,
 			FatalErrorRespectJSON("--suggest-next only works when closing a single issue")
 		}
 
+		if batch {
+			if continueFlag || suggestNext {
+				FatalErrorCode(exitValidation, "--continue and --suggest-next can't be used with --batch")
+			}
+			runBatchClose(ctx, args, filter, dryRun, force, reason, supersededBy, session, outcome, rules, evidence)
+			return
+		}
+
 		// Resolve partial IDs first, handling cross-rig routing
 		var resolvedIDs []string
 		var routedArgs []string // IDs that need cross-repo routing (bypass daemon)
@@ -88,41 +156,49 @@ create, update, show, or close operation).`,
 		closedIssues := []*types.Issue{}
 		closedCount := 0
 
-		// Handle local IDs
+		// Handle local IDs: validate every issue first, then close the ones
+		// that passed as a batch recorded in the intent log, so a crash
+		// midway is rolled forward on the next open.
+		var closableIDs []string
 		for _, id := range resolvedIDs {
 			// Get issue for checks (nil issue is handled by validateIssueClosable)
 			issue, _ := store.GetIssue(ctx, id)
-
-			if err := validateIssueClosable(id, issue, force); err != nil {
+			if err := checkClosable(ctx, id, issue, force, reason, rules, evidence); err != nil {
 				fmt.Fprintf(os.Stderr, "%s\n", err)
 				continue
 			}
 
-			// Check gate satisfaction for machine-checkable gates (GH#1467)
-			if !force {
-				if err := checkGateSatisfaction(issue); err != nil {
-					fmt.Fprintf(os.Stderr, "cannot close %s: %s\n", id, err)
-					continue
-				}
-			}
+			closableIDs = append(closableIDs, id)
+		}
 
-			// Check if issue has open blockers (GH#962)
-			if !force {
-				blocked, blockers, err := store.IsBlocked(ctx, id)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error checking blockers for %s: %v\n", id, err)
-					continue
-				}
-				if blocked && len(blockers) > 0 {
-					fmt.Fprintf(os.Stderr, "cannot close %s: blocked by open issues %v (use --force to override)\n", id, blockers)
-					continue
-				}
+		// A single close is one transaction; only batches need an intent.
+		var intentID int64
+		if len(closableIDs) > 1 {
+			id, err := store.BeginIntent(ctx, storage.IntentClose, closableIDs[0],
+				storage.CloseIntent{IssueIDs: closableIDs, Reason: reason, Session: session}, actor)
+			if err != nil {
+				debug.Logf("warning: failed to record close intent: %v", err)
+			} else {
+				intentID = id
 			}
+		}
 
-			if err := store.CloseIssue(ctx, id, reason, actor, session); err != nil {
+		for _, id := range closableIDs {
+			if err := closeWithEvidence(ctx, store, id, reason, session, evidence); err != nil {
 				fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
 				continue
 			}
+			if supersededBy != "" && supersededBy != id {
+				dep := &types.Dependency{IssueID: id, DependsOnID: supersededBy, Type: types.DepSupersedes}
+				if err := store.AddDependency(ctx, dep, actor); err != nil {
+					WarnError("failed to link %s as superseded by %s: %v", id, supersededBy, err)
+				}
+			}
+			if outcome != "" {
+				if err := setOutcome(ctx, store, id, outcome); err != nil {
+					WarnError("%v", err)
+				}
+			}
 
 			closedCount++
 
@@ -140,6 +216,9 @@ create, update, show, or close operation).`,
 				fmt.Printf("%s Closed %s: %s\n", ui.RenderPass("✓"), id, reason)
 			}
 		}
+		if intentID != 0 {
+			_ = store.FinishIntent(ctx, intentID) // Best effort: a leftover intent only re-closes open issues
+		}
 
 		// Handle routed IDs (cross-rig)
 		for _, id := range routedArgs {
@@ -169,6 +248,21 @@ create, update, show, or close operation).`,
 					fmt.Fprintf(os.Stderr, "cannot close %s: %s\n", id, err)
 					continue
 				}
+				if err := checkAcceptanceOnClose(result.Issue); err != nil {
+					result.Close()
+					fmt.Fprintf(os.Stderr, "cannot close %s: %s\n", id, err)
+					continue
+				}
+			}
+			if err := rules.Check(reason, result.Issue.Priority); err != nil {
+				result.Close()
+				fmt.Fprintf(os.Stderr, "cannot close %s: %s (use --category)\n", id, err)
+				continue
+			}
+			if err := checkCloseEvidence(result.Issue, reason, rules, evidence); err != nil {
+				result.Close()
+				fmt.Fprintf(os.Stderr, "cannot close %s: %s\n", id, err)
+				continue
 			}
 
 			// Check if issue has open blockers (GH#962)
@@ -186,11 +280,16 @@ create, update, show, or close operation).`,
 				}
 			}
 
-			if err := result.Store.CloseIssue(ctx, result.ResolvedID, reason, actor, session); err != nil {
+			if err := closeWithEvidence(ctx, result.Store, result.ResolvedID, reason, session, evidence); err != nil {
 				result.Close()
 				fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", id, err)
 				continue
 			}
+			if outcome != "" {
+				if err := setOutcome(ctx, result.Store, result.ResolvedID, outcome); err != nil {
+					WarnError("%v", err)
+				}
+			}
 
 			closedCount++
 
@@ -259,15 +358,130 @@ func init() {
 	_ = closeCmd.Flags().MarkHidden("resolution") // Hidden alias for agent/CLI ergonomics
 	closeCmd.Flags().StringP("message", "m", "", "Alias for --reason (git commit convention)")
 	_ = closeCmd.Flags().MarkHidden("message") // Hidden alias for agent/CLI ergonomics
-	closeCmd.Flags().BoolP("force", "f", false, "Force close pinned issues or unsatisfied gates")
+	closeCmd.Flags().String("category", "", "Close category: completed, wontfix, duplicate, obsolete, superseded-by:<id> (see close.categories)")
+	closeCmd.Flags().String("outcome", "", "Tag how the work turned out: shipped, reverted, abandoned (see close.outcomes)")
+	closeCmd.Flags().StringArray("evidence-url", []string{}, "Link verification evidence, such as a CI run (repeatable)")
+	closeCmd.Flags().StringArray("evidence-file", []string{}, "Attach a verification file, such as a JUnit report, up to 1 MiB (repeatable)")
+	closeCmd.Flags().BoolP("force", "f", false, "Force close pinned issues, unsatisfied gates, or unverified acceptance criteria")
 	closeCmd.Flags().Bool("continue", false, "Auto-advance to next step in molecule")
 	closeCmd.Flags().Bool("no-auto", false, "With --continue, show next step but don't claim it")
 	closeCmd.Flags().Bool("suggest-next", false, "Show newly unblocked issues after closing")
 	closeCmd.Flags().String("session", "", "Claude Code session ID (or set CLAUDE_SESSION_ID env var)")
+	registerBatchFlags(closeCmd)
 	closeCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(closeCmd)
 }
 
+// runBatchClose closes every issue a --batch selects in one transaction, so
+// either all of them close or, if one fails, none do. Issues that can't be
+// closed are reported and left out of the batch.
+func runBatchClose(ctx context.Context, args []string, filter string, dryRun, force bool, reason, supersededBy, session, outcome string, rules *resolution.Rules, evidence *closeEvidence) {
+	issues, err := batchTargets(ctx, args, filter, os.Stdin)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	var closable []*types.Issue
+	for _, issue := range issues {
+		if issue.Status == types.StatusClosed {
+			fmt.Fprintf(os.Stderr, "%s is already closed\n", issue.ID)
+			continue
+		}
+		if err := checkClosable(ctx, issue.ID, issue, force, reason, rules, evidence); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			continue
+		}
+		closable = append(closable, issue)
+	}
+	if dryRun || len(closable) == 0 {
+		printBatchPlan("close", closable)
+		return
+	}
+
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		for _, issue := range closable {
+			if err := tx.CloseIssue(ctx, issue.ID, reason, actor, session); err != nil {
+				return fmt.Errorf("closing %s: %w", issue.ID, err)
+			}
+			if supersededBy != "" && supersededBy != issue.ID {
+				dep := &types.Dependency{IssueID: issue.ID, DependsOnID: supersededBy, Type: types.DepSupersedes}
+				if err := tx.AddDependency(ctx, dep, actor); err != nil {
+					return fmt.Errorf("linking %s as superseded by %s: %w", issue.ID, supersededBy, err)
+				}
+			}
+			if outcome != "" {
+				if err := setOutcome(ctx, tx, issue.ID, outcome); err != nil {
+					return err
+				}
+			}
+			if err := attachCloseEvidence(ctx, tx, issue.ID, evidence); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		FatalErrorRespectJSON("%v (no issues were closed)", err)
+	}
+
+	closedIssues := []*types.Issue{}
+	for _, issue := range closable {
+		closedIssue, _ := store.GetIssue(ctx, issue.ID)
+		if closedIssue != nil && hookRunner != nil {
+			hookRunner.Run(hooks.EventClose, closedIssue)
+		}
+		if jsonOutput {
+			if closedIssue != nil {
+				closedIssues = append(closedIssues, closedIssue)
+			}
+		} else {
+			fmt.Printf("%s Closed %s: %s\n", ui.RenderPass("✓"), issue.ID, reason)
+		}
+	}
+	if jsonOutput {
+		outputJSON(closedIssues)
+	}
+}
+
+// checkClosable reports why a local issue can't be closed with reason, or
+// nil if it can. issue may be nil when it couldn't be read.
+func checkClosable(ctx context.Context, id string, issue *types.Issue, force bool, reason string, rules *resolution.Rules, evidence *closeEvidence) error {
+	if err := validateIssueClosable(id, issue, force); err != nil {
+		return err
+	}
+
+	// Check gate satisfaction for machine-checkable gates (GH#1467)
+	if !force {
+		if err := checkGateSatisfaction(issue); err != nil {
+			return fmt.Errorf("cannot close %s: %s", id, err)
+		}
+		if err := checkAcceptanceOnClose(issue); err != nil {
+			return fmt.Errorf("cannot close %s: %s", id, err)
+		}
+	}
+
+	// close.require-category-max-priority: urgent issues need --category
+	if issue != nil {
+		if err := rules.Check(reason, issue.Priority); err != nil {
+			return fmt.Errorf("cannot close %s: %s (use --category)", id, err)
+		}
+		if err := checkCloseEvidence(issue, reason, rules, evidence); err != nil {
+			return fmt.Errorf("cannot close %s: %s", id, err)
+		}
+	}
+
+	// Check if issue has open blockers (GH#962)
+	if !force {
+		blocked, blockers, err := store.IsBlocked(ctx, id)
+		if err != nil {
+			return fmt.Errorf("Error checking blockers for %s: %v", id, err)
+		}
+		if blocked && len(blockers) > 0 {
+			return fmt.Errorf("cannot close %s: blocked by open issues %v (use --force to override)", id, blockers)
+		}
+	}
+	return nil
+}
+
 // isMachineCheckableGate returns true if the issue is a gate with a machine-checkable await type.
 func isMachineCheckableGate(issue *types.Issue) bool {
 	if issue == nil || issue.IssueType != "gate" {
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/steveyegge/beads/internal/resolution"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/writepolicy"
+)
+
+// categorizeCloseReason prefixes reason with a --category value, e.g.
+// "wontfix: can't reproduce". A superseded-by ID is resolved to the full ID,
+// which is also returned. reason may be empty.
+func categorizeCloseReason(ctx context.Context, rules *resolution.Rules, reason, category string) (string, string, error) {
+	c, target, err := rules.ParseCategory(category)
+	if err != nil {
+		return "", "", err
+	}
+	if existing := rules.Parse(reason); existing.Category != "" {
+		return "", "", fmt.Errorf("--category %s conflicts with the %s category already in the reason", category, existing.Category)
+	}
+	if target != "" {
+		if target, err = utils.ResolvePartialID(ctx, store, target); err != nil {
+			return "", "", fmt.Errorf("resolving %s issue: %w", resolution.SupersededBy, err)
+		}
+	}
+	return resolution.Resolution{Category: c, Target: target, Note: reason}.String(), target, nil
+}
+
+// closeCategoryCounts counts closed issues per close category.
+func closeCategoryCounts(reasons map[string]int) map[string]int {
+	rules, err := writepolicy.CloseRules()
+	if err != nil {
+		rules = resolution.DefaultRules()
+	}
+	return rules.Count(reasons)
+}
+
+// printCloseCategories prints bd status's breakdown of closed issues by
+// close category, in close.categories order.
+func printCloseCategories(counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	rules, err := writepolicy.CloseRules()
+	if err != nil {
+		rules = resolution.DefaultRules()
+	}
+	fmt.Printf("\nClosed by Category:\n")
+	for _, category := range append(rules.Categories, resolution.Uncategorized) {
+		if n := counts[category]; n > 0 {
+			fmt.Printf("  %-24s%d\n", category+":", n)
+		}
+	}
+}
+
+// countCloseReasons counts issues' close reasons, for statistics computed
+// from a list of issues.
+func countCloseReasons(issues []*types.Issue) map[string]int {
+	reasons := make(map[string]int)
+	for _, issue := range issues {
+		if issue.Status == types.StatusClosed {
+			reasons[issue.CloseReason]++
+		}
+	}
+	return reasons
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/resolution"
+)
+
+func TestCategorizeCloseReason(t *testing.T) {
+	rules := resolution.DefaultRules()
+	ctx := context.Background()
+
+	tests := []struct {
+		reason, category, want string
+	}{
+		{"can't reproduce", "wontfix", "wontfix: can't reproduce"},
+		{"", "Obsolete", "obsolete"},
+		{"Fixed: typo", "completed", "completed: Fixed: typo"},
+	}
+	for _, tt := range tests {
+		got, target, err := categorizeCloseReason(ctx, rules, tt.reason, tt.category)
+		if err != nil || got != tt.want || target != "" {
+			t.Errorf("categorizeCloseReason(%q, %q) = %q, %q, %v; want %q", tt.reason, tt.category, got, target, err, tt.want)
+		}
+	}
+
+	for _, tt := range []struct{ reason, category string }{
+		{"done", "shipped"},               // Not an allowed category
+		{"duplicate: of bd-1", "wontfix"}, // Reason already has one
+		{"replaced", "superseded-by"},     // No replacing issue
+		{"replaced", "completed:bd-1"},    // Only superseded-by takes an ID
+	} {
+		if _, _, err := categorizeCloseReason(ctx, rules, tt.reason, tt.category); err == nil {
+			t.Errorf("categorizeCloseReason(%q, %q) succeeded, want an error", tt.reason, tt.category)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/beads/internal/resolution"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/writepolicy"
+)
+
+// maxEvidenceFileSize caps an --evidence-file; larger reports belong in CI
+// and can be linked with --evidence-url.
+const maxEvidenceFileSize = 1 << 20
+
+// closeEvidence is the verification evidence given to bd close.
+type closeEvidence struct {
+	urls  []string
+	files []evidenceFile
+}
+
+// evidenceFile is an --evidence-file read from disk.
+type evidenceFile struct {
+	name    string // Base name, stored as the evidence ref
+	content []byte
+}
+
+func (e *closeEvidence) empty() bool {
+	return len(e.urls) == 0 && len(e.files) == 0
+}
+
+// readCloseEvidence validates --evidence-url values and reads
+// --evidence-file paths, before anything is closed.
+func readCloseEvidence(urls, paths []string) (*closeEvidence, error) {
+	e := &closeEvidence{}
+	for _, raw := range urls {
+		u, err := url.Parse(raw)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid --evidence-url %q: need an absolute URL", raw)
+		}
+		e.urls = append(e.urls, raw)
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("--evidence-file: %w", err)
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("--evidence-file %s is a directory", path)
+		}
+		if info.Size() > maxEvidenceFileSize {
+			return nil, fmt.Errorf("--evidence-file %s is %d bytes, over the %d byte limit (link it with --evidence-url)", path, info.Size(), maxEvidenceFileSize)
+		}
+		content, err := os.ReadFile(path) // #nosec G304 -- user-specified evidence file
+		if err != nil {
+			return nil, fmt.Errorf("--evidence-file: %w", err)
+		}
+		e.files = append(e.files, evidenceFile{name: filepath.Base(path), content: content})
+	}
+	return e, nil
+}
+
+// checkCloseEvidence returns an error if issue can't be closed with reason
+// because close.require-evidence-types wants evidence and none was given.
+func checkCloseEvidence(issue *types.Issue, reason string, rules *resolution.Rules, e *closeEvidence) error {
+	if err := writepolicy.CheckCloseEvidence(issue, reason, rules, !e.empty()); err != nil {
+		return fmt.Errorf("%w (use --evidence-url or --evidence-file)", err)
+	}
+	return nil
+}
+
+// evidenceWriter stores evidence. *dolt.DoltStore's transactions implement
+// it, though storage.Transaction doesn't include it.
+type evidenceWriter interface {
+	AddEvidence(ctx context.Context, e *types.Evidence, content []byte) error
+}
+
+// attachCloseEvidence records e on issue id as part of tx.
+func attachCloseEvidence(ctx context.Context, tx storage.Transaction, id string, e *closeEvidence) error {
+	if e.empty() {
+		return nil
+	}
+	w, ok := tx.(evidenceWriter)
+	if !ok {
+		return fmt.Errorf("this database can't store evidence")
+	}
+	for _, u := range e.urls {
+		if err := w.AddEvidence(ctx, &types.Evidence{IssueID: id, Kind: types.EvidenceURL, Ref: u, AddedBy: actor}, nil); err != nil {
+			return fmt.Errorf("attaching evidence to %s: %w", id, err)
+		}
+	}
+	for _, f := range e.files {
+		if err := w.AddEvidence(ctx, &types.Evidence{IssueID: id, Kind: types.EvidenceFile, Ref: f.name, AddedBy: actor}, f.content); err != nil {
+			return fmt.Errorf("attaching evidence to %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// closeWithEvidence closes issue id in s and attaches e in one transaction,
+// so an issue is never left closed without the evidence given for it.
+func closeWithEvidence(ctx context.Context, s *dolt.DoltStore, id, reason, session string, e *closeEvidence) error {
+	if e.empty() {
+		return s.CloseIssue(ctx, id, reason, actor, session)
+	}
+	return s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		if err := tx.CloseIssue(ctx, id, reason, actor, session); err != nil {
+			return err
+		}
+		return attachCloseEvidence(ctx, tx, id, e)
+	})
+}
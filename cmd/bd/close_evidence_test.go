@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestReadCloseEvidence(t *testing.T) {
+	dir := t.TempDir()
+	report := filepath.Join(dir, "junit.xml")
+	if err := os.WriteFile(report, []byte("<testsuite/>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := readCloseEvidence([]string{"https://ci.example.com/runs/1"}, []string{report})
+	if err != nil {
+		t.Fatalf("readCloseEvidence: %v", err)
+	}
+	if e.empty() || len(e.files) != 1 || e.files[0].name != "junit.xml" || string(e.files[0].content) != "<testsuite/>" {
+		t.Errorf("evidence = %+v", e)
+	}
+
+	if _, err := readCloseEvidence([]string{"CI run 1"}, nil); err == nil {
+		t.Error("accepted a relative --evidence-url")
+	}
+	if _, err := readCloseEvidence(nil, []string{dir}); err == nil {
+		t.Error("accepted a directory as --evidence-file")
+	}
+	big := filepath.Join(dir, "big.log")
+	if err := os.WriteFile(big, make([]byte, maxEvidenceFileSize+1), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readCloseEvidence(nil, []string{big}); err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Errorf("oversized file = %v, want a size limit error", err)
+	}
+}
+
+func TestFindEvidenceFile(t *testing.T) {
+	evidence := []*types.Evidence{
+		{ID: 1, Kind: types.EvidenceFile, Ref: "junit.xml"},
+		{ID: 2, Kind: types.EvidenceURL, Ref: "https://ci.example.com/runs/2"},
+		{ID: 3, Kind: types.EvidenceFile, Ref: "junit.xml"},
+	}
+	if e := findEvidenceFile(evidence, "junit.xml"); e == nil || e.ID != 3 {
+		t.Errorf("by name = %+v, want the most recent", e)
+	}
+	if e := findEvidenceFile(evidence, "1"); e == nil || e.ID != 1 {
+		t.Errorf("by ID = %+v", e)
+	}
+	if e := findEvidenceFile(evidence, "2"); e != nil {
+		t.Errorf("URL evidence returned as a file: %+v", e)
+	}
+}
@@ -130,10 +130,19 @@ Examples:
 		}
 		issueID = fullID
 
+		// Check the comment for pasted credentials per scan.mode
+		quarantineLabel := scanOnWrite("comment", map[string]string{"comment": commentText})
+
 		comment, err := store.AddIssueComment(ctx, issueID, author, commentText)
 		if err != nil {
 			FatalErrorRespectJSON("adding comment: %v", err)
 		}
+		if quarantineLabel != "" {
+			if err := store.AddLabel(ctx, issueID, quarantineLabel, author); err != nil {
+				WarnError("failed to add label %s: %v", quarantineLabel, err)
+			}
+		}
+		linkReferences(ctx, store, issueID, commentText)
 
 		if jsonOutput {
 			outputJSON(comment)
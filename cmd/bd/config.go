@@ -43,6 +43,11 @@ Custom Status States:
   This enables issues to use statuses like 'awaiting_review' in addition to
   the built-in statuses (open, in_progress, blocked, deferred, closed).
 
+  status.ready lists the statuses whose unblocked issues bd ready offers
+  (default: open), and status.blocking the statuses in which a blocker holds
+  up its dependents (default: open, in_progress, blocked, deferred, hooked):
+    bd config set status.blocking "open,in_progress,blocked,deferred,hooked,awaiting_review"
+
 Examples:
   bd config set jira.url "https://company.atlassian.net"
   bd config set jira.project "PROJ"
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/acceptance"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/contextpack"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Limits on what a context pack gathers before budgeting.
+const (
+	contextMaxAncestors = 10 // Parent chain depth
+	contextMaxComments  = 5  // Most recent comments on the issue
+	contextMaxJournal   = 20 // Most recent journal entries on the issue
+	contextClosureDays  = 90 // How far back "recent" closures go
+)
+
+var contextCmd = &cobra.Command{
+	Use:     "context <id>",
+	GroupID: "issues",
+	Short:   "Assemble a token-budgeted context pack for prompting",
+	Long: `Assemble an issue and the issues around it into a Markdown context pack
+sized to a token budget, ready to paste into (or pipe to) an LLM prompt.
+
+The pack holds, in priority order:
+
+  1. The issue: description, design, acceptance criteria, notes, its work
+     journal (bd journal), and its most recent comments
+  2. Ancestors: its parent, grandparent, ... nearest first
+  3. Blockers: issues it depends on, open ones first
+  4. Related: other linked issues, in either direction
+  5. Recent closures: issues closed in the last 90 days that share its
+     parent or a label, most recent first
+
+Tokens are estimated at about four characters each. When the pack is over
+budget, it is trimmed by fixed rules, so the same issues always give the
+same pack: the lowest-priority items are first reduced to a one-line
+summary, then dropped (and listed at the end), and finally the issue's own
+text is cut short.
+
+The default budget comes from the context.budget config (8000 tokens).
+
+Examples:
+  bd context bd-42                      # Markdown pack, default budget
+  bd context bd-42 --budget 2000tokens  # Smaller pack
+  bd context bd-42 --budget 16k --json  # Items as JSON`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		budgetFlag, _ := cmd.Flags().GetString("budget")
+		if budgetFlag == "" {
+			budgetFlag = config.GetString("context.budget")
+		}
+		budget, err := contextpack.ParseBudget(budgetFlag)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		items, err := gatherContextItems(ctx, store, id, time.Now())
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		pack := contextpack.Build(items, budget)
+
+		if jsonOutput {
+			outputJSON(pack)
+			return
+		}
+		fmt.Print(pack.Markdown())
+	},
+}
+
+func init() {
+	contextCmd.Flags().String("budget", "", "Token budget, e.g. 8000, 8000tokens, or 8k (default: context.budget config)")
+	rootCmd.AddCommand(contextCmd)
+}
+
+// gatherContextItems loads the issue and the issues around it as context
+// pack items, in priority order.
+func gatherContextItems(ctx context.Context, s *dolt.DoltStore, id string, now time.Time) ([]contextpack.Item, error) {
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.GetIssueComments(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("loading comments: %w", err)
+	}
+	labels, err := s.GetLabels(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("loading labels: %w", err)
+	}
+	journal, err := s.GetJournal(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("loading journal: %w", err)
+	}
+	items := []contextpack.Item{contextItem(contextpack.KindIssue, issue, issueContextBody(issue, labels, journal, comments))}
+	seen := map[string]bool{id: true}
+
+	// Ancestors, nearest first
+	parentID := ""
+	for cur, depth := id, 0; depth < contextMaxAncestors; depth++ {
+		deps, err := s.GetDependenciesWithMetadata(ctx, cur)
+		if err != nil {
+			return nil, fmt.Errorf("loading ancestors: %w", err)
+		}
+		var parent *types.Issue
+		for _, dep := range deps {
+			if dep.DependencyType == types.DepParentChild && !seen[dep.ID] {
+				parent = &dep.Issue
+				break
+			}
+		}
+		if parent == nil {
+			break
+		}
+		if parentID == "" {
+			parentID = parent.ID
+		}
+		seen[parent.ID] = true
+		items = append(items, contextItem(contextpack.KindAncestor, parent, relatedContextBody(parent)))
+		cur = parent.ID
+	}
+
+	deps, err := s.GetDependenciesWithMetadata(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("loading dependencies: %w", err)
+	}
+	dependents, err := s.GetDependentsWithMetadata(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("loading dependents: %w", err)
+	}
+	var blockers, related []*types.Issue
+	for _, dep := range deps {
+		if seen[dep.ID] || dep.DependencyType == types.DepParentChild {
+			continue
+		}
+		seen[dep.ID] = true
+		if dep.DependencyType.AffectsReadyWork() {
+			blockers = append(blockers, &dep.Issue)
+		} else {
+			related = append(related, &dep.Issue)
+		}
+	}
+	for _, dep := range dependents {
+		if seen[dep.ID] || dep.DependencyType == types.DepParentChild {
+			continue
+		}
+		seen[dep.ID] = true
+		related = append(related, &dep.Issue)
+	}
+	sortContextIssues(blockers)
+	sortContextIssues(related)
+	for _, b := range blockers {
+		items = append(items, contextItem(contextpack.KindBlocker, b, relatedContextBody(b)))
+	}
+	for _, r := range related {
+		items = append(items, contextItem(contextpack.KindRelated, r, relatedContextBody(r)))
+	}
+
+	closures, err := recentRelevantClosures(ctx, s, parentID, labels, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range closures {
+		if seen[c.ID] {
+			continue
+		}
+		seen[c.ID] = true
+		items = append(items, contextItem(contextpack.KindClosure, c, closureContextBody(c)))
+	}
+	return items, nil
+}
+
+// recentRelevantClosures returns issues closed in the last
+// contextClosureDays that share the parent or a label, most recent first.
+func recentRelevantClosures(ctx context.Context, s *dolt.DoltStore, parentID string, labels []string, now time.Time) ([]*types.Issue, error) {
+	closed := types.StatusClosed
+	since := now.AddDate(0, 0, -contextClosureDays)
+	var filters []types.IssueFilter
+	if parentID != "" {
+		filters = append(filters, types.IssueFilter{Status: &closed, ClosedAfter: &since, ParentID: &parentID})
+	}
+	if len(labels) > 0 {
+		filters = append(filters, types.IssueFilter{Status: &closed, ClosedAfter: &since, LabelsAny: labels})
+	}
+	byID := map[string]*types.Issue{}
+	for _, filter := range filters {
+		found, err := s.SearchIssues(ctx, "", filter)
+		if err != nil {
+			return nil, fmt.Errorf("loading recent closures: %w", err)
+		}
+		for _, issue := range found {
+			byID[issue.ID] = issue
+		}
+	}
+	closures := make([]*types.Issue, 0, len(byID))
+	for _, issue := range byID {
+		closures = append(closures, issue)
+	}
+	sortClosures(closures)
+	return closures, nil
+}
+
+// sortContextIssues orders blockers and related issues: open before
+// closed, then by priority, then by ID.
+func sortContextIssues(issues []*types.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if ac, bc := a.Status == types.StatusClosed, b.Status == types.StatusClosed; ac != bc {
+			return bc
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.ID < b.ID
+	})
+}
+
+// sortClosures orders closed issues most recently closed first, then by ID.
+func sortClosures(issues []*types.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		var at, bt time.Time
+		if a.ClosedAt != nil {
+			at = *a.ClosedAt
+		}
+		if b.ClosedAt != nil {
+			bt = *b.ClosedAt
+		}
+		if !at.Equal(bt) {
+			return at.After(bt)
+		}
+		return a.ID < b.ID
+	})
+}
+
+// contextItem makes a pack item for an issue.
+func contextItem(kind contextpack.Kind, issue *types.Issue, body string) contextpack.Item {
+	return contextpack.Item{Kind: kind, ID: issue.ID, Title: issue.Title, Meta: contextMeta(issue), Body: body}
+}
+
+// contextMeta is an issue's one-line summary: type, priority, status, and
+// assignee.
+func contextMeta(issue *types.Issue) string {
+	parts := []string{string(issue.IssueType), fmt.Sprintf("P%d", issue.Priority), string(issue.Status)}
+	if issue.Assignee != "" {
+		parts = append(parts, "@"+issue.Assignee)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// issueContextBody is the full text of the issue a pack is for.
+func issueContextBody(issue *types.Issue, labels []string, journal []*types.JournalEntry, comments []*types.Comment) string {
+	var sections []string
+	if len(labels) > 0 {
+		sorted := append([]string(nil), labels...)
+		sort.Strings(sorted)
+		sections = append(sections, "**Labels:** "+strings.Join(sorted, ", "))
+	}
+	sections = appendContextField(sections, "", issue.Description)
+	sections = appendContextField(sections, "Design", issue.Design)
+	acceptanceLabel := "Acceptance criteria"
+	if criteria := acceptance.Parse(issue.AcceptanceCriteria); len(criteria) > 0 {
+		acceptanceLabel += " (" + acceptance.Describe(acceptance.Count(criteria)) + ")"
+	}
+	sections = appendContextField(sections, acceptanceLabel, issue.AcceptanceCriteria)
+	sections = appendContextField(sections, "Notes", issue.Notes)
+	// The journal comes before comments: when the body is cut short to fit
+	// the budget, what was already tried matters more
+	if len(journal) > contextMaxJournal {
+		journal = journal[len(journal)-contextMaxJournal:]
+	}
+	if len(journal) > 0 {
+		var b strings.Builder
+		b.WriteString("**Work journal:**")
+		for _, e := range journal {
+			fmt.Fprintf(&b, "\n- %s %s: %s", e.CreatedAt.UTC().Format("2006-01-02 15:04"), e.Author, strings.TrimSpace(e.Text))
+		}
+		sections = append(sections, b.String())
+	}
+	if len(comments) > contextMaxComments {
+		comments = comments[len(comments)-contextMaxComments:]
+	}
+	if len(comments) > 0 {
+		var b strings.Builder
+		b.WriteString("**Recent comments:**")
+		for _, c := range comments {
+			fmt.Fprintf(&b, "\n- %s (%s): %s", c.Author, c.CreatedAt.UTC().Format("2006-01-02"), strings.TrimSpace(c.Text))
+		}
+		sections = append(sections, b.String())
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// relatedContextBody is the text of an ancestor, blocker, or related
+// issue: its description and design.
+func relatedContextBody(issue *types.Issue) string {
+	var sections []string
+	sections = appendContextField(sections, "", issue.Description)
+	sections = appendContextField(sections, "Design", issue.Design)
+	return strings.Join(sections, "\n\n")
+}
+
+// closureContextBody is the text of a recently closed issue: when and why
+// it was closed, and its description.
+func closureContextBody(issue *types.Issue) string {
+	var sections []string
+	if issue.ClosedAt != nil || issue.CloseReason != "" {
+		line := "**Closed**"
+		if issue.ClosedAt != nil {
+			line += " " + issue.ClosedAt.UTC().Format("2006-01-02")
+		}
+		if reason := strings.TrimSpace(issue.CloseReason); reason != "" {
+			line += ": " + reason
+		}
+		sections = append(sections, line)
+	}
+	sections = appendContextField(sections, "", issue.Description)
+	return strings.Join(sections, "\n\n")
+}
+
+// appendContextField appends a field's text, under a bold label when the
+// label is set, if the text isn't blank.
+func appendContextField(sections []string, label, text string) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return sections
+	}
+	if label != "" {
+		text = "**" + label + ":**\n" + text
+	}
+	return append(sections, text)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSortContextIssues(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-4", Priority: 0, Status: types.StatusClosed},
+		{ID: "bd-3", Priority: 2, Status: types.StatusOpen},
+		{ID: "bd-2", Priority: 1, Status: types.StatusInProgress},
+		{ID: "bd-1", Priority: 2, Status: types.StatusOpen},
+	}
+	sortContextIssues(issues)
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.ID)
+	}
+	if want := "bd-2 bd-1 bd-3 bd-4"; strings.Join(got, " ") != want {
+		t.Errorf("order = %v, want %s", got, want)
+	}
+}
+
+func TestSortClosures(t *testing.T) {
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	later := day.Add(time.Hour)
+	issues := []*types.Issue{
+		{ID: "bd-1", ClosedAt: &day},
+		{ID: "bd-3", ClosedAt: &later},
+		{ID: "bd-2", ClosedAt: &later},
+	}
+	sortClosures(issues)
+	if issues[0].ID != "bd-2" || issues[1].ID != "bd-3" || issues[2].ID != "bd-1" {
+		t.Errorf("order = %s %s %s, want bd-2 bd-3 bd-1", issues[0].ID, issues[1].ID, issues[2].ID)
+	}
+}
+
+func TestIssueContextBody(t *testing.T) {
+	issue := &types.Issue{
+		Description:        "Login drops the session.",
+		AcceptanceCriteria: "- [x] Session survives reload\n- [ ] Logout clears it",
+		Notes:              "Repro on Safari only.",
+	}
+	var comments []*types.Comment
+	for i := 0; i < contextMaxComments+2; i++ {
+		comments = append(comments, &types.Comment{Author: "alice", Text: strings.Repeat("c", i+1)})
+	}
+	journal := []*types.JournalEntry{{
+		Author:    "bob",
+		Text:      "Tried clearing cookies on reload; session still dropped",
+		CreatedAt: time.Date(2026, 10, 2, 9, 30, 0, 0, time.UTC),
+	}}
+	body := issueContextBody(issue, []string{"ui", "auth"}, journal, comments)
+	for _, want := range []string{
+		"**Labels:** auth, ui",
+		"Login drops the session.",
+		"**Acceptance criteria (1/2 pass):**",
+		"**Notes:**\nRepro on Safari only.",
+		"**Work journal:**\n- 2026-10-02 09:30 bob: Tried clearing cookies on reload; session still dropped",
+		"**Recent comments:**",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body missing %q:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "**Design:**") {
+		t.Errorf("empty design rendered:\n%s", body)
+	}
+	if got := strings.Count(body, "\n- alice"); got != contextMaxComments {
+		t.Errorf("%d comments rendered, want the last %d", got, contextMaxComments)
+	}
+}
+
+func TestContextMeta(t *testing.T) {
+	issue := &types.Issue{IssueType: types.TypeBug, Priority: 1, Status: types.StatusInProgress, Assignee: "alice"}
+	if got, want := contextMeta(issue), "bug · P1 · in_progress · @alice"; got != want {
+		t.Errorf("contextMeta = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/secretscan"
+)
+
+// bugReportURL is where users are asked to report crashes.
+const bugReportURL = "https://github.com/steveyegge/beads/issues"
+
+// crashReportsDir returns where crash reports are written: .beads/crashes
+// in a workspace, otherwise the user cache directory.
+func crashReportsDir() string {
+	if beadsDir := beads.FindBeadsDir(); beadsDir != "" {
+		return filepath.Join(beadsDir, "crashes")
+	}
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(cacheDir, "beads", "crashes")
+	}
+	return filepath.Join(os.TempDir(), "beads-crashes")
+}
+
+// formatCrashReport renders a crash report for the panic value r. Secrets
+// in the command line (e.g. a pasted token in --description) are redacted.
+func formatCrashReport(r interface{}, stack []byte, args []string, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "bd crash report\n\n")
+	fmt.Fprintf(&b, "Time:     %s\n", now.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Version:  %s (%s)\n", Version, Build)
+	fmt.Fprintf(&b, "Platform: %s/%s, %s\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
+	fmt.Fprintf(&b, "Command:  %s\n", strings.Join(args, " "))
+	fmt.Fprintf(&b, "\npanic: %v\n\n%s", r, stack)
+	return secretscan.Default().Redact(b.String(), redacted)
+}
+
+// writeCrashReport writes a crash report to dir and returns its path.
+func writeCrashReport(dir, report string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("crash-%s-%d.txt", now.UTC().Format("20060102-150405"), os.Getpid()))
+	if err := os.WriteFile(path, []byte(report), 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// recoverCrash, deferred in main, turns a panic into a crash report and
+// exits with status 2, as an unrecovered panic would.
+func recoverCrash() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	stack := debug.Stack()
+	now := time.Now()
+	fmt.Fprintf(os.Stderr, "\nbd crashed: %v\n\n%s\n", r, stack)
+	path, err := writeCrashReport(crashReportsDir(), formatCrashReport(r, stack, os.Args, now), now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not write a crash report: %v\n", err)
+	} else {
+		fmt.Fprintf(os.Stderr, "A crash report was written to %s\n", path)
+	}
+	fmt.Fprintf(os.Stderr, "Please report this at %s, attaching the output of 'bd doctor bundle'.\n", bugReportURL)
+	os.Exit(exitError)
+}
@@ -17,8 +17,8 @@ import (
 	"github.com/steveyegge/beads/internal/routing"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/dolt"
-	"github.com/steveyegge/beads/internal/timeparsing"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/typeschema"
 	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/validation"
 )
@@ -28,16 +28,33 @@ var createCmd = &cobra.Command{
 	GroupID: "issues",
 	Aliases: []string{"new"},
 	Short:   "Create a new issue (or multiple issues from markdown file)",
-	Args:    cobra.MinimumNArgs(0), // Changed to allow no args when using -f
+	Long: `Create a new issue (or multiple issues from markdown file).
+
+Pass "-" instead of a title to read the issue from a JSON document on stdin.
+Keys are issue JSON field names (title, description, priority, issue_type,
+labels, ...) plus "parent" and "deps"; unknown or read-only keys are reported
+and ignored:
+  echo '{"title":"Fix login","issue_type":"bug","priority":1}' | bd create --json -
+
+--template fills in the flags you don't give from a YAML issue template:
+type, priority, labels, a description skeleton, and more, with {{name}}
+placeholders set by --var name=value. Its children become subtasks of the
+new issue. A bare name is also looked up in .beads/templates/:
+  bd create --template bugreport.yaml --var service=auth "Login times out"`,
+	Args: cobra.MinimumNArgs(0), // Changed to allow no args when using -f
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("create")
 		file, _ := cmd.Flags().GetString("file")
+		templatePath, _ := cmd.Flags().GetString("template")
 
 		// If file flag is provided, parse markdown and create multiple issues
 		if file != "" {
 			if len(args) > 0 {
 				FatalError("cannot specify both title and --file flag")
 			}
+			if templatePath != "" {
+				FatalError("cannot specify both --template and --file flags")
+			}
 			// --dry-run not supported with --file (would need to parse and preview multiple issues)
 			dryRun, _ := cmd.Flags().GetBool("dry-run")
 			if dryRun {
@@ -47,6 +64,28 @@ var createCmd = &cobra.Command{
 			return
 		}
 
+		// "-": read the issue from a JSON document on stdin
+		if rest, ok := splitStdinDocArg(args); ok {
+			if len(rest) > 0 {
+				FatalError("cannot specify a title with an issue document on stdin")
+			}
+			applyStdinIssueDoc(cmd, createDocFields, true)
+			args = rest
+		}
+
+		// --template: fill the flags left unset from an issue template
+		var subtasks []*issueTemplateSubtask
+		if templatePath != "" {
+			subtasks = applyCreateTemplate(cmd, templatePath, args)
+		} else if cmd.Flags().Changed("var") {
+			FatalError("--var requires --template")
+		}
+
+		// --edit: fill in the issue in $EDITOR, then create it as usual
+		if edit, _ := cmd.Flags().GetBool("edit"); edit {
+			args = editCreateFlags(cmd, args)
+		}
+
 		// Original single-issue creation logic
 		// Get title from flag or positional argument
 		titleFlag, _ := cmd.Flags().GetString("title")
@@ -168,9 +207,9 @@ var createCmd = &cobra.Command{
 		var dueAt *time.Time
 		dueStr, _ := cmd.Flags().GetString("due")
 		if dueStr != "" {
-			t, err := timeparsing.ParseRelativeTime(dueStr, time.Now())
+			t, err := parseTimeFlag(dueStr)
 			if err != nil {
-				FatalError("invalid --due format %q. Examples: +6h, tomorrow, next monday, 2025-01-15", dueStr)
+				FatalError("invalid --due format %q. Examples: +6h, +3 business days, tomorrow, 2025-01-15", dueStr)
 			}
 			dueAt = &t
 		}
@@ -179,9 +218,9 @@ var createCmd = &cobra.Command{
 		var deferUntil *time.Time
 		deferStr, _ := cmd.Flags().GetString("defer")
 		if deferStr != "" {
-			t, err := timeparsing.ParseRelativeTime(deferStr, time.Now())
+			t, err := parseDeferFlag(deferStr, silent || debug.IsQuiet())
 			if err != nil {
-				FatalError("invalid --defer format %q. Examples: +1h, tomorrow, next monday, 2025-01-15", deferStr)
+				FatalError("invalid --defer format %q. Examples: +1h, +3 business days, tomorrow, 2025-01-15", deferStr)
 			}
 			// Warn if defer date is in the past (user probably meant future)
 			if t.Before(time.Now()) && !silent && !debug.IsQuiet() {
@@ -192,6 +231,21 @@ var createCmd = &cobra.Command{
 			deferUntil = &t
 		}
 
+		// Type-specific fields, checked against the type's schema (type-schemas config)
+		fieldValues, err := fieldFlagValues(cmd)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		if err := checkTypeFields(types.IssueType(issueType).Normalize(), fieldValues); err != nil {
+			FatalErrorWithHintCode(exitValidation, err.Error(), "set type-specific fields with --field name=value (see 'bd types')")
+		}
+		var fieldsMetadata json.RawMessage
+		if len(fieldValues) > 0 {
+			if fieldsMetadata, err = typeschema.WithFields(nil, fieldValues); err != nil {
+				FatalError("%v", err)
+			}
+		}
+
 		// Handle --dry-run flag (before --rig to ensure it works with cross-rig creation)
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		if dryRun {
@@ -220,6 +274,7 @@ var createCmd = &cobra.Command{
 				Rig:                agentRig,
 				DueAt:              dueAt,
 				DeferUntil:         deferUntil,
+				Metadata:           fieldsMetadata,
 				// Event fields
 				EventKind: eventCategory,
 				Actor:     eventActor,
@@ -265,6 +320,9 @@ var createCmd = &cobra.Command{
 				if eventCategory != "" {
 					fmt.Printf("  Event category: %s\n", eventCategory)
 				}
+				for _, s := range subtasks {
+					fmt.Printf("  Subtask: %s\n", s.Title)
+				}
 			}
 			return
 		}
@@ -286,6 +344,9 @@ var createCmd = &cobra.Command{
 								// Found a matching route - auto-route to that rig
 								rigName := routing.ExtractProjectFromPath(route.Path)
 								if rigName != "" {
+									if len(subtasks) > 0 {
+										FatalError("template subtasks cannot be created in another rig")
+									}
 									createInRig(cmd, rigName, explicitID, title, description, issueType, priority, design, acceptance, notes, assignee, labels, externalRef, specID, wisp)
 									return
 								}
@@ -306,6 +367,9 @@ var createCmd = &cobra.Command{
 			targetRig = prefixOverride
 		}
 		if targetRig != "" {
+			if len(subtasks) > 0 {
+				FatalError("template subtasks cannot be created in another rig")
+			}
 			createInRig(cmd, targetRig, explicitID, title, description, issueType, priority, design, acceptance, notes, assignee, labels, externalRef, specID, wisp)
 			return
 		}
@@ -319,6 +383,14 @@ var createCmd = &cobra.Command{
 			}
 			estimatedMinutes = &est
 		}
+		var actualMinutes *int
+		if cmd.Flags().Changed("actual") {
+			act, _ := cmd.Flags().GetInt("actual")
+			if act < 0 {
+				FatalError("actual must be a non-negative number of minutes")
+			}
+			actualMinutes = &act
+		}
 
 		// Validate template based on --validate flag or config
 		validateTemplate, _ := cmd.Flags().GetBool("validate")
@@ -342,6 +414,14 @@ var createCmd = &cobra.Command{
 			}
 		}
 
+		// Check text for pasted credentials per scan.mode
+		if label := scanOnWrite("create", map[string]string{
+			"title": title, "description": description, "design": design,
+			"acceptance_criteria": acceptance, "notes": notes,
+		}); label != "" {
+			labels = append(labels, label)
+		}
+
 		// Use global jsonOutput set by PersistentPreRun
 
 		// Determine target repository using routing logic
@@ -485,6 +565,7 @@ var createCmd = &cobra.Command{
 			Assignee:           assignee,
 			ExternalRef:        externalRefPtr,
 			EstimatedMinutes:   estimatedMinutes,
+			ActualMinutes:      actualMinutes,
 			Ephemeral:          wisp,
 			CreatedBy:          getActorWithGit(),
 			Owner:              getOwner(),
@@ -497,6 +578,7 @@ var createCmd = &cobra.Command{
 			Payload:            eventPayload,
 			DueAt:              dueAt,
 			DeferUntil:         deferUntil,
+			Metadata:           fieldsMetadata,
 		}
 
 		ctx := rootCtx
@@ -536,28 +618,22 @@ var createCmd = &cobra.Command{
 			// If error getting parent or parent has no source_repo, continue with default
 		}
 
-		if err := store.CreateIssue(ctx, issue, actor); err != nil {
-			FatalError("%v", err)
-		}
+		// Collect the labels and dependencies that go with the new issue up
+		// front, so the whole operation is recorded in the intent log before
+		// anything is written. Dependencies reference the new issue with an
+		// empty ID until it is known.
+		intent := storage.CreateIntent{Title: issue.Title}
 
 		// If parent was specified, add parent-child dependency
 		if parentID != "" {
-			dep := &types.Dependency{
-				IssueID:     issue.ID,
+			intent.Dependencies = append(intent.Dependencies, &types.Dependency{
 				DependsOnID: parentID,
 				Type:        types.DepParentChild,
-			}
-			if err := store.AddDependency(ctx, dep, actor); err != nil {
-				WarnError("failed to add parent-child dependency %s -> %s: %v", issue.ID, parentID, err)
-			}
+			})
 		}
 
 		// Add labels if specified
-		for _, label := range labels {
-			if err := store.AddLabel(ctx, issue.ID, label, actor); err != nil {
-				WarnError("failed to add label %s: %v", label, err)
-			}
-		}
+		intent.Labels = append(intent.Labels, labels...)
 
 		// Auto-add role_type/rig labels for agent beads (enables filtering queries)
 		// Check for gt:agent label to identify agent beads (Gas Town separation)
@@ -570,70 +646,22 @@ var createCmd = &cobra.Command{
 		}
 		if hasAgentLabel {
 			if issue.RoleType != "" {
-				agentLabel := "role_type:" + issue.RoleType
-				if err := store.AddLabel(ctx, issue.ID, agentLabel, actor); err != nil {
-					WarnError("failed to add role_type label: %v", err)
-				}
+				intent.Labels = append(intent.Labels, "role_type:"+issue.RoleType)
 			}
 			if issue.Rig != "" {
-				rigLabel := "rig:" + issue.Rig
-				if err := store.AddLabel(ctx, issue.ID, rigLabel, actor); err != nil {
-					WarnError("failed to add rig label: %v", err)
-				}
+				intent.Labels = append(intent.Labels, "rig:"+issue.Rig)
 			}
 		}
 
 		// Add dependencies if specified (format: type:id or just id for default "blocks" type)
-		for _, depSpec := range deps {
-			// Skip empty specs (e.g., from trailing commas)
-			depSpec = strings.TrimSpace(depSpec)
-			if depSpec == "" {
-				continue
-			}
-
-			var depType types.DependencyType
-			var dependsOnID string
-
-			// Parse format: "type:id" or just "id" (defaults to "blocks")
-			if strings.Contains(depSpec, ":") {
-				parts := strings.SplitN(depSpec, ":", 2)
-				if len(parts) != 2 {
-					WarnError("invalid dependency format '%s', expected 'type:id' or 'id'", depSpec)
-					continue
-				}
-				depType = types.DependencyType(strings.TrimSpace(parts[0]))
-				// "depends-on" is an alias — keep default direction (new issue depends on target)
-				if depType == "depends-on" {
-					depType = types.DepBlocks
-				}
-				dependsOnID = strings.TrimSpace(parts[1])
-			} else {
-				// Default to "blocks" if no type specified
-				depType = types.DepBlocks
-				dependsOnID = depSpec
-			}
+		intent.Dependencies = append(intent.Dependencies, parseCreateDependencies(deps)...)
 
-			// Validate dependency type
-			if !depType.IsValid() {
-				WarnError("invalid dependency type '%s' (valid: blocks, related, parent-child, discovered-from)", depType)
-				continue
-			}
-
-			// Add the dependency
-			dep := &types.Dependency{
-				IssueID:     issue.ID,
-				DependsOnID: dependsOnID,
-				Type:        depType,
-			}
-			// When user explicitly says "blocks:X", they mean "new issue blocks X"
-			// So X depends on the new issue — swap direction
-			if depType == types.DepBlocks && strings.Contains(depSpec, ":") {
-				dep.IssueID = dependsOnID
-				dep.DependsOnID = issue.ID
-			}
-			if err := store.AddDependency(ctx, dep, actor); err != nil {
-				WarnError("failed to add dependency %s -> %s: %v", issue.ID, dependsOnID, err)
-			}
+		// Label routes (bd route) fill in the epic, assignee, and priority
+		if epic := routeNewIssue(ctx, issue, intent.Labels, parentID != ""); epic != "" {
+			intent.Dependencies = append(intent.Dependencies, &types.Dependency{
+				DependsOnID: epic,
+				Type:        types.DepParentChild,
+			})
 		}
 
 		// Add waits-for dependency if specified
@@ -656,17 +684,64 @@ var createCmd = &cobra.Command{
 				FatalError("failed to serialize waits-for metadata: %v", err)
 			}
 
-			dep := &types.Dependency{
-				IssueID:     issue.ID,
+			intent.Dependencies = append(intent.Dependencies, &types.Dependency{
 				DependsOnID: waitsFor,
 				Type:        types.DepWaitsFor,
 				Metadata:    string(metaJSON),
+			})
+		}
+
+		// Best effort: the intent log only adds crash recovery, so failing
+		// to record it must not block issue creation.
+		var intentID int64
+		if id, err := store.BeginIntent(ctx, storage.IntentCreate, issue.ID, intent, actor); err != nil {
+			debug.Logf("warning: failed to record create intent: %v", err)
+		} else {
+			intentID = id
+		}
+
+		if err := store.CreateIssue(ctx, issue, actor); err != nil {
+			if intentID != 0 {
+				_ = store.FinishIntent(ctx, intentID) // Nothing was written; drop the intent
+			}
+			FatalError("%v", err)
+		}
+		if intentID != 0 {
+			_ = store.SetIntentTarget(ctx, intentID, issue.ID) // Best effort: recovery can find the issue by title
+		}
+
+		for _, label := range intent.Labels {
+			if err := store.AddLabel(ctx, issue.ID, label, actor); err != nil {
+				WarnError("failed to add label %s: %v", label, err)
 			}
+		}
+
+		for _, d := range intent.Dependencies {
+			dep := intent.ResolveDependency(d, issue.ID)
 			if err := store.AddDependency(ctx, dep, actor); err != nil {
-				WarnError("failed to add waits-for dependency %s -> %s: %v", issue.ID, waitsFor, err)
+				switch dep.Type {
+				case types.DepParentChild:
+					WarnError("failed to add parent-child dependency %s -> %s: %v", dep.IssueID, dep.DependsOnID, err)
+				case types.DepWaitsFor:
+					WarnError("failed to add waits-for dependency %s -> %s: %v", dep.IssueID, dep.DependsOnID, err)
+				default:
+					WarnError("failed to add dependency %s -> %s: %v", dep.IssueID, dep.DependsOnID, err)
+				}
 			}
 		}
 
+		if intentID != 0 {
+			_ = store.FinishIntent(ctx, intentID) // Best effort: a leftover intent is rolled forward idempotently
+		}
+
+		// Relate the issue to the issues its text mentions
+		linkReferences(ctx, store, issue.ID, issueTexts(issue)...)
+
+		subtaskIDs, err := createTemplateSubtasks(ctx, issue, subtasks)
+		if err != nil {
+			WarnError("failed to create template subtasks: %v", err)
+		}
+
 		// If issue was routed to a different repo, commit+push so other
 		// agents/rigs see the new issue immediately (dolt-native sync).
 		if repoPath != "." && targetStore != nil {
@@ -692,6 +767,9 @@ var createCmd = &cobra.Command{
 			fmt.Printf("  Title: %s\n", issue.Title)
 			fmt.Printf("  Priority: P%d\n", issue.Priority)
 			fmt.Printf("  Status: %s\n", issue.Status)
+			if len(subtaskIDs) > 0 {
+				fmt.Printf("  Subtasks: %s\n", strings.Join(subtaskIDs, ", "))
+			}
 
 			// Show tip after successful create (direct mode only)
 			maybeShowTip(store)
@@ -705,8 +783,11 @@ var createCmd = &cobra.Command{
 func init() {
 	createCmd.Flags().StringP("file", "f", "", "Create multiple issues from markdown file")
 	createCmd.Flags().String("title", "", "Issue title (alternative to positional argument)")
+	createCmd.Flags().String("template", "", "YAML issue template supplying defaults and subtasks (path, or name in .beads/templates/)")
+	createCmd.Flags().StringArray("var", nil, "Template variable as key=value (repeatable; requires --template)")
 	createCmd.Flags().Bool("silent", false, "Output only the issue ID (for scripting)")
 	createCmd.Flags().Bool("dry-run", false, "Preview what would be created without actually creating")
+	createCmd.Flags().Bool("edit", false, "Fill in the issue in $EDITOR (pre-filled from flags and a template for the type)")
 	registerPriorityFlag(createCmd, "2")
 	createCmd.Flags().StringP("type", "t", "task", "Issue type (bug|feature|task|epic|chore|decision); custom types require types.custom config; aliases: enhancement/feat→feature, dec/adr→decision")
 	registerCommonIssueFlags(createCmd)
@@ -724,9 +805,11 @@ func init() {
 	createCmd.Flags().String("rig", "", "Create issue in a different rig (e.g., --rig beads)")
 	createCmd.Flags().String("prefix", "", "Create issue in rig by prefix (e.g., --prefix bd- or --prefix bd or --prefix beads)")
 	createCmd.Flags().IntP("estimate", "e", 0, "Time estimate in minutes (e.g., 60 for 1 hour)")
+	createCmd.Flags().Int("actual", 0, "Time already spent in minutes (see bd time log)")
 	createCmd.Flags().Bool("ephemeral", false, "Create as ephemeral (short-lived, subject to TTL compaction)")
 	createCmd.Flags().String("mol-type", "", "Molecule type: swarm (multi-polecat), patrol (recurring ops), work (default)")
 	createCmd.Flags().String("wisp-type", "", "Wisp type for TTL-based compaction: heartbeat, ping, patrol, gc_report, recovery, error, escalation")
+	createCmd.Flags().StringArray("field", nil, "Type-specific field as name=value (repeatable; see type-schemas config)")
 	createCmd.Flags().Bool("validate", false, "Validate description contains required sections for issue type")
 	// Agent-specific flags (only valid when --type=agent)
 	createCmd.Flags().String("agent-rig", "", "Agent's rig name (requires --type=agent)")
@@ -743,7 +826,7 @@ func init() {
 	//   --due=2025-01-15    Due on specific date
 	//   --defer=+1h         Hidden from bd ready for 1 hour
 	//   --defer=tomorrow    Hidden until tomorrow
-	createCmd.Flags().String("due", "", "Due date/time. Formats: +6h, +1d, +2w, tomorrow, next monday, 2025-01-15")
+	createCmd.Flags().String("due", "", "Due date/time. Formats: +6h, +1d, +2w, +3 business days, tomorrow, next monday, 2025-01-15")
 	createCmd.Flags().String("defer", "", "Defer until date (issue hidden from bd ready until then). Same formats as --due")
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 	rootCmd.AddCommand(createCmd)
@@ -814,7 +897,7 @@ func createInRig(cmd *cobra.Command, rigName, explicitID, title, description, is
 	var dueAt *time.Time
 	dueStr, _ := cmd.Flags().GetString("due")
 	if dueStr != "" {
-		t, err := timeparsing.ParseRelativeTime(dueStr, time.Now())
+		t, err := parseTimeFlag(dueStr)
 		if err != nil {
 			FatalError("invalid --due format %q", dueStr)
 		}
@@ -824,7 +907,7 @@ func createInRig(cmd *cobra.Command, rigName, explicitID, title, description, is
 	var deferUntil *time.Time
 	deferStr, _ := cmd.Flags().GetString("defer")
 	if deferStr != "" {
-		t, err := timeparsing.ParseRelativeTime(deferStr, time.Now())
+		t, err := parseDeferFlag(deferStr, true)
 		if err != nil {
 			FatalError("invalid --defer format %q", deferStr)
 		}
@@ -890,6 +973,65 @@ func createInRig(cmd *cobra.Command, rigName, explicitID, title, description, is
 	}
 }
 
+// parseCreateDependencies parses --deps specs (format: type:id, or just id
+// for the default "blocks" type) into dependencies on the new issue, whose ID
+// is left empty. Invalid specs are reported and skipped.
+func parseCreateDependencies(specs []string) []*types.Dependency {
+	var result []*types.Dependency
+	for _, depSpec := range specs {
+		// Skip empty specs (e.g., from trailing commas)
+		depSpec = strings.TrimSpace(depSpec)
+		if depSpec == "" {
+			continue
+		}
+
+		var depType types.DependencyType
+		var dependsOnID string
+
+		// Parse format: "type:id" or just "id" (defaults to "blocks")
+		if strings.Contains(depSpec, ":") {
+			parts := strings.SplitN(depSpec, ":", 2)
+			if len(parts) != 2 {
+				WarnError("invalid dependency format '%s', expected 'type:id' or 'id'", depSpec)
+				continue
+			}
+			depType = types.DependencyType(strings.TrimSpace(parts[0]))
+			// "depends-on" is an alias — keep default direction (new issue depends on target)
+			if depType == "depends-on" {
+				depType = types.DepBlocks
+			}
+			dependsOnID = strings.TrimSpace(parts[1])
+		} else {
+			// Default to "blocks" if no type specified
+			depType = types.DepBlocks
+			dependsOnID = depSpec
+		}
+		if dependsOnID == "" {
+			WarnError("invalid dependency format '%s', expected 'type:id' or 'id'", depSpec)
+			continue
+		}
+
+		// Validate dependency type
+		if !depType.IsValid() {
+			WarnError("invalid dependency type '%s' (valid: blocks, related, parent-child, discovered-from)", depType)
+			continue
+		}
+
+		dep := &types.Dependency{
+			DependsOnID: dependsOnID,
+			Type:        depType,
+		}
+		// When user explicitly says "blocks:X", they mean "new issue blocks X"
+		// So X depends on the new issue — swap direction
+		if depType == types.DepBlocks && strings.Contains(depSpec, ":") {
+			dep.IssueID = dependsOnID
+			dep.DependsOnID = ""
+		}
+		result = append(result, dep)
+	}
+	return result
+}
+
 // findTownBeadsDir finds the town-level .beads directory (where routes.jsonl lives).
 // It walks up from the current directory looking for a .beads directory with routes.jsonl.
 func findTownBeadsDir() (string, error) {
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// editCreateFlags implements `bd create --edit`: it opens the new issue as an
+// edit document, pre-filled from the flags and a description skeleton for
+// the type, and writes the result back into the flags so the normal create
+// path validates and creates it. Returns the remaining positional args.
+func editCreateFlags(cmd *cobra.Command, args []string) []string {
+	doc := &issueEditDoc{}
+	if len(args) > 0 {
+		doc.Title = args[0]
+	} else {
+		doc.Title, _ = cmd.Flags().GetString("title")
+	}
+	issueType, _ := cmd.Flags().GetString("type")
+	doc.Type = utils.NormalizeIssueType(issueType)
+	doc.Priority, _ = cmd.Flags().GetString("priority")
+	doc.Assignee, _ = cmd.Flags().GetString("assignee")
+	labels, _ := cmd.Flags().GetStringSlice("labels")
+	labelAlias, _ := cmd.Flags().GetStringSlice("label")
+	doc.Labels = append(labels, labelAlias...)
+	if cmd.Flags().Changed("estimate") {
+		est, _ := cmd.Flags().GetInt("estimate")
+		doc.Estimate = &est
+	}
+	doc.ExternalRef, _ = cmd.Flags().GetString("external-ref")
+	doc.Description, _ = getDescriptionFlag(cmd)
+	if doc.Description == "" {
+		doc.Description = descriptionSkeleton(types.IssueType(doc.Type))
+	}
+	doc.Design, _ = cmd.Flags().GetString("design")
+	doc.AcceptanceCriteria, _ = cmd.Flags().GetString("acceptance")
+	doc.Notes, _ = cmd.Flags().GetString("notes")
+
+	rules := loadEditDocRules(rootCtx, true)
+	header := rules.editDocHeader("New issue. Save and quit to create it; delete everything to cancel.")
+	edited, err := editDocInEditor(doc, header, rules)
+	if err != nil {
+		if errors.Is(err, errEditCancelled) {
+			fmt.Println("Create cancelled")
+			os.Exit(0)
+		}
+		FatalError("%v", err)
+	}
+	edited.Description = stripSkeletonHints(edited.Description, types.IssueType(doc.Type), types.IssueType(edited.Type))
+
+	// The description now comes from the document alone.
+	for _, name := range []string{"body", "message", "body-file", "description-file"} {
+		if f := cmd.Flags().Lookup(name); f != nil {
+			f.Changed = false
+		}
+	}
+	set := func(name, value string) {
+		if err := cmd.Flags().Set(name, value); err != nil {
+			FatalError("applying %s: %v", name, err)
+		}
+	}
+	set("title", edited.Title)
+	set("type", edited.Type)
+	set("priority", edited.Priority)
+	set("assignee", edited.Assignee)
+	set("external-ref", edited.ExternalRef)
+	set("description", edited.Description)
+	set("design", edited.Design)
+	set("acceptance", edited.AcceptanceCriteria)
+	set("notes", edited.Notes)
+	if edited.Estimate != nil {
+		set("estimate", strconv.Itoa(*edited.Estimate))
+	} else if f := cmd.Flags().Lookup("estimate"); f != nil {
+		f.Changed = false
+	}
+	for name, value := range map[string][]string{"labels": edited.Labels, "label": nil} {
+		if sv, ok := cmd.Flags().Lookup(name).Value.(interface{ Replace([]string) error }); ok {
+			if err := sv.Replace(value); err != nil {
+				FatalError("applying %s: %v", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// stripSkeletonHints removes the hint comments descriptionSkeleton added for
+// any of the given types, leaving the section headings.
+func stripSkeletonHints(description string, issueTypes ...types.IssueType) string {
+	for _, t := range issueTypes {
+		for _, section := range t.RequiredSections() {
+			description = strings.ReplaceAll(description, "<!-- "+section.Hint+" -->\n", "")
+		}
+	}
+	return strings.TrimRight(description, "\n")
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// An issue template gives bd create --template defaults for a kind of issue,
+// with {{variable}} placeholders filled in from --var:
+//
+//	type: bug
+//	priority: 1
+//	labels: [bug, "service:{{service}}"]
+//	title: "{{service}}: "
+//	description: |
+//	  ## Steps to reproduce
+//	  ## Expected
+//	  ## Actual ({{service}} {{version}})
+//	vars:
+//	  service:           # Required: no default
+//	  version: latest
+//	children:
+//	  - title: Reproduce in {{service}}
+//	  - title: Add a regression test
+//	    type: chore
+//
+// Flags given on the command line win over the template; labels are merged.
+
+// issueTemplateDoc is a parsed issue template.
+type issueTemplateDoc struct {
+	Title              string                  `yaml:"title"`
+	Type               string                  `yaml:"type"`
+	Priority           string                  `yaml:"priority"`
+	Assignee           string                  `yaml:"assignee"`
+	Labels             []string                `yaml:"labels"`
+	Estimate           *int                    `yaml:"estimate"`
+	Description        string                  `yaml:"description"`
+	Design             string                  `yaml:"design"`
+	AcceptanceCriteria string                  `yaml:"acceptance_criteria"`
+	Notes              string                  `yaml:"notes"`
+	Vars               map[string]*string      `yaml:"vars"` // Name → default; nil means required
+	Children           []*issueTemplateSubtask `yaml:"children"`
+}
+
+// issueTemplateSubtask is a child issue created under the templated issue.
+type issueTemplateSubtask struct {
+	Title              string   `yaml:"title"`
+	Type               string   `yaml:"type"`
+	Priority           string   `yaml:"priority"` // Default: the parent's
+	Assignee           string   `yaml:"assignee"`
+	Labels             []string `yaml:"labels"`
+	Estimate           *int     `yaml:"estimate"`
+	Description        string   `yaml:"description"`
+	AcceptanceCriteria string   `yaml:"acceptance_criteria"`
+}
+
+// parseIssueTemplate parses an issue template, rejecting unknown keys.
+func parseIssueTemplate(data []byte) (*issueTemplateDoc, error) {
+	var t issueTemplateDoc
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&t); err != nil {
+		return nil, fmt.Errorf("invalid issue template: %w", err)
+	}
+	for i, child := range t.Children {
+		if child == nil || strings.TrimSpace(child.Title) == "" {
+			return nil, fmt.Errorf("invalid issue template: children[%d] needs a title", i)
+		}
+	}
+	return &t, nil
+}
+
+// readIssueTemplate reads an issue template from path, or failing that from
+// .beads/templates/, so shared templates can be named without a path.
+func readIssueTemplate(path string) ([]byte, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- user-chosen template file
+	if err == nil || !os.IsNotExist(err) || strings.ContainsRune(path, os.PathSeparator) {
+		return data, err
+	}
+	if beadsDir := beads.FindBeadsDir(); beadsDir != "" {
+		if shared, sharedErr := os.ReadFile(filepath.Join(beadsDir, "templates", path)); sharedErr == nil { // #nosec G304
+			return shared, nil
+		}
+	}
+	return nil, err
+}
+
+// resolveIssueTemplate fills in a template's {{variable}} placeholders from
+// vars and the template's defaults, failing if any used variable has no
+// value. User-supplied values are inserted literally.
+func resolveIssueTemplate(t *issueTemplateDoc, vars map[string]string) (*issueTemplateDoc, error) {
+	values := make(map[string]string, len(t.Vars)+len(vars))
+	for name, def := range t.Vars {
+		if def != nil {
+			values[name] = *def
+		}
+	}
+	for name, value := range vars {
+		values[name] = value
+	}
+
+	texts := []string{t.Title, t.Assignee, t.Description, t.Design, t.AcceptanceCriteria, t.Notes}
+	texts = append(texts, t.Labels...)
+	for _, c := range t.Children {
+		texts = append(texts, c.Title, c.Assignee, c.Description, c.AcceptanceCriteria)
+		texts = append(texts, c.Labels...)
+	}
+	for name, def := range t.Vars {
+		if def == nil {
+			texts = append(texts, "{{"+name+"}}") // Declared required, even if unused
+		}
+	}
+	var missing []string
+	for _, name := range extractVariables(strings.Join(texts, "\n")) {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("template needs --var for: %s", strings.Join(missing, ", "))
+	}
+
+	sub := func(s string) string { return substituteVariables(s, values) }
+	subAll := func(list []string) []string {
+		out := make([]string, 0, len(list))
+		for _, s := range list {
+			if s = strings.TrimSpace(sub(s)); s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	r := *t
+	r.Title, r.Assignee = strings.TrimSpace(sub(t.Title)), sub(t.Assignee)
+	r.Description, r.Design = sub(t.Description), sub(t.Design)
+	r.AcceptanceCriteria, r.Notes = sub(t.AcceptanceCriteria), sub(t.Notes)
+	r.Labels = subAll(t.Labels)
+	r.Children = make([]*issueTemplateSubtask, len(t.Children))
+	for i, c := range t.Children {
+		rc := *c
+		rc.Title, rc.Assignee = strings.TrimSpace(sub(c.Title)), sub(c.Assignee)
+		rc.Description, rc.AcceptanceCriteria = sub(c.Description), sub(c.AcceptanceCriteria)
+		rc.Labels = subAll(c.Labels)
+		r.Children[i] = &rc
+	}
+	return &r, nil
+}
+
+// parseVarFlags parses --var key=value flags.
+func parseVarFlags(flags []string) (map[string]string, error) {
+	vars := make(map[string]string, len(flags))
+	for _, v := range flags {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid variable format '%s', expected 'key=value'", v)
+		}
+		vars[strings.TrimSpace(key)] = value
+	}
+	return vars, nil
+}
+
+// applyCreateTemplate implements bd create --template: it resolves the
+// template and writes its values into the flags the user didn't set, so
+// the normal create path validates and creates the issue. Returns the
+// template's subtasks, to create once the issue exists.
+func applyCreateTemplate(cmd *cobra.Command, path string, args []string) []*issueTemplateSubtask {
+	data, err := readIssueTemplate(path)
+	if err != nil {
+		FatalErrorCode(exitValidation, "reading template: %v", err)
+	}
+	tmpl, err := parseIssueTemplate(data)
+	if err != nil {
+		FatalErrorCode(exitValidation, "%s: %v", path, err)
+	}
+	varFlags, _ := cmd.Flags().GetStringArray("var")
+	vars, err := parseVarFlags(varFlags)
+	if err != nil {
+		FatalErrorCode(exitValidation, "%v", err)
+	}
+	tmpl, err = resolveIssueTemplate(tmpl, vars)
+	if err != nil {
+		FatalErrorCode(exitValidation, "%s: %v", path, err)
+	}
+
+	flags := cmd.Flags()
+	setDefault := func(name, value string) {
+		if value == "" || flags.Changed(name) {
+			return
+		}
+		if err := flags.Set(name, value); err != nil {
+			FatalErrorCode(exitValidation, "%s: applying %s: %v", path, name, err)
+		}
+	}
+	if len(args) == 0 && !flags.Changed("title") {
+		setDefault("title", tmpl.Title)
+	}
+	setDefault("type", tmpl.Type)
+	setDefault("priority", tmpl.Priority)
+	setDefault("assignee", tmpl.Assignee)
+	if tmpl.Estimate != nil {
+		setDefault("estimate", strconv.Itoa(*tmpl.Estimate))
+	}
+	descriptionGiven := false
+	for _, name := range []string{"description", "body", "message", "body-file", "description-file"} {
+		if f := flags.Lookup(name); f != nil && f.Changed {
+			descriptionGiven = true
+		}
+	}
+	if !descriptionGiven {
+		setDefault("description", strings.TrimRight(tmpl.Description, "\n"))
+	}
+	setDefault("design", strings.TrimRight(tmpl.Design, "\n"))
+	setDefault("acceptance", strings.TrimRight(tmpl.AcceptanceCriteria, "\n"))
+	setDefault("notes", strings.TrimRight(tmpl.Notes, "\n"))
+	if len(tmpl.Labels) > 0 {
+		labels, _ := flags.GetStringSlice("labels")
+		if sv, ok := flags.Lookup("labels").Value.(interface{ Replace([]string) error }); ok {
+			if err := sv.Replace(append(tmpl.Labels, labels...)); err != nil {
+				FatalErrorCode(exitValidation, "%s: applying labels: %v", path, err)
+			}
+		}
+	}
+	return tmpl.Children
+}
+
+// createTemplateSubtasks creates a template's subtasks as children of
+// parent, returning their IDs.
+func createTemplateSubtasks(ctx context.Context, parent *types.Issue, subtasks []*issueTemplateSubtask) ([]string, error) {
+	var ids []string
+	for _, s := range subtasks {
+		priority := parent.Priority
+		if s.Priority != "" {
+			p, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(s.Priority), "P"))
+			if err != nil || p < 0 || p > 4 {
+				return ids, fmt.Errorf("subtask %q: invalid priority %q", s.Title, s.Priority)
+			}
+			priority = p
+		}
+		issueType := types.TypeTask
+		if s.Type != "" {
+			issueType = types.IssueType(s.Type).Normalize()
+		}
+		childID, err := store.GetNextChildID(ctx, parent.ID)
+		if err != nil {
+			return ids, err
+		}
+		child := &types.Issue{
+			ID:                 childID,
+			Title:              s.Title,
+			Description:        strings.TrimRight(s.Description, "\n"),
+			AcceptanceCriteria: strings.TrimRight(s.AcceptanceCriteria, "\n"),
+			Status:             types.StatusOpen,
+			Priority:           priority,
+			IssueType:          issueType,
+			Assignee:           s.Assignee,
+			EstimatedMinutes:   s.Estimate,
+			CreatedBy:          parent.CreatedBy,
+			Owner:              parent.Owner,
+		}
+		if err := store.CreateIssue(ctx, child, actor); err != nil {
+			return ids, fmt.Errorf("subtask %q: %w", s.Title, err)
+		}
+		ids = append(ids, child.ID)
+		dep := &types.Dependency{IssueID: child.ID, DependsOnID: parent.ID, Type: types.DepParentChild}
+		if err := store.AddDependency(ctx, dep, actor); err != nil {
+			return ids, fmt.Errorf("linking %s under %s: %w", child.ID, parent.ID, err)
+		}
+		for _, label := range s.Labels {
+			if err := store.AddLabel(ctx, child.ID, label, actor); err != nil {
+				WarnError("failed to add label %s to %s: %v", label, child.ID, err)
+			}
+		}
+	}
+	return ids, nil
+}
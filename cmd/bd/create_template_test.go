@@ -0,0 +1,84 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const bugreportTemplate = `
+type: bug
+priority: P1
+labels: [bug, "service:{{service}}"]
+title: "{{service}}: "
+description: |
+  ## Actual ({{service}} {{version}})
+vars:
+  service:
+  version: latest
+children:
+  - title: Reproduce in {{service}}
+  - title: Add a regression test
+    type: chore
+`
+
+func TestResolveIssueTemplate(t *testing.T) {
+	tmpl, err := parseIssueTemplate([]byte(bugreportTemplate))
+	if err != nil {
+		t.Fatalf("parseIssueTemplate: %v", err)
+	}
+	r, err := resolveIssueTemplate(tmpl, map[string]string{"service": "auth"})
+	if err != nil {
+		t.Fatalf("resolveIssueTemplate: %v", err)
+	}
+	if r.Title != "auth:" || r.Type != "bug" || r.Priority != "P1" || r.Description != "## Actual (auth latest)\n" {
+		t.Errorf("resolved template = %+v", r)
+	}
+	if !reflect.DeepEqual(r.Labels, []string{"bug", "service:auth"}) {
+		t.Errorf("labels = %v", r.Labels)
+	}
+	if len(r.Children) != 2 || r.Children[0].Title != "Reproduce in auth" || r.Children[1].Type != "chore" {
+		t.Errorf("children = %+v, %+v", r.Children[0], r.Children[1])
+	}
+	if tmpl.Children[0].Title != "Reproduce in {{service}}" {
+		t.Error("resolveIssueTemplate modified the parsed template")
+	}
+
+	// Values from --var are inserted literally, and override defaults
+	r, err = resolveIssueTemplate(tmpl, map[string]string{"service": "{{version}}", "version": "2.1"})
+	if err != nil || r.Children[0].Title != "Reproduce in {{version}}" || r.Description != "## Actual ({{version}} 2.1)\n" {
+		t.Errorf("resolveIssueTemplate with literal braces = %+v, %v", r, err)
+	}
+
+	if _, err := resolveIssueTemplate(tmpl, nil); err == nil || !strings.Contains(err.Error(), "service") {
+		t.Errorf("resolveIssueTemplate without a required var = %v", err)
+	}
+	undeclared, _ := parseIssueTemplate([]byte("description: \"{{owner}} and {{team}}\"\n"))
+	if _, err := resolveIssueTemplate(undeclared, nil); err == nil || !strings.Contains(err.Error(), "owner, team") {
+		t.Errorf("resolveIssueTemplate with undeclared vars = %v", err)
+	}
+}
+
+func TestParseIssueTemplateRejects(t *testing.T) {
+	for _, bad := range []string{
+		"titel: typo\n",
+		"children:\n  - type: task\n",
+		"children:\n  - title: x\n    children: []\n",
+	} {
+		if _, err := parseIssueTemplate([]byte(bad)); err == nil {
+			t.Errorf("parseIssueTemplate(%q) accepted", bad)
+		}
+	}
+}
+
+func TestParseVarFlags(t *testing.T) {
+	vars, err := parseVarFlags([]string{"service=auth", "query=a=b", "empty="})
+	if err != nil || !reflect.DeepEqual(vars, map[string]string{"service": "auth", "query": "a=b", "empty": ""}) {
+		t.Errorf("parseVarFlags = %v, %v", vars, err)
+	}
+	for _, bad := range []string{"service", "=auth"} {
+		if _, err := parseVarFlags([]string{bad}); err == nil {
+			t.Errorf("parseVarFlags(%q) accepted", bad)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/acceptance"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/writepolicy"
+)
+
+var criteriaCmd = &cobra.Command{
+	Use:     "criteria <id>",
+	Aliases: []string{"ac"},
+	GroupID: "issues",
+	Short:   "Show and verify an issue's acceptance criteria",
+	Long: `Acceptance criteria are kept in the issue's acceptance criteria field as a
+markdown checklist, where each criterion is unverified, passing, or failing:
+  - [ ] Unverified
+  - [x] Pass
+  - [!] Fail
+
+Criteria written as plain lines count as unverified. Criteria are numbered
+from 1 in the order they appear.
+
+Set validation.acceptance-on-close to "error" to refuse closing issues
+whose criteria don't all pass (or "warn" to only warn); --force overrides.
+
+Commands:
+  bd criteria <id>                 List the criteria and their states
+  bd criteria add <id> <text>...   Add unverified criteria
+  bd criteria pass <id> <n|all>... Mark criteria as passing
+  bd criteria fail <id> <n|all>... Mark criteria as failing
+  bd criteria reset <id> <n|all>.. Mark criteria as unverified
+  bd criteria remove <id> <n>      Remove a criterion`,
+	Args: cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		issue := resolveCriteriaIssue(args[0])
+		outputCriteria(issue)
+	},
+}
+
+var criteriaAddCmd = &cobra.Command{
+	Use:   "add <id> <text>...",
+	Short: "Add unverified acceptance criteria",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(_ *cobra.Command, args []string) {
+		CheckReadonly("criteria add")
+		issue := resolveCriteriaIssue(args[0])
+		text := issue.AcceptanceCriteria
+		for _, item := range args[1:] {
+			if strings.TrimSpace(item) == "" {
+				FatalErrorRespectJSON("criterion text is empty")
+			}
+			text = acceptance.Add(text, item)
+		}
+		saveCriteria(issue, text)
+	},
+}
+
+var criteriaRemoveCmd = &cobra.Command{
+	Use:   "remove <id> <n>",
+	Short: "Remove an acceptance criterion",
+	Args:  cobra.ExactArgs(2),
+	Run: func(_ *cobra.Command, args []string) {
+		CheckReadonly("criteria remove")
+		issue := resolveCriteriaIssue(args[0])
+		n, err := strconv.Atoi(args[1])
+		if err != nil {
+			FatalErrorRespectJSON("invalid criterion number %q", args[1])
+		}
+		text, err := acceptance.Remove(issue.AcceptanceCriteria, n-1)
+		if err != nil {
+			FatalErrorRespectJSON("%s: %v", issue.ID, err)
+		}
+		saveCriteria(issue, text)
+	},
+}
+
+// newCriteriaStateCmd returns the subcommand that sets criteria to state.
+func newCriteriaStateCmd(name string, state acceptance.State, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:   name + " <id> <n|all>...",
+		Short: short,
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(_ *cobra.Command, args []string) {
+			CheckReadonly("criteria " + name)
+			issue := resolveCriteriaIssue(args[0])
+			indexes, err := parseCriteriaIndexes(args[1:], len(acceptance.Parse(issue.AcceptanceCriteria)))
+			if err != nil {
+				FatalErrorRespectJSON("%s: %v", issue.ID, err)
+			}
+			text := issue.AcceptanceCriteria
+			for _, i := range indexes {
+				if text, err = acceptance.SetState(text, i, state); err != nil {
+					FatalErrorRespectJSON("%s: %v", issue.ID, err)
+				}
+			}
+			saveCriteria(issue, text)
+		},
+	}
+}
+
+func init() {
+	criteriaCmd.AddCommand(criteriaAddCmd)
+	criteriaCmd.AddCommand(newCriteriaStateCmd("pass", acceptance.Pass, "Mark acceptance criteria as passing"))
+	criteriaCmd.AddCommand(newCriteriaStateCmd("fail", acceptance.Fail, "Mark acceptance criteria as failing"))
+	criteriaCmd.AddCommand(newCriteriaStateCmd("reset", acceptance.Unverified, "Mark acceptance criteria as unverified"))
+	criteriaCmd.AddCommand(criteriaRemoveCmd)
+	rootCmd.AddCommand(criteriaCmd)
+}
+
+// parseCriteriaIndexes parses 1-based criterion numbers (or "all") into
+// 0-based indexes into count criteria.
+func parseCriteriaIndexes(args []string, count int) ([]int, error) {
+	var indexes []int
+	for _, arg := range args {
+		if arg == "all" {
+			indexes = indexes[:0]
+			for i := 0; i < count; i++ {
+				indexes = append(indexes, i)
+			}
+			return indexes, nil
+		}
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid criterion number %q", arg)
+		}
+		if n < 1 || n > count {
+			return nil, fmt.Errorf("no criterion %d (there are %d)", n, count)
+		}
+		indexes = append(indexes, n-1)
+	}
+	return indexes, nil
+}
+
+// resolveCriteriaIssue loads the issue a criteria command targets.
+func resolveCriteriaIssue(arg string) *types.Issue {
+	ctx := rootCtx
+	id, err := utils.ResolvePartialID(ctx, store, arg)
+	if err != nil {
+		FatalErrorRespectJSON("resolving %s: %v", arg, err)
+	}
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	return issue
+}
+
+// saveCriteria writes updated criteria text and prints the result.
+func saveCriteria(issue *types.Issue, text string) {
+	if err := store.UpdateIssue(rootCtx, issue.ID, map[string]interface{}{"acceptance_criteria": text}, actor); err != nil {
+		FatalErrorRespectJSON("updating %s: %v", issue.ID, err)
+	}
+	SetLastTouchedID(issue.ID)
+	issue.AcceptanceCriteria = text
+	outputCriteria(issue)
+}
+
+// criteriaResult is the --json output of bd criteria.
+type criteriaResult struct {
+	IssueID    string                 `json:"issue_id"`
+	Criteria   []acceptance.Criterion `json:"criteria"`
+	Pass       int                    `json:"pass"`
+	Fail       int                    `json:"fail"`
+	Unverified int                    `json:"unverified"`
+}
+
+// outputCriteria prints an issue's criteria.
+func outputCriteria(issue *types.Issue) {
+	criteria := acceptance.Parse(issue.AcceptanceCriteria)
+	pass, fail, unverified := acceptance.Count(criteria)
+	if jsonOutput {
+		outputJSON(criteriaResult{IssueID: issue.ID, Criteria: criteria, Pass: pass, Fail: fail, Unverified: unverified})
+		return
+	}
+	if len(criteria) == 0 {
+		fmt.Printf("%s has no acceptance criteria (add some with 'bd criteria add %s <text>')\n", issue.ID, issue.ID)
+		return
+	}
+	fmt.Printf("%s %s %s\n", ui.RenderID(issue.ID), issue.Title, ui.RenderMuted("("+acceptance.Describe(pass, fail, unverified)+")"))
+	for i, c := range criteria {
+		fmt.Printf("  %2d. %s %s\n", i+1, renderCriterionState(c.State), c.Text)
+	}
+}
+
+// renderCriterionState renders a criterion's state as a symbol.
+func renderCriterionState(state acceptance.State) string {
+	switch state {
+	case acceptance.Pass:
+		return ui.RenderPass("✓")
+	case acceptance.Fail:
+		return ui.RenderFail("✗")
+	default:
+		return ui.RenderMuted("○")
+	}
+}
+
+// acceptanceHeading is the bd show heading for an issue's acceptance
+// criteria, with a summary of their states.
+func acceptanceHeading(issue *types.Issue) string {
+	heading := ui.RenderBold("ACCEPTANCE CRITERIA")
+	criteria := acceptance.Parse(issue.AcceptanceCriteria)
+	if len(criteria) == 0 {
+		return heading
+	}
+	return heading + " " + ui.RenderMuted("("+acceptance.Describe(acceptance.Count(criteria))+")")
+}
+
+// checkAcceptanceOnClose applies validation.acceptance-on-close to an
+// issue about to be closed: "error" refuses issues whose criteria don't all
+// pass, "warn" warns about them, and anything else ("none") allows them.
+func checkAcceptanceOnClose(issue *types.Issue) error {
+	warning, err := writepolicy.CheckAcceptanceOnClose(issue)
+	if warning != "" {
+		WarnError("%s: %s", issue.ID, warning)
+	}
+	if err != nil {
+		return fmt.Errorf("%w; verify them with 'bd criteria pass %s <n>' (use --force to override)", err, issue.ID)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCriteriaIndexes(t *testing.T) {
+	got, err := parseCriteriaIndexes([]string{"3", "1"}, 3)
+	if err != nil || !reflect.DeepEqual(got, []int{2, 0}) {
+		t.Errorf("parseCriteriaIndexes(3, 1) = %v, %v", got, err)
+	}
+	got, err = parseCriteriaIndexes([]string{"1", "all"}, 3)
+	if err != nil || !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("parseCriteriaIndexes(all) = %v, %v", got, err)
+	}
+	for _, bad := range [][]string{{"0"}, {"4"}, {"one"}} {
+		if _, err := parseCriteriaIndexes(bad, 3); err == nil {
+			t.Errorf("parseCriteriaIndexes(%v) succeeded", bad)
+		}
+	}
+}
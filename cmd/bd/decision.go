@@ -0,0 +1,579 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/git"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/typeschema"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Decision records are decision issues linked (relates-to) to the issues
+// and epics they decide. Their ADR number and status are type fields, so
+// they show in bd show and survive export; a decision is open while it is
+// proposed and closed once decided.
+const (
+	decisionNumberField = "adr-number"
+	decisionStatusField = "adr-status"
+)
+
+// decisionStatuses are the ADR statuses, in lifecycle order.
+var decisionStatuses = []string{"proposed", "accepted", "rejected", "deprecated", "superseded"}
+
+var decisionCmd = &cobra.Command{
+	Use:     "decision",
+	GroupID: "issues",
+	Short:   "Record architecture decisions linked to issues",
+	Long: `Decision records are lightweight ADRs (architecture decision records) kept
+next to the work they explain: each is a numbered decision issue linked to
+the issues and epics it decides, so the "why" stays findable from them.
+
+A decision's status is one of proposed, accepted, rejected, deprecated, or
+superseded. Proposed decisions stay open; decided ones are closed, so they
+don't show up as work. Recording a decision with --supersedes marks the
+older one superseded.
+
+'bd decision export' writes the records as Markdown files
+(docs/adr/0001-use-dolt.md, ...) for reading alongside the code.
+
+Commands:
+  bd decision record --title "..." [--issue <id>]... [--status accepted] [sections]
+  bd decision status <decision-id> <status>
+  bd decision list [--issue <id>] [--status <status>]
+  bd decision export [--dir docs/adr] [--dry-run]
+
+Examples:
+  bd decision record --issue bd-42 --title "Store blobs content-addressed" \
+    --context "Descriptions over 1MB slow down sync" \
+    --decision "Keep large text in a blobs table keyed by SHA-256" \
+    --consequences "Dedup across issues; blobs need GC"
+  bd decision list --issue bd-42
+  bd decision export`,
+}
+
+var decisionRecordCmd = &cobra.Command{
+	Use:   "record",
+	Short: "Record a decision and link it to issues",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		CheckReadonly("decision record")
+		ctx := rootCtx
+		title, _ := cmd.Flags().GetString("title")
+		status, _ := cmd.Flags().GetString("status")
+		issueArgs, _ := cmd.Flags().GetStringSlice("issue")
+		supersedesArg, _ := cmd.Flags().GetString("supersedes")
+		if strings.TrimSpace(title) == "" {
+			FatalErrorRespectJSON("--title is required")
+		}
+		status = strings.ToLower(strings.TrimSpace(status))
+		if !slices.Contains(decisionStatuses, status) {
+			FatalErrorRespectJSON("invalid status %q (valid: %s)", status, strings.Join(decisionStatuses, ", "))
+		}
+
+		var issueIDs []string
+		for _, arg := range issueArgs {
+			id, err := utils.ResolvePartialID(ctx, store, arg)
+			if err != nil {
+				FatalErrorRespectJSON("resolving %s: %v", arg, err)
+			}
+			issueIDs = append(issueIDs, id)
+		}
+		supersedes := ""
+		if supersedesArg != "" {
+			id, err := utils.ResolvePartialID(ctx, store, supersedesArg)
+			if err != nil {
+				FatalErrorRespectJSON("resolving %s: %v", supersedesArg, err)
+			}
+			old, err := store.GetIssue(ctx, id)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			if old.IssueType != types.TypeDecision {
+				FatalErrorRespectJSON("%s is not a decision", id)
+			}
+			supersedes = id
+		}
+
+		decisions, err := searchDecisions(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		sections := make(map[string]string)
+		for _, s := range decisionSections {
+			sections[s.flag], _ = cmd.Flags().GetString(s.flag)
+		}
+		metadata, err := typeschema.WithFields(nil, map[string]string{
+			decisionNumberField: strconv.Itoa(nextDecisionNumber(decisions)),
+			decisionStatusField: status,
+		})
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		decision := &types.Issue{
+			Title:       strings.TrimSpace(title),
+			Description: decisionDescription(sections),
+			Status:      types.StatusOpen,
+			Priority:    2,
+			IssueType:   types.TypeDecision,
+			CreatedBy:   getActorWithGit(),
+			Metadata:    metadata,
+		}
+		if err := store.CreateIssue(ctx, decision, actor); err != nil {
+			FatalErrorRespectJSON("creating decision: %v", err)
+		}
+		for _, id := range issueIDs {
+			if err := store.AddDependency(ctx, &types.Dependency{IssueID: decision.ID, DependsOnID: id, Type: types.DepRelatesTo}, actor); err != nil {
+				FatalErrorRespectJSON("linking %s to %s: %v", decision.ID, id, err)
+			}
+		}
+		if status != "proposed" {
+			if err := store.CloseIssue(ctx, decision.ID, "Decision "+status, actor, ""); err != nil {
+				FatalErrorRespectJSON("closing %s: %v", decision.ID, err)
+			}
+		}
+		if supersedes != "" {
+			if err := store.AddDependency(ctx, &types.Dependency{IssueID: decision.ID, DependsOnID: supersedes, Type: types.DepSupersedes}, actor); err != nil {
+				FatalErrorRespectJSON("linking %s to %s: %v", decision.ID, supersedes, err)
+			}
+			if err := setDecisionStatus(ctx, supersedes, "superseded"); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+		}
+		SetLastTouchedID(decision.ID)
+
+		record, err := loadDecisionRecord(ctx, decision.ID)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(record)
+			return
+		}
+		fmt.Printf("%s Recorded ADR %s: %s (%s)\n", ui.RenderPass("✓"), formatDecisionNumber(record.Number), ui.RenderID(record.ID), record.Status)
+		if len(record.Issues) > 0 {
+			fmt.Printf("  Linked to %s\n", strings.Join(record.Issues, ", "))
+		}
+		if supersedes != "" {
+			fmt.Printf("  Supersedes %s\n", supersedes)
+		}
+	},
+}
+
+var decisionStatusCmd = &cobra.Command{
+	Use:   "status <decision-id> <status>",
+	Short: "Change a decision's status",
+	Args:  cobra.ExactArgs(2),
+	Run: func(_ *cobra.Command, args []string) {
+		CheckReadonly("decision status")
+		ctx := rootCtx
+		status := strings.ToLower(strings.TrimSpace(args[1]))
+		if !slices.Contains(decisionStatuses, status) {
+			FatalErrorRespectJSON("invalid status %q (valid: %s)", status, strings.Join(decisionStatuses, ", "))
+		}
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		if err := setDecisionStatus(ctx, id, status); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		SetLastTouchedID(id)
+
+		record, err := loadDecisionRecord(ctx, id)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(record)
+			return
+		}
+		fmt.Printf("%s ADR %s (%s) is now %s\n", ui.RenderPass("✓"), formatDecisionNumber(record.Number), ui.RenderID(id), status)
+	},
+}
+
+var decisionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List decision records",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		ctx := rootCtx
+		issueArg, _ := cmd.Flags().GetString("issue")
+		status, _ := cmd.Flags().GetString("status")
+
+		issueID := ""
+		if issueArg != "" {
+			id, err := utils.ResolvePartialID(ctx, store, issueArg)
+			if err != nil {
+				FatalErrorRespectJSON("resolving %s: %v", issueArg, err)
+			}
+			issueID = id
+		}
+		records, err := loadDecisionRecords(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		records = filterDecisionRecords(records, issueID, strings.ToLower(status))
+
+		if jsonOutput {
+			outputJSON(records)
+			return
+		}
+		if len(records) == 0 {
+			fmt.Println("No decision records")
+			return
+		}
+		for _, r := range records {
+			line := fmt.Sprintf("%s %s %s %s", ui.RenderBold("ADR "+formatDecisionNumber(r.Number)), ui.RenderID(r.ID), r.Title, ui.RenderMuted("("+r.Status+")"))
+			if len(r.Issues) > 0 {
+				line += " → " + strings.Join(r.Issues, ", ")
+			}
+			fmt.Println(line)
+		}
+	},
+}
+
+var decisionExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write decision records as Markdown ADRs",
+	Long: `Write each decision record to <dir>/NNNN-<title>.md (docs/adr by default,
+relative to the repository root), overwriting earlier exports.
+
+Decisions created without a number (bd create -t decision) are exported
+after the numbered ones, in creation order; 'bd decision status' gives them
+a permanent number.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		ctx := rootCtx
+		dir, _ := cmd.Flags().GetString("dir")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if root := git.GetRepoRoot(); root != "" && !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+
+		records, err := loadDecisionRecords(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		numberDecisionRecords(records)
+
+		var files []string
+		if !dryRun && len(records) > 0 {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				FatalErrorRespectJSON("creating %s: %v", dir, err)
+			}
+		}
+		for _, r := range records {
+			path := filepath.Join(dir, decisionFileName(r.Number, r.Title))
+			files = append(files, path)
+			if dryRun {
+				continue
+			}
+			// #nosec G306 -- ADRs are documentation meant to be committed
+			if err := os.WriteFile(path, []byte(renderDecisionRecord(r, records)), 0o644); err != nil {
+				FatalErrorRespectJSON("writing %s: %v", path, err)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"dir": dir, "files": files, "dry_run": dryRun})
+			return
+		}
+		if len(files) == 0 {
+			fmt.Println("No decision records to export")
+			return
+		}
+		verb := "Wrote"
+		if dryRun {
+			verb = "Would write"
+		}
+		fmt.Printf("%s %s %d decision record(s) to %s\n", ui.RenderPass("✓"), verb, len(files), dir)
+		for _, f := range files {
+			fmt.Printf("  %s\n", filepath.Base(f))
+		}
+	},
+}
+
+func init() {
+	decisionRecordCmd.Flags().String("title", "", "Decision title (required)")
+	decisionRecordCmd.Flags().StringSlice("issue", nil, "Issue or epic the decision is about (repeatable)")
+	decisionRecordCmd.Flags().String("status", "accepted", "Status: "+strings.Join(decisionStatuses, ", "))
+	decisionRecordCmd.Flags().String("supersedes", "", "Decision this one supersedes (marked superseded)")
+	for _, s := range decisionSections {
+		decisionRecordCmd.Flags().String(s.flag, "", s.usage)
+	}
+	decisionListCmd.Flags().String("issue", "", "Only decisions linked to this issue")
+	decisionListCmd.Flags().String("status", "", "Only decisions with this status")
+	decisionExportCmd.Flags().String("dir", filepath.Join("docs", "adr"), "Directory to write ADRs to")
+	decisionExportCmd.Flags().Bool("dry-run", false, "List the files without writing them")
+	decisionCmd.AddCommand(decisionRecordCmd, decisionStatusCmd, decisionListCmd, decisionExportCmd)
+	rootCmd.AddCommand(decisionCmd)
+}
+
+// decisionSections are the ADR sections bd decision record takes as flags,
+// in the order they appear in the description.
+var decisionSections = []struct{ flag, heading, usage string }{
+	{"context", "Context", "Why a decision was needed"},
+	{"decision", "Decision", "What was decided"},
+	{"rationale", "Rationale", "Why this option was chosen"},
+	{"alternatives", "Alternatives Considered", "Options rejected and why"},
+	{"consequences", "Consequences", "What follows from the decision"},
+}
+
+// decisionRecord is a decision as shown by bd decision.
+type decisionRecord struct {
+	ID           string   `json:"id"`
+	Number       int      `json:"number"`
+	Title        string   `json:"title"`
+	Status       string   `json:"status"`
+	Date         string   `json:"date"`
+	Issues       []string `json:"issues,omitempty"`
+	Supersedes   []string `json:"supersedes,omitempty"`
+	SupersededBy []string `json:"superseded_by,omitempty"`
+	Body         string   `json:"body,omitempty"`
+}
+
+// decisionDescription builds a decision's description from its sections,
+// keyed by flag name; empty sections are left out.
+func decisionDescription(sections map[string]string) string {
+	var parts []string
+	for _, s := range decisionSections {
+		if text := strings.TrimSpace(sections[s.flag]); text != "" {
+			parts = append(parts, "## "+s.heading+"\n\n"+text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// searchDecisions returns all decision issues.
+func searchDecisions(ctx context.Context) ([]*types.Issue, error) {
+	decisionType := types.TypeDecision
+	return store.SearchIssues(ctx, "", types.IssueFilter{IssueType: &decisionType})
+}
+
+// nextDecisionNumber returns the ADR number after the highest one in use.
+func nextDecisionNumber(decisions []*types.Issue) int {
+	last := 0
+	for _, d := range decisions {
+		if n, err := strconv.Atoi(typeschema.FromMetadata(d.Metadata)[decisionNumberField]); err == nil && n > last {
+			last = n
+		}
+	}
+	return last + 1
+}
+
+// setDecisionStatus sets a decision's ADR status, closing or reopening it
+// to match, and numbers it if it has no number yet.
+func setDecisionStatus(ctx context.Context, id, status string) error {
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil {
+		return err
+	}
+	if issue.IssueType != types.TypeDecision {
+		return fmt.Errorf("%s is not a decision", id)
+	}
+	fields := map[string]string{decisionStatusField: status}
+	if typeschema.FromMetadata(issue.Metadata)[decisionNumberField] == "" {
+		decisions, err := searchDecisions(ctx)
+		if err != nil {
+			return err
+		}
+		fields[decisionNumberField] = strconv.Itoa(nextDecisionNumber(decisions))
+	}
+	metadata, err := typeschema.WithFields(issue.Metadata, fields)
+	if err != nil {
+		return err
+	}
+	updates := map[string]interface{}{"metadata": metadata}
+	if status == "proposed" && issue.Status == types.StatusClosed {
+		updates["status"] = string(types.StatusOpen)
+	}
+	if err := store.UpdateIssue(ctx, id, updates, actor); err != nil {
+		return fmt.Errorf("updating %s: %w", id, err)
+	}
+	if status != "proposed" && issue.Status != types.StatusClosed {
+		if err := store.CloseIssue(ctx, id, "Decision "+status, actor, ""); err != nil {
+			return fmt.Errorf("closing %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// loadDecisionRecord loads one decision with its links.
+func loadDecisionRecord(ctx context.Context, id string) (decisionRecord, error) {
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil {
+		return decisionRecord{}, err
+	}
+	records, err := decisionRecords(ctx, store, []*types.Issue{issue})
+	if err != nil {
+		return decisionRecord{}, err
+	}
+	return records[0], nil
+}
+
+// loadDecisionRecords loads all decisions with their links, sorted by
+// number (unnumbered ones last, by creation).
+func loadDecisionRecords(ctx context.Context) ([]decisionRecord, error) {
+	decisions, err := searchDecisions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(decisions, func(i, j int) bool { return decisions[i].CreatedAt.Before(decisions[j].CreatedAt) })
+	records, err := decisionRecords(ctx, store, decisions)
+	if err != nil {
+		return nil, err
+	}
+	sortDecisionRecords(records)
+	return records, nil
+}
+
+// decisionRecords builds the records for decisions, resolving their links.
+func decisionRecords(ctx context.Context, s *dolt.DoltStore, decisions []*types.Issue) ([]decisionRecord, error) {
+	ids := make([]string, len(decisions))
+	for i, d := range decisions {
+		ids[i] = d.ID
+	}
+	deps, err := s.GetDependencyRecordsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("loading decision links: %w", err)
+	}
+	records := make([]decisionRecord, len(decisions))
+	for i, d := range decisions {
+		fields := typeschema.FromMetadata(d.Metadata)
+		number, _ := strconv.Atoi(fields[decisionNumberField])
+		status := fields[decisionStatusField]
+		if status == "" {
+			status = "proposed"
+			if d.Status == types.StatusClosed {
+				status = "accepted"
+			}
+		}
+		records[i] = decisionRecord{
+			ID: d.ID, Number: number, Title: d.Title, Status: status,
+			Date: d.CreatedAt.Format("2006-01-02"), Body: d.Description,
+		}
+		for _, dep := range deps[d.ID] {
+			switch dep.Type {
+			case types.DepRelatesTo:
+				records[i].Issues = append(records[i].Issues, dep.DependsOnID)
+			case types.DepSupersedes:
+				records[i].Supersedes = append(records[i].Supersedes, dep.DependsOnID)
+			}
+		}
+	}
+	for i := range records {
+		for _, other := range records {
+			if slices.Contains(other.Supersedes, records[i].ID) {
+				records[i].SupersededBy = append(records[i].SupersededBy, other.ID)
+			}
+		}
+	}
+	return records, nil
+}
+
+// sortDecisionRecords sorts records by number, keeping unnumbered ones
+// last in their current order.
+func sortDecisionRecords(records []decisionRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i].Number, records[j].Number
+		if a == 0 || b == 0 {
+			return a != 0 && b == 0
+		}
+		return a < b
+	})
+}
+
+// filterDecisionRecords keeps the records linked to issueID and with
+// status (either may be empty to keep all).
+func filterDecisionRecords(records []decisionRecord, issueID, status string) []decisionRecord {
+	kept := []decisionRecord{}
+	for _, r := range records {
+		if (issueID == "" || slices.Contains(r.Issues, issueID)) && (status == "" || r.Status == status) {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+// numberDecisionRecords gives unnumbered records (sorted last) the numbers
+// after the highest one, in order. The numbers aren't saved.
+func numberDecisionRecords(records []decisionRecord) {
+	next := 1
+	for i := range records {
+		if records[i].Number == 0 {
+			records[i].Number = next
+		}
+		next = records[i].Number + 1
+	}
+}
+
+func formatDecisionNumber(n int) string {
+	return fmt.Sprintf("%04d", n)
+}
+
+var nonSlugRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// decisionFileName returns the ADR file name for a decision, e.g.
+// "0003-use-dolt-for-storage.md".
+func decisionFileName(number int, title string) string {
+	slug := strings.Trim(nonSlugRegex.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if len(slug) > 60 {
+		slug = strings.TrimRight(slug[:60], "-")
+	}
+	if slug == "" {
+		return formatDecisionNumber(number) + ".md"
+	}
+	return formatDecisionNumber(number) + "-" + slug + ".md"
+}
+
+// renderDecisionRecord renders a decision as a Markdown ADR. all is used
+// to link the decisions it supersedes or is superseded by.
+func renderDecisionRecord(r decisionRecord, all []decisionRecord) string {
+	link := func(id string) string {
+		for _, other := range all {
+			if other.ID == id {
+				return fmt.Sprintf("[ADR %s](%s)", formatDecisionNumber(other.Number), decisionFileName(other.Number, other.Title))
+			}
+		}
+		return id
+	}
+	links := func(ids []string) string {
+		out := make([]string, len(ids))
+		for i, id := range ids {
+			out[i] = link(id)
+		}
+		return strings.Join(out, ", ")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %d. %s\n\n", r.Number, r.Title)
+	fmt.Fprintf(&b, "- Status: %s\n", r.Status)
+	if len(r.SupersededBy) > 0 {
+		fmt.Fprintf(&b, "- Superseded by: %s\n", links(r.SupersededBy))
+	}
+	if len(r.Supersedes) > 0 {
+		fmt.Fprintf(&b, "- Supersedes: %s\n", links(r.Supersedes))
+	}
+	fmt.Fprintf(&b, "- Date: %s\n", r.Date)
+	fmt.Fprintf(&b, "- Record: %s\n", r.ID)
+	if len(r.Issues) > 0 {
+		fmt.Fprintf(&b, "- Issues: %s\n", strings.Join(r.Issues, ", "))
+	}
+	if body := strings.TrimSpace(r.Body); body != "" {
+		b.WriteString("\n" + body + "\n")
+	}
+	return b.String()
+}
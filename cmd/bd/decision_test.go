@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestDecisionDescription(t *testing.T) {
+	got := decisionDescription(map[string]string{"decision": "Use Dolt", "context": "Need merges ", "rationale": ""})
+	want := "## Context\n\nNeed merges\n\n## Decision\n\nUse Dolt"
+	if got != want {
+		t.Errorf("decisionDescription = %q, want %q", got, want)
+	}
+	if got := decisionDescription(nil); got != "" {
+		t.Errorf("decisionDescription(nil) = %q", got)
+	}
+}
+
+func TestNextDecisionNumber(t *testing.T) {
+	decisions := []*types.Issue{
+		{Metadata: json.RawMessage(`{"fields":{"adr-number":"3"}}`)},
+		{Metadata: json.RawMessage(`{"fields":{"adr-number":"7"}}`)},
+		{}, // Created with bd create -t decision
+	}
+	if got := nextDecisionNumber(decisions); got != 8 {
+		t.Errorf("nextDecisionNumber = %d, want 8", got)
+	}
+	if got := nextDecisionNumber(nil); got != 1 {
+		t.Errorf("nextDecisionNumber(nil) = %d, want 1", got)
+	}
+}
+
+func TestNumberDecisionRecords(t *testing.T) {
+	records := []decisionRecord{{ID: "a"}, {ID: "b", Number: 4}, {ID: "c"}, {ID: "d", Number: 2}}
+	sortDecisionRecords(records)
+	numberDecisionRecords(records)
+	var got []string
+	for _, r := range records {
+		got = append(got, r.ID+":"+formatDecisionNumber(r.Number))
+	}
+	if want := "d:0002 b:0004 a:0005 c:0006"; strings.Join(got, " ") != want {
+		t.Errorf("records = %s, want %s", strings.Join(got, " "), want)
+	}
+}
+
+func TestDecisionFileName(t *testing.T) {
+	for _, tt := range []struct {
+		number int
+		title  string
+		want   string
+	}{
+		{3, "Use Dolt for storage!", "0003-use-dolt-for-storage.md"},
+		{12, "  --  ", "0012.md"},
+		{1, strings.Repeat("word ", 20), "0001-" + strings.TrimRight(strings.Repeat("word-", 12), "-") + ".md"},
+	} {
+		if got := decisionFileName(tt.number, tt.title); got != tt.want {
+			t.Errorf("decisionFileName(%d, %q) = %q, want %q", tt.number, tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestRenderDecisionRecord(t *testing.T) {
+	old := decisionRecord{ID: "bd-d1", Number: 1, Title: "Use SQLite", Status: "superseded", Date: "2025-01-02", SupersededBy: []string{"bd-d2"}}
+	current := decisionRecord{ID: "bd-d2", Number: 2, Title: "Use Dolt", Status: "accepted", Date: "2026-03-04",
+		Issues: []string{"bd-7"}, Supersedes: []string{"bd-d1"}, Body: "## Decision\n\nDolt"}
+	all := []decisionRecord{old, current}
+
+	got := renderDecisionRecord(current, all)
+	want := "# 2. Use Dolt\n\n- Status: accepted\n- Supersedes: [ADR 0001](0001-use-sqlite.md)\n- Date: 2026-03-04\n- Record: bd-d2\n- Issues: bd-7\n\n## Decision\n\nDolt\n"
+	if got != want {
+		t.Errorf("renderDecisionRecord =\n%s\nwant\n%s", got, want)
+	}
+	if got := renderDecisionRecord(old, all); !strings.Contains(got, "- Superseded by: [ADR 0002](0002-use-dolt.md)\n") {
+		t.Errorf("superseded record =\n%s", got)
+	}
+}
+
+func TestFilterDecisionRecords(t *testing.T) {
+	records := []decisionRecord{
+		{ID: "bd-d1", Status: "accepted", Issues: []string{"bd-1"}},
+		{ID: "bd-d2", Status: "proposed", Issues: []string{"bd-1", "bd-2"}},
+		{ID: "bd-d3", Status: "accepted"},
+	}
+	ids := func(rs []decisionRecord) string {
+		var out []string
+		for _, r := range rs {
+			out = append(out, r.ID)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := ids(filterDecisionRecords(records, "bd-1", "")); got != "bd-d1,bd-d2" {
+		t.Errorf("by issue = %s", got)
+	}
+	if got := ids(filterDecisionRecords(records, "", "accepted")); got != "bd-d1,bd-d3" {
+		t.Errorf("by status = %s", got)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/steveyegge/beads/internal/timeparsing"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/utils"
@@ -23,11 +22,14 @@ dependency keeping them from being worked. Unlike closed issues, they will
 be revisited.
 
 Deferred issues don't show in 'bd ready' but remain visible in 'bd list'.
+An --until date on a weekend or holiday (see calendar.weekend and
+calendar.holidays) moves to the start of the next working day.
 
 Examples:
-  bd defer bd-abc                  # Defer a single issue (status-based)
-  bd defer bd-abc --until=tomorrow # Defer until specific time
-  bd defer bd-abc bd-def           # Defer multiple issues`,
+  bd defer bd-abc                              # Defer a single issue (status-based)
+  bd defer bd-abc --until=tomorrow             # Defer until specific time
+  bd defer bd-abc --until="+3 business days"   # Skip weekends and holidays
+  bd defer bd-abc bd-def                       # Defer multiple issues`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("defer")
@@ -36,9 +38,9 @@ Examples:
 		var deferUntil *time.Time
 		untilStr, _ := cmd.Flags().GetString("until")
 		if untilStr != "" {
-			t, err := timeparsing.ParseRelativeTime(untilStr, time.Now())
+			t, err := parseDeferFlag(untilStr, jsonOutput)
 			if err != nil {
-				FatalError("invalid --until format %q. Examples: +1h, tomorrow, next monday, 2025-01-15", untilStr)
+				FatalError("invalid --until format %q. Examples: +1h, +3 business days, tomorrow, 2025-01-15", untilStr)
 			}
 			deferUntil = &t
 		}
@@ -97,7 +99,7 @@ Examples:
 
 func init() {
 	// Time-based scheduling flag (GH#820)
-	deferCmd.Flags().String("until", "", "Defer until specific time (e.g., +1h, tomorrow, next monday)")
+	deferCmd.Flags().String("until", "", "Defer until specific time (e.g., +1h, tomorrow, +3 business days)")
 	deferCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(deferCmd)
 }
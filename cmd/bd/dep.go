@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/routing"
+	"github.com/steveyegge/beads/internal/schedule"
 	"github.com/steveyegge/beads/internal/storage/dolt"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
@@ -58,19 +60,9 @@ func warnIfCyclesExist(s *dolt.DoltStore) {
 	fmt.Fprintf(os.Stderr, "This can hide issues from the ready work list and cause confusion.\n\n")
 	fmt.Fprintf(os.Stderr, "Cycle path:\n")
 	for _, cycle := range cycles {
-		for j, issue := range cycle {
-			if j == 0 {
-				fmt.Fprintf(os.Stderr, "  %s", issue.ID)
-			} else {
-				fmt.Fprintf(os.Stderr, " → %s", issue.ID)
-			}
-		}
-		if len(cycle) > 0 {
-			fmt.Fprintf(os.Stderr, " → %s", cycle[0].ID)
-		}
-		fmt.Fprintf(os.Stderr, "\n")
+		fmt.Fprintf(os.Stderr, "  %s\n", formatCyclePath(cycle))
 	}
-	fmt.Fprintf(os.Stderr, "\nRun 'bd dep cycles' for detailed analysis.\n\n")
+	fmt.Fprintf(os.Stderr, "\nRun 'bd dep check' for detailed analysis.\n\n")
 }
 
 var depCmd = &cobra.Command{
@@ -183,11 +175,24 @@ External references are stored as-is and resolved at query time using
 the external_projects config. They block the issue until the capability
 is "shipped" in the target project.
 
+Blocks dependencies can carry scheduling semantics:
+  --relation fs   finish-to-start (default): start after the blocker closes
+  --relation ss   start-to-start: start once the blocker has started
+  --relation ff   finish-to-finish: start any time, close after the blocker closes
+  --lag 2d        wait this long after the blocker's start or finish (h, d, w);
+                  a negative lag (-1d) is a lead, counted from the blocker's
+                  due date (fs, ff) or defer date (ss) until it happens
+
+These apply to bd ready, bd blocked, bd close, and bd timeline. Re-adding a
+dependency replaces its relation and lag.
+
 Examples:
   bd dep add bd-42 bd-41                              # Positional args
   bd dep add bd-42 --blocked-by bd-41                 # Flag syntax (same effect)
   bd dep add bd-42 --depends-on bd-41                 # Alias (same effect)
-  bd dep add gt-xyz external:beads:mol-run-assignee   # Cross-project dependency`,
+  bd dep add gt-xyz external:beads:mol-run-assignee   # Cross-project dependency
+  bd dep add bd-42 bd-41 --lag 2d                     # Start 2 days after bd-41 closes
+  bd dep add bd-42 bd-41 --relation ss                # Start once bd-41 has started`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		blockedBy, _ := cmd.Flags().GetString("blocked-by")
 		dependsOn, _ := cmd.Flags().GetString("depends-on")
@@ -267,12 +272,21 @@ Examples:
 			FatalErrorRespectJSON("cannot add dependency: %s is already a child of %s. Children inherit dependency on parent completion via hierarchy. Adding an explicit dependency would create a deadlock", fromID, toID)
 		}
 
+		schedMeta, err := scheduleMetaFromFlags(cmd, types.DependencyType(depType))
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
 		// Direct mode
 		dep := &types.Dependency{
 			IssueID:     fromID,
 			DependsOnID: toID,
 			Type:        types.DependencyType(depType),
 		}
+		if !schedMeta.IsZero() {
+			metaJSON, _ := json.Marshal(schedMeta)
+			dep.Metadata = string(metaJSON)
+		}
 
 		if err := store.AddDependency(ctx, dep, actor); err != nil {
 			FatalErrorRespectJSON("%v", err)
@@ -282,17 +296,21 @@ Examples:
 		warnIfCyclesExist(store)
 
 		if jsonOutput {
-			outputJSON(map[string]interface{}{
+			result := map[string]interface{}{
 				"status":        "added",
 				"issue_id":      fromID,
 				"depends_on_id": toID,
 				"type":          depType,
-			})
+			}
+			if !schedMeta.IsZero() {
+				result["schedule"] = schedMeta
+			}
+			outputJSON(result)
 			return
 		}
 
-		fmt.Printf("%s Added dependency: %s depends on %s (%s)\n",
-			ui.RenderPass("✓"), fromID, toID, depType)
+		fmt.Printf("%s Added dependency: %s depends on %s (%s%s)\n",
+			ui.RenderPass("✓"), fromID, toID, depType, formatScheduleMeta(&schedMeta))
 	},
 }
 
@@ -332,7 +350,7 @@ Examples:
 			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
 		}
 		if routedResult == nil || routedResult.Issue == nil {
-			FatalErrorRespectJSON("no issue found: %s", args[0])
+			FatalErrorCode(exitNotFound, "no issue found: %s", args[0])
 		}
 		fullID = routedResult.ResolvedID
 		if routedResult.Routed {
@@ -428,8 +446,8 @@ Examples:
 				idStr = iss.ID
 			}
 
-			fmt.Printf("  %s: %s [P%d] (%s) via %s\n",
-				idStr, iss.Title, iss.Priority, iss.Status, iss.DependencyType)
+			fmt.Printf("  %s: %s [P%d] (%s) via %s%s\n",
+				idStr, iss.Title, iss.Priority, iss.Status, iss.DependencyType, formatScheduleMeta(iss.Schedule))
 		}
 		fmt.Println()
 	},
@@ -603,11 +621,25 @@ Examples:
 	},
 }
 
-var depCyclesCmd = &cobra.Command{
-	Use:   "cycles",
-	Short: "Detect dependency cycles",
-	Run: func(cmd *cobra.Command, args []string) {
+var depCheckCmd = &cobra.Command{
+	Use:     "check",
+	Aliases: []string{"cycles"},
+	Short:   "Check the dependency graph for cycles",
+	Long: `Scan every blocking dependency for cycles and show each as its path,
+each issue depending on the next: bd-a → bd-b → bd-c → bd-a.
+
+Issues in a cycle wait on each other, so none of them ever shows up in
+bd ready. bd dep add refuses to create one, but cycles can predate that
+check or arrive in imported data. Break a cycle by removing one of its
+dependencies with 'bd dep remove'.
 
+Exits with status 3 when cycles are found, so it can gate CI.
+
+Examples:
+  bd dep check
+  bd dep check --json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
 		ctx := rootCtx
 		cycles, err := store.DetectCycles(ctx)
 		if err != nil {
@@ -620,25 +652,37 @@ var depCyclesCmd = &cobra.Command{
 				cycles = [][]*types.Issue{}
 			}
 			outputJSON(cycles)
-			return
-		}
-
-		if len(cycles) == 0 {
+		} else if len(cycles) == 0 {
 			fmt.Printf("\n%s No dependency cycles detected\n\n", ui.RenderPass("✓"))
-			return
-		}
-
-		fmt.Printf("\n%s Found %d dependency cycles:\n\n", ui.RenderFail("⚠"), len(cycles))
-		for i, cycle := range cycles {
-			fmt.Printf("%d. Cycle involving:\n", i+1)
-			for _, issue := range cycle {
-				fmt.Printf("   - %s: %s\n", issue.ID, issue.Title)
+		} else {
+			fmt.Printf("\n%s Found %d dependency cycle(s):\n\n", ui.RenderFail("⚠"), len(cycles))
+			for i, cycle := range cycles {
+				fmt.Printf("%d. %s\n", i+1, formatCyclePath(cycle))
+				for _, issue := range cycle {
+					fmt.Printf("   %s: %s\n", issue.ID, issue.Title)
+				}
+				fmt.Println()
 			}
-			fmt.Println()
+			fmt.Println("Issues in a cycle never become ready. Break each cycle with 'bd dep remove <issue> <depends-on>'.")
+		}
+		if len(cycles) > 0 {
+			os.Exit(exitValidation)
 		}
 	},
 }
 
+// formatCyclePath renders a cycle as "a → b → c → a".
+func formatCyclePath(cycle []*types.Issue) string {
+	if len(cycle) == 0 {
+		return ""
+	}
+	ids := make([]string, 0, len(cycle)+1)
+	for _, issue := range cycle {
+		ids = append(ids, issue.ID)
+	}
+	return strings.Join(append(ids, cycle[0].ID), " → ")
+}
+
 // outputMermaidTree outputs a dependency tree in Mermaid.js flowchart format
 func outputMermaidTree(tree []*types.TreeNode, rootID string) {
 	if len(tree) == 0 {
@@ -1084,6 +1128,8 @@ func init() {
 	depCmd.Flags().StringP("blocks", "b", "", "Issue ID that this issue blocks (shorthand for: bd dep add <blocked> <blocker>)")
 
 	depAddCmd.Flags().StringP("type", "t", "blocks", "Dependency type (blocks|tracks|related|parent-child|discovered-from|until|caused-by|validates|relates-to|supersedes)")
+	depAddCmd.Flags().String("relation", "", "Scheduling relation for blocks: fs (finish-to-start, default), ss (start-to-start), ff (finish-to-finish)")
+	depAddCmd.Flags().String("lag", "", "Delay after the blocker's start or finish for blocks, e.g. 2d, 4h, 1w; negative for a lead")
 	depAddCmd.Flags().String("blocked-by", "", "Issue ID that blocks the first issue (alternative to positional arg)")
 	depAddCmd.Flags().String("depends-on", "", "Issue ID that the first issue depends on (alias for --blocked-by)")
 
@@ -1108,6 +1154,50 @@ func init() {
 	depCmd.AddCommand(depRemoveCmd)
 	depCmd.AddCommand(depListCmd)
 	depCmd.AddCommand(depTreeCmd)
-	depCmd.AddCommand(depCyclesCmd)
+	depCmd.AddCommand(depCheckCmd)
 	rootCmd.AddCommand(depCmd)
 }
+
+// scheduleMetaFromFlags builds the scheduling metadata for dep add from
+// --relation and --lag, which only apply to blocks dependencies.
+func scheduleMetaFromFlags(cmd *cobra.Command, depType types.DependencyType) (types.ScheduleMeta, error) {
+	relation, _ := cmd.Flags().GetString("relation")
+	lag, _ := cmd.Flags().GetString("lag")
+	if relation == "" && lag == "" {
+		return types.ScheduleMeta{}, nil
+	}
+	if depType != types.DepBlocks {
+		return types.ScheduleMeta{}, fmt.Errorf("--relation and --lag only apply to blocks dependencies")
+	}
+	switch strings.ToLower(relation) {
+	case "fs":
+		relation = types.RelationFinishToStart
+	case "ss":
+		relation = types.RelationStartToStart
+	case "ff":
+		relation = types.RelationFinishToFinish
+	}
+	meta := types.ScheduleMeta{Relation: relation, Lag: strings.TrimSpace(lag)}
+	if meta.Relation == types.RelationFinishToStart {
+		meta.Relation = "" // The default
+	}
+	if err := meta.Validate(); err != nil {
+		return types.ScheduleMeta{}, err
+	}
+	return meta, nil
+}
+
+// formatScheduleMeta renders a dependency's relation and lag as a suffix,
+// e.g. ", start-to-start +2d", or "" for a plain block.
+func formatScheduleMeta(meta *types.ScheduleMeta) string {
+	if meta == nil || meta.IsZero() {
+		return ""
+	}
+	out := ", " + meta.RelationOrDefault()
+	if lag := meta.LagDuration(); lag > 0 {
+		out += " +" + schedule.FormatLag(lag)
+	} else if lag < 0 {
+		out += " " + schedule.FormatLag(lag)
+	}
+	return out
+}
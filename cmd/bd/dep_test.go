@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -435,6 +436,21 @@ func TestDepCommandsInit(t *testing.T) {
 	}
 }
 
+func TestFormatCyclePath(t *testing.T) {
+	cycle := []*types.Issue{{ID: "bd-1"}, {ID: "bd-2"}, {ID: "bd-3"}}
+	if got, want := formatCyclePath(cycle), "bd-1 → bd-2 → bd-3 → bd-1"; got != want {
+		t.Errorf("formatCyclePath = %q, want %q", got, want)
+	}
+	if got := formatCyclePath(nil); got != "" {
+		t.Errorf("formatCyclePath(nil) = %q, want empty", got)
+	}
+
+	cmd, _, err := depCmd.Find([]string{"cycles"})
+	if err != nil || cmd != depCheckCmd {
+		t.Errorf("'bd dep cycles' should still run bd dep check")
+	}
+}
+
 func TestDepAddFlagAliases(t *testing.T) {
 	// Test that --blocked-by flag exists on depAddCmd
 	blockedByFlag := depAddCmd.Flags().Lookup("blocked-by")
@@ -464,6 +480,33 @@ func TestDepAddFlagAliases(t *testing.T) {
 	}
 }
 
+func TestScheduleMetaFromFlags(t *testing.T) {
+	newCmd := func(relation, lag string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("relation", relation, "")
+		cmd.Flags().String("lag", lag, "")
+		return cmd
+	}
+
+	meta, err := scheduleMetaFromFlags(newCmd("ss", "2d"), types.DepBlocks)
+	if err != nil || meta.Relation != types.RelationStartToStart || meta.Lag != "2d" {
+		t.Errorf("ss 2d: %+v, %v", meta, err)
+	}
+	if got := formatScheduleMeta(&meta); got != ", start-to-start +2d" {
+		t.Errorf("formatScheduleMeta = %q", got)
+	}
+	meta, err = scheduleMetaFromFlags(newCmd("fs", ""), types.DepBlocks)
+	if err != nil || !meta.IsZero() || formatScheduleMeta(&meta) != "" {
+		t.Errorf("fs: %+v, %v", meta, err)
+	}
+	if _, err := scheduleMetaFromFlags(newCmd("", "2 days"), types.DepBlocks); err == nil {
+		t.Error("accepted invalid lag")
+	}
+	if _, err := scheduleMetaFromFlags(newCmd("ss", ""), types.DepRelated); err == nil {
+		t.Error("accepted --relation on a related dependency")
+	}
+}
+
 func TestDepBlocksFlag(t *testing.T) {
 	// Test that the --blocks flag exists on depCmd
 	flag := depCmd.Flags().Lookup("blocks")
@@ -9,23 +9,45 @@ import (
 )
 
 var diffCmd = &cobra.Command{
-	Use:     "diff <from-ref> <to-ref>",
+	Use:     "diff <id> | diff <from-ref> <to-ref>",
 	GroupID: "views",
-	Short:   "Show changes between two commits or branches (requires Dolt backend)",
-	Long: `Show the differences in issues between two commits or branches.
+	Short:   "Show changes to an issue, or between two commits or branches",
+	Long: `Show what changed in one issue, or in all issues between two commits or branches.
 
-This command requires the Dolt storage backend. The refs can be:
+With one argument, show unified diffs of the issue's description, design,
+acceptance criteria, and notes, plus changed fields, between two revisions.
+By default this compares the previous revision with the current state: the
+latest Dolt commit where the issue differed, or, when the change is not in
+Dolt history yet, the state recorded in the audit log before the last edit.
+Use --from and --to to pick revisions (any ref below, or "current").
+
+With two arguments, list the issues added, modified, or removed between two
+refs. The refs can be:
 - Commit hashes (e.g., abc123def)
 - Branch names (e.g., main, feature-branch)
 - Special refs like HEAD, HEAD~1
 
 Examples:
-  bd diff main feature-branch   # Compare main to feature branch
-  bd diff HEAD~5 HEAD           # Show changes in last 5 commits
-  bd diff abc123 def456         # Compare two specific commits`,
-	Args: cobra.ExactArgs(2),
+  bd diff bd-123                      # What changed in bd-123's last edit
+  bd diff bd-123 --from HEAD~3        # bd-123 now vs three commits ago
+  bd diff bd-123 --from abc123 --to def456
+  bd diff main feature-branch         # Compare main to feature branch
+  bd diff HEAD~5 HEAD                 # Show changes in last 5 commits`,
+	Args: cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := rootCtx
+		fromFlag, _ := cmd.Flags().GetString("from")
+		toFlag, _ := cmd.Flags().GetString("to")
+
+		if len(args) == 1 {
+			contextLines, _ := cmd.Flags().GetInt("context")
+			runIssueDiff(ctx, args[0], fromFlag, toFlag, contextLines)
+			return
+		}
+		if fromFlag != "" || toFlag != "" {
+			FatalErrorRespectJSON("--from/--to apply to 'bd diff <id>'; pass refs as arguments to compare all issues")
+		}
+
 		fromRef := args[0]
 		toRef := args[1]
 
@@ -141,5 +163,8 @@ func joinStrings(strs []string, sep string) string {
 }
 
 func init() {
+	diffCmd.Flags().String("from", "", "Revision to diff from (Dolt ref or \"current\"; default: previous revision)")
+	diffCmd.Flags().String("to", "", "Revision to diff to (Dolt ref or \"current\"; default: current)")
+	diffCmd.Flags().IntP("context", "U", 3, "Lines of context in text diffs")
 	rootCmd.AddCommand(diffCmd)
 }
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/textdiff"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// issueRevision is one side of an issue diff.
+type issueRevision struct {
+	Ref   string       `json:"ref"`   // Dolt ref, "current", or "event:<id>"
+	Label string       `json:"label"` // Human-readable description of the revision
+	Issue *types.Issue `json:"-"`
+}
+
+// fieldChange is a changed scalar field.
+type fieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// textChange is a changed text field with its unified diff.
+type textChange struct {
+	Field string `json:"field"`
+	Diff  string `json:"diff"`
+}
+
+// issueDiff is the result of bd diff <id>.
+type issueDiff struct {
+	IssueID string         `json:"issue_id"`
+	From    *issueRevision `json:"from"`
+	To      *issueRevision `json:"to"`
+	Fields  []fieldChange  `json:"fields"`
+	Text    []textChange   `json:"text"`
+}
+
+// currentRevision names the live state of an issue (the working set).
+const currentRevision = "current"
+
+// runIssueDiff implements bd diff <id> [--from rev --to rev].
+func runIssueDiff(ctx context.Context, idArg, fromRef, toRef string, contextLines int) {
+	issueID, err := utils.ResolvePartialID(ctx, store, idArg)
+	if err != nil {
+		FatalErrorRespectJSON("resolving %s: %v", idArg, err)
+	}
+
+	to, err := loadIssueRevision(ctx, issueID, toRef)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	var from *issueRevision
+	if fromRef != "" {
+		from, err = loadIssueRevision(ctx, issueID, fromRef)
+	} else {
+		from, err = previousIssueRevision(ctx, issueID, to.Issue)
+	}
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if from == nil {
+		if jsonOutput {
+			outputJSON(&issueDiff{IssueID: issueID, To: to, Fields: []fieldChange{}, Text: []textChange{}})
+			return
+		}
+		fmt.Printf("No earlier revision of %s found (no Dolt history or audit log changes)\n", issueID)
+		return
+	}
+
+	diff := &issueDiff{
+		IssueID: issueID,
+		From:    from,
+		To:      to,
+		Fields:  issueFieldChanges(from.Issue, to.Issue),
+		Text:    issueTextChanges(from, to, contextLines),
+	}
+
+	if jsonOutput {
+		outputJSON(diff)
+		return
+	}
+
+	if len(diff.Fields) == 0 && len(diff.Text) == 0 {
+		fmt.Printf("No changes to %s between %s and %s\n", issueID, from.Label, to.Label)
+		return
+	}
+
+	fmt.Printf("\n%s %s: %s → %s\n\n", ui.RenderAccent("📝"), issueID,
+		ui.RenderMuted(from.Label), ui.RenderMuted(to.Label))
+	for _, c := range diff.Fields {
+		fmt.Printf("  %s: %s → %s\n", ui.RenderBold(c.Field), ui.RenderMuted(displayFieldValue(c.Old)), displayFieldValue(c.New))
+	}
+	for _, t := range diff.Text {
+		fmt.Printf("\n%s\n", ui.RenderBold(t.Field))
+		for _, line := range strings.Split(strings.TrimSuffix(t.Diff, "\n"), "\n") {
+			fmt.Println(colorDiffLine(line))
+		}
+	}
+	fmt.Println()
+}
+
+// loadIssueRevision loads an issue at a Dolt ref, or its current state.
+func loadIssueRevision(ctx context.Context, issueID, ref string) (*issueRevision, error) {
+	if ref == "" || ref == currentRevision {
+		issue, err := store.GetIssue(ctx, issueID)
+		if err != nil {
+			return nil, err
+		}
+		return &issueRevision{Ref: currentRevision, Label: "current", Issue: issue}, nil
+	}
+	issue, err := store.AsOf(ctx, issueID, ref)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s at %s: %w", issueID, ref, err)
+	}
+	return &issueRevision{Ref: ref, Label: ref, Issue: issue}, nil
+}
+
+// previousIssueRevision finds the latest earlier state of the issue that
+// differs from current: first in Dolt history, then in the audit log (which
+// also covers changes not yet committed to Dolt).
+func previousIssueRevision(ctx context.Context, issueID string, current *types.Issue) (*issueRevision, error) {
+	history, err := store.History(ctx, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("loading history for %s: %w", issueID, err)
+	}
+	for _, h := range history {
+		if h.Issue != nil && issueContentDiffers(h.Issue, current) {
+			return &issueRevision{
+				Ref:   h.CommitHash,
+				Label: fmt.Sprintf("commit %s (%s)", shortRef(h.CommitHash), h.CommitDate.Local().Format("2006-01-02 15:04")),
+				Issue: h.Issue,
+			}, nil
+		}
+	}
+
+	events, err := store.GetEvents(ctx, issueID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("loading audit log for %s: %w", issueID, err)
+	}
+	for _, e := range events {
+		old := issueFromEvent(e)
+		if old == nil {
+			continue
+		}
+		if err := store.ResolveDescription(ctx, old); err != nil {
+			return nil, err
+		}
+		if issueContentDiffers(old, current) {
+			return &issueRevision{
+				Ref: fmt.Sprintf("event:%d", e.ID),
+				Label: fmt.Sprintf("before %s by %s (%s)", e.EventType, e.Actor,
+					e.CreatedAt.Local().Format("2006-01-02 15:04")),
+				Issue: old,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// issueFromEvent decodes the issue snapshot recorded as an event's old
+// value, or nil if the event doesn't carry one.
+func issueFromEvent(e *types.Event) *types.Issue {
+	if e.OldValue == nil || !strings.HasPrefix(strings.TrimSpace(*e.OldValue), "{") {
+		return nil
+	}
+	var issue types.Issue
+	if err := json.Unmarshal([]byte(*e.OldValue), &issue); err != nil || issue.ID == "" {
+		return nil
+	}
+	return &issue
+}
+
+// issueContentDiffers reports whether any diffed field differs.
+func issueContentDiffers(a, b *types.Issue) bool {
+	if len(issueFieldChanges(a, b)) > 0 {
+		return true
+	}
+	for _, f := range issueTextFields {
+		if f.get(a) != f.get(b) {
+			return true
+		}
+	}
+	return false
+}
+
+// issueTextFields are the free-text fields shown as unified diffs.
+var issueTextFields = []struct {
+	name string
+	get  func(*types.Issue) string
+}{
+	{"description", func(i *types.Issue) string { return i.Description }},
+	{"design", func(i *types.Issue) string { return i.Design }},
+	{"acceptance_criteria", func(i *types.Issue) string { return i.AcceptanceCriteria }},
+	{"notes", func(i *types.Issue) string { return i.Notes }},
+}
+
+// issueFieldChanges returns the scalar fields that differ between a and b.
+func issueFieldChanges(a, b *types.Issue) []fieldChange {
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"title", a.Title, b.Title},
+		{"status", string(a.Status), string(b.Status)},
+		{"priority", fmt.Sprintf("P%d", a.Priority), fmt.Sprintf("P%d", b.Priority)},
+		{"type", string(a.IssueType), string(b.IssueType)},
+		{"assignee", a.Assignee, b.Assignee},
+		{"owner", a.Owner, b.Owner},
+		{"estimate", formatEstimate(a.EstimatedMinutes), formatEstimate(b.EstimatedMinutes)},
+		{"close_reason", a.CloseReason, b.CloseReason},
+	}
+	changes := []fieldChange{}
+	for _, f := range fields {
+		if f.old != f.new {
+			changes = append(changes, fieldChange{Field: f.name, Old: f.old, New: f.new})
+		}
+	}
+	return changes
+}
+
+// issueTextChanges returns unified diffs of the text fields that differ.
+func issueTextChanges(from, to *issueRevision, contextLines int) []textChange {
+	changes := []textChange{}
+	for _, f := range issueTextFields {
+		diff := textdiff.Unified(f.name+" ("+from.Label+")", f.name+" ("+to.Label+")",
+			f.get(from.Issue), f.get(to.Issue), contextLines)
+		if diff != "" {
+			changes = append(changes, textChange{Field: f.name, Diff: diff})
+		}
+	}
+	return changes
+}
+
+func formatEstimate(minutes *int) string {
+	if minutes == nil {
+		return ""
+	}
+	return fmt.Sprintf("%dm", *minutes)
+}
+
+func displayFieldValue(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
+
+func shortRef(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
+
+// colorDiffLine styles a unified diff line for the terminal.
+func colorDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		return ui.RenderBold(line)
+	case strings.HasPrefix(line, "@@"):
+		return ui.RenderAccent(line)
+	case strings.HasPrefix(line, "+"):
+		return ui.RenderPass(line)
+	case strings.HasPrefix(line, "-"):
+		return ui.RenderFail(line)
+	default:
+		return line
+	}
+}
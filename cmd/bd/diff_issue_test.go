@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueFieldChanges(t *testing.T) {
+	mins := 30
+	a := &types.Issue{Title: "Spec", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	b := &types.Issue{Title: "Spec v2", Status: types.StatusInProgress, Priority: 2, IssueType: types.TypeTask,
+		Assignee: "alice", EstimatedMinutes: &mins}
+
+	changes := issueFieldChanges(a, b)
+	got := map[string]fieldChange{}
+	for _, c := range changes {
+		got[c.Field] = c
+	}
+	if len(changes) != 4 {
+		t.Errorf("expected 4 changes, got %+v", changes)
+	}
+	if got["title"].Old != "Spec" || got["title"].New != "Spec v2" {
+		t.Errorf("title change = %+v", got["title"])
+	}
+	if got["estimate"].Old != "" || got["estimate"].New != "30m" {
+		t.Errorf("estimate change = %+v", got["estimate"])
+	}
+	if _, ok := got["priority"]; ok {
+		t.Error("unchanged priority reported")
+	}
+}
+
+func TestIssueContentDiffersOnText(t *testing.T) {
+	a := &types.Issue{Title: "Spec", Description: "v1"}
+	b := &types.Issue{Title: "Spec", Description: "v1"}
+	if issueContentDiffers(a, b) {
+		t.Error("identical issues reported as different")
+	}
+	b.Notes = "added a note"
+	if !issueContentDiffers(a, b) {
+		t.Error("notes change not detected")
+	}
+}
+
+func TestIssueTextChanges(t *testing.T) {
+	from := &issueRevision{Label: "abc123", Issue: &types.Issue{Description: "Use a cache.\n", Design: "same"}}
+	to := &issueRevision{Label: "current", Issue: &types.Issue{Description: "Use a bounded cache.\n", Design: "same"}}
+
+	changes := issueTextChanges(from, to, 3)
+	if len(changes) != 1 || changes[0].Field != "description" {
+		t.Fatalf("expected only a description diff, got %+v", changes)
+	}
+	for _, want := range []string{"--- description (abc123)", "+++ description (current)", "-Use a cache.", "+Use a bounded cache."} {
+		if !strings.Contains(changes[0].Diff, want) {
+			t.Errorf("diff missing %q:\n%s", want, changes[0].Diff)
+		}
+	}
+}
+
+func TestIssueFromEvent(t *testing.T) {
+	snapshot := `{"id":"bd-1","title":"Old title","description":"old text"}`
+	updates := `{"title":"New title"}`
+	plain := "open"
+
+	if issue := issueFromEvent(&types.Event{OldValue: &snapshot}); issue == nil || issue.Description != "old text" {
+		t.Errorf("expected issue snapshot, got %+v", issue)
+	}
+	if issue := issueFromEvent(&types.Event{OldValue: &updates}); issue != nil {
+		t.Errorf("update map without id should not decode as an issue: %+v", issue)
+	}
+	if issueFromEvent(&types.Event{OldValue: &plain}) != nil {
+		t.Error("plain old value should not decode as an issue")
+	}
+	if issueFromEvent(&types.Event{}) != nil {
+		t.Error("event without old value should not decode as an issue")
+	}
+}
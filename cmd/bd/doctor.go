@@ -98,6 +98,8 @@ Specific Check Mode (--check):
   - artifacts: Detect and optionally clean beads classic artifacts
     (stale JSONL, SQLite files, cruft .beads dirs). Use with --clean.
   - pollution: Detect and optionally clean test issues from database
+  - secrets: List issues whose text looks like it contains secrets or
+    scan.pii-patterns matches, and issues quarantined by scan.mode
   - validate: Run focused data-integrity checks (duplicates, orphaned
     deps, test pollution, git conflicts). Use with --fix to auto-repair.
 
@@ -149,6 +151,7 @@ Examples:
   bd doctor --check=artifacts --clean  # Delete safe-to-delete artifacts (with confirmation)
   bd doctor --check=pollution          # Show potential test issues
   bd doctor --check=pollution --clean  # Delete test issues (with confirmation)
+  bd doctor --check=secrets            # List issues with pasted credentials
   bd doctor --check=validate         # Data-integrity checks only
   bd doctor --check=validate --fix   # Auto-fix data-integrity issues
   bd doctor --deep             # Full graph integrity validation
@@ -201,8 +204,14 @@ Examples:
 			case "artifacts":
 				runArtifactsCheck(absPath, doctorClean, doctorYes)
 				return
+			case "storage":
+				runStorageCheck(absPath)
+				return
+			case "secrets":
+				runSecretsCheck(absPath)
+				return
 			default:
-				FatalErrorWithHint(fmt.Sprintf("unknown check %q", doctorCheckFlag), "Available checks: artifacts, pollution, validate")
+				FatalErrorWithHint(fmt.Sprintf("unknown check %q", doctorCheckFlag), "Available checks: artifacts, pollution, secrets, storage, validate")
 			}
 		}
 
@@ -621,6 +630,11 @@ func runDiagnostics(path string) doctorResult {
 	result.Checks = append(result.Checks, pollutionCheck)
 	// Don't fail overall check for test pollution, just warn
 
+	// Check 24a: Secrets or PII pasted into issue text
+	secretsCheck := convertDoctorCheck(doctor.CheckSecrets(path))
+	result.Checks = append(result.Checks, secretsCheck)
+	// Don't fail overall check for secrets, just warn
+
 	// Check 25: Git conflicts in JSONL (from bd validate)
 	conflictsCheck := convertDoctorCheck(doctor.CheckGitConflicts(path))
 	result.Checks = append(result.Checks, conflictsCheck)
@@ -684,6 +698,16 @@ func runDiagnostics(path string) doctorResult {
 	result.Checks = append(result.Checks, classicArtifactsCheck)
 	// Don't fail overall check for classic artifacts, just warn
 
+	// Check 33a: Intent log (operations interrupted by a crash)
+	intentLogCheck := convertDoctorCheck(doctor.CheckIntentLog(path))
+	result.Checks = append(result.Checks, intentLogCheck)
+	// Don't fail overall check for interrupted operations, just warn
+
+	// Check 34: Storage health (Dolt chunk store size and fragmentation)
+	storageCheck := convertDoctorCheck(doctor.CheckStorageHealth(path))
+	result.Checks = append(result.Checks, storageCheck)
+	// Don't fail overall check for storage size, just warn
+
 	return result
 }
 
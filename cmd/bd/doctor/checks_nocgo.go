@@ -2,6 +2,8 @@
 
 package doctor
 
+import "fmt"
+
 // Non-CGO stubs for doctor checks that require Dolt database access.
 // These checks are skipped in non-CGO builds.
 
@@ -56,3 +58,11 @@ func CheckCompactionCandidates(_ string) DoctorCheck {
 func FixStaleMQFiles(_ string) error {
 	return nil
 }
+
+func CheckSecrets(_ string) DoctorCheck {
+	return DoctorCheck{Name: "Secrets in Issues", Status: StatusOK, Message: "Requires CGO"}
+}
+
+func FindIssueSecrets(_ string) ([]IssueSecrets, error) {
+	return nil, fmt.Errorf("secret scan requires CGO")
+}
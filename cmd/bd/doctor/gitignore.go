@@ -18,10 +18,15 @@ bd.sock
 bd.sock.startlock
 sync-state.json
 last-touched
+last-gc
+crashes/
 
 # Local version tracking (prevents upgrade notification spam after git ops)
 .local_version
 
+# Retention manifest signing key (private; publish only its fingerprint)
+retention.key
+
 # Worktree redirect file (contains relative path to main repo's .beads/)
 # Must not be committed as paths would be wrong in other clones
 redirect
@@ -213,7 +213,7 @@ func CheckDependencyCycles(path string) DoctorCheck {
 		Status:  StatusError,
 		Message: fmt.Sprintf("Found %d circular dependency cycle(s)", cycleCount),
 		Detail:  fmt.Sprintf("First cycle involves: %s", firstCycle),
-		Fix:     "Run 'bd dep cycles' to see full cycle paths, then 'bd dep remove' to break cycles",
+		Fix:     "Run 'bd dep check' to see full cycle paths, then 'bd dep remove' to break cycles",
 	}
 }
 
@@ -0,0 +1,115 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+)
+
+// CheckIntentLog reports multi-statement operations (create with deps, batch
+// close, federation sync) that were interrupted by a crash. Abandoned intents
+// are normally resolved automatically the next time bd opens the database;
+// this check surfaces ones that are still pending or that recovery could not
+// resolve, plus a summary of recent recoveries.
+func CheckIntentLog(path string) DoctorCheck {
+	_, beadsDir := getBackendAndBeadsDir(path)
+
+	doltPath := filepath.Join(beadsDir, "dolt")
+	if _, err := os.Stat(doltPath); os.IsNotExist(err) {
+		return DoctorCheck{
+			Name:     "Intent Log",
+			Status:   StatusOK,
+			Message:  "N/A (no dolt database)",
+			Category: CategoryData,
+		}
+	}
+
+	ctx := context.Background()
+	store, err := dolt.New(ctx, &dolt.Config{Path: doltPath, ReadOnly: true, Database: doltDatabaseName(beadsDir)})
+	if err != nil {
+		return DoctorCheck{
+			Name:     "Intent Log",
+			Status:   StatusOK,
+			Message:  "N/A (unable to open database)",
+			Category: CategoryData,
+		}
+	}
+	defer func() { _ = store.Close() }()
+
+	intents, err := store.GetIntents(ctx, "")
+	if err != nil {
+		// Databases that haven't run the intent_log migration yet have nothing to report
+		if strings.Contains(err.Error(), "doesn't exist") || strings.Contains(err.Error(), "not found") {
+			return DoctorCheck{
+				Name:     "Intent Log",
+				Status:   StatusOK,
+				Message:  "No interrupted operations",
+				Category: CategoryData,
+			}
+		}
+		return DoctorCheck{
+			Name:     "Intent Log",
+			Status:   StatusWarning,
+			Message:  "Unable to read intent log",
+			Detail:   err.Error(),
+			Category: CategoryData,
+		}
+	}
+	return evaluateIntentLog(intents)
+}
+
+// evaluateIntentLog summarizes intent log entries as a doctor check.
+func evaluateIntentLog(intents []*storage.Intent) DoctorCheck {
+	check := DoctorCheck{
+		Name:     "Intent Log",
+		Status:   StatusOK,
+		Message:  "No interrupted operations",
+		Category: CategoryData,
+	}
+
+	var pending, failed, recovered []*storage.Intent
+	for _, in := range intents {
+		switch in.Status {
+		case storage.IntentPending:
+			pending = append(pending, in)
+		case storage.IntentFailed:
+			failed = append(failed, in)
+		case storage.IntentRolledForward, storage.IntentRolledBack:
+			recovered = append(recovered, in)
+		}
+	}
+
+	var details []string
+	for _, in := range failed {
+		details = append(details, fmt.Sprintf("#%d %s %s (failed): %s", in.ID, in.Op, in.Target, in.Resolution))
+	}
+	for _, in := range pending {
+		details = append(details, fmt.Sprintf("#%d %s %s (pending since %s, pid %d on %s)",
+			in.ID, in.Op, in.Target, in.CreatedAt.Local().Format("2006-01-02 15:04"), in.PID, in.Host))
+	}
+	for _, in := range recovered {
+		details = append(details, fmt.Sprintf("#%d %s (%s): %s", in.ID, in.Op, in.Status, in.Resolution))
+	}
+	check.Detail = strings.Join(details, "\n")
+
+	switch {
+	case len(failed) > 0:
+		check.Status = StatusWarning
+		check.Message = fmt.Sprintf("%d interrupted operation(s) could not be recovered", len(failed))
+		check.Fix = "Review the listed operations and repair by hand, then clear them:\n" +
+			"  bd sql \"DELETE FROM intent_log WHERE status = 'failed'\""
+	case len(pending) > 0:
+		check.Status = StatusWarning
+		check.Message = fmt.Sprintf("%d incomplete operation(s) in progress or interrupted", len(pending))
+		check.Fix = "If no other bd process is running, run any bd command that opens the database\n" +
+			"(e.g. 'bd ready') to roll interrupted operations forward or back"
+	case len(recovered) > 0:
+		check.Message = fmt.Sprintf("%d interrupted operation(s) recovered", len(recovered))
+	}
+	return check
+}
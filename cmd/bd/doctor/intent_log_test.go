@@ -0,0 +1,74 @@
+package doctor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestEvaluateIntentLog(t *testing.T) {
+	now := time.Now()
+	resolved := now.Add(-time.Minute)
+
+	tests := []struct {
+		name        string
+		intents     []*storage.Intent
+		wantStatus  string
+		wantMessage string
+		wantDetail  string
+	}{
+		{
+			name:        "empty",
+			wantStatus:  StatusOK,
+			wantMessage: "No interrupted operations",
+		},
+		{
+			name: "recovered only",
+			intents: []*storage.Intent{
+				{ID: 1, Op: storage.IntentCreate, Target: "bd-1", Status: storage.IntentRolledForward,
+					Resolution: "bd-1: applied label x", CreatedAt: now, ResolvedAt: &resolved},
+			},
+			wantStatus:  StatusOK,
+			wantMessage: "1 interrupted operation(s) recovered",
+			wantDetail:  "#1 create (rolled_forward): bd-1: applied label x",
+		},
+		{
+			name: "pending",
+			intents: []*storage.Intent{
+				{ID: 2, Op: storage.IntentClose, Target: "bd-2", Status: storage.IntentPending,
+					PID: 1234, Host: "box", CreatedAt: now},
+			},
+			wantStatus:  StatusWarning,
+			wantMessage: "1 incomplete operation(s)",
+			wantDetail:  "pid 1234 on box",
+		},
+		{
+			name: "failed wins over pending",
+			intents: []*storage.Intent{
+				{ID: 3, Op: storage.IntentSync, Target: "peer", Status: storage.IntentFailed,
+					Resolution: "failed to abort merge", CreatedAt: now},
+				{ID: 4, Op: storage.IntentClose, Status: storage.IntentPending, CreatedAt: now},
+			},
+			wantStatus:  StatusWarning,
+			wantMessage: "1 interrupted operation(s) could not be recovered",
+			wantDetail:  "#3 sync peer (failed): failed to abort merge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := evaluateIntentLog(tt.intents)
+			if check.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", check.Status, tt.wantStatus)
+			}
+			if !strings.Contains(check.Message, tt.wantMessage) {
+				t.Errorf("Message = %q, want it to contain %q", check.Message, tt.wantMessage)
+			}
+			if !strings.Contains(check.Detail, tt.wantDetail) {
+				t.Errorf("Detail = %q, want it to contain %q", check.Detail, tt.wantDetail)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+//go:build cgo
+
+package doctor
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/secretscan"
+)
+
+// FindIssueSecrets scans the text fields and comments of every issue for
+// likely secrets and scan.pii-patterns matches, and also reports issues
+// carrying scan.quarantine-label. Results are ordered by issue ID.
+func FindIssueSecrets(path string) ([]IssueSecrets, error) {
+	beadsDir := resolveBeadsDir(filepath.Join(path, ".beads"))
+	db, store, err := openStoreDB(beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = store.Close() }()
+
+	scanner, err := secretscan.New(config.GetStringMapString("scan.pii-patterns"))
+	if err != nil {
+		return nil, fmt.Errorf("scan.pii-patterns: %w", err)
+	}
+
+	byID := make(map[string]*IssueSecrets)
+	get := func(id, title string) *IssueSecrets {
+		r, ok := byID[id]
+		if !ok {
+			r = &IssueSecrets{IssueID: id, Title: title}
+			byID[id] = r
+		}
+		return r
+	}
+
+	rows, err := db.Query(`SELECT id, title, description, design, acceptance_criteria, notes FROM issues ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("querying issues: %w", err)
+	}
+	titles := make(map[string]string)
+	for rows.Next() {
+		var id, title, description, design, acceptance, notes string
+		if err := rows.Scan(&id, &title, &description, &design, &acceptance, &notes); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scanning issue: %w", err)
+		}
+		titles[id] = title
+		findings := scanner.ScanFields(map[string]string{
+			"title": title, "description": description, "design": design,
+			"acceptance_criteria": acceptance, "notes": notes,
+		})
+		if len(findings) > 0 {
+			get(id, title).Findings = findings
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("querying issues: %w", err)
+	}
+
+	rows, err = db.Query(`SELECT issue_id, text FROM comments ORDER BY issue_id, created_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("querying comments: %w", err)
+	}
+	for rows.Next() {
+		var id, text string
+		if err := rows.Scan(&id, &text); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scanning comment: %w", err)
+		}
+		if findings := scanner.Scan("comment", text); len(findings) > 0 {
+			r := get(id, titles[id])
+			r.Findings = append(r.Findings, findings...)
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("querying comments: %w", err)
+	}
+
+	if label := config.GetString("scan.quarantine-label"); label != "" {
+		rows, err = db.Query(`SELECT issue_id FROM labels WHERE label = ? ORDER BY issue_id`, label)
+		if err != nil {
+			return nil, fmt.Errorf("querying labels: %w", err)
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("scanning label: %w", err)
+			}
+			get(id, titles[id]).Quarantined = true
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("querying labels: %w", err)
+		}
+	}
+
+	results := make([]IssueSecrets, 0, len(byID))
+	for _, r := range byID {
+		results = append(results, *r)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].IssueID < results[j].IssueID })
+	return results, nil
+}
+
+// CheckSecrets reports issues whose text looks like it contains secrets
+// or PII, and issues quarantined by the write-time scanner.
+func CheckSecrets(path string) DoctorCheck {
+	results, err := FindIssueSecrets(path)
+	if err != nil {
+		return DoctorCheck{
+			Name:     "Secrets in Issues",
+			Status:   StatusOK,
+			Message:  "N/A (unable to scan issues)",
+			Detail:   err.Error(),
+			Category: CategoryData,
+		}
+	}
+
+	var flagged, quarantined int
+	for _, r := range results {
+		if len(r.Findings) > 0 {
+			flagged++
+		}
+		if r.Quarantined {
+			quarantined++
+		}
+	}
+	if flagged == 0 && quarantined == 0 {
+		return DoctorCheck{
+			Name:     "Secrets in Issues",
+			Status:   StatusOK,
+			Message:  "No likely secrets found",
+			Category: CategoryData,
+		}
+	}
+	return DoctorCheck{
+		Name:     "Secrets in Issues",
+		Status:   StatusWarning,
+		Message:  fmt.Sprintf("%d issue(s) look like they contain secrets or PII, %d quarantined", flagged, quarantined),
+		Detail:   "Issue text is committed to Dolt history and shared with every clone; rotate any real credentials",
+		Fix:      "Run 'bd doctor --check=secrets' to list them",
+		Category: CategoryData,
+	}
+}
@@ -0,0 +1,135 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage/dolt"
+)
+
+// Storage health thresholds. Vars (not consts) so tests can override.
+var (
+	// storageSizeWarnBytes warns when the Dolt data directory grows past this size.
+	storageSizeWarnBytes int64 = 1 << 30 // 1 GiB
+	// storageTableFileWarn warns when the live chunk store is split across this
+	// many table files. Every write session adds files until a GC consolidates
+	// them, so a high count means reads touch many files (fragmentation).
+	storageTableFileWarn = 256
+	// storageSQLiteWarnBytes warns about leftover legacy SQLite databases.
+	storageSQLiteWarnBytes int64 = 100 << 20 // 100 MiB
+)
+
+// CheckStorageHealth reports the on-disk size of the Dolt chunk store and
+// warns when it is large or fragmented enough that `bd storage gc` would help.
+// Leftover SQLite database files from the classic backend are included since
+// they are easy to forget and can be much larger than the Dolt store.
+func CheckStorageHealth(path string) DoctorCheck {
+	_, beadsDir := getBackendAndBeadsDir(path)
+
+	var stats *dolt.StorageStats
+	doltDir := filepath.Join(beadsDir, "dolt")
+	if s, err := dolt.CollectStorageStats(doltDir); err == nil {
+		stats = s
+	}
+
+	return evaluateStorageHealth(stats, findSQLiteFiles(beadsDir))
+}
+
+// evaluateStorageHealth turns collected storage stats into a doctor check.
+// Split from CheckStorageHealth so the thresholds can be tested without
+// building multi-gigabyte fixtures.
+func evaluateStorageHealth(stats *dolt.StorageStats, sqliteFiles map[string]int64) DoctorCheck {
+	check := DoctorCheck{
+		Name:     "Storage Health",
+		Status:   StatusOK,
+		Category: CategoryMaintenance,
+	}
+
+	var problems []string
+	if stats == nil {
+		check.Message = "N/A (no local Dolt data directory)"
+	} else {
+		check.Message = fmt.Sprintf("Dolt store %s (%d table files)", formatStorageBytes(stats.TotalBytes), stats.TableFiles)
+		if stats.TotalBytes > storageSizeWarnBytes {
+			problems = append(problems, fmt.Sprintf("Dolt store is %s (threshold %s)",
+				formatStorageBytes(stats.TotalBytes), formatStorageBytes(storageSizeWarnBytes)))
+		}
+		if stats.TableFiles > storageTableFileWarn {
+			problems = append(problems, fmt.Sprintf("chunk store is fragmented across %d table files (threshold %d)",
+				stats.TableFiles, storageTableFileWarn))
+		}
+	}
+
+	var sqliteNames []string
+	for name := range sqliteFiles {
+		sqliteNames = append(sqliteNames, name)
+	}
+	sort.Strings(sqliteNames)
+	largeSQLite := false
+	for _, name := range sqliteNames {
+		if size := sqliteFiles[name]; size > storageSQLiteWarnBytes {
+			problems = append(problems, fmt.Sprintf("legacy SQLite file %s is %s", name, formatStorageBytes(size)))
+			largeSQLite = true
+		}
+	}
+
+	if len(problems) == 0 {
+		return check
+	}
+
+	check.Status = StatusWarning
+	check.Message = strings.Join(problems, "; ")
+	var fixes []string
+	if stats != nil && (stats.TotalBytes > storageSizeWarnBytes || stats.TableFiles > storageTableFileWarn) {
+		fixes = append(fixes, "Run 'bd storage gc' to reclaim space and consolidate table files")
+	}
+	if largeSQLite {
+		fixes = append(fixes, "Remove legacy SQLite files after confirming migration ('bd doctor --check artifacts --clean')")
+	}
+	check.Fix = strings.Join(fixes, "\n")
+	if stats != nil {
+		check.Detail = fmt.Sprintf("Live chunks: %s, journal: %s, oldgen: %s",
+			formatStorageBytes(stats.NomsBytes), formatStorageBytes(stats.JournalSize), formatStorageBytes(stats.OldgenBytes))
+	}
+	return check
+}
+
+// findSQLiteFiles returns the sizes of legacy SQLite database files
+// (including WAL/SHM sidecars) in the beads directory, keyed by file name.
+func findSQLiteFiles(beadsDir string) map[string]int64 {
+	files := make(map[string]int64)
+	entries, err := os.ReadDir(beadsDir)
+	if err != nil {
+		return files
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".db") && !strings.HasSuffix(name, ".db-wal") && !strings.HasSuffix(name, ".db-shm") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			files[name] = info.Size()
+		}
+	}
+	return files
+}
+
+// formatStorageBytes formats a byte count as a human-readable string.
+func formatStorageBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
+}
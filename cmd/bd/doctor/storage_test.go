@@ -0,0 +1,121 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/dolt"
+)
+
+func TestEvaluateStorageHealth(t *testing.T) {
+	tests := []struct {
+		name        string
+		stats       *dolt.StorageStats
+		sqlite      map[string]int64
+		wantStatus  string
+		wantMessage string
+		wantFix     string
+	}{
+		{
+			name:        "no dolt dir",
+			stats:       nil,
+			wantStatus:  StatusOK,
+			wantMessage: "N/A",
+		},
+		{
+			name:        "healthy",
+			stats:       &dolt.StorageStats{TotalBytes: 10 << 20, TableFiles: 12},
+			wantStatus:  StatusOK,
+			wantMessage: "10.0 MB (12 table files)",
+		},
+		{
+			name:        "too large",
+			stats:       &dolt.StorageStats{TotalBytes: 2 << 30, TableFiles: 12},
+			wantStatus:  StatusWarning,
+			wantMessage: "Dolt store is 2.0 GB",
+			wantFix:     "bd storage gc",
+		},
+		{
+			name:        "fragmented",
+			stats:       &dolt.StorageStats{TotalBytes: 10 << 20, TableFiles: 500},
+			wantStatus:  StatusWarning,
+			wantMessage: "fragmented across 500 table files",
+			wantFix:     "bd storage gc",
+		},
+		{
+			name:        "small sqlite leftovers are fine",
+			stats:       &dolt.StorageStats{TotalBytes: 10 << 20},
+			sqlite:      map[string]int64{"beads.db": 1 << 20},
+			wantStatus:  StatusOK,
+			wantMessage: "Dolt store",
+		},
+		{
+			name:        "large sqlite leftover",
+			stats:       &dolt.StorageStats{TotalBytes: 10 << 20},
+			sqlite:      map[string]int64{"beads.db": 200 << 20},
+			wantStatus:  StatusWarning,
+			wantMessage: "legacy SQLite file beads.db is 200.0 MB",
+			wantFix:     "artifacts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := evaluateStorageHealth(tt.stats, tt.sqlite)
+			if check.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q (message: %s)", check.Status, tt.wantStatus, check.Message)
+			}
+			if !strings.Contains(check.Message, tt.wantMessage) {
+				t.Errorf("Message = %q, want it to contain %q", check.Message, tt.wantMessage)
+			}
+			if !strings.Contains(check.Fix, tt.wantFix) {
+				t.Errorf("Fix = %q, want it to contain %q", check.Fix, tt.wantFix)
+			}
+		})
+	}
+}
+
+func TestCheckStorageHealth_CountsTableFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	nomsDir := filepath.Join(tmpDir, ".beads", "dolt", "beads", ".dolt", "noms")
+	if err := os.MkdirAll(filepath.Join(nomsDir, "oldgen"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]int{
+		"manifest":                         10,
+		"LOCK":                             0,
+		"vvvvvvvvvvvvvvvvvvvvvvvvvvvvvvvv": 100,
+		"0a1b2c3d4e5f6g7h8i9j0k1l2m3n4o5p": 1000,
+		"1a1b2c3d4e5f6g7h8i9j0k1l2m3n4o5p": 1000,
+		"oldgen/2a1b2c3d4e5f6g7h8i9j0k1l2": 500,
+	}
+	for name, size := range files {
+		if err := os.WriteFile(filepath.Join(nomsDir, name), make([]byte, size), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats, err := dolt.CollectStorageStats(filepath.Join(tmpDir, ".beads", "dolt"))
+	if err != nil {
+		t.Fatalf("CollectStorageStats: %v", err)
+	}
+	if stats.TableFiles != 2 {
+		t.Errorf("TableFiles = %d, want 2", stats.TableFiles)
+	}
+	if stats.JournalSize != 100 {
+		t.Errorf("JournalSize = %d, want 100", stats.JournalSize)
+	}
+	if stats.OldgenBytes != 500 {
+		t.Errorf("OldgenBytes = %d, want 500", stats.OldgenBytes)
+	}
+	if stats.TotalBytes != 2610 {
+		t.Errorf("TotalBytes = %d, want 2610", stats.TotalBytes)
+	}
+
+	check := CheckStorageHealth(tmpDir)
+	if check.Status != StatusOK {
+		t.Errorf("Status = %q, want ok: %s", check.Status, check.Message)
+	}
+}
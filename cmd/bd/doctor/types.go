@@ -1,5 +1,7 @@
 package doctor
 
+import "github.com/steveyegge/beads/internal/secretscan"
+
 // Status constants for doctor checks
 const (
 	StatusOK      = "ok"
@@ -52,3 +54,12 @@ type OrphanIssue struct {
 	LatestCommit        string
 	LatestCommitMessage string
 }
+
+// IssueSecrets lists what the secret scanner found in one issue.
+// This is shared between the doctor check and 'bd doctor --check=secrets'.
+type IssueSecrets struct {
+	IssueID     string               `json:"issue_id"`
+	Title       string               `json:"title"`
+	Quarantined bool                 `json:"quarantined"`
+	Findings    []secretscan.Finding `json:"findings,omitempty"`
+}
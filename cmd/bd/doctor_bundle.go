@@ -0,0 +1,290 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/secretscan"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/writepolicy"
+)
+
+var doctorBundleCmd = &cobra.Command{
+	Use:   "bundle [path]",
+	Short: "Write a redacted diagnostic bundle to attach to bug reports",
+	Long: `Write a gzipped tarball of diagnostics to attach to a bug report:
+
+  manifest.json      bd version, platform, and the bundle's contents
+  doctor.json        bd doctor results
+  capabilities.json  bd capabilities (cgo, Dolt server, optional features)
+  config/            .beads/config.yaml and metadata.json
+  schema.json        Database schema version
+  stats.json         Issue counts by status (no titles, IDs, or people)
+  logs/              The last lines of the Dolt server logs
+  crashes/           The most recent crash reports
+
+Secrets, scan.pii-patterns matches, and email addresses are redacted, and
+your home directory is replaced by ~. Issue titles and descriptions are not
+collected, though doctor results may mention issue IDs. Review the bundle
+before sharing it.
+
+Examples:
+  bd doctor bundle                       # bd-diagnostics-<time>.tar.gz here
+  bd doctor bundle -o /tmp/report.tar.gz`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runDoctorBundle,
+}
+
+func init() {
+	doctorBundleCmd.Flags().StringP("output", "o", "", "Bundle path (default: bd-diagnostics-<time>.tar.gz)")
+	doctorCmd.AddCommand(doctorBundleCmd)
+}
+
+const (
+	bundleLogLines   = 200 // Lines kept from the end of each log
+	bundleMaxCrashes = 5   // Most recent crash reports included
+)
+
+// bundleLogs are the log files, relative to .beads, included in bundles.
+var bundleLogs = []string{"dolt-server.log", "dolt-config.log", "daemon.log"}
+
+// bundleFile is one file in a diagnostic bundle.
+type bundleFile struct {
+	Name string
+	Data []byte
+}
+
+// bundleRedactor scrubs text before it goes into a bundle.
+type bundleRedactor struct {
+	scanner *secretscan.Scanner // Secrets and configured PII
+	home    string              // Home directory, replaced by ~
+}
+
+func (r bundleRedactor) redact(data []byte) []byte {
+	text := r.scanner.Redact(string(data), redacted)
+	text = emailPattern.ReplaceAllString(text, redacted)
+	if r.home != "" && r.home != "/" {
+		text = strings.ReplaceAll(text, r.home, "~")
+	}
+	return []byte(text)
+}
+
+// diagnosticBundle collects files for a bundle, redacting each one.
+type diagnosticBundle struct {
+	redactor bundleRedactor
+	files    []bundleFile
+}
+
+func (b *diagnosticBundle) add(name string, data []byte) {
+	b.files = append(b.files, bundleFile{Name: name, Data: b.redactor.redact(data)})
+}
+
+func (b *diagnosticBundle) addJSON(name string, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		data = []byte(fmt.Sprintf(`{"error": %q}`, err.Error()))
+	}
+	b.add(name, append(data, '\n'))
+}
+
+// addFile adds the file at path, if it exists, keeping only its last
+// maxLines lines when maxLines > 0.
+func (b *diagnosticBundle) addFile(name, path string, maxLines int) {
+	// #nosec G304 -- paths are fixed names under .beads
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	if maxLines > 0 {
+		data = tailLines(data, maxLines)
+	}
+	b.add(name, data)
+}
+
+// tailLines returns the last n lines of data.
+func tailLines(data []byte, n int) []byte {
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= n {
+		return data
+	}
+	return bytes.Join(lines[len(lines)-n:], nil)
+}
+
+// recentCrashReports returns the paths of the newest crash reports in dir,
+// newest first.
+func recentCrashReports(dir string, limit int) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), "crash-") {
+			names = append(names, e.Name())
+		}
+	}
+	// Names start with a UTC timestamp, so they sort by age
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	if len(names) > limit {
+		names = names[:limit]
+	}
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = filepath.Join(dir, name)
+	}
+	return paths
+}
+
+// collectDatabaseInfo adds the schema version and anonymized statistics,
+// or the reason they are unavailable.
+func (b *diagnosticBundle) collectDatabaseInfo(ctx context.Context, beadsDir string) {
+	s, err := dolt.NewFromConfigWithOptions(ctx, beadsDir, &dolt.Config{ReadOnly: true})
+	if err != nil {
+		unavailable := map[string]string{"error": "database unavailable: " + err.Error()}
+		b.addJSON("schema.json", unavailable)
+		b.addJSON("stats.json", unavailable)
+		return
+	}
+	defer func() { _ = s.Close() }()
+
+	schema := map[string]string{}
+	if v, err := s.GetConfig(ctx, "schema_version"); err == nil {
+		schema["schema_version"] = v
+	}
+	if v, err := s.GetMetadata(ctx, "bd_version"); err == nil {
+		schema["bd_version"] = v
+	}
+	b.addJSON("schema.json", schema)
+
+	if stats, err := s.GetStatistics(ctx); err == nil {
+		b.addJSON("stats.json", stats)
+	} else {
+		b.addJSON("stats.json", map[string]string{"error": err.Error()})
+	}
+}
+
+// writeBundle writes files to path as a gzipped tarball.
+func writeBundle(path string, files []bundleFile, now time.Time) error {
+	// #nosec G304 -- path is the user's --output choice
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, file := range files {
+		hdr := &tar.Header{Name: file.Name, Mode: 0600, Size: int64(len(file.Data)), ModTime: now}
+		if err := tw.WriteHeader(hdr); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if _, err := tw.Write(file.Data); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func runDoctorBundle(cmd *cobra.Command, args []string) {
+	output, _ := cmd.Flags().GetString("output")
+	now := time.Now()
+	if output == "" {
+		output = fmt.Sprintf("bd-diagnostics-%s.tar.gz", now.Format("20060102-150405"))
+	}
+
+	checkPath := "."
+	if len(args) > 0 {
+		checkPath = args[0]
+	} else if beadsDir := os.Getenv("BEADS_DIR"); beadsDir != "" {
+		checkPath = filepath.Dir(beadsDir)
+	}
+	absPath, err := filepath.Abs(checkPath)
+	if err != nil {
+		FatalErrorRespectJSON("failed to resolve path: %v", err)
+	}
+	beadsDir := beads.FollowRedirect(filepath.Join(absPath, ".beads"))
+
+	scanner, err := writepolicy.NewSecretScanner()
+	if err != nil {
+		WarnError("scan.pii-patterns: %v (redacting secrets only)", err)
+		scanner = secretscan.Default()
+	}
+	home, _ := os.UserHomeDir()
+	b := &diagnosticBundle{redactor: bundleRedactor{scanner: scanner, home: home}}
+
+	result := runDiagnostics(absPath)
+	result.Timestamp = now.UTC().Format(time.RFC3339)
+	result.Platform = map[string]string{"os_arch": runtime.GOOS + "/" + runtime.GOARCH, "go_version": runtime.Version()}
+	b.addJSON("doctor.json", result)
+
+	env, autoStart := probeWorkspaceEnvironment()
+	b.addJSON("capabilities.json", map[string]interface{}{
+		"cgo":          env.CGO,
+		"server":       env.ServerAddr,
+		"capabilities": detectCapabilities(env, autoStart),
+	})
+
+	b.addFile("config/config.yaml", filepath.Join(beadsDir, "config.yaml"), 0)
+	b.addFile("config/metadata.json", filepath.Join(beadsDir, "metadata.json"), 0)
+	b.collectDatabaseInfo(rootCtx, beadsDir)
+	for _, name := range bundleLogs {
+		b.addFile("logs/"+name, filepath.Join(beadsDir, name), bundleLogLines)
+	}
+	for _, path := range recentCrashReports(filepath.Join(beadsDir, "crashes"), bundleMaxCrashes) {
+		b.addFile("crashes/"+filepath.Base(path), path, 0)
+	}
+
+	names := make([]string, 0, len(b.files)+1)
+	names = append(names, "manifest.json")
+	for _, f := range b.files {
+		names = append(names, f.Name)
+	}
+	manifest := bundleFile{Name: "manifest.json"}
+	manifest.Data, _ = json.MarshalIndent(map[string]interface{}{
+		"bd_version": Version,
+		"build":      Build,
+		"created_at": result.Timestamp,
+		"os_arch":    result.Platform["os_arch"],
+		"go_version": result.Platform["go_version"],
+		"files":      names,
+	}, "", "  ")
+	files := append([]bundleFile{manifest}, b.files...)
+
+	if err := writeBundle(output, files, now); err != nil {
+		FatalErrorRespectJSON("failed to write bundle: %v", err)
+	}
+	if jsonOutput {
+		outputJSON(map[string]interface{}{"path": output, "files": names})
+		return
+	}
+	fmt.Printf("%s Wrote %s (%d files)\n", ui.RenderPass("✓"), output, len(files))
+	for _, name := range names {
+		fmt.Printf("  %s\n", ui.RenderMuted(name))
+	}
+	fmt.Printf("\nSecrets and email addresses are redacted, but review the bundle before\nattaching it to a bug report at %s\n", bugReportURL)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/steveyegge/beads/cmd/bd/doctor"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// runSecretsCheck lists issues whose text looks like it contains secrets or
+// PII, and issues quarantined by the write-time scanner (see scan.mode).
+func runSecretsCheck(path string) {
+	results, err := doctor.FindIssueSecrets(path)
+	if err != nil {
+		FatalErrorRespectJSON("scanning issues: %v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"count":  len(results),
+			"issues": results,
+		})
+		return
+	}
+
+	if len(results) == 0 {
+		fmt.Printf("%s No likely secrets or quarantined issues found\n", ui.RenderPass("✓"))
+		return
+	}
+
+	fmt.Printf("Found %d issue(s) to review:\n\n", len(results))
+	for _, r := range results {
+		note := ""
+		if r.Quarantined {
+			note = " " + ui.RenderWarn("[quarantined]")
+		}
+		fmt.Printf("  %s: %s%s\n", ui.RenderID(r.IssueID), r.Title, note)
+		for _, f := range r.Findings {
+			fmt.Printf("    - %s: %s (%s)\n", f.Field, f.Rule, f.Match)
+		}
+	}
+	fmt.Println()
+	fmt.Println("Edit the text out with 'bd update' or 'bd edit', and rotate any real credentials:")
+	fmt.Println("they remain in Dolt history and in every clone that has pulled them.")
+}
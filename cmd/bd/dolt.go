@@ -25,6 +25,9 @@ var doltCmd = &cobra.Command{
 	Long: `Configure and manage Dolt database settings and server lifecycle.
 
 Beads connects to a running dolt sql-server for all database operations.
+When the configured server is local (127.0.0.1/localhost) and not running,
+bd starts one automatically so all bd processes share a single server
+(disable with 'bd dolt set auto-start false' or BEADS_DOLT_AUTO_START=0).
 
 Commands:
   bd dolt show         Show current Dolt configuration with connection test
@@ -37,10 +40,11 @@ Commands:
   bd dolt pull         Pull commits from Dolt remote
 
 Configuration keys for 'bd dolt set':
-  database  Database name (default: issue prefix or "beads")
-  host      Server host (default: 127.0.0.1)
-  port      Server port (default: 3307)
-  user      MySQL user (default: root)
+  database    Database name (default: issue prefix or "beads")
+  host        Server host (default: 127.0.0.1)
+  port        Server port (default: 3307)
+  user        MySQL user (default: root)
+  auto-start  Start a local server when none is reachable (default: true)
 
 Flags for 'bd dolt set':
   --update-config  Also write to config.yaml for team-wide defaults
@@ -67,10 +71,11 @@ var doltSetCmd = &cobra.Command{
 	Long: `Set a Dolt configuration value in metadata.json.
 
 Keys:
-  database  Database name (default: issue prefix or "beads")
-  host      Server host (default: 127.0.0.1)
-  port      Server port (default: 3307)
-  user      MySQL user (default: root)
+  database    Database name (default: issue prefix or "beads")
+  host        Server host (default: 127.0.0.1)
+  port        Server port (default: 3307)
+  user        MySQL user (default: root)
+  auto-start  Start a local server when none is reachable (default: true)
 
 Use --update-config to also write to config.yaml for team-wide defaults.
 
@@ -281,6 +286,7 @@ func showDoltConfig(testConnection bool) {
 			result["host"] = cfg.GetDoltServerHost()
 			result["port"] = cfg.GetDoltServerPort()
 			result["user"] = cfg.GetDoltServerUser()
+			result["auto_start"] = cfg.GetDoltAutoStart()
 			if testConnection {
 				result["connection_ok"] = testServerConnection(cfg)
 			}
@@ -300,6 +306,7 @@ func showDoltConfig(testConnection bool) {
 	fmt.Printf("  Host:     %s\n", cfg.GetDoltServerHost())
 	fmt.Printf("  Port:     %d\n", cfg.GetDoltServerPort())
 	fmt.Printf("  User:     %s\n", cfg.GetDoltServerUser())
+	fmt.Printf("  Auto-start: %v\n", cfg.GetDoltAutoStart())
 
 	if testConnection {
 		fmt.Println()
@@ -378,9 +385,18 @@ func setDoltConfig(key, value string, updateConfig bool) {
 		cfg.DoltServerUser = value
 		yamlKey = "dolt.user"
 
+	case "auto-start":
+		autoStart, err := strconv.ParseBool(value)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: auto-start must be true or false\n")
+			os.Exit(1)
+		}
+		cfg.DoltAutoStart = &autoStart
+		yamlKey = "dolt.auto-start"
+
 	default:
 		fmt.Fprintf(os.Stderr, "Error: unknown key '%s'\n", key)
-		fmt.Fprintf(os.Stderr, "Valid keys: mode, database, host, port, user\n")
+		fmt.Fprintf(os.Stderr, "Valid keys: mode, database, host, port, user, auto-start\n")
 		os.Exit(1)
 	}
 
@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/resolution"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/utils"
@@ -99,7 +100,8 @@ func runDuplicate(cmd *cobra.Command, args []string) error {
 	// Close the duplicate issue
 	closedStatus := string(types.StatusClosed)
 	updates := map[string]interface{}{
-		"status": closedStatus,
+		"status":       closedStatus,
+		"close_reason": resolution.Resolution{Category: resolution.Duplicate, Note: "of " + canonicalID}.String(),
 	}
 	if err := store.UpdateIssue(ctx, duplicateID, updates, actor); err != nil {
 		return fmt.Errorf("failed to close duplicate: %w", err)
@@ -161,7 +163,8 @@ func runSupersede(cmd *cobra.Command, args []string) error {
 	// Close the superseded issue
 	closedStatus := string(types.StatusClosed)
 	updates := map[string]interface{}{
-		"status": closedStatus,
+		"status":       closedStatus,
+		"close_reason": resolution.Resolution{Category: resolution.SupersededBy, Target: newID}.String(),
 	}
 	if err := store.UpdateIssue(ctx, oldID, updates, actor); err != nil {
 		return fmt.Errorf("failed to close superseded issue: %w", err)
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/resolution"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
@@ -363,7 +364,7 @@ func performMerge(targetID string, sourceIDs []string) map[string]interface{} {
 
 	for _, sourceID := range sourceIDs {
 		// Close the duplicate issue
-		reason := fmt.Sprintf("Duplicate of %s", targetID)
+		reason := resolution.Resolution{Category: resolution.Duplicate, Note: "merged into " + targetID}.String()
 		if err := store.CloseIssue(ctx, sourceID, reason, actor, ""); err != nil {
 			errors = append(errors, fmt.Sprintf("failed to close %s: %v", sourceID, err))
 			continue
@@ -1,14 +1,18 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/hooks"
 	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/utils"
 )
@@ -16,13 +20,21 @@ import (
 var editCmd = &cobra.Command{
 	Use:     "edit [id]",
 	GroupID: "issues",
-	Short:   "Edit an issue field in $EDITOR",
-	Long: `Edit an issue field using your configured $EDITOR.
+	Short:   "Edit an issue in $EDITOR",
+	Long: `Edit an issue using your configured $EDITOR.
 
-By default, edits the description. Use flags to edit other fields.
+By default, opens the issue as a YAML document (title, status, priority,
+type, assignee, labels, estimate, external ref and the text fields). When you
+save, the document is validated and only the fields you changed are applied,
+in a single transaction. If validation fails the editor reopens with the
+errors at the top; save without changes to give up. Delete everything to
+cancel.
+
+Use a field flag to edit just that field as plain text.
 
 Examples:
-  bd edit bd-42                    # Edit description
+  bd edit bd-42                    # Edit the whole issue as YAML
+  bd edit bd-42 --description      # Edit description only
   bd edit bd-42 --title            # Edit title
   bd edit bd-42 --design           # Edit design notes
   bd edit bd-42 --notes            # Edit notes
@@ -41,9 +53,11 @@ Examples:
 		id = fullID
 
 		// Determine which field to edit
-		fieldToEdit := "description"
+		fieldToEdit := ""
 		if cmd.Flags().Changed("title") {
 			fieldToEdit = "title"
+		} else if cmd.Flags().Changed("description") {
+			fieldToEdit = "description"
 		} else if cmd.Flags().Changed("design") {
 			fieldToEdit = "design"
 		} else if cmd.Flags().Changed("notes") {
@@ -52,24 +66,6 @@ Examples:
 			fieldToEdit = "acceptance_criteria"
 		}
 
-		// Get the editor from environment
-		editor := os.Getenv("EDITOR")
-		if editor == "" {
-			editor = os.Getenv("VISUAL")
-		}
-		if editor == "" {
-			// Try common defaults
-			for _, defaultEditor := range []string{"vim", "vi", "nano", "emacs"} {
-				if _, err := exec.LookPath(defaultEditor); err == nil {
-					editor = defaultEditor
-					break
-				}
-			}
-		}
-		if editor == "" {
-			FatalErrorRespectJSON("no editor found. Set $EDITOR or $VISUAL environment variable")
-		}
-
 		// Get the current issue
 		issue, err := store.GetIssue(ctx, id)
 		if err != nil {
@@ -79,6 +75,11 @@ Examples:
 			FatalErrorRespectJSON("fetching issue %s: %v", id, err)
 		}
 
+		if fieldToEdit == "" {
+			editIssueDocument(ctx, issue)
+			return
+		}
+
 		// Get the current field value
 		var currentValue string
 		switch fieldToEdit {
@@ -94,40 +95,10 @@ Examples:
 			currentValue = issue.AcceptanceCriteria
 		}
 
-		// Create a temporary file with the current value
-		tmpFile, err := os.CreateTemp("", fmt.Sprintf("bd-edit-%s-*.txt", fieldToEdit))
-		if err != nil {
-			FatalErrorRespectJSON("creating temp file: %v", err)
-		}
-		tmpPath := tmpFile.Name()
-		defer func() { _ = os.Remove(tmpPath) }()
-
-		// Write current value to temp file
-		if _, err := tmpFile.WriteString(currentValue); err != nil {
-			_ = tmpFile.Close()
-			FatalErrorRespectJSON("writing to temp file: %v", err)
-		}
-		_ = tmpFile.Close()
-
-		// Open the editor - parse command and args (handles "vim -w" or "zeditor --wait")
-		editorParts := strings.Fields(editor)
-		editorArgs := append(editorParts[1:], tmpPath)
-		editorCmd := exec.Command(editorParts[0], editorArgs...) //nolint:gosec // G204: editor from trusted $EDITOR/$VISUAL env or known defaults
-		editorCmd.Stdin = os.Stdin
-		editorCmd.Stdout = os.Stdout
-		editorCmd.Stderr = os.Stderr
-
-		if err := editorCmd.Run(); err != nil {
-			FatalErrorRespectJSON("running editor: %v", err)
-		}
-
-		// Read the edited content
-		// #nosec G304 -- tmpPath was created earlier in this function
-		editedContent, err := os.ReadFile(tmpPath)
+		editedContent, err := runEditor([]byte(currentValue), fmt.Sprintf("bd-edit-%s-*.txt", fieldToEdit))
 		if err != nil {
-			FatalErrorRespectJSON("reading edited file: %v", err)
+			FatalErrorRespectJSON("%v", err)
 		}
-
 		newValue := string(editedContent)
 
 		// Check if the value changed
@@ -155,12 +126,104 @@ Examples:
 	},
 }
 
+// editIssueDocument edits the whole issue as a YAML document and applies the
+// changed fields atomically.
+func editIssueDocument(ctx context.Context, issue *types.Issue) {
+	labels, err := store.GetLabels(ctx, issue.ID)
+	if err != nil {
+		FatalErrorRespectJSON("fetching labels for %s: %v", issue.ID, err)
+	}
+	original := editDocFromIssue(issue, labels)
+	rules := loadEditDocRules(ctx, false)
+	header := rules.editDocHeader(fmt.Sprintf(
+		"Editing %s. Save and quit to apply the changed fields; delete everything to cancel.", issue.ID))
+
+	edited, err := editDocInEditor(original, header, rules)
+	if err != nil {
+		if errors.Is(err, errEditCancelled) {
+			fmt.Println("Edit cancelled")
+			return
+		}
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	changes := diffEditDocs(original, edited)
+	if changes.Empty() {
+		fmt.Println("No changes made")
+		return
+	}
+	if status, ok := changes.Updates["status"].(string); ok && status == string(types.StatusClosed) {
+		if session := os.Getenv("CLAUDE_SESSION_ID"); session != "" {
+			changes.Updates["closed_by_session"] = session
+		}
+	}
+
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		if len(changes.Updates) > 0 {
+			if err := tx.UpdateIssue(ctx, issue.ID, changes.Updates, actor); err != nil {
+				return err
+			}
+		}
+		for _, l := range changes.AddLabels {
+			if err := tx.AddLabel(ctx, issue.ID, l, actor); err != nil {
+				return err
+			}
+		}
+		for _, l := range changes.RemoveLabels {
+			if err := tx.RemoveLabel(ctx, issue.ID, l, actor); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		FatalErrorRespectJSON("updating issue: %v", err)
+	}
+
+	updated, _ := store.GetIssue(ctx, issue.ID) // Best effort: nil issue handled below
+	if updated != nil && hookRunner != nil {
+		hookRunner.Run(hooks.EventUpdate, updated)
+	}
+	SetLastTouchedID(issue.ID)
+
+	if jsonOutput {
+		outputJSON(updated)
+		return
+	}
+	fields := make([]string, 0, len(changes.Updates)+1)
+	for key := range changes.Updates {
+		if key != "closed_by_session" {
+			fields = append(fields, strings.ReplaceAll(key, "_", " "))
+		}
+	}
+	if len(changes.AddLabels) > 0 || len(changes.RemoveLabels) > 0 {
+		fields = append(fields, "labels")
+	}
+	sort.Strings(fields)
+	fmt.Printf("%s Updated %s for issue: %s\n", ui.RenderPass("✓"), strings.Join(fields, ", "), issue.ID)
+}
+
+// loadEditDocRules collects the custom types and statuses an edit document
+// may use.
+func loadEditDocRules(ctx context.Context, creating bool) editDocRules {
+	rules := editDocRules{creating: creating}
+	if store != nil {
+		// Errors fall back to the built-in values (and config.yaml below).
+		rules.customTypes, _ = store.GetCustomTypes(ctx)
+		rules.customStatuses, _ = store.GetCustomStatuses(ctx)
+	}
+	if len(rules.customTypes) == 0 {
+		rules.customTypes = config.GetCustomTypesFromYAML()
+	}
+	return rules
+}
+
 func init() {
-	editCmd.Flags().Bool("title", false, "Edit the title")
-	editCmd.Flags().Bool("description", false, "Edit the description (default)")
-	editCmd.Flags().Bool("design", false, "Edit the design notes")
-	editCmd.Flags().Bool("notes", false, "Edit the notes")
-	editCmd.Flags().Bool("acceptance", false, "Edit the acceptance criteria")
+	editCmd.Flags().Bool("title", false, "Edit only the title")
+	editCmd.Flags().Bool("description", false, "Edit only the description")
+	editCmd.Flags().Bool("design", false, "Edit only the design notes")
+	editCmd.Flags().Bool("notes", false, "Edit only the notes")
+	editCmd.Flags().Bool("acceptance", false, "Edit only the acceptance criteria")
 	editCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(editCmd)
 }
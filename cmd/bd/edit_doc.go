@@ -0,0 +1,386 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// issueEditDoc is the YAML document edited by `bd edit` and `bd create --edit`.
+type issueEditDoc struct {
+	Title              string   `yaml:"title"`
+	Status             string   `yaml:"status,omitempty"` // Omitted when creating
+	Priority           string   `yaml:"priority"`         // "P2" or "2"
+	Type               string   `yaml:"type"`
+	Assignee           string   `yaml:"assignee"`
+	Labels             []string `yaml:"labels"`
+	Estimate           *int     `yaml:"estimate"` // Minutes
+	ExternalRef        string   `yaml:"external_ref"`
+	Description        string   `yaml:"description"`
+	Design             string   `yaml:"design"`
+	AcceptanceCriteria string   `yaml:"acceptance_criteria"`
+	Notes              string   `yaml:"notes"`
+}
+
+// editDocFromIssue builds the edit document for an existing issue.
+func editDocFromIssue(issue *types.Issue, labels []string) *issueEditDoc {
+	externalRef := ""
+	if issue.ExternalRef != nil {
+		externalRef = *issue.ExternalRef
+	}
+	return &issueEditDoc{
+		Title:              issue.Title,
+		Status:             string(issue.Status),
+		Priority:           fmt.Sprintf("P%d", issue.Priority),
+		Type:               string(issue.IssueType),
+		Assignee:           issue.Assignee,
+		Labels:             slices.Clone(labels),
+		Estimate:           issue.EstimatedMinutes,
+		ExternalRef:        externalRef,
+		Description:        issue.Description,
+		Design:             issue.Design,
+		AcceptanceCriteria: issue.AcceptanceCriteria,
+		Notes:              issue.Notes,
+	}
+}
+
+// descriptionSkeleton returns a description pre-filled with the recommended
+// sections for the issue type, for `bd create --edit`.
+func descriptionSkeleton(issueType types.IssueType) string {
+	var sb strings.Builder
+	for i, section := range issueType.RequiredSections() {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%s\n\n<!-- %s -->\n", section.Heading, section.Hint)
+	}
+	return sb.String()
+}
+
+// renderEditDoc formats doc as YAML with a comment header. Multi-line text
+// fields use literal block scalars so they read as plain text.
+func renderEditDoc(doc *issueEditDoc, header string) ([]byte, error) {
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	add := func(key string, value *yaml.Node) {
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	}
+	str := func(s string) *yaml.Node {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+	}
+	text := func(s string) *yaml.Node {
+		n := str(s)
+		if s != "" {
+			n.Style = yaml.LiteralStyle
+		}
+		return n
+	}
+
+	add("title", str(doc.Title))
+	if doc.Status != "" {
+		add("status", str(doc.Status))
+	}
+	add("priority", str(doc.Priority))
+	add("type", str(doc.Type))
+	add("assignee", str(doc.Assignee))
+	labels := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, l := range doc.Labels {
+		labels.Content = append(labels.Content, str(l))
+	}
+	add("labels", labels)
+	if doc.Estimate != nil {
+		add("estimate", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(*doc.Estimate)})
+	} else {
+		add("estimate", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"})
+	}
+	add("external_ref", str(doc.ExternalRef))
+	add("description", text(doc.Description))
+	add("design", text(doc.Design))
+	add("acceptance_criteria", text(doc.AcceptanceCriteria))
+	add("notes", text(doc.Notes))
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&yaml.Node{Kind: yaml.DocumentNode, HeadComment: header, Content: []*yaml.Node{mapping}}); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// errEditCancelled is returned when the user empties the edit document.
+var errEditCancelled = errors.New("edit cancelled (empty document)")
+
+// parseEditDoc parses an edited document. Unknown keys are rejected so typos
+// don't silently drop changes.
+func parseEditDoc(data []byte) (*issueEditDoc, error) {
+	if isBlankEditDoc(data) {
+		return nil, errEditCancelled
+	}
+	var doc issueEditDoc
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	doc.Title = strings.TrimSpace(doc.Title)
+	doc.Status = strings.TrimSpace(doc.Status)
+	doc.Priority = strings.TrimSpace(doc.Priority)
+	doc.Type = utils.NormalizeIssueType(strings.TrimSpace(doc.Type))
+	doc.Assignee = strings.TrimSpace(doc.Assignee)
+	doc.ExternalRef = strings.TrimSpace(doc.ExternalRef)
+	labels := doc.Labels[:0]
+	for _, l := range doc.Labels {
+		if l = strings.TrimSpace(l); l != "" && !slices.Contains(labels, l) {
+			labels = append(labels, l)
+		}
+	}
+	doc.Labels = labels
+	return &doc, nil
+}
+
+// isBlankEditDoc reports whether data holds only comments and whitespace.
+func isBlankEditDoc(data []byte) bool {
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return false
+		}
+	}
+	return true
+}
+
+// editDocRules holds what an edit document is validated against.
+type editDocRules struct {
+	creating       bool // New issue: the document has no status
+	customTypes    []string
+	customStatuses []string
+}
+
+// validate checks doc's field values, returning all problems at once.
+func (r editDocRules) validate(doc *issueEditDoc) error {
+	var problems []string
+	if doc.Title == "" {
+		problems = append(problems, "title: cannot be empty")
+	}
+	if !r.creating && !types.Status(doc.Status).IsValidWithCustom(r.customStatuses) {
+		problems = append(problems, fmt.Sprintf("status: invalid status %q (valid: %s)", doc.Status, r.validStatuses()))
+	}
+	if _, err := validation.ValidatePriority(doc.Priority); err != nil {
+		problems = append(problems, "priority: "+err.Error())
+	}
+	if !types.IssueType(doc.Type).IsValidWithCustom(r.customTypes) {
+		problems = append(problems, fmt.Sprintf("type: invalid issue type %q (valid: %s)", doc.Type, r.validTypes()))
+	}
+	if doc.Estimate != nil && *doc.Estimate < 0 {
+		problems = append(problems, "estimate: must be a non-negative number of minutes")
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// validStatuses lists the statuses a document may use, for messages.
+func (r editDocRules) validStatuses() string {
+	return strings.Join(append([]string{"open", "in_progress", "blocked", "deferred", "closed"}, r.customStatuses...), ", ")
+}
+
+// validTypes lists the issue types a document may use, for messages.
+func (r editDocRules) validTypes() string {
+	return strings.Join(append([]string{"bug", "feature", "task", "epic", "chore", "decision"}, r.customTypes...), ", ")
+}
+
+// editDocHeader returns the comment header for an edit document.
+func (r editDocRules) editDocHeader(intro string) string {
+	lines := []string{intro, `Use "|" block scalars for multi-line text.`}
+	if !r.creating {
+		lines = append(lines, "status: "+r.validStatuses())
+	}
+	lines = append(lines, "priority: P0 (critical) to P4 (backlog)", "type: "+r.validTypes())
+	return strings.Join(lines, "\n")
+}
+
+// editDocChanges is what applying an edited document to an issue changes.
+type editDocChanges struct {
+	Updates      map[string]interface{} // Field updates for UpdateIssue
+	AddLabels    []string
+	RemoveLabels []string
+}
+
+// Empty reports whether the edit changed nothing.
+func (c *editDocChanges) Empty() bool {
+	return len(c.Updates) == 0 && len(c.AddLabels) == 0 && len(c.RemoveLabels) == 0
+}
+
+// diffEditDocs returns the changes from old to edited. Both documents must
+// have passed validation.
+func diffEditDocs(old, edited *issueEditDoc) *editDocChanges {
+	c := &editDocChanges{Updates: make(map[string]interface{})}
+	setIf := func(key, oldVal, newVal string) {
+		if oldVal != newVal {
+			c.Updates[key] = newVal
+		}
+	}
+	setIf("title", old.Title, edited.Title)
+	setIf("status", old.Status, edited.Status)
+	oldPriority, _ := validation.ValidatePriority(old.Priority)
+	if newPriority, _ := validation.ValidatePriority(edited.Priority); newPriority != oldPriority {
+		c.Updates["priority"] = newPriority
+	}
+	setIf("issue_type", old.Type, edited.Type)
+	setIf("assignee", old.Assignee, edited.Assignee)
+	if old.ExternalRef != edited.ExternalRef {
+		if edited.ExternalRef == "" {
+			c.Updates["external_ref"] = nil
+		} else {
+			c.Updates["external_ref"] = edited.ExternalRef
+		}
+	}
+	setIf("description", old.Description, edited.Description)
+	setIf("design", old.Design, edited.Design)
+	setIf("acceptance_criteria", old.AcceptanceCriteria, edited.AcceptanceCriteria)
+	setIf("notes", old.Notes, edited.Notes)
+
+	switch {
+	case edited.Estimate == nil && old.Estimate != nil:
+		c.Updates["estimated_minutes"] = nil
+	case edited.Estimate != nil && (old.Estimate == nil || *old.Estimate != *edited.Estimate):
+		c.Updates["estimated_minutes"] = *edited.Estimate
+	}
+
+	for _, l := range edited.Labels {
+		if !slices.Contains(old.Labels, l) {
+			c.AddLabels = append(c.AddLabels, l)
+		}
+	}
+	for _, l := range old.Labels {
+		if !slices.Contains(edited.Labels, l) {
+			c.RemoveLabels = append(c.RemoveLabels, l)
+		}
+	}
+	return c
+}
+
+// editErrorPrefix marks validation errors written into the document.
+const editErrorPrefix = "# ERROR: "
+
+// withEditErrors prepends err to the document as comments, replacing errors
+// from a previous round.
+func withEditErrors(data []byte, err error) []byte {
+	lines := strings.Split(string(data), "\n")
+	for len(lines) > 0 && strings.HasPrefix(lines[0], editErrorPrefix) {
+		lines = lines[1:]
+	}
+	var sb strings.Builder
+	for _, msg := range strings.Split(err.Error(), "\n") {
+		sb.WriteString(editErrorPrefix + msg + "\n")
+	}
+	sb.WriteString(strings.Join(lines, "\n"))
+	return []byte(sb.String())
+}
+
+// editDocInEditor opens doc in the editor until the result parses and
+// passes rules. On a problem the document is reopened with the errors at the
+// top; saving it unchanged gives up with the error. Emptying the document
+// returns errEditCancelled.
+func editDocInEditor(doc *issueEditDoc, header string, rules editDocRules) (*issueEditDoc, error) {
+	content, err := renderEditDoc(doc, header)
+	if err != nil {
+		return nil, fmt.Errorf("rendering issue: %w", err)
+	}
+	retrying := false
+	for {
+		edited, err := runEditor(content, "bd-edit-*.yaml")
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parseEditDoc(edited)
+		if err == nil {
+			err = rules.validate(parsed)
+		}
+		if err == nil {
+			return parsed, nil
+		}
+		if errors.Is(err, errEditCancelled) || (retrying && bytes.Equal(edited, content)) {
+			return nil, err
+		}
+		content = withEditErrors(edited, err)
+		retrying = true
+	}
+}
+
+// findEditor returns the user's editor command: $EDITOR, $VISUAL, or the
+// first common editor found on PATH.
+func findEditor() (string, error) {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = os.Getenv("VISUAL")
+	}
+	if editor == "" {
+		// Try common defaults
+		for _, defaultEditor := range []string{"vim", "vi", "nano", "emacs"} {
+			if _, err := exec.LookPath(defaultEditor); err == nil {
+				editor = defaultEditor
+				break
+			}
+		}
+	}
+	if editor == "" {
+		return "", errors.New("no editor found. Set $EDITOR or $VISUAL environment variable")
+	}
+	return editor, nil
+}
+
+// runEditor writes content to a temp file named after pattern, opens it in
+// the user's editor, and returns the saved content.
+func runEditor(content []byte, pattern string) ([]byte, error) {
+	editor, err := findEditor()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpFile, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return nil, fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if _, err := tmpFile.Write(content); err != nil {
+		_ = tmpFile.Close()
+		return nil, fmt.Errorf("writing to temp file: %w", err)
+	}
+	_ = tmpFile.Close()
+
+	// Parse command and args (handles "vim -w" or "zeditor --wait")
+	editorParts := strings.Fields(editor)
+	editorArgs := append(editorParts[1:], tmpPath)
+	editorCmd := exec.Command(editorParts[0], editorArgs...) //nolint:gosec // G204: editor from trusted $EDITOR/$VISUAL env or known defaults
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return nil, fmt.Errorf("running editor: %w", err)
+	}
+
+	// #nosec G304 -- tmpPath was created earlier in this function
+	edited, err := os.ReadFile(tmpPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading edited file: %w", err)
+	}
+	return edited, nil
+}
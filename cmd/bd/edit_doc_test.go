@@ -0,0 +1,198 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func testEditDoc() *issueEditDoc {
+	est := 30
+	return &issueEditDoc{
+		Title:       "Fix: login #2",
+		Status:      "open",
+		Priority:    "P2",
+		Type:        "bug",
+		Labels:      []string{"auth", "ui"},
+		Estimate:    &est,
+		Description: "Steps:\n  1. open page\n  2. click login\n",
+		Notes:       "trailing spaces  \nsecond line",
+	}
+}
+
+func TestEditDocRoundTrip(t *testing.T) {
+	doc := testEditDoc()
+	data, err := renderEditDoc(doc, "Editing bd-1\nsecond line")
+	if err != nil {
+		t.Fatalf("renderEditDoc: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "# Editing bd-1\n# second line\n") {
+		t.Errorf("header not rendered as comments:\n%s", data)
+	}
+	if !strings.Contains(string(data), "description: |") {
+		t.Errorf("description should be a block scalar:\n%s", data)
+	}
+
+	parsed, err := parseEditDoc(data)
+	if err != nil {
+		t.Fatalf("parseEditDoc: %v", err)
+	}
+	if changes := diffEditDocs(doc, parsed); !changes.Empty() {
+		t.Errorf("round trip changed fields: %+v", changes)
+	}
+}
+
+func TestParseEditDoc(t *testing.T) {
+	t.Run("blank cancels", func(t *testing.T) {
+		if _, err := parseEditDoc([]byte("# only comments\n\n")); !errors.Is(err, errEditCancelled) {
+			t.Errorf("err = %v, want errEditCancelled", err)
+		}
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		if _, err := parseEditDoc([]byte("title: x\nprioirty: 1\n")); err == nil {
+			t.Error("expected error for unknown key")
+		}
+	})
+	t.Run("normalizes", func(t *testing.T) {
+		doc, err := parseEditDoc([]byte("title: '  x  '\ntype: feat\npriority: 1\nlabels: [a, ' a', '', b]\n"))
+		if err != nil {
+			t.Fatalf("parseEditDoc: %v", err)
+		}
+		if doc.Title != "x" || doc.Type != "feature" || doc.Priority != "1" {
+			t.Errorf("got title=%q type=%q priority=%q", doc.Title, doc.Type, doc.Priority)
+		}
+		if strings.Join(doc.Labels, ",") != "a,b" {
+			t.Errorf("labels = %v, want [a b]", doc.Labels)
+		}
+	})
+}
+
+func TestEditDocRulesValidate(t *testing.T) {
+	rules := editDocRules{customTypes: []string{"spike"}, customStatuses: []string{"review"}}
+
+	doc := testEditDoc()
+	doc.Type = "spike"
+	doc.Status = "review"
+	if err := rules.validate(doc); err != nil {
+		t.Errorf("custom type and status rejected: %v", err)
+	}
+
+	doc = testEditDoc()
+	doc.Title = ""
+	doc.Status = "done"
+	doc.Priority = "high"
+	doc.Type = "story"
+	neg := -5
+	doc.Estimate = &neg
+	err := rules.validate(doc)
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, field := range []string{"title:", "status:", "priority:", "type:", "estimate:"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("error missing %s problem:\n%v", field, err)
+		}
+	}
+
+	// Creating: no status in the document
+	doc = testEditDoc()
+	doc.Status = ""
+	if err := (editDocRules{creating: true}).validate(doc); err != nil {
+		t.Errorf("create document rejected: %v", err)
+	}
+}
+
+func TestDiffEditDocs(t *testing.T) {
+	old := testEditDoc()
+	edited := testEditDoc()
+	edited.Title = "Fix login"
+	edited.Priority = "1"
+	edited.Labels = []string{"ui", "urgent"}
+	edited.Estimate = nil
+	edited.ExternalRef = "gh-12"
+
+	c := diffEditDocs(old, edited)
+	want := map[string]interface{}{
+		"title":             "Fix login",
+		"priority":          1,
+		"estimated_minutes": nil,
+		"external_ref":      "gh-12",
+	}
+	if len(c.Updates) != len(want) {
+		t.Errorf("updates = %v, want %v", c.Updates, want)
+	}
+	for k, v := range want {
+		if got, ok := c.Updates[k]; !ok || got != v {
+			t.Errorf("updates[%s] = %v, want %v", k, got, v)
+		}
+	}
+	if strings.Join(c.AddLabels, ",") != "urgent" || strings.Join(c.RemoveLabels, ",") != "auth" {
+		t.Errorf("labels +%v -%v, want +[urgent] -[auth]", c.AddLabels, c.RemoveLabels)
+	}
+
+	// "P2" and "2" are the same priority
+	edited = testEditDoc()
+	edited.Priority = "2"
+	if c := diffEditDocs(old, edited); !c.Empty() {
+		t.Errorf("equivalent priority reported as change: %v", c.Updates)
+	}
+}
+
+func TestWithEditErrors(t *testing.T) {
+	data := []byte("title: x\n")
+	once := withEditErrors(data, errors.New("a: bad\nb: bad"))
+	if string(once) != "# ERROR: a: bad\n# ERROR: b: bad\ntitle: x\n" {
+		t.Errorf("first round:\n%s", once)
+	}
+	twice := withEditErrors(once, errors.New("c: bad"))
+	if string(twice) != "# ERROR: c: bad\ntitle: x\n" {
+		t.Errorf("errors from the previous round should be replaced:\n%s", twice)
+	}
+}
+
+func TestDescriptionSkeleton(t *testing.T) {
+	skeleton := descriptionSkeleton(types.TypeBug)
+	if !strings.Contains(skeleton, "## Steps to Reproduce") || !strings.Contains(skeleton, "<!--") {
+		t.Errorf("skeleton = %q", skeleton)
+	}
+	if got := stripSkeletonHints(skeleton, types.TypeBug); strings.Contains(got, "<!--") {
+		t.Errorf("hints not stripped: %q", got)
+	}
+	if descriptionSkeleton(types.TypeChore) != "" {
+		t.Error("chore has no required sections")
+	}
+}
+
+func TestEditDocInEditorRetriesInvalid(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the editor")
+	}
+	dir := t.TempDir()
+	// First run: set an invalid priority. Second run (file now has the
+	// error header): fix it.
+	script := filepath.Join(dir, "editor.sh")
+	body := `#!/bin/sh
+if grep -q '^# ERROR:' "$1"; then
+  sed -i.bak 's/^priority: .*/priority: P1/' "$1"
+else
+  sed -i.bak 's/^priority: .*/priority: P9/' "$1"
+fi
+`
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil { // #nosec G306 -- test editor must be executable
+		t.Fatal(err)
+	}
+	t.Setenv("EDITOR", script)
+
+	edited, err := editDocInEditor(testEditDoc(), "test", editDocRules{})
+	if err != nil {
+		t.Fatalf("editDocInEditor: %v", err)
+	}
+	if edited.Priority != "P1" {
+		t.Errorf("priority = %q, want P1", edited.Priority)
+	}
+}
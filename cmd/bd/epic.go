@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/resolution"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 	"os"
@@ -114,7 +115,7 @@ var closeEligibleEpicsCmd = &cobra.Command{
 		// Actually close the epics
 		closedIDs := []string{}
 		for _, epicStatus := range eligibleEpics {
-			err := store.CloseIssue(ctx, epicStatus.Epic.ID, "All children completed", "system", "")
+			err := store.CloseIssue(ctx, epicStatus.Epic.ID, resolution.Resolution{Category: resolution.Completed, Note: "all children completed"}.String(), "system", "")
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error closing %s: %v\n", epicStatus.Epic.ID, err)
 				continue
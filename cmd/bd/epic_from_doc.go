@@ -0,0 +1,389 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"golang.org/x/term"
+)
+
+var epicFromDocCmd = &cobra.Command{
+	Use:   "from-doc <design.md>",
+	Short: "Create an epic and child issues from a Markdown design doc",
+	Long: `Turn a Markdown design doc into an epic with child issues:
+
+  # Title              The epic's title (default: the file name); text
+                       before the first ## heading is its description
+  ## Heading           A child issue; the text under it is its description
+  - [ ] item           Under a ## heading: an acceptance criterion of that
+                       issue (see 'bd criteria'). Before the first ##
+                       heading: a child issue of its own ("[x]": created
+                       closed)
+
+Prose sections such as "## Background", "## Goals", "## Non-goals" or
+"## Alternatives" stay in the epic's description instead of becoming issues.
+Under a ## heading, "### Priority", "### Type", "### Assignee", "### Labels",
+"### Design" and "### Acceptance Criteria" set those fields, as in
+'bd create --file'.
+
+Child issues are ordered as in the doc: each is blocked by the one before
+it, so 'bd ready' walks the doc top to bottom. Use --parallel to skip these
+dependencies. The epic links back to the doc through its spec_id.
+
+The plan is shown for confirmation before anything is created: answer y to
+create it, or r to review each issue. Use --yes to skip the prompt (needed
+when stdin isn't a terminal) and --dry-run to only show the plan.
+
+Examples:
+  bd epic from-doc docs/design/search.md --dry-run
+  bd epic from-doc docs/design/search.md
+  bd epic from-doc rfc-12.md --parallel --yes --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+		parallel, _ := cmd.Flags().GetBool("parallel")
+		if !dryRun {
+			CheckReadonly("epic from-doc")
+		}
+
+		path, err := validateMarkdownPath(args[0])
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		// #nosec G304 -- Path is validated by validateMarkdownPath which prevents traversal
+		data, err := os.ReadFile(path)
+		if err != nil {
+			FatalErrorRespectJSON("reading %s: %v", path, err)
+		}
+		plan, err := parseDesignDoc(string(data), path)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		plan.Sequential = !parallel
+
+		if dryRun {
+			if jsonOutput {
+				outputJSON(plan)
+				return
+			}
+			printDocPlan(plan)
+			return
+		}
+		if !yes {
+			if jsonOutput || !term.IsTerminal(int(os.Stdin.Fd())) {
+				FatalErrorWithHint("confirmation needs a terminal",
+					"use --yes to create the epic without confirming, or --dry-run to preview it")
+			}
+			printDocPlan(plan)
+			if !confirmDocPlan(plan, bufio.NewReader(os.Stdin), os.Stdout) {
+				fmt.Println("Canceled; nothing was created.")
+				return
+			}
+		}
+
+		if err := createDocPlan(rootCtx, plan); err != nil {
+			FatalErrorRespectJSON("creating epic: %v", err)
+		}
+		SetLastTouchedID(plan.Epic.ID)
+		if jsonOutput {
+			outputJSON(plan)
+			return
+		}
+		fmt.Printf("%s Created epic %s: %s with %d child issue(s)\n",
+			ui.RenderPass("✓"), ui.RenderID(plan.Epic.ID), plan.Epic.Title, len(plan.Children))
+		for _, child := range plan.Children {
+			fmt.Printf("  %s: %s\n", ui.RenderID(child.ID), child.Title)
+		}
+	},
+}
+
+func init() {
+	epicFromDocCmd.Flags().Bool("dry-run", false, "Show the plan without creating anything")
+	epicFromDocCmd.Flags().BoolP("yes", "y", false, "Create without asking for confirmation")
+	epicFromDocCmd.Flags().Bool("parallel", false, "Don't make each child issue wait for the one before it")
+	epicCmd.AddCommand(epicFromDocCmd)
+}
+
+// docPlan is the epic and child issues planned from a design doc.
+type docPlan struct {
+	Epic       *types.Issue   `json:"epic"`
+	Children   []*types.Issue `json:"children"`
+	Sequential bool           `json:"sequential"` // Each child is blocked by the one before it
+}
+
+var (
+	// docHeadingRegex matches an ATX heading, capturing its level and text.
+	docHeadingRegex = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+
+	// docChecklistRegex matches a checklist item, capturing its box and text.
+	docChecklistRegex = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX])\]\s+(.+)$`)
+)
+
+// designDocProseSections are ## headings that describe the design rather
+// than work; they stay in the epic's description.
+var designDocProseSections = map[string]bool{
+	"abstract": true, "alternatives": true, "alternatives considered": true,
+	"appendix": true, "background": true, "context": true, "goals": true,
+	"motivation": true, "non-goals": true, "non goals": true,
+	"open questions": true, "overview": true, "problem": true,
+	"problem statement": true, "references": true, "risks": true,
+	"summary": true,
+}
+
+// docFieldSections are ### headings that set a child issue's fields; see
+// processIssueSection.
+var docFieldSections = map[string]bool{
+	"priority": true, "type": true, "description": true, "design": true,
+	"acceptance criteria": true, "acceptance": true, "assignee": true,
+	"labels": true,
+}
+
+// parseDesignDoc plans an epic and its child issues from a design doc.
+func parseDesignDoc(text, path string) (*docPlan, error) {
+	var (
+		epicTitle string
+		intro     []string
+		children  []*IssueTemplate
+		done      []bool
+		body      [][]string // Description lines of each ## child
+		checklist [][]string // Acceptance checklist lines of each ## child
+		current   = -1       // Index of the ## child being read, -1 for the intro
+		inProse   bool       // Inside a prose ## section
+		section   string     // Field ### section being read
+		content   []string
+		inFence   bool
+	)
+	endSection := func() {
+		if section != "" && current >= 0 {
+			processIssueSection(children[current], section, strings.Join(content, "\n"))
+		}
+		section, content = "", nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		if !inFence {
+			if m := docHeadingRegex.FindStringSubmatch(line); m != nil {
+				level, heading := len(m[1]), m[2]
+				switch {
+				case level == 1 && epicTitle == "":
+					epicTitle = heading
+					continue
+				case level == 2:
+					endSection()
+					if designDocProseSections[strings.ToLower(heading)] {
+						inProse, current = true, -1
+						intro = append(intro, line)
+						continue
+					}
+					inProse = false
+					children = append(children, &IssueTemplate{Title: heading, Priority: 2, IssueType: types.TypeTask})
+					done = append(done, false)
+					body = append(body, nil)
+					checklist = append(checklist, nil)
+					current = len(children) - 1
+					continue
+				case level == 3 && current >= 0:
+					endSection()
+					if docFieldSections[strings.ToLower(heading)] {
+						section = heading
+						continue
+					}
+				}
+			}
+		}
+
+		switch {
+		case section != "":
+			content = append(content, line)
+		case current >= 0:
+			if !inFence && docChecklistRegex.MatchString(line) {
+				checklist[current] = append(checklist[current], line)
+			} else {
+				body[current] = append(body[current], line)
+			}
+		case !inProse && !inFence && docChecklistRegex.MatchString(line):
+			m := docChecklistRegex.FindStringSubmatch(line)
+			children = append(children, &IssueTemplate{Title: strings.TrimSpace(m[2]), Priority: 2, IssueType: types.TypeTask})
+			done = append(done, m[1] != " ")
+			body = append(body, nil)
+			checklist = append(checklist, nil)
+		default:
+			intro = append(intro, line)
+		}
+	}
+	endSection()
+
+	if len(children) == 0 {
+		return nil, fmt.Errorf("no work found in %s (expected ## headings or - [ ] checklist items)", path)
+	}
+	if epicTitle == "" {
+		base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		epicTitle = strings.NewReplacer("-", " ", "_", " ").Replace(base)
+	}
+
+	plan := &docPlan{Epic: &types.Issue{
+		Title:       epicTitle,
+		Description: strings.TrimSpace(strings.Join(intro, "\n")),
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   types.TypeEpic,
+		SpecID:      path,
+	}}
+	for i, t := range children {
+		description := strings.TrimSpace(strings.Join(body[i], "\n"))
+		if t.Description != "" {
+			description = strings.TrimSpace(description + "\n\n" + t.Description)
+		}
+		acceptance := strings.TrimSpace(t.AcceptanceCriteria)
+		if items := strings.TrimSpace(strings.Join(checklist[i], "\n")); items != "" {
+			acceptance = strings.TrimSpace(acceptance + "\n" + items)
+		}
+		child := &types.Issue{
+			Title:              truncateTitle(t.Title, 500),
+			Description:        description,
+			Design:             t.Design,
+			AcceptanceCriteria: acceptance,
+			Status:             types.StatusOpen,
+			Priority:           t.Priority,
+			IssueType:          t.IssueType,
+			Assignee:           t.Assignee,
+			Labels:             t.Labels,
+		}
+		if done[i] {
+			child.Status = types.StatusClosed
+			child.CloseReason = "Checked off in " + path
+		}
+		plan.Children = append(plan.Children, child)
+	}
+	return plan, nil
+}
+
+// printDocPlan shows a design doc plan for confirmation.
+func printDocPlan(plan *docPlan) {
+	fmt.Printf("Epic: %s %s\n", ui.RenderBold(plan.Epic.Title), ui.RenderMuted("(from "+plan.Epic.SpecID+")"))
+	for i, child := range plan.Children {
+		fmt.Printf("  %d. %s\n", i+1, formatDocPlanChild(child))
+	}
+	if plan.Sequential && len(plan.Children) > 1 {
+		fmt.Println(ui.RenderMuted("Each issue is blocked by the one before it (--parallel to skip)."))
+	}
+}
+
+// formatDocPlanChild is a planned child issue's line in the plan.
+func formatDocPlanChild(child *types.Issue) string {
+	line := fmt.Sprintf("%s [%s, P%d]", child.Title, child.IssueType, child.Priority)
+	if n := len(docChecklistLines(child.AcceptanceCriteria)); n > 0 {
+		line += fmt.Sprintf(" (%d criteria)", n)
+	}
+	if child.Status == types.StatusClosed {
+		line += " " + ui.RenderPass("done")
+	}
+	return line
+}
+
+// docChecklistLines returns the checklist items in text.
+func docChecklistLines(text string) []string {
+	var items []string
+	for _, line := range strings.Split(text, "\n") {
+		if docChecklistRegex.MatchString(line) {
+			items = append(items, line)
+		}
+	}
+	return items
+}
+
+// confirmDocPlan asks whether to create the plan. Answering "r" reviews
+// each child issue in turn, dropping the ones declined; the plan is
+// created if any remain.
+func confirmDocPlan(plan *docPlan, in *bufio.Reader, out io.Writer) bool {
+	_, _ = fmt.Fprintf(out, "\nCreate this epic with %d child issue(s)? [y]es / [n]o / [r]eview each: ", len(plan.Children))
+	switch readDocAnswer(in) {
+	case "y", "yes":
+		return true
+	case "r", "review":
+	default:
+		return false
+	}
+
+	var kept []*types.Issue
+	for i, child := range plan.Children {
+		_, _ = fmt.Fprintf(out, "(%d/%d) %s\n  Create? [y]es / [n]o / [a]ll remaining / [q]uit: ", i+1, len(plan.Children), formatDocPlanChild(child))
+		switch readDocAnswer(in) {
+		case "y", "yes":
+			kept = append(kept, child)
+		case "a", "all":
+			kept = append(kept, plan.Children[i:]...)
+			plan.Children = kept
+			return true
+		case "q", "quit":
+			return false
+		}
+	}
+	plan.Children = kept
+	return len(kept) > 0
+}
+
+// readDocAnswer reads one lower-cased answer; EOF reads as no answer.
+func readDocAnswer(in *bufio.Reader) string {
+	response, _ := in.ReadString('\n')
+	return strings.ToLower(strings.TrimSpace(response))
+}
+
+// createDocPlan creates the epic and its children in one transaction,
+// filling in their IDs.
+func createDocPlan(ctx context.Context, plan *docPlan) error {
+	issues := append([]*types.Issue{plan.Epic}, plan.Children...)
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		for _, issue := range issues {
+			closeReason := issue.CloseReason
+			issue.Status, issue.CloseReason = types.StatusOpen, ""
+			if err := tx.CreateIssue(ctx, issue, actor); err != nil {
+				return fmt.Errorf("creating %q: %w", issue.Title, err)
+			}
+			for _, label := range issue.Labels {
+				if err := tx.AddLabel(ctx, issue.ID, label, actor); err != nil {
+					return fmt.Errorf("labelling %s: %w", issue.ID, err)
+				}
+			}
+			if closeReason != "" {
+				if err := tx.CloseIssue(ctx, issue.ID, closeReason, actor, ""); err != nil {
+					return fmt.Errorf("closing %s: %w", issue.ID, err)
+				}
+				issue.Status, issue.CloseReason = types.StatusClosed, closeReason
+			}
+		}
+		for i, child := range plan.Children {
+			deps := []*types.Dependency{{IssueID: child.ID, DependsOnID: plan.Epic.ID, Type: types.DepParentChild}}
+			if plan.Sequential && i > 0 {
+				deps = append(deps, &types.Dependency{IssueID: child.ID, DependsOnID: plan.Children[i-1].ID, Type: types.DepBlocks})
+			}
+			for _, dep := range deps {
+				if err := tx.AddDependency(ctx, dep, actor); err != nil {
+					return fmt.Errorf("adding dependency %s -> %s: %w", dep.IssueID, dep.DependsOnID, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// Nothing was written; don't report IDs that don't exist.
+		for _, issue := range issues {
+			issue.ID = ""
+		}
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const testDesignDoc = "# Search rework\n" +
+	"\n" +
+	"Replace LIKE queries with an index.\n" +
+	"\n" +
+	"## Background\n" +
+	"Search is slow on big repos.\n" +
+	"\n" +
+	"## Build the index\n" +
+	"Index titles and descriptions.\n" +
+	"- [ ] Index rebuilds on import\n" +
+	"- [x] Index survives restart\n" +
+	"\n" +
+	"### Priority\n" +
+	"1\n" +
+	"\n" +
+	"### Notes on storage\n" +
+	"Keep it in the dolt dir.\n" +
+	"\n" +
+	"## Switch bd search\n" +
+	"```\n" +
+	"## not a heading\n" +
+	"- [ ] not a criterion\n" +
+	"```\n" +
+	"### Labels\n" +
+	"search, perf\n"
+
+func TestParseDesignDoc(t *testing.T) {
+	plan, err := parseDesignDoc(testDesignDoc, "docs/search.md")
+	if err != nil {
+		t.Fatalf("parseDesignDoc: %v", err)
+	}
+	epic := plan.Epic
+	if epic.Title != "Search rework" || epic.IssueType != types.TypeEpic || epic.SpecID != "docs/search.md" {
+		t.Errorf("epic = %+v", epic)
+	}
+	if !strings.Contains(epic.Description, "Replace LIKE queries") || !strings.Contains(epic.Description, "## Background\nSearch is slow") {
+		t.Errorf("epic description missing intro or prose section:\n%s", epic.Description)
+	}
+
+	if len(plan.Children) != 2 {
+		t.Fatalf("got %d children, want 2", len(plan.Children))
+	}
+	build, search := plan.Children[0], plan.Children[1]
+	if build.Title != "Build the index" || build.Priority != 1 || build.IssueType != types.TypeTask {
+		t.Errorf("first child = %+v", build)
+	}
+	if build.AcceptanceCriteria != "- [ ] Index rebuilds on import\n- [x] Index survives restart" {
+		t.Errorf("acceptance = %q", build.AcceptanceCriteria)
+	}
+	if !strings.Contains(build.Description, "Index titles") || !strings.Contains(build.Description, "### Notes on storage\nKeep it") {
+		t.Errorf("description = %q", build.Description)
+	}
+	if search.Title != "Switch bd search" || strings.Join(search.Labels, ",") != "search,perf" {
+		t.Errorf("second child = %+v", search)
+	}
+	if search.AcceptanceCriteria != "" || !strings.Contains(search.Description, "## not a heading") {
+		t.Errorf("fenced code was parsed: %+v", search)
+	}
+}
+
+func TestParseDesignDocChecklist(t *testing.T) {
+	plan, err := parseDesignDoc("Rollout steps:\n- [x] Write migration\n- [ ] Run it\n", "rollout_plan.md")
+	if err != nil {
+		t.Fatalf("parseDesignDoc: %v", err)
+	}
+	if plan.Epic.Title != "rollout plan" || plan.Epic.Description != "Rollout steps:" {
+		t.Errorf("epic = %q / %q", plan.Epic.Title, plan.Epic.Description)
+	}
+	if len(plan.Children) != 2 || plan.Children[0].Status != types.StatusClosed || plan.Children[1].Status != types.StatusOpen {
+		t.Fatalf("children = %+v", plan.Children)
+	}
+	if plan.Children[1].Title != "Run it" {
+		t.Errorf("title = %q", plan.Children[1].Title)
+	}
+}
+
+func TestParseDesignDocNoWork(t *testing.T) {
+	if _, err := parseDesignDoc("# Idea\n\n## Background\nJust thoughts.\n", "idea.md"); err == nil {
+		t.Error("parseDesignDoc succeeded on a doc with no work")
+	}
+}
+
+func TestConfirmDocPlan(t *testing.T) {
+	newPlan := func() *docPlan {
+		return &docPlan{Epic: &types.Issue{}, Children: []*types.Issue{{Title: "a"}, {Title: "b"}, {Title: "c"}}}
+	}
+	titles := func(p *docPlan) string {
+		var got []string
+		for _, c := range p.Children {
+			got = append(got, c.Title)
+		}
+		return strings.Join(got, ",")
+	}
+	for _, tc := range []struct {
+		input string
+		ok    bool
+		want  string
+	}{
+		{"y\n", true, "a,b,c"},
+		{"\n", false, "a,b,c"},
+		{"r\nn\ny\nn\n", true, "b"},
+		{"r\nn\na\n", true, "b,c"},
+		{"r\ny\nq\n", false, "a,b,c"},
+		{"r\nn\nn\nn\n", false, ""},
+	} {
+		p := newPlan()
+		ok := confirmDocPlan(p, bufio.NewReader(strings.NewReader(tc.input)), io.Discard)
+		if ok != tc.ok || (ok && titles(p) != tc.want) {
+			t.Errorf("input %q: ok=%v children=%s, want ok=%v children=%s", tc.input, ok, titles(p), tc.ok, tc.want)
+		}
+	}
+}
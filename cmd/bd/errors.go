@@ -6,7 +6,9 @@ import (
 	"os"
 )
 
-// FatalError writes an error message to stderr and exits with code 1.
+// FatalError writes an error message to stderr and exits. The exit code is
+// 1, or a more specific one if an argument is an error exitCodeFor
+// recognizes (e.g. 2 for storage.ErrNotFound).
 // Use this for fatal errors that prevent the command from completing.
 //
 // Pattern A from ERROR_HANDLING.md:
@@ -21,10 +23,11 @@ import (
 //	}
 func FatalError(format string, args ...interface{}) {
 	fmt.Fprintf(os.Stderr, "Error: "+format+"\n", args...)
-	os.Exit(1)
+	os.Exit(exitCodeForArgs(args))
 }
 
-// FatalErrorRespectJSON writes an error message and exits with code 1.
+// FatalErrorRespectJSON writes an error message and exits with the same
+// code FatalError would.
 // If --json flag is set, outputs structured JSON to stdout.
 // Otherwise, outputs plain text to stderr.
 //
@@ -36,6 +39,18 @@ func FatalError(format string, args ...interface{}) {
 //	    FatalErrorRespectJSON("%v", err)
 //	}
 func FatalErrorRespectJSON(format string, args ...interface{}) {
+	FatalErrorCode(exitCodeForArgs(args), format, args...)
+}
+
+// FatalErrorCode is FatalErrorRespectJSON with an explicit exit code, for
+// failures that aren't errors exitCodeFor can classify.
+//
+// Example:
+//
+//	if !report.Valid {
+//	    FatalErrorCode(exitValidation, "%d issues failed validation", n)
+//	}
+func FatalErrorCode(code int, format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
 	if jsonOutput {
 		data, _ := json.MarshalIndent(map[string]string{"error": msg}, "", "  ") // json.MarshalIndent on simple maps does not fail in practice
@@ -43,7 +58,7 @@ func FatalErrorRespectJSON(format string, args ...interface{}) {
 	} else {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
 	}
-	os.Exit(1)
+	os.Exit(code)
 }
 
 // FatalErrorWithHint writes an error message with a hint to stderr and exits.
@@ -53,9 +68,18 @@ func FatalErrorRespectJSON(format string, args ...interface{}) {
 //
 //	FatalErrorWithHint("database not found", "Run 'bd init' to create a database")
 func FatalErrorWithHint(message, hint string) {
+	FatalErrorWithHintCode(exitError, message, hint)
+}
+
+// FatalErrorWithHintCode is FatalErrorWithHint with an explicit exit code.
+//
+// Example:
+//
+//	FatalErrorWithHintCode(exitConflict, err.Error(), "ask the holder to unlock it")
+func FatalErrorWithHintCode(code int, message, hint string) {
 	fmt.Fprintf(os.Stderr, "Error: %s\n", message)
 	fmt.Fprintf(os.Stderr, "Hint: %s\n", hint)
-	os.Exit(1)
+	os.Exit(code)
 }
 
 // WarnError writes a warning message to stderr and returns.
@@ -75,7 +99,7 @@ func WarnError(format string, args ...interface{}) {
 	fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
 }
 
-// CheckReadonly exits with an error if readonly mode is enabled.
+// CheckReadonly exits with an error (exit code 5) if readonly mode is enabled.
 // Call this at the start of write commands (create, update, close, delete, sync, etc.).
 // Used by worker sandboxes that should only read beads, not modify them.
 //
@@ -89,6 +113,6 @@ func WarnError(format string, args ...interface{}) {
 //	}
 func CheckReadonly(operation string) {
 	if readonlyMode {
-		FatalError("operation '%s' is not allowed in read-only mode", operation)
+		FatalErrorCode(exitPolicy, "operation '%s' is not allowed in read-only mode", operation)
 	}
 }
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/escalation"
+	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var escalateCmd = &cobra.Command{
+	Use:     "escalate",
+	GroupID: "issues",
+	Short:   "Escalate urgent issues nobody has acknowledged",
+	Long: `Walk unacknowledged urgent issues up an escalation chain, such as
+assignee, then team lead, then an org channel. Run it on a schedule (cron,
+CI) to keep P0/P1 issues from going unnoticed.
+
+An open issue moves one step along the chain each time it goes another
+escalation.after wait for its priority without being acknowledged, measured
+from when it was created. Each step runs the .beads/hooks/on_escalate hook
+with the issue ID and "escalate" as arguments, the issue JSON on stdin, and
+these environment variables, so the hook can page, mail, or post to a chat
+webhook:
+
+  BD_ESCALATION_CONTACT   Who to notify (the chain entry)
+  BD_ESCALATION_LEVEL     Step of the chain, starting at 1
+
+Each step is also printed and recorded in the issue's history, so it is
+notified once. 'bd ack <id>' stops an issue's escalation.
+
+Configuration (.beads/config.yaml):
+  escalation:
+    chain: [assignee, lead-bob, "#eng-oncall"]   # "assignee": the issue's assignee
+    after:
+      p0: 30m    # Wait before each step (default)
+      p1: 4h     # (default; other priorities don't escalate)
+
+Examples:
+  bd escalate             # Notify due steps
+  bd escalate --dry-run   # Show what is due without notifying`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if !dryRun {
+			CheckReadonly("escalate")
+		}
+		ctx := rootCtx
+
+		policy, err := loadEscalationPolicy()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if len(policy.Chain) == 0 {
+			FatalErrorWithHint("no escalation chain configured", "set escalation.chain in .beads/config.yaml; see 'bd escalate --help'")
+		}
+
+		var issues []*types.Issue
+		notTemplate := false
+		for _, priority := range policy.Priorities() {
+			priority := priority
+			found, err := store.SearchIssues(ctx, "", types.IssueFilter{
+				Priority:      &priority,
+				ExcludeStatus: []types.Status{types.StatusClosed},
+				IsTemplate:    &notTemplate,
+			})
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			issues = append(issues, found...)
+		}
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+		states, err := store.GetEscalationStates(ctx, ids)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		steps := escalationSteps(policy, issues, states, time.Now())
+		hookReady := hookRunner != nil && hookRunner.HookExists(hooks.EventEscalate)
+		if !dryRun && len(steps) > 0 && !hookReady {
+			WarnError("no executable .beads/hooks/on_escalate hook; escalations are only printed")
+		}
+		var done []escalationStep
+		for _, step := range steps {
+			if !dryRun {
+				if hookReady && step.Contact != "" {
+					if err := hookRunner.RunSyncEnv(hooks.EventEscalate, step.issue,
+						"BD_ESCALATION_CONTACT="+step.Contact,
+						"BD_ESCALATION_LEVEL="+strconv.Itoa(step.Level)); err != nil {
+						// Not recorded, so the next run retries it
+						WarnError("on_escalate hook failed for %s: %v", step.IssueID, err)
+						continue
+					}
+				}
+				if err := store.RecordEscalation(ctx, step.IssueID, actor, step.Level, step.Contact); err != nil {
+					FatalErrorRespectJSON("%v", err)
+				}
+			}
+			done = append(done, step)
+		}
+
+		if jsonOutput {
+			if done == nil {
+				done = []escalationStep{}
+			}
+			outputJSON(done)
+			return
+		}
+		if len(done) == 0 {
+			fmt.Println("No escalations due")
+			return
+		}
+		for _, step := range done {
+			contact := step.Contact
+			if contact == "" {
+				contact = ui.RenderMuted("(unassigned, skipped)")
+			}
+			fmt.Printf("%s %s [%s] → %s (step %d of %d): %s\n", ui.RenderWarn("⚠"),
+				ui.RenderID(step.IssueID), ui.RenderPriority(step.issue.Priority), contact,
+				step.Level, len(policy.Chain), step.issue.Title)
+		}
+		if dryRun {
+			fmt.Println(ui.RenderMuted("(dry run: nothing notified)"))
+		}
+	},
+}
+
+var ackCmd = &cobra.Command{
+	Use:     "ack <id>...",
+	GroupID: "issues",
+	Short:   "Acknowledge issues, stopping their escalation",
+	Long: `Acknowledge urgent issues so 'bd escalate' stops walking them up the
+escalation chain. The acknowledgment is recorded in the issue's history.
+
+Examples:
+  bd ack bd-42
+  bd ack bd-42 --note "Investigating, rollback in progress"`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("ack")
+		ctx := rootCtx
+		note, _ := cmd.Flags().GetString("note")
+
+		var acked []string
+		for _, arg := range args {
+			id, err := utils.ResolvePartialID(ctx, store, arg)
+			if err != nil {
+				FatalErrorRespectJSON("resolving %s: %v", arg, err)
+			}
+			if err := store.AcknowledgeIssue(ctx, id, actor, strings.TrimSpace(note)); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			SetLastTouchedID(id)
+			acked = append(acked, id)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"acknowledged": acked, "by": actor})
+			return
+		}
+		for _, id := range acked {
+			fmt.Printf("%s Acknowledged %s\n", ui.RenderPass("✓"), ui.RenderID(id))
+		}
+	},
+}
+
+// escalationStep is one notification bd escalate sends.
+type escalationStep struct {
+	IssueID string `json:"issue_id"`
+	Level   int    `json:"level"`
+	Contact string `json:"contact"` // Empty when the assignee step finds no assignee
+
+	issue *types.Issue
+}
+
+// loadEscalationPolicy parses the escalation config.
+func loadEscalationPolicy() (*escalation.Policy, error) {
+	return escalation.ParsePolicy(config.GetStringSlice("escalation.chain"), config.GetStringMapString("escalation.after"))
+}
+
+// escalationSteps returns the chain steps that are due for issues and not
+// yet notified, skipping acknowledged issues. A run that was missed catches
+// up on every step in order.
+func escalationSteps(policy *escalation.Policy, issues []*types.Issue, states map[string]*types.EscalationState, now time.Time) []escalationStep {
+	var steps []escalationStep
+	for _, issue := range issues {
+		notified := 0
+		if state := states[issue.ID]; state != nil {
+			if state.AcknowledgedAt != nil {
+				continue
+			}
+			notified = state.Level
+		}
+		for level := notified + 1; level <= policy.Level(issue.Priority, issue.CreatedAt, now); level++ {
+			steps = append(steps, escalationStep{
+				IssueID: issue.ID,
+				Level:   level,
+				Contact: policy.Contact(level, issue),
+				issue:   issue,
+			})
+		}
+	}
+	return steps
+}
+
+func init() {
+	escalateCmd.Flags().Bool("dry-run", false, "Show due escalations without notifying or recording them")
+	ackCmd.Flags().String("note", "", "Note recorded with the acknowledgment")
+	rootCmd.AddCommand(escalateCmd, ackCmd)
+}
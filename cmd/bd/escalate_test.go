@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/escalation"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEscalationSteps(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	policy := &escalation.Policy{
+		Chain: []string{"assignee", "lead", "#org"},
+		After: map[int]time.Duration{0: time.Hour},
+	}
+	acked := now.Add(-time.Minute)
+	issues := []*types.Issue{
+		{ID: "bd-1", Priority: 0, Assignee: "alice", CreatedAt: now.Add(-150 * time.Minute)}, // Steps 1-2 due
+		{ID: "bd-2", Priority: 0, CreatedAt: now.Add(-5 * time.Hour)},                        // Step 1 already sent
+		{ID: "bd-3", Priority: 0, CreatedAt: now.Add(-5 * time.Hour)},                        // Acknowledged
+		{ID: "bd-4", Priority: 1, CreatedAt: now.Add(-5 * time.Hour)},                        // P1 doesn't escalate
+	}
+	states := map[string]*types.EscalationState{
+		"bd-2": {Level: 1},
+		"bd-3": {AcknowledgedBy: "bob", AcknowledgedAt: &acked},
+	}
+
+	got := escalationSteps(policy, issues, states, now)
+	want := []escalationStep{
+		{IssueID: "bd-1", Level: 1, Contact: "alice"},
+		{IssueID: "bd-1", Level: 2, Contact: "lead"},
+		{IssueID: "bd-2", Level: 2, Contact: "lead"},
+		{IssueID: "bd-2", Level: 3, Contact: "#org"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("escalationSteps = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i].IssueID != want[i].IssueID || got[i].Level != want[i].Level || got[i].Contact != want[i].Contact {
+			t.Errorf("step %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var evidenceCmd = &cobra.Command{
+	Use:     "evidence [issue-id]",
+	GroupID: "issues",
+	Short:   "Show the verification evidence attached to an issue",
+	Long: `Show the verification evidence attached to an issue when it was closed
+with --evidence-url or --evidence-file.
+
+--cat prints a stored file, named by its evidence ID or file name (the most
+recent one with that name).
+
+Examples:
+  bd evidence bd-12
+  bd evidence bd-12 --json
+  bd evidence bd-12 --cat junit.xml`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		catRef, _ := cmd.Flags().GetString("cat")
+		ctx := rootCtx
+
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		byIssue, err := store.GetEvidence(ctx, []string{issueID})
+		if err != nil {
+			FatalErrorRespectJSON("getting evidence: %v", err)
+		}
+		evidence := byIssue[issueID]
+		if evidence == nil {
+			evidence = []*types.Evidence{}
+		}
+
+		if catRef != "" {
+			e := findEvidenceFile(evidence, catRef)
+			if e == nil {
+				FatalErrorRespectJSON("%s has no evidence file %q", issueID, catRef)
+			}
+			content, err := store.GetEvidenceContent(ctx, e.ContentHash)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			fmt.Print(content)
+			return
+		}
+
+		if jsonOutput {
+			outputJSON(evidence)
+			return
+		}
+		if len(evidence) == 0 {
+			fmt.Printf("No evidence on %s\n", issueID)
+			return
+		}
+		fmt.Printf("\nEvidence on %s:\n\n", issueID)
+		for _, e := range evidence {
+			detail := fmt.Sprintf("%s, %s", e.AddedBy, e.CreatedAt.Local().Format("2006-01-02 15:04"))
+			if e.Kind == types.EvidenceFile {
+				detail = fmt.Sprintf("%d bytes, %s", e.Size, detail)
+			}
+			fmt.Printf("  %d  %-4s  %s %s\n", e.ID, e.Kind, e.Ref, ui.RenderMuted("("+detail+")"))
+		}
+		fmt.Println()
+	},
+}
+
+func init() {
+	evidenceCmd.Flags().String("cat", "", "Print a stored evidence file, by evidence ID or file name")
+	evidenceCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(evidenceCmd)
+}
+
+// findEvidenceFile returns the evidence file with ID or name ref; of several
+// files with that name, the most recent.
+func findEvidenceFile(evidence []*types.Evidence, ref string) *types.Evidence {
+	id, idErr := strconv.ParseInt(ref, 10, 64)
+	var found *types.Evidence
+	for _, e := range evidence {
+		if e.Kind != types.EvidenceFile {
+			continue
+		}
+		if (idErr == nil && e.ID == id) || e.Ref == ref {
+			found = e
+		}
+	}
+	return found
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+)
+
+// Exit codes, so scripts can tell failures apart without parsing messages
+// (see docs/ERROR_HANDLING.md). They are part of bd's interface: don't
+// renumber them.
+const (
+	exitOK         = 0 // Success
+	exitError      = 1 // Any other error
+	exitNotFound   = 2 // An issue (or other requested entity) doesn't exist
+	exitValidation = 3 // Input or data failed validation (bd validate, bd lint, schema checks)
+	exitConflict   = 4 // Someone else holds the issue: already claimed, or locked
+	exitPolicy     = 5 // Refused by policy: read-only mode, secret scanning, lock admins, permissions
+)
+
+// exitCodeFor classifies err into an exit code. Only storage lookups count
+// as not found: a missing config or input file is an I/O problem, not a
+// missing issue.
+func exitCodeFor(err error) int {
+	var locked *dolt.IssueLockedError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, storage.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, storage.ErrValidation), errors.Is(err, storage.ErrCycle):
+		return exitValidation
+	case errors.Is(err, storage.ErrAlreadyClaimed), errors.As(err, &locked):
+		return exitConflict
+	case errors.Is(err, permissions.ErrDenied):
+		return exitPolicy
+	default:
+		return exitError
+	}
+}
+
+// exitCodeForArgs returns the exit code for the first error among a
+// FatalError call's format arguments, or exitError if there is none.
+func exitCodeForArgs(args []interface{}) int {
+	for _, arg := range args {
+		if err, ok := arg.(error); ok {
+			return exitCodeFor(err)
+		}
+	}
+	return exitError
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+func TestExitCodeFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, exitOK},
+		{"plain", errors.New("boom"), exitError},
+		{"not found", fmt.Errorf("%w: issue bd-1", storage.ErrNotFound), exitNotFound},
+		{"no match", fmt.Errorf("resolving: %w", &utils.NoMatchError{Input: "bd-x"}), exitNotFound},
+		{"missing file", fmt.Errorf("reading plan: %w", os.ErrNotExist), exitError},
+		{"validation", fmt.Errorf("%w: title is required", storage.ErrValidation), exitValidation},
+		{"cycle", storage.ErrCycle, exitValidation},
+		{"claimed", fmt.Errorf("%w by alice", storage.ErrAlreadyClaimed), exitConflict},
+		{"locked", fmt.Errorf("update: %w", &dolt.IssueLockedError{Lock: &types.IssueLock{IssueID: "bd-1", Holder: "alice"}}), exitConflict},
+		{"denied", fmt.Errorf("close: %w", &permissions.DeniedError{Actor: "bob", Capability: permissions.Close}), exitPolicy},
+	}
+	for _, tt := range tests {
+		if got := exitCodeFor(tt.err); got != tt.want {
+			t.Errorf("%s: exitCodeFor(%v) = %d, want %d", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestExitCodeForArgs(t *testing.T) {
+	if got := exitCodeForArgs([]interface{}{"bd-1", fmt.Errorf("%w: bd-1", storage.ErrNotFound)}); got != exitNotFound {
+		t.Errorf("exitCodeForArgs with a not-found error = %d, want %d", got, exitNotFound)
+	}
+	if got := exitCodeForArgs([]interface{}{"bd-1", 3}); got != exitError {
+		t.Errorf("exitCodeForArgs without an error = %d, want %d", got, exitError)
+	}
+	if got := exitCodeForArgs(nil); got != exitError {
+		t.Errorf("exitCodeForArgs(nil) = %d, want %d", got, exitError)
+	}
+}
+
+func TestNoMatchErrorMessage(t *testing.T) {
+	err := &utils.NoMatchError{Input: "bd-x"}
+	if got, want := err.Error(), `no issue found matching "bd-x"`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
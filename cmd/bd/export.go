@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/writepolicy"
+)
+
+var exportCmd = &cobra.Command{
+	Use:     "export",
+	GroupID: "sync",
+	Short:   "Export issues to JSONL or Obsidian markdown",
+	Long: `Export all persistent issues (not wisps), with their labels, dependencies,
+comments, and aliases, one JSON object per line. Output goes to stdout
+unless -o is given.
+
+--anonymize makes the export safe to share, e.g. when attaching a real
+database to a beads bug report:
+  - assignees, owners, actors, and comment authors become pseudonyms
+    (user-1, user-2, ...; with --salt, stable hashes such as user-3fa2c1d0)
+  - email addresses and mentions of known people in text are replaced
+  - secrets-looking strings (API keys, tokens, private keys, passwords,
+    credentials in URLs) and matches of scan.pii-patterns are replaced
+    with [REDACTED]
+  - session IDs are dropped
+Issue IDs, types, statuses, priorities, labels, timestamps, and the
+dependency graph are preserved. The same database and salt always produce
+the same pseudonyms.
+
+Examples:
+  bd export -o issues.jsonl                  # Full export
+  bd export --anonymize -o shareable.jsonl   # Safe to attach to a bug report
+  bd export --status open --format obsidian  # Open issues as Obsidian tasks`,
+	Run: runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringP("output", "o", "", "Write to this file instead of stdout")
+	exportCmd.Flags().String("format", "jsonl", "Output format: jsonl, obsidian")
+	exportCmd.Flags().StringP("status", "s", "", "Only export issues with this status")
+	exportCmd.Flags().Bool("anonymize", false, "Pseudonymize people and strip emails and secrets")
+	exportCmd.Flags().String("salt", "", "With --anonymize, derive pseudonyms from this secret (stable across exports)")
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, _ []string) {
+	output, _ := cmd.Flags().GetString("output")
+	format, _ := cmd.Flags().GetString("format")
+	status, _ := cmd.Flags().GetString("status")
+	anonymize, _ := cmd.Flags().GetBool("anonymize")
+	salt, _ := cmd.Flags().GetString("salt")
+
+	if format != "jsonl" && format != "obsidian" {
+		FatalErrorRespectJSON("invalid format %q (use: jsonl, obsidian)", format)
+	}
+	if salt != "" && !anonymize {
+		FatalErrorRespectJSON("--salt requires --anonymize")
+	}
+
+	loading := newProgress("Loading issues", 0)
+	issues, err := loadExportIssues(rootCtx, store, status)
+	loading.Done()
+	if err != nil {
+		if rootCtx.Err() != nil {
+			exitInterrupted("nothing was exported", map[string]interface{}{"count": 0})
+		}
+		FatalErrorRespectJSON("%v", err)
+	}
+	if anonymize {
+		scanner, err := writepolicy.NewSecretScanner()
+		if err != nil {
+			FatalErrorRespectJSON("scan.pii-patterns: %v", err)
+		}
+		newAnonymizer(salt, scanner).anonymizeIssues(issues)
+	}
+
+	var w io.Writer = os.Stdout
+	var f *os.File
+	if output != "" {
+		// #nosec G304 -- output path is provided by the user
+		if f, err = os.Create(output); err != nil {
+			FatalErrorRespectJSON("creating %s: %v", output, err)
+		}
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+	var progress *ui.Progress
+	if output != "" {
+		progress = newProgress("Exporting", len(issues))
+	} else {
+		// Don't draw over an export going to the terminal.
+		progress = ui.NewProgressTo(nil, "Exporting", len(issues))
+	}
+	if format == "obsidian" {
+		err = writeObsidianExport(bw, issues)
+	} else {
+		err = writeJSONLExport(rootCtx, bw, issues, progress.Add)
+	}
+	progress.Done()
+	interrupted := errors.Is(err, context.Canceled)
+	if interrupted {
+		// Keep what was written: each JSONL line is a complete issue.
+		err = nil
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	if f != nil {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		FatalErrorRespectJSON("writing export: %v", err)
+	}
+	if interrupted {
+		summary := fmt.Sprintf("exported %d of %d issues", progress.Count(), len(issues))
+		if output != "" {
+			summary += fmt.Sprintf("; %s is incomplete", output)
+		}
+		exitInterrupted(summary, map[string]interface{}{"path": output, "count": progress.Count(), "total": len(issues)})
+	}
+
+	if output == "" {
+		return
+	}
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"path":       output,
+			"format":     format,
+			"count":      len(issues),
+			"anonymized": anonymize,
+		})
+		return
+	}
+	note := ""
+	if anonymize {
+		note = " " + ui.RenderMuted("(anonymized)")
+	}
+	fmt.Printf("%s Exported %d issue(s) to %s%s\n", ui.RenderPass("✓"), len(issues), output, note)
+}
+
+// loadExportIssues returns persistent issues sorted by ID, with labels,
+// dependencies, comments, and external keys populated.
+func loadExportIssues(ctx context.Context, s *dolt.DoltStore, status string) ([]*types.Issue, error) {
+	persistent := false
+	filter := types.IssueFilter{Ephemeral: &persistent}
+	if status != "" && status != "all" {
+		st := types.Status(status)
+		filter.Status = &st
+	}
+	issues, err := s.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, fmt.Errorf("fetching issues: %w", err)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	deps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching dependencies: %w", err)
+	}
+	labels, err := s.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("fetching labels: %w", err)
+	}
+	comments, err := s.GetCommentsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("fetching comments: %w", err)
+	}
+	keys, err := s.GetExternalKeysForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("fetching external keys: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Dependencies = deps[issue.ID]
+		issue.Labels = labels[issue.ID]
+		issue.Comments = comments[issue.ID]
+		issue.ExternalKeys = keys[issue.ID]
+	}
+	return issues, nil
+}
+
+// writeJSONLExport writes one JSON object per issue, calling written after
+// each. It stops with ctx's error, after a complete line, when ctx is
+// canceled.
+func writeJSONLExport(ctx context.Context, w io.Writer, issues []*types.Issue, written func(int)) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, issue := range issues {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := enc.Encode(issue); err != nil {
+			return fmt.Errorf("encoding %s: %w", issue.ID, err)
+		}
+		written(1)
+	}
+	return nil
+}
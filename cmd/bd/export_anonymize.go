@@ -0,0 +1,227 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/secretscan"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// redacted replaces secrets and PII in anonymized exports.
+const redacted = "[REDACTED]"
+
+// emailPattern matches email addresses, which are pseudonymized like names.
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+// systemActors are generic, non-personal actor names kept as-is.
+var systemActors = map[string]bool{
+	"system": true, "unknown": true, "bd": true, "beads": true, "daemon": true,
+}
+
+// anonymizer pseudonymizes people and scrubs emails and secrets from
+// exported issues while keeping IDs and the dependency graph intact.
+type anonymizer struct {
+	salt    string
+	scanner *secretscan.Scanner // Secrets and configured PII to redact
+	names   map[string]string   // Real name or address -> pseudonym
+	next    int
+
+	// mentions matches known names as whole words in free text; built once
+	// every issue's people have been collected.
+	mentions *regexp.Regexp
+}
+
+func newAnonymizer(salt string, scanner *secretscan.Scanner) *anonymizer {
+	return &anonymizer{salt: salt, scanner: scanner, names: make(map[string]string)}
+}
+
+// pseudonym returns the stable stand-in for a person or email address:
+// user-N in order of first appearance, or a salted hash if a salt is set.
+func (a *anonymizer) pseudonym(name string) string {
+	key := strings.ToLower(name)
+	if name == "" || systemActors[key] {
+		return name
+	}
+	if p, ok := a.names[key]; ok {
+		return p
+	}
+	var p string
+	if a.salt != "" {
+		mac := hmac.New(sha256.New, []byte(a.salt))
+		mac.Write([]byte(key))
+		p = "user-" + hex.EncodeToString(mac.Sum(nil))[:8]
+	} else {
+		a.next++
+		p = fmt.Sprintf("user-%d", a.next)
+	}
+	if emailPattern.FindString(name) == name {
+		p += "@example.com"
+	}
+	a.names[key] = p
+	return p
+}
+
+// anonymizeIssues rewrites issues in place. Issues must be in a stable
+// order (loadExportIssues sorts by ID) for pseudonyms to be deterministic.
+func (a *anonymizer) anonymizeIssues(issues []*types.Issue) {
+	// First pass: assign pseudonyms in a deterministic order, so mentions
+	// of anyone in any issue can be recognized in text.
+	for _, issue := range issues {
+		for _, name := range issuePeople(issue) {
+			a.pseudonym(name)
+		}
+	}
+	a.buildMentions()
+
+	for _, issue := range issues {
+		a.anonymizeIssue(issue)
+	}
+}
+
+// issuePeople lists the people fields of an issue in a fixed order.
+func issuePeople(issue *types.Issue) []string {
+	people := []string{issue.CreatedBy, issue.Owner, issue.Assignee, issue.Sender, issue.Holder, issue.Actor}
+	people = append(people, issue.Waiters...)
+	if issue.Creator != nil {
+		people = append(people, issue.Creator.Name)
+	}
+	for _, v := range issue.Validations {
+		if v.Validator != nil {
+			people = append(people, v.Validator.Name)
+		}
+	}
+	for _, dep := range issue.Dependencies {
+		people = append(people, dep.CreatedBy)
+	}
+	for _, c := range issue.Comments {
+		people = append(people, c.Author)
+	}
+	for _, k := range issue.ExternalKeys {
+		people = append(people, k.CreatedBy)
+	}
+	return people
+}
+
+func (a *anonymizer) anonymizeIssue(issue *types.Issue) {
+	issue.CreatedBy = a.pseudonym(issue.CreatedBy)
+	issue.Owner = a.pseudonym(issue.Owner)
+	issue.Assignee = a.pseudonym(issue.Assignee)
+	issue.Sender = a.pseudonym(issue.Sender)
+	issue.Holder = a.pseudonym(issue.Holder)
+	issue.Actor = a.pseudonym(issue.Actor)
+	for i, w := range issue.Waiters {
+		issue.Waiters[i] = a.pseudonym(w)
+	}
+	issue.Creator = a.anonymizeEntity(issue.Creator)
+	for i := range issue.Validations {
+		issue.Validations[i].Validator = a.anonymizeEntity(issue.Validations[i].Validator)
+	}
+	issue.ClosedBySession = ""
+
+	for _, text := range []*string{
+		&issue.Title, &issue.Description, &issue.Design, &issue.AcceptanceCriteria,
+		&issue.Notes, &issue.CloseReason, &issue.Target, &issue.Payload,
+	} {
+		*text = a.scrubText(*text)
+	}
+	if issue.ExternalRef != nil {
+		ref := a.scrubText(*issue.ExternalRef)
+		issue.ExternalRef = &ref
+	}
+	issue.Metadata = a.scrubJSON(issue.Metadata)
+
+	for _, dep := range issue.Dependencies {
+		dep.CreatedBy = a.pseudonym(dep.CreatedBy)
+		if json.Valid([]byte(dep.Metadata)) {
+			dep.Metadata = string(a.scrubJSON(json.RawMessage(dep.Metadata)))
+		} else {
+			dep.Metadata = a.scrubText(dep.Metadata)
+		}
+	}
+	for _, c := range issue.Comments {
+		c.Author = a.pseudonym(c.Author)
+		c.Text = a.scrubText(c.Text)
+	}
+	for _, k := range issue.ExternalKeys {
+		k.CreatedBy = a.pseudonym(k.CreatedBy)
+	}
+}
+
+// anonymizeEntity returns a copy of ref naming only its pseudonym.
+func (a *anonymizer) anonymizeEntity(ref *types.EntityRef) *types.EntityRef {
+	if ref == nil {
+		return nil
+	}
+	return &types.EntityRef{Name: a.pseudonym(ref.Name), Platform: ref.Platform}
+}
+
+// buildMentions compiles a matcher for known names in free text. Very
+// short names are skipped to avoid rewriting ordinary words.
+func (a *anonymizer) buildMentions() {
+	var names []string
+	for name := range a.names {
+		if len(name) >= 3 && !emailPattern.MatchString(name) {
+			names = append(names, regexp.QuoteMeta(name))
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	// Longest first, so "alice smith" wins over "alice"
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	a.mentions = regexp.MustCompile(`(?i)\b(?:` + strings.Join(names, "|") + `)\b`)
+}
+
+// scrubText redacts secrets and replaces emails and known names.
+func (a *anonymizer) scrubText(s string) string {
+	if s == "" {
+		return s
+	}
+	s = a.scanner.Redact(s, redacted)
+	s = emailPattern.ReplaceAllStringFunc(s, a.pseudonym)
+	if a.mentions != nil {
+		s = a.mentions.ReplaceAllStringFunc(s, a.pseudonym)
+	}
+	return s
+}
+
+// scrubJSON scrubs every string value (not key) in a JSON document.
+func (a *anonymizer) scrubJSON(data json.RawMessage) json.RawMessage {
+	var v interface{}
+	if len(data) == 0 || json.Unmarshal(data, &v) != nil {
+		return data
+	}
+	out, err := json.Marshal(a.scrubValue(v))
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+func (a *anonymizer) scrubValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case string:
+		return a.scrubText(x)
+	case []interface{}:
+		for i := range x {
+			x[i] = a.scrubValue(x[i])
+		}
+	case map[string]interface{}:
+		for k := range x {
+			x[k] = a.scrubValue(x[k])
+		}
+	}
+	return v
+}
@@ -0,0 +1,349 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var exportFeedCmd = &cobra.Command{
+	Use:   "feed <closed|p0|epic:ID>",
+	Short: "Write an Atom feed of recent activity",
+	Long: `Write an Atom feed that stakeholders can follow in any feed reader,
+without a beads account:
+
+  closed     Issues closed recently, with their close reasons
+  p0         New P0 (critical) issues
+  epic:ID    Activity on an epic and everything under it: issues created,
+             closed and reopened, status changes and comments
+
+Feeds cover the last --days days, newest first. Publish them by writing
+them into a static site from cron or CI with -o. --base-url links each
+entry to <base-url>/<issue-id>, e.g. a page rendering the issue.
+
+Examples:
+  bd export feed closed -o public/feeds/closed.xml
+  bd export feed p0 --days 7 -o public/feeds/p0.xml
+  bd export feed epic:bd-12 --base-url https://tracker.example.com/issues`,
+	Args: cobra.ExactArgs(1),
+	Run:  runExportFeed,
+}
+
+func init() {
+	exportFeedCmd.Flags().StringP("output", "o", "", "Write to this file instead of stdout")
+	exportFeedCmd.Flags().Int("days", 14, "Include activity from the last N days")
+	exportFeedCmd.Flags().Int("limit", 50, "Maximum entries (0 = all)")
+	exportFeedCmd.Flags().String("base-url", "", "Link entries to <base-url>/<issue-id>")
+	exportCmd.AddCommand(exportFeedCmd)
+}
+
+// feedEntry is one entry of an activity feed.
+type feedEntry struct {
+	ID      string // Unique and stable, e.g. urn:bd:issue:bd-12:p0
+	IssueID string
+	Title   string
+	Author  string
+	Updated time.Time
+	Summary string
+}
+
+// atomFeed and the types below are the Atom (RFC 4287) elements bd writes.
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Updated   string      `xml:"updated"`
+	Generator string      `xml:"generator"`
+	Link      *atomLink   `xml:"link,omitempty"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title   string     `xml:"title"`
+	ID      string     `xml:"id"`
+	Updated string     `xml:"updated"`
+	Author  atomAuthor `xml:"author"`
+	Link    *atomLink  `xml:"link,omitempty"`
+	Summary string     `xml:"summary,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+func runExportFeed(cmd *cobra.Command, args []string) {
+	output, _ := cmd.Flags().GetString("output")
+	days, _ := cmd.Flags().GetInt("days")
+	limit, _ := cmd.Flags().GetInt("limit")
+	baseURL, _ := cmd.Flags().GetString("base-url")
+	if days < 1 {
+		FatalErrorRespectJSON("--days must be at least 1")
+	}
+	ctx := rootCtx
+	now := time.Now()
+	since := now.AddDate(0, 0, -days)
+
+	var title, feedID string
+	var entries []feedEntry
+	var err error
+	switch kind := args[0]; {
+	case kind == "closed":
+		title, feedID = "Recently closed issues", "urn:bd:feed:closed"
+		entries, err = closedFeedEntries(ctx, store, since)
+	case kind == "p0":
+		title, feedID = "New P0 issues", "urn:bd:feed:p0"
+		entries, err = p0FeedEntries(ctx, store, since)
+	case strings.HasPrefix(kind, "epic:"):
+		var epic *types.Issue
+		epic, entries, err = epicFeedEntries(ctx, store, strings.TrimPrefix(kind, "epic:"), since)
+		if epic != nil {
+			title, feedID = fmt.Sprintf("%s: %s", epic.ID, epic.Title), "urn:bd:feed:epic:"+epic.ID
+		}
+	default:
+		FatalErrorCode(exitValidation, "unknown feed %q (use: closed, p0, epic:ID)", kind)
+	}
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	sortFeedEntries(entries)
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	var w io.Writer = os.Stdout
+	var f *os.File
+	if output != "" {
+		// #nosec G304 -- output path is provided by the user
+		if f, err = os.Create(output); err != nil {
+			FatalErrorRespectJSON("creating %s: %v", output, err)
+		}
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+	err = writeAtomFeed(bw, title, feedID, baseURL, entries, now)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if f != nil {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		FatalErrorRespectJSON("writing feed: %v", err)
+	}
+
+	if output == "" {
+		return
+	}
+	if jsonOutput {
+		outputJSON(map[string]interface{}{"path": output, "feed": args[0], "entries": len(entries)})
+		return
+	}
+	fmt.Printf("%s Wrote %d feed entries to %s\n", ui.RenderPass("✓"), len(entries), output)
+}
+
+// closedFeedEntries returns an entry per issue closed since since.
+func closedFeedEntries(ctx context.Context, s *dolt.DoltStore, since time.Time) ([]feedEntry, error) {
+	closed, persistent := types.StatusClosed, false
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{Status: &closed, ClosedAfter: &since, Ephemeral: &persistent})
+	if err != nil {
+		return nil, fmt.Errorf("fetching closed issues: %w", err)
+	}
+	var entries []feedEntry
+	for _, issue := range issues {
+		if issue.ClosedAt == nil {
+			continue
+		}
+		summary := issue.CloseReason
+		if summary == "" {
+			summary = feedSummary(issue.Description)
+		}
+		entries = append(entries, feedEntry{
+			ID:      fmt.Sprintf("urn:bd:issue:%s:closed:%d", issue.ID, issue.ClosedAt.Unix()),
+			IssueID: issue.ID,
+			Title:   fmt.Sprintf("Closed %s: %s", issue.ID, issue.Title),
+			Author:  issue.Assignee,
+			Updated: *issue.ClosedAt,
+			Summary: summary,
+		})
+	}
+	return entries, nil
+}
+
+// p0FeedEntries returns an entry per P0 issue created since since.
+func p0FeedEntries(ctx context.Context, s *dolt.DoltStore, since time.Time) ([]feedEntry, error) {
+	p0, persistent := 0, false
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{Priority: &p0, CreatedAfter: &since, Ephemeral: &persistent})
+	if err != nil {
+		return nil, fmt.Errorf("fetching P0 issues: %w", err)
+	}
+	var entries []feedEntry
+	for _, issue := range issues {
+		entries = append(entries, feedEntry{
+			ID:      "urn:bd:issue:" + issue.ID + ":p0",
+			IssueID: issue.ID,
+			Title:   fmt.Sprintf("P0 %s: %s [%s]", issue.ID, issue.Title, issue.Status),
+			Author:  issue.CreatedBy,
+			Updated: issue.CreatedAt,
+			Summary: feedSummary(issue.Description),
+		})
+	}
+	return entries, nil
+}
+
+// epicFeedEntries returns an entry per event since since on epic epicID or
+// any issue under it.
+func epicFeedEntries(ctx context.Context, s *dolt.DoltStore, epicID string, since time.Time) (*types.Issue, []feedEntry, error) {
+	epicID, err := utils.ResolvePartialID(ctx, s, epicID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving %s: %w", epicID, err)
+	}
+	issues, err := getHierarchicalChildren(ctx, s, "", epicID)
+	if err != nil {
+		return nil, nil, err
+	}
+	var epic *types.Issue
+	var entries []feedEntry
+	for _, issue := range issues {
+		if issue.ID == epicID {
+			epic = issue
+		}
+		events, err := s.GetEvents(ctx, issue.ID, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, e := range events {
+			if e.CreatedAt.Before(since) {
+				continue
+			}
+			if entry, ok := eventFeedEntry(issue, e); ok {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return epic, entries, nil
+}
+
+// eventFeedEntry describes e on issue for a feed reader. Events that mean
+// little outside the team (label edits, locks, reactions...) are left out.
+func eventFeedEntry(issue *types.Issue, e *types.Event) (feedEntry, bool) {
+	entry := feedEntry{
+		ID:      fmt.Sprintf("urn:bd:event:%d", e.ID),
+		IssueID: issue.ID,
+		Author:  e.Actor,
+		Updated: e.CreatedAt,
+	}
+	deref := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	switch e.EventType {
+	case types.EventCreated:
+		entry.Title = fmt.Sprintf("Created %s: %s", issue.ID, issue.Title)
+		entry.Summary = feedSummary(issue.Description)
+	case types.EventClosed:
+		entry.Title = fmt.Sprintf("Closed %s: %s", issue.ID, issue.Title)
+		entry.Summary = deref(e.Comment)
+	case types.EventReopened:
+		entry.Title = fmt.Sprintf("Reopened %s: %s", issue.ID, issue.Title)
+		entry.Summary = deref(e.Comment)
+	case types.EventStatusChanged:
+		entry.Title = fmt.Sprintf("%s: %s → %s", issue.ID, feedStatus(deref(e.OldValue)), feedStatus(deref(e.NewValue)))
+		entry.Summary = issue.Title
+	case types.EventCommented:
+		entry.Title = fmt.Sprintf("Comment on %s: %s", issue.ID, issue.Title)
+		entry.Summary = feedSummary(deref(e.Comment))
+	default:
+		return feedEntry{}, false
+	}
+	return entry, true
+}
+
+// feedStatus returns the status in a status_changed event value (the
+// issue's fields as JSON), or "?" if it has none.
+func feedStatus(value string) types.Status {
+	var fields struct {
+		Status types.Status `json:"status"`
+	}
+	if json.Unmarshal([]byte(value), &fields) != nil || fields.Status == "" {
+		return "?"
+	}
+	return fields.Status
+}
+
+// feedSummary returns the first paragraph of text, cut to 500 characters.
+func feedSummary(text string) string {
+	text = strings.TrimSpace(text)
+	if i := strings.Index(text, "\n\n"); i >= 0 {
+		text = text[:i]
+	}
+	if r := []rune(text); len(r) > 500 {
+		text = string(r[:497]) + "..."
+	}
+	return text
+}
+
+// sortFeedEntries orders entries newest first.
+func sortFeedEntries(entries []feedEntry) {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Updated.After(entries[j].Updated) })
+}
+
+// writeAtomFeed writes entries as an Atom feed. An empty feed is updated
+// at now; otherwise at its newest entry.
+func writeAtomFeed(w io.Writer, title, id, baseURL string, entries []feedEntry, now time.Time) error {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	feed := atomFeed{Title: title, ID: id, Updated: now.UTC().Format(time.RFC3339), Generator: "bd", Entries: []atomEntry{}}
+	if baseURL != "" {
+		feed.Link = &atomLink{Href: baseURL}
+	}
+	if len(entries) > 0 {
+		feed.Updated = entries[0].Updated.UTC().Format(time.RFC3339)
+	}
+	for _, e := range entries {
+		author := e.Author
+		if author == "" {
+			author = "beads"
+		}
+		entry := atomEntry{
+			Title:   e.Title,
+			ID:      e.ID,
+			Updated: e.Updated.UTC().Format(time.RFC3339),
+			Author:  atomAuthor{Name: author},
+			Summary: e.Summary,
+		}
+		if baseURL != "" {
+			entry.Link = &atomLink{Href: baseURL + "/" + e.IssueID}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
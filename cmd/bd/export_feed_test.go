@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWriteAtomFeed(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	entries := []feedEntry{
+		{ID: "urn:bd:event:2", IssueID: "bd-2", Title: "Closed bd-2: Fix <login>", Author: "ana", Updated: now.Add(-time.Hour), Summary: "Done & shipped"},
+		{ID: "urn:bd:event:1", IssueID: "bd-1", Title: "Created bd-1: Search", Updated: now.Add(-2 * time.Hour)},
+	}
+	var buf bytes.Buffer
+	if err := writeAtomFeed(&buf, "Roadmap", "urn:bd:feed:epic:bd-1", "https://tracker.example.com/issues/", entries, now); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		`<updated>2026-03-02T11:00:00Z</updated>`,
+		`<title>Closed bd-2: Fix &lt;login&gt;</title>`,
+		`<link href="https://tracker.example.com/issues/bd-2"></link>`,
+		`<summary>Done &amp; shipped</summary>`,
+		`<name>beads</name>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("feed lacks %s:\n%s", want, out)
+		}
+	}
+
+	var parsed struct {
+		Entries []struct {
+			ID string `xml:"id"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &parsed); err != nil || len(parsed.Entries) != 2 {
+		t.Errorf("feed doesn't parse back: %v, %+v", err, parsed)
+	}
+
+	buf.Reset()
+	if err := writeAtomFeed(&buf, "Recently closed issues", "urn:bd:feed:closed", "", nil, now); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "<updated>2026-03-02T12:00:00Z</updated>") || strings.Contains(buf.String(), "<link") {
+		t.Errorf("empty feed:\n%s", buf.String())
+	}
+}
+
+func TestEventFeedEntry(t *testing.T) {
+	issue := &types.Issue{ID: "bd-3", Title: "Index", Description: "Build the index.\n\nDetails follow."}
+	str := func(s string) *string { return &s }
+	at := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		event   types.Event
+		title   string
+		summary string
+	}{
+		{types.Event{ID: 1, EventType: types.EventCreated}, "Created bd-3: Index", "Build the index."},
+		{types.Event{ID: 2, EventType: types.EventStatusChanged, OldValue: str(`{"status":"open","title":"Index"}`), NewValue: str(`{"status":"in_progress"}`)},
+			"bd-3: open → in_progress", "Index"},
+		{types.Event{ID: 3, EventType: types.EventCommented, Comment: str("Halfway there")}, "Comment on bd-3: Index", "Halfway there"},
+		{types.Event{ID: 4, EventType: types.EventClosed, Comment: str("Merged")}, "Closed bd-3: Index", "Merged"},
+	}
+	for _, tt := range tests {
+		tt.event.Actor, tt.event.CreatedAt = "ana", at
+		entry, ok := eventFeedEntry(issue, &tt.event)
+		if !ok || entry.Title != tt.title || entry.Summary != tt.summary || entry.Author != "ana" || !entry.Updated.Equal(at) {
+			t.Errorf("%s: entry = %+v, %v", tt.event.EventType, entry, ok)
+		}
+	}
+	if _, ok := eventFeedEntry(issue, &types.Event{EventType: types.EventLabelAdded}); ok {
+		t.Error("label events should be left out of feeds")
+	}
+}
+
+func TestFeedSummary(t *testing.T) {
+	if got := feedSummary("  First paragraph.\n\nSecond."); got != "First paragraph." {
+		t.Errorf("feedSummary = %q", got)
+	}
+	if got := feedSummary(strings.Repeat("é", 600)); len([]rune(got)) != 500 || !strings.HasSuffix(got, "...") {
+		t.Errorf("long summary has %d runes", len([]rune(got)))
+	}
+}
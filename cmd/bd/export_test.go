@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestWriteJSONLExportCanceled(t *testing.T) {
+	issues := []*types.Issue{{ID: "bd-1", Title: "One"}, {ID: "bd-2", Title: "Two"}, {ID: "bd-3", Title: "Three"}}
+	ctx, cancel := context.WithCancel(context.Background())
+	var sb strings.Builder
+	written := 0
+	err := writeJSONLExport(ctx, &sb, issues, func(n int) {
+		written += n
+		if written == 2 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if written != 2 || strings.Count(sb.String(), "\n") != 2 || !strings.Contains(sb.String(), `"id":"bd-2"`) {
+		t.Errorf("wrote %d issues:\n%s", written, sb.String())
+	}
+}
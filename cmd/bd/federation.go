@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/storage"
@@ -14,12 +15,18 @@ import (
 	"golang.org/x/term"
 )
 
+// cgoEnabled reports whether this binary was built with cgo, which
+// federation requires.
+const cgoEnabled = true
+
 var (
 	federationPeer     string
 	federationStrategy string
 	federationUser     string
 	federationPassword string
 	federationSov      string
+	federationGroup    string
+	federationDue      bool
 )
 
 var federationCmd = &cobra.Command{
@@ -35,12 +42,15 @@ Requires the Dolt storage backend.`,
 }
 
 var federationSyncCmd = &cobra.Command{
-	Use:   "sync [--peer name]",
+	Use:   "sync [--peer name | --group name | --due]",
 	Short: "Synchronize with a peer town",
 	Long: `Pull from and push to peer towns.
 
 Without --peer, syncs with all configured peers.
 With --peer, syncs only with the specified peer.
+With --group, syncs every peer in the group using the group's policies.
+With --due, syncs each group whose scheduled interval has elapsed
+(intended for cron or other periodic runners).
 
 Handles merge conflicts using the configured strategy:
   --strategy ours    Keep local changes on conflict
@@ -52,6 +62,8 @@ and report which tables have conflicts for manual resolution.
 Examples:
   bd federation sync                      # Sync with all peers
   bd federation sync --peer town-beta     # Sync with specific peer
+  bd federation sync --group partners     # Sync with a peer group
+  bd federation sync --due                # Sync groups whose schedule is due
   bd federation sync --strategy theirs    # Auto-resolve using remote values`,
 	Run: runFederationSync,
 }
@@ -118,6 +130,9 @@ func init() {
 	// Flags for sync
 	federationSyncCmd.Flags().StringVar(&federationPeer, "peer", "", "Specific peer to sync with")
 	federationSyncCmd.Flags().StringVar(&federationStrategy, "strategy", "", "Conflict resolution strategy (ours|theirs)")
+	federationSyncCmd.Flags().StringVar(&federationGroup, "group", "", "Sync all peers in a federation group")
+	federationSyncCmd.Flags().BoolVar(&federationDue, "due", false, "Sync only groups whose scheduled interval has elapsed")
+	federationSyncCmd.MarkFlagsMutuallyExclusive("peer", "group", "due")
 
 	// Flags for status
 	federationStatusCmd.Flags().StringVar(&federationPeer, "peer", "", "Specific peer to check")
@@ -150,6 +165,11 @@ func runFederationSync(cmd *cobra.Command, args []string) {
 		FatalErrorRespectJSON("invalid strategy %q: must be 'ours' or 'theirs'", federationStrategy)
 	}
 
+	if federationGroup != "" || federationDue {
+		runFederationGroupSync(ds)
+		return
+	}
+
 	// Get peers to sync with
 	var peers []string
 	if federationPeer != "" {
@@ -174,60 +194,135 @@ func runFederationSync(cmd *cobra.Command, args []string) {
 
 	// Sync with each peer
 	var results []*dolt.SyncResult
-	for _, peer := range peers {
+	for i, peer := range peers {
 		if !jsonOutput {
 			fmt.Printf("%s Syncing with %s...\n", ui.RenderAccent("🔄"), peer)
 		}
 
+		progress := newProgress(fmt.Sprintf("Peer %d of %d", i+1, len(peers)), 0)
 		result, err := ds.Sync(ctx, peer, federationStrategy)
+		progress.Done()
+		if ctx.Err() != nil {
+			exitInterrupted(fmt.Sprintf("synced %d of %d peers; the sync with %s was stopped", i, len(peers), peer),
+				map[string]interface{}{"peers": peers, "results": results})
+		}
 		results = append(results, result)
+		printFederationSyncResult(result, err)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"peers":   peers,
+			"results": results,
+		})
+	}
+}
 
+// runFederationGroupSync handles 'bd federation sync --group' and '--due'.
+func runFederationGroupSync(ds *dolt.DoltStore) {
+	ctx := rootCtx
+
+	var groups []*storage.FederationGroup
+	if federationGroup != "" {
+		group, err := ds.GetFederationGroup(ctx, federationGroup)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		groups = append(groups, group)
+	} else {
+		all, err := ds.ListFederationGroups(ctx)
 		if err != nil {
-			if !jsonOutput {
-				fmt.Printf("  %s %v\n", ui.RenderFail("✗"), err)
+			FatalErrorRespectJSON("failed to list groups: %v", err)
+		}
+		now := time.Now()
+		for _, g := range all {
+			if g.SyncDue(now) {
+				groups = append(groups, g)
 			}
-			continue
 		}
+	}
 
+	if len(groups) == 0 {
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"groups": map[string]interface{}{}})
+		} else {
+			fmt.Println("No federation groups due for sync.")
+		}
+		return
+	}
+
+	groupResults := make(map[string][]*dolt.SyncResult)
+	for i, group := range groups {
 		if !jsonOutput {
-			if result.Fetched {
-				fmt.Printf("  %s Fetched\n", ui.RenderPass("✓"))
-			}
-			if result.Merged {
-				fmt.Printf("  %s Merged", ui.RenderPass("✓"))
-				if result.PulledCommits > 0 {
-					fmt.Printf(" (%d commits)", result.PulledCommits)
-				}
-				fmt.Println()
-			}
-			if len(result.Conflicts) > 0 {
-				if result.ConflictsResolved {
-					fmt.Printf("  %s Resolved %d conflicts using %s strategy\n",
-						ui.RenderPass("✓"), len(result.Conflicts), federationStrategy)
-				} else {
-					fmt.Printf("  %s %d conflicts need resolution\n",
-						ui.RenderWarn("⚠"), len(result.Conflicts))
-					for _, c := range result.Conflicts {
-						fmt.Printf("    - %s\n", c.Field)
-					}
-				}
-			}
-			if result.Pushed {
-				fmt.Printf("  %s Pushed\n", ui.RenderPass("✓"))
-			} else if result.PushError != nil {
-				fmt.Printf("  %s Push skipped: %v\n", ui.RenderMuted("○"), result.PushError)
-			}
+			fmt.Printf("%s Syncing group %s (%d peers)...\n",
+				ui.RenderAccent("🔄"), group.Name, len(group.Peers))
+		}
+		progress := newProgress(fmt.Sprintf("Group %d of %d", i+1, len(groups)), 0)
+		results, err := ds.SyncGroup(ctx, group, federationStrategy)
+		progress.Done()
+		if ctx.Err() != nil {
+			exitInterrupted(fmt.Sprintf("synced %d of %d groups; the sync of group %s was stopped", i, len(groups), group.Name),
+				map[string]interface{}{"groups": groupResults})
+		}
+		groupResults[group.Name] = results
+		if jsonOutput {
+			continue
+		}
+		for _, result := range results {
+			fmt.Printf("  %s\n", result.Peer)
+			printFederationSyncResult(result, result.Error)
+		}
+		if err != nil {
+			fmt.Printf("  %s %v\n", ui.RenderFail("✗"), err)
 		}
 	}
 
 	if jsonOutput {
 		outputJSON(map[string]interface{}{
-			"peers":   peers,
-			"results": results,
+			"groups": groupResults,
 		})
 	}
 }
 
+// printFederationSyncResult prints the outcome of syncing with a single peer.
+func printFederationSyncResult(result *dolt.SyncResult, err error) {
+	if jsonOutput {
+		return
+	}
+	if err != nil {
+		fmt.Printf("  %s %v\n", ui.RenderFail("✗"), err)
+		return
+	}
+
+	if result.Fetched {
+		fmt.Printf("  %s Fetched\n", ui.RenderPass("✓"))
+	}
+	if result.Merged {
+		fmt.Printf("  %s Merged", ui.RenderPass("✓"))
+		if result.PulledCommits > 0 {
+			fmt.Printf(" (%d commits)", result.PulledCommits)
+		}
+		fmt.Println()
+	}
+	if len(result.Conflicts) > 0 {
+		if result.ConflictsResolved {
+			fmt.Printf("  %s Resolved %d conflicts using %s strategy\n",
+				ui.RenderPass("✓"), len(result.Conflicts), federationStrategy)
+		} else {
+			fmt.Printf("  %s %d conflicts need resolution\n",
+				ui.RenderWarn("⚠"), len(result.Conflicts))
+			for _, c := range result.Conflicts {
+				fmt.Printf("    - %s\n", c.Field)
+			}
+		}
+	}
+	if result.Pushed {
+		fmt.Printf("  %s Pushed\n", ui.RenderPass("✓"))
+	} else if result.PushError != nil {
+		fmt.Printf("  %s Push skipped: %v\n", ui.RenderMuted("○"), result.PushError)
+	}
+}
+
 func runFederationStatus(cmd *cobra.Command, args []string) {
 	ctx := rootCtx
 
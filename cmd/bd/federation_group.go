@@ -0,0 +1,274 @@
+//go:build cgo
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var (
+	federationGroupInterval string
+	federationGroupRedact   string
+	federationGroupSov      string
+)
+
+var federationGroupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Manage federation peer groups",
+	Long: `Manage named groups of federation peers.
+
+A group applies one sync schedule, redaction policy, and sovereignty tier
+to all of its member peers. Sync a whole group with:
+
+  bd federation sync --group <name>
+
+Groups with a redaction policy only pull from their peers: Dolt pushes
+whole commits, so withheld fields cannot be filtered out of a push.
+
+Examples:
+  bd federation group add partners beta gamma
+  bd federation group add partners delta --interval 1h
+  bd federation group set partners --redact notes,design --sovereignty T2
+  bd federation group remove partners gamma
+  bd federation group remove partners           # Delete the group
+  bd federation group list`,
+}
+
+var federationGroupAddCmd = &cobra.Command{
+	Use:   "add <group> <peer>...",
+	Short: "Add peers to a group (creating it if needed)",
+	Args:  cobra.MinimumNArgs(2),
+	Run:   runFederationGroupAdd,
+}
+
+var federationGroupRemoveCmd = &cobra.Command{
+	Use:   "remove <group> [peer...]",
+	Short: "Remove peers from a group, or delete the group",
+	Args:  cobra.MinimumNArgs(1),
+	Run:   runFederationGroupRemove,
+}
+
+var federationGroupSetCmd = &cobra.Command{
+	Use:   "set <group>",
+	Short: "Update a group's sync schedule, redaction, or sovereignty",
+	Args:  cobra.ExactArgs(1),
+	Run:   runFederationGroupSet,
+}
+
+var federationGroupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List federation peer groups",
+	Run:   runFederationGroupList,
+}
+
+func init() {
+	federationGroupCmd.AddCommand(federationGroupAddCmd)
+	federationGroupCmd.AddCommand(federationGroupRemoveCmd)
+	federationGroupCmd.AddCommand(federationGroupSetCmd)
+	federationGroupCmd.AddCommand(federationGroupListCmd)
+
+	for _, c := range []*cobra.Command{federationGroupAddCmd, federationGroupSetCmd} {
+		c.Flags().StringVar(&federationGroupInterval, "interval", "", "Scheduled sync interval (e.g., 30m, 6h; 0 = on demand only)")
+		c.Flags().StringVar(&federationGroupRedact, "redact", "", "Comma-separated issue fields to withhold (\"none\" clears)")
+		c.Flags().StringVar(&federationGroupSov, "sovereignty", "", "Sovereignty tier for the group (T1, T2, T3, T4)")
+	}
+
+	federationCmd.AddCommand(federationGroupCmd)
+}
+
+// applyFederationGroupFlags applies --interval, --redact, and --sovereignty to a group.
+// Only flags explicitly set on cmd are applied.
+func applyFederationGroupFlags(cmd *cobra.Command, group *storage.FederationGroup) error {
+	if cmd.Flags().Changed("interval") {
+		interval, err := time.ParseDuration(federationGroupInterval)
+		if err != nil || interval < 0 {
+			return fmt.Errorf("invalid interval %q: must be a duration like 30m or 6h", federationGroupInterval)
+		}
+		group.SyncInterval = interval
+	}
+	if cmd.Flags().Changed("redact") {
+		if strings.EqualFold(federationGroupRedact, "none") {
+			group.Redact = nil
+		} else {
+			fields, err := storage.ValidateRedactFields(strings.Split(federationGroupRedact, ","))
+			if err != nil {
+				return err
+			}
+			group.Redact = fields
+		}
+	}
+	if cmd.Flags().Changed("sovereignty") {
+		sov := strings.ToUpper(federationGroupSov)
+		if sov != "" && sov != "T1" && sov != "T2" && sov != "T3" && sov != "T4" {
+			return fmt.Errorf("invalid sovereignty tier: %s (must be T1, T2, T3, or T4)", federationGroupSov)
+		}
+		group.Sovereignty = sov
+	}
+	return nil
+}
+
+func runFederationGroupAdd(cmd *cobra.Command, args []string) {
+	CheckReadonly("federation group add")
+	ctx := rootCtx
+
+	ds, err := getFederatedStore()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	name, peers := args[0], args[1:]
+
+	// Members must be configured remotes so group syncs can reach them
+	remotes, err := ds.ListRemotes(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("failed to list peers: %v", err)
+	}
+	known := make(map[string]bool, len(remotes))
+	for _, r := range remotes {
+		known[r.Name] = true
+	}
+	for _, p := range peers {
+		if !known[p] {
+			FatalErrorRespectJSON("unknown peer %q (use 'bd federation add-peer' first)", p)
+		}
+	}
+
+	group, err := ds.GetFederationGroup(ctx, name)
+	if errors.Is(err, storage.ErrNotFound) {
+		group = &storage.FederationGroup{Name: name}
+	} else if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	added := group.AddPeers(peers...)
+	if err := applyFederationGroupFlags(cmd, group); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if err := ds.SaveFederationGroup(ctx, group); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(group)
+		return
+	}
+	fmt.Printf("%s Added %d peer(s) to group %s (%d total)\n",
+		ui.RenderPass("✓"), added, ui.RenderAccent(name), len(group.Peers))
+}
+
+func runFederationGroupRemove(cmd *cobra.Command, args []string) {
+	CheckReadonly("federation group remove")
+	ctx := rootCtx
+
+	ds, err := getFederatedStore()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	name, peers := args[0], args[1:]
+
+	if len(peers) == 0 {
+		if err := ds.RemoveFederationGroup(ctx, name); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"removed": name})
+			return
+		}
+		fmt.Printf("Removed group: %s\n", name)
+		return
+	}
+
+	group, err := ds.GetFederationGroup(ctx, name)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	removed := group.RemovePeers(peers...)
+	if err := ds.SaveFederationGroup(ctx, group); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(group)
+		return
+	}
+	fmt.Printf("Removed %d peer(s) from group %s (%d remaining)\n", removed, name, len(group.Peers))
+}
+
+func runFederationGroupSet(cmd *cobra.Command, args []string) {
+	CheckReadonly("federation group set")
+	ctx := rootCtx
+
+	ds, err := getFederatedStore()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	group, err := ds.GetFederationGroup(ctx, args[0])
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if err := applyFederationGroupFlags(cmd, group); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if err := ds.SaveFederationGroup(ctx, group); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(group)
+		return
+	}
+	fmt.Printf("%s Updated group %s\n", ui.RenderPass("✓"), ui.RenderAccent(group.Name))
+}
+
+func runFederationGroupList(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+
+	ds, err := getFederatedStore()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	groups, err := ds.ListFederationGroups(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(groups)
+		return
+	}
+
+	if len(groups) == 0 {
+		fmt.Println("No federation groups configured.")
+		return
+	}
+
+	fmt.Printf("\n%s Federation Groups:\n\n", ui.RenderAccent("🌐"))
+	for _, g := range groups {
+		fmt.Printf("  %s  %s\n", ui.RenderAccent(g.Name), strings.Join(g.Peers, ", "))
+		if g.SyncInterval > 0 {
+			fmt.Printf("    Schedule:    every %s\n", g.SyncInterval)
+		} else {
+			fmt.Printf("    Schedule:    %s\n", ui.RenderMuted("on demand"))
+		}
+		if len(g.Redact) > 0 {
+			fmt.Printf("    Redact:      %s (pull only)\n", strings.Join(g.Redact, ", "))
+		}
+		if g.Sovereignty != "" {
+			fmt.Printf("    Sovereignty: %s\n", g.Sovereignty)
+		}
+		if g.LastSync != nil {
+			fmt.Printf("    Last sync:   %s\n", g.LastSync.Format("2006-01-02 15:04:05"))
+		}
+	}
+	fmt.Println()
+}
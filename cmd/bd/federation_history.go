@@ -0,0 +1,148 @@
+//go:build cgo
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var (
+	federationHistoryLimit int
+	federationHistoryStats bool
+)
+
+var federationHistoryCmd = &cobra.Command{
+	Use:   "history [peer]",
+	Short: "Show federation sync history and per-peer statistics",
+	Long: `Show recorded federation syncs and aggregate statistics.
+
+Every 'bd federation sync' records its duration, commits pulled and pushed,
+conflicts, and errors. Use this history to monitor flaky peers.
+
+With a peer argument, lists that peer's recent syncs followed by its stats.
+Without a peer (or with --stats), shows aggregate stats for every peer:
+success rate, average and p95 duration, and the most recent error.
+
+Examples:
+  bd federation history                   # Stats for all peers
+  bd federation history town-beta         # Recent syncs with town-beta
+  bd federation history town-beta -n 50   # Last 50 syncs
+  bd federation history --json            # Machine-readable stats`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runFederationHistory,
+}
+
+func init() {
+	federationHistoryCmd.Flags().IntVarP(&federationHistoryLimit, "limit", "n", 20, "Number of recent syncs to list")
+	federationHistoryCmd.Flags().BoolVar(&federationHistoryStats, "stats", false, "Show only aggregate statistics")
+	federationCmd.AddCommand(federationHistoryCmd)
+}
+
+func runFederationHistory(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+
+	ds, err := getFederatedStore()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	peer := ""
+	if len(args) > 0 {
+		peer = args[0]
+	}
+
+	// Stats are computed over the full history, not just the listed window
+	all, err := ds.GetSyncHistory(ctx, peer, 0)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	stats := storage.ComputeSyncStats(all)
+
+	showEntries := peer != "" && !federationHistoryStats
+	var recent []*storage.SyncHistoryEntry
+	if showEntries {
+		recent = all
+		if federationHistoryLimit > 0 && len(recent) > federationHistoryLimit {
+			recent = recent[:federationHistoryLimit]
+		}
+	}
+
+	if jsonOutput {
+		result := map[string]interface{}{
+			"stats": stats,
+		}
+		if showEntries {
+			result["history"] = recent
+		}
+		outputJSON(result)
+		return
+	}
+
+	if len(all) == 0 {
+		if peer != "" {
+			fmt.Printf("No sync history for peer %s.\n", peer)
+		} else {
+			fmt.Println("No federation sync history recorded.")
+		}
+		return
+	}
+
+	if showEntries {
+		fmt.Printf("\n%s Sync history for %s:\n\n", ui.RenderAccent("🕘"), ui.RenderAccent(peer))
+		for _, e := range recent {
+			mark := ui.RenderPass("✓")
+			if !e.Succeeded() {
+				mark = ui.RenderFail("✗")
+			}
+			fmt.Printf("  %s %s  %6s  pulled %d, pushed %d",
+				mark, e.StartedAt.Local().Format("2006-01-02 15:04:05"),
+				formatSyncDuration(e.Duration), e.PulledCommits, e.PushedCommits)
+			if e.Conflicts > 0 {
+				fmt.Printf(", %d conflicts", e.Conflicts)
+			}
+			fmt.Println()
+			if e.Error != "" {
+				fmt.Printf("      %s\n", ui.RenderMuted(e.Error))
+			}
+		}
+	}
+
+	fmt.Printf("\n%s Sync statistics:\n\n", ui.RenderAccent("📊"))
+	for _, s := range stats {
+		rate := fmt.Sprintf("%.0f%%", s.SuccessRate*100)
+		switch {
+		case s.SuccessRate < 0.8:
+			rate = ui.RenderFail(rate)
+		case s.SuccessRate < 0.95:
+			rate = ui.RenderWarn(rate)
+		default:
+			rate = ui.RenderPass(rate)
+		}
+		fmt.Printf("  %s\n", ui.RenderAccent(s.Peer))
+		fmt.Printf("    Syncs:    %d (%d ok, %d failed, %s success)\n", s.Total, s.Succeeded, s.Failed, rate)
+		fmt.Printf("    Duration: avg %s, p95 %s\n", formatSyncDuration(s.AvgDuration), formatSyncDuration(s.P95Duration))
+		if s.Conflicts > 0 {
+			fmt.Printf("    Conflicts: %d\n", s.Conflicts)
+		}
+		if s.LastSuccess != nil {
+			fmt.Printf("    Last ok:  %s\n", s.LastSuccess.Local().Format("2006-01-02 15:04:05"))
+		}
+		if s.LastFailure != nil {
+			fmt.Printf("    Last err: %s  %s\n", s.LastFailure.Local().Format("2006-01-02 15:04:05"), ui.RenderMuted(s.LastError))
+		}
+	}
+	fmt.Println()
+}
+
+// formatSyncDuration renders a sync duration at a precision useful for humans.
+func formatSyncDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
@@ -2,11 +2,11 @@
 
 package main
 
-import (
-	"fmt"
+import "github.com/spf13/cobra"
 
-	"github.com/spf13/cobra"
-)
+// cgoEnabled reports whether this binary was built with cgo, which
+// federation requires.
+const cgoEnabled = false
 
 var federationCmd = &cobra.Command{
 	Use:     "federation",
@@ -18,14 +18,12 @@ This binary was built without CGO support. To use federation features:
   1. Use pre-built binaries from GitHub releases, or
   2. Build from source with CGO enabled
 
+Run 'bd capabilities' to see which other features this build lacks.
+
 Federation enables synchronized issue tracking across multiple Gas Towns,
 each maintaining their own Dolt database while sharing updates via remotes.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Federation requires CGO and Dolt backend.")
-		fmt.Println("")
-		fmt.Println("This binary was built without CGO support. To use federation:")
-		fmt.Println("  1. Download pre-built binaries from GitHub releases")
-		fmt.Println("  2. Or build from source with CGO enabled")
+		requireBuildCapabilities(cmd)
 	},
 }
 
@@ -0,0 +1,298 @@
+//go:build cgo
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var (
+	federationRelaySpokes  string
+	federationRelayID      string
+	federationRelayMaxHops int
+	federationRelayLimit   int
+)
+
+var federationRelayCmd = &cobra.Command{
+	Use:   "relay",
+	Short: "Relay changes between spokes (hub-and-spoke mode)",
+	Long: `Run this town as a relay hub for spokes that cannot reach each other.
+
+A relay run merges every spoke's changes into the hub, then pushes the
+combined result to all spokes. Each relay merge commit records provenance
+trailers (Relay-Origin, Relay-Path) so relayed changes can be traced back
+to the spoke they came from.
+
+Loop prevention: a hub never re-relays changes whose Relay-Path already
+contains its own ID, and stops forwarding once a change has passed through
+max-hops hubs.
+
+Relay settings are stored in .beads/config.yaml (federation.relay.*) and
+are never synced to peers.
+
+Examples:
+  bd federation relay enable                       # Relay between all peers
+  bd federation relay enable --spokes crew-a,crew-b --max-hops 2
+  bd federation relay run --strategy theirs        # Relay once
+  bd federation relay status
+  bd federation relay log                          # Recent relayed merges
+  bd federation relay disable`,
+}
+
+var federationRelayEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Enable relay mode on this town",
+	Args:  cobra.NoArgs,
+	Run:   runFederationRelayEnable,
+}
+
+var federationRelayDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Disable relay mode on this town",
+	Args:  cobra.NoArgs,
+	Run:   runFederationRelayDisable,
+}
+
+var federationRelayStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show relay configuration",
+	Args:  cobra.NoArgs,
+	Run:   runFederationRelayStatus,
+}
+
+var federationRelayRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Relay changes between spokes once",
+	Args:  cobra.NoArgs,
+	Run:   runFederationRelayRun,
+}
+
+var federationRelayLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show recent relayed merges and their provenance",
+	Args:  cobra.NoArgs,
+	Run:   runFederationRelayLog,
+}
+
+func init() {
+	federationRelayCmd.AddCommand(federationRelayEnableCmd)
+	federationRelayCmd.AddCommand(federationRelayDisableCmd)
+	federationRelayCmd.AddCommand(federationRelayStatusCmd)
+	federationRelayCmd.AddCommand(federationRelayRunCmd)
+	federationRelayCmd.AddCommand(federationRelayLogCmd)
+
+	federationRelayEnableCmd.Flags().StringVar(&federationRelaySpokes, "spokes", "", "Comma-separated spokes to relay between (default: all peers)")
+	federationRelayEnableCmd.Flags().StringVar(&federationRelayID, "id", "", "Hub identity recorded on relayed commits (default: issue prefix)")
+	federationRelayEnableCmd.Flags().IntVar(&federationRelayMaxHops, "max-hops", config.DefaultRelayMaxHops, "Maximum hubs a change may pass through")
+
+	federationRelayRunCmd.Flags().StringVar(&federationStrategy, "strategy", "", "Conflict resolution strategy (ours|theirs)")
+
+	federationRelayLogCmd.Flags().IntVarP(&federationRelayLimit, "limit", "n", 20, "Number of relayed merges to show")
+
+	federationCmd.AddCommand(federationRelayCmd)
+}
+
+func runFederationRelayEnable(cmd *cobra.Command, args []string) {
+	if federationRelayMaxHops < 1 {
+		FatalErrorRespectJSON("--max-hops must be at least 1")
+	}
+
+	settings := map[string]string{
+		"federation.relay.enabled":  "true",
+		"federation.relay.max-hops": strconv.Itoa(federationRelayMaxHops),
+	}
+	if cmd.Flags().Changed("spokes") {
+		settings["federation.relay.spokes"] = federationRelaySpokes
+	}
+	if cmd.Flags().Changed("id") {
+		settings["federation.relay.id"] = federationRelayID
+	}
+	for key, value := range settings {
+		if err := config.SetYamlConfig(key, value); err != nil {
+			FatalErrorRespectJSON("failed to set %s: %v", key, err)
+		}
+		config.Set(key, value)
+	}
+
+	if jsonOutput {
+		outputJSON(config.GetRelayConfig())
+		return
+	}
+	fmt.Printf("%s Relay mode enabled\n", ui.RenderPass("✓"))
+}
+
+func runFederationRelayDisable(cmd *cobra.Command, args []string) {
+	if err := config.SetYamlConfig("federation.relay.enabled", "false"); err != nil {
+		FatalErrorRespectJSON("failed to disable relay: %v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{"enabled": false})
+		return
+	}
+	fmt.Println("Relay mode disabled")
+}
+
+func runFederationRelayStatus(cmd *cobra.Command, args []string) {
+	cfg := config.GetRelayConfig()
+	spokes, err := resolveRelaySpokes(cfg)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	hubID := resolveRelayHubID(cfg)
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"enabled":  cfg.Enabled,
+			"id":       hubID,
+			"spokes":   spokes,
+			"max_hops": cfg.MaxHops,
+		})
+		return
+	}
+
+	state := ui.RenderMuted("disabled")
+	if cfg.Enabled {
+		state = ui.RenderPass("enabled")
+	}
+	fmt.Printf("\n%s Relay: %s\n\n", ui.RenderAccent("🔀"), state)
+	fmt.Printf("  Hub ID:   %s\n", hubID)
+	fmt.Printf("  Spokes:   %s\n", strings.Join(spokes, ", "))
+	fmt.Printf("  Max hops: %d\n\n", cfg.MaxHops)
+}
+
+func runFederationRelayRun(cmd *cobra.Command, args []string) {
+	CheckReadonly("federation relay run")
+	ctx := rootCtx
+
+	cfg := config.GetRelayConfig()
+	if !cfg.Enabled {
+		FatalErrorRespectJSON("relay mode is not enabled (use 'bd federation relay enable')")
+	}
+	if federationStrategy != "" && federationStrategy != "ours" && federationStrategy != "theirs" {
+		FatalErrorRespectJSON("invalid strategy %q: must be 'ours' or 'theirs'", federationStrategy)
+	}
+
+	ds, err := getFederatedStore()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	spokes, err := resolveRelaySpokes(cfg)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	result, err := ds.Relay(ctx, dolt.RelayOptions{
+		HubID:    resolveRelayHubID(cfg),
+		Spokes:   spokes,
+		Strategy: federationStrategy,
+		MaxHops:  cfg.MaxHops,
+	})
+	if jsonOutput {
+		outputJSON(result)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		return
+	}
+	if result == nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	fmt.Printf("%s Relaying between %d spokes...\n", ui.RenderAccent("🔀"), len(spokes))
+	for _, pulled := range result.Pulled {
+		fmt.Printf("  %s\n", pulled.Peer)
+		printFederationSyncResult(pulled, pulled.Error)
+	}
+	for spoke, reason := range result.Skipped {
+		fmt.Printf("  %s %s: %s\n", ui.RenderMuted("○"), spoke, reason)
+	}
+	if len(result.Pushed) > 0 {
+		fmt.Printf("  %s Forwarded to %s\n", ui.RenderPass("✓"), strings.Join(result.Pushed, ", "))
+	}
+	for spoke, pushErr := range result.Failed {
+		fmt.Printf("  %s Push to %s failed: %s\n", ui.RenderFail("✗"), spoke, pushErr)
+	}
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+}
+
+func runFederationRelayLog(cmd *cobra.Command, args []string) {
+	ctx := rootCtx
+
+	ds, err := getFederatedStore()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	commits, err := ds.RelayLog(ctx, federationRelayLimit)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	if jsonOutput {
+		outputJSON(commits)
+		return
+	}
+	if len(commits) == 0 {
+		fmt.Println("No relayed merges found.")
+		return
+	}
+
+	fmt.Printf("\n%s Relayed merges:\n\n", ui.RenderAccent("🔀"))
+	for _, c := range commits {
+		hash := c.Hash
+		if len(hash) > 8 {
+			hash = hash[:8]
+		}
+		fmt.Printf("  %s  %s  from %s via %s\n",
+			ui.RenderMuted(hash), c.Date.Local().Format("2006-01-02 15:04:05"),
+			ui.RenderAccent(c.Provenance.Origin), strings.Join(c.Provenance.Path, " → "))
+	}
+	fmt.Println()
+}
+
+// resolveRelaySpokes returns the configured spokes, or all peers except
+// 'origin' when none are configured.
+func resolveRelaySpokes(cfg config.RelayConfig) ([]string, error) {
+	if len(cfg.Spokes) > 0 {
+		return cfg.Spokes, nil
+	}
+	ds, err := getFederatedStore()
+	if err != nil {
+		return nil, err
+	}
+	remotes, err := ds.ListRemotes(rootCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list peers: %w", err)
+	}
+	var spokes []string
+	for _, r := range remotes {
+		if r.Name != "origin" {
+			spokes = append(spokes, r.Name)
+		}
+	}
+	return spokes, nil
+}
+
+// resolveRelayHubID returns the configured hub identity, defaulting to the
+// database's issue prefix.
+func resolveRelayHubID(cfg config.RelayConfig) string {
+	if cfg.ID != "" {
+		return cfg.ID
+	}
+	if store != nil {
+		if prefix, err := store.GetConfig(rootCtx, "issue_prefix"); err == nil && prefix != "" {
+			return prefix
+		}
+	}
+	return "hub"
+}
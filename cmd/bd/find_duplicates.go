@@ -14,6 +14,7 @@ import (
 	"github.com/anthropics/anthropic-sdk-go/option"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/embeddings"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 )
@@ -31,6 +32,7 @@ with different wording.
 
 Approaches:
   mechanical  Token-based text similarity (default, no API key needed)
+  embedding   Cosine similarity of cached embeddings (see 'bd similar')
   ai          LLM-based semantic comparison (requires ANTHROPIC_API_KEY)
 
 The mechanical approach tokenizes titles and descriptions, then computes
@@ -44,6 +46,7 @@ then asks the LLM to judge whether the remaining pairs are true duplicates.
 Examples:
   bd find-duplicates                       # Mechanical similarity (default)
   bd find-duplicates --threshold 0.4       # Lower threshold = more results
+  bd find-duplicates --method embedding    # Use the configured embedding model
   bd find-duplicates --method ai           # Use AI for semantic comparison
   bd find-duplicates --status open         # Only check open issues
   bd find-duplicates --limit 20            # Show top 20 pairs
@@ -52,7 +55,7 @@ Examples:
 }
 
 func init() {
-	findDuplicatesCmd.Flags().String("method", "mechanical", "Detection method: mechanical, ai")
+	findDuplicatesCmd.Flags().String("method", "mechanical", "Detection method: mechanical, embedding, ai")
 	findDuplicatesCmd.Flags().Float64("threshold", 0.5, "Similarity threshold (0.0-1.0, lower = more results)")
 	findDuplicatesCmd.Flags().StringP("status", "s", "", "Filter by status (default: non-closed)")
 	findDuplicatesCmd.Flags().IntP("limit", "n", 50, "Maximum number of pairs to show")
@@ -82,8 +85,8 @@ func runFindDuplicates(cmd *cobra.Command, _ []string) {
 	ctx := rootCtx
 
 	// Validate method
-	if method != "mechanical" && method != "ai" {
-		FatalError("invalid method %q (use: mechanical, ai)", method)
+	if method != "mechanical" && method != "embedding" && method != "ai" {
+		FatalError("invalid method %q (use: mechanical, embedding, ai)", method)
 	}
 
 	// AI method requires API key
@@ -136,6 +139,16 @@ func runFindDuplicates(cmd *cobra.Command, _ []string) {
 	switch method {
 	case "mechanical":
 		pairs = findMechanicalDuplicates(issues, threshold)
+	case "embedding":
+		embedder, err := newEmbedder()
+		if err != nil {
+			FatalError("%v", err)
+		}
+		vectors, err := embedIssues(ctx, store, embedder, issues, false)
+		if err != nil {
+			FatalError("%v", err)
+		}
+		pairs = findEmbeddingDuplicates(issues, vectors, threshold)
 	case "ai":
 		pairs = findAIDuplicates(ctx, issues, threshold, model)
 	}
@@ -331,6 +344,26 @@ func findMechanicalDuplicates(issues []*types.Issue, threshold float64) []duplic
 	return pairs
 }
 
+// findEmbeddingDuplicates finds similar issues by cosine similarity of their
+// embeddings.
+func findEmbeddingDuplicates(issues []*types.Issue, vectors map[string][]float32, threshold float64) []duplicatePair {
+	var pairs []duplicatePair
+	for i := 0; i < len(issues); i++ {
+		for j := i + 1; j < len(issues); j++ {
+			similarity := embeddings.Cosine(vectors[issues[i].ID], vectors[issues[j].ID])
+			if similarity >= threshold {
+				pairs = append(pairs, duplicatePair{
+					IssueA:     issues[i],
+					IssueB:     issues[j],
+					Similarity: similarity,
+					Method:     "embedding",
+				})
+			}
+		}
+	}
+	return pairs
+}
+
 // findAIDuplicates uses LLM-based semantic comparison to find duplicates.
 // It first pre-filters with mechanical similarity to reduce API calls.
 func findAIDuplicates(ctx context.Context, issues []*types.Issue, threshold float64, model string) []duplicatePair {
@@ -47,7 +47,11 @@ By default, performs bidirectional sync:
 - Pulls new/updated issues from GitLab to beads
 - Pushes local beads issues to GitLab
 
-Use --pull-only or --push-only to limit direction.`,
+Use --pull-only or --push-only to limit direction.
+
+Issues changed on both sides since the last sync are left alone and queued
+for 'bd sync conflicts', unless --prefer-local, --prefer-gitlab or
+--prefer-newer picks a side.`,
 	RunE: runGitLabSync,
 }
 
@@ -83,7 +87,9 @@ var issueIDCounter uint64
 type ConflictStrategy string
 
 const (
-	// ConflictStrategyPreferNewer uses the most recently updated version (default).
+	// ConflictStrategyQueue queues conflicts for bd sync conflicts (default).
+	ConflictStrategyQueue ConflictStrategy = "queue"
+	// ConflictStrategyPreferNewer uses the most recently updated version.
 	ConflictStrategyPreferNewer ConflictStrategy = "prefer-newer"
 	// ConflictStrategyPreferLocal always keeps the local beads version.
 	ConflictStrategyPreferLocal ConflictStrategy = "prefer-local"
@@ -114,7 +120,10 @@ func getConflictStrategy(preferLocal, preferGitLab, preferNewer bool) (ConflictS
 	if preferGitLab {
 		return ConflictStrategyPreferGitLab, nil
 	}
-	return ConflictStrategyPreferNewer, nil
+	if preferNewer {
+		return ConflictStrategyPreferNewer, nil
+	}
+	return ConflictStrategyQueue, nil
 }
 
 // generateIssueID creates a unique issue ID with the given prefix.
@@ -169,7 +178,7 @@ func init() {
 	// Conflict resolution flags (mutually exclusive)
 	gitlabSyncCmd.Flags().BoolVar(&gitlabPreferLocal, "prefer-local", false, "On conflict, keep local beads version")
 	gitlabSyncCmd.Flags().BoolVar(&gitlabPreferGitLab, "prefer-gitlab", false, "On conflict, use GitLab version")
-	gitlabSyncCmd.Flags().BoolVar(&gitlabPreferNewer, "prefer-newer", false, "On conflict, use most recent version (default)")
+	gitlabSyncCmd.Flags().BoolVar(&gitlabPreferNewer, "prefer-newer", false, "On conflict, use most recent version")
 
 	// Register gitlab command with root
 	rootCmd.AddCommand(gitlabCmd)
@@ -384,8 +393,10 @@ func runGitLabSync(cmd *cobra.Command, args []string) error {
 		opts.ConflictResolution = tracker.ConflictLocal
 	case ConflictStrategyPreferGitLab:
 		opts.ConflictResolution = tracker.ConflictExternal
-	default:
+	case ConflictStrategyPreferNewer:
 		opts.ConflictResolution = tracker.ConflictTimestamp
+	default:
+		opts.ConflictResolution = tracker.ConflictQueue
 	}
 
 	if gitlabSyncDryRun {
@@ -409,8 +420,11 @@ func runGitLabSync(cmd *cobra.Command, args []string) error {
 		if result.Stats.Pushed > 0 {
 			_, _ = fmt.Fprintf(out, "✓ Pushed %d issues\n", result.Stats.Pushed)
 		}
-		if result.Stats.Conflicts > 0 {
-			_, _ = fmt.Fprintf(out, "→ Resolved %d conflicts\n", result.Stats.Conflicts)
+		if resolved := result.Stats.Conflicts - result.Stats.Queued; resolved > 0 {
+			_, _ = fmt.Fprintf(out, "→ Resolved %d conflicts\n", resolved)
+		}
+		if result.Stats.Queued > 0 {
+			_, _ = fmt.Fprintf(out, "⚠ Queued %d conflicts; review them with 'bd sync conflicts list'\n", result.Stats.Queued)
 		}
 	}
 
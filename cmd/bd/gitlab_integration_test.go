@@ -220,14 +220,14 @@ func TestGitLabConflictStrategiesIntegration(t *testing.T) {
 		}
 	})
 
-	// Test default (no flags) returns prefer-newer
-	t.Run("DefaultIsPreferNewer", func(t *testing.T) {
+	// Test default (no flags) queues conflicts
+	t.Run("DefaultIsQueue", func(t *testing.T) {
 		strategy, err := getConflictStrategy(false, false, false)
 		if err != nil {
 			t.Fatalf("getConflictStrategy() error = %v", err)
 		}
-		if strategy != ConflictStrategyPreferNewer {
-			t.Errorf("strategy = %q, want %q (default)", strategy, ConflictStrategyPreferNewer)
+		if strategy != ConflictStrategyQueue {
+			t.Errorf("strategy = %q, want %q (default)", strategy, ConflictStrategyQueue)
 		}
 	})
 
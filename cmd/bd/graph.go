@@ -35,6 +35,9 @@ var (
 	graphAll     bool
 	graphDOT     bool
 	graphHTML    bool
+	graphMermaid bool
+	graphClosure bool
+	graphOpen    bool
 )
 
 var graphCmd = &cobra.Command{
@@ -53,7 +56,15 @@ Display formats:
   --box            ASCII boxes showing layers, more detailed
   --compact        Tree format, one line per issue, more scannable
   --dot            Graphviz DOT format (pipe to dot -Tsvg > graph.svg)
+  --mermaid        Mermaid flowchart, for design docs and PR descriptions
   --html           Self-contained interactive HTML with D3.js visualization
+  --json           Issues, dependencies and layout as JSON
+
+Scope:
+  <issue-id>       The issue and everything that depends on it (for an
+                   epic, its children and their dependents)
+  --closure        Also everything the issue depends on, transitively
+  --open           Leave out closed issues (--all never includes them)
 
 The graph shows execution order:
 - Layer 0 / leftmost = no dependencies (can start immediately)
@@ -68,6 +79,8 @@ Examples:
   bd graph --dot issue-id | dot -Tsvg > graph.svg  # SVG via Graphviz
   bd graph --dot issue-id | dot -Tpng > graph.png  # PNG via Graphviz
   bd graph --html issue-id > graph.html  # Interactive browser view
+  bd graph --mermaid --open epic-id      # Paste into a PR inside a mermaid block
+  bd graph --closure --json issue-id     # Full upstream and downstream closure
   bd graph --all --html > all.html       # All issues, interactive`,
 	Args: cobra.RangeArgs(0, 1),
 	Run: func(cmd *cobra.Command, args []string) {
@@ -80,6 +93,9 @@ Examples:
 		if !graphAll && len(args) == 0 {
 			FatalErrorWithHint("issue ID required", "Use --all for all open issues")
 		}
+		if graphAll && graphClosure {
+			FatalError("--closure needs an issue ID, not --all")
+		}
 
 		if store == nil {
 			FatalError("no database connection")
@@ -101,6 +117,11 @@ Examples:
 				outputJSON(subgraphs)
 				return
 			}
+			if graphMermaid {
+				// One flowchart, so it can be pasted as a single block
+				renderGraphMermaid(subgraphs...)
+				return
+			}
 
 			// Render all subgraphs
 			for i, subgraph := range subgraphs {
@@ -117,7 +138,7 @@ Examples:
 					renderGraphVisual(layout, subgraph)
 				}
 				if !graphDOT && !graphHTML && i < len(subgraphs)-1 {
-					fmt.Println(strings.Repeat("─", 60))
+					fmt.Println(ui.Glyph(strings.Repeat("─", 60)))
 				}
 			}
 			return
@@ -130,10 +151,13 @@ Examples:
 		}
 
 		// Load the subgraph
-		subgraph, err := loadGraphSubgraph(ctx, store, issueID)
+		subgraph, err := loadGraphSubgraph(ctx, store, issueID, graphClosure)
 		if err != nil {
 			FatalError("loading graph: %v", err)
 		}
+		if graphOpen {
+			subgraph = openSubgraph(subgraph)
+		}
 
 		// Compute layout
 		layout := computeLayout(subgraph)
@@ -150,6 +174,8 @@ Examples:
 		// Render graph in selected format
 		if graphDOT {
 			renderGraphDOT(layout, subgraph)
+		} else if graphMermaid {
+			renderGraphMermaid(subgraph)
 		} else if graphHTML {
 			renderGraphHTML(layout, subgraph)
 		} else if graphCompact {
@@ -168,13 +194,19 @@ func init() {
 	graphCmd.Flags().BoolVar(&graphBox, "box", false, "ASCII boxes showing layers")
 	graphCmd.Flags().BoolVar(&graphDOT, "dot", false, "Output Graphviz DOT format (pipe to: dot -Tsvg > graph.svg)")
 	graphCmd.Flags().BoolVar(&graphHTML, "html", false, "Output self-contained interactive HTML (redirect to file)")
+	graphCmd.Flags().BoolVar(&graphMermaid, "mermaid", false, "Output a Mermaid flowchart (for design docs and PRs)")
+	graphCmd.Flags().BoolVar(&graphClosure, "closure", false, "Include everything the issue depends on, transitively")
+	graphCmd.Flags().BoolVar(&graphOpen, "open", false, "Leave out closed issues")
+	graphCmd.MarkFlagsMutuallyExclusive("dot", "mermaid", "html")
 	graphCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(graphCmd)
 }
 
 // loadGraphSubgraph loads an issue and its subgraph for visualization
 // Unlike template loading, this includes ALL dependency types (not just parent-child)
-func loadGraphSubgraph(ctx context.Context, s *dolt.DoltStore, issueID string) (*TemplateSubgraph, error) {
+// With upstream, the issues the root depends on are followed too, giving
+// its transitive closure in both directions.
+func loadGraphSubgraph(ctx context.Context, s *dolt.DoltStore, issueID string, upstream bool) (*TemplateSubgraph, error) {
 	if s == nil {
 		return nil, fmt.Errorf("no database connection")
 	}
@@ -216,12 +248,26 @@ func loadGraphSubgraph(ctx context.Context, s *dolt.DoltStore, issueID string) (
 				queue = append(queue, dep.ID)
 			}
 		}
+	}
+
+	// With upstream, walk what the root depends on in a second pass, so the
+	// issues upstream of a dependent (its other blockers) stay out
+	queue = []string{root.ID}
+	for upstream && len(queue) > 0 {
+		currentID := queue[0]
+		queue = queue[1:]
 
-		// Get issues this one depends on (dependencies) - but only for non-root
-		// to avoid pulling in unrelated upstream issues
-		if currentID == root.ID {
-			// For root, we might want to include direct dependencies too
-			// Skip for now to keep graph focused on "what this issue encompasses"
+		dependencies, err := s.GetDependencies(ctx, currentID)
+		if err != nil {
+			continue
+		}
+		for _, dep := range dependencies {
+			if !visited[dep.ID] {
+				visited[dep.ID] = true
+				subgraph.Issues = append(subgraph.Issues, dep)
+				subgraph.IssueMap[dep.ID] = dep
+				queue = append(queue, dep.ID)
+			}
 		}
 	}
 
@@ -266,6 +312,27 @@ func loadGraphSubgraph(ctx context.Context, s *dolt.DoltStore, issueID string) (
 	return subgraph, nil
 }
 
+// openSubgraph returns the subgraph without its closed issues, other than
+// the root, and their dependencies.
+func openSubgraph(subgraph *TemplateSubgraph) *TemplateSubgraph {
+	open := &TemplateSubgraph{
+		Root:     subgraph.Root,
+		IssueMap: make(map[string]*types.Issue),
+	}
+	for _, issue := range subgraph.Issues {
+		if issue.Status != types.StatusClosed || issue.ID == subgraph.Root.ID {
+			open.Issues = append(open.Issues, issue)
+			open.IssueMap[issue.ID] = issue
+		}
+	}
+	for _, dep := range subgraph.Dependencies {
+		if open.IssueMap[dep.IssueID] != nil && open.IssueMap[dep.DependsOnID] != nil {
+			open.Dependencies = append(open.Dependencies, dep)
+		}
+	}
+	return open
+}
+
 // loadAllGraphSubgraphs loads all open issues and groups them by connected component
 // Each component is a subgraph of issues that share dependencies
 func loadAllGraphSubgraphs(ctx context.Context, s *dolt.DoltStore) ([]*TemplateSubgraph, error) {
@@ -551,7 +618,7 @@ func renderGraph(layout *GraphLayout, subgraph *TemplateSubgraph) {
 	// Calculate box width based on longest title
 	maxTitleLen := 0
 	for _, node := range layout.Nodes {
-		titleLen := len(truncateTitle(node.Issue.Title, 30))
+		titleLen := ui.DisplayWidth(truncateTitle(node.Issue.Title, 30))
 		if titleLen > maxTitleLen {
 			maxTitleLen = titleLen
 		}
@@ -562,7 +629,7 @@ func renderGraph(layout *GraphLayout, subgraph *TemplateSubgraph) {
 	// For simplicity, we'll render layer by layer with arrows between them
 
 	// First, show the legend
-	fmt.Println("  Status: ○ open  ◐ in_progress  ● blocked  ✓ closed")
+	fmt.Println(ui.Glyph("  Status: ○ open  ◐ in_progress  ● blocked  ✓ closed"))
 	fmt.Println()
 
 	// Build dependency counts from subgraph
@@ -605,8 +672,8 @@ func renderGraph(layout *GraphLayout, subgraph *TemplateSubgraph) {
 
 		// Print arrows to next layer if not last
 		if layerIdx < len(layerBoxes)-1 {
-			fmt.Println("      │")
-			fmt.Println("      ▼")
+			fmt.Println(ui.Glyph("      │"))
+			fmt.Println(ui.Glyph("      ▼"))
 		}
 		fmt.Println()
 	}
@@ -640,7 +707,7 @@ func renderGraphCompact(layout *GraphLayout, subgraph *TemplateSubgraph) {
 		ui.RenderAccent("📊"), layout.RootID, len(layout.Nodes), len(layout.Layers))
 
 	// Legend
-	fmt.Println("  Status: ○ open  ◐ in_progress  ● blocked  ✓ closed  ❄ deferred")
+	fmt.Println(ui.Glyph("  Status: ○ open  ◐ in_progress  ● blocked  ✓ closed  ❄ deferred"))
 	fmt.Println()
 
 	// Build parent-child map from subgraph dependencies
@@ -688,7 +755,7 @@ func renderGraphCompact(layout *GraphLayout, subgraph *TemplateSubgraph) {
 				connector = "└── "
 			}
 
-			fmt.Printf("  %s%s\n", connector, line)
+			fmt.Printf("  %s%s\n", ui.Glyph(connector), line)
 
 			// Render children (if this issue has children in the subgraph)
 			if childIDs, ok := children[id]; ok && len(childIDs) > 0 {
@@ -718,7 +785,7 @@ func renderCompactChildren(layout *GraphLayout, childIDs []string, children map[
 		}
 
 		line := formatCompactNode(node)
-		fmt.Printf("  %s%s%s\n", prefix, connector, line)
+		fmt.Printf("  %s%s\n", ui.Glyph(prefix+connector), line)
 
 		// Recurse for nested children
 		if grandchildren, ok := children[childID]; ok && len(grandchildren) > 0 {
@@ -753,7 +820,7 @@ func formatCompactNode(node *GraphNode) string {
 		return fmt.Sprintf("%s %s %s %s",
 			statusIcon,
 			style.Render(node.Issue.ID),
-			style.Render(fmt.Sprintf("● P%d", node.Issue.Priority)),
+			style.Render(fmt.Sprintf("%s P%d", ui.Glyph(ui.PriorityIcon), node.Issue.Priority)),
 			style.Render(title))
 	}
 
@@ -763,14 +830,16 @@ func formatCompactNode(node *GraphNode) string {
 // renderNodeBox renders a single node as an ASCII box
 // Uses semantic status styles from ui package for consistency
 func renderNodeBox(node *GraphNode, width int) string {
-	title := truncateTitle(node.Issue.Title, width-4)
-	paddedTitle := padRight(title, width-4)
 	status := string(node.Issue.Status)
 
 	// Use shared status icon and style
 	statusIcon := ui.RenderStatusIcon(status)
 	style := ui.GetStatusStyle(status)
 
+	// Title fills what the icon leaves; some icons are two columns wide
+	titleWidth := width - 3 - ui.DisplayWidth(statusIcon)
+	paddedTitle := padRight(truncateTitle(node.Issue.Title, titleWidth), titleWidth)
+
 	// Apply style to title for actionable statuses
 	var titleStr string
 	if node.Issue.Status == types.StatusOpen {
@@ -787,25 +856,18 @@ func renderNodeBox(node *GraphNode, width int) string {
 	idLine := fmt.Sprintf("  │ %s │", ui.RenderMuted(padRight(id, width-2)))
 	bottom := "  └" + strings.Repeat("─", width) + "┘"
 
-	return topBottom + "\n" + middle + "\n" + idLine + "\n" + bottom
+	return ui.Glyph(topBottom + "\n" + middle + "\n" + idLine + "\n" + bottom)
 }
 
-// truncateTitle truncates a title to max length (rune-safe)
+// truncateTitle truncates a title to maxLen terminal columns, so wide
+// characters (CJK, emoji) count double
 func truncateTitle(title string, maxLen int) string {
-	runes := []rune(title)
-	if len(runes) <= maxLen {
-		return title
-	}
-	return string(runes[:maxLen-1]) + "…"
+	return ui.Truncate(title, maxLen)
 }
 
-// padRight pads a string to the right with spaces (rune-safe)
+// padRight pads a string to the right with spaces to width terminal columns
 func padRight(s string, width int) string {
-	runes := []rune(s)
-	if len(runes) >= width {
-		return string(runes[:width])
-	}
-	return s + strings.Repeat(" ", width-len(runes))
+	return ui.PadRight(s, width)
 }
 
 // computeDependencyCounts calculates how many issues each issue blocks and is blocked by
@@ -849,14 +911,16 @@ func computeDependencyCounts(subgraph *TemplateSubgraph) (blocks map[string]int,
 // Uses semantic status styles from ui package for consistency across commands
 // Design principle: only actionable states get color, closed items fade
 func renderNodeBoxWithDeps(node *GraphNode, width int, blocksCount int, blockedByCount int) string {
-	title := truncateTitle(node.Issue.Title, width-4)
-	paddedTitle := padRight(title, width-4)
 	status := string(node.Issue.Status)
 
 	// Use shared status icon and style from ui package
 	statusIcon := ui.RenderStatusIcon(status)
 	style := ui.GetStatusStyle(status)
 
+	// Title fills what the icon leaves; some icons are two columns wide
+	titleWidth := width - 3 - ui.DisplayWidth(statusIcon)
+	paddedTitle := padRight(truncateTitle(node.Issue.Title, titleWidth), titleWidth)
+
 	// Apply style to title for actionable statuses
 	var titleStr string
 	if node.Issue.Status == types.StatusOpen {
@@ -898,7 +962,7 @@ func renderNodeBoxWithDeps(node *GraphNode, width int, blocksCount int, blockedB
 	var result string
 	if depInfoPlain != "" {
 		// Pad based on plain text length, then render with styled version
-		padding := width - 2 - len([]rune(depInfoPlain))
+		padding := width - 2 - ui.DisplayWidth(depInfoPlain)
 		if padding < 0 {
 			padding = 0
 		}
@@ -910,5 +974,5 @@ func renderNodeBoxWithDeps(node *GraphNode, width int, blocksCount int, blockedB
 		result = topBottom + "\n" + middle + "\n" + idLine + "\n" + bottom
 	}
 
-	return result
+	return ui.Glyph(result)
 }
@@ -63,6 +63,82 @@ func renderGraphDOT(layout *GraphLayout, subgraph *TemplateSubgraph) {
 	fmt.Println("}")
 }
 
+// renderGraphMermaid renders one or more subgraphs as a single Mermaid
+// flowchart, blockers pointing to the issues they block and parents to
+// their children (dashed), like the DOT output. Paste it into a mermaid
+// code block in a design doc or PR description.
+func renderGraphMermaid(subgraphs ...*TemplateSubgraph) {
+	fmt.Println("flowchart LR")
+	classes := make(map[string][]string) // Status class -> node IDs
+	nodes := make(map[string]bool)
+	for _, subgraph := range subgraphs {
+		for _, issue := range subgraph.Issues {
+			if nodes[issue.ID] {
+				continue
+			}
+			nodes[issue.ID] = true
+			fmt.Printf("  %s[\"%s %s<br/>P%d | %s\"]\n", mermaidNodeID(issue.ID), statusPlainIcon(issue.Status),
+				mermaidEscape(issue.ID), issue.Priority, mermaidEscape(truncateTitle(issue.Title, 40)))
+			class := mermaidStatusClass(issue.Status)
+			classes[class] = append(classes[class], mermaidNodeID(issue.ID))
+		}
+	}
+	for _, subgraph := range subgraphs {
+		for _, dep := range subgraph.Dependencies {
+			if !nodes[dep.IssueID] || !nodes[dep.DependsOnID] {
+				continue
+			}
+			switch dep.Type {
+			case types.DepBlocks:
+				fmt.Printf("  %s --> %s\n", mermaidNodeID(dep.DependsOnID), mermaidNodeID(dep.IssueID))
+			case types.DepParentChild:
+				fmt.Printf("  %s -.-> %s\n", mermaidNodeID(dep.DependsOnID), mermaidNodeID(dep.IssueID))
+			}
+		}
+	}
+
+	for _, class := range []string{"open", "in_progress", "blocked", "closed", "other"} {
+		if ids := classes[class]; len(ids) > 0 {
+			fmt.Printf("  classDef %s %s\n", class, mermaidClassStyles[class])
+			fmt.Printf("  class %s %s\n", strings.Join(ids, ","), class)
+		}
+	}
+}
+
+// mermaidClassStyles match the DOT node colors.
+var mermaidClassStyles = map[string]string{
+	"open":        "fill:#e8f4fd,color:#1a1a1a",
+	"in_progress": "fill:#fff3cd,color:#664d03",
+	"blocked":     "fill:#f8d7da,color:#842029",
+	"closed":      "fill:#d4edda,color:#888888",
+	"other":       "fill:#e2e3e5,color:#41464b",
+}
+
+func mermaidStatusClass(status types.Status) string {
+	switch status {
+	case types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusClosed:
+		return string(status)
+	default:
+		return "other"
+	}
+}
+
+// mermaidNodeID turns an issue ID into a Mermaid node ID, which can't
+// contain punctuation such as the dots of hierarchical IDs.
+func mermaidNodeID(id string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, id)
+}
+
+// mermaidEscape escapes the characters a quoted Mermaid label can't hold.
+func mermaidEscape(text string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(text)
+}
+
 // dotNodeAttrs returns the DOT label, fill color, and font color for a node
 func dotNodeAttrs(node *GraphNode) (label, fillColor, fontColor string) {
 	icon := statusPlainIcon(node.Issue.Status)
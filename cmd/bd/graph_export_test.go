@@ -121,6 +121,52 @@ func TestRenderGraphDOT_Empty(t *testing.T) {
 	}
 }
 
+func TestRenderGraphMermaid(t *testing.T) {
+	// Not parallel: captureGraphOutput redirects global os.Stdout
+	subgraph, _ := makeTestSubgraph()
+	subgraph.Issues[2].Title = `Parse "quoted" <tags>`
+	subgraph.Issues[3].ID = "test-a.1"
+
+	output := captureGraphOutput(func() {
+		renderGraphMermaid(subgraph)
+	})
+
+	for _, want := range []string{
+		"flowchart LR\n",
+		`  test_a["○ test-a<br/>P0 | Root issue"]`,
+		`  test_c["● test-c<br/>P2 | Parse #quot;quoted#quot; #lt;tags#gt;"]`,
+		`  test_a_1["✓ test-a.1<br/>P1 | Done task"]`,
+		"  test_a --> test_b\n",
+		"  test_b --> test_c\n",
+		"  test_a -.-> test_b\n",
+		"  class test_a open\n",
+		"  class test_a_1 closed\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Mermaid output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestOpenSubgraph(t *testing.T) {
+	subgraph, _ := makeTestSubgraph()
+	subgraph.Dependencies = append(subgraph.Dependencies,
+		&types.Dependency{IssueID: "test-d", DependsOnID: "test-c", Type: types.DepBlocks})
+
+	open := openSubgraph(subgraph)
+	if len(open.Issues) != 3 || open.IssueMap["test-d"] != nil {
+		t.Errorf("open issues = %v, want the closed test-d left out", open.Issues)
+	}
+	if len(open.Dependencies) != 3 {
+		t.Errorf("open dependencies = %d, want the one into test-d left out", len(open.Dependencies))
+	}
+
+	subgraph.Root.Status = types.StatusClosed
+	if open := openSubgraph(subgraph); open.IssueMap["test-a"] == nil {
+		t.Error("a closed root should stay in the graph")
+	}
+}
+
 func TestDotNodeAttrs(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -32,10 +32,10 @@ func TestTruncateTitle(t *testing.T) {
 			want:   "This is a very long…",
 		},
 		{
-			name:   "unicode safe",
+			name:   "wide characters count two columns",
 			title:  "日本語タイトル",
 			maxLen: 5,
-			want:   "日本語タ…",
+			want:   "日本…",
 		},
 		{
 			name:   "empty string",
@@ -87,10 +87,16 @@ func TestPadRight(t *testing.T) {
 			want:  "   ",
 		},
 		{
-			name:  "unicode safe",
+			name:  "wide characters count two columns",
 			s:     "日本",
 			width: 5,
-			want:  "日本   ",
+			want:  "日本 ",
+		},
+		{
+			name:  "never splits a wide character",
+			s:     "日本語",
+			width: 5,
+			want:  "日本 ",
 		},
 	}
 
@@ -25,7 +25,7 @@ func renderGraphVisual(layout *GraphLayout, subgraph *TemplateSubgraph) {
 	}
 
 	fmt.Printf("\n%s Dependency graph for %s:\n\n", ui.RenderAccent("📊"), layout.RootID)
-	fmt.Println("  Status: ○ open  ◐ in_progress  ● blocked  ✓ closed  ❄ deferred")
+	fmt.Println(ui.Glyph("  Status: ○ open  ◐ in_progress  ● blocked  ✓ closed  ❄ deferred"))
 	fmt.Println()
 
 	numLayers := len(layout.Layers)
@@ -108,7 +108,7 @@ func renderGraphVisual(layout *GraphLayout, subgraph *TemplateSubgraph) {
 			}
 		}
 
-		fmt.Println(strings.TrimRight(line.String(), " "))
+		fmt.Println(ui.Glyph(strings.TrimRight(line.String(), " ")))
 	}
 
 	fmt.Println()
@@ -130,7 +130,7 @@ func renderGraphVisual(layout *GraphLayout, subgraph *TemplateSubgraph) {
 func computeDAGNodeWidth(layout *GraphLayout) int {
 	maxW := 0
 	for _, node := range layout.Nodes {
-		titleLen := len([]rune(truncateTitle(node.Issue.Title, 22)))
+		titleLen := ui.DisplayWidth(truncateTitle(node.Issue.Title, 22))
 		contentW := titleLen + 3      // icon(1) + space(1) + trailing(1)
 		idW := len(node.Issue.ID) + 4 // space + ID + "  Pn"
 		if idW > contentW {
@@ -207,8 +207,8 @@ func dagNodeLine(node *GraphNode, nodeW, lineIdx int) string {
 
 	case 1: // status icon + title
 		icon := ui.RenderStatusIcon(string(node.Issue.Status))
-		title := truncateTitle(node.Issue.Title, nodeW-4) // room for icon + spaces
-		padded := padRight(title, nodeW-4)
+		titleW := nodeW - 3 - ui.DisplayWidth(icon) // room for icon + spaces
+		padded := padRight(truncateTitle(node.Issue.Title, titleW), titleW)
 
 		status := string(node.Issue.Status)
 		style := ui.GetStatusStyle(status)
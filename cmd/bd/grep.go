@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var grepCmd = &cobra.Command{
+	Use:     "grep <regex>",
+	GroupID: "issues",
+	Short:   "Search titles, descriptions, and comments with a regular expression",
+	Long: `Search issue text with a regular expression, like grep over code.
+
+Matches titles, descriptions, design, acceptance criteria, notes, and
+comments. The regular expression is evaluated by the database server, so no
+search index is needed; matching lines are printed with their line numbers
+and the matches highlighted.
+
+Examples:
+  bd grep 'TODO|FIXME'
+  bd grep -i 'timeout after \d+s' --open-only
+  bd grep -C 2 'panic:'              # Two lines of context around matches
+  bd grep -l 'deprecated'            # Only the matching issue IDs`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		openOnly, _ := cmd.Flags().GetBool("open-only")
+		filesOnly, _ := cmd.Flags().GetBool("files-with-matches")
+		ignoreCase, _ := cmd.Flags().GetBool("ignore-case")
+		contextLines, _ := cmd.Flags().GetInt("context")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		pattern := args[0]
+		if ignoreCase {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			FatalErrorRespectJSON("invalid regular expression: %v", err)
+		}
+		if contextLines < 0 {
+			FatalErrorRespectJSON("--context must not be negative")
+		}
+
+		hits, err := store.GrepText(ctx, pattern, openOnly, limit)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if filesOnly {
+			ids := grepIssueIDs(hits)
+			if jsonOutput {
+				outputJSON(ids)
+				return
+			}
+			for _, id := range ids {
+				fmt.Println(id)
+			}
+			return
+		}
+
+		results := make([]grepResult, 0, len(hits))
+		for _, hit := range hits {
+			results = append(results, grepResult{
+				ID:     hit.IssueID,
+				Title:  hit.Title,
+				Status: string(hit.Status),
+				Field:  hit.Field,
+				Author: hit.Author,
+				Lines:  grepLines(hit.Text, re, contextLines),
+			})
+		}
+		if jsonOutput {
+			outputJSON(results)
+			return
+		}
+		if len(results) == 0 {
+			fmt.Printf("No matches for %s\n", args[0])
+			return
+		}
+
+		stopPager := ui.StartPager(pagerOptions(cmd))
+		defer stopPager()
+		lastID := ""
+		for _, r := range results {
+			if r.ID != lastID {
+				if lastID != "" {
+					fmt.Println()
+				}
+				fmt.Printf("%s %s\n", ui.RenderID(r.ID), r.Title)
+				lastID = r.ID
+			}
+			label := strings.ReplaceAll(r.Field, "_", " ")
+			if r.Field == "comment" {
+				label = "comment by " + r.Author
+			}
+			fmt.Printf("  %s\n", ui.RenderMuted(label))
+			prev := 0
+			for _, l := range r.Lines {
+				if prev > 0 && l.Number > prev+1 {
+					fmt.Printf("    %s\n", ui.RenderMuted("--"))
+				}
+				sep := "-"
+				text := l.Text
+				if l.Match {
+					sep = ":"
+					text = highlightMatches(text, re)
+				}
+				fmt.Printf("    %s %s\n", ui.RenderMuted(fmt.Sprintf("%4d%s", l.Number, sep)), text)
+				prev = l.Number
+			}
+		}
+	},
+}
+
+// grepResult is one matching field or comment in bd grep output.
+type grepResult struct {
+	ID     string     `json:"id"`
+	Title  string     `json:"title"`
+	Status string     `json:"status"`
+	Field  string     `json:"field"`
+	Author string     `json:"author,omitempty"`
+	Lines  []grepLine `json:"lines"`
+}
+
+// grepLine is a matching line, or a context line around one.
+type grepLine struct {
+	Number int    `json:"line"`
+	Text   string `json:"text"`
+	Match  bool   `json:"match"`
+}
+
+// grepLines returns the lines of text matching re, numbered from 1, with up
+// to context lines before and after each. The server's regular expression
+// dialect can differ slightly from Go's; a hit with no line matching here
+// still shows its first line.
+func grepLines(text string, re *regexp.Regexp, context int) []grepLine {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	include := make([]bool, len(lines))
+	match := make([]bool, len(lines))
+	found := false
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		found = true
+		match[i] = true
+		for j := max(0, i-context); j <= min(len(lines)-1, i+context); j++ {
+			include[j] = true
+		}
+	}
+	if !found {
+		// A match spanning lines, or a dialect difference
+		include[0] = true
+	}
+	var out []grepLine
+	for i, line := range lines {
+		if include[i] {
+			out = append(out, grepLine{Number: i + 1, Text: line, Match: match[i]})
+		}
+	}
+	return out
+}
+
+// highlightMatches renders the parts of line matching re in the match color.
+func highlightMatches(line string, re *regexp.Regexp) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringIndex(line, -1) {
+		if m[0] == m[1] {
+			continue // Empty match
+		}
+		b.WriteString(line[last:m[0]])
+		b.WriteString(ui.RenderFail(line[m[0]:m[1]]))
+		last = m[1]
+	}
+	b.WriteString(line[last:])
+	return b.String()
+}
+
+// grepIssueIDs returns the IDs of the issues with hits, in order, once each.
+func grepIssueIDs(hits []*dolt.GrepHit) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, hit := range hits {
+		if !seen[hit.IssueID] {
+			seen[hit.IssueID] = true
+			ids = append(ids, hit.IssueID)
+		}
+	}
+	return ids
+}
+
+func init() {
+	grepCmd.Flags().Bool("open-only", false, "Skip closed issues")
+	grepCmd.Flags().BoolP("files-with-matches", "l", false, "Print only the IDs of matching issues")
+	grepCmd.Flags().BoolP("ignore-case", "i", false, "Match case-insensitively")
+	grepCmd.Flags().IntP("context", "C", 0, "Lines of context to show around each match")
+	grepCmd.Flags().Int("limit", 0, "Maximum number of matching fields and comments (0 = no limit)")
+	grepCmd.Flags().Bool("no-pager", false, "Disable pager output")
+	rootCmd.AddCommand(grepCmd)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage/dolt"
+)
+
+func TestGrepLines(t *testing.T) {
+	text := "one\ntwo TODO\nthree\nfour\nfive\nsix TODO\nseven\n"
+	re := regexp.MustCompile(`TODO`)
+
+	got := grepLines(text, re, 1)
+	want := []grepLine{
+		{1, "one", false}, {2, "two TODO", true}, {3, "three", false},
+		{5, "five", false}, {6, "six TODO", true}, {7, "seven", false},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("grepLines = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if got := grepLines(text, re, 0); len(got) != 2 || got[0].Number != 2 || got[1].Number != 6 {
+		t.Errorf("grepLines without context = %v", got)
+	}
+
+	// A hit the server matched across lines still shows where it starts
+	if got := grepLines("first\nsecond", regexp.MustCompile(`first.second`), 0); len(got) != 1 || got[0].Match {
+		t.Errorf("grepLines with no line match = %v", got)
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	// Colors are off in tests, so highlighting leaves the text intact
+	re := regexp.MustCompile(`o+`)
+	if got := highlightMatches("foo boo", re); got != "foo boo" {
+		t.Errorf("highlightMatches = %q", got)
+	}
+	if got := highlightMatches("abc", regexp.MustCompile(`x*`)); got != "abc" {
+		t.Errorf("highlightMatches with empty matches = %q", got)
+	}
+}
+
+func TestGrepIssueIDs(t *testing.T) {
+	hits := []*dolt.GrepHit{{IssueID: "bd-1"}, {IssueID: "bd-1"}, {IssueID: "bd-3"}, {IssueID: "bd-2"}}
+	got := grepIssueIDs(hits)
+	if len(got) != 3 || got[0] != "bd-1" || got[1] != "bd-3" || got[2] != "bd-2" {
+		t.Errorf("grepIssueIDs = %v", got)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var handoffCmd = &cobra.Command{
+	Use:     "handoff <id> --to <agent>",
+	GroupID: "issues",
+	Short:   "Pass an issue to another agent with context",
+	Long: `Hand an issue off to another agent (or person) so multi-agent pipelines
+can pass work along with its context. In one step, handoff:
+
+  - assigns the issue to --to, keeping its status
+  - appends a handoff note to the issue's notes: the state of the work
+    (--notes), next steps (--next), and gotchas (--gotcha)
+  - releases your lock on the issue, if you hold one (see 'bd lock')
+  - records a handed_off event in the issue's history
+
+Examples:
+  bd handoff bd-42 --to reviewer --notes "Parser done, tests green"
+  bd handoff bd-42 --to agent-2 --notes "Half the endpoints migrated" \
+    --next "Migrate /users and /orders" --next "Drop the v1 router" \
+    --gotcha "Run the fixtures script before the tests"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("handoff")
+		ctx := rootCtx
+		to, _ := cmd.Flags().GetString("to")
+		state, _ := cmd.Flags().GetString("notes")
+		next, _ := cmd.Flags().GetStringArray("next")
+		gotchas, _ := cmd.Flags().GetStringArray("gotcha")
+		to = strings.TrimSpace(to)
+		if to == "" {
+			FatalErrorRespectJSON("--to is required")
+		}
+
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		from := getActorWithGit()
+		note := formatHandoffNote(from, to, state, next, gotchas, time.Now())
+		if err := store.HandoffIssue(ctx, id, from, to, note); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		SetLastTouchedID(id)
+
+		issue, _ := store.GetIssue(ctx, id) // Best effort: only used for the update hook and --json
+		if issue != nil && hookRunner != nil {
+			hookRunner.Run(hooks.EventUpdate, issue)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{
+				"issue_id": id,
+				"from":     from,
+				"to":       to,
+				"note":     note,
+				"issue":    issue,
+			})
+			return
+		}
+		fmt.Printf("%s Handed off %s to %s\n", ui.RenderPass("✓"), ui.RenderID(id), to)
+	},
+}
+
+func init() {
+	handoffCmd.Flags().String("to", "", "Agent or person to hand the issue to (required)")
+	handoffCmd.Flags().String("notes", "", "State of the work: what's done and what isn't")
+	handoffCmd.Flags().StringArray("next", nil, "A next step (repeatable)")
+	handoffCmd.Flags().StringArray("gotcha", nil, "Something the next agent should watch out for (repeatable)")
+	rootCmd.AddCommand(handoffCmd)
+}
+
+// formatHandoffNote renders the markdown note bd handoff appends to an
+// issue's notes.
+func formatHandoffNote(from, to, state string, next, gotchas []string, at time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Handoff: %s → %s (%s)\n", from, to, at.UTC().Format("2006-01-02 15:04 UTC"))
+	if state = strings.TrimSpace(state); state != "" {
+		fmt.Fprintf(&b, "\n**State:** %s\n", state)
+	}
+	writeList := func(title string, items []string) {
+		var kept []string
+		for _, item := range items {
+			if item = strings.TrimSpace(item); item != "" {
+				kept = append(kept, item)
+			}
+		}
+		if len(kept) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n**%s:**\n", title)
+		for _, item := range kept {
+			fmt.Fprintf(&b, "- %s\n", item)
+		}
+	}
+	writeList("Next steps", next)
+	writeList("Gotchas", gotchas)
+	return strings.TrimRight(b.String(), "\n")
+}
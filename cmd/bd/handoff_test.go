@@ -0,0 +1,22 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatHandoffNote(t *testing.T) {
+	at := time.Date(2026, 3, 1, 14, 5, 0, 0, time.UTC)
+	got := formatHandoffNote("alice", "bob", " Parser done ", []string{"Wire up CLI", " "}, []string{"Tests need Dolt"}, at)
+	want := "## Handoff: alice → bob (2026-03-01 14:05 UTC)\n\n" +
+		"**State:** Parser done\n\n" +
+		"**Next steps:**\n- Wire up CLI\n\n" +
+		"**Gotchas:**\n- Tests need Dolt"
+	if got != want {
+		t.Errorf("formatHandoffNote =\n%s\nwant\n%s", got, want)
+	}
+
+	if got, want := formatHandoffNote("alice", "bob", "", nil, nil, at), "## Handoff: alice → bob (2026-03-01 14:05 UTC)"; got != want {
+		t.Errorf("formatHandoffNote with no details = %q, want %q", got, want)
+	}
+}
@@ -51,6 +51,8 @@ Examples:
 		}
 
 		// Display history in human-readable format
+		stopPager := ui.StartPager(pagerOptions(cmd))
+		defer stopPager()
 		fmt.Printf("\n%s History for %s (%d entries)\n\n",
 			ui.RenderAccent("📜"), issueID, len(history))
 
@@ -83,6 +85,7 @@ Examples:
 
 func init() {
 	historyCmd.Flags().IntVar(&historyLimit, "limit", 0, "Limit number of history entries (0 = all)")
+	historyCmd.Flags().Bool("no-pager", false, "Disable pager output")
 	historyCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(historyCmd)
 }
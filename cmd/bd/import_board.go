@@ -0,0 +1,344 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/boardimport"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"golang.org/x/term"
+)
+
+var importBoardCmd = &cobra.Command{
+	Use:     "import-board",
+	GroupID: "advanced",
+	Short:   "Import a GitHub Projects or ZenHub board",
+	Long: `Import the issues on a kanban board, keeping their columns as statuses,
+their epics as parent-child links and their blocking links as blocks
+dependencies.
+
+Each column is mapped to a status by its name (Backlog and Todo to open,
+In Progress and In Review to in_progress, Done to closed, ...); columns
+with other names map to open. Override with --map, and check the mapping
+at the confirmation prompt, where [e]dit changes it. Issues closed on
+GitHub are imported closed whatever their column.
+
+Imported issues keep their GitHub URL as external_ref, so importing the
+board again only adds the issues that are new, and a board imported from
+both GitHub Projects and ZenHub isn't imported twice. Everything is
+created in one transaction.
+
+Examples:
+  bd import-board github acme/4 --dry-run
+  bd import-board github acme/4 --map "Shipping=in_progress" --map "Icebox=deferred"
+  bd import-board zenhub zenhub-board.json --yes`,
+}
+
+var importBoardGitHubCmd = &cobra.Command{
+	Use:   "github <owner>/<project-number>",
+	Short: "Import a GitHub Projects (v2) board",
+	Long: `Import a GitHub Projects (v2) board owned by an organization or user,
+read through the GraphQL API. Columns come from the project's Status
+field (--field picks another single-select field); epics are issues with
+sub-issues, and blocking links come from GitHub's issue dependencies.
+Draft items are imported without an external_ref.
+
+The token needs the read:project scope (and repo for private issues):
+  bd config set github.token "YOUR_TOKEN"   # or set GITHUB_TOKEN
+
+Examples:
+  bd import-board github acme/4 --dry-run
+  bd import-board github octocat/1 --field Stage`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		owner, numStr, ok := strings.Cut(args[0], "/")
+		number, err := strconv.Atoi(numStr)
+		if !ok || owner == "" || err != nil || number <= 0 {
+			FatalErrorCode(exitValidation, "invalid project %q (want <owner>/<project-number>, e.g. acme/4)", args[0])
+		}
+		field, _ := cmd.Flags().GetString("field")
+		token := getGitHubToken(rootCtx)
+		if token == "" {
+			FatalErrorWithHint("no GitHub token configured",
+				"set one with 'bd config set github.token <token>' or the GITHUB_TOKEN environment variable")
+		}
+		fetching := newProgress("Fetching "+args[0], 0)
+		board, err := boardimport.NewGitHubClient(token).FetchProject(rootCtx, owner, number, field)
+		fetching.Done()
+		if err != nil {
+			if rootCtx.Err() != nil {
+				exitInterrupted("nothing was imported", nil)
+			}
+			FatalErrorRespectJSON("%v", err)
+		}
+		runImportBoard(cmd, board)
+	},
+}
+
+var importBoardZenHubCmd = &cobra.Command{
+	Use:   "zenhub <export.json>",
+	Short: "Import a ZenHub board export",
+	Long: `Import a ZenHub board saved as JSON: its pipelines in board order, each
+with its issues, and optionally the board's dependencies and epics as
+ZenHub's API returns them:
+
+  {
+    "workspace": "Platform",
+    "repo": "acme/api",
+    "pipelines": [
+      {"name": "In Progress", "issues": [
+        {"issue_number": 12, "title": "Invoices", "state": "open",
+         "labels": ["bug"], "assignee": "ana", "repo_name": "acme/api"}
+      ]}
+    ],
+    "dependencies": [
+      {"blocking": {"issue_number": 3}, "blocked": {"issue_number": 12}}
+    ],
+    "epics": [{"issue_number": 7, "issues": [{"issue_number": 12}]}]
+  }
+
+repo_name may be left out for issues in repo. Issues are identified by
+their GitHub URLs.
+
+Examples:
+  bd import-board zenhub zenhub-board.json --dry-run
+  bd import-board zenhub zenhub-board.json --map "New Issues=deferred"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := os.ReadFile(args[0]) // #nosec G304 -- user-specified export file
+		if err != nil {
+			FatalErrorRespectJSON("reading %s: %v", args[0], err)
+		}
+		board, err := boardimport.ParseZenHub(data)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		runImportBoard(cmd, board)
+	},
+}
+
+func init() {
+	importBoardGitHubCmd.Flags().String("field", "Status", "Single-select project field holding the columns")
+	for _, cmd := range []*cobra.Command{importBoardGitHubCmd, importBoardZenHubCmd} {
+		cmd.Flags().StringArray("map", nil, "Map a column to a status, as Column=status (repeatable)")
+		cmd.Flags().Bool("dry-run", false, "Show the mapping and what would be imported without importing")
+		cmd.Flags().BoolP("yes", "y", false, "Import without asking for confirmation")
+	}
+	importBoardCmd.AddCommand(importBoardGitHubCmd, importBoardZenHubCmd)
+	rootCmd.AddCommand(importBoardCmd)
+}
+
+// getGitHubToken returns github.token from the database config, or
+// GITHUB_TOKEN.
+func getGitHubToken(ctx context.Context) string {
+	if store != nil {
+		if token, _ := store.GetConfig(ctx, "github.token"); token != "" {
+			return token
+		}
+	}
+	return os.Getenv("GITHUB_TOKEN")
+}
+
+// boardImportResult is the output of bd import-board.
+type boardImportResult struct {
+	Board   string                    `json:"board"`
+	Mapping []boardimport.ColumnCount `json:"mapping"`
+	Plan    *boardimport.Plan         `json:"plan"`
+	DryRun  bool                      `json:"dry_run"`
+}
+
+func runImportBoard(cmd *cobra.Command, board *boardimport.Board) {
+	specs, _ := cmd.Flags().GetStringArray("map")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !dryRun {
+		CheckReadonly("import-board")
+	}
+	ctx := rootCtx
+
+	mapping := boardimport.DefaultMapping(board)
+	for _, spec := range specs {
+		if err := mapping.Set(board, spec); err != nil {
+			FatalErrorCode(exitValidation, "--map: %v", err)
+		}
+	}
+	imported := make(map[string]string) // Ref -> issue ID
+	for _, card := range board.Cards {
+		if card.Ref == "" {
+			continue
+		}
+		if issue, err := store.GetIssueByExternalRef(ctx, card.Ref); err == nil && issue != nil {
+			imported[card.Ref] = issue.ID
+		}
+	}
+	if board.Name == "" {
+		board.Name = "the board"
+	}
+
+	if dryRun {
+		p := boardimport.BuildPlan(board, mapping, imported)
+		if jsonOutput {
+			outputJSON(boardImportResult{Board: board.Name, Mapping: mapping.Review(board), Plan: p, DryRun: true})
+			return
+		}
+		printBoardReview(os.Stdout, board, mapping, p)
+		fmt.Println(ui.RenderMuted("(dry run: nothing imported)"))
+		return
+	}
+	if !yes {
+		if jsonOutput || !term.IsTerminal(int(os.Stdin.Fd())) {
+			FatalErrorWithHint("confirmation needs a terminal",
+				"use --yes (with --map to adjust columns) to import without confirming, or --dry-run to preview")
+		}
+		if !confirmBoardMapping(board, mapping, imported, bufio.NewReader(os.Stdin), os.Stdout) {
+			fmt.Println("Canceled; nothing was imported.")
+			return
+		}
+	}
+
+	p := boardimport.BuildPlan(board, mapping, imported)
+	progress := newProgress("Importing "+board.Name, len(p.Issues))
+	err := createBoardPlan(ctx, p, imported, progress.Add)
+	progress.Done()
+	if err != nil {
+		if ctx.Err() != nil {
+			exitInterrupted(fmt.Sprintf("nothing was imported (stopped after %d of %d issues and rolled back)", progress.Count(), len(p.Issues)),
+				map[string]interface{}{"created": 0, "total": len(p.Issues)})
+		}
+		FatalErrorRespectJSON("importing board: %v", err)
+	}
+	if jsonOutput {
+		outputJSON(boardImportResult{Board: board.Name, Mapping: mapping.Review(board), Plan: p})
+		return
+	}
+	fmt.Printf("%s Imported %d issue(s) and %d dependencies from %s", ui.RenderPass("✓"), len(p.Issues), len(p.Deps), board.Name)
+	if p.Skipped > 0 {
+		fmt.Printf(" (%d already imported)", p.Skipped)
+	}
+	fmt.Println()
+}
+
+// printBoardReview shows how the board's columns map to statuses and
+// what importing it would create.
+func printBoardReview(out io.Writer, board *boardimport.Board, mapping boardimport.Mapping, p *boardimport.Plan) {
+	rows := mapping.Review(board)
+	width := 0
+	for _, r := range rows {
+		if len(r.Column) > width {
+			width = len(r.Column)
+		}
+	}
+	_, _ = fmt.Fprintf(out, "\n%s (%d cards)\n\n", ui.RenderBold(board.Name), len(board.Cards))
+	for _, r := range rows {
+		note := ""
+		if !r.Guessed {
+			note = ui.RenderWarn("  (check)")
+		}
+		_, _ = fmt.Fprintf(out, "  %-*s → %-11s %3d card(s)%s\n", width, r.Column, r.Status, r.Cards, note)
+	}
+
+	var epics, parents, blocks int
+	for _, issue := range p.Issues {
+		if issue.IssueType == types.TypeEpic {
+			epics++
+		}
+	}
+	for _, d := range p.Deps {
+		if d.Type == types.DepParentChild {
+			parents++
+		} else {
+			blocks++
+		}
+	}
+	_, _ = fmt.Fprintf(out, "\nWould import %d issue(s) (%d epics), %d epic links and %d blocking links", len(p.Issues), epics, parents, blocks)
+	if p.Skipped > 0 {
+		_, _ = fmt.Fprintf(out, "; %d already imported", p.Skipped)
+	}
+	_, _ = fmt.Fprintln(out)
+}
+
+// confirmBoardMapping shows the mapping review and asks whether to import.
+// Answering "e" edits the mapping, one Column=status per line until an
+// empty line, and shows the review again.
+func confirmBoardMapping(board *boardimport.Board, mapping boardimport.Mapping, imported map[string]string, in *bufio.Reader, out io.Writer) bool {
+	for {
+		printBoardReview(out, board, mapping, boardimport.BuildPlan(board, mapping, imported))
+		_, _ = fmt.Fprint(out, "\nImport with this mapping? [y]es / [n]o / [e]dit mapping: ")
+		switch readDocAnswer(in) {
+		case "y", "yes":
+			return true
+		case "e", "edit":
+		default:
+			return false
+		}
+		for {
+			_, _ = fmt.Fprint(out, "  Column=status (empty line when done): ")
+			line, err := in.ReadString('\n')
+			spec := strings.TrimSpace(line)
+			if spec == "" {
+				if err != nil {
+					return false // EOF while editing
+				}
+				break
+			}
+			if err := mapping.Set(board, spec); err != nil {
+				_, _ = fmt.Fprintf(out, "  %s\n", err)
+			}
+		}
+	}
+}
+
+// createBoardPlan creates p's issues and dependencies in one transaction,
+// calling created after each issue. imported maps refs of cards imported
+// earlier to their issues, so new issues can link to them.
+func createBoardPlan(ctx context.Context, p *boardimport.Plan, imported map[string]string, created func(int)) error {
+	ids := make(map[string]string, len(imported)+len(p.Issues))
+	for ref, id := range imported {
+		ids[ref] = id
+	}
+	return store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		for _, issue := range p.Issues {
+			closeReason := issue.CloseReason
+			status := issue.Status
+			if status == types.StatusClosed {
+				issue.Status, issue.CloseReason = types.StatusOpen, ""
+			}
+			if err := tx.CreateIssue(ctx, issue, actor); err != nil {
+				return fmt.Errorf("creating %q: %w", issue.Title, err)
+			}
+			for _, label := range issue.Labels {
+				if err := tx.AddLabel(ctx, issue.ID, label, actor); err != nil {
+					return fmt.Errorf("labelling %s: %w", issue.ID, err)
+				}
+			}
+			if status == types.StatusClosed {
+				if err := tx.CloseIssue(ctx, issue.ID, closeReason, actor, ""); err != nil {
+					return fmt.Errorf("closing %s: %w", issue.ID, err)
+				}
+				issue.Status, issue.CloseReason = types.StatusClosed, closeReason
+			}
+			if issue.ExternalRef != nil {
+				ids[*issue.ExternalRef] = issue.ID
+			}
+			created(1)
+		}
+		for _, d := range p.Deps {
+			dep := &types.Dependency{IssueID: ids[d.IssueID], DependsOnID: ids[d.DependsOnID], Type: d.Type}
+			if dep.IssueID == "" || dep.DependsOnID == "" {
+				continue
+			}
+			if err := tx.AddDependency(ctx, dep, actor); err != nil {
+				return fmt.Errorf("adding dependency %s -> %s: %w", dep.IssueID, dep.DependsOnID, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/boardimport"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestConfirmBoardMapping(t *testing.T) {
+	board := &boardimport.Board{
+		Name:    "Roadmap",
+		Columns: []string{"Todo", "Shipping"},
+		Cards: []*boardimport.Card{
+			{Ref: "https://github.com/acme/api/issues/1", Title: "Search", Column: "Todo"},
+			{Ref: "https://github.com/acme/api/issues/2", Title: "Index", Column: "Shipping"},
+		},
+	}
+
+	mapping := boardimport.DefaultMapping(board)
+	var out bytes.Buffer
+	in := bufio.NewReader(strings.NewReader("e\nShipping=in_progress\nArchive=closed\n\ny\n"))
+	if !confirmBoardMapping(board, mapping, nil, in, &out) {
+		t.Fatalf("not confirmed:\n%s", out.String())
+	}
+	if mapping["Shipping"] != types.StatusInProgress {
+		t.Errorf("Shipping -> %s", mapping["Shipping"])
+	}
+	if !strings.Contains(out.String(), `no column "Archive"`) || !strings.Contains(out.String(), "Would import 2 issue(s)") {
+		t.Errorf("output:\n%s", out.String())
+	}
+
+	for _, answer := range []string{"n\n", "", "e\nTodo=closed\n"} {
+		out.Reset()
+		if confirmBoardMapping(board, boardimport.DefaultMapping(board), nil, bufio.NewReader(strings.NewReader(answer)), &out) {
+			t.Errorf("answer %q confirmed", answer)
+		}
+	}
+}
@@ -0,0 +1,401 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/refs"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"golang.org/x/term"
+)
+
+var inferDepsCmd = &cobra.Command{
+	Use:     "infer-deps --from-git",
+	GroupID: "deps",
+	Short:   "Propose dependencies from commit history",
+	Long: `Propose dependencies between issues from the git history, to give an
+imported backlog a dependency graph to start from.
+
+Commits are read for issue IDs, and three signals become proposals:
+  blocks      A commit says one issue "depends on", is "blocked by", "requires",
+              "builds on" or is a "follow-up to" another it names
+  relates-to  Two issues are mentioned in the same commit
+  relates-to  Two issues' commits change mostly the same files (--min-overlap)
+
+Commits naming more than five issues, and files changed for more than ten,
+say little about how two issues relate and are left out. Pairs that are
+already linked in any way are never proposed.
+
+Nothing changes until you confirm: --apply asks about each proposal, and
+--yes adds them all.
+
+Examples:
+  bd infer-deps --from-git                        # List proposals
+  bd infer-deps --from-git --since 6.months       # Only recent history
+  bd infer-deps --from-git --apply                # Confirm each one
+  bd infer-deps --from-git --apply --yes --json   # Add them all`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fromGit, _ := cmd.Flags().GetBool("from-git")
+		since, _ := cmd.Flags().GetString("since")
+		minOverlap, _ := cmd.Flags().GetFloat64("min-overlap")
+		limit, _ := cmd.Flags().GetInt("limit")
+		apply, _ := cmd.Flags().GetBool("apply")
+		yes, _ := cmd.Flags().GetBool("yes")
+		if !fromGit {
+			FatalErrorCode(exitValidation, "choose where to infer dependencies from (--from-git)")
+		}
+		if minOverlap <= 0 || minOverlap > 1 {
+			FatalErrorCode(exitValidation, "--min-overlap must be between 0 and 1")
+		}
+		if yes && !apply {
+			FatalErrorCode(exitValidation, "--yes only applies with --apply")
+		}
+		if apply {
+			CheckReadonly("infer-deps --apply")
+			if !yes && (jsonOutput || !term.IsTerminal(int(os.Stdin.Fd()))) {
+				FatalErrorWithHint("confirmation needs a terminal", "use --yes to add every proposal, or leave out --apply to list them")
+			}
+		}
+		ctx := rootCtx
+
+		commits, err := readGitCommits(".", since)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			FatalErrorRespectJSON("loading issues: %v", err)
+		}
+		exists := make(map[string]bool, len(issues))
+		for _, issue := range issues {
+			exists[issue.ID] = true
+		}
+		allDeps, err := store.GetAllDependencyRecords(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("loading dependencies: %v", err)
+		}
+		linked := make(map[[2]string]bool)
+		for _, deps := range allDeps {
+			for _, dep := range deps {
+				linked[referencePair(dep.IssueID, dep.DependsOnID)] = true
+			}
+		}
+
+		proposals := inferDeps(commits, referenceFinder(ctx, store), exists, linked, minOverlap)
+		if limit > 0 && len(proposals) > limit {
+			proposals = proposals[:limit]
+		}
+
+		if !apply {
+			if jsonOutput {
+				outputJSON(map[string]interface{}{"commits": len(commits), "proposals": proposals})
+				return
+			}
+			printDepProposals(os.Stdout, proposals, len(commits))
+			if len(proposals) > 0 {
+				fmt.Printf("\nAdd them with 'bd infer-deps --from-git --apply' (asks about each one)\n")
+			}
+			return
+		}
+
+		accepted := proposals
+		if !yes {
+			accepted = confirmDepProposals(proposals, bufio.NewReader(os.Stdin), os.Stdout)
+		}
+		added := []depProposal{}
+		for _, p := range accepted {
+			if p.Type == types.DepRelatesTo {
+				err = relateIssues(ctx, store, p.IssueID, p.DependsOnID)
+			} else {
+				err = store.AddDependency(ctx, &types.Dependency{IssueID: p.IssueID, DependsOnID: p.DependsOnID, Type: p.Type}, actor)
+			}
+			if err != nil {
+				WarnError("failed to add %s: %v", p, err)
+				continue
+			}
+			added = append(added, p)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"commits": len(commits), "proposals": proposals, "added": added})
+			return
+		}
+		fmt.Printf("%s Added %d of %d proposed dependencies\n", ui.RenderPass("✓"), len(added), len(proposals))
+	},
+}
+
+func init() {
+	inferDepsCmd.Flags().Bool("from-git", false, "Infer dependencies from the commits in this git repository")
+	inferDepsCmd.Flags().String("since", "", "Only read commits newer than this (git date, e.g. 6.months or 2025-01-01)")
+	inferDepsCmd.Flags().Float64("min-overlap", 0.5, "Share of changed files two issues need in common to be related (0-1)")
+	inferDepsCmd.Flags().IntP("limit", "n", 50, "Maximum number of proposals (0 for all)")
+	inferDepsCmd.Flags().Bool("apply", false, "Add proposals after confirming each one")
+	inferDepsCmd.Flags().BoolP("yes", "y", false, "With --apply, add every proposal without asking")
+	rootCmd.AddCommand(inferDepsCmd)
+}
+
+const (
+	// maxCommitIssues leaves out commits naming more issues than this,
+	// such as release notes and merges.
+	maxCommitIssues = 5
+	// maxFileIssues leaves out files changed for more issues than this,
+	// such as changelogs and module files.
+	maxFileIssues = 10
+	// minSharedFiles is how many changed files two issues need in common,
+	// whatever their overlap, to be related by co-change.
+	minSharedFiles = 2
+)
+
+// gitCommit is a commit read for dependency inference.
+type gitCommit struct {
+	Hash    string
+	Message string
+	Files   []string
+}
+
+// depProposal is a dependency inferred from the history: IssueID depends
+// on DependsOnID with Type. relates-to proposals are added both ways.
+type depProposal struct {
+	IssueID     string               `json:"issue_id"`
+	DependsOnID string               `json:"depends_on_id"`
+	Type        types.DependencyType `json:"type"`
+	Signal      string               `json:"signal"` // "wording", "commit" or "co-change"
+	Reason      string               `json:"reason"`
+	Commits     []string             `json:"commits,omitempty"`
+	Overlap     float64              `json:"overlap,omitempty"`
+}
+
+func (p depProposal) String() string {
+	if p.Type == types.DepBlocks {
+		return fmt.Sprintf("%s blocks %s", p.DependsOnID, p.IssueID)
+	}
+	return fmt.Sprintf("%s %s %s", p.IssueID, p.Type, p.DependsOnID)
+}
+
+// readGitCommits reads the non-merge commits of the repository at dir,
+// with the files each changed.
+func readGitCommits(dir, since string) ([]gitCommit, error) {
+	args := []string{"log", "--no-merges", "--name-only", "--format=%x1e%h%x1f%B%x1f"}
+	if since != "" {
+		args = append(args, "--since="+since)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("reading git history: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("reading git history: %w", err)
+	}
+	return parseGitCommits(string(out)), nil
+}
+
+// parseGitCommits parses the output of readGitCommits' git log.
+func parseGitCommits(out string) []gitCommit {
+	var commits []gitCommit
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.SplitN(record, "\x1f", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		c := gitCommit{Hash: strings.TrimSpace(fields[0]), Message: strings.TrimSpace(fields[1])}
+		for _, line := range strings.Split(fields[2], "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				c.Files = append(c.Files, line)
+			}
+		}
+		commits = append(commits, c)
+	}
+	return commits
+}
+
+// blockingWords introduce the issue a commit's issue waits on, as in
+// "bd-9: parser, depends on bd-4".
+var blockingWords = regexp.MustCompile(`(?i)\b(?:blocked by|depends on|requires|builds on|follow-up to)\s+`)
+
+// inferDeps proposes dependencies between existing issues from commits,
+// skipping pairs already linked. Blocking proposals come first, then
+// issues mentioned together (most commits first), then issues changing
+// the same files (most overlap first).
+func inferDeps(commits []gitCommit, finder *refs.Finder, exists map[string]bool, linked map[[2]string]bool, minOverlap float64) []depProposal {
+	proposed := make(map[[2]string]bool)
+	for pair := range linked {
+		proposed[pair] = true
+	}
+
+	var blocks []depProposal
+	mentioned := make(map[[2]string][]string) // pair -> commits naming both
+	issueFiles := make(map[string]map[string]bool)
+	for _, c := range commits {
+		var ids []string
+		for _, id := range finder.Find(c.Message) {
+			if exists[id] {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 || len(ids) > maxCommitIssues {
+			continue
+		}
+		for _, id := range ids {
+			if issueFiles[id] == nil {
+				issueFiles[id] = make(map[string]bool)
+			}
+			for _, f := range c.Files {
+				issueFiles[id][f] = true
+			}
+		}
+
+		for _, blocker := range blockingIDs(c.Message, finder, exists) {
+			for _, id := range ids {
+				pair := referencePair(id, blocker)
+				if id == blocker || proposed[pair] {
+					continue
+				}
+				proposed[pair] = true
+				blocks = append(blocks, depProposal{
+					IssueID: id, DependsOnID: blocker, Type: types.DepBlocks, Signal: "wording",
+					Reason:  fmt.Sprintf("%s: %q", c.Hash, firstLine(c.Message)),
+					Commits: []string{c.Hash},
+				})
+			}
+		}
+		for i := range ids {
+			for j := i + 1; j < len(ids); j++ {
+				pair := referencePair(ids[i], ids[j])
+				mentioned[pair] = append(mentioned[pair], c.Hash)
+			}
+		}
+	}
+
+	var together []depProposal
+	for pair, hashes := range mentioned {
+		if proposed[pair] {
+			continue
+		}
+		proposed[pair] = true
+		together = append(together, depProposal{
+			IssueID: pair[0], DependsOnID: pair[1], Type: types.DepRelatesTo, Signal: "commit",
+			Reason:  fmt.Sprintf("mentioned together in %d commit(s)", len(hashes)),
+			Commits: hashes,
+		})
+	}
+	sort.Slice(together, func(i, j int) bool {
+		if len(together[i].Commits) != len(together[j].Commits) {
+			return len(together[i].Commits) > len(together[j].Commits)
+		}
+		return together[i].IssueID+together[i].DependsOnID < together[j].IssueID+together[j].DependsOnID
+	})
+
+	return append(append(blocks, together...), coChanges(issueFiles, proposed, minOverlap)...)
+}
+
+// blockingIDs returns the existing issues message says its issue waits on.
+func blockingIDs(message string, finder *refs.Finder, exists map[string]bool) []string {
+	var ids []string
+	for _, loc := range blockingWords.FindAllStringIndex(message, -1) {
+		next := strings.Fields(message[loc[1]:])
+		if len(next) == 0 {
+			continue
+		}
+		found := finder.Find(next[0])
+		if len(found) == 1 && exists[found[0]] {
+			ids = append(ids, found[0])
+		}
+	}
+	return ids
+}
+
+// coChanges relates issues whose commits changed mostly the same files:
+// at least minSharedFiles, and minOverlap of all the files either changed.
+// Files changed for more than maxFileIssues issues are not counted.
+func coChanges(issueFiles map[string]map[string]bool, proposed map[[2]string]bool, minOverlap float64) []depProposal {
+	fileIssues := make(map[string]int)
+	for _, files := range issueFiles {
+		for f := range files {
+			fileIssues[f]++
+		}
+	}
+	ids := make([]string, 0, len(issueFiles))
+	for id, files := range issueFiles {
+		for f := range files {
+			if fileIssues[f] > maxFileIssues {
+				delete(files, f)
+			}
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var out []depProposal
+	for i, a := range ids {
+		for _, b := range ids[i+1:] {
+			pair := referencePair(a, b)
+			if proposed[pair] {
+				continue
+			}
+			shared := 0
+			for f := range issueFiles[a] {
+				if issueFiles[b][f] {
+					shared++
+				}
+			}
+			if shared < minSharedFiles {
+				continue
+			}
+			overlap := float64(shared) / float64(len(issueFiles[a])+len(issueFiles[b])-shared)
+			if overlap < minOverlap {
+				continue
+			}
+			out = append(out, depProposal{
+				IssueID: pair[0], DependsOnID: pair[1], Type: types.DepRelatesTo, Signal: "co-change",
+				Reason:  fmt.Sprintf("change the same files (%d shared, overlap %.2f)", shared, overlap),
+				Overlap: overlap,
+			})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Overlap > out[j].Overlap })
+	return out
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+func printDepProposals(out io.Writer, proposals []depProposal, commits int) {
+	if len(proposals) == 0 {
+		_, _ = fmt.Fprintf(out, "No dependencies to propose from %d commit(s)\n", commits)
+		return
+	}
+	_, _ = fmt.Fprintf(out, "Proposed dependencies from %d commit(s):\n\n", commits)
+	for _, p := range proposals {
+		_, _ = fmt.Fprintf(out, "  %-40s %s\n", p, ui.RenderMuted(p.Reason))
+	}
+}
+
+// confirmDepProposals asks about each proposal and returns those accepted.
+func confirmDepProposals(proposals []depProposal, in *bufio.Reader, out io.Writer) []depProposal {
+	var accepted []depProposal
+	for i, p := range proposals {
+		_, _ = fmt.Fprintf(out, "%s\n  %s\n", p, ui.RenderMuted(p.Reason))
+		_, _ = fmt.Fprint(out, "Add? [y]es / [n]o / [a]ll remaining / [q]uit: ")
+		switch readDocAnswer(in) {
+		case "y", "yes":
+			accepted = append(accepted, p)
+		case "a", "all":
+			return append(accepted, proposals[i:]...)
+		case "q", "quit":
+			return accepted
+		}
+	}
+	return accepted
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/refs"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseGitCommits(t *testing.T) {
+	out := "\x1ea1b2c3d\x1fbd-2: lexer\n\nDepends on bd-1.\n\x1f\n\ninternal/lex.go\ninternal/lex_test.go\n" +
+		"\x1ee4f5a6b\x1fdocs only\x1f\n"
+	got := parseGitCommits(out)
+	want := []gitCommit{
+		{Hash: "a1b2c3d", Message: "bd-2: lexer\n\nDepends on bd-1.", Files: []string{"internal/lex.go", "internal/lex_test.go"}},
+		{Hash: "e4f5a6b", Message: "docs only"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseGitCommits = %#v, want %#v", got, want)
+	}
+}
+
+func TestInferDeps(t *testing.T) {
+	exists := map[string]bool{}
+	for _, id := range []string{"bd-1", "bd-2", "bd-3", "bd-4", "bd-5", "bd-6"} {
+		exists[id] = true
+	}
+	commits := []gitCommit{
+		{Hash: "c1", Message: "bd-2: parser, depends on bd-1", Files: []string{"parse.go"}},
+		{Hash: "c2", Message: "Fix bd-3 and bd-4 together", Files: []string{"a.go"}},
+		{Hash: "c3", Message: "bd-3 follow-up, see bd-4", Files: []string{"b.go"}},
+		{Hash: "c4", Message: "bd-5: cache", Files: []string{"cache.go", "cache_test.go", "store.go"}},
+		{Hash: "c5", Message: "bd-6: cache eviction", Files: []string{"cache.go", "cache_test.go"}},
+		{Hash: "c6", Message: "bd-9 is not an issue here, nor is bd-1 blocked by bd-9", Files: []string{"x.go"}},
+		{Hash: "c7", Message: "release: bd-1 bd-2 bd-3 bd-4 bd-5 bd-6", Files: []string{"cache.go"}},
+	}
+	// bd-1 and bd-6 are already linked, so nothing is proposed for them
+	linked := map[[2]string]bool{referencePair("bd-6", "bd-1"): true}
+
+	got := inferDeps(commits, refs.NewFinder("bd"), exists, linked, 0.5)
+	var summary []string
+	for _, p := range got {
+		summary = append(summary, p.String()+" ("+p.Signal+")")
+	}
+	want := []string{
+		"bd-1 blocks bd-2 (wording)",
+		"bd-3 relates-to bd-4 (commit)",
+		"bd-5 relates-to bd-6 (co-change)",
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("inferDeps = %v, want %v", summary, want)
+	}
+	if got[0].IssueID != "bd-2" || got[0].DependsOnID != "bd-1" || got[0].Type != types.DepBlocks {
+		t.Errorf("blocking proposal = %+v, want bd-2 depending on bd-1", got[0])
+	}
+	if len(got[1].Commits) != 2 {
+		t.Errorf("commit proposal commits = %v, want c2 and c3", got[1].Commits)
+	}
+
+	if got := inferDeps(commits, refs.NewFinder("bd"), exists, linked, 0.9); len(got) != 2 {
+		t.Errorf("with --min-overlap 0.9 got %d proposals, want the co-change one dropped", len(got))
+	}
+}
+
+func TestConfirmDepProposals(t *testing.T) {
+	proposals := []depProposal{
+		{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepRelatesTo},
+		{IssueID: "bd-3", DependsOnID: "bd-4", Type: types.DepRelatesTo},
+		{IssueID: "bd-5", DependsOnID: "bd-6", Type: types.DepRelatesTo},
+		{IssueID: "bd-7", DependsOnID: "bd-8", Type: types.DepRelatesTo},
+	}
+	got := confirmDepProposals(proposals, bufio.NewReader(strings.NewReader("y\nn\na\n")), io.Discard)
+	if len(got) != 3 || got[0].IssueID != "bd-1" || got[1].IssueID != "bd-5" || got[2].IssueID != "bd-7" {
+		t.Errorf("accepted %+v, want bd-1, then all from bd-5", got)
+	}
+	if got := confirmDepProposals(proposals, bufio.NewReader(strings.NewReader("q\n")), io.Discard); len(got) != 0 {
+		t.Errorf("quit accepted %+v", got)
+	}
+}
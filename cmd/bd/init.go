@@ -41,7 +41,24 @@ With --stealth: configures per-repository git settings for invisible beads usage
 Beads requires a running dolt sql-server for database operations. If a server is detected
 on port 3307 or 3306, it is used automatically. Set connection details with --server-host,
 --server-port, and --server-user. Password should be set via BEADS_DOLT_PASSWORD
-environment variable.`,
+environment variable.
+
+Choose who runs the server with --backend:
+  dolt           bd starts a local sql-server when none is running (default;
+                 requires dolt on PATH unless a server is already up)
+  dolt-external  a server you run yourself; bd never starts one
+The environment is checked before anything is written.
+
+Scaffolding:
+  --labels        allowed labels, written to config.yaml as validation.labels
+  --statuses      custom statuses, stored as status.custom
+  --templates     starter workflow templates in .beads/formulas
+  --interactive   prompt for backend, prefix, labels, statuses, hooks, and templates
+
+Examples:
+  bd init --prefix api --labels backend,frontend,docs --templates
+  bd init --backend dolt-external --server-host 10.0.0.5
+  bd init -i`,
 	Run: func(cmd *cobra.Command, _ []string) {
 		prefix, _ := cmd.Flags().GetString("prefix")
 		quiet, _ := cmd.Flags().GetBool("quiet")
@@ -50,6 +67,11 @@ environment variable.`,
 		stealth, _ := cmd.Flags().GetBool("stealth")
 		skipHooks, _ := cmd.Flags().GetBool("skip-hooks")
 		force, _ := cmd.Flags().GetBool("force")
+		interactive, _ := cmd.Flags().GetBool("interactive")
+		backendChoice, _ := cmd.Flags().GetString("backend")
+		labelsFlag, _ := cmd.Flags().GetStringSlice("labels")
+		statusesFlag, _ := cmd.Flags().GetStringSlice("statuses")
+		templates, _ := cmd.Flags().GetBool("templates")
 		// fromJSONL flag is accepted but no longer used for SQLite import;
 		// Dolt bootstraps from issues.jsonl automatically on first open.
 		_, _ = cmd.Flags().GetBool("from-jsonl")
@@ -67,6 +89,11 @@ environment variable.`,
 		if err := config.Initialize(); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to initialize config: %v\n", err)
 			// Non-fatal - continue with defaults
+		} else if profileName != "" {
+			// Re-initializing dropped the profile, e.g. its issue-prefix
+			if err := config.ApplyProfile(profileName); err != nil {
+				FatalError("%v", err)
+			}
 		}
 
 		// Safety guard: check for existing JSONL with issues
@@ -116,6 +143,40 @@ environment variable.`,
 		// The hyphen is added automatically during ID generation
 		prefix = strings.TrimRight(prefix, "-")
 
+		// Scaffolding choices: flags, then the wizard if requested.
+		// Validate them and the environment before anything is written.
+		scaffold := &initScaffold{
+			Backend:   backendChoice,
+			Prefix:    prefix,
+			Labels:    splitList(labelsFlag...),
+			Statuses:  splitList(statusesFlag...),
+			SkipHooks: skipHooks,
+			Templates: templates,
+		}
+		host, port := (&dolt.Config{ServerHost: serverHost, ServerPort: serverPort}).ServerAddress()
+		env := probeEnvironment(host, port)
+		if interactive {
+			if !shouldPromptForRole() {
+				FatalError("--interactive requires a terminal; pass the choices as flags instead")
+			}
+			if err := runInitWizard(rootCtx, scaffold, env, !stealth); err != nil {
+				if isCanceled(err) {
+					fmt.Fprintln(os.Stderr, "Setup canceled.")
+					exitCanceled()
+				}
+				FatalError("running setup wizard: %v", err)
+			}
+			prefix = strings.TrimRight(scaffold.Prefix, "-")
+			skipHooks = scaffold.SkipHooks
+		}
+		if err := scaffold.validate(); err != nil {
+			FatalError("%v", err)
+		}
+		autoStart := scaffold.Backend == initBackendDolt && autoStartDefault()
+		if err := env.check(scaffold.Backend, autoStart); err != nil {
+			FatalErrorWithHint(err.Error(), "run 'bd init --interactive' to review the environment and choose a backend")
+		}
+
 		// Determine beadsDir first (used for all storage path calculations).
 		// BEADS_DIR takes precedence, otherwise use CWD/.beads (with redirect support).
 		// This must be computed BEFORE initDBPath to ensure consistent path resolution
@@ -259,8 +320,9 @@ environment variable.`,
 		}
 		// Build config. Beads always uses dolt sql-server.
 		doltCfg := &dolt.Config{
-			Path:     storagePath,
-			Database: dbName,
+			Path:      storagePath,
+			Database:  dbName,
+			AutoStart: autoStart,
 		}
 		if serverHost != "" {
 			doltCfg.ServerHost = serverHost
@@ -296,6 +358,9 @@ environment variable.`,
 				FatalError("failed to set issue prefix: %v", err)
 			}
 		}
+		if err := applyInitStatuses(ctx, store, scaffold.Statuses); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
 
 		// === TRACKING METADATA (Pattern B: Warn and Continue) ===
 		// Tracking metadata enhances functionality (diagnostics, version checks, collision detection)
@@ -380,6 +445,10 @@ environment variable.`,
 				if serverUser != "" {
 					cfg.DoltServerUser = serverUser
 				}
+				if scaffold.Backend == initBackendDoltExternal {
+					noAutoStart := false
+					cfg.DoltAutoStart = &noAutoStart
+				}
 			}
 
 			if err := cfg.Save(beadsDir); err != nil {
@@ -388,11 +457,20 @@ environment variable.`,
 			}
 
 			// Create config.yaml template (prefix is stored in DB, not config.yaml)
-			if err := createConfigYaml(beadsDir, false, ""); err != nil {
+			if err := createConfigYaml(beadsDir, false, "", scaffold.Labels); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to create config.yaml: %v\n", err)
 				// Non-fatal - continue anyway
 			}
 
+			if scaffold.Templates {
+				written, err := writeStarterTemplates(beadsDir)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				} else if len(written) > 0 && !quiet {
+					fmt.Printf("  Starter templates: %s (in .beads/formulas)\n", strings.Join(written, ", "))
+				}
+			}
+
 			// Create README.md
 			if err := createReadme(beadsDir); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: failed to create README.md: %v\n", err)
@@ -583,15 +661,7 @@ environment variable.`,
 		}
 
 		fmt.Printf("\n%s bd initialized successfully!\n\n", ui.RenderPass("✓"))
-		fmt.Printf("  Backend: %s\n", ui.RenderAccent(backend))
-		host := serverHost
-		if host == "" {
-			host = configfile.DefaultDoltServerHost
-		}
-		port := serverPort
-		if port == 0 {
-			port = configfile.DefaultDoltServerPort
-		}
+		fmt.Printf("  Backend: %s\n", ui.RenderAccent(scaffold.Backend))
 		user := serverUser
 		if user == "" {
 			user = configfile.DefaultDoltServerUser
@@ -600,7 +670,17 @@ environment variable.`,
 		fmt.Printf("  Server: %s\n", ui.RenderAccent(fmt.Sprintf("%s@%s:%d", user, host, port)))
 		fmt.Printf("  Database: %s\n", ui.RenderAccent(storagePath))
 		fmt.Printf("  Issue prefix: %s\n", ui.RenderAccent(prefix))
-		fmt.Printf("  Issues will be named: %s\n\n", ui.RenderAccent(prefix+"-<hash> (e.g., "+prefix+"-a3f2dd)"))
+		fmt.Printf("  Issues will be named: %s\n", ui.RenderAccent(prefix+"-<hash> (e.g., "+prefix+"-a3f2dd)"))
+		if len(scaffold.Labels) > 0 {
+			fmt.Printf("  Labels: %s\n", ui.RenderAccent(strings.Join(scaffold.Labels, ", ")))
+		}
+		if len(scaffold.Statuses) > 0 {
+			fmt.Printf("  Custom statuses: %s\n", ui.RenderAccent(strings.Join(scaffold.Statuses, ", ")))
+		}
+		if !env.CGO {
+			fmt.Printf("  Federation: %s\n", ui.RenderMuted("unavailable (bd built without cgo)"))
+		}
+		fmt.Println()
 		fmt.Printf("Run %s to get started.\n\n", ui.RenderAccent("bd quickstart"))
 
 		// Run limited diagnostics to verify init succeeded.
@@ -640,6 +720,11 @@ func init() {
 	initCmd.Flags().Bool("force", false, "Force re-initialization even if JSONL already has issues (may cause data loss)")
 	initCmd.Flags().Bool("from-jsonl", false, "Import from current .beads/issues.jsonl file instead of git history (preserves manual cleanups)")
 	initCmd.Flags().String("agents-template", "", "Path to custom AGENTS.md template (overrides embedded default)")
+	initCmd.Flags().BoolP("interactive", "i", false, "Prompt for backend, prefix, labels, statuses, hooks, and templates")
+	initCmd.Flags().String("backend", initBackendDolt, "Storage backend: dolt (bd starts a local server when needed) or dolt-external")
+	initCmd.Flags().StringSlice("labels", nil, "Allowed labels, written to config.yaml as validation.labels")
+	initCmd.Flags().StringSlice("statuses", nil, "Custom statuses to add (stored as status.custom)")
+	initCmd.Flags().Bool("templates", false, "Create starter workflow templates in .beads/formulas")
 
 	// Dolt server connection flags
 	initCmd.Flags().Bool("server", false, "No-op (server mode is always enabled); kept for backward compatibility")
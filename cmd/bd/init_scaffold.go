@@ -0,0 +1,316 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// Storage backends bd init can set up. Both store issues in Dolt; they
+// differ in who runs the sql-server.
+const (
+	initBackendDolt         = "dolt"          // bd starts a local server when none is running
+	initBackendDoltExternal = "dolt-external" // A server you run; bd never starts one
+)
+
+var initBackendDescriptions = map[string]string{
+	initBackendDolt:         "Dolt, with bd starting a local sql-server when none is running",
+	initBackendDoltExternal: "Dolt, on a sql-server you run yourself (bd never starts one)",
+}
+
+// initScaffold holds the scaffolding choices for bd init, from flags or
+// the interactive wizard.
+type initScaffold struct {
+	Backend   string
+	Prefix    string
+	Labels    []string // Label taxonomy, written to config.yaml as validation.labels
+	Statuses  []string // Custom statuses, stored as status.custom
+	SkipHooks bool
+	Templates bool // Write starter formulas to .beads/formulas
+}
+
+// customStatusRe matches valid custom status names (see bd doctor).
+var customStatusRe = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// validate checks the choices before anything is written.
+func (s *initScaffold) validate() error {
+	switch s.Backend {
+	case initBackendDolt, initBackendDoltExternal:
+	case "sqlite":
+		return fmt.Errorf("the sqlite backend has been removed; use --backend %s or %s", initBackendDolt, initBackendDoltExternal)
+	default:
+		return fmt.Errorf("unknown backend %q (valid: %s, %s)", s.Backend, initBackendDolt, initBackendDoltExternal)
+	}
+	for _, label := range s.Labels {
+		if strings.ContainsAny(label, ", \t") {
+			return fmt.Errorf("invalid label %q: labels cannot contain commas or whitespace", label)
+		}
+		if _, err := filepath.Match(label, ""); err != nil {
+			return fmt.Errorf("invalid label pattern %q", label)
+		}
+	}
+	for _, status := range s.Statuses {
+		if !customStatusRe.MatchString(status) {
+			return fmt.Errorf("invalid status %q: use lowercase letters, digits, and underscores, starting with a letter", status)
+		}
+		if types.Status(status).IsValid() {
+			return fmt.Errorf("status %q is built in", status)
+		}
+	}
+	return nil
+}
+
+// splitList splits a comma-separated answer or flag value into trimmed,
+// non-empty items.
+func splitList(values ...string) []string {
+	var out []string
+	for _, v := range values {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				out = append(out, item)
+			}
+		}
+	}
+	return out
+}
+
+// check reports whether the environment supports backend. autoStart is
+// whether bd may start a local server (see configfile.GetDoltAutoStart).
+func (env environment) check(backend string, autoStart bool) error {
+	if env.ServerUp {
+		return nil
+	}
+	switch {
+	case backend == initBackendDoltExternal || !autoStart:
+		return fmt.Errorf("no Dolt server is listening at %s; start one (dolt sql-server) or set --server-host/--server-port", env.ServerAddr)
+	case !env.Loopback:
+		return fmt.Errorf("no Dolt server is listening at %s, and bd only starts servers on this machine", env.ServerAddr)
+	case env.DoltBinary == "":
+		return fmt.Errorf("no Dolt server is listening at %s, and dolt is not installed to start one (see https://docs.dolthub.com/introduction/installation)", env.ServerAddr)
+	}
+	return nil
+}
+
+// summary describes the environment in one line per fact.
+func (env environment) summary() []string {
+	server := "not running"
+	if env.ServerUp {
+		server = "running"
+	}
+	dolt := "not installed"
+	if env.DoltBinary != "" {
+		dolt = env.DoltBinary
+	}
+	federation := "available"
+	if !env.CGO {
+		federation = "unavailable (bd built without cgo)"
+	}
+	return []string{
+		fmt.Sprintf("Dolt server at %s: %s", env.ServerAddr, server),
+		fmt.Sprintf("dolt binary: %s", dolt),
+		fmt.Sprintf("Federation: %s", federation),
+	}
+}
+
+// runInitWizard asks for each scaffolding choice, defaulting to the
+// current value of s.
+func runInitWizard(ctx context.Context, s *initScaffold, env environment, askHooks bool) error {
+	reader := bufio.NewReader(os.Stdin)
+	ask := func(question, def string) (string, error) {
+		if def != "" {
+			fmt.Printf("%s [%s]: ", question, def)
+		} else {
+			fmt.Printf("%s: ", question)
+		}
+		answer, err := readLineWithContext(ctx, reader, os.Stdin)
+		if err != nil {
+			return "", err
+		}
+		if answer = strings.TrimSpace(answer); answer == "" {
+			return def, nil
+		}
+		return answer, nil
+	}
+	yesNo := func(question string, def bool) (bool, error) {
+		hint := "y/N"
+		if def {
+			hint = "Y/n"
+		}
+		answer, err := ask(question, hint)
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		return def, nil
+	}
+
+	fmt.Printf("\n%s %s\n\n", ui.RenderBold("bd"), ui.RenderBold("Project Setup"))
+	for _, line := range env.summary() {
+		fmt.Printf("  %s\n", ui.RenderMuted(line))
+	}
+	fmt.Println()
+
+	backends := make([]string, 0, len(initBackendDescriptions))
+	for name := range initBackendDescriptions {
+		backends = append(backends, name)
+	}
+	sort.Strings(backends)
+	fmt.Println("Storage backends:")
+	for _, name := range backends {
+		fmt.Printf("  %-14s %s\n", name, initBackendDescriptions[name])
+	}
+	var err error
+	if s.Backend, err = ask("Backend", s.Backend); err != nil {
+		return err
+	}
+	if s.Prefix, err = ask("Issue prefix", s.Prefix); err != nil {
+		return err
+	}
+	labels, err := ask("Allowed labels, comma-separated (empty allows any)", strings.Join(s.Labels, ","))
+	if err != nil {
+		return err
+	}
+	s.Labels = splitList(labels)
+	statuses, err := ask("Custom statuses besides open/in_progress/blocked/closed, comma-separated", strings.Join(s.Statuses, ","))
+	if err != nil {
+		return err
+	}
+	s.Statuses = splitList(statuses)
+	if askHooks {
+		install, err := yesNo("Install git hooks", !s.SkipHooks)
+		if err != nil {
+			return err
+		}
+		s.SkipHooks = !install
+	}
+	if s.Templates, err = yesNo("Create starter templates in .beads/formulas", s.Templates); err != nil {
+		return err
+	}
+	fmt.Println()
+	return nil
+}
+
+// applyInitStatuses stores the custom statuses unless some are already
+// configured (e.g. another rig sharing the database).
+func applyInitStatuses(ctx context.Context, store *dolt.DoltStore, statuses []string) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+	if existing, _ := store.GetConfig(ctx, "status.custom"); existing != "" {
+		return fmt.Errorf("status.custom is already set to %q; change it with 'bd config set status.custom'", existing)
+	}
+	return store.SetConfig(ctx, "status.custom", strings.Join(statuses, ","))
+}
+
+// starterFormulas are the templates written by bd init --templates.
+var starterFormulas = map[string]string{
+	"bugfix": `# Starter template from bd init. Edit freely, or delete it.
+# Use: bd mol pour bugfix --var bug="Login fails on Safari"
+
+formula = "bugfix"
+description = "Reproduce, fix, and verify a bug."
+type = "workflow"
+version = 1
+
+[vars.bug]
+description = "Short description of the bug"
+required = true
+
+[[steps]]
+id = "reproduce"
+title = "Reproduce: {{bug}}"
+description = "Write down the steps to reproduce, and the expected and actual behavior."
+
+[[steps]]
+id = "fix"
+title = "Fix: {{bug}}"
+description = "Fix the root cause and add a regression test."
+needs = ["reproduce"]
+
+[[steps]]
+id = "verify"
+title = "Verify fix: {{bug}}"
+description = "Confirm the reproduction steps no longer fail."
+needs = ["fix"]
+`,
+	"feature": `# Starter template from bd init. Edit freely, or delete it.
+# Use: bd mol pour feature --var feature="Dark mode"
+
+formula = "feature"
+description = "Design, build, test, and document a feature."
+type = "workflow"
+version = 1
+
+[vars.feature]
+description = "Name of the feature"
+required = true
+
+[[steps]]
+id = "design"
+title = "Design: {{feature}}"
+description = "Agree on scope, approach, and acceptance criteria."
+
+[[steps]]
+id = "implement"
+title = "Implement: {{feature}}"
+needs = ["design"]
+
+[[steps]]
+id = "test"
+title = "Test: {{feature}}"
+needs = ["implement"]
+
+[[steps]]
+id = "document"
+title = "Document: {{feature}}"
+needs = ["implement"]
+`,
+}
+
+// writeStarterTemplates writes the starter formulas to beadsDir/formulas,
+// leaving existing files alone. It returns the names written.
+func writeStarterTemplates(beadsDir string) ([]string, error) {
+	dir := filepath.Join(beadsDir, "formulas")
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create formulas directory: %w", err)
+	}
+	names := make([]string, 0, len(starterFormulas))
+	for name := range starterFormulas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var written []string
+	for _, name := range names {
+		path := filepath.Join(dir, name+".formula.toml")
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		// nolint:gosec // G306: formulas are shared with collaborators via git
+		if err := os.WriteFile(path, []byte(starterFormulas[name]), 0644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, name)
+	}
+	return written, nil
+}
+
+// autoStartDefault is whether bd may start a local Dolt server, before any
+// metadata.json exists (see configfile.GetDoltAutoStart).
+func autoStartDefault() bool {
+	return (&configfile.Config{}).GetDoltAutoStart()
+}
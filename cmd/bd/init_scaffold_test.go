@@ -0,0 +1,121 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/formula"
+)
+
+func TestInitScaffoldValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       initScaffold
+		wantErr string
+	}{
+		{"defaults", initScaffold{Backend: initBackendDolt}, ""},
+		{"external with choices", initScaffold{Backend: initBackendDoltExternal, Labels: []string{"area/*", "bug"}, Statuses: []string{"in_review"}}, ""},
+		{"sqlite", initScaffold{Backend: "sqlite"}, "removed"},
+		{"unknown backend", initScaffold{Backend: "postgres"}, "unknown backend"},
+		{"label with space", initScaffold{Backend: initBackendDolt, Labels: []string{"needs review"}}, "whitespace"},
+		{"bad label pattern", initScaffold{Backend: initBackendDolt, Labels: []string{"area/["}}, "pattern"},
+		{"bad status", initScaffold{Backend: initBackendDolt, Statuses: []string{"In-Review"}}, "invalid status"},
+		{"builtin status", initScaffold{Backend: initBackendDolt, Statuses: []string{"deferred"}}, "built in"},
+	}
+	for _, tt := range tests {
+		err := tt.s.validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestInitEnvironmentCheck(t *testing.T) {
+	up := environment{ServerAddr: "127.0.0.1:3307", ServerUp: true}
+	local := environment{ServerAddr: "127.0.0.1:3307", Loopback: true, DoltBinary: "/usr/bin/dolt"}
+	noDolt := environment{ServerAddr: "127.0.0.1:3307", Loopback: true}
+	remote := environment{ServerAddr: "10.0.0.5:3307", DoltBinary: "/usr/bin/dolt"}
+
+	tests := []struct {
+		name      string
+		env       environment
+		backend   string
+		autoStart bool
+		ok        bool
+	}{
+		{"server up", up, initBackendDoltExternal, false, true},
+		{"local auto-start", local, initBackendDolt, true, true},
+		{"auto-start disabled", local, initBackendDolt, false, false},
+		{"external needs server", local, initBackendDoltExternal, false, false},
+		{"dolt not installed", noDolt, initBackendDolt, true, false},
+		{"remote host", remote, initBackendDolt, true, false},
+	}
+	for _, tt := range tests {
+		if err := tt.env.check(tt.backend, tt.autoStart); (err == nil) != tt.ok {
+			t.Errorf("%s: check = %v, want ok=%v", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	got := splitList("a, b", "", " c ,,")
+	if strings.Join(got, "|") != "a|b|c" {
+		t.Errorf("splitList = %q", got)
+	}
+}
+
+func TestWriteStarterTemplates(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "formulas", "bugfix.formula.toml")
+	if err := os.MkdirAll(filepath.Dir(existing), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(existing, []byte("# mine\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := writeStarterTemplates(dir)
+	if err != nil {
+		t.Fatalf("writeStarterTemplates: %v", err)
+	}
+	if strings.Join(written, ",") != "feature" {
+		t.Errorf("written = %v, want only feature (bugfix exists)", written)
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "# mine\n" {
+		t.Error("existing template was overwritten")
+	}
+
+	parser := formula.NewParser()
+	for name, src := range starterFormulas {
+		f, err := parser.ParseTOML([]byte(src))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := f.Validate(); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if f.Formula != name || len(f.Steps) == 0 {
+			t.Errorf("%s: formula %q with %d steps", name, f.Formula, len(f.Steps))
+		}
+	}
+}
+
+func TestCreateConfigYamlLabels(t *testing.T) {
+	dir := t.TempDir()
+	if err := createConfigYaml(dir, false, "", []string{"backend", "area/*"}); err != nil {
+		t.Fatalf("createConfigYaml: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "\nvalidation.labels: \"backend,area/*\"\n") {
+		t.Errorf("config.yaml missing labels:\n%s", data)
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // createConfigYaml creates the config.yaml template in the specified directory
 // In --no-db mode, the prefix is saved here since there's no database to store it.
-func createConfigYaml(beadsDir string, noDbMode bool, prefix string) error {
+// labels, if any, become the validation.labels taxonomy.
+func createConfigYaml(beadsDir string, noDbMode bool, prefix string, labels []string) error {
 	configYamlPath := filepath.Join(beadsDir, "config.yaml")
 
 	// Skip if already exists
@@ -27,6 +29,11 @@ func createConfigYaml(beadsDir string, noDbMode bool, prefix string) error {
 		prefixLine = fmt.Sprintf("issue-prefix: %q", prefix)
 	}
 
+	labelsLine := "# validation.labels: \"area/*,bug,feature\""
+	if len(labels) > 0 {
+		labelsLine = fmt.Sprintf("validation.labels: %q", strings.Join(labels, ","))
+	}
+
 	configYamlTemplate := fmt.Sprintf(`# Beads Configuration File
 # This file configures default behavior for all bd commands in this repository
 # All settings can also be set via environment variables (BD_* prefix)
@@ -53,6 +60,17 @@ func createConfigYaml(beadsDir string, noDbMode bool, prefix string) error {
 # Use 'bd export --events' to trigger manually regardless of this setting.
 # events-export: false
 
+# Allowed labels, checked by bd validate (comma-separated globs; empty allows any)
+%s
+
+# Working calendar for business-day dates and deferrals
+# calendar.weekend: [saturday, sunday]
+# calendar.holidays: ["2026-12-25"]
+
+# Do-not-start windows: no claiming or starting the work they cover
+# (bd update --claim/--status in_progress, bd ready --robot)
+# quiet-hours: ["fri 17:00-mon 08:00 p2+"]
+
 # Multi-repo configuration (experimental - bd-307)
 # Allows hydrating from multiple repositories and routing writes to the correct JSONL
 # repos:
@@ -69,7 +87,7 @@ func createConfigYaml(beadsDir string, noDbMode bool, prefix string) error {
 # - linear.api-key
 # - github.org
 # - github.repo
-`, prefixLine, noDbLine)
+`, prefixLine, noDbLine, labelsLine)
 
 	if err := os.WriteFile(configYamlPath, []byte(configYamlTemplate), 0600); err != nil {
 		return fmt.Errorf("failed to write config.yaml: %w", err)
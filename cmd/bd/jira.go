@@ -49,9 +49,11 @@ Modes:
   (no flags)     Bidirectional sync: pull then push, with conflict resolution
 
 Conflict Resolution:
-  By default, newer timestamp wins. Override with:
+  By default, issues changed on both sides are left alone and queued for
+  'bd sync conflicts'. Override with:
   --prefer-local   Always prefer local beads version
   --prefer-jira    Always prefer Jira version
+  --prefer-newer   Prefer the more recently updated version
 
 Examples:
   bd jira sync --pull                # Import from Jira
@@ -78,6 +80,7 @@ func init() {
 	jiraSyncCmd.Flags().Bool("dry-run", false, "Preview sync without making changes")
 	jiraSyncCmd.Flags().Bool("prefer-local", false, "Prefer local version on conflicts")
 	jiraSyncCmd.Flags().Bool("prefer-jira", false, "Prefer Jira version on conflicts")
+	jiraSyncCmd.Flags().Bool("prefer-newer", false, "Prefer the newer version on conflicts")
 	jiraSyncCmd.Flags().Bool("create-only", false, "Only create new issues, don't update existing")
 	jiraSyncCmd.Flags().String("state", "all", "Issue state to sync: open, closed, all")
 
@@ -92,6 +95,7 @@ func runJiraSync(cmd *cobra.Command, args []string) {
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	preferLocal, _ := cmd.Flags().GetBool("prefer-local")
 	preferJira, _ := cmd.Flags().GetBool("prefer-jira")
+	preferNewer, _ := cmd.Flags().GetBool("prefer-newer")
 	createOnly, _ := cmd.Flags().GetBool("create-only")
 	state, _ := cmd.Flags().GetString("state")
 
@@ -99,8 +103,8 @@ func runJiraSync(cmd *cobra.Command, args []string) {
 		CheckReadonly("jira sync")
 	}
 
-	if preferLocal && preferJira {
-		FatalError("cannot use both --prefer-local and --prefer-jira")
+	if (preferLocal && preferJira) || (preferNewer && (preferLocal || preferJira)) {
+		FatalError("use only one of --prefer-local, --prefer-jira and --prefer-newer")
 	}
 
 	if err := ensureStoreActive(); err != nil {
@@ -141,8 +145,10 @@ func runJiraSync(cmd *cobra.Command, args []string) {
 		opts.ConflictResolution = tracker.ConflictLocal
 	} else if preferJira {
 		opts.ConflictResolution = tracker.ConflictExternal
-	} else {
+	} else if preferNewer {
 		opts.ConflictResolution = tracker.ConflictTimestamp
+	} else {
+		opts.ConflictResolution = tracker.ConflictQueue
 	}
 
 	// Run sync
@@ -169,8 +175,11 @@ func runJiraSync(cmd *cobra.Command, args []string) {
 		if result.Stats.Pushed > 0 {
 			fmt.Printf("✓ Pushed %d issues\n", result.Stats.Pushed)
 		}
-		if result.Stats.Conflicts > 0 {
-			fmt.Printf("→ Resolved %d conflicts\n", result.Stats.Conflicts)
+		if resolved := result.Stats.Conflicts - result.Stats.Queued; resolved > 0 {
+			fmt.Printf("→ Resolved %d conflicts\n", resolved)
+		}
+		if result.Stats.Queued > 0 {
+			fmt.Printf("⚠ Queued %d conflicts; review them with 'bd sync conflicts list'\n", result.Stats.Queued)
 		}
 		fmt.Println("\n✓ Jira sync complete")
 		if len(result.Warnings) > 0 {
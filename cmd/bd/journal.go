@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var journalCmd = &cobra.Command{
+	Use:     "journal [issue-id]",
+	GroupID: "issues",
+	Short:   "View or add to an issue's work journal",
+	Long: `View or add to an issue's work journal: timestamped notes on what was
+tried and how it went, kept apart from the comments.
+
+Record dead ends as you hit them, so whoever picks the issue up next (person
+or agent) doesn't repeat them. The journal is shown by 'bd show --journal'
+and included in 'bd context' packs.
+
+Examples:
+  bd journal add bd-123 "Tried bumping the pool size; no change, the leak is per request"
+  bd journal add bd-123 -f attempt.md
+  bd journal bd-123
+  bd journal bd-123 --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		journal, err := store.GetJournal(ctx, issueID)
+		if err != nil {
+			FatalErrorRespectJSON("getting journal: %v", err)
+		}
+		if jsonOutput {
+			if journal == nil {
+				journal = []*types.JournalEntry{}
+			}
+			outputJSON(journal)
+			return
+		}
+		if len(journal) == 0 {
+			fmt.Printf("No journal entries on %s\n", issueID)
+			return
+		}
+		fmt.Printf("\nJournal for %s:\n\n", issueID)
+		printJournal(journal, func(t time.Time) string { return t.Local().Format("2006-01-02 15:04") })
+		fmt.Println()
+	},
+}
+
+var journalAddCmd = &cobra.Command{
+	Use:   "add [issue-id] [text]",
+	Short: "Add a timestamped entry to an issue's work journal",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("journal add")
+		text, _ := cmd.Flags().GetString("file")
+		if text != "" {
+			data, err := os.ReadFile(text) // #nosec G304 - user-provided file path is intentional
+			if err != nil {
+				FatalErrorRespectJSON("reading file: %v", err)
+			}
+			text = string(data)
+		} else if len(args) < 2 {
+			FatalErrorRespectJSON("journal text required (use -f to read from file)")
+		} else {
+			text = args[1]
+		}
+		if strings.TrimSpace(text) == "" {
+			FatalErrorRespectJSON("journal text is empty")
+		}
+
+		ctx := rootCtx
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		author := getActorWithGit()
+
+		// Check the entry for pasted credentials per scan.mode
+		quarantineLabel := scanOnWrite("journal entry", map[string]string{"journal": text})
+
+		entry, err := store.AddJournalEntry(ctx, issueID, author, text)
+		if err != nil {
+			FatalErrorRespectJSON("adding journal entry: %v", err)
+		}
+		if quarantineLabel != "" {
+			if err := store.AddLabel(ctx, issueID, quarantineLabel, author); err != nil {
+				WarnError("failed to add label %s: %v", quarantineLabel, err)
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(entry)
+			return
+		}
+		fmt.Printf("Journal entry added to %s\n", issueID)
+	},
+}
+
+// printJournal prints journal entries in order, each under its timestamp
+// and author.
+func printJournal(journal []*types.JournalEntry, formatTime func(time.Time) string) {
+	for _, e := range journal {
+		fmt.Printf("  %s %s\n", ui.RenderMuted(formatTime(e.CreatedAt)), e.Author)
+		for _, line := range strings.Split(strings.TrimRight(e.Text, "\n"), "\n") {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+}
+
+func init() {
+	journalAddCmd.Flags().StringP("file", "f", "", "Read the entry from a file")
+	journalCmd.AddCommand(journalAddCmd)
+	journalCmd.ValidArgsFunction = issueIDCompletion
+	journalAddCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(journalCmd)
+}
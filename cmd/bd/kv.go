@@ -127,12 +127,12 @@ Examples:
 			}
 			outputJSON(result)
 			if value == "" {
-				os.Exit(1)
+				os.Exit(exitNotFound)
 			}
 		} else {
 			if value == "" {
 				fmt.Fprintf(os.Stderr, "%s (not set)\n", key)
-				os.Exit(1)
+				os.Exit(exitNotFound)
 			} else {
 				fmt.Printf("%s\n", value)
 			}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var labelMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Rename a label everywhere it is used",
+	Long: `Rename a label on every issue and wisp that carries it, and rewrite the
+places that refer to it:
+
+  alerts             label comparisons in 'bd alert' queries
+  label-routes       a rule keyed by the old label
+  validation.labels  the old label in the allowed taxonomy
+  directory.labels   directories scoped to the old label
+  scan.quarantine-label, retention.hold-label
+
+Issues and alerts are rewritten in one transaction. config.yaml is
+rewritten just before it, and put back if the transaction fails, so the
+two never disagree. If the new label is already in use, the two labels'
+issues could no longer be told apart, so the rename is refused unless
+--merge is given.
+
+Examples:
+  bd label migrate --from frontend --to area:web --dry-run
+  bd label migrate --from ui --to area:web --merge`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		if strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+			FatalErrorCode(exitValidation, "--from and --to are required")
+		}
+		runRelabel(cmd, strings.TrimSpace(from), []string{strings.TrimSpace(to)})
+	},
+}
+
+var labelSplitCmd = &cobra.Command{
+	Use:   "split <label>",
+	Short: "Replace a label with several labels everywhere it is used",
+	Long: `Replace a label with several labels on every issue and wisp that carries
+it, and rewrite the places that refer to it, as 'bd label migrate' does.
+In alert queries label=old becomes (label=a OR label=b), and label!=old
+becomes (label!=a AND label!=b). A label-routes rule keyed by the old label
+is copied to each new one.
+
+Config keys that hold a single label (directory.labels,
+scan.quarantine-label, retention.hold-label) can't name several, so a split
+leaves them alone and warns.
+
+Examples:
+  bd label split platform --into backend,infra --dry-run
+  bd label split platform --into backend,infra --merge`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		into, _ := cmd.Flags().GetStringSlice("into")
+		var to []string
+		for _, label := range into {
+			if label = strings.TrimSpace(label); label != "" && !slices.Contains(to, label) {
+				to = append(to, label)
+			}
+		}
+		if len(to) < 2 {
+			FatalErrorCode(exitValidation, "--into needs at least two labels (use 'bd label migrate' to rename)")
+		}
+		runRelabel(cmd, strings.TrimSpace(args[0]), to)
+	},
+}
+
+func init() {
+	labelMigrateCmd.Flags().String("from", "", "Label to rename")
+	labelMigrateCmd.Flags().String("to", "", "New label name")
+	labelSplitCmd.Flags().StringSlice("into", nil, "Labels to replace it with, comma-separated")
+	for _, cmd := range []*cobra.Command{labelMigrateCmd, labelSplitCmd} {
+		cmd.Flags().Bool("merge", false, "Allow merging into labels already in use")
+		cmd.Flags().Bool("dry-run", false, "Show what would change without changing anything")
+	}
+	labelCmd.AddCommand(labelMigrateCmd, labelSplitCmd)
+}
+
+// relabelResult is the output of bd label migrate and bd label split.
+type relabelResult struct {
+	*dolt.RelabelReport
+	Config   []string `json:"config"`   // config.yaml keys rewritten
+	Warnings []string `json:"warnings"` // References left for the user to fix
+	DryRun   bool     `json:"dry_run"`
+}
+
+func runRelabel(cmd *cobra.Command, from string, to []string) {
+	merge, _ := cmd.Flags().GetBool("merge")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if !dryRun {
+		CheckReadonly("label " + cmd.Name())
+	}
+
+	updates, warnings := relabelConfig(from, to)
+	result := relabelResult{Config: []string{}, Warnings: warnings, DryRun: dryRun}
+	for _, u := range updates {
+		result.Config = append(result.Config, u.key)
+	}
+
+	// config.yaml goes first: a saved copy can undo it if the transaction
+	// fails, but nothing can undo a committed transaction if the file write
+	// fails.
+	restore := func() error { return nil }
+	configPath := ""
+	if !dryRun && len(updates) > 0 {
+		var err error
+		if restore, configPath, err = writeRelabelConfig(updates); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+	}
+	report, err := store.RelabelIssues(rootCtx, from, to, merge, dryRun, actor)
+	if err != nil {
+		if restoreErr := restore(); restoreErr != nil {
+			FatalErrorWithHint(fmt.Sprintf("%v; putting %s back also failed: %v", err, configPath, restoreErr),
+				fmt.Sprintf("no issues were relabeled; in %s, change %s back from %s to %s by hand",
+					configPath, strings.Join(result.Config, ", "), strings.Join(to, ", "), from))
+		}
+		FatalErrorRespectJSON("%v", err)
+	}
+	result.RelabelReport = report
+
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	verb := "Relabeled"
+	if dryRun {
+		verb = "Would relabel"
+	}
+	fmt.Printf("%s %s %s → %s on %d issue(s)", ui.RenderPass("✓"), verb, from, strings.Join(to, ", "), len(report.Issues))
+	if len(report.Merged) > 0 {
+		fmt.Printf(", %d merged", len(report.Merged))
+	}
+	fmt.Println()
+	if len(report.Alerts) > 0 {
+		fmt.Printf("  Alerts: %s\n", strings.Join(report.Alerts, ", "))
+	}
+	if len(result.Config) > 0 {
+		fmt.Printf("  Config: %s\n", strings.Join(result.Config, ", "))
+	}
+	for _, w := range warnings {
+		fmt.Printf("  %s %s\n", ui.RenderWarn("⚠"), w)
+	}
+	if dryRun {
+		fmt.Println(ui.RenderMuted("(dry run: nothing changed)"))
+	}
+}
+
+// writeRelabelConfig writes updates to config.yaml. It returns the file's
+// path and a function that puts back its previous contents; if a write
+// fails, the file is put back before returning.
+func writeRelabelConfig(updates []configUpdate) (func() error, string, error) {
+	beadsDir := getBeadsDir()
+	path := config.ConfigFileUsed()
+	if path == "" {
+		path = filepath.Join(beadsDir, "config.yaml")
+	}
+	restore, err := saveForRestore(path)
+	if err != nil {
+		return nil, path, err
+	}
+	for _, u := range updates {
+		if err := config.SaveConfigValue(u.key, u.value, beadsDir); err != nil {
+			if restoreErr := restore(); restoreErr != nil {
+				return nil, path, fmt.Errorf("writing %s to %s: %w; putting the file back also failed: %v (restore it from version control)", u.key, path, err, restoreErr)
+			}
+			return nil, path, fmt.Errorf("writing %s to %s: %w (nothing was relabeled)", u.key, path, err)
+		}
+	}
+	return restore, path, nil
+}
+
+// saveForRestore reads the file at path and returns a function that puts it
+// back as it is now, removing it if it doesn't exist yet.
+func saveForRestore(path string) (func() error, error) {
+	original, err := os.ReadFile(path) // #nosec G304 -- the project's config file
+	if os.IsNotExist(err) {
+		return func() error {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return nil
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return func() error { return os.WriteFile(path, original, 0o600) }, nil
+}
+
+// configUpdate is one config.yaml key to rewrite.
+type configUpdate struct {
+	key   string
+	value interface{}
+}
+
+// relabelConfig returns the config.yaml keys that refer to label from,
+// rewritten for to, and warnings for single-label keys a split can't
+// rewrite.
+func relabelConfig(from string, to []string) ([]configUpdate, []string) {
+	var updates []configUpdate
+	warnings := []string{}
+	if routes, ok := relabelRoutes(config.GetStringMapString("label-routes"), from, to); ok {
+		updates = append(updates, configUpdate{"label-routes", routes})
+	}
+	if labels, ok := relabelList(config.GetStringSlice("validation.labels"), from, to); ok {
+		updates = append(updates, configUpdate{"validation.labels", strings.Join(labels, ",")})
+	}
+
+	dirs := config.GetStringMapString("directory.labels")
+	if len(to) == 1 {
+		if dirs, ok := relabelValues(dirs, from, to[0]); ok {
+			updates = append(updates, configUpdate{"directory.labels", dirs})
+		}
+	} else if _, ok := relabelValues(dirs, from, ""); ok {
+		warnings = append(warnings, fmt.Sprintf("directory.labels scopes directories to %s; pick one of %s", from, strings.Join(to, ", ")))
+	}
+	for _, key := range []string{"scan.quarantine-label", "retention.hold-label"} {
+		if config.GetString(key) != from {
+			continue
+		}
+		if len(to) == 1 {
+			updates = append(updates, configUpdate{key, to[0]})
+		} else {
+			warnings = append(warnings, fmt.Sprintf("%s is %s; pick one of %s", key, from, strings.Join(to, ", ")))
+		}
+	}
+	return updates, warnings
+}
+
+// relabelRoutes copies the label-routes rule keyed by from to each label in
+// to (unless it has its own rule) and drops from's. Globs are left alone.
+func relabelRoutes(routes map[string]string, from string, to []string) (map[string]string, bool) {
+	rule, ok := routes[from]
+	if !ok {
+		return routes, false
+	}
+	out := make(map[string]string, len(routes)+len(to))
+	for label, r := range routes {
+		if label != from {
+			out[label] = r
+		}
+	}
+	for _, label := range to {
+		if _, exists := out[label]; !exists {
+			out[label] = rule
+		}
+	}
+	return out, true
+}
+
+// relabelList replaces from in a label list, such as validation.labels,
+// with to, keeping the list's order and dropping duplicates. Globs are
+// left alone.
+func relabelList(items []string, from string, to []string) ([]string, bool) {
+	var out []string
+	changed := false
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item != from {
+			if !slices.Contains(out, item) {
+				out = append(out, item)
+			}
+			continue
+		}
+		changed = true
+		for _, label := range to {
+			if !slices.Contains(out, label) {
+				out = append(out, label)
+			}
+		}
+	}
+	if !changed {
+		return items, false
+	}
+	return out, true
+}
+
+// relabelValues replaces from with to in a map's values, such as
+// directory.labels.
+func relabelValues(m map[string]string, from, to string) (map[string]string, bool) {
+	out := make(map[string]string, len(m))
+	changed := false
+	for k, v := range m {
+		if v == from {
+			v, changed = to, true
+		}
+		out[k] = v
+	}
+	if !changed {
+		return m, false
+	}
+	return out, true
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRelabelRoutes(t *testing.T) {
+	routes := map[string]string{"platform": "epic=bd-1", "infra": "assignee=ops", "area:*": "priority=P2"}
+	got, ok := relabelRoutes(routes, "platform", []string{"backend", "infra"})
+	want := map[string]string{"backend": "epic=bd-1", "infra": "assignee=ops", "area:*": "priority=P2"}
+	if !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("relabelRoutes = %v, %v; want %v", got, ok, want)
+	}
+	if _, ok := relabelRoutes(routes, "frontend", []string{"web"}); ok {
+		t.Error("rewrote routes without a rule for the label")
+	}
+}
+
+func TestRelabelList(t *testing.T) {
+	got, ok := relabelList([]string{"bug", "platform", " area/*", "infra"}, "platform", []string{"backend", "infra"})
+	if !ok || !stringSlicesEqual(got, []string{"bug", "backend", "infra", "area/*"}) {
+		t.Errorf("relabelList = %v, %v", got, ok)
+	}
+	if _, ok := relabelList([]string{"platform/*"}, "platform", []string{"backend"}); ok {
+		t.Error("rewrote a glob")
+	}
+}
+
+func TestRelabelValues(t *testing.T) {
+	dirs := map[string]string{"packages/web": "frontend", "packages/api": "backend"}
+	got, ok := relabelValues(dirs, "frontend", "area:web")
+	if !ok || got["packages/web"] != "area:web" || got["packages/api"] != "backend" {
+		t.Errorf("relabelValues = %v, %v", got, ok)
+	}
+	if dirs["packages/web"] != "frontend" {
+		t.Error("relabelValues modified its input")
+	}
+}
+
+func TestSaveForRestore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("label-routes:\n  ui: priority=P1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	restore, err := saveForRestore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("label-routes:\n  area:web: priority=P1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := restore(); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "label-routes:\n  ui: priority=P1\n" {
+		t.Errorf("restored config = %q", data)
+	}
+
+	// A file that didn't exist is removed again
+	created := filepath.Join(dir, "new.yaml")
+	restore, err = saveForRestore(created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(created, []byte("x: 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := restore(); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Errorf("restore left %s behind: %v", created, err)
+	}
+}
@@ -40,13 +40,13 @@ Data Mapping (optional, sensible defaults provided):
     bd config set linear.priority_map.3 2    # Medium -> Medium
     bd config set linear.priority_map.4 3    # Low -> Low
 
-  State mapping (Linear state type to Beads status):
-    bd config set linear.state_map.backlog open
-    bd config set linear.state_map.unstarted open
-    bd config set linear.state_map.started in_progress
-    bd config set linear.state_map.completed closed
-    bd config set linear.state_map.canceled closed
-    bd config set linear.state_map.my_custom_state in_progress  # Custom state names
+  Status mapping (Linear state type or name to Beads status, [:pull|:push]):
+    bd config set linear.status_map.backlog open:pull
+    bd config set linear.status_map.unstarted open
+    bd config set linear.status_map.started in_progress
+    bd config set linear.status_map.completed closed
+    bd config set linear.status_map.canceled closed:pull
+    bd config set linear.status_map.in_review in_progress:pull  # Custom state names
 
   Label to issue type mapping:
     bd config set linear.label_type_map.bug bug
@@ -88,9 +88,11 @@ Type Filtering (--push only):
   --include-ephemeral       Include ephemeral issues (wisps, etc.); default is to exclude
 
 Conflict Resolution:
-  By default, newer timestamp wins. Override with:
+  By default, issues changed on both sides are left alone and queued for
+  'bd sync conflicts'. Override with:
   --prefer-local    Always prefer local beads version
   --prefer-linear   Always prefer Linear version
+  --prefer-newer    Prefer the more recently updated version
 
 Examples:
   bd linear sync --pull                         # Import from Linear
@@ -134,6 +136,7 @@ func init() {
 	linearSyncCmd.Flags().Bool("dry-run", false, "Preview sync without making changes")
 	linearSyncCmd.Flags().Bool("prefer-local", false, "Prefer local version on conflicts")
 	linearSyncCmd.Flags().Bool("prefer-linear", false, "Prefer Linear version on conflicts")
+	linearSyncCmd.Flags().Bool("prefer-newer", false, "Prefer the newer version on conflicts")
 	linearSyncCmd.Flags().Bool("create-only", false, "Only create new issues, don't update existing")
 	linearSyncCmd.Flags().Bool("update-refs", true, "Update external_ref after creating Linear issues")
 	linearSyncCmd.Flags().String("state", "all", "Issue state to sync: open, closed, all")
@@ -153,6 +156,7 @@ func runLinearSync(cmd *cobra.Command, args []string) {
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	preferLocal, _ := cmd.Flags().GetBool("prefer-local")
 	preferLinear, _ := cmd.Flags().GetBool("prefer-linear")
+	preferNewer, _ := cmd.Flags().GetBool("prefer-newer")
 	createOnly, _ := cmd.Flags().GetBool("create-only")
 	state, _ := cmd.Flags().GetString("state")
 	typeFilters, _ := cmd.Flags().GetStringSlice("type")
@@ -163,8 +167,8 @@ func runLinearSync(cmd *cobra.Command, args []string) {
 		CheckReadonly("linear sync")
 	}
 
-	if preferLocal && preferLinear {
-		FatalError("cannot use both --prefer-local and --prefer-linear")
+	if (preferLocal && preferLinear) || (preferNewer && (preferLocal || preferLinear)) {
+		FatalError("use only one of --prefer-local, --prefer-linear and --prefer-newer")
 	}
 
 	if err := ensureStoreActive(); err != nil {
@@ -219,8 +223,10 @@ func runLinearSync(cmd *cobra.Command, args []string) {
 		opts.ConflictResolution = tracker.ConflictLocal
 	} else if preferLinear {
 		opts.ConflictResolution = tracker.ConflictExternal
-	} else {
+	} else if preferNewer {
 		opts.ConflictResolution = tracker.ConflictTimestamp
+	} else {
+		opts.ConflictResolution = tracker.ConflictQueue
 	}
 
 	// Run sync
@@ -247,8 +253,11 @@ func runLinearSync(cmd *cobra.Command, args []string) {
 		if result.Stats.Pushed > 0 {
 			fmt.Printf("✓ Pushed %d issues\n", result.Stats.Pushed)
 		}
-		if result.Stats.Conflicts > 0 {
-			fmt.Printf("→ Resolved %d conflicts\n", result.Stats.Conflicts)
+		if resolved := result.Stats.Conflicts - result.Stats.Queued; resolved > 0 {
+			fmt.Printf("→ Resolved %d conflicts\n", resolved)
+		}
+		if result.Stats.Queued > 0 {
+			fmt.Printf("⚠ Queued %d conflicts; review them with 'bd sync conflicts list'\n", result.Stats.Queued)
 		}
 		fmt.Println("\n✓ Linear sync complete")
 		if len(result.Warnings) > 0 {
@@ -546,6 +555,8 @@ func linearConfigToEnvVar(key string) string {
 		return "LINEAR_API_KEY"
 	case "linear.team_id":
 		return "LINEAR_TEAM_ID"
+	case "linear.webhook_secret":
+		return "LINEAR_WEBHOOK_SECRET"
 	default:
 		return ""
 	}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/linear"
+	"github.com/steveyegge/beads/internal/tracker"
+)
+
+// linearWebhookPath is where bd serve receives Linear webhooks.
+const linearWebhookPath = "/webhooks/linear"
+
+// maxWebhookBody bounds a webhook delivery; Linear's are a few kilobytes.
+const maxWebhookBody = 1 << 20
+
+// linearWebhook applies Linear webhook deliveries as they arrive. Each one
+// only names an issue: that issue alone is fetched from the API and pulled
+// like a sync would, so changes land without waiting for the next sync.
+type linearWebhook struct {
+	secret    string
+	projectID string
+	engine    *tracker.Engine
+	replays   linear.ReplayGuard
+	mu        sync.Mutex // Deliveries are applied one at a time
+}
+
+// linearWebhookResult is the response to a delivery.
+type linearWebhookResult struct {
+	Status  string `json:"status"` // "applied", "ignored" or "duplicate"
+	Issue   string `json:"issue,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Created int    `json:"created,omitempty"`
+	Updated int    `json:"updated,omitempty"`
+	Skipped int    `json:"skipped,omitempty"`
+}
+
+// newLinearWebhook returns the Linear webhook receiver, or nil if no
+// linear.webhook_secret is configured.
+func newLinearWebhook(ctx context.Context) (*linearWebhook, error) {
+	secret, _ := getLinearConfig(ctx, "linear.webhook_secret")
+	if secret == "" {
+		return nil, nil
+	}
+	engine, err := newIntegrationEngine(ctx, "linear")
+	if err != nil {
+		return nil, err
+	}
+	engine.OnWarning = func(msg string) { fmt.Fprintf(os.Stderr, "Warning: linear webhook: %s\n", msg) }
+	projectID, _ := store.GetConfig(ctx, "linear.project_id")
+	return &linearWebhook{secret: secret, projectID: projectID, engine: engine}, nil
+}
+
+func (h *linearWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	now := time.Now()
+	payload, err := linear.ParseWebhook(h.secret, body, r.Header.Get("Linear-Signature"), now)
+	if errors.Is(err, linear.ErrWebhookSignature) {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delivery := r.Header.Get("Linear-Delivery")
+	if delivery != "" && h.replays.Seen(delivery) {
+		writeLinearWebhookResult(w, &linearWebhookResult{Status: "duplicate"})
+		return
+	}
+	result, err := h.apply(r.Context(), payload)
+	if err != nil {
+		// A failed delivery isn't marked handled, so Linear's retry applies it
+		fmt.Fprintf(os.Stderr, "Warning: linear webhook: %v\n", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if delivery != "" {
+		h.replays.Handled(delivery, now)
+	}
+	writeLinearWebhookResult(w, result)
+}
+
+// apply pulls the issue a delivery names, unless it is one sync ignores.
+func (h *linearWebhook) apply(ctx context.Context, payload *linear.WebhookPayload) (*linearWebhookResult, error) {
+	ignored := func(reason string) (*linearWebhookResult, error) {
+		return &linearWebhookResult{Status: "ignored", Reason: reason}, nil
+	}
+	if payload.Type != "Issue" {
+		return ignored(payload.Type + " webhooks are not synced")
+	}
+	if payload.Action == "remove" {
+		return ignored("removed issues are not synced")
+	}
+	issue, err := payload.Issue()
+	if err != nil {
+		return ignored(err.Error())
+	}
+	if h.projectID != "" && issue.ProjectID != h.projectID {
+		return ignored("not in linear.project_id")
+	}
+
+	ext, err := h.engine.Tracker.FetchIssue(ctx, issue.Identifier)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", issue.Identifier, err)
+	}
+	if ext == nil {
+		return ignored(issue.Identifier + " is not in linear.team_id")
+	}
+	stats, err := h.engine.PullIssues(ctx, []tracker.TrackerIssue{*ext})
+	if err != nil {
+		return nil, fmt.Errorf("pulling %s: %w", issue.Identifier, err)
+	}
+	if err := store.SetConfig(ctx, "linear.webhook_last", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: linear webhook: recording delivery: %v\n", err)
+	}
+	return &linearWebhookResult{Status: "applied", Issue: issue.Identifier,
+		Created: stats.Created, Updated: stats.Updated, Skipped: stats.Skipped}, nil
+}
+
+func writeLinearWebhookResult(w http.ResponseWriter, result *linearWebhookResult) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
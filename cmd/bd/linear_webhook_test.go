@@ -0,0 +1,52 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLinearWebhookHandler(t *testing.T) {
+	h := &linearWebhook{secret: "s3cret"}
+	deliver := func(method, body, signature, delivery string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, linearWebhookPath, strings.NewReader(body))
+		req.Header.Set("Linear-Signature", signature)
+		req.Header.Set("Linear-Delivery", delivery)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	sign := func(body string) string {
+		mac := hmac.New(sha256.New, []byte("s3cret"))
+		mac.Write([]byte(body))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	comment := fmt.Sprintf(`{"action":"create","type":"Comment","data":{"id":"c1"},"webhookTimestamp":%d}`, time.Now().UnixMilli())
+	stale := fmt.Sprintf(`{"action":"update","type":"Issue","data":{"identifier":"ENG-1"},"webhookTimestamp":%d}`,
+		time.Now().Add(-time.Hour).UnixMilli())
+
+	if rec := deliver(http.MethodGet, "", "", ""); rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: status %d, want 405", rec.Code)
+	}
+	if rec := deliver(http.MethodPost, comment, "deadbeef", "d1"); rec.Code != http.StatusUnauthorized {
+		t.Errorf("bad signature: status %d, want 401", rec.Code)
+	}
+	if rec := deliver(http.MethodPost, stale, sign(stale), "d2"); rec.Code != http.StatusBadRequest {
+		t.Errorf("stale delivery: status %d, want 400", rec.Code)
+	}
+
+	for _, want := range []string{"ignored", "duplicate"} {
+		rec := deliver(http.MethodPost, comment, sign(comment), "d3")
+		var result linearWebhookResult
+		if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil || rec.Code != http.StatusOK || result.Status != want {
+			t.Errorf("comment delivery: status %d, body %s; want 200 %s", rec.Code, rec.Body, want)
+		}
+	}
+}
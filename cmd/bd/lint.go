@@ -155,8 +155,8 @@ Examples:
 			fmt.Println()
 		}
 
-		// Exit with error code if warnings found (useful for CI)
-		os.Exit(1)
+		// Exit with the validation code if warnings found (useful for CI)
+		os.Exit(exitValidation)
 	},
 }
 
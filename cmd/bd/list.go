@@ -130,6 +130,9 @@ func watchIssues(ctx context.Context, store *dolt.DoltStore, filter types.IssueF
 	}
 	defer func() { _ = watcher.Close() }() // Best effort cleanup
 
+	// Refreshes repeat the same query; skip it when nothing changed.
+	store.EnableQueryCache(0)
+
 	// Watch the .beads directory
 	if err := watcher.Add(beadsDir); err != nil {
 		fmt.Fprintf(os.Stderr, "Error watching directory: %v\n", err)
@@ -142,7 +145,7 @@ func watchIssues(ctx context.Context, store *dolt.DoltStore, filter types.IssueF
 		fmt.Fprintf(os.Stderr, "Error querying issues: %v\n", err)
 		return
 	}
-	sortIssues(issues, sortBy, reverse)
+	sortIssues(issues, sortBy, reverse, voteCountsForSort(ctx, store, issues, sortBy))
 	displayPrettyList(issues, true)
 
 	fmt.Fprintf(os.Stderr, "\nWatching for changes... (Press Ctrl+C to exit)\n")
@@ -178,7 +181,7 @@ func watchIssues(ctx context.Context, store *dolt.DoltStore, filter types.IssueF
 							fmt.Fprintf(os.Stderr, "Error refreshing issues: %v\n", err)
 							return
 						}
-						sortIssues(issues, sortBy, reverse)
+						sortIssues(issues, sortBy, reverse, voteCountsForSort(ctx, store, issues, sortBy))
 						displayPrettyList(issues, true)
 						fmt.Fprintf(os.Stderr, "\nWatching for changes... (Press Ctrl+C to exit)\n")
 					})
@@ -193,8 +196,9 @@ func watchIssues(ctx context.Context, store *dolt.DoltStore, filter types.IssueF
 	}
 }
 
-// sortIssues sorts a slice of issues by the specified field and direction
-func sortIssues(issues []*types.Issue, sortBy string, reverse bool) {
+// sortIssues sorts a slice of issues by the specified field and direction.
+// votes holds vote counts for the "votes" field (see voteCountsForSort).
+func sortIssues(issues []*types.Issue, sortBy string, reverse bool, votes map[string]int) {
 	if sortBy == "" {
 		return
 	}
@@ -234,6 +238,12 @@ func sortIssues(issues []*types.Issue, sortBy string, reverse bool) {
 			result = cmp.Compare(a.IssueType, b.IssueType)
 		case "assignee":
 			result = cmp.Compare(a.Assignee, b.Assignee)
+		case "votes":
+			// Default: most votes first, then priority
+			result = cmp.Compare(votes[b.ID], votes[a.ID])
+			if result == 0 {
+				result = cmp.Compare(a.Priority, b.Priority)
+			}
 		default:
 			// Unknown sort field, no sorting
 			result = 0
@@ -346,7 +356,7 @@ var listCmd = &cobra.Command{
 		watchMode, _ := cmd.Flags().GetBool("watch")
 
 		// Pager control (bd-jdz3)
-		noPager, _ := cmd.Flags().GetBool("no-pager")
+		pager := pagerOptions(cmd)
 
 		// Ready filter (bd-ihu31)
 		readyFlag, _ := cmd.Flags().GetBool("ready")
@@ -356,9 +366,26 @@ var listCmd = &cobra.Command{
 			prettyFormat = true
 		}
 
+		// Grouping and counts (--group-by, --count-only)
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		countOnly, _ := cmd.Flags().GetBool("count-only")
+		if groupBy != "" && !slices.Contains(listGroupFields, groupBy) {
+			FatalError("invalid --group-by %q (valid: %s)", groupBy, strings.Join(listGroupFields, ", "))
+		}
+		if (groupBy != "" || countOnly) && (prettyFormat || formatStr != "") {
+			FatalError("--group-by and --count-only cannot be combined with --pretty, --tree, --watch, or --format")
+		}
+		porcelain := porcelainFormat(cmd)
+		if porcelain != "" && (prettyFormat || formatStr != "" || groupBy != "" || countOnly) {
+			FatalError("--porcelain cannot be combined with --pretty, --tree, --watch, --format, --group-by, or --count-only")
+		}
+
 		// Use global jsonOutput set by PersistentPreRun
 
 		// Normalize labels: trim, dedupe, remove empty
+		if milestone, _ := cmd.Flags().GetString("milestone"); milestone != "" {
+			labels = append(labels, milestoneLabelPrefix+milestone)
+		}
 		labels = utils.NormalizeLabels(labels)
 		labelsAny = utils.NormalizeLabels(labelsAny)
 
@@ -381,6 +408,8 @@ var listCmd = &cobra.Command{
 			effectiveLimit = limit // Explicit value (including --limit 0 for unlimited)
 		case allFlag:
 			effectiveLimit = 0 // --all implies unlimited regardless of other flags
+		case groupBy != "" || countOnly:
+			effectiveLimit = 0 // Counts and estimates cover every matching issue
 		case ui.IsAgentMode():
 			effectiveLimit = 20 // Agent mode default
 		}
@@ -621,14 +650,31 @@ var listCmd = &cobra.Command{
 			activeStore = rigStore
 		}
 
-		// Direct mode
-		issues, err := activeStore.SearchIssues(ctx, "", filter)
+		// Direct mode (watch mode polls the primary below)
+		issues, err := activeStore.SearchIssues(withReadReplica(ctx, activeStore), "", filter)
 		if err != nil {
 			FatalError("%v", err)
 		}
 
 		// Apply sorting
-		sortIssues(issues, sortBy, reverse)
+		sortIssues(issues, sortBy, reverse, voteCountsForSort(ctx, activeStore, issues, sortBy))
+
+		if groupBy != "" || countOnly {
+			outputListAggregate(ctx, activeStore, issues, groupBy, countOnly)
+			return
+		}
+
+		if porcelain != "" {
+			if err := writePorcelainIssues(ctx, os.Stdout, activeStore, issues); err != nil {
+				FatalError("%v", err)
+			}
+			return
+		}
+
+		// Human-readable output shows translations in the display language
+		if !jsonOutput {
+			localizeIssues(issues)
+		}
 
 		// Handle watch mode (GH#654) - must be before other output modes
 		if watchMode {
@@ -649,11 +695,14 @@ var listCmd = &cobra.Command{
 					fmt.Printf("Issue '%s' has no children\n", parentID)
 					return
 				}
+				localizeIssues(treeIssues)
 
 				// Load dependencies for tree structure
 				// Best effort: display gracefully degrades with empty data
 				allDeps, _ := activeStore.GetAllDependencyRecords(ctx)
+				stopPager := ui.StartPager(pager)
 				displayPrettyListWithDeps(treeIssues, false, allDeps)
+				stopPager()
 				return
 			}
 
@@ -661,7 +710,9 @@ var listCmd = &cobra.Command{
 			// Load dependencies for tree structure
 			// Best effort: display gracefully degrades with empty data
 			allDeps, _ := activeStore.GetAllDependencyRecords(ctx)
+			stopPager := ui.StartPager(pager)
 			displayPrettyListWithDeps(issues, false, allDeps)
+			stopPager()
 			// Show truncation hint if we hit the limit (GH#788)
 			if effectiveLimit > 0 && len(issues) == effectiveLimit {
 				fmt.Fprintf(os.Stderr, "\nShowing %d issues (use --limit 0 for all)\n", effectiveLimit)
@@ -688,6 +739,7 @@ var listCmd = &cobra.Command{
 			depCounts, _ := activeStore.GetDependencyCounts(ctx, issueIDs)
 			allDeps, _ := activeStore.GetDependencyRecordsForIssues(ctx, issueIDs)
 			commentCounts, _ := activeStore.GetCommentCounts(ctx, issueIDs)
+			voteCounts, _ := activeStore.GetVoteCounts(ctx, issueIDs)
 
 			// Populate labels and dependencies for JSON output
 			for _, issue := range issues {
@@ -715,6 +767,7 @@ var listCmd = &cobra.Command{
 					DependencyCount: counts.DependencyCount,
 					DependentCount:  counts.DependentCount,
 					CommentCount:    commentCounts[issue.ID],
+					VoteCount:       voteCounts[issue.ID],
 					Parent:          parent,
 				}
 			}
@@ -764,7 +817,7 @@ var listCmd = &cobra.Command{
 		}
 
 		// Output with pager support
-		if err := ui.ToPager(buf.String(), ui.PagerOptions{NoPager: noPager}); err != nil {
+		if err := ui.ToPager(buf.String(), pager); err != nil {
 			if _, writeErr := fmt.Fprint(os.Stdout, buf.String()); writeErr != nil {
 				fmt.Fprintf(os.Stderr, "Error writing output: %v\n", writeErr)
 			}
@@ -788,6 +841,7 @@ func init() {
 	listCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
 	listCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
 	listCmd.Flags().String("label-pattern", "", "Filter by label glob pattern (e.g., 'tech-*' matches tech-debt, tech-legacy)")
+	listCmd.Flags().String("milestone", "", "Filter by milestone (issues labelled milestone:<name>)")
 	listCmd.Flags().String("label-regex", "", "Filter by label regex pattern (e.g., 'tech-(debt|legacy)')")
 	listCmd.Flags().String("title", "", "Filter by title text (case-insensitive substring match)")
 	listCmd.Flags().String("spec", "", "Filter by spec_id prefix")
@@ -796,8 +850,11 @@ func init() {
 	listCmd.Flags().String("format", "", "Output format: 'digraph' (for golang.org/x/tools/cmd/digraph), 'dot' (Graphviz), or Go template")
 	listCmd.Flags().Bool("all", false, "Show all issues including closed (overrides default filter)")
 	listCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
-	listCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
+	listCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee, votes")
 	listCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
+	listCmd.Flags().String("group-by", "", "Group issues with per-group counts and summed estimates: epic, assignee, label, status")
+	listCmd.Flags().Bool("count-only", false, "Print only the number of matching issues (per group with --group-by)")
+	addPorcelainFlag(listCmd)
 
 	// Pattern matching
 	listCmd.Flags().String("title-contains", "", "Filter by title substring (case-insensitive)")
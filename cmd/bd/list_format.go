@@ -3,9 +3,11 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/calendar"
 	"github.com/steveyegge/beads/internal/storage/dolt"
 	"github.com/steveyegge/beads/internal/timeparsing"
 	"github.com/steveyegge/beads/internal/types"
@@ -13,16 +15,46 @@ import (
 )
 
 // parseTimeFlag parses time strings using the layered time parsing architecture.
-// Supports compact durations (+6h, -1d), natural language (tomorrow, next monday),
+// Supports compact durations (+6h, -1d), business days on the working calendar
+// (+3 business days, next business day), natural language (tomorrow, next monday),
 // and absolute formats (2006-01-02, RFC3339).
 func parseTimeFlag(s string) (time.Time, error) {
-	return timeparsing.ParseRelativeTime(s, time.Now())
+	return timeparsing.ParseRelativeTimeIn(s, time.Now(), workingCalendar())
+}
+
+// parseDeferFlag parses a defer date like parseTimeFlag, moving it to the
+// start of the next working day if it falls on a weekend or holiday, and
+// noting the move on stderr unless quiet.
+func parseDeferFlag(s string, quiet bool) (time.Time, error) {
+	t, err := parseTimeFlag(s)
+	if err != nil {
+		return t, err
+	}
+	if moved := workingCalendar().NextWorkingDay(t); !moved.Equal(t) {
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "%s Defer date %s is not a working day; deferring until %s\n",
+				ui.RenderMuted("→"), t.Format("Mon 2006-01-02"), moved.Format("Mon 2006-01-02"))
+		}
+		t = moved
+	}
+	return t, nil
+}
+
+// workingCalendar returns the configured working calendar (calendar.weekend,
+// calendar.holidays), exiting if the configuration is invalid.
+func workingCalendar() *calendar.Calendar {
+	cal, err := calendar.Load()
+	if err != nil {
+		FatalErrorWithHint(fmt.Sprintf("invalid calendar config: %v", err),
+			"fix calendar.weekend or calendar.holidays in .beads/config.yaml")
+	}
+	return cal
 }
 
 // pinIndicator returns a pushpin emoji prefix for pinned issues
 func pinIndicator(issue *types.Issue) string {
 	if issue.Pinned {
-		return "📌 "
+		return ui.Glyph("📌 ")
 	}
 	return ""
 }
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// listGroupFields are the fields bd list --group-by accepts.
+var listGroupFields = []string{"epic", "assignee", "label", "status"}
+
+// Group keys for issues without a value for the grouped field.
+const (
+	noEpicGroup     = "(no epic)"
+	noAssigneeGroup = "(unassigned)"
+	noLabelGroup    = "(no labels)"
+)
+
+// issueGroup is one group of bd list --group-by output.
+type issueGroup struct {
+	Key              string         `json:"key"`
+	Title            string         `json:"title,omitempty"` // Epic title, for --group-by epic
+	Count            int            `json:"count"`
+	EstimatedMinutes int            `json:"estimated_minutes"`
+	Issues           []*types.Issue `json:"issues,omitempty"`
+}
+
+// listGroupKeys returns the groups an issue belongs to. An issue is in
+// one group per label for "label", and in exactly one group otherwise.
+func listGroupKeys(issue *types.Issue, by string, labels []string, parent string) []string {
+	switch by {
+	case "epic":
+		if parent == "" {
+			return []string{noEpicGroup}
+		}
+		return []string{parent}
+	case "assignee":
+		if issue.Assignee == "" {
+			return []string{noAssigneeGroup}
+		}
+		return []string{issue.Assignee}
+	case "label":
+		if len(labels) == 0 {
+			return []string{noLabelGroup}
+		}
+		return labels
+	default:
+		return []string{string(issue.Status)}
+	}
+}
+
+// groupIssues groups issues by field, keeping their order within each
+// group. Groups are ordered by size (largest first), then key, with the
+// group of issues lacking the field last.
+func groupIssues(issues []*types.Issue, by string, labels map[string][]string, parents map[string]string) []*issueGroup {
+	byKey := make(map[string]*issueGroup)
+	var groups []*issueGroup
+	for _, issue := range issues {
+		for _, key := range listGroupKeys(issue, by, labels[issue.ID], parents[issue.ID]) {
+			g, ok := byKey[key]
+			if !ok {
+				g = &issueGroup{Key: key}
+				byKey[key] = g
+				groups = append(groups, g)
+			}
+			g.Count++
+			if issue.EstimatedMinutes != nil {
+				g.EstimatedMinutes += *issue.EstimatedMinutes
+			}
+			g.Issues = append(g.Issues, issue)
+		}
+	}
+	isNone := func(key string) bool {
+		return key == noEpicGroup || key == noAssigneeGroup || key == noLabelGroup
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if ni, nj := isNone(groups[i].Key), isNone(groups[j].Key); ni != nj {
+			return nj
+		}
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// sumEstimates totals the issues' estimates in minutes.
+func sumEstimates(issues []*types.Issue) int {
+	total := 0
+	for _, issue := range issues {
+		if issue.EstimatedMinutes != nil {
+			total += *issue.EstimatedMinutes
+		}
+	}
+	return total
+}
+
+// formatEstimateTotal renders minutes as e.g. "5h30m", or "-" for none.
+func formatEstimateTotal(minutes int) string {
+	switch {
+	case minutes <= 0:
+		return "-"
+	case minutes < 60:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	default:
+		return fmt.Sprintf("%dh%dm", minutes/60, minutes%60)
+	}
+}
+
+// outputListAggregate prints bd list --group-by and --count-only output.
+func outputListAggregate(ctx context.Context, s *dolt.DoltStore, issues []*types.Issue, groupBy string, countOnly bool) {
+	if groupBy == "" {
+		if jsonOutput {
+			outputJSON(map[string]int{"count": len(issues), "estimated_minutes": sumEstimates(issues)})
+		} else {
+			fmt.Println(len(issues))
+		}
+		return
+	}
+
+	issueIDs := make([]string, len(issues))
+	for i, issue := range issues {
+		issueIDs[i] = issue.ID
+	}
+	// Best effort: issues without labels or parents land in the "none" group
+	labelsMap, _ := s.GetLabelsForIssues(ctx, issueIDs)
+	var parentMap map[string]string
+	if groupBy == "epic" {
+		_, _, parentMap, _ = s.GetBlockingInfoForIssues(ctx, issueIDs)
+	}
+	groups := groupIssues(issues, groupBy, labelsMap, parentMap)
+	if groupBy == "epic" {
+		var epicIDs []string
+		for _, g := range groups {
+			if g.Key != noEpicGroup {
+				epicIDs = append(epicIDs, g.Key)
+			}
+		}
+		if epics, err := s.GetIssuesByIDs(ctx, epicIDs); err == nil {
+			titles := make(map[string]string, len(epics))
+			for _, epic := range epics {
+				titles[epic.ID] = epic.Title
+			}
+			for _, g := range groups {
+				g.Title = titles[g.Key]
+			}
+		}
+	}
+
+	if jsonOutput {
+		if countOnly {
+			for _, g := range groups {
+				g.Issues = nil
+			}
+		}
+		if groups == nil {
+			groups = []*issueGroup{}
+		}
+		outputJSON(groups)
+		return
+	}
+
+	if countOnly {
+		for _, g := range groups {
+			fmt.Printf("%s\t%d\t%s\n", g.Key, g.Count, formatEstimateTotal(g.EstimatedMinutes))
+		}
+		return
+	}
+
+	var buf strings.Builder
+	for i, g := range groups {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		heading := ui.RenderBold(g.Key)
+		if g.Title != "" {
+			heading = ui.RenderID(g.Key) + " " + ui.RenderBold(g.Title)
+		}
+		summary := fmt.Sprintf("%d issues", g.Count)
+		if g.EstimatedMinutes > 0 {
+			summary += ", " + formatEstimateTotal(g.EstimatedMinutes) + " estimated"
+		}
+		buf.WriteString(fmt.Sprintf("%s %s\n", heading, ui.RenderMuted("("+summary+")")))
+		for _, issue := range g.Issues {
+			buf.WriteString("  ")
+			formatIssueCompact(&buf, issue, labelsMap[issue.ID], nil, nil, "")
+		}
+	}
+	fmt.Print(buf.String())
+}
@@ -3,6 +3,7 @@
 package main
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -186,7 +187,7 @@ func TestListSortIssues_ClosedNilLast(t *testing.T) {
 	open := &types.Issue{ID: "bd-3", ClosedAt: nil}
 
 	issues := []*types.Issue{open, closedOld, closedNew}
-	sortIssues(issues, "closed", false)
+	sortIssues(issues, "closed", false, nil)
 	if issues[0].ID != "bd-2" || issues[1].ID != "bd-1" || issues[2].ID != "bd-3" {
 		t.Fatalf("unexpected order: %s, %s, %s", issues[0].ID, issues[1].ID, issues[2].ID)
 	}
@@ -215,3 +216,42 @@ func TestListDisplayPrettyList(t *testing.T) {
 		t.Fatalf("unexpected output: %q", out)
 	}
 }
+
+func TestGroupIssues(t *testing.T) {
+	est := func(n int) *int { return &n }
+	issues := []*types.Issue{
+		{ID: "bd-1", Status: types.StatusOpen, Assignee: "alice", EstimatedMinutes: est(30)},
+		{ID: "bd-2", Status: types.StatusOpen, EstimatedMinutes: est(90)},
+		{ID: "bd-3", Status: types.StatusInProgress, Assignee: "bob"},
+		{ID: "bd-4", Status: types.StatusOpen, Assignee: "alice"},
+	}
+	labels := map[string][]string{"bd-1": {"ui", "bug"}, "bd-3": {"ui"}}
+	parents := map[string]string{"bd-1": "bd-9", "bd-2": "bd-9"}
+
+	summarize := func(groups []*issueGroup) string {
+		var parts []string
+		for _, g := range groups {
+			parts = append(parts, fmt.Sprintf("%s=%d/%d", g.Key, g.Count, g.EstimatedMinutes))
+		}
+		return strings.Join(parts, " ")
+	}
+	tests := map[string]string{
+		"status":   "open=3/120 in_progress=1/0",
+		"assignee": "alice=2/30 bob=1/0 (unassigned)=1/90",
+		"label":    "ui=2/30 bug=1/30 (no labels)=2/90",
+		"epic":     "bd-9=2/120 (no epic)=2/0",
+	}
+	for by, want := range tests {
+		if got := summarize(groupIssues(issues, by, labels, parents)); got != want {
+			t.Errorf("groupIssues(%s) = %q, want %q", by, got, want)
+		}
+	}
+}
+
+func TestFormatEstimateTotal(t *testing.T) {
+	for minutes, want := range map[int]string{0: "-", 45: "45m", 120: "2h", 330: "5h30m"} {
+		if got := formatEstimateTotal(minutes); got != want {
+			t.Errorf("formatEstimateTotal(%d) = %q, want %q", minutes, got, want)
+		}
+	}
+}
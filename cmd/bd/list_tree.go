@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
 )
 
 // buildIssueTree builds parent-child tree structure from issues
@@ -122,7 +123,7 @@ func printPrettyTree(childrenMap map[string][]*types.Issue, parentID string, pre
 		if isLast {
 			connector = "└── "
 		}
-		fmt.Printf("%s%s%s\n", prefix, connector, formatPrettyIssue(child))
+		fmt.Printf("%s%s\n", ui.Glyph(prefix+connector), formatPrettyIssue(child))
 
 		extension := "│   "
 		if isLast {
@@ -176,5 +177,5 @@ func displayPrettyListWithDeps(issues []*types.Issue, showHeader bool, allDeps m
 	}
 	fmt.Printf("Total: %d issues (%d open, %d in progress)\n", len(issues), openCount, inProgressCount)
 	fmt.Println()
-	fmt.Println("Status: ○ open  ◐ in_progress  ● blocked  ✓ closed  ❄ deferred")
+	fmt.Println(ui.Glyph("Status: ○ open  ◐ in_progress  ● blocked  ✓ closed  ❄ deferred"))
 }
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var lockCmd = &cobra.Command{
+	Use:     "lock [<id>]",
+	GroupID: "issues",
+	Short:   "Lock an issue against changes by other actors, or list locks",
+	Long: `Lock an issue so that only you can update, close, label, or delete it,
+e.g. while you rewrite a spec and don't want agents churning it. Other
+actors' writes fail with an error naming you and the reason.
+
+Locks expire after --ttl (default lock.default-ttl, 4h); --ttl 0 holds the
+lock until 'bd unlock'. Locking an issue you already hold refreshes the
+reason and expiry. Actors listed in lock.admins can modify locked issues
+and break locks.
+
+With no ID, lists the active locks.
+
+Examples:
+  bd lock bd-42 --reason "rewriting the spec"
+  bd lock bd-42 --ttl 30m
+  bd lock bd-42 --ttl 0        # Until unlocked
+  bd lock                      # List active locks`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			listLocks()
+			return
+		}
+		CheckReadonly("lock")
+		ctx := rootCtx
+		reason, _ := cmd.Flags().GetString("reason")
+		ttl, _ := cmd.Flags().GetString("ttl")
+		if !cmd.Flags().Changed("ttl") {
+			ttl = config.GetString("lock.default-ttl")
+		}
+		now := time.Now()
+		expiresAt, err := lockExpiry(ttl, now)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		fullID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		holder := getActorWithGit()
+		if err := store.LockIssue(ctx, fullID, holder, reason, expiresAt); err != nil {
+			var locked *dolt.IssueLockedError
+			if errors.As(err, &locked) && !jsonOutput {
+				FatalErrorWithHintCode(exitConflict, err.Error(), fmt.Sprintf("ask %s to run 'bd unlock %s', or break the lock with 'bd unlock %s --force'", locked.Lock.Holder, fullID, fullID))
+			}
+			FatalErrorRespectJSON("%v", err)
+		}
+		SetLastTouchedID(fullID)
+
+		lock := &types.IssueLock{IssueID: fullID, Holder: holder, Reason: reason, LockedAt: now.UTC(), ExpiresAt: expiresAt}
+		if jsonOutput {
+			outputJSON(lock)
+			return
+		}
+		fmt.Printf("%s Locked %s %s\n", ui.RenderPass("✓"), ui.RenderID(fullID), ui.RenderMuted(describeLock(lock, now)))
+	},
+}
+
+var unlockCmd = &cobra.Command{
+	Use:     "unlock <id>",
+	GroupID: "issues",
+	Short:   "Release an issue lock",
+	Long: `Release a lock taken with 'bd lock'. Only the holder can unlock an issue,
+unless --force is given. When lock.admins is set, only the actors it lists
+may use --force; otherwise anyone may. Breaking a lock is recorded in the
+issue's history.
+
+Examples:
+  bd unlock bd-42
+  bd unlock bd-42 --force     # Break another actor's lock`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("unlock")
+		ctx := rootCtx
+		force, _ := cmd.Flags().GetBool("force")
+		actor := getActorWithGit()
+		if force && !canForceUnlock(actor, config.GetStringSlice("lock.admins")) {
+			FatalErrorWithHintCode(exitPolicy, fmt.Sprintf("%s is not in lock.admins and cannot break locks", actor), "ask the lock holder or an admin to unlock the issue")
+		}
+
+		fullID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		lock, err := store.UnlockIssue(ctx, fullID, actor, force)
+		if err != nil {
+			var locked *dolt.IssueLockedError
+			if errors.As(err, &locked) && !jsonOutput {
+				FatalErrorWithHintCode(exitConflict, err.Error(), fmt.Sprintf("only %s can unlock it; use --force to break the lock", locked.Lock.Holder))
+			}
+			FatalErrorRespectJSON("%v", err)
+		}
+		SetLastTouchedID(fullID)
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"issue_id": fullID, "unlocked": lock != nil, "lock": lock})
+			return
+		}
+		switch {
+		case lock == nil:
+			fmt.Printf("%s %s is not locked\n", ui.RenderMuted("·"), ui.RenderID(fullID))
+		case lock.Holder != actor:
+			fmt.Printf("%s Broke %s's lock on %s\n", ui.RenderWarn("!"), lock.Holder, ui.RenderID(fullID))
+		default:
+			fmt.Printf("%s Unlocked %s\n", ui.RenderPass("✓"), ui.RenderID(fullID))
+		}
+	},
+}
+
+func listLocks() {
+	locks, err := store.ListIssueLocks(rootCtx)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if jsonOutput {
+		if locks == nil {
+			locks = []*types.IssueLock{}
+		}
+		outputJSON(locks)
+		return
+	}
+	if len(locks) == 0 {
+		fmt.Println("No locked issues")
+		return
+	}
+	now := time.Now()
+	fmt.Printf("\nLocked issues:\n\n")
+	for _, lock := range locks {
+		fmt.Printf("  %s %s\n", ui.RenderID(lock.IssueID), ui.RenderMuted(describeLock(lock, now)))
+	}
+	fmt.Println()
+}
+
+// lockExpiry returns when a lock taken at now with the given TTL expires,
+// or nil for a TTL of 0.
+func lockExpiry(ttl string, now time.Time) (*time.Time, error) {
+	ttl = strings.TrimSpace(ttl)
+	if ttl == "" || ttl == "0" {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lock TTL %q: use a duration such as 30m or 4h, or 0 for no expiry", ttl)
+	}
+	if d < 0 {
+		return nil, fmt.Errorf("invalid lock TTL %q: must not be negative", ttl)
+	}
+	if d == 0 {
+		return nil, nil
+	}
+	expiresAt := now.Add(d).UTC()
+	return &expiresAt, nil
+}
+
+// canForceUnlock reports whether actor may break other actors' locks:
+// anyone when admins is empty, otherwise only the listed actors.
+func canForceUnlock(actor string, admins []string) bool {
+	return len(admins) == 0 || slices.Contains(admins, actor)
+}
+
+// isLockAdmin reports whether actor is in lock.admins, and so may modify
+// issues other actors have locked.
+func isLockAdmin(actor string) bool {
+	return actor != "" && slices.Contains(config.GetStringSlice("lock.admins"), actor)
+}
+
+// describeLock summarizes a lock, e.g. "by alice (rewriting the spec),
+// expires in ~3.9 hours".
+func describeLock(lock *types.IssueLock, now time.Time) string {
+	s := "by " + lock.Holder
+	if lock.Reason != "" {
+		s += " (" + lock.Reason + ")"
+	}
+	if lock.ExpiresAt == nil {
+		return s + ", until unlocked"
+	}
+	return s + ", expires in " + formatDuration(lock.ExpiresAt.Sub(now).Hours())
+}
+
+func init() {
+	lockCmd.Flags().String("reason", "", "Why the issue is locked (shown to other actors)")
+	lockCmd.Flags().String("ttl", "", "How long the lock lasts, e.g. 30m or 2h; 0 for no expiry (default: lock.default-ttl, 4h)")
+	unlockCmd.Flags().Bool("force", false, "Break a lock held by another actor")
+
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(unlockCmd)
+}
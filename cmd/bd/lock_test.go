@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestLockExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, ttl := range []string{"", "0", "0s"} {
+		if got, err := lockExpiry(ttl, now); err != nil || got != nil {
+			t.Errorf("lockExpiry(%q) = %v, %v; want no expiry", ttl, got, err)
+		}
+	}
+	got, err := lockExpiry("90m", now)
+	if err != nil || got == nil || !got.Equal(now.Add(90*time.Minute)) {
+		t.Errorf("lockExpiry(90m) = %v, %v", got, err)
+	}
+	for _, ttl := range []string{"soon", "-1h"} {
+		if _, err := lockExpiry(ttl, now); err == nil {
+			t.Errorf("lockExpiry(%q) should fail", ttl)
+		}
+	}
+}
+
+func TestCanForceUnlock(t *testing.T) {
+	if !canForceUnlock("anyone", nil) {
+		t.Error("anyone may force unlock when lock.admins is empty")
+	}
+	if !canForceUnlock("alice", []string{"alice"}) || canForceUnlock("agent", []string{"alice"}) {
+		t.Error("only lock.admins may force unlock when it is set")
+	}
+}
+
+func TestDescribeLock(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	expires := now.Add(2 * time.Hour)
+	lock := &types.IssueLock{IssueID: "bd-1", Holder: "alice", Reason: "rewriting", ExpiresAt: &expires}
+	if got := describeLock(lock, now); !strings.HasPrefix(got, "by alice (rewriting), expires in ") {
+		t.Errorf("describeLock = %q", got)
+	}
+	lock.ExpiresAt = nil
+	if got := describeLock(lock, now); got != "by alice (rewriting), until unlocked" {
+		t.Errorf("describeLock = %q", got)
+	}
+	if !(&types.IssueLock{ExpiresAt: &expires}).Expired(expires) {
+		t.Error("a lock is expired at its expiry time")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -22,9 +23,13 @@ import (
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/debug"
 	"github.com/steveyegge/beads/internal/hooks"
+	"github.com/steveyegge/beads/internal/idgen"
 	"github.com/steveyegge/beads/internal/molecules"
+	"github.com/steveyegge/beads/internal/permissions"
 	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/writepolicy"
 )
 
 // Command group IDs for help organization
@@ -64,7 +69,8 @@ var (
 	readonlyMode    bool               // Read-only mode: block write operations (for worker sandboxes)
 	storeIsReadOnly bool               // Track if store was opened read-only (for staleness checks)
 	lockTimeout     = 30 * time.Second // Dolt open timeout (fixed default)
-	profileEnabled  bool
+	profileEnabled  bool               // --cpu-profile: write CPU profile and trace files
+	profileName     string             // --profile: named config profile to apply
 	profileFile     *os.File
 	traceFile       *os.File
 	verboseFlag     bool // Enable verbose/debug output
@@ -99,15 +105,17 @@ var readOnlyCommands = map[string]bool{
 	"list":       true,
 	"ready":      true,
 	"show":       true,
+	"context":    true,
 	"stats":      true,
 	"blocked":    true,
 	"count":      true,
 	"search":     true,
+	"grep":       true,
 	"graph":      true,
 	"duplicates": true,
 	"comments":   true, // list comments (not add)
 	"current":    true, // bd sync mode current
-	// NOTE: "export" is NOT read-only - it writes to clear dirty issues and update jsonl_file_hash
+	"export":     true,
 }
 
 // isReadOnlyCommand returns true if the command only reads from the database.
@@ -193,7 +201,8 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&allowStale, "allow-stale", false, "Allow operations on potentially stale data (skip staleness check)")
 	rootCmd.PersistentFlags().BoolVar(&readonlyMode, "readonly", false, "Read-only mode: block write operations (for worker sandboxes)")
 	rootCmd.PersistentFlags().StringVar(&doltAutoCommit, "dolt-auto-commit", "", "Dolt auto-commit policy (off|on|batch). 'on': commit after each write. 'batch': defer commits to bd sync / bd dolt commit; uncommitted changes persist in the working set until then. SIGTERM/SIGHUP flush pending batch commits. Default: off. Override via config key dolt.auto-commit")
-	rootCmd.PersistentFlags().BoolVar(&profileEnabled, "profile", false, "Generate CPU profile for performance analysis")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Config profile to use (profiles.<name> in config.yaml; default: $BD_PROFILE)")
+	rootCmd.PersistentFlags().BoolVar(&profileEnabled, "cpu-profile", false, "Generate CPU profile for performance analysis")
 	rootCmd.PersistentFlags().BoolVarP(&verboseFlag, "verbose", "v", false, "Enable verbose/debug output")
 	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress non-essential output (errors only)")
 
@@ -254,8 +263,19 @@ var rootCmd = &cobra.Command{
 			FatalError("%v", err)
 		}
 
+		// Apply the config profile first so the settings below see it.
+		// Priority: --profile > BD_PROFILE > profile in config
+		if !cmd.Flags().Changed("profile") {
+			profileName = config.GetString("profile")
+		}
+		if profileName != "" {
+			if err := config.ApplyProfile(profileName); err != nil {
+				FatalError("%v", err)
+			}
+		}
+
 		// Apply viper configuration if flags weren't explicitly set
-		// Priority: flags > viper (config file + env vars) > defaults
+		// Priority: flags > viper (env vars > profile > config file) > defaults
 		// Do this BEFORE early-return so init/version/help respect config
 
 		// Track flag overrides for notification (only in verbose mode)
@@ -314,6 +334,11 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
+		// Plain-ASCII output for terminals and CI logs that garble symbols and emoji
+		if !config.DisplayGlyphs() {
+			ui.SetGlyphs(false)
+		}
+
 		// Validate Dolt auto-commit mode early so all commands fail fast on invalid config.
 		if _, err := getDoltAutoCommitMode(); err != nil {
 			FatalError("%v", err)
@@ -326,6 +351,7 @@ var rootCmd = &cobra.Command{
 			"__complete",       // Cobra's internal completion command (shell completions work without db)
 			"__completeNoDesc", // Cobra's completion without descriptions (used by fish)
 			"bash",
+			"capabilities",
 			"completion",
 			"doctor",
 			"dolt",
@@ -338,19 +364,24 @@ var rootCmd = &cobra.Command{
 			"merge",
 			"migrate", // manages its own store lifecycle (#1668)
 			"onboard",
+			"permissions", // reads .beads/permissions.yaml only
 			"powershell",
 			"prime",
 			"quickstart",
 			"resolve-conflicts",
 			"setup",
+			"storage",
 			"sync", // deprecated no-op, prints message only
 			"version",
+			"which-db", // reports resolution without opening the database
 			"zsh",
 		}
 		// Subcommands under noDbCommands parents that still need db access.
 		// e.g., "bd dolt push" needs the store even though "dolt" is in noDbCommands.
 		dbRequiredSubcommands := map[string][]string{
-			"dolt": {"push", "pull", "commit"},
+			"dolt":    {"push", "pull", "commit"},
+			"storage": {"gc"},
+			"sync":    {"run", "status"},
 		}
 
 		// Check both the command name and parent command name for subcommands
@@ -380,6 +411,9 @@ var rootCmd = &cobra.Command{
 			return
 		}
 
+		// Commands this build lacks fail here, not with a store error
+		requireBuildCapabilities(cmd)
+
 		// Protect forks from accidentally committing upstream issue database
 		ensureForkProtection()
 
@@ -502,6 +536,9 @@ var rootCmd = &cobra.Command{
 		// Read-only commands open the store in read-only mode to avoid modifying
 		// the database (which breaks file watchers).
 		useReadOnly := isReadOnlyCommand(cmd.Name())
+		if robot, _ := cmd.Flags().GetBool("robot"); robot && cmd == readyCmd {
+			useReadOnly = false // bd ready --robot claims the issue it prints
+		}
 
 		// Auto-migrate database on version bump
 		// Skip for read-only commands - they can't write anyway
@@ -531,6 +568,9 @@ var rootCmd = &cobra.Command{
 			doltCfg.ServerUser = cfg.GetDoltServerUser()
 			doltCfg.ServerPassword = cfg.GetDoltServerPassword()
 			doltCfg.ServerTLS = cfg.GetDoltServerTLS()
+			doltCfg.AutoStart = cfg.GetDoltAutoStart()
+			doltCfg.ReadReplicas = cfg.GetDoltReadReplicas()
+			doltCfg.ReplicaMaxStaleness = cfg.GetDoltReplicaMaxStaleness()
 		}
 
 		// Server mode defaults auto-commit to OFF because the server handles
@@ -540,6 +580,30 @@ var rootCmd = &cobra.Command{
 			doltAutoCommit = string(doltAutoCommitOff)
 		}
 
+		// lock.admins may modify issues other actors have locked (bd lock)
+		doltCfg.LockOverride = isLockAdmin(actor)
+
+		// .beads/permissions.yaml limits what each actor may write
+		policy, err := permissions.Load(beadsDir)
+		if err != nil {
+			FatalErrorWithHintCode(exitValidation, err.Error(), "fix or remove .beads/"+permissions.FileName)
+		}
+		doltCfg.Policy = policy
+		doltCfg.PolicyActor = actor
+
+		// close.categories and the priorities that must close with one
+		if doltCfg.CloseRules, err = writepolicy.CloseRules(); err != nil {
+			FatalErrorWithHintCode(exitValidation, err.Error(), "fix close.categories in config.yaml")
+		}
+
+		// id.town partitions new IDs by town so federated towns never collide
+		if town := strings.TrimSpace(config.GetString("id.town")); town != "" {
+			if err := idgen.ValidateTownTag(town); err != nil {
+				FatalErrorWithHintCode(exitValidation, fmt.Sprintf("id.town: %v", err), "set id.town in config.yaml to a tag unique among your federated towns")
+			}
+			doltCfg.IDTown = town
+		}
+
 		doltCfg.Path = doltPath
 		store, err = dolt.New(rootCtx, doltCfg)
 
@@ -551,6 +615,9 @@ var rootCmd = &cobra.Command{
 			if handleFreshCloneError(err, beadsDir) {
 				os.Exit(1)
 			}
+			if handleServerUnreachableError(err) {
+				os.Exit(1)
+			}
 			FatalError("failed to open database: %v", err)
 		}
 
@@ -582,6 +649,11 @@ var rootCmd = &cobra.Command{
 			}
 		}
 
+		// Materialize recurring issues that came due (bd recur)
+		if cmd.Name() != "import" && store != nil {
+			maybeTickRecurrences(cmd)
+		}
+
 		// Tips (including sync conflict proactive checks) are shown via maybeShowTip()
 		// after successful command execution, not in PreRun
 
@@ -600,6 +672,8 @@ var rootCmd = &cobra.Command{
 			if err := maybeAutoCommit(rootCtx, doltAutoCommitParams{Command: cmd.Name()}); err != nil {
 				FatalError("dolt auto-commit failed: %v", err)
 			}
+			// Periodic storage GC (opt-in via storage.auto-gc-interval)
+			maybeAutoGC(rootCtx)
 		}
 
 		// Tip metadata auto-commit: if a tip was shown, create a separate Dolt commit for the
@@ -610,21 +684,29 @@ var rootCmd = &cobra.Command{
 				FatalError("dolt tip auto-commit failed: %v", err)
 			} else if mode == doltAutoCommitOn {
 				// Apply tip metadata writes now (deferred in recordTipShown for Dolt).
+				// Actors the permission policy doesn't let write metadata just
+				// see tips again; that mustn't fail their command.
+				denied := false
 				for tipID := range commandTipIDsShown {
 					key := fmt.Sprintf("tip_%s_last_shown", tipID)
 					value := time.Now().Format(time.RFC3339)
-					if err := store.SetMetadata(rootCtx, key, value); err != nil {
+					if err := store.SetMetadata(rootCtx, key, value); errors.Is(err, permissions.ErrDenied) {
+						denied = true
+						break
+					} else if err != nil {
 						FatalError("dolt tip auto-commit failed: %v", err)
 					}
 				}
 
-				ids := make([]string, 0, len(commandTipIDsShown))
-				for tipID := range commandTipIDsShown {
-					ids = append(ids, tipID)
-				}
-				msg := formatDoltAutoCommitMessage("tip", getActor(), ids)
-				if err := maybeAutoCommit(rootCtx, doltAutoCommitParams{Command: "tip", MessageOverride: msg}); err != nil {
-					FatalError("dolt tip auto-commit failed: %v", err)
+				if !denied {
+					ids := make([]string, 0, len(commandTipIDsShown))
+					for tipID := range commandTipIDsShown {
+						ids = append(ids, tipID)
+					}
+					msg := formatDoltAutoCommitMessage("tip", getActor(), ids)
+					if err := maybeAutoCommit(rootCtx, doltAutoCommitParams{Command: "tip", MessageOverride: msg}); err != nil {
+						FatalError("dolt tip auto-commit failed: %v", err)
+					}
 				}
 			}
 		}
@@ -726,6 +808,8 @@ func flushBatchCommitOnShutdown() {
 }
 
 func main() {
+	defer recoverCrash()
+
 	// BD_NAME overrides the binary name in help text (e.g. BD_NAME=ops makes
 	// "ops --help" show "ops" instead of "bd"). Useful for multi-instance
 	// setups where wrapper scripts set BEADS_DIR for routing.
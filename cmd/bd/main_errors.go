@@ -1,7 +1,9 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
@@ -22,6 +24,29 @@ func isFreshCloneError(err error) bool {
 		strings.Contains(errStr, "required config key missing: issue_prefix")
 }
 
+// handleServerUnreachableError explains which features are unavailable
+// when the Dolt server can't be reached, and returns true if the error was
+// handled (so caller should exit).
+func handleServerUnreachableError(err error) bool {
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	env, autoStart := probeWorkspaceEnvironment()
+	if env.ServerUp {
+		return false // Came back up, or a different failure
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: failed to open database: %v\n", err)
+	fmt.Fprintf(os.Stderr, "\nUnavailable until the Dolt server is reachable:\n")
+	for _, line := range unavailableWithoutServer(detectCapabilities(env, autoStart)) {
+		fmt.Fprintf(os.Stderr, "  • %s\n", line)
+	}
+	fmt.Fprintf(os.Stderr, "\nDolt is the only storage backend, so there is no local fallback.\n")
+	fmt.Fprintf(os.Stderr, "Run 'bd capabilities' to see what this environment supports.\n")
+	return true
+}
+
 // handleFreshCloneError displays a helpful message when a fresh clone is detected
 // and returns true if the error was handled (so caller should exit).
 // If not a fresh clone error, returns false and does nothing.
@@ -5,6 +5,7 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -248,8 +249,8 @@ func (s *markdownParseState) finalize() ([]*IssueTemplate, error) {
 }
 
 // createMarkdownScanner creates a scanner with appropriate buffer size
-func createMarkdownScanner(file *os.File) *bufio.Scanner {
-	scanner := bufio.NewScanner(file)
+func createMarkdownScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
 	// Increase buffer size for large markdown files
 	const maxScannerBuffer = 1024 * 1024 // 1MB
 	buf := make([]byte, maxScannerBuffer)
@@ -273,8 +274,14 @@ func parseMarkdownFile(path string) ([]*IssueTemplate, error) {
 		_ = file.Close() // Close errors on read-only operations are not actionable
 	}()
 
+	return parseMarkdown(file)
+}
+
+// parseMarkdown parses issue templates from markdown in the format described
+// at parseMarkdownFile.
+func parseMarkdown(r io.Reader) ([]*IssueTemplate, error) {
 	state := &markdownParseState{}
-	scanner := createMarkdownScanner(file)
+	scanner := createMarkdownScanner(r)
 
 	for scanner.Scan() {
 		line := scanner.Text()
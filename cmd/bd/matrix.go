@@ -0,0 +1,286 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+var matrixCmd = &cobra.Command{
+	Use:     "matrix",
+	GroupID: "views",
+	Short:   "Show open issues in an urgency × importance grid",
+	Long: `Show open issues in a grid of importance (priority, rows) by urgency
+(how soon they are due, columns), with a count in each cell:
+
+  overdue  due date has passed
+  week     due within 7 days
+  month    due within 30 days
+  later    due in more than 30 days
+  none     no due date
+
+Cells that suggest misprioritized work are called out below the grid:
+low-priority (P3-P4) issues that are overdue or due this week, and
+high-priority (P0-P1) issues not due for over a month.
+
+Pass --priority and/or --urgency to drill down into the issues behind a
+row, column, or cell.
+
+Examples:
+  bd matrix                              # The grid
+  bd matrix --priority 3 --urgency week  # Issues in one cell
+  bd matrix --urgency overdue            # Everything overdue, by priority
+  bd matrix --json`,
+	Run: runMatrix,
+}
+
+func init() {
+	matrixCmd.Flags().StringP("priority", "p", "", "Drill down into this priority (0-4 or P0-P4)")
+	matrixCmd.Flags().StringP("urgency", "u", "", "Drill down into this urgency (overdue|week|month|later|none)")
+	matrixCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
+	matrixCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL)")
+	rootCmd.AddCommand(matrixCmd)
+}
+
+// Urgency buckets, from most to least urgent.
+const (
+	urgencyOverdue = "overdue"
+	urgencyWeek    = "week"
+	urgencyMonth   = "month"
+	urgencyLater   = "later"
+	urgencyNone    = "none"
+)
+
+var matrixUrgencies = []string{urgencyOverdue, urgencyWeek, urgencyMonth, urgencyLater, urgencyNone}
+
+// urgencyOf buckets an issue by how soon it is due.
+func urgencyOf(issue *types.Issue, now time.Time) string {
+	if issue.DueAt == nil {
+		return urgencyNone
+	}
+	switch left := issue.DueAt.Sub(now); {
+	case left < 0:
+		return urgencyOverdue
+	case left <= 7*24*time.Hour:
+		return urgencyWeek
+	case left <= 30*24*time.Hour:
+		return urgencyMonth
+	default:
+		return urgencyLater
+	}
+}
+
+// matrixCell is one priority × urgency cell.
+type matrixCell struct {
+	Priority int      `json:"priority"`
+	Urgency  string   `json:"urgency"`
+	Count    int      `json:"count"`
+	IDs      []string `json:"ids"`
+}
+
+// matrixReport is the full grid, with every cell present.
+type matrixReport struct {
+	Urgencies []string     `json:"urgencies"`
+	Cells     []matrixCell `json:"cells"`
+	Total     int          `json:"total"`
+	Warnings  []string     `json:"warnings"`
+}
+
+// cell returns the cell for priority and urgency.
+func (r *matrixReport) cell(priority int, urgency string) *matrixCell {
+	for i := range r.Cells {
+		if r.Cells[i].Priority == priority && r.Cells[i].Urgency == urgency {
+			return &r.Cells[i]
+		}
+	}
+	return nil
+}
+
+// buildMatrix places issues in the grid and flags misprioritized cells.
+// Issues with an out-of-range priority are skipped.
+func buildMatrix(issues []*types.Issue, now time.Time) *matrixReport {
+	r := &matrixReport{Urgencies: matrixUrgencies, Warnings: []string{}}
+	for p := 0; p <= 4; p++ {
+		for _, u := range matrixUrgencies {
+			r.Cells = append(r.Cells, matrixCell{Priority: p, Urgency: u, IDs: []string{}})
+		}
+	}
+	for _, issue := range sortByDue(issues) {
+		c := r.cell(issue.Priority, urgencyOf(issue, now))
+		if c == nil {
+			continue
+		}
+		c.Count++
+		c.IDs = append(c.IDs, issue.ID)
+		r.Total++
+	}
+
+	count := func(priorities []int, urgencies ...string) int {
+		n := 0
+		for _, p := range priorities {
+			for _, u := range urgencies {
+				n += r.cell(p, u).Count
+			}
+		}
+		return n
+	}
+	if n := count([]int{3, 4}, urgencyOverdue, urgencyWeek); n > 0 {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("%d low-priority (P3-P4) %s overdue or due this week", n, pluralIssues(n)))
+	}
+	if n := count([]int{0, 1}, urgencyLater); n > 0 {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("%d high-priority (P0-P1) %s not due for over a month", n, pluralIssues(n)))
+	}
+	return r
+}
+
+func pluralIssues(n int) string {
+	if n == 1 {
+		return "issue is"
+	}
+	return "issues are"
+}
+
+// sortByDue orders issues by due date (undated last), then priority, then ID.
+func sortByDue(issues []*types.Issue) []*types.Issue {
+	out := append([]*types.Issue(nil), issues...)
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if (a.DueAt == nil) != (b.DueAt == nil) {
+			return a.DueAt != nil
+		}
+		if a.DueAt != nil && !a.DueAt.Equal(*b.DueAt) {
+			return a.DueAt.Before(*b.DueAt)
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.ID < b.ID
+	})
+	return out
+}
+
+func runMatrix(cmd *cobra.Command, _ []string) {
+	urgency, _ := cmd.Flags().GetString("urgency")
+	assignee, _ := cmd.Flags().GetString("assignee")
+	labels, _ := cmd.Flags().GetStringSlice("label")
+	ctx := rootCtx
+
+	persistent, notTemplate := false, false
+	filter := types.IssueFilter{
+		ExcludeStatus: []types.Status{types.StatusClosed, types.StatusPinned},
+		Ephemeral:     &persistent,
+		IsTemplate:    &notTemplate,
+		Labels:        labels,
+	}
+	if assignee != "" {
+		filter.Assignee = &assignee
+	}
+	drill := false
+	if cmd.Flags().Changed("priority") {
+		priorityStr, _ := cmd.Flags().GetString("priority")
+		priority, err := validation.ValidatePriority(priorityStr)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		filter.Priority = &priority
+		drill = true
+	}
+	if urgency != "" {
+		urgency = strings.ToLower(urgency)
+		valid := false
+		for _, u := range matrixUrgencies {
+			valid = valid || u == urgency
+		}
+		if !valid {
+			FatalErrorRespectJSON("invalid --urgency %q (valid: %s)", urgency, strings.Join(matrixUrgencies, ", "))
+		}
+		drill = true
+	}
+
+	issues, err := store.SearchIssues(ctx, "", filter)
+	if err != nil {
+		FatalErrorRespectJSON("listing issues: %v", err)
+	}
+	now := time.Now()
+
+	if drill {
+		selected := []*types.Issue{}
+		for _, issue := range sortByDue(issues) {
+			if urgency == "" || urgencyOf(issue, now) == urgency {
+				selected = append(selected, issue)
+			}
+		}
+		if jsonOutput {
+			outputJSON(selected)
+			return
+		}
+		displayMatrixCell(selected, now)
+		return
+	}
+
+	report := buildMatrix(issues, now)
+	if jsonOutput {
+		outputJSON(report)
+		return
+	}
+	displayMatrix(report)
+}
+
+func displayMatrix(r *matrixReport) {
+	if r.Total == 0 {
+		fmt.Printf("\n%s No open issues\n\n", ui.RenderPass("✨"))
+		return
+	}
+	fmt.Printf("\n%s Priority matrix (%d open issues):\n\n", ui.RenderAccent("▦"), r.Total)
+	fmt.Printf("  %-4s", "")
+	for _, u := range r.Urgencies {
+		fmt.Printf("%9s", u)
+	}
+	fmt.Println()
+	for p := 0; p <= 4; p++ {
+		fmt.Printf("  %s  ", ui.RenderPriorityCompact(p))
+		for _, u := range r.Urgencies {
+			c := r.cell(p, u)
+			cell := fmt.Sprintf("%9d", c.Count)
+			switch {
+			case c.Count == 0:
+				cell = ui.RenderMuted(fmt.Sprintf("%9s", "·"))
+			case (p >= 3 && (u == urgencyOverdue || u == urgencyWeek)) || (p <= 1 && u == urgencyLater):
+				cell = ui.RenderWarn(cell)
+			}
+			fmt.Print(cell)
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+	for _, w := range r.Warnings {
+		fmt.Printf("%s %s\n", ui.RenderWarn("!"), w)
+	}
+	fmt.Println(ui.RenderMuted("Drill down with: bd matrix --priority <P> --urgency <bucket>"))
+	fmt.Println()
+}
+
+func displayMatrixCell(issues []*types.Issue, now time.Time) {
+	if len(issues) == 0 {
+		fmt.Println("No issues in this part of the matrix")
+		return
+	}
+	fmt.Printf("\n%d issue(s):\n\n", len(issues))
+	for _, issue := range issues {
+		due := ui.RenderMuted("no due date")
+		if issue.DueAt != nil {
+			due = fmt.Sprintf("due %s", issue.DueAt.Local().Format("2006-01-02"))
+			if issue.DueAt.Before(now) {
+				due = ui.RenderWarn(due)
+			}
+		}
+		fmt.Printf("  %s %s: %s (%s)\n", ui.RenderPriority(issue.Priority), ui.RenderID(issue.ID), issue.Title, due)
+	}
+	fmt.Println()
+}
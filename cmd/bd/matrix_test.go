@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildMatrix(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	due := func(days int) *time.Time { d := now.AddDate(0, 0, days); return &d }
+	issues := []*types.Issue{
+		{ID: "a", Priority: 0, DueAt: due(60)},
+		{ID: "b", Priority: 3, DueAt: due(-1)},
+		{ID: "c", Priority: 4, DueAt: due(3)},
+		{ID: "d", Priority: 3, DueAt: due(-5)},
+		{ID: "e", Priority: 2, DueAt: due(20)},
+		{ID: "f", Priority: 2},
+	}
+
+	r := buildMatrix(issues, now)
+	if r.Total != 6 || len(r.Cells) != 25 {
+		t.Fatalf("total %d, %d cells", r.Total, len(r.Cells))
+	}
+	for _, tt := range []struct {
+		priority int
+		urgency  string
+		ids      []string
+	}{
+		{0, urgencyLater, []string{"a"}},
+		{3, urgencyOverdue, []string{"d", "b"}}, // Most overdue first
+		{4, urgencyWeek, []string{"c"}},
+		{2, urgencyMonth, []string{"e"}},
+		{2, urgencyNone, []string{"f"}},
+		{1, urgencyNone, nil},
+	} {
+		c := r.cell(tt.priority, tt.urgency)
+		if c.Count != len(tt.ids) {
+			t.Errorf("P%d/%s: count %d, want %d", tt.priority, tt.urgency, c.Count, len(tt.ids))
+			continue
+		}
+		for i, id := range tt.ids {
+			if c.IDs[i] != id {
+				t.Errorf("P%d/%s: ids %v, want %v", tt.priority, tt.urgency, c.IDs, tt.ids)
+				break
+			}
+		}
+	}
+	if len(r.Warnings) != 2 {
+		t.Errorf("warnings = %v", r.Warnings)
+	}
+}
+
+func TestUrgencyOf(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *types.Issue { due := now.Add(d); return &types.Issue{DueAt: &due} }
+	day := 24 * time.Hour
+	for _, tt := range []struct {
+		issue *types.Issue
+		want  string
+	}{
+		{&types.Issue{}, urgencyNone},
+		{at(-time.Minute), urgencyOverdue},
+		{at(7 * day), urgencyWeek},
+		{at(7*day + time.Hour), urgencyMonth},
+		{at(31 * day), urgencyLater},
+	} {
+		if got := urgencyOf(tt.issue, now); got != tt.want {
+			t.Errorf("urgencyOf(due %v) = %q, want %q", tt.issue.DueAt, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:     "mcp",
+	GroupID: "advanced",
+	Short:   "Serve beads to AI agents as a Model Context Protocol server",
+	Long: `Serve beads as a Model Context Protocol (MCP) server on stdin and stdout,
+so agents can call tools like create_issue and get_ready_work directly
+instead of running bd and parsing its output.
+
+Tools call the same methods as 'bd rpc', so they go through the same
+validation, and take the same params:
+
+  get_ready_work {filter}            get_blocked_issues {filter}
+  search_issues {filter}             get_issue {id}
+  create_issue {issue}               update_issue {id, updates}
+  close_issue {id, reason}
+  add_dependency {issue_id, depends_on_id, type}
+  remove_dependency {issue_id, depends_on_id}
+  get_dependency_tree {issue_id, max_depth, reverse}
+  add_label {issue_id, label}        remove_label {issue_id, label}
+  add_issue_comment {issue_id, text} get_issue_comments {issue_id}
+  get_statistics
+
+Omitted fields of a new issue default as in 'bd create'. Failures, such as
+an unknown issue or an invalid field, are returned to the agent as tool
+errors. Writes are attributed to the actor (--actor, BD_ACTOR, ...); with
+--readonly, only the read tools are offered.
+
+Register it with an MCP client by command, e.g. for Claude Code:
+  claude mcp add beads -- bd mcp`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		storage := rpc.NewServer(rpc.StorageMethods(store, actor), readonlyMode)
+		server := rpc.NewMCPServer(storage, rpc.StorageTools, "beads", Version)
+		if err := server.Serve(rootCtx, os.Stdin, os.Stdout); err != nil && rootCtx.Err() == nil {
+			FatalError("mcp: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+}
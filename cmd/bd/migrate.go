@@ -23,9 +23,11 @@ var migrateCmd = &cobra.Command{
 Without subcommand, checks and updates database metadata to current version.
 
 Backend migration flags:
-  --to-dolt     Migrate from SQLite to Dolt backend
+  --to-dolt     Migrate from SQLite to Dolt backend, verifying row counts
+                and checksums before switching over
 
 Subcommands:
+  ids         Switch to distributed IDs for federated towns
   issues      Move issues between repositories
   sync        Set up sync.branch workflow for multi-clone setups
 `,
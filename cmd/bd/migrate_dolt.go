@@ -25,23 +25,26 @@ import (
 
 // migrationData holds all data extracted from the source database
 type migrationData struct {
-	issues     []*types.Issue
-	labelsMap  map[string][]string
-	depsMap    map[string][]*types.Dependency
-	eventsMap  map[string][]*types.Event
-	config     map[string]string
-	prefix     string
-	issueCount int
+	issues      []*types.Issue
+	labelsMap   map[string][]string
+	depsMap     map[string][]*types.Dependency
+	eventsMap   map[string][]*types.Event
+	commentsMap map[string][]*types.Comment
+	config      map[string]string
+	metadata    map[string]string
+	prefix      string
+	issueCount  int
 }
 
 // handleToDoltMigration migrates from SQLite to Dolt backend.
 // 1. Finds SQLite .db files in .beads/
 // 2. Creates Dolt database in `.beads/dolt/`
-// 3. Imports all issues, labels, dependencies, events
-// 4. Copies all config values
-// 5. Updates `metadata.json` to use Dolt
+// 3. Imports all issues, labels, dependencies, comments, events
+// 4. Copies all config and metadata values
+// 5. Verifies row counts and checksums against the SQLite database
+// 6. Updates `metadata.json` to use Dolt
 func handleToDoltMigration(dryRun bool, autoYes bool) {
-	ctx := context.Background()
+	ctx := rootCtx
 
 	// Find .beads directory
 	beadsDir := beads.FindBeadsDir()
@@ -114,9 +117,30 @@ func handleToDoltMigration(dryRun bool, autoYes bool) {
 	if importErr != nil {
 		_ = doltStore.Close()
 		_ = os.RemoveAll(doltPath)
+		if ctx.Err() != nil {
+			exitInterrupted(fmt.Sprintf("nothing was migrated (stopped after %d of %d issues); the partial Dolt directory was removed and %s is unchanged",
+				imported, len(data.issues), filepath.Base(sqlitePath)),
+				map[string]interface{}{"imported": 0, "total": len(data.issues), "backup_path": backupPath})
+		}
 		exitWithError("import_failed", importErr.Error(), "partial Dolt directory has been cleaned up")
 	}
 
+	// Verify before switching over, so a lossy migration leaves SQLite in use
+	printProgress("Verifying row counts and checksums...")
+	checks, err := verifyMigration(ctx, doltStore.UnderlyingDB(), data)
+	if err == nil {
+		printMigrationChecks(checks)
+		if failed := failedMigrationChecks(checks); failed != "" {
+			err = fmt.Errorf("migrated data does not match the SQLite database: %s", failed)
+		}
+	}
+	if err != nil {
+		_ = doltStore.Close()
+		_ = os.RemoveAll(doltPath)
+		exitWithError("verification_failed", err.Error(),
+			fmt.Sprintf("the Dolt directory was removed and %s is still in use", filepath.Base(sqlitePath)))
+	}
+
 	// Set sync.mode to dolt-native in the DB.
 	if err := doltStore.SetConfig(ctx, "sync.mode", "dolt-native"); err != nil {
 		printWarning(fmt.Sprintf("failed to set sync.mode in DB: %v", err))
@@ -172,7 +196,7 @@ func handleToDoltMigration(dryRun bool, autoYes bool) {
 	}
 
 	// Final status
-	printFinalStatus("dolt", imported, skipped, backupPath, doltPath, sqlitePath, true)
+	printFinalStatus("dolt", imported, skipped, checks, backupPath, doltPath, sqlitePath, true)
 }
 
 // findSQLiteDB looks for a SQLite .db file in the beads directory.
@@ -401,8 +425,51 @@ func extractFromSQLite(ctx context.Context, dbPath string) (*migrationData, erro
 		}
 	}
 
-	// Assign labels and dependencies to issues
+	// Get comments
+	commentsMap := make(map[string][]*types.Comment)
+	commentRows, err := db.QueryContext(ctx, "SELECT issue_id, COALESCE(author,''), COALESCE(text,''), COALESCE(created_at,'') FROM comments ORDER BY id")
+	if err == nil {
+		defer commentRows.Close()
+		for commentRows.Next() {
+			var comment types.Comment
+			var createdAt string
+			if err := commentRows.Scan(&comment.IssueID, &comment.Author, &comment.Text, &createdAt); err == nil {
+				if t := parseNullTime(createdAt); t != nil {
+					comment.CreatedAt = *t
+				}
+				commentsMap[comment.IssueID] = append(commentsMap[comment.IssueID], &comment)
+			}
+		}
+	}
+
+	// Get metadata, both the table and the per-issue column (older databases
+	// may lack either)
+	metadata := make(map[string]string)
+	metadataRows, err := db.QueryContext(ctx, "SELECT key, value FROM metadata")
+	if err == nil {
+		defer metadataRows.Close()
+		for metadataRows.Next() {
+			var k, v string
+			if err := metadataRows.Scan(&k, &v); err == nil {
+				metadata[k] = v
+			}
+		}
+	}
+	issueMetadata := make(map[string]json.RawMessage)
+	issueMetadataRows, err := db.QueryContext(ctx, "SELECT id, metadata FROM issues WHERE metadata IS NOT NULL AND metadata NOT IN ('', '{}')")
+	if err == nil {
+		defer issueMetadataRows.Close()
+		for issueMetadataRows.Next() {
+			var id, raw string
+			if err := issueMetadataRows.Scan(&id, &raw); err == nil && json.Valid([]byte(raw)) {
+				issueMetadata[id] = json.RawMessage(raw)
+			}
+		}
+	}
+
+	// Assign labels, dependencies and metadata to issues
 	for _, issue := range issues {
+		issue.Metadata = issueMetadata[issue.ID]
 		if labels, ok := labelsMap[issue.ID]; ok {
 			issue.Labels = labels
 		}
@@ -412,13 +479,15 @@ func extractFromSQLite(ctx context.Context, dbPath string) (*migrationData, erro
 	}
 
 	return &migrationData{
-		issues:     issues,
-		labelsMap:  labelsMap,
-		depsMap:    depsMap,
-		eventsMap:  eventsMap,
-		config:     config,
-		prefix:     prefix,
-		issueCount: len(issues),
+		issues:      issues,
+		labelsMap:   labelsMap,
+		depsMap:     depsMap,
+		eventsMap:   eventsMap,
+		commentsMap: commentsMap,
+		config:      config,
+		metadata:    metadata,
+		prefix:      prefix,
+		issueCount:  len(issues),
 	}, nil
 }
 
@@ -430,6 +499,15 @@ func importToDolt(ctx context.Context, store *dolt.DoltStore, data *migrationDat
 			return 0, 0, fmt.Errorf("failed to set config %s: %w", key, err)
 		}
 	}
+	// Metadata the new database already set for itself (e.g. its version) wins
+	for key, value := range data.metadata {
+		if existing, _ := store.GetMetadata(ctx, key); existing != "" {
+			continue
+		}
+		if err := store.SetMetadata(ctx, key, value); err != nil {
+			return 0, 0, fmt.Errorf("failed to set metadata %s: %w", key, err)
+		}
+	}
 
 	tx, err := store.UnderlyingDB().BeginTx(ctx, nil)
 	if err != nil {
@@ -440,12 +518,14 @@ func importToDolt(ctx context.Context, store *dolt.DoltStore, data *migrationDat
 	imported := 0
 	skipped := 0
 	seenIDs := make(map[string]bool)
-	total := len(data.issues)
+	progress := newProgress("  Importing issues", len(data.issues))
+	defer progress.Done()
 
-	for i, issue := range data.issues {
-		if !jsonOutput && total > 100 && (i+1)%100 == 0 {
-			fmt.Printf("  Importing issues: %d/%d\r", i+1, total)
+	for _, issue := range data.issues {
+		if err := ctx.Err(); err != nil {
+			return imported, skipped, err
 		}
+		progress.Add(1)
 
 		if seenIDs[issue.ID] {
 			skipped++
@@ -502,6 +582,12 @@ func importToDolt(ctx context.Context, store *dolt.DoltStore, data *migrationDat
 			return imported, skipped, fmt.Errorf("failed to insert issue %s: %w", issue.ID, err)
 		}
 
+		if len(issue.Metadata) > 0 {
+			if _, err := tx.ExecContext(ctx, `UPDATE issues SET metadata = ? WHERE id = ?`, string(issue.Metadata), issue.ID); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to set metadata for issue %s: %v\n", issue.ID, err)
+			}
+		}
+
 		// Insert labels
 		for _, label := range issue.Labels {
 			if _, err := tx.ExecContext(ctx, `INSERT INTO labels (issue_id, label) VALUES (?, ?)`, issue.ID, label); err != nil {
@@ -512,13 +598,14 @@ func importToDolt(ctx context.Context, store *dolt.DoltStore, data *migrationDat
 		imported++
 	}
 
-	if !jsonOutput && total > 100 {
-		fmt.Printf("  Importing issues: %d/%d\n", total, total)
-	}
+	progress.Done()
 
 	// Import dependencies
 	printProgress("Importing dependencies...")
 	for _, issue := range data.issues {
+		if err := ctx.Err(); err != nil {
+			return imported, skipped, err
+		}
 		for _, dep := range issue.Dependencies {
 			var exists int
 			if err := tx.QueryRowContext(ctx, "SELECT 1 FROM issues WHERE id = ?", dep.DependsOnID).Scan(&exists); err != nil {
@@ -535,10 +622,43 @@ func importToDolt(ctx context.Context, store *dolt.DoltStore, data *migrationDat
 		}
 	}
 
-	// Import events (includes comments)
+	// Import comments
+	printProgress("Importing comments...")
+	for issueID, comments := range data.commentsMap {
+		if err := ctx.Err(); err != nil {
+			return imported, skipped, err
+		}
+		if !seenIDs[issueID] {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %d comments on %s: issue not found\n", len(comments), issueID)
+			continue
+		}
+		for _, comment := range comments {
+			createdAt := comment.CreatedAt
+			if createdAt.IsZero() {
+				createdAt = time.Now().UTC()
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO comments (issue_id, author, text, created_at) VALUES (?, ?, ?, ?)
+			`, issueID, comment.Author, comment.Text, createdAt); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to insert comment on %s: %v\n", issueID, err)
+			}
+		}
+	}
+
+	// Import events (includes comment events)
 	printProgress("Importing events...")
+	totalEvents := 0
+	for _, events := range data.eventsMap {
+		totalEvents += len(events)
+	}
+	progress = newProgress("  Importing events", totalEvents)
+	defer progress.Done()
 	eventCount := 0
 	for issueID, events := range data.eventsMap {
+		if err := ctx.Err(); err != nil {
+			return imported, skipped, err
+		}
+		progress.Add(len(events))
 		for _, event := range events {
 			_, err := tx.ExecContext(ctx, `
 				INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment, created_at)
@@ -551,6 +671,7 @@ func importToDolt(ctx context.Context, store *dolt.DoltStore, data *migrationDat
 			}
 		}
 	}
+	progress.Done()
 	if !jsonOutput {
 		fmt.Printf("  Imported %d events\n", eventCount)
 	}
@@ -623,8 +744,10 @@ func printMigrationPlan(title, source, target string, data *migrationData) {
 	for _, events := range data.eventsMap {
 		eventCount += len(events)
 	}
+	fmt.Printf("Comments to migrate: %d\n", countComments(data))
 	fmt.Printf("Events to migrate: %d\n", eventCount)
 	fmt.Printf("Config keys: %d\n", len(data.config))
+	fmt.Printf("Metadata keys: %d\n", len(data.metadata))
 
 	if data.prefix != "" {
 		fmt.Printf("Issue prefix: %s\n", data.prefix)
@@ -640,14 +763,16 @@ func printDryRun(source, target string, data *migrationData, withBackup bool) {
 
 	if jsonOutput {
 		result := map[string]interface{}{
-			"dry_run":      true,
-			"source":       source,
-			"target":       target,
-			"issue_count":  data.issueCount,
-			"event_count":  eventCount,
-			"config_keys":  len(data.config),
-			"prefix":       data.prefix,
-			"would_backup": withBackup,
+			"dry_run":       true,
+			"source":        source,
+			"target":        target,
+			"issue_count":   data.issueCount,
+			"event_count":   eventCount,
+			"comment_count": countComments(data),
+			"config_keys":   len(data.config),
+			"metadata_keys": len(data.metadata),
+			"prefix":        data.prefix,
+			"would_backup":  withBackup,
 		}
 		outputJSON(result)
 	} else {
@@ -662,9 +787,11 @@ func printDryRun(source, target string, data *migrationData, withBackup bool) {
 		step++
 		fmt.Printf("  %d. Import %d issues with labels and dependencies\n", step, data.issueCount)
 		step++
-		fmt.Printf("  %d. Import %d events (history/comments)\n", step, eventCount)
+		fmt.Printf("  %d. Import %d comments and %d events (history)\n", step, countComments(data), eventCount)
+		step++
+		fmt.Printf("  %d. Copy %d config and %d metadata values\n", step, len(data.config), len(data.metadata))
 		step++
-		fmt.Printf("  %d. Copy %d config values\n", step, len(data.config))
+		fmt.Printf("  %d. Verify row counts and checksums\n", step)
 		step++
 		fmt.Printf("  %d. Update metadata.json\n", step)
 	}
@@ -688,13 +815,14 @@ func confirmBackendMigration(from, to string, withBackup bool) bool {
 	return strings.ToLower(response) == "y" || strings.ToLower(response) == "yes"
 }
 
-func printFinalStatus(backend string, imported, skipped int, backupPath, newPath, oldPath string, toDolt bool) {
+func printFinalStatus(backend string, imported, skipped int, checks []migrationCheck, backupPath, newPath, oldPath string, toDolt bool) {
 	if jsonOutput {
 		result := map[string]interface{}{
 			"status":          "success",
 			"backend":         backend,
 			"issues_imported": imported,
 			"issues_skipped":  skipped,
+			"verification":    checks,
 		}
 		if backupPath != "" {
 			result["backup_path"] = backupPath
@@ -721,6 +849,14 @@ func printFinalStatus(backend string, imported, skipped int, backupPath, newPath
 	}
 }
 
+func countComments(data *migrationData) int {
+	n := 0
+	for _, comments := range data.commentsMap {
+		n += len(comments)
+	}
+	return n
+}
+
 // Helper functions for nullable values
 
 func nullableString(s string) interface{} {
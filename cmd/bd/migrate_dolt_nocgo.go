@@ -8,19 +8,18 @@ import (
 )
 
 // handleToDoltMigration is a stub for non-cgo builds.
-// Dolt requires CGO, so this migration is not available.
+// Reading the legacy SQLite database requires CGO, so this migration is not
+// available. (Dolt itself does not: bd talks to the server in pure Go.)
 func handleToDoltMigration(dryRun bool, autoYes bool) {
 	if jsonOutput {
 		outputJSON(map[string]interface{}{
-			"error":   "dolt_not_available",
-			"message": "Dolt backend requires CGO. This binary was built without CGO support.",
+			"error":   "cgo_required",
+			"message": "Migrating a SQLite database requires CGO. This binary was built without CGO support.",
+			"hint":    cgoHint,
 		})
-	} else {
-		fmt.Fprintf(os.Stderr, "Error: Dolt backend requires CGO\n")
-		fmt.Fprintf(os.Stderr, "This binary was built without CGO support.\n")
-		fmt.Fprintf(os.Stderr, "To use Dolt, rebuild with: CGO_ENABLED=1 go build\n")
+		os.Exit(1)
 	}
-	os.Exit(1)
+	FatalErrorWithHint("migrating a SQLite database requires CGO; this binary was built without it", cgoHint)
 }
 
 // handleToSQLiteMigration is a stub for non-cgo builds.
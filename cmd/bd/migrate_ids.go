@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/idgen"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"golang.org/x/term"
+)
+
+var migrateIDsCmd = &cobra.Command{
+	Use:   "ids",
+	Short: "Switch to distributed IDs that federated towns can't collide on",
+	Long: `Switch the database to distributed IDs, so federated towns creating issues
+offline never allocate the same ID.
+
+With distributed IDs, each town sets a two-character tag in its config.yaml
+(id.town, a letter followed by a letter or digit, unique among the towns),
+and every ID it allocates starts with the tag: bd-k3x9a for an issue,
+bd-k3x9a.k31 for a child. Towns never allocate in each other's namespace.
+
+This migration moves existing IDs into the reserved 00 namespace
+(bd-a3f becomes bd-00a3f, bd-a3f.2 becomes bd-00a3f.2), rewrites references
+to them in dependencies and in issue titles, descriptions, design, acceptance
+criteria, and notes, and marks the database as using distributed IDs: from
+then on, creating issues requires id.town. Comments and history keep the
+IDs they were written with. IDs with a longer prefix (bd-mol-..., wisps)
+are left alone.
+
+Run it once, on one town, after every town has synced; the other towns pull
+the result and set their own id.town before creating issues.
+
+Examples:
+  bd migrate ids --dry-run   # Show the renames
+  bd migrate ids --yes`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, _ []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+		if !dryRun {
+			CheckReadonly("migrate ids")
+		}
+		if err := ensureStoreActive(); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		ctx := rootCtx
+
+		if scheme, _ := store.GetConfig(ctx, "id_scheme"); scheme == idgen.SchemeDistributed {
+			if jsonOutput {
+				outputJSON(map[string]interface{}{"already_migrated": true, "renamed": 0})
+				return
+			}
+			fmt.Println("This database already uses distributed IDs")
+			return
+		}
+		prefix, err := store.GetConfig(ctx, "issue_prefix")
+		if err != nil || prefix == "" {
+			FatalErrorRespectJSON("issue_prefix config is missing (run 'bd init --prefix <prefix>' first)")
+		}
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			FatalErrorRespectJSON("listing issues: %v", err)
+		}
+		rewrites, renames := planIDMigration(issues, prefix)
+
+		if dryRun || !jsonOutput {
+			printIDMigrationPlan(rewrites, renames, dryRun)
+		}
+		if dryRun {
+			if jsonOutput {
+				outputJSON(map[string]interface{}{"dry_run": true, "renamed": len(renames), "renames": renames})
+			}
+			return
+		}
+		if !yes {
+			if jsonOutput || !term.IsTerminal(int(os.Stdin.Fd())) {
+				FatalErrorWithHint("confirmation needs a terminal", "use --yes to migrate without confirming, or --dry-run to preview")
+			}
+			fmt.Printf("\nRename %d issue(s) to distributed IDs? [y/N] ", len(renames))
+			response, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if strings.ToLower(strings.TrimSpace(response)) != "y" {
+				fmt.Println("Canceled; nothing was changed.")
+				return
+			}
+		}
+
+		if err := store.MigrateIssueIDs(ctx, rewrites, getActorWithGit()); err != nil {
+			FatalErrorRespectJSON("migrating IDs: %v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"renamed": len(renames), "renames": renames})
+			return
+		}
+		fmt.Printf("%s Renamed %d issue(s); this database now uses distributed IDs\n", ui.RenderPass("✓"), len(renames))
+		fmt.Println("  Set id.town in each town's config.yaml (e.g. id.town: k3) before creating issues.")
+	},
+}
+
+func init() {
+	migrateIDsCmd.Flags().Bool("dry-run", false, "Show the renames without changing anything")
+	migrateIDsCmd.Flags().Bool("yes", false, "Migrate without asking for confirmation")
+	migrateCmd.AddCommand(migrateIDsCmd)
+}
+
+// planIDMigration plans moving legacy IDs with prefix under
+// idgen.LegacyTownTag. It returns the issues to update (renamed, or with
+// references to renamed issues in their text) and the renames, old ID to
+// new.
+func planIDMigration(issues []*types.Issue, prefix string) ([]dolt.IssueRewrite, map[string]string) {
+	renames := make(map[string]string)
+	for _, issue := range issues {
+		if newID, ok := idgen.MigrateLegacyID(issue.ID, prefix); ok {
+			renames[issue.ID] = newID
+		}
+	}
+
+	pattern := idgen.LegacyIDRegex(prefix)
+	rewrite := func(text string) string {
+		return pattern.ReplaceAllStringFunc(text, func(id string) string {
+			if newID, ok := renames[id]; ok {
+				return newID
+			}
+			return id
+		})
+	}
+
+	var rewrites []dolt.IssueRewrite
+	for _, issue := range issues {
+		updated := *issue
+		if newID, ok := renames[issue.ID]; ok {
+			updated.ID = newID
+		}
+		updated.Title = rewrite(issue.Title)
+		updated.Description = rewrite(issue.Description)
+		updated.Design = rewrite(issue.Design)
+		updated.AcceptanceCriteria = rewrite(issue.AcceptanceCriteria)
+		updated.Notes = rewrite(issue.Notes)
+		if updated.ID != issue.ID || updated.Title != issue.Title || updated.Description != issue.Description ||
+			updated.Design != issue.Design || updated.AcceptanceCriteria != issue.AcceptanceCriteria || updated.Notes != issue.Notes {
+			rewrites = append(rewrites, dolt.IssueRewrite{OldID: issue.ID, Issue: &updated})
+		}
+	}
+	return rewrites, renames
+}
+
+// printIDMigrationPlan shows the first renames of an ID migration.
+func printIDMigrationPlan(rewrites []dolt.IssueRewrite, renames map[string]string, dryRun bool) {
+	if dryRun {
+		fmt.Printf("DRY RUN: would rename %d issue(s) and rewrite references in %d\n", len(renames), len(rewrites)-len(renames))
+	} else {
+		fmt.Printf("Renaming %d issue(s) and rewriting references in %d\n", len(renames), len(rewrites)-len(renames))
+	}
+	shown := 0
+	for _, r := range rewrites {
+		if r.Issue.ID == r.OldID {
+			continue
+		}
+		if shown == 10 {
+			fmt.Printf("  ... and %d more\n", len(renames)-shown)
+			break
+		}
+		fmt.Printf("  %s -> %s\n", ui.RenderWarn(r.OldID), ui.RenderAccent(r.Issue.ID))
+		shown++
+	}
+}
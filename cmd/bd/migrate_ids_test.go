@@ -0,0 +1,36 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPlanIDMigration(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "bd-a3f", Title: "Parent"},
+		{ID: "bd-a3f.1", Title: "Child", Description: "Part of bd-a3f, see bd-a3f.1. Not bd-a3fx or gt-a3f."},
+		{ID: "bd-mol-x1", Title: "Molecule", Notes: "Spawned from bd-a3f.1"},
+		{ID: "bd-mol-x2", Title: "Unrelated"},
+	}
+	rewrites, renames := planIDMigration(issues, "bd")
+
+	wantRenames := map[string]string{"bd-a3f": "bd-00a3f", "bd-a3f.1": "bd-00a3f.1"}
+	if !reflect.DeepEqual(renames, wantRenames) {
+		t.Errorf("renames = %v, want %v", renames, wantRenames)
+	}
+	if len(rewrites) != 3 {
+		t.Fatalf("got %d rewrites, want 3 (two renames, one reference): %+v", len(rewrites), rewrites)
+	}
+	child := rewrites[1].Issue
+	if want := "Part of bd-00a3f, see bd-00a3f.1. Not bd-a3fx or gt-a3f."; child.Description != want {
+		t.Errorf("description = %q, want %q", child.Description, want)
+	}
+	if mol := rewrites[2]; mol.OldID != "bd-mol-x1" || mol.Issue.ID != "bd-mol-x1" || mol.Issue.Notes != "Spawned from bd-00a3f.1" {
+		t.Errorf("reference rewrite = %s -> %+v", mol.OldID, mol.Issue)
+	}
+	if issues[1].Description != "Part of bd-a3f, see bd-a3f.1. Not bd-a3fx or gt-a3f." {
+		t.Error("planIDMigration modified its input")
+	}
+}
@@ -0,0 +1,196 @@
+//go:build cgo
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// migrationCheck compares one table after a backend migration: the rows the
+// source should have produced against the rows the target holds. Checksums
+// cover content, not timestamps, whose precision differs between backends.
+type migrationCheck struct {
+	Table          string `json:"table"`
+	SourceRows     int    `json:"source_rows"`
+	TargetRows     int    `json:"target_rows"`
+	SourceChecksum string `json:"source_checksum"`
+	TargetChecksum string `json:"target_checksum"`
+}
+
+func (c migrationCheck) ok() bool {
+	return c.SourceRows == c.TargetRows && c.SourceChecksum == c.TargetChecksum
+}
+
+// migrationVerifyQueries read each verified table back from Dolt, with
+// columns in the order migrationRows uses for the source.
+var migrationVerifyQueries = []struct{ table, query string }{
+	{"issues", `SELECT id, title, COALESCE(description,''), COALESCE(design,''), COALESCE(acceptance_criteria,''),
+		COALESCE(notes,''), status, priority, issue_type, COALESCE(assignee,'') FROM issues`},
+	{"labels", `SELECT issue_id, label FROM labels`},
+	{"dependencies", `SELECT issue_id, depends_on_id, type FROM dependencies`},
+	{"comments", `SELECT issue_id, author, text FROM comments`},
+	{"events", `SELECT issue_id, event_type, actor, COALESCE(comment,'') FROM events`},
+}
+
+// verifyMigration compares the migrated Dolt database with the data read
+// from the source, table by table.
+func verifyMigration(ctx context.Context, db *sql.DB, data *migrationData) ([]migrationCheck, error) {
+	source := migrationRows(data)
+	checks := make([]migrationCheck, 0, len(migrationVerifyQueries))
+	for _, q := range migrationVerifyQueries {
+		target, err := queryMigrationRows(ctx, db, q.query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read back %s: %w", q.table, err)
+		}
+		checks = append(checks, migrationCheck{
+			Table:          q.table,
+			SourceRows:     len(source[q.table]),
+			TargetRows:     len(target),
+			SourceChecksum: migrationDigest(source[q.table]),
+			TargetChecksum: migrationDigest(target),
+		})
+	}
+	return checks, nil
+}
+
+// migrationRows renders the rows importToDolt writes for data, table by
+// table: duplicate issues, labels and dependencies are dropped, as are
+// dependencies on missing issues and comments and events on them.
+func migrationRows(data *migrationData) map[string][]string {
+	rows := make(map[string][]string)
+	ids := make(map[string]bool)
+	for _, issue := range data.issues {
+		if ids[issue.ID] {
+			continue
+		}
+		ids[issue.ID] = true
+		rows["issues"] = append(rows["issues"], migrationRow(issue.ID, issue.Title, issue.Description,
+			issue.Design, issue.AcceptanceCriteria, issue.Notes, string(issue.Status),
+			strconv.Itoa(issue.Priority), string(issue.IssueType), issue.Assignee))
+		seen := make(map[string]bool)
+		for _, label := range issue.Labels {
+			if !seen[label] {
+				seen[label] = true
+				rows["labels"] = append(rows["labels"], migrationRow(issue.ID, label))
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, issue := range data.issues {
+		for _, dep := range issue.Dependencies {
+			key := migrationRow(dep.IssueID, dep.DependsOnID)
+			if !ids[dep.DependsOnID] || seen[key] {
+				continue
+			}
+			seen[key] = true
+			rows["dependencies"] = append(rows["dependencies"], migrationRow(dep.IssueID, dep.DependsOnID, string(dep.Type)))
+		}
+	}
+	for issueID, comments := range data.commentsMap {
+		for _, c := range comments {
+			if ids[issueID] {
+				rows["comments"] = append(rows["comments"], migrationRow(issueID, c.Author, c.Text))
+			}
+		}
+	}
+	for issueID, events := range data.eventsMap {
+		for _, e := range events {
+			if ids[issueID] {
+				comment := ""
+				if e.Comment != nil {
+					comment = *e.Comment
+				}
+				rows["events"] = append(rows["events"], migrationRow(issueID, string(e.EventType), e.Actor, comment))
+			}
+		}
+	}
+	return rows
+}
+
+func queryMigrationRows(ctx context.Context, db *sql.DB, query string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []string
+	values := make([]sql.NullString, len(cols))
+	dest := make([]interface{}, len(cols))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		fields := make([]string, len(values))
+		for i, v := range values {
+			fields[i] = v.String
+		}
+		out = append(out, migrationRow(fields...))
+	}
+	return out, rows.Err()
+}
+
+// migrationRow joins a row's fields with the ASCII unit separator.
+func migrationRow(fields ...string) string {
+	return strings.Join(fields, "\x1f")
+}
+
+// migrationDigest checksums rows regardless of their order.
+func migrationDigest(rows []string) string {
+	sorted := slices.Clone(rows)
+	slices.Sort(sorted)
+	h := sha256.New()
+	for _, row := range sorted {
+		h.Write([]byte(row))
+		h.Write([]byte{'\x1e'})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// failedMigrationChecks describes the tables that don't match, or returns
+// "" if all do.
+func failedMigrationChecks(checks []migrationCheck) string {
+	var failed []string
+	for _, c := range checks {
+		if c.ok() {
+			continue
+		}
+		if c.SourceRows != c.TargetRows {
+			failed = append(failed, fmt.Sprintf("%s: %d rows in source, %d migrated", c.Table, c.SourceRows, c.TargetRows))
+		} else {
+			failed = append(failed, fmt.Sprintf("%s: checksum %s in source, %s migrated", c.Table, c.SourceChecksum, c.TargetChecksum))
+		}
+	}
+	return strings.Join(failed, "; ")
+}
+
+func printMigrationChecks(checks []migrationCheck) {
+	if jsonOutput {
+		return
+	}
+	fmt.Printf("  %-14s %8s %8s  %s\n", "table", "source", "dolt", "checksum")
+	for _, c := range checks {
+		mark := ui.RenderPass("✓")
+		if !c.ok() {
+			mark = ui.RenderFail("✗")
+		}
+		fmt.Printf("  %-14s %8d %8d  %s %s\n", c.Table, c.SourceRows, c.TargetRows, c.TargetChecksum, mark)
+	}
+}
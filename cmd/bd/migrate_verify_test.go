@@ -0,0 +1,66 @@
+//go:build cgo
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestMigrationRows(t *testing.T) {
+	note := "looks good"
+	a := &types.Issue{ID: "bd-1", Title: "A", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask,
+		Labels: []string{"x", "x", "y"},
+		Dependencies: []*types.Dependency{
+			{IssueID: "bd-1", DependsOnID: "bd-2", Type: types.DepBlocks},
+			{IssueID: "bd-1", DependsOnID: "bd-missing", Type: types.DepBlocks},
+		}}
+	b := &types.Issue{ID: "bd-2", Title: "B", Status: types.StatusClosed, Priority: 2, IssueType: types.TypeBug}
+	data := &migrationData{
+		issues: []*types.Issue{a, b, a}, // duplicate IDs are imported once
+		commentsMap: map[string][]*types.Comment{
+			"bd-1":    {{Author: "ann", Text: "hi"}},
+			"bd-gone": {{Author: "bob", Text: "orphan"}},
+		},
+		eventsMap: map[string][]*types.Event{
+			"bd-2": {{EventType: types.EventCommented, Actor: "ann", Comment: &note}},
+		},
+	}
+
+	rows := migrationRows(data)
+	for table, want := range map[string]int{"issues": 2, "labels": 2, "dependencies": 1, "comments": 1, "events": 1} {
+		if got := len(rows[table]); got != want {
+			t.Errorf("%s: %d rows, want %d: %q", table, got, want, rows[table])
+		}
+	}
+	if got := rows["events"][0]; got != migrationRow("bd-2", string(types.EventCommented), "ann", note) {
+		t.Errorf("event row = %q", got)
+	}
+}
+
+func TestMigrationChecks(t *testing.T) {
+	rows := []string{migrationRow("bd-1", "A"), migrationRow("bd-2", "B")}
+	reversed := []string{rows[1], rows[0]}
+	if migrationDigest(rows) != migrationDigest(reversed) {
+		t.Error("digest depends on row order")
+	}
+	if migrationDigest(rows) == migrationDigest([]string{rows[0], migrationRow("bd-2", "b")}) {
+		t.Error("digest ignores content")
+	}
+
+	checks := []migrationCheck{
+		{Table: "issues", SourceRows: 2, TargetRows: 2, SourceChecksum: "aa", TargetChecksum: "aa"},
+		{Table: "labels", SourceRows: 3, TargetRows: 2, SourceChecksum: "bb", TargetChecksum: "cc"},
+		{Table: "comments", SourceRows: 1, TargetRows: 1, SourceChecksum: "dd", TargetChecksum: "ee"},
+	}
+	failed := failedMigrationChecks(checks)
+	if strings.Contains(failed, "issues") || !strings.Contains(failed, "labels: 3 rows in source, 2 migrated") ||
+		!strings.Contains(failed, "comments: checksum dd in source, ee migrated") {
+		t.Errorf("failedMigrationChecks = %q", failed)
+	}
+	if failed := failedMigrationChecks(checks[:1]); failed != "" {
+		t.Errorf("failedMigrationChecks(matching) = %q, want empty", failed)
+	}
+}
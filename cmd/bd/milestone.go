@@ -0,0 +1,404 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// milestoneLabelPrefix prefixes the label that attaches an issue to a
+// milestone, as in "milestone:sprint-12".
+const milestoneLabelPrefix = "milestone:"
+
+var milestoneCmd = &cobra.Command{
+	Use:     "milestone",
+	GroupID: "issues",
+	Short:   "Group issues into sprints or releases with a due date",
+	Long: `Milestones are sprints or releases with an optional due date. Issues are
+attached with 'bd milestone add', which records a milestone:<name> label
+(an issue belongs to at most one milestone), so 'bd list --milestone' and
+'bd ready --milestone' narrow to a milestone's work.
+
+'bd milestone status' shows progress and per-day burndown: how many of the
+milestone's issues were open and closed at the end of each day since it
+was created.
+
+Milestones are stored in the database, so everyone sharing it shares them.
+
+Examples:
+  bd milestone create sprint-12 --title "Sprint 12" --due 2026-10-30
+  bd milestone add sprint-12 bd-12 bd-14
+  bd ready --milestone sprint-12
+  bd milestone status sprint-12
+  bd milestone close sprint-12`,
+}
+
+var milestoneCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a milestone",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("milestone create")
+		title, _ := cmd.Flags().GetString("title")
+		description, _ := cmd.Flags().GetString("description")
+		dueStr, _ := cmd.Flags().GetString("due")
+		name, err := parseMilestoneName(args[0])
+		if err != nil {
+			FatalErrorCode(exitValidation, "%v", err)
+		}
+
+		m := &types.Milestone{Name: name, Title: strings.TrimSpace(title), Description: description, CreatedBy: actor}
+		if dueStr != "" {
+			due, err := parseTimeFlag(dueStr)
+			if err != nil {
+				FatalErrorCode(exitValidation, "invalid --due: %v", err)
+			}
+			m.DueAt = &due
+		}
+		if err := store.CreateMilestone(rootCtx, m); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(m)
+			return
+		}
+		fmt.Printf("%s Created milestone %s%s\n", ui.RenderPass("✓"), m.Name, formatMilestoneDue(m))
+	},
+}
+
+var milestoneListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List milestones and their progress",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+		ctx := rootCtx
+		milestones, err := store.GetMilestones(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		rows := []milestoneProgress{}
+		for _, m := range milestones {
+			if !all && m.Status == types.MilestoneClosed {
+				continue
+			}
+			issues, err := milestoneIssues(ctx, m.Name)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			rows = append(rows, progressOf(m, issues))
+		}
+		if jsonOutput {
+			outputJSON(rows)
+			return
+		}
+		if len(rows) == 0 {
+			fmt.Println("No milestones (create one with 'bd milestone create')")
+			return
+		}
+		for _, p := range rows {
+			line := fmt.Sprintf("%s  %d/%d closed%s", ui.RenderBold(p.Name), p.Closed, p.Total, formatMilestoneDue(p.Milestone))
+			if p.Title != "" {
+				line += " " + ui.RenderMuted(p.Title)
+			}
+			if p.Status == types.MilestoneClosed {
+				line += " " + ui.RenderMuted("(closed)")
+			}
+			fmt.Println(line)
+		}
+	},
+}
+
+var milestoneCloseCmd = &cobra.Command{
+	Use:   "close <name>",
+	Short: "Close a milestone",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("milestone close")
+		force, _ := cmd.Flags().GetBool("force")
+		ctx := rootCtx
+		m := resolveMilestone(ctx, args[0])
+		issues, err := milestoneIssues(ctx, m.Name)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if p := progressOf(m, issues); p.Open > 0 && !force {
+			FatalErrorWithHint(fmt.Sprintf("milestone %s has %d open issues", m.Name, p.Open),
+				fmt.Sprintf("list them with: bd list --milestone %s, or close anyway with --force", m.Name))
+		}
+		if _, err := store.CloseMilestone(ctx, m.Name); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]string{"closed": m.Name})
+			return
+		}
+		fmt.Printf("%s Closed milestone %s\n", ui.RenderPass("✓"), m.Name)
+	},
+}
+
+var milestoneAddCmd = &cobra.Command{
+	Use:   "add <name> <issue-id>...",
+	Short: "Attach issues to a milestone",
+	Long:  `Attach issues to a milestone, moving them out of any other milestone.`,
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("milestone add")
+		ctx := rootCtx
+		m := resolveMilestone(ctx, args[0])
+		ids := resolveMilestoneIssueIDs(ctx, args[1:])
+		for _, id := range ids {
+			if err := setMilestone(ctx, store, id, m.Name); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"milestone": m.Name, "added": ids})
+			return
+		}
+		fmt.Printf("%s Added %d issue(s) to %s\n", ui.RenderPass("✓"), len(ids), m.Name)
+	},
+}
+
+var milestoneRemoveCmd = &cobra.Command{
+	Use:   "remove <name> <issue-id>...",
+	Short: "Detach issues from a milestone",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("milestone remove")
+		ctx := rootCtx
+		m := resolveMilestone(ctx, args[0])
+		ids := resolveMilestoneIssueIDs(ctx, args[1:])
+		for _, id := range ids {
+			if err := store.RemoveLabel(ctx, id, milestoneLabelPrefix+m.Name, actor); err != nil {
+				FatalErrorRespectJSON("removing %s from %s: %v", id, m.Name, err)
+			}
+		}
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"milestone": m.Name, "removed": ids})
+			return
+		}
+		fmt.Printf("%s Removed %d issue(s) from %s\n", ui.RenderPass("✓"), len(ids), m.Name)
+	},
+}
+
+var milestoneStatusCmd = &cobra.Command{
+	Use:   "status <name>",
+	Short: "Show a milestone's progress and burndown",
+	Long: `Show a milestone's progress and burndown: for each day from the
+milestone's creation to today (or its close), how many of its issues were
+open and how many closed at the end of that day. Issues count from the day
+they were created.
+
+If the milestone has a baseline ('bd milestone baseline'), the committed
+issues are compared against it: done, pending, slipped, dropped, and added
+since.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		m := resolveMilestone(ctx, args[0])
+		issues, err := milestoneIssues(ctx, m.Name)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		now := time.Now()
+		status := buildMilestoneStatus(m, issues, now)
+		if status.Baseline, err = milestoneBaselineDiffOf(ctx, m, issues, now); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(status)
+			return
+		}
+		displayMilestoneStatus(status)
+	},
+}
+
+func init() {
+	milestoneCreateCmd.Flags().String("title", "", "Human-readable title, e.g. \"Sprint 12\"")
+	milestoneCreateCmd.Flags().String("description", "", "Description")
+	milestoneCreateCmd.Flags().String("due", "", "Due date (e.g. 2026-10-30, +2w, next friday)")
+	milestoneListCmd.Flags().Bool("all", false, "Include closed milestones")
+	milestoneCloseCmd.Flags().Bool("force", false, "Close even if the milestone has open issues")
+	milestoneAddCmd.ValidArgsFunction = issueIDCompletion
+	milestoneRemoveCmd.ValidArgsFunction = issueIDCompletion
+	milestoneCmd.AddCommand(milestoneCreateCmd, milestoneListCmd, milestoneCloseCmd,
+		milestoneAddCmd, milestoneRemoveCmd, milestoneStatusCmd)
+	rootCmd.AddCommand(milestoneCmd)
+}
+
+// parseMilestoneName checks that a milestone name can be used in a label
+// and a --label flag: no whitespace or commas.
+func parseMilestoneName(s string) (string, error) {
+	name := strings.TrimSpace(s)
+	if name == "" || strings.ContainsAny(name, " \t\n,") {
+		return "", fmt.Errorf("invalid milestone name %q: must be non-empty, without spaces or commas", s)
+	}
+	return name, nil
+}
+
+// resolveMilestone returns the named milestone, exiting if there is none.
+func resolveMilestone(ctx context.Context, name string) *types.Milestone {
+	m, err := store.GetMilestone(ctx, name)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if m == nil {
+		FatalErrorWithHint(fmt.Sprintf("no milestone named %q", name), "list milestones with: bd milestone list")
+	}
+	return m
+}
+
+func resolveMilestoneIssueIDs(ctx context.Context, args []string) []string {
+	ids := make([]string, 0, len(args))
+	for _, arg := range args {
+		id, err := utils.ResolvePartialID(ctx, store, arg)
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", arg, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// milestoneIssues returns the issues attached to a milestone.
+func milestoneIssues(ctx context.Context, name string) ([]*types.Issue, error) {
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Labels: []string{milestoneLabelPrefix + name}})
+	if err != nil {
+		return nil, fmt.Errorf("fetching issues of milestone %s: %w", name, err)
+	}
+	return issues, nil
+}
+
+// setMilestone attaches an issue to a milestone, removing it from any other.
+func setMilestone(ctx context.Context, w issueWriter, issueID, name string) error {
+	labels, err := w.GetLabels(ctx, issueID)
+	if err != nil {
+		return fmt.Errorf("getting labels of %s: %w", issueID, err)
+	}
+	want := milestoneLabelPrefix + name
+	has := false
+	for _, label := range labels {
+		if label == want {
+			has = true
+		} else if strings.HasPrefix(label, milestoneLabelPrefix) {
+			if err := w.RemoveLabel(ctx, issueID, label, actor); err != nil {
+				return fmt.Errorf("removing %s from %s: %w", label, issueID, err)
+			}
+		}
+	}
+	if has {
+		return nil
+	}
+	if err := w.AddLabel(ctx, issueID, want, actor); err != nil {
+		return fmt.Errorf("adding %s to %s: %w", issueID, name, err)
+	}
+	return nil
+}
+
+func formatMilestoneDue(m *types.Milestone) string {
+	if m.DueAt == nil {
+		return ""
+	}
+	return ", due " + m.DueAt.Local().Format("2006-01-02")
+}
+
+// milestoneProgress is a milestone with counts of its issues.
+type milestoneProgress struct {
+	*types.Milestone
+	Total  int `json:"total"`
+	Open   int `json:"open"` // Not yet closed, whatever their status
+	Closed int `json:"closed"`
+}
+
+func progressOf(m *types.Milestone, issues []*types.Issue) milestoneProgress {
+	p := milestoneProgress{Milestone: m, Total: len(issues)}
+	for _, issue := range issues {
+		if issue.Status == types.StatusClosed {
+			p.Closed++
+		} else {
+			p.Open++
+		}
+	}
+	return p
+}
+
+// burndownPoint is how many of a milestone's issues were open and closed at
+// the end of a day.
+type burndownPoint struct {
+	Date   string `json:"date"`
+	Open   int    `json:"open"`
+	Closed int    `json:"closed"`
+}
+
+// milestoneStatus is the output of bd milestone status.
+type milestoneStatus struct {
+	milestoneProgress
+	Overdue  bool                   `json:"overdue"`
+	Baseline *milestoneBaselineDiff `json:"baseline,omitempty"`
+	Burndown []burndownPoint        `json:"burndown"`
+}
+
+// buildMilestoneStatus computes a milestone's progress and a burndown point
+// for each day from its creation to now (or to when it was closed).
+func buildMilestoneStatus(m *types.Milestone, issues []*types.Issue, now time.Time) *milestoneStatus {
+	s := &milestoneStatus{milestoneProgress: progressOf(m, issues), Burndown: []burndownPoint{}}
+	s.Overdue = m.Status == types.MilestoneOpen && m.DueAt != nil && now.After(*m.DueAt) && s.Open > 0
+
+	last := now
+	if m.ClosedAt != nil && m.ClosedAt.Before(now) {
+		last = *m.ClosedAt
+	}
+	loc := now.Location()
+	y, mo, d := m.CreatedAt.In(loc).Date()
+	for date := time.Date(y, mo, d, 0, 0, 0, 0, loc); !date.After(last); date = date.AddDate(0, 0, 1) {
+		cutoff := date.AddDate(0, 0, 1)
+		if cutoff.After(last) {
+			cutoff = last
+		}
+		p := burndownPoint{Date: date.Format("2006-01-02")}
+		for _, issue := range issues {
+			if issue.CreatedAt.After(cutoff) {
+				continue
+			}
+			if issue.ClosedAt != nil && !issue.ClosedAt.After(cutoff) {
+				p.Closed++
+			} else {
+				p.Open++
+			}
+		}
+		s.Burndown = append(s.Burndown, p)
+	}
+	return s
+}
+
+func displayMilestoneStatus(s *milestoneStatus) {
+	title := s.Name
+	if s.Title != "" {
+		title += ": " + s.Title
+	}
+	fmt.Printf("\n%s Milestone %s\n\n", ui.RenderAccent("🏁"), title)
+	fmt.Printf("  Status: %s%s\n", s.Status, formatMilestoneDue(s.Milestone))
+	fmt.Printf("  Issues: %d closed, %d open of %d\n", s.Closed, s.Open, s.Total)
+	if s.Overdue {
+		fmt.Printf("  %s\n", ui.RenderWarn("Overdue"))
+	}
+	if s.Baseline != nil {
+		displayMilestoneBaselineDiff(s.Baseline)
+	}
+	if len(s.Burndown) == 0 {
+		return
+	}
+	fmt.Printf("\n  %-10s  %6s  %6s\n", "Date", "Open", "Closed")
+	for _, p := range s.Burndown {
+		fmt.Printf("  %-10s  %6d  %6d\n", p.Date, p.Open, p.Closed)
+	}
+	fmt.Println()
+}
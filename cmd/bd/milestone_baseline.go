@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var milestoneBaselineCmd = &cobra.Command{
+	Use:   "baseline <name>",
+	Short: "Record the issues currently committed to a milestone",
+	Long: `Record the issues currently attached to a milestone as its commitment.
+'bd milestone status' then compares the milestone against it: which
+committed issues are done, which slipped (moved to another milestone, or
+still open once the milestone is due or closed), which were dropped, and
+which were added afterwards.
+
+The baseline is stored in the database as a snapshot of each committed
+issue (title, status, estimate), so it survives issues being edited,
+moved, or deleted. Running it again replaces the baseline.
+
+Examples:
+  bd milestone baseline sprint-12
+  bd milestone status sprint-12 --json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("milestone baseline")
+		ctx := rootCtx
+		m := resolveMilestone(ctx, args[0])
+		issues, err := milestoneIssues(ctx, m.Name)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		previous, err := store.GetMilestoneBaseline(ctx, m.Name)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		now := time.Now().UTC()
+		items := make([]*types.MilestoneBaselineItem, 0, len(issues))
+		for _, issue := range issues {
+			items = append(items, &types.MilestoneBaselineItem{
+				Milestone:        m.Name,
+				IssueID:          issue.ID,
+				Title:            issue.Title,
+				Status:           issue.Status,
+				EstimatedMinutes: issue.EstimatedMinutes,
+				TakenBy:          actor,
+				TakenAt:          now,
+			})
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].IssueID < items[j].IssueID })
+		if err := store.SetMilestoneBaseline(ctx, m.Name, items); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(items)
+			return
+		}
+		fmt.Printf("%s Baseline for %s: %d issues\n", ui.RenderPass("✓"), m.Name, len(items))
+		if len(previous) > 0 {
+			fmt.Printf("  %s\n", ui.RenderMuted("Replaced the baseline from "+previous[0].TakenAt.Local().Format("2006-01-02")))
+		}
+	},
+}
+
+func init() {
+	milestoneCmd.AddCommand(milestoneBaselineCmd)
+}
+
+// milestoneBaselineDiff compares a milestone's issues now against its
+// baseline. Each committed issue is in exactly one of Done, Pending,
+// Slipped, and Dropped.
+type milestoneBaselineDiff struct {
+	TakenAt   time.Time         `json:"taken_at"`
+	Committed int               `json:"committed"`
+	Done      []string          `json:"done"`
+	Pending   []string          `json:"pending"` // Open, but the milestone isn't due yet
+	Slipped   []string          `json:"slipped"`
+	MovedTo   map[string]string `json:"moved_to,omitempty"` // Slipped issue → the milestone it moved to
+	Dropped   []string          `json:"dropped"`            // No longer in any milestone, or deleted
+	Added     []string          `json:"added"`              // In the milestone, not in the baseline
+}
+
+// compareMilestoneBaseline sorts a milestone's baseline against its current
+// issues. elsewhere maps baseline issues that left the milestone to the
+// milestone they are in now; those in none are dropped.
+func compareMilestoneBaseline(m *types.Milestone, baseline []*types.MilestoneBaselineItem, current []*types.Issue, elsewhere map[string]string, now time.Time) *milestoneBaselineDiff {
+	d := &milestoneBaselineDiff{
+		Committed: len(baseline),
+		Done:      []string{},
+		Pending:   []string{},
+		Slipped:   []string{},
+		MovedTo:   map[string]string{},
+		Dropped:   []string{},
+		Added:     []string{},
+	}
+	if len(baseline) > 0 {
+		d.TakenAt = baseline[0].TakenAt
+	}
+	late := m.Status == types.MilestoneClosed || (m.DueAt != nil && now.After(*m.DueAt))
+
+	byID := make(map[string]*types.Issue, len(current))
+	for _, issue := range current {
+		byID[issue.ID] = issue
+	}
+	committed := make(map[string]bool, len(baseline))
+	for _, item := range baseline {
+		committed[item.IssueID] = true
+		issue := byID[item.IssueID]
+		switch {
+		case issue == nil && elsewhere[item.IssueID] != "":
+			d.Slipped = append(d.Slipped, item.IssueID)
+			d.MovedTo[item.IssueID] = elsewhere[item.IssueID]
+		case issue == nil:
+			d.Dropped = append(d.Dropped, item.IssueID)
+		case issue.Status == types.StatusClosed:
+			d.Done = append(d.Done, item.IssueID)
+		case late:
+			d.Slipped = append(d.Slipped, item.IssueID)
+		default:
+			d.Pending = append(d.Pending, item.IssueID)
+		}
+	}
+	for _, issue := range current {
+		if !committed[issue.ID] {
+			d.Added = append(d.Added, issue.ID)
+		}
+	}
+	for _, ids := range [][]string{d.Done, d.Pending, d.Slipped, d.Dropped, d.Added} {
+		sort.Strings(ids)
+	}
+	return d
+}
+
+// milestoneBaselineDiffOf loads a milestone's baseline and compares it with
+// its current issues, returning nil if no baseline was taken.
+func milestoneBaselineDiffOf(ctx context.Context, m *types.Milestone, current []*types.Issue, now time.Time) (*milestoneBaselineDiff, error) {
+	baseline, err := store.GetMilestoneBaseline(ctx, m.Name)
+	if err != nil || len(baseline) == 0 {
+		return nil, err
+	}
+	inMilestone := make(map[string]bool, len(current))
+	for _, issue := range current {
+		inMilestone[issue.ID] = true
+	}
+	var gone []string
+	for _, item := range baseline {
+		if !inMilestone[item.IssueID] {
+			gone = append(gone, item.IssueID)
+		}
+	}
+	elsewhere := map[string]string{}
+	if len(gone) > 0 {
+		labels, err := store.GetLabelsForIssues(ctx, gone)
+		if err != nil {
+			return nil, fmt.Errorf("getting labels: %w", err)
+		}
+		for id, issueLabels := range labels {
+			for _, label := range issueLabels {
+				if strings.HasPrefix(label, milestoneLabelPrefix) {
+					elsewhere[id] = strings.TrimPrefix(label, milestoneLabelPrefix)
+				}
+			}
+		}
+	}
+	return compareMilestoneBaseline(m, baseline, current, elsewhere, now), nil
+}
+
+func displayMilestoneBaselineDiff(d *milestoneBaselineDiff) {
+	fmt.Printf("\n  Baseline (%s): %d committed\n", d.TakenAt.Local().Format("2006-01-02"), d.Committed)
+	slipped := make([]string, 0, len(d.Slipped))
+	for _, id := range d.Slipped {
+		if to := d.MovedTo[id]; to != "" {
+			id += " → " + to
+		}
+		slipped = append(slipped, id)
+	}
+	plain := func(s string) string { return s }
+	for _, row := range []struct {
+		label  string
+		ids    []string
+		render func(string) string
+	}{
+		{"Done", d.Done, plain},
+		{"Pending", d.Pending, plain},
+		{"Slipped", slipped, ui.RenderWarn},
+		{"Dropped", d.Dropped, ui.RenderMuted},
+		{"Added", d.Added, plain},
+	} {
+		if len(row.ids) > 0 {
+			fmt.Printf("    %-8s %3d  %s\n", row.label, len(row.ids), row.render(strings.Join(row.ids, ", ")))
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildMilestoneStatus(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2026, 10, d, hour, 0, 0, 0, time.UTC) }
+	closedAt := func(t time.Time) *time.Time { return &t }
+	due := day(3, 0)
+	m := &types.Milestone{Name: "sprint-12", DueAt: &due, Status: types.MilestoneOpen, CreatedAt: day(1, 9)}
+	issues := []*types.Issue{
+		{ID: "a", Status: types.StatusClosed, CreatedAt: day(1, 8), ClosedAt: closedAt(day(2, 15))},
+		{ID: "b", Status: types.StatusOpen, CreatedAt: day(1, 10)},
+		{ID: "c", Status: types.StatusClosed, CreatedAt: day(3, 11), ClosedAt: closedAt(day(4, 12))},
+		{ID: "d", Status: types.StatusInProgress, CreatedAt: day(4, 16)},
+	}
+
+	s := buildMilestoneStatus(m, issues, day(4, 18))
+	if s.Total != 4 || s.Open != 2 || s.Closed != 2 || !s.Overdue {
+		t.Errorf("total/open/closed/overdue = %d/%d/%d/%v, want 4/2/2/true", s.Total, s.Open, s.Closed, s.Overdue)
+	}
+	want := []burndownPoint{
+		{Date: "2026-10-01", Open: 2, Closed: 0},
+		{Date: "2026-10-02", Open: 1, Closed: 1},
+		{Date: "2026-10-03", Open: 2, Closed: 1},
+		{Date: "2026-10-04", Open: 2, Closed: 2},
+	}
+	if !reflect.DeepEqual(s.Burndown, want) {
+		t.Errorf("burndown = %+v, want %+v", s.Burndown, want)
+	}
+
+	// A closed milestone's burndown stops when it was closed
+	m.Status, m.ClosedAt = types.MilestoneClosed, closedAt(day(2, 18))
+	s = buildMilestoneStatus(m, issues, day(4, 18))
+	if len(s.Burndown) != 2 || s.Overdue {
+		t.Errorf("closed milestone: %d points, overdue %v; want 2, false", len(s.Burndown), s.Overdue)
+	}
+}
+
+func TestParseMilestoneName(t *testing.T) {
+	if name, err := parseMilestoneName(" v2.1 "); err != nil || name != "v2.1" {
+		t.Errorf("parseMilestoneName(v2.1) = %q, %v", name, err)
+	}
+	for _, bad := range []string{"", "sprint 12", "a,b"} {
+		if _, err := parseMilestoneName(bad); err == nil {
+			t.Errorf("parseMilestoneName(%q) accepted", bad)
+		}
+	}
+}
+
+func TestCompareMilestoneBaseline(t *testing.T) {
+	taken := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	m := &types.Milestone{Name: "sprint-12", DueAt: &due, Status: types.MilestoneOpen}
+	var baseline []*types.MilestoneBaselineItem
+	for _, id := range []string{"done", "open", "moved", "dropped"} {
+		baseline = append(baseline, &types.MilestoneBaselineItem{IssueID: id, TakenAt: taken})
+	}
+	current := []*types.Issue{
+		{ID: "done", Status: types.StatusClosed},
+		{ID: "open", Status: types.StatusInProgress},
+		{ID: "new", Status: types.StatusOpen},
+	}
+	elsewhere := map[string]string{"moved": "sprint-13"}
+
+	d := compareMilestoneBaseline(m, baseline, current, elsewhere, due.Add(-time.Hour))
+	if d.Committed != 4 || !d.TakenAt.Equal(taken) {
+		t.Errorf("committed %d at %v", d.Committed, d.TakenAt)
+	}
+	check := func(name string, got, want []string) {
+		t.Helper()
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+	check("done", d.Done, []string{"done"})
+	check("pending", d.Pending, []string{"open"})
+	check("slipped", d.Slipped, []string{"moved"})
+	check("dropped", d.Dropped, []string{"dropped"})
+	check("added", d.Added, []string{"new"})
+	if d.MovedTo["moved"] != "sprint-13" {
+		t.Errorf("moved_to = %v", d.MovedTo)
+	}
+
+	// Once the milestone is due, committed work still open has slipped
+	d = compareMilestoneBaseline(m, baseline, current, elsewhere, due.Add(time.Hour))
+	check("pending after due", d.Pending, []string{})
+	check("slipped after due", d.Slipped, []string{"moved", "open"})
+}
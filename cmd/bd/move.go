@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/resolution"
 	"github.com/steveyegge/beads/internal/routing"
 	"github.com/steveyegge/beads/internal/storage/dolt"
 	"github.com/steveyegge/beads/internal/types"
@@ -121,6 +122,7 @@ Examples:
 			Assignee:           sourceIssue.Assignee,
 			ExternalRef:        sourceIssue.ExternalRef,
 			EstimatedMinutes:   sourceIssue.EstimatedMinutes,
+			ActualMinutes:      sourceIssue.ActualMinutes,
 			SourceRepo:         sourceIssue.SourceRepo,
 			Ephemeral:          sourceIssue.Ephemeral,
 			MolType:            sourceIssue.MolType,
@@ -165,7 +167,7 @@ Examples:
 
 		// Step 8: Close the source issue (unless --keep-open)
 		if !keepOpen {
-			closeReason := fmt.Sprintf("Moved to %s", newID)
+			closeReason := resolution.Resolution{Category: resolution.SupersededBy, Target: newID, Note: "moved"}.String()
 			if err := sourceStore.CloseIssue(ctx, resolvedSourceID, closeReason, actor, ""); err != nil {
 				WarnError("failed to close source issue: %v", err)
 			}
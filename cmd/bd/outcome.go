@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// outcomeLabelPrefix prefixes the label that records how closed work turned
+// out, as in "outcome:shipped".
+const outcomeLabelPrefix = "outcome:"
+
+var outcomeCmd = &cobra.Command{
+	Use:     "outcome [issue-id] [outcome]",
+	GroupID: "issues",
+	Short:   "Record how a closed issue turned out",
+	Long: `Record how a closed issue turned out: shipped, reverted, or abandoned by
+default (close.outcomes sets the list). Outcomes are stored as an
+outcome:<value> label, replacing any earlier one, and summarized by
+'bd report retro'.
+
+Tag when closing with 'bd close --outcome', or afterwards with this command,
+for example when a shipped change is rolled back. Without an outcome, the
+issue's current outcome is shown.
+
+Examples:
+  bd close bd-12 --outcome shipped
+  bd outcome bd-12 reverted
+  bd outcome bd-12`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, issueID)
+		if err != nil || issue == nil {
+			FatalErrorRespectJSON("issue %s not found", issueID)
+		}
+
+		if len(args) == 1 {
+			labels, err := store.GetLabels(ctx, issueID)
+			if err != nil {
+				FatalErrorRespectJSON("getting labels: %v", err)
+			}
+			outcome := issueOutcome(labels)
+			if jsonOutput {
+				outputJSON(map[string]string{"id": issueID, "outcome": outcome})
+				return
+			}
+			if outcome == "" {
+				fmt.Printf("%s has no outcome\n", issueID)
+				return
+			}
+			fmt.Printf("%s: %s\n", issueID, outcome)
+			return
+		}
+
+		CheckReadonly("outcome")
+		outcome, err := parseOutcome(args[1])
+		if err != nil {
+			FatalErrorCode(exitValidation, "%v", err)
+		}
+		if issue.Status != types.StatusClosed {
+			FatalErrorWithHint(fmt.Sprintf("%s is %s, not closed", issueID, issue.Status),
+				fmt.Sprintf("close it with: bd close %s --outcome %s", issueID, outcome))
+		}
+		if err := setOutcome(ctx, store, issueID, outcome); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(map[string]string{"id": issueID, "outcome": outcome})
+			return
+		}
+		fmt.Printf("%s Marked %s as %s\n", ui.RenderPass("✓"), issueID, outcome)
+	},
+}
+
+func init() {
+	outcomeCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(outcomeCmd)
+}
+
+// closeOutcomes returns the outcomes closed issues can be tagged with, from
+// close.outcomes.
+func closeOutcomes() []string {
+	var outcomes []string
+	for _, o := range config.GetStringSlice("close.outcomes") {
+		if o = strings.ToLower(strings.TrimSpace(o)); o != "" {
+			outcomes = append(outcomes, o)
+		}
+	}
+	return outcomes
+}
+
+// parseOutcome normalizes an --outcome value, refusing ones not in
+// close.outcomes.
+func parseOutcome(s string) (string, error) {
+	outcome := strings.ToLower(strings.TrimSpace(s))
+	outcomes := closeOutcomes()
+	for _, o := range outcomes {
+		if o == outcome {
+			return outcome, nil
+		}
+	}
+	return "", fmt.Errorf("invalid outcome %q (valid: %s; see close.outcomes)", s, strings.Join(outcomes, ", "))
+}
+
+// issueOutcome returns the outcome recorded in an issue's labels, or "".
+func issueOutcome(labels []string) string {
+	for _, label := range labels {
+		if strings.HasPrefix(label, outcomeLabelPrefix) {
+			return strings.TrimPrefix(label, outcomeLabelPrefix)
+		}
+	}
+	return ""
+}
+
+// setOutcome tags an issue with outcome, removing any other outcome label.
+func setOutcome(ctx context.Context, w issueWriter, issueID, outcome string) error {
+	labels, err := w.GetLabels(ctx, issueID)
+	if err != nil {
+		return fmt.Errorf("getting labels of %s: %w", issueID, err)
+	}
+	want := outcomeLabelPrefix + outcome
+	has := false
+	for _, label := range labels {
+		if label == want {
+			has = true
+		} else if strings.HasPrefix(label, outcomeLabelPrefix) {
+			if err := w.RemoveLabel(ctx, issueID, label, actor); err != nil {
+				return fmt.Errorf("removing %s from %s: %w", label, issueID, err)
+			}
+		}
+	}
+	if has {
+		return nil
+	}
+	if err := w.AddLabel(ctx, issueID, want, actor); err != nil {
+		return fmt.Errorf("tagging %s as %s: %w", issueID, outcome, err)
+	}
+	return nil
+}
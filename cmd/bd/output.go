@@ -3,8 +3,23 @@ package main
 import (
 	"encoding/json"
 	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/ui"
 )
 
+// pagerOptions returns the pager settings for a command with a --no-pager
+// flag: the flag or no-pager in config turns paging off, and pager in config
+// picks the program.
+func pagerOptions(cmd *cobra.Command) ui.PagerOptions {
+	noPager, _ := cmd.Flags().GetBool("no-pager")
+	return ui.PagerOptions{
+		NoPager: noPager || config.GetBool("no-pager"),
+		Command: config.GetString("pager"),
+	}
+}
+
 // outputJSON outputs data as pretty-printed JSON to stdout.
 func outputJSON(v interface{}) {
 	encoder := json.NewEncoder(os.Stdout)
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// actorPermissions is what bd permissions reports for one actor.
+type actorPermissions struct {
+	Actor        string                   `json:"actor"`
+	Policy       string                   `json:"policy,omitempty"` // Path of the policy file; empty if there is none
+	Entry        string                   `json:"entry,omitempty"`  // Actor name, pattern, or "default" that matched
+	Capabilities []permissions.Capability `json:"capabilities"`
+}
+
+var permissionsCmd = &cobra.Command{
+	Use:     "permissions [actor]",
+	GroupID: "setup",
+	Short:   "Show the capabilities the permission policy grants an actor",
+	Long: `Show which writes an actor may make under .beads/permissions.yaml.
+Without an argument, shows the current actor (--actor, BD_ACTOR, git
+user.name, $USER).
+
+The policy grants capabilities per actor; every store write checks them
+and fails with exit code 5 when the actor lacks one:
+
+  create      Create issues
+  update      Edit fields, labels, dependencies and comments; claim
+  close       Close and reopen issues
+  delete      Delete issues
+  federation  Push, pull and sync with peers and remotes
+  admin       Everything, including renames, ID migrations and erasure
+
+Example .beads/permissions.yaml:
+
+  default: [create, update, close]
+  actors:
+    alice: [admin]
+    release-bot: [create, update, close, federation]
+    sandbox-*: [create, update]
+
+Actors match by name, then by the longest matching glob pattern; anyone
+else gets default. Without a policy file every actor may do everything.
+
+Examples:
+  bd permissions
+  bd permissions release-bot --json`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		who := getActorWithGit()
+		if len(args) == 1 {
+			who = args[0]
+		}
+		r := resolveDatabase(cmd.Flags().Changed("db"))
+		if r == nil {
+			FatalErrorWithHint("no beads database found from "+currentDir(),
+				"run 'bd init' to create one, or point --db, BD_DATABASE, or BEADS_DIR at it")
+		}
+		policy, err := permissions.Load(r.BeadsDir)
+		if err != nil {
+			FatalErrorWithHintCode(exitValidation, err.Error(), "fix or remove .beads/"+permissions.FileName)
+		}
+
+		result := actorPermissions{Actor: who}
+		result.Capabilities, result.Entry = policy.Granted(who)
+		if policy != nil {
+			result.Policy = filepath.Join(r.BeadsDir, permissions.FileName)
+		}
+		if result.Capabilities == nil {
+			result.Capabilities = []permissions.Capability{}
+		}
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+
+		if policy == nil {
+			fmt.Printf("%s: every capability (no %s in %s)\n", who, permissions.FileName, r.BeadsDir)
+			return
+		}
+		fmt.Printf("%s (matched %s in %s)\n", who, result.Entry, result.Policy)
+		for _, c := range permissions.Capabilities {
+			mark := "-"
+			if policy.Allows(who, c) {
+				mark = ui.Glyph("✓")
+			}
+			fmt.Printf("  %s %s\n", mark, c)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(permissionsCmd)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Porcelain output is for scripts: one issue per line, tab-separated
+// fields in a fixed order (see docs/PORCELAIN.md). A version's fields never
+// change; new or reordered fields get a new version, and old versions stay
+// available.
+const porcelainV1 = "v1"
+
+// porcelainV1Fields is the v1 field order.
+var porcelainV1Fields = []string{"id", "status", "priority", "type", "assignee", "parent", "labels", "created", "updated", "title"}
+
+// porcelainEscaper escapes the characters that would break a record.
+var porcelainEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// addPorcelainFlag adds --porcelain[=version] to a read command.
+func addPorcelainFlag(cmd *cobra.Command) {
+	cmd.Flags().String("porcelain", "", "Stable tab-separated output for scripts: --porcelain or --porcelain=v1 (see docs/PORCELAIN.md)")
+	cmd.Flags().Lookup("porcelain").NoOptDefVal = porcelainV1
+}
+
+// porcelainFormat returns the requested porcelain version, or "" for
+// normal output. Unknown versions and --json are errors.
+func porcelainFormat(cmd *cobra.Command) string {
+	version, _ := cmd.Flags().GetString("porcelain")
+	if version == "" {
+		return ""
+	}
+	if version != porcelainV1 {
+		FatalError("unknown --porcelain version %q (supported: %s)", version, porcelainV1)
+	}
+	if jsonOutput {
+		FatalError("--porcelain and --json are mutually exclusive")
+	}
+	return version
+}
+
+// formatPorcelainIssue renders an issue as a v1 record, without the newline.
+func formatPorcelainIssue(issue *types.Issue, labels []string, parent string) string {
+	fields := []string{
+		issue.ID,
+		string(issue.Status),
+		strconv.Itoa(issue.Priority),
+		string(issue.IssueType),
+		issue.Assignee,
+		parent,
+		strings.Join(labels, ","),
+		issue.CreatedAt.UTC().Format(time.RFC3339),
+		issue.UpdatedAt.UTC().Format(time.RFC3339),
+		issue.Title,
+	}
+	for i, f := range fields {
+		fields[i] = porcelainEscaper.Replace(f)
+	}
+	return strings.Join(fields, "\t")
+}
+
+// writePorcelainIssues writes issues as v1 records, loading their labels
+// and parents from s.
+func writePorcelainIssues(ctx context.Context, w io.Writer, s *dolt.DoltStore, issues []*types.Issue) error {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labels, err := s.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("loading labels: %w", err)
+	}
+	deps, err := s.GetDependencyRecordsForIssues(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("loading dependencies: %w", err)
+	}
+	for _, issue := range issues {
+		parent := ""
+		for _, dep := range deps[issue.ID] {
+			if dep.Type == types.DepParentChild {
+				parent = dep.DependsOnID
+				break
+			}
+		}
+		if _, err := fmt.Fprintln(w, formatPorcelainIssue(issue, labels[issue.ID], parent)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFormatPorcelainIssue(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	issue := &types.Issue{
+		ID:        "bd-1",
+		Status:    types.StatusOpen,
+		Priority:  1,
+		IssueType: types.TypeBug,
+		Assignee:  "alice",
+		Title:     "Tabs\tand\nnewlines \\ escaped",
+		CreatedAt: created,
+		UpdatedAt: created.Add(time.Hour),
+	}
+	got := formatPorcelainIssue(issue, []string{"ui", "p:x"}, "bd-9")
+	want := "bd-1\topen\t1\tbug\talice\tbd-9\tui,p:x\t2026-03-01T12:00:00Z\t2026-03-01T13:00:00Z\tTabs\\tand\\nnewlines \\\\ escaped"
+	if got != want {
+		t.Errorf("formatPorcelainIssue =\n%q\nwant\n%q", got, want)
+	}
+}
+
+// The v1 field order is a compatibility promise to scripts: changing it
+// breaks them. Add a new porcelain version instead.
+func TestPorcelainV1FieldsStable(t *testing.T) {
+	want := "id status priority type assignee parent labels created updated title"
+	got := ""
+	for i, f := range porcelainV1Fields {
+		if i > 0 {
+			got += " "
+		}
+		got += f
+	}
+	if got != want {
+		t.Errorf("porcelain v1 fields = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// newProgress starts a progress line for a long operation (see
+// ui.Progress). It stays silent with --json and --quiet.
+func newProgress(label string, total int) *ui.Progress {
+	if jsonOutput || quietFlag {
+		return ui.NewProgressTo(nil, label, total)
+	}
+	return ui.NewProgress(label, total)
+}
+
+// exitInterrupted ends a command that Ctrl-C stopped partway, saying how
+// far it got, e.g. "exported 120 of 400 issues". With --json the summary
+// is written as an object with "interrupted": true and fields.
+func exitInterrupted(summary string, fields map[string]interface{}) {
+	if jsonOutput {
+		result := map[string]interface{}{"interrupted": true, "summary": summary}
+		for k, v := range fields {
+			result[k] = v
+		}
+		outputJSON(result)
+	} else {
+		fmt.Fprintf(os.Stderr, "%s Interrupted: %s\n", ui.RenderWarn("⚠"), summary)
+	}
+	exitCanceled()
+}
@@ -49,6 +49,7 @@ Supported fields:
   created           Creation date/time
   updated           Last update date/time
   closed            Close date/time
+  age               Time since creation (durations only: age>2d)
   id                Issue ID (supports wildcards: bd-*)
   spec              Spec ID (supports wildcards)
   pinned            Boolean (true/false)
@@ -173,7 +174,7 @@ Examples:
 		}
 
 		// Apply sorting
-		sortIssues(issues, sortBy, reverse)
+		sortIssues(issues, sortBy, reverse, voteCountsForSort(ctx, store, issues, sortBy))
 
 		// Output results
 		if jsonOutput {
@@ -186,6 +187,7 @@ Examples:
 				labelsMap, _ := store.GetLabelsForIssues(ctx, issueIDs)   // Best effort: display gracefully degrades with empty data
 				depCounts, _ := store.GetDependencyCounts(ctx, issueIDs)  // Best effort: display gracefully degrades with empty data
 				commentCounts, _ := store.GetCommentCounts(ctx, issueIDs) // Best effort: display gracefully degrades with empty data
+				voteCounts, _ := store.GetVoteCounts(ctx, issueIDs)       // Best effort: display gracefully degrades with empty data
 
 				for _, issue := range issues {
 					issue.Labels = labelsMap[issue.ID]
@@ -202,6 +204,7 @@ Examples:
 						DependencyCount: counts.DependencyCount,
 						DependentCount:  counts.DependentCount,
 						CommentCount:    commentCounts[issue.ID],
+						VoteCount:       voteCounts[issue.ID],
 					}
 				}
 				outputJSON(issuesWithCounts)
@@ -308,7 +311,7 @@ func init() {
 	queryCmd.Flags().IntP("limit", "n", 50, "Limit results (default: 50, 0 = unlimited)")
 	queryCmd.Flags().BoolP("all", "a", false, "Include closed issues (default: exclude closed)")
 	queryCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
-	queryCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
+	queryCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee, votes")
 	queryCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
 	queryCmd.Flags().Bool("parse-only", false, "Only parse the query and show the AST (for debugging)")
 
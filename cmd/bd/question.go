@@ -0,0 +1,349 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// Questions are message issues with the question label that reply to the
+// issue they ask about. A blocking question also blocks that issue, so it
+// leaves bd ready until the question is answered (closed).
+const questionLabel = "question"
+
+// questionTitleMax is the longest question title; longer questions keep
+// their full text in the description.
+const questionTitleMax = 120
+
+var questionCmd = &cobra.Command{
+	Use:     "question",
+	GroupID: "issues",
+	Short:   "Ask and answer clarifying questions on issues",
+	Long: `Questions formalize the clarification loop between agents and humans:
+an agent asks a question about an issue, someone answers it, and the answer
+stays on record with the issue.
+
+A question marked --blocking blocks its issue: the issue leaves 'bd ready'
+(and shows in 'bd blocked') until the question is answered. Answering a
+question adds the answer as a comment and closes the question with the
+answer as its close reason.
+
+Commands:
+  bd question ask <id> "<question>" [--blocking] [--to <who>]
+  bd question answer <question-id> "<answer>"
+  bd question list [<id>] [--all] [--blocking]
+
+Examples:
+  bd question ask bd-42 "Should the export include archived rows?" --blocking --to alice
+  bd question list --blocking          # Open questions holding up work
+  bd question answer bd-77 "No, active rows only"`,
+}
+
+var questionAskCmd = &cobra.Command{
+	Use:   "ask <id> <question>",
+	Short: "Ask a question about an issue",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("question ask")
+		ctx := rootCtx
+		blocking, _ := cmd.Flags().GetBool("blocking")
+		to, _ := cmd.Flags().GetString("to")
+		text := strings.TrimSpace(args[1])
+		if text == "" {
+			FatalErrorRespectJSON("question text is empty")
+		}
+
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, issueID)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if issue.Status == types.StatusClosed {
+			FatalErrorRespectJSON("%s is closed; reopen it before asking about it", issueID)
+		}
+
+		title, description := questionTitle(text)
+		question := &types.Issue{
+			Title:       title,
+			Description: description,
+			Status:      types.StatusOpen,
+			Priority:    issue.Priority,
+			IssueType:   types.TypeMessage,
+			Assignee:    strings.TrimSpace(to),
+			Sender:      actor,
+		}
+		if err := store.CreateIssue(ctx, question, actor); err != nil {
+			FatalErrorRespectJSON("creating question: %v", err)
+		}
+		if err := store.AddLabel(ctx, question.ID, questionLabel, actor); err != nil {
+			FatalErrorRespectJSON("labelling question %s: %v", question.ID, err)
+		}
+		if err := store.AddDependency(ctx, &types.Dependency{IssueID: question.ID, DependsOnID: issueID, Type: types.DepRepliesTo}, actor); err != nil {
+			FatalErrorRespectJSON("linking question %s to %s: %v", question.ID, issueID, err)
+		}
+		if blocking {
+			if err := store.AddDependency(ctx, &types.Dependency{IssueID: issueID, DependsOnID: question.ID, Type: types.DepBlocks}, actor); err != nil {
+				FatalErrorRespectJSON("blocking %s on question %s: %v", issueID, question.ID, err)
+			}
+		}
+		SetLastTouchedID(question.ID)
+
+		info := questionInfo{ID: question.ID, IssueID: issueID, Question: text, AskedBy: actor, To: question.Assignee, Blocking: blocking, Status: question.Status}
+		if jsonOutput {
+			outputJSON(info)
+			return
+		}
+		note := ""
+		if blocking {
+			note = fmt.Sprintf(" (%s is blocked until it's answered)", issueID)
+		}
+		fmt.Printf("%s Asked %s on %s%s\n", ui.RenderPass("✓"), ui.RenderID(question.ID), ui.RenderID(issueID), note)
+	},
+}
+
+var questionAnswerCmd = &cobra.Command{
+	Use:   "answer <question-id> <answer>",
+	Short: "Answer a question, unblocking its issue",
+	Args:  cobra.ExactArgs(2),
+	Run: func(_ *cobra.Command, args []string) {
+		CheckReadonly("question answer")
+		ctx := rootCtx
+		answer := strings.TrimSpace(args[1])
+		if answer == "" {
+			FatalErrorRespectJSON("answer text is empty")
+		}
+
+		qid, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		question, err := store.GetIssue(ctx, qid)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		labels, err := store.GetLabels(ctx, qid)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if !isQuestion(question, labels) {
+			FatalErrorRespectJSON("%s is not a question (see 'bd question ask')", qid)
+		}
+		if question.Status == types.StatusClosed {
+			FatalErrorRespectJSON("%s was already answered: %s", qid, question.CloseReason)
+		}
+
+		if _, err := store.AddIssueComment(ctx, qid, actor, answer); err != nil {
+			FatalErrorRespectJSON("adding answer to %s: %v", qid, err)
+		}
+		if err := store.CloseIssue(ctx, qid, answer, actor, ""); err != nil {
+			FatalErrorRespectJSON("closing %s: %v", qid, err)
+		}
+		SetLastTouchedID(qid)
+
+		infos, err := loadQuestions(ctx, store, []*types.Issue{question})
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		info := infos[0]
+		info.Status = types.StatusClosed
+		info.Answer = answer
+		if jsonOutput {
+			outputJSON(info)
+			return
+		}
+		fmt.Printf("%s Answered %s", ui.RenderPass("✓"), ui.RenderID(qid))
+		if info.IssueID != "" {
+			fmt.Printf(" on %s", ui.RenderID(info.IssueID))
+		}
+		fmt.Println()
+	},
+}
+
+var questionListCmd = &cobra.Command{
+	Use:   "list [<id>]",
+	Short: "List open questions, optionally for one issue",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		all, _ := cmd.Flags().GetBool("all")
+		blockingOnly, _ := cmd.Flags().GetBool("blocking")
+
+		issueID := ""
+		if len(args) == 1 {
+			id, err := utils.ResolvePartialID(ctx, store, args[0])
+			if err != nil {
+				FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+			}
+			issueID = id
+		}
+
+		messageType := types.TypeMessage
+		filter := types.IssueFilter{IssueType: &messageType, Labels: []string{questionLabel}}
+		if !all {
+			filter.ExcludeStatus = []types.Status{types.StatusClosed}
+		}
+		questions, err := store.SearchIssues(ctx, "", filter)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		infos, err := loadQuestions(ctx, store, questions)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		infos = filterQuestions(infos, issueID, blockingOnly)
+
+		if jsonOutput {
+			outputJSON(infos)
+			return
+		}
+		if len(infos) == 0 {
+			if all {
+				fmt.Println("No questions")
+			} else {
+				fmt.Println("No open questions")
+			}
+			return
+		}
+		for _, q := range infos {
+			fmt.Println(formatQuestionLine(q))
+		}
+	},
+}
+
+func init() {
+	questionAskCmd.Flags().Bool("blocking", false, "Block the issue until the question is answered")
+	questionAskCmd.Flags().String("to", "", "Who should answer (assigned the question)")
+	questionListCmd.Flags().Bool("all", false, "Include answered questions")
+	questionListCmd.Flags().Bool("blocking", false, "Only questions that block their issue")
+	questionCmd.AddCommand(questionAskCmd, questionAnswerCmd, questionListCmd)
+	rootCmd.AddCommand(questionCmd)
+}
+
+// questionInfo is a question with its issue, as shown by bd question.
+type questionInfo struct {
+	ID       string       `json:"id"`
+	IssueID  string       `json:"issue_id"`
+	Question string       `json:"question"`
+	AskedBy  string       `json:"asked_by,omitempty"`
+	To       string       `json:"to,omitempty"`
+	Blocking bool         `json:"blocking"`
+	Status   types.Status `json:"status"`
+	Answer   string       `json:"answer,omitempty"`
+}
+
+// questionTitle splits question text into a title and, for questions too
+// long for one, a description holding the full text.
+func questionTitle(text string) (title, description string) {
+	title = strings.TrimSpace(strings.SplitN(text, "\n", 2)[0])
+	title = truncateTitle(title, questionTitleMax)
+	if title != text {
+		description = text
+	}
+	return title, description
+}
+
+// isQuestion reports whether an issue is a question.
+func isQuestion(issue *types.Issue, labels []string) bool {
+	if issue.IssueType != types.TypeMessage {
+		return false
+	}
+	for _, l := range labels {
+		if l == questionLabel {
+			return true
+		}
+	}
+	return false
+}
+
+// loadQuestions resolves each question's issue and whether it blocks it.
+func loadQuestions(ctx context.Context, s *dolt.DoltStore, questions []*types.Issue) ([]questionInfo, error) {
+	ids := make([]string, len(questions))
+	for i, q := range questions {
+		ids[i] = q.ID
+	}
+	deps, err := s.GetDependencyRecordsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("loading question links: %w", err)
+	}
+	infos := make([]questionInfo, len(questions))
+	var issueIDs []string
+	for i, q := range questions {
+		text := q.Description
+		if text == "" {
+			text = q.Title
+		}
+		infos[i] = questionInfo{ID: q.ID, Question: text, AskedBy: q.Sender, To: q.Assignee, Status: q.Status}
+		if q.Status == types.StatusClosed {
+			infos[i].Answer = q.CloseReason
+		}
+		for _, dep := range deps[q.ID] {
+			if dep.Type == types.DepRepliesTo {
+				infos[i].IssueID = dep.DependsOnID
+				issueIDs = append(issueIDs, dep.DependsOnID)
+				break
+			}
+		}
+	}
+
+	issueDeps, err := s.GetDependencyRecordsForIssues(ctx, issueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("loading question links: %w", err)
+	}
+	for i := range infos {
+		for _, dep := range issueDeps[infos[i].IssueID] {
+			if dep.Type == types.DepBlocks && dep.DependsOnID == infos[i].ID {
+				infos[i].Blocking = true
+				break
+			}
+		}
+	}
+	return infos, nil
+}
+
+// filterQuestions keeps the questions about issueID (any issue if empty)
+// and, with blockingOnly, only the blocking ones.
+func filterQuestions(infos []questionInfo, issueID string, blockingOnly bool) []questionInfo {
+	kept := []questionInfo{}
+	for _, q := range infos {
+		if (issueID == "" || q.IssueID == issueID) && (!blockingOnly || q.Blocking) {
+			kept = append(kept, q)
+		}
+	}
+	return kept
+}
+
+// formatQuestionLine renders a question for bd question list.
+func formatQuestionLine(q questionInfo) string {
+	var b strings.Builder
+	b.WriteString(ui.RenderID(q.ID))
+	if q.IssueID != "" {
+		b.WriteString(" → " + ui.RenderID(q.IssueID))
+	}
+	if q.Blocking && q.Status != types.StatusClosed {
+		b.WriteString(" " + ui.RenderWarn("[blocking]"))
+	}
+	b.WriteString(" " + truncateTitle(strings.ReplaceAll(q.Question, "\n", " "), questionTitleMax))
+	var who []string
+	if q.AskedBy != "" {
+		who = append(who, "asked by "+q.AskedBy)
+	}
+	if q.To != "" {
+		who = append(who, "for "+q.To)
+	}
+	if len(who) > 0 {
+		b.WriteString(" " + ui.RenderMuted("("+strings.Join(who, ", ")+")"))
+	}
+	if q.Status == types.StatusClosed {
+		b.WriteString("\n    " + ui.RenderPass("✓") + " " + q.Answer)
+	}
+	return b.String()
+}
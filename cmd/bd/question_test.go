@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestQuestionTitle(t *testing.T) {
+	title, desc := questionTitle("Archived rows too?")
+	if title != "Archived rows too?" || desc != "" {
+		t.Errorf("short question = %q, %q", title, desc)
+	}
+	long := "Which DB?\nWe have Postgres and SQLite in the stack."
+	if title, desc := questionTitle(long); title != "Which DB?" || desc != long {
+		t.Errorf("multi-line question = %q, %q", title, desc)
+	}
+	if title, desc := questionTitle(strings.Repeat("x", 200)); len([]rune(title)) != questionTitleMax || len(desc) != 200 {
+		t.Errorf("long question title has %d runes, description %d bytes", len([]rune(title)), len(desc))
+	}
+}
+
+func TestIsQuestion(t *testing.T) {
+	msg := &types.Issue{IssueType: types.TypeMessage}
+	if !isQuestion(msg, []string{"x", questionLabel}) {
+		t.Error("labelled message is not a question")
+	}
+	if isQuestion(msg, nil) {
+		t.Error("unlabelled message is a question")
+	}
+	if isQuestion(&types.Issue{IssueType: types.TypeTask}, []string{questionLabel}) {
+		t.Error("labelled task is a question")
+	}
+}
+
+func TestFilterQuestions(t *testing.T) {
+	infos := []questionInfo{
+		{ID: "bd-q1", IssueID: "bd-1", Blocking: true},
+		{ID: "bd-q2", IssueID: "bd-1"},
+		{ID: "bd-q3", IssueID: "bd-2", Blocking: true},
+	}
+	ids := func(qs []questionInfo) string {
+		var out []string
+		for _, q := range qs {
+			out = append(out, q.ID)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := ids(filterQuestions(infos, "bd-1", false)); got != "bd-q1,bd-q2" {
+		t.Errorf("questions for bd-1 = %s", got)
+	}
+	if got := ids(filterQuestions(infos, "", true)); got != "bd-q1,bd-q3" {
+		t.Errorf("blocking questions = %s", got)
+	}
+	if got := filterQuestions(infos, "bd-9", false); got == nil || len(got) != 0 {
+		t.Errorf("questions for bd-9 = %v, want empty", got)
+	}
+}
@@ -38,7 +38,7 @@ var quickstartCmd = &cobra.Command{
 		fmt.Printf("%s\n", ui.RenderBold("MANAGING DEPENDENCIES"))
 		fmt.Printf("  %s     Add dependency (bd-2 blocks bd-1)\n", ui.RenderAccent("bd dep add bd-1 bd-2"))
 		fmt.Printf("  %s  Visualize dependency tree\n", ui.RenderAccent("bd dep tree bd-1"))
-		fmt.Printf("  %s      Detect circular dependencies\n\n", ui.RenderAccent("bd dep cycles"))
+		fmt.Printf("  %s       Detect circular dependencies\n\n", ui.RenderAccent("bd dep check"))
 
 		fmt.Printf("%s\n", ui.RenderBold("DEPENDENCY TYPES"))
 		fmt.Printf("  %s  Task B must complete before task A\n", ui.RenderWarn("blocks"))
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/quiethours"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/writepolicy"
+)
+
+// registerQuietHoursFlag adds --ignore-quiet-hours to a command that claims
+// or starts work.
+func registerQuietHoursFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("ignore-quiet-hours", false, "Claim or start work even during a quiet-hours window")
+}
+
+// quietHours returns the configured do-not-start windows, or none when
+// --ignore-quiet-hours is set.
+func quietHours(cmd *cobra.Command) []quiethours.Window {
+	if ignore, _ := cmd.Flags().GetBool("ignore-quiet-hours"); ignore {
+		return nil
+	}
+	windows, err := writepolicy.QuietHours()
+	if err != nil {
+		FatalErrorWithHint(err.Error(), "fix quiet-hours in .beads/config.yaml")
+	}
+	return windows
+}
+
+// checkQuietHours refuses to start issue, by claiming it or moving it to
+// in_progress, during a window that holds back work of its priority.
+// Issues already in progress can be claimed as usual.
+func checkQuietHours(windows []quiethours.Window, issue *types.Issue, now time.Time) error {
+	if err := writepolicy.CheckQuietHours(windows, issue, now); err != nil {
+		return fmt.Errorf("%w; use --ignore-quiet-hours to override", err)
+	}
+	return nil
+}
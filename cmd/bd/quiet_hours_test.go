@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/quiethours"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCheckQuietHours(t *testing.T) {
+	windows, err := quiethours.Parse([]string{"fri 17:00-mon 08:00 p2+"})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	saturday := time.Date(2026, 3, 14, 10, 0, 0, 0, time.Local)
+
+	err = checkQuietHours(windows, &types.Issue{ID: "bd-1", Priority: 2, Status: types.StatusOpen}, saturday)
+	if err == nil || !strings.Contains(err.Error(), "--ignore-quiet-hours") {
+		t.Errorf("P2 on a Saturday: err = %v, want quiet hours refusal", err)
+	}
+	if err := checkQuietHours(windows, &types.Issue{ID: "bd-2", Priority: 1, Status: types.StatusOpen}, saturday); err != nil {
+		t.Errorf("P1 is outside the window: %v", err)
+	}
+	if err := checkQuietHours(windows, &types.Issue{ID: "bd-3", Priority: 3, Status: types.StatusInProgress}, saturday); err != nil {
+		t.Errorf("work already in progress isn't being started: %v", err)
+	}
+	if err := checkQuietHours(nil, &types.Issue{ID: "bd-4", Priority: 4, Status: types.StatusOpen}, saturday); err != nil {
+		t.Errorf("no windows (or --ignore-quiet-hours): %v", err)
+	}
+}
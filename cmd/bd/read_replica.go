@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+)
+
+// withReadReplica routes the read queries of a list/ready/search command to a
+// read replica when one is configured and within its staleness bound. When
+// the results may lag the primary, a note on stderr says by how much, so
+// JSON output on stdout keeps its shape.
+func withReadReplica(ctx context.Context, s *dolt.DoltStore) context.Context {
+	if s == nil {
+		return ctx
+	}
+	readCtx, info := s.WithReadReplica(ctx)
+	if info.Fallback != "" {
+		debug.Logf("read replica: using primary: %s\n", info.Fallback)
+	}
+	if info.FromReplica() && info.Staleness > 0 && !debug.IsQuiet() {
+		fmt.Fprintf(os.Stderr, "Note: results from read replica %s, up to %s behind primary\n",
+			info.Source, info.Staleness.Round(time.Second))
+	}
+	return readCtx
+}
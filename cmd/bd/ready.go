@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/config"
@@ -29,10 +32,54 @@ Use --mol to filter to a specific molecule's steps:
 Use --gated to find molecules ready for gate-resume dispatch:
   bd ready --gated           # Find molecules where a gate closed
 
-This is useful for agents executing molecules to see which steps can run next.`,
+This is useful for agents executing molecules to see which steps can run next.
+
+Use --robot as the entry point of an agent work loop: it claims the next
+ready, unassigned issue (atomically, like 'bd update --claim') and prints
+it as one line of JSON with its description, acceptance checklist, parent
+epic, and cleared blockers. With no ready work it prints null and exits 2.
+During a quiet-hours window (config quiet-hours) it skips the issues the
+window holds back, unless --ignore-quiet-hours is given.
+  bd ready --robot           # Claim and print the next issue
+  bd ready --robot -l area:ui
+
+Use --watch to keep a live queue open in a second terminal: bd ready stays
+running and re-renders the list, with all the same filters, whenever any
+client writes to the database (checked every --interval) and at least once
+a minute, as deferred issues come due. With --json, each refresh is one
+line of JSON.
+  bd ready --watch -l area:ui
+  bd ready --watch --json | jq -c 'map(.id)'
+
+Use --fair to keep one big epic or busy label from filling the top of the
+queue: after sorting, issues are interleaved round-robin by top-level epic
+(or by label), so the first -n issues take one from each group before a
+second from any. P0 issues and issues outside any group keep their place.
+Set ready.fairness in config.yaml to make it the default.
+  bd ready --fair epic
+  bd ready --fair label -n 20
+  bd ready --fair none       # Override ready.fairness
+
+Use --sort hybrid so old low-priority work isn't starved by fresh urgent
+work: an issue sorts one priority level higher for every
+ready.aging.interval it has waited (default 30d), up to
+ready.aging.max-boost levels (default 3). A P3 left for three months sorts
+above a new P1.
+  bd ready --sort hybrid`,
 	Run: func(cmd *cobra.Command, args []string) {
+		robot, _ := cmd.Flags().GetBool("robot")
+		if robot {
+			if porcelainFormat(cmd) != "" {
+				FatalError("--robot and --porcelain are mutually exclusive")
+			}
+			jsonOutput = true // Errors, too, are JSON for the agent
+		}
+
 		// Handle --gated flag (gate-resume discovery)
 		gated, _ := cmd.Flags().GetBool("gated")
+		if robot && (gated || cmd.Flags().Changed("mol")) {
+			FatalErrorRespectJSON("--robot can't be combined with --gated or --mol")
+		}
 		if gated {
 			runMolReadyGated(cmd, args)
 			return
@@ -48,18 +95,38 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		limit, _ := cmd.Flags().GetInt("limit")
 		assignee, _ := cmd.Flags().GetString("assignee")
 		unassigned, _ := cmd.Flags().GetBool("unassigned")
+		mine, _ := cmd.Flags().GetBool("mine")
+		if mine {
+			if assignee != "" || unassigned {
+				FatalError("--mine cannot be combined with --assignee or --unassigned")
+			}
+			assignee = actor
+		}
 		sortPolicy, _ := cmd.Flags().GetString("sort")
+		fairness, _ := cmd.Flags().GetString("fair")
+		if !cmd.Flags().Changed("fair") {
+			fairness = config.GetString("ready.fairness")
+		}
+		if fairness == "none" {
+			fairness = ""
+		}
 		labels, _ := cmd.Flags().GetStringSlice("label")
 		labelsAny, _ := cmd.Flags().GetStringSlice("label-any")
 		issueType, _ := cmd.Flags().GetString("type")
 		issueType = utils.NormalizeIssueType(issueType) // Expand aliases (mr→merge-request, etc.)
 		parentID, _ := cmd.Flags().GetString("parent")
 		molTypeStr, _ := cmd.Flags().GetString("mol-type")
-		prettyFormat, _ := cmd.Flags().GetBool("pretty")
-		plainFormat, _ := cmd.Flags().GetBool("plain")
 		includeDeferred, _ := cmd.Flags().GetBool("include-deferred")
 		includeEphemeral, _ := cmd.Flags().GetBool("include-ephemeral")
 		rigOverride, _ := cmd.Flags().GetString("rig")
+		watch, _ := cmd.Flags().GetBool("watch")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if watch && (robot || porcelainFormat(cmd) != "") {
+			FatalError("--watch can't be combined with --robot or --porcelain")
+		}
+		if watch && interval <= 0 {
+			FatalError("--interval must be positive")
+		}
 		var molType *types.MolType
 		if molTypeStr != "" {
 			mt := types.MolType(molTypeStr)
@@ -71,6 +138,9 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		// Use global jsonOutput set by PersistentPreRun (respects config.yaml + env vars)
 
 		// Normalize labels: trim, dedupe, remove empty
+		if milestone, _ := cmd.Flags().GetString("milestone"); milestone != "" {
+			labels = append(labels, milestoneLabelPrefix+milestone)
+		}
 		labels = utils.NormalizeLabels(labels)
 		labelsAny = utils.NormalizeLabels(labelsAny)
 
@@ -82,11 +152,12 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		}
 
 		filter := types.WorkFilter{
-			Status:           "open", // Only show open issues, not in_progress (matches bd list --ready)
+			Status:           readyStatusFilter(rootCtx), // Open only unless status.ready says otherwise
 			Type:             issueType,
 			Limit:            limit,
 			Unassigned:       unassigned,
 			SortPolicy:       types.SortPolicy(sortPolicy),
+			Fairness:         types.Fairness(fairness),
 			Labels:           labels,
 			LabelsAny:        labelsAny,
 			IncludeDeferred:  includeDeferred,  // GH#820: respect --include-deferred flag
@@ -108,7 +179,10 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		}
 		// Validate sort policy
 		if !filter.SortPolicy.IsValid() {
-			FatalError("invalid sort policy '%s'. Valid values: hybrid, priority, oldest", sortPolicy)
+			FatalError("invalid sort policy '%s'. Valid values: hybrid, priority, oldest, votes", sortPolicy)
+		}
+		if !filter.Fairness.IsValid() {
+			FatalError("invalid fairness '%s'. Valid values: epic, label, none", fairness)
 		}
 		// Direct mode
 		ctx := rootCtx
@@ -125,103 +199,170 @@ This is useful for agents executing molecules to see which steps can run next.`,
 		} else {
 		}
 
-		issues, err := activeStore.GetReadyWork(ctx, filter)
-		if err != nil {
+		if robot {
+			runReadyRobot(ctx, activeStore, filter, actor, quietHours(cmd))
+			return
+		}
+
+		if watch {
+			watchReady(ctx, activeStore, interval, func() {
+				renderReady(ctx, cmd, activeStore, filter, mine, true)
+			})
+			return
+		}
+		renderReady(ctx, cmd, activeStore, filter, mine, false)
+	},
+}
+
+// renderReady prints the ready work matching filter in the output format
+// cmd asks for. While watching, each render replaces the last: the screen
+// is cleared, JSON is one line per render, and tips are left out.
+func renderReady(ctx context.Context, cmd *cobra.Command, activeStore *dolt.DoltStore, filter types.WorkFilter, mine, watching bool) {
+	prettyFormat, _ := cmd.Flags().GetBool("pretty")
+	plainFormat, _ := cmd.Flags().GetBool("plain")
+
+	issues, err := activeStore.GetReadyWork(withReadReplica(ctx, activeStore), filter)
+	if err != nil {
+		if watching {
+			WarnError("refreshing ready work: %v", err) // Try again at the next change
+			return
+		}
+		FatalError("%v", err)
+	}
+	if porcelainFormat(cmd) != "" {
+		if err := writePorcelainIssues(ctx, os.Stdout, activeStore, issues); err != nil {
 			FatalError("%v", err)
 		}
-		if jsonOutput {
-			// Always output array, even if empty
-			if issues == nil {
-				issues = []*types.Issue{}
-			}
-			issueIDs := make([]string, len(issues))
-			for i, issue := range issues {
-				issueIDs[i] = issue.ID
-			}
-			commentCounts, _ := activeStore.GetCommentCounts(ctx, issueIDs) // Best effort: comment counts are supplementary display info
-			issuesWithCounts := make([]*types.IssueWithCounts, len(issues))
-			for i, issue := range issues {
-				issuesWithCounts[i] = &types.IssueWithCounts{
-					Issue:        issue,
-					CommentCount: commentCounts[issue.ID],
-				}
+		return
+	}
+	if jsonOutput {
+		// Always output array, even if empty
+		if issues == nil {
+			issues = []*types.Issue{}
+		}
+		issueIDs := make([]string, len(issues))
+		for i, issue := range issues {
+			issueIDs[i] = issue.ID
+		}
+		commentCounts, _ := activeStore.GetCommentCounts(ctx, issueIDs) // Best effort: comment counts are supplementary display info
+		voteCounts, _ := activeStore.GetVoteCounts(ctx, issueIDs)       // Best effort: vote counts are supplementary display info
+		issuesWithCounts := make([]*types.IssueWithCounts, len(issues))
+		for i, issue := range issues {
+			issuesWithCounts[i] = &types.IssueWithCounts{
+				Issue:        issue,
+				CommentCount: commentCounts[issue.ID],
+				VoteCount:    voteCounts[issue.ID],
 			}
-			outputJSON(issuesWithCounts)
+		}
+		if watching {
+			_ = json.NewEncoder(os.Stdout).Encode(issuesWithCounts) // One line per refresh
 			return
 		}
+		outputJSON(issuesWithCounts)
+		return
+	}
+	if watching {
+		fmt.Print("\033[2J\033[H")
+		fmt.Println(ui.RenderMuted(fmt.Sprintf("Ready work at %s, watching for changes (Ctrl+C to exit)", time.Now().Format("15:04:05"))))
+	} else {
 		// Show upgrade notification if needed
 		maybeShowUpgradeNotification()
+	}
 
-		if len(issues) == 0 {
-			// Check if there are any open issues at all
-			hasOpenIssues := false
-			if stats, statsErr := activeStore.GetStatistics(ctx); statsErr == nil {
-				hasOpenIssues = stats.OpenIssues > 0 || stats.InProgressIssues > 0
-			}
-			if hasOpenIssues {
-				fmt.Printf("\n%s No ready work found (all issues have blocking dependencies)\n\n",
-					ui.RenderWarn("✨"))
-			} else {
-				fmt.Printf("\n%s No open issues\n\n", ui.RenderPass("✨"))
-			}
-			// Show tip even when no ready work found
+	// Availability: warn if you're away, and note issues whose assignee is
+	away := awayToday(ctx, activeStore)
+	if u := away[actor]; mine && u != nil {
+		fmt.Printf("\n%s You are marked away until %s (bd availability)\n", ui.RenderWarn("⚠"), u.End)
+	}
+
+	if len(issues) == 0 {
+		// Check if there are any open issues at all
+		hasOpenIssues := false
+		if stats, statsErr := activeStore.GetStatistics(ctx); statsErr == nil {
+			hasOpenIssues = stats.OpenIssues > 0 || stats.InProgressIssues > 0
+		}
+		if hasOpenIssues {
+			fmt.Printf("\n%s No ready work found (all issues have blocking dependencies)\n\n",
+				ui.RenderWarn("✨"))
+		} else {
+			fmt.Printf("\n%s No open issues\n\n", ui.RenderPass("✨"))
+		}
+		// Show tip even when no ready work found
+		if !watching {
 			maybeShowTip(store)
-			return
 		}
-		// Check if results were truncated by the limit
-		totalReady := len(issues)
-		truncated := false
-		if filter.Limit > 0 && len(issues) == filter.Limit {
-			// Re-query without limit to get total count
-			countFilter := filter
-			countFilter.Limit = 0
-			allIssues, countErr := activeStore.GetReadyWork(ctx, countFilter)
-			if countErr == nil && len(allIssues) > len(issues) {
-				totalReady = len(allIssues)
-				truncated = true
-			}
+		return
+	}
+	// Check if results were truncated by the limit
+	totalReady := len(issues)
+	truncated := false
+	if filter.Limit > 0 && len(issues) == filter.Limit {
+		// Re-query without limit to get total count
+		countFilter := filter
+		countFilter.Limit = 0
+		allIssues, countErr := activeStore.GetReadyWork(ctx, countFilter)
+		if countErr == nil && len(allIssues) > len(issues) {
+			totalReady = len(allIssues)
+			truncated = true
 		}
+	}
 
-		// Build parent epic map for pretty display
-		parentEpicMap := buildParentEpicMap(ctx, activeStore, issues)
-
-		// Determine display mode: --plain or --pretty=false triggers plain format
-		usePlain := plainFormat || !prettyFormat
-		if usePlain {
-			fmt.Printf("\n%s Ready work (%d issues with no active blockers):\n\n", ui.RenderAccent("📋"), len(issues))
-			for i, issue := range issues {
-				fmt.Printf("%d. [%s] [%s] %s: %s\n", i+1,
-					ui.RenderPriority(issue.Priority),
-					ui.RenderType(string(issue.IssueType)),
-					ui.RenderID(issue.ID), issue.Title)
-				if issue.EstimatedMinutes != nil {
-					fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
-				}
-				if issue.Assignee != "" {
-					fmt.Printf("   Assignee: %s\n", issue.Assignee)
-				}
+	// Build parent epic map for pretty display
+	parentEpicMap := buildParentEpicMap(ctx, activeStore, issues)
+
+	// Determine display mode: --plain or --pretty=false triggers plain format
+	usePlain := plainFormat || !prettyFormat
+	if usePlain {
+		fmt.Printf("\n%s Ready work (%d issues with no active blockers):\n\n", ui.RenderAccent("📋"), len(issues))
+		for i, issue := range issues {
+			fmt.Printf("%d. [%s] [%s] %s: %s\n", i+1,
+				ui.RenderPriority(issue.Priority),
+				ui.RenderType(string(issue.IssueType)),
+				ui.RenderID(issue.ID), issue.Title)
+			if issue.EstimatedMinutes != nil {
+				fmt.Printf("   Estimate: %d min\n", *issue.EstimatedMinutes)
+			}
+			if issue.Assignee != "" {
+				fmt.Printf("   Assignee: %s\n", issue.Assignee)
+			}
+			if u := away[issue.Assignee]; u != nil {
+				fmt.Printf("   %s\n", ui.RenderWarn(awayNote(u)))
 			}
-			fmt.Println()
-		} else {
-			displayReadyList(issues, parentEpicMap)
 		}
+		fmt.Println()
+	} else {
+		displayReadyList(issues, parentEpicMap)
+		printAwayAssignees(issues, away)
+	}
 
-		// Show truncation footer if results were limited
-		if truncated {
-			fmt.Printf("%s\n\n", ui.RenderMuted(fmt.Sprintf("Showing %d of %d ready issues. Use -n to show more.", len(issues), totalReady)))
-		}
+	// Show truncation footer if results were limited
+	if truncated {
+		fmt.Printf("%s\n\n", ui.RenderMuted(fmt.Sprintf("Showing %d of %d ready issues. Use -n to show more.", len(issues), totalReady)))
+	}
 
-		// Show tip after successful ready (direct mode only)
+	// Show tip after successful ready (direct mode only)
+	if !watching {
 		maybeShowTip(store)
-	},
+	}
 }
+
 var blockedCmd = &cobra.Command{
-	Use:   "blocked",
-	Short: "Show blocked issues",
+	Use:   "blocked [id]",
+	Short: "Show blocked issues, or why one issue is not ready",
+	Long: `Show blocked issues and what blocks them.
+
+With an issue ID, explain why that issue is not in 'bd ready': its open
+blockers, including the blockers of those blockers, a defer_until on it or
+its parent, and anything else that keeps it out, such as its status.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		// Use global jsonOutput set by PersistentPreRun (respects config.yaml + env vars)
 		// Use factory to respect backend configuration (bd-m2jr: SQLite fallback fix)
 		ctx := rootCtx
+		if len(args) == 1 {
+			runBlockingChain(ctx, args[0])
+			return
+		}
 		parentID, _ := cmd.Flags().GetString("parent")
 		var blockedFilter types.WorkFilter
 		if parentID != "" {
@@ -231,6 +372,16 @@ var blockedCmd = &cobra.Command{
 		if err != nil {
 			FatalErrorRespectJSON("%v", err)
 		}
+		if porcelainFormat(cmd) != "" {
+			issues := make([]*types.Issue, len(blocked))
+			for i, b := range blocked {
+				issues[i] = &b.Issue
+			}
+			if err := writePorcelainIssues(ctx, os.Stdout, store, issues); err != nil {
+				FatalError("%v", err)
+			}
+			return
+		}
 		if jsonOutput {
 			// Always output array, even if empty
 			if blocked == nil {
@@ -244,6 +395,7 @@ var blockedCmd = &cobra.Command{
 			return
 		}
 		fmt.Printf("\n%s Blocked issues (%d):\n\n", ui.RenderFail("🚫"), len(blocked))
+		away := awayToday(ctx, store)
 		for _, issue := range blocked {
 			fmt.Printf("[%s] %s: %s\n",
 				ui.RenderPriority(issue.Priority),
@@ -256,11 +408,73 @@ var blockedCmd = &cobra.Command{
 			resolved := resolveBlockedByRefs(ctx, blockedBy)
 			fmt.Printf("  Blocked by %d open dependencies: %v\n",
 				issue.BlockedByCount, resolved)
+			if u := away[issue.Assignee]; u != nil {
+				fmt.Printf("  %s\n", ui.RenderWarn("Assignee "+awayNote(u)))
+			}
 			fmt.Println()
 		}
 	},
 }
 
+// runBlockingChain shows why one issue is not ready work.
+func runBlockingChain(ctx context.Context, ref string) {
+	id, err := utils.ResolvePartialID(ctx, store, ref)
+	if err != nil {
+		FatalErrorRespectJSON("resolving %s: %v", ref, err)
+	}
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	chain, err := store.GetBlockingChain(ctx, id)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	// bd ready shows only open issues unless status.ready is configured
+	if status := readyStatusFilter(ctx); status != "" && issue.Status != status && chain.Ready {
+		chain.Ready = false
+		chain.Reasons = append(chain.Reasons, fmt.Sprintf("status is %s (bd ready lists %s issues)", issue.Status, status))
+	}
+	if jsonOutput {
+		outputJSON(chain)
+		return
+	}
+
+	fmt.Printf("\n%s: %s\n", ui.RenderID(issue.ID), issue.Title)
+	if chain.Ready {
+		fmt.Printf("\n%s Ready: nothing is holding it back\n\n", ui.RenderPass("✓"))
+		return
+	}
+	fmt.Println()
+	for _, reason := range chain.Reasons {
+		fmt.Printf("  %s %s\n", ui.RenderWarn("•"), reason)
+	}
+	if chain.DeferUntil != nil {
+		by := ""
+		if chain.DeferredBy != issue.ID {
+			by = " (its parent " + chain.DeferredBy + ")"
+		}
+		fmt.Printf("  %s Deferred until %s%s\n", ui.RenderWarn("•"), chain.DeferUntil.Local().Format("2006-01-02 15:04"), by)
+	}
+	if len(chain.Blockers) > 0 {
+		fmt.Printf("  %s Blocked by %d open issues:\n", ui.RenderFail("•"), len(chain.Blockers))
+		children := make(map[string][]*types.Blocker)
+		for _, b := range chain.Blockers {
+			children[b.Blocks] = append(children[b.Blocks], b)
+		}
+		var show func(id string)
+		show = func(id string) {
+			for _, b := range children[id] {
+				fmt.Printf("%s[%s] %s (%s): %s\n", strings.Repeat("  ", b.Depth+1),
+					ui.RenderPriority(b.Issue.Priority), ui.RenderID(b.Issue.ID), b.Issue.Status, b.Issue.Title)
+				show(b.Issue.ID)
+			}
+		}
+		show(issue.ID)
+	}
+	fmt.Println()
+}
+
 // buildParentEpicMap builds a map from child issue ID to parent epic title.
 // Only includes parents that are epics.
 func buildParentEpicMap(ctx context.Context, s *dolt.DoltStore, issues []*types.Issue) map[string]string {
@@ -332,6 +546,21 @@ func displayReadyList(issues []*types.Issue, parentEpicMap map[string]string) {
 	fmt.Println("Status: ○ open  ◐ in_progress  ● blocked  ✓ closed  ❄ deferred")
 }
 
+// printAwayAssignees lists the ready issues whose assignee is away today,
+// after the pretty ready list.
+func printAwayAssignees(issues []*types.Issue, away map[string]*types.Unavailability) {
+	var lines []string
+	for _, issue := range issues {
+		if u := away[issue.Assignee]; u != nil {
+			lines = append(lines, fmt.Sprintf("  %s: %s", ui.RenderID(issue.ID), awayNote(u)))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Printf("%s\n%s\n\n", ui.RenderWarn("Assigned to someone away:"), strings.Join(lines, "\n"))
+}
+
 // runMoleculeReady shows ready steps within a specific molecule
 func runMoleculeReady(_ *cobra.Command, molIDArg string) {
 	ctx := rootCtx
@@ -462,9 +691,12 @@ func init() {
 	readyCmd.Flags().IntP("priority", "p", 0, "Filter by priority")
 	readyCmd.Flags().StringP("assignee", "a", "", "Filter by assignee")
 	readyCmd.Flags().BoolP("unassigned", "u", false, "Show only unassigned issues")
-	readyCmd.Flags().StringP("sort", "s", "priority", "Sort policy: priority (default), hybrid, oldest")
+	readyCmd.Flags().Bool("mine", false, "Show only issues assigned to you (warns if you are marked away)")
+	readyCmd.Flags().StringP("sort", "s", "priority", "Sort policy: priority (default), hybrid (priority, aged by ready.aging.*), oldest, votes")
+	readyCmd.Flags().String("fair", "", "Interleave the sorted queue by epic or label so none fills the top: epic, label, none (default: ready.fairness)")
 	readyCmd.Flags().StringSliceP("label", "l", []string{}, "Filter by labels (AND: must have ALL). Can combine with --label-any")
 	readyCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE). Can combine with --label")
+	readyCmd.Flags().String("milestone", "", "Filter by milestone (issues labelled milestone:<name>)")
 	readyCmd.Flags().StringP("type", "t", "", "Filter by issue type (task, bug, feature, epic, decision, merge-request). Aliases: mr→merge-request, feat→feature, mol→molecule, dec/adr→decision")
 	readyCmd.Flags().String("mol", "", "Filter to steps within a specific molecule")
 	readyCmd.Flags().String("parent", "", "Filter to descendants of this bead/epic")
@@ -473,9 +705,29 @@ func init() {
 	readyCmd.Flags().Bool("plain", false, "Display issues as a plain numbered list")
 	readyCmd.Flags().Bool("include-deferred", false, "Include issues with future defer_until timestamps")
 	readyCmd.Flags().Bool("include-ephemeral", false, "Include ephemeral issues (wisps) in results")
+	readyCmd.Flags().Bool("robot", false, "Claim the next ready issue and print it with its context as one line of JSON (for agent work loops)")
+	registerQuietHoursFlag(readyCmd)
+	readyCmd.Flags().BoolP("watch", "w", false, "Keep running and re-render the list whenever the data changes")
+	readyCmd.Flags().Duration("interval", 2*time.Second, "How often --watch checks for changes")
 	readyCmd.Flags().Bool("gated", false, "Find molecules ready for gate-resume dispatch")
+	addPorcelainFlag(readyCmd)
+	addPorcelainFlag(blockedCmd)
 	readyCmd.Flags().String("rig", "", "Query a different rig's database (e.g., --rig gastown, --rig gt-, --rig gt)")
 	rootCmd.AddCommand(readyCmd)
 	blockedCmd.Flags().String("parent", "", "Filter to descendants of this bead/epic")
 	rootCmd.AddCommand(blockedCmd)
 }
+
+// readyStatusFilter returns the status bd ready asks the store for: only
+// open issues, not in_progress (matches bd list --ready), unless status.ready
+// configures the ready-eligible statuses, in which case it returns "" so the
+// store applies them.
+func readyStatusFilter(ctx context.Context) types.Status {
+	if configured, _ := store.GetConfig(ctx, "status.ready"); configured != "" { // Best effort: unset means open only
+		return ""
+	}
+	if len(config.GetStatusListFromYAML("status.ready")) > 0 {
+		return ""
+	}
+	return types.StatusOpen
+}
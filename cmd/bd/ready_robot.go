@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/steveyegge/beads/internal/acceptance"
+	"github.com/steveyegge/beads/internal/quiethours"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// robotIssue is the bd ready --robot output: the claimed issue with the
+// context an agent needs to start on it, on one line of JSON.
+type robotIssue struct {
+	ID               string                 `json:"id"`
+	Title            string                 `json:"title"`
+	Type             types.IssueType        `json:"type"`
+	Priority         int                    `json:"priority"`
+	Status           types.Status           `json:"status"`
+	Assignee         string                 `json:"assignee"`
+	Labels           []string               `json:"labels"`
+	Description      string                 `json:"description,omitempty"`
+	Design           string                 `json:"design,omitempty"`
+	Notes            string                 `json:"notes,omitempty"`
+	EstimatedMinutes *int                   `json:"estimated_minutes,omitempty"`
+	Parent           *robotRef              `json:"parent,omitempty"`
+	BlockersCleared  []robotRef             `json:"blockers_cleared"`
+	Acceptance       []acceptance.Criterion `json:"acceptance"`
+}
+
+// robotRef is a related issue in bd ready --robot output.
+type robotRef struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	CloseReason string `json:"close_reason,omitempty"`
+}
+
+// runReadyRobot claims the first ready, unassigned issue matching filter
+// for actor and prints it as a robotIssue. Issues claimed or locked by
+// someone else in the meantime, and issues held back by quiet hours, are
+// skipped. With no ready work it prints null and exits with exitNotFound,
+// so agent loops can stop.
+func runReadyRobot(ctx context.Context, s *dolt.DoltStore, filter types.WorkFilter, actor string, quiet []quiethours.Window) {
+	CheckReadonly("ready --robot")
+	filter.Unassigned = true
+	filter.Assignee = nil
+	filter.Limit = 0
+
+	issues, err := s.GetReadyWork(ctx, filter)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	now := time.Now()
+	held := 0
+	for _, issue := range issues {
+		if checkQuietHours(quiet, issue, now) != nil {
+			held++
+			continue
+		}
+		err := s.ClaimIssue(ctx, issue.ID, actor)
+		var locked *dolt.IssueLockedError
+		if errors.Is(err, storage.ErrAlreadyClaimed) || errors.As(err, &locked) {
+			continue
+		}
+		if err != nil {
+			FatalErrorRespectJSON("claiming %s: %v", issue.ID, err)
+		}
+		out, err := buildRobotIssue(ctx, s, issue.ID)
+		if err != nil {
+			FatalErrorRespectJSON("%s was claimed, but loading it failed: %v", issue.ID, err)
+		}
+		data, err := json.Marshal(out)
+		if err != nil {
+			FatalErrorRespectJSON("encoding %s: %v", issue.ID, err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+	if held > 0 {
+		fmt.Fprintf(os.Stderr, "%d ready issue(s) held back by quiet hours (--ignore-quiet-hours to claim anyway)\n", held)
+	}
+	fmt.Println("null")
+	os.Exit(exitNotFound)
+}
+
+// buildRobotIssue loads an issue and its context.
+func buildRobotIssue(ctx context.Context, s *dolt.DoltStore, id string) (*robotIssue, error) {
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.GetLabels(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("loading labels: %w", err)
+	}
+	if labels == nil {
+		labels = []string{}
+	}
+	deps, err := s.GetDependencyRecords(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("loading dependencies: %w", err)
+	}
+
+	out := &robotIssue{
+		ID:               issue.ID,
+		Title:            issue.Title,
+		Type:             issue.IssueType,
+		Priority:         issue.Priority,
+		Status:           issue.Status,
+		Assignee:         issue.Assignee,
+		Labels:           labels,
+		Description:      issue.Description,
+		Design:           issue.Design,
+		Notes:            issue.Notes,
+		EstimatedMinutes: issue.EstimatedMinutes,
+		BlockersCleared:  []robotRef{},
+		Acceptance:       acceptance.Parse(issue.AcceptanceCriteria),
+	}
+
+	// The issue is ready, so its blocking dependencies are all resolved.
+	var parentID string
+	var blockerIDs []string
+	for _, dep := range deps {
+		switch {
+		case dep.Type == types.DepParentChild:
+			parentID = dep.DependsOnID
+		case dep.Type.AffectsReadyWork():
+			blockerIDs = append(blockerIDs, dep.DependsOnID)
+		}
+	}
+	related := blockerIDs
+	if parentID != "" {
+		related = append(related, parentID)
+	}
+	if len(related) == 0 {
+		return out, nil
+	}
+	others, err := s.GetIssuesByIDs(ctx, related)
+	if err != nil {
+		return nil, fmt.Errorf("loading related issues: %w", err)
+	}
+	byID := make(map[string]*types.Issue, len(others))
+	for _, other := range others {
+		byID[other.ID] = other
+	}
+	if parent := byID[parentID]; parent != nil {
+		out.Parent = &robotRef{ID: parent.ID, Title: parent.Title}
+	}
+	for _, blockerID := range blockerIDs {
+		if blocker := byID[blockerID]; blocker != nil {
+			out.BlockersCleared = append(out.BlockersCleared, robotRef{ID: blocker.ID, Title: blocker.Title, CloseReason: blocker.CloseReason})
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage/dolt"
+)
+
+// readyWatchRefresh is how often bd ready --watch re-renders even without
+// writes: readiness also changes with the clock, as deferred issues come due.
+const readyWatchRefresh = time.Minute
+
+// watchReady calls render, then again whenever s's data changes, until
+// ctx is canceled (Ctrl+C). Changes are detected by polling the data
+// version every interval, which sees writes from every client of a shared
+// server; if the version can't be read, it re-renders at every poll.
+func watchReady(ctx context.Context, s *dolt.DoltStore, interval time.Duration, render func()) {
+	// Refreshes repeat the same queries; skip them when nothing changed.
+	s.EnableQueryCache(0)
+
+	last, err := s.DataVersion(ctx)
+	render()
+	rendered := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		version, err2 := s.DataVersion(ctx)
+		if !readyWatchDue(last, err, version, err2, time.Since(rendered)) {
+			continue
+		}
+		last, err = version, err2
+		render()
+		rendered = time.Now()
+	}
+}
+
+// readyWatchDue reports whether bd ready --watch should re-render, given
+// the data version at the last render, the current one (either may have
+// failed to read), and the time since the last render.
+func readyWatchDue(last string, lastErr error, version string, err error, since time.Duration) bool {
+	return err != nil || lastErr != nil || version != last || since >= readyWatchRefresh
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReadyWatchDue(t *testing.T) {
+	failed := errors.New("connection refused")
+	tests := []struct {
+		name    string
+		last    string
+		lastErr error
+		version string
+		err     error
+		since   time.Duration
+		want    bool
+	}{
+		{"unchanged", "abc", nil, "abc", nil, 5 * time.Second, false},
+		{"written", "abc", nil, "def", nil, 5 * time.Second, true},
+		{"clock refresh", "abc", nil, "abc", nil, readyWatchRefresh, true},
+		{"version unreadable", "abc", nil, "", failed, time.Second, true},
+		{"version never read", "", failed, "", failed, time.Second, true},
+	}
+	for _, tt := range tests {
+		if got := readyWatchDue(tt.last, tt.lastErr, tt.version, tt.err, tt.since); got != tt.want {
+			t.Errorf("%s: readyWatchDue = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReadyWatchFlags(t *testing.T) {
+	if f := readyCmd.Flags().Lookup("watch"); f == nil || f.Shorthand != "w" || f.DefValue != "false" {
+		t.Errorf("--watch flag = %+v", f)
+	}
+	if f := readyCmd.Flags().Lookup("interval"); f == nil || f.DefValue != "2s" {
+		t.Errorf("--interval flag = %+v", f)
+	}
+}
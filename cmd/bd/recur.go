@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+var recurCmd = &cobra.Command{
+	Use:     "recur",
+	GroupID: "issues",
+	Short:   "Recurring issues for periodic chores",
+	Long: `Recurring issues are templates that become a fresh open issue on a
+schedule, for maintenance chores agents should pick up periodically, such
+as a weekly dependency audit.
+
+Due recurrences are materialized lazily by any bd command that opens the
+database for writing (set recur.auto-tick to false to turn this off), or
+explicitly with 'bd recur tick', e.g. from cron. An occurrence is created
+once even if several bd processes notice it; missed occurrences are not
+backfilled. If the issue from the previous occurrence is still open, no new
+one is created for that occurrence.
+
+The template is a markdown file in the format 'bd create --file' reads,
+without the "## Title" heading (the title comes from the command line):
+text before the first section is the description, and "### Priority",
+"### Type", "### Description", "### Design", "### Acceptance Criteria",
+"### Assignee" and "### Labels" sections set those fields. The template is
+stored in the database when the recurrence is added.
+
+Examples:
+  bd recur add "Weekly dependency audit" --every 7d --template audit.md
+  bd recur add "Rotate staging keys" --every 1m --type chore -p 1 --start 2026-11-01
+  bd recur list
+  bd recur tick
+  bd recur remove 3`,
+}
+
+var recurAddCmd = &cobra.Command{
+	Use:   "add <title>",
+	Short: "Add a recurring issue",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("recur add")
+		every, _ := cmd.Flags().GetString("every")
+		templatePath, _ := cmd.Flags().GetString("template")
+		startStr, _ := cmd.Flags().GetString("start")
+
+		title := strings.TrimSpace(args[0])
+		if title == "" {
+			FatalErrorCode(exitValidation, "title required")
+		}
+		if err := validateRecurEvery(every); err != nil {
+			FatalErrorCode(exitValidation, "%v", err)
+		}
+		r := &types.Recurrence{Title: title, Every: every, Priority: 2, IssueType: types.TypeTask, CreatedBy: actor}
+		if templatePath != "" {
+			if err := loadRecurTemplate(r, templatePath); err != nil {
+				FatalErrorCode(exitValidation, "--template: %v", err)
+			}
+		}
+		if cmd.Flags().Changed("priority") {
+			p, _ := cmd.Flags().GetString("priority")
+			if r.Priority = validation.ParsePriority(p); r.Priority == -1 {
+				FatalErrorCode(exitValidation, "invalid priority %q (expected 0-4 or P0-P4)", p)
+			}
+		}
+		if cmd.Flags().Changed("type") {
+			t, _ := cmd.Flags().GetString("type")
+			issueType, err := validation.ParseIssueType(t)
+			if err != nil {
+				FatalErrorCode(exitValidation, "%v", err)
+			}
+			r.IssueType = issueType
+		}
+		if labels, _ := cmd.Flags().GetStringSlice("label"); len(labels) > 0 {
+			r.Labels = utils.NormalizeLabels(append(r.Labels, labels...))
+		}
+
+		r.NextAt = time.Now()
+		if startStr != "" {
+			start, err := parseTimeFlag(startStr)
+			if err != nil {
+				FatalErrorCode(exitValidation, "invalid --start: %v", err)
+			}
+			r.NextAt = start
+		}
+		// Whole seconds, as stored, so claiming an occurrence matches exactly
+		r.NextAt = r.NextAt.UTC().Truncate(time.Second)
+
+		if err := store.CreateRecurrence(rootCtx, r); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(r)
+			return
+		}
+		fmt.Printf("%s Added recurrence %d: %s every %s, next %s\n", ui.RenderPass("✓"), r.ID, r.Title, r.Every,
+			r.NextAt.Local().Format("2006-01-02 15:04"))
+	},
+}
+
+var recurListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recurring issues",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		recurrences, err := store.GetRecurrences(rootCtx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			if recurrences == nil {
+				recurrences = []*types.Recurrence{}
+			}
+			outputJSON(recurrences)
+			return
+		}
+		if len(recurrences) == 0 {
+			fmt.Println("No recurring issues (add one with 'bd recur add')")
+			return
+		}
+		for _, r := range recurrences {
+			line := fmt.Sprintf("%3d  %s  every %s, next %s", r.ID, ui.RenderBold(r.Title), r.Every, r.NextAt.Local().Format("2006-01-02 15:04"))
+			if r.LastIssueID != "" {
+				line += " " + ui.RenderMuted("(last: "+r.LastIssueID+")")
+			}
+			fmt.Println(line)
+		}
+	},
+}
+
+var recurRemoveCmd = &cobra.Command{
+	Use:   "remove <id>",
+	Short: "Remove a recurring issue (issues it created are kept)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("recur remove")
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			FatalErrorCode(exitValidation, "invalid recurrence ID %q (see bd recur list)", args[0])
+		}
+		removed, err := store.RemoveRecurrence(rootCtx, id)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if !removed {
+			FatalErrorRespectJSON("no recurrence %d", id)
+		}
+		if jsonOutput {
+			outputJSON(map[string]int64{"removed": id})
+			return
+		}
+		fmt.Printf("%s Removed recurrence %d\n", ui.RenderPass("✓"), id)
+	},
+}
+
+var recurTickCmd = &cobra.Command{
+	Use:   "tick",
+	Short: "Create the issues for recurrences that are due",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("recur tick")
+		results, err := tickRecurrences(rootCtx, time.Now())
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(results)
+			return
+		}
+		if len(results) == 0 {
+			fmt.Println("No recurrences due")
+			return
+		}
+		printRecurTickResults(results)
+	},
+}
+
+func init() {
+	recurAddCmd.Flags().String("every", "", "Interval between occurrences: 12h, 1d, 7d, 2w, 1m (required)")
+	recurAddCmd.Flags().String("template", "", "Markdown file with the issue's fields (see bd recur --help)")
+	recurAddCmd.Flags().String("start", "", "When the first occurrence is due (default: now), e.g. 2026-11-02 09:00 or next monday")
+	recurAddCmd.Flags().StringP("priority", "p", "", "Priority (0-4 or P0-P4), overriding the template")
+	recurAddCmd.Flags().StringP("type", "t", "", "Issue type, overriding the template")
+	recurAddCmd.Flags().StringSliceP("label", "l", []string{}, "Labels, added to the template's")
+	_ = recurAddCmd.MarkFlagRequired("every")
+	recurCmd.AddCommand(recurAddCmd, recurListCmd, recurRemoveCmd, recurTickCmd)
+	rootCmd.AddCommand(recurCmd)
+}
+
+// recurEveryRe matches a recurrence interval: a positive compact duration.
+var recurEveryRe = regexp.MustCompile(`^[1-9][0-9]*[hdwmy]$`)
+
+func validateRecurEvery(every string) error {
+	if !recurEveryRe.MatchString(every) {
+		return fmt.Errorf("invalid --every %q (use e.g. 12h, 1d, 7d, 2w, 1m)", every)
+	}
+	return nil
+}
+
+// nextRecurrence returns the first occurrence after now of a recurrence
+// last due at due, skipping occurrences that were missed.
+func nextRecurrence(every string, due, now time.Time) (time.Time, error) {
+	next := due
+	for !next.After(now) {
+		t, err := timeparsing.ParseCompactDuration("+"+every, next)
+		if err != nil {
+			return time.Time{}, err
+		}
+		next = t
+	}
+	return next, nil
+}
+
+// loadRecurTemplate fills r's fields from a markdown template file.
+func loadRecurTemplate(r *types.Recurrence, path string) error {
+	cleanPath, err := validateMarkdownPath(path)
+	if err != nil {
+		return err
+	}
+	// #nosec G304 -- Path is validated by validateMarkdownPath which prevents traversal
+	content, err := os.ReadFile(cleanPath)
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if h2Regex.MatchString(line) {
+			return fmt.Errorf("%s has a \"## \" heading; the title comes from the command line", path)
+		}
+	}
+	templates, err := parseMarkdown(strings.NewReader("## " + r.Title + "\n" + string(content)))
+	if err != nil {
+		return err
+	}
+	t := templates[0]
+	r.Description, r.Design, r.AcceptanceCriteria = t.Description, t.Design, t.AcceptanceCriteria
+	r.Priority, r.IssueType, r.Assignee, r.Labels = t.Priority, t.IssueType, t.Assignee, t.Labels
+	return nil
+}
+
+// recurTickResult is what bd recur tick did for one due recurrence.
+type recurTickResult struct {
+	Recurrence int64     `json:"recurrence"`
+	Title      string    `json:"title"`
+	IssueID    string    `json:"issue_id,omitempty"` // Issue created
+	StillOpen  string    `json:"still_open,omitempty"`
+	NextAt     time.Time `json:"next_at"`
+}
+
+// tickRecurrences creates an issue for each recurrence due at now and
+// moves it to its next occurrence. An occurrence whose previous issue is
+// still open is skipped rather than creating a duplicate.
+func tickRecurrences(ctx context.Context, now time.Time) ([]recurTickResult, error) {
+	recurrences, err := store.GetRecurrences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := []recurTickResult{}
+	for _, r := range recurrences {
+		if r.NextAt.After(now) {
+			continue
+		}
+		next, err := nextRecurrence(r.Every, r.NextAt, now)
+		if err != nil {
+			return results, fmt.Errorf("recurrence %d: %w", r.ID, err)
+		}
+		claimed, err := store.ClaimRecurrence(ctx, r.ID, r.NextAt, next)
+		if err != nil {
+			return results, err
+		}
+		if !claimed {
+			continue // Another bd process got it
+		}
+		result := recurTickResult{Recurrence: r.ID, Title: r.Title, NextAt: next}
+
+		if r.LastIssueID != "" {
+			if last, err := store.GetIssue(ctx, r.LastIssueID); err == nil && last != nil && last.Status != types.StatusClosed {
+				result.StillOpen = last.ID
+				results = append(results, result)
+				continue
+			}
+		}
+		issue := &types.Issue{
+			Title:              r.Title,
+			Description:        r.Description,
+			Design:             r.Design,
+			AcceptanceCriteria: r.AcceptanceCriteria,
+			Status:             types.StatusOpen,
+			Priority:           r.Priority,
+			IssueType:          r.IssueType,
+			Assignee:           r.Assignee,
+		}
+		if err := store.CreateIssue(ctx, issue, r.CreatedBy); err != nil {
+			return results, fmt.Errorf("creating issue for recurrence %d: %w", r.ID, err)
+		}
+		for _, label := range r.Labels {
+			if err := store.AddLabel(ctx, issue.ID, label, r.CreatedBy); err != nil {
+				return results, fmt.Errorf("adding label %s to %s: %w", label, issue.ID, err)
+			}
+		}
+		if err := store.SetRecurrenceLastIssue(ctx, r.ID, issue.ID); err != nil {
+			return results, err
+		}
+		result.IssueID = issue.ID
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func printRecurTickResults(results []recurTickResult) {
+	for _, res := range results {
+		if res.IssueID != "" {
+			fmt.Printf("%s Created %s: %s\n", ui.RenderPass("✓"), res.IssueID, res.Title)
+		} else {
+			fmt.Printf("%s Skipped %q: %s is still open\n", ui.RenderMuted("→"), res.Title, res.StillOpen)
+		}
+	}
+}
+
+// maybeTickRecurrences materializes due recurrences as a side effect of
+// an ordinary command, unless recur.auto-tick is off or the database is
+// read-only. Failures never fail the command.
+func maybeTickRecurrences(cmd *cobra.Command) {
+	if cmd == recurTickCmd || readonlyMode || storeIsReadOnly || !config.GetBool("recur.auto-tick") {
+		return
+	}
+	results, err := tickRecurrences(rootCtx, time.Now())
+	if err != nil {
+		debug.Logf("warning: failed to tick recurrences: %v", err)
+	}
+	for _, res := range results {
+		if res.IssueID != "" && !quietFlag && !jsonOutput {
+			fmt.Fprintf(os.Stderr, "%s Created recurring issue %s: %s\n", ui.RenderMuted("→"), res.IssueID, res.Title)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestNextRecurrence(t *testing.T) {
+	due := time.Date(2026, 10, 5, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		every string
+		now   time.Time
+		want  time.Time
+	}{
+		{"7d", due, due.AddDate(0, 0, 7)},
+		{"7d", due.Add(-time.Hour), due},                     // Not due yet
+		{"7d", due.AddDate(0, 0, 20), due.AddDate(0, 0, 21)}, // Missed ones are skipped
+		{"1m", due.AddDate(0, 0, 1), due.AddDate(0, 1, 0)},
+		{"12h", due.Add(13 * time.Hour), due.Add(24 * time.Hour)},
+	}
+	for _, tt := range tests {
+		got, err := nextRecurrence(tt.every, due, tt.now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("nextRecurrence(%s, now %v) = %v, %v; want %v", tt.every, tt.now, got, err, tt.want)
+		}
+	}
+}
+
+func TestValidateRecurEvery(t *testing.T) {
+	for _, ok := range []string{"12h", "1d", "7d", "2w", "1m", "1y"} {
+		if err := validateRecurEvery(ok); err != nil {
+			t.Errorf("validateRecurEvery(%q) = %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"", "0d", "+7d", "-1d", "7", "7days", "1h30m"} {
+		if validateRecurEvery(bad) == nil {
+			t.Errorf("validateRecurEvery(%q) accepted", bad)
+		}
+	}
+}
+
+func TestLoadRecurTemplate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.md")
+	content := "Check for vulnerable dependencies.\n\n### Priority\n1\n\n### Type\nchore\n\n### Labels\nmaintenance, deps\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	r := &types.Recurrence{Title: "Weekly dependency audit"}
+	if err := loadRecurTemplate(r, path); err != nil {
+		t.Fatalf("loadRecurTemplate: %v", err)
+	}
+	if r.Title != "Weekly dependency audit" || r.Description != "Check for vulnerable dependencies." ||
+		r.Priority != 1 || r.IssueType != types.TypeChore || !reflect.DeepEqual(r.Labels, []string{"maintenance", "deps"}) {
+		t.Errorf("recurrence from template = %+v", r)
+	}
+
+	titled := filepath.Join(dir, "titled.md")
+	if err := os.WriteFile(titled, []byte("## Another title\nBody\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadRecurTemplate(&types.Recurrence{Title: "x"}, titled); err == nil {
+		t.Error("loadRecurTemplate accepted a template with its own ## title")
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/refs"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var linkRefsCmd = &cobra.Command{
+	Use:   "link-refs",
+	Short: "Link issues to the issues their text and comments mention",
+	Long: `Scan every issue's text and comments for references to other issues
+("see bd-a3f") and add a relates_to link for each, in both directions.
+
+New and edited text is linked as it is written (references.auto-link in
+config.yaml); run this once to link text written before that, or after
+turning auto-linking back on. Pairs that are already linked in any way,
+including by a blocking or parent-child dependency, are left alone.
+
+Examples:
+  bd dep link-refs --dry-run    # Show the links that would be added
+  bd dep link-refs`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if !dryRun {
+			CheckReadonly("dep link-refs")
+		}
+
+		issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+		if err != nil {
+			FatalErrorRespectJSON("loading issues: %v", err)
+		}
+		exists := make(map[string]bool, len(issues))
+		ids := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			exists[issue.ID] = true
+			ids = append(ids, issue.ID)
+		}
+		comments, err := store.GetCommentsForIssues(ctx, ids)
+		if err != nil {
+			FatalErrorRespectJSON("loading comments: %v", err)
+		}
+		allDeps, err := store.GetAllDependencyRecords(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("loading dependencies: %v", err)
+		}
+		linked := make(map[[2]string]bool)
+		for _, deps := range allDeps {
+			for _, dep := range deps {
+				linked[referencePair(dep.IssueID, dep.DependsOnID)] = true
+			}
+		}
+
+		finder := referenceFinder(ctx, store)
+		var added []referenceLink
+		for _, issue := range issues {
+			texts := issueTexts(issue)
+			for _, c := range comments[issue.ID] {
+				texts = append(texts, c.Text)
+			}
+			for _, target := range referencesToLink(issue.ID, finder.Find(texts...), exists, linked) {
+				if !dryRun {
+					if err := relateIssues(ctx, store, issue.ID, target); err != nil {
+						FatalErrorRespectJSON("%v", err)
+					}
+				}
+				linked[referencePair(issue.ID, target)] = true
+				added = append(added, referenceLink{From: issue.ID, To: target})
+			}
+		}
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"links": added, "dry_run": dryRun})
+			return
+		}
+		if len(added) == 0 {
+			fmt.Println("No unlinked references found")
+			return
+		}
+		for _, l := range added {
+			fmt.Printf("  %s %s %s\n", ui.RenderID(l.From), ui.Glyph("↔"), ui.RenderID(l.To))
+		}
+		if dryRun {
+			fmt.Printf("\nWould link %d reference(s) (dry run)\n", len(added))
+			return
+		}
+		fmt.Printf("\n%s Linked %d reference(s)\n", ui.RenderPass("✓"), len(added))
+	},
+}
+
+// referenceLink is a relates_to link added for a reference in issue text.
+type referenceLink struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// referenceFinder returns a Finder for the IDs of issues in s: the
+// database's issue prefix and any allowed_prefixes.
+func referenceFinder(ctx context.Context, s *dolt.DoltStore) *refs.Finder {
+	prefix, _ := s.GetConfig(ctx, "issue_prefix") // Best effort: falls back to config.yaml
+	if prefix == "" {
+		prefix = config.GetString("issue-prefix")
+	}
+	allowed, _ := s.GetConfig(ctx, "allowed_prefixes") // Best effort: empty means the issue prefix only
+	return refs.NewFinder(append([]string{prefix}, strings.Split(allowed, ",")...)...)
+}
+
+// issueTexts returns the issue's free-text fields.
+func issueTexts(issue *types.Issue) []string {
+	return []string{issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes}
+}
+
+// referencePair returns a key for the link between a and b in either direction.
+func referencePair(a, b string) [2]string {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
+
+// referencesToLink returns the referenced IDs that should get a relates_to
+// link with issueID: those naming an existing issue other than issueID that
+// is not already linked with it in either direction. Existing links of any
+// type are kept as they are, so a blocking dependency is never relabelled.
+func referencesToLink(issueID string, ids []string, exists map[string]bool, linked map[[2]string]bool) []string {
+	var out []string
+	for _, id := range ids {
+		if id != issueID && exists[id] && !linked[referencePair(issueID, id)] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// relateIssues adds a relates_to link between a and b in both directions,
+// as bd dep relate does.
+func relateIssues(ctx context.Context, s *dolt.DoltStore, a, b string) error {
+	for _, dep := range []*types.Dependency{
+		{IssueID: a, DependsOnID: b, Type: types.DepRelatesTo},
+		{IssueID: b, DependsOnID: a, Type: types.DepRelatesTo},
+	} {
+		if err := s.AddDependency(ctx, dep, actor); err != nil {
+			return fmt.Errorf("failed to add relates-to %s -> %s: %w", dep.IssueID, dep.DependsOnID, err)
+		}
+	}
+	return nil
+}
+
+// linkReferences relates issueID to the issues mentioned in texts that it
+// is not yet linked with, when references.auto-link is on. It is best
+// effort: the text is already written, so failures are only warnings.
+func linkReferences(ctx context.Context, s *dolt.DoltStore, issueID string, texts ...string) {
+	if !config.ReferencesAutoLink() {
+		return
+	}
+	ids := referenceFinder(ctx, s).Find(texts...)
+	if len(ids) == 0 {
+		return
+	}
+	found, err := s.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		WarnError("failed to look up referenced issues: %v", err)
+		return
+	}
+	exists := make(map[string]bool, len(found))
+	for _, issue := range found {
+		exists[issue.ID] = true
+	}
+	deps, err := s.GetDependencyRecordsForIssues(ctx, append(ids, issueID))
+	if err != nil {
+		WarnError("failed to look up links of %s: %v", issueID, err)
+		return
+	}
+	linked := make(map[[2]string]bool)
+	for _, records := range deps {
+		for _, dep := range records {
+			linked[referencePair(dep.IssueID, dep.DependsOnID)] = true
+		}
+	}
+
+	var added []string
+	for _, target := range referencesToLink(issueID, ids, exists, linked) {
+		if err := relateIssues(ctx, s, issueID, target); err != nil {
+			WarnError("%v", err)
+			continue
+		}
+		added = append(added, target)
+	}
+	if len(added) > 0 && !jsonOutput {
+		fmt.Fprintf(os.Stderr, "%s\n", ui.RenderMuted(fmt.Sprintf("Related %s to referenced %s", issueID, strings.Join(added, ", "))))
+	}
+}
+
+// referenceHighlighter returns a function that styles the references in
+// rendered text to existing issues in s, so they stand out as IDs to open
+// with bd show. Mentions of IDs that don't exist are left plain.
+func referenceHighlighter(ctx context.Context, s *dolt.DoltStore, texts ...string) func(string) string {
+	finder := referenceFinder(ctx, s)
+	ids := finder.Find(texts...)
+	if len(ids) == 0 {
+		return func(text string) string { return text }
+	}
+	exists := make(map[string]bool)
+	if found, err := s.GetIssuesByIDs(ctx, ids); err == nil {
+		for _, issue := range found {
+			exists[issue.ID] = true
+		}
+	}
+	return func(text string) string {
+		return finder.Replace(text, func(id string) string {
+			if exists[id] {
+				return ui.RenderID(id)
+			}
+			return id
+		})
+	}
+}
+
+func init() {
+	linkRefsCmd.Flags().Bool("dry-run", false, "Show the links that would be added without adding them")
+	depCmd.AddCommand(linkRefsCmd)
+}
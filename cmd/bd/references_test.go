@@ -0,0 +1,24 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReferencesToLink(t *testing.T) {
+	exists := map[string]bool{"bd-1": true, "bd-2": true, "bd-3": true, "bd-4": true}
+	linked := map[[2]string]bool{
+		referencePair("bd-1", "bd-2"): true, // bd-1 -> bd-2, any type
+		referencePair("bd-3", "bd-1"): true, // bd-3 -> bd-1, the other direction
+	}
+	got := referencesToLink("bd-1", []string{"bd-1", "bd-2", "bd-3", "bd-4", "bd-missing"}, exists, linked)
+	if want := []string{"bd-4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("referencesToLink = %q, want %q", got, want)
+	}
+}
+
+func TestReferencePair(t *testing.T) {
+	if referencePair("bd-2", "bd-1") != referencePair("bd-1", "bd-2") {
+		t.Error("referencePair should not depend on direction")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/resolution"
 	"github.com/steveyegge/beads/internal/routing"
 	"github.com/steveyegge/beads/internal/storage/dolt"
 	"github.com/steveyegge/beads/internal/types"
@@ -100,6 +101,7 @@ Examples:
 			Assignee:           sourceIssue.Assignee,
 			ExternalRef:        sourceIssue.ExternalRef,
 			EstimatedMinutes:   sourceIssue.EstimatedMinutes,
+			ActualMinutes:      sourceIssue.ActualMinutes,
 			SourceRepo:         sourceIssue.SourceRepo,
 			Ephemeral:          sourceIssue.Ephemeral,
 			MolType:            sourceIssue.MolType,
@@ -130,7 +132,7 @@ Examples:
 
 		// Step 7: Close the source issue (unless --keep-open)
 		if !keepOpen {
-			closeReason := fmt.Sprintf("Refiled to %s", newIssue.ID)
+			closeReason := resolution.Resolution{Category: resolution.SupersededBy, Target: newIssue.ID, Note: "refiled"}.String()
 			if err := result.Store.CloseIssue(ctx, resolvedSourceID, closeReason, actor, ""); err != nil {
 				WarnError("failed to close source issue: %v", err)
 			}
@@ -3,8 +3,11 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/hooks"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/utils"
@@ -15,7 +18,23 @@ var reopenCmd = &cobra.Command{
 	GroupID: "issues",
 	Short:   "Reopen one or more closed issues",
 	Long: `Reopen closed issues by setting status to 'open' and clearing the closed_at timestamp.
-This is more explicit than 'bd update --status open' and emits a Reopened event.`,
+This is more explicit than 'bd update --status open' and emits a Reopened event.
+
+Every reopen is counted; 'bd report reopens' lists the issues that bounce,
+which often points at unclear acceptance criteria. The --reason is added as
+a comment.
+
+To notify whoever closed the issue, add an executable
+.beads/hooks/on_reopen hook. It gets the issue ID and "reopen" as
+arguments, the issue JSON on stdin, and these environment variables:
+
+  BD_REOPEN_CLOSER   Actor who last closed the issue
+  BD_REOPEN_REASON   The --reason, if any
+  BD_REOPEN_COUNT    How many times the issue has now been reopened
+
+Examples:
+  bd reopen bd-12 --reason "Still fails on Windows"
+  bd report reopens`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("reopen")
@@ -39,6 +58,25 @@ This is more explicit than 'bd update --status open' and emits a Reopened event.
 				fmt.Fprintf(os.Stderr, "Error resolving %s: %v\n", id, err)
 				continue
 			}
+			issue, err := store.GetIssue(ctx, fullID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reopening %s: %v\n", fullID, err)
+				continue
+			}
+			if issue.Status != types.StatusClosed {
+				fmt.Fprintf(os.Stderr, "Error reopening %s: it is %s, not closed\n", fullID, issue.Status)
+				continue
+			}
+			history, err := store.GetReopenHistory(ctx, []string{fullID})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reopening %s: %v\n", fullID, err)
+				continue
+			}
+			previous := history[fullID]
+			if previous == nil {
+				previous = &types.ReopenHistory{}
+			}
+
 			// UpdateIssue automatically clears closed_at when status changes from closed
 			updates := map[string]interface{}{
 				"status": string(types.StatusOpen),
@@ -53,17 +91,20 @@ This is more explicit than 'bd update --status open' and emits a Reopened event.
 					fmt.Fprintf(os.Stderr, "Warning: failed to add comment to %s: %v\n", fullID, err)
 				}
 			}
+			reopens := previous.Reopens + 1
+			reopened, _ := store.GetIssue(ctx, fullID)
+			notified := notifyReopen(reopened, previous.LastClosedBy, reason, reopens)
 			if jsonOutput {
-				issue, _ := store.GetIssue(ctx, fullID)
-				if issue != nil {
-					reopenedIssues = append(reopenedIssues, issue)
+				if reopened != nil {
+					reopenedIssues = append(reopenedIssues, reopened)
 				}
 			} else {
 				reasonMsg := ""
 				if reason != "" {
 					reasonMsg = ": " + reason
 				}
-				fmt.Printf("%s Reopened %s%s\n", ui.RenderAccent("↻"), fullID, reasonMsg)
+				fmt.Printf("%s Reopened %s%s %s\n", ui.RenderAccent("↻"), fullID, reasonMsg,
+					ui.RenderMuted(describeReopen(reopens, previous.LastClosedBy, notified)))
 			}
 		}
 		if jsonOutput && len(reopenedIssues) > 0 {
@@ -77,3 +118,40 @@ func init() {
 	reopenCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(reopenCmd)
 }
+
+// notifyReopen runs the on_reopen hook so it can tell closer the issue is
+// back. It reports whether the hook ran.
+func notifyReopen(issue *types.Issue, closer, reason string, reopens int) bool {
+	if issue == nil || hookRunner == nil || !hookRunner.HookExists(hooks.EventReopen) {
+		return false
+	}
+	if err := hookRunner.RunSyncEnv(hooks.EventReopen, issue,
+		"BD_REOPEN_CLOSER="+closer,
+		"BD_REOPEN_REASON="+reason,
+		"BD_REOPEN_COUNT="+strconv.Itoa(reopens)); err != nil {
+		WarnError("on_reopen hook failed for %s: %v", issue.ID, err)
+		return false
+	}
+	return true
+}
+
+// describeReopen summarizes a reopen for bd reopen's output, e.g.
+// "(reopened 2 times, closed by alice, notified)".
+func describeReopen(reopens int, closer string, notified bool) string {
+	parts := []string{"reopened " + pluralTimes(reopens)}
+	if closer != "" {
+		parts = append(parts, "closed by "+closer)
+	}
+	if notified {
+		parts = append(parts, "notified")
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// pluralTimes renders a count of occurrences: "once", "2 times".
+func pluralTimes(n int) string {
+	if n == 1 {
+		return "once"
+	}
+	return fmt.Sprintf("%d times", n)
+}
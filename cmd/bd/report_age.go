@@ -0,0 +1,288 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var reportCmd = &cobra.Command{
+	Use:     "report",
+	GroupID: "views",
+	Short:   "Backlog reports",
+}
+
+var reportAgeCmd = &cobra.Command{
+	Use:   "age",
+	Short: "Bucket open issues by age, per label and assignee",
+	Long: `Bucket open issues by age (time since creation) per label and per
+assignee, to drive backlog grooming:
+
+  week     created within the last 7 days
+  month    within the last 30 days
+  quarter  within the last 90 days
+  older    more than 90 days ago
+
+Issues with several labels count under each. Below the tables, the P0-P1
+issues that have gone longest without an update are listed.
+
+Examples:
+  bd report age                 # By label and by assignee
+  bd report age --by assignee   # Assignees only
+  bd report age --top 10 --json`,
+	Run: runReportAge,
+}
+
+func init() {
+	reportAgeCmd.Flags().String("by", "both", "Group by label, assignee, or both")
+	reportAgeCmd.Flags().Int("top", 5, "Number of oldest untouched P0-P1 issues to list (0 = none)")
+	reportCmd.AddCommand(reportAgeCmd)
+	rootCmd.AddCommand(reportCmd)
+}
+
+// Age buckets, youngest first.
+var ageBuckets = []string{"week", "month", "quarter", "older"}
+
+// ageBucketOf buckets an issue by how long ago it was created.
+func ageBucketOf(issue *types.Issue, now time.Time) string {
+	switch age := now.Sub(issue.CreatedAt); {
+	case age <= 7*24*time.Hour:
+		return "week"
+	case age <= 30*24*time.Hour:
+		return "month"
+	case age <= 90*24*time.Hour:
+		return "quarter"
+	default:
+		return "older"
+	}
+}
+
+// ageGroup is one label's or assignee's row of the age report.
+type ageGroup struct {
+	Name       string         `json:"name"`
+	Counts     map[string]int `json:"counts"`
+	Total      int            `json:"total"`
+	OldestDays int            `json:"oldest_days"`
+}
+
+// untouchedIssue is a high-priority issue that hasn't been updated lately.
+type untouchedIssue struct {
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	Priority      int    `json:"priority"`
+	Assignee      string `json:"assignee,omitempty"`
+	AgeDays       int    `json:"age_days"`
+	UntouchedDays int    `json:"untouched_days"`
+}
+
+// ageReport is the output of bd report age.
+type ageReport struct {
+	Buckets    []string         `json:"buckets"`
+	Total      int              `json:"total"`
+	Overall    ageGroup         `json:"overall"`
+	ByLabel    []ageGroup       `json:"by_label,omitempty"`
+	ByAssignee []ageGroup       `json:"by_assignee,omitempty"`
+	Untouched  []untouchedIssue `json:"oldest_untouched_high_priority"`
+}
+
+// buildAgeReport buckets issues by age. labels maps issue IDs to their
+// labels; byLabel and byAssignee select the groupings, and top is how many
+// untouched P0-P1 issues to list.
+func buildAgeReport(issues []*types.Issue, labels map[string][]string, now time.Time, byLabel, byAssignee bool, top int) *ageReport {
+	r := &ageReport{Buckets: ageBuckets, Untouched: []untouchedIssue{}}
+	overall := map[string]*ageGroup{}
+	labelGroups := make(map[string]*ageGroup)
+	assigneeGroups := make(map[string]*ageGroup)
+	add := func(groups map[string]*ageGroup, name, bucket string, days int) {
+		g := groups[name]
+		if g == nil {
+			g = &ageGroup{Name: name, Counts: emptyAgeCounts()}
+			groups[name] = g
+		}
+		g.Counts[bucket]++
+		g.Total++
+		if days > g.OldestDays {
+			g.OldestDays = days
+		}
+	}
+
+	var high []*types.Issue
+	for _, issue := range issues {
+		bucket := ageBucketOf(issue, now)
+		days := daysSince(issue.CreatedAt, now)
+		add(overall, "all", bucket, days)
+		if byLabel {
+			issueLabels := labels[issue.ID]
+			if len(issueLabels) == 0 {
+				issueLabels = []string{"(no label)"}
+			}
+			for _, label := range issueLabels {
+				add(labelGroups, label, bucket, days)
+			}
+		}
+		if byAssignee {
+			assignee := issue.Assignee
+			if assignee == "" {
+				assignee = "(unassigned)"
+			}
+			add(assigneeGroups, assignee, bucket, days)
+		}
+		if issue.Priority <= 1 {
+			high = append(high, issue)
+		}
+	}
+	if g := overall["all"]; g != nil {
+		r.Overall, r.Total = *g, g.Total
+	} else {
+		r.Overall = ageGroup{Name: "all", Counts: emptyAgeCounts()}
+	}
+	if byLabel {
+		r.ByLabel = sortAgeGroups(labelGroups)
+	}
+	if byAssignee {
+		r.ByAssignee = sortAgeGroups(assigneeGroups)
+	}
+
+	sort.SliceStable(high, func(i, j int) bool {
+		if !high[i].UpdatedAt.Equal(high[j].UpdatedAt) {
+			return high[i].UpdatedAt.Before(high[j].UpdatedAt)
+		}
+		return high[i].ID < high[j].ID
+	})
+	for i, issue := range high {
+		if i == top {
+			break
+		}
+		r.Untouched = append(r.Untouched, untouchedIssue{
+			ID:            issue.ID,
+			Title:         issue.Title,
+			Priority:      issue.Priority,
+			Assignee:      issue.Assignee,
+			AgeDays:       daysSince(issue.CreatedAt, now),
+			UntouchedDays: daysSince(issue.UpdatedAt, now),
+		})
+	}
+	return r
+}
+
+func emptyAgeCounts() map[string]int {
+	counts := make(map[string]int, len(ageBuckets))
+	for _, b := range ageBuckets {
+		counts[b] = 0
+	}
+	return counts
+}
+
+// sortAgeGroups orders groups by size, largest first, then by name.
+func sortAgeGroups(groups map[string]*ageGroup) []ageGroup {
+	out := make([]ageGroup, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Total != out[j].Total {
+			return out[i].Total > out[j].Total
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func daysSince(t, now time.Time) int {
+	return int(now.Sub(t).Hours() / 24)
+}
+
+func runReportAge(cmd *cobra.Command, _ []string) {
+	by, _ := cmd.Flags().GetString("by")
+	top, _ := cmd.Flags().GetInt("top")
+	byLabel, byAssignee := by == "label" || by == "both", by == "assignee" || by == "both"
+	if !byLabel && !byAssignee {
+		FatalErrorRespectJSON("invalid --by %q (valid: label, assignee, both)", by)
+	}
+	ctx := rootCtx
+
+	persistent, notTemplate := false, false
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{
+		ExcludeStatus: []types.Status{types.StatusClosed, types.StatusPinned},
+		Ephemeral:     &persistent,
+		IsTemplate:    &notTemplate,
+	})
+	if err != nil {
+		FatalErrorRespectJSON("listing issues: %v", err)
+	}
+	var labels map[string][]string
+	if byLabel && len(issues) > 0 {
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+		if labels, err = store.GetLabelsForIssues(ctx, ids); err != nil {
+			FatalErrorRespectJSON("fetching labels: %v", err)
+		}
+	}
+
+	report := buildAgeReport(issues, labels, time.Now(), byLabel, byAssignee, top)
+	if jsonOutput {
+		outputJSON(report)
+		return
+	}
+	displayAgeReport(report)
+}
+
+func displayAgeReport(r *ageReport) {
+	if r.Total == 0 {
+		fmt.Printf("\n%s No open issues\n\n", ui.RenderPass("✨"))
+		return
+	}
+	fmt.Printf("\n%s Backlog age (%d open issues):\n", ui.RenderAccent("⏳"), r.Total)
+	displayAgeTable("Overall", []ageGroup{r.Overall})
+	if r.ByLabel != nil {
+		displayAgeTable("By label", r.ByLabel)
+	}
+	if r.ByAssignee != nil {
+		displayAgeTable("By assignee", r.ByAssignee)
+	}
+
+	if len(r.Untouched) > 0 {
+		fmt.Printf("\n%s Oldest untouched P0-P1:\n", ui.RenderWarn("!"))
+		for _, u := range r.Untouched {
+			line := fmt.Sprintf("  %s %s: %s %s", ui.RenderPriority(u.Priority), ui.RenderID(u.ID), u.Title,
+				ui.RenderMuted(fmt.Sprintf("(untouched %dd, age %dd)", u.UntouchedDays, u.AgeDays)))
+			if u.Assignee != "" {
+				line += " " + ui.RenderMuted("@"+u.Assignee)
+			}
+			fmt.Println(line)
+		}
+	}
+	fmt.Println()
+}
+
+func displayAgeTable(title string, groups []ageGroup) {
+	width := len("(unassigned)")
+	for _, g := range groups {
+		if len(g.Name) > width {
+			width = len(g.Name)
+		}
+	}
+	fmt.Printf("\n%s\n", ui.RenderBold(title))
+	fmt.Printf("  %-*s", width, "")
+	for _, b := range ageBuckets {
+		fmt.Printf("%9s", b)
+	}
+	fmt.Printf("%9s%9s\n", "total", "oldest")
+	for _, g := range groups {
+		fmt.Printf("  %-*s", width, g.Name)
+		for _, b := range ageBuckets {
+			if n := g.Counts[b]; n > 0 {
+				fmt.Printf("%9d", n)
+			} else {
+				fmt.Print(ui.RenderMuted(fmt.Sprintf("%9s", "·")))
+			}
+		}
+		fmt.Printf("%9d%9s\n", g.Total, fmt.Sprintf("%dd", g.OldestDays))
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildAgeReport(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	issues := []*types.Issue{
+		{ID: "a", Priority: 0, Assignee: "alice", CreatedAt: ago(200), UpdatedAt: ago(100)},
+		{ID: "b", Priority: 1, CreatedAt: ago(40), UpdatedAt: ago(30)},
+		{ID: "c", Priority: 2, Assignee: "alice", CreatedAt: ago(3), UpdatedAt: ago(1)},
+		{ID: "d", Priority: 1, Assignee: "bob", CreatedAt: ago(10), UpdatedAt: ago(2)},
+	}
+	labels := map[string][]string{"a": {"backend", "infra"}, "c": {"backend"}}
+
+	r := buildAgeReport(issues, labels, now, true, true, 2)
+	if r.Total != 4 {
+		t.Fatalf("total = %d", r.Total)
+	}
+	want := map[string]int{"week": 1, "month": 1, "quarter": 1, "older": 1}
+	for b, n := range want {
+		if r.Overall.Counts[b] != n {
+			t.Errorf("overall %s = %d, want %d", b, r.Overall.Counts[b], n)
+		}
+	}
+	if r.Overall.OldestDays != 200 {
+		t.Errorf("oldest = %d", r.Overall.OldestDays)
+	}
+
+	if len(r.ByLabel) != 3 || r.ByLabel[0].Name != "(no label)" || r.ByLabel[1].Name != "backend" {
+		t.Fatalf("by label = %+v", r.ByLabel)
+	}
+	if backend := r.ByLabel[1]; backend.Total != 2 || backend.Counts["older"] != 1 || backend.Counts["week"] != 1 {
+		t.Errorf("backend = %+v", backend)
+	}
+	if len(r.ByAssignee) != 3 || r.ByAssignee[0].Name != "alice" || r.ByAssignee[0].Total != 2 {
+		t.Errorf("by assignee = %+v", r.ByAssignee)
+	}
+
+	if len(r.Untouched) != 2 || r.Untouched[0].ID != "a" || r.Untouched[1].ID != "b" {
+		t.Fatalf("untouched = %+v", r.Untouched)
+	}
+	if r.Untouched[0].UntouchedDays != 100 || r.Untouched[0].AgeDays != 200 {
+		t.Errorf("untouched a = %+v", r.Untouched[0])
+	}
+
+	if r := buildAgeReport(issues, nil, now, false, true, 0); r.ByLabel != nil || len(r.Untouched) != 0 {
+		t.Errorf("assignee-only report = %+v", r)
+	}
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// burnupBaselineMetadata is the epic metadata key holding its burnup
+// baseline.
+const burnupBaselineMetadata = "burnup_baseline"
+
+var epicBaselineCmd = &cobra.Command{
+	Use:   "baseline [epic-id]",
+	Short: "Record an epic's current scope as its burnup baseline",
+	Long: `Record the issues currently under an epic (children, grandchildren, and
+so on, not counting sub-epics themselves) as its baseline, for
+'bd report burnup'. Issues added to the epic afterwards count as scope
+growth rather than planned work.
+
+The baseline is stored in the epic's metadata under burnup_baseline.
+Running it again replaces the baseline.
+
+Examples:
+  bd epic baseline bd-40
+  bd report burnup --epic bd-40`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("epic baseline")
+		ctx := rootCtx
+		epic := resolveEpic(ctx, args[0])
+		members, err := epicMembers(ctx, epic.ID)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		previous, _ := burnupBaselineOf(epic)
+
+		baseline := &epicBaseline{TakenAt: time.Now().UTC(), Issues: []string{}}
+		for _, m := range members {
+			baseline.Issues = append(baseline.Issues, m.Issue.ID)
+		}
+		sort.Strings(baseline.Issues)
+		metadata, err := withMetadataKey(epic.Metadata, burnupBaselineMetadata, baseline)
+		if err != nil {
+			FatalErrorRespectJSON("%s: %v", epic.ID, err)
+		}
+		if err := store.UpdateIssue(ctx, epic.ID, map[string]interface{}{"metadata": metadata}, actor); err != nil {
+			FatalErrorRespectJSON("recording baseline: %v", err)
+		}
+
+		if jsonOutput {
+			outputJSON(baseline)
+			return
+		}
+		fmt.Printf("%s Baseline for %s: %d issues\n", ui.RenderPass("✓"), epic.ID, len(baseline.Issues))
+		if previous != nil {
+			fmt.Printf("  %s\n", ui.RenderMuted("Replaced the baseline from "+previous.TakenAt.Local().Format("2006-01-02")))
+		}
+	},
+}
+
+var reportBurnupCmd = &cobra.Command{
+	Use:   "burnup",
+	Short: "Burnup data for an epic: scope growth versus progress",
+	Long: `Show an epic's burnup: at each day (or week) since its baseline, how many
+issues were in scope, how many of those were added after the baseline, and
+how many were done. Separating added scope from completed work shows
+whether the finish line is moving or the work is slow.
+
+Scope is everything under the epic (not counting sub-epics themselves). An
+issue joins it when it is linked under the epic, and counts as done when it
+was closed. Issues in the baseline that have since left the epic are listed
+as removed.
+
+The epic needs a baseline first: 'bd epic baseline <id>'.
+
+Examples:
+  bd report burnup --epic bd-40
+  bd report burnup --epic bd-40 --interval week --json`,
+	Args: cobra.NoArgs,
+	Run:  runReportBurnup,
+}
+
+func init() {
+	reportBurnupCmd.Flags().String("epic", "", "Epic to report on (required)")
+	reportBurnupCmd.Flags().String("interval", "day", "Time between data points: day or week")
+	_ = reportBurnupCmd.MarkFlagRequired("epic")
+	reportCmd.AddCommand(reportBurnupCmd)
+	epicBaselineCmd.ValidArgsFunction = issueIDCompletion
+	epicCmd.AddCommand(epicBaselineCmd)
+}
+
+// epicBaseline is the scope of an epic when its baseline was recorded.
+type epicBaseline struct {
+	TakenAt time.Time `json:"taken_at"`
+	Issues  []string  `json:"issues"`
+}
+
+// burnupBaselineOf returns the baseline recorded in an epic's metadata, or
+// nil if it has none.
+func burnupBaselineOf(epic *types.Issue) (*epicBaseline, error) {
+	if len(epic.Metadata) == 0 {
+		return nil, nil
+	}
+	var md map[string]json.RawMessage
+	if json.Unmarshal(epic.Metadata, &md) != nil || md[burnupBaselineMetadata] == nil {
+		return nil, nil
+	}
+	var b epicBaseline
+	if err := json.Unmarshal(md[burnupBaselineMetadata], &b); err != nil {
+		return nil, fmt.Errorf("invalid %s in %s metadata: %w", burnupBaselineMetadata, epic.ID, err)
+	}
+	return &b, nil
+}
+
+// burnupMember is an issue under an epic, and when it came under it.
+type burnupMember struct {
+	Issue    *types.Issue
+	JoinedAt time.Time
+}
+
+// resolveEpic resolves an epic ID, exiting if it isn't an epic.
+func resolveEpic(ctx context.Context, id string) *types.Issue {
+	epicID, err := utils.ResolvePartialID(ctx, store, id)
+	if err != nil {
+		FatalErrorRespectJSON("resolving %s: %v", id, err)
+	}
+	epic, err := store.GetIssue(ctx, epicID)
+	if err != nil || epic == nil {
+		FatalErrorRespectJSON("issue %s not found", epicID)
+	}
+	if epic.IssueType != types.TypeEpic {
+		FatalErrorCode(exitValidation, "%s is a %s, not an epic", epicID, epic.IssueType)
+	}
+	return epic
+}
+
+// epicMembers returns the issues under an epic, through parent-child links
+// at any depth, leaving out sub-epics. An issue joined the epic when the
+// last link on its path was made.
+func epicMembers(ctx context.Context, epicID string) ([]burnupMember, error) {
+	deps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching dependencies: %w", err)
+	}
+	children := make(map[string][]*types.Dependency)
+	for _, records := range deps {
+		for _, dep := range records {
+			if dep.Type == types.DepParentChild {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], dep)
+			}
+		}
+	}
+
+	joined := map[string]time.Time{epicID: {}}
+	queue := []string{epicID}
+	var ids []string
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, dep := range children[parent] {
+			if _, seen := joined[dep.IssueID]; seen {
+				continue
+			}
+			at := dep.CreatedAt
+			if at.Before(joined[parent]) {
+				at = joined[parent]
+			}
+			joined[dep.IssueID] = at
+			ids = append(ids, dep.IssueID)
+			queue = append(queue, dep.IssueID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	issues, err := store.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("fetching epic issues: %w", err)
+	}
+	var members []burnupMember
+	for _, issue := range issues {
+		if issue.IssueType == types.TypeEpic {
+			continue
+		}
+		at := joined[issue.ID]
+		if at.Before(issue.CreatedAt) {
+			at = issue.CreatedAt
+		}
+		members = append(members, burnupMember{Issue: issue, JoinedAt: at})
+	}
+	return members, nil
+}
+
+// burnupPoint is the epic's scope and progress at one point in time.
+type burnupPoint struct {
+	Date      time.Time `json:"date"`
+	Scope     int       `json:"scope"`
+	Baseline  int       `json:"baseline"` // Baseline issues still in scope
+	Added     int       `json:"added"`    // Issues added after the baseline
+	Completed int       `json:"completed"`
+}
+
+// burnupReport is the output of bd report burnup.
+type burnupReport struct {
+	Epic       string        `json:"epic"`
+	Title      string        `json:"title"`
+	BaselineAt time.Time     `json:"baseline_at"`
+	Baseline   int           `json:"baseline"` // Issues in the baseline
+	Scope      int           `json:"scope"`    // Issues in scope now
+	Completed  int           `json:"completed"`
+	Added      []string      `json:"added"`   // In scope, not in the baseline
+	Removed    []string      `json:"removed"` // In the baseline, no longer in scope
+	Points     []burnupPoint `json:"points"`
+}
+
+// buildBurnupReport computes an epic's burnup from its baseline to now, a
+// point at the end of every days-th day (and one for now), from the members
+// currently under it.
+func buildBurnupReport(epic *types.Issue, baseline *epicBaseline, members []burnupMember, now time.Time, days int) *burnupReport {
+	r := &burnupReport{
+		Epic:       epic.ID,
+		Title:      epic.Title,
+		BaselineAt: baseline.TakenAt,
+		Baseline:   len(baseline.Issues),
+		Scope:      len(members),
+		Added:      []string{},
+		Removed:    []string{},
+		Points:     []burnupPoint{},
+	}
+	inBaseline := make(map[string]bool, len(baseline.Issues))
+	for _, id := range baseline.Issues {
+		inBaseline[id] = true
+	}
+	current := make(map[string]bool, len(members))
+	for _, m := range members {
+		current[m.Issue.ID] = true
+		if !inBaseline[m.Issue.ID] {
+			r.Added = append(r.Added, m.Issue.ID)
+		}
+		if m.Issue.ClosedAt != nil {
+			r.Completed++
+		}
+	}
+	for _, id := range baseline.Issues {
+		if !current[id] {
+			r.Removed = append(r.Removed, id)
+		}
+	}
+	sort.Strings(r.Added)
+	sort.Strings(r.Removed)
+
+	// pointAt counts the epic as of cutoff, labelled date
+	pointAt := func(date, cutoff time.Time) burnupPoint {
+		p := burnupPoint{Date: date}
+		for _, m := range members {
+			// Baseline issues count from the start, even if linked just before
+			if !inBaseline[m.Issue.ID] && m.JoinedAt.After(cutoff) {
+				continue
+			}
+			p.Scope++
+			if inBaseline[m.Issue.ID] {
+				p.Baseline++
+			} else {
+				p.Added++
+			}
+			if m.Issue.ClosedAt != nil && !m.Issue.ClosedAt.After(cutoff) {
+				p.Completed++
+			}
+		}
+		return p
+	}
+	// A point at the end of the baseline day and every step after it, then now
+	y, mo, d := baseline.TakenAt.In(now.Location()).Date()
+	for date := time.Date(y, mo, d, 0, 0, 0, 0, now.Location()); ; date = date.AddDate(0, 0, days) {
+		end := date.AddDate(0, 0, 1)
+		if !end.Before(now) {
+			break
+		}
+		r.Points = append(r.Points, pointAt(date, end))
+	}
+	r.Points = append(r.Points, pointAt(now, now))
+	return r
+}
+
+func runReportBurnup(cmd *cobra.Command, _ []string) {
+	epicFlag, _ := cmd.Flags().GetString("epic")
+	interval, _ := cmd.Flags().GetString("interval")
+	days := map[string]int{"day": 1, "week": 7}[interval]
+	if days == 0 {
+		FatalErrorCode(exitValidation, "invalid --interval %q (valid: day, week)", interval)
+	}
+	ctx := rootCtx
+
+	epic := resolveEpic(ctx, epicFlag)
+	baseline, err := burnupBaselineOf(epic)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if baseline == nil {
+		FatalErrorWithHint(fmt.Sprintf("%s has no burnup baseline", epic.ID),
+			fmt.Sprintf("record one with: bd epic baseline %s", epic.ID))
+	}
+	members, err := epicMembers(ctx, epic.ID)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	report := buildBurnupReport(epic, baseline, members, time.Now(), days)
+	if jsonOutput {
+		outputJSON(report)
+		return
+	}
+	displayBurnupReport(report)
+}
+
+func displayBurnupReport(r *burnupReport) {
+	fmt.Printf("\n%s Burnup for %s: %s\n\n", ui.RenderAccent("📈"), ui.RenderID(r.Epic), r.Title)
+	growth := ""
+	if r.Baseline > 0 {
+		growth = fmt.Sprintf(" (%+d%% scope)", len(r.Added)*100/r.Baseline)
+	}
+	fmt.Printf("  Baseline: %d issues on %s\n", r.Baseline, r.BaselineAt.Local().Format("2006-01-02"))
+	fmt.Printf("  Added:    %d%s\n", len(r.Added), growth)
+	if len(r.Removed) > 0 {
+		fmt.Printf("  Removed:  %d\n", len(r.Removed))
+	}
+	fmt.Printf("  Done:     %d of %d\n", r.Completed, r.Scope)
+
+	fmt.Printf("\n  %-10s %7s %9s %7s %7s\n", "date", "scope", "baseline", "added", "done")
+	for _, p := range r.Points {
+		added := fmt.Sprintf("%7d", p.Added)
+		if p.Added > 0 {
+			added = ui.RenderWarn(added)
+		}
+		fmt.Printf("  %-10s %7d %9d %s %7d\n", p.Date.Format("2006-01-02"), p.Scope, p.Baseline, added, p.Completed)
+	}
+	if len(r.Added) > 0 {
+		fmt.Printf("\n  %s %v\n", ui.RenderMuted("Added since baseline:"), r.Added)
+	}
+	if len(r.Removed) > 0 {
+		fmt.Printf("  %s %v\n", ui.RenderMuted("Removed since baseline:"), r.Removed)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildBurnupReport(t *testing.T) {
+	loc := time.UTC
+	day := func(d, hour int) time.Time { return time.Date(2026, 9, d, hour, 0, 0, 0, loc) }
+	closedAt := func(t time.Time) *time.Time { return &t }
+	epic := &types.Issue{ID: "epic", Title: "Checkout v2", IssueType: types.TypeEpic}
+	baseline := &epicBaseline{TakenAt: day(1, 10), Issues: []string{"a", "b", "gone"}}
+	members := []burnupMember{
+		{Issue: &types.Issue{ID: "a", ClosedAt: closedAt(day(2, 15))}, JoinedAt: day(1, 9)},
+		{Issue: &types.Issue{ID: "b"}, JoinedAt: day(1, 9)},
+		{Issue: &types.Issue{ID: "c", ClosedAt: closedAt(day(4, 12))}, JoinedAt: day(3, 11)}, // Scope growth
+		{Issue: &types.Issue{ID: "d"}, JoinedAt: day(4, 16)},                                 // Scope growth
+	}
+
+	r := buildBurnupReport(epic, baseline, members, day(4, 18), 1)
+	if r.Baseline != 3 || r.Scope != 4 || r.Completed != 2 {
+		t.Errorf("baseline/scope/completed = %d/%d/%d, want 3/4/2", r.Baseline, r.Scope, r.Completed)
+	}
+	if !reflect.DeepEqual(r.Added, []string{"c", "d"}) || !reflect.DeepEqual(r.Removed, []string{"gone"}) {
+		t.Errorf("added %v, removed %v", r.Added, r.Removed)
+	}
+
+	type row struct{ scope, baseline, added, completed int }
+	var got []row
+	var dates []string
+	for _, p := range r.Points {
+		got = append(got, row{p.Scope, p.Baseline, p.Added, p.Completed})
+		dates = append(dates, p.Date.Format("01-02"))
+	}
+	want := []row{
+		{2, 2, 0, 0}, // End of Sept 1
+		{2, 2, 0, 1}, // Sept 2: a done
+		{3, 2, 1, 1}, // Sept 3: c added
+		{4, 2, 2, 2}, // Now (Sept 4): d added, c done
+	}
+	if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(dates, []string{"09-01", "09-02", "09-03", "09-04"}) {
+		t.Errorf("points = %v on %v, want %v", got, dates, want)
+	}
+
+	weekly := buildBurnupReport(epic, baseline, members, day(4, 18), 7)
+	if len(weekly.Points) != 2 {
+		t.Errorf("weekly points = %d, want the baseline day and now", len(weekly.Points))
+	}
+}
+
+func TestBurnupBaselineOf(t *testing.T) {
+	epic := &types.Issue{ID: "epic"}
+	if b, err := burnupBaselineOf(epic); b != nil || err != nil {
+		t.Errorf("no metadata: %v, %v", b, err)
+	}
+
+	md, err := withMetadataKey(json.RawMessage(`{"owner":"pay-team"}`), burnupBaselineMetadata,
+		&epicBaseline{TakenAt: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), Issues: []string{"a"}})
+	if err != nil {
+		t.Fatalf("withMetadataKey: %v", err)
+	}
+	epic.Metadata = md
+	b, err := burnupBaselineOf(epic)
+	if err != nil || b == nil || !reflect.DeepEqual(b.Issues, []string{"a"}) {
+		t.Fatalf("burnupBaselineOf = %+v, %v", b, err)
+	}
+	var kept map[string]any
+	if json.Unmarshal(md, &kept) != nil || kept["owner"] != "pay-team" {
+		t.Errorf("other metadata lost: %s", md)
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var reportCrossEpicCmd = &cobra.Command{
+	Use:   "cross-epic",
+	Short: "Dependencies that cross epic boundaries, per epic pair",
+	Long: `List the blocking dependencies whose two issues sit under different
+epics: places where one team's work waits on another's. They are counted
+per epic pair, with the specific issues, to surface coordination risks.
+
+An issue belongs to the nearest epic above it (an epic to itself); issues
+under no epic are left out. blocks, conditional-blocks, and waits-for
+dependencies count. By default only dependencies still in force are listed,
+those whose blocker and blocked issue are both unclosed; --all includes
+resolved ones too.
+
+Examples:
+  bd report cross-epic
+  bd report cross-epic --epic bd-40     # Pairs involving one epic
+  bd report cross-epic --all --json`,
+	Args: cobra.NoArgs,
+	Run:  runReportCrossEpic,
+}
+
+func init() {
+	reportCrossEpicCmd.Flags().String("epic", "", "Only pairs involving this epic")
+	reportCrossEpicCmd.Flags().Bool("all", false, "Include dependencies already resolved (either issue closed)")
+	reportCmd.AddCommand(reportCrossEpicCmd)
+}
+
+// crossEpicLink is one dependency between issues under different epics.
+type crossEpicLink struct {
+	IssueID       string               `json:"issue_id"` // The blocked issue
+	IssueTitle    string               `json:"issue_title"`
+	BlockerID     string               `json:"blocker_id"`
+	BlockerTitle  string               `json:"blocker_title"`
+	BlockerStatus types.Status         `json:"blocker_status"`
+	Type          types.DependencyType `json:"type"`
+}
+
+// crossEpicPair is the dependencies from issues under one epic on issues
+// under another.
+type crossEpicPair struct {
+	Epic           string          `json:"epic"` // Epic whose issues are blocked
+	EpicTitle      string          `json:"epic_title"`
+	BlockedBy      string          `json:"blocked_by"` // Epic whose issues block them
+	BlockedByTitle string          `json:"blocked_by_title"`
+	Count          int             `json:"count"`
+	Links          []crossEpicLink `json:"links"`
+}
+
+// crossEpicReport is the output of bd report cross-epic.
+type crossEpicReport struct {
+	Total int             `json:"total"`
+	Pairs []crossEpicPair `json:"pairs"`
+}
+
+// buildCrossEpicReport finds blocking dependencies between issues under
+// different epics. deps maps issue IDs to their dependency records. With
+// all false, dependencies where either issue is closed are left out; with
+// epic set, only pairs involving that epic are kept. Pairs are ordered by
+// count, most first.
+func buildCrossEpicReport(issues []*types.Issue, deps map[string][]*types.Dependency, epic string, all bool) *crossEpicReport {
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	parents := make(map[string]string)
+	for id, records := range deps {
+		for _, dep := range records {
+			if dep.Type == types.DepParentChild {
+				parents[id] = dep.DependsOnID
+			}
+		}
+	}
+	epicOf := func(issue *types.Issue) *types.Issue {
+		if issue.IssueType == types.TypeEpic {
+			return issue
+		}
+		return retroEpicOf(issue.ID, parents, byID)
+	}
+
+	pairs := make(map[[2]string]*crossEpicPair)
+	r := &crossEpicReport{Pairs: []crossEpicPair{}}
+	for id, records := range deps {
+		for _, dep := range records {
+			if dep.Type == types.DepParentChild || !dep.Type.AffectsReadyWork() {
+				continue
+			}
+			issue, blocker := byID[id], byID[dep.DependsOnID]
+			if issue == nil || blocker == nil {
+				continue // External, ephemeral, or deleted
+			}
+			if !all && (issue.Status == types.StatusClosed || blocker.Status == types.StatusClosed) {
+				continue
+			}
+			from, to := epicOf(issue), epicOf(blocker)
+			if from == nil || to == nil || from.ID == to.ID {
+				continue
+			}
+			if epic != "" && from.ID != epic && to.ID != epic {
+				continue
+			}
+			key := [2]string{from.ID, to.ID}
+			p := pairs[key]
+			if p == nil {
+				p = &crossEpicPair{Epic: from.ID, EpicTitle: from.Title, BlockedBy: to.ID, BlockedByTitle: to.Title}
+				pairs[key] = p
+			}
+			p.Links = append(p.Links, crossEpicLink{
+				IssueID:       issue.ID,
+				IssueTitle:    issue.Title,
+				BlockerID:     blocker.ID,
+				BlockerTitle:  blocker.Title,
+				BlockerStatus: blocker.Status,
+				Type:          dep.Type,
+			})
+			p.Count++
+			r.Total++
+		}
+	}
+
+	for _, p := range pairs {
+		sort.Slice(p.Links, func(i, j int) bool {
+			if p.Links[i].IssueID != p.Links[j].IssueID {
+				return p.Links[i].IssueID < p.Links[j].IssueID
+			}
+			return p.Links[i].BlockerID < p.Links[j].BlockerID
+		})
+		r.Pairs = append(r.Pairs, *p)
+	}
+	sort.Slice(r.Pairs, func(i, j int) bool {
+		a, b := r.Pairs[i], r.Pairs[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Epic != b.Epic {
+			return a.Epic < b.Epic
+		}
+		return a.BlockedBy < b.BlockedBy
+	})
+	return r
+}
+
+func runReportCrossEpic(cmd *cobra.Command, _ []string) {
+	epicFlag, _ := cmd.Flags().GetString("epic")
+	all, _ := cmd.Flags().GetBool("all")
+	ctx := rootCtx
+
+	epic := ""
+	if epicFlag != "" {
+		id, err := utils.ResolvePartialID(ctx, store, epicFlag)
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", epicFlag, err)
+		}
+		epic = id
+	}
+	persistent := false
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Ephemeral: &persistent})
+	if err != nil {
+		FatalErrorRespectJSON("listing issues: %v", err)
+	}
+	deps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("fetching dependencies: %v", err)
+	}
+
+	report := buildCrossEpicReport(issues, deps, epic, all)
+	if jsonOutput {
+		outputJSON(report)
+		return
+	}
+	displayCrossEpicReport(report)
+}
+
+func displayCrossEpicReport(r *crossEpicReport) {
+	if len(r.Pairs) == 0 {
+		fmt.Println("No dependencies cross epic boundaries")
+		return
+	}
+	fmt.Printf("\n%s Cross-epic dependencies: %d across %d epic pairs\n", ui.RenderAccent("🔗"), r.Total, len(r.Pairs))
+	for _, p := range r.Pairs {
+		fmt.Printf("\n  %s %s waits on %s %s: %d\n", ui.RenderID(p.Epic), ui.RenderMuted(p.EpicTitle),
+			ui.RenderID(p.BlockedBy), ui.RenderMuted(p.BlockedByTitle), p.Count)
+		for _, l := range p.Links {
+			fmt.Printf("    %s ← %s %s %s\n", l.IssueID, l.BlockerID, ui.RenderMuted("("+string(l.BlockerStatus)+")"), l.BlockerTitle)
+		}
+	}
+	fmt.Println()
+}
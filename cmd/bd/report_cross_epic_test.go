@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildCrossEpicReport(t *testing.T) {
+	issue := func(id string, typ types.IssueType, status types.Status) *types.Issue {
+		return &types.Issue{ID: id, Title: id, IssueType: typ, Status: status}
+	}
+	issues := []*types.Issue{
+		issue("pay", types.TypeEpic, types.StatusOpen),
+		issue("auth", types.TypeEpic, types.StatusOpen),
+		issue("auth-sso", types.TypeEpic, types.StatusOpen), // Nested under auth
+		issue("p1", types.TypeTask, types.StatusOpen),
+		issue("p2", types.TypeTask, types.StatusOpen),
+		issue("a1", types.TypeTask, types.StatusInProgress),
+		issue("a2", types.TypeTask, types.StatusClosed),
+		issue("s1", types.TypeTask, types.StatusOpen),
+		issue("loose", types.TypeTask, types.StatusOpen),
+	}
+	dep := func(from, to string, typ types.DependencyType) *types.Dependency {
+		return &types.Dependency{IssueID: from, DependsOnID: to, Type: typ}
+	}
+	deps := map[string][]*types.Dependency{
+		"p1":       {dep("p1", "pay", types.DepParentChild), dep("p1", "a1", types.DepBlocks), dep("p1", "s1", types.DepWaitsFor)},
+		"p2":       {dep("p2", "pay", types.DepParentChild), dep("p2", "a1", types.DepBlocks), dep("p2", "a2", types.DepBlocks), dep("p2", "p1", types.DepBlocks)},
+		"a1":       {dep("a1", "auth", types.DepParentChild), dep("a1", "loose", types.DepBlocks), dep("a1", "p2", types.DepRelated)},
+		"a2":       {dep("a2", "auth", types.DepParentChild)},
+		"auth-sso": {dep("auth-sso", "auth", types.DepParentChild)},
+		"s1":       {dep("s1", "auth-sso", types.DepParentChild), dep("s1", "ext:other:x", types.DepBlocks)},
+	}
+
+	r := buildCrossEpicReport(issues, deps, "", false)
+	if r.Total != 3 || len(r.Pairs) != 2 {
+		t.Fatalf("total %d in %d pairs, want 3 in 2: %+v", r.Total, len(r.Pairs), r.Pairs)
+	}
+	if p := r.Pairs[0]; p.Epic != "pay" || p.BlockedBy != "auth" || p.Count != 2 ||
+		p.Links[0].IssueID != "p1" || p.Links[1].IssueID != "p2" || p.Links[0].BlockerStatus != types.StatusInProgress {
+		t.Errorf("first pair = %+v", p)
+	}
+	if p := r.Pairs[1]; p.Epic != "pay" || p.BlockedBy != "auth-sso" || p.Links[0].Type != types.DepWaitsFor {
+		t.Errorf("second pair = %+v", p)
+	}
+
+	// --all counts the dependency on closed a2 too
+	if all := buildCrossEpicReport(issues, deps, "", true); all.Total != 4 || all.Pairs[0].Count != 3 {
+		t.Errorf("with all: total %d, first pair %d", all.Total, all.Pairs[0].Count)
+	}
+	// --epic keeps only pairs involving it
+	if only := buildCrossEpicReport(issues, deps, "auth-sso", false); only.Total != 1 || only.Pairs[0].BlockedBy != "auth-sso" {
+		t.Errorf("with epic: %+v", only.Pairs)
+	}
+}
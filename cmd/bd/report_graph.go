@@ -0,0 +1,304 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var reportGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Dependency graph metrics, to spot over-constrained plans",
+	Long: `Measure the graph of blocking dependencies (blocks, conditional-blocks,
+waits-for) between open issues:
+
+  depth     the longest chain of issues each waiting on the next
+  fan-out   blockers per issue that has any
+  fan-in    dependents per issue that blocks any
+  roots     issues that depend on nothing and block something
+  leaves    issues that block nothing and depend on something
+
+It also shows the largest blocked cluster (connected issues, most of them
+waiting) with the issues that unblock it, and lists issues whose
+dependency counts reach --max-deps. Deep chains, big clusters and
+issues with many dependencies are the signs of an over-constrained plan.
+Parent-child links are hierarchy, not ordering, so they are left out.
+
+Examples:
+  bd report graph
+  bd report graph --max-deps 5
+  bd report graph --json`,
+	Args: cobra.NoArgs,
+	Run:  runReportGraph,
+}
+
+func init() {
+	reportGraphCmd.Flags().Int("max-deps", 8, "List issues that depend on or block at least this many issues")
+	reportGraphCmd.Flags().Int("limit", 10, "Maximum high-dependency issues to list (0 = all)")
+	reportCmd.AddCommand(reportGraphCmd)
+}
+
+// graphCluster is a connected group of issues in the blocking graph.
+type graphCluster struct {
+	Size    int      `json:"size"`
+	Blocked int      `json:"blocked"`
+	IDs     []string `json:"ids"`
+	Roots   []string `json:"roots"` // Unblocked issues the rest wait on
+}
+
+// graphHotspot is an issue with a suspiciously high dependency count.
+type graphHotspot struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Priority  int    `json:"priority"`
+	DependsOn int    `json:"depends_on"`
+	Blocks    int    `json:"blocks"`
+}
+
+// graphReport is the output of bd report graph.
+type graphReport struct {
+	Issues         int            `json:"issues"`    // Open issues
+	Edges          int            `json:"edges"`     // Blocking dependencies between them
+	Connected      int            `json:"connected"` // Issues with at least one
+	Roots          int            `json:"roots"`
+	Leaves         int            `json:"leaves"`
+	MaxDepth       int            `json:"max_depth"`
+	LongestChain   []string       `json:"longest_chain"` // Blocker first
+	AvgFanIn       float64        `json:"avg_fan_in"`
+	AvgFanOut      float64        `json:"avg_fan_out"`
+	LargestCluster *graphCluster  `json:"largest_blocked_cluster,omitempty"`
+	MaxDeps        int            `json:"max_deps"`
+	HighDependency []graphHotspot `json:"high_dependency"`
+}
+
+// buildGraphReport computes the metrics of the blocking graph among issues.
+// deps maps issue IDs to their dependency records; dependencies on issues
+// outside issues (closed or missing) are ignored. Edges that close a cycle
+// don't count toward depth.
+func buildGraphReport(issues []*types.Issue, deps map[string][]*types.Dependency, maxDeps, limit int) *graphReport {
+	r := &graphReport{Issues: len(issues), MaxDeps: maxDeps, LongestChain: []string{}, HighDependency: []graphHotspot{}}
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+
+	blockers := make(map[string][]string)   // Issue → issues it waits on
+	dependents := make(map[string][]string) // Issue → issues waiting on it
+	seen := make(map[[2]string]bool)
+	for _, issue := range issues {
+		for _, dep := range deps[issue.ID] {
+			if !dep.Type.AffectsReadyWork() || dep.Type == types.DepParentChild {
+				continue
+			}
+			edge := [2]string{dep.IssueID, dep.DependsOnID}
+			if byID[dep.DependsOnID] == nil || dep.IssueID == dep.DependsOnID || seen[edge] {
+				continue
+			}
+			seen[edge] = true
+			blockers[dep.IssueID] = append(blockers[dep.IssueID], dep.DependsOnID)
+			dependents[dep.DependsOnID] = append(dependents[dep.DependsOnID], dep.IssueID)
+			r.Edges++
+		}
+	}
+	for _, m := range []map[string][]string{blockers, dependents} {
+		for _, ids := range m {
+			sort.Strings(ids)
+		}
+	}
+
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		in, out := len(dependents[id]), len(blockers[id])
+		if in == 0 && out == 0 {
+			continue
+		}
+		r.Connected++
+		if out == 0 {
+			r.Roots++
+		}
+		if in == 0 {
+			r.Leaves++
+		}
+		if in >= maxDeps || out >= maxDeps {
+			issue := byID[id]
+			r.HighDependency = append(r.HighDependency, graphHotspot{
+				ID: id, Title: issue.Title, Priority: issue.Priority, DependsOn: out, Blocks: in,
+			})
+		}
+	}
+	if n := len(dependents); n > 0 {
+		r.AvgFanIn = float64(r.Edges) / float64(n)
+	}
+	if n := len(blockers); n > 0 {
+		r.AvgFanOut = float64(r.Edges) / float64(n)
+	}
+	sort.SliceStable(r.HighDependency, func(i, j int) bool {
+		a, b := r.HighDependency[i], r.HighDependency[j]
+		return a.DependsOn+a.Blocks > b.DependsOn+b.Blocks
+	})
+	if limit > 0 && len(r.HighDependency) > limit {
+		r.HighDependency = r.HighDependency[:limit]
+	}
+
+	r.LongestChain = longestChain(ids, blockers)
+	if len(r.LongestChain) > 0 {
+		r.MaxDepth = len(r.LongestChain) - 1
+	}
+	r.LargestCluster = largestBlockedCluster(ids, blockers, dependents)
+	return r
+}
+
+// longestChain returns the longest path through blockers, blocker first.
+// Edges back into the chain being explored (cycles) are skipped.
+func longestChain(ids []string, blockers map[string][]string) []string {
+	next := make(map[string]string) // Issue → the blocker its longest chain continues with
+	depth := make(map[string]int)
+	onPath := make(map[string]bool)
+	var visit func(id string) int
+	visit = func(id string) int {
+		if d, ok := depth[id]; ok {
+			return d
+		}
+		onPath[id] = true
+		best := 0
+		for _, b := range blockers[id] {
+			if onPath[b] {
+				continue
+			}
+			if d := visit(b) + 1; d > best {
+				best, next[id] = d, b
+			}
+		}
+		onPath[id] = false
+		depth[id] = best
+		return best
+	}
+
+	var deepest string
+	for _, id := range ids {
+		if len(blockers[id]) == 0 {
+			continue
+		}
+		if d := visit(id); deepest == "" || d > depth[deepest] {
+			deepest = id
+		}
+	}
+	if deepest == "" {
+		return []string{}
+	}
+	var chain []string
+	for id := deepest; id != ""; id = next[id] {
+		chain = append(chain, id)
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
+
+// largestBlockedCluster finds the connected group of issues with the most
+// blocked issues, or nil when nothing is blocked.
+func largestBlockedCluster(ids []string, blockers, dependents map[string][]string) *graphCluster {
+	var best *graphCluster
+	done := make(map[string]bool)
+	for _, start := range ids {
+		if done[start] || len(blockers[start]) == 0 {
+			continue
+		}
+		c := &graphCluster{}
+		stack := []string{start}
+		done[start] = true
+		for len(stack) > 0 {
+			id := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			c.IDs = append(c.IDs, id)
+			if len(blockers[id]) > 0 {
+				c.Blocked++
+			} else {
+				c.Roots = append(c.Roots, id)
+			}
+			for _, m := range []map[string][]string{blockers, dependents} {
+				for _, n := range m[id] {
+					if !done[n] {
+						done[n] = true
+						stack = append(stack, n)
+					}
+				}
+			}
+		}
+		c.Size = len(c.IDs)
+		if best == nil || c.Blocked > best.Blocked || (c.Blocked == best.Blocked && c.Size > best.Size) {
+			best = c
+		}
+	}
+	if best != nil {
+		sort.Strings(best.IDs)
+		sort.Strings(best.Roots)
+	}
+	return best
+}
+
+func runReportGraph(cmd *cobra.Command, _ []string) {
+	maxDeps, _ := cmd.Flags().GetInt("max-deps")
+	limit, _ := cmd.Flags().GetInt("limit")
+	if maxDeps < 1 {
+		FatalErrorRespectJSON("--max-deps must be at least 1")
+	}
+	ctx := rootCtx
+
+	persistent, notTemplate := false, false
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{
+		ExcludeStatus: []types.Status{types.StatusClosed},
+		Ephemeral:     &persistent,
+		IsTemplate:    &notTemplate,
+	})
+	if err != nil {
+		FatalErrorRespectJSON("listing issues: %v", err)
+	}
+	deps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	report := buildGraphReport(issues, deps, maxDeps, limit)
+	if jsonOutput {
+		outputJSON(report)
+		return
+	}
+	displayGraphReport(report)
+}
+
+func displayGraphReport(r *graphReport) {
+	if r.Edges == 0 {
+		fmt.Printf("\n%s No blocking dependencies between %d open issues\n\n", ui.RenderPass("✨"), r.Issues)
+		return
+	}
+	fmt.Printf("\n%s Dependency graph (%d open issues, %d blocking dependencies):\n\n", ui.RenderAccent("🕸"), r.Issues, r.Edges)
+	fmt.Printf("  %-12s %d %s\n", "Connected", r.Connected, ui.RenderMuted(fmt.Sprintf("(%d without dependencies)", r.Issues-r.Connected)))
+	fmt.Printf("  %-12s %d %s\n", "Roots", r.Roots, ui.RenderMuted("(depend on nothing)"))
+	fmt.Printf("  %-12s %d %s\n", "Leaves", r.Leaves, ui.RenderMuted("(block nothing)"))
+	fmt.Printf("  %-12s %d %s\n", "Max depth", r.MaxDepth, ui.RenderMuted(strings.Join(r.LongestChain, " → ")))
+	fmt.Printf("  %-12s %.1f %s\n", "Avg fan-out", r.AvgFanOut, ui.RenderMuted("(blockers per blocked issue)"))
+	fmt.Printf("  %-12s %.1f %s\n", "Avg fan-in", r.AvgFanIn, ui.RenderMuted("(dependents per blocking issue)"))
+
+	if c := r.LargestCluster; c != nil {
+		fmt.Printf("\n%s %d issues, %d blocked, waiting on %s\n", ui.RenderBold("Largest blocked cluster:"),
+			c.Size, c.Blocked, strings.Join(c.Roots, ", "))
+	}
+	if len(r.HighDependency) > 0 {
+		fmt.Printf("\n%s Depending on or blocking %d+ issues:\n", ui.RenderWarn("!"), r.MaxDeps)
+		for _, h := range r.HighDependency {
+			fmt.Printf("  %s %s: %s %s\n", ui.RenderPriority(h.Priority), ui.RenderID(h.ID), h.Title,
+				ui.RenderMuted(fmt.Sprintf("(depends on %d, blocks %d)", h.DependsOn, h.Blocks)))
+		}
+	}
+	fmt.Println()
+}
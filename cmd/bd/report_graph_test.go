@@ -0,0 +1,73 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildGraphReport(t *testing.T) {
+	var issues []*types.Issue
+	for _, id := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		issues = append(issues, &types.Issue{ID: id, Title: id})
+	}
+	dep := func(from, to string, typ types.DependencyType) *types.Dependency {
+		return &types.Dependency{IssueID: from, DependsOnID: to, Type: typ}
+	}
+	deps := map[string][]*types.Dependency{
+		// a ← b ← c ← d is the longest chain; e also waits on b
+		"b": {dep("b", "a", types.DepBlocks)},
+		"c": {dep("c", "b", types.DepBlocks), dep("c", "closed", types.DepBlocks)},
+		"d": {dep("d", "c", types.DepWaitsFor), dep("d", "c", types.DepBlocks)},
+		"e": {dep("e", "b", types.DepBlocks), dep("e", "a", types.DepBlocks)},
+		// A separate pair, plus links that don't order work
+		"g": {dep("g", "f", types.DepConditionalBlocks)},
+		"h": {dep("h", "a", types.DepParentChild), dep("h", "f", types.DepRelatesTo)},
+	}
+
+	r := buildGraphReport(issues, deps, 2, 0)
+	if r.Issues != 8 || r.Edges != 6 || r.Connected != 7 {
+		t.Errorf("issues/edges/connected = %d/%d/%d, want 8/6/7", r.Issues, r.Edges, r.Connected)
+	}
+	if r.Roots != 2 || r.Leaves != 3 {
+		t.Errorf("roots/leaves = %d/%d, want 2 (a, f) and 3 (d, e, g)", r.Roots, r.Leaves)
+	}
+	if want := []string{"a", "b", "c", "d"}; r.MaxDepth != 3 || !reflect.DeepEqual(r.LongestChain, want) {
+		t.Errorf("depth %d via %v, want 3 via %v", r.MaxDepth, r.LongestChain, want)
+	}
+	// 6 edges over 5 blocked issues (b, c, d, e, g) and 4 blockers (a, b, c, f)
+	if r.AvgFanOut != 1.2 || r.AvgFanIn != 1.5 {
+		t.Errorf("fan-out %.2f, fan-in %.2f; want 1.2, 1.5", r.AvgFanOut, r.AvgFanIn)
+	}
+	if c := r.LargestCluster; c == nil || c.Size != 5 || c.Blocked != 4 || !reflect.DeepEqual(c.Roots, []string{"a"}) {
+		t.Errorf("largest cluster = %+v, want a-e with 4 blocked behind a", c)
+	}
+	var hot []string
+	for _, h := range r.HighDependency {
+		hot = append(hot, h.ID)
+	}
+	if want := []string{"b", "a", "e"}; !reflect.DeepEqual(hot, want) {
+		t.Errorf("high dependency = %v, want %v", hot, want)
+	}
+}
+
+func TestBuildGraphReportCycle(t *testing.T) {
+	issues := []*types.Issue{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	deps := map[string][]*types.Dependency{
+		"a": {{IssueID: "a", DependsOnID: "b", Type: types.DepBlocks}},
+		"b": {{IssueID: "b", DependsOnID: "c", Type: types.DepBlocks}},
+		"c": {{IssueID: "c", DependsOnID: "a", Type: types.DepBlocks}},
+	}
+	r := buildGraphReport(issues, deps, 8, 0)
+	if r.MaxDepth != 2 || len(r.LongestChain) != 3 {
+		t.Errorf("depth %d via %v, want the cycle cut to a chain of 2", r.MaxDepth, r.LongestChain)
+	}
+	if r.LargestCluster == nil || r.LargestCluster.Blocked != 3 || len(r.LargestCluster.Roots) != 0 {
+		t.Errorf("largest cluster = %+v, want all 3 blocked and no roots", r.LargestCluster)
+	}
+
+	if empty := buildGraphReport(issues, nil, 8, 0); empty.Edges != 0 || empty.LargestCluster != nil || empty.MaxDepth != 0 {
+		t.Errorf("report without dependencies = %+v", empty)
+	}
+}
@@ -0,0 +1,339 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var reportHeatmapCmd = &cobra.Command{
+	Use:   "heatmap",
+	Short: "Show where bugs, reopens and slow work cluster, per label over time",
+	Long: `Show per label (or assignee) and per week or month:
+
+  bugs     bug issues created
+  reopens  closed issues moved back to another status
+  cycle    average days from start (first in_progress, else creation) to
+           close, for issues closed in the period
+
+Each metric is drawn as a heatmap, darker cells marking the hot spots, so
+areas with quality problems stand out. Issues with several labels count
+under each. Rows are ordered by bugs plus reopens.
+
+--csv writes one row per label and period instead, for spreadsheets.
+
+Examples:
+  bd report heatmap
+  bd report heatmap --by assignee --period week --periods 12
+  bd report heatmap --csv > heatmap.csv`,
+	Args: cobra.NoArgs,
+	Run:  runReportHeatmap,
+}
+
+func init() {
+	reportHeatmapCmd.Flags().String("by", "label", "Group by label or assignee")
+	reportHeatmapCmd.Flags().String("period", "month", "Period length: week or month")
+	reportHeatmapCmd.Flags().Int("periods", 6, "Number of periods, ending with the current one")
+	reportHeatmapCmd.Flags().Int("limit", 20, "Maximum rows to show (0 = all)")
+	reportHeatmapCmd.Flags().Bool("csv", false, "Output CSV")
+	reportCmd.AddCommand(reportHeatmapCmd)
+}
+
+// heatmapCell is one group's numbers for one period.
+type heatmapCell struct {
+	Bugs      int     `json:"bugs"`
+	Reopens   int     `json:"reopens"`
+	Closed    int     `json:"closed"`
+	CycleDays float64 `json:"avg_cycle_days"` // Over Closed issues; 0 when none
+}
+
+// heatmapRow is one label's or assignee's row of the heatmap.
+type heatmapRow struct {
+	Name    string        `json:"name"`
+	Bugs    int           `json:"bugs"`
+	Reopens int           `json:"reopens"`
+	Cells   []heatmapCell `json:"cells"` // One per period, oldest first
+}
+
+// heatmapReport is the output of bd report heatmap.
+type heatmapReport struct {
+	By      string       `json:"by"`
+	Period  string       `json:"period"`
+	Periods []string     `json:"periods"` // Period labels, oldest first
+	Rows    []heatmapRow `json:"rows"`
+}
+
+// periodStart returns the start of the week (Monday) or month containing t.
+func periodStart(t time.Time, period string) time.Time {
+	y, m, d := t.Date()
+	if period == "month" {
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	}
+	day := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// heatmapPeriods returns the starts of the n periods ending with the one
+// containing now, oldest first.
+func heatmapPeriods(now time.Time, period string, n int) []time.Time {
+	starts := make([]time.Time, n)
+	start := periodStart(now, period)
+	for i := n - 1; i >= 0; i-- {
+		starts[i] = start
+		if period == "month" {
+			start = start.AddDate(0, -1, 0)
+		} else {
+			start = start.AddDate(0, 0, -7)
+		}
+	}
+	return starts
+}
+
+// periodIndex returns the index of the period containing t, or -1 if t is
+// before the first.
+func periodIndex(starts []time.Time, t time.Time) int {
+	for i := len(starts) - 1; i >= 0; i-- {
+		if !t.Before(starts[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// buildHeatmapReport counts bugs, reopens and cycle times per group and
+// period. labels maps issue IDs to their labels (for by "label"), and
+// changes holds the issues' status changes.
+func buildHeatmapReport(issues []*types.Issue, labels map[string][]string, changes map[string][]types.StatusChange,
+	by, period string, n int, now time.Time, limit int) *heatmapReport {
+	starts := heatmapPeriods(now, period, n)
+	r := &heatmapReport{By: by, Period: period, Rows: []heatmapRow{}}
+	for _, s := range starts {
+		if period == "month" {
+			r.Periods = append(r.Periods, s.Format("2006-01"))
+		} else {
+			r.Periods = append(r.Periods, s.Format("2006-01-02"))
+		}
+	}
+
+	rows := make(map[string]*heatmapRow)
+	cycleTotals := make(map[string][]float64)
+	for _, issue := range issues {
+		var groups []string
+		if by == "assignee" {
+			groups = []string{issue.Assignee}
+			if issue.Assignee == "" {
+				groups = []string{"(unassigned)"}
+			}
+		} else if groups = labels[issue.ID]; len(groups) == 0 {
+			groups = []string{"(no label)"}
+		}
+
+		bugIn := -1
+		if issue.IssueType == types.TypeBug {
+			bugIn = periodIndex(starts, issue.CreatedAt)
+		}
+		var reopensIn []int
+		started := issue.CreatedAt
+		var sawStart bool
+		for _, c := range changes[issue.ID] {
+			if c.From == types.StatusClosed && c.To != types.StatusClosed {
+				if i := periodIndex(starts, c.At); i >= 0 {
+					reopensIn = append(reopensIn, i)
+				}
+			}
+			if c.To == types.StatusInProgress && !sawStart {
+				started, sawStart = c.At, true
+			}
+		}
+		closedIn := -1
+		var cycleDays float64
+		if issue.Status == types.StatusClosed && issue.ClosedAt != nil {
+			closedIn = periodIndex(starts, *issue.ClosedAt)
+			if d := issue.ClosedAt.Sub(started); d > 0 {
+				cycleDays = d.Hours() / 24
+			}
+		}
+		if bugIn < 0 && len(reopensIn) == 0 && closedIn < 0 {
+			continue
+		}
+
+		for _, g := range groups {
+			row := rows[g]
+			if row == nil {
+				row = &heatmapRow{Name: g, Cells: make([]heatmapCell, n)}
+				rows[g] = row
+				cycleTotals[g] = make([]float64, n)
+			}
+			if bugIn >= 0 {
+				row.Cells[bugIn].Bugs++
+				row.Bugs++
+			}
+			for _, i := range reopensIn {
+				row.Cells[i].Reopens++
+				row.Reopens++
+			}
+			if closedIn >= 0 {
+				row.Cells[closedIn].Closed++
+				cycleTotals[g][closedIn] += cycleDays
+			}
+		}
+	}
+
+	for name, row := range rows {
+		for i := range row.Cells {
+			if c := &row.Cells[i]; c.Closed > 0 {
+				c.CycleDays = cycleTotals[name][i] / float64(c.Closed)
+			}
+		}
+		r.Rows = append(r.Rows, *row)
+	}
+	sort.Slice(r.Rows, func(i, j int) bool {
+		a, b := r.Rows[i], r.Rows[j]
+		if a.Bugs+a.Reopens != b.Bugs+b.Reopens {
+			return a.Bugs+a.Reopens > b.Bugs+b.Reopens
+		}
+		return a.Name < b.Name
+	})
+	if limit > 0 && len(r.Rows) > limit {
+		r.Rows = r.Rows[:limit]
+	}
+	return r
+}
+
+func runReportHeatmap(cmd *cobra.Command, _ []string) {
+	by, _ := cmd.Flags().GetString("by")
+	period, _ := cmd.Flags().GetString("period")
+	n, _ := cmd.Flags().GetInt("periods")
+	limit, _ := cmd.Flags().GetInt("limit")
+	csvOutput, _ := cmd.Flags().GetBool("csv")
+	if by != "label" && by != "assignee" {
+		FatalErrorRespectJSON("invalid --by %q (valid: label, assignee)", by)
+	}
+	if period != "week" && period != "month" {
+		FatalErrorRespectJSON("invalid --period %q (valid: week, month)", period)
+	}
+	if n < 1 {
+		FatalErrorRespectJSON("--periods must be at least 1")
+	}
+	ctx := rootCtx
+
+	persistent, notTemplate := false, false
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{
+		Ephemeral:  &persistent,
+		IsTemplate: &notTemplate,
+	})
+	if err != nil {
+		FatalErrorRespectJSON("listing issues: %v", err)
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	changes, err := store.GetStatusChanges(ctx, ids)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	var labels map[string][]string
+	if by == "label" && len(ids) > 0 {
+		if labels, err = store.GetLabelsForIssues(ctx, ids); err != nil {
+			FatalErrorRespectJSON("fetching labels: %v", err)
+		}
+	}
+
+	report := buildHeatmapReport(issues, labels, changes, by, period, n, time.Now(), limit)
+	switch {
+	case jsonOutput:
+		outputJSON(report)
+	case csvOutput:
+		if err := writeHeatmapCSV(report); err != nil {
+			FatalErrorRespectJSON("writing CSV: %v", err)
+		}
+	default:
+		displayHeatmapReport(report)
+	}
+}
+
+// writeHeatmapCSV writes one row per group and period.
+func writeHeatmapCSV(r *heatmapReport) error {
+	w := csv.NewWriter(os.Stdout)
+	_ = w.Write([]string{r.By, "period", "bugs", "reopens", "closed", "avg_cycle_days"})
+	for _, row := range r.Rows {
+		for i, c := range row.Cells {
+			_ = w.Write([]string{row.Name, r.Periods[i], strconv.Itoa(c.Bugs), strconv.Itoa(c.Reopens),
+				strconv.Itoa(c.Closed), strconv.FormatFloat(c.CycleDays, 'f', 1, 64)})
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// heatShades are the cell shades from cold to hot.
+var heatShades = []string{"░", "▒", "▓", "█"}
+
+// heatCell renders v as a shaded cell scaled against the hottest value;
+// zero is a dot.
+func heatCell(v, hottest float64, text string) string {
+	if v <= 0 || hottest <= 0 {
+		return ui.RenderMuted(fmt.Sprintf("%7s", "·"))
+	}
+	level := int(v / hottest * float64(len(heatShades)))
+	if level >= len(heatShades) {
+		level = len(heatShades) - 1
+	}
+	cell := fmt.Sprintf("%5s %s", text, heatShades[level])
+	if level == len(heatShades)-1 {
+		return ui.RenderFail(cell)
+	}
+	if level >= len(heatShades)/2 {
+		return ui.RenderWarn(cell)
+	}
+	return cell
+}
+
+func displayHeatmapReport(r *heatmapReport) {
+	if len(r.Rows) == 0 {
+		fmt.Printf("\n%s No bugs, reopens or closes in the last %d %ss\n\n", ui.RenderPass("✨"), len(r.Periods), r.Period)
+		return
+	}
+	fmt.Printf("\n%s Quality heatmap by %s, per %s:\n", ui.RenderAccent("▦"), r.By, r.Period)
+	displayHeatmapTable(r, "Bugs filed", func(c heatmapCell) float64 { return float64(c.Bugs) }, "%.0f")
+	displayHeatmapTable(r, "Reopens", func(c heatmapCell) float64 { return float64(c.Reopens) }, "%.0f")
+	displayHeatmapTable(r, "Avg cycle time (days)", func(c heatmapCell) float64 { return c.CycleDays }, "%.1f")
+	fmt.Println()
+}
+
+func displayHeatmapTable(r *heatmapReport, title string, value func(heatmapCell) float64, format string) {
+	width := len("(unassigned)")
+	var hottest float64
+	for _, row := range r.Rows {
+		if len(row.Name) > width {
+			width = len(row.Name)
+		}
+		for _, c := range row.Cells {
+			if v := value(c); v > hottest {
+				hottest = v
+			}
+		}
+	}
+	fmt.Printf("\n%s\n", ui.RenderBold(title))
+	fmt.Printf("  %-*s", width, "")
+	for _, p := range r.Periods {
+		fmt.Printf(" %10s", p)
+	}
+	fmt.Println()
+	for _, row := range r.Rows {
+		fmt.Printf("  %-*s", width, row.Name)
+		for _, c := range row.Cells {
+			v := value(c)
+			fmt.Printf("    %s", heatCell(v, hottest, fmt.Sprintf(format, v)))
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestHeatmapPeriods(t *testing.T) {
+	now := time.Date(2026, 3, 18, 15, 0, 0, 0, time.UTC) // A Wednesday
+	months := heatmapPeriods(now, "month", 3)
+	if got := months[0].Format("2006-01-02") + " " + months[2].Format("2006-01-02"); got != "2026-01-01 2026-03-01" {
+		t.Errorf("months = %s", got)
+	}
+	weeks := heatmapPeriods(now, "week", 2)
+	if got := weeks[0].Format("2006-01-02") + " " + weeks[1].Format("2006-01-02"); got != "2026-03-09 2026-03-16" {
+		t.Errorf("weeks = %s", got)
+	}
+	if i := periodIndex(months, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)); i != -1 {
+		t.Errorf("index before the first period = %d", i)
+	}
+	if i := periodIndex(months, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)); i != 1 {
+		t.Errorf("index in February = %d", i)
+	}
+}
+
+func TestBuildHeatmapReport(t *testing.T) {
+	now := time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC)
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 0, 0, 0, 0, time.UTC) }
+	closedMar10 := day(3, 10)
+	closedFeb20 := day(2, 20)
+	issues := []*types.Issue{
+		{ID: "a", IssueType: types.TypeBug, Status: types.StatusOpen, CreatedAt: day(3, 1)},
+		{ID: "b", IssueType: types.TypeBug, Status: types.StatusClosed, CreatedAt: day(2, 1), ClosedAt: &closedMar10},
+		{ID: "c", IssueType: types.TypeTask, Status: types.StatusClosed, CreatedAt: day(2, 10), ClosedAt: &closedFeb20},
+		{ID: "d", IssueType: types.TypeBug, Status: types.StatusOpen, CreatedAt: day(1, 5)}, // Before the periods
+	}
+	labels := map[string][]string{"a": {"parser"}, "b": {"parser", "cli"}, "c": {"cli"}}
+	changes := map[string][]types.StatusChange{
+		"b": {
+			{At: day(2, 6), From: types.StatusOpen, To: types.StatusInProgress},
+			{At: day(2, 8), From: types.StatusInProgress, To: types.StatusClosed},
+			{At: day(3, 2), From: types.StatusClosed, To: types.StatusOpen},
+			{At: day(3, 10), From: types.StatusOpen, To: types.StatusClosed},
+		},
+	}
+
+	r := buildHeatmapReport(issues, labels, changes, "label", "month", 2, now, 0)
+	if len(r.Periods) != 2 || r.Periods[0] != "2026-02" || r.Periods[1] != "2026-03" {
+		t.Fatalf("periods = %v", r.Periods)
+	}
+	if len(r.Rows) != 2 || r.Rows[0].Name != "parser" || r.Rows[1].Name != "cli" {
+		t.Fatalf("rows = %+v", r.Rows)
+	}
+	parser := r.Rows[0]
+	if parser.Bugs != 2 || parser.Reopens != 1 {
+		t.Errorf("parser totals = %d bugs, %d reopens; want 2, 1", parser.Bugs, parser.Reopens)
+	}
+	if c := parser.Cells[1]; c.Bugs != 1 || c.Reopens != 1 || c.Closed != 1 || c.CycleDays != 32 {
+		t.Errorf("parser March = %+v, want 1 bug, 1 reopen, 1 closed after 32 days", c)
+	}
+	if c := r.Rows[1].Cells[0]; c.Closed != 1 || c.CycleDays != 10 {
+		t.Errorf("cli February = %+v, want 1 closed after 10 days (from creation)", c)
+	}
+
+	byAssignee := buildHeatmapReport(issues, nil, changes, "assignee", "month", 2, now, 1)
+	if len(byAssignee.Rows) != 1 || byAssignee.Rows[0].Name != "(unassigned)" || byAssignee.Rows[0].Bugs != 2 {
+		t.Errorf("by assignee = %+v", byAssignee.Rows)
+	}
+}
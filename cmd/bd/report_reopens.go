@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var reportReopensCmd = &cobra.Command{
+	Use:   "reopens",
+	Short: "List issues that were reopened, most often first",
+	Long: `List issues that were closed and then reopened, most reopened first,
+counted from their event history ('bd reopen', or any update that moves a
+closed issue back to another status). Issues that bounce usually had
+unclear acceptance criteria or were closed too early.
+
+Examples:
+  bd report reopens
+  bd report reopens --min 2         # Only issues reopened at least twice
+  bd report reopens --limit 0 --json`,
+	Args: cobra.NoArgs,
+	Run:  runReportReopens,
+}
+
+func init() {
+	reportReopensCmd.Flags().Int("min", 1, "Only list issues reopened at least this many times")
+	reportReopensCmd.Flags().Int("limit", 20, "Maximum issues to list (0 = all)")
+	reportCmd.AddCommand(reportReopensCmd)
+}
+
+// reopenEntry is one issue's row of the reopen report.
+type reopenEntry struct {
+	ID             string       `json:"id"`
+	Title          string       `json:"title"`
+	Priority       int          `json:"priority"`
+	Status         types.Status `json:"status"`
+	Assignee       string       `json:"assignee,omitempty"`
+	Reopens        int          `json:"reopens"`
+	LastClosedBy   string       `json:"last_closed_by,omitempty"`
+	LastReopenedAt *time.Time   `json:"last_reopened_at,omitempty"`
+}
+
+// reopenReport is the output of bd report reopens.
+type reopenReport struct {
+	ReopenedIssues int           `json:"reopened_issues"` // Issues reopened at least once
+	TotalReopens   int           `json:"total_reopens"`
+	Issues         []reopenEntry `json:"issues"`
+}
+
+// buildReopenReport lists the issues reopened at least minReopens times, most
+// reopened first, up to limit (0 = all). Histories without an issue, such
+// as those of deleted issues, are skipped.
+func buildReopenReport(histories map[string]*types.ReopenHistory, issues map[string]*types.Issue, minReopens, limit int) *reopenReport {
+	r := &reopenReport{Issues: []reopenEntry{}}
+	for id, h := range histories {
+		issue := issues[id]
+		if issue == nil || h.Reopens == 0 {
+			continue
+		}
+		r.ReopenedIssues++
+		r.TotalReopens += h.Reopens
+		if h.Reopens < minReopens {
+			continue
+		}
+		r.Issues = append(r.Issues, reopenEntry{
+			ID:             id,
+			Title:          issue.Title,
+			Priority:       issue.Priority,
+			Status:         issue.Status,
+			Assignee:       issue.Assignee,
+			Reopens:        h.Reopens,
+			LastClosedBy:   h.LastClosedBy,
+			LastReopenedAt: h.LastReopenedAt,
+		})
+	}
+	sort.Slice(r.Issues, func(i, j int) bool {
+		a, b := r.Issues[i], r.Issues[j]
+		if a.Reopens != b.Reopens {
+			return a.Reopens > b.Reopens
+		}
+		return a.ID < b.ID
+	})
+	if limit > 0 && len(r.Issues) > limit {
+		r.Issues = r.Issues[:limit]
+	}
+	return r
+}
+
+func runReportReopens(cmd *cobra.Command, _ []string) {
+	minReopens, _ := cmd.Flags().GetInt("min")
+	limit, _ := cmd.Flags().GetInt("limit")
+	ctx := rootCtx
+
+	histories, err := store.GetReopenHistory(ctx, nil)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	var ids []string
+	for id, h := range histories {
+		if h.Reopens > 0 {
+			ids = append(ids, id)
+		}
+	}
+	issues := make(map[string]*types.Issue, len(ids))
+	if len(ids) > 0 {
+		found, err := store.GetIssuesByIDs(ctx, ids)
+		if err != nil {
+			FatalErrorRespectJSON("fetching issues: %v", err)
+		}
+		for _, issue := range found {
+			issues[issue.ID] = issue
+		}
+	}
+
+	report := buildReopenReport(histories, issues, minReopens, limit)
+	if jsonOutput {
+		outputJSON(report)
+		return
+	}
+	displayReopenReport(report)
+}
+
+func displayReopenReport(r *reopenReport) {
+	if r.ReopenedIssues == 0 {
+		fmt.Printf("\n%s No reopened issues\n\n", ui.RenderPass("✨"))
+		return
+	}
+	fmt.Printf("\n%s Reopened issues (%d issues, %d reopens):\n\n", ui.RenderAccent("↻"), r.ReopenedIssues, r.TotalReopens)
+	for _, e := range r.Issues {
+		detail := fmt.Sprintf("%s, reopened %s", e.Status, pluralTimes(e.Reopens))
+		if e.LastClosedBy != "" {
+			detail += ", last closed by " + e.LastClosedBy
+		}
+		line := fmt.Sprintf("  %s %s: %s %s", ui.RenderPriority(e.Priority), ui.RenderID(e.ID), e.Title, ui.RenderMuted("("+detail+")"))
+		if e.Assignee != "" {
+			line += " " + ui.RenderMuted("@"+e.Assignee)
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
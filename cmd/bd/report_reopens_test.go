@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildReopenReport(t *testing.T) {
+	histories := map[string]*types.ReopenHistory{
+		"a": {Reopens: 1, LastClosedBy: "alice"},
+		"b": {Reopens: 3, LastClosedBy: "bob"},
+		"c": {Reopens: 0, LastClosedBy: "carol"}, // Closed, never reopened
+		"d": {Reopens: 2},                        // Deleted since
+		"e": {Reopens: 3},
+	}
+	issues := map[string]*types.Issue{
+		"a": {ID: "a", Title: "A", Status: types.StatusOpen},
+		"b": {ID: "b", Title: "B", Status: types.StatusClosed},
+		"c": {ID: "c", Title: "C", Status: types.StatusClosed},
+		"e": {ID: "e", Title: "E", Status: types.StatusInProgress},
+	}
+
+	r := buildReopenReport(histories, issues, 1, 0)
+	if r.ReopenedIssues != 3 || r.TotalReopens != 7 {
+		t.Errorf("totals = %d issues, %d reopens; want 3, 7", r.ReopenedIssues, r.TotalReopens)
+	}
+	var got []string
+	for _, e := range r.Issues {
+		got = append(got, e.ID)
+	}
+	if want := []string{"b", "e", "a"}; !stringSlicesEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+	if r.Issues[0].LastClosedBy != "bob" {
+		t.Errorf("last closed by = %q", r.Issues[0].LastClosedBy)
+	}
+
+	if r := buildReopenReport(histories, issues, 2, 1); len(r.Issues) != 1 || r.Issues[0].ID != "b" || r.TotalReopens != 7 {
+		t.Errorf("--min 2 --limit 1 = %+v", r)
+	}
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var reportRetroCmd = &cobra.Command{
+	Use:   "retro",
+	Short: "Summarize how recently closed work turned out, per epic and assignee",
+	Long: `Summarize the outcomes of issues closed in a window (shipped, reverted,
+abandoned, or untagged; see 'bd outcome' and close.outcomes), per epic and
+per assignee, as input to a retrospective.
+
+Issues count under the nearest epic above them, or "(no epic)"; epics
+themselves aren't counted. --since takes a duration back from now (30d, 2w,
+3m) or a date (2026-01-01).
+
+Examples:
+  bd report retro                   # Last 30 days
+  bd report retro --since 2w --by assignee
+  bd report retro --since 2026-07-01 --json`,
+	Args: cobra.NoArgs,
+	Run:  runReportRetro,
+}
+
+func init() {
+	reportRetroCmd.Flags().String("since", "30d", "Include issues closed since this long ago (30d, 2w) or this date")
+	reportRetroCmd.Flags().String("by", "both", "Group by epic, assignee, or both")
+	reportCmd.AddCommand(reportRetroCmd)
+}
+
+// untaggedOutcome counts closed issues without an outcome label.
+const untaggedOutcome = "untagged"
+
+// retroGroup is one epic's or assignee's row of the retro report.
+type retroGroup struct {
+	Name     string         `json:"name"`
+	Title    string         `json:"title,omitempty"` // Epic title
+	Outcomes map[string]int `json:"outcomes"`
+	Total    int            `json:"total"`
+}
+
+// retroReport is the output of bd report retro.
+type retroReport struct {
+	Since      time.Time    `json:"since"`
+	Outcomes   []string     `json:"outcomes"` // Column order, untagged last
+	Total      int          `json:"total"`
+	Overall    retroGroup   `json:"overall"`
+	ByEpic     []retroGroup `json:"by_epic,omitempty"`
+	ByAssignee []retroGroup `json:"by_assignee,omitempty"`
+}
+
+// buildRetroReport counts the outcomes of closed issues. labels maps issue
+// IDs to their labels, parents maps issue IDs to their parent's ID, and
+// ancestors holds the parents (and their parents) by ID, for finding each
+// issue's epic. outcomes is close.outcomes; outcomes recorded before it
+// changed get columns of their own.
+func buildRetroReport(issues []*types.Issue, labels map[string][]string, parents map[string]string, ancestors map[string]*types.Issue, outcomes []string, since time.Time, byEpic, byAssignee bool) *retroReport {
+	r := &retroReport{Since: since}
+	known := make(map[string]bool, len(outcomes))
+	for _, o := range outcomes {
+		known[o] = true
+	}
+	var extra []string
+	overall := make(map[string]*retroGroup)
+	epicGroups := make(map[string]*retroGroup)
+	assigneeGroups := make(map[string]*retroGroup)
+	add := func(groups map[string]*retroGroup, name, title, outcome string) {
+		g := groups[name]
+		if g == nil {
+			g = &retroGroup{Name: name, Title: title, Outcomes: map[string]int{}}
+			groups[name] = g
+		}
+		g.Outcomes[outcome]++
+		g.Total++
+	}
+
+	for _, issue := range issues {
+		if issue.IssueType == types.TypeEpic {
+			continue
+		}
+		outcome := issueOutcome(labels[issue.ID])
+		if outcome == "" {
+			outcome = untaggedOutcome
+		} else if !known[outcome] {
+			known[outcome] = true
+			extra = append(extra, outcome)
+		}
+		add(overall, "all", "", outcome)
+		if byEpic {
+			if epic := retroEpicOf(issue.ID, parents, ancestors); epic != nil {
+				add(epicGroups, epic.ID, epic.Title, outcome)
+			} else {
+				add(epicGroups, "(no epic)", "", outcome)
+			}
+		}
+		if byAssignee {
+			assignee := issue.Assignee
+			if assignee == "" {
+				assignee = "(unassigned)"
+			}
+			add(assigneeGroups, assignee, "", outcome)
+		}
+	}
+
+	sort.Strings(extra)
+	r.Outcomes = append(append(append([]string{}, outcomes...), extra...), untaggedOutcome)
+	if all := sortRetroGroups(overall, r.Outcomes); len(all) > 0 {
+		r.Overall, r.Total = all[0], all[0].Total
+	} else {
+		r.Overall = retroGroup{Name: "all", Outcomes: emptyRetroCounts(r.Outcomes)}
+	}
+	if byEpic {
+		r.ByEpic = sortRetroGroups(epicGroups, r.Outcomes)
+	}
+	if byAssignee {
+		r.ByAssignee = sortRetroGroups(assigneeGroups, r.Outcomes)
+	}
+	return r
+}
+
+// retroEpicOf returns the nearest epic above an issue, or nil.
+func retroEpicOf(id string, parents map[string]string, ancestors map[string]*types.Issue) *types.Issue {
+	seen := map[string]bool{id: true}
+	for parent := parents[id]; parent != "" && !seen[parent]; parent = parents[parent] {
+		seen[parent] = true
+		if p := ancestors[parent]; p != nil && p.IssueType == types.TypeEpic {
+			return p
+		}
+	}
+	return nil
+}
+
+func emptyRetroCounts(outcomes []string) map[string]int {
+	counts := make(map[string]int, len(outcomes))
+	for _, o := range outcomes {
+		counts[o] = 0
+	}
+	return counts
+}
+
+// sortRetroGroups orders groups by size, largest first, then by name,
+// giving each a count for every outcome.
+func sortRetroGroups(groups map[string]*retroGroup, outcomes []string) []retroGroup {
+	out := make([]retroGroup, 0, len(groups))
+	for _, g := range groups {
+		counts := emptyRetroCounts(outcomes)
+		for o, n := range g.Outcomes {
+			counts[o] = n
+		}
+		g.Outcomes = counts
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Total != out[j].Total {
+			return out[i].Total > out[j].Total
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// parseRetroSince parses --since: an unsigned duration such as 30d counts
+// back from now, and anything else is parsed like other time flags.
+func parseRetroSince(s string, now time.Time) (time.Time, error) {
+	if !strings.HasPrefix(s, "+") && !strings.HasPrefix(s, "-") {
+		if t, err := timeparsing.ParseCompactDuration("-"+s, now); err == nil {
+			return t, nil
+		}
+	}
+	return parseTimeFlag(s)
+}
+
+func runReportRetro(cmd *cobra.Command, _ []string) {
+	sinceFlag, _ := cmd.Flags().GetString("since")
+	by, _ := cmd.Flags().GetString("by")
+	byEpic, byAssignee := by == "epic" || by == "both", by == "assignee" || by == "both"
+	if !byEpic && !byAssignee {
+		FatalErrorCode(exitValidation, "invalid --by %q (valid: epic, assignee, both)", by)
+	}
+	since, err := parseRetroSince(sinceFlag, time.Now())
+	if err != nil {
+		FatalErrorCode(exitValidation, "invalid --since %q: %v", sinceFlag, err)
+	}
+	ctx := rootCtx
+
+	closed, persistent := types.StatusClosed, false
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{Status: &closed, ClosedAfter: &since, Ephemeral: &persistent})
+	if err != nil {
+		FatalErrorRespectJSON("listing closed issues: %v", err)
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labels := map[string][]string{}
+	if len(ids) > 0 {
+		if labels, err = store.GetLabelsForIssues(ctx, ids); err != nil {
+			FatalErrorRespectJSON("fetching labels: %v", err)
+		}
+	}
+
+	// Walk up parent-child links a level at a time to find each issue's epic
+	parents := make(map[string]string)
+	ancestors := make(map[string]*types.Issue)
+	for level := ids; byEpic && len(level) > 0; {
+		deps, err := store.GetDependencyRecordsForIssues(ctx, level)
+		if err != nil {
+			FatalErrorRespectJSON("fetching dependencies: %v", err)
+		}
+		var next []string
+		for _, id := range level {
+			for _, dep := range deps[id] {
+				if dep.Type != types.DepParentChild {
+					continue
+				}
+				parents[id] = dep.DependsOnID
+				if _, ok := ancestors[dep.DependsOnID]; !ok {
+					ancestors[dep.DependsOnID] = nil
+					next = append(next, dep.DependsOnID)
+				}
+			}
+		}
+		if len(next) > 0 {
+			found, err := store.GetIssuesByIDs(ctx, next)
+			if err != nil {
+				FatalErrorRespectJSON("fetching parents: %v", err)
+			}
+			for _, p := range found {
+				ancestors[p.ID] = p
+			}
+		}
+		level = next
+	}
+
+	report := buildRetroReport(issues, labels, parents, ancestors, closeOutcomes(), since, byEpic, byAssignee)
+	if jsonOutput {
+		outputJSON(report)
+		return
+	}
+	displayRetroReport(report)
+}
+
+func displayRetroReport(r *retroReport) {
+	if r.Total == 0 {
+		fmt.Printf("\n%s No issues closed since %s\n\n", ui.RenderPass("✨"), r.Since.Local().Format("2006-01-02"))
+		return
+	}
+	fmt.Printf("\n%s Retro: %d issues closed since %s\n", ui.RenderAccent("↺"), r.Total, r.Since.Local().Format("2006-01-02"))
+	displayRetroTable("Overall", r.Outcomes, []retroGroup{r.Overall})
+	if r.ByEpic != nil {
+		displayRetroTable("By epic", r.Outcomes, r.ByEpic)
+	}
+	if r.ByAssignee != nil {
+		displayRetroTable("By assignee", r.Outcomes, r.ByAssignee)
+	}
+	if n := r.Overall.Outcomes[untaggedOutcome]; n > 0 {
+		fmt.Printf("\n%s\n", ui.RenderMuted(fmt.Sprintf("%d untagged; tag them with: bd outcome <id> <outcome>", n)))
+	}
+	fmt.Println()
+}
+
+func displayRetroTable(title string, outcomes []string, groups []retroGroup) {
+	width := len("(unassigned)")
+	for _, g := range groups {
+		if len(g.Name) > width {
+			width = len(g.Name)
+		}
+	}
+	fmt.Printf("\n%s\n", ui.RenderBold(title))
+	fmt.Printf("  %-*s", width, "")
+	for _, o := range outcomes {
+		fmt.Printf("%10s", o)
+	}
+	fmt.Printf("%10s\n", "total")
+	for _, g := range groups {
+		fmt.Printf("  %-*s", width, g.Name)
+		for _, o := range outcomes {
+			if n := g.Outcomes[o]; n > 0 {
+				fmt.Printf("%10d", n)
+			} else {
+				fmt.Print(ui.RenderMuted(fmt.Sprintf("%10s", "·")))
+			}
+		}
+		fmt.Printf("%10d", g.Total)
+		if g.Title != "" {
+			fmt.Printf("  %s", ui.RenderMuted(g.Title))
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildRetroReport(t *testing.T) {
+	since := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	issues := []*types.Issue{
+		{ID: "a", Assignee: "alice", IssueType: types.TypeTask},
+		{ID: "b", Assignee: "alice", IssueType: types.TypeTask},
+		{ID: "c", Assignee: "bob", IssueType: types.TypeBug},
+		{ID: "d", IssueType: types.TypeTask},
+		{ID: "epic", IssueType: types.TypeEpic}, // Not counted
+	}
+	labels := map[string][]string{
+		"a": {"backend", "outcome:shipped"},
+		"b": {"outcome:reverted"},
+		"c": {"outcome:rolled-back"}, // Since dropped from close.outcomes
+	}
+	// a is a grandchild of epic, b a direct child; c's parent isn't an epic
+	parents := map[string]string{"a": "story", "story": "epic", "b": "epic", "c": "task"}
+	ancestors := map[string]*types.Issue{
+		"story": {ID: "story", IssueType: types.TypeFeature},
+		"epic":  {ID: "epic", Title: "Checkout v2", IssueType: types.TypeEpic},
+		"task":  {ID: "task", IssueType: types.TypeTask},
+	}
+
+	r := buildRetroReport(issues, labels, parents, ancestors, []string{"shipped", "reverted", "abandoned"}, since, true, true)
+	if r.Total != 4 {
+		t.Fatalf("total = %d, want 4", r.Total)
+	}
+	wantOutcomes := []string{"shipped", "reverted", "abandoned", "rolled-back", "untagged"}
+	if !reflect.DeepEqual(r.Outcomes, wantOutcomes) {
+		t.Errorf("outcomes = %v, want %v", r.Outcomes, wantOutcomes)
+	}
+	wantOverall := map[string]int{"shipped": 1, "reverted": 1, "abandoned": 0, "rolled-back": 1, "untagged": 1}
+	if !reflect.DeepEqual(r.Overall.Outcomes, wantOverall) {
+		t.Errorf("overall = %v, want %v", r.Overall.Outcomes, wantOverall)
+	}
+
+	if len(r.ByEpic) != 2 || r.ByEpic[1].Name != "epic" || r.ByEpic[1].Title != "Checkout v2" || r.ByEpic[1].Outcomes["shipped"] != 1 {
+		t.Fatalf("by epic = %+v", r.ByEpic)
+	}
+	if noEpic := r.ByEpic[0]; noEpic.Name != "(no epic)" || noEpic.Outcomes["rolled-back"] != 1 || noEpic.Outcomes["untagged"] != 1 {
+		t.Errorf("(no epic) = %+v", noEpic)
+	}
+
+	var assignees []string
+	for _, g := range r.ByAssignee {
+		assignees = append(assignees, g.Name)
+	}
+	if want := []string{"alice", "(unassigned)", "bob"}; !reflect.DeepEqual(assignees, want) {
+		t.Errorf("assignees = %v, want %v", assignees, want)
+	}
+
+	if r := buildRetroReport(nil, nil, nil, nil, []string{"shipped"}, since, false, true); r.Total != 0 || r.Overall.Outcomes["shipped"] != 0 || r.ByEpic != nil {
+		t.Errorf("empty report = %+v", r)
+	}
+}
+
+func TestParseRetroSince(t *testing.T) {
+	now := time.Now()
+	got, err := parseRetroSince("30d", now)
+	if err != nil || !got.Equal(now.AddDate(0, 0, -30)) {
+		t.Errorf("parseRetroSince(30d) = %v, %v", got, err)
+	}
+	got, err = parseRetroSince("2026-07-01", now)
+	if err != nil || got.Format("2006-01-02") != "2026-07-01" {
+		t.Errorf("parseRetroSince(2026-07-01) = %v, %v", got, err)
+	}
+	if _, err := parseRetroSince("whenever", now); err == nil {
+		t.Error("parseRetroSince accepted garbage")
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/sla"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var reportSLACmd = &cobra.Command{
+	Use:   "sla",
+	Short: "Report due-date (SLA) timers, paused in configured statuses",
+	Long: `Report the SLA timer of each open issue with a due date.
+
+Time an issue spends in a status listed in sla.paused-statuses (for example
+deferred, or a custom waiting-on-customer status) doesn't count: it pushes
+the effective due date back. The time in each status comes from the issue's
+event history. Each issue is reported as:
+
+  breached   past its effective due date
+  due soon   effective due date within --soon
+  paused     in a paused status now
+  on track   otherwise
+  met        closed by its effective due date (with --closed)
+
+Configuration (.beads/config.yaml):
+  sla:
+    paused-statuses: [deferred, waiting-on-customer]
+
+Examples:
+  bd report sla
+  bd report sla --soon 72h
+  bd report sla --closed 30 --json   # Include issues closed in the last 30 days`,
+	Args: cobra.NoArgs,
+	Run:  runReportSLA,
+}
+
+func init() {
+	reportSLACmd.Flags().Duration("soon", 24*time.Hour, "Report issues whose effective due date is this close as due soon")
+	reportSLACmd.Flags().Int("closed", 0, "Also report issues closed in the last N days, as met or breached")
+	reportCmd.AddCommand(reportSLACmd)
+}
+
+// SLA states, in report order.
+var slaStates = []string{"breached", "due soon", "paused", "on track", "met"}
+
+// slaEntry is one issue's row of the SLA report.
+type slaEntry struct {
+	ID             string       `json:"id"`
+	Title          string       `json:"title"`
+	Priority       int          `json:"priority"`
+	Assignee       string       `json:"assignee,omitempty"`
+	Status         types.Status `json:"status"`
+	State          string       `json:"state"`
+	DueAt          time.Time    `json:"due_at"`
+	EffectiveDueAt time.Time    `json:"effective_due_at"`
+	PausedHours    float64      `json:"paused_hours"`
+	RemainingHours float64      `json:"remaining_hours"` // Negative once breached
+}
+
+// slaReport is the output of bd report sla.
+type slaReport struct {
+	PausedStatuses []string       `json:"paused_statuses"`
+	Counts         map[string]int `json:"counts"`
+	Issues         []slaEntry     `json:"issues"`
+}
+
+// buildSLAReport measures the SLA timer of each issue with a due date.
+// changes maps issue IDs to their status history. Open issues are measured
+// up to now and closed ones up to when they closed.
+func buildSLAReport(issues []*types.Issue, changes map[string][]types.StatusChange, paused []string, now time.Time, soon time.Duration) *slaReport {
+	pausedSet := make(map[types.Status]bool, len(paused))
+	for _, s := range paused {
+		pausedSet[types.Status(s)] = true
+	}
+	r := &slaReport{PausedStatuses: paused, Counts: make(map[string]int), Issues: []slaEntry{}}
+	if r.PausedStatuses == nil {
+		r.PausedStatuses = []string{}
+	}
+	for _, state := range slaStates {
+		r.Counts[state] = 0
+	}
+	for _, issue := range issues {
+		if issue.DueAt == nil {
+			continue
+		}
+		until := now
+		if issue.Status == types.StatusClosed && issue.ClosedAt != nil {
+			until = *issue.ClosedAt
+		}
+		timer := sla.Measure(issue, changes[issue.ID], pausedSet, until)
+		remaining := timer.Remaining(until)
+		var state string
+		switch {
+		case remaining < 0:
+			state = "breached"
+		case issue.Status == types.StatusClosed:
+			state = "met"
+		case timer.PausedNow:
+			state = "paused"
+		case remaining <= soon:
+			state = "due soon"
+		default:
+			state = "on track"
+		}
+		r.Counts[state]++
+		r.Issues = append(r.Issues, slaEntry{
+			ID:             issue.ID,
+			Title:          issue.Title,
+			Priority:       issue.Priority,
+			Assignee:       issue.Assignee,
+			Status:         issue.Status,
+			State:          state,
+			DueAt:          timer.Due,
+			EffectiveDueAt: timer.EffectiveDue,
+			PausedHours:    roundHours(timer.Paused),
+			RemainingHours: roundHours(remaining),
+		})
+	}
+	rank := make(map[string]int, len(slaStates))
+	for i, state := range slaStates {
+		rank[state] = i
+	}
+	sort.SliceStable(r.Issues, func(i, j int) bool {
+		a, b := r.Issues[i], r.Issues[j]
+		if a.State != b.State {
+			return rank[a.State] < rank[b.State]
+		}
+		if a.RemainingHours != b.RemainingHours {
+			return a.RemainingHours < b.RemainingHours
+		}
+		return a.ID < b.ID
+	})
+	return r
+}
+
+func roundHours(d time.Duration) float64 {
+	return float64(d.Round(6*time.Minute)) / float64(time.Hour)
+}
+
+func runReportSLA(cmd *cobra.Command, _ []string) {
+	soon, _ := cmd.Flags().GetDuration("soon")
+	closedDays, _ := cmd.Flags().GetInt("closed")
+	ctx := rootCtx
+	now := time.Now()
+
+	persistent, notTemplate := false, false
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{
+		ExcludeStatus: []types.Status{types.StatusClosed},
+		Ephemeral:     &persistent,
+		IsTemplate:    &notTemplate,
+	})
+	if err != nil {
+		FatalErrorRespectJSON("listing issues: %v", err)
+	}
+	if closedDays > 0 {
+		closed, since := types.StatusClosed, now.AddDate(0, 0, -closedDays)
+		recent, err := store.SearchIssues(ctx, "", types.IssueFilter{
+			Status:      &closed,
+			ClosedAfter: &since,
+			Ephemeral:   &persistent,
+			IsTemplate:  &notTemplate,
+		})
+		if err != nil {
+			FatalErrorRespectJSON("listing closed issues: %v", err)
+		}
+		issues = append(issues, recent...)
+	}
+	var ids []string
+	for _, issue := range issues {
+		if issue.DueAt != nil {
+			ids = append(ids, issue.ID)
+		}
+	}
+	changes, err := store.GetStatusChanges(ctx, ids)
+	if err != nil {
+		FatalErrorRespectJSON("reading status history: %v", err)
+	}
+
+	report := buildSLAReport(issues, changes, config.GetStringSlice("sla.paused-statuses"), now, soon)
+	if jsonOutput {
+		outputJSON(report)
+		return
+	}
+	displaySLAReport(report)
+}
+
+func displaySLAReport(r *slaReport) {
+	if len(r.Issues) == 0 {
+		fmt.Printf("\n%s No issues with due dates\n\n", ui.RenderPass("✨"))
+		return
+	}
+	var counts []string
+	for _, state := range slaStates {
+		if n := r.Counts[state]; n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", n, state))
+		}
+	}
+	fmt.Printf("\n%s SLA timers (%s):\n", ui.RenderAccent("⏱"), strings.Join(counts, ", "))
+	if len(r.PausedStatuses) > 0 {
+		fmt.Println(ui.RenderMuted("  Paused while " + strings.Join(r.PausedStatuses, ", ")))
+	}
+	fmt.Println()
+	for _, e := range r.Issues {
+		state := fmt.Sprintf("%-8s", e.State)
+		switch e.State {
+		case "breached":
+			state = ui.RenderFail(state)
+		case "due soon":
+			state = ui.RenderWarn(state)
+		case "met", "on track":
+			state = ui.RenderPass(state)
+		default:
+			state = ui.RenderMuted(state)
+		}
+		detail := "due " + e.EffectiveDueAt.Local().Format("2006-01-02 15:04")
+		if e.PausedHours > 0 {
+			detail += fmt.Sprintf(", paused %s", formatHours(e.PausedHours))
+		}
+		if e.RemainingHours < 0 {
+			detail += fmt.Sprintf(", %s over", formatHours(-e.RemainingHours))
+		} else if e.State != "met" {
+			detail += fmt.Sprintf(", %s left", formatHours(e.RemainingHours))
+		}
+		line := fmt.Sprintf("  %s %s %s: %s %s", state, ui.RenderPriority(e.Priority), ui.RenderID(e.ID), e.Title, ui.RenderMuted("("+detail+")"))
+		if e.Assignee != "" {
+			line += " " + ui.RenderMuted("@"+e.Assignee)
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
+
+// formatHours renders hours as "5h" under two days and "3d" beyond.
+func formatHours(h float64) string {
+	if h < 48 {
+		return fmt.Sprintf("%.0fh", h)
+	}
+	return fmt.Sprintf("%.0fd", h/24)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildSLAReport(t *testing.T) {
+	now := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)
+	ago := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	at := func(tm time.Time) *time.Time { return &tm }
+	issues := []*types.Issue{
+		// Due 2 days ago, but waited on the customer for 3 days: 1 day left
+		{ID: "a", Status: types.StatusOpen, CreatedAt: ago(10), DueAt: at(ago(2))},
+		// Due 1 day ago, never paused
+		{ID: "b", Status: types.StatusInProgress, CreatedAt: ago(10), DueAt: at(ago(1))},
+		// Deferred since 5 days ago
+		{ID: "c", Status: types.StatusDeferred, CreatedAt: ago(10), DueAt: at(ago(3))},
+		// Due in 10 hours
+		{ID: "d", Status: types.StatusOpen, CreatedAt: ago(1), DueAt: at(now.Add(10 * time.Hour))},
+		// Closed a day before it was due
+		{ID: "e", Status: types.StatusClosed, CreatedAt: ago(10), ClosedAt: at(ago(5)), DueAt: at(ago(4))},
+		// No due date
+		{ID: "f", Status: types.StatusOpen, CreatedAt: ago(10)},
+		// Due in a week
+		{ID: "g", Status: types.StatusOpen, CreatedAt: ago(1), DueAt: at(now.AddDate(0, 0, 7))},
+	}
+	changes := map[string][]types.StatusChange{
+		"a": {
+			{At: ago(8), From: types.StatusOpen, To: "waiting"},
+			{At: ago(5), From: "waiting", To: types.StatusOpen},
+		},
+		"c": {{At: ago(5), From: types.StatusOpen, To: types.StatusDeferred}},
+	}
+
+	r := buildSLAReport(issues, changes, []string{"deferred", "waiting"}, now, 24*time.Hour)
+	var got []string
+	for _, e := range r.Issues {
+		got = append(got, e.ID+":"+e.State)
+	}
+	want := []string{"b:breached", "d:due soon", "a:due soon", "c:paused", "g:on track", "e:met"}
+	if len(got) != len(want) {
+		t.Fatalf("issues = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("issues = %v, want %v", got, want)
+		}
+	}
+	if a := r.Issues[2]; a.PausedHours != 72 || a.RemainingHours != 24 {
+		t.Errorf("a = %+v, want 72h paused, 24h left", a)
+	}
+	if c := r.Issues[3]; c.PausedHours != 120 || !c.EffectiveDueAt.Equal(ago(3).AddDate(0, 0, 5)) {
+		t.Errorf("c = %+v, want 120h paused", c)
+	}
+	if r.Counts["due soon"] != 2 || r.Counts["breached"] != 1 || r.Counts["met"] != 1 {
+		t.Errorf("counts = %v", r.Counts)
+	}
+}
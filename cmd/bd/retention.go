@@ -0,0 +1,373 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/retention"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+var retentionCmd = &cobra.Command{
+	Use:     "retention",
+	GroupID: "maint",
+	Short:   "Apply the retention policy for closed issues",
+	Long: `Delete closed issues once they are older than the configured retention
+period, except issues under legal hold, and record each deletion run in a
+signed manifest.
+
+Policy (config.yaml):
+  retention.closed-after   Retention period after closing, e.g. 7y, 18m, 90d
+                           (a bare number means years; empty disables)
+  retention.hold-label     Label that puts an issue and all its descendants
+                           under legal hold (default: hold)
+  retention.manifest-dir   Where deletion manifests are written
+                           (default: .beads/retention)
+  retention.signing-key    ed25519 key that signs manifests, created on first
+                           run (default: .beads/retention.key; keep it out of git)
+
+Pinned issues and wisps are never deleted by retention.
+
+Each run writes a manifest listing the policy, cutoff, and every deleted
+issue's ID, type, dates, and content hash (not its content), signed with the
+signing key. Commit the manifests and publish the key fingerprint shown by
+'bd retention status' so auditors can check them with 'bd retention verify'.
+
+Deletion removes issues from the current database. Earlier Dolt commits
+still contain them until the history is rewritten or squashed.
+
+Commands:
+  bd retention status             Show the policy and what a run would delete
+  bd retention run [--dry-run]    Delete expired issues and write a manifest
+  bd retention verify <manifest>  Check a manifest's signature`,
+}
+
+var retentionStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the retention policy and what a run would delete",
+	Run:   runRetentionStatus,
+}
+
+var retentionRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Delete closed issues past retention and write a signed manifest",
+	Long: `Delete closed issues that were closed before the retention cutoff and are
+neither under legal hold nor pinned, then write a signed deletion manifest.
+
+Examples:
+  bd retention run --dry-run   # List what would be deleted
+  bd retention run             # Delete and write .beads/retention/<time>.json`,
+	Run: runRetentionRun,
+}
+
+var retentionVerifyCmd = &cobra.Command{
+	Use:   "verify <manifest>",
+	Short: "Verify a deletion manifest's signature",
+	Long: `Check that a deletion manifest is unmodified, and that none of the issues
+it lists exist in the database again.
+
+The signature is checked against the public key in the manifest. Pass
+--fingerprint with the published key fingerprint to also check who signed it.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runRetentionVerify,
+}
+
+func init() {
+	retentionRunCmd.Flags().Bool("dry-run", false, "List what would be deleted without deleting")
+	retentionVerifyCmd.Flags().String("fingerprint", "", "Expected signing key fingerprint (from 'bd retention status')")
+	retentionCmd.AddCommand(retentionStatusCmd, retentionRunCmd, retentionVerifyCmd)
+	rootCmd.AddCommand(retentionCmd)
+}
+
+// retentionPolicy is the retention.* configuration, with paths resolved.
+type retentionPolicy struct {
+	ClosedAfter string
+	Age         retention.Age
+	HoldLabel   string
+	ManifestDir string
+	SigningKey  string
+}
+
+// loadRetentionPolicy reads retention.* from config. Relative paths are
+// resolved against the .beads directory.
+func loadRetentionPolicy() (*retentionPolicy, error) {
+	closedAfter := strings.TrimSpace(config.GetString("retention.closed-after"))
+	if closedAfter == "" {
+		return nil, fmt.Errorf("no retention policy configured (set retention.closed-after in config.yaml, e.g. 7y)")
+	}
+	age, err := retention.ParseAge(closedAfter)
+	if err != nil {
+		return nil, err
+	}
+	beadsDir := beads.FindBeadsDir()
+	resolve := func(path, def string) string {
+		if path == "" {
+			path = def
+		}
+		if !filepath.IsAbs(path) && beadsDir != "" {
+			path = filepath.Join(beadsDir, path)
+		}
+		return path
+	}
+	return &retentionPolicy{
+		ClosedAfter: closedAfter,
+		Age:         age,
+		HoldLabel:   config.GetString("retention.hold-label"),
+		ManifestDir: resolve(config.GetString("retention.manifest-dir"), "retention"),
+		SigningKey:  resolve(config.GetString("retention.signing-key"), "retention.key"),
+	}, nil
+}
+
+// planRetention applies the policy to the persistent closed issues in s.
+func planRetention(ctx context.Context, s *dolt.DoltStore, policy *retentionPolicy, now time.Time) (*retention.Plan, time.Time, error) {
+	cutoff := policy.Age.Cutoff(now)
+	closed := types.StatusClosed
+	persistent := false
+	issues, err := s.SearchIssues(ctx, "", types.IssueFilter{Status: &closed, ClosedBefore: &cutoff, Ephemeral: &persistent})
+	if err != nil {
+		return nil, cutoff, fmt.Errorf("listing closed issues: %w", err)
+	}
+
+	held := make(map[string]bool)
+	if policy.HoldLabel != "" {
+		heldIssues, err := s.GetIssuesByLabel(ctx, policy.HoldLabel)
+		if err != nil {
+			return nil, cutoff, fmt.Errorf("listing held issues: %w", err)
+		}
+		for _, issue := range heldIssues {
+			held[issue.ID] = true
+		}
+	}
+
+	deps, err := s.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, cutoff, fmt.Errorf("fetching dependencies: %w", err)
+	}
+	parents := make(map[string]string)
+	for id, list := range deps {
+		for _, dep := range list {
+			if dep.Type == types.DepParentChild {
+				parents[id] = dep.DependsOnID
+			}
+		}
+	}
+	return retention.Select(issues, cutoff, held, parents), cutoff, nil
+}
+
+// retentionResult is the --json output of status and run.
+type retentionResult struct {
+	ClosedAfter string   `json:"closed_after"`
+	HoldLabel   string   `json:"hold_label"`
+	Cutoff      string   `json:"cutoff"`
+	Delete      []string `json:"delete"`
+	Held        []string `json:"held"`
+	Pinned      []string `json:"pinned"`
+	DryRun      bool     `json:"dry_run,omitempty"`
+	Deleted     int      `json:"deleted"`
+	Manifest    string   `json:"manifest,omitempty"`
+	Fingerprint string   `json:"fingerprint,omitempty"`
+}
+
+func newRetentionResult(policy *retentionPolicy, plan *retention.Plan, cutoff time.Time) retentionResult {
+	ids := make([]string, len(plan.Delete))
+	for i, issue := range plan.Delete {
+		ids[i] = issue.ID
+	}
+	return retentionResult{
+		ClosedAfter: policy.ClosedAfter,
+		HoldLabel:   policy.HoldLabel,
+		Cutoff:      cutoff.UTC().Format(time.RFC3339),
+		Delete:      ids,
+		Held:        append([]string{}, plan.Held...),
+		Pinned:      append([]string{}, plan.Pinned...),
+	}
+}
+
+func runRetentionStatus(_ *cobra.Command, _ []string) {
+	policy, err := loadRetentionPolicy()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	plan, cutoff, err := planRetention(rootCtx, store, policy, time.Now())
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	result := newRetentionResult(policy, plan, cutoff)
+	// Report the key fingerprint without creating a key
+	if _, err := os.Stat(policy.SigningKey); err == nil {
+		if key, err := retention.LoadOrCreateKey(policy.SigningKey); err == nil {
+			result.Fingerprint = retention.Fingerprint(retention.PublicKey(key))
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	fmt.Printf("Policy:      delete issues closed more than %s ago (before %s)\n", policy.ClosedAfter, cutoff.Format("2006-01-02"))
+	if policy.HoldLabel != "" {
+		fmt.Printf("Legal hold:  label %q (applies to descendants too)\n", policy.HoldLabel)
+	}
+	fmt.Printf("Manifests:   %s\n", policy.ManifestDir)
+	if result.Fingerprint != "" {
+		fmt.Printf("Signing key: %s\n", result.Fingerprint)
+	} else {
+		fmt.Printf("Signing key: %s %s\n", policy.SigningKey, ui.RenderMuted("(created on first run)"))
+	}
+	fmt.Println()
+	fmt.Printf("Past retention: %d to delete, %d held, %d pinned\n", len(plan.Delete), len(plan.Held), len(plan.Pinned))
+}
+
+func runRetentionRun(cmd *cobra.Command, _ []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if !dryRun {
+		CheckReadonly("retention run")
+	}
+	policy, err := loadRetentionPolicy()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	ctx := rootCtx
+	now := time.Now().UTC()
+	plan, cutoff, err := planRetention(ctx, store, policy, now)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	result := newRetentionResult(policy, plan, cutoff)
+
+	if dryRun || len(plan.Delete) == 0 {
+		result.DryRun = dryRun
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		if len(plan.Delete) == 0 {
+			fmt.Printf("No issues past retention (closed before %s)\n", cutoff.Format("2006-01-02"))
+		} else {
+			fmt.Printf("Would delete %d issue(s) closed before %s:\n", len(plan.Delete), cutoff.Format("2006-01-02"))
+			for _, issue := range plan.Delete {
+				fmt.Printf("  %s: %s %s\n", ui.RenderID(issue.ID), issue.Title, ui.RenderMuted("(closed "+issue.ClosedAt.Format("2006-01-02")+")"))
+			}
+		}
+		printRetentionKept(plan)
+		return
+	}
+
+	key, err := retention.LoadOrCreateKey(policy.SigningKey)
+	if err != nil {
+		FatalErrorRespectJSON("loading signing key: %v", err)
+	}
+	manifest := &retention.Manifest{
+		Version:     retention.ManifestVersion,
+		RunAt:       now,
+		Actor:       getActorWithGit(),
+		ClosedAfter: policy.ClosedAfter,
+		HoldLabel:   policy.HoldLabel,
+		Cutoff:      cutoff.UTC(),
+		HeldCount:   len(plan.Held),
+	}
+	for _, issue := range plan.Delete {
+		manifest.Deleted = append(manifest.Deleted, retention.NewEntry(issue))
+	}
+	if err := manifest.Sign(key); err != nil {
+		FatalErrorRespectJSON("signing manifest: %v", err)
+	}
+
+	// Write the manifest before deleting, under a temporary name, so a
+	// deletion never happens without its record.
+	path := filepath.Join(policy.ManifestDir, now.Format("20060102T150405Z")+".json")
+	tmp := path + ".pending"
+	if err := writeRetentionManifest(tmp, manifest); err != nil {
+		FatalErrorRespectJSON("writing manifest: %v", err)
+	}
+	if _, err := store.DeleteIssues(ctx, result.Delete, false, true, false); err != nil {
+		_ = os.Remove(tmp)
+		FatalErrorRespectJSON("deleting issues: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		FatalErrorRespectJSON("finalizing manifest %s: %v", tmp, err)
+	}
+
+	result.Deleted = len(plan.Delete)
+	result.Manifest = path
+	result.Fingerprint = retention.Fingerprint(manifest.PublicKey)
+	if jsonOutput {
+		outputJSON(result)
+		return
+	}
+	fmt.Printf("%s Deleted %d issue(s) closed before %s\n", ui.RenderPass("✓"), result.Deleted, cutoff.Format("2006-01-02"))
+	fmt.Printf("  Manifest: %s\n", path)
+	fmt.Printf("  Signed by: %s\n", result.Fingerprint)
+	printRetentionKept(plan)
+}
+
+// printRetentionKept notes issues past retention that were kept.
+func printRetentionKept(plan *retention.Plan) {
+	if len(plan.Held) > 0 {
+		fmt.Printf("\n%s %d issue(s) kept under legal hold: %s\n", ui.RenderWarn("⏸"), len(plan.Held), strings.Join(plan.Held, ", "))
+	}
+	if len(plan.Pinned) > 0 {
+		fmt.Printf("%s %d pinned issue(s) kept: %s\n", ui.RenderMuted("·"), len(plan.Pinned), strings.Join(plan.Pinned, ", "))
+	}
+	fmt.Println()
+}
+
+func writeRetentionManifest(path string, m *retention.Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
+
+func runRetentionVerify(cmd *cobra.Command, args []string) {
+	fingerprint, _ := cmd.Flags().GetString("fingerprint")
+	m, err := retention.ReadManifest(args[0])
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if err := m.Verify(); err != nil {
+		FatalErrorRespectJSON("%s: %v", args[0], err)
+	}
+	signer := retention.Fingerprint(m.PublicKey)
+	if fingerprint != "" && fingerprint != signer {
+		FatalErrorRespectJSON("%s: signed by %s, expected %s", args[0], signer, fingerprint)
+	}
+
+	// Deleted issues should stay deleted
+	var present []string
+	for _, e := range m.Deleted {
+		if issue, err := store.GetIssue(rootCtx, e.ID); err == nil && issue != nil {
+			present = append(present, e.ID)
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(map[string]interface{}{
+			"manifest":    args[0],
+			"valid":       true,
+			"fingerprint": signer,
+			"deleted":     len(m.Deleted),
+			"present":     present,
+		})
+		return
+	}
+	fmt.Printf("%s Signature valid: %d deletion(s) on %s by %s\n", ui.RenderPass("✓"), len(m.Deleted), m.RunAt.Format("2006-01-02"), m.Actor)
+	fmt.Printf("  Signed by: %s\n", signer)
+	if len(present) > 0 {
+		fmt.Printf("%s %d listed issue(s) exist again: %s\n", ui.RenderWarn("⚠"), len(present), strings.Join(present, ", "))
+	}
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/labelroute"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var routeCmd = &cobra.Command{
+	Use:     "route",
+	GroupID: "issues",
+	Short:   "Show label routing rules",
+	Long: `Label routes file issues that carry a label under a default epic, give
+them a default assignee (a person or team), and raise them to a minimum
+priority, so issues filed by agents land in the right buckets.
+
+Rules are configured in .beads/config.yaml, keyed by label or label glob:
+  label-routes:
+    "area:auth": "epic=bd-12, assignee=alice, priority=P1"
+    "security": "assignee=sec-team, priority=P0"
+
+'bd create' applies the rules to each new issue, and 'bd route run' applies
+them to existing open issues. Routing only fills gaps: it adds an epic to
+issues without a parent, an assignee to unassigned issues, and raises
+priorities below the floor, never lowering a priority or replacing a
+value. When several rules match, they apply in label order: the first
+epic and assignee win, and the most urgent floor does.
+
+Commands:
+  bd route                 List the rules
+  bd route run [--dry-run] Apply the rules to open issues`,
+	Run: func(_ *cobra.Command, _ []string) {
+		rules, err := loadLabelRoutes()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			outputJSON(rules)
+			return
+		}
+		if len(rules) == 0 {
+			fmt.Println("No label routes configured (see 'bd route --help')")
+			return
+		}
+		fmt.Printf("\nLabel routes:\n\n")
+		for _, rule := range rules {
+			fmt.Printf("  %-20s %s\n", rule.Label, describeRoute(rule))
+		}
+		fmt.Println()
+	},
+}
+
+var routeRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Apply label routes to open issues",
+	Long: `Apply the label-routes rules to open issues that predate them or were
+created without them (e.g. by imports).
+
+Examples:
+  bd route run --dry-run   # Show what would change
+  bd route run`,
+	Args: cobra.NoArgs,
+	Run:  runRouteRun,
+}
+
+func init() {
+	routeRunCmd.Flags().Bool("dry-run", false, "Show what would change without changing it")
+	routeCmd.AddCommand(routeRunCmd)
+	rootCmd.AddCommand(routeCmd)
+}
+
+// loadLabelRoutes parses the label-routes config.
+func loadLabelRoutes() ([]labelroute.Rule, error) {
+	return labelroute.ParseRules(config.GetStringMapString("label-routes"))
+}
+
+// resolveRouteEpics resolves each rule's epic to a full issue ID, or
+// returns an error naming a rule whose epic doesn't exist.
+func resolveRouteEpics(ctx context.Context, s *dolt.DoltStore, rules []labelroute.Rule) error {
+	for i := range rules {
+		if rules[i].Epic == "" {
+			continue
+		}
+		id, err := utils.ResolvePartialID(ctx, s, rules[i].Epic)
+		if err != nil {
+			return fmt.Errorf("label-routes.%s: epic %s: %v", rules[i].Label, rules[i].Epic, err)
+		}
+		rules[i].Epic = id
+	}
+	return nil
+}
+
+// describeRoute summarizes a rule's settings, e.g. "epic bd-12, assignee alice, at least P1".
+func describeRoute(rule labelroute.Rule) string {
+	var parts []string
+	if rule.Epic != "" {
+		parts = append(parts, "epic "+rule.Epic)
+	}
+	if rule.Assignee != "" {
+		parts = append(parts, "assignee "+rule.Assignee)
+	}
+	if rule.PriorityFloor != nil {
+		parts = append(parts, fmt.Sprintf("at least P%d", *rule.PriorityFloor))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeRoutePlan summarizes what a plan changes.
+func describeRoutePlan(plan labelroute.Plan) string {
+	var parts []string
+	if plan.Epic != "" {
+		parts = append(parts, "epic → "+plan.Epic)
+	}
+	if plan.Assignee != "" {
+		parts = append(parts, "assignee → "+plan.Assignee)
+	}
+	if plan.Priority != nil {
+		parts = append(parts, fmt.Sprintf("priority → P%d", *plan.Priority))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// routeNewIssue applies label routes to an issue about to be created with
+// the given labels, setting its assignee and priority, and returns the
+// epic to file it under (empty for none). Routing problems are warnings,
+// never a reason to fail the create.
+func routeNewIssue(ctx context.Context, issue *types.Issue, labels []string, hasParent bool) string {
+	rules, err := loadLabelRoutes()
+	if err != nil {
+		WarnError("%v (label routes not applied)", err)
+		return ""
+	}
+	if len(rules) == 0 {
+		return ""
+	}
+	if err := resolveRouteEpics(ctx, store, rules); err != nil {
+		WarnError("%v (label routes not applied)", err)
+		return ""
+	}
+	plan := labelroute.Resolve(rules, issue, labels, hasParent)
+	if plan.Assignee != "" {
+		issue.Assignee = plan.Assignee
+	}
+	if plan.Priority != nil {
+		issue.Priority = *plan.Priority
+	}
+	return plan.Epic
+}
+
+// applyRoutePlan makes the changes in plan.
+func applyRoutePlan(ctx context.Context, plan labelroute.Plan, actor string) error {
+	updates := make(map[string]interface{})
+	if plan.Assignee != "" {
+		updates["assignee"] = plan.Assignee
+	}
+	if plan.Priority != nil {
+		updates["priority"] = *plan.Priority
+	}
+	if len(updates) > 0 {
+		if err := store.UpdateIssue(ctx, plan.IssueID, updates, actor); err != nil {
+			return err
+		}
+	}
+	if plan.Epic != "" {
+		dep := &types.Dependency{IssueID: plan.IssueID, DependsOnID: plan.Epic, Type: types.DepParentChild}
+		if err := store.AddDependency(ctx, dep, actor); err != nil {
+			return fmt.Errorf("adding to epic %s: %w", plan.Epic, err)
+		}
+	}
+	return nil
+}
+
+// routeRunResult is the --json output of bd route run.
+type routeRunResult struct {
+	DryRun  bool              `json:"dry_run"`
+	Checked int               `json:"checked"`
+	Routed  []labelroute.Plan `json:"routed"`
+	Failed  map[string]string `json:"failed,omitempty"` // Issue ID -> error
+}
+
+func runRouteRun(cmd *cobra.Command, _ []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if !dryRun {
+		CheckReadonly("route run")
+	}
+	ctx := rootCtx
+	rules, err := loadLabelRoutes()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if len(rules) == 0 {
+		FatalErrorWithHint("no label routes configured", "add label-routes to .beads/config.yaml; see 'bd route --help'")
+	}
+	if err := resolveRouteEpics(ctx, store, rules); err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{ExcludeStatus: []types.Status{types.StatusClosed}})
+	if err != nil {
+		FatalErrorRespectJSON("loading issues: %v", err)
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labels, err := store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		FatalErrorRespectJSON("loading labels: %v", err)
+	}
+	deps, err := store.GetDependencyRecordsForIssues(ctx, ids)
+	if err != nil {
+		FatalErrorRespectJSON("loading dependencies: %v", err)
+	}
+
+	result := routeRunResult{DryRun: dryRun, Checked: len(issues), Routed: []labelroute.Plan{}}
+	actor := getActorWithGit()
+	for _, issue := range issues {
+		if len(labels[issue.ID]) == 0 {
+			continue
+		}
+		hasParent := false
+		for _, dep := range deps[issue.ID] {
+			if dep.Type == types.DepParentChild {
+				hasParent = true
+				break
+			}
+		}
+		plan := labelroute.Resolve(rules, issue, labels[issue.ID], hasParent)
+		if plan.Empty() {
+			continue
+		}
+		if !dryRun {
+			if err := applyRoutePlan(ctx, plan, actor); err != nil {
+				if result.Failed == nil {
+					result.Failed = make(map[string]string)
+				}
+				result.Failed[issue.ID] = err.Error()
+				continue
+			}
+		}
+		result.Routed = append(result.Routed, plan)
+	}
+
+	if jsonOutput {
+		outputJSON(result)
+	} else {
+		verb := "Routed"
+		if dryRun {
+			verb = "Would route"
+		}
+		if len(result.Routed) == 0 && len(result.Failed) == 0 {
+			fmt.Printf("Nothing to route (%d open issues checked)\n", result.Checked)
+		} else if len(result.Routed) > 0 {
+			fmt.Printf("%s %d of %d open issues:\n", verb, len(result.Routed), result.Checked)
+			for _, plan := range result.Routed {
+				fmt.Printf("  %s %s %s\n", ui.RenderID(plan.IssueID), describeRoutePlan(plan), ui.RenderMuted("("+strings.Join(plan.Rules, ", ")+")"))
+			}
+		}
+		failed := make([]string, 0, len(result.Failed))
+		for id := range result.Failed {
+			failed = append(failed, id)
+		}
+		sort.Strings(failed)
+		for _, id := range failed {
+			fmt.Printf("  %s %s %s\n", ui.RenderFail("✗"), ui.RenderID(id), result.Failed[id])
+		}
+	}
+	if len(result.Failed) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+)
+
+var rpcCmd = &cobra.Command{
+	Use:     "rpc --stdio",
+	GroupID: "advanced",
+	Short:   "Serve the storage interface as JSON-RPC over stdio",
+	Long: `Serve beads' storage interface as JSON-RPC 2.0 on stdin and stdout, so
+programs in any language can run bd as a subprocess and call it through a
+stable protocol instead of parsing CLI output.
+
+Each request is one line of JSON and each response is written as one line.
+Requests without an "id" are notifications and get no response. A line
+holding a JSON array is a batch and is answered with an array. The server
+exits when stdin closes.
+
+Methods mirror the storage interface in snake_case, with named params:
+
+  create_issue {issue}               create_issues {issues}
+  get_issue {id}                     get_issues_by_ids {ids}
+  get_issue_by_external_ref {external_ref}
+  update_issue {id, updates}         close_issue {id, reason, session}
+  delete_issue {id}                  search_issues {query, filter}
+  add_dependency {issue_id, depends_on_id, type}
+  remove_dependency {issue_id, depends_on_id}
+  get_dependencies {issue_id}        get_dependents {issue_id}
+  get_dependencies_with_metadata {issue_id}
+  get_dependents_with_metadata {issue_id}
+  get_dependency_tree {issue_id, max_depth, show_all_paths, reverse}
+  add_label {issue_id, label}        remove_label {issue_id, label}
+  get_labels {issue_id}              get_issues_by_label {label}
+  get_ready_work {filter}            get_blocked_issues {filter}
+  get_epics_eligible_for_closure     get_statistics
+  add_issue_comment {issue_id, text} get_issue_comments {issue_id}
+  get_events {issue_id, limit}       get_all_events_since {since_id}
+  set_config {key, value}            get_config {key}
+  get_all_config                     methods
+
+Writes are attributed to the actor (--actor, BD_ACTOR, ...) and checked
+like the matching commands: scan.mode, type-schemas,
+validation.acceptance-on-close, close.require-evidence-types and
+quiet-hours apply, and refusals are validation errors. Storage errors
+use code -32000, with data.kind set to not_found, already_claimed, cycle,
+validation, prefix_mismatch, not_initialized, permission_denied (see
+.beads/permissions.yaml), or error. With --readonly,
+write methods fail with code -32001.
+
+Example:
+  echo '{"jsonrpc":"2.0","id":1,"method":"get_issue","params":{"id":"bd-1"}}' | bd rpc --stdio`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		stdio, _ := cmd.Flags().GetBool("stdio")
+		if !stdio {
+			FatalErrorWithHint("no transport selected", "use 'bd rpc --stdio'")
+		}
+		server := rpc.NewServer(rpc.StorageMethods(store, actor), readonlyMode)
+		if err := server.Serve(rootCtx, os.Stdin, os.Stdout); err != nil && rootCtx.Err() == nil {
+			FatalError("rpc: %v", err)
+		}
+	},
+}
+
+func init() {
+	rpcCmd.Flags().Bool("stdio", false, "Speak JSON-RPC on stdin and stdout")
+	rootCmd.AddCommand(rpcCmd)
+}
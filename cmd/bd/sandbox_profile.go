@@ -0,0 +1,560 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+	"golang.org/x/term"
+)
+
+var sandboxCmd = &cobra.Command{
+	Use:     "sandbox",
+	GroupID: "advanced",
+	Short:   "Copy work into a sandbox profile and promote the results back",
+	Long: `Run experimental agent work in a sandbox database, then merge back what
+you accept.
+
+A sandbox is a config profile with its own db (see 'Profiles' in
+docs/CONFIG.md). 'bd sandbox pull' copies an epic and its descendants into
+it, keeping their IDs; agents then work there with --profile. 'bd sandbox
+promote' merges the sandbox's results into the current database:
+
+  - Issues closed in the sandbox are closed here too
+  - Issues created in the sandbox are created here with new IDs, with their
+    labels and their dependencies on each other and on pulled issues
+
+Other edits made in the sandbox stay there. Promoted issues are renamed in
+the sandbox to their new IDs, so promoting again only picks up new work.
+
+The promotion is shown for review first: answer y to apply it, or r to
+review each change. Use --yes to skip the prompt (needed when stdin isn't a
+terminal) and --dry-run to only show it.
+
+Examples:
+  bd --profile experiments init --prefix exp
+  bd sandbox pull bd-a3f8 --to experiments
+  bd --profile experiments ready
+  bd sandbox promote --from experiments --dry-run
+  bd sandbox promote --from experiments`,
+}
+
+var sandboxPullCmd = &cobra.Command{
+	Use:   "pull <epic> --to <profile>",
+	Short: "Copy an epic and its descendants into a sandbox profile",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("sandbox pull")
+		ctx := rootCtx
+		profile, _ := cmd.Flags().GetString("to")
+		if profile == "" {
+			FatalErrorRespectJSON("--to is required: the profile of the sandbox to copy into")
+		}
+
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		subgraph, err := loadTemplateSubgraph(ctx, store, id)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		sandbox := openSandboxStore(ctx, profile)
+		defer func() { _ = sandbox.Close() }()
+		result, err := pullIntoSandbox(ctx, sandbox, subgraph)
+		if err != nil {
+			FatalErrorRespectJSON("copying into sandbox %s: %v", profile, err)
+		}
+		result.Profile = profile
+		commitSandbox(ctx, sandbox, fmt.Sprintf("bd sandbox pull %s", id))
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+		fmt.Printf("%s Copied %d issue(s) from %s into sandbox %s\n",
+			ui.RenderPass("✓"), len(result.Copied), ui.RenderID(id), profile)
+		if len(result.Existing) > 0 {
+			fmt.Printf("  %d already in the sandbox, left as they are\n", len(result.Existing))
+		}
+		fmt.Printf("  Work on them with: bd --profile %s ready\n", profile)
+	},
+}
+
+var sandboxPromoteCmd = &cobra.Command{
+	Use:   "promote --from <profile>",
+	Short: "Merge closures and new issues from a sandbox profile back",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		profile, _ := cmd.Flags().GetString("from")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+		if profile == "" {
+			FatalErrorRespectJSON("--from is required: the profile of the sandbox to promote")
+		}
+		if !dryRun {
+			CheckReadonly("sandbox promote")
+		}
+
+		sandbox := openSandboxStore(ctx, profile)
+		defer func() { _ = sandbox.Close() }()
+		promotion, err := loadSandboxPromotion(ctx, sandbox)
+		if err != nil {
+			FatalErrorRespectJSON("reading sandbox %s: %v", profile, err)
+		}
+		promotion.Profile = profile
+
+		if promotion.empty() {
+			if jsonOutput {
+				outputJSON(promotion)
+				return
+			}
+			fmt.Printf("Nothing to promote from sandbox %s\n", profile)
+			return
+		}
+		if dryRun {
+			if jsonOutput {
+				outputJSON(promotion)
+				return
+			}
+			printSandboxPromotion(promotion)
+			return
+		}
+		if !yes {
+			if jsonOutput || !term.IsTerminal(int(os.Stdin.Fd())) {
+				FatalErrorWithHint("review needs a terminal",
+					"use --yes to promote without reviewing, or --dry-run to preview it")
+			}
+			printSandboxPromotion(promotion)
+			if !confirmSandboxPromotion(promotion, bufio.NewReader(os.Stdin), os.Stdout) {
+				fmt.Println("Canceled; nothing was promoted.")
+				return
+			}
+		}
+
+		if err := applySandboxPromotion(ctx, promotion); err != nil {
+			FatalErrorRespectJSON("promoting from sandbox %s: %v", profile, err)
+		}
+		renameSandboxIssues(ctx, sandbox, promotion)
+		commitSandbox(ctx, sandbox, fmt.Sprintf("bd sandbox promote: %d issue(s) renamed", len(promotion.Promoted)))
+
+		if jsonOutput {
+			outputJSON(promotion)
+			return
+		}
+		fmt.Printf("%s Promoted from sandbox %s: closed %d, created %d issue(s)\n",
+			ui.RenderPass("✓"), profile, len(promotion.Closures), len(promotion.NewIssues))
+		for _, issue := range promotion.NewIssues {
+			fmt.Printf("  %s → %s: %s\n", issue.ID, ui.RenderID(promotion.Promoted[issue.ID]), issue.Title)
+		}
+	},
+}
+
+// sandboxPullResult is what bd sandbox pull copied.
+type sandboxPullResult struct {
+	Profile      string   `json:"profile"`
+	Epic         string   `json:"epic"`
+	Copied       []string `json:"copied"`
+	Existing     []string `json:"existing,omitempty"` // Already in the sandbox, not copied
+	Dependencies int      `json:"dependencies"`
+}
+
+// sandboxPromotion is what bd sandbox promote merges back from a sandbox.
+type sandboxPromotion struct {
+	Profile      string              `json:"profile"`
+	Closures     []*types.Issue      `json:"closures"`           // Closed in the sandbox, open here
+	NewIssues    []*types.Issue      `json:"new_issues"`         // Only in the sandbox
+	Dependencies []*types.Dependency `json:"dependencies"`       // Sandbox IDs; touch a new issue
+	Promoted     map[string]string   `json:"promoted,omitempty"` // Sandbox ID -> new ID here
+
+	deps   []*types.Dependency // All of the sandbox's dependencies
+	inMain map[string]bool     // Sandbox IDs that exist here
+}
+
+func (p *sandboxPromotion) empty() bool {
+	return len(p.Closures) == 0 && len(p.NewIssues) == 0
+}
+
+// openSandboxStore opens the database of the sandbox profile, which must
+// exist and differ from the current one.
+func openSandboxStore(ctx context.Context, profile string) *dolt.DoltStore {
+	db, err := config.ProfileDatabase(profile)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	if db == "" {
+		FatalErrorWithHint(fmt.Sprintf("profile %s doesn't set db", profile),
+			fmt.Sprintf("set profiles.%s.db in config.yaml so the sandbox has a database of its own", profile))
+	}
+	if utils.CanonicalizePath(db) == utils.CanonicalizePath(dbPath) {
+		FatalErrorWithHint(fmt.Sprintf("profile %s is the current database", profile),
+			fmt.Sprintf("run bd sandbox against the main database, without --profile %s", profile))
+	}
+	beadsDir := filepath.Dir(db)
+	if cfg, _ := configfile.Load(beadsDir); cfg == nil || !cfg.IsDoltServerMode() {
+		if _, err := os.Stat(db); err != nil {
+			FatalErrorWithHint(fmt.Sprintf("sandbox database %s doesn't exist", db),
+				fmt.Sprintf("run 'bd --profile %s init --prefix <prefix>' to create it", profile))
+		}
+	}
+	s, err := dolt.NewFromConfig(ctx, beadsDir)
+	if err != nil {
+		FatalErrorRespectJSON("opening sandbox %s: %v", profile, err)
+	}
+	return s
+}
+
+// commitSandbox records the sandbox's changes in its Dolt history; the
+// auto-commit after each command only covers the current database.
+func commitSandbox(ctx context.Context, sandbox *dolt.DoltStore, msg string) {
+	if err := sandbox.Commit(ctx, msg); err != nil && !isDoltNothingToCommit(err) {
+		WarnError("committing sandbox changes: %v", err)
+	}
+}
+
+// pullIntoSandbox copies the subgraph's issues, with their labels and the
+// dependencies between them, into the sandbox under the same IDs. Issues
+// already in the sandbox are left alone.
+func pullIntoSandbox(ctx context.Context, sandbox *dolt.DoltStore, subgraph *TemplateSubgraph) (*sandboxPullResult, error) {
+	result := &sandboxPullResult{Epic: subgraph.Root.ID, Copied: []string{}}
+	ids := make([]string, 0, len(subgraph.Issues))
+	for _, issue := range subgraph.Issues {
+		ids = append(ids, issue.ID)
+	}
+	existing, err := sandbox.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	inSandbox := make(map[string]bool, len(existing))
+	for _, issue := range existing {
+		inSandbox[issue.ID] = true
+	}
+	labels, err := store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var copied []*types.Issue
+	copiedIDs := make(map[string]bool)
+	for _, issue := range subgraph.Issues {
+		if issue.Ephemeral {
+			continue
+		}
+		if inSandbox[issue.ID] {
+			result.Existing = append(result.Existing, issue.ID)
+			continue
+		}
+		issue.Labels = labels[issue.ID]
+		copied = append(copied, issue)
+		copiedIDs[issue.ID] = true
+		result.Copied = append(result.Copied, issue.ID)
+	}
+	err = sandbox.CreateIssuesWithFullOptions(ctx, copied, actor, storage.BatchCreateOptions{
+		OrphanHandling:       storage.OrphanAllow,
+		SkipPrefixValidation: true, // The sandbox has a prefix of its own
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, dep := range subgraph.Dependencies {
+		if !copiedIDs[dep.IssueID] || !(copiedIDs[dep.DependsOnID] || inSandbox[dep.DependsOnID]) {
+			continue
+		}
+		if err := sandbox.AddDependency(ctx, dep, actor); err != nil {
+			return nil, fmt.Errorf("adding dependency %s -> %s: %w", dep.IssueID, dep.DependsOnID, err)
+		}
+		result.Dependencies++
+	}
+	return result, nil
+}
+
+// loadSandboxPromotion compares the sandbox with the current database.
+func loadSandboxPromotion(ctx context.Context, sandbox *dolt.DoltStore) (*sandboxPromotion, error) {
+	issues, err := sandbox.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	mainIssues, err := store.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	inMain := make(map[string]*types.Issue, len(mainIssues))
+	for _, issue := range mainIssues {
+		inMain[issue.ID] = issue
+	}
+	deps, err := sandbox.GetAllDependencyRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := planSandboxPromotion(issues, deps, inMain)
+	newIDs := make([]string, 0, len(p.NewIssues))
+	for _, issue := range p.NewIssues {
+		newIDs = append(newIDs, issue.ID)
+	}
+	labels, err := sandbox.GetLabelsForIssues(ctx, newIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range p.NewIssues {
+		issue.Labels = labels[issue.ID]
+	}
+	return p, nil
+}
+
+// planSandboxPromotion picks what to promote: sandbox issues closed there
+// but still open in current (the current database's copies by ID), and
+// sandbox issues current lacks, with the dependencies that involve them.
+func planSandboxPromotion(sandbox []*types.Issue, deps map[string][]*types.Dependency, current map[string]*types.Issue) *sandboxPromotion {
+	p := &sandboxPromotion{
+		Closures:     []*types.Issue{},
+		NewIssues:    []*types.Issue{},
+		Dependencies: []*types.Dependency{},
+		inMain:       make(map[string]bool),
+	}
+	for _, issue := range sandbox {
+		if issue.Ephemeral {
+			continue
+		}
+		existing, ok := current[issue.ID]
+		switch {
+		case !ok:
+			p.NewIssues = append(p.NewIssues, issue)
+		case issue.Status == types.StatusClosed && existing.Status != types.StatusClosed:
+			p.Closures = append(p.Closures, issue)
+		}
+		if ok {
+			p.inMain[issue.ID] = true
+		}
+	}
+
+	ids := make([]string, 0, len(deps))
+	for id := range deps {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		p.deps = append(p.deps, deps[id]...)
+	}
+	p.selectDependencies()
+	return p
+}
+
+// selectDependencies keeps the dependencies that involve a new issue and
+// whose other end is promoted too or already here.
+func (p *sandboxPromotion) selectDependencies() {
+	isNew := make(map[string]bool, len(p.NewIssues))
+	for _, issue := range p.NewIssues {
+		isNew[issue.ID] = true
+	}
+	p.Dependencies = []*types.Dependency{}
+	for _, dep := range p.deps {
+		from, to := dep.IssueID, dep.DependsOnID
+		if (isNew[from] || isNew[to]) && (isNew[from] || p.inMain[from]) && (isNew[to] || p.inMain[to]) {
+			p.Dependencies = append(p.Dependencies, dep)
+		}
+	}
+}
+
+func printSandboxPromotion(p *sandboxPromotion) {
+	fmt.Printf("From sandbox %s:\n", p.Profile)
+	if len(p.Closures) > 0 {
+		fmt.Printf("\n  Close (%d):\n", len(p.Closures))
+		for _, issue := range p.Closures {
+			fmt.Printf("    %s\n", formatSandboxClosure(issue))
+		}
+	}
+	if len(p.NewIssues) > 0 {
+		fmt.Printf("\n  Create (%d):\n", len(p.NewIssues))
+		for _, issue := range p.NewIssues {
+			fmt.Printf("    %s\n", formatSandboxNewIssue(issue))
+		}
+	}
+	if len(p.Dependencies) > 0 {
+		fmt.Printf("\n  With %d dependencies\n", len(p.Dependencies))
+	}
+}
+
+func formatSandboxClosure(issue *types.Issue) string {
+	line := fmt.Sprintf("%s: %s", ui.RenderID(issue.ID), issue.Title)
+	if issue.CloseReason != "" {
+		line += " " + ui.RenderMuted("("+issue.CloseReason+")")
+	}
+	return line
+}
+
+func formatSandboxNewIssue(issue *types.Issue) string {
+	line := fmt.Sprintf("%s [%s, P%d] (sandbox %s)", issue.Title, issue.IssueType, issue.Priority, issue.ID)
+	if issue.Status == types.StatusClosed {
+		line += " " + ui.RenderPass("done")
+	}
+	return line
+}
+
+// confirmSandboxPromotion asks whether to apply the promotion. Answering
+// "r" reviews each change in turn, dropping the ones declined; the
+// promotion is applied if any remain.
+func confirmSandboxPromotion(p *sandboxPromotion, in *bufio.Reader, out io.Writer) bool {
+	_, _ = fmt.Fprintf(out, "\nClose %d and create %d issue(s)? [y]es / [n]o / [r]eview each: ", len(p.Closures), len(p.NewIssues))
+	switch readDocAnswer(in) {
+	case "y", "yes":
+		return true
+	case "r", "review":
+	default:
+		return false
+	}
+
+	total := len(p.Closures) + len(p.NewIssues)
+	n := 0
+	ask := func(verb, line string) string {
+		n++
+		_, _ = fmt.Fprintf(out, "(%d/%d) %s %s\n  Promote? [y]es / [n]o / [a]ll remaining / [q]uit: ", n, total, verb, line)
+		return readDocAnswer(in)
+	}
+	var closures, created []*types.Issue
+	all := false
+	for _, issue := range p.Closures {
+		answer := "y"
+		if !all {
+			answer = ask("Close", formatSandboxClosure(issue))
+		}
+		switch answer {
+		case "a", "all":
+			all = true
+			closures = append(closures, issue)
+		case "y", "yes":
+			closures = append(closures, issue)
+		case "q", "quit":
+			return false
+		}
+	}
+	for _, issue := range p.NewIssues {
+		answer := "y"
+		if !all {
+			answer = ask("Create", formatSandboxNewIssue(issue))
+		}
+		switch answer {
+		case "a", "all":
+			all = true
+			created = append(created, issue)
+		case "y", "yes":
+			created = append(created, issue)
+		case "q", "quit":
+			return false
+		}
+	}
+	p.Closures, p.NewIssues = closures, created
+	if p.Closures == nil {
+		p.Closures = []*types.Issue{}
+	}
+	if p.NewIssues == nil {
+		p.NewIssues = []*types.Issue{}
+	}
+	p.selectDependencies()
+	return !p.empty()
+}
+
+// applySandboxPromotion closes and creates issues here in one transaction,
+// filling in p.Promoted.
+func applySandboxPromotion(ctx context.Context, p *sandboxPromotion) error {
+	promoted := make(map[string]string, len(p.NewIssues))
+	closeReason := func(issue *types.Issue) string {
+		if issue.CloseReason != "" {
+			return issue.CloseReason
+		}
+		return "Closed in sandbox " + p.Profile
+	}
+	err := store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		for _, issue := range p.Closures {
+			if err := tx.CloseIssue(ctx, issue.ID, closeReason(issue), actor, ""); err != nil {
+				return fmt.Errorf("closing %s: %w", issue.ID, err)
+			}
+		}
+		for _, issue := range p.NewIssues {
+			created := &types.Issue{
+				Title:              issue.Title,
+				Description:        issue.Description,
+				Design:             issue.Design,
+				AcceptanceCriteria: issue.AcceptanceCriteria,
+				Notes:              issue.Notes,
+				Status:             issue.Status,
+				Priority:           issue.Priority,
+				IssueType:          issue.IssueType,
+				Assignee:           issue.Assignee,
+			}
+			if created.Status == types.StatusClosed {
+				created.Status = types.StatusOpen
+			}
+			if err := tx.CreateIssue(ctx, created, actor); err != nil {
+				return fmt.Errorf("creating %q: %w", issue.Title, err)
+			}
+			for _, label := range issue.Labels {
+				if err := tx.AddLabel(ctx, created.ID, label, actor); err != nil {
+					return fmt.Errorf("labelling %s: %w", created.ID, err)
+				}
+			}
+			if issue.Status == types.StatusClosed {
+				if err := tx.CloseIssue(ctx, created.ID, closeReason(issue), actor, ""); err != nil {
+					return fmt.Errorf("closing %s: %w", created.ID, err)
+				}
+			}
+			promoted[issue.ID] = created.ID
+		}
+		mapped := func(id string) string {
+			if newID, ok := promoted[id]; ok {
+				return newID
+			}
+			return id
+		}
+		for _, dep := range p.Dependencies {
+			d := &types.Dependency{IssueID: mapped(dep.IssueID), DependsOnID: mapped(dep.DependsOnID), Type: dep.Type, Metadata: dep.Metadata}
+			if err := tx.AddDependency(ctx, d, actor); err != nil {
+				return fmt.Errorf("adding dependency %s -> %s: %w", d.IssueID, d.DependsOnID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	p.Promoted = promoted
+	return nil
+}
+
+// renameSandboxIssues gives promoted issues their new IDs in the sandbox,
+// so they count as existing here the next time the sandbox is promoted.
+func renameSandboxIssues(ctx context.Context, sandbox *dolt.DoltStore, p *sandboxPromotion) {
+	for _, issue := range p.NewIssues {
+		newID := p.Promoted[issue.ID]
+		if err := sandbox.UpdateIssueID(ctx, issue.ID, newID, issue, actor); err != nil {
+			WarnError("renaming %s to %s in the sandbox (promoting again would create it twice): %v", issue.ID, newID, err)
+		}
+	}
+}
+
+func init() {
+	sandboxPullCmd.Flags().String("to", "", "Profile of the sandbox to copy into")
+	sandboxPromoteCmd.Flags().String("from", "", "Profile of the sandbox to promote")
+	sandboxPromoteCmd.Flags().Bool("dry-run", false, "Show what would be promoted without changing anything")
+	sandboxPromoteCmd.Flags().BoolP("yes", "y", false, "Promote without reviewing")
+	sandboxCmd.AddCommand(sandboxPullCmd, sandboxPromoteCmd)
+	rootCmd.AddCommand(sandboxCmd)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestPlanSandboxPromotion(t *testing.T) {
+	sandbox := []*types.Issue{
+		{ID: "bd-1", Title: "Epic", Status: types.StatusOpen},
+		{ID: "bd-2", Title: "Done in sandbox", Status: types.StatusClosed},
+		{ID: "bd-3", Title: "Closed both places", Status: types.StatusClosed},
+		{ID: "exp-a", Title: "Found in sandbox", Status: types.StatusOpen},
+		{ID: "exp-b", Title: "Follow-up", Status: types.StatusClosed},
+		{ID: "exp-wisp-c", Title: "Scratch", Ephemeral: true},
+	}
+	current := map[string]*types.Issue{
+		"bd-1": {ID: "bd-1", Status: types.StatusOpen},
+		"bd-2": {ID: "bd-2", Status: types.StatusInProgress},
+		"bd-3": {ID: "bd-3", Status: types.StatusClosed},
+	}
+	deps := map[string][]*types.Dependency{
+		"bd-2":  {{IssueID: "bd-2", DependsOnID: "bd-1", Type: types.DepParentChild}},                                             // Pulled: both here
+		"exp-a": {{IssueID: "exp-a", DependsOnID: "bd-1", Type: types.DepParentChild}},                                            // New -> here
+		"exp-b": {{IssueID: "exp-b", DependsOnID: "exp-a", Type: types.DepBlocks}, {IssueID: "exp-b", DependsOnID: "exp-wisp-c"}}, // Wisp isn't promoted
+	}
+
+	p := planSandboxPromotion(sandbox, deps, current)
+	if len(p.Closures) != 1 || p.Closures[0].ID != "bd-2" {
+		t.Errorf("closures = %v, want bd-2", issueIDs(p.Closures))
+	}
+	if got := issueIDs(p.NewIssues); strings.Join(got, ",") != "exp-a,exp-b" {
+		t.Errorf("new issues = %v, want exp-a, exp-b", got)
+	}
+	if len(p.Dependencies) != 2 || p.Dependencies[0].IssueID != "exp-a" || p.Dependencies[1].DependsOnID != "exp-a" {
+		t.Errorf("dependencies = %+v, want exp-a -> bd-1 and exp-b -> exp-a", p.Dependencies)
+	}
+}
+
+func TestConfirmSandboxPromotion(t *testing.T) {
+	plan := func() *sandboxPromotion {
+		return planSandboxPromotion(
+			[]*types.Issue{
+				{ID: "bd-1", Title: "Done", Status: types.StatusClosed},
+				{ID: "exp-a", Title: "New", Status: types.StatusOpen},
+				{ID: "exp-b", Title: "Newer", Status: types.StatusOpen},
+			},
+			map[string][]*types.Dependency{"exp-b": {{IssueID: "exp-b", DependsOnID: "exp-a", Type: types.DepBlocks}}},
+			map[string]*types.Issue{"bd-1": {ID: "bd-1", Status: types.StatusOpen}},
+		)
+	}
+	tests := []struct {
+		input     string
+		want      bool
+		closures  int
+		newIssues []string
+		deps      int
+	}{
+		{"y\n", true, 1, []string{"exp-a", "exp-b"}, 1},
+		{"n\n", false, 1, []string{"exp-a", "exp-b"}, 1},
+		{"", false, 1, []string{"exp-a", "exp-b"}, 1},
+		{"r\nn\nn\ny\n", true, 0, []string{"exp-b"}, 0}, // Dropping exp-a drops exp-b's dependency on it
+		{"r\ny\na\n", true, 1, []string{"exp-a", "exp-b"}, 1},
+		{"r\nn\nn\nn\n", false, 0, nil, 0},
+	}
+	for _, tt := range tests {
+		p := plan()
+		got := confirmSandboxPromotion(p, bufio.NewReader(strings.NewReader(tt.input)), io.Discard)
+		if got != tt.want {
+			t.Errorf("input %q: confirmed = %v, want %v", tt.input, got, tt.want)
+		}
+		if !got {
+			continue
+		}
+		if len(p.Closures) != tt.closures || strings.Join(issueIDs(p.NewIssues), ",") != strings.Join(tt.newIssues, ",") || len(p.Dependencies) != tt.deps {
+			t.Errorf("input %q: closures %v, new %v, %d deps; want %d, %v, %d",
+				tt.input, issueIDs(p.Closures), issueIDs(p.NewIssues), len(p.Dependencies), tt.closures, tt.newIssues, tt.deps)
+		}
+	}
+}
+
+func issueIDs(issues []*types.Issue) []string {
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
@@ -15,10 +15,14 @@ var searchCmd = &cobra.Command{
 	Use:     "search [query]",
 	GroupID: "issues",
 	Short:   "Search issues by text query",
-	Long: `Search issues across title, description, and ID.
+	Long: `Search issues across title, description, comments, and ID.
+
+Every word of the query must appear somewhere in the issue or its comments,
+in any order; put a phrase in double quotes to match it as written.
 
 Examples:
   bd search "authentication bug"
+  bd search 'login "session expired"'  # Word and exact phrase
   bd search "login" --status open
   bd search "database" --label backend --limit 10
   bd search --query "performance" --assignee alice
@@ -28,6 +32,7 @@ Examples:
   bd search "refactor" --updated-after 2025-01-01 --priority-min 1
   bd search "bug" --sort priority
   bd search "task" --sort created --reverse
+  bd search "login crash" --sort relevance # Rank by embedding similarity
   bd search "api" --desc-contains "endpoint"
   bd search "cleanup" --no-assignee --no-labels`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -86,7 +91,11 @@ Examples:
 
 		// Build filter
 		filter := types.IssueFilter{
-			Limit: limit,
+			FullText: query,
+			Limit:    limit,
+		}
+		if sortBy == "relevance" {
+			filter.Limit = 0 // Rank every match, then apply the limit
 		}
 
 		if status != "" && status != "all" {
@@ -192,15 +201,29 @@ Examples:
 
 		ctx := rootCtx
 
-		// Direct mode - search using store
-		// The query parameter in SearchIssues already searches across title, description, and id
-		issues, err := store.SearchIssues(ctx, query, filter)
+		issues, err := store.SearchIssues(withReadReplica(ctx, store), "", filter)
 		if err != nil {
 			FatalError("%v", err)
 		}
 
 		// Apply sorting
-		sortIssues(issues, sortBy, reverse)
+		if sortBy == "relevance" {
+			if err := rankByRelevance(ctx, store, issues, query, reverse); err != nil {
+				FatalError("ranking by relevance: %v", err)
+			}
+			if limit > 0 && len(issues) > limit {
+				issues = issues[:limit]
+			}
+		} else {
+			sortIssues(issues, sortBy, reverse, voteCountsForSort(ctx, store, issues, sortBy))
+		}
+
+		if porcelainFormat(cmd) != "" {
+			if err := writePorcelainIssues(ctx, os.Stdout, store, issues); err != nil {
+				FatalError("%v", err)
+			}
+			return
+		}
 
 		if jsonOutput {
 			// Get labels and dependency counts
@@ -223,6 +246,11 @@ Examples:
 				fmt.Fprintf(os.Stderr, "Warning: failed to get comment counts: %v\n", err)
 				commentCounts = make(map[string]int)
 			}
+			voteCounts, err := store.GetVoteCounts(ctx, issueIDs)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to get vote counts: %v\n", err)
+				voteCounts = make(map[string]int)
+			}
 
 			// Populate labels
 			for _, issue := range issues {
@@ -241,6 +269,7 @@ Examples:
 					DependencyCount: counts.DependencyCount,
 					DependentCount:  counts.DependentCount,
 					CommentCount:    commentCounts[issue.ID],
+					VoteCount:       voteCounts[issue.ID],
 				}
 			}
 			outputJSON(issuesWithCounts)
@@ -310,7 +339,8 @@ func init() {
 	searchCmd.Flags().StringSlice("label-any", []string{}, "Filter by labels (OR: must have AT LEAST ONE)")
 	searchCmd.Flags().IntP("limit", "n", 50, "Limit results (default: 50)")
 	searchCmd.Flags().Bool("long", false, "Show detailed multi-line output for each issue")
-	searchCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee")
+	addPorcelainFlag(searchCmd)
+	searchCmd.Flags().String("sort", "", "Sort by field: priority, created, updated, closed, status, id, title, type, assignee, votes, relevance")
 	searchCmd.Flags().BoolP("reverse", "r", false, "Reverse sort order")
 
 	// Date range flags
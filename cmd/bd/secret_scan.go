@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/writepolicy"
+)
+
+// scanOnWrite scans the text fields an operation is about to write,
+// according to scan.mode. Findings are printed to stderr; in block mode the
+// command exits instead. It returns the label to add to the issue when the
+// text should be quarantined, or "".
+func scanOnWrite(op string, fields map[string]string) string {
+	scan, err := writepolicy.ScanText(fields)
+	if scan.Warning != "" {
+		WarnError("%s", scan.Warning)
+	}
+	if err != nil {
+		FatalErrorCode(exitPolicy, "%s blocked: %v\nRemove them and retry (see scan.mode in config.yaml)", op, err)
+	}
+	if len(scan.Findings) == 0 {
+		return ""
+	}
+	note := ""
+	if scan.Label != "" {
+		note = fmt.Sprintf(" (adding label %q)", scan.Label)
+	}
+	fmt.Fprintf(os.Stderr, "%s %s: text looks like it contains secrets or PII%s:\n%s\n", ui.RenderWarn("⚠"), op, note, writepolicy.FormatFindings(scan.Findings))
+	return scan.Label
+}
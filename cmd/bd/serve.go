@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/rpc"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+)
+
+var serveCmd = &cobra.Command{
+	Use:     "serve --http <addr>",
+	GroupID: "advanced",
+	Short:   "Serve the storage interface as a REST API over HTTP",
+	Long: `Serve beads over HTTP with JSON bodies, so dashboards and other tools can
+read and update the tracker without shelling out to bd. Routes call the same
+methods as 'bd rpc', with the same errors:
+
+  GET    /api/issues                   Search: q, status, type, assignee,
+                                       label, label_any, parent, priority, limit
+  POST   /api/issues                   Create (body: the issue)
+  GET    /api/issues/{id}              Show
+  PATCH  /api/issues/{id}              Update (body: fields to change)
+  DELETE /api/issues/{id}              Delete
+  POST   /api/issues/{id}/close        Close (body: reason)
+  GET    /api/issues/{id}/dependencies Dependencies; POST {depends_on_id, type}
+                                       to add, DELETE .../dependencies/{dep}
+  GET    /api/issues/{id}/dependents   Dependents
+  GET    /api/issues/{id}/labels       Labels; POST {label}, DELETE .../labels/{label}
+  GET    /api/issues/{id}/comments     Comments; POST {text} to add one
+  GET    /api/issues/{id}/events       Audit events: limit
+  GET    /api/ready                    Ready work: type, priority, assignee,
+                                       unassigned, label, label_any, parent, limit
+  GET    /api/blocked                  Blocked issues: type, priority, assignee,
+                                       unassigned, label, label_any, parent, limit
+  GET    /api/stats                    Statistics
+  GET    /api/federation               Federation peers and pending changes
+
+Errors are returned as {"error": {"code", "message", "data": {"kind"}}} with
+404 for not_found, 409 for conflicts, 422 for validation errors, and 403 for
+writes in read-only mode or refused by .beads/permissions.yaml.
+
+Writes are off unless a token is given with --token or BD_SERVE_TOKEN.
+Every request must then send it as "Authorization: Bearer <token>", and
+writes are attributed to the actor bd runs as (--actor, BD_ACTOR, ...).
+To keep web pages in your browser from reaching the API, requests must
+name a loopback host (add others with --allow-host), cross-origin requests
+are refused, and POST and PATCH bodies must be sent as application/json.
+
+Linear webhooks: with linear.webhook_secret set (the signing secret Linear
+shows for the webhook), POST /webhooks/linear applies Linear issue changes
+as they happen. Each delivery's signature and timestamp are checked and
+replays ignored; the issue it names is fetched and pulled like 'bd linear
+sync' would. Changes made while bd serve isn't running, and local changes to
+push, are still picked up by scheduled syncs (bd sync run). Webhooks are
+writes, so they are only received when writes are on.
+
+Examples:
+  bd serve
+  curl 'localhost:8080/api/ready?assignee=alice&limit=5'
+  BD_SERVE_TOKEN=s3cret bd serve
+  curl -X POST localhost:8080/api/issues -H 'Authorization: Bearer s3cret' \
+    -H 'Content-Type: application/json' -d '{"title":"Fix login","priority":1}'
+  bd serve --http :8080 --allow-host tracker.internal`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		addr, _ := cmd.Flags().GetString("http")
+		token, _ := cmd.Flags().GetString("token")
+		if token == "" {
+			token = os.Getenv("BD_SERVE_TOKEN")
+		}
+		allowHosts, _ := cmd.Flags().GetStringSlice("allow-host")
+		readOnly := readonlyMode || token == ""
+
+		methods := rpc.StorageMethods(store, actor)
+		methods["federation_status"] = federationStatusMethod(store)
+		guard := rpc.HTTPGuard{Token: token, AllowedHosts: allowHosts}
+		mux := http.NewServeMux()
+		mux.Handle("/", guard.Wrap(rpc.NewServer(methods, readOnly).HTTPHandler()))
+
+		webhook, err := newLinearWebhook(rootCtx)
+		if err != nil {
+			FatalError("serve: Linear webhooks: %v", err)
+		}
+		if webhook != nil && readOnly {
+			if readonlyMode {
+				fmt.Fprintf(os.Stderr, "Not receiving Linear webhooks in read-only mode\n")
+			} else {
+				fmt.Fprintf(os.Stderr, "Not receiving Linear webhooks without a token (set --token or BD_SERVE_TOKEN)\n")
+			}
+			webhook = nil
+		}
+		if webhook != nil {
+			mux.Handle(linearWebhookPath, webhook)
+		}
+
+		server := &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+			BaseContext:       func(net.Listener) context.Context { return rootCtx },
+		}
+
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			FatalError("serve: %v", err)
+		}
+		mode := ""
+		if readonlyMode {
+			mode = " (read-only)"
+		} else if readOnly {
+			mode = " (read-only: set --token or BD_SERVE_TOKEN to allow writes)"
+		}
+		fmt.Fprintf(os.Stderr, "Serving %s on http://%s/api/%s\n", store.Path(), listener.Addr(), mode)
+		if webhook != nil {
+			fmt.Fprintf(os.Stderr, "Receiving Linear webhooks on http://%s%s\n", listener.Addr(), linearWebhookPath)
+		}
+
+		go func() {
+			<-rootCtx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		}()
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			FatalError("serve: %v", err)
+		}
+	},
+}
+
+// federationPeerStatus is one peer in federation_status. Ahead and Behind
+// count commits as of the last fetch, or are -1 if the peer was never
+// fetched.
+type federationPeerStatus struct {
+	Name         string     `json:"name"`
+	URL          string     `json:"url"`
+	Ahead        int        `json:"ahead"`
+	Behind       int        `json:"behind"`
+	HasConflicts bool       `json:"has_conflicts"`
+	LastSync     *time.Time `json:"last_sync,omitempty"`
+}
+
+// federationStatusMethod reports federation peers and uncommitted changes.
+// Unlike 'bd federation status' it doesn't fetch from peers, so it stays
+// cheap enough for dashboards to poll.
+func federationStatusMethod(ds *dolt.DoltStore) rpc.Method {
+	return rpc.Method{Call: func(ctx context.Context, _ json.RawMessage) (interface{}, error) {
+		remotes, err := ds.ListRemotes(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list remotes: %w", err)
+		}
+		peers := []federationPeerStatus{}
+		for _, r := range remotes {
+			ps := federationPeerStatus{Name: r.Name, URL: r.URL, Ahead: -1, Behind: -1}
+			if status, err := ds.SyncStatus(ctx, r.Name); err == nil {
+				ps.Ahead, ps.Behind, ps.HasConflicts = status.LocalAhead, status.LocalBehind, status.HasConflicts
+				if !status.LastSync.IsZero() {
+					ps.LastSync = &status.LastSync
+				}
+			}
+			peers = append(peers, ps)
+		}
+		pending := 0
+		if status, err := ds.Status(ctx); err == nil && status != nil {
+			pending = len(status.Staged) + len(status.Unstaged)
+		}
+		return map[string]interface{}{"peers": peers, "pending_changes": pending}, nil
+	}}
+}
+
+func init() {
+	serveCmd.Flags().String("http", "127.0.0.1:8080", "Address to listen on, e.g. :8080 for all interfaces")
+	serveCmd.Flags().String("token", "", "Bearer token required on every request; enables writes (default $BD_SERVE_TOKEN)")
+	serveCmd.Flags().StringSlice("allow-host", nil, "Host names to accept besides loopback ones, for serving on other interfaces")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -7,8 +7,11 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/localize"
+	"github.com/steveyegge/beads/internal/summarize"
 	"github.com/steveyegge/beads/internal/types"
 	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/writepolicy"
 )
 
 var showCmd = &cobra.Command{
@@ -26,6 +29,8 @@ var showCmd = &cobra.Command{
 		idFlags, _ := cmd.Flags().GetStringArray("id")
 		localTime, _ := cmd.Flags().GetBool("local-time")
 		watchMode, _ := cmd.Flags().GetBool("watch")
+		showJournal, _ := cmd.Flags().GetBool("journal")
+		porcelain := porcelainFormat(cmd)
 		ctx := rootCtx
 
 		// Helper to format timestamp based on --local-time flag
@@ -96,6 +101,13 @@ var showCmd = &cobra.Command{
 		// Direct mode - use routed resolution for cross-repo lookups
 		allDetails := []interface{}{}
 		foundCount := 0
+		failCode := exitNotFound                    // Unless a lookup fails for another reason
+		typeSchemas, _ := writepolicy.TypeSchemas() // Best effort: fields are listed without templates if config is invalid
+		displayLangs := displayLanguages()
+		stopPager := func() {}
+		if !jsonOutput && porcelain == "" {
+			stopPager = ui.StartPager(pagerOptions(cmd))
+		}
 		for idx, id := range args {
 			// Resolve and get issue with routing (e.g., gt-xyz routes to gastown)
 			result, err := resolveAndGetIssueWithRouting(ctx, store, id)
@@ -104,6 +116,9 @@ var showCmd = &cobra.Command{
 					result.Close()
 				}
 				fmt.Fprintf(os.Stderr, "Error fetching %s: %v\n", id, err)
+				if exitCodeFor(err) != exitNotFound {
+					failCode = exitError
+				}
 				continue
 			}
 			if result == nil || result.Issue == nil {
@@ -117,13 +132,23 @@ var showCmd = &cobra.Command{
 			issueStore := result.Store // Use the store that contains this issue
 			// Note: result.Close() called at end of loop iteration
 			foundCount++
+			issue.ExternalKeys, _ = issueStore.GetExternalKeys(ctx, issue.ID) // Best effort: show issue even if aliases are unavailable
 
 			if shortMode {
+				localize.Apply(issue, displayLangs)
 				fmt.Println(formatShortIssue(issue))
 				result.Close()
 				continue
 			}
 
+			if porcelain != "" {
+				if err := writePorcelainIssues(ctx, os.Stdout, issueStore, []*types.Issue{issue}); err != nil {
+					fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", issue.ID, err)
+				}
+				result.Close()
+				continue
+			}
+
 			if jsonOutput {
 				// Include labels, dependencies (with metadata), dependents (with metadata), and comments in JSON output
 				details := &types.IssueDetails{Issue: *issue}
@@ -138,6 +163,13 @@ var showCmd = &cobra.Command{
 				details.Dependents, _ = issueStore.GetDependentsWithMetadata(ctx, issue.ID) // Best effort: show issue even if dependents unavailable
 
 				details.Comments, _ = issueStore.GetIssueComments(ctx, issue.ID) // Best effort: show issue even if comments unavailable
+				if reactions, err := issueStore.GetReactions(ctx, issue.ID); err == nil {
+					details.Reactions = summarizeReactions(reactions)
+				}
+				details.Lock, _ = issueStore.GetIssueLock(ctx, issue.ID) // Best effort: show issue even if lock lookup fails
+				if showJournal {
+					details.Journal, _ = issueStore.GetJournal(ctx, issue.ID) // Best effort: show issue even if the journal is unavailable
+				}
 				// Compute parent from dependencies
 				for _, dep := range details.Dependencies {
 					if dep.DependencyType == types.DepParentChild {
@@ -149,6 +181,7 @@ var showCmd = &cobra.Command{
 				result.Close() // Close before continuing to next iteration
 				continue
 			}
+			translatedFrom := localize.Apply(issue, displayLangs)
 			if idx > 0 {
 				fmt.Println("\n" + ui.RenderMuted(strings.Repeat("─", 60)))
 				fmt.Printf("\n%s\n", formatIssueHeader(issue))
@@ -158,6 +191,9 @@ var showCmd = &cobra.Command{
 
 			// Metadata: Owner · Type | Created · Updated
 			fmt.Println(formatIssueMetadata(issue))
+			if translatedFrom != "" {
+				fmt.Println(ui.RenderMuted(fmt.Sprintf("Showing the %s translation (bd translate %s)", translatedFrom, issue.ID)))
+			}
 
 			// Compaction info (if applicable)
 			if issue.CompactionLevel > 0 {
@@ -167,24 +203,31 @@ var showCmd = &cobra.Command{
 					saved := issue.OriginalSize - currentSize
 					if saved > 0 {
 						reduction := float64(saved) / float64(issue.OriginalSize) * 100
-						fmt.Printf("📊 %d → %d bytes (%.0f%% reduction)\n",
-							issue.OriginalSize, currentSize, reduction)
+						fmt.Print(ui.Glyph(fmt.Sprintf("📊 %d → %d bytes (%.0f%% reduction)\n",
+							issue.OriginalSize, currentSize, reduction)))
 					}
 				}
 			}
 
 			// Content sections
+			if fields := formatTypeFields(issue, typeSchemas); fields != "" {
+				fmt.Printf("\n%s\n%s\n", ui.RenderBold("FIELDS"), fields)
+			}
+			if summary := summarize.FromMetadata(issue.Metadata); summary != nil {
+				fmt.Printf("\n%s %s\n%s\n", ui.RenderBold("SUMMARY"), ui.RenderMuted("("+summary.Model+")"), summary.Text)
+			}
+			highlightRefs := referenceHighlighter(ctx, issueStore, issueTexts(issue)...)
 			if issue.Description != "" {
-				fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), ui.RenderMarkdown(issue.Description))
+				fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), highlightRefs(ui.RenderMarkdown(issue.Description)))
 			}
 			if issue.Design != "" {
-				fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESIGN"), ui.RenderMarkdown(issue.Design))
+				fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESIGN"), highlightRefs(ui.RenderMarkdown(issue.Design)))
 			}
 			if issue.Notes != "" {
-				fmt.Printf("\n%s\n%s\n", ui.RenderBold("NOTES"), ui.RenderMarkdown(issue.Notes))
+				fmt.Printf("\n%s\n%s\n", ui.RenderBold("NOTES"), highlightRefs(ui.RenderMarkdown(issue.Notes)))
 			}
 			if issue.AcceptanceCriteria != "" {
-				fmt.Printf("\n%s\n%s\n", ui.RenderBold("ACCEPTANCE CRITERIA"), ui.RenderMarkdown(issue.AcceptanceCriteria))
+				fmt.Printf("\n%s\n%s\n", acceptanceHeading(issue), highlightRefs(ui.RenderMarkdown(issue.AcceptanceCriteria)))
 			}
 
 			// Show labels
@@ -294,13 +337,28 @@ var showCmd = &cobra.Command{
 				}
 			}
 
+			// Show lock
+			if lock, err := issueStore.GetIssueLock(ctx, issue.ID); err == nil && lock != nil {
+				fmt.Printf("\n%s %s\n", ui.RenderWarn("LOCKED"), describeLock(lock, time.Now()))
+			}
+
+			// Show reactions
+			if reactions, err := issueStore.GetReactions(ctx, issue.ID); err == nil && len(reactions) > 0 {
+				fmt.Printf("\n%s %s\n", ui.RenderBold("REACTIONS"), formatReactions(summarizeReactions(reactions)))
+			}
+
 			// Show comments
 			comments, _ := issueStore.GetIssueComments(ctx, issue.ID) // Best effort: show issue even if comments unavailable
 			if len(comments) > 0 {
 				fmt.Printf("\n%s\n", ui.RenderBold("COMMENTS"))
+				commentTexts := make([]string, len(comments))
+				for i, comment := range comments {
+					commentTexts[i] = comment.Text
+				}
+				highlightComment := referenceHighlighter(ctx, issueStore, commentTexts...)
 				for _, comment := range comments {
 					fmt.Printf("  %s %s\n", ui.RenderMuted(formatTime(comment.CreatedAt)), comment.Author)
-					rendered := ui.RenderMarkdown(comment.Text)
+					rendered := highlightComment(ui.RenderMarkdown(comment.Text))
 					// TrimRight removes trailing newlines that Glamour adds, preventing extra blank lines
 					for _, line := range strings.Split(strings.TrimRight(rendered, "\n"), "\n") {
 						fmt.Printf("    %s\n", line)
@@ -308,9 +366,18 @@ var showCmd = &cobra.Command{
 				}
 			}
 
+			// Show the work journal, oldest first
+			if showJournal {
+				if journal, _ := issueStore.GetJournal(ctx, issue.ID); len(journal) > 0 { // Best effort: show issue even if the journal is unavailable
+					fmt.Printf("\n%s\n", ui.RenderBold("JOURNAL"))
+					printJournal(journal, formatTime)
+				}
+			}
+
 			fmt.Println()
 			result.Close() // Close routed storage after each iteration
 		}
+		stopPager()
 
 		if jsonOutput {
 			if len(allDetails) > 0 {
@@ -319,13 +386,15 @@ var showCmd = &cobra.Command{
 				// No issues found - exit non-zero with structured JSON error
 				// so downstream consumers (e.g., gt bd move) get a proper error
 				// instead of empty stdout causing "unexpected end of JSON input"
-				FatalErrorRespectJSON("no issues found matching the provided IDs")
+				FatalErrorCode(failCode, "no issues found matching the provided IDs")
 			}
 		} else if foundCount > 0 {
-			// Show tip after successful show (non-JSON mode)
-			maybeShowTip(store)
+			// Show tip after successful show (non-JSON, non-porcelain mode)
+			if porcelain == "" {
+				maybeShowTip(store)
+			}
 		} else {
-			os.Exit(1)
+			os.Exit(failCode)
 		}
 
 		// Track first shown issue as last touched
@@ -338,12 +407,15 @@ var showCmd = &cobra.Command{
 func init() {
 	showCmd.Flags().Bool("thread", false, "Show full conversation thread (for messages)")
 	showCmd.Flags().Bool("short", false, "Show compact one-line output per issue")
+	addPorcelainFlag(showCmd)
 	showCmd.Flags().Bool("refs", false, "Show issues that reference this issue (reverse lookup)")
 	showCmd.Flags().Bool("children", false, "Show only the children of this issue")
 	showCmd.Flags().String("as-of", "", "Show issue as it existed at a specific commit hash or branch (requires Dolt)")
 	showCmd.Flags().StringArray("id", nil, "Issue ID (use for IDs that look like flags, e.g., --id=gt--xyz)")
+	showCmd.Flags().Bool("journal", false, "Show the issue's work journal (bd journal), oldest first")
 	showCmd.Flags().Bool("local-time", false, "Show timestamps in local time instead of UTC")
 	showCmd.Flags().BoolP("watch", "w", false, "Watch for changes and auto-refresh display")
+	showCmd.Flags().Bool("no-pager", false, "Disable pager output")
 	showCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(showCmd)
 }
@@ -39,17 +39,18 @@ func displayShowIssue(ctx context.Context, issueID string) {
 	fmt.Println(formatIssueMetadata(issue))
 
 	// Content sections (matches standard bd show order)
+	highlightRefs := referenceHighlighter(ctx, issueStore, issueTexts(issue)...)
 	if issue.Description != "" {
-		fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), ui.RenderMarkdown(issue.Description))
+		fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESCRIPTION"), highlightRefs(ui.RenderMarkdown(issue.Description)))
 	}
 	if issue.Design != "" {
-		fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESIGN"), ui.RenderMarkdown(issue.Design))
+		fmt.Printf("\n%s\n%s\n", ui.RenderBold("DESIGN"), highlightRefs(ui.RenderMarkdown(issue.Design)))
 	}
 	if issue.Notes != "" {
-		fmt.Printf("\n%s\n%s\n", ui.RenderBold("NOTES"), ui.RenderMarkdown(issue.Notes))
+		fmt.Printf("\n%s\n%s\n", ui.RenderBold("NOTES"), highlightRefs(ui.RenderMarkdown(issue.Notes)))
 	}
 	if issue.AcceptanceCriteria != "" {
-		fmt.Printf("\n%s\n%s\n", ui.RenderBold("ACCEPTANCE CRITERIA"), ui.RenderMarkdown(issue.AcceptanceCriteria))
+		fmt.Printf("\n%s\n%s\n", acceptanceHeading(issue), highlightRefs(ui.RenderMarkdown(issue.AcceptanceCriteria)))
 	}
 
 	// Labels
@@ -153,9 +154,14 @@ func displayShowIssue(ctx context.Context, issueID string) {
 	comments, _ := issueStore.GetIssueComments(ctx, issue.ID)
 	if len(comments) > 0 {
 		fmt.Printf("\n%s\n", ui.RenderBold("COMMENTS"))
+		commentTexts := make([]string, len(comments))
+		for i, comment := range comments {
+			commentTexts[i] = comment.Text
+		}
+		highlightComment := referenceHighlighter(ctx, issueStore, commentTexts...)
 		for _, comment := range comments {
 			fmt.Printf("  %s %s\n", ui.RenderMuted(comment.CreatedAt.UTC().Format("2006-01-02 15:04")), comment.Author)
-			rendered := ui.RenderMarkdown(comment.Text)
+			rendered := highlightComment(ui.RenderMarkdown(comment.Text))
 			for _, line := range strings.Split(strings.TrimRight(rendered, "\n"), "\n") {
 				fmt.Printf("    %s\n", line)
 			}
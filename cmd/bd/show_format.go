@@ -68,8 +68,8 @@ func formatIssueHeader(issue *types.Issue) string {
 
 	// Build header: STATUS_ICON ID · Title   [Priority · STATUS]
 	idStyled := ui.RenderAccent(issue.ID)
-	return fmt.Sprintf("%s %s%s · %s%s   [%s · %s]",
-		statusIcon, idStyled, typeBadge, issue.Title, tierEmoji, priorityTag, statusStr)
+	return ui.Glyph(fmt.Sprintf("%s %s%s · %s%s   [%s · %s]",
+		statusIcon, idStyled, typeBadge, issue.Title, tierEmoji, priorityTag, statusStr))
 }
 
 // formatIssueMetadata returns the metadata line(s) with grouped info
@@ -113,6 +113,12 @@ func formatIssueMetadata(issue *types.Issue) string {
 	if issue.DeferUntil != nil {
 		timeParts = append(timeParts, fmt.Sprintf("Deferred: %s", issue.DeferUntil.Format("2006-01-02")))
 	}
+	if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+		timeParts = append(timeParts, fmt.Sprintf("Estimate: %s", formatEstimateTotal(*issue.EstimatedMinutes)))
+	}
+	if issue.ActualMinutes != nil && *issue.ActualMinutes > 0 {
+		timeParts = append(timeParts, fmt.Sprintf("Actual: %s", formatEstimateTotal(*issue.ActualMinutes)))
+	}
 	if len(timeParts) > 0 {
 		lines = append(lines, strings.Join(timeParts, " · "))
 	}
@@ -126,6 +132,13 @@ func formatIssueMetadata(issue *types.Issue) string {
 	if issue.ExternalRef != nil && *issue.ExternalRef != "" {
 		lines = append(lines, fmt.Sprintf("External: %s", *issue.ExternalRef))
 	}
+	if len(issue.ExternalKeys) > 0 {
+		keys := make([]string, len(issue.ExternalKeys))
+		for i, k := range issue.ExternalKeys {
+			keys[i] = k.Key
+		}
+		lines = append(lines, fmt.Sprintf("Aliases: %s", strings.Join(keys, ", ")))
+	}
 	if issue.SpecID != "" {
 		lines = append(lines, fmt.Sprintf("Spec: %s", issue.SpecID))
 	}
@@ -135,7 +148,7 @@ func formatIssueMetadata(issue *types.Issue) string {
 		lines = append(lines, fmt.Sprintf("Wisp type: %s", ui.RenderMuted(string(issue.WispType))))
 	}
 
-	return strings.Join(lines, "\n")
+	return ui.Glyph(strings.Join(lines, "\n"))
 }
 
 // formatDependencyLine formats a single dependency with semantic colors
@@ -143,6 +156,7 @@ func formatIssueMetadata(issue *types.Issue) string {
 func formatDependencyLine(prefix string, dep *types.IssueWithDependencyMetadata) string {
 	// Status icon (always rendered with semantic color)
 	statusIcon := ui.GetStatusIcon(string(dep.Status))
+	prefix = ui.Glyph(prefix)
 
 	// Closed items: mute entire row since the work is complete
 	if dep.Status == types.StatusClosed {
@@ -173,6 +187,7 @@ func formatDependencyLine(prefix string, dep *types.IssueWithDependencyMetadata)
 // Closed items get entire row muted - the work is done, no need for attention
 func formatSimpleDependencyLine(prefix string, dep *types.Issue) string {
 	statusIcon := ui.GetStatusIcon(string(dep.Status))
+	prefix = ui.Glyph(prefix)
 
 	// Closed items: mute entire row since the work is complete
 	if dep.Status == types.StatusClosed {
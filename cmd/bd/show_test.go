@@ -66,6 +66,36 @@ func TestShow_NoExternalRef(t *testing.T) {
 	}
 }
 
+func TestShow_Alias(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping CLI test in short mode")
+	}
+
+	tmpDir := setupCLITestDB(t)
+
+	out := runBDInProcess(t, tmpDir, "create", "Alias test", "-p", "1", "--json")
+	jsonStart := strings.Index(out, "{")
+	if jsonStart < 0 {
+		t.Fatalf("No JSON found in create output: %s", out)
+	}
+	var issue map[string]interface{}
+	if err := json.Unmarshal([]byte(out[jsonStart:]), &issue); err != nil {
+		t.Fatalf("failed to parse create output: %v, output: %s", err, out)
+	}
+	id := issue["id"].(string)
+
+	runBDInProcess(t, tmpDir, "alias", "add", id, "JIRA-123", "--source", "jira")
+
+	// The alias resolves like an ID, and show lists it
+	showOut := runBDInProcess(t, tmpDir, "show", "JIRA-123")
+	if !strings.Contains(showOut, id) {
+		t.Errorf("expected %s in output, got: %s", id, showOut)
+	}
+	if !strings.Contains(showOut, "Aliases: JIRA-123") {
+		t.Errorf("expected 'Aliases: JIRA-123' in output, got: %s", showOut)
+	}
+}
+
 func TestShow_IDFlag(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping CLI test in short mode")
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/embeddings"
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var similarCmd = &cobra.Command{
+	Use:     "similar [id]",
+	GroupID: "views",
+	Short:   "Find issues similar to an issue or a piece of text",
+	Long: `Find issues whose text is closest to an issue (or to --text) by embedding
+similarity. Useful for spotting duplicates before filing, and for finding
+related or prior work.
+
+Embeddings are computed from each issue's title and description, cached
+per issue in the database (local only, never synced), and recomputed when
+the text changes. The embedding model is configured in config.yaml:
+
+  embeddings.provider     local (default, offline), ollama, or openai
+  embeddings.model        Model name (ollama: nomic-embed-text,
+                          openai: text-embedding-3-small)
+  embeddings.url          Base URL (any OpenAI-compatible API works)
+  embeddings.api-key-env  Env var holding the API key (default OPENAI_API_KEY)
+  embeddings.dimensions   Vector size (0 = provider default)
+
+Embeddings also power 'bd find-duplicates --method embedding' and
+'bd search --sort relevance'.
+
+Examples:
+  bd similar bd-42                      # Issues similar to bd-42
+  bd similar --text "login crash"       # Check for duplicates before filing
+  bd similar bd-42 --status open -n 5   # Top 5 open matches
+  bd similar bd-42 --reindex            # Recompute all cached embeddings`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runSimilar,
+}
+
+func init() {
+	similarCmd.Flags().String("text", "", "Find issues similar to this text instead of an issue")
+	similarCmd.Flags().IntP("limit", "n", 10, "Maximum number of matches to show")
+	similarCmd.Flags().Float64("threshold", 0.3, "Minimum similarity (0.0-1.0)")
+	similarCmd.Flags().StringP("status", "s", "", "Only match issues with this status (default: all)")
+	similarCmd.Flags().Bool("reindex", false, "Recompute embeddings even when cached ones are current")
+	rootCmd.AddCommand(similarCmd)
+}
+
+// similarIssue is one match in bd similar output.
+type similarIssue struct {
+	*types.Issue
+	Similarity float64 `json:"similarity"`
+}
+
+func runSimilar(cmd *cobra.Command, args []string) {
+	text, _ := cmd.Flags().GetString("text")
+	limit, _ := cmd.Flags().GetInt("limit")
+	threshold, _ := cmd.Flags().GetFloat64("threshold")
+	status, _ := cmd.Flags().GetString("status")
+	reindex, _ := cmd.Flags().GetBool("reindex")
+
+	if (len(args) == 0) == (text == "") {
+		FatalErrorRespectJSON("specify exactly one of an issue ID or --text")
+	}
+
+	ctx := rootCtx
+	embedder, err := newEmbedder()
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	var target *types.Issue
+	if len(args) == 1 {
+		fullID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		if target, err = store.GetIssue(ctx, fullID); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		text = issueText(target)
+	}
+
+	filter := types.IssueFilter{}
+	if status != "" && status != "all" {
+		s := types.Status(status)
+		filter.Status = &s
+	}
+	candidates, err := store.SearchIssues(ctx, "", filter)
+	if err != nil {
+		FatalErrorRespectJSON("fetching issues: %v", err)
+	}
+	if target != nil {
+		candidates = append(candidates, target) // Embed (and cache) the target alongside
+	}
+
+	vectors, err := embedIssues(ctx, store, embedder, candidates, reindex)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	var query []float32
+	if target != nil {
+		query = vectors[target.ID]
+	} else {
+		qv, err := embedder.Embed(ctx, []string{text})
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		query = qv[0]
+	}
+
+	var matches []similarIssue
+	for _, issue := range candidates {
+		if target != nil && issue.ID == target.ID {
+			continue
+		}
+		if score := embeddings.Cosine(query, vectors[issue.ID]); score >= threshold {
+			matches = append(matches, similarIssue{Issue: issue, Similarity: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Similarity > matches[j].Similarity
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	if jsonOutput {
+		result := map[string]interface{}{
+			"model":   embedder.Model(),
+			"matches": matches,
+		}
+		if matches == nil {
+			result["matches"] = []similarIssue{}
+		}
+		if target != nil {
+			result["issue_id"] = target.ID
+		} else {
+			result["text"] = text
+		}
+		outputJSON(result)
+		return
+	}
+
+	subject := fmt.Sprintf("%q", text)
+	if target != nil {
+		subject = fmt.Sprintf("%s %s", ui.RenderID(target.ID), target.Title)
+	}
+	if len(matches) == 0 {
+		fmt.Printf("No issues similar to %s (threshold: %.0f%%)\n", subject, threshold*100)
+		return
+	}
+	fmt.Printf("\nIssues similar to %s %s:\n\n", subject, ui.RenderMuted("("+embedder.Model()+")"))
+	for _, m := range matches {
+		fmt.Printf("  %3.0f%%  %s [P%d] [%s] %s  %s\n", m.Similarity*100,
+			ui.RenderID(m.ID), m.Priority, m.IssueType, m.Status, m.Title)
+	}
+	fmt.Println()
+}
+
+// newEmbedder returns the embedder configured under embeddings.* in config.yaml.
+func newEmbedder() (embeddings.Embedder, error) {
+	cfg := embeddings.Config{
+		Provider:   config.GetString("embeddings.provider"),
+		Model:      config.GetString("embeddings.model"),
+		URL:        config.GetString("embeddings.url"),
+		Dimensions: config.GetInt("embeddings.dimensions"),
+	}
+	if keyEnv := config.GetString("embeddings.api-key-env"); keyEnv != "" {
+		cfg.APIKey = os.Getenv(keyEnv)
+	}
+	return embeddings.New(cfg)
+}
+
+// embedIssues returns an embedding for each issue, keyed by ID. Cached
+// embeddings are reused while the issue text is unchanged (unless reindex
+// is set); the rest are computed in one batch and cached, except in
+// read-only mode.
+func embedIssues(ctx context.Context, s *dolt.DoltStore, embedder embeddings.Embedder, issues []*types.Issue, reindex bool) (map[string][]float32, error) {
+	model := embedder.Model()
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	cached := map[string]*dolt.Embedding{}
+	if !reindex {
+		var err error
+		if cached, err = s.GetEmbeddings(ctx, model, ids); err != nil {
+			return nil, err
+		}
+	}
+
+	vectors := make(map[string][]float32, len(issues))
+	var stale []*dolt.Embedding
+	var texts []string
+	for _, issue := range issues {
+		if _, seen := vectors[issue.ID]; seen {
+			continue
+		}
+		text := issueText(issue)
+		hash := embeddings.ContentHash(text)
+		if e := cached[issue.ID]; e != nil && e.ContentHash == hash {
+			vectors[issue.ID] = e.Vector
+			continue
+		}
+		vectors[issue.ID] = nil // Filled in below; also dedupes repeated issues
+		stale = append(stale, &dolt.Embedding{IssueID: issue.ID, Model: model, ContentHash: hash})
+		texts = append(texts, text)
+	}
+	if len(stale) == 0 {
+		return vectors, nil
+	}
+
+	computed, err := embedder.Embed(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("computing embeddings with %s: %w", model, err)
+	}
+	for i, e := range stale {
+		e.Vector = computed[i]
+		vectors[e.IssueID] = e.Vector
+	}
+	if readonlyMode {
+		return vectors, nil
+	}
+	if err := s.SaveEmbeddings(ctx, stale); err != nil && !errors.Is(err, permissions.ErrDenied) {
+		// The cache is an optimization; results are still correct without it,
+		// so actors the policy doesn't let write just go without
+		fmt.Fprintf(os.Stderr, "Warning: failed to cache embeddings: %v\n", err)
+	}
+	return vectors, nil
+}
+
+// rankByRelevance orders issues by embedding similarity to query, most
+// similar first (least similar first if reverse).
+func rankByRelevance(ctx context.Context, s *dolt.DoltStore, issues []*types.Issue, query string, reverse bool) error {
+	embedder, err := newEmbedder()
+	if err != nil {
+		return err
+	}
+	vectors, err := embedIssues(ctx, s, embedder, issues, false)
+	if err != nil {
+		return err
+	}
+	qv, err := embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return err
+	}
+	scores := make(map[string]float64, len(issues))
+	for _, issue := range issues {
+		scores[issue.ID] = embeddings.Cosine(qv[0], vectors[issue.ID])
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if reverse {
+			return scores[issues[i].ID] < scores[issues[j].ID]
+		}
+		return scores[issues[i].ID] > scores[issues[j].ID]
+	})
+	return nil
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestFindEmbeddingDuplicates(t *testing.T) {
+	issues := []*types.Issue{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	vectors := map[string][]float32{
+		"a": {1, 0},
+		"b": {0.9, 0.1},
+		"c": {0, 1},
+	}
+	pairs := findEmbeddingDuplicates(issues, vectors, 0.8)
+	if len(pairs) != 1 || pairs[0].IssueA.ID != "a" || pairs[0].IssueB.ID != "b" || pairs[0].Method != "embedding" {
+		t.Errorf("pairs = %+v", pairs)
+	}
+}
@@ -17,8 +17,10 @@ import (
 
 // StatusOutput represents the complete status output
 type StatusOutput struct {
-	Summary        *types.Statistics      `json:"summary"`
-	RecentActivity *RecentActivitySummary `json:"recent_activity,omitempty"`
+	Summary         *types.Statistics      `json:"summary"`
+	CloseCategories map[string]int         `json:"close_categories,omitempty"` // Closed issues per close category
+	TimeByEpic      []epicTimeRollup       `json:"time_by_epic,omitempty"`     // Estimate vs. actual per epic
+	RecentActivity  *RecentActivitySummary `json:"recent_activity,omitempty"`
 }
 
 // RecentActivitySummary represents activity from git history
@@ -41,7 +43,8 @@ var statusCmd = &cobra.Command{
 
 This command provides a summary of issue counts by state (open, in_progress,
 blocked, closed), ready work, extended statistics (pinned issues,
-average lead time), and recent activity over the last 24 hours from git history.
+average lead time), estimated versus actual time per epic (see 'bd time'),
+and recent activity over the last 24 hours from git history.
 
 Similar to how 'git status' shows working tree state, 'bd status' gives you
 a quick overview of your issue database without needing multiple queries.
@@ -89,6 +92,20 @@ Examples:
 			}
 		}
 
+		// Closed issues by close category (wontfix, duplicate, ...)
+		var closeReasons map[string]int
+		if showAssigned {
+			closeReasons = getAssignedCloseReasons(actor)
+		} else if closeReasons, err = store.GetCloseReasonCounts(ctx); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		// Estimate vs. actual time per epic
+		timeByEpic, err := getEpicTimeRollups()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
 		// Get recent activity from git history (last 24 hours) unless --no-activity
 		var recentActivity *RecentActivitySummary
 		if !noActivity {
@@ -96,8 +113,10 @@ Examples:
 		}
 
 		output := &StatusOutput{
-			Summary:        stats,
-			RecentActivity: recentActivity,
+			Summary:         stats,
+			CloseCategories: closeCategoryCounts(closeReasons),
+			TimeByEpic:      timeByEpic,
+			RecentActivity:  recentActivity,
 		}
 
 		// JSON output
@@ -132,6 +151,9 @@ Examples:
 			}
 		}
 
+		printCloseCategories(output.CloseCategories)
+		printEpicTimeRollups(output.TimeByEpic)
+
 		if recentActivity != nil {
 			fmt.Printf("\nRecent Activity (last %d hours):\n", recentActivity.HoursTracked)
 			fmt.Printf("  Commits:                %d\n", recentActivity.CommitCount)
@@ -317,3 +339,29 @@ func init() {
 	// Note: --json flag is defined as a persistent flag in main.go, not here
 	rootCmd.AddCommand(statusCmd)
 }
+
+// getEpicTimeRollups sums estimated and actual time per epic over all
+// persistent issues.
+func getEpicTimeRollups() ([]epicTimeRollup, error) {
+	persistent := false
+	issues, err := store.SearchIssues(rootCtx, "", types.IssueFilter{Ephemeral: &persistent})
+	if err != nil {
+		return nil, fmt.Errorf("listing issues: %w", err)
+	}
+	deps, err := store.GetAllDependencyRecords(rootCtx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching dependencies: %w", err)
+	}
+	return buildEpicTimeRollups(issues, deps), nil
+}
+
+// getAssignedCloseReasons counts the close reasons of issues assigned to
+// assignee, like GetCloseReasonCounts does for all issues.
+func getAssignedCloseReasons(assignee string) map[string]int {
+	closed := types.StatusClosed
+	issues, err := store.SearchIssues(rootCtx, "", types.IssueFilter{Assignee: &assignee, Status: &closed})
+	if err != nil {
+		return nil
+	}
+	return countCloseReasons(issues)
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// stdinDocArg is the positional argument that makes bd create and bd update
+// read an issue document (a JSON object) from stdin:
+//
+//	echo '{"title":"Fix login","priority":1}' | bd create --json -
+//	bd show bd-42 --json | jq '.[0] | .notes = "done"' | bd update bd-42 --json -
+//
+// Document keys are issue JSON field names. Each key sets the matching flag,
+// so the command validates and applies the document exactly as it would the
+// flags.
+const stdinDocArg = "-"
+
+// createDocFields maps the keys bd create accepts on stdin to its flags.
+var createDocFields = map[string]string{
+	"id":                  "id",
+	"title":               "title",
+	"description":         "description",
+	"design":              "design",
+	"acceptance_criteria": "acceptance",
+	"notes":               "notes",
+	"spec_id":             "spec-id",
+	"priority":            "priority",
+	"issue_type":          "type",
+	"type":                "type",
+	"assignee":            "assignee",
+	"labels":              "labels",
+	"estimated_minutes":   "estimate",
+	"external_ref":        "external-ref",
+	"due_at":              "due",
+	"defer_until":         "defer",
+	"ephemeral":           "ephemeral",
+	"wisp_type":           "wisp-type",
+	"mol_type":            "mol-type",
+	"parent":              "parent",
+	"deps":                "deps",
+}
+
+// updateDocFields maps the keys bd update accepts on stdin to its flags.
+// "id" is handled separately: it names the issue to update.
+var updateDocFields = map[string]string{
+	"title":               "title",
+	"description":         "description",
+	"design":              "design",
+	"acceptance_criteria": "acceptance",
+	"notes":               "notes",
+	"append_notes":        "append-notes",
+	"spec_id":             "spec-id",
+	"status":              "status",
+	"priority":            "priority",
+	"issue_type":          "type",
+	"type":                "type",
+	"assignee":            "assignee",
+	"labels":              "set-labels",
+	"add_labels":          "add-label",
+	"remove_labels":       "remove-label",
+	"estimated_minutes":   "estimate",
+	"external_ref":        "external-ref",
+	"due_at":              "due",
+	"defer_until":         "defer",
+	"await_id":            "await-id",
+	"metadata":            "metadata",
+	"parent":              "parent",
+}
+
+// issueJSONFields is the set of JSON field names in `bd show --json` output
+// (types.IssueDetails), used to tell read-only fields from typos.
+var issueJSONFields = func() map[string]bool {
+	fields := make(map[string]bool)
+	var collect func(t reflect.Type)
+	collect = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+				collect(f.Type)
+				continue
+			}
+			if name != "" && name != "-" {
+				fields[name] = true
+			}
+		}
+	}
+	collect(reflect.TypeOf(types.IssueDetails{}))
+	return fields
+}()
+
+// splitStdinDocArg removes stdinDocArg from args, reporting whether it was
+// present.
+func splitStdinDocArg(args []string) ([]string, bool) {
+	rest := make([]string, 0, len(args))
+	found := false
+	for _, a := range args {
+		if a == stdinDocArg {
+			found = true
+			continue
+		}
+		rest = append(rest, a)
+	}
+	return rest, found
+}
+
+// readIssueDoc reads one JSON object from r.
+func readIssueDoc(r io.Reader) (map[string]json.RawMessage, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading stdin: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, errors.New("no issue document on stdin")
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var doc map[string]json.RawMessage
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid issue document: %w", err)
+	}
+	if doc == nil {
+		return nil, errors.New("invalid issue document: expected a JSON object")
+	}
+	if dec.More() {
+		return nil, errors.New("invalid issue document: expected a single JSON object")
+	}
+	return doc, nil
+}
+
+// applyIssueDoc validates doc and sets the flags its keys map to in fields.
+// Keys that aren't accepted are skipped and reported as warnings. Nothing is
+// set unless the whole document is valid. With clearable, null clears a
+// field; otherwise null keys are skipped.
+func applyIssueDoc(cmd *cobra.Command, doc map[string]json.RawMessage, fields map[string]string, rules editDocRules, clearable bool) (warnings []string, err error) {
+	keys := make([]string, 0, len(doc))
+	for k := range doc {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	type assignment struct {
+		flag   string
+		value  string   // Scalar flags
+		values []string // Slice flags
+		slice  bool
+	}
+	var assignments []assignment
+	var problems []string
+	var readOnly []string
+	setBy := make(map[string]string) // flag -> document key
+
+	for _, key := range keys {
+		raw := doc[key]
+		flagName, ok := fields[key]
+		if !ok {
+			if key == "id" {
+				continue // Handled by the caller
+			}
+			if issueJSONFields[key] {
+				readOnly = append(readOnly, key)
+			} else {
+				warnings = append(warnings, fmt.Sprintf("unknown field %q ignored", key))
+			}
+			continue
+		}
+		flag := cmd.Flags().Lookup(flagName)
+		if flag == nil {
+			continue
+		}
+		if flag.Changed {
+			problems = append(problems, fmt.Sprintf("%s: also given as --%s", key, flagName))
+			continue
+		}
+		if other, dup := setBy[flagName]; dup {
+			problems = append(problems, fmt.Sprintf("%s: conflicts with %s", key, other))
+			continue
+		}
+		setBy[flagName] = key
+
+		isNull := string(bytes.TrimSpace(raw)) == "null"
+		if isNull && !clearable {
+			continue
+		}
+
+		a := assignment{flag: flagName}
+		var problem string
+		switch flag.Value.Type() {
+		case "stringSlice":
+			a.slice = true
+			if !isNull && json.Unmarshal(raw, &a.values) != nil {
+				problem = "must be an array of strings"
+			} else if flagName == "set-labels" && len(a.values) == 0 {
+				problem = "must not be empty (use remove_labels to remove labels)"
+			}
+		case "int":
+			var n int
+			switch {
+			case isNull:
+				problem = "cannot be cleared"
+			case json.Unmarshal(raw, &n) != nil:
+				problem = "must be an integer"
+			case n < 0:
+				problem = "must not be negative"
+			default:
+				a.value = fmt.Sprint(n)
+			}
+		case "bool":
+			var b bool
+			if isNull || json.Unmarshal(raw, &b) != nil {
+				problem = "must be true or false"
+			} else {
+				a.value = fmt.Sprint(b)
+			}
+		default:
+			a.value, problem = docStringValue(key, raw, isNull, rules)
+		}
+		if problem != "" {
+			problems = append(problems, key+": "+problem)
+			continue
+		}
+		assignments = append(assignments, a)
+	}
+
+	if len(readOnly) > 0 {
+		warnings = append(warnings, fmt.Sprintf("read-only fields ignored: %s", strings.Join(readOnly, ", ")))
+	}
+	if len(problems) > 0 {
+		return warnings, fmt.Errorf("invalid issue document:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	for _, a := range assignments {
+		flag := cmd.Flags().Lookup(a.flag)
+		if a.slice {
+			sv, ok := flag.Value.(interface{ Replace([]string) error })
+			if !ok {
+				return warnings, fmt.Errorf("--%s does not accept a list", a.flag)
+			}
+			if err := sv.Replace(a.values); err != nil {
+				return warnings, err
+			}
+			flag.Changed = true
+			continue
+		}
+		if err := cmd.Flags().Set(a.flag, a.value); err != nil {
+			return warnings, fmt.Errorf("--%s: %w", a.flag, err)
+		}
+	}
+	return warnings, nil
+}
+
+// docStringValue converts and validates the value of a string-valued key.
+func docStringValue(key string, raw json.RawMessage, isNull bool, rules editDocRules) (string, string) {
+	if isNull {
+		if key == "title" || key == "status" || key == "priority" || key == "issue_type" || key == "type" {
+			return "", "cannot be cleared"
+		}
+		return "", ""
+	}
+
+	if key == "metadata" {
+		if !bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+			return "", "must be a JSON object"
+		}
+		return string(raw), ""
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		// Priority may be given as a number
+		var n json.Number
+		if key != "priority" || json.Unmarshal(raw, &n) != nil {
+			return "", "must be a string"
+		}
+		s = n.String()
+	}
+
+	switch key {
+	case "title":
+		if strings.TrimSpace(s) == "" {
+			return "", "cannot be empty"
+		}
+	case "status":
+		if !types.Status(s).IsValidWithCustom(rules.customStatuses) {
+			return "", fmt.Sprintf("invalid status %q (valid: %s)", s, rules.validStatuses())
+		}
+	case "priority":
+		if _, err := validation.ValidatePriority(s); err != nil {
+			return "", err.Error()
+		}
+	case "issue_type", "type":
+		s = utils.NormalizeIssueType(s)
+		if !types.IssueType(s).IsValidWithCustom(rules.customTypes) {
+			return "", fmt.Sprintf("invalid issue type %q (valid: %s)", s, rules.validTypes())
+		}
+	}
+	return s, ""
+}
+
+// checkStdinDocFlags rejects flags that would also read stdin or that can't
+// combine with a stdin document.
+func checkStdinDocFlags(cmd *cobra.Command) {
+	for _, name := range []string{"body-file", "description-file", "description", "body", "message"} {
+		if v, _ := cmd.Flags().GetString(name); v == "-" && cmd.Flags().Changed(name) {
+			FatalErrorRespectJSON("--%s=- cannot be combined with an issue document on stdin", name)
+		}
+	}
+	for _, name := range []string{"file", "edit"} {
+		if cmd.Flags().Lookup(name) != nil && cmd.Flags().Changed(name) {
+			FatalErrorRespectJSON("--%s cannot be combined with an issue document on stdin", name)
+		}
+	}
+}
+
+// applyStdinIssueDoc reads the issue document from stdin and applies it to
+// cmd's flags, printing warnings for ignored fields. Returns the document's
+// "id" value, if any.
+func applyStdinIssueDoc(cmd *cobra.Command, fields map[string]string, creating bool) string {
+	checkStdinDocFlags(cmd)
+	doc, err := readIssueDoc(os.Stdin)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	warnings, err := applyIssueDoc(cmd, doc, fields, loadEditDocRules(rootCtx, creating), !creating)
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "%s %s\n", ui.RenderWarn("⚠"), w)
+	}
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+
+	var id string
+	if raw, ok := doc["id"]; ok && string(raw) != "null" {
+		if json.Unmarshal(raw, &id) != nil {
+			FatalErrorRespectJSON("invalid issue document:\n  id: must be a string")
+		}
+	}
+	return id
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newStdinDocTestCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("title", "", "")
+	cmd.Flags().String("status", "", "")
+	registerPriorityFlag(cmd, "2")
+	cmd.Flags().String("type", "task", "")
+	registerCommonIssueFlags(cmd)
+	cmd.Flags().IntP("estimate", "e", 0, "")
+	cmd.Flags().StringSlice("set-labels", nil, "")
+	cmd.Flags().StringSlice("add-label", nil, "")
+	cmd.Flags().String("metadata", "", "")
+	cmd.Flags().Bool("ephemeral", false, "")
+	return cmd
+}
+
+func mustReadIssueDoc(t *testing.T, s string) map[string]json.RawMessage {
+	t.Helper()
+	doc, err := readIssueDoc(strings.NewReader(s))
+	if err != nil {
+		t.Fatalf("readIssueDoc: %v", err)
+	}
+	return doc
+}
+
+func TestReadIssueDoc(t *testing.T) {
+	for name, input := range map[string]string{
+		"empty":    "  \n",
+		"array":    `[{"title":"x"}]`,
+		"null":     "null",
+		"trailing": `{"title":"x"} {"title":"y"}`,
+		"broken":   `{"title":`,
+	} {
+		if _, err := readIssueDoc(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestApplyIssueDoc(t *testing.T) {
+	cmd := newStdinDocTestCmd()
+	doc := mustReadIssueDoc(t, `{
+		"id": "bd-1",
+		"title": "Fix login",
+		"status": "in_progress",
+		"priority": 1,
+		"type": "feat",
+		"description": "line one\nline two",
+		"labels": ["auth", "ui"],
+		"estimated_minutes": 45,
+		"metadata": {"k": "v"},
+		"created_at": "2026-01-01T00:00:00Z",
+		"titel": "typo"
+	}`)
+
+	warnings, err := applyIssueDoc(cmd, doc, updateDocFields, editDocRules{}, true)
+	if err != nil {
+		t.Fatalf("applyIssueDoc: %v", err)
+	}
+	joined := strings.Join(warnings, "\n")
+	if !strings.Contains(joined, `unknown field "titel"`) || !strings.Contains(joined, "read-only fields ignored: created_at") {
+		t.Errorf("warnings = %q", warnings)
+	}
+
+	str := func(name string) string { v, _ := cmd.Flags().GetString(name); return v }
+	if str("title") != "Fix login" || str("status") != "in_progress" || str("priority") != "1" || str("type") != "feature" {
+		t.Errorf("title=%q status=%q priority=%q type=%q", str("title"), str("status"), str("priority"), str("type"))
+	}
+	if str("description") != "line one\nline two" || str("metadata") != `{"k": "v"}` {
+		t.Errorf("description=%q metadata=%q", str("description"), str("metadata"))
+	}
+	if est, _ := cmd.Flags().GetInt("estimate"); est != 45 {
+		t.Errorf("estimate = %d", est)
+	}
+	if labels, _ := cmd.Flags().GetStringSlice("set-labels"); strings.Join(labels, ",") != "auth,ui" || !cmd.Flags().Changed("set-labels") {
+		t.Errorf("set-labels = %v (changed=%v)", labels, cmd.Flags().Changed("set-labels"))
+	}
+	if cmd.Flags().Changed("design") {
+		t.Error("keys absent from the document must not set flags")
+	}
+}
+
+func TestApplyIssueDocValidation(t *testing.T) {
+	cmd := newStdinDocTestCmd()
+	doc := mustReadIssueDoc(t, `{
+		"title": "",
+		"status": "done",
+		"priority": "high",
+		"issue_type": "story",
+		"estimated_minutes": -1,
+		"labels": [],
+		"metadata": "[1]",
+		"notes": 5
+	}`)
+	_, err := applyIssueDoc(cmd, doc, updateDocFields, editDocRules{}, true)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, key := range []string{"title:", "status:", "priority:", "issue_type:", "estimated_minutes:", "labels:", "metadata:", "notes:"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("error missing %s problem:\n%v", key, err)
+		}
+	}
+	for _, name := range []string{"title", "status", "notes"} {
+		if cmd.Flags().Changed(name) {
+			t.Errorf("--%s set although the document was invalid", name)
+		}
+	}
+}
+
+func TestApplyIssueDocConflicts(t *testing.T) {
+	cmd := newStdinDocTestCmd()
+	if err := cmd.Flags().Set("title", "from flag"); err != nil {
+		t.Fatal(err)
+	}
+	doc := mustReadIssueDoc(t, `{"title": "from doc", "type": "bug", "issue_type": "task"}`)
+	_, err := applyIssueDoc(cmd, doc, updateDocFields, editDocRules{}, true)
+	if err == nil || !strings.Contains(err.Error(), "also given as --title") || !strings.Contains(err.Error(), "conflicts with") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestApplyIssueDocNulls(t *testing.T) {
+	doc := mustReadIssueDoc(t, `{"title": "x", "assignee": null, "estimated_minutes": null}`)
+
+	// Creating: nulls are skipped
+	cmd := newStdinDocTestCmd()
+	if _, err := applyIssueDoc(cmd, doc, createDocFields, editDocRules{creating: true}, false); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if cmd.Flags().Changed("assignee") || cmd.Flags().Changed("estimate") {
+		t.Error("null keys should be skipped on create")
+	}
+
+	// Updating: null clears, but only clearable fields
+	cmd = newStdinDocTestCmd()
+	_, err := applyIssueDoc(cmd, doc, updateDocFields, editDocRules{}, true)
+	if err == nil || !strings.Contains(err.Error(), "estimated_minutes: cannot be cleared") {
+		t.Errorf("err = %v", err)
+	}
+
+	cmd = newStdinDocTestCmd()
+	doc = mustReadIssueDoc(t, `{"assignee": null}`)
+	if _, err := applyIssueDoc(cmd, doc, updateDocFields, editDocRules{}, true); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if v, _ := cmd.Flags().GetString("assignee"); !cmd.Flags().Changed("assignee") || v != "" {
+		t.Errorf("assignee = %q (changed=%v), want cleared", v, cmd.Flags().Changed("assignee"))
+	}
+}
+
+func TestSplitStdinDocArg(t *testing.T) {
+	rest, ok := splitStdinDocArg([]string{"bd-1", "-"})
+	if !ok || len(rest) != 1 || rest[0] != "bd-1" {
+		t.Errorf("got %v, %v", rest, ok)
+	}
+	if _, ok := splitStdinDocArg([]string{"bd-1"}); ok {
+		t.Error("no stdin arg expected")
+	}
+}
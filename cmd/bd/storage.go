@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/cmd/bd/doctor"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// lastGCFile records when garbage collection last ran (RFC3339), so the
+// automatic GC after write commands can honor storage.auto-gc-interval.
+const lastGCFile = "last-gc"
+
+var storageCmd = &cobra.Command{
+	Use:     "storage",
+	GroupID: "maint",
+	Short:   "Inspect and maintain on-disk storage",
+	Long: `Inspect and maintain the on-disk Dolt storage behind the beads database.
+
+Dolt appends new chunk table files on every write session and keeps
+unreachable chunks until garbage collection runs. Over time this grows the
+.beads/dolt directory and spreads reads across many files.
+
+Commands:
+  bd storage stats    Show disk usage of the Dolt data directory
+  bd storage gc       Run Dolt garbage collection and report space reclaimed
+
+Automatic GC:
+  Set storage.auto-gc-interval (e.g. "24h") to run a shallow GC after a
+  write command once the interval has elapsed since the last GC:
+    bd config set storage.auto-gc-interval 24h
+
+See also: bd doctor --check storage`,
+}
+
+var storageGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Run Dolt garbage collection and report space reclaimed",
+	Long: `Run Dolt garbage collection (dolt_gc) through the connected server.
+
+A full GC removes chunks that are no longer reachable from any branch, tag,
+or working set and rewrites the rest into a compact set of table files.
+A shallow GC (--shallow) only consolidates table files; it is faster and
+safe to run often, but reclaims less space.
+
+Unlike 'bd compact --dolt', this works while the sql-server is running.
+
+Examples:
+  bd storage gc
+  bd storage gc --shallow
+  bd storage gc --json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		shallow, _ := cmd.Flags().GetBool("shallow")
+
+		if store == nil {
+			FatalErrorRespectJSON("no database connection available (run 'bd init' first)")
+		}
+		if err := ensureStoreActive(); err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if !jsonOutput {
+			fmt.Println("Running Dolt garbage collection...")
+		}
+
+		result, err := store.GC(rootCtx, shallow)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		recordGCRun()
+
+		if jsonOutput {
+			outputJSON(result)
+			return
+		}
+
+		fmt.Printf("%s Dolt garbage collection complete", ui.RenderPass("✓"))
+		if shallow {
+			fmt.Print(" (shallow)")
+		}
+		fmt.Println()
+		if result.Before != nil && result.After != nil {
+			reclaimed := result.Reclaimed
+			if reclaimed < 0 {
+				reclaimed = 0 // GC may not always reduce size
+			}
+			fmt.Printf("  %s → %s (reclaimed %s)\n",
+				formatBytes(result.Before.TotalBytes), formatBytes(result.After.TotalBytes), formatBytes(reclaimed))
+			fmt.Printf("  Table files: %d → %d\n", result.Before.TableFiles, result.After.TableFiles)
+		} else {
+			fmt.Println("  Space reclaimed: unknown (no local data directory)")
+		}
+		fmt.Printf("  Time: %v\n", result.Duration.Round(time.Millisecond))
+	},
+}
+
+var storageStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show disk usage of the Dolt data directory",
+	Run: func(cmd *cobra.Command, args []string) {
+		beadsDir := beads.FindBeadsDir()
+		if beadsDir == "" {
+			FatalErrorRespectJSON("no .beads directory found")
+		}
+
+		doltDir := filepath.Join(beadsDir, "dolt")
+		stats, err := dolt.CollectStorageStats(doltDir)
+		if err != nil {
+			FatalErrorRespectJSON("cannot read Dolt data directory %s: %v", doltDir, err)
+		}
+		lastGC := readLastGC(beadsDir)
+
+		if jsonOutput {
+			result := map[string]interface{}{
+				"stats": stats,
+			}
+			if !lastGC.IsZero() {
+				result["last_gc"] = lastGC
+			}
+			outputJSON(result)
+			return
+		}
+
+		fmt.Printf("Dolt data directory: %s\n\n", stats.Path)
+		fmt.Printf("  Total:        %s\n", formatBytes(stats.TotalBytes))
+		fmt.Printf("  Live chunks:  %s (%d table files)\n", formatBytes(stats.NomsBytes), stats.TableFiles)
+		fmt.Printf("  Journal:      %s\n", formatBytes(stats.JournalSize))
+		fmt.Printf("  Old gen:      %s\n", formatBytes(stats.OldgenBytes))
+		if lastGC.IsZero() {
+			fmt.Printf("  Last GC:      never (by bd)\n")
+		} else {
+			fmt.Printf("  Last GC:      %s (%s ago)\n", lastGC.Local().Format("2006-01-02 15:04"),
+				time.Since(lastGC).Round(time.Minute))
+		}
+	},
+}
+
+// runStorageCheck runs the storage health check on its own
+// (bd doctor --check storage).
+func runStorageCheck(path string) {
+	check := convertDoctorCheck(doctor.CheckStorageHealth(path))
+
+	if jsonOutput {
+		outputJSON(check)
+		return
+	}
+
+	statusIcon := ui.RenderPassIcon()
+	if check.Status == statusWarning {
+		statusIcon = ui.RenderWarnIcon()
+	}
+	fmt.Println()
+	fmt.Printf("  %s  %s%s\n", statusIcon, check.Name, ui.RenderMuted(" "+check.Message))
+	if check.Detail != "" {
+		fmt.Printf("     %s%s\n", ui.MutedStyle.Render(ui.TreeLast), ui.RenderMuted(check.Detail))
+	}
+	if check.Fix != "" {
+		fmt.Printf("\n%s\n", check.Fix)
+	}
+}
+
+// readLastGC returns when bd last ran garbage collection, or the zero time.
+func readLastGC(beadsDir string) time.Time {
+	data, err := os.ReadFile(filepath.Join(beadsDir, lastGCFile)) // #nosec G304 -- path constructed from beadsDir
+	if err != nil {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// recordGCRun stamps the current time as the last GC run.
+// Best effort: a missing stamp only means the next auto-GC runs early.
+func recordGCRun() {
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(beadsDir, lastGCFile), []byte(time.Now().UTC().Format(time.RFC3339)+"\n"), 0600)
+}
+
+// maybeAutoGC runs a shallow GC after a write command when
+// storage.auto-gc-interval is set and has elapsed since the last GC.
+// Failures are reported as warnings; they never fail the command.
+func maybeAutoGC(ctx context.Context) {
+	interval := config.GetDuration("storage.auto-gc-interval")
+	if interval <= 0 || store == nil {
+		return
+	}
+	beadsDir := beads.FindBeadsDir()
+	if beadsDir == "" {
+		return
+	}
+	if last := readLastGC(beadsDir); !last.IsZero() && time.Since(last) < interval {
+		return
+	}
+
+	// Stamp first so concurrent commands don't all start a GC.
+	recordGCRun()
+	if _, err := store.GC(ctx, true); errors.Is(err, permissions.ErrDenied) {
+		debug.Logf("skipping automatic storage gc: %v", err) // Left to an admin actor
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: automatic storage gc failed: %v\n", err)
+	}
+}
+
+func init() {
+	storageGCCmd.Flags().Bool("shallow", false, "Only consolidate table files (faster, reclaims less)")
+
+	storageCmd.AddCommand(storageGCCmd)
+	storageCmd.AddCommand(storageStatsCmd)
+	rootCmd.AddCommand(storageCmd)
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/summarize"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var summarizeCmd = &cobra.Command{
+	Use:     "summarize <id> [<id>...]",
+	GroupID: "issues",
+	Short:   "Generate a concise LLM summary of an issue",
+	Long: `Generate a short summary of an issue's description, design, notes, and
+comment thread with an LLM, and store it in the issue's metadata ("summary").
+'bd show' displays it above the description.
+
+Summaries are cached by a hash of the text they were generated from: running
+summarize again is free until the issue or its comments change (or --force).
+
+The LLM endpoint is configured in config.yaml:
+
+  summarize.provider     anthropic (default) or openai (any OpenAI-compatible
+                         chat API, including Ollama and other local servers)
+  summarize.model        Model name (default: ai.model for anthropic)
+  summarize.url          Base URL, e.g. http://localhost:11434/v1
+  summarize.api-key-env  Env var holding the API key (default
+                         ANTHROPIC_API_KEY or OPENAI_API_KEY)
+
+Examples:
+  bd summarize bd-42              # Summarize (or reuse the current summary)
+  bd summarize bd-42 --force      # Regenerate even if nothing changed
+  bd summarize bd-42 --dry-run    # Print a summary without saving it
+  bd epic summarize bd-40         # Summarize an epic from its children`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runSummarize(cmd, args, false)
+	},
+}
+
+var epicSummarizeCmd = &cobra.Command{
+	Use:   "summarize <epic-id> [<epic-id>...]",
+	Short: "Generate a concise LLM summary of an epic and its children",
+	Long: `Summarize an epic from its own text, its comments, and its children's
+status. Children that already have a summary (see 'bd summarize') contribute
+it; the rest contribute the start of their description. The summary is
+regenerated only when any of that changes (or with --force).`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runSummarize(cmd, args, true)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{summarizeCmd, epicSummarizeCmd} {
+		c.Flags().Bool("force", false, "Regenerate even if the cached summary is current")
+		c.Flags().Bool("dry-run", false, "Print the summary without saving it")
+		c.Flags().String("model", "", "Model to use (default from summarize.model)")
+	}
+	rootCmd.AddCommand(summarizeCmd)
+	epicCmd.AddCommand(epicSummarizeCmd)
+}
+
+// summaryResult is the --json output of bd summarize.
+type summaryResult struct {
+	IssueID     string    `json:"issue_id"`
+	Summary     string    `json:"summary"`
+	Model       string    `json:"model"`
+	SourceHash  string    `json:"source_hash"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Cached      bool      `json:"cached"` // The stored summary was current, so no LLM call was made
+	Saved       bool      `json:"saved"`
+}
+
+func runSummarize(cmd *cobra.Command, args []string, epic bool) {
+	force, _ := cmd.Flags().GetBool("force")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	model, _ := cmd.Flags().GetString("model")
+	if !dryRun {
+		if epic {
+			CheckReadonly("epic summarize")
+		} else {
+			CheckReadonly("summarize")
+		}
+	}
+
+	ctx := rootCtx
+	var client summarize.Client // Created on first cache miss, so cached runs need no API key
+	var results []summaryResult
+	for _, id := range args {
+		fullID, err := utils.ResolvePartialID(ctx, store, id)
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", id, err)
+		}
+		issue, err := store.GetIssue(ctx, fullID)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		prompt, err := summaryPrompt(ctx, issue, epic)
+		if err != nil {
+			FatalErrorRespectJSON("%s: %v", fullID, err)
+		}
+
+		hash := summarize.SourceHash(prompt)
+		if existing := summarize.FromMetadata(issue.Metadata); existing != nil && existing.SourceHash == hash && !force {
+			results = append(results, summaryResult{
+				IssueID: fullID, Summary: existing.Text, Model: existing.Model,
+				SourceHash: hash, GeneratedAt: existing.GeneratedAt, Cached: true,
+			})
+			continue
+		}
+
+		if client == nil {
+			if client, err = newSummarizer(model); err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+		}
+		text, err := client.Complete(ctx, prompt)
+		if err != nil {
+			FatalErrorRespectJSON("%s: %v", fullID, err)
+		}
+		s := &summarize.Summary{Text: text, SourceHash: hash, Model: client.Model(), GeneratedAt: time.Now().UTC()}
+		result := summaryResult{IssueID: fullID, Summary: s.Text, Model: s.Model, SourceHash: hash, GeneratedAt: s.GeneratedAt}
+		if !dryRun {
+			md, err := summarize.WithSummary(issue.Metadata, s)
+			if err != nil {
+				FatalErrorRespectJSON("%s: %v", fullID, err)
+			}
+			if err := store.UpdateIssue(ctx, fullID, map[string]interface{}{"metadata": string(md)}, getActorWithGit()); err != nil {
+				FatalErrorRespectJSON("saving summary for %s: %v", fullID, err)
+			}
+			result.Saved = true
+			SetLastTouchedID(fullID)
+		}
+		results = append(results, result)
+	}
+
+	if jsonOutput {
+		if len(results) == 1 {
+			outputJSON(results[0])
+		} else {
+			outputJSON(results)
+		}
+		return
+	}
+	for _, r := range results {
+		switch {
+		case r.Cached:
+			fmt.Printf("%s %s summary is current %s\n", ui.RenderMuted("·"), ui.RenderID(r.IssueID), ui.RenderMuted("(use --force to regenerate)"))
+		case r.Saved:
+			fmt.Printf("%s Summarized %s %s\n", ui.RenderPass("✓"), ui.RenderID(r.IssueID), ui.RenderMuted("("+r.Model+")"))
+		default:
+			fmt.Printf("%s %s %s\n", ui.RenderAccent("Summary of"), ui.RenderID(r.IssueID), ui.RenderMuted("(not saved)"))
+		}
+		fmt.Printf("\n%s\n\n", indentLines(r.Summary, "  "))
+	}
+}
+
+// summaryPrompt builds the summarizer prompt for an issue, or for an epic
+// together with its children.
+func summaryPrompt(ctx context.Context, issue *types.Issue, epic bool) (string, error) {
+	comments, err := store.GetIssueComments(ctx, issue.ID)
+	if err != nil {
+		return "", fmt.Errorf("getting comments: %w", err)
+	}
+	if !epic {
+		return summarize.IssuePrompt(issue, comments), nil
+	}
+	children, err := store.SearchIssues(ctx, "", types.IssueFilter{ParentID: &issue.ID})
+	if err != nil {
+		return "", fmt.Errorf("getting children: %w", err)
+	}
+	if len(children) == 0 && issue.IssueType != types.TypeEpic {
+		return "", fmt.Errorf("not an epic and has no children; use 'bd summarize %s'", issue.ID)
+	}
+	return summarize.EpicPrompt(issue, children, comments), nil
+}
+
+// newSummarizer returns the summarizer configured under summarize.* in
+// config.yaml; model overrides summarize.model.
+func newSummarizer(model string) (summarize.Client, error) {
+	provider := config.GetString("summarize.provider")
+	if model == "" {
+		model = config.GetString("summarize.model")
+	}
+	keyEnv := config.GetString("summarize.api-key-env")
+	if provider == "" || provider == summarize.ProviderAnthropic {
+		if model == "" {
+			model = config.DefaultAIModel()
+		}
+		if keyEnv == "" {
+			keyEnv = "ANTHROPIC_API_KEY"
+		}
+	} else if keyEnv == "" {
+		keyEnv = "OPENAI_API_KEY"
+	}
+	return summarize.New(summarize.Config{
+		Provider: provider,
+		Model:    model,
+		URL:      config.GetString("summarize.url"),
+		APIKey:   os.Getenv(keyEnv),
+	})
+}
+
+// indentLines prefixes every line of s with indent.
+func indentLines(s, indent string) string {
+	return indent + strings.ReplaceAll(s, "\n", "\n"+indent)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+func TestNewSummarizerDefaults(t *testing.T) {
+	initConfigForTest(t)
+
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	if _, err := newSummarizer(""); err == nil {
+		t.Error("the default anthropic provider should require ANTHROPIC_API_KEY")
+	}
+	t.Setenv("ANTHROPIC_API_KEY", "sk-test")
+	c, err := newSummarizer("")
+	if err != nil || c.Model() != "anthropic/"+config.DefaultAIModel() {
+		t.Errorf("default summarizer = %v, %v", c, err)
+	}
+
+	// OpenAI-compatible local servers need no key
+	config.Set("summarize.provider", "openai")
+	config.Set("summarize.model", "llama3")
+	if c, err := newSummarizer(""); err != nil || c.Model() != "openai/llama3" {
+		t.Errorf("openai summarizer = %v, %v", c, err)
+	}
+	if c, _ := newSummarizer("qwen"); c.Model() != "openai/qwen" {
+		t.Errorf("--model should override summarize.model, got %s", c.Model())
+	}
+}
+
+func TestIndentLines(t *testing.T) {
+	if got := indentLines("a\nb", "  "); got != "  a\n  b" {
+		t.Errorf("indentLines = %q", got)
+	}
+}
@@ -15,7 +15,12 @@ var syncCmd = &cobra.Command{
 
 Use Dolt remote commands directly:
   bd dolt push     Push to Dolt remote
-  bd dolt pull     Pull from Dolt remote`,
+  bd dolt pull     Pull from Dolt remote
+
+Its subcommands manage syncs with external trackers (Linear, Jira, GitLab):
+  bd sync run        Run the tracker syncs that are due on their schedules
+  bd sync status     Show the schedule and health of each tracker sync
+  bd sync conflicts  Review and resolve conflicts from tracker syncs`,
 	Run: func(_ *cobra.Command, _ []string) {
 		fmt.Println("bd sync is deprecated. Use 'bd dolt push' and 'bd dolt pull' instead.")
 	},
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/textdiff"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var syncConflictsCmd = &cobra.Command{
+	Use:   "conflicts",
+	Short: "Review and resolve conflicts from tracker syncs (Linear, Jira, GitLab)",
+	Long: `When a Linear, Jira or GitLab sync finds an issue changed both in beads and
+in the tracker since the last sync, it queues the conflict instead of letting
+one side overwrite the other (unless a --prefer-* flag picks a side). The
+issue is left alone on both sides until the conflict is resolved:
+
+  --strategy local     (or ours)   Keep the beads version; the next sync
+                                   pushes it to the tracker
+  --strategy external  (or theirs) Take the tracker's version into beads now
+
+To merge the two, edit the issue in beads and keep the local version.
+
+Examples:
+  bd sync conflicts list                            # Side-by-side diffs
+  bd sync conflicts list --tracker jira --all       # Include resolved ones
+  bd sync conflicts resolve 3 --strategy local
+  bd sync conflicts resolve bd-a1b2 --strategy theirs
+  bd sync conflicts resolve --all --strategy external`,
+}
+
+var syncConflictsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List queued sync conflicts with a side-by-side diff",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		tracker, _ := cmd.Flags().GetString("tracker")
+		all, _ := cmd.Flags().GetBool("all")
+		ctx := rootCtx
+
+		conflicts, err := store.ListSyncConflicts(ctx, tracker, all)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if jsonOutput {
+			if conflicts == nil {
+				conflicts = []*types.SyncConflict{}
+			}
+			outputJSON(conflicts)
+			return
+		}
+		if len(conflicts) == 0 {
+			fmt.Println("No sync conflicts")
+			return
+		}
+		for _, c := range conflicts {
+			printSyncConflict(ctx, c)
+		}
+		fmt.Printf("Resolve with: bd sync conflicts resolve <id> --strategy local|external\n\n")
+	},
+}
+
+var syncConflictsResolveCmd = &cobra.Command{
+	Use:   "resolve [<conflict-id>|<issue-id>]... --strategy local|external",
+	Short: "Resolve sync conflicts by keeping one side",
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("sync conflicts resolve")
+		strategy, _ := cmd.Flags().GetString("strategy")
+		all, _ := cmd.Flags().GetBool("all")
+		ctx := rootCtx
+
+		resolution, err := parseSyncStrategy(strategy)
+		if err != nil {
+			FatalErrorCode(exitValidation, "%v", err)
+		}
+		if all == (len(args) > 0) {
+			FatalErrorCode(exitValidation, "name the conflicts to resolve, or use --all")
+		}
+
+		open, err := store.ListSyncConflicts(ctx, "", false)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		var targets []*types.SyncConflict
+		for _, c := range open {
+			if c.Resolution == "" && all {
+				targets = append(targets, c)
+			}
+		}
+		for _, arg := range args {
+			found, err := findSyncConflicts(ctx, open, arg)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			targets = append(targets, found...)
+		}
+
+		resolved := []*types.SyncConflict{}
+		for _, c := range targets {
+			if err := store.ResolveSyncConflict(ctx, c.ID, resolution, actor); err != nil {
+				FatalErrorRespectJSON("resolving conflict %d: %v", c.ID, err)
+			}
+			if updated, err := store.GetSyncConflict(ctx, c.ID); err == nil {
+				c = updated
+			}
+			resolved = append(resolved, c)
+		}
+		if jsonOutput {
+			outputJSON(resolved)
+			return
+		}
+		if len(resolved) == 0 {
+			fmt.Println("No open sync conflicts")
+			return
+		}
+		for _, c := range resolved {
+			if resolution == types.SyncKeepLocal {
+				fmt.Printf("%s #%d %s: keeping local version, the next %s sync pushes it\n",
+					ui.RenderPass("✓"), c.ID, c.IssueID, c.Tracker)
+			} else {
+				fmt.Printf("%s #%d %s: took the %s version\n", ui.RenderPass("✓"), c.ID, c.IssueID, c.Tracker)
+			}
+		}
+	},
+}
+
+// parseSyncStrategy maps --strategy to a resolution, accepting ours and
+// theirs as in federation sync.
+func parseSyncStrategy(strategy string) (string, error) {
+	switch strings.ToLower(strategy) {
+	case "local", "ours":
+		return types.SyncKeepLocal, nil
+	case "external", "theirs":
+		return types.SyncKeepExternal, nil
+	case "":
+		return "", fmt.Errorf("--strategy is required: local (ours) or external (theirs)")
+	default:
+		return "", fmt.Errorf("unknown strategy %q: use local (ours) or external (theirs)", strategy)
+	}
+}
+
+// findSyncConflicts returns the open conflicts arg names: a conflict ID, or
+// an issue whose open conflicts are meant.
+func findSyncConflicts(ctx context.Context, open []*types.SyncConflict, arg string) ([]*types.SyncConflict, error) {
+	if id, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64); err == nil {
+		for _, c := range open {
+			if c.ID == id && c.Resolution == "" {
+				return []*types.SyncConflict{c}, nil
+			}
+		}
+		return nil, fmt.Errorf("no open sync conflict #%d", id)
+	}
+	issueID, err := utils.ResolvePartialID(ctx, store, arg)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", arg, err)
+	}
+	var found []*types.SyncConflict
+	for _, c := range open {
+		if c.IssueID == issueID && c.Resolution == "" {
+			found = append(found, c)
+		}
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("%s has no open sync conflicts", issueID)
+	}
+	return found, nil
+}
+
+// printSyncConflict shows a conflict's fields side by side: the issue as it
+// is in beads now, which keeping the local version pushes, and the
+// tracker's version as last fetched.
+func printSyncConflict(ctx context.Context, c *types.SyncConflict) {
+	local := c.Local
+	if issue, err := store.GetIssue(ctx, c.IssueID); err == nil {
+		local = types.SyncedFields{Title: issue.Title, Description: issue.Description, Priority: issue.Priority, Status: issue.Status}
+	}
+
+	fmt.Printf("\n%s #%d %s ↔ %s %s  %s\n", ui.RenderWarn("⚠"), c.ID, ui.RenderAccent(c.IssueID),
+		c.Tracker, c.ExternalID, ui.RenderMuted("detected "+c.DetectedAt.Local().Format("2006-01-02 15:04")))
+	switch {
+	case c.PendingPush:
+		fmt.Printf("  %s\n", ui.RenderMuted("resolved: keeping local version, pushed on the next sync"))
+	case c.Resolution != "":
+		fmt.Printf("  %s\n", ui.RenderMuted(fmt.Sprintf("resolved: kept %s version (%s)", c.Resolution, c.ResolvedBy)))
+	}
+
+	localLabel := fmt.Sprintf("local (%s)", c.LocalUpdated.Local().Format("01-02 15:04"))
+	externalLabel := fmt.Sprintf("%s (%s)", c.Tracker, c.ExternalUpdated.Local().Format("01-02 15:04"))
+	rows := [][3]string{
+		{"title", local.Title, c.External.Title},
+		{"status", string(local.Status), string(c.External.Status)},
+		{"priority", fmt.Sprintf("P%d", local.Priority), fmt.Sprintf("P%d", c.External.Priority)},
+	}
+	width := len(localLabel)
+	for _, r := range rows {
+		width = max(width, min(ui.DisplayWidth(r[1]), 40))
+	}
+	fmt.Printf("  %-10s %s  %s\n", "", ui.RenderBold(ui.PadRight(localLabel, width)), ui.RenderBold(externalLabel))
+	for _, r := range rows {
+		left, right := ui.Truncate(r[1], 40), ui.Truncate(r[2], 40)
+		if r[1] != r[2] {
+			right = ui.RenderWarn(right)
+		}
+		fmt.Printf("  %-10s %s  %s\n", r[0], ui.PadRight(left, width), right)
+	}
+
+	if diff := textdiff.Unified("description (local)", "description ("+c.Tracker+")",
+		local.Description, c.External.Description, 3); diff != "" {
+		fmt.Println()
+		for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+			fmt.Println("  " + colorDiffLine(line))
+		}
+	}
+	fmt.Println()
+}
+
+func init() {
+	syncConflictsListCmd.Flags().String("tracker", "", "Only conflicts with this tracker (linear, jira, gitlab)")
+	syncConflictsListCmd.Flags().Bool("all", false, "Include resolved conflicts")
+	syncConflictsResolveCmd.Flags().String("strategy", "", "Side to keep: local (ours) or external (theirs)")
+	syncConflictsResolveCmd.Flags().Bool("all", false, "Resolve every open conflict")
+	syncConflictsCmd.AddCommand(syncConflictsListCmd, syncConflictsResolveCmd)
+	syncCmd.AddCommand(syncConflictsCmd)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseSyncStrategy(t *testing.T) {
+	for strategy, want := range map[string]string{
+		"local":    types.SyncKeepLocal,
+		"ours":     types.SyncKeepLocal,
+		"External": types.SyncKeepExternal,
+		"theirs":   types.SyncKeepExternal,
+	} {
+		got, err := parseSyncStrategy(strategy)
+		if err != nil || got != want {
+			t.Errorf("parseSyncStrategy(%q) = %q, %v; want %q", strategy, got, err, want)
+		}
+	}
+	for _, strategy := range []string{"", "newer", "mine"} {
+		if got, err := parseSyncStrategy(strategy); err == nil {
+			t.Errorf("parseSyncStrategy(%q) = %q, want an error", strategy, got)
+		}
+	}
+}
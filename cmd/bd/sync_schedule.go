@@ -0,0 +1,439 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/gitlab"
+	"github.com/steveyegge/beads/internal/jira"
+	"github.com/steveyegge/beads/internal/linear"
+	"github.com/steveyegge/beads/internal/tracker"
+	"github.com/steveyegge/beads/internal/ui"
+)
+
+// integrationTrackers are the trackers bd sync run and bd sync status cover.
+var integrationTrackers = []string{"linear", "jira", "gitlab"}
+
+// maxSyncWait bounds how long bd sync run --watch sleeps, so schedules
+// changed with bd config set take effect without a restart.
+const maxSyncWait = 5 * time.Minute
+
+var syncRunCmd = &cobra.Command{
+	Use:   "run [linear|jira|gitlab]...",
+	Short: "Run the tracker syncs that are due on their schedules",
+	Long: `Run the Linear, Jira and GitLab syncs that are due. A tracker is synced on a
+schedule once it has an interval:
+
+  bd config set linear.sync_interval 15m
+
+Each run pulls and pushes like 'bd <tracker> sync', queueing conflicts for
+'bd sync conflicts'. It resumes from the tracker's last_sync cursor, so only
+issues changed since the last successful run are fetched.
+
+A failed run is retried sooner than the interval, backing off from a minute
+to an hour with random jitter. When a tracker rate-limits the sync, the
+retry waits at least as long as its Retry-After asks. The schedule lives in
+the database, so every machine sharing it sees the same one.
+
+Run it from cron, or keep it running with --watch, which wakes whenever the
+next sync is due. Name trackers to limit the run to them.
+
+Examples:
+  bd sync run                  # Whatever is due, then exit (for cron)
+  bd sync run --watch          # Keep syncing on schedule
+  bd sync run linear --force   # Sync Linear now, due or not`,
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("sync run")
+		watch, _ := cmd.Flags().GetBool("watch")
+		force, _ := cmd.Flags().GetBool("force")
+		ctx := rootCtx
+
+		for _, name := range args {
+			if !slices.Contains(integrationTrackers, name) {
+				FatalErrorCode(exitValidation, "unknown tracker %q: use %s", name, strings.Join(integrationTrackers, ", "))
+			}
+		}
+		names := integrationTrackers
+		if len(args) > 0 {
+			names = args
+		}
+
+		for {
+			runs := runScheduledSyncs(ctx, names, force)
+			failed := printScheduledSyncs(runs, watch)
+			if !watch {
+				if failed {
+					os.Exit(1)
+				}
+				return
+			}
+			force = false
+
+			timer := time.NewTimer(nextSyncWait(runs, time.Now()))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	},
+}
+
+var syncStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the schedule and health of each tracker sync",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		statuses := make([]*integrationStatus, 0, len(integrationTrackers))
+		for _, name := range integrationTrackers {
+			st, err := getIntegrationStatus(ctx, name)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			statuses = append(statuses, st)
+		}
+		if jsonOutput {
+			outputJSON(statuses)
+			return
+		}
+		now := time.Now()
+		for _, st := range statuses {
+			printIntegrationStatus(st, now)
+		}
+	},
+}
+
+// scheduledSync is the outcome of one tracker in a bd sync run pass.
+type scheduledSync struct {
+	Tracker string              `json:"tracker"`
+	Ran     bool                `json:"ran"`
+	Result  *tracker.SyncResult `json:"result,omitempty"`
+	Error   string              `json:"error,omitempty"`
+	NextAt  *time.Time          `json:"next_at,omitempty"` // Unset for unscheduled trackers
+}
+
+// integrationStatus is one tracker's row in bd sync status.
+type integrationStatus struct {
+	Tracker       string             `json:"tracker"`
+	Configured    bool               `json:"configured"`
+	Interval      string             `json:"interval,omitempty"`
+	Cursor        string             `json:"cursor,omitempty"`       // <tracker>.last_sync
+	LastWebhook   string             `json:"last_webhook,omitempty"` // Applied by bd serve
+	State         *tracker.SyncState `json:"state"`
+	OpenConflicts int                `json:"open_conflicts"`
+	Problem       string             `json:"problem,omitempty"`
+}
+
+// runScheduledSyncs syncs each named tracker that is scheduled and due, or
+// with force, each one regardless.
+func runScheduledSyncs(ctx context.Context, names []string, force bool) []*scheduledSync {
+	var runs []*scheduledSync
+	for _, name := range names {
+		run := &scheduledSync{Tracker: name}
+		runs = append(runs, run)
+
+		interval, err := syncInterval(ctx, name)
+		if err != nil {
+			run.Error = err.Error()
+			continue
+		}
+		if interval == 0 && !force {
+			continue
+		}
+		state, err := tracker.LoadSyncState(ctx, store, name)
+		if err != nil {
+			run.Error = err.Error()
+			continue
+		}
+		now := time.Now().UTC()
+		if !force && !state.Due(now) {
+			run.NextAt = &state.NextAt
+			continue
+		}
+
+		run.Ran = true
+		run.Result, err = syncIntegration(ctx, name)
+		if err != nil {
+			run.Error = err.Error()
+		}
+		state.Record(now, interval, run.Result, err, rand.Float64()) // #nosec G404 -- jitter, not security
+		if err := tracker.SaveSyncState(ctx, store, name, state); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		if interval > 0 || err != nil {
+			run.NextAt = &state.NextAt
+		}
+	}
+	return runs
+}
+
+// syncIntegration runs one unattended two-way sync with the named tracker.
+func syncIntegration(ctx context.Context, name string) (*tracker.SyncResult, error) {
+	engine, err := newIntegrationEngine(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	engine.OnWarning = func(msg string) { fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", name, msg) }
+	return engine.Sync(ctx, tracker.SyncOptions{
+		Pull:               true,
+		Push:               true,
+		State:              "all",
+		ConflictResolution: tracker.ConflictQueue,
+		ExcludeEphemeral:   true,
+	})
+}
+
+// newIntegrationEngine builds a sync engine for the named tracker with the
+// same hooks as its own sync command.
+func newIntegrationEngine(ctx context.Context, name string) (*tracker.Engine, error) {
+	switch name {
+	case "linear":
+		if err := validateLinearConfig(); err != nil {
+			return nil, err
+		}
+		lt := &linear.Tracker{}
+		if err := lt.Init(ctx, store); err != nil {
+			return nil, fmt.Errorf("initializing Linear tracker: %w", err)
+		}
+		engine := tracker.NewEngine(lt, store, actor)
+		engine.PullHooks = buildLinearPullHooks(ctx)
+		engine.PushHooks = buildLinearPushHooks(ctx, lt)
+		return engine, nil
+	case "jira":
+		if err := validateJiraConfig(); err != nil {
+			return nil, err
+		}
+		jt := &jira.Tracker{}
+		if err := jt.Init(ctx, store); err != nil {
+			return nil, fmt.Errorf("initializing Jira tracker: %w", err)
+		}
+		engine := tracker.NewEngine(jt, store, actor)
+		engine.PushHooks = buildJiraPushHooks(ctx)
+		return engine, nil
+	case "gitlab":
+		if err := validateGitLabConfig(getGitLabConfig()); err != nil {
+			return nil, err
+		}
+		gt := &gitlab.Tracker{}
+		if err := gt.Init(ctx, store); err != nil {
+			return nil, fmt.Errorf("initializing GitLab tracker: %w", err)
+		}
+		engine := tracker.NewEngine(gt, store, actor)
+		engine.PullHooks = buildGitLabPullHooks(ctx)
+		return engine, nil
+	default:
+		return nil, fmt.Errorf("unknown tracker %q", name)
+	}
+}
+
+// syncInterval returns the <tracker>.sync_interval schedule, or zero if the
+// tracker is not synced on a schedule.
+func syncInterval(ctx context.Context, name string) (time.Duration, error) {
+	raw, err := store.GetConfig(ctx, name+".sync_interval")
+	if err != nil {
+		return 0, fmt.Errorf("reading %s.sync_interval: %w", name, err)
+	}
+	if raw == "" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(raw)
+	if err != nil || interval < time.Minute {
+		return 0, fmt.Errorf("invalid %s.sync_interval %q: use a duration of at least 1m, e.g. 15m", name, raw)
+	}
+	return interval, nil
+}
+
+// nextSyncWait returns how long bd sync run --watch sleeps before its next
+// pass: until the earliest scheduled sync, at most maxSyncWait.
+func nextSyncWait(runs []*scheduledSync, now time.Time) time.Duration {
+	wait := maxSyncWait
+	for _, run := range runs {
+		if run.NextAt != nil {
+			wait = min(wait, run.NextAt.Sub(now))
+		}
+	}
+	return max(wait, time.Second)
+}
+
+// printScheduledSyncs reports a bd sync run pass and whether any sync
+// failed. In watch mode only the syncs that ran are shown.
+func printScheduledSyncs(runs []*scheduledSync, watch bool) bool {
+	failed := false
+	for _, run := range runs {
+		if run.Error != "" {
+			failed = true
+		}
+	}
+	if jsonOutput {
+		outputJSON(runs)
+		return failed
+	}
+
+	now := time.Now()
+	ran := 0
+	for _, run := range runs {
+		switch {
+		case run.Error != "":
+			ran++
+			line := fmt.Sprintf("%s %s: %s", ui.RenderFail("✗"), run.Tracker, run.Error)
+			if run.NextAt != nil {
+				line += ui.RenderMuted(" (retrying " + formatUntil(*run.NextAt, now) + ")")
+			}
+			fmt.Println(line)
+		case run.Ran:
+			ran++
+			line := fmt.Sprintf("%s %s: %s", ui.RenderPass("✓"), run.Tracker, syncStatsSummary(run.Result.Stats))
+			if run.NextAt != nil {
+				line += ui.RenderMuted(" (next " + formatUntil(*run.NextAt, now) + ")")
+			}
+			fmt.Println(line)
+		case run.NextAt != nil && !watch:
+			fmt.Printf("  %s: %s\n", run.Tracker, ui.RenderMuted("not due, next "+formatUntil(*run.NextAt, now)))
+		}
+	}
+	if ran == 0 && !watch {
+		fmt.Println("No tracker syncs due ('bd sync status' shows the schedule)")
+	}
+	return failed
+}
+
+// getIntegrationStatus gathers the bd sync status row for a tracker.
+func getIntegrationStatus(ctx context.Context, name string) (*integrationStatus, error) {
+	st := &integrationStatus{Tracker: name}
+	var err error
+	if st.State, err = tracker.LoadSyncState(ctx, store, name); err != nil {
+		return nil, err
+	}
+	if st.Cursor, err = store.GetConfig(ctx, name+".last_sync"); err != nil {
+		return nil, fmt.Errorf("reading %s.last_sync: %w", name, err)
+	}
+	st.LastWebhook, _ = store.GetConfig(ctx, name+".webhook_last")
+	if interval, err := syncInterval(ctx, name); err != nil {
+		st.Problem = err.Error()
+	} else if interval > 0 {
+		st.Interval = shortDuration(interval)
+	}
+
+	switch name {
+	case "linear":
+		st.Configured = validateLinearConfig() == nil
+	case "jira":
+		st.Configured = validateJiraConfig() == nil
+	case "gitlab":
+		st.Configured = validateGitLabConfig(getGitLabConfig()) == nil
+	}
+
+	conflicts, err := store.ListSyncConflicts(ctx, name, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range conflicts {
+		if c.Resolution == "" {
+			st.OpenConflicts++
+		}
+	}
+	return st, nil
+}
+
+func printIntegrationStatus(st *integrationStatus, now time.Time) {
+	state := st.State
+	label := ui.PadRight(st.Tracker, 7)
+	indent := strings.Repeat(" ", 9)
+
+	switch {
+	case st.Problem != "":
+		fmt.Printf("%s %s %s\n", label, ui.RenderFail("✗"), st.Problem)
+	case !st.Configured && st.Cursor == "":
+		fmt.Printf("%s %s\n", label, ui.RenderMuted("- not configured"))
+		return
+	case st.Interval == "":
+		fmt.Printf("%s %s not scheduled %s\n", label, ui.RenderMuted("○"),
+			ui.RenderMuted(fmt.Sprintf("(bd config set %s.sync_interval 15m)", st.Tracker)))
+	case state.Failures > 0:
+		fmt.Printf("%s %s every %s · %d failed in a row · retrying %s\n", label, ui.RenderFail("✗"),
+			st.Interval, state.Failures, formatUntil(state.NextAt, now))
+	default:
+		fmt.Printf("%s %s every %s · next %s\n", label, ui.RenderPass("✓"), st.Interval, formatUntil(state.NextAt, now))
+	}
+
+	if state.LastError != "" {
+		fmt.Printf("%slast error: %s\n", indent, ui.RenderWarn(state.LastError))
+	}
+	switch {
+	case !state.LastSuccess.IsZero() && state.LastStats != nil && state.Failures == 0:
+		fmt.Printf("%slast synced %s: %s\n", indent, formatTimeAgo(state.LastSuccess), syncStatsSummary(*state.LastStats))
+	case !state.LastSuccess.IsZero():
+		fmt.Printf("%slast synced %s\n", indent, formatTimeAgo(state.LastSuccess))
+	}
+	details := []string{}
+	if st.Cursor != "" {
+		details = append(details, "cursor "+st.Cursor)
+	}
+	if t, err := time.Parse(time.RFC3339, st.LastWebhook); err == nil {
+		details = append(details, "last webhook "+formatTimeAgo(t))
+	}
+	if st.OpenConflicts > 0 {
+		details = append(details, ui.RenderWarn(fmt.Sprintf("%d open conflicts", st.OpenConflicts))+
+			ui.RenderMuted(" (bd sync conflicts list)"))
+	}
+	if len(details) > 0 {
+		fmt.Printf("%s%s\n", indent, strings.Join(details, " · "))
+	}
+}
+
+// syncStatsSummary describes what a sync changed, e.g. "pulled 3, pushed 1".
+func syncStatsSummary(stats tracker.SyncStats) string {
+	var parts []string
+	if stats.Pulled > 0 {
+		parts = append(parts, fmt.Sprintf("pulled %d", stats.Pulled))
+	}
+	if stats.Pushed > 0 {
+		parts = append(parts, fmt.Sprintf("pushed %d", stats.Pushed))
+	}
+	if stats.Errors > 0 {
+		parts = append(parts, fmt.Sprintf("%d errors", stats.Errors))
+	}
+	if stats.Queued > 0 {
+		parts = append(parts, fmt.Sprintf("%d conflicts queued", stats.Queued))
+	}
+	if len(parts) == 0 {
+		return "no changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatUntil describes a time relative to now, e.g. "in 15m" or "now".
+func formatUntil(t, now time.Time) string {
+	d := t.Sub(now)
+	switch {
+	case d <= 0:
+		return "now"
+	case d < time.Minute:
+		return "in <1m"
+	default:
+		return "in " + shortDuration(d.Round(time.Minute))
+	}
+}
+
+// shortDuration formats d without trailing zero units, e.g. "15m" or "1h".
+func shortDuration(d time.Duration) string {
+	s := strings.TrimSuffix(d.String(), "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+func init() {
+	syncRunCmd.Flags().Bool("watch", false, "Keep running, syncing each tracker when it is due")
+	syncRunCmd.Flags().Bool("force", false, "Sync now even if not due or not scheduled")
+	syncCmd.AddCommand(syncRunCmd, syncStatusCmd)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/tracker"
+)
+
+func TestNextSyncWait(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	tests := []struct {
+		name string
+		runs []*scheduledSync
+		want time.Duration
+	}{
+		{"nothing scheduled", []*scheduledSync{{Tracker: "jira"}}, maxSyncWait},
+		{"earliest wins", []*scheduledSync{{NextAt: at(3 * time.Minute)}, {NextAt: at(2 * time.Minute)}}, 2 * time.Minute},
+		{"capped", []*scheduledSync{{NextAt: at(time.Hour)}}, maxSyncWait},
+		{"overdue", []*scheduledSync{{NextAt: at(-time.Minute)}}, time.Second},
+	}
+	for _, tt := range tests {
+		if got := nextSyncWait(tt.runs, now); got != tt.want {
+			t.Errorf("%s: nextSyncWait = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSyncStatsSummary(t *testing.T) {
+	tests := []struct {
+		stats tracker.SyncStats
+		want  string
+	}{
+		{tracker.SyncStats{}, "no changes"},
+		{tracker.SyncStats{Pulled: 3, Pushed: 1}, "pulled 3, pushed 1"},
+		{tracker.SyncStats{Pushed: 2, Errors: 1, Queued: 4}, "pushed 2, 1 errors, 4 conflicts queued"},
+	}
+	for _, tt := range tests {
+		if got := syncStatsSummary(tt.stats); got != tt.want {
+			t.Errorf("syncStatsSummary(%+v) = %q, want %q", tt.stats, got, tt.want)
+		}
+	}
+}
+
+func TestFormatUntil(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for d, want := range map[time.Duration]string{
+		-time.Minute:     "now",
+		30 * time.Second: "in <1m",
+		15 * time.Minute: "in 15m",
+		90 * time.Minute: "in 1h30m",
+		2 * time.Hour:    "in 2h",
+	} {
+		if got := formatUntil(now.Add(d), now); got != want {
+			t.Errorf("formatUntil(now+%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -19,7 +19,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/configfile"
 	"github.com/steveyegge/beads/internal/storage/dolt"
 )
@@ -60,20 +59,6 @@ func generateUniqueTestID(t *testing.T, prefix string, index int) string {
 
 const windowsOS = "windows"
 
-// initConfigForTest initializes viper config for a test and ensures cleanup.
-// main.go's init() calls config.Initialize() which picks up the real .beads/config.yaml.
-// TestMain resets viper, but any test calling config.Initialize() re-loads the real config.
-// This helper ensures viper is reset after the test completes, preventing state pollution
-// (e.g., sync.mode=dolt-native leaking into JSONL export tests).
-func initConfigForTest(t *testing.T) {
-	t.Helper()
-	config.ResetForTesting()
-	if err := config.Initialize(); err != nil {
-		t.Fatalf("config.Initialize: %v", err)
-	}
-	t.Cleanup(config.ResetForTesting)
-}
-
 // ensureTestMode is a no-op; BEADS_TEST_MODE is set once in TestMain.
 // Previously each test set/unset the env var, which raced under t.Parallel().
 func ensureTestMode(t *testing.T) {
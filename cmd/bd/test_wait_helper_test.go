@@ -8,9 +8,24 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/git"
 )
 
+// initConfigForTest initializes viper config for a test and ensures cleanup.
+// main.go's init() calls config.Initialize() which picks up the real .beads/config.yaml.
+// TestMain resets viper, but any test calling config.Initialize() re-loads the real config.
+// This helper ensures viper is reset after the test completes, preventing state pollution
+// (e.g., sync.mode=dolt-native leaking into JSONL export tests).
+func initConfigForTest(t *testing.T) {
+	t.Helper()
+	config.ResetForTesting()
+	if err := config.Initialize(); err != nil {
+		t.Fatalf("config.Initialize: %v", err)
+	}
+	t.Cleanup(config.ResetForTesting)
+}
+
 // waitFor repeatedly evaluates pred until it returns true or timeout expires.
 // Use this instead of time.Sleep for event-driven testing.
 func waitFor(t *testing.T, timeout, poll time.Duration, pred func() bool) {
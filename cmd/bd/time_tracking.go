@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var timeCmd = &cobra.Command{
+	Use:     "time [issue-id]",
+	GroupID: "issues",
+	Short:   "Show or log time spent on an issue",
+	Long: `Show an issue's estimate and the time actually spent on it, or log time
+with 'bd time log'. Estimates are set with --estimate on create and update,
+actuals with --actual or by logging time as you go. 'bd stats' rolls both up
+per epic, to compare planned against actual across sprints.
+
+Durations are minutes (90) or Go durations (1h30m, 45m).
+
+Examples:
+  bd create "Add retry" --estimate 120
+  bd time log bd-12 45m
+  bd time log bd-12 1h30m
+  bd time bd-12 --json
+  bd update bd-12 --actual 200     # Correct the total`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, issueID)
+		if err != nil || issue == nil {
+			FatalErrorRespectJSON("issue %s not found", issueID)
+		}
+		if jsonOutput {
+			outputJSON(timeEntryOf(issue))
+			return
+		}
+		fmt.Printf("%s: %s\n", issueID, formatTimeSpent(issue.EstimatedMinutes, issue.ActualMinutes))
+	},
+}
+
+var timeLogCmd = &cobra.Command{
+	Use:   "log [issue-id] [duration]",
+	Short: "Add time spent to an issue's actual",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("time log")
+		minutes, err := parseTimeSpent(args[1])
+		if err != nil {
+			FatalErrorCode(exitValidation, "%v", err)
+		}
+		ctx := rootCtx
+		issueID, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, issueID)
+		if err != nil || issue == nil {
+			FatalErrorRespectJSON("issue %s not found", issueID)
+		}
+
+		total := minutes
+		if issue.ActualMinutes != nil {
+			total += *issue.ActualMinutes
+		}
+		if err := store.UpdateIssue(ctx, issueID, map[string]interface{}{"actual_minutes": total}, actor); err != nil {
+			FatalErrorRespectJSON("logging time on %s: %v", issueID, err)
+		}
+		issue.ActualMinutes = &total
+		SetLastTouchedID(issueID)
+
+		if jsonOutput {
+			outputJSON(timeEntryOf(issue))
+			return
+		}
+		fmt.Printf("%s Logged %s on %s (%s)\n", ui.RenderPass("✓"), formatEstimateTotal(minutes), issueID,
+			formatTimeSpent(issue.EstimatedMinutes, issue.ActualMinutes))
+	},
+}
+
+func init() {
+	timeCmd.AddCommand(timeLogCmd)
+	timeCmd.ValidArgsFunction = issueIDCompletion
+	timeLogCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(timeCmd)
+}
+
+// timeEntry is the JSON output of bd time.
+type timeEntry struct {
+	ID               string `json:"id"`
+	EstimatedMinutes *int   `json:"estimated_minutes,omitempty"`
+	ActualMinutes    *int   `json:"actual_minutes,omitempty"`
+}
+
+func timeEntryOf(issue *types.Issue) timeEntry {
+	return timeEntry{ID: issue.ID, EstimatedMinutes: issue.EstimatedMinutes, ActualMinutes: issue.ActualMinutes}
+}
+
+// parseTimeSpent parses a bd time log duration, minutes ("90") or a Go
+// duration ("1h30m"), into a positive number of whole minutes.
+func parseTimeSpent(s string) (int, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n <= 0 {
+			return 0, fmt.Errorf("invalid duration %q: must be positive", s)
+		}
+		return n, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q (use minutes, or e.g. 1h30m)", s)
+	}
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	if minutes <= 0 {
+		return 0, fmt.Errorf("invalid duration %q: must be at least a minute", s)
+	}
+	return minutes, nil
+}
+
+// formatTimeSpent renders an estimate and actual as e.g.
+// "estimate 2h, actual 2h30m (+25%)".
+func formatTimeSpent(estimated, actual *int) string {
+	est, act := 0, 0
+	if estimated != nil {
+		est = *estimated
+	}
+	if actual != nil {
+		act = *actual
+	}
+	s := fmt.Sprintf("estimate %s, actual %s", formatEstimateTotal(est), formatEstimateTotal(act))
+	if est > 0 && act > 0 {
+		s += fmt.Sprintf(" (%+d%%)", (act-est)*100/est)
+	}
+	return s
+}
+
+// epicTimeRollup is one epic's estimate and actual, summed over the epic
+// and everything under it.
+type epicTimeRollup struct {
+	ID               string       `json:"id"`
+	Title            string       `json:"title"`
+	Status           types.Status `json:"status"`
+	EstimatedMinutes int          `json:"estimated_minutes"`
+	ActualMinutes    int          `json:"actual_minutes"`
+	Issues           int          `json:"issues"` // Issues with an estimate or actual
+}
+
+// buildEpicTimeRollups sums estimates and actuals per epic over its
+// parent-child descendants; nested epics count toward their parents too.
+// deps maps issue IDs to their dependency records. Epics with no time
+// recorded are left out; the rest are ordered by actual, most first.
+func buildEpicTimeRollups(issues []*types.Issue, deps map[string][]*types.Dependency) []epicTimeRollup {
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	children := make(map[string][]string)
+	for id, records := range deps {
+		for _, dep := range records {
+			if dep.Type == types.DepParentChild && byID[id] != nil {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], id)
+			}
+		}
+	}
+
+	rollups := []epicTimeRollup{}
+	for _, epic := range issues {
+		if epic.IssueType != types.TypeEpic {
+			continue
+		}
+		r := epicTimeRollup{ID: epic.ID, Title: epic.Title, Status: epic.Status}
+		seen := map[string]bool{}
+		queue := []string{epic.ID}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			issue := byID[id]
+			if issue.EstimatedMinutes != nil || issue.ActualMinutes != nil {
+				r.Issues++
+			}
+			if issue.EstimatedMinutes != nil {
+				r.EstimatedMinutes += *issue.EstimatedMinutes
+			}
+			if issue.ActualMinutes != nil {
+				r.ActualMinutes += *issue.ActualMinutes
+			}
+			queue = append(queue, children[id]...)
+		}
+		if r.EstimatedMinutes > 0 || r.ActualMinutes > 0 {
+			rollups = append(rollups, r)
+		}
+	}
+	sort.Slice(rollups, func(i, j int) bool {
+		if rollups[i].ActualMinutes != rollups[j].ActualMinutes {
+			return rollups[i].ActualMinutes > rollups[j].ActualMinutes
+		}
+		return rollups[i].ID < rollups[j].ID
+	})
+	return rollups
+}
+
+// printEpicTimeRollups prints bd status's estimate-versus-actual table.
+func printEpicTimeRollups(rollups []epicTimeRollup) {
+	if len(rollups) == 0 {
+		return
+	}
+	fmt.Printf("\nTime by Epic (estimate / actual):\n")
+	for _, r := range rollups {
+		est, act := r.EstimatedMinutes, r.ActualMinutes
+		line := fmt.Sprintf("  %s  %s / %s", ui.RenderID(r.ID), formatEstimateTotal(est), formatEstimateTotal(act))
+		if est > 0 && act > 0 {
+			diff := fmt.Sprintf("%+d%%", (act-est)*100/est)
+			if act > est {
+				diff = ui.RenderWarn(diff)
+			}
+			line += " " + diff
+		}
+		fmt.Println(line + " " + ui.RenderMuted(strings.TrimSpace(r.Title)))
+	}
+}
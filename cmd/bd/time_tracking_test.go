@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestBuildEpicTimeRollups(t *testing.T) {
+	mins := func(n int) *int { return &n }
+	issues := []*types.Issue{
+		{ID: "epic", Title: "Checkout v2", IssueType: types.TypeEpic, EstimatedMinutes: mins(60)},
+		{ID: "sub", Title: "Payments", IssueType: types.TypeEpic},
+		{ID: "a", IssueType: types.TypeTask, EstimatedMinutes: mins(120), ActualMinutes: mins(180)},
+		{ID: "b", IssueType: types.TypeTask, ActualMinutes: mins(30)},
+		{ID: "c", IssueType: types.TypeTask},
+		{ID: "idle", Title: "Nothing tracked", IssueType: types.TypeEpic},
+		{ID: "loose", IssueType: types.TypeTask, EstimatedMinutes: mins(500)},
+	}
+	child := func(id, parent string) *types.Dependency {
+		return &types.Dependency{IssueID: id, DependsOnID: parent, Type: types.DepParentChild}
+	}
+	deps := map[string][]*types.Dependency{
+		"sub": {child("sub", "epic")},
+		"a":   {child("a", "epic")},
+		"b":   {child("b", "sub"), {IssueID: "b", DependsOnID: "a", Type: types.DepBlocks}},
+		"c":   {child("c", "idle")},
+	}
+
+	got := buildEpicTimeRollups(issues, deps)
+	want := []epicTimeRollup{
+		{ID: "epic", Title: "Checkout v2", EstimatedMinutes: 180, ActualMinutes: 210, Issues: 3},
+		{ID: "sub", Title: "Payments", ActualMinutes: 30, Issues: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rollups = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseTimeSpent(t *testing.T) {
+	for in, want := range map[string]int{"90": 90, "45m": 45, "1h30m": 90, "2h": 120, "89s": 1} {
+		if got, err := parseTimeSpent(in); err != nil || got != want {
+			t.Errorf("parseTimeSpent(%q) = %d, %v, want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"0", "-5", "10s", "soon", ""} {
+		if _, err := parseTimeSpent(in); err == nil {
+			t.Errorf("parseTimeSpent(%q) accepted", in)
+		}
+	}
+}
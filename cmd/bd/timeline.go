@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/calendar"
+	"github.com/steveyegge/beads/internal/schedule"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var timelineCmd = &cobra.Command{
+	Use:     "timeline",
+	GroupID: "views",
+	Short:   "Project start and finish dates for open work",
+	Long: `Project when each open issue can start and finish, from its estimate
+(--estimate), defer date, and blocks dependencies, including their relation
+and lag (see 'bd dep add --help'). Estimates and lags count working time on
+the configured calendar (calendar.weekend, calendar.holidays), minus the
+days the assignee is away (bd availability):
+
+  finish-to-start   starts after the blocker finishes (+ lag)
+  start-to-start    starts after the blocker starts (+ lag)
+  finish-to-finish  finishes no earlier than the blocker (+ lag)
+
+In-progress issues keep their actual start. Issues finishing after their
+due date are flagged late. Issues without an estimate are projected to take
+no time and marked as unestimated.
+
+Examples:
+  bd timeline                 # All open work
+  bd timeline --parent bd-42  # An epic's descendants
+  bd timeline --json`,
+	Run: runTimeline,
+}
+
+func init() {
+	timelineCmd.Flags().String("parent", "", "Only show descendants of this issue")
+	timelineCmd.Flags().IntP("limit", "n", 0, "Maximum issues to show (0 = all)")
+	rootCmd.AddCommand(timelineCmd)
+}
+
+// timelineEntry is one row of the timeline report.
+type timelineEntry struct {
+	schedule.Projection
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+}
+
+func runTimeline(cmd *cobra.Command, _ []string) {
+	parent, _ := cmd.Flags().GetString("parent")
+	limit, _ := cmd.Flags().GetInt("limit")
+	ctx := rootCtx
+
+	persistent := false
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{
+		ExcludeStatus: []types.Status{types.StatusClosed, types.StatusPinned},
+		Ephemeral:     &persistent,
+	})
+	if err != nil {
+		FatalErrorRespectJSON("listing issues: %v", err)
+	}
+	deps, err := store.GetAllDependencyRecords(ctx)
+	if err != nil {
+		FatalErrorRespectJSON("fetching dependencies: %v", err)
+	}
+
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	var links []schedule.Link
+	var finished []string // Blockers outside the open set anchor their dependents
+	children := make(map[string][]string)
+	for id, list := range deps {
+		for _, dep := range list {
+			switch dep.Type {
+			case types.DepBlocks:
+				if byID[id] == nil {
+					continue
+				}
+				links = append(links, schedule.Link{From: dep.DependsOnID, To: id, Meta: types.ParseScheduleMeta(dep.Metadata)})
+				if byID[dep.DependsOnID] == nil {
+					finished = append(finished, dep.DependsOnID)
+				}
+			case types.DepParentChild:
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], id)
+			}
+		}
+	}
+	if len(finished) > 0 {
+		extra, err := store.GetIssuesByIDs(ctx, finished)
+		if err != nil {
+			FatalErrorRespectJSON("fetching blockers: %v", err)
+		}
+		issues = append(issues, extra...)
+	}
+
+	var ids []string
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	started, err := store.StartTimes(ctx, ids)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	cal, err := calendar.Load()
+	if err != nil {
+		FatalErrorRespectJSON("invalid calendar config: %v", err)
+	}
+	// Assignees' days away (bd availability) are non-working for their issues
+	periods, _ := store.GetUnavailability(ctx, "", todayDate()) // Best effort: project without availability
+	personalCals := make(map[string]*calendar.Calendar)
+	for person, days := range daysOff(periods) {
+		personalCals[person] = cal.WithDaysOff(days)
+	}
+	projections := schedule.ProjectWith(issues, started, links, time.Now(), func(issue *types.Issue) *calendar.Calendar {
+		if c := personalCals[issue.Assignee]; c != nil {
+			return c
+		}
+		return cal
+	})
+
+	var scope map[string]bool
+	if parent != "" {
+		parentID, err := utils.ResolvePartialID(ctx, store, parent)
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", parent, err)
+		}
+		scope = descendantsOf(parentID, children)
+	}
+
+	entries := []timelineEntry{}
+	for _, p := range projections {
+		if scope != nil && !scope[p.ID] {
+			continue
+		}
+		issue := byID[p.ID]
+		entries = append(entries, timelineEntry{Projection: p, Title: issue.Title, Status: string(issue.Status), Priority: issue.Priority})
+		if limit > 0 && len(entries) == limit {
+			break
+		}
+	}
+
+	if jsonOutput {
+		outputJSON(entries)
+		return
+	}
+	if len(entries) == 0 {
+		fmt.Println("No open issues to schedule")
+		return
+	}
+	displayTimeline(entries)
+}
+
+// descendantsOf returns the transitive children of id.
+func descendantsOf(id string, children map[string][]string) map[string]bool {
+	out := make(map[string]bool)
+	queue := []string{id}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, child := range children[next] {
+			if !out[child] {
+				out[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+	return out
+}
+
+func displayTimeline(entries []timelineEntry) {
+	late, unestimated := 0, 0
+	var end time.Time
+	fmt.Printf("\n%s Timeline (%d issues):\n\n", ui.RenderAccent("📅"), len(entries))
+	for _, e := range entries {
+		start := e.Start.Local().Format("Jan 02")
+		if !e.Started {
+			start = "~" + start
+		}
+		var notes []string
+		if e.DrivenBy != "" {
+			notes = append(notes, "after "+e.DrivenBy)
+		}
+		if !e.Estimated {
+			notes = append(notes, "unestimated")
+			unestimated++
+		}
+		line := fmt.Sprintf("  %-7s → %-7s %s %s: %s", start, e.Finish.Local().Format("Jan 02"),
+			ui.RenderPriority(e.Priority), ui.RenderID(e.ID), e.Title)
+		if len(notes) > 0 {
+			line += " " + ui.RenderMuted("("+strings.Join(notes, ", ")+")")
+		}
+		if e.LateBy != "" {
+			line += " " + ui.RenderWarn("late by "+e.LateBy)
+			late++
+		}
+		fmt.Println(line)
+		if e.Finish.After(end) {
+			end = e.Finish
+		}
+	}
+	fmt.Printf("\nProjected finish: %s", end.Local().Format("2006-01-02"))
+	if late > 0 {
+		fmt.Printf(", %d late", late)
+	}
+	if unestimated > 0 {
+		fmt.Printf(", %d unestimated", unestimated)
+	}
+	fmt.Println()
+	fmt.Println()
+}
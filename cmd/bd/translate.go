@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/localize"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+var translateCmd = &cobra.Command{
+	Use:     "translate <id>",
+	GroupID: "issues",
+	Short:   "Add or list translations of an issue's title and description",
+	Long: `Keep an issue's title and description in several languages, for teams
+that share one database across languages.
+
+The issue's own title and description stay the original. bd list and bd show
+display the translation in your preferred language (display.language in
+config.yaml or BD_DISPLAY_LANGUAGE, e.g. "pt-BR, en"), falling back to the
+original when the issue has none; regional tags fall back to their base
+language. JSON and porcelain output always carry the original, with the
+translations in metadata.translations.
+
+Without --lang, lists the issue's translations.
+
+Examples:
+  bd translate bd-42 --lang de --title "Anmeldung schlägt fehl" --description "..."
+  bd translate bd-42 --lang de --remove
+  bd translate bd-42                     # List translations
+  BD_DISPLAY_LANGUAGE=de bd show bd-42`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		langArg, _ := cmd.Flags().GetString("lang")
+		title, _ := cmd.Flags().GetString("title")
+		description, _ := cmd.Flags().GetString("description")
+		remove, _ := cmd.Flags().GetBool("remove")
+
+		id, err := utils.ResolvePartialID(ctx, store, args[0])
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", args[0], err)
+		}
+		issue, err := store.GetIssue(ctx, id)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if langArg == "" {
+			if cmd.Flags().Changed("title") || cmd.Flags().Changed("description") || remove {
+				FatalErrorRespectJSON("--lang is required to set or remove a translation")
+			}
+			translations := localize.FromMetadata(issue.Metadata)
+			if jsonOutput {
+				outputJSON(map[string]interface{}{"id": id, "translations": translations})
+				return
+			}
+			if len(translations) == 0 {
+				fmt.Printf("%s has no translations\n", id)
+				return
+			}
+			for _, lang := range localize.Languages(issue.Metadata) {
+				v := translations[lang]
+				t := v.Title
+				if t == "" {
+					t = ui.RenderMuted("(original title)")
+				}
+				fmt.Printf("  %-8s %s\n", lang, t)
+			}
+			return
+		}
+
+		CheckReadonly("translate")
+		lang, err := localize.NormalizeTag(langArg)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		var v localize.Variant
+		if remove {
+			if cmd.Flags().Changed("title") || cmd.Flags().Changed("description") {
+				FatalErrorRespectJSON("--remove cannot be combined with --title or --description")
+			}
+		} else {
+			v = localize.FromMetadata(issue.Metadata)[lang]
+			if cmd.Flags().Changed("title") {
+				v.Title = title
+			}
+			if cmd.Flags().Changed("description") {
+				v.Description = description
+			}
+			if v == (localize.Variant{}) {
+				FatalErrorRespectJSON("give a --title or --description for %s (or --remove)", lang)
+			}
+			if len(v.Title) > 500 {
+				FatalErrorRespectJSON("title must be 500 characters or less (got %d)", len(v.Title))
+			}
+		}
+		if label := scanOnWrite("translate", map[string]string{"title": v.Title, "description": v.Description}); label != "" {
+			if err := store.AddLabel(ctx, id, label, actor); err != nil {
+				FatalErrorRespectJSON("labelling %s: %v", id, err)
+			}
+		}
+
+		metadata, err := localize.WithVariant(issue.Metadata, lang, v)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		if err := store.UpdateIssue(ctx, id, map[string]interface{}{"metadata": metadata}, actor); err != nil {
+			FatalErrorRespectJSON("updating %s: %v", id, err)
+		}
+		SetLastTouchedID(id)
+
+		if jsonOutput {
+			outputJSON(map[string]interface{}{"id": id, "lang": lang, "removed": remove, "translation": v})
+			return
+		}
+		if remove {
+			fmt.Printf("%s Removed the %s translation of %s\n", ui.RenderPass("✓"), lang, ui.RenderID(id))
+		} else {
+			fmt.Printf("%s Saved the %s translation of %s\n", ui.RenderPass("✓"), lang, ui.RenderID(id))
+		}
+	},
+}
+
+func init() {
+	translateCmd.Flags().String("lang", "", "Language tag of the translation (e.g. de, pt-BR)")
+	translateCmd.Flags().String("title", "", "Translated title")
+	translateCmd.Flags().String("description", "", "Translated description")
+	translateCmd.Flags().Bool("remove", false, "Remove the translation")
+	translateCmd.ValidArgsFunction = issueIDCompletion
+	rootCmd.AddCommand(translateCmd)
+}
+
+// displayLanguages returns the preferred display languages from the
+// display.language config, most preferred first.
+func displayLanguages() []string {
+	return localize.ParsePreferences(config.GetString("display.language"))
+}
+
+// localizeIssues shows issues in the preferred display language, replacing
+// titles and descriptions with translations where they exist. Only use it
+// on issues that are displayed, never written back.
+func localizeIssues(issues []*types.Issue) {
+	prefs := displayLanguages()
+	if len(prefs) == 0 {
+		return
+	}
+	for _, issue := range issues {
+		localize.Apply(issue, prefs)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/typeschema"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/writepolicy"
+)
+
+// fieldFlagValues returns the values of cmd's --field flags.
+func fieldFlagValues(cmd *cobra.Command) (map[string]string, error) {
+	args, _ := cmd.Flags().GetStringArray("field")
+	return typeschema.ParseAssignments(args)
+}
+
+// checkTypeFields checks an issue's type-specific field values against the
+// schema for its type, per validation.type-fields: in error mode the
+// problems are returned as an error, in warn mode they are printed.
+func checkTypeFields(issueType types.IssueType, values map[string]string) error {
+	warning, err := writepolicy.CheckTypeFields(issueType, values)
+	if warning != "" {
+		fmt.Fprintf(os.Stderr, "%s %s\n", ui.RenderWarn("⚠"), warning)
+	}
+	return err
+}
+
+// formatTypeFields renders an issue's type-specific fields for bd show:
+// the type's template if it has one, otherwise one "name: value" line per
+// field, in schema order and then by name. It returns "" for issues
+// without fields.
+func formatTypeFields(issue *types.Issue, schemas []typeschema.Schema) string {
+	values := typeschema.FromMetadata(issue.Metadata)
+	schema := typeschema.Find(schemas, issue.IssueType)
+	if schema != nil && schema.Template != "" {
+		return schema.Render(issue)
+	}
+	if len(values) == 0 {
+		return ""
+	}
+
+	var names []string
+	if schema != nil {
+		for _, field := range schema.Fields {
+			if _, ok := values[field.Name]; ok {
+				names = append(names, field.Name)
+			}
+		}
+	}
+	var extra []string
+	for name := range values {
+		if schema == nil || schema.Field(name) == nil {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	names = append(names, extra...)
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = fmt.Sprintf("%s: %s", name, values[name])
+	}
+	return strings.Join(lines, "\n")
+}
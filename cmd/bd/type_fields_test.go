@@ -0,0 +1,29 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/typeschema"
+)
+
+func TestFormatTypeFields(t *testing.T) {
+	schemas := []typeschema.Schema{
+		{Type: "incident", Fields: []typeschema.Field{{Name: "severity", Required: true}, {Name: "impact", Required: true}}},
+		{Type: "experiment", Fields: []typeschema.Field{{Name: "hypothesis"}}, Template: "Testing: {hypothesis}"},
+	}
+	metadata := json.RawMessage(`{"fields":{"impact":"all","zone":"eu","severity":"sev1"}}`)
+
+	incident := &types.Issue{IssueType: "incident", Metadata: metadata}
+	if got, want := formatTypeFields(incident, schemas), "severity: sev1\nimpact: all\nzone: eu"; got != want {
+		t.Errorf("formatTypeFields(incident) = %q, want %q", got, want)
+	}
+	experiment := &types.Issue{IssueType: "experiment", Metadata: json.RawMessage(`{"fields":{"hypothesis":"caching helps"}}`)}
+	if got := formatTypeFields(experiment, schemas); got != "Testing: caching helps" {
+		t.Errorf("formatTypeFields(experiment) = %q", got)
+	}
+	if got := formatTypeFields(&types.Issue{IssueType: types.TypeTask}, schemas); got != "" {
+		t.Errorf("formatTypeFields(task) = %q, want empty", got)
+	}
+}
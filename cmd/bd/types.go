@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/typeschema"
+	"github.com/steveyegge/beads/internal/writepolicy"
 )
 
 // coreWorkTypes are the built-in types that beads validates without configuration.
@@ -31,9 +34,20 @@ var typesCmd = &cobra.Command{
 Core work types (bug, task, feature, chore, epic, decision) are always valid.
 Additional types require configuration via types.custom in .beads/config.yaml.
 
+Any type can have a soft schema: type-specific fields its issues must fill
+in, set with --field name=value on bd create and bd update and stored in the
+issue's metadata, and a template bd show displays them with:
+  type-schemas:
+    incident: "severity=sev1|sev2|sev3, impact, customer?"
+  type-templates:
+    incident: "{severity}: {impact}"
+Fields are required unless marked with "?", and "=" lists the allowed values.
+validation.type-fields (error, warn, none) sets how they are enforced.
+
 Examples:
-  bd types              # List all types with descriptions
-  bd types --json       # Output as JSON
+  bd types                # List all types with descriptions
+  bd types --json         # Output as JSON
+  bd types --json-schema  # JSON Schema for issues, including type fields
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Ensure database access is active (types command needs to read config).
@@ -51,10 +65,25 @@ Examples:
 			}
 		}
 
+		schemas, err := writepolicy.TypeSchemas()
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+
+		if jsonSchema, _ := cmd.Flags().GetBool("json-schema"); jsonSchema {
+			var names []string
+			for _, t := range coreWorkTypes {
+				names = append(names, string(t.Type))
+			}
+			outputJSON(typeschema.JSONSchema(schemas, append(names, customTypes...)))
+			return
+		}
+
 		if jsonOutput {
 			result := struct {
-				CoreTypes   []typeInfo `json:"core_types"`
-				CustomTypes []string   `json:"custom_types,omitempty"`
+				CoreTypes   []typeInfo          `json:"core_types"`
+				CustomTypes []string            `json:"custom_types,omitempty"`
+				Schemas     []typeschema.Schema `json:"schemas,omitempty"`
 			}{}
 
 			for _, t := range coreWorkTypes {
@@ -64,6 +93,7 @@ Examples:
 				})
 			}
 			result.CustomTypes = customTypes
+			result.Schemas = schemas
 			outputJSON(result)
 			return
 		}
@@ -83,6 +113,16 @@ Examples:
 			fmt.Println("\nNo custom types configured.")
 			fmt.Println("Configure with: bd config set types.custom \"type1,type2,...\"")
 		}
+
+		if len(schemas) > 0 {
+			fmt.Println("\nType fields (type-schemas):")
+			for _, schema := range schemas {
+				fmt.Printf("  %-14s %s\n", schema.Type, describeTypeFields(schema))
+				if schema.Template != "" {
+					fmt.Printf("  %-14s shown as %q\n", "", schema.Template)
+				}
+			}
+		}
 	},
 }
 
@@ -91,6 +131,23 @@ type typeInfo struct {
 	Description string `json:"description"`
 }
 
+// describeTypeFields lists a schema's fields for bd types: optional fields
+// end in "?", and allowed values follow "=".
+func describeTypeFields(schema typeschema.Schema) string {
+	parts := make([]string, len(schema.Fields))
+	for i, field := range schema.Fields {
+		parts[i] = field.Name
+		if !field.Required {
+			parts[i] += "?"
+		}
+		if len(field.Values) > 0 {
+			parts[i] += "=" + strings.Join(field.Values, "|")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 func init() {
+	typesCmd.Flags().Bool("json-schema", false, "Output a JSON Schema for issues, including type-specific fields")
 	rootCmd.AddCommand(typesCmd)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -10,8 +11,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/beads/internal/config"
 	"github.com/steveyegge/beads/internal/hooks"
-	"github.com/steveyegge/beads/internal/timeparsing"
+	"github.com/steveyegge/beads/internal/quiethours"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/typeschema"
 	"github.com/steveyegge/beads/internal/ui"
 	"github.com/steveyegge/beads/internal/utils"
 	"github.com/steveyegge/beads/internal/validation"
@@ -24,13 +27,53 @@ var updateCmd = &cobra.Command{
 	Long: `Update one or more issues.
 
 If no issue ID is provided, updates the last touched issue (from most recent
-create, update, show, or close operation).`,
+create, update, show, or close operation).
+
+Pass "-" to read the changes from a JSON document on stdin instead of flags.
+Keys are issue JSON field names (title, status, priority, labels, notes, ...);
+only the keys present are changed, null clears a field, and unknown or
+read-only keys are reported and ignored. The document's "id" names the issue
+when no ID argument is given:
+  bd show bd-42 --json | jq '.[0] | .status = "closed"' | bd update --json -
+
+--batch changes many issues in one transaction, and so one Dolt commit: the
+IDs given as arguments, the IDs read from stdin for "-", and the issues
+matching --filter (a bd query expression). Issues that can't be updated are
+reported and skipped; if any write fails, no issue changes. --dry-run lists
+the issues without changing them:
+  bd update --batch --filter 'label=sprint-9 and status=open' --add-label carryover --dry-run
+  bd list --porcelain --assignee ana | cut -f1 | bd update --batch - --assignee ben
+
+During a quiet-hours window (config quiet-hours, e.g. "fri 17:00-mon 08:00 p2+"),
+--claim and --status in_progress refuse the issues the window holds back, so
+long agent runs don't start before a weekend; --ignore-quiet-hours overrides.`,
 	Args: cobra.MinimumNArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
 		CheckReadonly("update")
+		batch, filter, dryRun := batchFlags(cmd)
+
+		// "-": read the changes from a JSON document on stdin (IDs, with --batch)
+		if rest, ok := splitStdinDocArg(args); ok && !batch {
+			docID := applyStdinIssueDoc(cmd, updateDocFields, false)
+			switch {
+			case len(rest) > 1:
+				FatalErrorRespectJSON("an issue document on stdin updates a single issue")
+			case len(rest) == 0 && docID != "":
+				rest = []string{docID}
+			case len(rest) == 1 && docID != "":
+				argID, err := utils.ResolvePartialID(rootCtx, store, rest[0])
+				if err != nil {
+					FatalErrorRespectJSON("resolving %s: %v", rest[0], err)
+				}
+				if argID != docID {
+					FatalErrorRespectJSON("issue document id %q does not match %s", docID, argID)
+				}
+			}
+			args = rest
+		}
 
 		// If no IDs provided, use last touched issue
-		if len(args) == 0 {
+		if len(args) == 0 && !batch {
 			lastTouched := GetLastTouchedID()
 			if lastTouched == "" {
 				FatalErrorRespectJSON("no issue ID provided and no last touched issue")
@@ -114,6 +157,13 @@ create, update, show, or close operation).`,
 			}
 			updates["estimated_minutes"] = estimate
 		}
+		if cmd.Flags().Changed("actual") {
+			actual, _ := cmd.Flags().GetInt("actual")
+			if actual < 0 {
+				FatalErrorRespectJSON("actual must be a non-negative number of minutes")
+			}
+			updates["actual_minutes"] = actual
+		}
 		if cmd.Flags().Changed("type") {
 			issueType, _ := cmd.Flags().GetString("type")
 			// Normalize aliases (e.g., "enhancement" -> "feature") before validating
@@ -172,9 +222,9 @@ create, update, show, or close operation).`,
 				// Empty string clears the due date
 				updates["due_at"] = nil
 			} else {
-				t, err := timeparsing.ParseRelativeTime(dueStr, time.Now())
+				t, err := parseTimeFlag(dueStr)
 				if err != nil {
-					FatalErrorRespectJSON("invalid --due format %q. Examples: +6h, tomorrow, next monday, 2025-01-15", dueStr)
+					FatalErrorRespectJSON("invalid --due format %q. Examples: +6h, +3 business days, tomorrow, 2025-01-15", dueStr)
 				}
 				updates["due_at"] = t
 			}
@@ -185,9 +235,9 @@ create, update, show, or close operation).`,
 				// Empty string clears the defer_until
 				updates["defer_until"] = nil
 			} else {
-				t, err := timeparsing.ParseRelativeTime(deferStr, time.Now())
+				t, err := parseDeferFlag(deferStr, jsonOutput)
 				if err != nil {
-					FatalErrorRespectJSON("invalid --defer format %q. Examples: +1h, tomorrow, next monday, 2025-01-15", deferStr)
+					FatalErrorRespectJSON("invalid --defer format %q. Examples: +1h, +3 business days, tomorrow, 2025-01-15", deferStr)
 				}
 				// Warn if defer date is in the past (user probably meant future)
 				if t.Before(time.Now()) && !jsonOutput {
@@ -233,6 +283,14 @@ create, update, show, or close operation).`,
 			}
 			updates["metadata"] = json.RawMessage(metadataJSON)
 		}
+		// Type-specific fields, merged into metadata per issue
+		if cmd.Flags().Changed("field") {
+			fieldValues, err := fieldFlagValues(cmd)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+			updates["type_fields"] = fieldValues
+		}
 
 		// Get claim flag
 		claimFlag, _ := cmd.Flags().GetBool("claim")
@@ -242,8 +300,36 @@ create, update, show, or close operation).`,
 			return
 		}
 
+		// Check new text for pasted credentials per scan.mode
+		scanFields := make(map[string]string)
+		var newTexts []string
+		for _, key := range []string{"title", "description", "design", "acceptance_criteria", "notes", "append_notes"} {
+			if v, ok := updates[key].(string); ok {
+				scanFields[key] = v
+				newTexts = append(newTexts, v)
+			}
+		}
+		if label := scanOnWrite("update", scanFields); label != "" {
+			addLabels, _ := updates["add_labels"].([]string)
+			updates["add_labels"] = append(addLabels, label)
+		}
+
 		ctx := rootCtx
 
+		// Claiming or starting work is held back during quiet hours
+		var quiet []quiethours.Window
+		if claimFlag || updates["status"] == string(types.StatusInProgress) {
+			quiet = quietHours(cmd)
+		}
+
+		if batch {
+			if claimFlag {
+				FatalErrorCode(exitValidation, "--claim claims one issue at a time and can't be used with --batch")
+			}
+			runBatchUpdate(ctx, args, filter, dryRun, updates, newTexts, quiet)
+			return
+		}
+
 		updatedIssues := []*types.Issue{}
 		var firstUpdatedID string // Track first successful update for last-touched
 		for _, id := range args {
@@ -271,6 +357,11 @@ create, update, show, or close operation).`,
 				result.Close()
 				continue
 			}
+			if err := checkQuietHours(quiet, issue, time.Now()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				result.Close()
+				continue
+			}
 
 			// Handle claim operation atomically using compare-and-swap semantics
 			if claimFlag {
@@ -281,95 +372,14 @@ create, update, show, or close operation).`,
 				}
 			}
 
-			// Apply regular field updates if any
-			regularUpdates := make(map[string]interface{})
-			for k, v := range updates {
-				if k != "add_labels" && k != "remove_labels" && k != "set_labels" && k != "parent" && k != "append_notes" {
-					regularUpdates[k] = v
-				}
-			}
-			// Handle append_notes: combine existing notes with new content
-			if appendNotes, ok := updates["append_notes"].(string); ok {
-				combined := issue.Notes
-				if combined != "" {
-					combined += "\n"
-				}
-				combined += appendNotes
-				regularUpdates["notes"] = combined
-			}
-			if len(regularUpdates) > 0 {
-				if err := issueStore.UpdateIssue(ctx, result.ResolvedID, regularUpdates, actor); err != nil {
-					fmt.Fprintf(os.Stderr, "Error updating %s: %v\n", id, err)
-					result.Close()
-					continue
-				}
-			}
-
-			// Handle label operations
-			var setLabels, addLabels, removeLabels []string
-			if v, ok := updates["set_labels"].([]string); ok {
-				setLabels = v
-			}
-			if v, ok := updates["add_labels"].([]string); ok {
-				addLabels = v
-			}
-			if v, ok := updates["remove_labels"].([]string); ok {
-				removeLabels = v
-			}
-			if len(setLabels) > 0 || len(addLabels) > 0 || len(removeLabels) > 0 {
-				if err := applyLabelUpdates(ctx, issueStore, result.ResolvedID, actor, setLabels, addLabels, removeLabels); err != nil {
-					fmt.Fprintf(os.Stderr, "Error updating labels for %s: %v\n", id, err)
-					result.Close()
-					continue
-				}
+			if err := applyIssueUpdates(ctx, issueStore, issue, updates); err != nil {
+				fmt.Fprintf(os.Stderr, "Error %v\n", err)
+				result.Close()
+				continue
 			}
-
-			// Handle parent reparenting
-			if newParent, ok := updates["parent"].(string); ok {
-				// Validate new parent exists (unless empty string to remove parent)
-				if newParent != "" {
-					parentIssue, err := issueStore.GetIssue(ctx, newParent)
-					if err != nil {
-						fmt.Fprintf(os.Stderr, "Error getting parent %s: %v\n", newParent, err)
-						result.Close()
-						continue
-					}
-					if parentIssue == nil {
-						fmt.Fprintf(os.Stderr, "Error: parent issue %s not found\n", newParent)
-						result.Close()
-						continue
-					}
-				}
-
-				// Find and remove existing parent-child dependency
-				deps, err := issueStore.GetDependencyRecords(ctx, result.ResolvedID)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Error getting dependencies for %s: %v\n", id, err)
-					result.Close()
-					continue
-				}
-				for _, dep := range deps {
-					if dep.Type == types.DepParentChild {
-						if err := issueStore.RemoveDependency(ctx, result.ResolvedID, dep.DependsOnID, actor); err != nil {
-							fmt.Fprintf(os.Stderr, "Error removing old parent dependency: %v\n", err)
-						}
-						break
-					}
-				}
-
-				// Add new parent-child dependency (if not removing parent)
-				if newParent != "" {
-					newDep := &types.Dependency{
-						IssueID:     result.ResolvedID,
-						DependsOnID: newParent,
-						Type:        types.DepParentChild,
-					}
-					if err := issueStore.AddDependency(ctx, newDep, actor); err != nil {
-						fmt.Fprintf(os.Stderr, "Error adding parent dependency: %v\n", err)
-						result.Close()
-						continue
-					}
-				}
+			if len(newTexts) > 0 {
+				// Relate the issue to the issues its new text mentions
+				linkReferences(ctx, issueStore, result.ResolvedID, newTexts...)
 			}
 
 			// Run update hook
@@ -414,11 +424,13 @@ func init() {
 	updateCmd.Flags().String("acceptance-criteria", "", "DEPRECATED: use --acceptance")
 	_ = updateCmd.Flags().MarkHidden("acceptance-criteria") // Only fails if flag missing (caught in tests)
 	updateCmd.Flags().IntP("estimate", "e", 0, "Time estimate in minutes (e.g., 60 for 1 hour)")
+	updateCmd.Flags().Int("actual", 0, "Total time spent in minutes, replacing what bd time log recorded")
 	updateCmd.Flags().StringSlice("add-label", nil, "Add labels (repeatable)")
 	updateCmd.Flags().StringSlice("remove-label", nil, "Remove labels (repeatable)")
 	updateCmd.Flags().StringSlice("set-labels", nil, "Set labels, replacing all existing (repeatable)")
 	updateCmd.Flags().String("parent", "", "New parent issue ID (reparents the issue, use empty string to remove parent)")
 	updateCmd.Flags().Bool("claim", false, "Atomically claim the issue (sets assignee to you, status to in_progress; fails if already claimed)")
+	registerQuietHoursFlag(updateCmd)
 	updateCmd.Flags().String("session", "", "Claude Code session ID for status=closed (or set CLAUDE_SESSION_ID env var)")
 	// Time-based scheduling flags (GH#820)
 	// Examples:
@@ -429,7 +441,7 @@ func init() {
 	//   --due=""            Clear due date
 	//   --defer=+1h         Hidden from bd ready for 1 hour
 	//   --defer=""          Clear defer (show in bd ready immediately)
-	updateCmd.Flags().String("due", "", "Due date/time (empty to clear). Formats: +6h, +1d, +2w, tomorrow, next monday, 2025-01-15")
+	updateCmd.Flags().String("due", "", "Due date/time (empty to clear). Formats: +6h, +1d, +2w, +3 business days, tomorrow, next monday, 2025-01-15")
 	updateCmd.Flags().String("defer", "", "Defer until date (empty to clear). Issue hidden from bd ready until then")
 	// Gate fields (bd-z6kw)
 	updateCmd.Flags().String("await-id", "", "Set gate await_id (e.g., GitHub run ID for gh:run gates)")
@@ -438,6 +450,181 @@ func init() {
 	updateCmd.Flags().Bool("persistent", false, "Mark issue as persistent (promote wisp to regular issue)")
 	// Metadata flag (GH#1413)
 	updateCmd.Flags().String("metadata", "", "Set custom metadata (JSON string or @file.json to read from file)")
+	updateCmd.Flags().StringArray("field", nil, "Set a type-specific field as name=value, empty value to clear (repeatable; see type-schemas config)")
+	registerBatchFlags(updateCmd)
 	updateCmd.ValidArgsFunction = issueIDCompletion
 	rootCmd.AddCommand(updateCmd)
 }
+
+// runBatchUpdate applies updates to every issue a --batch selects in one
+// transaction, so either all of them change or, if one fails, none do.
+func runBatchUpdate(ctx context.Context, args []string, filter string, dryRun bool, updates map[string]interface{}, newTexts []string, quiet []quiethours.Window) {
+	issues, err := batchTargets(ctx, args, filter, os.Stdin)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	now := time.Now()
+	var targets []*types.Issue
+	for _, issue := range issues {
+		if err := validateIssueUpdatable(issue.ID, issue); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			continue
+		}
+		if err := checkQuietHours(quiet, issue, now); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			continue
+		}
+		targets = append(targets, issue)
+	}
+	if dryRun || len(targets) == 0 {
+		printBatchPlan("update", targets)
+		return
+	}
+
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		for _, issue := range targets {
+			if err := applyIssueUpdates(ctx, tx, issue, updates); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		FatalErrorRespectJSON("%v (no issues were updated)", err)
+	}
+
+	updatedIssues := []*types.Issue{}
+	for _, issue := range targets {
+		if len(newTexts) > 0 {
+			linkReferences(ctx, store, issue.ID, newTexts...)
+		}
+		updatedIssue, _ := store.GetIssue(ctx, issue.ID) // Best effort: nil issue handled by subsequent nil check
+		if updatedIssue != nil && hookRunner != nil {
+			hookRunner.Run(hooks.EventUpdate, updatedIssue)
+		}
+		if jsonOutput {
+			if updatedIssue != nil {
+				updatedIssues = append(updatedIssues, updatedIssue)
+			}
+		} else {
+			fmt.Printf("%s Updated issue: %s\n", ui.RenderPass("✓"), issue.ID)
+		}
+	}
+	if jsonOutput {
+		outputJSON(updatedIssues)
+	}
+}
+
+// applyIssueUpdates writes the changes bd update collected from its flags to
+// one issue, through the store or a batch's transaction. issue is the issue
+// as it was before the changes.
+func applyIssueUpdates(ctx context.Context, w issueWriter, issue *types.Issue, updates map[string]interface{}) error {
+	id := issue.ID
+
+	// Apply regular field updates if any
+	regularUpdates := make(map[string]interface{})
+	for k, v := range updates {
+		if k != "add_labels" && k != "remove_labels" && k != "set_labels" && k != "parent" && k != "append_notes" && k != "type_fields" {
+			regularUpdates[k] = v
+		}
+	}
+	// Handle append_notes: combine existing notes with new content
+	if appendNotes, ok := updates["append_notes"].(string); ok {
+		combined := issue.Notes
+		if combined != "" {
+			combined += "\n"
+		}
+		combined += appendNotes
+		regularUpdates["notes"] = combined
+	}
+	// Check the type's fields when the type or the fields change
+	_, typeChanged := updates["issue_type"]
+	_, metadataChanged := updates["metadata"]
+	fieldValues, fieldsChanged := updates["type_fields"].(map[string]string)
+	if typeChanged || metadataChanged || fieldsChanged {
+		metadata := issue.Metadata
+		if md, ok := regularUpdates["metadata"].(json.RawMessage); ok {
+			metadata = md
+		}
+		if fieldsChanged {
+			md, err := typeschema.WithFields(metadata, fieldValues)
+			if err != nil {
+				return fmt.Errorf("updating %s: %w", id, err)
+			}
+			metadata = md
+			regularUpdates["metadata"] = md
+		}
+		issueType := issue.IssueType
+		if t, ok := updates["issue_type"].(string); ok {
+			issueType = types.IssueType(t)
+		}
+		if err := checkTypeFields(issueType, typeschema.FromMetadata(metadata)); err != nil {
+			return fmt.Errorf("updating %s: %w (set them with --field name=value)", id, err)
+		}
+	}
+	if len(regularUpdates) > 0 {
+		if err := w.UpdateIssue(ctx, id, regularUpdates, actor); err != nil {
+			return fmt.Errorf("updating %s: %w", id, err)
+		}
+	}
+
+	// Handle label operations
+	var setLabels, addLabels, removeLabels []string
+	if v, ok := updates["set_labels"].([]string); ok {
+		setLabels = v
+	}
+	if v, ok := updates["add_labels"].([]string); ok {
+		addLabels = v
+	}
+	if v, ok := updates["remove_labels"].([]string); ok {
+		removeLabels = v
+	}
+	if len(setLabels) > 0 || len(addLabels) > 0 || len(removeLabels) > 0 {
+		if err := applyLabelUpdates(ctx, w, id, actor, setLabels, addLabels, removeLabels); err != nil {
+			return fmt.Errorf("updating labels for %s: %w", id, err)
+		}
+	}
+
+	// Handle parent reparenting
+	newParent, ok := updates["parent"].(string)
+	if !ok {
+		return nil
+	}
+	// Validate new parent exists (unless empty string to remove parent)
+	if newParent != "" {
+		parentIssue, err := w.GetIssue(ctx, newParent)
+		if err != nil {
+			return fmt.Errorf("getting parent %s: %w", newParent, err)
+		}
+		if parentIssue == nil {
+			return fmt.Errorf("updating %s: parent issue %s not found", id, newParent)
+		}
+	}
+
+	// Find and remove existing parent-child dependency
+	deps, err := w.GetDependencyRecords(ctx, id)
+	if err != nil {
+		return fmt.Errorf("getting dependencies for %s: %w", id, err)
+	}
+	for _, dep := range deps {
+		if dep.Type == types.DepParentChild {
+			if err := w.RemoveDependency(ctx, id, dep.DependsOnID, actor); err != nil {
+				fmt.Fprintf(os.Stderr, "Error removing old parent dependency: %v\n", err)
+			}
+			break
+		}
+	}
+
+	// Add new parent-child dependency (if not removing parent)
+	if newParent != "" {
+		newDep := &types.Dependency{
+			IssueID:     id,
+			DependsOnID: newParent,
+			Type:        types.DepParentChild,
+		}
+		if err := w.AddDependency(ctx, newDep, actor); err != nil {
+			return fmt.Errorf("adding parent dependency: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,389 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+	"github.com/steveyegge/beads/internal/validation"
+	"github.com/steveyegge/beads/internal/writepolicy"
+)
+
+// validateReport is the --json output of bd validate.
+type validateReport struct {
+	Valid      bool                   `json:"valid"`
+	Checked    int                    `json:"checked"`
+	Errors     int                    `json:"errors"`
+	Warnings   int                    `json:"warnings"`
+	Violations []validation.Violation `json:"violations"`
+}
+
+var validateCmd = &cobra.Command{
+	Use:     "validate [file...]",
+	GroupID: "views",
+	Short:   "Check issues, plan files, or imports against project invariants",
+	Long: `Check issues against project invariants, for use in CI before merging a
+plan file or an import.
+
+With no arguments, checks the open issues in the database. With files, checks
+their issues instead: .yaml/.yml files are plans (see 'bd apply'), .jsonl and
+.json files hold one issue per line (the export format). Use - to read stdin.
+References to issues outside the files are looked up in the database.
+
+Checks:
+  invalid-field        Title, status, type, priority, or estimate out of range
+  required-field       Fields required for the issue type are empty
+  estimate             P0/P1 issues have no estimate
+  cycle                Blocking or parent-child dependencies form a cycle
+  missing-parent       The parent (epic) doesn't exist
+  parent-type          The parent isn't an epic (warning)
+  missing-dependency   A dependency target doesn't exist
+  label-taxonomy       A label doesn't match the configured taxonomy
+  type-field           A field in the type's schema (type-schemas) is missing or invalid
+
+Rules are configured in .beads/config.yaml:
+  validation:
+    required-fields:               # Per type, comma-separated; "*" = all types
+      bug: description
+      epic: description, acceptance_criteria
+    estimate-max-priority: 1       # P0-P1 need estimates; -1 disables
+    labels: "area:*, team:*, tech-debt"   # Allowed label globs; empty = any
+
+Exit codes: 0 valid, 3 violations found, 2 an input doesn't exist,
+1 an input couldn't be read or parsed.
+
+Examples:
+  bd validate                     # Check open issues in the database
+  bd validate --all               # Include closed issues
+  bd validate plan.yaml           # Check a plan before 'bd apply'
+  bd validate export.jsonl --json # JSON violations report for CI`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := rootCtx
+		all, _ := cmd.Flags().GetBool("all")
+		strict, _ := cmd.Flags().GetBool("strict")
+
+		rules, err := loadSpecRules(ctx)
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		lookup := func(id string) *types.Issue {
+			if issue, err := store.GetIssue(ctx, id); err == nil {
+				return issue
+			}
+			resolved, err := utils.ResolvePartialID(ctx, store, id)
+			if err != nil {
+				return nil
+			}
+			issue, _ := store.GetIssue(ctx, resolved)
+			return issue
+		}
+
+		var issues []*types.Issue
+		var violations []validation.Violation
+		if len(args) == 0 {
+			issues, err = loadDatabaseSpecs(ctx, all)
+			if err != nil {
+				FatalErrorRespectJSON("%v", err)
+			}
+		}
+		for _, arg := range args {
+			fileIssues, fileViolations, err := readSpecFile(arg, lookup)
+			if err != nil {
+				validateInputError("%s: %v", arg, err)
+			}
+			issues = append(issues, fileIssues...)
+			violations = append(violations, fileViolations...)
+		}
+		violations = append(violations, validation.CheckSpecs(issues, lookup, rules)...)
+
+		report := validateReport{Checked: len(issues), Violations: violations}
+		if report.Violations == nil {
+			report.Violations = []validation.Violation{}
+		}
+		for _, v := range violations {
+			if v.Severity == validation.SeverityWarning {
+				report.Warnings++
+			} else {
+				report.Errors++
+			}
+		}
+		report.Valid = report.Errors == 0 && (!strict || report.Warnings == 0)
+
+		if jsonOutput {
+			outputJSON(report)
+		} else {
+			printValidateReport(report)
+		}
+		if !report.Valid {
+			os.Exit(exitValidation)
+		}
+	},
+}
+
+// validateInputError reports an unreadable input and exits with
+// exitNotFound or exitError, so CI can tell it apart from violations.
+func validateInputError(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if jsonOutput {
+		outputJSON(map[string]string{"error": msg})
+	} else {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
+	}
+	os.Exit(exitCodeForArgs(args))
+}
+
+// loadSpecRules reads the validation.* rules from config.
+func loadSpecRules(ctx context.Context) (validation.SpecRules, error) {
+	rules := validation.SpecRules{
+		RequiredFields:      make(map[string][]string),
+		EstimateMaxPriority: config.GetInt("validation.estimate-max-priority"),
+	}
+	for issueType, list := range config.GetStringMapString("validation.required-fields") {
+		for _, field := range splitConfigList(list) {
+			if !validation.ValidSpecField(field) {
+				return rules, fmt.Errorf("validation.required-fields.%s: unknown field %q (valid: %s)",
+					issueType, field, strings.Join(validation.SpecFields, ", "))
+			}
+			rules.RequiredFields[issueType] = append(rules.RequiredFields[issueType], field)
+		}
+	}
+	for _, item := range config.GetStringSlice("validation.labels") {
+		rules.LabelPatterns = append(rules.LabelPatterns, splitConfigList(item)...)
+	}
+	for _, pattern := range rules.LabelPatterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return rules, fmt.Errorf("validation.labels: invalid pattern %q", pattern)
+		}
+	}
+	if len(rules.LabelPatterns) > 0 {
+		// Labels bd manages itself
+		rules.LabelPatterns = append(rules.LabelPatterns, planLabelPrefix+"*")
+	}
+
+	var err error
+	if rules.TypeSchemas, err = writepolicy.TypeSchemas(); err != nil {
+		return rules, err
+	}
+	if rules.CustomStatuses, err = store.GetCustomStatuses(ctx); err != nil {
+		rules.CustomStatuses = config.GetCustomStatusesFromYAML()
+	}
+	if rules.CustomTypes, err = store.GetCustomTypes(ctx); err != nil {
+		rules.CustomTypes = config.GetCustomTypesFromYAML()
+	}
+	return rules, nil
+}
+
+func splitConfigList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// loadDatabaseSpecs loads the issues to check from the database, with their
+// labels and dependencies.
+func loadDatabaseSpecs(ctx context.Context, all bool) ([]*types.Issue, error) {
+	filter := types.IssueFilter{}
+	if !all {
+		filter.ExcludeStatus = []types.Status{types.StatusClosed}
+	}
+	issues, err := store.SearchIssues(ctx, "", filter)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	labels, err := store.GetLabelsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("loading labels: %w", err)
+	}
+	deps, err := store.GetDependencyRecordsForIssues(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("loading dependencies: %w", err)
+	}
+	for _, issue := range issues {
+		issue.Labels = labels[issue.ID]
+		issue.Dependencies = deps[issue.ID]
+	}
+	return issues, nil
+}
+
+// readSpecFile reads the issues in a plan or JSONL file. Problems found while
+// converting plan entries are returned as violations.
+func readSpecFile(name string, lookup func(string) *types.Issue) ([]*types.Issue, []validation.Violation, error) {
+	var data []byte
+	var err error
+	if name == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name) // #nosec G304 -- user-specified input file
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	isJSONL := false
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".jsonl", ".json":
+		isJSONL = true
+	case ".yaml", ".yml":
+	default:
+		isJSONL = bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+	}
+	if isJSONL {
+		issues, err := parseIssuesJSONL(data)
+		return issues, nil, err
+	}
+
+	_, entries, err := parsePlan(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	issues, violations := planSpecIssues(entries, lookup)
+	return issues, violations, nil
+}
+
+// parseIssuesJSONL parses one issue per line, skipping blank lines.
+func parseIssuesJSONL(data []byte) ([]*types.Issue, error) {
+	var issues []*types.Issue
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var issue types.Issue
+		if err := json.Unmarshal(text, &issue); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if issue.ID == "" {
+			return nil, fmt.Errorf("line %d: issue has no id", line)
+		}
+		issues = append(issues, &issue)
+	}
+	return issues, scanner.Err()
+}
+
+// planSpecIssues converts plan entries to issues identified by their plan
+// keys, as bd apply would create them. Adopted issues start from their
+// database state.
+func planSpecIssues(entries []*planIssue, lookup func(string) *types.Issue) ([]*types.Issue, []validation.Violation) {
+	var issues []*types.Issue
+	var violations []validation.Violation
+	for _, e := range entries {
+		issue := &types.Issue{Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if e.ID != "" {
+			existing := lookup(e.ID)
+			if existing == nil {
+				violations = append(violations, validation.Violation{Rule: validation.RuleMissingDependency,
+					Severity: validation.SeverityError, IssueID: e.Key, Field: "id",
+					Message: fmt.Sprintf("adopted issue %s does not exist", e.ID)})
+			} else {
+				copied := *existing
+				issue = &copied
+				issue.Dependencies = nil
+			}
+		}
+		issue.ID = e.Key
+		issue.Title = strings.TrimSpace(e.Title)
+
+		if e.Type != nil {
+			issue.IssueType = types.IssueType(utils.NormalizeIssueType(strings.TrimSpace(*e.Type)))
+		}
+		if e.Status != nil {
+			issue.Status = types.Status(strings.TrimSpace(*e.Status))
+			if issue.Status == types.StatusClosed && issue.ClosedAt == nil {
+				now := time.Now()
+				issue.ClosedAt = &now
+			}
+		}
+		if e.Priority != nil {
+			p, err := validation.ValidatePriority(strings.TrimSpace(*e.Priority))
+			if err != nil {
+				violations = append(violations, validation.Violation{Rule: validation.RuleInvalidField,
+					Severity: validation.SeverityError, IssueID: e.Key, Field: "priority", Message: err.Error()})
+			} else {
+				issue.Priority = p
+			}
+		}
+		if e.Assignee != nil {
+			issue.Assignee = strings.TrimSpace(*e.Assignee)
+		}
+		if e.Labels != nil {
+			issue.Labels = *e.Labels
+		}
+		if e.Estimate != nil {
+			est := *e.Estimate
+			issue.EstimatedMinutes = &est
+		}
+		if e.ExternalRef != nil {
+			ref := strings.TrimSpace(*e.ExternalRef)
+			issue.ExternalRef = &ref
+		}
+		for dst, src := range map[*string]*string{
+			&issue.Description:        e.Description,
+			&issue.Design:             e.Design,
+			&issue.AcceptanceCriteria: e.AcceptanceCriteria,
+			&issue.Notes:              e.Notes,
+		} {
+			if src != nil {
+				*dst = *src
+			}
+		}
+
+		if e.Parent != "" {
+			issue.Dependencies = append(issue.Dependencies, &types.Dependency{IssueID: e.Key, DependsOnID: e.Parent, Type: types.DepParentChild})
+		}
+		if e.DependsOn != nil {
+			for _, ref := range *e.DependsOn {
+				issue.Dependencies = append(issue.Dependencies, &types.Dependency{IssueID: e.Key, DependsOnID: ref, Type: types.DepBlocks})
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues, violations
+}
+
+func printValidateReport(r validateReport) {
+	if len(r.Violations) == 0 {
+		fmt.Printf("%s No violations found (%d issues checked)\n", ui.RenderPass("✓"), r.Checked)
+		return
+	}
+	for _, v := range r.Violations {
+		mark := ui.RenderFail("✗")
+		if v.Severity == validation.SeverityWarning {
+			mark = ui.RenderWarn("⚠")
+		}
+		id := v.IssueID
+		if id == "" {
+			id = "-"
+		}
+		fmt.Printf("%s %s %s %s\n", mark, ui.RenderAccent(id), v.Message, ui.RenderMuted("["+v.Rule+"]"))
+	}
+	fmt.Printf("\n%d issues checked: %d errors, %d warnings\n", r.Checked, r.Errors, r.Warnings)
+}
+
+func init() {
+	validateCmd.Flags().Bool("all", false, "Include closed issues when checking the database")
+	validateCmd.Flags().Bool("strict", false, "Treat warnings as violations")
+
+	rootCmd.AddCommand(validateCmd)
+}
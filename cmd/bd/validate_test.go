@@ -0,0 +1,76 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+func TestPlanSpecIssues(t *testing.T) {
+	_, entries, err := parsePlan([]byte(`
+name: p
+issues:
+  - key: epic
+    title: Epic
+    type: epic
+    children:
+      - key: a
+        title: A
+        priority: P0
+        depends_on: [b]
+      - key: b
+        title: B
+        priority: urgent
+        depends_on: [a]
+  - key: legacy
+    id: bd-7
+    title: Legacy
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lookup := func(id string) *types.Issue {
+		if id == "bd-7" {
+			return &types.Issue{ID: "bd-7", Title: "old", Status: types.StatusOpen, Priority: 3, IssueType: types.TypeBug, Description: "kept"}
+		}
+		return nil
+	}
+
+	issues, violations := planSpecIssues(entries, lookup)
+	if len(issues) != 4 {
+		t.Fatalf("issues = %d", len(issues))
+	}
+	if len(violations) != 1 || violations[0].IssueID != "b" || violations[0].Field != "priority" {
+		t.Errorf("conversion violations = %+v", violations)
+	}
+	legacy := issues[3]
+	if legacy.ID != "legacy" || legacy.Title != "Legacy" || legacy.Description != "kept" || legacy.IssueType != types.TypeBug {
+		t.Errorf("adopted issue should start from the database: %+v", legacy)
+	}
+
+	var rules []string
+	for _, v := range validation.CheckSpecs(issues, lookup, validation.SpecRules{EstimateMaxPriority: 1}) {
+		rules = append(rules, v.IssueID+":"+v.Rule)
+	}
+	if got := strings.Join(rules, ","); got != "a:estimate,a:cycle" {
+		t.Errorf("violations = %s", got)
+	}
+}
+
+func TestParseIssuesJSONL(t *testing.T) {
+	issues, err := parseIssuesJSONL([]byte(`{"id":"bd-1","title":"One"}
+
+{"id":"bd-2","title":"Two","dependencies":[{"issue_id":"bd-2","depends_on_id":"bd-1","type":"blocks"}]}
+`))
+	if err != nil || len(issues) != 2 || len(issues[1].Dependencies) != 1 {
+		t.Fatalf("issues=%v err=%v", issues, err)
+	}
+	if _, err := parseIssuesJSONL([]byte("{\"id\":\"bd-1\"}\n{broken\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("err = %v", err)
+	}
+	if _, err := parseIssuesJSONL([]byte(`{"title":"no id"}`)); err == nil {
+		t.Error("expected error for missing id")
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/storage/dolt"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/ui"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// reactionAliases map common spellings to the stored reaction name.
+var reactionAliases = map[string]string{
+	"👍":          types.VoteReaction,
+	"thumbsup":   types.VoteReaction,
+	"up":         types.VoteReaction,
+	"vote":       types.VoteReaction,
+	"👀":          "eyes",
+	"❤️":         "heart",
+	"🎉":          "tada",
+	"👎":          "-1",
+	"thumbsdown": "-1",
+}
+
+// reactionResult is the --json output of bd vote and bd react.
+type reactionResult struct {
+	IssueID  string `json:"issue_id"`
+	Actor    string `json:"actor"`
+	Reaction string `json:"reaction"`
+	Changed  bool   `json:"changed"` // False when the reaction was already there (or absent, for --remove)
+	Votes    int    `json:"votes"`
+}
+
+// normalizeReaction validates a reaction name and expands aliases.
+func normalizeReaction(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if alias, ok := reactionAliases[strings.ToLower(s)]; ok {
+		return alias, nil
+	}
+	if s == "" {
+		return "", fmt.Errorf("reaction cannot be empty")
+	}
+	if len(s) > 64 {
+		return "", fmt.Errorf("reaction %q is too long (max 64 bytes)", s)
+	}
+	if strings.IndexFunc(s, unicode.IsSpace) >= 0 {
+		return "", fmt.Errorf("reaction %q cannot contain spaces", s)
+	}
+	return s, nil
+}
+
+// summarizeReactions groups reactions by kind: votes first, then by count.
+func summarizeReactions(reactions []*types.Reaction) []*types.ReactionSummary {
+	byKind := make(map[string]*types.ReactionSummary)
+	var out []*types.ReactionSummary
+	for _, r := range reactions {
+		sum, ok := byKind[r.Reaction]
+		if !ok {
+			sum = &types.ReactionSummary{Reaction: r.Reaction}
+			byKind[r.Reaction] = sum
+			out = append(out, sum)
+		}
+		sum.Count++
+		sum.Actors = append(sum.Actors, r.Actor)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if vi, vj := out[i].Reaction == types.VoteReaction, out[j].Reaction == types.VoteReaction; vi != vj {
+			return vi
+		}
+		return out[i].Count > out[j].Count
+	})
+	return out
+}
+
+// formatReactions renders reaction summaries on one line, e.g. "+1 ×3  eyes ×1".
+func formatReactions(sums []*types.ReactionSummary) string {
+	parts := make([]string, len(sums))
+	for i, s := range sums {
+		parts[i] = fmt.Sprintf("%s ×%d", s.Reaction, s.Count)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// setReaction adds or removes the current actor's reaction on each issue.
+func setReaction(ids []string, reaction string, remove bool) {
+	ctx := rootCtx
+	actor := getActorWithGit()
+	var results []reactionResult
+	for _, id := range ids {
+		fullID, err := utils.ResolvePartialID(ctx, store, id)
+		if err != nil {
+			FatalErrorRespectJSON("resolving %s: %v", id, err)
+		}
+		var changed bool
+		if remove {
+			changed, err = store.RemoveReaction(ctx, fullID, actor, reaction)
+		} else {
+			changed, err = store.AddReaction(ctx, fullID, actor, reaction)
+		}
+		if err != nil {
+			FatalErrorRespectJSON("%s: %v", fullID, err)
+		}
+		counts, err := store.GetVoteCounts(ctx, []string{fullID})
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		results = append(results, reactionResult{IssueID: fullID, Actor: actor, Reaction: reaction, Changed: changed, Votes: counts[fullID]})
+		SetLastTouchedID(fullID)
+	}
+
+	if jsonOutput {
+		if len(results) == 1 {
+			outputJSON(results[0])
+		} else {
+			outputJSON(results)
+		}
+		return
+	}
+	for _, r := range results {
+		var msg string
+		switch {
+		case reaction == types.VoteReaction && remove && r.Changed:
+			msg = "Removed vote from"
+		case reaction == types.VoteReaction && remove:
+			msg = "No vote to remove on"
+		case reaction == types.VoteReaction && r.Changed:
+			msg = "Voted for"
+		case reaction == types.VoteReaction:
+			msg = "Already voted for"
+		case remove && r.Changed:
+			msg = fmt.Sprintf("Removed %s from", reaction)
+		case remove:
+			msg = fmt.Sprintf("No %s reaction to remove on", reaction)
+		case r.Changed:
+			msg = fmt.Sprintf("Reacted %s to", reaction)
+		default:
+			msg = fmt.Sprintf("Already reacted %s to", reaction)
+		}
+		mark := ui.RenderPass("✓")
+		if !r.Changed {
+			mark = ui.RenderMuted("·")
+		}
+		fmt.Printf("%s %s %s %s\n", mark, msg, ui.RenderID(r.IssueID), ui.RenderMuted(fmt.Sprintf("(%d votes)", r.Votes)))
+	}
+}
+
+var voteCmd = &cobra.Command{
+	Use:     "vote <id> [<id>...]",
+	GroupID: "issues",
+	Short:   "Vote for issues",
+	Long: `Vote for issues to signal demand. Each actor has one vote per issue;
+voting again is a no-op. Votes are counted in 'bd list --sort votes',
+'bd ready --sort votes', and shown by 'bd show'.
+
+Examples:
+  bd vote bd-42               # Vote as the current actor
+  bd vote bd-42 bd-43         # Vote for several issues
+  bd vote bd-42 --remove      # Retract your vote
+  bd --actor pm-team vote bd-42`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		CheckReadonly("vote")
+		remove, _ := cmd.Flags().GetBool("remove")
+		setReaction(args, types.VoteReaction, remove)
+	},
+}
+
+var reactCmd = &cobra.Command{
+	Use:     "react <id> [reaction]",
+	GroupID: "issues",
+	Short:   "Add a reaction to an issue, or list its reactions",
+	Long: `Add a reaction (a short name or emoji such as eyes, heart, or 🎉) to an
+issue. Each actor can give each reaction once. The +1 reaction (also 👍,
+thumbsup, up) is a vote; see 'bd vote'.
+
+With no reaction, lists the issue's reactions and who gave them.
+
+Examples:
+  bd react bd-42 eyes          # React as the current actor
+  bd react bd-42 eyes --remove # Remove your reaction
+  bd react bd-42               # List reactions`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		remove, _ := cmd.Flags().GetBool("remove")
+		if len(args) == 1 {
+			if remove {
+				FatalErrorRespectJSON("--remove needs a reaction")
+			}
+			listReactions(args[0])
+			return
+		}
+		CheckReadonly("react")
+		reaction, err := normalizeReaction(args[1])
+		if err != nil {
+			FatalErrorRespectJSON("%v", err)
+		}
+		setReaction(args[:1], reaction, remove)
+	},
+}
+
+func listReactions(id string) {
+	ctx := rootCtx
+	fullID, err := utils.ResolvePartialID(ctx, store, id)
+	if err != nil {
+		FatalErrorRespectJSON("resolving %s: %v", id, err)
+	}
+	reactions, err := store.GetReactions(ctx, fullID)
+	if err != nil {
+		FatalErrorRespectJSON("%v", err)
+	}
+	sums := summarizeReactions(reactions)
+	if jsonOutput {
+		if sums == nil {
+			sums = []*types.ReactionSummary{}
+		}
+		outputJSON(sums)
+		return
+	}
+	if len(sums) == 0 {
+		fmt.Printf("No reactions on %s\n", fullID)
+		return
+	}
+	fmt.Printf("\nReactions on %s:\n\n", fullID)
+	for _, s := range sums {
+		fmt.Printf("  %-8s %d  %s\n", s.Reaction, s.Count, ui.RenderMuted(strings.Join(s.Actors, ", ")))
+	}
+	fmt.Println()
+}
+
+func init() {
+	voteCmd.Flags().Bool("remove", false, "Retract your vote")
+	reactCmd.Flags().Bool("remove", false, "Remove your reaction")
+
+	rootCmd.AddCommand(voteCmd)
+	rootCmd.AddCommand(reactCmd)
+}
+
+// voteCountsForSort loads vote counts for issues when sorting by votes.
+func voteCountsForSort(ctx context.Context, s *dolt.DoltStore, issues []*types.Issue, sortBy string) map[string]int {
+	if sortBy != "votes" || s == nil {
+		return nil
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	votes, err := s.GetVoteCounts(ctx, ids)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to get vote counts: %v\n", err)
+	}
+	return votes
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestNormalizeReaction(t *testing.T) {
+	for in, want := range map[string]string{"👍": "+1", "Vote": "+1", " eyes ": "eyes", "🚀": "🚀"} {
+		if got, err := normalizeReaction(in); err != nil || got != want {
+			t.Errorf("normalizeReaction(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "two words", strings.Repeat("x", 65)} {
+		if _, err := normalizeReaction(bad); err == nil {
+			t.Errorf("normalizeReaction(%q) should fail", bad)
+		}
+	}
+}
+
+func TestSummarizeReactions(t *testing.T) {
+	sums := summarizeReactions([]*types.Reaction{
+		{Actor: "alice", Reaction: "eyes"},
+		{Actor: "bob", Reaction: "eyes"},
+		{Actor: "carol", Reaction: "tada"},
+		{Actor: "alice", Reaction: types.VoteReaction},
+	})
+	if got := formatReactions(sums); got != "+1 ×1  eyes ×2  tada ×1" {
+		t.Errorf("formatReactions = %q", got)
+	}
+	if strings.Join(sums[1].Actors, ",") != "alice,bob" {
+		t.Errorf("eyes actors = %v", sums[1].Actors)
+	}
+}
+
+func TestSortIssuesByVotes(t *testing.T) {
+	issues := []*types.Issue{
+		{ID: "a", Priority: 1},
+		{ID: "b", Priority: 3},
+		{ID: "c", Priority: 2},
+		{ID: "d", Priority: 0},
+	}
+	votes := map[string]int{"b": 5, "c": 2, "a": 2}
+	sortIssues(issues, "votes", false, votes)
+	var ids []string
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	if got := strings.Join(ids, ","); got != "b,a,c,d" {
+		t.Errorf("order = %s", got)
+	}
+	sortIssues(issues, "votes", true, votes)
+	if issues[0].ID != "d" {
+		t.Errorf("reverse should put the fewest votes first, got %s", issues[0].ID)
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/beads/internal/beads"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/configfile"
+	"github.com/steveyegge/beads/internal/utils"
+)
+
+// dbResolution is how bd resolved the database it uses.
+type dbResolution struct {
+	DatabasePath string `json:"database_path"`
+	BeadsDir     string `json:"beads_dir"`
+	Source       string `json:"source"`            // --db, BD_DB, BD_DATABASE, profile, config, BEADS_DIR, BEADS_DB, search
+	Profile      string `json:"profile,omitempty"` // Active config profile
+	Backend      string `json:"backend"`
+	DoltMode     string `json:"dolt_mode"`
+	Server       string `json:"server,omitempty"`          // host:port in server mode
+	ServerDB     string `json:"server_database,omitempty"` // Database name in server mode
+	Exists       bool   `json:"exists"`
+}
+
+var whichDBCmd = &cobra.Command{
+	Use:     "which-db",
+	GroupID: "setup",
+	Short:   "Show which database bd resolves to, and why",
+	Long: `Show the database bd uses from the current directory, which setting
+selected it, and its backend, without opening it.
+
+bd resolves the database in this order, first match wins:
+
+  1. --db <path>
+  2. BD_DB or BD_DATABASE environment variable
+  3. db in the config profile selected by --profile or BD_PROFILE
+  4. db in config.yaml
+  5. BEADS_DIR environment variable (a .beads directory)
+  6. BEADS_DB environment variable (deprecated)
+  7. The nearest .beads directory in the current directory or its
+     ancestors, like git: nested projects use their own .beads. The
+     search stops at the git repository root; in a git worktree the
+     main repository's .beads is tried first.
+
+Examples:
+  bd which-db
+  bd which-db --json
+  bd --profile experiments which-db
+  cd services/api && bd which-db   # Nested project in a mono-repo`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		r := resolveDatabase(cmd.Flags().Changed("db"))
+		if r == nil {
+			if jsonOutput {
+				outputJSON(map[string]string{"error": "no beads database found"})
+				os.Exit(1)
+			}
+			FatalErrorWithHint("no beads database found from "+currentDir(),
+				"run 'bd init' to create one, or point --db, BD_DATABASE, or BEADS_DIR at it")
+		}
+		if jsonOutput {
+			outputJSON(r)
+			return
+		}
+		fmt.Println(r.DatabasePath)
+		fmt.Printf("  beads dir:   %s\n", r.BeadsDir)
+		fmt.Printf("  resolved by: %s\n", describeDBSource(r.Source))
+		if r.Profile != "" {
+			fmt.Printf("  profile:     %s\n", r.Profile)
+		}
+		backend := fmt.Sprintf("%s (%s)", r.Backend, r.DoltMode)
+		if r.Server != "" {
+			backend = fmt.Sprintf("%s (server %s, database %s)", r.Backend, r.Server, r.ServerDB)
+		}
+		fmt.Printf("  backend:     %s\n", backend)
+		if !r.Exists {
+			fmt.Println("  (database directory doesn't exist yet)")
+		}
+	},
+}
+
+// resolveDatabase follows bd's database resolution order, returning nil if
+// no database is found. flagSet reports whether --db was given; dbPath
+// already holds --db, BD_DB/BD_DATABASE, or the profile's or config.yaml's
+// db by then.
+func resolveDatabase(flagSet bool) *dbResolution {
+	r := &dbResolution{Profile: config.ActiveProfile()}
+	switch {
+	case flagSet:
+		r.DatabasePath, r.Source = dbPath, "--db"
+	case dbPath != "":
+		r.DatabasePath, r.Source = dbPath, "config"
+		for _, env := range []string{"BD_DB", "BD_DATABASE"} {
+			if os.Getenv(env) != "" {
+				r.Source = env
+				break
+			}
+		}
+		if r.Source == "config" && config.GetValueSource("db") == config.SourceProfile {
+			r.Source = "profile"
+		}
+	default:
+		r.DatabasePath, r.Source = beads.ResolveDatabasePath()
+	}
+	if r.DatabasePath == "" {
+		return nil
+	}
+	r.DatabasePath = utils.CanonicalizePath(r.DatabasePath)
+	r.BeadsDir = filepath.Dir(r.DatabasePath)
+
+	r.Backend, r.DoltMode = configfile.BackendDolt, configfile.DoltModeEmbedded
+	if cfg, err := configfile.Load(r.BeadsDir); err == nil && cfg != nil {
+		r.Backend, r.DoltMode = cfg.GetBackend(), cfg.GetDoltMode()
+		if cfg.IsDoltServerMode() {
+			r.DoltMode = configfile.DoltModeServer
+			r.Server = fmt.Sprintf("%s:%d", cfg.GetDoltServerHost(), cfg.GetDoltServerPort())
+			r.ServerDB = cfg.GetDoltDatabase()
+		}
+	}
+	if r.Server != "" {
+		r.Exists = true // Lives on the server
+	} else if info, err := os.Stat(r.DatabasePath); err == nil && info.IsDir() {
+		r.Exists = true
+	}
+	return r
+}
+
+func describeDBSource(source string) string {
+	switch source {
+	case "--db":
+		return "--db flag"
+	case "BD_DB", "BD_DATABASE", "BEADS_DIR", "BEADS_DB":
+		return source + " environment variable"
+	case "profile":
+		return fmt.Sprintf("db in profile %s of %s", config.ActiveProfile(), config.ConfigFileUsed())
+	case "config":
+		return "db in " + config.ConfigFileUsed()
+	case beads.DatabaseFromSearch:
+		return "nearest .beads above " + currentDir()
+	}
+	return source
+}
+
+func currentDir() string {
+	if cwd, err := os.Getwd(); err == nil {
+		return cwd
+	}
+	return "."
+}
+
+func init() {
+	rootCmd.AddCommand(whichDBCmd)
+}
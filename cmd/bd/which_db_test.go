@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveDatabase(t *testing.T) {
+	oldDBPath := dbPath
+	t.Cleanup(func() { dbPath = oldDBPath })
+	t.Setenv("BD_DB", "")
+
+	beadsDir := t.TempDir()
+	metadata := `{"database": "dolt", "dolt_mode": "server", "dolt_server_port": 3400, "dolt_database": "proj"}`
+	if err := os.WriteFile(filepath.Join(beadsDir, "metadata.json"), []byte(metadata), 0600); err != nil {
+		t.Fatal(err)
+	}
+	dbPath = filepath.Join(beadsDir, "dolt")
+
+	r := resolveDatabase(true)
+	if r == nil || r.Source != "--db" || r.DoltMode != "server" || r.ServerDB != "proj" || !r.Exists {
+		t.Fatalf("resolveDatabase(--db) = %+v", r)
+	}
+	if r.Server != "127.0.0.1:3400" {
+		t.Errorf("server = %q, want 127.0.0.1:3400", r.Server)
+	}
+
+	t.Setenv("BD_DATABASE", dbPath)
+	if r := resolveDatabase(false); r == nil || r.Source != "BD_DATABASE" {
+		t.Errorf("resolveDatabase(BD_DATABASE) = %+v", r)
+	}
+}
@@ -0,0 +1,178 @@
+// Package acceptance reads and updates the acceptance criteria kept in an
+// issue's acceptance_criteria field as a markdown checklist, where each
+// item's box holds its state: "[ ]" unverified, "[x]" pass, "[!]" fail.
+//
+// Keeping the checklist in the existing text field means criteria export,
+// import, and sync like any other field, and stay readable in bd show.
+// Text around the checklist (headings, prose) is preserved by updates.
+package acceptance
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// State is a criterion's verification state.
+type State string
+
+// Verification states.
+const (
+	Unverified State = "unverified"
+	Pass       State = "pass"
+	Fail       State = "fail"
+)
+
+// ParseState parses a state name.
+func ParseState(s string) (State, error) {
+	switch State(strings.ToLower(strings.TrimSpace(s))) {
+	case Unverified:
+		return Unverified, nil
+	case Pass:
+		return Pass, nil
+	case Fail:
+		return Fail, nil
+	}
+	return "", fmt.Errorf("invalid state %q (want pass, fail, or unverified)", s)
+}
+
+// box is the checkbox for the state.
+func (s State) box() string {
+	switch s {
+	case Pass:
+		return "[x]"
+	case Fail:
+		return "[!]"
+	default:
+		return "[ ]"
+	}
+}
+
+// Criterion is one acceptance criterion.
+type Criterion struct {
+	Text  string `json:"text"`
+	State State  `json:"state"`
+
+	line int // Index of the criterion's line in the field
+}
+
+// listItem matches a markdown list item, with an optional [ ]/[x]/[!] box.
+var listItem = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(?:\[([ xX!])\]\s*)?(.*)$`)
+
+// Parse returns the criteria in text. List items ("- [ ] works",
+// "1. works") are the criteria when there are any; otherwise each
+// non-blank line is one. Headings are never criteria.
+func Parse(text string) []Criterion {
+	var listed, plain []Criterion
+	for i, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if m := listItem.FindStringSubmatch(line); m != nil {
+			if item := strings.TrimSpace(m[4]); item != "" {
+				listed = append(listed, Criterion{Text: item, State: boxState(m[3]), line: i})
+			}
+			continue
+		}
+		plain = append(plain, Criterion{Text: trimmed, State: Unverified, line: i})
+	}
+	if len(listed) > 0 {
+		return listed
+	}
+	if plain == nil {
+		return []Criterion{}
+	}
+	return plain
+}
+
+// boxState returns the state of a checkbox's contents.
+func boxState(box string) State {
+	switch box {
+	case "x", "X":
+		return Pass
+	case "!":
+		return Fail
+	default:
+		return Unverified
+	}
+}
+
+// SetState sets the state of the criterion at index (0-based) and returns
+// the updated text.
+func SetState(text string, index int, state State) (string, error) {
+	criteria := Parse(text)
+	if index < 0 || index >= len(criteria) {
+		return "", fmt.Errorf("no criterion %d (there are %d)", index+1, len(criteria))
+	}
+	lines := strings.Split(text, "\n")
+	c := criteria[index]
+	indent, marker := "", "-"
+	if m := listItem.FindStringSubmatch(lines[c.line]); m != nil {
+		indent, marker = m[1], m[2]
+	}
+	lines[c.line] = fmt.Sprintf("%s%s %s %s", indent, marker, state.box(), c.Text)
+	return strings.Join(lines, "\n"), nil
+}
+
+// Add appends an unverified criterion and returns the updated text.
+// Criteria written as plain lines become list items first, so they
+// remain criteria alongside the new one.
+func Add(text, item string) string {
+	item = strings.TrimSpace(item)
+	lines := strings.Split(text, "\n")
+	if criteria := Parse(text); len(criteria) > 0 && !listItem.MatchString(lines[criteria[0].line]) {
+		for _, c := range criteria {
+			lines[c.line] = "- " + Unverified.box() + " " + c.Text
+		}
+	}
+	text = strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if text != "" {
+		text += "\n"
+	}
+	return text + "- " + Unverified.box() + " " + item
+}
+
+// Remove deletes the criterion at index (0-based) and returns the updated
+// text.
+func Remove(text string, index int) (string, error) {
+	criteria := Parse(text)
+	if index < 0 || index >= len(criteria) {
+		return "", fmt.Errorf("no criterion %d (there are %d)", index+1, len(criteria))
+	}
+	lines := strings.Split(text, "\n")
+	line := criteria[index].line
+	lines = append(lines[:line], lines[line+1:]...)
+	return strings.Join(lines, "\n"), nil
+}
+
+// Count returns how many criteria are in each state.
+func Count(criteria []Criterion) (pass, fail, unverified int) {
+	for _, c := range criteria {
+		switch c.State {
+		case Pass:
+			pass++
+		case Fail:
+			fail++
+		default:
+			unverified++
+		}
+	}
+	return pass, fail, unverified
+}
+
+// Describe summarizes criteria states, e.g. "2/3 pass, 1 fail".
+func Describe(pass, fail, unverified int) string {
+	s := fmt.Sprintf("%d/%d pass", pass, pass+fail+unverified)
+	if fail > 0 {
+		s += fmt.Sprintf(", %d fail", fail)
+	}
+	return s
+}
+
+// AllPass reports whether every criterion passes. It is true when there
+// are none.
+func AllPass(criteria []Criterion) bool {
+	pass, _, _ := Count(criteria)
+	return pass == len(criteria)
+}
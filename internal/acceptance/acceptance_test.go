@@ -0,0 +1,102 @@
+package acceptance
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Criterion
+	}{
+		{"empty", "", []Criterion{}},
+		{"checkboxes", "## Acceptance\n- [ ] Login works\n- [x] Tests pass\n* [!] Docs updated\n",
+			[]Criterion{{"Login works", Unverified, 1}, {"Tests pass", Pass, 2}, {"Docs updated", Fail, 3}}},
+		{"numbered, prose ignored", "Must satisfy:\n1. Fast\n2) Correct [X]\n",
+			[]Criterion{{"Fast", Unverified, 1}, {"Correct [X]", Unverified, 2}}},
+		{"plain lines", "Login works\n\nTests pass",
+			[]Criterion{{"Login works", Unverified, 0}, {"Tests pass", Unverified, 2}}},
+		{"empty items skipped", "- [ ]\n- [X] Done",
+			[]Criterion{{"Done", Pass, 1}}},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Parse = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetState(t *testing.T) {
+	text := "## Acceptance\nMust satisfy:\n  1. Fast\n- [x] Correct"
+	got, err := SetState(text, 0, Fail)
+	if err != nil {
+		t.Fatalf("SetState: %v", err)
+	}
+	if want := "## Acceptance\nMust satisfy:\n  1. [!] Fast\n- [x] Correct"; got != want {
+		t.Errorf("SetState = %q, want %q", got, want)
+	}
+	got, _ = SetState(got, 1, Unverified)
+	if want := "## Acceptance\nMust satisfy:\n  1. [!] Fast\n- [ ] Correct"; got != want {
+		t.Errorf("SetState = %q, want %q", got, want)
+	}
+	if _, err := SetState(text, 2, Pass); err == nil {
+		t.Error("SetState out of range succeeded")
+	}
+	got, _ = SetState("Login works", 0, Pass)
+	if want := "- [x] Login works"; got != want {
+		t.Errorf("SetState on a plain line = %q, want %q", got, want)
+	}
+}
+
+func TestAddRemove(t *testing.T) {
+	if got, want := Add("", "Fast"), "- [ ] Fast"; got != want {
+		t.Errorf("Add to empty = %q, want %q", got, want)
+	}
+	got := Add("Login works\nTests pass\n", "Fast")
+	if want := "- [ ] Login works\n- [ ] Tests pass\n- [ ] Fast"; got != want {
+		t.Errorf("Add to plain lines = %q, want %q", got, want)
+	}
+	got, err := Remove(got, 1)
+	if err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if want := "- [ ] Login works\n- [ ] Fast"; got != want {
+		t.Errorf("Remove = %q, want %q", got, want)
+	}
+	if _, err := Remove(got, 5); err == nil {
+		t.Error("Remove out of range succeeded")
+	}
+}
+
+func TestCountAndAllPass(t *testing.T) {
+	criteria := Parse("- [x] a\n- [!] b\n- [ ] c\n- [x] d")
+	if pass, fail, unverified := Count(criteria); pass != 2 || fail != 1 || unverified != 1 {
+		t.Errorf("Count = %d, %d, %d; want 2, 1, 1", pass, fail, unverified)
+	}
+	if AllPass(criteria) {
+		t.Error("AllPass with a failing criterion")
+	}
+	if !AllPass(Parse("- [x] a")) || !AllPass(Parse("")) {
+		t.Error("AllPass = false for all-passing or no criteria")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	if got, want := Describe(2, 0, 1), "2/3 pass"; got != want {
+		t.Errorf("Describe = %q, want %q", got, want)
+	}
+	if got, want := Describe(1, 1, 1), "1/3 pass, 1 fail"; got != want {
+		t.Errorf("Describe = %q, want %q", got, want)
+	}
+}
+
+func TestParseState(t *testing.T) {
+	if s, err := ParseState(" PASS "); err != nil || s != Pass {
+		t.Errorf("ParseState = %q, %v", s, err)
+	}
+	if _, err := ParseState("done"); err == nil {
+		t.Error("ParseState(done) succeeded")
+	}
+}
@@ -2,6 +2,7 @@ package audit
 
 import (
 	"bufio"
+	"bytes"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
@@ -125,6 +126,57 @@ func Append(e *Entry) (string, error) {
 	return e.ID, nil
 }
 
+// Rewrite applies fn to every entry in .beads/interactions.jsonl and
+// returns how many entries fn changed (reported true for). Unless dryRun,
+// the file is replaced atomically when any entry changed.
+//
+// This is the one exception to the append-only rule, for erasure requests
+// (bd actor erase). Lines that don't parse as entries are kept unchanged.
+func Rewrite(fn func(*Entry) bool, dryRun bool) (int, error) {
+	p, err := Path()
+	if err != nil {
+		return 0, err
+	}
+	data, err := os.ReadFile(p) // #nosec G304 -- path is under .beads/
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read interactions log: %w", err)
+	}
+
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	changed := 0
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		trimmed := bytes.TrimSpace(line)
+		var e Entry
+		if len(trimmed) == 0 || json.Unmarshal(trimmed, &e) != nil || !fn(&e) {
+			out.Write(line)
+			continue
+		}
+		if err := enc.Encode(&e); err != nil {
+			return 0, fmt.Errorf("failed to encode interactions log entry: %w", err)
+		}
+		changed++
+	}
+	if changed == 0 || dryRun {
+		return changed, nil
+	}
+
+	tmp := p + ".tmp"
+	// nolint:gosec // JSONL is intended to be shared via git across clones/tools.
+	if err := os.WriteFile(tmp, out.Bytes(), 0644); err != nil {
+		return 0, fmt.Errorf("failed to write interactions log: %w", err)
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		_ = os.Remove(tmp)
+		return 0, fmt.Errorf("failed to replace interactions log: %w", err)
+	}
+	return changed, nil
+}
+
 func newID() (string, error) {
 	var b [4]byte
 	if _, err := rand.Read(b[:]); err != nil {
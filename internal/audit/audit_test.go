@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -52,3 +53,54 @@ func TestAppend_CreatesFileAndWritesJSONL(t *testing.T) {
 		t.Fatalf("expected 2 lines, got %d", lines)
 	}
 }
+
+func TestRewrite_ReplacesMatchingEntries(t *testing.T) {
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), []byte{}, 0644); err != nil {
+		t.Fatalf("write issues.jsonl: %v", err)
+	}
+	t.Setenv("BEADS_DIR", beadsDir)
+
+	for _, actor := range []string{"alice", "bob", "alice"} {
+		if _, err := Append(&Entry{Kind: "tool_call", Actor: actor}); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+	p := filepath.Join(beadsDir, FileName)
+	f, err := os.OpenFile(p, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	_, _ = f.WriteString("not json\n")
+	_ = f.Close()
+
+	rename := func(e *Entry) bool {
+		if e.Actor != "alice" {
+			return false
+		}
+		e.Actor = "erased"
+		return true
+	}
+	before, _ := os.ReadFile(p)
+	if n, err := Rewrite(rename, true); err != nil || n != 2 {
+		t.Fatalf("dry run: n=%d err=%v", n, err)
+	}
+	if after, _ := os.ReadFile(p); string(after) != string(before) {
+		t.Fatalf("dry run modified the log")
+	}
+	if n, err := Rewrite(rename, false); err != nil || n != 2 {
+		t.Fatalf("rewrite: n=%d err=%v", n, err)
+	}
+
+	data, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	got := string(data)
+	if strings.Contains(got, "alice") || strings.Count(got, `"actor":"erased"`) != 2 || !strings.Contains(got, "bob") || !strings.HasSuffix(got, "not json\n") {
+		t.Fatalf("unexpected log after rewrite:\n%s", got)
+	}
+}
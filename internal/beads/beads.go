@@ -255,6 +255,21 @@ type Transaction = storage.Transaction
 //
 // Returns empty string if no database is found.
 func FindDatabasePath() string {
+	path, _ := ResolveDatabasePath()
+	return path
+}
+
+// Sources of a database found by ResolveDatabasePath.
+const (
+	DatabaseFromBeadsDir = "BEADS_DIR" // $BEADS_DIR
+	DatabaseFromBeadsDB  = "BEADS_DB"  // $BEADS_DB
+	DatabaseFromSearch   = "search"    // .beads in the current directory or an ancestor
+)
+
+// ResolveDatabasePath is FindDatabasePath that also reports which step of
+// the search order found the database, as one of the DatabaseFrom
+// constants. Both are empty if no database is found.
+func ResolveDatabasePath() (path, source string) {
 	// 1. Check BEADS_DIR environment variable (preferred)
 	if beadsDir := os.Getenv("BEADS_DIR"); beadsDir != "" {
 		// Canonicalize the path to prevent nested .beads directories
@@ -265,7 +280,7 @@ func FindDatabasePath() string {
 
 		// Use helper to find database (no warnings for BEADS_DIR - user explicitly set it)
 		if dbPath := findDatabaseInBeadsDir(absBeadsDir, false); dbPath != "" {
-			return dbPath
+			return dbPath, DatabaseFromBeadsDir
 		}
 
 		// BEADS_DIR is set but no database found - this is OK for --no-db mode
@@ -274,16 +289,16 @@ func FindDatabasePath() string {
 
 	// 2. Check BEADS_DB environment variable (deprecated but still supported)
 	if envDB := os.Getenv("BEADS_DB"); envDB != "" {
-		return utils.CanonicalizePath(envDB)
+		return utils.CanonicalizePath(envDB), DatabaseFromBeadsDB
 	}
 
 	// 3. Search for .beads/*.db in current directory and ancestors
 	if foundDB := findDatabaseInTree(); foundDB != "" {
-		return utils.CanonicalizePath(foundDB)
+		return utils.CanonicalizePath(foundDB), DatabaseFromSearch
 	}
 
 	// No fallback to ~/.beads - return empty string
-	return ""
+	return "", ""
 }
 
 // hasBeadsProjectFiles checks if a .beads directory contains actual project files.
@@ -0,0 +1,239 @@
+// Package boardimport imports kanban boards from GitHub Projects and ZenHub.
+//
+// Each source is read into a Board: its columns in board order and the
+// cards on them, with epics and blocking links given as references to
+// other cards. A Mapping from column names to beads statuses turns the
+// cards into issues; the caller reviews the mapping before importing.
+package boardimport
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Board is a board read from GitHub Projects or ZenHub.
+type Board struct {
+	Source  string   `json:"source"`  // "github" or "zenhub"
+	Name    string   `json:"name"`    // Project or workspace title
+	Columns []string `json:"columns"` // Column names in board order
+	Cards   []*Card  `json:"cards"`
+}
+
+// Card is one issue on a board.
+type Card struct {
+	// Ref identifies the card: its GitHub issue URL, or "" for a draft
+	// that has no issue behind it. It becomes the beads external_ref.
+	Ref         string    `json:"ref,omitempty"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Column      string    `json:"column"`           // "" when the card has no column
+	Closed      bool      `json:"closed,omitempty"` // The issue is closed, whatever its column
+	Epic        bool      `json:"epic,omitempty"`
+	Labels      []string  `json:"labels,omitempty"`
+	Assignee    string    `json:"assignee,omitempty"`
+	Parent      string    `json:"parent,omitempty"`     // Ref of the card's epic
+	BlockedBy   []string  `json:"blocked_by,omitempty"` // Refs of cards blocking it
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+}
+
+// NoColumn is the column name shown for cards that aren't in a column.
+const NoColumn = "(no column)"
+
+// Mapping maps board column names to beads statuses.
+type Mapping map[string]types.Status
+
+// columnStatuses are the statuses guessed for common column names.
+var columnStatuses = map[string]types.Status{
+	"backlog": types.StatusOpen, "todo": types.StatusOpen, "to do": types.StatusOpen,
+	"new": types.StatusOpen, "new issues": types.StatusOpen, "icebox": types.StatusDeferred,
+	"ready": types.StatusOpen, "up next": types.StatusOpen, "triage": types.StatusOpen,
+	"in progress": types.StatusInProgress, "doing": types.StatusInProgress,
+	"in review": types.StatusInProgress, "review": types.StatusInProgress,
+	"review/qa": types.StatusInProgress, "qa": types.StatusInProgress,
+	"blocked": types.StatusBlocked, "on hold": types.StatusDeferred,
+	"done": types.StatusClosed, "closed": types.StatusClosed, "complete": types.StatusClosed,
+	"completed": types.StatusClosed, "shipped": types.StatusClosed, "released": types.StatusClosed,
+}
+
+// GuessStatus returns the status a column name suggests, and false when
+// it suggests none (such columns map to open).
+func GuessStatus(column string) (types.Status, bool) {
+	if status, ok := columnStatuses[strings.ToLower(strings.TrimSpace(column))]; ok {
+		return status, true
+	}
+	return types.StatusOpen, false
+}
+
+// DefaultMapping guesses a status for each of b's columns.
+func DefaultMapping(b *Board) Mapping {
+	m := make(Mapping)
+	for _, column := range b.columnNames() {
+		m[column], _ = GuessStatus(column)
+	}
+	return m
+}
+
+// Set maps a column, given as "Column=status", overriding the guess. The
+// column name is matched case-insensitively against b's columns.
+func (m Mapping) Set(b *Board, spec string) error {
+	column, status, ok := strings.Cut(spec, "=")
+	if !ok {
+		return fmt.Errorf("invalid column mapping %q (want Column=status)", spec)
+	}
+	column, status = strings.TrimSpace(column), strings.TrimSpace(status)
+	s := types.Status(status)
+	switch s {
+	case types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusDeferred, types.StatusClosed:
+	default:
+		return fmt.Errorf("invalid status %q for column %q (valid: open, in_progress, blocked, deferred, closed)", status, column)
+	}
+	for _, name := range b.columnNames() {
+		if strings.EqualFold(name, column) {
+			m[name] = s
+			return nil
+		}
+	}
+	return fmt.Errorf("board has no column %q (columns: %s)", column, strings.Join(b.columnNames(), ", "))
+}
+
+// columnNames returns b's columns, with NoColumn last if any card lacks one.
+func (b *Board) columnNames() []string {
+	names := append([]string{}, b.Columns...)
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		seen[name] = true
+	}
+	for _, c := range b.Cards {
+		column := c.Column
+		if column == "" {
+			column = NoColumn
+		}
+		if !seen[column] {
+			seen[column] = true
+			names = append(names, column)
+		}
+	}
+	return names
+}
+
+// ColumnCount is one row of the mapping review.
+type ColumnCount struct {
+	Column  string       `json:"column"`
+	Status  types.Status `json:"status"`
+	Guessed bool         `json:"guessed"` // Status came from the column name
+	Cards   int          `json:"cards"`
+}
+
+// Review summarizes how m maps b's columns, in board order.
+func (m Mapping) Review(b *Board) []ColumnCount {
+	counts := make(map[string]int)
+	for _, c := range b.Cards {
+		column := c.Column
+		if column == "" {
+			column = NoColumn
+		}
+		counts[column]++
+	}
+	var rows []ColumnCount
+	for _, column := range b.columnNames() {
+		guess, guessed := GuessStatus(column)
+		rows = append(rows, ColumnCount{Column: column, Status: m[column], Guessed: guessed && guess == m[column], Cards: counts[column]})
+	}
+	return rows
+}
+
+// Plan is what importing a board would create.
+type Plan struct {
+	Issues  []*types.Issue      `json:"issues"`
+	Deps    []*types.Dependency `json:"dependencies"` // IssueID and DependsOnID hold card refs
+	Skipped int                 `json:"skipped"`      // Cards already imported
+}
+
+// BuildPlan converts b's cards into issues using m, skipping cards whose
+// ref is in imported, which maps the refs of cards imported earlier to
+// their issues. Closed issues and cards in
+// columns mapped to closed are created closed. Epic links become
+// parent-child dependencies and blocking links blocks dependencies,
+// between cards on the board; links to cards outside it are dropped.
+func BuildPlan(b *Board, m Mapping, imported map[string]string) *Plan {
+	p := &Plan{Issues: []*types.Issue{}, Deps: []*types.Dependency{}}
+	onBoard := make(map[string]bool)
+	for _, c := range b.Cards {
+		if c.Ref != "" {
+			onBoard[c.Ref] = true
+		}
+	}
+	for _, c := range b.Cards {
+		if _, done := imported[c.Ref]; done && c.Ref != "" {
+			p.Skipped++
+			continue
+		}
+		column := c.Column
+		if column == "" {
+			column = NoColumn
+		}
+		status := m[column]
+		if status == "" {
+			status = types.StatusOpen
+		}
+		if c.Closed {
+			status = types.StatusClosed
+		}
+		issue := &types.Issue{
+			Title:       c.Title,
+			Description: c.Description,
+			Status:      status,
+			Priority:    2,
+			IssueType:   types.TypeTask,
+			Assignee:    c.Assignee,
+			Labels:      append([]string{}, c.Labels...),
+			CreatedAt:   c.CreatedAt,
+		}
+		if c.Epic {
+			issue.IssueType = types.TypeEpic
+		}
+		for _, label := range c.Labels {
+			if t, ok := labelTypes[strings.ToLower(label)]; ok && !c.Epic {
+				issue.IssueType = t
+			}
+		}
+		if c.Ref != "" {
+			ref := c.Ref
+			issue.ExternalRef = &ref
+		}
+		if status == types.StatusClosed {
+			issue.CloseReason = "Closed on " + b.sourceName()
+		}
+		p.Issues = append(p.Issues, issue)
+
+		if c.Ref == "" {
+			continue
+		}
+		if c.Parent != "" && onBoard[c.Parent] {
+			p.Deps = append(p.Deps, &types.Dependency{IssueID: c.Ref, DependsOnID: c.Parent, Type: types.DepParentChild})
+		}
+		for _, blocker := range c.BlockedBy {
+			if onBoard[blocker] {
+				p.Deps = append(p.Deps, &types.Dependency{IssueID: c.Ref, DependsOnID: blocker, Type: types.DepBlocks})
+			}
+		}
+	}
+	return p
+}
+
+// labelTypes are GitHub labels that set the imported issue's type.
+var labelTypes = map[string]types.IssueType{
+	"bug": types.TypeBug, "type: bug": types.TypeBug,
+	"enhancement": types.TypeFeature, "feature": types.TypeFeature, "type: feature": types.TypeFeature,
+	"chore": types.TypeChore,
+}
+
+func (b *Board) sourceName() string {
+	if b.Source == "zenhub" {
+		return "ZenHub"
+	}
+	return "GitHub Projects"
+}
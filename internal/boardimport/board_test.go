@@ -0,0 +1,100 @@
+package boardimport
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func testBoard() *Board {
+	return &Board{
+		Source:  "github",
+		Columns: []string{"Backlog", "In Review", "Shipping", "Done"},
+		Cards: []*Card{
+			{Ref: "https://github.com/acme/api/issues/1", Title: "Search", Column: "Backlog", Epic: true},
+			{Ref: "https://github.com/acme/api/issues/2", Title: "Index", Column: "In Review", Parent: "https://github.com/acme/api/issues/1", Labels: []string{"bug"}},
+			{Ref: "https://github.com/acme/api/issues/3", Title: "Query", Column: "Shipping", Parent: "https://github.com/acme/api/issues/1",
+				BlockedBy: []string{"https://github.com/acme/api/issues/2", "https://github.com/acme/web/issues/9"}},
+			{Ref: "https://github.com/acme/api/issues/4", Title: "Old", Column: "Backlog", Closed: true},
+			{Title: "Draft idea"},
+		},
+	}
+}
+
+func TestDefaultMapping(t *testing.T) {
+	b := testBoard()
+	m := DefaultMapping(b)
+	want := Mapping{"Backlog": types.StatusOpen, "In Review": types.StatusInProgress, "Shipping": types.StatusOpen,
+		"Done": types.StatusClosed, NoColumn: types.StatusOpen}
+	if len(m) != len(want) {
+		t.Fatalf("mapping = %v, want %v", m, want)
+	}
+	for column, status := range want {
+		if m[column] != status {
+			t.Errorf("%s -> %s, want %s", column, m[column], status)
+		}
+	}
+
+	rows := m.Review(b)
+	if len(rows) != 5 || rows[0].Column != "Backlog" || rows[0].Cards != 2 || !rows[0].Guessed ||
+		rows[2].Column != "Shipping" || rows[2].Guessed || rows[4].Column != NoColumn || rows[4].Cards != 1 {
+		t.Errorf("review = %+v", rows)
+	}
+}
+
+func TestMappingSet(t *testing.T) {
+	b := testBoard()
+	m := DefaultMapping(b)
+	if err := m.Set(b, "shipping = in_progress"); err != nil {
+		t.Fatal(err)
+	}
+	if m["Shipping"] != types.StatusInProgress {
+		t.Errorf("Shipping -> %s", m["Shipping"])
+	}
+	for _, spec := range []string{"Shipping", "Shipping=tombstone", "Archive=closed"} {
+		if err := m.Set(b, spec); err == nil {
+			t.Errorf("Set(%q) succeeded", spec)
+		}
+	}
+}
+
+func TestBuildPlan(t *testing.T) {
+	b := testBoard()
+	m := DefaultMapping(b)
+	p := BuildPlan(b, m, map[string]string{"https://github.com/acme/api/issues/4": "bd-4"})
+	if len(p.Issues) != 4 || p.Skipped != 1 {
+		t.Fatalf("plan has %d issues, %d skipped", len(p.Issues), p.Skipped)
+	}
+	epic, index, query, draft := p.Issues[0], p.Issues[1], p.Issues[2], p.Issues[3]
+	if epic.IssueType != types.TypeEpic || index.IssueType != types.TypeBug || query.IssueType != types.TypeTask {
+		t.Errorf("types = %s, %s, %s", epic.IssueType, index.IssueType, query.IssueType)
+	}
+	if index.Status != types.StatusInProgress || *index.ExternalRef != "https://github.com/acme/api/issues/2" {
+		t.Errorf("index = %s, %v", index.Status, index.ExternalRef)
+	}
+	if draft.ExternalRef != nil || draft.Status != types.StatusOpen {
+		t.Errorf("draft = %+v", draft)
+	}
+
+	// The link to acme/web#9, which isn't on the board, is dropped
+	if len(p.Deps) != 3 {
+		t.Fatalf("deps = %d, want 3", len(p.Deps))
+	}
+	var blocks int
+	for _, d := range p.Deps {
+		if d.Type == types.DepBlocks {
+			blocks++
+			if d.IssueID != "https://github.com/acme/api/issues/3" || d.DependsOnID != "https://github.com/acme/api/issues/2" {
+				t.Errorf("blocks dep = %+v", d)
+			}
+		}
+	}
+	if blocks != 1 {
+		t.Errorf("%d blocks deps, want 1", blocks)
+	}
+
+	b.Cards[0].Closed = true
+	if p := BuildPlan(b, m, nil); p.Issues[0].Status != types.StatusClosed || p.Issues[0].CloseReason == "" {
+		t.Errorf("closed card imported as %s (%q)", p.Issues[0].Status, p.Issues[0].CloseReason)
+	}
+}
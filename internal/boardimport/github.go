@@ -0,0 +1,251 @@
+package boardimport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GitHubEndpoint is the GitHub GraphQL API endpoint.
+const GitHubEndpoint = "https://api.github.com/graphql"
+
+// GitHubClient reads GitHub Projects (v2) boards through the GraphQL API.
+type GitHubClient struct {
+	Token      string
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+// NewGitHubClient creates a client authenticating with token, which needs
+// the read:project scope (and repo, for private repositories).
+func NewGitHubClient(token string) *GitHubClient {
+	return &GitHubClient{
+		Token:      token,
+		Endpoint:   GitHubEndpoint,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// projectQuery fetches one page of a project's items. The project is
+// looked up on the owner as an organization or a user, whichever it is.
+const projectQuery = `query($owner: String!, $number: Int!, $field: String!, $cursor: String) {
+  repositoryOwner(login: $owner) {
+    ... on Organization { projectV2(number: $number) { ...board } }
+    ... on User { projectV2(number: $number) { ...board } }
+  }
+}
+fragment board on ProjectV2 {
+  title
+  field(name: $field) { ... on ProjectV2SingleSelectField { options { name } } }
+  items(first: 100, after: $cursor) {
+    pageInfo { hasNextPage endCursor }
+    nodes {
+      fieldValueByName(name: $field) { ... on ProjectV2ItemFieldSingleSelectValue { name } }
+      content {
+        ... on DraftIssue { title body createdAt }
+        ... on Issue {
+          url title body state createdAt
+          labels(first: 50) { nodes { name } }
+          assignees(first: 1) { nodes { login } }
+          parent { url }
+          subIssuesSummary { total }
+          blockedBy(first: 50) { nodes { url } }
+        }
+      }
+    }
+  }
+}`
+
+type ghNodes[T any] struct {
+	Nodes []T `json:"nodes"`
+}
+
+type ghProject struct {
+	Title string `json:"title"`
+	Field *struct {
+		Options []struct {
+			Name string `json:"name"`
+		} `json:"options"`
+	} `json:"field"`
+	Items struct {
+		PageInfo struct {
+			HasNextPage bool   `json:"hasNextPage"`
+			EndCursor   string `json:"endCursor"`
+		} `json:"pageInfo"`
+		Nodes []ghItem `json:"nodes"`
+	} `json:"items"`
+}
+
+type ghItem struct {
+	FieldValue *struct {
+		Name string `json:"name"`
+	} `json:"fieldValueByName"`
+	Content *struct {
+		URL       string    `json:"url"`
+		Title     string    `json:"title"`
+		Body      string    `json:"body"`
+		State     string    `json:"state"`
+		CreatedAt time.Time `json:"createdAt"`
+		Labels    ghNodes[struct {
+			Name string `json:"name"`
+		}] `json:"labels"`
+		Assignees ghNodes[struct {
+			Login string `json:"login"`
+		}] `json:"assignees"`
+		Parent *struct {
+			URL string `json:"url"`
+		} `json:"parent"`
+		SubIssues struct {
+			Total int `json:"total"`
+		} `json:"subIssuesSummary"`
+		BlockedBy ghNodes[struct {
+			URL string `json:"url"`
+		}] `json:"blockedBy"`
+	} `json:"content"`
+}
+
+// FetchProject reads project number of owner (an organization or user).
+// field names the single-select field that holds the board columns,
+// usually "Status". Items that are pull requests or that the token can't
+// see come back without content and are left out.
+func (c *GitHubClient) FetchProject(ctx context.Context, owner string, number int, field string) (*Board, error) {
+	b := &Board{Source: "github", Cards: []*Card{}}
+	cursor := ""
+	for {
+		vars := map[string]interface{}{"owner": owner, "number": number, "field": field}
+		if cursor != "" {
+			vars["cursor"] = cursor
+		}
+		var data struct {
+			Owner *struct {
+				Project *ghProject `json:"projectV2"`
+			} `json:"repositoryOwner"`
+		}
+		if err := c.query(ctx, projectQuery, vars, &data); err != nil {
+			return nil, err
+		}
+		if data.Owner == nil || data.Owner.Project == nil {
+			return nil, fmt.Errorf("project %s/%d not found (or the token lacks the read:project scope)", owner, number)
+		}
+		p := data.Owner.Project
+		if cursor == "" {
+			b.Name = p.Title
+			if p.Field == nil {
+				return nil, fmt.Errorf("project %s/%d has no single-select field %q", owner, number, field)
+			}
+			for _, o := range p.Field.Options {
+				b.Columns = append(b.Columns, o.Name)
+			}
+		}
+		for _, item := range p.Items.Nodes {
+			if card := item.card(); card != nil {
+				b.Cards = append(b.Cards, card)
+			}
+		}
+		if !p.Items.PageInfo.HasNextPage {
+			break
+		}
+		cursor = p.Items.PageInfo.EndCursor
+	}
+	b.markParents()
+	return b, nil
+}
+
+// card converts a project item, or returns nil for items without issue
+// content (pull requests, redacted items).
+func (item ghItem) card() *Card {
+	ct := item.Content
+	if ct == nil || ct.Title == "" {
+		return nil
+	}
+	card := &Card{
+		Ref:         ct.URL,
+		Title:       ct.Title,
+		Description: ct.Body,
+		Closed:      ct.State == "CLOSED",
+		Epic:        ct.SubIssues.Total > 0,
+		CreatedAt:   ct.CreatedAt,
+	}
+	if item.FieldValue != nil {
+		card.Column = item.FieldValue.Name
+	}
+	for _, l := range ct.Labels.Nodes {
+		card.Labels = append(card.Labels, l.Name)
+	}
+	if len(ct.Assignees.Nodes) > 0 {
+		card.Assignee = ct.Assignees.Nodes[0].Login
+	}
+	if ct.Parent != nil {
+		card.Parent = ct.Parent.URL
+	}
+	for _, n := range ct.BlockedBy.Nodes {
+		card.BlockedBy = append(card.BlockedBy, n.URL)
+	}
+	return card
+}
+
+// markParents marks cards that other cards name as their parent as epics,
+// for sub-issue trees the sub-issue summary didn't report.
+func (b *Board) markParents() {
+	parents := make(map[string]bool)
+	for _, c := range b.Cards {
+		if c.Parent != "" {
+			parents[c.Parent] = true
+		}
+	}
+	for _, c := range b.Cards {
+		if parents[c.Ref] {
+			c.Epic = true
+		}
+	}
+}
+
+// query runs a GraphQL query, decoding its data into out.
+func (c *GitHubClient) query(ctx context.Context, q string, vars map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"query": q, "variables": vars})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("GitHub request failed: %w", err)
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("GitHub API error: %s (status %d)", strings.TrimSpace(string(respBody)), resp.StatusCode)
+	}
+
+	var gqlResp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(respBody, &gqlResp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(gqlResp.Errors) > 0 {
+		msgs := make([]string, len(gqlResp.Errors))
+		for i, e := range gqlResp.Errors {
+			msgs[i] = e.Message
+		}
+		return fmt.Errorf("GraphQL errors: %s", strings.Join(msgs, "; "))
+	}
+	return json.Unmarshal(gqlResp.Data, out)
+}
@@ -0,0 +1,92 @@
+package boardimport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchProject(t *testing.T) {
+	pages := []string{
+		`{"data": {"repositoryOwner": {"projectV2": {
+  "title": "Roadmap",
+  "field": {"options": [{"name": "Todo"}, {"name": "In Progress"}, {"name": "Done"}]},
+  "items": {"pageInfo": {"hasNextPage": true, "endCursor": "c1"}, "nodes": [
+    {"fieldValueByName": {"name": "Todo"}, "content": {"url": "https://github.com/acme/api/issues/1", "title": "Search",
+      "state": "OPEN", "labels": {"nodes": [{"name": "area:search"}]}, "assignees": {"nodes": [{"login": "ana"}]},
+      "subIssuesSummary": {"total": 0}, "blockedBy": {"nodes": []}}},
+    {"fieldValueByName": null, "content": null}
+  ]}}}}}`,
+		`{"data": {"repositoryOwner": {"projectV2": {
+  "title": "Roadmap",
+  "field": {"options": []},
+  "items": {"pageInfo": {"hasNextPage": false, "endCursor": ""}, "nodes": [
+    {"fieldValueByName": {"name": "Done"}, "content": {"url": "https://github.com/acme/api/issues/2", "title": "Index",
+      "state": "CLOSED", "parent": {"url": "https://github.com/acme/api/issues/1"},
+      "blockedBy": {"nodes": [{"url": "https://github.com/acme/api/issues/3"}]}}},
+    {"fieldValueByName": {"name": "Todo"}, "content": {"title": "Draft idea", "body": "later"}}
+  ]}}}}}`,
+	}
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		_ = json.Unmarshal(body, &req)
+		if req.Variables["owner"] != "acme" || req.Variables["field"] != "Status" {
+			t.Errorf("variables = %v", req.Variables)
+		}
+		if requests == 1 && req.Variables["cursor"] != "c1" {
+			t.Errorf("second page cursor = %v", req.Variables["cursor"])
+		}
+		_, _ = io.WriteString(w, pages[requests])
+		requests++
+	}))
+	defer server.Close()
+
+	client := NewGitHubClient("secret")
+	client.Endpoint = server.URL
+	b, err := client.FetchProject(context.Background(), "acme", 4, "Status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Name != "Roadmap" || strings.Join(b.Columns, ",") != "Todo,In Progress,Done" || len(b.Cards) != 3 {
+		t.Fatalf("board = %+v", b)
+	}
+	search, index, draft := b.Cards[0], b.Cards[1], b.Cards[2]
+	if search.Column != "Todo" || search.Assignee != "ana" || !search.Epic || search.Labels[0] != "area:search" {
+		t.Errorf("search = %+v", search)
+	}
+	if !index.Closed || index.Parent != search.Ref || len(index.BlockedBy) != 1 {
+		t.Errorf("index = %+v", index)
+	}
+	if draft.Ref != "" || draft.Description != "later" {
+		t.Errorf("draft = %+v", draft)
+	}
+}
+
+func TestFetchProjectErrors(t *testing.T) {
+	for name, response := range map[string]string{
+		"not found":  `{"data": {"repositoryOwner": null}}`,
+		"no field":   `{"data": {"repositoryOwner": {"projectV2": {"title": "Roadmap", "field": null}}}}`,
+		"api errors": `{"errors": [{"message": "Resource not accessible"}]}`,
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, response)
+		}))
+		client := NewGitHubClient("secret")
+		client.Endpoint = server.URL
+		if _, err := client.FetchProject(context.Background(), "acme", 4, "Status"); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+		server.Close()
+	}
+}
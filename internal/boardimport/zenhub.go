@@ -0,0 +1,128 @@
+package boardimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// zenhubExport is a ZenHub board export: the board's pipelines, with the
+// dependency and epic responses of ZenHub's API saved alongside. Issues
+// name their repository with repo_name, or default to repo.
+type zenhubExport struct {
+	Workspace string `json:"workspace"`
+	Repo      string `json:"repo"`
+	Pipelines []struct {
+		Name   string        `json:"name"`
+		Issues []zenhubIssue `json:"issues"`
+	} `json:"pipelines"`
+	Dependencies []struct {
+		Blocking zenhubRef `json:"blocking"`
+		Blocked  zenhubRef `json:"blocked"`
+	} `json:"dependencies"`
+	Epics []struct {
+		zenhubRef
+		Issues []zenhubRef `json:"issues"`
+	} `json:"epics"`
+}
+
+type zenhubRef struct {
+	IssueNumber int    `json:"issue_number"`
+	RepoName    string `json:"repo_name"`
+}
+
+type zenhubIssue struct {
+	zenhubRef
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	State     string    `json:"state"`
+	IsEpic    bool      `json:"is_epic"`
+	Labels    []string  `json:"labels"`
+	Assignee  string    `json:"assignee"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ParseZenHub reads a ZenHub board export. Cards get the GitHub URLs of
+// their issues as refs, so a board imported from both ZenHub and GitHub
+// Projects isn't imported twice.
+func ParseZenHub(data []byte) (*Board, error) {
+	var export zenhubExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("parsing ZenHub export: %w", err)
+	}
+	if len(export.Pipelines) == 0 {
+		return nil, fmt.Errorf("ZenHub export has no pipelines")
+	}
+	url := func(r zenhubRef) (string, error) {
+		repo := r.RepoName
+		if repo == "" {
+			repo = export.Repo
+		}
+		if repo == "" || r.IssueNumber <= 0 {
+			return "", fmt.Errorf("ZenHub export: issue %d has no repo_name (set repo for single-repository boards)", r.IssueNumber)
+		}
+		return fmt.Sprintf("https://github.com/%s/issues/%d", repo, r.IssueNumber), nil
+	}
+
+	b := &Board{Source: "zenhub", Name: export.Workspace, Cards: []*Card{}}
+	byRef := make(map[string]*Card)
+	for _, p := range export.Pipelines {
+		b.Columns = append(b.Columns, p.Name)
+		for _, issue := range p.Issues {
+			ref, err := url(issue.zenhubRef)
+			if err != nil {
+				return nil, err
+			}
+			title := issue.Title
+			if title == "" {
+				title = fmt.Sprintf("Issue #%d", issue.IssueNumber)
+			}
+			card := &Card{
+				Ref:         ref,
+				Title:       title,
+				Description: issue.Body,
+				Column:      p.Name,
+				Closed:      issue.State == "closed",
+				Epic:        issue.IsEpic,
+				Labels:      issue.Labels,
+				Assignee:    issue.Assignee,
+				CreatedAt:   issue.CreatedAt,
+			}
+			b.Cards = append(b.Cards, card)
+			byRef[ref] = card
+		}
+	}
+
+	for _, d := range export.Dependencies {
+		blocked, err := url(d.Blocked)
+		if err != nil {
+			return nil, err
+		}
+		blocking, err := url(d.Blocking)
+		if err != nil {
+			return nil, err
+		}
+		if card := byRef[blocked]; card != nil {
+			card.BlockedBy = append(card.BlockedBy, blocking)
+		}
+	}
+	for _, e := range export.Epics {
+		epic, err := url(e.zenhubRef)
+		if err != nil {
+			return nil, err
+		}
+		if card := byRef[epic]; card != nil {
+			card.Epic = true
+		}
+		for _, child := range e.Issues {
+			ref, err := url(child)
+			if err != nil {
+				return nil, err
+			}
+			if card := byRef[ref]; card != nil {
+				card.Parent = epic
+			}
+		}
+	}
+	return b, nil
+}
@@ -0,0 +1,48 @@
+package boardimport
+
+import "testing"
+
+func TestParseZenHub(t *testing.T) {
+	data := []byte(`{
+  "workspace": "Platform",
+  "repo": "acme/api",
+  "pipelines": [
+    {"name": "New Issues", "issues": [{"issue_number": 7, "title": "Billing", "is_epic": true}]},
+    {"name": "In Progress", "issues": [
+      {"issue_number": 8, "title": "Invoices", "assignee": "ana"},
+      {"issue_number": 3, "repo_name": "acme/web", "labels": ["bug"]}
+    ]},
+    {"name": "Closed", "issues": [{"issue_number": 5, "title": "Setup", "state": "closed"}]}
+  ],
+  "dependencies": [{"blocking": {"issue_number": 3, "repo_name": "acme/web"}, "blocked": {"issue_number": 8}}],
+  "epics": [{"issue_number": 7, "issues": [{"issue_number": 8}, {"issue_number": 3, "repo_name": "acme/web"}]}]
+}`)
+	b, err := ParseZenHub(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Name != "Platform" || len(b.Columns) != 3 || len(b.Cards) != 4 {
+		t.Fatalf("board = %+v", b)
+	}
+	invoices, web := b.Cards[1], b.Cards[2]
+	if invoices.Ref != "https://github.com/acme/api/issues/8" || invoices.Column != "In Progress" || invoices.Assignee != "ana" {
+		t.Errorf("invoices = %+v", invoices)
+	}
+	if invoices.Parent != "https://github.com/acme/api/issues/7" || len(invoices.BlockedBy) != 1 ||
+		invoices.BlockedBy[0] != "https://github.com/acme/web/issues/3" {
+		t.Errorf("invoices links = %q, %q", invoices.Parent, invoices.BlockedBy)
+	}
+	if web.Title != "Issue #3" || web.Parent != "https://github.com/acme/api/issues/7" {
+		t.Errorf("web = %+v", web)
+	}
+	if !b.Cards[3].Closed || !b.Cards[0].Epic {
+		t.Errorf("closed = %v, epic = %v", b.Cards[3].Closed, b.Cards[0].Epic)
+	}
+
+	if _, err := ParseZenHub([]byte(`{"pipelines": [{"name": "Todo", "issues": [{"issue_number": 1}]}]}`)); err == nil {
+		t.Error("accepted an issue without a repository")
+	}
+	if _, err := ParseZenHub([]byte(`{}`)); err == nil {
+		t.Error("accepted an export without pipelines")
+	}
+}
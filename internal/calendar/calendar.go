@@ -0,0 +1,171 @@
+// Package calendar implements the working calendar (weekend days and
+// holidays) used for business-day dates, deferrals, and schedule lags.
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+)
+
+// Calendar says which days are working days.
+type Calendar struct {
+	weekend  map[time.Weekday]bool
+	holidays map[string]bool // YYYY-MM-DD
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// New returns a calendar with the given weekend days (names such as
+// "saturday" or "sat") and holidays (YYYY-MM-DD).
+func New(weekend, holidays []string) (*Calendar, error) {
+	c := &Calendar{weekend: make(map[time.Weekday]bool), holidays: make(map[string]bool)}
+	for _, name := range weekend {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		day, ok := weekdays[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekend day %q", name)
+		}
+		c.weekend[day] = true
+	}
+	if len(c.weekend) == 7 {
+		return nil, fmt.Errorf("weekend covers every day of the week")
+	}
+	for _, date := range holidays {
+		date = strings.TrimSpace(date)
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return nil, fmt.Errorf("invalid holiday %q (use YYYY-MM-DD)", date)
+		}
+		c.holidays[date] = true
+	}
+	return c, nil
+}
+
+// Default returns a Monday-to-Friday calendar without holidays.
+func Default() *Calendar {
+	c, _ := New([]string{"saturday", "sunday"}, nil)
+	return c
+}
+
+// Load returns the calendar configured by calendar.weekend and
+// calendar.holidays, or Default if config hasn't been initialized.
+func Load() (*Calendar, error) {
+	if _, ok := config.AllSettings()["calendar"]; !ok {
+		return Default(), nil
+	}
+	return New(config.GetStringSlice("calendar.weekend"), config.GetStringSlice("calendar.holidays"))
+}
+
+// LoadOrDefault returns the configured calendar, or Default if the
+// configuration is invalid.
+func LoadOrDefault() *Calendar {
+	if c, err := Load(); err == nil {
+		return c
+	}
+	return Default()
+}
+
+// WithDaysOff returns a copy of c that also treats days (YYYY-MM-DD) as
+// non-working, such as the days a person is away. Invalid dates are
+// ignored.
+func (c *Calendar) WithDaysOff(days []string) *Calendar {
+	out := &Calendar{weekend: c.weekend, holidays: make(map[string]bool, len(c.holidays)+len(days))}
+	for day := range c.holidays {
+		out.holidays[day] = true
+	}
+	for _, day := range days {
+		if _, err := time.Parse("2006-01-02", day); err == nil {
+			out.holidays[day] = true
+		}
+	}
+	return out
+}
+
+// IsWorkingDay reports whether t falls on a working day, in t's location.
+func (c *Calendar) IsWorkingDay(t time.Time) bool {
+	return !c.weekend[t.Weekday()] && !c.holidays[t.Format("2006-01-02")]
+}
+
+// NextWorkingDay returns t if it falls on a working day, otherwise the
+// start of the next working day.
+func (c *Calendar) NextWorkingDay(t time.Time) time.Time {
+	if c.IsWorkingDay(t) {
+		return t
+	}
+	day := startOfDay(t)
+	for !c.IsWorkingDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
+
+// AddBusinessDays moves t by n working days, keeping the time of day.
+// Starting from a non-working day, the first working day counts as one.
+func (c *Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if c.IsWorkingDay(t) {
+			n--
+		}
+	}
+	return t
+}
+
+// AddWorking moves t by d of working time: only time on working days
+// counts, so 48h from Friday noon is Tuesday noon on a Monday-to-Friday
+// calendar. Negative d moves back.
+func (c *Calendar) AddWorking(t time.Time, d time.Duration) time.Time {
+	if d >= 0 {
+		for {
+			next := startOfDay(t).AddDate(0, 0, 1)
+			if c.IsWorkingDay(t) {
+				if avail := next.Sub(t); d <= avail {
+					return t.Add(d)
+				} else {
+					d -= avail
+				}
+			}
+			t = next
+		}
+	}
+	d = -d
+	for {
+		day := startOfDay(t)
+		if day.Equal(t) {
+			day = day.AddDate(0, 0, -1) // At midnight, the day to consume is the previous one
+		}
+		if c.IsWorkingDay(day) {
+			if avail := t.Sub(day); d <= avail {
+				return t.Add(-d)
+			} else {
+				d -= avail
+			}
+		}
+		t = day
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
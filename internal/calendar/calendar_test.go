@@ -0,0 +1,78 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	if _, err := New([]string{"Sat", "sunday"}, []string{"2026-12-25"}); err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := New([]string{"caturday"}, nil); err == nil {
+		t.Error("expected error for invalid weekend day")
+	}
+	if _, err := New(nil, []string{"12/25/2026"}); err == nil {
+		t.Error("expected error for invalid holiday")
+	}
+	all := []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+	if _, err := New(all, nil); err == nil {
+		t.Error("expected error for a week without working days")
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	// Friday 2026-12-25 is a holiday; Thursday 2026-12-24 is a working day
+	cal, err := New([]string{"saturday", "sunday"}, []string{"2026-12-25"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(day, hour int) time.Time { return time.Date(2026, 12, day, hour, 0, 0, 0, time.UTC) }
+	day := 24 * time.Hour
+
+	if cal.IsWorkingDay(at(25, 9)) || cal.IsWorkingDay(at(26, 9)) || !cal.IsWorkingDay(at(28, 9)) {
+		t.Error("IsWorkingDay: holiday or weekend treated as working, or Monday not")
+	}
+	if got := cal.NextWorkingDay(at(25, 9)); !got.Equal(at(28, 0)) {
+		t.Errorf("NextWorkingDay(holiday) = %v", got)
+	}
+	if got := cal.NextWorkingDay(at(24, 9)); !got.Equal(at(24, 9)) {
+		t.Errorf("NextWorkingDay(working day) = %v", got)
+	}
+
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"business +1 over holiday", cal.AddBusinessDays(at(24, 10), 1), at(28, 10)},
+		{"business +3", cal.AddBusinessDays(at(24, 10), 3), at(30, 10)},
+		{"business -1 over weekend", cal.AddBusinessDays(at(28, 10), -1), at(24, 10)},
+		{"business from weekend", cal.AddBusinessDays(at(26, 10), 1), at(28, 10)},
+		{"working within day", cal.AddWorking(at(24, 10), 6*time.Hour), at(24, 16)},
+		{"working over holiday", cal.AddWorking(at(24, 12), day), at(28, 12)},
+		{"working from weekend", cal.AddWorking(at(26, 12), 12*time.Hour), at(28, 12)},
+		{"working back over weekend", cal.AddWorking(at(28, 12), -day), at(24, 12)},
+		{"working back to midnight", cal.AddWorking(at(28, 12), -12*time.Hour), at(28, 0)},
+	}
+	for _, tt := range tests {
+		if !tt.got.Equal(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestWithDaysOff(t *testing.T) {
+	cal := Default()
+	away := cal.WithDaysOff([]string{"2026-12-28", "not-a-date"})
+	monday := time.Date(2026, 12, 28, 9, 0, 0, 0, time.UTC)
+	if away.IsWorkingDay(monday) {
+		t.Error("day off treated as working")
+	}
+	if !cal.IsWorkingDay(monday) {
+		t.Error("WithDaysOff changed the original calendar")
+	}
+	if got := away.NextWorkingDay(monday); !got.Equal(time.Date(2026, 12, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("NextWorkingDay(day off) = %v", got)
+	}
+}
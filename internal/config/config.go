@@ -10,6 +10,7 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/resolution"
 	"gopkg.in/yaml.v3"
 )
 
@@ -27,10 +28,17 @@ const (
 
 var v *viper.Viper
 
+// envAliases lists environment variables bound to a key (with BindEnv in
+// Initialize) besides the BD_-prefixed one.
+var envAliases = map[string][]string{
+	"db": {"BD_DATABASE"},
+}
+
 // Initialize sets up the viper configuration singleton
 // Should be called once at application startup
 func Initialize() error {
 	v = viper.New()
+	activeProfile, profileKeys = "", nil
 
 	// Set config type to yaml (we only load config.yaml, not config.json)
 	v.SetConfigType("yaml")
@@ -127,10 +135,12 @@ func Initialize() error {
 	v.SetDefault("events-export", false)
 	v.SetDefault("no-db", false)
 	v.SetDefault("db", "")
+	v.SetDefault("profile", "")
 	v.SetDefault("actor", "")
 	v.SetDefault("issue-prefix", "")
 	// Additional environment variables (not prefixed with BD_)
 	_ = v.BindEnv("identity", "BEADS_IDENTITY") // BindEnv only fails with zero args, which can't happen here
+	_ = v.BindEnv("db", "BD_DB", "BD_DATABASE")
 	v.SetDefault("identity", "")
 
 	// Dolt configuration defaults
@@ -138,6 +148,10 @@ func Initialize() error {
 	// Values: off | on
 	v.SetDefault("dolt.auto-commit", "on")
 
+	// Storage maintenance: run a shallow Dolt GC after write commands once this
+	// interval has elapsed since the last GC (e.g. "24h"). Empty/0 disables.
+	v.SetDefault("storage.auto-gc-interval", "")
+
 	// Routing configuration defaults
 	v.SetDefault("routing.mode", "")
 	v.SetDefault("routing.default", ".")
@@ -160,6 +174,12 @@ func Initialize() error {
 	v.SetDefault("federation.remote", "")      // e.g., dolthub://org/beads, gs://bucket/beads, s3://bucket/beads
 	v.SetDefault("federation.sovereignty", "") // T1 | T2 | T3 | T4 (empty = no restriction)
 
+	// Federation relay (hub-and-spoke) configuration
+	v.SetDefault("federation.relay.enabled", false)
+	v.SetDefault("federation.relay.id", "")     // Hub identity recorded on relayed commits (default: issue prefix)
+	v.SetDefault("federation.relay.spokes", "") // Comma-separated spokes (empty = all peers)
+	v.SetDefault("federation.relay.max-hops", DefaultRelayMaxHops)
+
 	// Push configuration defaults
 	v.SetDefault("no-push", false)
 
@@ -173,6 +193,20 @@ func Initialize() error {
 	// - "error": validate and fail on missing sections
 	v.SetDefault("validation.on-create", "none")
 	v.SetDefault("validation.on-sync", "none")
+	// Closing issues whose acceptance criteria don't all pass (bd criteria)
+	v.SetDefault("validation.acceptance-on-close", "none")
+
+	// bd validate rules: fields required per issue type (comma-separated,
+	// "*" applies to all types), the least urgent priority that must carry an
+	// estimate (-1 disables), and the label taxonomy (comma-separated globs,
+	// empty allows any label)
+	v.SetDefault("validation.required-fields", map[string]string{
+		"bug":     "description",
+		"feature": "description",
+		"epic":    "description",
+	})
+	v.SetDefault("validation.estimate-max-priority", 1)
+	v.SetDefault("validation.labels", "")
 
 	// Hierarchy configuration defaults (GH#995)
 	// Maximum nesting depth for hierarchical IDs (e.g., bd-abc.1.2.3)
@@ -190,6 +224,111 @@ func Initialize() error {
 	// AI configuration defaults
 	v.SetDefault("ai.model", "claude-haiku-4-5-20251001")
 
+	// Embedding defaults for bd similar (local model needs no network or key)
+	v.SetDefault("embeddings.provider", "local")
+	v.SetDefault("embeddings.model", "")
+	v.SetDefault("embeddings.url", "")
+	v.SetDefault("embeddings.api-key-env", "OPENAI_API_KEY")
+	v.SetDefault("embeddings.dimensions", 0)
+
+	// Summarizer defaults for bd summarize (empty model/key env: provider default)
+	v.SetDefault("summarize.provider", "anthropic")
+	v.SetDefault("summarize.model", "")
+	v.SetDefault("summarize.url", "")
+	v.SetDefault("summarize.api-key-env", "")
+
+	// Town tag for distributed IDs (empty = IDs aren't partitioned by town)
+	v.SetDefault("id.town", "")
+
+	// Preferred languages for issue titles and descriptions in list/show
+	// (comma-separated tags, e.g. "pt-BR, en"; empty = original text)
+	v.SetDefault("display.language", "")
+
+	// Unicode symbols and emoji in human-readable output (false = plain ASCII)
+	v.SetDefault("display.glyphs", true)
+
+	// Pager for long list/show/history output (empty = PAGER, then less)
+	v.SetDefault("pager", "")
+	v.SetDefault("no-pager", false)
+
+	// Link issues to the issues their text and comments mention (bd-123)
+	v.SetDefault("references.auto-link", true)
+
+	// Token budget for bd context packs
+	v.SetDefault("context.budget", "8000")
+
+	// Secret scanning of text written by create/update/comment
+	// Values for scan.mode: "off" | "warn" | "quarantine" | "block"
+	v.SetDefault("scan.mode", "off")
+	v.SetDefault("scan.quarantine-label", "quarantine")
+	v.SetDefault("scan.pii-patterns", map[string]string{})
+
+	// Retention policy for bd retention (empty closed-after: no policy;
+	// empty paths: .beads/retention and .beads/retention.key)
+	v.SetDefault("retention.closed-after", "")
+	v.SetDefault("retention.hold-label", "hold")
+	v.SetDefault("retention.manifest-dir", "")
+	v.SetDefault("retention.signing-key", "")
+
+	// Working calendar for business-day dates, deferrals, and schedule lags
+	// (weekend day names; holidays as YYYY-MM-DD)
+	v.SetDefault("calendar.weekend", []string{"saturday", "sunday"})
+	v.SetDefault("calendar.holidays", []string{})
+
+	// Label routes for bd create and bd route run: label glob -> settings
+	// such as "epic=bd-12, assignee=alice, priority=P1"
+	v.SetDefault("label-routes", map[string]string{})
+
+	// Soft schemas for issue types: type -> field specs such as
+	// "severity=sev1|sev2, impact, customer?", and type -> display template.
+	// Values for validation.type-fields: "error" | "warn" | "none"
+	v.SetDefault("type-schemas", map[string]string{})
+	v.SetDefault("type-templates", map[string]string{})
+	v.SetDefault("validation.type-fields", "error")
+
+	// Issue locks for bd lock ("0" default-ttl: locks never expire; empty
+	// admins: anyone may break a lock with bd unlock --force)
+	v.SetDefault("lock.default-ttl", "4h")
+	v.SetDefault("lock.admins", []string{})
+
+	// Escalation chains for bd escalate: contacts in order ("assignee" is
+	// the issue's assignee), and per priority how long an unacknowledged
+	// issue waits before each step
+	v.SetDefault("escalation.chain", []string{})
+	v.SetDefault("escalation.after", map[string]string{"p0": "30m", "p1": "4h"})
+
+	// Ready queue fairness for bd ready: "epic" | "label" | "" (sort order only)
+	v.SetDefault("ready.fairness", "")
+
+	// Priority aging for bd ready --sort hybrid: waiting this long gains an
+	// issue one priority level, up to max-boost levels
+	v.SetDefault("ready.aging.interval", "30d")
+	v.SetDefault("ready.aging.max-boost", 3)
+
+	// Do-not-start windows for claiming and starting work, e.g.
+	// "fri 17:00-mon 08:00 p2+" (local time; pN+ limits the priorities)
+	v.SetDefault("quiet-hours", []string{})
+
+	// Statuses that pause due-date (SLA) timers in bd report sla
+	v.SetDefault("sla.paused-statuses", []string{})
+
+	// Close categories ("wontfix: ..."), and the priority down to which
+	// closing needs one (-1: never required)
+	v.SetDefault("close.categories", resolution.Builtin)
+	v.SetDefault("close.require-category-max-priority", -1)
+
+	// Outcomes closed issues can be tagged with (bd close --outcome, bd
+	// outcome), summarized by bd report retro
+	v.SetDefault("close.outcomes", []string{"shipped", "reverted", "abandoned"})
+
+	// Issue types whose close needs verification evidence (bd close
+	// --evidence-url/--evidence-file), e.g. [bug]
+	v.SetDefault("close.require-evidence-types", []string{})
+
+	// Whether any bd command creates the issues of due recurrences (bd
+	// recur), or only bd recur tick does
+	v.SetDefault("recur.auto-tick", true)
+
 	// External projects for cross-project dependency resolution (bd-h807)
 	// Maps project names to paths for resolving external: blocked_by references
 	v.SetDefault("external_projects", map[string]string{})
@@ -225,6 +364,7 @@ func Initialize() error {
 // WARNING: Not thread-safe. Only call from single-threaded test contexts.
 func ResetForTesting() {
 	v = nil
+	activeProfile, profileKeys = "", nil
 }
 
 // ConfigSource represents where a configuration value came from
@@ -234,6 +374,7 @@ const (
 	SourceDefault    ConfigSource = "default"
 	SourceConfigFile ConfigSource = "config_file"
 	SourceEnvVar     ConfigSource = "env_var"
+	SourceProfile    ConfigSource = "profile"
 	SourceFlag       ConfigSource = "flag"
 )
 
@@ -247,7 +388,7 @@ type ConfigOverride struct {
 }
 
 // GetValueSource returns the source of a configuration value.
-// Priority (highest to lowest): env var > config file > default
+// Priority (highest to lowest): env var > profile > config file > default
 // Note: Flag overrides are handled separately in main.go since viper doesn't know about cobra flags.
 func GetValueSource(key string) ConfigSource {
 	if v == nil {
@@ -267,6 +408,15 @@ func GetValueSource(key string) ConfigSource {
 	if os.Getenv(beadsEnvKey) != "" {
 		return SourceEnvVar
 	}
+	for _, alias := range envAliases[key] {
+		if os.Getenv(alias) != "" {
+			return SourceEnvVar
+		}
+	}
+
+	if profileKeys[key] {
+		return SourceProfile
+	}
 
 	// Check if value is set in config file (as opposed to being a default)
 	if v.InConfig(key) {
@@ -291,8 +441,8 @@ func CheckOverrides(flagOverrides map[string]struct {
 		}
 
 		source := GetValueSource(key)
-		if source == SourceConfigFile || source == SourceEnvVar {
-			// Flag is overriding a config file or env var value
+		if source == SourceConfigFile || source == SourceEnvVar || source == SourceProfile {
+			// Flag is overriding a config file, env var, or profile value
 			var originalValue interface{}
 			switch v := flagInfo.Value.(type) {
 			case bool:
@@ -356,6 +506,8 @@ func LogOverride(override ConfigOverride) {
 		sourceDesc = "config file"
 	case SourceEnvVar:
 		sourceDesc = "environment variable"
+	case SourceProfile:
+		sourceDesc = "profile " + activeProfile
 	case SourceDefault:
 		sourceDesc = "default"
 	default:
@@ -480,6 +632,27 @@ func DefaultAIModel() string {
 	return GetString("ai.model")
 }
 
+// DisplayGlyphs reports whether human-readable output may use Unicode
+// symbols and emoji. True unless display.glyphs is set to false.
+// Override via: bd config set display.glyphs false or BD_DISPLAY_GLYPHS=false
+func DisplayGlyphs() bool {
+	if v == nil {
+		return true
+	}
+	return v.GetBool("display.glyphs")
+}
+
+// ReferencesAutoLink reports whether issue references written into text
+// and comments become relates_to links. True unless references.auto-link
+// is set to false.
+// Override via: bd config set references.auto-link false or BD_REFERENCES_AUTO_LINK=false
+func ReferencesAutoLink() bool {
+	if v == nil {
+		return true
+	}
+	return v.GetBool("references.auto-link")
+}
+
 // AllSettings returns all configuration settings as a map
 func AllSettings() map[string]interface{} {
 	if v == nil {
@@ -744,6 +917,32 @@ func GetFederationConfig() FederationConfig {
 	}
 }
 
+// DefaultRelayMaxHops is the default limit on how many relay hubs a change
+// may pass through before it is no longer forwarded.
+const DefaultRelayMaxHops = 3
+
+// RelayConfig holds the hub-and-spoke relay configuration.
+type RelayConfig struct {
+	Enabled bool     // Whether this town relays changes between its spokes
+	ID      string   // Hub identity recorded in relay provenance
+	Spokes  []string // Spokes to relay between (empty = all peers)
+	MaxHops int      // Maximum relay hops before forwarding stops
+}
+
+// GetRelayConfig returns the current relay configuration.
+func GetRelayConfig() RelayConfig {
+	maxHops := GetInt("federation.relay.max-hops")
+	if maxHops <= 0 {
+		maxHops = DefaultRelayMaxHops
+	}
+	return RelayConfig{
+		Enabled: GetBool("federation.relay.enabled"),
+		ID:      GetString("federation.relay.id"),
+		Spokes:  getConfigList("federation.relay.spokes"),
+		MaxHops: maxHops,
+	}
+}
+
 // GetCustomTypesFromYAML retrieves custom issue types from config.yaml.
 // This is used as a fallback when the database doesn't have types.custom set yet
 // (e.g., during bd init auto-import before the database is fully configured).
@@ -760,6 +959,13 @@ func GetCustomStatusesFromYAML() []string {
 	return getConfigList("status.custom")
 }
 
+// GetStatusListFromYAML retrieves a status list (status.ready,
+// status.blocking) from config.yaml, as a fallback for when the database
+// doesn't set it. Returns nil if it isn't configured.
+func GetStatusListFromYAML(key string) []string {
+	return getConfigList(key)
+}
+
 // ===== Agent Role Configuration =====
 // These functions return agent role types from config.yaml for agent ID parsing.
 // Each role category has different parsing semantics:
@@ -90,6 +90,7 @@ func TestEnvironmentBinding(t *testing.T) {
 		{"BD_JSON", "json", "true", true, func(k string) interface{} { return GetBool(k) }},
 		{"BD_ACTOR", "actor", "testuser", "testuser", func(k string) interface{} { return GetString(k) }},
 		{"BD_DB", "db", "/tmp/test.db", "/tmp/test.db", func(k string) interface{} { return GetString(k) }},
+		{"BD_DATABASE", "db", "/tmp/other.db", "/tmp/other.db", func(k string) interface{} { return GetString(k) }},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Profile state set by ApplyProfile: the active profile's name and the keys
+// it set.
+var (
+	activeProfile string
+	profileKeys   map[string]bool
+)
+
+// ApplyProfile overlays the settings of the named profile, configured under
+// profiles.<name> in config.yaml, on top of the config file. Environment
+// variables still win over the profile, and flags win over both since
+// main.go only reads config for flags that weren't given. A relative db is
+// resolved against the directory holding config.yaml, so a profile can point
+// at a database of its own next to the main one.
+//
+// Example config.yaml:
+//
+//	profiles:
+//	  experiments:
+//	    db: profiles/experiments/dolt
+//	    actor: sandbox-agent
+//	    dolt:
+//	      auto-commit: "off"
+func ApplyProfile(name string) error {
+	settings, err := profileSettings(name)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]bool)
+	for key, value := range settings {
+		if GetValueSource(key) == SourceEnvVar {
+			continue
+		}
+		v.Set(key, value)
+		keys[key] = true
+	}
+	activeProfile, profileKeys = name, keys
+	return nil
+}
+
+// ProfileDatabase returns the db the named profile points at, resolved like
+// ApplyProfile resolves it, or "" if the profile doesn't set one. It doesn't
+// apply the profile.
+func ProfileDatabase(name string) (string, error) {
+	settings, err := profileSettings(name)
+	if err != nil {
+		return "", err
+	}
+	db, _ := settings["db"].(string)
+	return db, nil
+}
+
+// profileSettings returns the named profile's settings under dotted keys,
+// with a relative db resolved against the directory holding config.yaml.
+func profileSettings(name string) (map[string]interface{}, error) {
+	if v == nil {
+		return nil, fmt.Errorf("config not initialized")
+	}
+	raw := v.Get("profiles." + name)
+	if raw == nil {
+		names := ProfileNames()
+		if len(names) == 0 {
+			return nil, fmt.Errorf("unknown profile %q: no profiles are configured in config.yaml", name)
+		}
+		return nil, fmt.Errorf("unknown profile %q (configured: %s)", name, strings.Join(names, ", "))
+	}
+	section, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("profile %q must be a mapping of config keys to values", name)
+	}
+
+	settings := make(map[string]interface{})
+	flattenSettings("", section, settings)
+	for key := range settings {
+		if key == "profile" || key == "profiles" || strings.HasPrefix(key, "profiles.") {
+			return nil, fmt.Errorf("profile %q can't set %s", name, key)
+		}
+	}
+	if db, ok := settings["db"].(string); ok && db != "" && !filepath.IsAbs(db) {
+		if configFile := v.ConfigFileUsed(); configFile != "" {
+			settings["db"] = filepath.Join(filepath.Dir(configFile), db)
+		}
+	}
+	return settings, nil
+}
+
+// ActiveProfile returns the name of the profile applied by ApplyProfile, or
+// "" if none is.
+func ActiveProfile() string {
+	return activeProfile
+}
+
+// ProfileNames returns the names of the profiles configured in config.yaml,
+// sorted.
+func ProfileNames() []string {
+	if v == nil {
+		return nil
+	}
+	var names []string
+	for name := range v.GetStringMap("profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// flattenSettings adds the leaves of a nested settings map to out under
+// dotted keys, e.g. {dolt: {auto-commit: off}} as dolt.auto-commit.
+func flattenSettings(prefix string, m map[string]interface{}, out map[string]interface{}) {
+	for key, value := range m {
+		key = strings.ToLower(key)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if sub, ok := value.(map[string]interface{}); ok {
+			flattenSettings(key, sub, out)
+			continue
+		}
+		out[key] = value
+	}
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApplyProfile(t *testing.T) {
+	restore := envSnapshot(t)
+	defer restore()
+
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("failed to create .beads directory: %v", err)
+	}
+	configContent := `
+actor: project-user
+dolt:
+  auto-commit: "on"
+profiles:
+  experiments:
+    db: profiles/experiments/dolt
+    actor: sandbox-agent
+    dolt:
+      auto-commit: "off"
+  shared:
+    db: /srv/beads/dolt
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte(configContent), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Chdir(tmpDir)
+
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+	if got := ProfileNames(); !reflect.DeepEqual(got, []string{"experiments", "shared"}) {
+		t.Errorf("ProfileNames() = %v", got)
+	}
+
+	wantDB := filepath.Join(filepath.Dir(ConfigFileUsed()), "profiles", "experiments", "dolt")
+	if got, err := ProfileDatabase("experiments"); err != nil || got != wantDB {
+		t.Errorf("ProfileDatabase(experiments) = %q, %v; want %q", got, err, wantDB)
+	}
+	if got, _ := ProfileDatabase("shared"); got != "/srv/beads/dolt" {
+		t.Errorf("ProfileDatabase(shared) = %q, want the absolute path as is", got)
+	}
+	if ActiveProfile() != "" || GetString("actor") != "project-user" {
+		t.Error("ProfileDatabase applied the profile")
+	}
+
+	t.Setenv("BD_ACTOR", "env-user")
+	if err := ApplyProfile("experiments"); err != nil {
+		t.Fatalf("ApplyProfile() returned error: %v", err)
+	}
+	if got := ActiveProfile(); got != "experiments" {
+		t.Errorf("ActiveProfile() = %q, want experiments", got)
+	}
+	if got := GetString("db"); got != wantDB {
+		t.Errorf("GetString(db) = %q, want %q (relative to config.yaml)", got, wantDB)
+	}
+	if got := GetString("dolt.auto-commit"); got != "off" {
+		t.Errorf("GetString(dolt.auto-commit) = %q, want off (from profile)", got)
+	}
+	if got := GetString("actor"); got != "env-user" {
+		t.Errorf("GetString(actor) = %q, want env-user (env var beats profile)", got)
+	}
+	if got := GetValueSource("db"); got != SourceProfile {
+		t.Errorf("GetValueSource(db) = %q, want %q", got, SourceProfile)
+	}
+	if got := GetValueSource("actor"); got != SourceEnvVar {
+		t.Errorf("GetValueSource(actor) = %q, want %q", got, SourceEnvVar)
+	}
+}
+
+func TestApplyProfileErrors(t *testing.T) {
+	restore := envSnapshot(t)
+	defer restore()
+
+	tmpDir := t.TempDir()
+	beadsDir := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("failed to create .beads directory: %v", err)
+	}
+	configContent := `
+profiles:
+  flat: sandbox
+  nested:
+    profile: other
+  sandbox:
+    actor: agent
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte(configContent), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Chdir(tmpDir)
+
+	if err := Initialize(); err != nil {
+		t.Fatalf("Initialize() returned error: %v", err)
+	}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"missing", `unknown profile "missing" (configured: flat, nested, sandbox)`},
+		{"flat", "must be a mapping"},
+		{"nested", "can't set profile"},
+	}
+	for _, tt := range tests {
+		err := ApplyProfile(tt.name)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ApplyProfile(%q) = %v, want error containing %q", tt.name, err, tt.want)
+		}
+	}
+	if got := ActiveProfile(); got != "" {
+		t.Errorf("ActiveProfile() = %q after failed applies, want none", got)
+	}
+}
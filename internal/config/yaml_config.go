@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // YamlOnlyKeys are configuration keys that must be stored in config.yaml
@@ -53,6 +54,9 @@ var YamlOnlyKeys = map[string]bool{
 
 	// Hierarchy settings (GH#995)
 	"hierarchy.max-depth": true,
+
+	// Storage maintenance (local disk, never synced)
+	"storage.auto-gc-interval": true,
 }
 
 // IsYamlOnlyKey returns true if the given key should be stored in config.yaml
@@ -64,7 +68,7 @@ func IsYamlOnlyKey(key string) bool {
 	}
 
 	// Check prefix matches for nested keys
-	prefixes := []string{"routing.", "sync.", "git.", "directory.", "repos.", "external_projects.", "validation.", "hierarchy.", "ai."}
+	prefixes := []string{"routing.", "sync.", "git.", "directory.", "repos.", "external_projects.", "validation.", "hierarchy.", "ai.", "embeddings.", "summarize.", "scan.", "retention.", "calendar.", "federation.relay."}
 	for _, prefix := range prefixes {
 		if strings.HasPrefix(key, prefix) {
 			return true
@@ -280,6 +284,18 @@ func validateYamlConfigValue(key, value string) error {
 		if depth < 1 {
 			return fmt.Errorf("hierarchy.max-depth must be at least 1, got %d", depth)
 		}
+	case "storage.auto-gc-interval":
+		// Empty or "0" disables automatic GC; anything else must be a duration
+		if value == "" || value == "0" {
+			return nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("storage.auto-gc-interval must be a duration like \"24h\", got %q", value)
+		}
+		if d < 0 {
+			return fmt.Errorf("storage.auto-gc-interval must not be negative, got %s", value)
+		}
 	}
 	return nil
 }
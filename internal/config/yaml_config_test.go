@@ -30,6 +30,21 @@ func TestIsYamlOnlyKey(t *testing.T) {
 		{"hierarchy.max-depth", true},
 		{"hierarchy.custom_setting", true}, // prefix match
 
+		// Federation relay settings are per-town, never synced
+		{"federation.relay.enabled", true},
+		{"federation.relay.spokes", true},
+
+		// Embedding settings select the model behind the local embeddings cache
+		{"embeddings.provider", true},
+		{"embeddings.api-key-env", true},
+		{"summarize.provider", true},
+		{"scan.mode", true},
+		{"retention.closed-after", true},
+		{"calendar.holidays", true},
+
+		// Storage maintenance settings are local to each clone
+		{"storage.auto-gc-interval", true},
+
 		// Non-yaml keys (should return false)
 		{"jira.url", false},
 		{"jira.project", false},
@@ -260,6 +275,33 @@ func TestValidateYamlConfigValue_HierarchyMaxDepth(t *testing.T) {
 	}
 }
 
+// TestValidateYamlConfigValue_AutoGCInterval tests validation of storage.auto-gc-interval
+func TestValidateYamlConfigValue_AutoGCInterval(t *testing.T) {
+	tests := []struct {
+		value     string
+		expectErr bool
+	}{
+		{"24h", false},
+		{"90m", false},
+		{"0", false},
+		{"", false},
+		{"daily", true},
+		{"-1h", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			err := validateYamlConfigValue("storage.auto-gc-interval", tt.value)
+			if tt.expectErr && err == nil {
+				t.Errorf("expected error for value %q, got nil", tt.value)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("unexpected error for value %q: %v", tt.value, err)
+			}
+		})
+	}
+}
+
 // TestValidateYamlConfigValue_OtherKeys tests that other keys are not validated
 func TestValidateYamlConfigValue_OtherKeys(t *testing.T) {
 	// Other keys should pass validation regardless of value
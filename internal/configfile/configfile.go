@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const ConfigFileName = "metadata.json"
@@ -28,6 +29,12 @@ type Config struct {
 	DoltServerUser string `json:"dolt_server_user,omitempty"` // MySQL user (default: root)
 	DoltDatabase   string `json:"dolt_database,omitempty"`    // SQL database name (default: beads)
 	DoltServerTLS  bool   `json:"dolt_server_tls,omitempty"`  // Enable TLS for server connections (required for Hosted Dolt)
+	DoltAutoStart  *bool  `json:"dolt_auto_start,omitempty"`  // Start a local sql-server when none is reachable (default: true)
+
+	// Read replicas (server mode): list/ready/search queries are routed to a
+	// replica whose data is no more than DoltReplicaMaxStaleness behind.
+	DoltReadReplicas        []string `json:"dolt_read_replicas,omitempty"`         // "host:port" of read-only replicas
+	DoltReplicaMaxStaleness string   `json:"dolt_replica_max_staleness,omitempty"` // Duration, e.g. "5s" (default: 5s)
 	// Note: Password should be set via BEADS_DOLT_PASSWORD env var for security
 
 	// Stale closed issues check configuration
@@ -297,3 +304,59 @@ func (c *Config) GetDoltServerTLS() bool {
 	}
 	return c.DoltServerTLS
 }
+
+// DefaultReplicaMaxStaleness bounds how far behind the primary a read replica
+// may be before reads fall back to the primary.
+const DefaultReplicaMaxStaleness = 5 * time.Second
+
+// GetDoltReadReplicas returns the "host:port" addresses of read replicas.
+// Checks BEADS_DOLT_READ_REPLICAS env var (comma-separated) first, then config.
+func (c *Config) GetDoltReadReplicas() []string {
+	raw := c.DoltReadReplicas
+	if r := os.Getenv("BEADS_DOLT_READ_REPLICAS"); r != "" {
+		raw = strings.Split(r, ",")
+	}
+	var replicas []string
+	for _, addr := range raw {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			replicas = append(replicas, addr)
+		}
+	}
+	return replicas
+}
+
+// GetDoltReplicaMaxStaleness returns the staleness bound for read replicas.
+// Checks BEADS_DOLT_REPLICA_MAX_STALENESS env var first, then config, then
+// DefaultReplicaMaxStaleness. Invalid values fall back to the default.
+func (c *Config) GetDoltReplicaMaxStaleness() time.Duration {
+	value := c.DoltReplicaMaxStaleness
+	if v := os.Getenv("BEADS_DOLT_REPLICA_MAX_STALENESS"); v != "" {
+		value = v
+	}
+	if value == "" {
+		return DefaultReplicaMaxStaleness
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return DefaultReplicaMaxStaleness
+	}
+	return d
+}
+
+// GetDoltAutoStart returns whether bd should start a local dolt sql-server
+// when the configured (loopback) server is not reachable.
+// Checks BEADS_DOLT_AUTO_START env var first ("0"/"false" disables), then
+// config, then defaults to true. Test mode (BEADS_TEST_MODE=1) defaults to
+// false so tests never leave stray servers behind.
+func (c *Config) GetDoltAutoStart() bool {
+	if a := os.Getenv("BEADS_DOLT_AUTO_START"); a != "" {
+		return a == "1" || strings.ToLower(a) == "true"
+	}
+	if os.Getenv("BEADS_TEST_MODE") == "1" {
+		return false
+	}
+	if c.DoltAutoStart != nil {
+		return *c.DoltAutoStart
+	}
+	return true
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -528,3 +529,83 @@ func TestEnvVarOverrides(t *testing.T) {
 		}
 	})
 }
+
+func TestGetDoltAutoStart(t *testing.T) {
+	disabled := false
+
+	t.Run("defaults to enabled", func(t *testing.T) {
+		t.Setenv("BEADS_TEST_MODE", "")
+		cfg := &Config{}
+		if !cfg.GetDoltAutoStart() {
+			t.Error("GetDoltAutoStart() = false, want true by default")
+		}
+	})
+
+	t.Run("config disables", func(t *testing.T) {
+		t.Setenv("BEADS_TEST_MODE", "")
+		cfg := &Config{DoltAutoStart: &disabled}
+		if cfg.GetDoltAutoStart() {
+			t.Error("GetDoltAutoStart() = true, want false when disabled in config")
+		}
+	})
+
+	t.Run("env var overrides config", func(t *testing.T) {
+		t.Setenv("BEADS_DOLT_AUTO_START", "1")
+		cfg := &Config{DoltAutoStart: &disabled}
+		if !cfg.GetDoltAutoStart() {
+			t.Error("GetDoltAutoStart() = false, want true when env var set")
+		}
+	})
+
+	t.Run("test mode disables by default", func(t *testing.T) {
+		t.Setenv("BEADS_TEST_MODE", "1")
+		cfg := &Config{}
+		if cfg.GetDoltAutoStart() {
+			t.Error("GetDoltAutoStart() = true, want false in test mode")
+		}
+	})
+}
+
+func TestGetDoltReadReplicas(t *testing.T) {
+	t.Run("from config", func(t *testing.T) {
+		t.Setenv("BEADS_DOLT_READ_REPLICAS", "")
+		cfg := &Config{DoltReadReplicas: []string{"10.0.0.2:3307", " ", "10.0.0.3:3307"}}
+		got := cfg.GetDoltReadReplicas()
+		if len(got) != 2 || got[0] != "10.0.0.2:3307" || got[1] != "10.0.0.3:3307" {
+			t.Errorf("GetDoltReadReplicas() = %v", got)
+		}
+	})
+
+	t.Run("env var overrides config", func(t *testing.T) {
+		t.Setenv("BEADS_DOLT_READ_REPLICAS", "replica-a:3307, replica-b:3307")
+		cfg := &Config{DoltReadReplicas: []string{"10.0.0.2:3307"}}
+		got := cfg.GetDoltReadReplicas()
+		if len(got) != 2 || got[0] != "replica-a:3307" || got[1] != "replica-b:3307" {
+			t.Errorf("GetDoltReadReplicas() = %v", got)
+		}
+	})
+}
+
+func TestGetDoltReplicaMaxStaleness(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		env    string
+		want   time.Duration
+	}{
+		{"default", "", "", DefaultReplicaMaxStaleness},
+		{"config", "30s", "", 30 * time.Second},
+		{"env overrides config", "30s", "2m", 2 * time.Minute},
+		{"zero means always current", "0s", "", 0},
+		{"invalid falls back", "soon", "", DefaultReplicaMaxStaleness},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BEADS_DOLT_REPLICA_MAX_STALENESS", tt.env)
+			cfg := &Config{DoltReplicaMaxStaleness: tt.config}
+			if got := cfg.GetDoltReplicaMaxStaleness(); got != tt.want {
+				t.Errorf("GetDoltReplicaMaxStaleness() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
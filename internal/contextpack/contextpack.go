@@ -0,0 +1,205 @@
+// Package contextpack assembles an issue and the issues around it into a
+// Markdown context pack that fits a token budget, for pasting into (or
+// piping to) an LLM prompt.
+//
+// Items are packed in priority order and trimmed by fixed rules, so the
+// same issues and budget always produce the same pack. When the pack is
+// over budget, the lowest-priority items are first reduced to a one-line
+// summary, then dropped, and finally the issue's own body is cut short.
+package contextpack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kind is the relationship of an item to the issue the pack is for.
+type Kind string
+
+// Item kinds, in the order their sections appear in a pack.
+const (
+	KindIssue    Kind = "issue"
+	KindAncestor Kind = "ancestor"
+	KindBlocker  Kind = "blocker"
+	KindRelated  Kind = "related"
+	KindClosure  Kind = "closure"
+)
+
+// heading is the Markdown section heading for items of the kind.
+func (k Kind) heading() string {
+	switch k {
+	case KindIssue:
+		return "Issue"
+	case KindAncestor:
+		return "Ancestors"
+	case KindBlocker:
+		return "Blockers"
+	case KindRelated:
+		return "Related"
+	case KindClosure:
+		return "Recent closures"
+	}
+	return string(k)
+}
+
+// TruncatedMarker ends a body that was cut short to fit the budget.
+const TruncatedMarker = "…[truncated]"
+
+// Item is one issue in a pack.
+type Item struct {
+	Kind  Kind   `json:"kind"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Meta  string `json:"meta,omitempty"` // One line: type, priority, status, ...
+	Body  string `json:"body,omitempty"` // Markdown: description, design, notes, ...
+
+	Brief     bool `json:"brief,omitempty"`     // Body dropped to fit the budget
+	Truncated bool `json:"truncated,omitempty"` // Body cut short to fit the budget
+}
+
+// Pack is a budgeted context pack.
+type Pack struct {
+	IssueID string   `json:"issue_id"`
+	Budget  int      `json:"budget"`
+	Tokens  int      `json:"tokens"` // Estimated size of the Markdown rendering
+	Items   []Item   `json:"items"`
+	Omitted []string `json:"omitted,omitempty"` // IDs dropped to fit the budget
+}
+
+// EstimateTokens estimates the number of LLM tokens in s at about four
+// characters per token, which is close for English prose and Markdown.
+func EstimateTokens(s string) int {
+	return (utf8.RuneCountInString(s) + 3) / 4
+}
+
+// ParseBudget parses a token budget such as "8000", "8000tokens", or "8k".
+func ParseBudget(s string) (int, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	v = strings.TrimSuffix(strings.TrimSuffix(v, "tokens"), "token")
+	v = strings.TrimSpace(v)
+	mult := 1
+	if strings.HasSuffix(v, "k") {
+		mult = 1000
+		v = strings.TrimSuffix(v, "k")
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid token budget %q (want e.g. 8000, 8000tokens, or 8k)", s)
+	}
+	return n * mult, nil
+}
+
+// Build packs items into budget tokens. items[0] is the issue the pack is
+// for; the rest are in priority order, most important first. Build applies
+// these rules, in order, until the pack fits:
+//
+//  1. Reduce the lowest-priority item still shown in full to a summary
+//     line (ID, title, and meta).
+//  2. Drop the lowest-priority item, recording its ID in Omitted.
+//  3. Cut the issue's body to fit, ending it with TruncatedMarker.
+//
+// The issue's title and meta are always kept, so a pack can exceed a
+// budget too small to hold them.
+func Build(items []Item, budget int) *Pack {
+	p := &Pack{Budget: budget, Items: append([]Item(nil), items...)}
+	if len(p.Items) == 0 {
+		return p
+	}
+	p.IssueID = p.Items[0].ID
+
+	for i := len(p.Items) - 1; i > 0 && p.tokens() > budget; i-- {
+		if p.Items[i].Body != "" {
+			p.Items[i].Body = ""
+			p.Items[i].Brief = true
+		}
+	}
+	for len(p.Items) > 1 && p.tokens() > budget {
+		last := p.Items[len(p.Items)-1]
+		p.Items = p.Items[:len(p.Items)-1]
+		p.Omitted = append([]string{last.ID}, p.Omitted...)
+	}
+	if p.tokens() > budget {
+		p.truncateIssueBody()
+	}
+	p.Tokens = p.tokens()
+	return p
+}
+
+// truncateIssueBody cuts the issue's body to the longest prefix that keeps
+// the pack within budget, preferring to cut at the end of a line.
+func (p *Pack) truncateIssueBody() {
+	issue := &p.Items[0]
+	body := []rune(issue.Body)
+	issue.Truncated = true
+	issue.Body = ""
+	if p.tokens() > p.Budget {
+		issue.Body = TruncatedMarker
+		return
+	}
+	// Binary search for the longest prefix that fits.
+	lo, hi := 0, len(body)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		issue.Body = cutBody(body[:mid])
+		if p.tokens() <= p.Budget {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	prefix := body[:lo]
+	if nl := strings.LastIndex(string(prefix), "\n"); nl > 0 && nl >= len(string(prefix))/2 {
+		prefix = []rune(string(prefix)[:nl])
+	}
+	issue.Body = cutBody(prefix)
+}
+
+// cutBody ends a truncated body with the marker.
+func cutBody(prefix []rune) string {
+	s := strings.TrimRight(string(prefix), " \t\n")
+	if s == "" {
+		return TruncatedMarker
+	}
+	return s + "\n" + TruncatedMarker
+}
+
+// tokens estimates the size of the pack's Markdown rendering.
+func (p *Pack) tokens() int {
+	return EstimateTokens(p.Markdown())
+}
+
+// Markdown renders the pack, with one section per kind of item.
+func (p *Pack) Markdown() string {
+	var b strings.Builder
+	if len(p.Items) > 0 {
+		fmt.Fprintf(&b, "# Context: %s\n", p.Items[0].ID)
+	}
+	var section Kind
+	for _, item := range p.Items {
+		if item.Kind != section {
+			section = item.Kind
+			fmt.Fprintf(&b, "\n## %s\n", section.heading())
+		}
+		if item.Brief {
+			fmt.Fprintf(&b, "\n- %s: %s", item.ID, item.Title)
+			if item.Meta != "" {
+				fmt.Fprintf(&b, " (%s)", item.Meta)
+			}
+			b.WriteString("\n")
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s: %s\n", item.ID, item.Title)
+		if item.Meta != "" {
+			fmt.Fprintf(&b, "%s\n", item.Meta)
+		}
+		if item.Body != "" {
+			fmt.Fprintf(&b, "\n%s\n", item.Body)
+		}
+	}
+	if len(p.Omitted) > 0 {
+		fmt.Fprintf(&b, "\n_Omitted to fit the budget: %s_\n", strings.Join(p.Omitted, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,111 @@
+package contextpack
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBudget(t *testing.T) {
+	for in, want := range map[string]int{"8000": 8000, "8000tokens": 8000, "8k": 8000, " 2K tokens ": 2000, "1token": 1} {
+		got, err := ParseBudget(in)
+		if err != nil || got != want {
+			t.Errorf("ParseBudget(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "0", "-5", "lots", "8m"} {
+		if _, err := ParseBudget(bad); err == nil {
+			t.Errorf("ParseBudget(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	if got := EstimateTokens(""); got != 0 {
+		t.Errorf("EstimateTokens(\"\") = %d", got)
+	}
+	if got := EstimateTokens("abcde"); got != 2 {
+		t.Errorf("EstimateTokens(5 chars) = %d, want 2", got)
+	}
+	if got := EstimateTokens("→→→→"); got != 1 {
+		t.Errorf("EstimateTokens counts bytes, not characters: %d", got)
+	}
+}
+
+func testItems() []Item {
+	return []Item{
+		{Kind: KindIssue, ID: "bd-1", Title: "Fix login", Meta: "bug · P1 · open", Body: strings.Repeat("The login form drops the session.\n", 20)},
+		{Kind: KindAncestor, ID: "bd-0", Title: "Auth epic", Meta: "epic · P1 · open", Body: strings.Repeat("Rework auth. ", 20)},
+		{Kind: KindBlocker, ID: "bd-2", Title: "Session store", Meta: "task · P2 · open", Body: strings.Repeat("Move sessions. ", 20)},
+		{Kind: KindClosure, ID: "bd-3", Title: "Cookie flags", Meta: "task · P2 · closed", Body: strings.Repeat("Set SameSite. ", 20)},
+	}
+}
+
+func TestBuildFitsEverything(t *testing.T) {
+	p := Build(testItems(), 100000)
+	if len(p.Items) != 4 || p.Omitted != nil {
+		t.Fatalf("Build dropped items with a large budget: %+v", p)
+	}
+	for _, item := range p.Items {
+		if item.Brief || item.Truncated {
+			t.Errorf("%s trimmed with a large budget", item.ID)
+		}
+	}
+	md := p.Markdown()
+	for _, want := range []string{"# Context: bd-1", "## Issue", "## Ancestors", "## Blockers", "## Recent closures", "### bd-2: Session store"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md)
+		}
+	}
+	if p.Tokens != EstimateTokens(md) || p.IssueID != "bd-1" {
+		t.Errorf("Tokens = %d, IssueID = %q", p.Tokens, p.IssueID)
+	}
+}
+
+func TestBuildBriefsLowestPriorityFirst(t *testing.T) {
+	full := Build(testItems(), 100000).Tokens
+	p := Build(testItems(), full-10)
+	if !p.Items[3].Brief || p.Items[1].Brief || p.Items[2].Brief {
+		t.Errorf("want only the closure reduced: %+v", p.Items)
+	}
+	if p.Tokens > p.Budget {
+		t.Errorf("Tokens %d over budget %d", p.Tokens, p.Budget)
+	}
+	if !strings.Contains(p.Markdown(), "- bd-3: Cookie flags (task · P2 · closed)") {
+		t.Errorf("brief item not rendered as a summary line:\n%s", p.Markdown())
+	}
+}
+
+func TestBuildDropsThenTruncates(t *testing.T) {
+	p := Build(testItems(), 60)
+	if len(p.Items) != 1 || !reflect.DeepEqual(p.Omitted, []string{"bd-0", "bd-2", "bd-3"}) {
+		t.Fatalf("want all but the issue omitted in order, got items %+v omitted %v", p.Items, p.Omitted)
+	}
+	issue := p.Items[0]
+	if !issue.Truncated || !strings.HasSuffix(issue.Body, TruncatedMarker) {
+		t.Errorf("issue body not truncated: %q", issue.Body)
+	}
+	if p.Tokens > 60 {
+		t.Errorf("Tokens %d over budget 60", p.Tokens)
+	}
+	if !strings.Contains(p.Markdown(), "_Omitted to fit the budget: bd-0, bd-2, bd-3_") {
+		t.Errorf("omitted note missing:\n%s", p.Markdown())
+	}
+}
+
+func TestBuildIsDeterministic(t *testing.T) {
+	for budget := 20; budget < 600; budget += 37 {
+		a, b := Build(testItems(), budget), Build(testItems(), budget)
+		if a.Markdown() != b.Markdown() {
+			t.Fatalf("budget %d: packs differ", budget)
+		}
+	}
+}
+
+func TestBuildDoesNotModifyItems(t *testing.T) {
+	items := testItems()
+	Build(items, 50)
+	if !reflect.DeepEqual(items, testItems()) {
+		t.Error("Build modified its input")
+	}
+}
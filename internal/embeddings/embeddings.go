@@ -0,0 +1,109 @@
+// Package embeddings turns issue text into vectors for similarity search.
+//
+// An Embedder is either the built-in local model, which needs no network
+// access, or an HTTP provider (Ollama, or any OpenAI-compatible embeddings
+// API). Vectors are only comparable when produced by the same Model.
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Provider names accepted by New.
+const (
+	ProviderLocal  = "local"
+	ProviderOllama = "ollama"
+	ProviderOpenAI = "openai"
+)
+
+// DefaultTimeout bounds each request to an HTTP provider.
+const DefaultTimeout = 60 * time.Second
+
+// Embedder computes embedding vectors for text.
+type Embedder interface {
+	// Model identifies the provider, model, and dimensions, e.g.
+	// "ollama/nomic-embed-text". Stored vectors are keyed by it so that
+	// switching models never mixes incomparable vectors.
+	Model() string
+
+	// Embed returns one vector per input text, in order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// Config selects and configures an Embedder.
+type Config struct {
+	Provider   string // local (default), ollama, or openai
+	Model      string // Provider model name; empty for the provider default
+	URL        string // Base URL for HTTP providers; empty for the provider default
+	APIKey     string // Bearer token for the openai provider
+	Dimensions int    // Vector size; 0 for the provider default
+}
+
+// New returns the Embedder described by cfg.
+func New(cfg Config) (Embedder, error) {
+	client := &http.Client{Timeout: DefaultTimeout}
+	switch strings.ToLower(strings.TrimSpace(cfg.Provider)) {
+	case "", ProviderLocal:
+		return NewLocal(cfg.Dimensions), nil
+	case ProviderOllama:
+		return &ollamaEmbedder{
+			url:    strings.TrimRight(defaultString(cfg.URL, "http://localhost:11434"), "/"),
+			model:  defaultString(cfg.Model, "nomic-embed-text"),
+			client: client,
+		}, nil
+	case ProviderOpenAI:
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("the openai embeddings provider requires an API key")
+		}
+		return &openAIEmbedder{
+			url:        strings.TrimRight(defaultString(cfg.URL, "https://api.openai.com/v1"), "/"),
+			model:      defaultString(cfg.Model, "text-embedding-3-small"),
+			apiKey:     cfg.APIKey,
+			dimensions: cfg.Dimensions,
+			client:     client,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown embeddings provider %q (use: %s, %s, %s)",
+			cfg.Provider, ProviderLocal, ProviderOllama, ProviderOpenAI)
+	}
+}
+
+// Cosine returns the cosine similarity of two vectors, or 0 if their
+// lengths differ or either is zero.
+func Cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, magA, magB float64
+	for i := range a {
+		x, y := float64(a[i]), float64(b[i])
+		dot += x * y
+		magA += x * x
+		magB += y * y
+	}
+	if magA == 0 || magB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(magA) * math.Sqrt(magB))
+}
+
+// ContentHash returns the hex SHA-256 of text. A stored vector is reused
+// only while the hash of the issue text it was computed from still matches.
+func ContentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+func defaultString(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,104 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocalEmbedderRanksRelatedText(t *testing.T) {
+	e := NewLocal(0)
+	if e.Model() != "local/hash-v1-256" {
+		t.Errorf("Model() = %q", e.Model())
+	}
+	vecs, err := e.Embed(context.Background(), []string{
+		"Login page crashes when the password is empty",
+		"Crash on login with an empty password field",
+		"Add dark mode to the settings screen",
+		"",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	related := Cosine(vecs[0], vecs[1])
+	unrelated := Cosine(vecs[0], vecs[2])
+	if related <= unrelated || related < 0.3 {
+		t.Errorf("related = %.3f, unrelated = %.3f", related, unrelated)
+	}
+	if Cosine(vecs[0], vecs[3]) != 0 {
+		t.Error("empty text should have a zero vector")
+	}
+
+	again, _ := e.Embed(context.Background(), []string{"Login page crashes when the password is empty"})
+	if Cosine(vecs[0], again[0]) < 0.9999 {
+		t.Error("local embeddings should be deterministic")
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(Config{Provider: "bogus"}); err == nil {
+		t.Error("expected error for unknown provider")
+	}
+	if _, err := New(Config{Provider: ProviderOpenAI}); err == nil {
+		t.Error("expected error for openai without an API key")
+	}
+	e, err := New(Config{Provider: ProviderOllama})
+	if err != nil || e.Model() != "ollama/nomic-embed-text" {
+		t.Errorf("ollama default = %v, %v", e, err)
+	}
+}
+
+func TestOpenAIEmbedder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" || r.Header.Get("Authorization") != "Bearer sk-test" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var req struct {
+			Input      []string `json:"input"`
+			Dimensions int      `json:"dimensions"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		// Answer out of order to check that index is honored
+		_, _ = w.Write([]byte(`{"data":[{"index":1,"embedding":[0,1]},{"index":0,"embedding":[1,0]}]}`))
+	}))
+	defer srv.Close()
+
+	e, err := New(Config{Provider: ProviderOpenAI, URL: srv.URL + "/v1/", APIKey: "sk-test", Dimensions: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Model() != "openai/text-embedding-3-small-2" {
+		t.Errorf("Model() = %q", e.Model())
+	}
+	vecs, err := e.Embed(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vecs[0][0] != 1 || vecs[1][1] != 1 {
+		t.Errorf("vectors = %v", vecs)
+	}
+}
+
+func TestOllamaEmbedderErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"embeddings":[[1,2]]}`))
+	}))
+	defer srv.Close()
+
+	e, _ := New(Config{Provider: ProviderOllama, URL: srv.URL})
+	if _, err := e.Embed(context.Background(), []string{"a", "b"}); err == nil {
+		t.Error("expected error when the server returns too few vectors")
+	}
+}
+
+func TestCosine(t *testing.T) {
+	if got := Cosine([]float32{1, 0}, []float32{1, 0}); got != 1 {
+		t.Errorf("identical = %v", got)
+	}
+	if got := Cosine([]float32{1, 0}, []float32{1, 0, 0}); got != 0 {
+		t.Errorf("mismatched lengths = %v", got)
+	}
+}
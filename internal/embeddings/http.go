@@ -0,0 +1,140 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// batchSize caps the number of texts sent in one request.
+const batchSize = 64
+
+// ollamaEmbedder calls a local Ollama server's /api/embed endpoint.
+type ollamaEmbedder struct {
+	url    string
+	model  string
+	client *http.Client
+}
+
+func (e *ollamaEmbedder) Model() string {
+	return "ollama/" + e.model
+}
+
+func (e *ollamaEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return inBatches(texts, func(batch []string) ([][]float32, error) {
+		var resp struct {
+			Embeddings [][]float32 `json:"embeddings"`
+			Error      string      `json:"error"`
+		}
+		req := map[string]interface{}{"model": e.model, "input": batch}
+		if err := postJSON(ctx, e.client, e.url+"/api/embed", "", req, &resp); err != nil {
+			return nil, err
+		}
+		if resp.Error != "" {
+			return nil, fmt.Errorf("ollama: %s", resp.Error)
+		}
+		return resp.Embeddings, nil
+	})
+}
+
+// openAIEmbedder calls an OpenAI-compatible /embeddings endpoint.
+type openAIEmbedder struct {
+	url        string
+	model      string
+	apiKey     string
+	dimensions int
+	client     *http.Client
+}
+
+func (e *openAIEmbedder) Model() string {
+	if e.dimensions > 0 {
+		return fmt.Sprintf("openai/%s-%d", e.model, e.dimensions)
+	}
+	return "openai/" + e.model
+}
+
+func (e *openAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return inBatches(texts, func(batch []string) ([][]float32, error) {
+		var resp struct {
+			Data []struct {
+				Index     int       `json:"index"`
+				Embedding []float32 `json:"embedding"`
+			} `json:"data"`
+		}
+		req := map[string]interface{}{"model": e.model, "input": batch}
+		if e.dimensions > 0 {
+			req["dimensions"] = e.dimensions
+		}
+		if err := postJSON(ctx, e.client, e.url+"/embeddings", e.apiKey, req, &resp); err != nil {
+			return nil, err
+		}
+		vectors := make([][]float32, len(batch))
+		for _, d := range resp.Data {
+			if d.Index < 0 || d.Index >= len(batch) {
+				return nil, fmt.Errorf("embeddings response has out-of-range index %d", d.Index)
+			}
+			vectors[d.Index] = d.Embedding
+		}
+		return vectors, nil
+	})
+}
+
+// inBatches embeds texts batchSize at a time and checks that every text
+// got a vector.
+func inBatches(texts []string, embed func([]string) ([][]float32, error)) ([][]float32, error) {
+	out := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += batchSize {
+		end := min(start+batchSize, len(texts))
+		vectors, err := embed(texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		if len(vectors) != end-start {
+			return nil, fmt.Errorf("embeddings response has %d vectors for %d inputs", len(vectors), end-start)
+		}
+		for i, v := range vectors {
+			if len(v) == 0 {
+				return nil, fmt.Errorf("embeddings response is missing a vector for input %d", start+i)
+			}
+		}
+		out = append(out, vectors...)
+	}
+	return out, nil
+}
+
+// postJSON sends body as JSON and decodes a successful response into out.
+func postJSON(ctx context.Context, client *http.Client, url, apiKey string, body, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode embeddings request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create embeddings request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("embeddings request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read embeddings response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("embeddings API error (status %d): %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse embeddings response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package embeddings
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// DefaultLocalDimensions is the vector size of the local model.
+const DefaultLocalDimensions = 256
+
+// stopwords are dropped before hashing; they carry no topical signal.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "but": true, "by": true, "can": true, "do": true, "for": true,
+	"from": true, "has": true, "have": true, "if": true, "in": true, "into": true,
+	"is": true, "it": true, "its": true, "not": true, "of": true, "on": true,
+	"or": true, "should": true, "so": true, "that": true, "the": true,
+	"there": true, "this": true, "to": true, "was": true, "we": true,
+	"when": true, "which": true, "will": true, "with": true,
+}
+
+// localEmbedder is a feature-hashing model: words, word pairs, and
+// character trigrams are hashed into a fixed number of signed buckets.
+// It is deterministic, fast, and offline, and tolerates inflection and
+// typos better than exact token overlap, but knows nothing of synonyms.
+type localEmbedder struct {
+	dims int
+}
+
+// NewLocal returns the built-in local Embedder with the given vector size
+// (DefaultLocalDimensions if dims <= 0).
+func NewLocal(dims int) Embedder {
+	if dims <= 0 {
+		dims = DefaultLocalDimensions
+	}
+	return &localEmbedder{dims: dims}
+}
+
+func (e *localEmbedder) Model() string {
+	return fmt.Sprintf("local/hash-v1-%d", e.dims)
+}
+
+func (e *localEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = e.embed(text)
+	}
+	return vectors, nil
+}
+
+func (e *localEmbedder) embed(text string) []float32 {
+	features := make(map[string]float64)
+	words := words(text)
+	for i, w := range words {
+		features["w:"+w]++
+		if i > 0 {
+			features["b:"+words[i-1]+" "+w] += 0.5
+		}
+		padded := []rune("^" + w + "$")
+		for j := 0; j+3 <= len(padded); j++ {
+			features["c:"+string(padded[j:j+3])] += 0.25
+		}
+	}
+
+	vec := make([]float64, e.dims)
+	for f, tf := range features {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(f))
+		sum := h.Sum64()
+		weight := 1 + math.Log(1+tf) // Dampen repeated terms
+		if sum>>63 == 1 {
+			weight = -weight
+		}
+		vec[sum%uint64(e.dims)] += weight
+	}
+
+	var norm float64
+	for _, x := range vec {
+		norm += x * x
+	}
+	norm = math.Sqrt(norm)
+	out := make([]float32, e.dims)
+	if norm == 0 {
+		return out
+	}
+	for i, x := range vec {
+		out[i] = float32(x / norm)
+	}
+	return out
+}
+
+// words splits text into lowercase, lightly stemmed words, dropping
+// stopwords and single characters.
+func words(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	out := fields[:0]
+	for _, w := range fields {
+		if len([]rune(w)) < 2 || stopwords[w] {
+			continue
+		}
+		out = append(out, stem(w))
+	}
+	return out
+}
+
+// stem strips a few common English suffixes so that "crash", "crashes",
+// and "crashing" share a feature.
+func stem(w string) string {
+	for _, suffix := range []string{"ing", "ed", "es", "s"} {
+		if strings.HasSuffix(w, suffix) && len(w)-len(suffix) >= 3 {
+			return strings.TrimSuffix(w, suffix)
+		}
+	}
+	return w
+}
@@ -0,0 +1,167 @@
+// Package erasure replaces a person's names with a pseudonym, for honoring
+// erasure requests (see bd actor erase).
+package erasure
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Eraser maps one person's names (an actor name and any aliases, such as
+// an email address) to a single pseudonym.
+type Eraser struct {
+	names       map[string]bool
+	replacement string
+	mentions    *regexp.Regexp // Case-insensitive, longest name first
+}
+
+// New returns an eraser for names. Empty names are ignored.
+func New(names []string, replacement string) *Eraser {
+	e := &Eraser{names: make(map[string]bool), replacement: replacement}
+	var quoted []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || e.names[name] {
+			continue
+		}
+		e.names[name] = true
+		quoted = append(quoted, name)
+	}
+	sort.Slice(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	for i, name := range quoted {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	if len(quoted) > 0 {
+		e.mentions = regexp.MustCompile(`(?i)(?:` + strings.Join(quoted, "|") + `)`)
+	}
+	return e
+}
+
+// Pseudonym returns a random, unlinkable replacement name.
+func Pseudonym() string {
+	var b [4]byte
+	_, _ = rand.Read(b[:]) // crypto/rand.Read does not fail in practice
+	return "erased-" + hex.EncodeToString(b[:])
+}
+
+// Names returns the erased names, sorted.
+func (e *Eraser) Names() []string {
+	names := make([]string, 0, len(e.names))
+	for name := range e.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Replacement returns the pseudonym.
+func (e *Eraser) Replacement() string {
+	return e.replacement
+}
+
+// Matches reports whether v is exactly one of the erased names.
+func (e *Eraser) Matches(v string) bool {
+	return e.names[v]
+}
+
+// Actor returns the pseudonym if v is one of the erased names, else v.
+func (e *Eraser) Actor(v string) string {
+	if e.names[v] {
+		return e.replacement
+	}
+	return v
+}
+
+// Text replaces whole-word mentions of any name in s, ignoring case, and
+// returns the new text and the number of mentions replaced. "bob" matches
+// in "ask bob," and "@bob" but not in "bobcat".
+func (e *Eraser) Text(s string) (string, int) {
+	if e.mentions == nil || s == "" {
+		return s, 0
+	}
+	locs := e.mentions.FindAllStringIndex(s, -1)
+	var sb strings.Builder
+	last, n := 0, 0
+	for _, loc := range locs {
+		if !isBoundary(s, loc[0], true) || !isBoundary(s, loc[1], false) {
+			continue
+		}
+		sb.WriteString(s[last:loc[0]])
+		sb.WriteString(e.replacement)
+		last = loc[1]
+		n++
+	}
+	if n == 0 {
+		return s, 0
+	}
+	sb.WriteString(s[last:])
+	return sb.String(), n
+}
+
+// isBoundary reports whether the match edge at i is not inside a word.
+func isBoundary(s string, i int, start bool) bool {
+	var r rune
+	if start {
+		if i == 0 {
+			return true
+		}
+		r, _ = utf8.DecodeLastRuneInString(s[:i])
+	} else {
+		if i == len(s) {
+			return true
+		}
+		r, _ = utf8.DecodeRuneInString(s[i:])
+	}
+	return !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
+
+// Value rewrites the strings in a decoded JSON value that are exactly a
+// name and, with mentions, mentions inside the others. Maps and slices are
+// rewritten in place.
+func (e *Eraser) Value(v interface{}, mentions bool) interface{} {
+	switch x := v.(type) {
+	case string:
+		if e.names[x] {
+			return e.replacement
+		}
+		if mentions {
+			x, _ = e.Text(x)
+		}
+		return x
+	case []interface{}:
+		for i := range x {
+			x[i] = e.Value(x[i], mentions)
+		}
+	case map[string]interface{}:
+		for k := range x {
+			x[k] = e.Value(x[k], mentions)
+		}
+	}
+	return v
+}
+
+// Document applies Value to a JSON document, such as an audit snapshot. A
+// document that isn't JSON is treated as a single string. The original is
+// returned unchanged, byte for byte, when nothing was replaced.
+func (e *Eraser) Document(doc string, mentions bool) string {
+	var v interface{}
+	if json.Unmarshal([]byte(doc), &v) != nil {
+		return e.Value(doc, mentions).(string)
+	}
+	before, err := json.Marshal(v)
+	if err != nil {
+		return doc
+	}
+	after, err := json.Marshal(e.Value(v, mentions))
+	if err != nil || bytes.Equal(before, after) {
+		return doc
+	}
+	return string(after)
+}
@@ -0,0 +1,58 @@
+package erasure
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	e := New([]string{"bob", "Bob Smith", "bob@example.com", " "}, "erased-1")
+	tests := []struct {
+		in   string
+		want string
+		n    int
+	}{
+		{"ask bob, then @bob", "ask erased-1, then @erased-1", 2},
+		{"BOB SMITH reported it", "erased-1 reported it", 1},
+		{"mail bob@example.com.", "mail erased-1.", 1},
+		{"bobcat and kabob", "bobcat and kabob", 0},
+		{"", "", 0},
+	}
+	for _, tt := range tests {
+		got, n := e.Text(tt.in)
+		if got != tt.want || n != tt.n {
+			t.Errorf("Text(%q) = %q, %d; want %q, %d", tt.in, got, n, tt.want, tt.n)
+		}
+	}
+	if names := e.Names(); len(names) != 3 {
+		t.Errorf("Names() = %v", names)
+	}
+}
+
+func TestDocument(t *testing.T) {
+	e := New([]string{"bob"}, "erased-1")
+
+	doc := `{"assignee": "bob", "waiters": ["alice", "bob"], "notes": "ask bob"}`
+	got := e.Document(doc, false)
+	if !strings.Contains(got, `"assignee":"erased-1"`) || !strings.Contains(got, `["alice","erased-1"]`) || !strings.Contains(got, `"ask bob"`) {
+		t.Errorf("Document without mentions = %s", got)
+	}
+	if got := e.Document(doc, true); strings.Contains(got, "bob") {
+		t.Errorf("Document with mentions = %s", got)
+	}
+
+	untouched := `{"b": 1,  "a": "alice"}`
+	if got := e.Document(untouched, true); got != untouched {
+		t.Errorf("Document rewrote an unrelated document: %s", got)
+	}
+	if got := e.Document("bob", false); got != "erased-1" {
+		t.Errorf("Document(plain value) = %q", got)
+	}
+}
+
+func TestPseudonym(t *testing.T) {
+	a, b := Pseudonym(), Pseudonym()
+	if !strings.HasPrefix(a, "erased-") || len(a) != len("erased-")+8 || a == b {
+		t.Errorf("Pseudonym() = %q, %q", a, b)
+	}
+}
@@ -0,0 +1,83 @@
+// Package escalation decides when high-priority issues that nobody has
+// acknowledged escalate along a chain of contacts, such as assignee, then
+// team lead, then an org channel.
+package escalation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// Assignee is the chain entry that stands for the issue's assignee.
+const Assignee = "assignee"
+
+// Policy is a parsed escalation config.
+type Policy struct {
+	Chain []string              // Contacts in escalation order
+	After map[int]time.Duration // Priority -> wait before each step
+}
+
+// ParsePolicy parses the escalation.chain and escalation.after config.
+// after maps priorities ("p0", "P1", "0") to how long an unacknowledged
+// issue waits before each step of the chain, such as "30m".
+func ParsePolicy(chain []string, after map[string]string) (*Policy, error) {
+	p := &Policy{After: make(map[int]time.Duration, len(after))}
+	for _, contact := range chain {
+		if contact = strings.TrimSpace(contact); contact != "" {
+			p.Chain = append(p.Chain, contact)
+		}
+	}
+	for key, value := range after {
+		priority, err := validation.ValidatePriority(strings.TrimSpace(key))
+		if err != nil {
+			return nil, fmt.Errorf("escalation.after.%s: %w", key, err)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("escalation.after.%s: invalid duration %q (use e.g. 30m or 4h)", key, value)
+		}
+		p.After[priority] = d
+	}
+	return p, nil
+}
+
+// Priorities returns the priorities that escalate, most urgent first.
+func (p *Policy) Priorities() []int {
+	out := make([]int, 0, len(p.After))
+	for priority := range p.After {
+		out = append(out, priority)
+	}
+	sort.Ints(out)
+	return out
+}
+
+// Level returns how many steps of the chain are due for an issue of the
+// given priority that has gone unacknowledged since since: one per elapsed
+// wait, capped at the length of the chain. Priorities without a wait never
+// escalate.
+func (p *Policy) Level(priority int, since, now time.Time) int {
+	wait, ok := p.After[priority]
+	if !ok || !now.After(since) {
+		return 0
+	}
+	level := int(now.Sub(since) / wait)
+	if level > len(p.Chain) {
+		level = len(p.Chain)
+	}
+	return level
+}
+
+// Contact returns who step level (1-based) of the chain notifies for issue.
+// It is empty when the step is the assignee and the issue is unassigned.
+func (p *Policy) Contact(level int, issue *types.Issue) string {
+	contact := p.Chain[level-1]
+	if contact == Assignee {
+		return issue.Assignee
+	}
+	return contact
+}
@@ -0,0 +1,69 @@
+package escalation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy([]string{"assignee", " lead-bob ", "", "#eng"}, map[string]string{"p0": "30m", "P1": "4h"})
+	if err != nil {
+		t.Fatalf("ParsePolicy: %v", err)
+	}
+	if want := []string{"assignee", "lead-bob", "#eng"}; !reflect.DeepEqual(p.Chain, want) {
+		t.Errorf("Chain = %q, want %q", p.Chain, want)
+	}
+	if want := map[int]time.Duration{0: 30 * time.Minute, 1: 4 * time.Hour}; !reflect.DeepEqual(p.After, want) {
+		t.Errorf("After = %v, want %v", p.After, want)
+	}
+	if got := p.Priorities(); !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Errorf("Priorities = %v", got)
+	}
+
+	for _, after := range []map[string]string{
+		{"urgent": "30m"},
+		{"p0": "soon"},
+		{"p0": "0s"},
+	} {
+		if _, err := ParsePolicy(nil, after); err == nil {
+			t.Errorf("ParsePolicy(%v) should fail", after)
+		}
+	}
+}
+
+func TestLevel(t *testing.T) {
+	p := &Policy{Chain: []string{"assignee", "lead", "#org"}, After: map[int]time.Duration{0: time.Hour}}
+	since := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		priority int
+		elapsed  time.Duration
+		want     int
+	}{
+		{0, 59 * time.Minute, 0},
+		{0, time.Hour, 1},
+		{0, 150 * time.Minute, 2},
+		{0, 10 * time.Hour, 3}, // Capped at the chain length
+		{1, 10 * time.Hour, 0}, // P1 doesn't escalate under this policy
+	}
+	for _, tt := range tests {
+		if got := p.Level(tt.priority, since, since.Add(tt.elapsed)); got != tt.want {
+			t.Errorf("Level(P%d, +%v) = %d, want %d", tt.priority, tt.elapsed, got, tt.want)
+		}
+	}
+}
+
+func TestContact(t *testing.T) {
+	p := &Policy{Chain: []string{"assignee", "lead"}}
+	if got := p.Contact(1, &types.Issue{Assignee: "alice"}); got != "alice" {
+		t.Errorf("Contact(1) = %q, want alice", got)
+	}
+	if got := p.Contact(1, &types.Issue{}); got != "" {
+		t.Errorf("Contact(1) unassigned = %q, want empty", got)
+	}
+	if got := p.Contact(2, &types.Issue{Assignee: "alice"}); got != "lead" {
+		t.Errorf("Contact(2) = %q, want lead", got)
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"net/url"
 	"strconv"
 	"time"
+
+	"github.com/steveyegge/beads/internal/tracker"
 )
 
 // NewClient creates a new GitLab client with the given token, base URL, and project ID.
@@ -80,7 +82,9 @@ func (c *Client) doRequest(ctx context.Context, method, urlStr string, body inte
 	}
 
 	var lastErr error
+	var rateLimited *tracker.RateLimitError
 	for attempt := 0; attempt <= MaxRetries; attempt++ {
+		rateLimited = nil
 		req, err := http.NewRequestWithContext(ctx, method, urlStr, reqBody)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create request: %w", err)
@@ -107,6 +111,10 @@ func (c *Client) doRequest(ctx context.Context, method, urlStr string, body inte
 		if resp.StatusCode == http.StatusTooManyRequests {
 			delay := RetryDelay * time.Duration(1<<attempt)
 			lastErr = fmt.Errorf("rate limited (attempt %d/%d)", attempt+1, MaxRetries+1)
+			rateLimited = &tracker.RateLimitError{RetryAfter: tracker.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+			if attempt == MaxRetries {
+				break
+			}
 			select {
 			case <-ctx.Done():
 				return nil, nil, ctx.Err()
@@ -133,7 +141,12 @@ func (c *Client) doRequest(ctx context.Context, method, urlStr string, body inte
 		return respBody, resp.Header, nil
 	}
 
-	return nil, nil, fmt.Errorf("max retries (%d) exceeded: %w", MaxRetries+1, lastErr)
+	err := fmt.Errorf("max retries (%d) exceeded: %w", MaxRetries+1, lastErr)
+	if rateLimited != nil {
+		rateLimited.Err = err
+		return nil, nil, rateLimited
+	}
+	return nil, nil, err
 }
 
 // FetchIssues retrieves issues from GitLab with optional filtering by state.
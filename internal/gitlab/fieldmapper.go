@@ -34,6 +34,12 @@ func (m *gitlabFieldMapper) PriorityToTracker(beadsPriority int) interface{} {
 
 func (m *gitlabFieldMapper) StatusToBeads(trackerState interface{}) types.Status {
 	if state, ok := trackerState.(string); ok {
+		if m.config.Statuses != nil {
+			if status, ok := m.config.Statuses.ToBeads(state); ok {
+				return status
+			}
+			return types.StatusOpen
+		}
 		if status, exists := m.config.StateMap[state]; exists {
 			return types.Status(status)
 		}
@@ -49,12 +55,10 @@ func (m *gitlabFieldMapper) StatusToBeads(trackerState interface{}) types.Status
 }
 
 func (m *gitlabFieldMapper) StatusToTracker(beadsStatus types.Status) interface{} {
-	switch beadsStatus {
-	case types.StatusClosed:
+	if _, closed := statusToGitLab(beadsStatus, m.config); closed {
 		return "closed"
-	default:
-		return "opened"
 	}
+	return "opened"
 }
 
 func (m *gitlabFieldMapper) TypeToBeads(trackerType interface{}) types.IssueType {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/steveyegge/beads/internal/tracker"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -14,6 +15,23 @@ type MappingConfig struct {
 	StateMap     map[string]string // GitLab state → beads status
 	LabelTypeMap map[string]string // type label value → beads issue type
 	RelationMap  map[string]string // GitLab link type → beads dependency type
+
+	// Statuses maps GitLab states and status labels to beads statuses both
+	// ways, with gitlab.status_map.* config applied. When nil, StateMap and
+	// the built-in status:: labels are used.
+	Statuses *tracker.StatusMap
+}
+
+// defaultStatusRules map GitLab states and scoped status labels to beads
+// statuses. GitLab issues are only opened or closed, so other statuses
+// are carried by status::<status> labels.
+var defaultStatusRules = []tracker.StatusRule{
+	{State: "opened", Status: types.StatusOpen, Direction: tracker.StatusBoth},
+	{State: "reopened", Status: types.StatusOpen, Direction: tracker.StatusPull},
+	{State: "closed", Status: types.StatusClosed, Direction: tracker.StatusBoth},
+	{State: "status::in_progress", Status: types.StatusInProgress, Direction: tracker.StatusBoth},
+	{State: "status::blocked", Status: types.StatusBlocked, Direction: tracker.StatusBoth},
+	{State: "status::deferred", Status: types.StatusDeferred, Direction: tracker.StatusBoth},
 }
 
 // DefaultMappingConfig returns the default mapping configuration.
@@ -46,6 +64,7 @@ func DefaultMappingConfig() *MappingConfig {
 			"is_blocked_by": "blocked_by",
 			"relates_to":    "related",
 		},
+		Statuses: tracker.NewStatusMap(defaultStatusRules...),
 	}
 }
 
@@ -66,6 +85,19 @@ func priorityFromLabels(labels []string, config *MappingConfig) int {
 // statusFromLabelsAndState determines beads status from GitLab labels and state.
 // GitLab's closed state takes precedence over status labels.
 func statusFromLabelsAndState(labels []string, state string, config *MappingConfig) string {
+	if config.Statuses != nil {
+		candidates := make([]string, 0, len(labels)+2)
+		if state == "closed" {
+			candidates = append(candidates, state)
+		}
+		candidates = append(candidates, labels...)
+		candidates = append(candidates, state)
+		if status, ok := config.Statuses.ToBeads(candidates...); ok {
+			return string(status)
+		}
+		return "open"
+	}
+
 	// Closed state always wins
 	if state == "closed" {
 		return "closed"
@@ -179,12 +211,9 @@ func BeadsIssueToGitLabFields(issue *types.Issue, config *MappingConfig) map[str
 	}
 
 	// Add status label (if not open or closed - those are handled by state)
-	if issue.Status == types.StatusInProgress {
-		labels = append(labels, "status::in_progress")
-	} else if issue.Status == types.StatusBlocked {
-		labels = append(labels, "status::blocked")
-	} else if issue.Status == types.StatusDeferred {
-		labels = append(labels, "status::deferred")
+	statusLabel, closed := statusToGitLab(issue.Status, config)
+	if statusLabel != "" {
+		labels = append(labels, statusLabel)
 	}
 
 	// Add any existing non-scoped labels
@@ -198,13 +227,37 @@ func BeadsIssueToGitLabFields(issue *types.Issue, config *MappingConfig) map[str
 	}
 
 	// Set state_event for closed issues
-	if issue.Status == types.StatusClosed {
+	if closed {
 		fields["state_event"] = "close"
 	}
 
 	return fields
 }
 
+// statusToGitLab returns the status label a beads status is pushed as, if
+// any, and whether the issue is pushed as closed.
+func statusToGitLab(status types.Status, config *MappingConfig) (label string, closed bool) {
+	if config.Statuses == nil {
+		switch status {
+		case types.StatusInProgress, types.StatusBlocked, types.StatusDeferred:
+			return "status::" + string(status), false
+		}
+		return "", status == types.StatusClosed
+	}
+
+	target, ok := config.Statuses.ToTracker(status)
+	if !ok {
+		return "", status == types.StatusClosed
+	}
+	switch strings.ToLower(target) {
+	case "closed":
+		return "", true
+	case "opened", "reopened":
+		return "", false
+	}
+	return target, false
+}
+
 // priorityToLabel converts beads priority (0-4) to GitLab priority label value.
 func priorityToLabel(priority int) string {
 	switch priority {
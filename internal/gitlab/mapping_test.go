@@ -2,6 +2,7 @@
 package gitlab
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -402,6 +403,38 @@ func TestBeadsIssueToGitLabFields_StateEvent(t *testing.T) {
 	}
 }
 
+// TestStatusMapConfig verifies gitlab.status_map.* config on both pull and push.
+func TestStatusMapConfig(t *testing.T) {
+	config := DefaultMappingConfig()
+	var errs []error
+	config.Statuses, errs = config.Statuses.WithConfig(map[string]string{
+		"gitlab.status_map.workflow::review": "in_progress",
+		"gitlab.status_map.wip":              "in_progress:pull",
+	}, "gitlab")
+	if len(errs) > 0 {
+		t.Fatalf("WithConfig: %v", errs)
+	}
+
+	if got := statusFromLabelsAndState([]string{"workflow::review"}, "opened", config); got != "in_progress" {
+		t.Errorf("status from workflow::review = %q, want in_progress", got)
+	}
+	if got := statusFromLabelsAndState([]string{"wip"}, "opened", config); got != "in_progress" {
+		t.Errorf("status from wip = %q, want in_progress", got)
+	}
+	if got := statusFromLabelsAndState([]string{"workflow::review"}, "closed", config); got != "closed" {
+		t.Errorf("status of closed issue = %q, want closed", got)
+	}
+
+	fields := BeadsIssueToGitLabFields(&types.Issue{Title: "Review me", Status: types.StatusInProgress}, config)
+	labels, _ := fields["labels"].([]string)
+	if !slices.Contains(labels, "workflow::review") || slices.Contains(labels, "status::in_progress") {
+		t.Errorf("labels = %v, want workflow::review instead of status::in_progress", labels)
+	}
+	if _, ok := fields["state_event"]; ok {
+		t.Errorf("state_event = %v, want none for in_progress", fields["state_event"])
+	}
+}
+
 // TestissueLinksToDependencies verifies conversion of GitLab IssueLinks to beads Dependencies.
 func TestIssueLinksToDependencies(t *testing.T) {
 	config := DefaultMappingConfig()
@@ -2,6 +2,7 @@ package gitlab
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
@@ -51,8 +52,18 @@ func (t *Tracker) Init(ctx context.Context, store storage.Storage) error {
 		return fmt.Errorf("GitLab project ID not configured (set gitlab.project_id or GITLAB_PROJECT_ID)")
 	}
 
+	allConfig, err := store.GetAllConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	config := DefaultMappingConfig()
+	var errs []error
+	if config.Statuses, errs = config.Statuses.WithConfig(allConfig, "gitlab"); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
 	t.client = NewClient(token, baseURL, projectID)
-	t.config = DefaultMappingConfig()
+	t.config = config
 	return nil
 }
 
@@ -164,6 +175,15 @@ func (t *Tracker) BuildExternalRef(issue *tracker.TrackerIssue) string {
 	return fmt.Sprintf("gitlab:%s", issue.Identifier)
 }
 
+// ExternalKey returns "GL#<iid>" as the alias of a synced issue, since a
+// bare IID would look like a partial beads ID.
+func (t *Tracker) ExternalKey(issue *tracker.TrackerIssue) string {
+	if issue.Identifier == "" {
+		return ""
+	}
+	return "GL#" + issue.Identifier
+}
+
 // getConfig reads a config value from storage, falling back to env var.
 func (t *Tracker) getConfig(ctx context.Context, key, envVar string) (string, error) {
 	val, err := t.store.GetConfig(ctx, key)
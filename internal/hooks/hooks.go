@@ -12,16 +12,22 @@ import (
 
 // Event types
 const (
-	EventCreate = "create"
-	EventUpdate = "update"
-	EventClose  = "close"
+	EventCreate   = "create"
+	EventUpdate   = "update"
+	EventClose    = "close"
+	EventEscalate = "escalate"
+	EventReopen   = "reopen"
+	EventAlert    = "alert"
 )
 
 // Hook file names
 const (
-	HookOnCreate = "on_create"
-	HookOnUpdate = "on_update"
-	HookOnClose  = "on_close"
+	HookOnCreate   = "on_create"
+	HookOnUpdate   = "on_update"
+	HookOnClose    = "on_close"
+	HookOnEscalate = "on_escalate"
+	HookOnReopen   = "on_reopen"
+	HookOnAlert    = "on_alert"
 )
 
 // Runner handles hook execution
@@ -67,13 +73,19 @@ func (r *Runner) Run(event string, issue *types.Issue) {
 
 	// Run asynchronously (ignore error as this is fire-and-forget)
 	go func() {
-		_ = r.runHook(hookPath, event, issue) // Best effort: hook failures should not block the triggering operation
+		_ = r.runHook(hookPath, event, issue, nil) // Best effort: hook failures should not block the triggering operation
 	}()
 }
 
 // RunSync executes a hook synchronously and returns any error.
 // Useful for testing or when you need to wait for the hook.
 func (r *Runner) RunSync(event string, issue *types.Issue) error {
+	return r.RunSyncEnv(event, issue)
+}
+
+// RunSyncEnv is RunSync with extra environment variables ("KEY=value") for
+// the hook, such as the contact to notify for an escalation.
+func (r *Runner) RunSyncEnv(event string, issue *types.Issue, env ...string) error {
 	hookName := eventToHook(event)
 	if hookName == "" {
 		return nil
@@ -91,7 +103,7 @@ func (r *Runner) RunSync(event string, issue *types.Issue) error {
 		return nil // Not executable, skip
 	}
 
-	return r.runHook(hookPath, event, issue)
+	return r.runHook(hookPath, event, issue, env)
 }
 
 // HookExists checks if a hook exists for an event
@@ -118,6 +130,12 @@ func eventToHook(event string) string {
 		return HookOnUpdate
 	case EventClose:
 		return HookOnClose
+	case EventEscalate:
+		return HookOnEscalate
+	case EventReopen:
+		return HookOnReopen
+	case EventAlert:
+		return HookOnAlert
 	default:
 		return ""
 	}
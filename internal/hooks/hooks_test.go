@@ -218,6 +218,32 @@ cat > ` + outputFile
 	}
 }
 
+func TestRunSyncEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	hookPath := filepath.Join(tmpDir, HookOnEscalate)
+	outputFile := filepath.Join(tmpDir, "output.txt")
+
+	hookScript := `#!/bin/sh
+echo "$1 $2 $BD_ESCALATION_CONTACT" > ` + outputFile
+	if err := os.WriteFile(hookPath, []byte(hookScript), 0755); err != nil {
+		t.Fatalf("Failed to create hook file: %v", err)
+	}
+
+	runner := NewRunner(tmpDir)
+	issue := &types.Issue{ID: "bd-test", Title: "Test Issue"}
+	if err := runner.RunSyncEnv(EventEscalate, issue, "BD_ESCALATION_CONTACT=lead"); err != nil {
+		t.Errorf("RunSyncEnv returned error: %v", err)
+	}
+
+	output, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if expected := "bd-test escalate lead\n"; string(output) != expected {
+		t.Errorf("Hook output = %q, want %q", string(output), expected)
+	}
+}
+
 func TestRunSync_Timeout(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping timeout test in short mode")
@@ -380,6 +406,9 @@ func TestAllHookEvents(t *testing.T) {
 		{EventCreate, HookOnCreate},
 		{EventUpdate, HookOnUpdate},
 		{EventClose, HookOnClose},
+		{EventEscalate, HookOnEscalate},
+		{EventReopen, HookOnReopen},
+		{EventAlert, HookOnAlert},
 	}
 
 	for _, e := range events {
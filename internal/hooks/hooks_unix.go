@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"syscall"
 
@@ -16,7 +17,7 @@ import (
 
 // runHook executes the hook and enforces a timeout, killing the process group
 // on expiration to ensure descendant processes are terminated.
-func (r *Runner) runHook(hookPath, event string, issue *types.Issue) error {
+func (r *Runner) runHook(hookPath, event string, issue *types.Issue, env []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
 
@@ -30,6 +31,9 @@ func (r *Runner) runHook(hookPath, event string, issue *types.Issue) error {
 	// #nosec G204 -- hookPath is from controlled .beads/hooks directory
 	cmd := exec.CommandContext(ctx, hookPath, issue.ID, event)
 	cmd.Stdin = bytes.NewReader(issueJSON)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 
 	// Capture output for debugging (but don't block on it)
 	var stdout, stderr bytes.Buffer
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"os"
 	"os/exec"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -15,7 +16,7 @@ import (
 // Windows lacks Unix-style process groups; on timeout we best-effort kill
 // the started process. Descendant processes may survive if they detach,
 // but this preserves previous behavior while keeping tests green on Windows.
-func (r *Runner) runHook(hookPath, event string, issue *types.Issue) error {
+func (r *Runner) runHook(hookPath, event string, issue *types.Issue, env []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
 	defer cancel()
 
@@ -26,6 +27,9 @@ func (r *Runner) runHook(hookPath, event string, issue *types.Issue) error {
 
 	cmd := exec.CommandContext(ctx, hookPath, issue.ID, event)
 	cmd.Stdin = bytes.NewReader(issueJSON)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
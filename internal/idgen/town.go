@@ -0,0 +1,81 @@
+package idgen
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Distributed IDs let federated towns create issues offline without ever
+// allocating the same ID. Each town has a two-character tag, and every ID
+// it allocates starts with the tag: hash IDs are prefix-<tag><hash>
+// ("bd-k3x9a") and child IDs are parent.<tag><n> ("bd-k3x9a.k31"). Towns
+// only allocate inside their own namespace, so checking the local database
+// for collisions is enough.
+//
+// Tags start with a letter. IDs created before a database switched to
+// distributed IDs are moved under LegacyTownTag, which starts with a digit
+// and so can never be a town's tag; legacy child numbers are all digits
+// and so can never start with one either.
+
+// LegacyTownTag is the tag of IDs created before distributed IDs.
+const LegacyTownTag = "00"
+
+// SchemeDistributed is the value of the id_scheme database config once a
+// database's IDs have been migrated to distributed IDs.
+const SchemeDistributed = "distributed"
+
+var townTagRegex = regexp.MustCompile(`^[a-z][a-z0-9]$`)
+
+// ValidateTownTag checks that tag is a valid town tag: a lowercase letter
+// followed by a lowercase letter or digit.
+func ValidateTownTag(tag string) error {
+	if !townTagRegex.MatchString(tag) {
+		return fmt.Errorf("invalid town tag %q: want a letter followed by a letter or digit, e.g. k3", tag)
+	}
+	return nil
+}
+
+// GenerateTownHashID creates a hash ID in town's namespace: the tag
+// followed by a hash of length characters (see GenerateHashID).
+func GenerateTownHashID(prefix, town, title, description, creator string, timestamp time.Time, length, nonce int) string {
+	id := GenerateHashID(prefix, title, description, creator, timestamp, length, nonce)
+	return prefix + "-" + town + strings.TrimPrefix(id, prefix+"-")
+}
+
+// TownChildID returns the n'th child ID that town allocates under parentID.
+func TownChildID(parentID, town string, n int) string {
+	return fmt.Sprintf("%s.%s%d", parentID, town, n)
+}
+
+// TownChildNumber returns n if childID is TownChildID(parentID, town, n).
+func TownChildNumber(childID, parentID, town string) (int, bool) {
+	suffix, ok := strings.CutPrefix(childID, parentID+"."+town)
+	if !ok || suffix == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(suffix)
+	if err != nil || n <= 0 || strconv.Itoa(n) != suffix {
+		return 0, false
+	}
+	return n, true
+}
+
+// LegacyIDRegex returns a pattern matching the legacy IDs with prefix that
+// MigrateLegacyID moves: a hash root and optional numeric child suffixes.
+func LegacyIDRegex(prefix string) *regexp.Regexp {
+	return regexp.MustCompile(`\b` + regexp.QuoteMeta(prefix) + `-[a-z0-9]+(?:\.[0-9]+)*\b`)
+}
+
+// MigrateLegacyID returns the distributed ID for a legacy ID with prefix,
+// moving its root under LegacyTownTag ("bd-a3f.2" becomes "bd-00a3f.2").
+// It reports false for IDs it leaves alone, such as those with a longer
+// prefix ("bd-mol-a3f") or a non-numeric child suffix.
+func MigrateLegacyID(id, prefix string) (string, bool) {
+	if m := LegacyIDRegex(prefix).FindString(id); m != id {
+		return "", false
+	}
+	return prefix + "-" + LegacyTownTag + strings.TrimPrefix(id, prefix+"-"), true
+}
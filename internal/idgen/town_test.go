@@ -0,0 +1,65 @@
+package idgen
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateTownTag(t *testing.T) {
+	for _, ok := range []string{"k3", "ab", "z9"} {
+		if err := ValidateTownTag(ok); err != nil {
+			t.Errorf("ValidateTownTag(%q) = %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"", "k", "k3x", "3k", "00", "K3", "k-"} {
+		if err := ValidateTownTag(bad); err == nil {
+			t.Errorf("ValidateTownTag(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestGenerateTownHashID(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	plain := GenerateHashID("bd", "Title", "Desc", "alice", ts, 5, 0)
+	town := GenerateTownHashID("bd", "k3", "Title", "Desc", "alice", ts, 5, 0)
+	if town != "bd-k3"+strings.TrimPrefix(plain, "bd-") {
+		t.Errorf("GenerateTownHashID = %q, plain ID %q", town, plain)
+	}
+	if other := GenerateTownHashID("bd", "m7", "Title", "Desc", "alice", ts, 5, 0); other == town {
+		t.Errorf("towns allocated the same ID %q", town)
+	}
+}
+
+func TestTownChildID(t *testing.T) {
+	id := TownChildID("bd-k3x9a", "m7", 12)
+	if id != "bd-k3x9a.m712" {
+		t.Fatalf("TownChildID = %q", id)
+	}
+	if n, ok := TownChildNumber(id, "bd-k3x9a", "m7"); !ok || n != 12 {
+		t.Errorf("TownChildNumber(%q) = %d, %v", id, n, ok)
+	}
+	for _, other := range []string{"bd-k3x9a.12", "bd-k3x9a.k312", "bd-k3x9a.m7", "bd-k3x9a.m70", "bd-k3x9a.m712.1"} {
+		if _, ok := TownChildNumber(other, "bd-k3x9a", "m7"); ok {
+			t.Errorf("TownChildNumber(%q) matched", other)
+		}
+	}
+}
+
+func TestMigrateLegacyID(t *testing.T) {
+	for in, want := range map[string]string{
+		"bd-a3f":     "bd-00a3f",
+		"bd-a3f.2":   "bd-00a3f.2",
+		"bd-a3f.2.1": "bd-00a3f.2.1",
+		"bd-00ab":    "bd-0000ab",
+	} {
+		if got, ok := MigrateLegacyID(in, "bd"); !ok || got != want {
+			t.Errorf("MigrateLegacyID(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+	for _, skip := range []string{"bd-mol-a3f", "bd-wisp-x1", "gt-a3f", "bd-a3f.k31"} {
+		if got, ok := MigrateLegacyID(skip, "bd"); ok {
+			t.Errorf("MigrateLegacyID(%q) = %q, want skipped", skip, got)
+		}
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"github.com/steveyegge/beads/internal/tracker"
 )
 
 // Issue represents a Jira issue from the REST API.
@@ -234,6 +236,52 @@ func (c *Client) UpdateIssue(ctx context.Context, key string, fields map[string]
 	return nil
 }
 
+// Transition is a workflow transition available on an issue. Jira only
+// changes an issue's status through transitions.
+type Transition struct {
+	ID   string      `json:"id"`
+	Name string      `json:"name"`
+	To   StatusField `json:"to"`
+}
+
+// GetTransitions fetches the transitions available on an issue in its
+// current status.
+func (c *Client) GetTransitions(ctx context.Context, key string) ([]Transition, error) {
+	apiURL := fmt.Sprintf("%s/rest/api/3/issue/%s/transitions", c.URL, url.PathEscape(key))
+
+	body, err := c.doRequest(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get transitions for %s: %w", key, err)
+	}
+
+	var result struct {
+		Transitions []Transition `json:"transitions"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("parse transitions response: %w", err)
+	}
+
+	return result.Transitions, nil
+}
+
+// TransitionIssue moves an issue through the transition with the given ID.
+func (c *Client) TransitionIssue(ctx context.Context, key, transitionID string) error {
+	payload := map[string]interface{}{"transition": map[string]string{"id": transitionID}}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal transition request: %w", err)
+	}
+
+	apiURL := fmt.Sprintf("%s/rest/api/3/issue/%s/transitions", c.URL, url.PathEscape(key))
+
+	_, err = c.doRequest(ctx, "POST", apiURL, data)
+	if err != nil {
+		return fmt.Errorf("transition issue %s: %w", key, err)
+	}
+
+	return nil
+}
+
 // doRequest executes an authenticated HTTP request and returns the response body.
 func (c *Client) doRequest(ctx context.Context, method, apiURL string, body []byte) ([]byte, error) {
 	if c.URL == "" {
@@ -276,6 +324,13 @@ func (c *Client) doRequest(ctx context.Context, method, apiURL string, body []by
 		return nil, nil
 	}
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, &tracker.RateLimitError{
+			RetryAfter: tracker.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+			Err:        fmt.Errorf("jira API returned %d: rate limited", resp.StatusCode),
+		}
+	}
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		return nil, fmt.Errorf("jira API returned %d: %s", resp.StatusCode, string(respBody))
 	}
@@ -8,7 +8,26 @@ import (
 )
 
 // jiraFieldMapper implements tracker.FieldMapper for Jira.
-type jiraFieldMapper struct{}
+type jiraFieldMapper struct {
+	statuses *tracker.StatusMap // nil means defaultStatusMap()
+}
+
+// defaultStatusMap maps the states of Jira's default workflows. Where
+// several states share a status, the first is the one pushed.
+func defaultStatusMap() *tracker.StatusMap {
+	return tracker.NewStatusMap(
+		tracker.StatusRule{State: "To Do", Status: types.StatusOpen, Direction: tracker.StatusBoth},
+		tracker.StatusRule{State: "Open", Status: types.StatusOpen, Direction: tracker.StatusPull},
+		tracker.StatusRule{State: "Backlog", Status: types.StatusOpen, Direction: tracker.StatusPull},
+		tracker.StatusRule{State: "New", Status: types.StatusOpen, Direction: tracker.StatusPull},
+		tracker.StatusRule{State: "In Progress", Status: types.StatusInProgress, Direction: tracker.StatusBoth},
+		tracker.StatusRule{State: "In Review", Status: types.StatusInProgress, Direction: tracker.StatusPull},
+		tracker.StatusRule{State: "Blocked", Status: types.StatusBlocked, Direction: tracker.StatusBoth},
+		tracker.StatusRule{State: "Done", Status: types.StatusClosed, Direction: tracker.StatusBoth},
+		tracker.StatusRule{State: "Closed", Status: types.StatusClosed, Direction: tracker.StatusPull},
+		tracker.StatusRule{State: "Resolved", Status: types.StatusClosed, Direction: tracker.StatusPull},
+	)
+}
 
 func (m *jiraFieldMapper) PriorityToBeads(trackerPriority interface{}) int {
 	if name, ok := trackerPriority.(string); ok {
@@ -47,33 +66,25 @@ func (m *jiraFieldMapper) PriorityToTracker(beadsPriority int) interface{} {
 
 func (m *jiraFieldMapper) StatusToBeads(trackerState interface{}) types.Status {
 	if state, ok := trackerState.(string); ok {
-		switch state {
-		case "To Do", "Open", "Backlog", "New":
-			return types.StatusOpen
-		case "In Progress", "In Review":
-			return types.StatusInProgress
-		case "Blocked":
-			return types.StatusBlocked
-		case "Done", "Closed", "Resolved":
-			return types.StatusClosed
+		if status, ok := m.statusMap().ToBeads(state); ok {
+			return status
 		}
 	}
 	return types.StatusOpen
 }
 
 func (m *jiraFieldMapper) StatusToTracker(beadsStatus types.Status) interface{} {
-	switch beadsStatus {
-	case types.StatusOpen:
-		return "To Do"
-	case types.StatusInProgress:
-		return "In Progress"
-	case types.StatusBlocked:
-		return "Blocked"
-	case types.StatusClosed:
-		return "Done"
-	default:
-		return "To Do"
+	if state, ok := m.statusMap().ToTracker(beadsStatus); ok {
+		return state
+	}
+	return "To Do"
+}
+
+func (m *jiraFieldMapper) statusMap() *tracker.StatusMap {
+	if m.statuses == nil {
+		return defaultStatusMap()
 	}
+	return m.statuses
 }
 
 func (m *jiraFieldMapper) TypeToBeads(trackerType interface{}) types.IssueType {
@@ -2,6 +2,7 @@ package jira
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -23,6 +24,7 @@ type Tracker struct {
 	store      storage.Storage
 	jiraURL    string
 	projectKey string
+	statuses   *tracker.StatusMap
 }
 
 func (t *Tracker) Name() string         { return "jira" }
@@ -50,6 +52,25 @@ func (t *Tracker) Init(ctx context.Context, store storage.Storage) error {
 		return fmt.Errorf("Jira API token not configured (set jira.api_token or JIRA_API_TOKEN)")
 	}
 
+	allConfig, err := store.GetAllConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	// jira.reverse_status_map.<status> = <state> is how the example
+	// scripts configure pushes.
+	for key, value := range allConfig {
+		if status, ok := strings.CutPrefix(key, "jira.reverse_status_map."); ok {
+			if _, set := allConfig["jira.status_map."+value]; !set {
+				allConfig["jira.status_map."+value] = status + ":push"
+			}
+		}
+	}
+	statuses, errs := defaultStatusMap().WithConfig(allConfig, "jira")
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	t.statuses = statuses
+
 	t.client = NewClient(jiraURL, username, apiToken)
 	return nil
 }
@@ -117,6 +138,9 @@ func (t *Tracker) CreateIssue(ctx context.Context, issue *types.Issue) (*tracker
 	if err != nil {
 		return nil, err
 	}
+	if created, err = t.transitionTo(ctx, created, issue.Status); err != nil {
+		return nil, err
+	}
 
 	ti := jiraToTrackerIssue(created)
 	return &ti, nil
@@ -135,12 +159,49 @@ func (t *Tracker) UpdateIssue(ctx context.Context, externalID string, issue *typ
 	if err != nil {
 		return nil, err
 	}
+	if updated, err = t.transitionTo(ctx, updated, issue.Status); err != nil {
+		return nil, err
+	}
 	ti := jiraToTrackerIssue(updated)
 	return &ti, nil
 }
 
 func (t *Tracker) FieldMapper() tracker.FieldMapper {
-	return &jiraFieldMapper{}
+	return &jiraFieldMapper{statuses: t.statuses}
+}
+
+// transitionTo moves a Jira issue to the state status is pushed as, unless
+// its current state already maps to status. Jira has no settable status
+// field, so this goes through one of the workflow transitions available from
+// the current state, and fails if none leads to the target.
+func (t *Tracker) transitionTo(ctx context.Context, ji *Issue, status types.Status) (*Issue, error) {
+	statuses := (&jiraFieldMapper{statuses: t.statuses}).statusMap()
+	target, ok := statuses.ToTracker(status)
+	if !ok {
+		return ji, nil
+	}
+	current := statusName(ji)
+	if tracker.SameState(current, target) {
+		return ji, nil
+	}
+	if mapped, ok := statuses.ToBeads(current); ok && mapped == status {
+		return ji, nil
+	}
+
+	transitions, err := t.client.GetTransitions(ctx, ji.Key)
+	if err != nil {
+		return nil, err
+	}
+	for _, tr := range transitions {
+		if tracker.SameState(tr.To.Name, target) {
+			if err := t.client.TransitionIssue(ctx, ji.Key, tr.ID); err != nil {
+				return nil, err
+			}
+			return t.client.GetIssue(ctx, ji.Key)
+		}
+	}
+	return nil, fmt.Errorf("%s: no transition from %q to %q (set jira.status_map.<state> to map %s to a reachable state)",
+		ji.Key, current, target, status)
 }
 
 func (t *Tracker) IsExternalRef(ref string) bool {
@@ -1,7 +1,13 @@
 package jira
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -257,3 +263,115 @@ func TestFieldMapperIssueToTracker(t *testing.T) {
 		t.Errorf("priority = %v, want Highest", fields["priority"])
 	}
 }
+
+func TestFieldMapperStatus(t *testing.T) {
+	statuses, errs := defaultStatusMap().WithConfig(map[string]string{
+		"jira.status_map.Triage":      "open:pull",
+		"jira.status_map.Won't Do":    "closed",
+		"jira.status_map.In Progress": "in_progress:pull",
+	}, "jira")
+	if len(errs) > 0 {
+		t.Fatalf("WithConfig: %v", errs)
+	}
+	defaults := &jiraFieldMapper{}
+	configured := &jiraFieldMapper{statuses: statuses}
+
+	pulls := []struct {
+		mapper *jiraFieldMapper
+		state  string
+		want   types.Status
+	}{
+		{defaults, "In Review", types.StatusInProgress},
+		{defaults, "resolved", types.StatusClosed},
+		{defaults, "Triage", types.StatusOpen}, // Unknown
+		{configured, "Triage", types.StatusOpen},
+		{configured, "Won't Do", types.StatusClosed},
+		{configured, "In Progress", types.StatusInProgress},
+	}
+	for _, tt := range pulls {
+		if got := tt.mapper.StatusToBeads(tt.state); got != tt.want {
+			t.Errorf("StatusToBeads(%q) = %q, want %q", tt.state, got, tt.want)
+		}
+	}
+
+	pushes := []struct {
+		mapper *jiraFieldMapper
+		status types.Status
+		want   string
+	}{
+		{defaults, types.StatusClosed, "Done"},
+		{defaults, types.StatusDeferred, "To Do"},
+		{configured, types.StatusClosed, "Won't Do"},
+		{configured, types.StatusInProgress, "To Do"}, // Pull-only now
+	}
+	for _, tt := range pushes {
+		if got := tt.mapper.StatusToTracker(tt.status); got != tt.want {
+			t.Errorf("StatusToTracker(%q) = %v, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestTransitionTo(t *testing.T) {
+	status := "To Do"
+	var transitioned []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/3/issue/PROJ-1/transitions" && r.Method == "GET":
+			_, _ = io.WriteString(w, `{"transitions": [
+				{"id": "21", "name": "Start", "to": {"name": "In Progress"}},
+				{"id": "31", "name": "Finish", "to": {"name": "Done"}}]}`)
+		case r.URL.Path == "/rest/api/3/issue/PROJ-1/transitions" && r.Method == "POST":
+			var body struct {
+				Transition struct{ ID string } `json:"transition"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			transitioned = append(transitioned, body.Transition.ID)
+			status = map[string]string{"21": "In Progress", "31": "Done"}[body.Transition.ID]
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/rest/api/3/issue/PROJ-1":
+			_ = json.NewEncoder(w).Encode(Issue{Key: "PROJ-1", Fields: IssueFields{Status: &StatusField{Name: status}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	tr := &Tracker{client: NewClient(srv.URL, "user", "token")}
+	ctx := context.Background()
+	issue := func(state string) *Issue {
+		return &Issue{Key: "PROJ-1", Fields: IssueFields{Status: &StatusField{Name: state}}}
+	}
+
+	got, err := tr.transitionTo(ctx, issue("To Do"), types.StatusClosed)
+	if err != nil {
+		t.Fatalf("transitionTo(closed): %v", err)
+	}
+	if statusName(got) != "Done" || len(transitioned) != 1 || transitioned[0] != "31" {
+		t.Errorf("transitionTo(closed) = %q via %v, want Done via [31]", statusName(got), transitioned)
+	}
+
+	// Resolved already pulls as closed, so it is left alone.
+	transitioned = nil
+	if _, err := tr.transitionTo(ctx, issue("Resolved"), types.StatusClosed); err != nil || len(transitioned) != 0 {
+		t.Errorf("transitionTo from Resolved: err %v, transitions %v; want none", err, transitioned)
+	}
+
+	_, err = tr.transitionTo(ctx, issue("To Do"), types.StatusBlocked)
+	if err == nil || !strings.Contains(err.Error(), `no transition from "To Do" to "Blocked"`) {
+		t.Errorf("transitionTo(blocked) error = %v, want no transition", err)
+	}
+}
+
+func TestRateLimitError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, "user", "token").GetIssue(context.Background(), "PROJ-1")
+	var rateLimited *tracker.RateLimitError
+	if !errors.As(err, &rateLimited) || rateLimited.RetryAfter != 30*time.Second {
+		t.Errorf("GetIssue error = %v, want a rate limit error asking for 30s", err)
+	}
+}
@@ -0,0 +1,120 @@
+// Package labelroute applies label routing rules, which file issues that
+// carry a label under a default epic, give them a default assignee, and
+// raise them to a minimum priority.
+package labelroute
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+// Rule routes issues whose labels match Label, a glob such as "area:*".
+type Rule struct {
+	Label         string `json:"label"`
+	Epic          string `json:"epic,omitempty"`           // Parent for issues without one
+	Assignee      string `json:"assignee,omitempty"`       // Assignee (or team) for unassigned issues
+	PriorityFloor *int   `json:"priority_floor,omitempty"` // Least urgent priority allowed
+}
+
+// ParseRules parses the label-routes config, which maps label globs to
+// comma-separated settings such as "epic=bd-12, assignee=alice, priority=P1".
+// Rules are returned sorted by label, the order in which they apply.
+func ParseRules(cfg map[string]string) ([]Rule, error) {
+	rules := make([]Rule, 0, len(cfg))
+	for label, spec := range cfg {
+		label = strings.ToLower(strings.TrimSpace(label))
+		if _, err := filepath.Match(label, ""); err != nil || label == "" {
+			return nil, fmt.Errorf("label-routes: invalid label pattern %q", label)
+		}
+		rule := Rule{Label: label}
+		for _, part := range strings.Split(spec, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			key, value, ok := strings.Cut(part, "=")
+			key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+			if !ok || value == "" {
+				return nil, fmt.Errorf("label-routes.%s: %q is not key=value", label, part)
+			}
+			switch key {
+			case "epic":
+				rule.Epic = value
+			case "assignee":
+				rule.Assignee = value
+			case "priority":
+				p, err := validation.ValidatePriority(value)
+				if err != nil {
+					return nil, fmt.Errorf("label-routes.%s: %w", label, err)
+				}
+				rule.PriorityFloor = &p
+			default:
+				return nil, fmt.Errorf("label-routes.%s: unknown setting %q (valid: epic, assignee, priority)", label, key)
+			}
+		}
+		if rule.Epic == "" && rule.Assignee == "" && rule.PriorityFloor == nil {
+			return nil, fmt.Errorf("label-routes.%s: no epic, assignee, or priority", label)
+		}
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Label < rules[j].Label })
+	return rules, nil
+}
+
+// Matches reports whether any of labels matches the rule, ignoring case.
+func (r Rule) Matches(labels []string) bool {
+	for _, label := range labels {
+		if ok, _ := filepath.Match(r.Label, strings.ToLower(label)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Plan is what routing changes on one issue.
+type Plan struct {
+	IssueID  string   `json:"issue_id"`
+	Epic     string   `json:"epic,omitempty"`     // Parent to add
+	Assignee string   `json:"assignee,omitempty"` // Assignee to set
+	Priority *int     `json:"priority,omitempty"` // Priority to raise to
+	Rules    []string `json:"rules"`              // Labels of the rules that applied
+}
+
+// Empty reports whether the plan changes nothing.
+func (p Plan) Empty() bool {
+	return p.Epic == "" && p.Assignee == "" && p.Priority == nil
+}
+
+// Resolve works out how rules route an issue with the given labels. The
+// first matching rule with an epic or assignee sets it, and only when the
+// issue has no parent or assignee; the most urgent matching floor raises
+// the priority. Routing never lowers a priority or replaces a value the
+// issue already has.
+func Resolve(rules []Rule, issue *types.Issue, labels []string, hasParent bool) Plan {
+	plan := Plan{IssueID: issue.ID}
+	for _, rule := range rules {
+		if !rule.Matches(labels) {
+			continue
+		}
+		applied := false
+		if rule.Epic != "" && plan.Epic == "" && !hasParent && rule.Epic != issue.ID {
+			plan.Epic, applied = rule.Epic, true
+		}
+		if rule.Assignee != "" && plan.Assignee == "" && issue.Assignee == "" {
+			plan.Assignee, applied = rule.Assignee, true
+		}
+		if f := rule.PriorityFloor; f != nil && issue.Priority > *f && (plan.Priority == nil || *f < *plan.Priority) {
+			p := *f
+			plan.Priority, applied = &p, true
+		}
+		if applied {
+			plan.Rules = append(plan.Rules, rule.Label)
+		}
+	}
+	return plan
+}
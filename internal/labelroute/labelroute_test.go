@@ -0,0 +1,73 @@
+package labelroute
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func intPtr(n int) *int { return &n }
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules(map[string]string{
+		"area:auth": "epic=bd-12, assignee=alice, priority=P1",
+		"Security":  "priority=0",
+	})
+	if err != nil {
+		t.Fatalf("ParseRules: %v", err)
+	}
+	want := []Rule{
+		{Label: "area:auth", Epic: "bd-12", Assignee: "alice", PriorityFloor: intPtr(1)},
+		{Label: "security", PriorityFloor: intPtr(0)},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("ParseRules = %+v, want %+v", rules, want)
+	}
+
+	for _, bad := range []map[string]string{
+		{"area:auth": "epic"},
+		{"area:auth": "owner=alice"},
+		{"area:auth": "priority=high"},
+		{"area:auth": ""},
+		{"[": "epic=bd-1"},
+	} {
+		if _, err := ParseRules(bad); err == nil {
+			t.Errorf("ParseRules(%v) succeeded", bad)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	rules := []Rule{
+		{Label: "area:*", Epic: "bd-1", Assignee: "team-core", PriorityFloor: intPtr(2)},
+		{Label: "security", Assignee: "sec-team", PriorityFloor: intPtr(0)},
+	}
+	tests := []struct {
+		name      string
+		issue     types.Issue
+		labels    []string
+		hasParent bool
+		want      Plan
+	}{
+		{"no match", types.Issue{ID: "bd-9", Priority: 3}, []string{"docs"}, false,
+			Plan{IssueID: "bd-9"}},
+		{"glob, case-insensitive", types.Issue{ID: "bd-9", Priority: 3}, []string{"Area:Auth"}, false,
+			Plan{IssueID: "bd-9", Epic: "bd-1", Assignee: "team-core", Priority: intPtr(2), Rules: []string{"area:*"}}},
+		{"keeps parent, assignee, and urgent priority", types.Issue{ID: "bd-9", Priority: 1, Assignee: "bob"}, []string{"area:ui"}, true,
+			Plan{IssueID: "bd-9"}},
+		{"first assignee wins, most urgent floor", types.Issue{ID: "bd-9", Priority: 3}, []string{"area:ui", "security"}, false,
+			Plan{IssueID: "bd-9", Epic: "bd-1", Assignee: "team-core", Priority: intPtr(0), Rules: []string{"area:*", "security"}}},
+		{"epic is not its own parent", types.Issue{ID: "bd-1", Priority: 2, Assignee: "x"}, []string{"area:ui"}, false,
+			Plan{IssueID: "bd-1"}},
+	}
+	for _, tt := range tests {
+		got := Resolve(rules, &tt.issue, tt.labels, tt.hasParent)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Resolve = %+v, want %+v", tt.name, got, tt.want)
+		}
+		if got.Empty() != (len(got.Rules) == 0) {
+			t.Errorf("%s: Empty() = %v with rules %v", tt.name, got.Empty(), got.Rules)
+		}
+	}
+}
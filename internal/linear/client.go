@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/tracker"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -131,7 +132,9 @@ func (c *Client) Execute(ctx context.Context, req *GraphQLRequest) (json.RawMess
 	}
 
 	var lastErr error
+	var rateLimited *tracker.RateLimitError
 	for attempt := 0; attempt <= MaxRetries; attempt++ {
+		rateLimited = nil
 		httpReq, err := http.NewRequestWithContext(ctx, "POST", c.Endpoint, bytes.NewReader(body))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create request: %w", err)
@@ -156,6 +159,10 @@ func (c *Client) Execute(ctx context.Context, req *GraphQLRequest) (json.RawMess
 		if resp.StatusCode == http.StatusTooManyRequests {
 			delay := RetryDelay * time.Duration(1<<attempt) // Exponential backoff
 			lastErr = fmt.Errorf("rate limited (attempt %d/%d), retrying after %v", attempt+1, MaxRetries+1, delay)
+			rateLimited = &tracker.RateLimitError{RetryAfter: tracker.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+			if attempt == MaxRetries {
+				break
+			}
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
@@ -187,7 +194,12 @@ func (c *Client) Execute(ctx context.Context, req *GraphQLRequest) (json.RawMess
 		return gqlResp.Data, nil
 	}
 
-	return nil, fmt.Errorf("max retries (%d) exceeded: %w", MaxRetries+1, lastErr)
+	err = fmt.Errorf("max retries (%d) exceeded: %w", MaxRetries+1, lastErr)
+	if rateLimited != nil {
+		rateLimited.Err = err
+		return nil, rateLimited
+	}
+	return nil, err
 }
 
 // FetchIssues retrieves issues from Linear with optional filtering by state.
@@ -619,19 +631,7 @@ func BuildStateCache(ctx context.Context, client *Client) (*StateCache, error) {
 
 // FindStateForBeadsStatus returns the best Linear state ID for a Beads status.
 func (sc *StateCache) FindStateForBeadsStatus(status types.Status) string {
-	targetType := StatusToLinearStateType(status)
-
-	for _, s := range sc.States {
-		if s.Type == targetType {
-			return s.ID
-		}
-	}
-
-	if len(sc.States) > 0 {
-		return sc.States[0].ID
-	}
-
-	return ""
+	return stateForStatus(sc.States, status, sc.Statuses)
 }
 
 // ExtractLinearIdentifier extracts the Linear issue identifier (e.g., "TEAM-123") from a Linear URL.
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/beads/internal/idgen"
+	"github.com/steveyegge/beads/internal/tracker"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -130,6 +131,15 @@ type MappingConfig struct {
 	// Key is lowercase state type or name, value is Beads status string.
 	StateMap map[string]string
 
+	// Statuses maps statuses both ways, with direction rules; built from
+	// the defaults and linear.status_map.* (or linear.state_map.*) config.
+	// When nil, StateMap is used for pulls.
+	Statuses *tracker.StatusMap
+
+	// StatusErrors lists invalid linear.status_map.* entries, which were
+	// skipped.
+	StatusErrors []error
+
 	// LabelTypeMap maps Linear label names to Beads issue types.
 	// Key is lowercase label name, value is Beads issue type.
 	LabelTypeMap map[string]string
@@ -139,6 +149,18 @@ type MappingConfig struct {
 	RelationMap map[string]string
 }
 
+// defaultStatusRules map Linear state types to statuses, in the order
+// they are preferred for pushes. Linear has no blocked state type, so
+// blocked issues are pushed as started.
+var defaultStatusRules = []tracker.StatusRule{
+	{State: "unstarted", Status: types.StatusOpen, Direction: tracker.StatusBoth},
+	{State: "backlog", Status: types.StatusOpen, Direction: tracker.StatusPull},
+	{State: "started", Status: types.StatusInProgress, Direction: tracker.StatusBoth},
+	{State: "started", Status: types.StatusBlocked, Direction: tracker.StatusPush},
+	{State: "completed", Status: types.StatusClosed, Direction: tracker.StatusBoth},
+	{State: "canceled", Status: types.StatusClosed, Direction: tracker.StatusPull},
+}
+
 // DefaultMappingConfig returns sensible default mappings.
 func DefaultMappingConfig() *MappingConfig {
 	return &MappingConfig{
@@ -159,6 +181,7 @@ func DefaultMappingConfig() *MappingConfig {
 			"completed": "closed",
 			"canceled":  "closed",
 		},
+		Statuses: tracker.NewStatusMap(defaultStatusRules...),
 		// Label patterns for issue type inference
 		LabelTypeMap: map[string]string{
 			"bug":         "bug",
@@ -191,9 +214,12 @@ type ConfigLoader interface {
 // Examples:
 //
 //	linear.priority_map.0 = 4       (Linear "no priority" -> Beads backlog)
-//	linear.state_map.started = in_progress
+//	linear.status_map.in review = in_progress:pull
 //	linear.label_type_map.bug = bug
 //	linear.relation_map.blocks = blocks
+//
+// linear.state_map.* is the older, pull-only form of linear.status_map.*;
+// see tracker.ParseStatusRule for the value format.
 func LoadMappingConfig(loader ConfigLoader) *MappingConfig {
 	config := DefaultMappingConfig()
 
@@ -207,6 +233,7 @@ func LoadMappingConfig(loader ConfigLoader) *MappingConfig {
 		return config
 	}
 
+	statusConfig := make(map[string]string)
 	for key, value := range allConfig {
 		// Parse priority mappings: linear.priority_map.<linear_priority>
 		if strings.HasPrefix(key, "linear.priority_map.") {
@@ -220,6 +247,14 @@ func LoadMappingConfig(loader ConfigLoader) *MappingConfig {
 		if strings.HasPrefix(key, "linear.state_map.") {
 			stateKey := strings.ToLower(strings.TrimPrefix(key, "linear.state_map."))
 			config.StateMap[stateKey] = value
+			if _, ok := allConfig["linear.status_map."+stateKey]; !ok {
+				// state_map only ever applied to pulls, and unknown
+				// values meant open.
+				statusConfig["linear.status_map."+stateKey] = string(ParseBeadsStatus(value)) + ":pull"
+			}
+		}
+		if strings.HasPrefix(key, "linear.status_map.") {
+			statusConfig[key] = value
 		}
 
 		// Parse label-to-type mappings: linear.label_type_map.<label_name>
@@ -234,6 +269,7 @@ func LoadMappingConfig(loader ConfigLoader) *MappingConfig {
 			config.RelationMap[relationType] = value
 		}
 	}
+	config.Statuses, config.StatusErrors = config.Statuses.WithConfig(statusConfig, "linear")
 
 	return config
 }
@@ -278,12 +314,19 @@ func PriorityToLinear(beadsPriority int, config *MappingConfig) int {
 }
 
 // StateToBeadsStatus maps Linear state type to Beads status.
-// Checks both state type (backlog, unstarted, etc.) and state name for custom workflows.
-// Uses configurable mapping from linear.state_map.* config.
+// Checks both state name (for custom workflows) and state type (backlog,
+// unstarted, etc.), name first. Uses configurable mapping from
+// linear.status_map.* config.
 func StateToBeadsStatus(state *State, config *MappingConfig) types.Status {
 	if state == nil {
 		return types.StatusOpen
 	}
+	if config.Statuses != nil {
+		if status, ok := config.Statuses.ToBeads(state.Name, state.Type); ok {
+			return status
+		}
+		return types.StatusOpen
+	}
 
 	// First, try to match by state type (preferred)
 	stateType := strings.ToLower(state.Type)
@@ -317,6 +360,32 @@ func ParseBeadsStatus(s string) types.Status {
 	}
 }
 
+// stateForStatus returns the ID of the workflow state a Beads status is
+// pushed as: the state named by the status map, else the first state of
+// the mapped (or default) type, else the first state.
+func stateForStatus(states []State, status types.Status, statuses *tracker.StatusMap) string {
+	targetType := StatusToLinearStateType(status)
+	if statuses != nil {
+		if target, ok := statuses.ToTracker(status); ok {
+			for _, s := range states {
+				if tracker.SameState(s.Name, target) {
+					return s.ID
+				}
+			}
+			targetType = strings.ToLower(target)
+		}
+	}
+	for _, s := range states {
+		if s.Type == targetType {
+			return s.ID
+		}
+	}
+	if len(states) > 0 {
+		return states[0].ID
+	}
+	return ""
+}
+
 // StatusToLinearStateType converts Beads status to Linear state type for filtering.
 // This is used when pushing issues to Linear to find the appropriate state.
 func StatusToLinearStateType(status types.Status) string {
@@ -550,6 +550,57 @@ func TestLoadMappingConfig(t *testing.T) {
 	}
 }
 
+func TestLoadMappingConfigStatusMap(t *testing.T) {
+	config := LoadMappingConfig(&mockConfigLoader{
+		config: map[string]string{
+			"linear.status_map.in review": "in_progress:pull",
+			"linear.status_map.blocked":   "blocked",
+			"linear.state_map.triage":     "inprogress", // Old name, old leniency
+			"linear.status_map.bad":       "nope",
+		},
+	})
+	if len(config.StatusErrors) != 1 {
+		t.Errorf("StatusErrors = %v, want one for bad", config.StatusErrors)
+	}
+
+	// Names win over types, so custom states under "started" can differ.
+	pulls := []struct {
+		state *State
+		want  types.Status
+	}{
+		{&State{Type: "started", Name: "In Review"}, types.StatusInProgress},
+		{&State{Type: "started", Name: "Blocked"}, types.StatusBlocked},
+		{&State{Type: "started", Name: "In Progress"}, types.StatusInProgress},
+		{&State{Type: "triage", Name: "Triage"}, types.StatusInProgress},
+	}
+	for _, tt := range pulls {
+		if got := StateToBeadsStatus(tt.state, config); got != tt.want {
+			t.Errorf("StateToBeadsStatus(%s) = %v, want %v", tt.state.Name, got, tt.want)
+		}
+	}
+
+	states := []State{
+		{ID: "todo", Name: "Todo", Type: "unstarted"},
+		{ID: "doing", Name: "In Progress", Type: "started"},
+		{ID: "blocked", Name: "Blocked", Type: "started"},
+		{ID: "done", Name: "Done", Type: "completed"},
+	}
+	pushes := map[types.Status]string{
+		types.StatusOpen:       "todo",
+		types.StatusInProgress: "doing",
+		types.StatusBlocked:    "blocked",
+		types.StatusClosed:     "done",
+	}
+	for status, want := range pushes {
+		if got := stateForStatus(states, status, config.Statuses); got != want {
+			t.Errorf("stateForStatus(%s) = %q, want %q", status, got, want)
+		}
+	}
+	if got := stateForStatus(states, types.StatusBlocked, nil); got != "doing" {
+		t.Errorf("stateForStatus(blocked) without a map = %q, want the first started state", got)
+	}
+}
+
 func TestLoadMappingConfigNilLoader(t *testing.T) {
 	config := LoadMappingConfig(nil)
 
@@ -2,6 +2,7 @@ package linear
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -56,6 +57,9 @@ func (t *Tracker) Init(ctx context.Context, store storage.Storage) error {
 
 	t.client = client
 	t.config = LoadMappingConfig(&configLoaderAdapter{ctx: ctx, store: store})
+	if len(t.config.StatusErrors) > 0 {
+		return errors.Join(t.config.StatusErrors...)
+	}
 	return nil
 }
 
@@ -159,21 +163,16 @@ func (t *Tracker) BuildExternalRef(issue *tracker.TrackerIssue) string {
 
 // findStateID looks up the Linear workflow state ID for a beads status.
 func (t *Tracker) findStateID(ctx context.Context, status types.Status) (string, error) {
-	targetType := StatusToLinearStateType(status)
-
 	states, err := t.client.GetTeamStates(ctx)
 	if err != nil {
 		return "", err
 	}
-
-	for _, s := range states {
-		if s.Type == targetType {
-			return s.ID, nil
-		}
+	var statuses *tracker.StatusMap
+	if t.config != nil {
+		statuses = t.config.Statuses
 	}
-
-	if len(states) > 0 {
-		return states[0].ID, nil
+	if id := stateForStatus(states, status, statuses); id != "" {
+		return id, nil
 	}
 	return "", fmt.Errorf("no workflow states found")
 }
@@ -247,7 +246,14 @@ func BuildStateCacheFromTracker(ctx context.Context, t *Tracker) (*StateCache, e
 	if t.client == nil {
 		return nil, fmt.Errorf("Linear tracker not initialized")
 	}
-	return BuildStateCache(ctx, t.client)
+	cache, err := BuildStateCache(ctx, t.client)
+	if err != nil {
+		return nil, err
+	}
+	if t.config != nil {
+		cache.Statuses = t.config.Statuses
+	}
+	return cache, nil
 }
 
 // configLoaderAdapter wraps storage.Storage to implement linear.ConfigLoader.
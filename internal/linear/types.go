@@ -8,6 +8,8 @@ package linear
 import (
 	"net/http"
 	"time"
+
+	"github.com/steveyegge/beads/internal/tracker"
 )
 
 // API configuration constants.
@@ -234,7 +236,8 @@ type DependencyInfo struct {
 type StateCache struct {
 	States      []State
 	StatesByID  map[string]State
-	OpenStateID string // First "unstarted" or "backlog" state
+	OpenStateID string             // First "unstarted" or "backlog" state
+	Statuses    *tracker.StatusMap // Configured status mapping; nil for the defaults
 }
 
 // Team represents a team in Linear.
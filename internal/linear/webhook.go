@@ -0,0 +1,109 @@
+package linear
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// WebhookTolerance is how far a delivery's webhookTimestamp may be from the
+// time it is received. Older deliveries are refused as possible replays.
+const WebhookTolerance = time.Minute
+
+// ErrWebhookSignature is returned for deliveries whose Linear-Signature
+// doesn't match the body.
+var ErrWebhookSignature = errors.New("invalid webhook signature")
+
+// WebhookPayload is the body Linear POSTs to a webhook.
+type WebhookPayload struct {
+	Action           string          `json:"action"` // "create", "update" or "remove"
+	Type             string          `json:"type"`   // "Issue", "Comment", ...
+	Data             json.RawMessage `json:"data"`
+	URL              string          `json:"url"`
+	WebhookID        string          `json:"webhookId"`
+	WebhookTimestamp int64           `json:"webhookTimestamp"` // Unix milliseconds
+}
+
+// WebhookIssue is the part of an Issue webhook's data used to find the
+// issue; the issue itself is fetched from the API.
+type WebhookIssue struct {
+	ID         string `json:"id"`
+	Identifier string `json:"identifier"`
+	TeamID     string `json:"teamId"`
+	ProjectID  string `json:"projectId"`
+}
+
+// ParseWebhook verifies a delivery against the webhook's signing secret
+// and decodes it. signature is the Linear-Signature header: the hex
+// HMAC-SHA256 of the body. Deliveries signed with another secret, or sent
+// more than WebhookTolerance before or after now, are rejected.
+func ParseWebhook(secret string, body []byte, signature string, now time.Time) (*WebhookPayload, error) {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	got, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(got, mac.Sum(nil)) {
+		return nil, ErrWebhookSignature
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+	sent := time.UnixMilli(payload.WebhookTimestamp)
+	if payload.WebhookTimestamp == 0 || now.Sub(sent).Abs() > WebhookTolerance {
+		return nil, fmt.Errorf("webhook timestamp %s is more than %v from now", sent.UTC().Format(time.RFC3339), WebhookTolerance)
+	}
+	return &payload, nil
+}
+
+// Issue decodes an Issue webhook's data.
+func (p *WebhookPayload) Issue() (*WebhookIssue, error) {
+	if p.Type != "Issue" {
+		return nil, fmt.Errorf("webhook is for a %s, not an Issue", p.Type)
+	}
+	var issue WebhookIssue
+	if err := json.Unmarshal(p.Data, &issue); err != nil {
+		return nil, fmt.Errorf("invalid issue in webhook: %w", err)
+	}
+	if issue.Identifier == "" {
+		return nil, fmt.Errorf("webhook issue has no identifier")
+	}
+	return &issue, nil
+}
+
+// ReplayGuard remembers recently handled deliveries, by their
+// Linear-Delivery ID, so a replayed one is applied only once. ParseWebhook
+// already refuses deliveries older than WebhookTolerance, so IDs are only
+// kept that long. The zero value is ready to use.
+type ReplayGuard struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// Seen reports whether the delivery was already handled.
+func (g *ReplayGuard) Seen(deliveryID string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	_, ok := g.seen[deliveryID]
+	return ok
+}
+
+// Handled records a delivery, received at now, as applied.
+func (g *ReplayGuard) Handled(deliveryID string, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.seen == nil {
+		g.seen = make(map[string]time.Time)
+	}
+	for id, at := range g.seen {
+		if now.Sub(at) > 2*WebhookTolerance {
+			delete(g.seen, id)
+		}
+	}
+	g.seen[deliveryID] = now
+}
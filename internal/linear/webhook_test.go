@@ -0,0 +1,70 @@
+package linear
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestParseWebhook(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	body := func(sent time.Time) []byte {
+		return []byte(fmt.Sprintf(`{"action":"update","type":"Issue","data":{"id":"abc","identifier":"ENG-7","teamId":"team-1"},"webhookTimestamp":%d}`,
+			sent.UnixMilli()))
+	}
+
+	fresh := body(now.Add(-10 * time.Second))
+	payload, err := ParseWebhook("s3cret", fresh, signWebhook("s3cret", fresh), now)
+	if err != nil {
+		t.Fatalf("ParseWebhook: %v", err)
+	}
+	issue, err := payload.Issue()
+	if err != nil || issue.Identifier != "ENG-7" || issue.TeamID != "team-1" || payload.Action != "update" {
+		t.Errorf("payload = %+v, issue = %+v, err = %v", payload, issue, err)
+	}
+
+	if _, err := ParseWebhook("s3cret", fresh, signWebhook("other", fresh), now); !errors.Is(err, ErrWebhookSignature) {
+		t.Errorf("wrong secret: err = %v, want ErrWebhookSignature", err)
+	}
+	if _, err := ParseWebhook("s3cret", fresh, "not-hex", now); !errors.Is(err, ErrWebhookSignature) {
+		t.Errorf("malformed signature: err = %v, want ErrWebhookSignature", err)
+	}
+	stale := body(now.Add(-2 * time.Minute))
+	if _, err := ParseWebhook("s3cret", stale, signWebhook("s3cret", stale), now); err == nil || !strings.Contains(err.Error(), "timestamp") {
+		t.Errorf("stale delivery: err = %v, want a timestamp error", err)
+	}
+}
+
+func TestWebhookIssueType(t *testing.T) {
+	p := &WebhookPayload{Type: "Comment", Data: []byte(`{"id":"c1"}`)}
+	if _, err := p.Issue(); err == nil {
+		t.Error("Issue() on a Comment webhook should fail")
+	}
+}
+
+func TestReplayGuard(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var g ReplayGuard
+	if g.Seen("d1") {
+		t.Fatal("new delivery reported as seen")
+	}
+	g.Handled("d1", now)
+	if !g.Seen("d1") {
+		t.Error("handled delivery not reported as seen")
+	}
+	g.Handled("d2", now.Add(3*WebhookTolerance))
+	if g.Seen("d1") {
+		t.Error("delivery older than the replay window is still remembered")
+	}
+}
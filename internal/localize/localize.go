@@ -0,0 +1,149 @@
+// Package localize keeps translated titles and descriptions of issues, so
+// international teams sharing one database can each read issues in their
+// own language. Translations are stored in the issue's metadata under
+// MetadataKey, keyed by language tag; the issue's own title and
+// description stay the original and are shown when no translation in a
+// preferred language exists.
+package localize
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// MetadataKey is the issue metadata field holding translations.
+const MetadataKey = "translations"
+
+// Variant is an issue's title and description in one language. Either may
+// be empty, in which case the original is shown for it.
+type Variant struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+var tagRegex = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// NormalizeTag lowercases a language tag such as "pt-BR" and checks it is
+// a language code optionally followed by subtags.
+func NormalizeTag(tag string) (string, error) {
+	norm := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if !tagRegex.MatchString(norm) {
+		return "", fmt.Errorf("invalid language tag %q: want a code like de, pt-br, or zh-hant", tag)
+	}
+	return norm, nil
+}
+
+// ParsePreferences parses a comma-separated list of preferred languages,
+// most preferred first, adding each regional tag's base language after it
+// ("pt-br" falls back to "pt"). Invalid tags are skipped.
+func ParsePreferences(list string) []string {
+	var prefs []string
+	add := func(tag string) {
+		for _, p := range prefs {
+			if p == tag {
+				return
+			}
+		}
+		prefs = append(prefs, tag)
+	}
+	for _, part := range strings.Split(list, ",") {
+		tag, err := NormalizeTag(part)
+		if err != nil {
+			continue
+		}
+		add(tag)
+		if base, _, ok := strings.Cut(tag, "-"); ok {
+			add(base)
+		}
+	}
+	return prefs
+}
+
+// FromMetadata returns the translations stored in issue metadata, by
+// language tag.
+func FromMetadata(metadata json.RawMessage) map[string]Variant {
+	var md struct {
+		Translations map[string]Variant `json:"translations"`
+	}
+	if len(metadata) == 0 || json.Unmarshal(metadata, &md) != nil || md.Translations == nil {
+		return map[string]Variant{}
+	}
+	return md.Translations
+}
+
+// Languages returns the languages an issue has translations in, sorted.
+func Languages(metadata json.RawMessage) []string {
+	var langs []string
+	for lang := range FromMetadata(metadata) {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// WithVariant returns metadata with the translation for lang set to v, or
+// removed if v is empty. Other metadata is preserved.
+func WithVariant(metadata json.RawMessage, lang string, v Variant) (json.RawMessage, error) {
+	md := make(map[string]json.RawMessage)
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &md); err != nil {
+			return nil, fmt.Errorf("issue metadata is not a JSON object: %w", err)
+		}
+		if md == nil { // "null"
+			md = make(map[string]json.RawMessage)
+		}
+	}
+	translations := FromMetadata(metadata)
+	if v == (Variant{}) {
+		delete(translations, lang)
+	} else {
+		translations[lang] = v
+	}
+	if len(translations) == 0 {
+		delete(md, MetadataKey)
+	} else {
+		data, err := json.Marshal(translations)
+		if err != nil {
+			return nil, err
+		}
+		md[MetadataKey] = data
+	}
+	return json.Marshal(md)
+}
+
+// Pick returns the translation in the first of prefs the issue has one
+// for, and its language; ok is false if there is none.
+func Pick(metadata json.RawMessage, prefs []string) (v Variant, lang string, ok bool) {
+	if len(prefs) == 0 {
+		return Variant{}, "", false
+	}
+	translations := FromMetadata(metadata)
+	for _, pref := range prefs {
+		if v, ok := translations[pref]; ok {
+			return v, pref, true
+		}
+	}
+	return Variant{}, "", false
+}
+
+// Apply replaces issue's title and description with its translation in the
+// first of prefs it has one for, keeping the original for fields the
+// translation leaves empty. It returns the language used, or "".
+func Apply(issue *types.Issue, prefs []string) string {
+	v, lang, ok := Pick(issue.Metadata, prefs)
+	if !ok {
+		return ""
+	}
+	if v.Title != "" {
+		issue.Title = v.Title
+	}
+	if v.Description != "" {
+		issue.Description = v.Description
+	}
+	return lang
+}
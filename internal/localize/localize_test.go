@@ -0,0 +1,73 @@
+package localize
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	for in, want := range map[string]string{"de": "de", "pt-BR": "pt-br", " zh_Hant ": "zh-hant"} {
+		if got, err := NormalizeTag(in); err != nil || got != want {
+			t.Errorf("NormalizeTag(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "d", "deutsch", "de-", "de--at", "12"} {
+		if _, err := NormalizeTag(bad); err == nil {
+			t.Errorf("NormalizeTag(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestParsePreferences(t *testing.T) {
+	got := ParsePreferences("pt-BR, fr, bogus!, pt, en")
+	if want := []string{"pt-br", "pt", "fr", "en"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParsePreferences = %v, want %v", got, want)
+	}
+	if got := ParsePreferences(""); got != nil {
+		t.Errorf("ParsePreferences(\"\") = %v", got)
+	}
+}
+
+func TestWithVariant(t *testing.T) {
+	md, err := WithVariant(json.RawMessage(`{"summary":{"text":"s"}}`), "de", Variant{Title: "Anmeldung kaputt"})
+	if err != nil {
+		t.Fatalf("WithVariant: %v", err)
+	}
+	md, err = WithVariant(md, "fr", Variant{Title: "Connexion cassée", Description: "Détails"})
+	if err != nil {
+		t.Fatalf("WithVariant: %v", err)
+	}
+	if got := Languages(md); !reflect.DeepEqual(got, []string{"de", "fr"}) {
+		t.Errorf("Languages = %v", got)
+	}
+
+	for _, lang := range []string{"de", "fr"} {
+		if md, err = WithVariant(md, lang, Variant{}); err != nil {
+			t.Fatalf("WithVariant(remove %s): %v", lang, err)
+		}
+	}
+	if string(md) != `{"summary":{"text":"s"}}` {
+		t.Errorf("removing translations left %s", md)
+	}
+}
+
+func TestApply(t *testing.T) {
+	md, _ := WithVariant(nil, "de", Variant{Title: "Anmeldung kaputt"})
+	md, _ = WithVariant(md, "fr", Variant{Title: "Connexion cassée", Description: "Détails"})
+
+	issue := &types.Issue{Title: "Login broken", Description: "Details", Metadata: md}
+	if lang := Apply(issue, []string{"es", "de", "fr"}); lang != "de" || issue.Title != "Anmeldung kaputt" || issue.Description != "Details" {
+		t.Errorf("Apply(de) = %q: %q / %q", lang, issue.Title, issue.Description)
+	}
+
+	issue = &types.Issue{Title: "Login broken", Metadata: md}
+	if lang := Apply(issue, []string{"es"}); lang != "" || issue.Title != "Login broken" {
+		t.Errorf("Apply(es) = %q: %q", lang, issue.Title)
+	}
+	if lang := Apply(issue, nil); lang != "" {
+		t.Errorf("Apply(no preferences) = %q", lang)
+	}
+}
@@ -0,0 +1,184 @@
+// Package permissions implements the local actor policy: a file in the
+// .beads directory granting each actor capabilities such as create, close
+// or delete. The storage layer checks it on every write.
+package permissions
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the policy file's name in the .beads directory.
+const FileName = "permissions.yaml"
+
+// Capability is a kind of write an actor may be granted.
+type Capability string
+
+const (
+	Create     Capability = "create"     // Create issues
+	Update     Capability = "update"     // Edit issues: fields, labels, dependencies, comments, claims
+	Close      Capability = "close"      // Close and reopen issues
+	Delete     Capability = "delete"     // Delete issues
+	Federation Capability = "federation" // Push, pull and sync with peers and remotes
+	Admin      Capability = "admin"      // Everything, including renames, migrations and config
+)
+
+// Capabilities lists every capability, in the order they are documented.
+var Capabilities = []Capability{Create, Update, Close, Delete, Federation, Admin}
+
+// ErrDenied matches every *DeniedError with errors.Is.
+var ErrDenied = errors.New("permission denied")
+
+// DeniedError is returned for a write the actor lacks the capability for.
+type DeniedError struct {
+	Actor      string
+	Capability Capability
+}
+
+func (e *DeniedError) Error() string {
+	actor := e.Actor
+	if actor == "" {
+		actor = "(unknown actor)"
+	}
+	return fmt.Sprintf("permission denied: %s lacks the %s capability (see .beads/%s)", actor, e.Capability, FileName)
+}
+
+func (e *DeniedError) Is(target error) bool {
+	return target == ErrDenied
+}
+
+// Policy grants capabilities to actors.
+//
+// Example .beads/permissions.yaml:
+//
+//	default: [create, update, close]
+//	actors:
+//	  alice: [admin]
+//	  release-bot: [create, update, close, federation]
+//	  sandbox-*: [create, update]
+//
+// Actors are matched by name, then by the longest matching glob pattern;
+// anyone else gets default. admin grants every capability.
+type Policy struct {
+	Default []Capability            `yaml:"default"`
+	Actors  map[string][]Capability `yaml:"actors"`
+}
+
+// Load reads the policy in beadsDir. It returns nil, and no error, if there
+// is no policy file: then every actor may do everything.
+func Load(beadsDir string) (*Policy, error) {
+	p := filepath.Join(beadsDir, FileName)
+	data, err := os.ReadFile(p) // #nosec G304 - path is the .beads policy file
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", p, err)
+	}
+	policy, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p, err)
+	}
+	return policy, nil
+}
+
+// Parse parses and validates a policy file.
+func Parse(data []byte) (*Policy, error) {
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+	if err := validate("default", p.Default); err != nil {
+		return nil, err
+	}
+	for actor, caps := range p.Actors {
+		if _, err := path.Match(actor, ""); err != nil {
+			return nil, fmt.Errorf("actors: invalid pattern %q: %w", actor, err)
+		}
+		if err := validate("actors."+actor, caps); err != nil {
+			return nil, err
+		}
+	}
+	return &p, nil
+}
+
+func validate(key string, caps []Capability) error {
+	for _, c := range caps {
+		if !isCapability(c) {
+			return fmt.Errorf("%s: unknown capability %q (valid: %s)", key, c, capabilityList())
+		}
+	}
+	return nil
+}
+
+func isCapability(c Capability) bool {
+	for _, known := range Capabilities {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
+
+func capabilityList() string {
+	names := make([]string, len(Capabilities))
+	for i, c := range Capabilities {
+		names[i] = string(c)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Granted returns the capabilities the policy grants actor, and the entry
+// that granted them: the actor's name, a pattern, or "default". A nil
+// policy grants everything.
+func (p *Policy) Granted(actor string) (caps []Capability, entry string) {
+	if p == nil {
+		return Capabilities, ""
+	}
+	if caps, ok := p.Actors[actor]; ok {
+		return caps, actor
+	}
+	var patterns []string
+	for pattern := range p.Actors {
+		if ok, _ := path.Match(pattern, actor); ok {
+			patterns = append(patterns, pattern)
+		}
+	}
+	if len(patterns) == 0 {
+		return p.Default, "default"
+	}
+	// The longest pattern is the most specific; ties go to the first.
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	return p.Actors[patterns[0]], patterns[0]
+}
+
+// Allows reports whether actor has capability c.
+func (p *Policy) Allows(actor string, c Capability) bool {
+	caps, _ := p.Granted(actor)
+	for _, granted := range caps {
+		if granted == c || granted == Admin {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns a *DeniedError if actor lacks capability c.
+func (p *Policy) Check(actor string, c Capability) error {
+	if p.Allows(actor, c) {
+		return nil
+	}
+	return &DeniedError{Actor: actor, Capability: c}
+}
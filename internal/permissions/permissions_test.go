@@ -0,0 +1,92 @@
+package permissions
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testPolicy = `
+default: [create, update]
+actors:
+  alice: [admin]
+  release-bot: [create, update, close, federation]
+  agent-*: [update]
+  agent-sandbox-*: [create]
+`
+
+func TestPolicyAllows(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	tests := []struct {
+		actor string
+		c     Capability
+		want  bool
+	}{
+		{"alice", Delete, true}, // admin grants everything
+		{"alice", Federation, true},
+		{"release-bot", Close, true},
+		{"release-bot", Delete, false},
+		{"agent-7", Update, true},
+		{"agent-7", Create, false},
+		{"agent-sandbox-2", Create, true}, // Longest pattern wins
+		{"agent-sandbox-2", Update, false},
+		{"bob", Create, true}, // default
+		{"bob", Close, false},
+		{"", Close, false},
+	}
+	for _, tt := range tests {
+		if got := p.Allows(tt.actor, tt.c); got != tt.want {
+			t.Errorf("Allows(%q, %s) = %v, want %v", tt.actor, tt.c, got, tt.want)
+		}
+	}
+	if _, entry := p.Granted("agent-sandbox-2"); entry != "agent-sandbox-*" {
+		t.Errorf("Granted(agent-sandbox-2) entry = %q", entry)
+	}
+
+	err = p.Check("bob", Delete)
+	var denied *DeniedError
+	if !errors.As(err, &denied) || !errors.Is(err, ErrDenied) || denied.Actor != "bob" || denied.Capability != Delete {
+		t.Errorf("Check(bob, delete) = %v, want a DeniedError", err)
+	}
+}
+
+func TestNilPolicyAllowsEverything(t *testing.T) {
+	var p *Policy
+	for _, c := range Capabilities {
+		if err := p.Check("anyone", c); err != nil {
+			t.Errorf("nil policy Check(%s) = %v", c, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tt := range []struct{ policy, want string }{
+		{"default: [create, destroy]", `unknown capability "destroy"`},
+		{"actors:\n  bob: [publish]", `actors.bob: unknown capability "publish"`},
+		{"actors:\n  \"[bad\": [create]", "invalid pattern"},
+		{"default: create: update", "invalid policy"},
+	} {
+		if _, err := Parse([]byte(tt.policy)); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) = %v, want error containing %q", tt.policy, err, tt.want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	if p, err := Load(dir); p != nil || err != nil {
+		t.Fatalf("Load without a file = %v, %v; want nil, nil", p, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(testPolicy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Load(dir)
+	if err != nil || p == nil || !p.Allows("alice", Admin) {
+		t.Fatalf("Load = %+v, %v", p, err)
+	}
+}
@@ -178,6 +178,12 @@ func (e *Evaluator) applyComparison(comp *ComparisonNode, filter *types.IssueFil
 		return e.applyUpdatedFilter(comp, filter)
 	case "closed", "closed_at":
 		return e.applyClosedFilter(comp, filter)
+	case "age":
+		created, err := ageAsCreated(comp)
+		if err != nil {
+			return err
+		}
+		return e.applyCreatedFilter(created, filter)
 	case "id":
 		return e.applyIDFilter(comp, filter)
 	case "spec", "spec_id":
@@ -493,6 +499,27 @@ func (e *Evaluator) applyNot(not *NotNode, filter *types.IssueFilter) error {
 	}
 }
 
+// ageAsCreated rewrites an age comparison as the matching created one:
+// age>2d (older than two days) is created<2d (created before two days ago).
+func ageAsCreated(comp *ComparisonNode) (*ComparisonNode, error) {
+	if comp.ValueType != TokenDuration {
+		return nil, fmt.Errorf("age needs a duration such as 2d or 12h, got %q", comp.Value)
+	}
+	created := *comp
+	created.Field = "created"
+	switch comp.Op {
+	case OpGreater:
+		created.Op = OpLess
+	case OpGreaterEq:
+		created.Op = OpLessEq
+	case OpLess:
+		created.Op = OpGreater
+	case OpLessEq:
+		created.Op = OpGreaterEq
+	}
+	return &created, nil
+}
+
 // parseTimeValue parses a time value from a comparison node.
 // Supports duration values (7d, 24h) which are interpreted as "now - duration".
 func (e *Evaluator) parseTimeValue(comp *ComparisonNode) (time.Time, error) {
@@ -600,6 +627,12 @@ func (e *Evaluator) buildComparisonPredicate(comp *ComparisonNode) (func(*types.
 		return e.buildUpdatedPredicate(comp)
 	case "closed", "closed_at":
 		return e.buildClosedPredicate(comp)
+	case "age":
+		created, err := ageAsCreated(comp)
+		if err != nil {
+			return nil, err
+		}
+		return e.buildCreatedPredicate(created)
 	case "id":
 		return e.buildIDPredicate(comp)
 	case "spec", "spec_id":
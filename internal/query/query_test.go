@@ -349,6 +349,14 @@ func TestEvaluatorSimpleQueries(t *testing.T) {
 					f.CreatedBefore.Month() == expected.Month() && f.CreatedBefore.Day() == expected.Day()
 			},
 		},
+		{
+			name:  "age greater than duration",
+			query: "age>2d",
+			expectFilter: func(f *types.IssueFilter) bool {
+				expected := now.AddDate(0, 0, -2)
+				return f.CreatedBefore != nil && f.CreatedBefore.Equal(expected) && f.CreatedAfter == nil
+			},
+		},
 		{
 			name:  "AND expression",
 			query: "status=open AND priority>1",
@@ -516,6 +524,11 @@ func TestPredicateEvaluation(t *testing.T) {
 		{"status=open OR status=blocked matches blocked", "status=open OR status=blocked", blockedFeature, true},
 		{"status=open OR status=blocked doesn't match closed", "status=open OR status=blocked", closedTask, false},
 
+		// Age tests
+		{"age>2d matches a 5 day old bug", "age>2d", openBug, true},
+		{"age>7d doesn't match a 5 day old bug", "age>7d", openBug, false},
+		{"age<7d matches a 5 day old bug", "age<7d", openBug, true},
+
 		// AND tests
 		{"status=open AND type=bug matches", "status=open AND type=bug", openBug, true},
 		{"status=open AND type=bug doesn't match blocked", "status=open AND type=bug", blockedFeature, false},
@@ -576,6 +589,7 @@ func TestEvaluatorErrors(t *testing.T) {
 		{"priority out of range", "priority=5"},
 		{"invalid boolean", "pinned=maybe"},
 		{"unknown field", "unknown=value"},
+		{"age without a duration", "age>2025-01-01"},
 	}
 
 	for _, tt := range tests {
@@ -617,3 +631,31 @@ func TestDurationParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestRenameLabel(t *testing.T) {
+	tests := []struct {
+		expr string
+		to   []string
+		want string
+	}{
+		{"label=ui AND priority=0", []string{"frontend"}, "label=frontend AND priority=0"},
+		{`labels = "ui" OR label=UI`, []string{"area:web"}, `labels="area:web" OR label="area:web"`},
+		{"NOT label!=ui", []string{"web"}, "NOT label!=web"},
+		{"label=ui", []string{"web", "mobile"}, "(label=web OR label=mobile)"},
+		{"status=open AND label!=ui", []string{"web", "mobile"}, "status=open AND (label!=web AND label!=mobile)"},
+	}
+	for _, tt := range tests {
+		got, changed := RenameLabel(tt.expr, "ui", tt.to)
+		if !changed || got != tt.want {
+			t.Errorf("RenameLabel(%q) = %q, %v; want %q", tt.expr, got, changed, tt.want)
+		}
+		if _, err := Parse(got); err != nil {
+			t.Errorf("RenameLabel(%q) doesn't parse: %v", tt.expr, err)
+		}
+	}
+	for _, expr := range []string{"label=uikit", "title=ui", "status=open", `title="unterminated`} {
+		if got, changed := RenameLabel(expr, "ui", []string{"web"}); changed || got != expr {
+			t.Errorf("RenameLabel(%q) = %q, %v; want it unchanged", expr, got, changed)
+		}
+	}
+}
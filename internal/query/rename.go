@@ -0,0 +1,86 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RenameLabel rewrites label comparisons on from in expr to compare against
+// to instead, leaving the rest of the query as written. With several
+// labels (a split), label=from becomes (label=a OR label=b) and
+// label!=from becomes (label!=a AND label!=b). It reports whether anything
+// changed; a query that doesn't lex is returned unchanged.
+func RenameLabel(expr, from string, to []string) (string, bool) {
+	if len(to) == 0 {
+		return expr, false
+	}
+	tokens, err := NewLexer(expr).Tokenize()
+	if err != nil {
+		return expr, false
+	}
+
+	var sb strings.Builder
+	last := 0
+	for i := 0; i+2 < len(tokens); i++ {
+		field, op, value := tokens[i], tokens[i+1], tokens[i+2]
+		if field.Type != TokenIdent || !isLabelField(field.Value) ||
+			(op.Type != TokenEquals && op.Type != TokenNotEquals) ||
+			!isValueToken(value.Type) || !strings.EqualFold(value.Value, from) {
+			continue
+		}
+		sb.WriteString(expr[last:field.Pos])
+		sb.WriteString(labelComparison(field.Value, op.Value, to))
+		last = tokenEnd(expr, value)
+		i += 2
+	}
+	if last == 0 {
+		return expr, false
+	}
+	sb.WriteString(expr[last:])
+	return sb.String(), true
+}
+
+func isLabelField(field string) bool {
+	field = strings.ToLower(field)
+	return field == "label" || field == "labels"
+}
+
+func isValueToken(t TokenType) bool {
+	return t == TokenIdent || t == TokenString || t == TokenNumber || t == TokenDuration
+}
+
+// labelComparison writes field op label for each of labels, joined to keep
+// the comparison's meaning.
+func labelComparison(field, op string, labels []string) string {
+	parts := make([]string, len(labels))
+	for i, label := range labels {
+		parts[i] = field + op + quoteValue(label)
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	join := " OR "
+	if op == "!=" {
+		join = " AND "
+	}
+	return "(" + strings.Join(parts, join) + ")"
+}
+
+// quoteValue quotes v unless it lexes as a single identifier.
+func quoteValue(v string) string {
+	tokens, err := NewLexer(v).Tokenize()
+	if err == nil && len(tokens) == 2 && tokens[0].Type == TokenIdent && tokens[0].Value == v {
+		return v
+	}
+	return strconv.Quote(v)
+}
+
+// tokenEnd returns the offset in expr just past tok.
+func tokenEnd(expr string, tok Token) int {
+	l := NewLexer(expr)
+	l.pos = tok.Pos
+	if _, err := l.NextToken(); err != nil {
+		return tok.Pos + len(tok.Value)
+	}
+	return l.pos
+}
@@ -0,0 +1,153 @@
+// Package quiethours parses do-not-start windows: times, such as Friday
+// evening through the weekend, when new work may not be claimed or
+// started, so that agent runs don't span them.
+package quiethours
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/validation"
+)
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// Window is one do-not-start window.
+type Window struct {
+	Spec        string // As configured, e.g. "fri 17:00-mon 08:00 p2+"
+	weekly      bool   // Start and end are minutes of the week, else of the day
+	start, end  int
+	minPriority int // Applies to this priority and less urgent ones
+}
+
+// Parse parses window specs of the form
+//
+//	[day] HH:MM-[day] HH:MM [pN+]
+//
+// A window with days ("fri 17:00-mon 08:00") recurs weekly; one without
+// ("18:00-08:00") recurs daily. Either may wrap around. "p2+" limits the
+// window to P2 and less urgent work; without it, the window applies to all
+// priorities. Times are local.
+func Parse(specs []string) ([]Window, error) {
+	var windows []Window
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		w, err := parseWindow(spec)
+		if err != nil {
+			return nil, fmt.Errorf("quiet-hours %q: %w", spec, err)
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+func parseWindow(spec string) (Window, error) {
+	w := Window{Spec: spec}
+	from, rest, ok := strings.Cut(strings.ToLower(spec), "-")
+	if !ok {
+		return w, fmt.Errorf("want START-END, e.g. fri 17:00-mon 08:00")
+	}
+	fields := strings.Fields(rest)
+	if n := len(fields); n > 0 && strings.HasPrefix(fields[n-1], "p") && strings.HasSuffix(fields[n-1], "+") {
+		priority, err := validation.ValidatePriority(strings.TrimSuffix(fields[n-1], "+"))
+		if err != nil {
+			return w, err
+		}
+		w.minPriority = priority
+		fields = fields[:n-1]
+	}
+
+	startDay, start, err := parseTime(strings.Fields(from))
+	if err != nil {
+		return w, err
+	}
+	endDay, end, err := parseTime(fields)
+	if err != nil {
+		return w, err
+	}
+	if (startDay < 0) != (endDay < 0) {
+		return w, fmt.Errorf("give a day for both the start and the end, or for neither")
+	}
+	w.start, w.end = start, end
+	if startDay >= 0 {
+		w.weekly = true
+		w.start += startDay * minutesPerDay
+		w.end += endDay * minutesPerDay
+	}
+	if w.start == w.end {
+		return w, fmt.Errorf("start and end are the same time")
+	}
+	return w, nil
+}
+
+// parseTime parses "[day] HH:MM", returning the weekday (-1 if none) and
+// the minutes since midnight.
+func parseTime(fields []string) (day, minutes int, err error) {
+	day = -1
+	switch len(fields) {
+	case 2:
+		d, ok := weekdays[fields[0]]
+		if !ok {
+			return 0, 0, fmt.Errorf("invalid day %q", fields[0])
+		}
+		day = int(d)
+		fields = fields[1:]
+	case 1:
+	default:
+		return 0, 0, fmt.Errorf("want [day] HH:MM, got %q", strings.Join(fields, " "))
+	}
+	h, m, ok := strings.Cut(fields[0], ":")
+	hours, herr := strconv.Atoi(h)
+	mins, merr := strconv.Atoi(m)
+	if !ok || herr != nil || merr != nil || hours < 0 || hours > 24 || mins < 0 || mins > 59 || (hours == 24 && mins != 0) {
+		return 0, 0, fmt.Errorf("invalid time %q (use HH:MM)", fields[0])
+	}
+	return day, hours*60 + mins, nil
+}
+
+// Contains reports whether t falls in the window, in t's location.
+func (w Window) Contains(t time.Time) bool {
+	m, period := t.Hour()*60+t.Minute(), minutesPerDay
+	if w.weekly {
+		m, period = m+int(t.Weekday())*minutesPerDay, minutesPerWeek
+	}
+	start, end := w.start%period, w.end%period
+	if start < end {
+		return m >= start && m < end
+	}
+	return m >= start || m < end
+}
+
+// Applies reports whether the window holds back work of this priority.
+func (w Window) Applies(priority int) bool {
+	return priority >= w.minPriority
+}
+
+// Active returns the window that holds back starting work of the given
+// priority at t, or nil.
+func Active(windows []Window, priority int, t time.Time) *Window {
+	for i := range windows {
+		if windows[i].Applies(priority) && windows[i].Contains(t) {
+			return &windows[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package quiethours
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	windows, err := Parse([]string{"fri 17:00-mon 08:00 p2+", "", " 22:00-06:30 "})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(windows) != 2 || windows[0].Spec != "fri 17:00-mon 08:00 p2+" || windows[1].Spec != "22:00-06:30" {
+		t.Fatalf("windows = %+v", windows)
+	}
+
+	for _, bad := range []string{
+		"17:00",                   // No end
+		"fri 17:00-08:00",         // Day on one side only
+		"fri 25:00-mon 08:00",     // Bad time
+		"fry 17:00-mon 08:00",     // Bad day
+		"09:00-09:00",             // Empty
+		"fri 17:00-mon 08:00 p9+", // Bad priority
+	} {
+		if _, err := Parse([]string{bad}); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+func TestActive(t *testing.T) {
+	windows, err := Parse([]string{"fri 17:00-mon 08:00 p2+", "22:00-06:30"})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, 0, 0, time.UTC) // March 2026: the 13th is a Friday
+	}
+	tests := []struct {
+		name     string
+		t        time.Time
+		priority int
+		want     string
+	}{
+		{"friday afternoon", at(13, 16, 59), 2, ""},
+		{"friday evening", at(13, 17, 0), 2, "fri 17:00-mon 08:00 p2+"},
+		{"friday evening P1", at(13, 17, 0), 1, ""},
+		{"saturday", at(14, 12, 0), 3, "fri 17:00-mon 08:00 p2+"},
+		{"monday morning", at(16, 7, 59), 4, "fri 17:00-mon 08:00 p2+"},
+		{"monday at eight", at(16, 8, 0), 2, ""},
+		{"tuesday night", at(17, 23, 0), 0, "22:00-06:30"},
+		{"wednesday dawn", at(18, 6, 29), 1, "22:00-06:30"},
+		{"wednesday morning", at(18, 6, 30), 1, ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if w := Active(windows, tt.priority, tt.t); w != nil {
+			got = w.Spec
+		}
+		if got != tt.want {
+			t.Errorf("%s: Active = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Package refs finds references to issues ("bd-a3f", "bd-a3f.2") in
+// descriptions and comments.
+package refs
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Finder matches issue IDs with a fixed set of prefixes.
+type Finder struct {
+	re *regexp.Regexp // nil when there are no prefixes
+}
+
+// NewFinder returns a Finder for IDs with any of prefixes (without the
+// trailing hyphen). Empty and duplicate prefixes are ignored.
+func NewFinder(prefixes ...string) *Finder {
+	seen := make(map[string]bool)
+	var quoted []string
+	for _, p := range prefixes {
+		p = strings.TrimSuffix(strings.TrimSpace(p), "-")
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		quoted = append(quoted, p)
+	}
+	if len(quoted) == 0 {
+		return &Finder{}
+	}
+	// Longest first, so "hq-cv-a1" is read as prefix "hq-cv" rather than "hq"
+	sort.Slice(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+	for i, p := range quoted {
+		quoted[i] = regexp.QuoteMeta(p)
+	}
+	return &Finder{re: regexp.MustCompile(`\b(?:` + strings.Join(quoted, "|") + `)-[a-z0-9]+(?:\.[0-9]+)*\b`)}
+}
+
+// Find returns the IDs referenced in texts, in order of first appearance,
+// once each.
+func (f *Finder) Find(texts ...string) []string {
+	if f.re == nil {
+		return nil
+	}
+	var ids []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, id := range f.re.FindAllString(text, -1) {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// Replace returns text with each reference replaced by render(id). Use it
+// to style references for display; render may return the ID unchanged.
+func (f *Finder) Replace(text string, render func(id string) string) string {
+	if f.re == nil {
+		return text
+	}
+	return f.re.ReplaceAllStringFunc(text, render)
+}
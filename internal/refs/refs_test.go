@@ -0,0 +1,54 @@
+package refs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	f := NewFinder("bd", "hq-cv", "bd-", "")
+	tests := []struct {
+		name  string
+		texts []string
+		want  []string
+	}{
+		{"none", []string{"nothing to see"}, nil},
+		{"simple", []string{"see bd-a3f for details"}, []string{"bd-a3f"}},
+		{"child", []string{"blocked on bd-a3f.2."}, []string{"bd-a3f.2"}},
+		{"order and dedupe", []string{"bd-2 then bd-1", "and bd-2 again"}, []string{"bd-2", "bd-1"}},
+		{"longer prefix", []string{"filed as hq-cv-x9"}, []string{"hq-cv-x9"}},
+		{"punctuation", []string{"(bd-1), `bd-2`, [bd-3](url)"}, []string{"bd-1", "bd-2", "bd-3"}},
+		{"not inside words", []string{"abd-1 and bd-"}, nil},
+		{"other prefix", []string{"gt-1 is elsewhere"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.Find(tt.texts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Find(%q) = %q, want %q", tt.texts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindNoPrefixes(t *testing.T) {
+	if got := NewFinder().Find("bd-1"); got != nil {
+		t.Errorf("Find with no prefixes = %q, want nil", got)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	f := NewFinder("bd")
+	got := f.Replace("fixes bd-1 and bd-2.1", func(id string) string {
+		if id == "bd-1" {
+			return "<" + id + ">"
+		}
+		return strings.ToUpper(id)
+	})
+	if want := "fixes <bd-1> and BD-2.1"; got != want {
+		t.Errorf("Replace = %q, want %q", got, want)
+	}
+	if got := NewFinder().Replace("bd-1", strings.ToUpper); got != "bd-1" {
+		t.Errorf("Replace with no prefixes = %q, want unchanged", got)
+	}
+}
@@ -0,0 +1,149 @@
+// Package resolution implements close categories: a structured prefix on an
+// issue's close reason, such as "wontfix: can't reproduce" or
+// "superseded-by:bd-42: replaced by the new importer". They live in the
+// close_reason column, so they export, import and federate with it, and
+// bd stats can count how issues were resolved.
+package resolution
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Built-in categories.
+const (
+	Completed    = "completed"
+	WontFix      = "wontfix"
+	Duplicate    = "duplicate"
+	Obsolete     = "obsolete"
+	SupersededBy = "superseded-by" // Written superseded-by:<id>
+)
+
+// Builtin lists the built-in categories, the default for close.categories.
+var Builtin = []string{Completed, WontFix, Duplicate, Obsolete, SupersededBy}
+
+// Uncategorized is the stats bucket for reasons without a category.
+const Uncategorized = "uncategorized"
+
+var categoryPattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// Resolution is a close reason split into its parts.
+type Resolution struct {
+	Category string // Lowercase category; empty if the reason has none
+	Target   string // Replacing issue, for superseded-by
+	Note     string // Free text after the category
+}
+
+// String formats r as a close reason: "category: note", "category", or,
+// without a category, the note.
+func (r Resolution) String() string {
+	head := r.Category
+	if head == "" {
+		return r.Note
+	}
+	if r.Target != "" {
+		head += ":" + r.Target
+	}
+	if r.Note == "" {
+		return head
+	}
+	return head + ": " + r.Note
+}
+
+// Rules are a project's close categories and the priorities that need one.
+type Rules struct {
+	Categories  []string // Allowed categories (close.categories)
+	MaxPriority int      // Issues this urgent or more need a category; -1 for none
+}
+
+// NewRules validates categories and returns the rules for them.
+func NewRules(categories []string, maxPriority int) (*Rules, error) {
+	r := &Rules{MaxPriority: maxPriority}
+	for _, c := range categories {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if !categoryPattern.MatchString(c) {
+			return nil, fmt.Errorf("invalid close category %q: use lowercase letters, digits and dashes", c)
+		}
+		r.Categories = append(r.Categories, c)
+	}
+	return r, nil
+}
+
+// DefaultRules allows the built-in categories and requires none.
+func DefaultRules() *Rules {
+	return &Rules{Categories: Builtin, MaxPriority: -1}
+}
+
+func (r *Rules) allowed(category string) bool {
+	for _, c := range r.Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse splits reason into its category, target and note. A leading word
+// is only a category if the rules allow it ("Fixed: typo" stays a note);
+// case doesn't matter, so "Completed" and "completed" count the same.
+func (r *Rules) Parse(reason string) Resolution {
+	reason = strings.TrimSpace(reason)
+	head, note, found := strings.Cut(reason, ": ")
+	if !found {
+		head = reason
+	}
+	category, target, _ := strings.Cut(strings.ToLower(head), ":")
+	if !r.allowed(category) || (target != "") != (category == SupersededBy) {
+		return Resolution{Note: reason}
+	}
+	if target != "" {
+		target = head[len(category)+1:] // Keep the ID's case
+	}
+	return Resolution{Category: category, Target: target, Note: strings.TrimSpace(note)}
+}
+
+// ParseCategory parses a --category value: an allowed category, or
+// superseded-by:<id>.
+func (r *Rules) ParseCategory(s string) (category, target string, err error) {
+	category, target, _ = strings.Cut(strings.TrimSpace(s), ":")
+	category = strings.ToLower(category)
+	if !r.allowed(category) {
+		return "", "", fmt.Errorf("unknown close category %q (allowed: %s)", s, strings.Join(r.Categories, ", "))
+	}
+	if category == SupersededBy && target == "" {
+		return "", "", fmt.Errorf("%s needs the replacing issue: %s:<id>", SupersededBy, SupersededBy)
+	}
+	if category != SupersededBy && target != "" {
+		return "", "", fmt.Errorf("close category %q takes no issue ID", category)
+	}
+	return category, target, nil
+}
+
+// Required reports whether closing an issue of priority needs a category.
+func (r *Rules) Required(priority int) bool {
+	return r.MaxPriority >= 0 && priority <= r.MaxPriority
+}
+
+// Check returns an error if closing an issue of priority with reason
+// breaks the rules: its priority needs a category and reason has none.
+func (r *Rules) Check(reason string, priority int) error {
+	if !r.Required(priority) || r.Parse(reason).Category != "" {
+		return nil
+	}
+	return fmt.Errorf("P%d issues need a close category (%s)", priority, strings.Join(r.Categories, ", "))
+}
+
+// Count tallies closed issues by category, from a count per close reason.
+// Reasons without a category count as Uncategorized.
+func (r *Rules) Count(reasons map[string]int) map[string]int {
+	counts := make(map[string]int)
+	for reason, n := range reasons {
+		category := r.Parse(reason).Category
+		if category == "" {
+			category = Uncategorized
+		}
+		counts[category] += n
+	}
+	return counts
+}
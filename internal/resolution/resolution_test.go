@@ -0,0 +1,100 @@
+package resolution
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	r := DefaultRules()
+	tests := []struct {
+		reason string
+		want   Resolution
+	}{
+		{"wontfix: can't reproduce", Resolution{Category: WontFix, Note: "can't reproduce"}},
+		{"Completed", Resolution{Category: Completed}},
+		{"superseded-by:bd-A1: new importer", Resolution{Category: SupersededBy, Target: "bd-A1", Note: "new importer"}},
+		{"superseded-by:bd-9", Resolution{Category: SupersededBy, Target: "bd-9"}},
+		{"superseded-by: bd-9", Resolution{Note: "superseded-by: bd-9"}}, // Missing target
+		{"duplicate:bd-9", Resolution{Note: "duplicate:bd-9"}},           // Only superseded-by takes a target
+		{"Fixed: typo in docs", Resolution{Note: "Fixed: typo in docs"}},
+		{"Closed", Resolution{Note: "Closed"}},
+		{"", Resolution{}},
+	}
+	for _, tt := range tests {
+		if got := r.Parse(tt.reason); got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.reason, got, tt.want)
+		}
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	r := DefaultRules()
+	for _, res := range []Resolution{
+		{Category: Obsolete, Note: "feature removed"},
+		{Category: SupersededBy, Target: "bd-42"},
+		{Category: SupersededBy, Target: "bd-42", Note: "see new design"},
+		{Note: "free text"},
+	} {
+		if got := r.Parse(res.String()); got != res {
+			t.Errorf("Parse(%q) = %+v, want %+v", res.String(), got, res)
+		}
+	}
+}
+
+func TestParseCategory(t *testing.T) {
+	r, err := NewRules([]string{"completed", "Shipped", SupersededBy}, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, target, err := r.ParseCategory("SHIPPED"); err != nil || c != "shipped" || target != "" {
+		t.Errorf("ParseCategory(SHIPPED) = %q, %q, %v", c, target, err)
+	}
+	if c, target, err := r.ParseCategory("superseded-by:bd-7"); err != nil || c != SupersededBy || target != "bd-7" {
+		t.Errorf("ParseCategory(superseded-by:bd-7) = %q, %q, %v", c, target, err)
+	}
+	for _, bad := range []string{"wontfix", "superseded-by", "completed:bd-1"} {
+		if _, _, err := r.ParseCategory(bad); err == nil {
+			t.Errorf("ParseCategory(%q) succeeded, want an error", bad)
+		}
+	}
+	if _, err := NewRules([]string{"won't fix"}, -1); err == nil {
+		t.Error("NewRules accepted an invalid category")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	r := &Rules{Categories: Builtin, MaxPriority: 1}
+	if err := r.Check("Closed", 2); err != nil {
+		t.Errorf("P2 without a category: %v", err)
+	}
+	if err := r.Check("completed: shipped", 0); err != nil {
+		t.Errorf("P0 with a category: %v", err)
+	}
+	if err := r.Check("Closed", 1); err == nil || !strings.Contains(err.Error(), "P1 issues need a close category") {
+		t.Errorf("P1 without a category = %v", err)
+	}
+	if err := DefaultRules().Check("Closed", 0); err != nil {
+		t.Errorf("default rules: %v", err)
+	}
+}
+
+func TestCount(t *testing.T) {
+	got := DefaultRules().Count(map[string]int{
+		"completed":             3,
+		"Completed: shipped":    2,
+		"wontfix: by design":    1,
+		"superseded-by:bd-1":    1,
+		"Closed":                4,
+		"Fixed the flaky build": 1,
+	})
+	want := map[string]int{Completed: 5, WontFix: 1, SupersededBy: 1, Uncategorized: 5}
+	if len(got) != len(want) {
+		t.Fatalf("Count = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("Count[%s] = %d, want %d", k, got[k], v)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package retention
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// ManifestVersion is the current deletion manifest format.
+const ManifestVersion = 1
+
+// Manifest records one retention run: the policy applied and what it
+// deleted. It identifies issues by ID and content hash only, so keeping the
+// manifest doesn't retain the deleted content.
+type Manifest struct {
+	Version     int       `json:"version"`
+	RunAt       time.Time `json:"run_at"`
+	Actor       string    `json:"actor"`
+	ClosedAfter string    `json:"closed_after"` // The policy's retention period, e.g. "7y"
+	HoldLabel   string    `json:"hold_label"`
+	Cutoff      time.Time `json:"cutoff"`
+	Deleted     []Entry   `json:"deleted"`
+	HeldCount   int       `json:"held_count"`
+	PublicKey   string    `json:"public_key"` // Base64 ed25519 key the signature verifies against
+	Signature   string    `json:"signature,omitempty"`
+}
+
+// Entry identifies one deleted issue.
+type Entry struct {
+	ID          string    `json:"id"`
+	IssueType   string    `json:"issue_type"`
+	CreatedAt   time.Time `json:"created_at"`
+	ClosedAt    time.Time `json:"closed_at"`
+	ContentHash string    `json:"content_hash"`
+}
+
+// NewEntry describes a closed issue for a manifest.
+func NewEntry(issue *types.Issue) Entry {
+	e := Entry{
+		ID:          issue.ID,
+		IssueType:   string(issue.IssueType),
+		CreatedAt:   issue.CreatedAt.UTC(),
+		ContentHash: issue.ComputeContentHash(),
+	}
+	if issue.ClosedAt != nil {
+		e.ClosedAt = issue.ClosedAt.UTC()
+	}
+	return e
+}
+
+// payload is the byte string a signature covers: the manifest's JSON
+// encoding with an empty signature.
+func (m *Manifest) payload() ([]byte, error) {
+	unsigned := *m
+	unsigned.Signature = ""
+	return json.Marshal(unsigned)
+}
+
+// PublicKey returns the base64 public half of key, as recorded in manifests.
+func PublicKey(key ed25519.PrivateKey) string {
+	pub, _ := key.Public().(ed25519.PublicKey)
+	return base64.StdEncoding.EncodeToString(pub)
+}
+
+// Sign sets PublicKey and Signature using key.
+func (m *Manifest) Sign(key ed25519.PrivateKey) error {
+	if len(key) != ed25519.PrivateKeySize {
+		return errors.New("invalid signing key")
+	}
+	m.PublicKey = PublicKey(key)
+	data, err := m.payload()
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+	m.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, data))
+	return nil
+}
+
+// Verify checks the signature against the manifest's own public key. That
+// proves the manifest is unmodified; to prove who signed it, also compare
+// Fingerprint(m.PublicKey) with the signer's published fingerprint.
+func (m *Manifest) Verify() error {
+	pub, err := base64.StdEncoding.DecodeString(m.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return errors.New("manifest has no valid public key")
+	}
+	sig, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return errors.New("manifest has no valid signature")
+	}
+	data, err := m.payload()
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+	if !ed25519.Verify(pub, data, sig) {
+		return errors.New("signature does not match manifest contents")
+	}
+	return nil
+}
+
+// Fingerprint returns a short SHA-256 fingerprint of a base64 public key,
+// for publishing and comparing signing keys.
+func Fingerprint(publicKey string) string {
+	sum := sha256.Sum256([]byte(publicKey))
+	return "SHA256:" + hex.EncodeToString(sum[:])[:32]
+}
+
+// ReadManifest loads a manifest file.
+func ReadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- user-provided manifest path
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// LoadOrCreateKey reads the PKCS#8 PEM ed25519 key at path, generating and
+// saving a new one (mode 0600) if the file doesn't exist.
+func LoadOrCreateKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- configured key path
+	if err == nil {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("%s: not a PEM file", path)
+		}
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		key, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%s: not an ed25519 key", path)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating signing key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("encoding signing key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		return nil, fmt.Errorf("saving signing key: %w", err)
+	}
+	return key, nil
+}
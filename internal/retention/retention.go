@@ -0,0 +1,94 @@
+// Package retention selects closed issues that have outlived the configured
+// retention period and records their deletion in signed manifests.
+package retention
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Age is a calendar period such as 7 years or 18 months. Calendar units,
+// not a fixed number of hours, so "7y" means the same date seven years ago.
+type Age struct {
+	Years, Months, Days int
+}
+
+var agePattern = regexp.MustCompile(`^(\d+)([ymwd]?)$`)
+
+// ParseAge parses a retention period: a number followed by y (years),
+// m (months), w (weeks), or d (days). A bare number means years.
+func ParseAge(s string) (Age, error) {
+	m := agePattern.FindStringSubmatch(s)
+	if m == nil {
+		return Age{}, fmt.Errorf("invalid retention period %q (examples: 7y, 18m, 90d)", s)
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil || n <= 0 {
+		return Age{}, fmt.Errorf("invalid retention period %q: must be positive", s)
+	}
+	switch m[2] {
+	case "m":
+		return Age{Months: n}, nil
+	case "w":
+		return Age{Days: 7 * n}, nil
+	case "d":
+		return Age{Days: n}, nil
+	default:
+		return Age{Years: n}, nil
+	}
+}
+
+// Cutoff returns the instant a period before now; issues closed before it
+// are past retention.
+func (a Age) Cutoff(now time.Time) time.Time {
+	return now.AddDate(-a.Years, -a.Months, -a.Days)
+}
+
+// Plan is the outcome of applying a policy to closed issues.
+type Plan struct {
+	Delete []*types.Issue // Past retention and not protected, sorted by ID
+	Held   []string       // Past retention but under legal hold (directly or via an ancestor)
+	Pinned []string       // Past retention but pinned
+}
+
+// Select partitions issues closed before cutoff. held is the set of issue
+// IDs carrying the hold label; a hold also covers every descendant, found by
+// following parents (child ID -> parent ID).
+func Select(issues []*types.Issue, cutoff time.Time, held map[string]bool, parents map[string]string) *Plan {
+	plan := &Plan{}
+	for _, issue := range issues {
+		if issue.Status != types.StatusClosed || issue.ClosedAt == nil || !issue.ClosedAt.Before(cutoff) {
+			continue
+		}
+		switch {
+		case underHold(issue.ID, held, parents):
+			plan.Held = append(plan.Held, issue.ID)
+		case issue.Pinned:
+			plan.Pinned = append(plan.Pinned, issue.ID)
+		default:
+			plan.Delete = append(plan.Delete, issue)
+		}
+	}
+	sort.Slice(plan.Delete, func(i, j int) bool { return plan.Delete[i].ID < plan.Delete[j].ID })
+	sort.Strings(plan.Held)
+	sort.Strings(plan.Pinned)
+	return plan
+}
+
+// underHold reports whether id or any of its ancestors is held.
+func underHold(id string, held map[string]bool, parents map[string]string) bool {
+	seen := make(map[string]bool)
+	for id != "" && !seen[id] {
+		if held[id] {
+			return true
+		}
+		seen[id] = true // Guards against parent cycles
+		id = parents[id]
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package retention
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseAge(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"7y", time.Date(2019, 3, 31, 12, 0, 0, 0, time.UTC)},
+		{"7", time.Date(2019, 3, 31, 12, 0, 0, 0, time.UTC)},
+		{"1m", time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)}, // Feb 31 normalizes like time.AddDate
+		{"2w", time.Date(2026, 3, 17, 12, 0, 0, 0, time.UTC)},
+		{"90d", time.Date(2025, 12, 31, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		age, err := ParseAge(tt.in)
+		if err != nil {
+			t.Errorf("ParseAge(%q): %v", tt.in, err)
+			continue
+		}
+		if got := age.Cutoff(now); !got.Equal(tt.want) {
+			t.Errorf("ParseAge(%q).Cutoff = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "0y", "-1y", "7 years", "y"} {
+		if _, err := ParseAge(bad); err == nil {
+			t.Errorf("ParseAge(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	cutoff := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := cutoff.AddDate(-1, 0, 0)
+	recent := cutoff.AddDate(0, 1, 0)
+	closed := func(id string, at time.Time) *types.Issue {
+		return &types.Issue{ID: id, Status: types.StatusClosed, ClosedAt: &at}
+	}
+	pinned := closed("bd-pin", old)
+	pinned.Pinned = true
+	issues := []*types.Issue{
+		closed("bd-c", old),
+		closed("bd-a", old),
+		closed("bd-new", recent),
+		closed("bd-held", old),
+		closed("bd-child", old), // Parent epic is held
+		pinned,
+		{ID: "bd-open", Status: types.StatusOpen},
+	}
+	held := map[string]bool{"bd-held": true, "bd-epic": true}
+	parents := map[string]string{"bd-child": "bd-mid", "bd-mid": "bd-epic", "bd-a": "bd-c", "bd-c": "bd-a"}
+
+	plan := Select(issues, cutoff, held, parents)
+	var ids []string
+	for _, issue := range plan.Delete {
+		ids = append(ids, issue.ID)
+	}
+	if len(ids) != 2 || ids[0] != "bd-a" || ids[1] != "bd-c" {
+		t.Errorf("Delete = %v, want [bd-a bd-c]", ids)
+	}
+	if len(plan.Held) != 2 || plan.Held[0] != "bd-child" || plan.Held[1] != "bd-held" {
+		t.Errorf("Held = %v", plan.Held)
+	}
+	if len(plan.Pinned) != 1 || plan.Pinned[0] != "bd-pin" {
+		t.Errorf("Pinned = %v", plan.Pinned)
+	}
+}
+
+func TestManifestSignVerify(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "keys", "retention.key")
+	key, err := LoadOrCreateKey(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(keyPath); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("key file mode = %v, %v", info.Mode(), err)
+	}
+	again, err := LoadOrCreateKey(keyPath)
+	if err != nil || !again.Equal(key) {
+		t.Fatalf("reloaded key differs: %v", err)
+	}
+
+	closedAt := time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC)
+	m := &Manifest{
+		Version:     ManifestVersion,
+		ClosedAfter: "7y",
+		Deleted:     []Entry{NewEntry(&types.Issue{ID: "bd-1", Title: "Old", Status: types.StatusClosed, ClosedAt: &closedAt})},
+	}
+	if err := m.Sign(key); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Verify(); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if Fingerprint(m.PublicKey) != Fingerprint(PublicKey(key)) {
+		t.Error("fingerprint does not match signing key")
+	}
+
+	m.Deleted[0].ID = "bd-2"
+	if err := m.Verify(); err == nil {
+		t.Error("Verify accepted a modified manifest")
+	}
+}
@@ -0,0 +1,318 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// params builds a method's params from an HTTP request.
+type params func(r *http.Request) (map[string]interface{}, error)
+
+// HTTPHandler serves the server's methods as a REST API under /api/, for
+// dashboards and tools that would rather speak HTTP than run a subprocess.
+// Each route calls one method, so read-only mode, params validation and
+// error kinds are the same as over JSON-RPC:
+//
+//	GET    /api/issues                      search_issues (q, status, type, assignee, label, label_any, parent, limit)
+//	POST   /api/issues                      create_issue (body: the issue)
+//	GET    /api/issues/{id}                 get_issue
+//	PATCH  /api/issues/{id}                 update_issue (body: the updates)
+//	DELETE /api/issues/{id}                 delete_issue
+//	POST   /api/issues/{id}/close           close_issue (body: reason, session)
+//	GET    /api/issues/{id}/dependencies    get_dependencies_with_metadata
+//	POST   /api/issues/{id}/dependencies    add_dependency (body: depends_on_id, type)
+//	DELETE /api/issues/{id}/dependencies/{dep}  remove_dependency
+//	GET    /api/issues/{id}/dependents      get_dependents_with_metadata
+//	GET    /api/issues/{id}/labels          get_labels
+//	POST   /api/issues/{id}/labels          add_label (body: label)
+//	DELETE /api/issues/{id}/labels/{label}  remove_label
+//	GET    /api/issues/{id}/comments        get_issue_comments
+//	POST   /api/issues/{id}/comments        add_issue_comment (body: text)
+//	GET    /api/issues/{id}/events          get_events (limit)
+//	GET    /api/issues/{id}/blockers        get_blocking_chain
+//	GET    /api/ready                       get_ready_work (type, priority, assignee, unassigned, label, label_any, parent, limit)
+//	GET    /api/blocked                     get_blocked_issues (type, priority, assignee, unassigned, label, label_any, parent, limit)
+//	GET    /api/stats                       get_statistics
+//	GET    /api/federation                  federation_status, when the server has it
+//
+// Results are returned as JSON with status 200, or 201 for creations and
+// 204 when there is nothing to return. Errors are {"error": {code, message,
+// data}} with a matching HTTP status. Serve it behind an HTTPGuard.
+func (s *Server) HTTPHandler() http.Handler {
+	mux := http.NewServeMux()
+	route := func(pattern, method string, p params, status int) {
+		mux.Handle(pattern, s.httpMethod(method, p, status))
+	}
+
+	route("GET /api/issues", "search_issues", query(searchParams), http.StatusOK)
+	route("POST /api/issues", "create_issue", bodyAs("issue"), http.StatusCreated)
+	route("GET /api/issues/{id}", "get_issue", path("id", "id"), http.StatusOK)
+	route("PATCH /api/issues/{id}", "update_issue", merge(bodyAs("updates"), path("id", "id")), http.StatusOK)
+	route("DELETE /api/issues/{id}", "delete_issue", path("id", "id"), http.StatusNoContent)
+	route("POST /api/issues/{id}/close", "close_issue", merge(bodyFields, path("id", "id")), http.StatusOK)
+
+	route("GET /api/issues/{id}/dependencies", "get_dependencies_with_metadata", path("id", "issue_id"), http.StatusOK)
+	route("POST /api/issues/{id}/dependencies", "add_dependency", merge(bodyFields, path("id", "issue_id")), http.StatusNoContent)
+	route("DELETE /api/issues/{id}/dependencies/{dep}", "remove_dependency", merge(path("id", "issue_id"), path("dep", "depends_on_id")), http.StatusNoContent)
+	route("GET /api/issues/{id}/dependents", "get_dependents_with_metadata", path("id", "issue_id"), http.StatusOK)
+
+	route("GET /api/issues/{id}/labels", "get_labels", path("id", "issue_id"), http.StatusOK)
+	route("POST /api/issues/{id}/labels", "add_label", merge(bodyFields, path("id", "issue_id")), http.StatusNoContent)
+	route("DELETE /api/issues/{id}/labels/{label}", "remove_label", merge(path("id", "issue_id"), path("label", "label")), http.StatusNoContent)
+
+	route("GET /api/issues/{id}/comments", "get_issue_comments", path("id", "issue_id"), http.StatusOK)
+	route("POST /api/issues/{id}/comments", "add_issue_comment", merge(bodyFields, path("id", "issue_id")), http.StatusCreated)
+	route("GET /api/issues/{id}/events", "get_events", merge(query(eventParams), path("id", "issue_id")), http.StatusOK)
+	route("GET /api/issues/{id}/blockers", "get_blocking_chain", path("id", "issue_id"), http.StatusOK)
+
+	route("GET /api/ready", "get_ready_work", query(workParams), http.StatusOK)
+	route("GET /api/blocked", "get_blocked_issues", query(blockedParams), http.StatusOK)
+	route("GET /api/stats", "get_statistics", nil, http.StatusOK)
+	route("GET /api/federation", "federation_status", nil, http.StatusOK)
+
+	mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		writeHTTPError(w, &Error{Code: CodeMethodNotFound, Message: "no route for " + r.Method + " " + r.URL.Path})
+	})
+	return mux
+}
+
+// httpMethod handles a route by calling method with the params p builds.
+func (s *Server) httpMethod(method string, p params, status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var raw json.RawMessage
+		if p != nil {
+			values, err := p(r)
+			if err != nil {
+				var rpcErr *Error
+				if !errors.As(err, &rpcErr) {
+					rpcErr = &Error{Code: CodeInvalidParams, Message: "invalid params: " + err.Error()}
+				}
+				writeHTTPError(w, rpcErr)
+				return
+			}
+			if raw, err = json.Marshal(values); err != nil {
+				writeHTTPError(w, &Error{Code: CodeInternalError, Message: err.Error()})
+				return
+			}
+		}
+		result, rpcErr := s.Call(r.Context(), method, raw)
+		if rpcErr != nil {
+			writeHTTPError(w, rpcErr)
+			return
+		}
+		if status == http.StatusNoContent {
+			w.WriteHeader(status)
+			return
+		}
+		writeHTTPJSON(w, status, result)
+	})
+}
+
+// path takes the {name} path segment as the param key.
+func path(name, key string) params {
+	return func(r *http.Request) (map[string]interface{}, error) {
+		return map[string]interface{}{key: r.PathValue(name)}, nil
+	}
+}
+
+// bodyAs takes the whole request body, which must be JSON, as the param key.
+func bodyAs(key string) params {
+	return func(r *http.Request) (map[string]interface{}, error) {
+		body, err := readBody(r)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{key: body}, nil
+	}
+}
+
+// bodyFields takes the fields of the request body, a JSON object, as params.
+// An empty body gives no params.
+func bodyFields(r *http.Request) (map[string]interface{}, error) {
+	body, err := readBody(r)
+	if err != nil || body == nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("request body must be a JSON object: %w", err)
+	}
+	return fields, nil
+}
+
+func readBody(r *http.Request) (json.RawMessage, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxMessageSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %w", err)
+	}
+	if len(body) == 0 {
+		return nil, nil
+	}
+	if !json.Valid(body) {
+		return nil, &Error{Code: CodeParseError, Message: "parse error: request body is not valid JSON"}
+	}
+	return body, nil
+}
+
+// merge combines params; later ones win. Path segments go last, so a body
+// can't redirect a request to another issue.
+func merge(all ...params) params {
+	return func(r *http.Request) (map[string]interface{}, error) {
+		merged := make(map[string]interface{})
+		for _, p := range all {
+			values, err := p(r)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range values {
+				merged[k] = v
+			}
+		}
+		return merged, nil
+	}
+}
+
+// query builds params from the URL query with build.
+func query(build func(q queryValues) (map[string]interface{}, error)) params {
+	return func(r *http.Request) (map[string]interface{}, error) {
+		return build(queryValues(r.URL.Query()))
+	}
+}
+
+func searchParams(q queryValues) (map[string]interface{}, error) {
+	filter := map[string]interface{}{}
+	q.str(filter, "status", "status")
+	q.str(filter, "type", "issue_type")
+	q.str(filter, "assignee", "assignee")
+	q.str(filter, "parent", "parent_id")
+	q.str(filter, "q", "full_text")
+	q.list(filter, "label", "labels")
+	q.list(filter, "label_any", "labels_any")
+	if err := q.num(filter, "priority", "priority"); err != nil {
+		return nil, err
+	}
+	if err := q.num(filter, "limit", "limit"); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"filter": filter}, nil
+}
+
+func workParams(q queryValues) (map[string]interface{}, error) {
+	p, err := blockedParams(q)
+	if err != nil {
+		return nil, err
+	}
+	filter := p["filter"].(map[string]interface{})
+	q.str(filter, "sort", "sort_policy")
+	q.str(filter, "fairness", "fairness")
+	return p, nil
+}
+
+// blockedParams takes the filters get_blocked_issues applies; sorting and
+// fairness are for ready work only.
+func blockedParams(q queryValues) (map[string]interface{}, error) {
+	filter := map[string]interface{}{}
+	q.str(filter, "type", "type")
+	q.str(filter, "assignee", "assignee")
+	q.str(filter, "parent", "parent_id")
+	q.list(filter, "label", "labels")
+	q.list(filter, "label_any", "labels_any")
+	if err := q.num(filter, "priority", "priority"); err != nil {
+		return nil, err
+	}
+	if err := q.num(filter, "limit", "limit"); err != nil {
+		return nil, err
+	}
+	if err := q.flag(filter, "unassigned", "unassigned"); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"filter": filter}, nil
+}
+
+func eventParams(q queryValues) (map[string]interface{}, error) {
+	p := map[string]interface{}{}
+	return p, q.num(p, "limit", "limit")
+}
+
+// queryValues copies URL query parameters into params.
+type queryValues map[string][]string
+
+func (q queryValues) str(into map[string]interface{}, name, key string) {
+	if v := q[name]; len(v) > 0 && v[0] != "" {
+		into[key] = v[0]
+	}
+}
+
+// list accepts repeated parameters (label=a&label=b).
+func (q queryValues) list(into map[string]interface{}, name, key string) {
+	if v := q[name]; len(v) > 0 {
+		into[key] = v
+	}
+}
+
+func (q queryValues) num(into map[string]interface{}, name, key string) error {
+	v := q[name]
+	if len(v) == 0 || v[0] == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v[0])
+	if err != nil {
+		return fmt.Errorf("%s must be a number", name)
+	}
+	into[key] = n
+	return nil
+}
+
+func (q queryValues) flag(into map[string]interface{}, name, key string) error {
+	v := q[name]
+	if len(v) == 0 || v[0] == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(v[0])
+	if err != nil {
+		return fmt.Errorf("%s must be true or false", name)
+	}
+	into[key] = b
+	return nil
+}
+
+// httpStatus maps a JSON-RPC error to an HTTP status.
+func httpStatus(err *Error) int {
+	switch err.Code {
+	case CodeParseError, CodeInvalidRequest, CodeInvalidParams:
+		return http.StatusBadRequest
+	case CodeMethodNotFound:
+		return http.StatusNotFound
+	case CodeReadOnly:
+		return http.StatusForbidden
+	case CodeStorage:
+		data, _ := err.Data.(ErrorData)
+		switch data.Kind {
+		case "not_found":
+			return http.StatusNotFound
+		case "already_claimed", "cycle":
+			return http.StatusConflict
+		case "validation", "prefix_mismatch":
+			return http.StatusUnprocessableEntity
+		case "permission_denied":
+			return http.StatusForbidden
+		case "not_initialized":
+			return http.StatusServiceUnavailable
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+func writeHTTPError(w http.ResponseWriter, err *Error) {
+	writeHTTPJSON(w, httpStatus(err), map[string]*Error{"error": err})
+}
+
+func writeHTTPJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,87 @@
+package rpc
+
+import (
+	"crypto/subtle"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// HTTPGuard keeps web pages the user visits from using an HTTP API served
+// on their machine. A page can send a cross-site form or text/plain POST to
+// 127.0.0.1 without a CORS preflight, and can read responses once DNS
+// rebinding points its own name at 127.0.0.1. So requests must name a
+// loopback host (or one of AllowedHosts), cross-origin requests are
+// refused, and bodies sent with POST, PUT or PATCH must be declared
+// application/json, which a page can't send without a preflight.
+type HTTPGuard struct {
+	Token        string   // If set, required on every request as "Authorization: Bearer <token>"
+	AllowedHosts []string // Host names accepted besides localhost, 127.0.0.1 and ::1
+}
+
+// Wrap returns next guarded by g.
+func (g HTTPGuard) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !g.hostAllowed(r.Host) {
+			writeHTTPJSON(w, http.StatusForbidden, map[string]*Error{"error": {Code: CodeInvalidRequest,
+				Message: "host " + r.Host + " not allowed (see bd serve --allow-host)"}})
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" && !sameOrigin(origin, r.Host) {
+			writeHTTPJSON(w, http.StatusForbidden, map[string]*Error{"error": {Code: CodeInvalidRequest,
+				Message: "cross-origin request from " + origin + " refused"}})
+			return
+		}
+		if g.Token != "" {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(g.Token)) != 1 {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeHTTPJSON(w, http.StatusUnauthorized, map[string]*Error{"error": {Code: CodeInvalidRequest,
+					Message: "missing or wrong bearer token"}})
+				return
+			}
+		}
+		switch r.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+			if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+				writeHTTPJSON(w, http.StatusUnsupportedMediaType, map[string]*Error{"error": {Code: CodeInvalidRequest,
+					Message: "request body must be sent as Content-Type: application/json"}})
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// hostAllowed reports whether the request's Host header names a loopback
+// address or one of g.AllowedHosts.
+func (g HTTPGuard) hostAllowed(hostport string) bool {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.Trim(host, "[]"))
+	if host == "localhost" {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return true
+	}
+	for _, allowed := range g.AllowedHosts {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameOrigin reports whether origin, an Origin header, is the server itself.
+func sameOrigin(origin, host string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false // Including "null", sent by sandboxed frames and file: pages
+	}
+	return strings.EqualFold(u.Host, host)
+}
@@ -0,0 +1,183 @@
+package rpc
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// httpServer serves a fakeStore over HTTP for the duration of the test.
+func httpServer(t *testing.T, readOnly bool) (*fakeStore, *httptest.Server) {
+	t.Helper()
+	store := &fakeStore{issues: map[string]*types.Issue{"bd-1": {ID: "bd-1", Title: "First"}}}
+	ts := httptest.NewServer(NewServer(StorageMethods(store, "tester"), readOnly).HTTPHandler())
+	t.Cleanup(ts.Close)
+	return store, ts
+}
+
+func request(t *testing.T, ts *httptest.Server, method, path, body string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	out, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, strings.TrimSpace(string(out))
+}
+
+func TestHTTPHandler(t *testing.T) {
+	store, ts := httpServer(t, false)
+	store.deniedLabel = "release"
+
+	tests := []struct {
+		method, path, body string
+		status             int
+		want               string // Prefix of the response body
+	}{
+		{"GET", "/api/issues/bd-1", "", 200, `{"id":"bd-1","title":"First"`},
+		{"POST", "/api/issues", `{"title":"Fix login","priority":1}`, 201, `{"id":"bd-2","title":"Fix login"`},
+		{"GET", "/api/issues/bd-404", "", 404, `{"error":{"code":-32000,"message":"not found: issue bd-404","data":{"kind":"not_found"}}}`},
+		{"POST", "/api/issues/bd-1/labels", `{"label":"ui"}`, 204, ``},
+		{"POST", "/api/issues/bd-1/labels", `{"label":"release"}`, 403, `{"error":{"code":-32000,"message":"permission denied: `},
+		{"POST", "/api/issues/bd-1/labels", `{"lable":"ui"}`, 400, `{"error":{"code":-32602,"message":"invalid params: json: unknown field \"lable\""}}`},
+		{"POST", "/api/issues/bd-1/labels", `{"label":`, 400, `{"error":{"code":-32700`},
+		{"GET", "/api/issues/bd-1/labels", "", 200, `[]`},
+		{"GET", "/api/ready?limit=many", "", 400, `{"error":{"code":-32602,"message":"invalid params: limit must be a number"}}`},
+		{"GET", "/api/nothing", "", 404, `{"error":{"code":-32601,"message":"no route for GET /api/nothing"}}`},
+		{"GET", "/api/federation", "", 404, `{"error":{"code":-32601,"message":"method not found: federation_status"}}`},
+	}
+	for _, tt := range tests {
+		status, body := request(t, ts, tt.method, tt.path, tt.body)
+		if status != tt.status || !strings.HasPrefix(body, tt.want) {
+			t.Errorf("%s %s = %d %s\nwant %d %s", tt.method, tt.path, status, body, tt.status, tt.want)
+		}
+	}
+	if created := store.issues["bd-2"]; created == nil || created.Status != types.StatusOpen || created.IssueType != types.TypeTask {
+		t.Errorf("created issue = %+v, want bd create's defaults", created)
+	}
+	if len(store.labels) != 1 || store.labels[0] != "bd-1:ui" {
+		t.Errorf("labels = %v, want the one added", store.labels)
+	}
+}
+
+func TestHTTPHandlerReadOnly(t *testing.T) {
+	store, ts := httpServer(t, true)
+	status, body := request(t, ts, "POST", "/api/issues/bd-1/labels", `{"label":"ui"}`)
+	if status != http.StatusForbidden || !strings.Contains(body, `"code":-32001`) {
+		t.Errorf("write in read-only mode = %d %s", status, body)
+	}
+	if len(store.labels) != 0 {
+		t.Errorf("read-only server wrote labels %v", store.labels)
+	}
+}
+
+func TestSearchParams(t *testing.T) {
+	p, err := searchParams(queryValues{
+		"q": {"login crash"}, "status": {"open"}, "type": {"bug"},
+		"label": {"ui", "auth"}, "limit": {"5"}, "assignee": {""},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := json.Marshal(p)
+	want := `{"filter":{"full_text":"login crash","issue_type":"bug","labels":["ui","auth"],"limit":5,"status":"open"}}`
+	if string(got) != want {
+		t.Errorf("searchParams = %s\nwant %s", got, want)
+	}
+	// The params must decode as search_issues params
+	var decoded struct {
+		Query  string      `json:"query"`
+		Filter IssueFilter `json:"filter"`
+	}
+	if err := decodeParams(got, &decoded); err != nil {
+		t.Errorf("search_issues rejects the params: %v", err)
+	}
+}
+
+func TestWorkParams(t *testing.T) {
+	p, err := workParams(queryValues{
+		"type": {"bug"}, "priority": {"1"}, "assignee": {"alice"}, "unassigned": {"false"},
+		"label": {"ui"}, "label_any": {"a", "b"}, "parent": {"bd-1"}, "sort": {"priority"}, "limit": {"3"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := json.Marshal(p)
+	var decoded struct {
+		Filter WorkFilter `json:"filter"`
+	}
+	if err := decodeParams(raw, &decoded); err != nil {
+		t.Fatalf("get_ready_work rejects the params: %v", err)
+	}
+	if f := decoded.Filter; f.Type != "bug" || *f.Priority != 1 || *f.Assignee != "alice" || f.SortPolicy != "priority" || f.Limit != 3 || len(f.LabelsAny) != 2 {
+		t.Errorf("filter = %+v", f)
+	}
+	if _, err := workParams(queryValues{"unassigned": {"maybe"}}); err == nil {
+		t.Error("unassigned=maybe should be rejected")
+	}
+	// Blocked issues take the filters but not ready work's ordering
+	p, err = blockedParams(queryValues{"parent": {"bd-1"}, "sort": {"oldest"}, "fairness": {"epic"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ = json.Marshal(p)
+	if want := `{"filter":{"parent_id":"bd-1"}}`; string(raw) != want {
+		t.Errorf("blockedParams = %s\nwant %s", raw, want)
+	}
+}
+
+func TestHTTPGuard(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	tests := []struct {
+		name    string
+		guard   HTTPGuard
+		method  string
+		host    string
+		headers map[string]string
+		status  int
+	}{
+		{"loopback read", HTTPGuard{}, "GET", "127.0.0.1:8080", nil, 204},
+		{"localhost read", HTTPGuard{}, "GET", "localhost:8080", nil, 204},
+		{"ipv6 loopback", HTTPGuard{}, "GET", "[::1]:8080", nil, 204},
+		{"rebound host", HTTPGuard{}, "GET", "evil.example:8080", nil, 403},
+		{"allowed host", HTTPGuard{AllowedHosts: []string{"tracker.internal"}}, "GET", "tracker.internal:8080", nil, 204},
+		{"same origin", HTTPGuard{}, "POST", "localhost:8080",
+			map[string]string{"Origin": "http://localhost:8080", "Content-Type": "application/json"}, 204},
+		{"cross origin", HTTPGuard{}, "POST", "localhost:8080",
+			map[string]string{"Origin": "https://evil.example", "Content-Type": "application/json"}, 403},
+		{"null origin", HTTPGuard{}, "GET", "localhost:8080", map[string]string{"Origin": "null"}, 403},
+		{"form post", HTTPGuard{}, "POST", "localhost:8080",
+			map[string]string{"Content-Type": "application/x-www-form-urlencoded"}, 415},
+		{"text post", HTTPGuard{}, "PATCH", "localhost:8080", map[string]string{"Content-Type": "text/plain"}, 415},
+		{"json post", HTTPGuard{}, "POST", "localhost:8080",
+			map[string]string{"Content-Type": "application/json; charset=utf-8"}, 204},
+		{"delete", HTTPGuard{}, "DELETE", "localhost:8080", nil, 204},
+		{"no token", HTTPGuard{Token: "s3cret"}, "GET", "localhost:8080", nil, 401},
+		{"wrong token", HTTPGuard{Token: "s3cret"}, "GET", "localhost:8080",
+			map[string]string{"Authorization": "Bearer guess"}, 401},
+		{"token", HTTPGuard{Token: "s3cret"}, "GET", "localhost:8080",
+			map[string]string{"Authorization": "Bearer s3cret"}, 204},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "/api/issues", strings.NewReader(`{}`))
+		req.Host = tt.host
+		for k, v := range tt.headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		tt.guard.Wrap(ok).ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s: %s from %s = %d %s, want %d", tt.name, tt.method, tt.host, rec.Code, rec.Body, tt.status)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+)
+
+// MCPProtocolVersion is the newest Model Context Protocol revision spoken.
+// Older revisions a client asks for are accepted too; tools work the same
+// in all of them.
+const MCPProtocolVersion = "2025-06-18"
+
+var mcpProtocolVersions = []string{MCPProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// Tool offers the method of the same name to MCP clients. Its input is the
+// method's params, described by a JSON Schema.
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema schema
+}
+
+// schema is a JSON Schema.
+type schema map[string]interface{}
+
+// object describes an object with exactly the given properties, since
+// methods reject unknown params.
+func object(properties map[string]schema, required ...string) schema {
+	s := schema{"type": "object", "properties": properties, "additionalProperties": false}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func prop(typ, description string) schema {
+	return schema{"type": typ, "description": description}
+}
+
+func enum(description string, values ...string) schema {
+	return schema{"type": "string", "description": description, "enum": values}
+}
+
+func array(items schema, description string) schema {
+	return schema{"type": "array", "items": items, "description": description}
+}
+
+// Schemas for params shared by several tools.
+var (
+	issueIDSchema  = prop("string", "Issue ID, e.g. bd-a1b2")
+	statusSchema   = prop("string", "Status: open, in_progress, blocked, deferred, closed, or a configured custom status")
+	prioritySchema = schema{"type": "integer", "minimum": 0, "maximum": 4,
+		"description": "Priority: 0 critical, 1 high, 2 medium, 3 low, 4 backlog"}
+	typeSchema   = prop("string", "Issue type: bug, feature, task, epic, chore, or a configured custom type")
+	labelsSchema = array(prop("string", "Label"), "Labels")
+	limitSchema  = schema{"type": "integer", "minimum": 1, "description": "Maximum number of issues to return"}
+
+	// workFilterSchema is WorkFilter, less the fields agents don't need.
+	workFilterSchema = object(map[string]schema{
+		"type":             typeSchema,
+		"priority":         prioritySchema,
+		"assignee":         prop("string", "Only issues assigned to this person"),
+		"unassigned":       prop("boolean", "Only unassigned issues"),
+		"labels":           array(prop("string", "Label"), "Only issues with all of these labels"),
+		"labels_any":       array(prop("string", "Label"), "Only issues with any of these labels"),
+		"parent_id":        prop("string", "Only children of this issue"),
+		"sort_policy":      enum("Order (default priority; hybrid ages old issues up)", "hybrid", "priority", "oldest", "votes"),
+		"fairness":         enum("Interleave the order by epic or label so none fills the top", "epic", "label"),
+		"include_deferred": prop("boolean", "Include issues deferred into the future"),
+		"limit":            limitSchema,
+	})
+
+	// blockedFilterSchema is the part of WorkFilter get_blocked_issues applies.
+	blockedFilterSchema = object(map[string]schema{
+		"type":       typeSchema,
+		"priority":   prioritySchema,
+		"assignee":   prop("string", "Only issues assigned to this person"),
+		"unassigned": prop("boolean", "Only unassigned issues"),
+		"labels":     array(prop("string", "Label"), "Only issues with all of these labels"),
+		"labels_any": array(prop("string", "Label"), "Only issues with any of these labels"),
+		"parent_id":  prop("string", "Only children of this issue"),
+		"limit":      limitSchema,
+	})
+)
+
+// StorageTools are the StorageMethods an agent needs to track its work,
+// offered as MCP tools.
+var StorageTools = []Tool{
+	{
+		Name:        "get_ready_work",
+		Description: "List open issues with no open blockers, highest priority first: the work that can be started now.",
+		InputSchema: object(map[string]schema{"filter": workFilterSchema}),
+	},
+	{
+		Name:        "get_blocked_issues",
+		Description: "List issues waiting on open blockers, with the IDs of what blocks them.",
+		InputSchema: object(map[string]schema{"filter": blockedFilterSchema}),
+	},
+	{
+		Name:        "get_blocking_chain",
+		Description: "Explain why an issue is not ready work: its open blockers, including blockers of blockers, its deferral, and anything else such as its status.",
+		InputSchema: object(map[string]schema{"issue_id": issueIDSchema}, "issue_id"),
+	},
+	{
+		Name:        "search_issues",
+		Description: "Find issues matching a filter. full_text matches every word against titles, descriptions, IDs and comments.",
+		InputSchema: object(map[string]schema{
+			"filter": object(map[string]schema{
+				"full_text":  prop("string", "Words (or \"quoted phrases\") that must all appear"),
+				"status":     statusSchema,
+				"priority":   prioritySchema,
+				"issue_type": typeSchema,
+				"assignee":   prop("string", "Only issues assigned to this person"),
+				"labels":     array(prop("string", "Label"), "Only issues with all of these labels"),
+				"labels_any": array(prop("string", "Label"), "Only issues with any of these labels"),
+				"parent_id":  prop("string", "Only children of this issue"),
+				"limit":      limitSchema,
+			}),
+		}),
+	},
+	{
+		Name:        "get_issue",
+		Description: "Show an issue with its labels.",
+		InputSchema: object(map[string]schema{"id": issueIDSchema}, "id"),
+	},
+	{
+		Name:        "create_issue",
+		Description: "Create an issue. Omitted fields default as in bd create: open, task, priority 2. Returns the issue with its new ID.",
+		InputSchema: object(map[string]schema{
+			"issue": object(map[string]schema{
+				"title":               prop("string", "Title, up to 500 characters"),
+				"description":         prop("string", "What and why"),
+				"design":              prop("string", "How it will be done"),
+				"acceptance_criteria": prop("string", "When it is done"),
+				"notes":               prop("string", "Working notes"),
+				"status":              statusSchema,
+				"priority":            prioritySchema,
+				"issue_type":          typeSchema,
+				"assignee":            prop("string", "Who is working on it"),
+				"labels":              labelsSchema,
+				"external_ref":        prop("string", "Reference in another system, e.g. gh-9"),
+			}, "title"),
+		}, "issue"),
+	},
+	{
+		Name:        "update_issue",
+		Description: "Change fields of an issue. Set status to in_progress and assignee to yourself to claim it.",
+		InputSchema: object(map[string]schema{
+			"id": issueIDSchema,
+			"updates": object(map[string]schema{
+				"title":               prop("string", "Title"),
+				"description":         prop("string", "Description"),
+				"design":              prop("string", "Design"),
+				"acceptance_criteria": prop("string", "Acceptance criteria"),
+				"notes":               prop("string", "Notes"),
+				"status":              statusSchema,
+				"priority":            prioritySchema,
+				"issue_type":          typeSchema,
+				"assignee":            prop("string", "Assignee, or empty to unassign"),
+				"external_ref":        prop("string", "Reference in another system"),
+			}),
+		}, "id", "updates"),
+	},
+	{
+		Name:        "close_issue",
+		Description: "Close an issue as done, with the reason.",
+		InputSchema: object(map[string]schema{
+			"id":     issueIDSchema,
+			"reason": prop("string", "Why it is closed, e.g. what was done"),
+		}, "id"),
+	},
+	{
+		Name:        "add_dependency",
+		Description: "Record that issue_id depends on depends_on_id. With the default type, blocks, issue_id isn't ready until depends_on_id is closed.",
+		InputSchema: object(map[string]schema{
+			"issue_id":      issueIDSchema,
+			"depends_on_id": issueIDSchema,
+			"type":          prop("string", "Dependency type: blocks (default), parent-child, related, discovered-from, ..."),
+		}, "issue_id", "depends_on_id"),
+	},
+	{
+		Name:        "remove_dependency",
+		Description: "Remove the dependency of issue_id on depends_on_id.",
+		InputSchema: object(map[string]schema{
+			"issue_id":      issueIDSchema,
+			"depends_on_id": issueIDSchema,
+		}, "issue_id", "depends_on_id"),
+	},
+	{
+		Name:        "get_dependency_tree",
+		Description: "Show what an issue depends on, transitively, or with reverse what depends on it.",
+		InputSchema: object(map[string]schema{
+			"issue_id":  issueIDSchema,
+			"max_depth": schema{"type": "integer", "minimum": 1, "description": "Depth limit (default 50)"},
+			"reverse":   prop("boolean", "Show dependents instead of dependencies"),
+		}, "issue_id"),
+	},
+	{
+		Name:        "add_label",
+		Description: "Add a label to an issue.",
+		InputSchema: object(map[string]schema{"issue_id": issueIDSchema, "label": prop("string", "Label")}, "issue_id", "label"),
+	},
+	{
+		Name:        "remove_label",
+		Description: "Remove a label from an issue.",
+		InputSchema: object(map[string]schema{"issue_id": issueIDSchema, "label": prop("string", "Label")}, "issue_id", "label"),
+	},
+	{
+		Name:        "add_issue_comment",
+		Description: "Comment on an issue, e.g. with progress or findings.",
+		InputSchema: object(map[string]schema{"issue_id": issueIDSchema, "text": prop("string", "Comment")}, "issue_id", "text"),
+	},
+	{
+		Name:        "get_issue_comments",
+		Description: "List the comments on an issue, oldest first.",
+		InputSchema: object(map[string]schema{"issue_id": issueIDSchema}, "issue_id"),
+	},
+	{
+		Name:        "get_statistics",
+		Description: "Count issues by status, including how many are ready and blocked.",
+		InputSchema: object(map[string]schema{}),
+	},
+}
+
+// mcpTool is a tool as listed to clients.
+type mcpTool struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	InputSchema schema       `json:"inputSchema"`
+	Annotations mcpToolHints `json:"annotations"`
+}
+
+type mcpToolHints struct {
+	ReadOnly bool `json:"readOnlyHint"`
+}
+
+// mcpContent is a block of a tool result.
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type mcpToolResult struct {
+	Content []mcpContent `json:"content"`
+	IsError bool         `json:"isError,omitempty"`
+}
+
+// NewMCPServer serves tools, calling the methods of s, as a Model Context
+// Protocol server, so AI agents can call them directly. It answers MCP's
+// JSON-RPC methods (initialize, ping, tools/list, tools/call) and is served
+// like any other server; MCP's notifications need no reply and are ignored.
+//
+// Only the given tools can be called, and none that write when s is
+// read-only. A tool's result is returned as JSON text. Errors from the
+// method, validation included, are tool results with isError set, which
+// MCP clients show to the model, rather than protocol errors.
+func NewMCPServer(s *Server, tools []Tool, name, version string) *Server {
+	offered := make(map[string]bool, len(tools))
+	var listed []mcpTool
+	for _, tool := range tools {
+		method, ok := s.methods[tool.Name]
+		if !ok || (s.readOnly && method.Write) {
+			continue
+		}
+		offered[tool.Name] = true
+		listed = append(listed, mcpTool{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: tool.InputSchema,
+			Annotations: mcpToolHints{ReadOnly: !method.Write},
+		})
+	}
+
+	return NewServer(map[string]Method{
+		"initialize": {Call: func(_ context.Context, params json.RawMessage) (interface{}, error) {
+			var p struct {
+				ProtocolVersion string `json:"protocolVersion"`
+			}
+			_ = json.Unmarshal(params, &p)
+			protocol := MCPProtocolVersion
+			if slices.Contains(mcpProtocolVersions, p.ProtocolVersion) {
+				protocol = p.ProtocolVersion
+			}
+			return map[string]interface{}{
+				"protocolVersion": protocol,
+				"capabilities":    map[string]interface{}{"tools": map[string]bool{"listChanged": false}},
+				"serverInfo":      map[string]string{"name": name, "version": version},
+			}, nil
+		}},
+		"ping": {Call: func(context.Context, json.RawMessage) (interface{}, error) {
+			return struct{}{}, nil
+		}},
+		"tools/list": {Call: func(context.Context, json.RawMessage) (interface{}, error) {
+			return map[string]interface{}{"tools": listed}, nil
+		}},
+		"tools/call": {Call: func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+			var p struct {
+				Name      string          `json:"name"`
+				Arguments json.RawMessage `json:"arguments"`
+			}
+			if err := json.Unmarshal(params, &p); err != nil {
+				return nil, &Error{Code: CodeInvalidParams, Message: "invalid params: " + err.Error()}
+			}
+			if !offered[p.Name] {
+				return nil, &Error{Code: CodeInvalidParams, Message: "unknown tool: " + p.Name}
+			}
+			result, rpcErr := s.Call(ctx, p.Name, p.Arguments)
+			if rpcErr != nil {
+				return mcpToolResult{Content: []mcpContent{{Type: "text", Text: rpcErr.Message}}, IsError: true}, nil
+			}
+			text, err := json.Marshal(result)
+			if err != nil {
+				return nil, err
+			}
+			return mcpToolResult{Content: []mcpContent{{Type: "text", Text: string(text)}}}, nil
+		}},
+	}, false)
+}
@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// mcpCall sends requests to an MCP server over a fakeStore and returns the
+// decoded responses.
+func mcpCall(t *testing.T, readOnly bool, input ...string) (*fakeStore, []Response) {
+	t.Helper()
+	store := &fakeStore{issues: map[string]*types.Issue{"bd-1": {ID: "bd-1", Title: "First"}}}
+	server := NewMCPServer(NewServer(StorageMethods(store, "tester"), readOnly), StorageTools, "beads", "1.2.3")
+	var out bytes.Buffer
+	if err := server.Serve(context.Background(), strings.NewReader(strings.Join(input, "\n")), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	var replies []Response
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var r Response
+		if err := dec.Decode(&r); err != nil {
+			t.Fatal(err)
+		}
+		replies = append(replies, r)
+	}
+	return store, replies
+}
+
+// toolText returns the text and error flag of a tools/call result.
+func toolText(t *testing.T, r Response) (string, bool) {
+	t.Helper()
+	if r.Error != nil {
+		t.Fatalf("tools/call %s failed: %s", r.ID, r.Error.Message)
+	}
+	data, _ := json.Marshal(r.Result)
+	var result mcpToolResult
+	if err := json.Unmarshal(data, &result); err != nil || len(result.Content) != 1 {
+		t.Fatalf("tools/call %s result = %s", r.ID, data)
+	}
+	return result.Content[0].Text, result.IsError
+}
+
+func TestMCPServer(t *testing.T) {
+	store, replies := mcpCall(t, false,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"0"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"get_issue","arguments":{"id":"bd-1"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"get_issue","arguments":{"id":"bd-404"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"create_issue","arguments":{"issue":{"title":"New","labels":["ui"]}}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"create_issue","arguments":{"issue":{"title":"Bad","priority":7}}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"set_config","arguments":{"key":"k","value":"v"}}}`,
+		`{"jsonrpc":"2.0","id":7,"method":"ping"}`,
+	)
+	if len(replies) != 7 {
+		t.Fatalf("got %d responses, want 7 (none for the notification)", len(replies))
+	}
+
+	initialized, _ := json.Marshal(replies[0].Result)
+	if !strings.Contains(string(initialized), `"protocolVersion":"2025-03-26"`) || !strings.Contains(string(initialized), `"serverInfo":{"name":"beads","version":"1.2.3"}`) {
+		t.Errorf("initialize = %s", initialized)
+	}
+
+	if text, isErr := toolText(t, replies[1]); isErr || !strings.HasPrefix(text, `{"id":"bd-1","title":"First"`) {
+		t.Errorf("get_issue bd-1 = %s (error %v)", text, isErr)
+	}
+	if text, isErr := toolText(t, replies[2]); !isErr || text != "not found: issue bd-404" {
+		t.Errorf("get_issue bd-404 = %s (error %v), want a tool error", text, isErr)
+	}
+
+	// bd create's defaults apply, and labels are added
+	if _, isErr := toolText(t, replies[3]); isErr {
+		t.Errorf("create_issue failed: %v", replies[3].Result)
+	}
+	created := store.issues["bd-2"]
+	if created == nil || created.Status != types.StatusOpen || created.IssueType != types.TypeTask || created.Priority != 2 {
+		t.Errorf("created issue = %+v, want open task at priority 2", created)
+	}
+	if len(store.labels) != 1 || store.labels[0] != "bd-2:ui" {
+		t.Errorf("labels = %v, want bd-2:ui", store.labels)
+	}
+	if text, isErr := toolText(t, replies[4]); !isErr || !strings.Contains(text, "priority must be between 0 and 4") {
+		t.Errorf("invalid create_issue = %s (error %v), want a validation error", text, isErr)
+	}
+
+	// Only the offered tools can be called
+	if replies[5].Error == nil || replies[5].Error.Code != CodeInvalidParams {
+		t.Errorf("set_config = %+v, want unknown tool", replies[5])
+	}
+	if replies[6].Error != nil {
+		t.Errorf("ping failed: %s", replies[6].Error.Message)
+	}
+}
+
+func TestMCPToolsList(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		_, replies := mcpCall(t, readOnly, `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+		data, _ := json.Marshal(replies[0].Result)
+		var result struct{ Tools []mcpTool }
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatal(err)
+		}
+		names := map[string]mcpTool{}
+		for _, tool := range result.Tools {
+			names[tool.Name] = tool
+		}
+		if tool, ok := names["get_ready_work"]; !ok || !tool.Annotations.ReadOnly || tool.InputSchema["type"] != "object" {
+			t.Errorf("readOnly=%v: get_ready_work = %+v", readOnly, tool)
+		}
+		if _, ok := names["create_issue"]; ok == readOnly {
+			t.Errorf("readOnly=%v: create_issue listed = %v", readOnly, ok)
+		}
+	}
+
+	// Every tool names a storage method.
+	methods := StorageMethods(&fakeStore{}, "tester")
+	for _, tool := range StorageTools {
+		if _, ok := methods[tool.Name]; !ok {
+			t.Errorf("tool %s has no method", tool.Name)
+		}
+	}
+}
@@ -0,0 +1,440 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Params shared by several methods.
+type (
+	idParams struct {
+		ID string `json:"id"`
+	}
+	issueIDParams struct {
+		IssueID string `json:"issue_id"`
+	}
+	labelParams struct {
+		IssueID string `json:"issue_id"`
+		Label   string `json:"label"`
+	}
+	configParams struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}
+)
+
+// IssueFilter is the search_issues filter: a subset of types.IssueFilter
+// with snake_case fields.
+type IssueFilter struct {
+	Status        *types.Status    `json:"status,omitempty"`
+	ExcludeStatus []types.Status   `json:"exclude_status,omitempty"`
+	Priority      *int             `json:"priority,omitempty"`
+	IssueType     *types.IssueType `json:"issue_type,omitempty"`
+	Assignee      *string          `json:"assignee,omitempty"`
+	NoAssignee    bool             `json:"no_assignee,omitempty"`
+	Labels        []string         `json:"labels,omitempty"`
+	LabelsAny     []string         `json:"labels_any,omitempty"`
+	IDs           []string         `json:"ids,omitempty"`
+	ParentID      *string          `json:"parent_id,omitempty"`
+	TitleContains string           `json:"title_contains,omitempty"`
+	FullText      string           `json:"full_text,omitempty"`
+	CreatedAfter  *time.Time       `json:"created_after,omitempty"`
+	UpdatedAfter  *time.Time       `json:"updated_after,omitempty"`
+	Limit         int              `json:"limit,omitempty"`
+}
+
+func (f IssueFilter) toTypes() types.IssueFilter {
+	return types.IssueFilter{
+		Status:        f.Status,
+		ExcludeStatus: f.ExcludeStatus,
+		Priority:      f.Priority,
+		IssueType:     f.IssueType,
+		Assignee:      f.Assignee,
+		NoAssignee:    f.NoAssignee,
+		Labels:        f.Labels,
+		LabelsAny:     f.LabelsAny,
+		IDs:           f.IDs,
+		ParentID:      f.ParentID,
+		TitleContains: f.TitleContains,
+		FullText:      f.FullText,
+		CreatedAfter:  f.CreatedAfter,
+		UpdatedAfter:  f.UpdatedAfter,
+		Limit:         f.Limit,
+	}
+}
+
+// WorkFilter is the get_ready_work and get_blocked_issues filter: a subset
+// of types.WorkFilter with snake_case fields.
+type WorkFilter struct {
+	Status          types.Status     `json:"status,omitempty"`
+	Type            string           `json:"type,omitempty"`
+	Priority        *int             `json:"priority,omitempty"`
+	Assignee        *string          `json:"assignee,omitempty"`
+	Unassigned      bool             `json:"unassigned,omitempty"`
+	Labels          []string         `json:"labels,omitempty"`
+	LabelsAny       []string         `json:"labels_any,omitempty"`
+	ParentID        *string          `json:"parent_id,omitempty"`
+	SortPolicy      types.SortPolicy `json:"sort_policy,omitempty"`
+	Fairness        types.Fairness   `json:"fairness,omitempty"`
+	IncludeDeferred bool             `json:"include_deferred,omitempty"`
+	Limit           int              `json:"limit,omitempty"`
+}
+
+func (f WorkFilter) toTypes() types.WorkFilter {
+	return types.WorkFilter{
+		Status:          f.Status,
+		Type:            f.Type,
+		Priority:        f.Priority,
+		Assignee:        f.Assignee,
+		Unassigned:      f.Unassigned,
+		Labels:          f.Labels,
+		LabelsAny:       f.LabelsAny,
+		ParentID:        f.ParentID,
+		SortPolicy:      f.SortPolicy,
+		Fairness:        f.Fairness,
+		IncludeDeferred: f.IncludeDeferred,
+		Limit:           f.Limit,
+	}
+}
+
+// StorageMethods exposes the storage interface as methods named after it
+// in snake_case (get_issue, search_issues, ...). Writes are attributed to
+// actor and checked against the same write policies as bd's commands.
+// Methods that return nothing return null; lists are never null.
+func StorageMethods(s storage.Storage, actor string) map[string]Method {
+	return map[string]Method{
+		// Issues
+		"create_issue": write(func(ctx context.Context, p struct {
+			Issue json.RawMessage `json:"issue"`
+		}) (interface{}, error) {
+			issue, err := newIssue(p.Issue)
+			if err != nil {
+				return nil, err
+			}
+			if issue == nil {
+				return nil, missing("issue")
+			}
+			if err := createIssues(ctx, s, []*types.Issue{issue}, actor); err != nil {
+				return nil, err
+			}
+			return s.GetIssue(ctx, issue.ID)
+		}),
+		"create_issues": write(func(ctx context.Context, p struct {
+			Issues []json.RawMessage `json:"issues"`
+		}) (interface{}, error) {
+			if len(p.Issues) == 0 {
+				return nil, missing("issues")
+			}
+			issues := make([]*types.Issue, 0, len(p.Issues))
+			for _, raw := range p.Issues {
+				issue, err := newIssue(raw)
+				if err != nil {
+					return nil, err
+				}
+				if issue == nil {
+					return nil, missing("issues")
+				}
+				issues = append(issues, issue)
+			}
+			if err := createIssues(ctx, s, issues, actor); err != nil {
+				return nil, err
+			}
+			return issues, nil
+		}),
+		"get_issue": read(func(ctx context.Context, p idParams) (interface{}, error) {
+			if p.ID == "" {
+				return nil, missing("id")
+			}
+			return s.GetIssue(ctx, p.ID)
+		}),
+		"get_issue_by_external_ref": read(func(ctx context.Context, p struct {
+			ExternalRef string `json:"external_ref"`
+		}) (interface{}, error) {
+			if p.ExternalRef == "" {
+				return nil, missing("external_ref")
+			}
+			return s.GetIssueByExternalRef(ctx, p.ExternalRef)
+		}),
+		"get_issues_by_ids": read(func(ctx context.Context, p struct {
+			IDs []string `json:"ids"`
+		}) (interface{}, error) {
+			return list(s.GetIssuesByIDs(ctx, p.IDs))
+		}),
+		"update_issue": write(func(ctx context.Context, p struct {
+			ID      string                 `json:"id"`
+			Updates map[string]interface{} `json:"updates"`
+		}) (interface{}, error) {
+			if p.ID == "" {
+				return nil, missing("id")
+			}
+			if len(p.Updates) == 0 {
+				return nil, missing("updates")
+			}
+			issue, err := s.GetIssue(ctx, p.ID)
+			if err != nil {
+				return nil, err
+			}
+			label, err := checkUpdate(issue, p.Updates)
+			if err != nil {
+				return nil, err
+			}
+			// The quarantine label goes on first, so flagged text is never stored without it
+			if label != "" {
+				if err := s.AddLabel(ctx, p.ID, label, actor); err != nil {
+					return nil, err
+				}
+			}
+			if err := s.UpdateIssue(ctx, p.ID, p.Updates, actor); err != nil {
+				return nil, err
+			}
+			return s.GetIssue(ctx, p.ID)
+		}),
+		"close_issue": write(func(ctx context.Context, p struct {
+			ID      string `json:"id"`
+			Reason  string `json:"reason"`
+			Session string `json:"session"`
+		}) (interface{}, error) {
+			if p.ID == "" {
+				return nil, missing("id")
+			}
+			issue, err := s.GetIssue(ctx, p.ID)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkClose(issue, p.Reason); err != nil {
+				return nil, err
+			}
+			if err := s.CloseIssue(ctx, p.ID, p.Reason, actor, p.Session); err != nil {
+				return nil, err
+			}
+			return s.GetIssue(ctx, p.ID)
+		}),
+		"delete_issue": write(func(ctx context.Context, p idParams) (interface{}, error) {
+			if p.ID == "" {
+				return nil, missing("id")
+			}
+			return nil, s.DeleteIssue(ctx, p.ID)
+		}),
+		"search_issues": read(func(ctx context.Context, p struct {
+			Query  string      `json:"query"`
+			Filter IssueFilter `json:"filter"`
+		}) (interface{}, error) {
+			return list(s.SearchIssues(ctx, p.Query, p.Filter.toTypes()))
+		}),
+
+		// Dependencies
+		"add_dependency": write(func(ctx context.Context, p struct {
+			IssueID     string               `json:"issue_id"`
+			DependsOnID string               `json:"depends_on_id"`
+			Type        types.DependencyType `json:"type"`
+		}) (interface{}, error) {
+			if p.IssueID == "" || p.DependsOnID == "" {
+				return nil, missing("issue_id and depends_on_id")
+			}
+			if p.Type == "" {
+				p.Type = types.DepBlocks
+			}
+			return nil, s.AddDependency(ctx, &types.Dependency{
+				IssueID:     p.IssueID,
+				DependsOnID: p.DependsOnID,
+				Type:        p.Type,
+				CreatedAt:   time.Now(),
+				CreatedBy:   actor,
+			}, actor)
+		}),
+		"remove_dependency": write(func(ctx context.Context, p struct {
+			IssueID     string `json:"issue_id"`
+			DependsOnID string `json:"depends_on_id"`
+		}) (interface{}, error) {
+			if p.IssueID == "" || p.DependsOnID == "" {
+				return nil, missing("issue_id and depends_on_id")
+			}
+			return nil, s.RemoveDependency(ctx, p.IssueID, p.DependsOnID, actor)
+		}),
+		"get_dependencies": read(func(ctx context.Context, p issueIDParams) (interface{}, error) {
+			return list(s.GetDependencies(ctx, p.IssueID))
+		}),
+		"get_dependents": read(func(ctx context.Context, p issueIDParams) (interface{}, error) {
+			return list(s.GetDependents(ctx, p.IssueID))
+		}),
+		"get_dependencies_with_metadata": read(func(ctx context.Context, p issueIDParams) (interface{}, error) {
+			return list(s.GetDependenciesWithMetadata(ctx, p.IssueID))
+		}),
+		"get_dependents_with_metadata": read(func(ctx context.Context, p issueIDParams) (interface{}, error) {
+			return list(s.GetDependentsWithMetadata(ctx, p.IssueID))
+		}),
+		"get_dependency_tree": read(func(ctx context.Context, p struct {
+			IssueID      string `json:"issue_id"`
+			MaxDepth     int    `json:"max_depth"`
+			ShowAllPaths bool   `json:"show_all_paths"`
+			Reverse      bool   `json:"reverse"`
+		}) (interface{}, error) {
+			if p.IssueID == "" {
+				return nil, missing("issue_id")
+			}
+			if p.MaxDepth <= 0 {
+				p.MaxDepth = 50
+			}
+			return list(s.GetDependencyTree(ctx, p.IssueID, p.MaxDepth, p.ShowAllPaths, p.Reverse))
+		}),
+
+		// Labels
+		"add_label": write(func(ctx context.Context, p labelParams) (interface{}, error) {
+			if p.IssueID == "" || p.Label == "" {
+				return nil, missing("issue_id and label")
+			}
+			return nil, s.AddLabel(ctx, p.IssueID, p.Label, actor)
+		}),
+		"remove_label": write(func(ctx context.Context, p labelParams) (interface{}, error) {
+			if p.IssueID == "" || p.Label == "" {
+				return nil, missing("issue_id and label")
+			}
+			return nil, s.RemoveLabel(ctx, p.IssueID, p.Label, actor)
+		}),
+		"get_labels": read(func(ctx context.Context, p issueIDParams) (interface{}, error) {
+			return list(s.GetLabels(ctx, p.IssueID))
+		}),
+		"get_issues_by_label": read(func(ctx context.Context, p labelParams) (interface{}, error) {
+			if p.Label == "" {
+				return nil, missing("label")
+			}
+			return list(s.GetIssuesByLabel(ctx, p.Label))
+		}),
+
+		// Work queries
+		"get_ready_work": read(func(ctx context.Context, p struct {
+			Filter WorkFilter `json:"filter"`
+		}) (interface{}, error) {
+			return list(s.GetReadyWork(ctx, p.Filter.toTypes()))
+		}),
+		"get_blocked_issues": read(func(ctx context.Context, p struct {
+			Filter WorkFilter `json:"filter"`
+		}) (interface{}, error) {
+			return list(s.GetBlockedIssues(ctx, p.Filter.toTypes()))
+		}),
+		"get_blocking_chain": read(func(ctx context.Context, p issueIDParams) (interface{}, error) {
+			if p.IssueID == "" {
+				return nil, missing("issue_id")
+			}
+			return s.GetBlockingChain(ctx, p.IssueID)
+		}),
+		"get_epics_eligible_for_closure": read(func(ctx context.Context, _ struct{}) (interface{}, error) {
+			return list(s.GetEpicsEligibleForClosure(ctx))
+		}),
+
+		// Comments and events
+		"add_issue_comment": write(func(ctx context.Context, p struct {
+			IssueID string `json:"issue_id"`
+			Text    string `json:"text"`
+		}) (interface{}, error) {
+			if p.IssueID == "" || p.Text == "" {
+				return nil, missing("issue_id and text")
+			}
+			label, err := scanText(map[string]string{"comment": p.Text})
+			if err != nil {
+				return nil, err
+			}
+			// The quarantine label goes on first, so flagged text is never stored without it
+			if label != "" {
+				if err := s.AddLabel(ctx, p.IssueID, label, actor); err != nil {
+					return nil, err
+				}
+			}
+			return s.AddIssueComment(ctx, p.IssueID, actor, p.Text)
+		}),
+		"get_issue_comments": read(func(ctx context.Context, p issueIDParams) (interface{}, error) {
+			return list(s.GetIssueComments(ctx, p.IssueID))
+		}),
+		"get_events": read(func(ctx context.Context, p struct {
+			IssueID string `json:"issue_id"`
+			Limit   int    `json:"limit"`
+		}) (interface{}, error) {
+			return list(s.GetEvents(ctx, p.IssueID, p.Limit))
+		}),
+		"get_all_events_since": read(func(ctx context.Context, p struct {
+			SinceID int64 `json:"since_id"`
+		}) (interface{}, error) {
+			return list(s.GetAllEventsSince(ctx, p.SinceID))
+		}),
+
+		// Statistics
+		"get_statistics": read(func(ctx context.Context, _ struct{}) (interface{}, error) {
+			return s.GetStatistics(ctx)
+		}),
+
+		// Configuration
+		"set_config": write(func(ctx context.Context, p configParams) (interface{}, error) {
+			if p.Key == "" {
+				return nil, missing("key")
+			}
+			return nil, s.SetConfig(ctx, p.Key, p.Value)
+		}),
+		"get_config": read(func(ctx context.Context, p configParams) (interface{}, error) {
+			if p.Key == "" {
+				return nil, missing("key")
+			}
+			return s.GetConfig(ctx, p.Key)
+		}),
+		"get_all_config": read(func(ctx context.Context, _ struct{}) (interface{}, error) {
+			return s.GetAllConfig(ctx)
+		}),
+	}
+}
+
+// newIssue decodes an issue to create, filling in what bd create defaults
+// when it's omitted: status open, type task, priority 2. It returns nil for
+// a missing issue.
+func newIssue(raw json.RawMessage) (*types.Issue, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var issue types.Issue
+	if err := decodeParams(raw, &issue); err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err == nil {
+		if _, ok := fields["priority"]; !ok {
+			issue.Priority = 2
+		}
+	}
+	issue.SetDefaults()
+	return &issue, nil
+}
+
+// read wraps a method that takes params of type P.
+func read[P any](call func(ctx context.Context, p P) (interface{}, error)) Method {
+	return Method{Call: func(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+		var p P
+		if err := decodeParams(raw, &p); err != nil {
+			return nil, err
+		}
+		return call(ctx, p)
+	}}
+}
+
+// write is read for methods that modify the database.
+func write[P any](call func(ctx context.Context, p P) (interface{}, error)) Method {
+	m := read(call)
+	m.Write = true
+	return m
+}
+
+// list returns items as a result, turning a nil slice into an empty one.
+func list[T any](items []T, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []T{}
+	}
+	return items, nil
+}
+
+func missing(what string) error {
+	return &Error{Code: CodeInvalidParams, Message: "invalid params: " + what + " required"}
+}
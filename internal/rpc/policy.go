@@ -0,0 +1,160 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/typeschema"
+	"github.com/steveyegge/beads/internal/writepolicy"
+)
+
+// The write methods apply the same policies as bd's commands (see
+// internal/writepolicy). Checks in warn mode let the write through without
+// telling the client; there is no channel for warnings.
+
+// customizer is implemented by stores that allow custom statuses and types.
+type customizer interface {
+	GetCustomStatuses(ctx context.Context) ([]string, error)
+	GetCustomTypes(ctx context.Context) ([]string, error)
+}
+
+// refused wraps an error from a policy check as a validation error.
+func refused(err error) error {
+	return fmt.Errorf("%w: %w", storage.ErrValidation, err)
+}
+
+// createIssues checks issues, then creates them and their labels in one
+// transaction, so a label that can't be added doesn't leave an issue
+// behind without it.
+func createIssues(ctx context.Context, s storage.Storage, issues []*types.Issue, actor string) error {
+	var customStatuses, customTypes []string
+	if c, ok := s.(customizer); ok {
+		var err error
+		if customStatuses, err = c.GetCustomStatuses(ctx); err != nil {
+			return err
+		}
+		if customTypes, err = c.GetCustomTypes(ctx); err != nil {
+			return err
+		}
+	}
+	for _, issue := range issues {
+		// The transaction's CreateIssue leaves validation to its callers
+		if err := issue.ValidateWithCustom(customStatuses, customTypes); err != nil {
+			return refused(err)
+		}
+		if err := checkNewIssue(issue); err != nil {
+			return err
+		}
+	}
+	return s.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		for _, issue := range issues {
+			if err := tx.CreateIssue(ctx, issue, actor); err != nil {
+				return err
+			}
+			for _, label := range issue.Labels {
+				if err := tx.AddLabel(ctx, issue.ID, label, actor); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// checkNewIssue scans a new issue's text for secrets and checks its
+// type-specific fields. A quarantine label is added to issue.Labels.
+func checkNewIssue(issue *types.Issue) error {
+	fields := map[string]string{
+		"title": issue.Title, "description": issue.Description, "design": issue.Design,
+		"acceptance_criteria": issue.AcceptanceCriteria, "notes": issue.Notes,
+	}
+	label, err := scanText(fields)
+	if err != nil {
+		return err
+	}
+	if label != "" {
+		issue.Labels = append(issue.Labels, label)
+	}
+	if _, err := writepolicy.CheckTypeFields(issue.IssueType, typeschema.FromMetadata(issue.Metadata)); err != nil {
+		return refused(err)
+	}
+	return nil
+}
+
+// checkUpdate checks updates to issue: new text is scanned for secrets,
+// type-specific fields are checked when the type or metadata changes, and
+// moving the issue to in_progress is held back during quiet hours. It
+// returns the label to add when the text should be quarantined, or "".
+func checkUpdate(issue *types.Issue, updates map[string]interface{}) (string, error) {
+	fields := make(map[string]string)
+	for _, key := range []string{"title", "description", "design", "acceptance_criteria", "notes"} {
+		if v, ok := updates[key].(string); ok {
+			fields[key] = v
+		}
+	}
+	label, err := scanText(fields)
+	if err != nil {
+		return "", err
+	}
+
+	issueType, typeChanged := updates["issue_type"].(string)
+	metadata, metadataChanged := updates["metadata"]
+	if typeChanged || metadataChanged {
+		if !typeChanged {
+			issueType = string(issue.IssueType)
+		}
+		values := typeschema.FromMetadata(issue.Metadata)
+		if metadataChanged {
+			md, err := storage.NormalizeMetadataValue(metadata)
+			if err != nil {
+				return "", refused(fmt.Errorf("invalid metadata: %w", err))
+			}
+			values = typeschema.FromMetadata(json.RawMessage(md))
+		}
+		if _, err := writepolicy.CheckTypeFields(types.IssueType(issueType), values); err != nil {
+			return "", refused(err)
+		}
+	}
+
+	if updates["status"] == string(types.StatusInProgress) {
+		windows, err := writepolicy.QuietHours()
+		if err != nil {
+			return "", err
+		}
+		if err := writepolicy.CheckQuietHours(windows, issue, time.Now()); err != nil {
+			return "", refused(err)
+		}
+	}
+	return label, nil
+}
+
+// checkClose checks that issue can be closed with reason: its acceptance
+// criteria must pass and, for the types in close.require-evidence-types,
+// evidence is needed, which only bd close can attach.
+func checkClose(issue *types.Issue, reason string) error {
+	if _, err := writepolicy.CheckAcceptanceOnClose(issue); err != nil {
+		return refused(fmt.Errorf("cannot close %s: %w", issue.ID, err))
+	}
+	rules, err := writepolicy.CloseRules()
+	if err != nil {
+		return err
+	}
+	if err := writepolicy.CheckCloseEvidence(issue, reason, rules, false); err != nil {
+		return refused(fmt.Errorf("cannot close %s: %w (close it with bd close --evidence-url or --evidence-file)", issue.ID, err))
+	}
+	return nil
+}
+
+// scanText scans fields for secrets per scan.mode, returning the label to
+// add when the text should be quarantined, or "".
+func scanText(fields map[string]string) (string, error) {
+	scan, err := writepolicy.ScanText(fields)
+	if err != nil {
+		return "", refused(err)
+	}
+	return scan.Label, nil
+}
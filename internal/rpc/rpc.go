@@ -0,0 +1,258 @@
+// Package rpc serves JSON-RPC 2.0 over a stream, one message per line, so
+// programs in any language can drive beads as a subprocess through a
+// stable protocol instead of parsing CLI output.
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// Version is the JSON-RPC version spoken.
+const Version = "2.0"
+
+// JSON-RPC error codes. CodeStorage is used for errors returned by the
+// storage layer; their Data carries an ErrorData.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	CodeStorage        = -32000
+	CodeReadOnly       = -32001
+)
+
+// maxMessageSize bounds a single request line.
+const maxMessageSize = 64 << 20
+
+// Request is a JSON-RPC request; a request without an ID is a notification
+// and gets no response.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a JSON-RPC response, carrying either Result or Error.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error object.
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ErrorData classifies a storage error so clients can handle it without
+// matching on messages. Kind is one of not_found, already_claimed, cycle,
+// validation, prefix_mismatch, not_initialized, permission_denied, or error.
+type ErrorData struct {
+	Kind string `json:"kind"`
+}
+
+// Method is one callable method. Write methods are refused by read-only
+// servers.
+type Method struct {
+	Write bool
+	Call  func(ctx context.Context, params json.RawMessage) (interface{}, error)
+}
+
+// Server dispatches requests to its methods.
+type Server struct {
+	methods  map[string]Method
+	readOnly bool
+}
+
+// NewServer creates a server for methods. A read-only server refuses write
+// methods.
+func NewServer(methods map[string]Method, readOnly bool) *Server {
+	s := &Server{methods: make(map[string]Method, len(methods)+1), readOnly: readOnly}
+	for name, m := range methods {
+		s.methods[name] = m
+	}
+	if _, ok := methods["methods"]; !ok {
+		s.methods["methods"] = Method{Call: func(context.Context, json.RawMessage) (interface{}, error) {
+			return s.methodNames(), nil
+		}}
+	}
+	return s
+}
+
+// methodNames lists the available methods, sorted.
+func (s *Server) methodNames() []string {
+	names := make([]string, 0, len(s.methods))
+	for name, m := range s.methods {
+		if !(s.readOnly && m.Write) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Serve reads requests from r, one JSON value per line, and writes each
+// response to w on its own line, until r is exhausted or ctx is done.
+// Batches (JSON arrays of requests) are answered with an array.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+	enc := json.NewEncoder(w)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if reply := s.handleMessage(ctx, line); reply != nil {
+			if err := enc.Encode(reply); err != nil {
+				return fmt.Errorf("writing response: %w", err)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// handleMessage answers one line: a request or a batch. It returns nil
+// when nothing should be written back.
+func (s *Server) handleMessage(ctx context.Context, msg []byte) interface{} {
+	if msg[0] != '[' {
+		if reply := s.handleRequest(ctx, msg); reply != nil {
+			return reply
+		}
+		return nil // Notification
+	}
+	var batch []json.RawMessage
+	if err := json.Unmarshal(msg, &batch); err != nil {
+		return errorResponse(nil, &Error{Code: CodeParseError, Message: "parse error: " + err.Error()})
+	}
+	if len(batch) == 0 {
+		return errorResponse(nil, &Error{Code: CodeInvalidRequest, Message: "empty batch"})
+	}
+	var replies []*Response
+	for _, raw := range batch {
+		if reply := s.handleRequest(ctx, raw); reply != nil {
+			replies = append(replies, reply)
+		}
+	}
+	if len(replies) == 0 {
+		return nil
+	}
+	return replies
+}
+
+func (s *Server) handleRequest(ctx context.Context, raw json.RawMessage) *Response {
+	var req Request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return errorResponse(nil, &Error{Code: CodeParseError, Message: "parse error: " + err.Error()})
+		}
+		return errorResponse(nil, &Error{Code: CodeInvalidRequest, Message: "invalid request: " + err.Error()})
+	}
+	notification := len(req.ID) == 0
+	reply := func(result interface{}, err *Error) *Response {
+		if notification {
+			return nil
+		}
+		if err != nil {
+			return errorResponse(req.ID, err)
+		}
+		return &Response{JSONRPC: Version, ID: req.ID, Result: result}
+	}
+	if req.JSONRPC != Version || req.Method == "" {
+		return errorResponse(req.ID, &Error{Code: CodeInvalidRequest, Message: `invalid request: need "jsonrpc": "2.0" and a method`})
+	}
+
+	return reply(s.Call(ctx, req.Method, req.Params))
+}
+
+// Call runs one method, as a request naming it would. Methods with nothing
+// to return succeed with a null result.
+func (s *Server) Call(ctx context.Context, name string, params json.RawMessage) (interface{}, *Error) {
+	method, ok := s.methods[name]
+	if !ok {
+		return nil, &Error{Code: CodeMethodNotFound, Message: "method not found: " + name}
+	}
+	if s.readOnly && method.Write {
+		return nil, &Error{Code: CodeReadOnly, Message: name + " is not allowed in read-only mode"}
+	}
+	result, err := method.Call(ctx, params)
+	if err != nil {
+		return nil, toError(err)
+	}
+	if result == nil {
+		// A null result, which omitempty would otherwise drop
+		result = json.RawMessage("null")
+	}
+	return result, nil
+}
+
+func errorResponse(id json.RawMessage, err *Error) *Response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &Response{JSONRPC: Version, ID: id, Error: err}
+}
+
+// toError converts a method's error to a JSON-RPC error.
+func toError(err error) *Error {
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+	kind := "error"
+	for _, k := range []struct {
+		err  error
+		kind string
+	}{
+		{storage.ErrNotFound, "not_found"},
+		{storage.ErrAlreadyClaimed, "already_claimed"},
+		{storage.ErrCycle, "cycle"},
+		{storage.ErrValidation, "validation"},
+		{storage.ErrPrefixMismatch, "prefix_mismatch"},
+		{storage.ErrNotInitialized, "not_initialized"},
+		{permissions.ErrDenied, "permission_denied"},
+	} {
+		if errors.Is(err, k.err) {
+			kind = k.kind
+			break
+		}
+	}
+	return &Error{Code: CodeStorage, Message: err.Error(), Data: ErrorData{Kind: kind}}
+}
+
+// decodeParams decodes params into v, rejecting unknown fields so typos
+// fail loudly. Missing params leave v at its zero value.
+func decodeParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(params))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+// Package schedule evaluates the scheduling semantics of blocks dependencies
+// (finish-to-start, start-to-start, finish-to-finish, each with an optional
+// lag or lead) for readiness, and projects start and finish dates for the
+// timeline report.
+package schedule
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/calendar"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Phase is the point in the dependent's life that a dependency gates.
+type Phase int
+
+const (
+	// Start gates starting work (readiness).
+	Start Phase = iota
+	// Finish gates closing.
+	Finish
+)
+
+// State is what is known about a blocker when evaluating a dependency.
+type State struct {
+	Started       bool
+	Finished      bool
+	StartedAt     time.Time // Zero if unknown
+	FinishedAt    time.Time // Zero if unknown
+	PlannedStart  time.Time // defer_until, anchors leads before the blocker starts
+	PlannedFinish time.Time // due_at, anchors leads before the blocker finishes
+}
+
+// StateOf derives a blocker's state from its issue. startedAt is when work
+// on it began (see the store's StartTimes), zero if unknown.
+func StateOf(issue *types.Issue, startedAt time.Time) State {
+	st := State{StartedAt: startedAt}
+	switch issue.Status {
+	case types.StatusOpen, types.StatusBlocked, types.StatusDeferred:
+	case types.StatusInProgress, types.StatusHooked:
+		st.Started = true
+	default:
+		st.Started, st.Finished = true, true
+	}
+	if issue.ClosedAt != nil {
+		st.FinishedAt = *issue.ClosedAt
+	}
+	if st.Finished && st.StartedAt.IsZero() {
+		st.StartedAt = st.FinishedAt
+	}
+	if issue.DeferUntil != nil {
+		st.PlannedStart = *issue.DeferUntil
+	}
+	if issue.DueAt != nil {
+		st.PlannedFinish = *issue.DueAt
+	}
+	return st
+}
+
+// Satisfied reports whether a dependency on blocker b lets the dependent
+// reach phase at now. When it doesn't, until is when it will, or zero if
+// that depends on the blocker making progress first.
+//
+// Finish-to-finish dependencies don't gate starting; every relation gates
+// finishing, since a dependent can't finish before it could start. A lag
+// counts from the blocker's actual start or finish. A lead (negative lag)
+// counts from the blocker's planned start (defer_until) or finish (due_at)
+// until the actual one happens. Lags count working time on cal, or elapsed
+// time if cal is nil.
+func Satisfied(meta types.ScheduleMeta, b State, phase Phase, now time.Time, cal *calendar.Calendar) (ok bool, until time.Time) {
+	relation := meta.RelationOrDefault()
+	if phase == Start && relation == types.RelationFinishToFinish {
+		return true, time.Time{}
+	}
+	lag := meta.LagDuration()
+
+	happened, anchor, planned := b.Finished, b.FinishedAt, b.PlannedFinish
+	if relation == types.RelationStartToStart {
+		happened, anchor, planned = b.Started, b.StartedAt, b.PlannedStart
+	}
+	if !happened {
+		if lag >= 0 || planned.IsZero() {
+			return false, time.Time{}
+		}
+		anchor = planned
+	}
+	if anchor.IsZero() {
+		return true, time.Time{} // Happened at an unknown time
+	}
+	if at := add(cal, anchor, lag); now.Before(at) {
+		return false, at
+	}
+	return true, time.Time{}
+}
+
+// Link is a blocks dependency: To depends on From.
+type Link struct {
+	From string
+	To   string
+	Meta types.ScheduleMeta
+}
+
+// Projection is an issue's projected schedule.
+type Projection struct {
+	ID        string    `json:"id"`
+	Start     time.Time `json:"start"`
+	Finish    time.Time `json:"finish"`
+	Started   bool      `json:"started"`             // Start is the actual start
+	DrivenBy  string    `json:"driven_by,omitempty"` // Blocker that sets the start, if any
+	Estimated bool      `json:"estimated"`           // False when the issue has no estimate and is projected to take no time
+	LateBy    string    `json:"late_by,omitempty"`   // How far Finish is past the due date
+}
+
+// Project computes start and finish dates for every issue that isn't
+// finished, from estimates, defer dates, and links. Estimates and lags count
+// working time on cal, or elapsed time if cal is nil.
+// Finished issues anchor their dependents at their actual dates. started
+// maps issue IDs to when work began. Links to unknown issues, and links
+// that would close a cycle, are ignored. The result is ordered by start.
+func Project(issues []*types.Issue, started map[string]time.Time, links []Link, now time.Time, cal *calendar.Calendar) []Projection {
+	return ProjectWith(issues, started, links, now, func(*types.Issue) *calendar.Calendar { return cal })
+}
+
+// ProjectWith is Project with a calendar per issue, such as one that skips
+// the days its assignee is away. An issue's estimate and the lags of its
+// incoming links count working time on its own calendar.
+func ProjectWith(issues []*types.Issue, started map[string]time.Time, links []Link, now time.Time, calFor func(*types.Issue) *calendar.Calendar) []Projection {
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	preds := make(map[string][]Link)
+	for _, l := range links {
+		if byID[l.From] != nil && byID[l.To] != nil {
+			preds[l.To] = append(preds[l.To], l)
+		}
+	}
+
+	type times struct{ start, finish time.Time }
+	done := make(map[string]times)
+	visiting := make(map[string]bool)
+	results := make(map[string]*Projection)
+
+	var visit func(id string) (times, bool)
+	visit = func(id string) (times, bool) {
+		if t, ok := done[id]; ok {
+			return t, true
+		}
+		if visiting[id] {
+			return times{}, false
+		}
+		visiting[id] = true
+		defer delete(visiting, id)
+
+		issue := byID[id]
+		cal := calFor(issue)
+		st := StateOf(issue, started[id])
+		if st.Finished {
+			t := times{st.StartedAt, st.FinishedAt}
+			if t.finish.IsZero() {
+				t = times{now, now}
+			}
+			done[id] = t
+			return t, true
+		}
+
+		var dur time.Duration
+		if issue.EstimatedMinutes != nil {
+			dur = time.Duration(*issue.EstimatedMinutes) * time.Minute
+		}
+		p := &Projection{ID: id, Started: st.Started, Estimated: issue.EstimatedMinutes != nil}
+		start := now
+		if cal != nil && !st.Started {
+			start = cal.NextWorkingDay(start)
+		}
+		if st.Started {
+			if !st.StartedAt.IsZero() {
+				start = st.StartedAt
+			}
+		} else if !st.PlannedStart.IsZero() && st.PlannedStart.After(start) {
+			start = st.PlannedStart
+		}
+		// Finish-to-finish links constrain the finish; the others, and
+		// finish-to-finish via the estimate, constrain the start.
+		var finishFloor time.Time
+		for _, l := range preds[id] {
+			pt, ok := visit(l.From)
+			if !ok {
+				continue
+			}
+			lag := l.Meta.LagDuration()
+			var at time.Time
+			switch l.Meta.RelationOrDefault() {
+			case types.RelationStartToStart:
+				at = add(cal, pt.start, lag)
+			case types.RelationFinishToFinish:
+				if ff := add(cal, pt.finish, lag); ff.After(finishFloor) {
+					finishFloor = ff
+				}
+				at = add(cal, add(cal, pt.finish, lag), -dur)
+			default:
+				at = add(cal, pt.finish, lag)
+			}
+			if !st.Started && at.After(start) {
+				start, p.DrivenBy = at, l.From
+			}
+		}
+		finish := add(cal, start, dur)
+		if st.Started && finish.Before(now) {
+			finish = now // Overrunning its estimate
+		}
+		if finishFloor.After(finish) {
+			finish = finishFloor
+		}
+
+		p.Start, p.Finish = start, finish
+		if issue.DueAt != nil && finish.After(*issue.DueAt) {
+			p.LateBy = FormatLag(finish.Sub(*issue.DueAt))
+		}
+		results[id] = p
+		t := times{start, finish}
+		done[id] = t
+		return t, true
+	}
+
+	for _, issue := range issues {
+		visit(issue.ID)
+	}
+
+	out := make([]Projection, 0, len(results))
+	for _, p := range results {
+		out = append(out, *p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Start.Equal(out[j].Start) {
+			return out[i].Start.Before(out[j].Start)
+		}
+		if pi, pj := byID[out[i].ID].Priority, byID[out[j].ID].Priority; pi != pj {
+			return pi < pj
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// add moves t by d of working time on cal, or elapsed time if cal is nil.
+func add(cal *calendar.Calendar, t time.Time, d time.Duration) time.Time {
+	if cal == nil {
+		return t.Add(d)
+	}
+	return cal.AddWorking(t, d)
+}
+
+// FormatLag renders a duration in the units ParseLag accepts, rounded to
+// whole hours: "3d", "1w", "5h", "-2d".
+func FormatLag(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	hours := int64((d + time.Hour/2) / time.Hour)
+	switch {
+	case hours == 0:
+		return "0h"
+	case hours%(7*24) == 0:
+		return sign + strconv.FormatInt(hours/(7*24), 10) + "w"
+	case hours%24 == 0:
+		return sign + strconv.FormatInt(hours/24, 10) + "d"
+	default:
+		return sign + strconv.FormatInt(hours, 10) + "h"
+	}
+}
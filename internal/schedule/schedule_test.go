@@ -0,0 +1,154 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/calendar"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSatisfied(t *testing.T) {
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	open := State{}
+	started := State{Started: true, StartedAt: now.Add(-day)}
+	closed := State{Started: true, Finished: true, StartedAt: now.Add(-3 * day), FinishedAt: now.Add(-day)}
+	due := State{PlannedFinish: now.Add(day)}
+
+	tests := []struct {
+		name  string
+		meta  types.ScheduleMeta
+		b     State
+		phase Phase
+		ok    bool
+		until time.Time
+	}{
+		{"fs open blocker", types.ScheduleMeta{}, open, Start, false, time.Time{}},
+		{"fs closed blocker", types.ScheduleMeta{}, closed, Start, true, time.Time{}},
+		{"fs lag pending", types.ScheduleMeta{Lag: "2d"}, closed, Start, false, now.Add(day)},
+		{"fs lag elapsed", types.ScheduleMeta{Lag: "1d"}, closed, Start, true, time.Time{}},
+		{"fs lead before due", types.ScheduleMeta{Lag: "-2d"}, due, Start, true, time.Time{}},
+		{"fs lead not reached", types.ScheduleMeta{Lag: "-12h"}, due, Start, false, now.Add(12 * time.Hour)},
+		{"fs lead without due date", types.ScheduleMeta{Lag: "-2d"}, open, Start, false, time.Time{}},
+		{"ss not started", types.ScheduleMeta{Relation: types.RelationStartToStart}, open, Start, false, time.Time{}},
+		{"ss started", types.ScheduleMeta{Relation: types.RelationStartToStart}, started, Start, true, time.Time{}},
+		{"ss lag pending", types.ScheduleMeta{Relation: types.RelationStartToStart, Lag: "2d"}, started, Start, false, now.Add(day)},
+		{"ff ignores start", types.ScheduleMeta{Relation: types.RelationFinishToFinish}, open, Start, true, time.Time{}},
+		{"ff gates finish", types.ScheduleMeta{Relation: types.RelationFinishToFinish}, started, Finish, false, time.Time{}},
+		{"ff lag after close", types.ScheduleMeta{Relation: types.RelationFinishToFinish, Lag: "2d"}, closed, Finish, false, now.Add(day)},
+	}
+	for _, tt := range tests {
+		ok, until := Satisfied(tt.meta, tt.b, tt.phase, now, nil)
+		if ok != tt.ok || !until.Equal(tt.until) {
+			t.Errorf("%s: Satisfied = %v, %v; want %v, %v", tt.name, ok, until, tt.ok, tt.until)
+		}
+	}
+}
+
+func TestSatisfiedWorkingCalendar(t *testing.T) {
+	// Blocker closed Friday noon; a 1d lag ends Monday noon, not Saturday
+	friday := time.Date(2026, 5, 8, 12, 0, 0, 0, time.UTC)
+	monday := friday.AddDate(0, 0, 3)
+	closed := State{Started: true, Finished: true, StartedAt: friday, FinishedAt: friday}
+	meta := types.ScheduleMeta{Lag: "1d"}
+	if ok, until := Satisfied(meta, closed, Start, friday.AddDate(0, 0, 2), calendar.Default()); ok || !until.Equal(monday) {
+		t.Errorf("Sunday: Satisfied = %v, %v; want false, %v", ok, until, monday)
+	}
+	if ok, _ := Satisfied(meta, closed, Start, friday.AddDate(0, 0, 2), nil); !ok {
+		t.Error("elapsed lag should be satisfied by Sunday")
+	}
+}
+
+func TestProject(t *testing.T) {
+	now := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	est := func(days int) *int { m := days * 24 * 60; return &m }
+	closedAt := now.Add(-day)
+	due := now.Add(4 * day)
+	issues := []*types.Issue{
+		{ID: "done", Status: types.StatusClosed, ClosedAt: &closedAt},
+		{ID: "a", Status: types.StatusOpen, EstimatedMinutes: est(2)},
+		{ID: "b", Status: types.StatusOpen, EstimatedMinutes: est(1), DueAt: &due},
+		{ID: "c", Status: types.StatusOpen, EstimatedMinutes: est(1)},
+		{ID: "d", Status: types.StatusOpen},
+	}
+	links := []Link{
+		{From: "done", To: "a", Meta: types.ScheduleMeta{Lag: "2d"}},                           // a starts now+1d
+		{From: "a", To: "b", Meta: types.ScheduleMeta{Lag: "1d"}},                              // b starts now+4d, finishes now+5d
+		{From: "a", To: "c", Meta: types.ScheduleMeta{Relation: types.RelationStartToStart}},   // c starts with a
+		{From: "b", To: "d", Meta: types.ScheduleMeta{Relation: types.RelationFinishToFinish}}, // d finishes with b
+		{From: "missing", To: "d"},
+	}
+	got := make(map[string]Projection)
+	var order []string
+	for _, p := range Project(issues, nil, links, now, nil) {
+		got[p.ID] = p
+		order = append(order, p.ID)
+	}
+	if _, ok := got["done"]; ok || len(got) != 4 {
+		t.Fatalf("projected %v", order)
+	}
+	check := func(id string, start, finish time.Duration, drivenBy string) {
+		t.Helper()
+		p := got[id]
+		if !p.Start.Equal(now.Add(start)) || !p.Finish.Equal(now.Add(finish)) || p.DrivenBy != drivenBy {
+			t.Errorf("%s: start %v finish %v driven by %q", id, p.Start.Sub(now), p.Finish.Sub(now), p.DrivenBy)
+		}
+	}
+	check("a", day, 3*day, "done")
+	check("c", day, 2*day, "a")
+	check("b", 4*day, 5*day, "a")
+	check("d", 5*day, 5*day, "b")
+	if got["b"].LateBy != "1d" || got["a"].LateBy != "" {
+		t.Errorf("late: b=%q a=%q", got["b"].LateBy, got["a"].LateBy)
+	}
+	if got["d"].Estimated {
+		t.Error("d has no estimate")
+	}
+	if order[0] != "a" || order[1] != "c" {
+		t.Errorf("order = %v", order)
+	}
+}
+
+func TestFormatLag(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		14 * 24 * time.Hour: "2w",
+		3 * 24 * time.Hour:  "3d",
+		-2 * 24 * time.Hour: "-2d",
+		5 * time.Hour:       "5h",
+		10 * time.Minute:    "0h",
+	} {
+		if got := FormatLag(d); got != want {
+			t.Errorf("FormatLag(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestProjectWithPerIssueCalendar(t *testing.T) {
+	// Monday; the assignee of "away" is off Monday and Tuesday
+	now := time.Date(2026, 5, 11, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	est := 24 * 60
+	issues := []*types.Issue{
+		{ID: "here", Status: types.StatusOpen, EstimatedMinutes: &est},
+		{ID: "away", Status: types.StatusOpen, EstimatedMinutes: &est, Assignee: "alice"},
+	}
+	cal := calendar.Default()
+	aliceCal := cal.WithDaysOff([]string{"2026-05-11", "2026-05-12"})
+	got := make(map[string]Projection)
+	for _, p := range ProjectWith(issues, nil, nil, now, func(issue *types.Issue) *calendar.Calendar {
+		if issue.Assignee == "alice" {
+			return aliceCal
+		}
+		return cal
+	}) {
+		got[p.ID] = p
+	}
+	if p := got["here"]; !p.Start.Equal(now) || !p.Finish.Equal(now.Add(day)) {
+		t.Errorf("here: start %v finish %v", p.Start, p.Finish)
+	}
+	if p := got["away"]; !p.Start.Equal(now.Add(2*day)) || !p.Finish.Equal(now.Add(3*day)) {
+		t.Errorf("away: start %v finish %v, want Wednesday to Thursday", p.Start, p.Finish)
+	}
+}
@@ -0,0 +1,196 @@
+// Package secretscan finds likely credentials and configured PII patterns in
+// issue text, so they can be flagged before they are committed to the
+// database and redacted from shareable exports.
+package secretscan
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"unicode"
+)
+
+// Kind classifies what a rule detects.
+type Kind string
+
+const (
+	KindSecret Kind = "secret"
+	KindPII    Kind = "pii"
+)
+
+// Rule is a named detection pattern. If the pattern has a capture group
+// named "secret", only that group is the sensitive part (e.g. the value in
+// "password: hunter2"); otherwise the whole match is.
+type Rule struct {
+	Name    string
+	Kind    Kind
+	Pattern *regexp.Regexp
+
+	// Filter, if set, must accept the sensitive text for it to count.
+	Filter func(string) bool
+}
+
+// DefaultRules match well-known credential formats.
+var DefaultRules = []Rule{
+	{Name: "private-key", Kind: KindSecret, Pattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	{Name: "aws-access-key", Kind: KindSecret, Pattern: regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`)},
+	{Name: "github-token", Kind: KindSecret, Pattern: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{30,}|github_pat_[A-Za-z0-9_]{30,})`)},
+	{Name: "slack-token", Kind: KindSecret, Pattern: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{Name: "api-key", Kind: KindSecret, Pattern: regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}`)}, // OpenAI/Anthropic-style keys
+	{Name: "jwt", Kind: KindSecret, Pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+	{Name: "registry-token", Kind: KindSecret, Pattern: regexp.MustCompile(`\b(?:glpat|npm|pypi|hf)[-_][A-Za-z0-9_-]{20,}`)},
+
+	// "password: value" style assignments; the key is kept for context.
+	{Name: "secret-assignment", Kind: KindSecret, Pattern: regexp.MustCompile(`(?i)\b(?:api[_-]?key|access[_-]?key|secret(?:[_-]?key)?|client[_-]?secret|passw(?:or)?d|pwd|token|auth[_-]?token|bearer)\b["']?\s*[:=]\s*(?P<secret>"[^"]*"|'[^']*'|\S+)`)},
+	{Name: "bearer-token", Kind: KindSecret, Pattern: regexp.MustCompile(`(?i)\bbearer\s+(?P<secret>[A-Za-z0-9._~+/-]{16,}=*)`)},
+	{Name: "url-credentials", Kind: KindSecret, Pattern: regexp.MustCompile(`://[^/\s:@]+:(?P<secret>[^@\s/]+)@`)},
+
+	// Long base64/base62 runs; only those mixing upper, lower, and digits
+	// count, so hex hashes and identifiers survive.
+	{Name: "high-entropy-token", Kind: KindSecret, Pattern: regexp.MustCompile(`[A-Za-z0-9+/_-]{32,}={0,2}`), Filter: looksRandom},
+}
+
+// Finding is one match in scanned text. Match is masked so findings can be
+// printed and logged without repeating the secret.
+type Finding struct {
+	Rule  string `json:"rule"`
+	Kind  Kind   `json:"kind"`
+	Field string `json:"field,omitempty"`
+	Match string `json:"match"`
+}
+
+// Scanner checks text against a fixed set of rules.
+type Scanner struct {
+	rules []Rule
+}
+
+// New returns a scanner with DefaultRules plus one PII rule per entry of
+// pii (rule name -> regular expression).
+func New(pii map[string]string) (*Scanner, error) {
+	rules := append([]Rule(nil), DefaultRules...)
+	names := make([]string, 0, len(pii))
+	for name := range pii {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		re, err := regexp.Compile(pii[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid PII pattern %q: %w", name, err)
+		}
+		rules = append(rules, Rule{Name: name, Kind: KindPII, Pattern: re})
+	}
+	return &Scanner{rules: rules}, nil
+}
+
+// Default returns a scanner with only DefaultRules.
+func Default() *Scanner {
+	return &Scanner{rules: DefaultRules}
+}
+
+// Scan returns the findings in text, attributed to field. A sensitive
+// string matched by several rules is reported once.
+func (s *Scanner) Scan(field, text string) []Finding {
+	if text == "" {
+		return nil
+	}
+	var findings []Finding
+	seen := make(map[string]bool)
+	for _, r := range s.rules {
+		for _, loc := range r.Pattern.FindAllStringSubmatchIndex(text, -1) {
+			start, end := sensitiveSpan(r.Pattern, loc)
+			if start < 0 {
+				continue
+			}
+			match := text[start:end]
+			if seen[match] || (r.Filter != nil && !r.Filter(match)) {
+				continue
+			}
+			seen[match] = true
+			findings = append(findings, Finding{Rule: r.Name, Kind: r.Kind, Field: field, Match: Mask(match)})
+		}
+	}
+	return findings
+}
+
+// ScanFields scans several named fields, in field name order.
+func (s *Scanner) ScanFields(fields map[string]string) []Finding {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var findings []Finding
+	for _, name := range names {
+		findings = append(findings, s.Scan(name, fields[name])...)
+	}
+	return findings
+}
+
+// Redact replaces the sensitive part of every match with replacement.
+// Rules apply in order, so earlier (more specific) rules win.
+func (s *Scanner) Redact(text, replacement string) string {
+	if text == "" {
+		return text
+	}
+	for _, r := range s.rules {
+		text = redactRule(r, text, replacement)
+	}
+	return text
+}
+
+func redactRule(r Rule, text, replacement string) string {
+	locs := r.Pattern.FindAllStringSubmatchIndex(text, -1)
+	if len(locs) == 0 {
+		return text
+	}
+	out := make([]byte, 0, len(text))
+	last := 0
+	for _, loc := range locs {
+		start, end := sensitiveSpan(r.Pattern, loc)
+		if start < 0 || (r.Filter != nil && !r.Filter(text[start:end])) {
+			continue
+		}
+		out = append(out, text[last:start]...)
+		out = append(out, replacement...)
+		last = end
+	}
+	return string(append(out, text[last:]...))
+}
+
+// sensitiveSpan returns the bounds of the "secret" group in a submatch
+// index, or of the whole match if the pattern has no such group.
+func sensitiveSpan(re *regexp.Regexp, loc []int) (int, int) {
+	if i := re.SubexpIndex("secret"); i > 0 {
+		return loc[2*i], loc[2*i+1]
+	}
+	return loc[0], loc[1]
+}
+
+// Mask keeps the first few characters of s so a finding can be located
+// without being disclosed.
+func Mask(s string) string {
+	r := []rune(s)
+	keep := 4
+	if len(r) <= 8 {
+		keep = min(1, len(r))
+	}
+	return string(r[:keep]) + "…"
+}
+
+// looksRandom reports whether tok mixes upper case, lower case, and digits,
+// as generated keys do and identifiers, words, and hex hashes rarely do.
+func looksRandom(tok string) bool {
+	var upper, lower, digit bool
+	for _, r := range tok {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	return upper && lower && digit
+}
@@ -0,0 +1,60 @@
+// Package sla measures due-date (SLA) timers, which pause while an issue
+// sits in a configured status such as "deferred" or "waiting-on-customer".
+package sla
+
+import (
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Timer is an issue's SLA timer measured up to some moment.
+type Timer struct {
+	Due          time.Time     // The issue's due date
+	EffectiveDue time.Time     // Due, pushed back by the time spent paused
+	Paused       time.Duration // Time spent in paused statuses
+	PausedNow    bool          // Whether the issue is in a paused status at the end
+}
+
+// Remaining is how long is left before the effective due date at until;
+// negative once the timer is breached.
+func (t Timer) Remaining(until time.Time) time.Duration {
+	return t.EffectiveDue.Sub(until)
+}
+
+// Measure runs an issue's SLA timer from its creation until until (now, or
+// when it closed). changes is its status history, oldest first; time spent
+// in any of the paused statuses doesn't count against the due date. The
+// issue must have a due date.
+func Measure(issue *types.Issue, changes []types.StatusChange, paused map[types.Status]bool, until time.Time) Timer {
+	status := issue.Status
+	if len(changes) > 0 && changes[0].From != "" {
+		status = changes[0].From
+	}
+	var total time.Duration
+	from := issue.CreatedAt
+	count := func(to time.Time) {
+		if to.After(until) {
+			to = until
+		}
+		if paused[status] && to.After(from) {
+			total += to.Sub(from)
+		}
+	}
+	for _, change := range changes {
+		if change.At.Before(from) {
+			status = change.To
+			continue
+		}
+		count(change.At)
+		status, from = change.To, change.At
+	}
+	count(until)
+
+	return Timer{
+		Due:          *issue.DueAt,
+		EffectiveDue: issue.DueAt.Add(total),
+		Paused:       total,
+		PausedNow:    paused[status],
+	}
+}
@@ -0,0 +1,66 @@
+package sla
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestMeasure(t *testing.T) {
+	created := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return created.AddDate(0, 0, n) }
+	due := day(5)
+	paused := map[types.Status]bool{types.StatusDeferred: true, "waiting": true}
+
+	tests := []struct {
+		name       string
+		status     types.Status
+		changes    []types.StatusChange
+		until      time.Time
+		wantPaused time.Duration
+		pausedNow  bool
+	}{
+		{"never paused", types.StatusInProgress,
+			[]types.StatusChange{{At: day(1), From: types.StatusOpen, To: types.StatusInProgress}},
+			day(7), 0, false},
+		{"paused then resumed", types.StatusInProgress,
+			[]types.StatusChange{
+				{At: day(1), From: types.StatusOpen, To: "waiting"},
+				{At: day(3), From: "waiting", To: types.StatusInProgress},
+			},
+			day(7), 48 * time.Hour, false},
+		{"paused now", types.StatusDeferred,
+			[]types.StatusChange{{At: day(4), From: types.StatusOpen, To: types.StatusDeferred}},
+			day(6), 48 * time.Hour, true},
+		{"created paused, no history", types.StatusDeferred, nil,
+			day(2), 48 * time.Hour, true},
+		{"close without from", types.StatusClosed,
+			[]types.StatusChange{
+				{At: day(1), From: types.StatusOpen, To: types.StatusDeferred},
+				{At: day(2), To: types.StatusClosed},
+			},
+			day(2), 24 * time.Hour, false},
+	}
+	for _, tt := range tests {
+		issue := &types.Issue{Status: tt.status, CreatedAt: created, DueAt: &due}
+		timer := Measure(issue, tt.changes, paused, tt.until)
+		if timer.Paused != tt.wantPaused || timer.PausedNow != tt.pausedNow {
+			t.Errorf("%s: paused = %v (now %v), want %v (now %v)", tt.name, timer.Paused, timer.PausedNow, tt.wantPaused, tt.pausedNow)
+		}
+		if !timer.EffectiveDue.Equal(due.Add(tt.wantPaused)) {
+			t.Errorf("%s: effective due = %v, want %v", tt.name, timer.EffectiveDue, due.Add(tt.wantPaused))
+		}
+	}
+}
+
+func TestRemaining(t *testing.T) {
+	due := time.Date(2025, 7, 5, 0, 0, 0, 0, time.UTC)
+	timer := Timer{Due: due, EffectiveDue: due.Add(24 * time.Hour)}
+	if got := timer.Remaining(due); got != 24*time.Hour {
+		t.Errorf("Remaining = %v, want 24h", got)
+	}
+	if got := timer.Remaining(due.Add(36 * time.Hour)); got != -12*time.Hour {
+		t.Errorf("Remaining = %v, want -12h", got)
+	}
+}
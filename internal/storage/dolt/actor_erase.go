@@ -0,0 +1,420 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/erasure"
+	"github.com/steveyegge/beads/internal/permissions"
+)
+
+// actorColumns hold a bare actor name. idColumn names the issue each row
+// belongs to, for the report; rows that aren't about an issue select an
+// empty string instead.
+var actorColumns = []struct{ table, idColumn, column string }{
+	{"issues", "id", "assignee"},
+	{"issues", "id", "created_by"},
+	{"issues", "id", "owner"},
+	{"issues", "id", "sender"},
+	{"issues", "id", "actor"},
+	{"wisps", "id", "assignee"},
+	{"wisps", "id", "created_by"},
+	{"wisps", "id", "owner"},
+	{"wisps", "id", "sender"},
+	{"wisps", "id", "actor"},
+	{"dependencies", "issue_id", "created_by"},
+	{"wisp_dependencies", "issue_id", "created_by"},
+	{"comments", "issue_id", "author"},
+	{"wisp_comments", "issue_id", "author"},
+	{"events", "issue_id", "actor"},
+	{"wisp_events", "issue_id", "actor"},
+	{"interactions", "issue_id", "actor"},
+	{"intent_log", "target", "actor"},
+	{"issue_evidence", "issue_id", "added_by"},
+	{"issue_journal", "issue_id", "author"},
+	{"availability", "''", "person"},
+	{"availability", "''", "created_by"},
+	{"issue_locks", "issue_id", "holder"},
+	{"alerts", "''", "created_by"},
+	{"external_keys", "issue_id", "created_by"},
+	{"sync_conflicts", "issue_id", "resolved_by"},
+	{"milestones", "''", "created_by"},
+	{"milestone_baselines", "issue_id", "taken_by"},
+	{"recurrences", "''", "assignee"},
+	{"recurrences", "''", "created_by"},
+}
+
+// documentColumn is a column whose value may embed names: plain text, or a
+// JSON document (audit snapshots, waiters lists, intent payloads) whose
+// string values are rewritten one by one. Plain text columns are only
+// rewritten when mentions are erased too.
+type documentColumn struct {
+	table, keyColumn, idColumn, column string
+	plainText                          bool // Free text: only mentions apply
+}
+
+var documentColumns = []documentColumn{
+	{"issues", "id", "id", "waiters", false},
+	{"wisps", "id", "id", "waiters", false},
+	{"events", "id", "issue_id", "old_value", false},
+	{"events", "id", "issue_id", "new_value", false},
+	{"wisp_events", "id", "issue_id", "old_value", false},
+	{"wisp_events", "id", "issue_id", "new_value", false},
+	{"intent_log", "id", "target", "payload", false},
+	{"issue_snapshots", "id", "issue_id", "original_content", false},
+	{"issue_snapshots", "id", "issue_id", "archived_events", false},
+	{"compaction_snapshots", "id", "issue_id", "snapshot_json", false},
+	{"issues", "id", "id", "title", true},
+	{"issues", "id", "id", "description", true},
+	{"issues", "id", "id", "design", true},
+	{"issues", "id", "id", "acceptance_criteria", true},
+	{"issues", "id", "id", "notes", true},
+	{"issues", "id", "id", "close_reason", true},
+	{"wisps", "id", "id", "title", true},
+	{"wisps", "id", "id", "description", true},
+	{"wisps", "id", "id", "design", true},
+	{"wisps", "id", "id", "acceptance_criteria", true},
+	{"wisps", "id", "id", "notes", true},
+	{"wisps", "id", "id", "close_reason", true},
+	{"comments", "id", "issue_id", "text", true},
+	{"wisp_comments", "id", "issue_id", "text", true},
+	{"issue_journal", "id", "issue_id", "text", true},
+	{"events", "id", "issue_id", "comment", true},
+	{"wisp_events", "id", "issue_id", "comment", true},
+	// start_date is fixed width, so with the person it identifies the row
+	{"availability", "CONCAT(start_date, person)", "''", "note", true},
+}
+
+// ErasureChange counts the rows an erasure rewrote in one column.
+type ErasureChange struct {
+	Table  string `json:"table"`
+	Column string `json:"column"`
+	Rows   int    `json:"rows"`
+}
+
+// ActorErasureReport lists what EraseActor rewrote.
+type ActorErasureReport struct {
+	Replacement string          `json:"replacement"`
+	Changes     []ErasureChange `json:"changes"`
+	IssueIDs    []string        `json:"issue_ids"` // Issues with at least one rewritten row
+	Rows        int             `json:"rows"`
+}
+
+// EraseActor replaces the eraser's names with its pseudonym in every column
+// that records who did something: issue people fields, comment authors,
+// dependency creators, reactions, the events audit trail (including issue
+// snapshots inside events), interactions, evidence, the intent log, and
+// away periods. With mentions, whole-word mentions in issue text, comments
+// and away notes are replaced too.
+// Everything happens in one transaction; with dryRun it is rolled back, so
+// the report shows what would change.
+//
+// Earlier Dolt commits still contain the names; see SquashHistory.
+func (s *DoltStore) EraseActor(ctx context.Context, e *erasure.Eraser, mentions, dryRun bool) (*ActorErasureReport, error) {
+	names := e.Names()
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no names to erase")
+	}
+	if !dryRun {
+		if err := s.authorize("", permissions.Admin); err != nil {
+			return nil, err
+		}
+	}
+	report := &ActorErasureReport{Replacement: e.Replacement()}
+	issueIDs := make(map[string]bool)
+	record := func(table, column string, ids []string) {
+		if len(ids) == 0 {
+			return
+		}
+		report.Changes = append(report.Changes, ErasureChange{Table: table, Column: column, Rows: len(ids)})
+		report.Rows += len(ids)
+		for _, id := range ids {
+			if id != "" {
+				issueIDs[id] = true
+			}
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // No-op after successful commit
+
+	inClause, inArgs := doltBuildSQLInClause(names)
+	for _, c := range actorColumns {
+		ids, err := queryStrings(ctx, tx,
+			fmt.Sprintf("SELECT %s FROM %s WHERE %s IN (%s)", c.idColumn, c.table, c.column, inClause), inArgs...) //nolint:gosec // G201: identifiers are constants
+		if err != nil {
+			return nil, fmt.Errorf("failed to find %s.%s: %w", c.table, c.column, err)
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		args := append([]interface{}{e.Replacement()}, inArgs...)
+		if _, err := tx.ExecContext(ctx,
+			fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s IN (%s)", c.table, c.column, c.column, inClause), args...); err != nil { //nolint:gosec // G201: identifiers are constants
+			return nil, fmt.Errorf("failed to rewrite %s.%s: %w", c.table, c.column, err)
+		}
+		record(c.table, c.column, ids)
+	}
+
+	// Reactions are keyed by actor, so the pseudonym may already have the
+	// same reaction; keep one.
+	ids, err := queryStrings(ctx, tx,
+		fmt.Sprintf("SELECT issue_id FROM reactions WHERE actor IN (%s)", inClause), inArgs...) //nolint:gosec // G201: placeholders only
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reactions: %w", err)
+	}
+	if len(ids) > 0 {
+		args := append([]interface{}{e.Replacement()}, inArgs...)
+		if _, err := tx.ExecContext(ctx,
+			fmt.Sprintf("UPDATE IGNORE reactions SET actor = ? WHERE actor IN (%s)", inClause), args...); err != nil { //nolint:gosec // G201: placeholders only
+			return nil, fmt.Errorf("failed to rewrite reactions: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			fmt.Sprintf("DELETE FROM reactions WHERE actor IN (%s)", inClause), inArgs...); err != nil { //nolint:gosec // G201: placeholders only
+			return nil, fmt.Errorf("failed to remove duplicate reactions: %w", err)
+		}
+		record("reactions", "actor", ids)
+	}
+
+	for _, c := range documentColumns {
+		if c.plainText && !mentions {
+			continue
+		}
+		ids, err := eraseInDocuments(ctx, tx, c, e, mentions)
+		if err != nil {
+			return nil, err
+		}
+		record(c.table, c.column, ids)
+	}
+	if mentions {
+		ids, err := eraseInDescriptionBlobs(ctx, tx, e)
+		if err != nil {
+			return nil, err
+		}
+		record("description_blobs", "content", ids)
+	}
+
+	for id := range issueIDs {
+		report.IssueIDs = append(report.IssueIDs, id)
+	}
+	sort.Strings(report.IssueIDs)
+
+	if dryRun {
+		return report, nil // Deferred rollback discards the changes
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit erasure: %w", err)
+	}
+	return report, nil
+}
+
+// eraseInDocuments rewrites the rows of one document column that mention
+// any name, returning the issue IDs of the rewritten rows.
+func eraseInDocuments(ctx context.Context, tx *sql.Tx, c documentColumn, e *erasure.Eraser, mentions bool) ([]string, error) {
+	where, args := likeAnyClause(fmt.Sprintf("LOWER(CAST(%s AS CHAR))", c.column), e.Names())
+	//nolint:gosec // G201: identifiers are constants, values are placeholders
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT %s, %s, CAST(%s AS CHAR) FROM %s WHERE %s",
+		c.keyColumn, c.idColumn, c.column, c.table, where), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s.%s: %w", c.table, c.column, err)
+	}
+	type change struct{ key, value string }
+	var changes []change
+	var ids []string
+	for rows.Next() {
+		var key, id string
+		var value sql.NullString
+		if err := rows.Scan(&key, &id, &value); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan %s.%s: %w", c.table, c.column, err)
+		}
+		var updated string
+		if c.plainText {
+			updated, _ = e.Text(value.String)
+		} else {
+			updated = e.Document(value.String, mentions)
+		}
+		if updated != value.String {
+			changes = append(changes, change{key, updated})
+			ids = append(ids, id)
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan %s.%s: %w", c.table, c.column, err)
+	}
+
+	for _, ch := range changes {
+		//nolint:gosec // G201: identifiers are constants
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ?", c.table, c.column, c.keyColumn), ch.value, ch.key); err != nil {
+			return nil, fmt.Errorf("failed to rewrite %s.%s: %w", c.table, c.column, err)
+		}
+	}
+	return ids, nil
+}
+
+// eraseInDescriptionBlobs rewrites large descriptions and evidence files
+// stored as blobs. The rewritten text is stored again under its new content
+// address, the referencing issues and evidence are repointed, and the old
+// blob is removed.
+func eraseInDescriptionBlobs(ctx context.Context, tx *sql.Tx, e *erasure.Eraser) ([]string, error) {
+	where, args := likeAnyClause("LOWER(content)", e.Names())
+	rows, err := tx.QueryContext(ctx, "SELECT hash, content FROM description_blobs WHERE "+where, args...) //nolint:gosec // G202: placeholders only
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan description blobs: %w", err)
+	}
+	rewritten := make(map[string]string) // old hash -> new content
+	for rows.Next() {
+		var hash, content string
+		if err := rows.Scan(&hash, &content); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan description blob: %w", err)
+		}
+		if updated, n := e.Text(content); n > 0 {
+			rewritten[hash] = updated
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan description blobs: %w", err)
+	}
+
+	var ids []string
+	for oldHash, content := range rewritten {
+		stored, err := storeDescription(ctx, tx, content)
+		if err != nil {
+			return nil, err
+		}
+		issueIDs, err := queryStrings(ctx, tx, `SELECT id FROM issues WHERE description = ?`, blobRef(oldHash))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find blob references: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE issues SET description = ? WHERE description = ?`,
+			stored, blobRef(oldHash)); err != nil {
+			return nil, fmt.Errorf("failed to repoint description blob: %w", err)
+		}
+		evidenceIDs, err := queryStrings(ctx, tx, `SELECT issue_id FROM issue_evidence WHERE content_hash = ?`, oldHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find evidence references: %w", err)
+		}
+		if len(evidenceIDs) > 0 {
+			newHash, err := storeBlob(ctx, tx, content)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := tx.ExecContext(ctx, `UPDATE issue_evidence SET content_hash = ?, size = ? WHERE content_hash = ?`,
+				newHash, len(content), oldHash); err != nil {
+				return nil, fmt.Errorf("failed to repoint evidence blob: %w", err)
+			}
+			issueIDs = append(issueIDs, evidenceIDs...)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM description_blobs WHERE hash = ?`, oldHash); err != nil {
+			return nil, fmt.Errorf("failed to remove description blob: %w", err)
+		}
+		ids = append(ids, issueIDs...)
+	}
+	return ids, nil
+}
+
+// likeAnyClause builds "expr LIKE ? OR ..." matching any of names as a
+// lowercase substring; expr must already be lowercased.
+func likeAnyClause(expr string, names []string) (string, []interface{}) {
+	escaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	clauses := make([]string, len(names))
+	args := make([]interface{}, len(names))
+	for i, name := range names {
+		clauses[i] = expr + " LIKE ?"
+		args[i] = "%" + escaper.Replace(strings.ToLower(name)) + "%"
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// queryStrings runs a query returning one string column.
+func queryStrings(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []string
+	for rows.Next() {
+		var v sql.NullString
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		out = append(out, v.String)
+	}
+	return out, rows.Err()
+}
+
+// SquashHistory replaces the current branch's history with a single commit
+// of its current state on top of the repository's initial commit, then
+// garbage-collects, so earlier versions of rows (such as names removed by
+// EraseActor) are no longer stored. It returns the other branches, whose
+// history still references the old commits until they are deleted or
+// squashed; remotes and other clones keep their copies until force-pushed
+// over and collected there.
+func (s *DoltStore) SquashHistory(ctx context.Context, message string) (otherBranches []string, err error) {
+	if err := s.authorize("", permissions.Admin); err != nil {
+		return nil, err
+	}
+	current, err := s.CurrentBranch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roots, err := queryDBStrings(ctx, s, `SELECT commit_hash FROM dolt_log`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	if len(roots) < 2 {
+		return nil, nil // Nothing to squash
+	}
+	root := roots[len(roots)-1] // dolt_log lists newest first; the last entry is the initial commit
+
+	// Keep uncommitted changes (e.g. the erasure itself) in the squash
+	if _, err := s.db.ExecContext(ctx, "CALL DOLT_RESET('--soft', ?)", root); err != nil {
+		return nil, fmt.Errorf("failed to reset to initial commit: %w", err)
+	}
+	if err := s.Commit(ctx, message); err != nil {
+		return nil, err
+	}
+	if _, err := s.GC(ctx, false); err != nil {
+		return nil, err
+	}
+
+	branches, err := s.ListBranches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range branches {
+		if b != current {
+			otherBranches = append(otherBranches, b)
+		}
+	}
+	return otherBranches, nil
+}
+
+// queryDBStrings runs a query returning one string column outside a
+// transaction.
+func queryDBStrings(ctx context.Context, s *DoltStore, query string, args ...interface{}) ([]string, error) {
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,36 @@
+package dolt
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestActorColumnsCoverSchema fails when a table gains a column that names
+// an actor without bd actor erase rewriting it.
+func TestActorColumnsCoverSchema(t *testing.T) {
+	actorLike := regexp.MustCompile(`^(assignee|owner|sender|actor|author|holder|person|[a-z_]+_by)$`)
+	createTable := regexp.MustCompile(`^CREATE TABLE IF NOT EXISTS (\w+)`)
+	erased := map[string]bool{
+		"reactions.actor": true, // Part of the key; EraseActor rewrites it separately
+	}
+	for _, c := range actorColumns {
+		erased[c.table+"."+c.column] = true
+	}
+
+	var table string
+	for _, line := range strings.Split(schema, "\n") {
+		line = strings.TrimSpace(line)
+		if m := createTable.FindStringSubmatch(line); m != nil {
+			table = m[1]
+			continue
+		}
+		fields := strings.Fields(line)
+		if table == "" || len(fields) < 2 || !actorLike.MatchString(fields[0]) {
+			continue
+		}
+		if column := table + "." + fields[0]; !erased[column] {
+			t.Errorf("%s holds an actor name but is not in actorColumns", column)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Default aging curve for SortPolicyHybrid: one priority level per 30 days
+// waiting, up to three, so a P3 left for three months sorts with P0s.
+const (
+	defaultAgingInterval = 30 * 24 * time.Hour
+	defaultAgingMaxBoost = 3
+)
+
+// agingCurve returns how long ready work waits to gain a priority level under
+// SortPolicyHybrid, and the most levels it can gain, from ready.aging.interval
+// and ready.aging.max-boost (database first, then config.yaml).
+func (s *DoltStore) agingCurve(ctx context.Context) (interval time.Duration, maxBoost int, err error) {
+	get := func(key string) (string, error) {
+		value, err := s.GetConfig(ctx, key)
+		if err != nil || value != "" {
+			return value, err
+		}
+		return config.GetString(key), nil
+	}
+
+	interval, maxBoost = defaultAgingInterval, defaultAgingMaxBoost
+	value, err := get("ready.aging.interval")
+	if err != nil {
+		return 0, 0, err
+	}
+	if value != "" {
+		if interval, err = types.ParseLag(value); err != nil || interval <= 0 {
+			return 0, 0, fmt.Errorf("invalid ready.aging.interval %q (use e.g. 30d, 2w)", value)
+		}
+	}
+	value, err = get("ready.aging.max-boost")
+	if err != nil {
+		return 0, 0, err
+	}
+	if value != "" {
+		if maxBoost, err = strconv.Atoi(value); err != nil || maxBoost < 0 {
+			return 0, 0, fmt.Errorf("invalid ready.aging.max-boost %q (use a number of priority levels)", value)
+		}
+	}
+	return interval, maxBoost, nil
+}
+
+// agedPriority is the priority issue sorts at under SortPolicyHybrid: one
+// level more urgent per interval since it was created, by at most maxBoost
+// levels and never past P0.
+func agedPriority(issue *types.Issue, now time.Time, interval time.Duration, maxBoost int) int {
+	boost := int(now.Sub(issue.CreatedAt) / interval)
+	if boost > maxBoost {
+		boost = maxBoost
+	}
+	if boost < 0 {
+		boost = 0
+	}
+	if aged := issue.Priority - boost; aged > 0 {
+		return aged
+	}
+	return 0
+}
+
+// sortByAgedPriority sorts ready work by aged priority. On a tie, the issue
+// that earned its place without aging comes first, then the oldest.
+func sortByAgedPriority(issues []*types.Issue, now time.Time, interval time.Duration, maxBoost int) {
+	aged := make(map[string]int, len(issues))
+	for _, issue := range issues {
+		aged[issue.ID] = agedPriority(issue, now, interval, maxBoost)
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if aged[a.ID] != aged[b.ID] {
+			return aged[a.ID] < aged[b.ID]
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+}
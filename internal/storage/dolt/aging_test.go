@@ -0,0 +1,43 @@
+package dolt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSortByAgedPriority(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	issue := func(id string, priority int, age time.Duration) *types.Issue {
+		return &types.Issue{ID: id, Priority: priority, CreatedAt: now.Add(-age)}
+	}
+
+	issues := []*types.Issue{
+		issue("fresh-p1", 1, day),
+		issue("fresh-p0", 0, day),
+		issue("old-p3", 3, 95*day),      // Boosted 3 levels: sorts with P0s
+		issue("month-p3", 3, 31*day),    // Boosted 1 level: P2
+		issue("ancient-p4", 4, 400*day), // Capped at 3 levels: P1
+		issue("new-p2", 2, 0),
+	}
+	sortByAgedPriority(issues, now, 30*day, 3)
+
+	var got []string
+	for _, i := range issues {
+		got = append(got, i.ID)
+	}
+	want := []string{"fresh-p0", "old-p3", "fresh-p1", "ancient-p4", "new-p2", "month-p3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+
+	if p := agedPriority(issue("p1", 1, 200*day), now, 30*day, 3); p != 0 {
+		t.Errorf("aged priority = %d, want no higher than P0", p)
+	}
+	if p := agedPriority(issue("p3", 3, 200*day), now, 30*day, 0); p != 3 {
+		t.Errorf("aged priority with max-boost 0 = %d, want 3", p)
+	}
+}
@@ -0,0 +1,97 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SaveAlert creates alert a, or replaces the alert of the same name and
+// forgets its last matches, so the next check announces the current ones.
+func (s *DoltStore) SaveAlert(ctx context.Context, a *types.Alert) error {
+	if err := s.authorize(a.CreatedBy, permissions.Update); err != nil {
+		return err
+	}
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = time.Now().UTC()
+	}
+	_, err := s.execContext(ctx, `
+		INSERT INTO alerts (name, query, notify, created_by, created_at, last_ids)
+		VALUES (?, ?, ?, ?, ?, '')
+		ON DUPLICATE KEY UPDATE query = VALUES(query), notify = VALUES(notify),
+			created_by = VALUES(created_by), created_at = VALUES(created_at),
+			last_ids = '', last_checked_at = NULL, last_fired_at = NULL
+	`, a.Name, a.Query, a.Notify, a.CreatedBy, a.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save alert %s: %w", a.Name, err)
+	}
+	return nil
+}
+
+// RemoveAlert deletes the named alert, reporting whether it existed.
+func (s *DoltStore) RemoveAlert(ctx context.Context, name string) (bool, error) {
+	if err := s.authorize("", permissions.Update); err != nil {
+		return false, err
+	}
+	result, err := s.execContext(ctx, "DELETE FROM alerts WHERE name = ?", name)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove alert %s: %w", name, err)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
+
+// GetAlerts returns every alert, ordered by name.
+func (s *DoltStore) GetAlerts(ctx context.Context) ([]*types.Alert, error) {
+	rows, err := s.queryContext(ctx, `
+		SELECT name, query, notify, created_by, created_at, last_ids, last_checked_at, last_fired_at
+		FROM alerts ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var alerts []*types.Alert
+	for rows.Next() {
+		var a types.Alert
+		var lastIDs string
+		var checked, fired sql.NullTime
+		if err := rows.Scan(&a.Name, &a.Query, &a.Notify, &a.CreatedBy, &a.CreatedAt, &lastIDs, &checked, &fired); err != nil {
+			return nil, fmt.Errorf("failed to scan alert: %w", err)
+		}
+		if lastIDs != "" {
+			a.LastIDs = strings.Split(lastIDs, ",")
+		}
+		if checked.Valid {
+			a.LastCheckedAt = &checked.Time
+		}
+		if fired.Valid {
+			a.LastFiredAt = &fired.Time
+		}
+		alerts = append(alerts, &a)
+	}
+	return alerts, rows.Err()
+}
+
+// RecordAlertCheck stores the matches (sorted IDs) of the named alert's
+// check at at, and whether the check fired a notification.
+func (s *DoltStore) RecordAlertCheck(ctx context.Context, name string, ids []string, at time.Time, fired bool) error {
+	if err := s.authorize("", permissions.Update); err != nil {
+		return err
+	}
+	query := "UPDATE alerts SET last_ids = ?, last_checked_at = ?"
+	args := []interface{}{strings.Join(ids, ","), at}
+	if fired {
+		query += ", last_fired_at = ?"
+		args = append(args, at)
+	}
+	if _, err := s.execContext(ctx, query+" WHERE name = ?", append(args, name)...); err != nil {
+		return fmt.Errorf("failed to record check of alert %s: %w", name, err)
+	}
+	return nil
+}
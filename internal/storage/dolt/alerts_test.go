@@ -0,0 +1,58 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAlerts(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	for _, a := range []*types.Alert{
+		{Name: "p0-open", Query: "priority=0 and status=open and age>2d", Notify: "slack:#oncall", CreatedBy: "alice"},
+		{Name: "blocked", Query: "status=blocked", Notify: "mail:lead", CreatedBy: "bob"},
+	} {
+		if err := store.SaveAlert(ctx, a); err != nil {
+			t.Fatalf("SaveAlert: %v", err)
+		}
+	}
+
+	at := time.Now().UTC().Truncate(time.Second)
+	if err := store.RecordAlertCheck(ctx, "p0-open", []string{"bd-1", "bd-2"}, at, true); err != nil {
+		t.Fatalf("RecordAlertCheck: %v", err)
+	}
+	alerts, err := store.GetAlerts(ctx)
+	if err != nil {
+		t.Fatalf("GetAlerts: %v", err)
+	}
+	if len(alerts) != 2 || alerts[0].Name != "blocked" || alerts[0].LastIDs != nil || alerts[0].LastCheckedAt != nil {
+		t.Fatalf("alerts = %+v", alerts)
+	}
+	p0 := alerts[1]
+	if len(p0.LastIDs) != 2 || p0.LastIDs[1] != "bd-2" || p0.LastFiredAt == nil || p0.Notify != "slack:#oncall" {
+		t.Errorf("p0-open after check = %+v", p0)
+	}
+
+	// Replacing an alert forgets its matches
+	if err := store.SaveAlert(ctx, &types.Alert{Name: "p0-open", Query: "priority=0", Notify: "slack:#oncall", CreatedBy: "alice"}); err != nil {
+		t.Fatalf("SaveAlert (replace): %v", err)
+	}
+	if alerts, _ = store.GetAlerts(ctx); alerts[1].Query != "priority=0" || alerts[1].LastIDs != nil || alerts[1].LastFiredAt != nil {
+		t.Errorf("replaced alert = %+v", alerts[1])
+	}
+
+	if removed, err := store.RemoveAlert(ctx, "blocked"); err != nil || !removed {
+		t.Errorf("RemoveAlert = %v, %v", removed, err)
+	}
+	if removed, _ := store.RemoveAlert(ctx, "blocked"); removed {
+		t.Error("RemoveAlert removed a missing alert")
+	}
+}
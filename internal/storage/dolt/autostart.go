@@ -0,0 +1,165 @@
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/lockfile"
+)
+
+// Managed local server.
+// When no dolt sql-server is reachable on a loopback address, bd can start
+// one itself for the local database directory. The server outlives the bd
+// process, so later invocations (and the daemon) share it instead of each
+// contending for the database files.
+
+// autoStartLockFile serializes auto-start between concurrent bd processes.
+const autoStartLockFile = "dolt-server.start.lock"
+
+// serverOwnerFile returns the file recording which data directory the
+// server bd auto-started on port serves. It lives in the user's cache
+// directory so that every repository on the machine sees it.
+func serverOwnerFile(port int) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "bd", fmt.Sprintf("dolt-server-%d.dir", port)), nil
+}
+
+// recordServerOwner notes that the server on port was auto-started for
+// dataDir.
+func recordServerOwner(port int, dataDir string) error {
+	path, err := serverOwnerFile(port)
+	if err != nil {
+		return err
+	}
+	if abs, err := filepath.Abs(dataDir); err == nil {
+		dataDir = abs
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(dataDir), 0o600)
+}
+
+// checkServerOwner refuses a local server that bd auto-started for another
+// data directory. Sharing it would create and write this repository's
+// database inside the other repository's .beads/dolt. Servers bd didn't
+// start (bd dolt start, Gas Town, a server you run) are not checked.
+func checkServerOwner(cfg *Config) error {
+	if !isLoopbackHost(cfg.ServerHost) {
+		return nil
+	}
+	path, err := serverOwnerFile(cfg.ServerPort)
+	if err != nil {
+		return nil // No cache directory, so no auto-started server recorded
+	}
+	// #nosec G304 -- path is derived from the user cache directory.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	ownerDir := strings.TrimSpace(string(data))
+	if ownerDir == "" || GetRunningServerPID(ownerDir) == 0 {
+		return nil // That server has stopped; whatever listens now isn't it
+	}
+	if samePath(ownerDir, cfg.Path) {
+		return nil
+	}
+	return fmt.Errorf("the dolt sql-server on %s was auto-started for %s, not %s; "+
+		"give this repository its own port with 'bd dolt set port <port>'",
+		net.JoinHostPort(cfg.ServerHost, strconv.Itoa(cfg.ServerPort)), ownerDir, cfg.Path)
+}
+
+// samePath reports whether a and b name the same directory.
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return absA == absB
+}
+
+// isLoopbackHost reports whether host refers to the local machine.
+// Auto-start is restricted to loopback hosts: starting a local server in
+// place of an unreachable remote one would silently split the data.
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ensureLocalServer starts a managed dolt sql-server for cfg.Path if the
+// configured server is unreachable. It is a no-op when a server is already
+// listening. Concurrent callers are serialized with a file lock; a caller
+// that loses the race waits for the winner's server instead of starting a
+// second one.
+func ensureLocalServer(ctx context.Context, cfg *Config) error {
+	if isServerListening(cfg.ServerHost, cfg.ServerPort) {
+		return nil
+	}
+	if !isLoopbackHost(cfg.ServerHost) {
+		return fmt.Errorf("auto-start is only supported for local servers (host %s)", cfg.ServerHost)
+	}
+	if _, err := exec.LookPath("dolt"); err != nil {
+		return fmt.Errorf("dolt binary not found in PATH: %w", err)
+	}
+	if err := os.MkdirAll(cfg.Path, 0o750); err != nil {
+		return fmt.Errorf("failed to create dolt data directory: %w", err)
+	}
+
+	lockPath := filepath.Join(cfg.Path, autoStartLockFile)
+	// #nosec G304 -- lockPath is derived from the database path.
+	lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open auto-start lock: %w", err)
+	}
+	defer func() { _ = lock.Close() }() // Best effort: closing releases the flock
+	if err := lockfile.FlockExclusiveBlocking(lock); err != nil {
+		return fmt.Errorf("failed to acquire auto-start lock: %w", err)
+	}
+	defer func() { _ = lockfile.FlockUnlock(lock) }() // Best effort: released on close anyway
+
+	// Another process may have started the server while we waited for the lock
+	if isServerListening(cfg.ServerHost, cfg.ServerPort) {
+		return nil
+	}
+
+	server := NewServer(ServerConfig{
+		DataDir:           cfg.Path,
+		SQLPort:           cfg.ServerPort,
+		Host:              cfg.ServerHost,
+		LogFile:           filepath.Join(filepath.Dir(cfg.Path), "dolt-server.log"),
+		User:              cfg.ServerUser,
+		DisableRemotesAPI: true,
+	})
+
+	// The server must outlive this process, so it is not tied to ctx.
+	startErr := make(chan error, 1)
+	go func() { startErr <- server.Start(context.Background()) }()
+	select {
+	case err := <-startErr:
+		if err != nil {
+			return fmt.Errorf("failed to auto-start dolt sql-server: %w", err)
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(ServerStartTimeout + time.Second):
+		return fmt.Errorf("timed out auto-starting dolt sql-server")
+	}
+	if err := recordServerOwner(cfg.ServerPort, cfg.Path); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record auto-started server: %v\n", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package dolt
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestCheckServerOwner(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir()) // os.UserCacheDir on macOS
+	repoA := filepath.Join(t.TempDir(), "a", ".beads", "dolt")
+	repoB := filepath.Join(t.TempDir(), "b", ".beads", "dolt")
+	if err := os.MkdirAll(repoA, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	cfgA := &Config{Path: repoA, ServerHost: "127.0.0.1", ServerPort: 3307}
+	cfgB := &Config{Path: repoB, ServerHost: "127.0.0.1", ServerPort: 3307}
+
+	if err := checkServerOwner(cfgB); err != nil {
+		t.Errorf("no auto-started server: %v", err)
+	}
+
+	// A server auto-started for repo A, still running (this test's PID)
+	if err := recordServerOwner(3307, repoA); err != nil {
+		t.Fatal(err)
+	}
+	pidFile := filepath.Join(repoA, "dolt-server.pid")
+	if err := os.WriteFile(pidFile, []byte(strconv.Itoa(os.Getpid())), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkServerOwner(cfgA); err != nil {
+		t.Errorf("repo A reusing its own server: %v", err)
+	}
+	if err := checkServerOwner(cfgB); err == nil {
+		t.Error("repo B allowed to share the server auto-started for repo A")
+	}
+	if err := checkServerOwner(&Config{Path: repoB, ServerHost: "127.0.0.1", ServerPort: 3308}); err != nil {
+		t.Errorf("repo B on its own port: %v", err)
+	}
+
+	// Once A's server stops, the record no longer applies
+	if err := os.Remove(pidFile); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkServerOwner(cfgB); err != nil {
+		t.Errorf("after A's server stopped: %v", err)
+	}
+}
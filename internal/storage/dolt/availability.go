@@ -0,0 +1,83 @@
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// SetUnavailability records that u.Person is away from u.Start to u.End.
+// A period starting on the same day for the same person is replaced.
+func (s *DoltStore) SetUnavailability(ctx context.Context, u *types.Unavailability) error {
+	if err := s.authorize(u.CreatedBy, permissions.Update); err != nil {
+		return err
+	}
+	if u.CreatedAt.IsZero() {
+		u.CreatedAt = time.Now().UTC()
+	}
+	_, err := s.execContext(ctx, `
+		INSERT INTO availability (person, start_date, end_date, note, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE end_date = VALUES(end_date), note = VALUES(note),
+			created_by = VALUES(created_by), created_at = VALUES(created_at)
+	`, u.Person, u.Start, u.End, u.Note, u.CreatedBy, u.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set availability for %s: %w", u.Person, err)
+	}
+	return nil
+}
+
+// RemoveUnavailability deletes person's away periods that overlap from..to
+// (YYYY-MM-DD, inclusive), or all of them when from and to are empty. It
+// returns the number of periods removed.
+func (s *DoltStore) RemoveUnavailability(ctx context.Context, person, from, to string) (int64, error) {
+	if err := s.authorize("", permissions.Update); err != nil {
+		return 0, err
+	}
+	query := "DELETE FROM availability WHERE person = ?"
+	args := []interface{}{person}
+	if from != "" || to != "" {
+		query += " AND end_date >= ? AND start_date <= ?"
+		args = append(args, from, to)
+	}
+	result, err := s.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to clear availability for %s: %w", person, err)
+	}
+	n, _ := result.RowsAffected()
+	return n, nil
+}
+
+// GetUnavailability returns the away periods of person (everyone when
+// empty) that end on or after since (YYYY-MM-DD; empty for all), ordered by
+// person and start date.
+func (s *DoltStore) GetUnavailability(ctx context.Context, person, since string) ([]*types.Unavailability, error) {
+	query := "SELECT person, start_date, end_date, note, created_by, created_at FROM availability WHERE 1=1"
+	var args []interface{}
+	if person != "" {
+		query += " AND person = ?"
+		args = append(args, person)
+	}
+	if since != "" {
+		query += " AND end_date >= ?"
+		args = append(args, since)
+	}
+	rows, err := s.queryContext(ctx, query+" ORDER BY person, start_date", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability: %w", err)
+	}
+	defer rows.Close()
+
+	var periods []*types.Unavailability
+	for rows.Next() {
+		var u types.Unavailability
+		if err := rows.Scan(&u.Person, &u.Start, &u.End, &u.Note, &u.CreatedBy, &u.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan availability: %w", err)
+		}
+		periods = append(periods, &u)
+	}
+	return periods, rows.Err()
+}
@@ -0,0 +1,64 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestAvailability(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	for _, u := range []*types.Unavailability{
+		{Person: "alice", Start: "2025-07-01", End: "2025-07-14", Note: "vacation", CreatedBy: "alice"},
+		{Person: "alice", Start: "2025-08-04", End: "2025-08-04", CreatedBy: "alice"},
+		{Person: "bob", Start: "2025-06-01", End: "2025-06-02", CreatedBy: "lead"},
+	} {
+		if err := store.SetUnavailability(ctx, u); err != nil {
+			t.Fatalf("SetUnavailability: %v", err)
+		}
+	}
+
+	// Replacing a period with the same start updates it
+	if err := store.SetUnavailability(ctx, &types.Unavailability{Person: "alice", Start: "2025-07-01", End: "2025-07-18", CreatedBy: "alice"}); err != nil {
+		t.Fatalf("SetUnavailability (replace): %v", err)
+	}
+	periods, err := store.GetUnavailability(ctx, "alice", "")
+	if err != nil {
+		t.Fatalf("GetUnavailability: %v", err)
+	}
+	if len(periods) != 2 || periods[0].End != "2025-07-18" || periods[0].Note != "" {
+		t.Fatalf("alice's periods = %+v, want the replaced July period and Aug 4", periods)
+	}
+
+	// since drops periods that have ended
+	periods, err = store.GetUnavailability(ctx, "", "2025-07-01")
+	if err != nil {
+		t.Fatalf("GetUnavailability: %v", err)
+	}
+	if len(periods) != 2 || periods[0].Person != "alice" {
+		t.Errorf("periods since July = %+v, want alice's two", periods)
+	}
+
+	// Removing by range only touches overlapping periods
+	n, err := store.RemoveUnavailability(ctx, "alice", "2025-07-10", "2025-07-10")
+	if err != nil || n != 1 {
+		t.Fatalf("RemoveUnavailability(range) = %d, %v; want 1", n, err)
+	}
+	if n, err = store.RemoveUnavailability(ctx, "bob", "", ""); err != nil || n != 1 {
+		t.Fatalf("RemoveUnavailability(all) = %d, %v; want 1", n, err)
+	}
+	periods, err = store.GetUnavailability(ctx, "", "")
+	if err != nil {
+		t.Fatalf("GetUnavailability: %v", err)
+	}
+	if len(periods) != 1 || periods[0].Start != "2025-08-04" {
+		t.Errorf("remaining periods = %+v, want alice's Aug 4", periods)
+	}
+}
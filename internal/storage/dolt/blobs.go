@@ -0,0 +1,155 @@
+package dolt
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Large descriptions (agent-generated design docs can run to megabytes) are
+// moved out of the issues row into the content-addressed description_blobs
+// table. The row keeps a short reference instead, so:
+//   - updating any other field of the issue doesn't rewrite the description
+//     into new chunks (Dolt stores whole rows), keeping history and
+//     federation pushes small;
+//   - scans over the issues table don't drag the text along.
+//
+// Reads resolve references transparently, so callers always see the full
+// text. Dolt already compresses chunks on disk and on the wire, so blobs are
+// stored as plain text, which keeps them searchable with LIKE.
+
+// descriptionBlobPrefix marks a description column value as a reference to a
+// description_blobs row: the prefix followed by the hex SHA-256 of the text.
+const descriptionBlobPrefix = "beads-blob:sha256:"
+
+// descriptionBlobThreshold is the size in bytes above which a description is
+// stored as a blob. Variable for tests.
+var descriptionBlobThreshold = 32 * 1024
+
+// blobRef returns the reference stored in place of a blob's content.
+func blobRef(hash string) string {
+	return descriptionBlobPrefix + hash
+}
+
+// parseBlobRef returns the hash from a blob reference, or ok=false if value
+// is an ordinary (inline) description.
+func parseBlobRef(value string) (hash string, ok bool) {
+	hash, ok = strings.CutPrefix(value, descriptionBlobPrefix)
+	if !ok || len(hash) != sha256.Size*2 {
+		return "", false
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return "", false
+	}
+	return hash, true
+}
+
+// storeDescription returns the value to write to the description column:
+// the text itself, or a reference to a blob holding it when it exceeds
+// descriptionBlobThreshold. Blobs are content-addressed, so storing the same
+// text twice is a no-op.
+func storeDescription(ctx context.Context, tx *sql.Tx, description string) (string, error) {
+	if len(description) <= descriptionBlobThreshold {
+		return description, nil
+	}
+	hash, err := storeBlob(ctx, tx, description)
+	if err != nil {
+		return "", err
+	}
+	return blobRef(hash), nil
+}
+
+// storeBlob stores content in description_blobs, whatever its size, and
+// returns its hash. Storing the same content twice is a no-op.
+func storeBlob(ctx context.Context, tx *sql.Tx, content string) (string, error) {
+	sum := sha256.Sum256([]byte(content))
+	hash := hex.EncodeToString(sum[:])
+	if _, err := tx.ExecContext(ctx, `
+		INSERT IGNORE INTO description_blobs (hash, content, size) VALUES (?, ?, ?)
+	`, hash, content, len(content)); err != nil {
+		return "", fmt.Errorf("failed to store description blob: %w", err)
+	}
+	return hash, nil
+}
+
+// issueQuerier is the common interface of *sql.DB and *sql.Tx used to
+// resolve blob references inside or outside a transaction.
+type issueQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// resolveDescriptions replaces blob references in the issues' descriptions
+// with the blob content.
+func resolveDescriptions(ctx context.Context, q issueQuerier, issues ...*types.Issue) error {
+	byHash := make(map[string][]*types.Issue)
+	for _, issue := range issues {
+		if hash, ok := parseBlobRef(issue.Description); ok {
+			byHash[hash] = append(byHash[hash], issue)
+		}
+	}
+	if len(byHash) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(byHash))
+	args := make([]any, 0, len(byHash))
+	for hash := range byHash {
+		placeholders = append(placeholders, "?")
+		args = append(args, hash)
+	}
+	// nolint:gosec // G201: placeholders contains only ? markers
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(
+		"SELECT hash, content FROM description_blobs WHERE hash IN (%s)", strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return fmt.Errorf("failed to load description blobs: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hash, content string
+		if err := rows.Scan(&hash, &content); err != nil {
+			return fmt.Errorf("failed to scan description blob: %w", err)
+		}
+		for _, issue := range byHash[hash] {
+			issue.Description = content
+		}
+		delete(byHash, hash)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	// A missing blob (e.g. a partial federation merge) leaves the reference
+	// in place rather than failing the read.
+	return nil
+}
+
+// ResolveDescription replaces a blob reference in issue.Description with the
+// blob content. Issues read through the store are already resolved; this is
+// for issue snapshots decoded from elsewhere, such as audit log events.
+func (s *DoltStore) ResolveDescription(ctx context.Context, issue *types.Issue) error {
+	return resolveDescriptions(ctx, s.db, issue)
+}
+
+// descriptionSearchClause returns a WHERE fragment matching issues whose
+// description - inline or in a blob - contains pattern (a LIKE pattern).
+func descriptionSearchClause(pattern string) (string, []any) {
+	return "(description LIKE ? OR description IN (SELECT CONCAT('" + descriptionBlobPrefix + "', hash) FROM description_blobs WHERE content LIKE ?))",
+		[]any{pattern, pattern}
+}
+
+// eventSnapshot returns a copy of issue suitable for an event's old_value:
+// a large description is replaced by its blob reference so the audit trail
+// doesn't copy the full text on every change.
+func eventSnapshot(issue *types.Issue) *types.Issue {
+	if len(issue.Description) <= descriptionBlobThreshold {
+		return issue
+	}
+	cp := *issue
+	sum := sha256.Sum256([]byte(issue.Description))
+	cp.Description = blobRef(hex.EncodeToString(sum[:]))
+	return &cp
+}
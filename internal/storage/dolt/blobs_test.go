@@ -0,0 +1,110 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseBlobRef(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"reference", blobRef(hash), true},
+		{"plain text", "Fix the login flow", false},
+		{"empty", "", false},
+		{"short hash", descriptionBlobPrefix + "abc123", false},
+		{"not hex", descriptionBlobPrefix + strings.Repeat("zz", 32), false},
+		{"prefix mid-text", "see " + blobRef(hash), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseBlobRef(tt.value)
+			if ok != tt.want {
+				t.Fatalf("parseBlobRef(%q) ok = %v, want %v", tt.value, ok, tt.want)
+			}
+			if ok && got != hash {
+				t.Errorf("parseBlobRef() hash = %q, want %q", got, hash)
+			}
+		})
+	}
+}
+
+func TestEventSnapshot(t *testing.T) {
+	small := &types.Issue{ID: "bd-1", Description: "short"}
+	if eventSnapshot(small) != small {
+		t.Error("small description should be recorded as-is")
+	}
+
+	large := &types.Issue{ID: "bd-2", Description: strings.Repeat("x", descriptionBlobThreshold+1)}
+	snap := eventSnapshot(large)
+	if _, ok := parseBlobRef(snap.Description); !ok {
+		t.Errorf("large description not replaced by a blob reference: %.40q", snap.Description)
+	}
+	if len(large.Description) != descriptionBlobThreshold+1 {
+		t.Error("eventSnapshot modified the original issue")
+	}
+}
+
+func TestLargeDescriptionRoundTrip(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	large := "# Design\n" + strings.Repeat("The cache is keyed by filter. ", 10000) + "needle-in-blob"
+	issue := &types.Issue{
+		Title:       "Design doc",
+		Description: large,
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   types.TypeTask,
+	}
+	if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	// Stored as a reference, read back in full.
+	var raw string
+	if err := store.db.QueryRowContext(ctx, "SELECT description FROM issues WHERE id = ?", issue.ID).Scan(&raw); err != nil {
+		t.Fatalf("failed to read raw description: %v", err)
+	}
+	if _, ok := parseBlobRef(raw); !ok {
+		t.Fatalf("expected blob reference in issues row, got %d bytes", len(raw))
+	}
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if got.Description != large {
+		t.Errorf("description not resolved: got %d bytes, want %d", len(got.Description), len(large))
+	}
+
+	// Search sees blob content.
+	found, err := store.SearchIssues(ctx, "needle-in-blob", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if len(found) != 1 || found[0].Description != large {
+		t.Errorf("search for blob content returned %d issues", len(found))
+	}
+
+	// Shrinking the description stores it inline again.
+	if err := store.UpdateIssue(ctx, issue.ID, map[string]interface{}{"description": "short now"}, "tester"); err != nil {
+		t.Fatalf("failed to update issue: %v", err)
+	}
+	got, err = store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("failed to get issue: %v", err)
+	}
+	if got.Description != "short now" {
+		t.Errorf("expected inline description, got %q", got.Description)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/steveyegge/beads/internal/permissions"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -69,6 +70,10 @@ func (s *DoltStore) CheckEligibility(ctx context.Context, issueID string, tier i
 // ApplyCompaction records a compaction result in the database.
 // Updates compaction_level, compacted_at, compacted_at_commit, and original_size.
 func (s *DoltStore) ApplyCompaction(ctx context.Context, issueID string, tier int, originalSize int, _ int, commitHash string) error {
+	if err := s.authorize("", permissions.Admin); err != nil {
+		return err
+	}
+
 	_, err := s.execContext(ctx,
 		`UPDATE issues SET compaction_level = ?, compacted_at = ?, compacted_at_commit = ?, original_size = ?, updated_at = ? WHERE id = ?`,
 		tier, time.Now().UTC(), commitHash, originalSize, time.Now().UTC(), issueID)
@@ -7,10 +7,14 @@ import (
 	"strings"
 
 	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/permissions"
 )
 
 // SetConfig sets a configuration value
 func (s *DoltStore) SetConfig(ctx context.Context, key, value string) error {
+	if err := s.authorize("", configCapability(key)); err != nil {
+		return err
+	}
 	_, err := s.execContext(ctx, `
 		INSERT INTO config (`+"`key`"+`, value) VALUES (?, ?)
 		ON DUPLICATE KEY UPDATE value = VALUES(value)
@@ -31,6 +35,12 @@ func (s *DoltStore) SetConfig(ctx context.Context, key, value string) error {
 	}
 	s.cacheMu.Unlock()
 
+	if key == "status.ready" || key == "status.blocking" {
+		// Ready and blocked results depend on the status mapping
+		s.invalidateBlockedIDsCache()
+		s.invalidateQueryCache()
+	}
+
 	return nil
 }
 
@@ -74,15 +84,25 @@ func (s *DoltStore) GetAllConfig(ctx context.Context) (map[string]string, error)
 
 // DeleteConfig removes a configuration value
 func (s *DoltStore) DeleteConfig(ctx context.Context, key string) error {
+	if err := s.authorize("", configCapability(key)); err != nil {
+		return err
+	}
 	_, err := s.execContext(ctx, "DELETE FROM config WHERE `key` = ?", key)
 	if err != nil {
 		return fmt.Errorf("failed to delete config %s: %w", key, err)
 	}
+	if key == "status.ready" || key == "status.blocking" {
+		s.invalidateBlockedIDsCache()
+		s.invalidateQueryCache()
+	}
 	return nil
 }
 
 // SetMetadata sets a metadata value
 func (s *DoltStore) SetMetadata(ctx context.Context, key, value string) error {
+	if err := s.authorize("", permissions.Admin); err != nil {
+		return err
+	}
 	_, err := s.execContext(ctx, `
 		INSERT INTO metadata (`+"`key`"+`, value) VALUES (?, ?)
 		ON DUPLICATE KEY UPDATE value = VALUES(value)
@@ -144,6 +164,46 @@ func (s *DoltStore) GetCustomStatuses(ctx context.Context) ([]string, error) {
 	return result, nil
 }
 
+// defaultReadyStatuses are the statuses whose issues count as ready work
+// when they aren't blocked.
+var defaultReadyStatuses = []string{"open", "in_progress"}
+
+// defaultBlockingStatuses are the statuses in which an issue still blocks
+// the issues that depend on it.
+var defaultBlockingStatuses = []string{"open", "in_progress", "blocked", "deferred", "hooked"}
+
+// GetReadyStatuses returns the statuses eligible for ready work, from the
+// status.ready config (database first, then config.yaml). Defaults to open
+// and in_progress.
+func (s *DoltStore) GetReadyStatuses(ctx context.Context) ([]string, error) {
+	return s.getStatusList(ctx, "status.ready", defaultReadyStatuses)
+}
+
+// GetBlockingStatuses returns the statuses in which a blocker holds up its
+// dependents, from the status.blocking config (database first, then
+// config.yaml). Defaults to every status but closed, pinned, and tombstone.
+func (s *DoltStore) GetBlockingStatuses(ctx context.Context) ([]string, error) {
+	return s.getStatusList(ctx, "status.blocking", defaultBlockingStatuses)
+}
+
+// getStatusList reads a comma-separated status list config key, falling
+// back to config.yaml and then to defaults.
+func (s *DoltStore) getStatusList(ctx context.Context, key string, defaults []string) ([]string, error) {
+	value, err := s.GetConfig(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if value != "" {
+		if statuses := parseCommaSeparatedList(value); len(statuses) > 0 {
+			return statuses, nil
+		}
+	}
+	if statuses := config.GetStatusListFromYAML(key); len(statuses) > 0 {
+		return statuses, nil
+	}
+	return defaults, nil
+}
+
 // GetCustomTypes returns custom issue type values from config.
 // If the database doesn't have custom types configured, falls back to config.yaml.
 // This fallback is essential during operations when the database connection is
@@ -14,6 +14,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/steveyegge/beads/internal/permissions"
 	"github.com/steveyegge/beads/internal/storage"
 )
 
@@ -110,6 +111,10 @@ func (s *DoltStore) decryptPassword(encrypted []byte) (string, error) {
 // AddFederationPeer adds or updates a federation peer with credentials.
 // This stores credentials in the database and also adds the Dolt remote.
 func (s *DoltStore) AddFederationPeer(ctx context.Context, peer *storage.FederationPeer) error {
+	if err := s.authorize("", permissions.Federation); err != nil {
+		return err
+	}
+
 	// Validate peer name
 	if err := validatePeerName(peer.Name); err != nil {
 		return fmt.Errorf("invalid peer name: %w", err)
@@ -235,6 +240,10 @@ func (s *DoltStore) ListFederationPeers(ctx context.Context) ([]*storage.Federat
 
 // RemoveFederationPeer removes a federation peer and its credentials.
 func (s *DoltStore) RemoveFederationPeer(ctx context.Context, name string) error {
+	if err := s.authorize("", permissions.Federation); err != nil {
+		return err
+	}
+
 	result, err := s.execContext(ctx, "DELETE FROM federation_peers WHERE name = ?", name)
 	if err != nil {
 		return fmt.Errorf("failed to remove federation peer: %w", err)
@@ -4,8 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/schedule"
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -13,6 +18,10 @@ import (
 // Uses an explicit transaction so writes persist when @@autocommit is OFF
 // (e.g. Dolt server started with --no-auto-commit).
 func (s *DoltStore) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
 	// Route to wisp_dependencies if the issue is an active wisp
 	if s.isActiveWisp(ctx, dep.IssueID) {
 		return s.addWispDependency(ctx, dep, actor)
@@ -49,26 +58,10 @@ func (s *DoltStore) AddDependency(ctx context.Context, dep *types.Dependency, ac
 		}
 	}
 
-	// Cycle detection for blocking dependency types: check if adding this edge
-	// would create a cycle by seeing if depends_on_id can already reach issue_id.
+	// Cycle detection for blocking dependency types
 	if dep.Type == types.DepBlocks {
-		var reachable int
-		if err := tx.QueryRowContext(ctx, `
-			WITH RECURSIVE reachable AS (
-				SELECT ? AS node, 0 AS depth
-				UNION ALL
-				SELECT d.depends_on_id, r.depth + 1
-				FROM reachable r
-				JOIN dependencies d ON d.issue_id = r.node
-				WHERE d.type = 'blocks'
-				  AND r.depth < 100
-			)
-			SELECT COUNT(*) FROM reachable WHERE node = ?
-		`, dep.DependsOnID, dep.IssueID).Scan(&reachable); err != nil {
-			return fmt.Errorf("failed to check for dependency cycle: %w", err)
-		}
-		if reachable > 0 {
-			return fmt.Errorf("adding dependency would create a cycle")
+		if err := checkBlockingCycle(ctx, tx, dep.IssueID, dep.DependsOnID); err != nil {
+			return err
 		}
 	}
 
@@ -87,6 +80,10 @@ func (s *DoltStore) AddDependency(ctx context.Context, dep *types.Dependency, ac
 // RemoveDependency removes a dependency between two issues.
 // Uses an explicit transaction so writes persist when @@autocommit is OFF.
 func (s *DoltStore) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
 	// Route to wisp_dependencies if the issue is an active wisp
 	if s.isActiveWisp(ctx, issueID) {
 		return s.removeWispDependency(ctx, issueID, dependsOnID)
@@ -166,7 +163,7 @@ func (s *DoltStore) GetDependenciesWithMetadata(ctx context.Context, issueID str
 	// Collect dep metadata first, then close rows before fetching issues.
 	// This avoids connection pool deadlock when MaxOpenConns=1 (embedded dolt).
 	type depMeta struct {
-		depID, depType string
+		depID, depType, metadata string
 	}
 	var deps []depMeta
 	for rows.Next() {
@@ -178,7 +175,7 @@ func (s *DoltStore) GetDependenciesWithMetadata(ctx context.Context, issueID str
 			_ = rows.Close() // Best effort cleanup on error path
 			return nil, fmt.Errorf("failed to scan dependency: %w", err)
 		}
-		deps = append(deps, depMeta{depID: depID, depType: depType})
+		deps = append(deps, depMeta{depID: depID, depType: depType, metadata: metadata.String})
 	}
 	if err := rows.Err(); err != nil {
 		_ = rows.Close() // Best effort cleanup on error path
@@ -210,10 +207,14 @@ func (s *DoltStore) GetDependenciesWithMetadata(ctx context.Context, issueID str
 		if !ok {
 			continue
 		}
-		results = append(results, &types.IssueWithDependencyMetadata{
+		result := &types.IssueWithDependencyMetadata{
 			Issue:          *issue,
 			DependencyType: types.DependencyType(d.depType),
-		})
+		}
+		if meta := types.ParseScheduleMeta(d.metadata); result.DependencyType == types.DepBlocks && !meta.IsZero() {
+			result.Schedule = &meta
+		}
+		results = append(results, result)
 	}
 	return results, nil
 }
@@ -236,7 +237,7 @@ func (s *DoltStore) GetDependentsWithMetadata(ctx context.Context, issueID strin
 	// Collect dep metadata first, then close rows before fetching issues.
 	// This avoids connection pool deadlock when MaxOpenConns=1 (embedded dolt).
 	type depMeta struct {
-		depID, depType string
+		depID, depType, metadata string
 	}
 	var deps []depMeta
 	for rows.Next() {
@@ -248,7 +249,7 @@ func (s *DoltStore) GetDependentsWithMetadata(ctx context.Context, issueID strin
 			_ = rows.Close() // Best effort cleanup on error path
 			return nil, fmt.Errorf("failed to scan dependent: %w", err)
 		}
-		deps = append(deps, depMeta{depID: depID, depType: depType})
+		deps = append(deps, depMeta{depID: depID, depType: depType, metadata: metadata.String})
 	}
 	if err := rows.Err(); err != nil {
 		_ = rows.Close() // Best effort cleanup on error path
@@ -280,10 +281,14 @@ func (s *DoltStore) GetDependentsWithMetadata(ctx context.Context, issueID strin
 		if !ok {
 			continue
 		}
-		results = append(results, &types.IssueWithDependencyMetadata{
+		result := &types.IssueWithDependencyMetadata{
 			Issue:          *issue,
 			DependencyType: types.DependencyType(d.depType),
-		})
+		}
+		if meta := types.ParseScheduleMeta(d.metadata); result.DependencyType == types.DepBlocks && !meta.IsZero() {
+			result.Schedule = &meta
+		}
+		results = append(results, result)
 	}
 	return results, nil
 }
@@ -661,15 +666,14 @@ func (s *DoltStore) buildDependencyTree(ctx context.Context, issueID string, dep
 	return nodes, nil
 }
 
-// DetectCycles finds circular dependencies
+// DetectCycles finds circular blocking dependencies. Each cycle lists its
+// issues in dependency order: each depends on the next, and the last on
+// the first.
 func (s *DoltStore) DetectCycles(ctx context.Context) ([][]*types.Issue, error) {
-	// Get all dependencies
 	deps, err := s.GetAllDependencyRecords(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	// Build adjacency list
 	graph := make(map[string][]string)
 	for issueID, records := range deps {
 		for _, dep := range records {
@@ -679,87 +683,189 @@ func (s *DoltStore) DetectCycles(ctx context.Context) ([][]*types.Issue, error)
 		}
 	}
 
-	// Find cycles using DFS
 	var cycles [][]*types.Issue
-	visited := make(map[string]bool)
-	recStack := make(map[string]bool)
-	path := make([]string, 0)
+	for _, cyclePath := range findCycles(graph) {
+		var cycleIssues []*types.Issue
+		for _, id := range cyclePath {
+			issue, _ := s.GetIssue(ctx, id) // Best effort: nil issue handled by caller
+			if issue != nil {
+				cycleIssues = append(cycleIssues, issue)
+			}
+		}
+		if len(cycleIssues) > 0 {
+			cycles = append(cycles, cycleIssues)
+		}
+	}
+	return cycles, nil
+}
 
-	var dfs func(node string) bool
-	dfs = func(node string) bool {
+// findCycles finds cycles in graph, which maps each issue to the issues it
+// depends on, with a depth-first search that reports one cycle per back
+// edge. Issues are visited in ID order, so the result is stable.
+func findCycles(graph map[string][]string) [][]string {
+	nodes := make([]string, 0, len(graph))
+	for node, next := range graph {
+		nodes = append(nodes, node)
+		sort.Strings(next)
+	}
+	sort.Strings(nodes)
+
+	var cycles [][]string
+	visited := make(map[string]bool)
+	onPath := make(map[string]bool)
+	var path []string
+	var dfs func(node string)
+	dfs = func(node string) {
 		visited[node] = true
-		recStack[node] = true
+		onPath[node] = true
 		path = append(path, node)
-
-		for _, neighbor := range graph[node] {
-			if !visited[neighbor] {
-				if dfs(neighbor) {
-					return true
-				}
-			} else if recStack[neighbor] {
-				// Found cycle - extract it
-				cycleStart := -1
-				for i, n := range path {
-					if n == neighbor {
-						cycleStart = i
-						break
-					}
-				}
-				if cycleStart >= 0 {
-					cyclePath := path[cycleStart:]
-					var cycleIssues []*types.Issue
-					for _, id := range cyclePath {
-						issue, _ := s.GetIssue(ctx, id) // Best effort: nil issue handled by caller
-						if issue != nil {
-							cycleIssues = append(cycleIssues, issue)
-						}
-					}
-					if len(cycleIssues) > 0 {
-						cycles = append(cycles, cycleIssues)
-					}
-				}
+		for _, next := range graph[node] {
+			if !visited[next] {
+				dfs(next)
+			} else if onPath[next] {
+				start := slices.Index(path, next)
+				cycles = append(cycles, slices.Clone(path[start:]))
 			}
 		}
-
 		path = path[:len(path)-1]
-		recStack[node] = false
-		return false
+		onPath[node] = false
 	}
-
-	for node := range graph {
+	for _, node := range nodes {
 		if !visited[node] {
 			dfs(node)
 		}
 	}
+	return cycles
+}
 
-	return cycles, nil
+// checkBlockingCycle returns a *storage.CycleError if a blocks dependency
+// of issueID on dependsOnID would close a cycle, that is, if dependsOnID
+// already reaches issueID through blocks dependencies.
+func checkBlockingCycle(ctx context.Context, tx *sql.Tx, issueID, dependsOnID string) error {
+	var reachable int
+	if err := tx.QueryRowContext(ctx, `
+		WITH RECURSIVE reachable AS (
+			SELECT ? AS node, 0 AS depth
+			UNION ALL
+			SELECT d.depends_on_id, r.depth + 1
+			FROM reachable r
+			JOIN dependencies d ON d.issue_id = r.node
+			WHERE d.type = 'blocks'
+			  AND r.depth < 100
+		)
+		SELECT COUNT(*) FROM reachable WHERE node = ?
+	`, dependsOnID, issueID).Scan(&reachable); err != nil {
+		return fmt.Errorf("failed to check for dependency cycle: %w", err)
+	}
+	if reachable == 0 {
+		return nil
+	}
+
+	// Only a rejected dependency pays for reading the graph to explain why
+	rows, err := tx.QueryContext(ctx, `SELECT issue_id, depends_on_id FROM dependencies WHERE type = 'blocks'`)
+	if err != nil {
+		return fmt.Errorf("failed to read dependency cycle: %w", err)
+	}
+	defer rows.Close()
+	graph := make(map[string][]string)
+	for rows.Next() {
+		var from, to string
+		if err := rows.Scan(&from, &to); err != nil {
+			return fmt.Errorf("failed to read dependency cycle: %w", err)
+		}
+		graph[from] = append(graph[from], to)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read dependency cycle: %w", err)
+	}
+	path := dependencyPath(graph, dependsOnID, issueID)
+	if path == nil {
+		return storage.ErrCycle
+	}
+	return &storage.CycleError{Path: append([]string{issueID}, path...)}
+}
+
+// dependencyPath returns the shortest path from one issue to another in
+// graph, both included, or nil if there is none.
+func dependencyPath(graph map[string][]string, from, to string) []string {
+	prev := map[string]string{from: from}
+	queue := []string{from}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == to {
+			path := []string{to}
+			for node != from {
+				node = prev[node]
+				path = append(path, node)
+			}
+			slices.Reverse(path)
+			return path
+		}
+		for _, next := range graph[node] {
+			if _, seen := prev[next]; !seen {
+				prev[next] = node
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil
 }
 
-// IsBlocked checks if an issue has open blockers
+// IsBlocked checks if an issue has open blockers, i.e. whether it can be
+// closed. Dependencies with scheduling semantics block closing until their
+// constraint is met (see schedule.Satisfied).
 func (s *DoltStore) IsBlocked(ctx context.Context, issueID string) (bool, []string, error) {
 	rows, err := s.queryContext(ctx, `
-		SELECT d.depends_on_id
+		SELECT d.depends_on_id, d.metadata, i.status
 		FROM dependencies d
 		JOIN issues i ON d.depends_on_id = i.id
 		WHERE d.issue_id = ?
 		  AND d.type = 'blocks'
-		  AND i.status IN ('open', 'in_progress', 'blocked', 'deferred', 'hooked')
 	`, issueID)
 	if err != nil {
 		return false, nil, fmt.Errorf("failed to check blockers: %w", err)
 	}
-	defer rows.Close()
 
 	var blockers []string
+	var scheduled []scheduledDep
 	for rows.Next() {
 		var id string
-		if err := rows.Scan(&id); err != nil {
+		var metadata sql.NullString
+		var status types.Status
+		if err := rows.Scan(&id, &metadata, &status); err != nil {
+			_ = rows.Close() // Best effort cleanup on error path
 			return false, nil, err
 		}
-		blockers = append(blockers, id)
+		if meta := types.ParseScheduleMeta(metadata.String); !meta.IsZero() {
+			scheduled = append(scheduled, scheduledDep{issueID, id, meta})
+		} else if isActiveStatus(status) {
+			blockers = append(blockers, id)
+		}
+	}
+	_ = rows.Close() // Redundant close for safety (rows already iterated)
+	if err := rows.Err(); err != nil {
+		return false, nil, err
 	}
 
-	return len(blockers) > 0, blockers, rows.Err()
+	unsatisfied, err := s.unsatisfiedScheduledDeps(ctx, scheduled, schedule.Finish)
+	if err != nil {
+		return false, nil, err
+	}
+	for _, d := range unsatisfied {
+		blockers = append(blockers, d.blockerID)
+	}
+	return len(blockers) > 0, blockers, nil
+}
+
+// isActiveStatus reports whether an issue in status still blocks its
+// dependents.
+func isActiveStatus(status types.Status) bool {
+	switch status {
+	case types.StatusOpen, types.StatusInProgress, types.StatusBlocked, types.StatusDeferred, types.StatusHooked:
+		return true
+	}
+	return false
 }
 
 // GetNewlyUnblockedByClose finds issues that become unblocked when an issue is closed
@@ -786,7 +892,58 @@ func (s *DoltStore) GetNewlyUnblockedByClose(ctx context.Context, closedIssueID
 	}
 	defer rows.Close()
 
-	return s.scanIssueIDs(ctx, rows)
+	issues, err := s.scanIssueIDs(ctx, rows)
+	if err != nil || len(issues) == 0 {
+		return issues, err
+	}
+
+	// With scheduling semantics, closing may not unblock yet (a lag is
+	// running), or the dependent wasn't waiting for the close at all
+	// (start-to-start, finish-to-finish).
+	deps, err := s.GetDependencyRecordsForIssues(ctx, issueIDsOf(issues))
+	if err != nil {
+		return nil, err
+	}
+	var scheduled []scheduledDep
+	waiting := make(map[string]bool)
+	for _, issue := range issues {
+		waiting[issue.ID] = true
+		for _, dep := range deps[issue.ID] {
+			if dep.Type != types.DepBlocks {
+				continue
+			}
+			meta := types.ParseScheduleMeta(dep.Metadata)
+			if meta.IsZero() {
+				continue
+			}
+			if dep.DependsOnID == closedIssueID && meta.RelationOrDefault() != types.RelationFinishToStart {
+				waiting[issue.ID] = false
+			}
+			scheduled = append(scheduled, scheduledDep{issue.ID, dep.DependsOnID, meta})
+		}
+	}
+	unsatisfied, err := s.unsatisfiedScheduledDeps(ctx, scheduled, schedule.Start)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range unsatisfied {
+		waiting[d.issueID] = false
+	}
+	var result []*types.Issue
+	for _, issue := range issues {
+		if waiting[issue.ID] {
+			result = append(result, issue)
+		}
+	}
+	return result, nil
+}
+
+func issueIDsOf(issues []*types.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
 }
 
 // Helper functions
@@ -897,8 +1054,15 @@ func (s *DoltStore) getIssuesByIDsDolt(ctx context.Context, ids []string) ([]*ty
 		}
 		issues = append(issues, issue)
 	}
+	if err := queryRows.Err(); err != nil {
+		return nil, err
+	}
+	_ = queryRows.Close() // Release the connection before resolving blobs
 
-	return issues, queryRows.Err()
+	if err := resolveDescriptions(ctx, s.reader(ctx), issues...); err != nil {
+		return nil, err
+	}
+	return issues, nil
 }
 
 func scanDependencyRows(rows *sql.Rows) ([]*types.Dependency, error) {
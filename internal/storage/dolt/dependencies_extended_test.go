@@ -3,8 +3,12 @@
 package dolt
 
 import (
+	"errors"
+	"reflect"
+	"slices"
 	"testing"
 
+	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -441,9 +445,25 @@ func TestDetectCycles_WithCycle(t *testing.T) {
 
 	// Third dep would create cycle - should be rejected
 	dep3 := &types.Dependency{IssueID: issueC.ID, DependsOnID: issueA.ID, Type: types.DepBlocks}
-	if err := store.AddDependency(ctx, dep3, "tester"); err == nil {
+	err := store.AddDependency(ctx, dep3, "tester")
+	if err == nil {
 		t.Fatal("expected AddDependency to fail when creating cycle, but it succeeded")
 	}
+	var cycleErr *storage.CycleError
+	if !errors.As(err, &cycleErr) || !errors.Is(err, storage.ErrCycle) {
+		t.Fatalf("AddDependency error = %v, want a *storage.CycleError", err)
+	}
+	if want := []string{"cycle-c", "cycle-a", "cycle-b", "cycle-c"}; !slices.Equal(cycleErr.Path, want) {
+		t.Errorf("cycle path = %v, want %v", cycleErr.Path, want)
+	}
+
+	// A transaction is held to the same rule
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		return tx.AddDependency(ctx, dep3, "tester")
+	})
+	if !errors.As(err, &cycleErr) {
+		t.Errorf("transactional AddDependency error = %v, want a *storage.CycleError", err)
+	}
 
 	// Since cycle was prevented, DetectCycles should find nothing
 	cycles, err := store.DetectCycles(ctx)
@@ -456,6 +476,43 @@ func TestDetectCycles_WithCycle(t *testing.T) {
 	}
 }
 
+func TestFindCycles(t *testing.T) {
+	graph := map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a", "d"},
+		"d": {},
+		"e": {"e"},
+		"f": {"b"},
+	}
+	want := [][]string{{"a", "b", "c"}, {"e"}}
+	if got := findCycles(graph); !reflect.DeepEqual(got, want) {
+		t.Errorf("findCycles = %v, want %v", got, want)
+	}
+	if got := findCycles(map[string][]string{"a": {"b"}, "b": {"c"}}); len(got) != 0 {
+		t.Errorf("findCycles on a chain = %v, want none", got)
+	}
+}
+
+func TestDependencyPath(t *testing.T) {
+	graph := map[string][]string{
+		"a": {"b", "x"},
+		"b": {"c"},
+		"x": {"y"},
+		"y": {"z"},
+		"z": {"c"},
+	}
+	if got, want := dependencyPath(graph, "a", "c"), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("dependencyPath(a, c) = %v, want the shortest path %v", got, want)
+	}
+	if got := dependencyPath(graph, "c", "a"); got != nil {
+		t.Errorf("dependencyPath(c, a) = %v, want nil", got)
+	}
+	if got, want := dependencyPath(graph, "a", "a"), []string{"a"}; !slices.Equal(got, want) {
+		t.Errorf("dependencyPath(a, a) = %v, want %v", got, want)
+	}
+}
+
 // =============================================================================
 // GetNewlyUnblockedByClose Tests
 // =============================================================================
@@ -1256,6 +1256,9 @@ func TestValidateRef(t *testing.T) {
 		{"valid branch", "main", false},
 		{"valid with underscore", "feature_branch", false},
 		{"valid with dash", "feature-branch", false},
+		{"valid ancestor", "HEAD~1", false},
+		{"valid parent", "main^", false},
+		{"ancestry without base", "~1", true},
 		{"empty", "", true},
 		{"too long", string(make([]byte, 200)), true},
 		{"with SQL injection", "main'; DROP TABLE issues; --", true},
@@ -0,0 +1,116 @@
+package dolt
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/permissions"
+)
+
+// Embedding is a cached text embedding of an issue.
+type Embedding struct {
+	IssueID     string
+	Model       string    // Embedder model identifier the vector came from
+	ContentHash string    // Hash of the issue text the vector was computed from
+	Vector      []float32 // The embedding itself
+}
+
+// GetEmbeddings returns the cached embeddings of the given issues for model,
+// keyed by issue ID. Issues without a cached embedding are omitted.
+func (s *DoltStore) GetEmbeddings(ctx context.Context, model string, issueIDs []string) (map[string]*Embedding, error) {
+	result := make(map[string]*Embedding)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+	placeholders := make([]string, len(issueIDs))
+	args := make([]interface{}, 0, len(issueIDs)+1)
+	args = append(args, model)
+	for i, id := range issueIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	// nolint:gosec // G201: placeholders contains only ? markers, actual values passed via args
+	query := fmt.Sprintf(`
+		SELECT issue_id, content_hash, vector FROM issue_embeddings
+		WHERE model = ? AND issue_id IN (%s)
+	`, strings.Join(placeholders, ","))
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get embeddings: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		e := &Embedding{Model: model}
+		var blob []byte
+		if err := rows.Scan(&e.IssueID, &e.ContentHash, &blob); err != nil {
+			return nil, fmt.Errorf("failed to scan embedding: %w", err)
+		}
+		if e.Vector, err = decodeVector(blob); err != nil {
+			return nil, fmt.Errorf("embedding for %s: %w", e.IssueID, err)
+		}
+		result[e.IssueID] = e
+	}
+	return result, rows.Err()
+}
+
+// SaveEmbeddings stores embeddings, replacing any cached for the same
+// issue and model.
+func (s *DoltStore) SaveEmbeddings(ctx context.Context, embeddings []*Embedding) error {
+	if err := s.authorize("", permissions.Update); err != nil {
+		return err
+	}
+	for _, e := range embeddings {
+		_, err := s.execContext(ctx, `
+			REPLACE INTO issue_embeddings (issue_id, model, content_hash, dimensions, vector, updated_at)
+			VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		`, e.IssueID, e.Model, e.ContentHash, len(e.Vector), encodeVector(e.Vector))
+		if err != nil {
+			return fmt.Errorf("failed to save embedding for %s: %w", e.IssueID, err)
+		}
+	}
+	return nil
+}
+
+// DeleteEmbeddings drops every cached embedding for model, or for all
+// models if model is empty. It returns the number of rows removed.
+func (s *DoltStore) DeleteEmbeddings(ctx context.Context, model string) (int64, error) {
+	if err := s.authorize("", permissions.Update); err != nil {
+		return 0, err
+	}
+	query, args := "DELETE FROM issue_embeddings", []interface{}{}
+	if model != "" {
+		query += " WHERE model = ?"
+		args = append(args, model)
+	}
+	result, err := s.execContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete embeddings: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return n, nil
+}
+
+// encodeVector packs a vector as little-endian float32s.
+func encodeVector(v []float32) []byte {
+	buf := make([]byte, 4*len(v))
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(x))
+	}
+	return buf
+}
+
+// decodeVector unpacks a vector written by encodeVector.
+func decodeVector(buf []byte) ([]float32, error) {
+	if len(buf)%4 != 0 {
+		return nil, fmt.Errorf("corrupt vector of %d bytes", len(buf))
+	}
+	v := make([]float32, len(buf)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+	}
+	return v, nil
+}
@@ -0,0 +1,59 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestVectorEncoding(t *testing.T) {
+	v := []float32{0, 1.5, -2.25, 3e-7}
+	got, err := decodeVector(encodeVector(v))
+	if err != nil || len(got) != len(v) {
+		t.Fatalf("decodeVector = %v, %v", got, err)
+	}
+	for i := range v {
+		if got[i] != v[i] {
+			t.Errorf("got[%d] = %v, want %v", i, got[i], v[i])
+		}
+	}
+	if _, err := decodeVector([]byte{1, 2, 3}); err == nil {
+		t.Error("expected error for a truncated vector")
+	}
+}
+
+func TestEmbeddings(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	issue := &types.Issue{Title: "Embedded", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	for _, hash := range []string{"old", "new"} {
+		err := store.SaveEmbeddings(ctx, []*Embedding{{IssueID: issue.ID, Model: "local/test", ContentHash: hash, Vector: []float32{1, 0.5}}})
+		if err != nil {
+			t.Fatalf("SaveEmbeddings: %v", err)
+		}
+	}
+	got, err := store.GetEmbeddings(ctx, "local/test", []string{issue.ID, "missing"})
+	if err != nil {
+		t.Fatalf("GetEmbeddings: %v", err)
+	}
+	if len(got) != 1 || got[issue.ID].ContentHash != "new" || got[issue.ID].Vector[1] != 0.5 {
+		t.Errorf("embeddings = %+v", got)
+	}
+	if other, _ := store.GetEmbeddings(ctx, "other/model", []string{issue.ID}); len(other) != 0 {
+		t.Errorf("embeddings from another model should not be returned: %+v", other)
+	}
+
+	if n, err := store.DeleteEmbeddings(ctx, ""); err != nil || n != 1 {
+		t.Errorf("DeleteEmbeddings = %d, %v", n, err)
+	}
+}
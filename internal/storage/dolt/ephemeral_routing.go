@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/steveyegge/beads/internal/permissions"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -55,6 +56,10 @@ func partitionIDs(ids []string) (ephIDs, doltIDs []string) {
 // Uses direct SQL inserts to bypass IsEphemeralID routing, which would otherwise
 // redirect label/dependency/event writes back to wisp tables.
 func (s *DoltStore) PromoteFromEphemeral(ctx context.Context, id string, actor string) error {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
 	issue, err := s.getWisp(ctx, id)
 	if errors.Is(err, storage.ErrNotFound) {
 		return fmt.Errorf("wisp %s not found", id)
@@ -0,0 +1,105 @@
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AcknowledgeIssue records that actor has seen an issue, which stops its
+// escalation chain. note is optional.
+func (s *DoltStore) AcknowledgeIssue(ctx context.Context, id, actor, note string) error {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
+	if s.isActiveWisp(ctx, id) {
+		return fmt.Errorf("cannot acknowledge ephemeral issue %s", id)
+	}
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get issue for acknowledgment: %w", err)
+	}
+	if issue.Status == types.StatusClosed {
+		return fmt.Errorf("cannot acknowledge closed issue %s", id)
+	}
+	if _, err := s.execContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, id, types.EventAcknowledged, actor, note); err != nil {
+		return fmt.Errorf("failed to acknowledge issue: %w", err)
+	}
+	return nil
+}
+
+// RecordEscalation records that step level (1-based) of an issue's
+// escalation chain notified contact.
+func (s *DoltStore) RecordEscalation(ctx context.Context, id, actor string, level int, contact string) error {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
+	if _, err := s.execContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, new_value, comment)
+		VALUES (?, ?, ?, ?, ?)
+	`, id, types.EventEscalated, actor, strconv.Itoa(level), contact); err != nil {
+		return fmt.Errorf("failed to record escalation: %w", err)
+	}
+	return nil
+}
+
+// GetEscalationStates returns the escalation state of each of issueIDs that
+// has been escalated or acknowledged, keyed by issue ID.
+func (s *DoltStore) GetEscalationStates(ctx context.Context, issueIDs []string) (map[string]*types.EscalationState, error) {
+	states := make(map[string]*types.EscalationState)
+	if len(issueIDs) == 0 {
+		return states, nil
+	}
+	args := []interface{}{types.EventAcknowledged, types.EventEscalated}
+	for _, id := range issueIDs {
+		args = append(args, id)
+	}
+	// nolint:gosec // G201: only placeholders are interpolated
+	rows, err := s.queryContext(ctx, fmt.Sprintf(`
+		SELECT issue_id, event_type, actor, new_value, created_at
+		FROM events
+		WHERE event_type IN (?, ?) AND issue_id IN (%s)
+		ORDER BY created_at
+	`, placeholders(len(issueIDs))), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get escalation states: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, actor string
+		var eventType types.EventType
+		var newValue *string
+		var at time.Time
+		if err := rows.Scan(&id, &eventType, &actor, &newValue, &at); err != nil {
+			return nil, fmt.Errorf("failed to scan escalation event: %w", err)
+		}
+		state := states[id]
+		if state == nil {
+			state = &types.EscalationState{}
+			states[id] = state
+		}
+		switch eventType {
+		case types.EventAcknowledged:
+			if state.AcknowledgedAt == nil {
+				state.AcknowledgedBy, state.AcknowledgedAt = actor, &at
+			}
+		case types.EventEscalated:
+			if newValue != nil {
+				if level, err := strconv.Atoi(*newValue); err == nil && level > state.Level {
+					state.Level = level
+				}
+			}
+		}
+	}
+	return states, rows.Err()
+}
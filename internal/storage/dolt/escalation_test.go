@@ -0,0 +1,56 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEscalationStates(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	for _, id := range []string{"test-esc1", "test-esc2", "test-esc3"} {
+		issue := &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: 0, IssueType: types.TypeBug}
+		if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+			t.Fatalf("CreateIssue(%s): %v", id, err)
+		}
+	}
+	for level, contact := range []string{"alice", "lead"} {
+		if err := store.RecordEscalation(ctx, "test-esc1", "tester", level+1, contact); err != nil {
+			t.Fatalf("RecordEscalation: %v", err)
+		}
+	}
+	if err := store.RecordEscalation(ctx, "test-esc2", "tester", 1, "bob"); err != nil {
+		t.Fatalf("RecordEscalation: %v", err)
+	}
+	if err := store.AcknowledgeIssue(ctx, "test-esc2", "bob", "on it"); err != nil {
+		t.Fatalf("AcknowledgeIssue: %v", err)
+	}
+
+	states, err := store.GetEscalationStates(ctx, []string{"test-esc1", "test-esc2", "test-esc3"})
+	if err != nil {
+		t.Fatalf("GetEscalationStates: %v", err)
+	}
+	if s := states["test-esc1"]; s == nil || s.Level != 2 || s.AcknowledgedAt != nil {
+		t.Errorf("test-esc1 state = %+v, want level 2, unacknowledged", s)
+	}
+	if s := states["test-esc2"]; s == nil || s.Level != 1 || s.AcknowledgedBy != "bob" || s.AcknowledgedAt == nil {
+		t.Errorf("test-esc2 state = %+v, want level 1, acknowledged by bob", s)
+	}
+	if s := states["test-esc3"]; s != nil {
+		t.Errorf("test-esc3 state = %+v, want none", s)
+	}
+
+	if err := store.CloseIssue(ctx, "test-esc3", "done", "tester", ""); err != nil {
+		t.Fatalf("CloseIssue: %v", err)
+	}
+	if err := store.AcknowledgeIssue(ctx, "test-esc3", "bob", ""); err == nil {
+		t.Error("acknowledging a closed issue should fail")
+	}
+}
@@ -3,14 +3,20 @@ package dolt
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
+	"github.com/steveyegge/beads/internal/permissions"
 	"github.com/steveyegge/beads/internal/types"
 )
 
 // AddComment adds a comment event to an issue
 func (s *DoltStore) AddComment(ctx context.Context, issueID, actor, comment string) error {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
 	table := wispEventTable(issueID)
 	if IsEphemeralID(issueID) && !s.isActiveWisp(ctx, issueID) {
 		table = "events" // Promoted wisp — use permanent table
@@ -77,14 +83,136 @@ func (s *DoltStore) GetAllEventsSince(ctx context.Context, sinceID int64) ([]*ty
 	return scanEvents(rows)
 }
 
+// GetStatusChanges returns the status changes in each issue's event
+// history, oldest first: status updates, claims, closes, and reopens.
+// Issues whose status never changed are absent.
+func (s *DoltStore) GetStatusChanges(ctx context.Context, ids []string) (map[string][]types.StatusChange, error) {
+	result := make(map[string][]types.StatusChange)
+	if len(ids) == 0 {
+		return result, nil
+	}
+	inClause, args := doltBuildSQLInClause(ids)
+	//nolint:gosec // G201: inClause contains only ? placeholders
+	rows, err := s.queryContext(ctx, fmt.Sprintf(`
+		SELECT id, issue_id, event_type, actor, old_value, new_value, comment, created_at
+		FROM events
+		WHERE issue_id IN (%s) AND event_type IN (?, ?, ?, 'claimed')
+		ORDER BY created_at, id
+	`, inClause), append(args, types.EventStatusChanged, types.EventClosed, types.EventReopened)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status changes: %w", err)
+	}
+	defer rows.Close()
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		changes := result[event.IssueID]
+		change, ok := statusChangeOf(event)
+		if !ok {
+			continue
+		}
+		if change.From == "" && len(changes) > 0 {
+			change.From = changes[len(changes)-1].To
+		}
+		if change.From == change.To {
+			continue
+		}
+		result[event.IssueID] = append(changes, change)
+	}
+	return result, nil
+}
+
+// GetReopenHistory returns each issue's reopen count and most recent
+// closer, from its closed and reopened events. With no ids it covers every
+// issue. Issues never closed are absent.
+func (s *DoltStore) GetReopenHistory(ctx context.Context, ids []string) (map[string]*types.ReopenHistory, error) {
+	query := `
+		SELECT issue_id, event_type, actor, created_at FROM events
+		WHERE event_type IN (?, ?)`
+	args := []interface{}{types.EventClosed, types.EventReopened}
+	if len(ids) > 0 {
+		inClause, idArgs := doltBuildSQLInClause(ids)
+		query += ` AND issue_id IN (` + inClause + `)`
+		args = append(args, idArgs...)
+	}
+	rows, err := s.queryContext(ctx, query+` ORDER BY created_at, id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reopen history: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]*types.ReopenHistory)
+	for rows.Next() {
+		var issueID, actor string
+		var eventType types.EventType
+		var at time.Time
+		if err := rows.Scan(&issueID, &eventType, &actor, &at); err != nil {
+			return nil, fmt.Errorf("failed to scan reopen history: %w", err)
+		}
+		h := result[issueID]
+		if h == nil {
+			h = &types.ReopenHistory{}
+			result[issueID] = h
+		}
+		if eventType == types.EventClosed {
+			h.LastClosedBy = actor
+		} else {
+			h.Reopens++
+			h.LastReopenedAt = &at
+		}
+	}
+	return result, rows.Err()
+}
+
+// statusChangeOf reads the status change recorded by a status_changed,
+// claimed, closed, or reopened event. Updates and claims carry the issue's
+// previous state in old_value and the changed fields in new_value.
+func statusChangeOf(event *types.Event) (types.StatusChange, bool) {
+	change := types.StatusChange{At: event.CreatedAt}
+	switch event.EventType {
+	case types.EventClosed:
+		change.To = types.StatusClosed
+	case "claimed":
+		change.To = types.StatusInProgress
+	default:
+		var updates struct {
+			Status types.Status `json:"status"`
+		}
+		if event.NewValue == nil || json.Unmarshal([]byte(*event.NewValue), &updates) != nil || updates.Status == "" {
+			return change, false
+		}
+		change.To = updates.Status
+	}
+	if event.OldValue != nil {
+		var old struct {
+			Status types.Status `json:"status"`
+		}
+		if json.Unmarshal([]byte(*event.OldValue), &old) == nil {
+			change.From = old.Status
+		}
+	}
+	return change, true
+}
+
 // AddIssueComment adds a comment to an issue (structured comment)
 func (s *DoltStore) AddIssueComment(ctx context.Context, issueID, author, text string) (*types.Comment, error) {
+	if err := s.authorize(author, permissions.Update); err != nil {
+		return nil, err
+	}
+
 	return s.ImportIssueComment(ctx, issueID, author, text, time.Now().UTC())
 }
 
 // ImportIssueComment adds a comment during import, preserving the original timestamp.
 // This prevents comment timestamp drift across JSONL sync cycles.
 func (s *DoltStore) ImportIssueComment(ctx context.Context, issueID, author, text string, createdAt time.Time) (*types.Comment, error) {
+	if err := s.authorize("", permissions.Update); err != nil {
+		return nil, err
+	}
+
 	// Verify issue exists — route to wisps table for active wisps
 	issueTable := wispIssueTable(issueID)
 	commentTable := wispCommentTable(issueID)
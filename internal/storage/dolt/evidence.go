@@ -0,0 +1,118 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddEvidence attaches e to e.IssueID. A file's content is stored in
+// description_blobs, so the same report attached twice is kept once; e's
+// ContentHash and Size are set from it. e.ID is set to the new row's ID.
+func (s *DoltStore) AddEvidence(ctx context.Context, e *types.Evidence, content []byte) error {
+	if err := s.authorize(e.AddedBy, permissions.Close); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // No-op after successful commit
+
+	if err := insertEvidence(ctx, tx, e, content); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// AddEvidence is DoltStore.AddEvidence as part of the transaction, so
+// evidence can be attached in the same transaction that closes an issue.
+// It isn't part of storage.Transaction; callers find it by type assertion.
+func (t *doltTransaction) AddEvidence(ctx context.Context, e *types.Evidence, content []byte) error {
+	if err := t.authorize(e.AddedBy, permissions.Close); err != nil {
+		return err
+	}
+	return insertEvidence(ctx, t.tx, e, content)
+}
+
+// insertEvidence adds e to issue_evidence in tx.
+func insertEvidence(ctx context.Context, tx *sql.Tx, e *types.Evidence, content []byte) error {
+	switch e.Kind {
+	case types.EvidenceURL, types.EvidenceFile:
+	default:
+		return fmt.Errorf("unknown evidence kind %q", e.Kind)
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now().UTC()
+	}
+
+	var hash sql.NullString
+	if e.Kind == types.EvidenceFile {
+		h, err := storeBlob(ctx, tx, string(content))
+		if err != nil {
+			return err
+		}
+		hash = sql.NullString{String: h, Valid: true}
+		e.ContentHash, e.Size = h, len(content)
+	}
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO issue_evidence (issue_id, kind, ref, content_hash, size, added_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, e.IssueID, e.Kind, e.Ref, hash, e.Size, e.AddedBy, e.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add evidence to %s: %w", e.IssueID, err)
+	}
+	if e.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("failed to get evidence ID: %w", err)
+	}
+	return nil
+}
+
+// GetEvidence returns the evidence attached to the issues, oldest first,
+// keyed by issue ID. Issues without evidence are absent.
+func (s *DoltStore) GetEvidence(ctx context.Context, ids []string) (map[string][]*types.Evidence, error) {
+	result := make(map[string][]*types.Evidence)
+	if len(ids) == 0 {
+		return result, nil
+	}
+	inClause, args := doltBuildSQLInClause(ids)
+	rows, err := s.queryContext(ctx, `
+		SELECT id, issue_id, kind, ref, content_hash, size, added_by, created_at
+		FROM issue_evidence WHERE issue_id IN (`+inClause+`) ORDER BY created_at, id`, args...) //nolint:gosec // G202: placeholders only
+	if err != nil {
+		return nil, fmt.Errorf("failed to get evidence: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e types.Evidence
+		var hash sql.NullString
+		if err := rows.Scan(&e.ID, &e.IssueID, &e.Kind, &e.Ref, &hash, &e.Size, &e.AddedBy, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan evidence: %w", err)
+		}
+		e.ContentHash = hash.String
+		result[e.IssueID] = append(result[e.IssueID], &e)
+	}
+	return result, rows.Err()
+}
+
+// GetEvidenceContent returns the content of an evidence file by its hash.
+func (s *DoltStore) GetEvidenceContent(ctx context.Context, hash string) (string, error) {
+	var content string
+	err := s.queryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(&content)
+	}, `SELECT content FROM description_blobs WHERE hash = ?`, hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("evidence content %s not found", hash)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get evidence content: %w", err)
+	}
+	return content, nil
+}
@@ -0,0 +1,70 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestEvidence(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	issue := &types.Issue{Title: "Flaky parser", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	link := &types.Evidence{IssueID: issue.ID, Kind: types.EvidenceURL, Ref: "https://ci.example.com/runs/7", AddedBy: "tester"}
+	if err := store.AddEvidence(ctx, link, nil); err != nil {
+		t.Fatalf("AddEvidence (url): %v", err)
+	}
+	report := &types.Evidence{IssueID: issue.ID, Kind: types.EvidenceFile, Ref: "junit.xml", AddedBy: "tester"}
+	if err := store.AddEvidence(ctx, report, []byte("<testsuite tests=\"3\"/>")); err != nil {
+		t.Fatalf("AddEvidence (file): %v", err)
+	}
+	if report.ContentHash == "" || report.Size != 22 {
+		t.Errorf("file evidence hash/size = %q/%d", report.ContentHash, report.Size)
+	}
+	if err := store.AddEvidence(ctx, &types.Evidence{IssueID: issue.ID, Kind: "video", Ref: "x"}, nil); err == nil {
+		t.Error("AddEvidence accepted an unknown kind")
+	}
+
+	byIssue, err := store.GetEvidence(ctx, []string{issue.ID, "missing-1"})
+	if err != nil {
+		t.Fatalf("GetEvidence: %v", err)
+	}
+	got := byIssue[issue.ID]
+	if len(byIssue) != 1 || len(got) != 2 || got[0].Ref != link.Ref || got[1].ContentHash != report.ContentHash {
+		t.Fatalf("GetEvidence = %+v", byIssue)
+	}
+	content, err := store.GetEvidenceContent(ctx, report.ContentHash)
+	if err != nil || content != "<testsuite tests=\"3\"/>" {
+		t.Errorf("GetEvidenceContent = %q, %v", content, err)
+	}
+
+	// Evidence added in a transaction is rolled back with it
+	errRollback := errors.New("rollback")
+	err = store.RunInTransaction(ctx, func(tx storage.Transaction) error {
+		if err := tx.CloseIssue(ctx, issue.ID, "Fixed", "tester", ""); err != nil {
+			return err
+		}
+		if err := tx.(*doltTransaction).AddEvidence(ctx, &types.Evidence{IssueID: issue.ID, Kind: types.EvidenceURL, Ref: "https://ci.example.com/runs/8", AddedBy: "tester"}, nil); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("RunInTransaction = %v", err)
+	}
+	if byIssue, _ := store.GetEvidence(ctx, []string{issue.ID}); len(byIssue[issue.ID]) != 2 {
+		t.Errorf("evidence after rollback = %+v, want the original two", byIssue[issue.ID])
+	}
+}
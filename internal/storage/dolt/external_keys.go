@@ -0,0 +1,177 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// upsertExternalKeySQL points a key at an issue, moving it if it already
+// names another one.
+const upsertExternalKeySQL = `
+	INSERT INTO external_keys (ext_key, issue_id, source, created_by, created_at)
+	VALUES (?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE issue_id = VALUES(issue_id), source = VALUES(source),
+		created_by = VALUES(created_by), created_at = VALUES(created_at)
+`
+
+// ValidateExternalKey checks that key can be used as an alias: it must be
+// non-empty, at most 255 bytes, and contain no whitespace.
+func ValidateExternalKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("%w: external key is empty", storage.ErrValidation)
+	}
+	if len(key) > 255 {
+		return fmt.Errorf("%w: external key %q is longer than 255 bytes", storage.ErrValidation, key)
+	}
+	if strings.IndexFunc(key, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("%w: external key %q contains whitespace", storage.ErrValidation, key)
+	}
+	return nil
+}
+
+// SetExternalKey makes key (e.g. JIRA-123) an alias of issueID. source
+// names the system the key comes from, such as "jira", or "manual". A key
+// that already names another issue is moved to this one.
+func (s *DoltStore) SetExternalKey(ctx context.Context, issueID, key, source, actor string) error {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+	if err := ValidateExternalKey(key); err != nil {
+		return err
+	}
+	if s.isActiveWisp(ctx, issueID) {
+		return fmt.Errorf("cannot alias ephemeral issue %s", issueID)
+	}
+	var exists int
+	if err := s.db.QueryRowContext(ctx, "SELECT 1 FROM issues WHERE id = ?", issueID).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: issue %s", storage.ErrNotFound, issueID)
+		}
+		return fmt.Errorf("failed to check issue %s: %w", issueID, err)
+	}
+	if _, err := s.execContext(ctx, upsertExternalKeySQL, key, issueID, source, actor, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to set external key %s: %w", key, err)
+	}
+	return nil
+}
+
+// upsertExternalKey stores k for issueID within tx, for batch creation.
+func upsertExternalKey(ctx context.Context, tx *sql.Tx, issueID string, k *types.ExternalKey, actor string) error {
+	if err := ValidateExternalKey(k.Key); err != nil {
+		return fmt.Errorf("issue %s: %w", issueID, err)
+	}
+	createdBy, createdAt := k.CreatedBy, k.CreatedAt
+	if createdBy == "" {
+		createdBy = actor
+	}
+	if createdAt.IsZero() {
+		createdAt = time.Now().UTC()
+	}
+	if _, err := tx.ExecContext(ctx, upsertExternalKeySQL, k.Key, issueID, k.Source, createdBy, createdAt); err != nil {
+		return fmt.Errorf("failed to insert external key %s for %s: %w", k.Key, issueID, err)
+	}
+	return nil
+}
+
+// RemoveExternalKey removes an alias; removed reports whether it existed.
+func (s *DoltStore) RemoveExternalKey(ctx context.Context, key, actor string) (removed bool, err error) {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return false, err
+	}
+	result, err := s.execContext(ctx, `DELETE FROM external_keys WHERE ext_key = ?`, key)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove external key %s: %w", key, err)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
+
+// ResolveExternalKey returns the ID of the issue key is an alias of, or an
+// error matching storage.ErrNotFound if it names none.
+func (s *DoltStore) ResolveExternalKey(ctx context.Context, key string) (string, error) {
+	var id string
+	err := s.db.QueryRowContext(ctx, "SELECT issue_id FROM external_keys WHERE ext_key = ?", key).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: external key %s", storage.ErrNotFound, key)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve external key %s: %w", key, err)
+	}
+	return id, nil
+}
+
+// GetExternalKeys returns an issue's aliases, sorted by key.
+func (s *DoltStore) GetExternalKeys(ctx context.Context, issueID string) ([]*types.ExternalKey, error) {
+	keys, err := s.GetExternalKeysForIssues(ctx, []string{issueID})
+	if err != nil {
+		return nil, err
+	}
+	return keys[issueID], nil
+}
+
+// GetExternalKeysForIssues returns the aliases of several issues, keyed by
+// issue ID.
+func (s *DoltStore) GetExternalKeysForIssues(ctx context.Context, issueIDs []string) (map[string][]*types.ExternalKey, error) {
+	result := make(map[string][]*types.ExternalKey)
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+	placeholders := make([]string, len(issueIDs))
+	args := make([]interface{}, len(issueIDs))
+	for i, id := range issueIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	// nolint:gosec // G201: placeholders contains only ? markers, actual values passed via args
+	keys, err := s.queryExternalKeys(ctx, fmt.Sprintf(`
+		SELECT ext_key, issue_id, source, created_by, created_at FROM external_keys
+		WHERE issue_id IN (%s)
+		ORDER BY issue_id, ext_key
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		result[k.IssueID] = append(result[k.IssueID], k)
+	}
+	return result, nil
+}
+
+// ListExternalKeys returns every alias, sorted by key. A non-empty source
+// limits them to keys from that system.
+func (s *DoltStore) ListExternalKeys(ctx context.Context, source string) ([]*types.ExternalKey, error) {
+	query := `SELECT ext_key, issue_id, source, created_by, created_at FROM external_keys`
+	var args []interface{}
+	if source != "" {
+		query += ` WHERE source = ?`
+		args = append(args, source)
+	}
+	return s.queryExternalKeys(ctx, query+` ORDER BY ext_key`, args...)
+}
+
+func (s *DoltStore) queryExternalKeys(ctx context.Context, query string, args ...interface{}) ([]*types.ExternalKey, error) {
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get external keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*types.ExternalKey
+	for rows.Next() {
+		k := &types.ExternalKey{}
+		if err := rows.Scan(&k.Key, &k.IssueID, &k.Source, &k.CreatedBy, &k.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan external key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
@@ -0,0 +1,99 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestValidateExternalKey(t *testing.T) {
+	for _, key := range []string{"JIRA-123", "GH#456", "LIN-789"} {
+		if err := ValidateExternalKey(key); err != nil {
+			t.Errorf("ValidateExternalKey(%q) = %v", key, err)
+		}
+	}
+	for _, key := range []string{"", "JIRA 123", "a\tb", strings.Repeat("x", 256)} {
+		if err := ValidateExternalKey(key); !errors.Is(err, storage.ErrValidation) {
+			t.Errorf("ValidateExternalKey(%q) = %v, want a validation error", key, err)
+		}
+	}
+}
+
+func TestExternalKeys(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	var ids []string
+	for _, title := range []string{"First", "Second"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	first, second := ids[0], ids[1]
+
+	if err := store.SetExternalKey(ctx, first, "JIRA-123", "jira", "tester"); err != nil {
+		t.Fatalf("SetExternalKey: %v", err)
+	}
+	if err := store.SetExternalKey(ctx, first, "GH#456", "manual", "tester"); err != nil {
+		t.Fatalf("SetExternalKey: %v", err)
+	}
+	if err := store.SetExternalKey(ctx, "bd-missing", "LIN-1", "manual", "tester"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("aliasing a missing issue = %v, want ErrNotFound", err)
+	}
+
+	if id, err := store.ResolveExternalKey(ctx, "JIRA-123"); err != nil || id != first {
+		t.Errorf("ResolveExternalKey = %q, %v; want %s", id, err, first)
+	}
+	if _, err := store.ResolveExternalKey(ctx, "JIRA-999"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("unknown key = %v, want ErrNotFound", err)
+	}
+
+	keys, err := store.GetExternalKeys(ctx, first)
+	if err != nil {
+		t.Fatalf("GetExternalKeys: %v", err)
+	}
+	if len(keys) != 2 || keys[0].Key != "GH#456" || keys[1].Key != "JIRA-123" || keys[1].Source != "jira" {
+		t.Errorf("keys = %+v", keys)
+	}
+
+	// Setting a key again moves it to the new issue.
+	if err := store.SetExternalKey(ctx, second, "JIRA-123", "jira", "tester"); err != nil {
+		t.Fatalf("SetExternalKey: %v", err)
+	}
+	if id, _ := store.ResolveExternalKey(ctx, "JIRA-123"); id != second {
+		t.Errorf("moved key resolves to %s, want %s", id, second)
+	}
+
+	jira, err := store.ListExternalKeys(ctx, "jira")
+	if err != nil {
+		t.Fatalf("ListExternalKeys: %v", err)
+	}
+	if len(jira) != 1 || jira[0].IssueID != second {
+		t.Errorf("jira keys = %+v", jira)
+	}
+
+	if removed, err := store.RemoveExternalKey(ctx, "GH#456", "tester"); err != nil || !removed {
+		t.Errorf("RemoveExternalKey = %v, %v", removed, err)
+	}
+	if removed, _ := store.RemoveExternalKey(ctx, "GH#456", "tester"); removed {
+		t.Error("removing a missing key should report false")
+	}
+
+	// Deleting an issue drops its aliases.
+	if err := store.DeleteIssue(ctx, second); err != nil {
+		t.Fatalf("DeleteIssue: %v", err)
+	}
+	if _, err := store.ResolveExternalKey(ctx, "JIRA-123"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("key of deleted issue = %v, want ErrNotFound", err)
+	}
+}
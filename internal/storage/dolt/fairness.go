@@ -0,0 +1,124 @@
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// maxFairnessDepth bounds the walk up parent-child links when grouping
+// ready work by epic.
+const maxFairnessDepth = 10
+
+// applyFairness interleaves sorted ready work by epic or label.
+func (s *DoltStore) applyFairness(ctx context.Context, issues []*types.Issue, fairness types.Fairness) error {
+	if fairness == types.FairnessNone || len(issues) < 2 {
+		return nil
+	}
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+
+	var groups map[string][]string
+	switch fairness {
+	case types.FairnessLabel:
+		labels, err := s.GetLabelsForIssues(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to get labels for fairness: %w", err)
+		}
+		groups = labels
+	case types.FairnessEpic:
+		epics, err := s.topLevelParents(ctx, ids)
+		if err != nil {
+			return err
+		}
+		groups = make(map[string][]string, len(epics))
+		for id, epic := range epics {
+			groups[id] = []string{epic}
+		}
+	default:
+		return fmt.Errorf("invalid fairness %q", fairness)
+	}
+	interleaveByGroup(issues, groups)
+	return nil
+}
+
+// topLevelParents maps each issue with a parent to its topmost ancestor
+// through parent-child links.
+func (s *DoltStore) topLevelParents(ctx context.Context, ids []string) (map[string]string, error) {
+	parent := make(map[string]string)
+	visited := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		visited[id] = true
+	}
+	frontier := ids
+	for depth := 0; depth < maxFairnessDepth && len(frontier) > 0; depth++ {
+		deps, err := s.GetDependencyRecordsForIssues(ctx, frontier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parents for fairness: %w", err)
+		}
+		var next []string
+		for _, id := range frontier {
+			for _, dep := range deps[id] {
+				if dep.Type != types.DepParentChild {
+					continue
+				}
+				parent[id] = dep.DependsOnID
+				if !visited[dep.DependsOnID] {
+					visited[dep.DependsOnID] = true
+					next = append(next, dep.DependsOnID)
+				}
+				break
+			}
+		}
+		frontier = next
+	}
+
+	top := make(map[string]string)
+	for _, id := range ids {
+		root, ok := parent[id]
+		if !ok {
+			continue
+		}
+		for steps := 0; steps < maxFairnessDepth; steps++ {
+			up, has := parent[root]
+			if !has {
+				break
+			}
+			root = up
+		}
+		top[id] = root
+	}
+	return top, nil
+}
+
+// interleaveByGroup reorders sorted issues round-robin across the groups
+// in groups (issue ID → epics or labels): the first issue of every group
+// comes first, then the second, and so on, each round in the original
+// order. An issue in several groups goes in the round of its busiest
+// group. Issues without a group and P0 issues keep their place in the
+// first round.
+func interleaveByGroup(issues []*types.Issue, groups map[string][]string) {
+	seen := make(map[string]int)
+	round := make(map[string]int, len(issues))
+	for _, issue := range issues {
+		r := 0
+		for _, g := range groups[issue.ID] {
+			if seen[g] > r {
+				r = seen[g]
+			}
+		}
+		for _, g := range groups[issue.ID] {
+			seen[g]++
+		}
+		if issue.Priority > 0 {
+			round[issue.ID] = r
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return round[issues[i].ID] < round[issues[j].ID]
+	})
+}
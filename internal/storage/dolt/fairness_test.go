@@ -0,0 +1,50 @@
+package dolt
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestInterleaveByGroup(t *testing.T) {
+	issue := func(id string, priority int) *types.Issue { return &types.Issue{ID: id, Priority: priority} }
+	ids := func(issues []*types.Issue) []string {
+		out := make([]string, len(issues))
+		for i, issue := range issues {
+			out[i] = issue.ID
+		}
+		return out
+	}
+
+	t.Run("epic", func(t *testing.T) {
+		// A big epic sorted ahead of maintenance work
+		issues := []*types.Issue{
+			issue("big-1", 1), issue("big-2", 1), issue("big-3", 1), issue("big-4", 2),
+			issue("fix-1", 2), issue("loose", 3), issue("ops-1", 3), issue("fix-2", 3),
+		}
+		groups := map[string][]string{
+			"big-1": {"big"}, "big-2": {"big"}, "big-3": {"big"}, "big-4": {"big"},
+			"fix-1": {"maint"}, "fix-2": {"maint"}, "ops-1": {"ops"},
+		}
+		interleaveByGroup(issues, groups)
+		want := []string{"big-1", "fix-1", "loose", "ops-1", "big-2", "fix-2", "big-3", "big-4"}
+		if got := ids(issues); !reflect.DeepEqual(got, want) {
+			t.Errorf("order = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("labels and P0", func(t *testing.T) {
+		issues := []*types.Issue{issue("a", 1), issue("b", 1), issue("c", 0), issue("d", 2), issue("e", 2)}
+		groups := map[string][]string{
+			"a": {"ui"}, "b": {"ui", "api"}, "c": {"ui"}, "d": {"api"}, "e": {"docs"},
+		}
+		interleaveByGroup(issues, groups)
+		// b waits for ui's second round; the P0 stays in the first; d is
+		// api's second issue
+		want := []string{"a", "c", "e", "b", "d"}
+		if got := ids(issues); !reflect.DeepEqual(got, want) {
+			t.Errorf("order = %v, want %v", got, want)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/steveyegge/beads/internal/permissions"
 	"github.com/steveyegge/beads/internal/storage"
 )
 
@@ -14,6 +15,10 @@ import (
 // PushTo pushes commits to a specific peer remote.
 // If credentials are stored for this peer, they are used automatically.
 func (s *DoltStore) PushTo(ctx context.Context, peer string) error {
+	if err := s.authorize("", permissions.Federation); err != nil {
+		return err
+	}
+
 	return s.withPeerCredentials(ctx, peer, func() error {
 		// DOLT_PUSH(remote, branch)
 		_, err := s.execContext(ctx, "CALL DOLT_PUSH(?, ?)", peer, s.branch)
@@ -28,6 +33,10 @@ func (s *DoltStore) PushTo(ctx context.Context, peer string) error {
 // If credentials are stored for this peer, they are used automatically.
 // Returns any merge conflicts if present.
 func (s *DoltStore) PullFrom(ctx context.Context, peer string) ([]storage.Conflict, error) {
+	if err := s.authorize("", permissions.Federation); err != nil {
+		return nil, err
+	}
+
 	var conflicts []storage.Conflict
 	err := s.withPeerCredentials(ctx, peer, func() error {
 		// DOLT_PULL(remote) - pulls and merges
@@ -80,6 +89,10 @@ func (s *DoltStore) ListRemotes(ctx context.Context) ([]storage.RemoteInfo, erro
 
 // RemoveRemote removes a configured remote.
 func (s *DoltStore) RemoveRemote(ctx context.Context, name string) error {
+	if err := s.authorize("", permissions.Federation); err != nil {
+		return err
+	}
+
 	_, err := s.execContext(ctx, "CALL DOLT_REMOTE('remove', ?)", name)
 	if err != nil {
 		return fmt.Errorf("failed to remove remote %s: %w", name, err)
@@ -155,10 +168,29 @@ func (s *DoltStore) setLastSyncTime(ctx context.Context, peer string) error {
 //
 // Returns the sync result including any conflicts encountered.
 func (s *DoltStore) Sync(ctx context.Context, peer string, strategy string) (*SyncResult, error) {
+	if err := s.authorize("", permissions.Federation); err != nil {
+		return nil, err
+	}
+
+	return s.syncPeer(ctx, peer, strategy, true)
+}
+
+// syncPeer implements Sync. When push is false, the local changes are not
+// pushed to the peer (used for groups with a redaction policy).
+func (s *DoltStore) syncPeer(ctx context.Context, peer string, strategy string, push bool) (*SyncResult, error) {
 	result := &SyncResult{
 		Peer:      peer,
 		StartTime: time.Now(),
 	}
+	defer func() {
+		_ = s.recordSyncResult(ctx, result) // Best effort: history is for monitoring and must not fail the sync
+	}()
+
+	// Record the sync in the intent log so a crash mid-merge is detected
+	// (and the merge aborted) on the next open.
+	if intentID, err := s.BeginIntent(ctx, storage.IntentSync, peer, storage.SyncIntent{Peer: peer}, s.committerName); err == nil {
+		defer func() { _ = s.FinishIntent(ctx, intentID) }() // Best effort cleanup
+	}
 
 	// Step 1: Fetch from peer
 	if err := s.Fetch(ctx, peer); err != nil {
@@ -212,11 +244,13 @@ func (s *DoltStore) Sync(ctx context.Context, peer string, strategy string) (*Sy
 	}
 
 	// Step 5: Push our changes to peer
-	if err := s.PushTo(ctx, peer); err != nil {
-		// Push failure is not fatal - peer may not accept pushes
-		result.PushError = err
-	} else {
-		result.Pushed = true
+	if push {
+		if err := s.PushTo(ctx, peer); err != nil {
+			// Push failure is not fatal - peer may not accept pushes
+			result.PushError = err
+		} else {
+			result.Pushed = true
+		}
 	}
 
 	// Record last sync time
@@ -0,0 +1,196 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// Federation peer groups.
+// Groups let a town apply one sync schedule, redaction policy, and
+// sovereignty tier to several peers at once.
+
+// SaveFederationGroup creates or replaces a federation group.
+func (s *DoltStore) SaveFederationGroup(ctx context.Context, group *storage.FederationGroup) error {
+	if err := s.authorize("", permissions.Federation); err != nil {
+		return err
+	}
+
+	if err := validatePeerName(group.Name); err != nil {
+		return fmt.Errorf("invalid group name: %w", err)
+	}
+
+	peers := group.Peers
+	if peers == nil {
+		peers = []string{}
+	}
+	peersJSON, err := json.Marshal(peers)
+	if err != nil {
+		return fmt.Errorf("failed to encode group peers: %w", err)
+	}
+
+	_, err = s.execContext(ctx, `
+		INSERT INTO federation_groups (name, peers, sync_interval_ns, redact_fields, sovereignty)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			peers = VALUES(peers),
+			sync_interval_ns = VALUES(sync_interval_ns),
+			redact_fields = VALUES(redact_fields),
+			sovereignty = VALUES(sovereignty),
+			updated_at = CURRENT_TIMESTAMP
+	`, group.Name, string(peersJSON), int64(group.SyncInterval), strings.Join(group.Redact, ","), group.Sovereignty)
+	if err != nil {
+		return fmt.Errorf("failed to save federation group: %w", err)
+	}
+	return nil
+}
+
+// GetFederationGroup retrieves a federation group by name.
+// Returns storage.ErrNotFound (wrapped) if the group does not exist.
+func (s *DoltStore) GetFederationGroup(ctx context.Context, name string) (*storage.FederationGroup, error) {
+	var group *storage.FederationGroup
+	err := s.queryRowContext(ctx, func(row *sql.Row) error {
+		var scanErr error
+		group, scanErr = scanFederationGroup(row)
+		return scanErr
+	}, `
+		SELECT name, peers, sync_interval_ns, redact_fields, sovereignty, last_sync, created_at, updated_at
+		FROM federation_groups WHERE name = ?
+	`, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: federation group %s", storage.ErrNotFound, name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get federation group: %w", err)
+	}
+	return group, nil
+}
+
+// ListFederationGroups returns all configured federation groups.
+func (s *DoltStore) ListFederationGroups(ctx context.Context) ([]*storage.FederationGroup, error) {
+	rows, err := s.queryContext(ctx, `
+		SELECT name, peers, sync_interval_ns, redact_fields, sovereignty, last_sync, created_at, updated_at
+		FROM federation_groups ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list federation groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*storage.FederationGroup
+	for rows.Next() {
+		group, err := scanFederationGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan federation group: %w", err)
+		}
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+// RemoveFederationGroup deletes a federation group. Member peers are not removed.
+func (s *DoltStore) RemoveFederationGroup(ctx context.Context, name string) error {
+	if err := s.authorize("", permissions.Federation); err != nil {
+		return err
+	}
+
+	result, err := s.execContext(ctx, "DELETE FROM federation_groups WHERE name = ?", name)
+	if err != nil {
+		return fmt.Errorf("failed to remove federation group: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 { // Best effort: drivers that can't report rows fall through
+		return fmt.Errorf("%w: federation group %s", storage.ErrNotFound, name)
+	}
+	return nil
+}
+
+// updateGroupLastSync records a successful sync of every peer in a group.
+func (s *DoltStore) updateGroupLastSync(ctx context.Context, name string) error {
+	_, err := s.execContext(ctx, "UPDATE federation_groups SET last_sync = CURRENT_TIMESTAMP WHERE name = ?", name)
+	return err
+}
+
+// SyncGroup syncs with every peer in a group using the given conflict strategy.
+// Peers are synced independently; a failure with one peer does not stop the
+// others. The group's last sync time is only advanced when all peers succeed.
+//
+// When the group has a redaction policy, local changes are not pushed:
+// Dolt pushes whole commits, so withheld fields cannot be filtered out.
+// Such groups receive updates but only send them through an explicit,
+// unredacted sync with the individual peer.
+func (s *DoltStore) SyncGroup(ctx context.Context, group *storage.FederationGroup, strategy string) ([]*SyncResult, error) {
+	if err := s.authorize("", permissions.Federation); err != nil {
+		return nil, err
+	}
+
+	if len(group.Peers) == 0 {
+		return nil, fmt.Errorf("federation group %s has no peers", group.Name)
+	}
+
+	var results []*SyncResult
+	failed := 0
+	for _, peer := range group.Peers {
+		var result *SyncResult
+		var err error
+		if len(group.Redact) > 0 {
+			result, err = s.syncPeer(ctx, peer, strategy, false)
+			if err == nil {
+				result.PushError = fmt.Errorf("withheld by group %s redaction policy (%s)", group.Name, strings.Join(group.Redact, ", "))
+			}
+		} else {
+			result, err = s.Sync(ctx, peer, strategy)
+		}
+		results = append(results, result)
+		if err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d peers in group %s failed to sync", failed, len(group.Peers), group.Name)
+	}
+	_ = s.updateGroupLastSync(ctx, group.Name) // Best effort: group sync timestamp is advisory for scheduling
+	return results, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanFederationGroup scans a federation_groups row.
+func scanFederationGroup(row rowScanner) (*storage.FederationGroup, error) {
+	var group storage.FederationGroup
+	var peersJSON sql.NullString
+	var intervalNs sql.NullInt64
+	var redact, sovereignty sql.NullString
+	var lastSync sql.NullTime
+
+	if err := row.Scan(&group.Name, &peersJSON, &intervalNs, &redact, &sovereignty, &lastSync, &group.CreatedAt, &group.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	if peersJSON.Valid && peersJSON.String != "" {
+		if err := json.Unmarshal([]byte(peersJSON.String), &group.Peers); err != nil {
+			return nil, fmt.Errorf("invalid peers for group %s: %w", group.Name, err)
+		}
+	}
+	if intervalNs.Valid {
+		group.SyncInterval = time.Duration(intervalNs.Int64)
+	}
+	if redact.Valid && redact.String != "" {
+		group.Redact = strings.Split(redact.String, ",")
+	}
+	group.Sovereignty = sovereignty.String
+	if lastSync.Valid {
+		group.LastSync = &lastSync.Time
+	}
+	return &group, nil
+}
@@ -0,0 +1,131 @@
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/permissions"
+)
+
+// StorageStats summarizes the on-disk footprint of a Dolt data directory.
+type StorageStats struct {
+	Path        string `json:"path"`
+	TotalBytes  int64  `json:"total_bytes"`
+	NomsBytes   int64  `json:"noms_bytes"`   // Live chunk store (.dolt/noms)
+	OldgenBytes int64  `json:"oldgen_bytes"` // Generational GC archive (.dolt/noms/oldgen)
+	TableFiles  int    `json:"table_files"`  // Chunk table files in the live generation
+	JournalSize int64  `json:"journal_bytes"`
+}
+
+// GCResult describes the outcome of a Dolt garbage collection run.
+type GCResult struct {
+	Before    *StorageStats `json:"before,omitempty"`
+	After     *StorageStats `json:"after,omitempty"`
+	Reclaimed int64         `json:"reclaimed_bytes"`
+	Duration  time.Duration `json:"duration_ns"`
+	Shallow   bool          `json:"shallow,omitempty"`
+}
+
+// doltJournalFile is the name of the chunk journal Dolt keeps in the noms
+// directory. It is a 32-character "v" string so it never collides with a
+// base32 table file name.
+const doltJournalFile = "vvvvvvvvvvvvvvvvvvvvvvvvvvvvvvvv"
+
+// nomsMetaFiles are files in a noms directory that are not chunk table files.
+var nomsMetaFiles = map[string]bool{
+	"manifest":    true,
+	"LOCK":        true,
+	"journal.idx": true,
+}
+
+// CollectStorageStats walks a Dolt data directory (typically .beads/dolt) and
+// reports its size. Every database below the directory is included, so the
+// numbers match what a user sees with du.
+func CollectStorageStats(dataDir string) (*StorageStats, error) {
+	if _, err := os.Stat(dataDir); err != nil {
+		return nil, err
+	}
+
+	stats := &StorageStats{Path: dataDir}
+	err := filepath.WalkDir(dataDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Files can disappear mid-walk while a server is compacting.
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		size := info.Size()
+		stats.TotalBytes += size
+
+		dir := filepath.Base(filepath.Dir(path))
+		switch {
+		case dir == "oldgen" && filepath.Base(filepath.Dir(filepath.Dir(path))) == "noms":
+			stats.OldgenBytes += size
+		case dir == "noms":
+			stats.NomsBytes += size
+			name := d.Name()
+			if name == doltJournalFile {
+				stats.JournalSize += size
+			} else if !nomsMetaFiles[name] && !strings.HasPrefix(name, ".") {
+				stats.TableFiles++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure %s: %w", dataDir, err)
+	}
+	return stats, nil
+}
+
+// GC runs Dolt garbage collection on the connected database, removing chunks
+// that are no longer reachable from any branch, tag, or working set.
+//
+// A shallow GC only consolidates table files and is safe to run frequently;
+// a full GC also drops unreachable history and reclaims the most space.
+// When the store has a local data directory the reclaimed space is measured
+// by comparing the directory size before and after the run.
+func (s *DoltStore) GC(ctx context.Context, shallow bool) (*GCResult, error) {
+	if err := s.authorize("", permissions.Admin); err != nil {
+		return nil, err
+	}
+	result := &GCResult{Shallow: shallow}
+
+	if s.dataDir != "" {
+		if before, err := CollectStorageStats(s.dataDir); err == nil {
+			result.Before = before
+		}
+	}
+
+	query := "CALL DOLT_GC()"
+	if shallow {
+		query = "CALL DOLT_GC('--shallow')"
+	}
+
+	start := time.Now()
+	if _, err := s.db.ExecContext(ctx, query); err != nil {
+		return nil, fmt.Errorf("dolt gc failed: %w", err)
+	}
+	result.Duration = time.Since(start)
+
+	if result.Before != nil {
+		if after, err := CollectStorageStats(s.dataDir); err == nil {
+			result.After = after
+			result.Reclaimed = result.Before.TotalBytes - after.TotalBytes
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// GrepHit is an issue field or comment whose text matched a GrepText pattern.
+type GrepHit struct {
+	IssueID string
+	Title   string
+	Status  types.Status
+	Field   string // title, description, design, acceptance_criteria, notes, or comment
+	Author  string // Comment author; empty for issue fields
+	Text    string // The full text of the field or comment
+}
+
+// grepFields are the issue columns GrepText searches, in display order.
+var grepFields = []string{"title", "description", "design", "acceptance_criteria", "notes"}
+
+// GrepText returns the issue fields and comments matching the regular
+// expression pattern, evaluated by the server (REGEXP), ordered by issue
+// and then field. Descriptions stored as blobs are searched by content.
+// With openOnly, closed issues are skipped. limit caps the number of hits
+// (0 = no limit).
+func (s *DoltStore) GrepText(ctx context.Context, pattern string, openOnly bool, limit int) ([]*GrepHit, error) {
+	statusClause := ""
+	if openOnly {
+		statusClause = " AND i.status != 'closed'"
+	}
+
+	var branches []string
+	var args []any
+	for order, field := range grepFields {
+		where := "i." + field + " REGEXP ?"
+		if field == "description" {
+			where += " AND i.description NOT LIKE '" + descriptionBlobPrefix + "%'"
+		}
+		branches = append(branches, fmt.Sprintf(
+			"SELECT i.id, i.title, i.status, '%s' AS field, %d AS ord, '' AS author, i.%s AS text, 0 AS comment_id FROM issues i WHERE %s%s",
+			field, order, field, where, statusClause))
+		args = append(args, pattern)
+	}
+	branches = append(branches,
+		"SELECT i.id, i.title, i.status, 'description', 1, '', b.content, 0 FROM issues i"+
+			" JOIN description_blobs b ON i.description = CONCAT('"+descriptionBlobPrefix+"', b.hash)"+
+			" WHERE b.content REGEXP ?"+statusClause,
+		fmt.Sprintf("SELECT i.id, i.title, i.status, 'comment', %d, c.author, c.text, c.id FROM comments c"+
+			" JOIN issues i ON i.id = c.issue_id WHERE c.text REGEXP ?%s", len(grepFields), statusClause))
+	args = append(args, pattern, pattern)
+
+	// nolint:gosec // G201: branches are built from constants; the pattern is passed via args
+	query := strings.Join(branches, "\nUNION ALL\n") + "\nORDER BY id, ord, comment_id"
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issue text: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []*GrepHit
+	for rows.Next() {
+		var h GrepHit
+		var ord, commentID int64
+		if err := rows.Scan(&h.IssueID, &h.Title, &h.Status, &h.Field, &ord, &h.Author, &h.Text, &commentID); err != nil {
+			return nil, fmt.Errorf("failed to scan search hit: %w", err)
+		}
+		hits = append(hits, &h)
+	}
+	return hits, rows.Err()
+}
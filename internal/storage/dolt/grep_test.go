@@ -0,0 +1,64 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestGrepText(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	oldThreshold := descriptionBlobThreshold
+	descriptionBlobThreshold = 64
+	defer func() { descriptionBlobThreshold = oldThreshold }()
+
+	open := &types.Issue{Title: "Login times out", Description: "Steps:\nwait\ntimeout after 30s",
+		Status: types.StatusOpen, Priority: 2, IssueType: types.TypeBug}
+	closed := &types.Issue{Title: "Old work", Description: strings.Repeat("filler ", 20) + "timeout after 5s",
+		Status: types.StatusClosed, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{open, closed} {
+		if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+	}
+	if _, err := store.AddIssueComment(ctx, open.ID, "alice", "Saw the timeout after 30s again"); err != nil {
+		t.Fatalf("AddIssueComment: %v", err)
+	}
+
+	hits, err := store.GrepText(ctx, `timeout after [0-9]+s`, false, 0)
+	if err != nil {
+		t.Fatalf("GrepText: %v", err)
+	}
+	var got []string
+	for _, h := range hits {
+		got = append(got, h.IssueID+":"+h.Field+":"+h.Author)
+	}
+	want := []string{open.ID + ":description:", open.ID + ":comment:alice", closed.ID + ":description:"}
+	if open.ID > closed.ID {
+		want = []string{closed.ID + ":description:", open.ID + ":description:", open.ID + ":comment:alice"}
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("hits = %v, want %v", got, want)
+	}
+
+	hits, err = store.GrepText(ctx, `(?i)TIMEOUT`, true, 0)
+	if err != nil {
+		t.Fatalf("GrepText(open only): %v", err)
+	}
+	for _, h := range hits {
+		if h.IssueID == closed.ID {
+			t.Errorf("open-only search returned closed issue %s", h.IssueID)
+		}
+	}
+	if len(hits) != 3 { // title, description, comment
+		t.Errorf("open-only hits = %d, want 3", len(hits))
+	}
+}
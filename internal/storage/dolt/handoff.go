@@ -0,0 +1,65 @@
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// HandoffIssue passes an issue from one actor to another in a single
+// transaction: it assigns the issue to to, appends note to its notes,
+// releases from's lock on it (if any), and records an EventHandedOff
+// event. The issue's status is unchanged, so in-progress work stays in
+// progress under its new assignee.
+func (s *DoltStore) HandoffIssue(ctx context.Context, id, from, to, note string) error {
+	if err := s.authorize(from, permissions.Update); err != nil {
+		return err
+	}
+
+	if s.isActiveWisp(ctx, id) {
+		return fmt.Errorf("cannot hand off ephemeral issue %s", id)
+	}
+	issue, err := s.GetIssue(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get issue for handoff: %w", err)
+	}
+	if issue.Status == types.StatusClosed {
+		return fmt.Errorf("cannot hand off closed issue %s", id)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // No-op after successful commit
+
+	if !s.lockOverride {
+		if err := checkIssueLock(ctx, tx, id, from); err != nil {
+			return err
+		}
+	}
+
+	notes := issue.Notes
+	if notes != "" {
+		notes += "\n\n"
+	}
+	notes += note
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE issues SET assignee = ?, notes = ?, updated_at = ? WHERE id = ?
+	`, to, notes, time.Now().UTC(), id); err != nil {
+		return fmt.Errorf("failed to hand off issue: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM issue_locks WHERE issue_id = ? AND holder = ?`, id, from); err != nil {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value, new_value, comment)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, id, types.EventHandedOff, from, issue.Assignee, to, note); err != nil {
+		return fmt.Errorf("failed to record handoff event: %w", err)
+	}
+	return tx.Commit()
+}
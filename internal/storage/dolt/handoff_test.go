@@ -0,0 +1,71 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestHandoffIssue(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	issue := &types.Issue{Title: "Migrate", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask, Notes: "Started"}
+	if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	id := issue.ID
+	if err := store.ClaimIssue(ctx, id, "alice"); err != nil {
+		t.Fatalf("ClaimIssue: %v", err)
+	}
+	if err := store.LockIssue(ctx, id, "alice", "", nil); err != nil {
+		t.Fatalf("LockIssue: %v", err)
+	}
+
+	// Someone else can't hand off alice's locked issue
+	var locked *IssueLockedError
+	if err := store.HandoffIssue(ctx, id, "mallory", "mallory", "mine now"); !errors.As(err, &locked) {
+		t.Errorf("handoff by another actor = %v, want IssueLockedError", err)
+	}
+
+	if err := store.HandoffIssue(ctx, id, "alice", "bob", "## Handoff"); err != nil {
+		t.Fatalf("HandoffIssue: %v", err)
+	}
+	got, err := store.GetIssue(ctx, id)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if got.Assignee != "bob" || got.Status != types.StatusInProgress || got.Notes != "Started\n\n## Handoff" {
+		t.Errorf("after handoff: assignee %q, status %q, notes %q", got.Assignee, got.Status, got.Notes)
+	}
+	if lock, err := store.GetIssueLock(ctx, id); err != nil || lock != nil {
+		t.Errorf("lock after handoff = %v, %v; want released", lock, err)
+	}
+
+	events, err := store.GetEvents(ctx, id, 0)
+	if err != nil {
+		t.Fatalf("GetEvents: %v", err)
+	}
+	found := false
+	for _, e := range events {
+		if e.EventType == types.EventHandedOff && e.Actor == "alice" && e.NewValue != nil && *e.NewValue == "bob" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("no handed_off event from alice to bob in %+v", events)
+	}
+
+	if err := store.CloseIssue(ctx, id, "done", "bob", ""); err != nil {
+		t.Fatalf("CloseIssue: %v", err)
+	}
+	if err := store.HandoffIssue(ctx, id, "bob", "carol", ""); err == nil {
+		t.Error("handoff of a closed issue succeeded")
+	}
+}
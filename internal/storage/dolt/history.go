@@ -11,8 +11,9 @@ import (
 	"github.com/steveyegge/beads/internal/types"
 )
 
-// validRefPattern matches valid Dolt commit hashes (32 hex chars) or branch names
-var validRefPattern = regexp.MustCompile(`^[a-zA-Z0-9_\-]+$`)
+// validRefPattern matches valid Dolt commit hashes (32 hex chars) or branch
+// names, optionally followed by ancestry suffixes (HEAD~2, main^)
+var validRefPattern = regexp.MustCompile(`^[a-zA-Z0-9_\-]+([~^][0-9]*)*$`)
 
 // validTablePattern matches valid table names
 var validTablePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
@@ -146,8 +147,19 @@ func (s *DoltStore) getIssueHistory(ctx context.Context, issueID string) ([]*iss
 		h.Issue = &issue
 		history = append(history, &h)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	_ = rows.Close() // Release the connection before resolving blobs
 
-	return history, rows.Err()
+	issues := make([]*types.Issue, len(history))
+	for i, h := range history {
+		issues[i] = h.Issue
+	}
+	if err := resolveDescriptions(ctx, s.db, issues...); err != nil {
+		return nil, err
+	}
+	return history, nil
 }
 
 // getIssueAsOf returns an issue as it existed at a specific commit or time
@@ -165,14 +177,16 @@ func (s *DoltStore) getIssueAsOf(ctx context.Context, issueID string, ref string
 
 	// nolint:gosec // G201: ref is validated by validateRef() above - AS OF requires literal
 	query := fmt.Sprintf(`
-		SELECT id, content_hash, title, description, status, priority, issue_type, assignee, estimated_minutes,
+		SELECT id, content_hash, title, description, design, acceptance_criteria, notes,
+		       status, priority, issue_type, assignee, estimated_minutes,
 		       created_at, created_by, owner, updated_at, closed_at
 		FROM issues AS OF '%s'
 		WHERE id = ?
 	`, ref)
 
 	err := s.db.QueryRowContext(ctx, query, issueID).Scan(
-		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Status, &issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
+		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design, &issue.AcceptanceCriteria, &issue.Notes,
+		&issue.Status, &issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
 		&createdAtStr, &issue.CreatedBy, &owner, &updatedAtStr, &closedAt,
 	)
 
@@ -208,6 +222,9 @@ func (s *DoltStore) getIssueAsOf(ctx context.Context, issueID string, ref string
 		issue.EstimatedMinutes = &mins
 	}
 
+	if err := resolveDescriptions(ctx, s.db, &issue); err != nil {
+		return nil, err
+	}
 	return &issue, nil
 }
 
@@ -0,0 +1,333 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// intentAbandonAfter is how long a pending intent recorded on another host
+// (whose process liveness can't be checked) is assumed to still be running.
+const intentAbandonAfter = time.Hour
+
+// intentRetention is how long resolved intents are kept for `bd doctor`.
+// Failed intents are kept until cleared by hand.
+const intentRetention = 7 * 24 * time.Hour
+
+// BeginIntent records that a multi-statement operation is about to start.
+// The returned ID must be passed to FinishIntent once the operation has run
+// to completion (successfully or not). If the process dies in between, the
+// pending entry is picked up by RecoverIntents on the next open.
+func (s *DoltStore) BeginIntent(ctx context.Context, op storage.IntentOp, target string, payload any, actor string) (int64, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode %s intent: %w", op, err)
+	}
+	host, _ := os.Hostname() // Best effort: empty host falls back to age-based recovery
+
+	result, err := s.execContext(ctx, `
+		INSERT INTO intent_log (op, target, payload, actor, host, pid, status, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, string(op), target, string(data), actor, host, os.Getpid(), string(storage.IntentPending), time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to record %s intent: %w", op, err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get intent id: %w", err)
+	}
+	return id, nil
+}
+
+// SetIntentTarget records the primary object of an intent once it is known
+// (e.g. the generated ID of a newly created issue).
+func (s *DoltStore) SetIntentTarget(ctx context.Context, id int64, target string) error {
+	if _, err := s.execContext(ctx, "UPDATE intent_log SET target = ? WHERE id = ?", target, id); err != nil {
+		return fmt.Errorf("failed to update intent %d: %w", id, err)
+	}
+	return nil
+}
+
+// FinishIntent removes a completed intent from the log.
+func (s *DoltStore) FinishIntent(ctx context.Context, id int64) error {
+	if _, err := s.execContext(ctx, "DELETE FROM intent_log WHERE id = ?", id); err != nil {
+		return fmt.Errorf("failed to finish intent %d: %w", id, err)
+	}
+	return nil
+}
+
+// GetIntents returns intent log entries, oldest first. If status is empty,
+// all entries are returned.
+func (s *DoltStore) GetIntents(ctx context.Context, status storage.IntentStatus) ([]*storage.Intent, error) {
+	query := `SELECT id, op, target, payload, actor, host, pid, status, resolution, created_at, resolved_at
+		FROM intent_log`
+	var args []any
+	if status != "" {
+		query += " WHERE status = ?"
+		args = append(args, string(status))
+	}
+	query += " ORDER BY id"
+
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query intent log: %w", err)
+	}
+	defer rows.Close()
+
+	var intents []*storage.Intent
+	for rows.Next() {
+		var in storage.Intent
+		var op, st string
+		var payload, actor, host, resolution sql.NullString
+		var pid sql.NullInt64
+		var resolvedAt sql.NullTime
+		if err := rows.Scan(&in.ID, &op, &in.Target, &payload, &actor, &host, &pid, &st,
+			&resolution, &in.CreatedAt, &resolvedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan intent: %w", err)
+		}
+		in.Op = storage.IntentOp(op)
+		in.Status = storage.IntentStatus(st)
+		in.Payload = payload.String
+		in.Actor = actor.String
+		in.Host = host.String
+		in.PID = int(pid.Int64)
+		in.Resolution = resolution.String
+		if resolvedAt.Valid {
+			t := resolvedAt.Time
+			in.ResolvedAt = &t
+		}
+		intents = append(intents, &in)
+	}
+	return intents, rows.Err()
+}
+
+// RecoverIntents resolves pending intents left behind by processes that died
+// mid-operation. Each abandoned intent is rolled forward (remaining steps are
+// applied) or rolled back (partial effects are undone, or the operation never
+// took effect), and its outcome is kept in the log for `bd doctor`.
+// Intents still owned by a live process are left alone.
+func (s *DoltStore) RecoverIntents(ctx context.Context) ([]*storage.Intent, error) {
+	pending, err := s.GetIntents(ctx, storage.IntentPending)
+	if err != nil {
+		return nil, err
+	}
+
+	host, _ := os.Hostname() // Best effort: empty host falls back to age-based recovery
+	var recovered []*storage.Intent
+	for _, in := range pending {
+		if !intentAbandoned(in, host, time.Now()) {
+			continue
+		}
+
+		status, resolution := s.recoverIntent(ctx, in)
+		now := time.Now().UTC()
+		if _, err := s.execContext(ctx,
+			"UPDATE intent_log SET status = ?, resolution = ?, resolved_at = ? WHERE id = ?",
+			string(status), resolution, now, in.ID); err != nil {
+			return recovered, fmt.Errorf("failed to record recovery of intent %d: %w", in.ID, err)
+		}
+		in.Status = status
+		in.Resolution = resolution
+		in.ResolvedAt = &now
+		recovered = append(recovered, in)
+	}
+
+	// Prune old recoveries so the log stays small
+	_, _ = s.execContext(ctx, // Best effort: stale entries only add noise to bd doctor
+		"DELETE FROM intent_log WHERE status IN (?, ?) AND resolved_at < ?",
+		string(storage.IntentRolledForward), string(storage.IntentRolledBack), time.Now().UTC().Add(-intentRetention))
+	return recovered, nil
+}
+
+// intentAbandoned reports whether a pending intent's owning process is gone.
+// On the same host the PID is checked directly; intents from other hosts
+// (shared sql-server) are considered abandoned after intentAbandonAfter.
+func intentAbandoned(in *storage.Intent, localHost string, now time.Time) bool {
+	if in.Host != "" && in.Host == localHost && in.PID > 0 {
+		if in.PID == os.Getpid() {
+			return false
+		}
+		proc, err := os.FindProcess(in.PID)
+		return err != nil || !processMayBeAlive(proc)
+	}
+	return now.Sub(in.CreatedAt) > intentAbandonAfter
+}
+
+// recoverIntent applies the op-specific recovery for one abandoned intent.
+func (s *DoltStore) recoverIntent(ctx context.Context, in *storage.Intent) (storage.IntentStatus, string) {
+	var err error
+	var status storage.IntentStatus
+	var resolution string
+
+	switch in.Op {
+	case storage.IntentCreate:
+		status, resolution, err = s.recoverCreateIntent(ctx, in)
+	case storage.IntentClose:
+		status, resolution, err = s.recoverCloseIntent(ctx, in)
+	case storage.IntentSync:
+		status, resolution, err = s.recoverSyncIntent(ctx, in)
+	default:
+		err = fmt.Errorf("unknown intent op %q", in.Op)
+	}
+	if err != nil {
+		return storage.IntentFailed, err.Error()
+	}
+	return status, resolution
+}
+
+// recoverCreateIntent finishes adding labels and dependencies to an issue
+// whose creation was interrupted. If the issue itself was never created there
+// is nothing to undo.
+func (s *DoltStore) recoverCreateIntent(ctx context.Context, in *storage.Intent) (storage.IntentStatus, string, error) {
+	var payload storage.CreateIntent
+	if err := in.DecodePayload(&payload); err != nil {
+		return "", "", err
+	}
+
+	issueID := in.Target
+	if issueID == "" {
+		// Crashed between creating the issue and recording its ID: look for
+		// an issue with the intended title created after the intent.
+		err := s.queryRowContext(ctx, func(row *sql.Row) error {
+			return row.Scan(&issueID)
+		}, "SELECT id FROM issues WHERE title = ? AND created_at >= ? ORDER BY created_at LIMIT 1",
+			payload.Title, in.CreatedAt.Add(-time.Second))
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.IntentRolledBack, "issue was never created", nil
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("failed to look up created issue: %w", err)
+		}
+	}
+
+	issue, err := s.GetIssue(ctx, issueID)
+	if err != nil || issue == nil {
+		return storage.IntentRolledBack, fmt.Sprintf("issue %s was never created", issueID), nil
+	}
+
+	existingLabels, err := s.GetLabels(ctx, issueID)
+	if err != nil {
+		return "", "", err
+	}
+	var added, skipped []string
+	for _, label := range payload.Labels {
+		if slices.Contains(existingLabels, label) {
+			continue
+		}
+		if err := s.AddLabel(ctx, issueID, label, in.Actor); err != nil {
+			skipped = append(skipped, "label "+label)
+			continue
+		}
+		added = append(added, "label "+label)
+	}
+
+	for _, d := range payload.Dependencies {
+		dep := payload.ResolveDependency(d, issueID)
+		exists, err := s.dependencyExists(ctx, dep)
+		if err != nil {
+			return "", "", err
+		}
+		if exists {
+			continue
+		}
+		// Mirror the create command: a dependency that can't be added
+		// (missing target, cycle) is skipped rather than failing the issue.
+		if err := s.AddDependency(ctx, dep, in.Actor); err != nil {
+			skipped = append(skipped, fmt.Sprintf("dependency %s -> %s", dep.IssueID, dep.DependsOnID))
+			continue
+		}
+		added = append(added, fmt.Sprintf("dependency %s -> %s", dep.IssueID, dep.DependsOnID))
+	}
+
+	return storage.IntentRolledForward, describeRecovery(issueID, added, skipped), nil
+}
+
+// recoverCloseIntent closes the issues of an interrupted batch close that
+// are still open.
+func (s *DoltStore) recoverCloseIntent(ctx context.Context, in *storage.Intent) (storage.IntentStatus, string, error) {
+	var payload storage.CloseIntent
+	if err := in.DecodePayload(&payload); err != nil {
+		return "", "", err
+	}
+
+	var closed, skipped []string
+	for _, id := range payload.IssueIDs {
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil || issue == nil {
+			skipped = append(skipped, id)
+			continue
+		}
+		if issue.Status == types.StatusClosed {
+			continue
+		}
+		if err := s.CloseIssue(ctx, id, payload.Reason, in.Actor, payload.Session); err != nil {
+			skipped = append(skipped, id)
+			continue
+		}
+		closed = append(closed, id)
+	}
+	return storage.IntentRolledForward, describeRecovery(strings.Join(payload.IssueIDs, ", "), closed, skipped), nil
+}
+
+// recoverSyncIntent aborts a merge left in progress by an interrupted sync.
+// A merge that completed is kept; last-sync bookkeeping is refreshed by the
+// next sync.
+func (s *DoltStore) recoverSyncIntent(ctx context.Context, in *storage.Intent) (storage.IntentStatus, string, error) {
+	var payload storage.SyncIntent
+	if err := in.DecodePayload(&payload); err != nil {
+		return "", "", err
+	}
+
+	var merging bool
+	err := s.queryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(&merging)
+	}, "SELECT is_merging FROM dolt_merge_status")
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", "", fmt.Errorf("failed to read merge status: %w", err)
+	}
+	if !merging {
+		return storage.IntentRolledForward, fmt.Sprintf("sync with %s: merge had completed", payload.Peer), nil
+	}
+
+	if _, err := s.db.ExecContext(ctx, "CALL DOLT_MERGE('--abort')"); err != nil {
+		return "", "", fmt.Errorf("failed to abort interrupted merge from %s: %w", payload.Peer, err)
+	}
+	return storage.IntentRolledBack, fmt.Sprintf("sync with %s: aborted interrupted merge", payload.Peer), nil
+}
+
+// dependencyExists reports whether dep is already recorded.
+func (s *DoltStore) dependencyExists(ctx context.Context, dep *types.Dependency) (bool, error) {
+	records, err := s.GetDependencyRecords(ctx, dep.IssueID)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range records {
+		if r.DependsOnID == dep.DependsOnID && r.Type == dep.Type {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// describeRecovery summarizes the steps a roll-forward applied and skipped.
+func describeRecovery(target string, applied, skipped []string) string {
+	msg := target + ": "
+	if len(applied) == 0 {
+		msg += "nothing left to apply"
+	} else {
+		msg += "applied " + strings.Join(applied, ", ")
+	}
+	if len(skipped) > 0 {
+		msg += "; skipped " + strings.Join(skipped, ", ")
+	}
+	return msg
+}
@@ -0,0 +1,63 @@
+package dolt
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+func TestIntentAbandoned(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		intent *storage.Intent
+		want   bool
+	}{
+		{
+			name:   "own process is never abandoned",
+			intent: &storage.Intent{Host: "here", PID: os.Getpid(), CreatedAt: now.Add(-48 * time.Hour)},
+			want:   false,
+		},
+		{
+			name:   "dead local process",
+			intent: &storage.Intent{Host: "here", PID: 1 << 30, CreatedAt: now},
+			want:   true,
+		},
+		{
+			name:   "other host, recent",
+			intent: &storage.Intent{Host: "elsewhere", PID: 42, CreatedAt: now.Add(-time.Minute)},
+			want:   false,
+		},
+		{
+			name:   "other host, old",
+			intent: &storage.Intent{Host: "elsewhere", PID: 42, CreatedAt: now.Add(-2 * intentAbandonAfter)},
+			want:   true,
+		},
+		{
+			name:   "unknown host falls back to age",
+			intent: &storage.Intent{CreatedAt: now.Add(-time.Minute)},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := intentAbandoned(tt.intent, "here", now); got != tt.want {
+				t.Errorf("intentAbandoned() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeRecovery(t *testing.T) {
+	if got := describeRecovery("bd-1", nil, nil); got != "bd-1: nothing left to apply" {
+		t.Errorf("got %q", got)
+	}
+	got := describeRecovery("bd-1", []string{"label a"}, []string{"dependency bd-1 -> bd-9"})
+	want := "bd-1: applied label a; skipped dependency bd-1 -> bd-9"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
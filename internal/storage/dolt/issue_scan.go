@@ -11,7 +11,7 @@ import (
 // Every query that reads a complete types.Issue from the issues table should
 // use this constant to avoid column-list drift between scan sites.
 const issueSelectColumns = `id, content_hash, title, description, design, acceptance_criteria, notes,
-	       status, priority, issue_type, assignee, estimated_minutes,
+	       status, priority, issue_type, assignee, estimated_minutes, actual_minutes,
 	       created_at, created_by, owner, updated_at, closed_at, external_ref, spec_id,
 	       compaction_level, compacted_at, compacted_at_commit, original_size, source_repo, close_reason,
 	       sender, ephemeral, wisp_type, pinned, is_template, crystallizes,
@@ -34,7 +34,7 @@ func scanIssueFrom(s issueScanner) (*types.Issue, error) {
 	var issue types.Issue
 	var createdAtStr, updatedAtStr sql.NullString // TEXT columns - must parse manually
 	var closedAt, compactedAt, lastActivity, dueAt, deferUntil sql.NullTime
-	var estimatedMinutes, originalSize, timeoutNs sql.NullInt64
+	var estimatedMinutes, actualMinutes, originalSize, timeoutNs sql.NullInt64
 	var assignee, externalRef, specID, compactedAtCommit, owner sql.NullString
 	var contentHash, sourceRepo, closeReason sql.NullString
 	var workType, sourceSystem sql.NullString
@@ -48,7 +48,7 @@ func scanIssueFrom(s issueScanner) (*types.Issue, error) {
 	if err := s.Scan(
 		&issue.ID, &contentHash, &issue.Title, &issue.Description, &issue.Design,
 		&issue.AcceptanceCriteria, &issue.Notes, &issue.Status,
-		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes,
+		&issue.Priority, &issue.IssueType, &assignee, &estimatedMinutes, &actualMinutes,
 		&createdAtStr, &issue.CreatedBy, &owner, &updatedAtStr, &closedAt, &externalRef, &specID,
 		&issue.CompactionLevel, &compactedAt, &compactedAtCommit, &originalSize, &sourceRepo, &closeReason,
 		&sender, &ephemeral, &wispType, &pinned, &isTemplate, &crystallizes,
@@ -80,6 +80,10 @@ func scanIssueFrom(s issueScanner) (*types.Issue, error) {
 		mins := int(estimatedMinutes.Int64)
 		issue.EstimatedMinutes = &mins
 	}
+	if actualMinutes.Valid {
+		mins := int(actualMinutes.Int64)
+		issue.ActualMinutes = &mins
+	}
 	if assignee.Valid {
 		issue.Assignee = assignee.String
 	}
@@ -5,18 +5,25 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/steveyegge/beads/internal/idgen"
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/resolution"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
 
 // CreateIssue creates a new issue
 func (s *DoltStore) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	if err := s.authorize(actor, permissions.Create); err != nil {
+		return err
+	}
+
 	// Route ephemeral issues to wisps table
 	if issue.Ephemeral {
 		return s.createWisp(ctx, issue, actor)
@@ -57,7 +64,7 @@ func (s *DoltStore) CreateIssue(ctx context.Context, issue *types.Issue, actor s
 
 	// Validate issue
 	if err := issue.ValidateWithCustom(customStatuses, customTypes); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+		return fmt.Errorf("%w: %w", storage.ErrValidation, err)
 	}
 
 	// Compute content hash
@@ -91,7 +98,7 @@ func (s *DoltStore) CreateIssue(ctx context.Context, issue *types.Issue, actor s
 
 	// Generate or validate ID
 	if issue.ID == "" {
-		generatedID, err := generateIssueID(ctx, tx, prefix, issue, actor)
+		generatedID, err := generateIssueID(ctx, tx, prefix, s.idTown, issue, actor)
 		if err != nil {
 			return fmt.Errorf("failed to generate issue ID: %w", err)
 		}
@@ -123,6 +130,10 @@ func (s *DoltStore) CreateIssues(ctx context.Context, issues []*types.Issue, act
 // This is the backend-agnostic batch creation method that supports orphan handling
 // and prefix validation options.
 func (s *DoltStore) CreateIssuesWithFullOptions(ctx context.Context, issues []*types.Issue, actor string, opts storage.BatchCreateOptions) error {
+	if err := s.authorize(actor, permissions.Create); err != nil {
+		return err
+	}
+
 	if len(issues) == 0 {
 		return nil
 	}
@@ -190,7 +201,7 @@ func (s *DoltStore) CreateIssuesWithFullOptions(ctx context.Context, issues []*t
 
 		// Validate issue
 		if err := issue.ValidateWithCustom(customStatuses, customTypes); err != nil {
-			return fmt.Errorf("validation failed for issue %s: %w", issue.ID, err)
+			return fmt.Errorf("%w for issue %s: %w", storage.ErrValidation, issue.ID, err)
 		}
 
 		if issue.ContentHash == "" {
@@ -260,6 +271,14 @@ func (s *DoltStore) CreateIssuesWithFullOptions(ctx context.Context, issues []*t
 				return fmt.Errorf("failed to insert comment for %s: %w", issue.ID, err)
 			}
 		}
+
+		// Persist external keys, so aliases such as JIRA-123 survive export
+		// and import. A key already naming another issue moves to this one.
+		for _, k := range issue.ExternalKeys {
+			if err := upsertExternalKey(ctx, tx, issue.ID, k, actor); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Second pass: persist dependencies after all issues exist (GH#1844).
@@ -363,22 +382,38 @@ func (s *DoltStore) GetIssueByExternalRef(ctx context.Context, externalRef strin
 func (s *DoltStore) UpdateIssue(ctx context.Context, id string, updates map[string]interface{}, actor string) error {
 	// Route ephemeral IDs to wisps table (falls through for promoted wisps)
 	if s.isActiveWisp(ctx, id) {
+		if err := s.authorize(actor, updateCapability("", updates)); err != nil {
+			return err
+		}
 		return s.updateWisp(ctx, id, updates, actor)
 	}
+	if err := s.checkIssueLock(ctx, id, actor); err != nil {
+		return err
+	}
 
 	oldIssue, err := s.GetIssue(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to get issue for update: %w", err)
 	}
+	if err := s.authorize(actor, updateCapability(oldIssue.Status, updates)); err != nil {
+		return err
+	}
+	if err := checkCloseCategoryOnUpdate(s.closeRules, oldIssue, updates); err != nil {
+		return err
+	}
 
 	// Build update query
 	setClauses := []string{"updated_at = ?"}
 	args := []interface{}{time.Now().UTC()}
+	descriptionArg := -1 // Index of a description value that may become a blob
 
 	for key, value := range updates {
 		if !isAllowedUpdateField(key) {
 			return fmt.Errorf("invalid field for update: %s", key)
 		}
+		if _, ok := value.(string); ok && key == "description" {
+			descriptionArg = len(args)
+		}
 
 		columnName := key
 		if key == "wisp" {
@@ -413,6 +448,12 @@ func (s *DoltStore) UpdateIssue(ctx context.Context, id string, updates map[stri
 	}
 	defer func() { _ = tx.Rollback() }() // No-op after successful commit
 
+	if descriptionArg >= 0 {
+		if args[descriptionArg], err = storeDescription(ctx, tx, args[descriptionArg].(string)); err != nil {
+			return err
+		}
+	}
+
 	// nolint:gosec // G201: setClauses contains only column names (e.g. "status = ?"), actual values passed via args
 	query := fmt.Sprintf("UPDATE issues SET %s WHERE id = ?", strings.Join(setClauses, ", "))
 	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
@@ -420,8 +461,13 @@ func (s *DoltStore) UpdateIssue(ctx context.Context, id string, updates map[stri
 	}
 
 	// Record event
-	oldData, _ := json.Marshal(oldIssue)
-	newData, _ := json.Marshal(updates)
+	oldData, _ := json.Marshal(eventSnapshot(oldIssue))
+	eventUpdates := updates
+	if descriptionArg >= 0 {
+		eventUpdates = maps.Clone(updates)
+		eventUpdates["description"] = args[descriptionArg]
+	}
+	newData, _ := json.Marshal(eventUpdates)
 	eventType := determineEventType(oldIssue, updates)
 
 	if err := recordEvent(ctx, tx, id, eventType, actor, string(oldData), string(newData)); err != nil {
@@ -435,10 +481,17 @@ func (s *DoltStore) UpdateIssue(ctx context.Context, id string, updates map[stri
 // It sets the assignee to actor and status to "in_progress" only if the issue
 // currently has no assignee. Returns storage.ErrAlreadyClaimed if already claimed.
 func (s *DoltStore) ClaimIssue(ctx context.Context, id string, actor string) error {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
 	// Route ephemeral IDs to wisps table (falls through for promoted wisps)
 	if s.isActiveWisp(ctx, id) {
 		return s.claimWisp(ctx, id, actor)
 	}
+	if err := s.checkIssueLock(ctx, id, actor); err != nil {
+		return err
+	}
 
 	oldIssue, err := s.GetIssue(ctx, id)
 	if err != nil {
@@ -481,7 +534,7 @@ func (s *DoltStore) ClaimIssue(ctx context.Context, id string, actor string) err
 	}
 
 	// Record the claim event
-	oldData, _ := json.Marshal(oldIssue)
+	oldData, _ := json.Marshal(eventSnapshot(oldIssue))
 	newUpdates := map[string]interface{}{
 		"assignee": actor,
 		"status":   "in_progress",
@@ -497,10 +550,26 @@ func (s *DoltStore) ClaimIssue(ctx context.Context, id string, actor string) err
 
 // CloseIssue closes an issue with a reason
 func (s *DoltStore) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
+	if err := s.authorize(actor, permissions.Close); err != nil {
+		return err
+	}
+
 	// Route ephemeral IDs to wisps table (falls through for promoted wisps)
 	if s.isActiveWisp(ctx, id) {
 		return s.closeWisp(ctx, id, reason, actor, session)
 	}
+	if err := s.checkIssueLock(ctx, id, actor); err != nil {
+		return err
+	}
+	if s.closeRules != nil && s.closeRules.MaxPriority >= 0 {
+		issue, err := s.GetIssue(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkCloseCategory(s.closeRules, issue, reason); err != nil {
+			return err
+		}
+	}
 
 	now := time.Now().UTC()
 
@@ -533,12 +602,42 @@ func (s *DoltStore) CloseIssue(ctx context.Context, id string, reason string, ac
 	return tx.Commit()
 }
 
+// checkCloseCategory returns a validation error if rules require issue to
+// close with a category and reason has none.
+func checkCloseCategory(rules *resolution.Rules, issue *types.Issue, reason string) error {
+	if rules == nil {
+		return nil
+	}
+	if err := rules.Check(reason, issue.Priority); err != nil {
+		return fmt.Errorf("%w: cannot close %s: %w", storage.ErrValidation, issue.ID, err)
+	}
+	return nil
+}
+
+// checkCloseCategoryOnUpdate applies checkCloseCategory to updates that
+// close issue, taking the reason from their close_reason.
+func checkCloseCategoryOnUpdate(rules *resolution.Rules, issue *types.Issue, updates map[string]interface{}) error {
+	status, ok := updates["status"]
+	if !ok || types.Status(fmt.Sprint(status)) != types.StatusClosed || issue.Status == types.StatusClosed {
+		return nil
+	}
+	reason, _ := updates["close_reason"].(string)
+	return checkCloseCategory(rules, issue, reason)
+}
+
 // DeleteIssue permanently removes an issue
 func (s *DoltStore) DeleteIssue(ctx context.Context, id string) error {
+	if err := s.authorize("", permissions.Delete); err != nil {
+		return err
+	}
+
 	// Route ephemeral IDs to wisps table (falls through for promoted wisps)
 	if s.isActiveWisp(ctx, id) {
 		return s.deleteWisp(ctx, id)
 	}
+	if err := s.checkIssueLock(ctx, id, ""); err != nil {
+		return err
+	}
 
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -547,7 +646,7 @@ func (s *DoltStore) DeleteIssue(ctx context.Context, id string) error {
 	defer func() { _ = tx.Rollback() }() // No-op after successful commit
 
 	// Delete related data (foreign keys will cascade, but be explicit)
-	tables := []string{"dependencies", "events", "comments", "labels"}
+	tables := []string{"dependencies", "events", "comments", "labels", "reactions", "issue_embeddings", "issue_locks", "external_keys", "issue_journal"}
 	for _, table := range tables {
 		// Validate table name to prevent SQL injection (tables are hardcoded above,
 		// but validate defensively in case the list is ever modified)
@@ -593,6 +692,11 @@ func (s *DoltStore) DeleteIssues(ctx context.Context, ids []string, cascade bool
 	if len(ids) == 0 {
 		return &types.DeleteIssuesResult{}, nil
 	}
+	if !dryRun {
+		if err := s.authorize("", permissions.Delete); err != nil {
+			return nil, err
+		}
+	}
 
 	// Route wisp IDs to wisp deletion; process regular IDs in batch below.
 	ephIDs, regularIDs := partitionIDs(ids)
@@ -700,6 +804,11 @@ func (s *DoltStore) DeleteIssues(ctx context.Context, ids []string, cascade bool
 	for _, id := range expandedIDs {
 		expandedIDSet[id] = true
 	}
+	if !s.lockOverride {
+		if err := checkIssueLocks(ctx, tx, expandedIDSet); err != nil {
+			return nil, err
+		}
+	}
 
 	var depsCount, labelsCount, eventsCount int
 	// Pass 1: deps originating from deleted issues (no cross-batch overlap possible)
@@ -935,10 +1044,14 @@ func doltBuildSQLInClause(ids []string) (string, []interface{}) {
 // =============================================================================
 
 func insertIssue(ctx context.Context, tx *sql.Tx, issue *types.Issue) error {
-	_, err := tx.ExecContext(ctx, `
+	description, err := storeDescription(ctx, tx, issue.Description)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
 		INSERT INTO issues (
 			id, content_hash, title, description, design, acceptance_criteria, notes,
-			status, priority, issue_type, assignee, estimated_minutes,
+			status, priority, issue_type, assignee, estimated_minutes, actual_minutes,
 			created_at, created_by, owner, updated_at, closed_at, external_ref, spec_id,
 			compaction_level, compacted_at, compacted_at_commit, original_size,
 			sender, ephemeral, wisp_type, pinned, is_template, crystallizes,
@@ -949,7 +1062,7 @@ func insertIssue(ctx context.Context, tx *sql.Tx, issue *types.Issue) error {
 			due_at, defer_until, metadata
 		) VALUES (
 			?, ?, ?, ?, ?, ?, ?,
-			?, ?, ?, ?, ?,
+			?, ?, ?, ?, ?, ?,
 			?, ?, ?, ?, ?, ?, ?,
 			?, ?, ?, ?,
 			?, ?, ?, ?, ?, ?,
@@ -960,8 +1073,8 @@ func insertIssue(ctx context.Context, tx *sql.Tx, issue *types.Issue) error {
 			?, ?, ?
 		)
 	`,
-		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes,
-		issue.Status, issue.Priority, issue.IssueType, nullString(issue.Assignee), nullInt(issue.EstimatedMinutes),
+		issue.ID, issue.ContentHash, issue.Title, description, issue.Design, issue.AcceptanceCriteria, issue.Notes,
+		issue.Status, issue.Priority, issue.IssueType, nullString(issue.Assignee), nullInt(issue.EstimatedMinutes), nullInt(issue.ActualMinutes),
 		issue.CreatedAt, issue.CreatedBy, issue.Owner, issue.UpdatedAt, issue.ClosedAt, nullStringPtr(issue.ExternalRef), issue.SpecID,
 		issue.CompactionLevel, issue.CompactedAt, nullStringPtr(issue.CompactedAtCommit), nullIntVal(issue.OriginalSize),
 		issue.Sender, issue.Ephemeral, issue.WispType, issue.Pinned, issue.IsTemplate, issue.Crystallizes,
@@ -988,6 +1101,9 @@ func scanIssue(ctx context.Context, db *sql.DB, id string) (*types.Issue, error)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get issue: %w", err)
 	}
+	if err := resolveDescriptions(ctx, db, issue); err != nil {
+		return nil, err
+	}
 	return issue, nil
 }
 
@@ -1001,7 +1117,12 @@ func recordEvent(ctx context.Context, tx *sql.Tx, issueID string, eventType type
 
 // generateIssueID generates a unique hash-based ID for an issue
 // Uses adaptive length based on database size and tries multiple nonces on collision
-func generateIssueID(ctx context.Context, tx *sql.Tx, prefix string, issue *types.Issue, actor string) (string, error) {
+func generateIssueID(ctx context.Context, tx *sql.Tx, prefix, town string, issue *types.Issue, actor string) (string, error) {
+	town, err := idTownTx(ctx, tx, town)
+	if err != nil {
+		return "", err
+	}
+
 	// Get adaptive base length based on current database size
 	baseLength, err := GetAdaptiveIDLengthTx(ctx, tx, prefix)
 	if err != nil {
@@ -1018,7 +1139,7 @@ func generateIssueID(ctx context.Context, tx *sql.Tx, prefix string, issue *type
 	for length := baseLength; length <= maxLength; length++ {
 		// Try up to 10 nonces at each length
 		for nonce := 0; nonce < 10; nonce++ {
-			candidate := generateHashID(prefix, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
+			candidate := generateHashID(prefix, town, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
 
 			// Check if this ID already exists
 			var count int
@@ -1036,9 +1157,13 @@ func generateIssueID(ctx context.Context, tx *sql.Tx, prefix string, issue *type
 	return "", fmt.Errorf("failed to generate unique ID after trying lengths %d-%d with 10 nonces each", baseLength, maxLength)
 }
 
-// generateHashID creates a hash-based ID for a top-level issue.
+// generateHashID creates a hash-based ID for a top-level issue, in town's
+// namespace if town is set.
 // Uses base36 encoding (0-9, a-z) for better information density than hex.
-func generateHashID(prefix, title, description, creator string, timestamp time.Time, length, nonce int) string {
+func generateHashID(prefix, town, title, description, creator string, timestamp time.Time, length, nonce int) string {
+	if town != "" {
+		return idgen.GenerateTownHashID(prefix, town, title, description, creator, timestamp, length, nonce)
+	}
 	return idgen.GenerateHashID(prefix, title, description, creator, timestamp, length, nonce)
 }
 
@@ -1046,7 +1171,7 @@ func isAllowedUpdateField(key string) bool {
 	allowed := map[string]bool{
 		"status": true, "priority": true, "title": true, "assignee": true,
 		"description": true, "design": true, "acceptance_criteria": true, "notes": true,
-		"issue_type": true, "estimated_minutes": true, "external_ref": true, "spec_id": true,
+		"issue_type": true, "estimated_minutes": true, "actual_minutes": true, "external_ref": true, "spec_id": true,
 		"closed_at": true, "close_reason": true, "closed_by_session": true,
 		"source_repo": true,
 		"sender":      true, "wisp": true, "wisp_type": true, "pinned": true,
@@ -1162,6 +1287,10 @@ func parseJSONStringArray(s string) []string {
 // It also cleans up related data: dependencies, labels, comments, and events.
 // Returns the number of issues deleted.
 func (s *DoltStore) DeleteIssuesBySourceRepo(ctx context.Context, sourceRepo string) (int, error) {
+	if err := s.authorize("", permissions.Delete); err != nil {
+		return 0, err
+	}
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to begin transaction: %w", err)
@@ -1195,7 +1324,7 @@ func (s *DoltStore) DeleteIssuesBySourceRepo(ctx context.Context, sourceRepo str
 	}
 
 	// Delete related data for all affected issues
-	tables := []string{"dependencies", "events", "comments", "labels"}
+	tables := []string{"dependencies", "events", "comments", "labels", "reactions", "issue_embeddings", "issue_locks", "external_keys", "issue_journal"}
 	for _, table := range tables {
 		if err := validateTableName(table); err != nil {
 			return 0, fmt.Errorf("invalid table name %q: %w", table, err)
@@ -0,0 +1,63 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddJournalEntry appends a work-log entry by author to an issue's journal.
+func (s *DoltStore) AddJournalEntry(ctx context.Context, issueID, author, text string) (*types.JournalEntry, error) {
+	if err := s.authorize(author, permissions.Update); err != nil {
+		return nil, err
+	}
+
+	var exists bool
+	if err := s.queryRowContext(ctx, func(row *sql.Row) error {
+		return row.Scan(&exists)
+	}, `SELECT EXISTS(SELECT 1 FROM issues WHERE id = ?)`, issueID); err != nil {
+		return nil, fmt.Errorf("failed to check issue existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("issue %s not found", issueID)
+	}
+
+	entry := &types.JournalEntry{IssueID: issueID, Author: author, Text: text, CreatedAt: time.Now().UTC()}
+	result, err := s.execContext(ctx, `
+		INSERT INTO issue_journal (issue_id, author, text, created_at)
+		VALUES (?, ?, ?, ?)
+	`, entry.IssueID, entry.Author, entry.Text, entry.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add journal entry to %s: %w", issueID, err)
+	}
+	if entry.ID, err = result.LastInsertId(); err != nil {
+		return nil, fmt.Errorf("failed to get journal entry ID: %w", err)
+	}
+	return entry, nil
+}
+
+// GetJournal returns an issue's journal entries, oldest first.
+func (s *DoltStore) GetJournal(ctx context.Context, issueID string) ([]*types.JournalEntry, error) {
+	rows, err := s.queryContext(ctx, `
+		SELECT id, issue_id, author, text, created_at
+		FROM issue_journal WHERE issue_id = ? ORDER BY created_at, id
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get journal: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*types.JournalEntry
+	for rows.Next() {
+		var e types.JournalEntry
+		if err := rows.Scan(&e.ID, &e.IssueID, &e.Author, &e.Text, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan journal entry: %w", err)
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,45 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestJournal(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	issue := &types.Issue{Title: "Connection leak", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeBug}
+	if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	first, err := store.AddJournalEntry(ctx, issue.ID, "alice", "Tried a bigger pool; no change")
+	if err != nil {
+		t.Fatalf("AddJournalEntry: %v", err)
+	}
+	if _, err := store.AddJournalEntry(ctx, issue.ID, "bob", "Leak is per request, not per connection"); err != nil {
+		t.Fatalf("AddJournalEntry: %v", err)
+	}
+	if _, err := store.AddJournalEntry(ctx, "missing-1", "alice", "x"); err == nil {
+		t.Error("AddJournalEntry accepted a missing issue")
+	}
+
+	journal, err := store.GetJournal(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetJournal: %v", err)
+	}
+	if len(journal) != 2 || journal[0].ID != first.ID || journal[1].Author != "bob" {
+		t.Fatalf("GetJournal = %+v, want alice's entry then bob's", journal)
+	}
+	comments, err := store.GetIssueComments(ctx, issue.ID)
+	if err != nil || len(comments) != 0 {
+		t.Errorf("journal entries leaked into comments: %v, %v", comments, err)
+	}
+}
@@ -5,14 +5,22 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/steveyegge/beads/internal/permissions"
 	"github.com/steveyegge/beads/internal/types"
 )
 
 // AddLabel adds a label to an issue
 func (s *DoltStore) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
 	if s.isActiveWisp(ctx, issueID) {
 		return s.addWispLabel(ctx, issueID, label, actor)
 	}
+	if err := s.checkIssueLock(ctx, issueID, actor); err != nil {
+		return err
+	}
 	_, err := s.execContext(ctx, `
 		INSERT IGNORE INTO labels (issue_id, label) VALUES (?, ?)
 	`, issueID, label)
@@ -32,9 +40,16 @@ func (s *DoltStore) AddLabel(ctx context.Context, issueID, label, actor string)
 
 // RemoveLabel removes a label from an issue
 func (s *DoltStore) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
 	if s.isActiveWisp(ctx, issueID) {
 		return s.removeWispLabel(ctx, issueID, label)
 	}
+	if err := s.checkIssueLock(ctx, issueID, actor); err != nil {
+		return err
+	}
 	_, err := s.execContext(ctx, `
 		DELETE FROM labels WHERE issue_id = ? AND label = ?
 	`, issueID, label)
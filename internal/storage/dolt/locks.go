@@ -0,0 +1,240 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// IssueLockedError is returned when an actor modifies an issue that another
+// actor has locked.
+type IssueLockedError struct {
+	Lock *types.IssueLock
+}
+
+func (e *IssueLockedError) Error() string {
+	msg := fmt.Sprintf("%s is locked by %s", e.Lock.IssueID, e.Lock.Holder)
+	if e.Lock.Reason != "" {
+		msg += " (" + e.Lock.Reason + ")"
+	}
+	if e.Lock.ExpiresAt != nil {
+		msg += " until " + e.Lock.ExpiresAt.Local().Format("2006-01-02 15:04")
+	}
+	return msg
+}
+
+// queryIssueLock returns id's lock, or nil if it is unlocked or the lock
+// has expired at now.
+func queryIssueLock(ctx context.Context, q issueQuerier, id string, now time.Time) (*types.IssueLock, error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT issue_id, holder, reason, locked_at, expires_at FROM issue_locks
+		WHERE issue_id = ?
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue lock: %w", err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	lock, err := scanIssueLock(rows)
+	if err != nil {
+		return nil, err
+	}
+	if lock.Expired(now) {
+		return nil, nil
+	}
+	return lock, nil
+}
+
+func scanIssueLock(rows *sql.Rows) (*types.IssueLock, error) {
+	var lock types.IssueLock
+	var expiresAt sql.NullTime
+	if err := rows.Scan(&lock.IssueID, &lock.Holder, &lock.Reason, &lock.LockedAt, &expiresAt); err != nil {
+		return nil, fmt.Errorf("failed to scan issue lock: %w", err)
+	}
+	if expiresAt.Valid {
+		lock.ExpiresAt = &expiresAt.Time
+	}
+	return &lock, nil
+}
+
+// checkIssueLock returns an *IssueLockedError if id is locked by anyone
+// but actor. An empty actor (e.g. for deletes) is blocked by any lock.
+func checkIssueLock(ctx context.Context, q issueQuerier, id, actor string) error {
+	lock, err := queryIssueLock(ctx, q, id, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if lock != nil && (actor == "" || lock.Holder != actor) {
+		return &IssueLockedError{Lock: lock}
+	}
+	return nil
+}
+
+// checkIssueLock checks id's lock for actor unless the store was opened
+// with Config.LockOverride.
+func (s *DoltStore) checkIssueLock(ctx context.Context, id, actor string) error {
+	if s.lockOverride {
+		return nil
+	}
+	return checkIssueLock(ctx, s.db, id, actor)
+}
+
+// checkIssueLock checks id's lock for actor, within the transaction.
+func (t *doltTransaction) checkIssueLock(ctx context.Context, id, actor string) error {
+	if t.store.lockOverride {
+		return nil
+	}
+	return checkIssueLock(ctx, t.tx, id, actor)
+}
+
+// checkIssueLocks returns an *IssueLockedError for the first of ids that
+// is locked, for bulk deletes.
+func checkIssueLocks(ctx context.Context, q issueQuerier, ids map[string]bool) error {
+	rows, err := q.QueryContext(ctx, `
+		SELECT issue_id, holder, reason, locked_at, expires_at FROM issue_locks
+		WHERE expires_at IS NULL OR expires_at > ?
+		ORDER BY issue_id
+	`, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to check issue locks: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		lock, err := scanIssueLock(rows)
+		if err != nil {
+			return err
+		}
+		if ids[lock.IssueID] {
+			return &IssueLockedError{Lock: lock}
+		}
+	}
+	return rows.Err()
+}
+
+// LockIssue locks an issue so that only holder can modify it until it is
+// unlocked or expiresAt (nil: no expiry) passes. Relocking an issue holder
+// already holds replaces the reason and expiry; an unexpired lock held by
+// another actor is an *IssueLockedError.
+func (s *DoltStore) LockIssue(ctx context.Context, id, holder, reason string, expiresAt *time.Time) error {
+	if err := s.authorize(holder, permissions.Update); err != nil {
+		return err
+	}
+
+	if s.isActiveWisp(ctx, id) {
+		return fmt.Errorf("cannot lock ephemeral issue %s", id)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // No-op after successful commit
+
+	now := time.Now().UTC()
+	existing, err := queryIssueLock(ctx, tx, id, now)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.Holder != holder {
+		return &IssueLockedError{Lock: existing}
+	}
+
+	var expires interface{}
+	if expiresAt != nil {
+		expires = expiresAt.UTC()
+	}
+	if _, err := tx.ExecContext(ctx, `
+		REPLACE INTO issue_locks (issue_id, holder, reason, locked_at, expires_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, id, holder, reason, now, expires); err != nil {
+		return fmt.Errorf("failed to lock issue: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, id, types.EventLocked, holder, reason); err != nil {
+		return fmt.Errorf("failed to record lock event: %w", err)
+	}
+	return tx.Commit()
+}
+
+// UnlockIssue releases an issue's lock and returns it, or nil if the issue
+// was not locked. Only the holder may unlock unless force is set or the
+// store was opened with Config.LockOverride; breaking another actor's lock
+// is recorded in the issue's events.
+func (s *DoltStore) UnlockIssue(ctx context.Context, id, actor string, force bool) (*types.IssueLock, error) {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // No-op after successful commit
+
+	lock, err := queryIssueLock(ctx, tx, id, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if lock == nil {
+		// Clear any expired lock so it doesn't linger in the table
+		if _, err := tx.ExecContext(ctx, `DELETE FROM issue_locks WHERE issue_id = ?`, id); err != nil {
+			return nil, fmt.Errorf("failed to unlock issue: %w", err)
+		}
+		return nil, tx.Commit()
+	}
+	if lock.Holder != actor && !force && !s.lockOverride {
+		return nil, &IssueLockedError{Lock: lock}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM issue_locks WHERE issue_id = ?`, id); err != nil {
+		return nil, fmt.Errorf("failed to unlock issue: %w", err)
+	}
+	comment := ""
+	if lock.Holder != actor {
+		comment = "Broke lock held by " + lock.Holder
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, comment)
+		VALUES (?, ?, ?, ?)
+	`, id, types.EventUnlocked, actor, comment); err != nil {
+		return nil, fmt.Errorf("failed to record unlock event: %w", err)
+	}
+	return lock, tx.Commit()
+}
+
+// GetIssueLock returns an issue's lock, or nil if it is unlocked or the
+// lock has expired.
+func (s *DoltStore) GetIssueLock(ctx context.Context, id string) (*types.IssueLock, error) {
+	return queryIssueLock(ctx, s.db, id, time.Now().UTC())
+}
+
+// ListIssueLocks returns the unexpired locks, oldest first.
+func (s *DoltStore) ListIssueLocks(ctx context.Context) ([]*types.IssueLock, error) {
+	rows, err := s.queryContext(ctx, `
+		SELECT issue_id, holder, reason, locked_at, expires_at FROM issue_locks
+		WHERE expires_at IS NULL OR expires_at > ?
+		ORDER BY locked_at, issue_id
+	`, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue locks: %w", err)
+	}
+	defer rows.Close()
+
+	var locks []*types.IssueLock
+	for rows.Next() {
+		lock, err := scanIssueLock(rows)
+		if err != nil {
+			return nil, err
+		}
+		locks = append(locks, lock)
+	}
+	return locks, rows.Err()
+}
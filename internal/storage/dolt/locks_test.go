@@ -0,0 +1,77 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestIssueLocks(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	issue := &types.Issue{Title: "Spec", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+	id := issue.ID
+
+	if err := store.LockIssue(ctx, id, "alice", "rewriting", nil); err != nil {
+		t.Fatalf("LockIssue: %v", err)
+	}
+
+	// The holder can still write; others can't
+	if err := store.UpdateIssue(ctx, id, map[string]interface{}{"title": "Spec v2"}, "alice"); err != nil {
+		t.Errorf("holder update: %v", err)
+	}
+	var locked *IssueLockedError
+	if err := store.UpdateIssue(ctx, id, map[string]interface{}{"title": "Churn"}, "agent"); !errors.As(err, &locked) {
+		t.Errorf("update by another actor = %v, want IssueLockedError", err)
+	}
+	if err := store.AddLabel(ctx, id, "x", "agent"); !errors.As(err, &locked) {
+		t.Errorf("label by another actor = %v, want IssueLockedError", err)
+	}
+	if err := store.CloseIssue(ctx, id, "done", "agent", ""); !errors.As(err, &locked) {
+		t.Errorf("close by another actor = %v, want IssueLockedError", err)
+	}
+	if err := store.DeleteIssue(ctx, id); !errors.As(err, &locked) {
+		t.Errorf("delete of a locked issue = %v, want IssueLockedError", err)
+	}
+	if err := store.LockIssue(ctx, id, "agent", "", nil); !errors.As(err, &locked) {
+		t.Errorf("lock by another actor = %v, want IssueLockedError", err)
+	}
+
+	// Only the holder unlocks without force
+	if _, err := store.UnlockIssue(ctx, id, "agent", false); !errors.As(err, &locked) {
+		t.Errorf("unlock by another actor = %v, want IssueLockedError", err)
+	}
+	lock, err := store.UnlockIssue(ctx, id, "agent", true)
+	if err != nil || lock == nil || lock.Holder != "alice" {
+		t.Fatalf("forced unlock = %v, %v", lock, err)
+	}
+	if err := store.UpdateIssue(ctx, id, map[string]interface{}{"title": "Spec v3"}, "agent"); err != nil {
+		t.Errorf("update after unlock: %v", err)
+	}
+
+	// Expired locks are ignored
+	past := time.Now().Add(-time.Minute)
+	if err := store.LockIssue(ctx, id, "alice", "", &past); err != nil {
+		t.Fatalf("LockIssue: %v", err)
+	}
+	if lock, err := store.GetIssueLock(ctx, id); err != nil || lock != nil {
+		t.Errorf("GetIssueLock after expiry = %v, %v; want nil", lock, err)
+	}
+	if locks, err := store.ListIssueLocks(ctx); err != nil || len(locks) != 0 {
+		t.Errorf("ListIssueLocks after expiry = %v, %v; want none", locks, err)
+	}
+	if err := store.UpdateIssue(ctx, id, map[string]interface{}{"title": "Spec v4"}, "agent"); err != nil {
+		t.Errorf("update after expiry: %v", err)
+	}
+}
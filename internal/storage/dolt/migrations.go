@@ -24,6 +24,23 @@ var migrationsList = []Migration{
 	{"orphan_detection", migrations.DetectOrphanedChildren},
 	{"wisps_table", migrations.MigrateWispsTable},
 	{"wisp_auxiliary_tables", migrations.MigrateWispAuxiliaryTables},
+	{"federation_groups", migrations.MigrateFederationGroupsTable},
+	{"sync_history", migrations.MigrateSyncHistoryTable},
+	{"intent_log", migrations.MigrateIntentLogTable},
+	{"description_blobs", migrations.MigrateDescriptionBlobsTable},
+	{"reactions", migrations.MigrateReactionsTable},
+	{"issue_embeddings", migrations.MigrateIssueEmbeddingsTable},
+	{"issue_locks", migrations.MigrateIssueLocksTable},
+	{"availability", migrations.MigrateAvailabilityTable},
+	{"issue_evidence", migrations.MigrateIssueEvidenceTable},
+	{"alerts", migrations.MigrateAlertsTable},
+	{"external_keys", migrations.MigrateExternalKeysTable},
+	{"sync_conflicts", migrations.MigrateSyncConflictsTable},
+	{"issue_journal", migrations.MigrateIssueJournalTable},
+	{"actual_minutes_column", migrations.MigrateActualMinutesColumn},
+	{"milestones", migrations.MigrateMilestonesTable},
+	{"milestone_baselines", migrations.MigrateMilestoneBaselinesTable},
+	{"recurrences", migrations.MigrateRecurrencesTable},
 }
 
 // RunMigrations executes all registered Dolt migrations in order.
@@ -66,6 +66,7 @@ const wispsTableSchema = `CREATE TABLE IF NOT EXISTS wisps (
     issue_type VARCHAR(32) NOT NULL DEFAULT 'task',
     assignee VARCHAR(255),
     estimated_minutes INT,
+    actual_minutes INT,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     created_by VARCHAR(255) DEFAULT '',
     owner VARCHAR(255) DEFAULT '',
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateFederationGroupsTable creates the federation_groups table, which
+// stores named peer groups with their sync schedule, redaction policy, and
+// sovereignty tier.
+func MigrateFederationGroupsTable(db *sql.DB) error {
+	exists, err := tableExists(db, "federation_groups")
+	if err != nil {
+		return fmt.Errorf("failed to check federation_groups existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(federationGroupsSchema); err != nil {
+		return fmt.Errorf("failed to create federation_groups table: %w", err)
+	}
+	return nil
+}
+
+const federationGroupsSchema = `CREATE TABLE federation_groups (
+    name VARCHAR(255) PRIMARY KEY,
+    peers JSON DEFAULT (JSON_ARRAY()),
+    sync_interval_ns BIGINT DEFAULT 0,
+    redact_fields TEXT DEFAULT '',
+    sovereignty VARCHAR(8) DEFAULT '',
+    last_sync DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+)`
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// MigrateSyncHistoryTable creates the sync_history table, which records the
+// outcome of each federation sync for monitoring. Sync history is local
+// operational data, so the table is added to dolt_ignore before it is
+// created to keep it out of Dolt commits (and out of peers' databases).
+func MigrateSyncHistoryTable(db *sql.DB) error {
+	_, err := db.Exec("REPLACE INTO dolt_ignore VALUES ('sync_history', true)")
+	if err != nil {
+		return fmt.Errorf("failed to add sync_history to dolt_ignore: %w", err)
+	}
+	_, err = db.Exec("CALL DOLT_ADD('dolt_ignore')")
+	if err != nil {
+		return fmt.Errorf("failed to stage dolt_ignore: %w", err)
+	}
+	_, err = db.Exec("CALL DOLT_COMMIT('-m', 'chore: add sync_history to dolt_ignore')")
+	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "nothing to commit") {
+		return fmt.Errorf("failed to commit dolt_ignore changes: %w", err)
+	}
+
+	exists, err := tableExists(db, "sync_history")
+	if err != nil {
+		return fmt.Errorf("failed to check sync_history existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(syncHistorySchema); err != nil {
+		return fmt.Errorf("failed to create sync_history table: %w", err)
+	}
+	return nil
+}
+
+const syncHistorySchema = `CREATE TABLE sync_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    peer VARCHAR(255) NOT NULL,
+    started_at DATETIME(6) NOT NULL,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    fetched TINYINT(1) DEFAULT 0,
+    merged TINYINT(1) DEFAULT 0,
+    pushed TINYINT(1) DEFAULT 0,
+    pulled_commits INT DEFAULT 0,
+    pushed_commits INT DEFAULT 0,
+    conflicts INT DEFAULT 0,
+    error TEXT,
+    push_error TEXT,
+    INDEX idx_sync_history_peer_started (peer, started_at)
+)`
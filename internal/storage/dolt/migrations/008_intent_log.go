@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// MigrateIntentLogTable creates the intent_log table, a write-ahead record of
+// multi-statement operations used to detect and recover from crashes midway
+// through them. Intents describe local in-flight work, so the table is added
+// to dolt_ignore before it is created to keep it out of Dolt commits.
+func MigrateIntentLogTable(db *sql.DB) error {
+	_, err := db.Exec("REPLACE INTO dolt_ignore VALUES ('intent_log', true)")
+	if err != nil {
+		return fmt.Errorf("failed to add intent_log to dolt_ignore: %w", err)
+	}
+	_, err = db.Exec("CALL DOLT_ADD('dolt_ignore')")
+	if err != nil {
+		return fmt.Errorf("failed to stage dolt_ignore: %w", err)
+	}
+	_, err = db.Exec("CALL DOLT_COMMIT('-m', 'chore: add intent_log to dolt_ignore')")
+	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "nothing to commit") {
+		return fmt.Errorf("failed to commit dolt_ignore changes: %w", err)
+	}
+
+	exists, err := tableExists(db, "intent_log")
+	if err != nil {
+		return fmt.Errorf("failed to check intent_log existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(intentLogSchema); err != nil {
+		return fmt.Errorf("failed to create intent_log table: %w", err)
+	}
+	return nil
+}
+
+const intentLogSchema = `CREATE TABLE intent_log (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    op VARCHAR(32) NOT NULL,
+    target VARCHAR(255) NOT NULL DEFAULT '',
+    payload TEXT,
+    actor VARCHAR(255) DEFAULT '',
+    host VARCHAR(255) DEFAULT '',
+    pid INT DEFAULT 0,
+    status VARCHAR(32) NOT NULL DEFAULT 'pending',
+    resolution TEXT,
+    created_at DATETIME(6) NOT NULL,
+    resolved_at DATETIME(6),
+    INDEX idx_intent_log_status (status)
+)`
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateDescriptionBlobsTable creates the description_blobs table, a
+// content-addressed store for issue descriptions above the inline size
+// threshold. Issues reference a blob by hash instead of carrying the text.
+func MigrateDescriptionBlobsTable(db *sql.DB) error {
+	exists, err := tableExists(db, "description_blobs")
+	if err != nil {
+		return fmt.Errorf("failed to check description_blobs existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(descriptionBlobsSchema); err != nil {
+		return fmt.Errorf("failed to create description_blobs table: %w", err)
+	}
+	return nil
+}
+
+const descriptionBlobsSchema = `CREATE TABLE description_blobs (
+    hash CHAR(64) PRIMARY KEY,
+    content LONGTEXT NOT NULL,
+    size INT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateReactionsTable creates the reactions table, which records each
+// actor's reactions (including votes) to issues.
+func MigrateReactionsTable(db *sql.DB) error {
+	exists, err := tableExists(db, "reactions")
+	if err != nil {
+		return fmt.Errorf("failed to check reactions existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(reactionsSchema); err != nil {
+		return fmt.Errorf("failed to create reactions table: %w", err)
+	}
+	return nil
+}
+
+const reactionsSchema = `CREATE TABLE reactions (
+    issue_id VARCHAR(255) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    reaction VARCHAR(64) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, actor, reaction),
+    INDEX idx_reactions_reaction (reaction),
+    CONSTRAINT fk_reactions_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+)`
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// MigrateIssueEmbeddingsTable creates the issue_embeddings table, which
+// caches text embedding vectors for similarity search. Embeddings are
+// derived from issue text and depend on the locally configured model, so
+// the table is added to dolt_ignore before it is created, like sync_history.
+func MigrateIssueEmbeddingsTable(db *sql.DB) error {
+	_, err := db.Exec("REPLACE INTO dolt_ignore VALUES ('issue_embeddings', true)")
+	if err != nil {
+		return fmt.Errorf("failed to add issue_embeddings to dolt_ignore: %w", err)
+	}
+	_, err = db.Exec("CALL DOLT_ADD('dolt_ignore')")
+	if err != nil {
+		return fmt.Errorf("failed to stage dolt_ignore: %w", err)
+	}
+	_, err = db.Exec("CALL DOLT_COMMIT('-m', 'chore: add issue_embeddings to dolt_ignore')")
+	if err != nil && !strings.Contains(strings.ToLower(err.Error()), "nothing to commit") {
+		return fmt.Errorf("failed to commit dolt_ignore changes: %w", err)
+	}
+
+	exists, err := tableExists(db, "issue_embeddings")
+	if err != nil {
+		return fmt.Errorf("failed to check issue_embeddings existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(issueEmbeddingsSchema); err != nil {
+		return fmt.Errorf("failed to create issue_embeddings table: %w", err)
+	}
+	return nil
+}
+
+const issueEmbeddingsSchema = `CREATE TABLE issue_embeddings (
+    issue_id VARCHAR(255) NOT NULL,
+    model VARCHAR(255) NOT NULL,
+    content_hash CHAR(64) NOT NULL,
+    dimensions INT NOT NULL,
+    vector LONGBLOB NOT NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, model)
+)`
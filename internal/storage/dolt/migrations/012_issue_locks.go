@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateIssueLocksTable creates the issue_locks table, which records the
+// actor holding each locked issue (see bd lock).
+func MigrateIssueLocksTable(db *sql.DB) error {
+	exists, err := tableExists(db, "issue_locks")
+	if err != nil {
+		return fmt.Errorf("failed to check issue_locks existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(issueLocksSchema); err != nil {
+		return fmt.Errorf("failed to create issue_locks table: %w", err)
+	}
+	return nil
+}
+
+const issueLocksSchema = `CREATE TABLE issue_locks (
+    issue_id VARCHAR(255) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    locked_at DATETIME NOT NULL,
+    expires_at DATETIME,
+    CONSTRAINT fk_issue_locks_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+)`
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateAvailabilityTable creates the availability table, which records
+// the days people are away (see bd availability).
+func MigrateAvailabilityTable(db *sql.DB) error {
+	exists, err := tableExists(db, "availability")
+	if err != nil {
+		return fmt.Errorf("failed to check availability existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(availabilitySchema); err != nil {
+		return fmt.Errorf("failed to create availability table: %w", err)
+	}
+	return nil
+}
+
+const availabilitySchema = `CREATE TABLE availability (
+    person VARCHAR(255) NOT NULL,
+    start_date CHAR(10) NOT NULL,
+    end_date CHAR(10) NOT NULL,
+    note TEXT NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (person, start_date),
+    INDEX idx_availability_end (end_date)
+)`
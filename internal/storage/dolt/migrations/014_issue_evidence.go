@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateIssueEvidenceTable creates the issue_evidence table, which records
+// verification evidence attached when closing issues (see bd close
+// --evidence-url). File contents live in description_blobs.
+func MigrateIssueEvidenceTable(db *sql.DB) error {
+	exists, err := tableExists(db, "issue_evidence")
+	if err != nil {
+		return fmt.Errorf("failed to check issue_evidence existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(issueEvidenceSchema); err != nil {
+		return fmt.Errorf("failed to create issue_evidence table: %w", err)
+	}
+	return nil
+}
+
+const issueEvidenceSchema = `CREATE TABLE issue_evidence (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    issue_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    ref TEXT NOT NULL,
+    content_hash CHAR(64),
+    size INT NOT NULL DEFAULT 0,
+    added_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_issue_evidence_issue (issue_id),
+    CONSTRAINT fk_issue_evidence_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+)`
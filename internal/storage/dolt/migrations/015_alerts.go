@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateAlertsTable creates the alerts table, which holds saved queries
+// and their matches at the last check (see bd alert).
+func MigrateAlertsTable(db *sql.DB) error {
+	exists, err := tableExists(db, "alerts")
+	if err != nil {
+		return fmt.Errorf("failed to check alerts existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(alertsSchema); err != nil {
+		return fmt.Errorf("failed to create alerts table: %w", err)
+	}
+	return nil
+}
+
+const alertsSchema = `CREATE TABLE alerts (
+    name VARCHAR(255) PRIMARY KEY,
+    query TEXT NOT NULL,
+    notify VARCHAR(255) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    last_ids TEXT NOT NULL,
+    last_checked_at DATETIME,
+    last_fired_at DATETIME
+)`
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateExternalKeysTable creates the external_keys table, which maps
+// foreign identifiers such as JIRA-123 to issues (see bd alias).
+func MigrateExternalKeysTable(db *sql.DB) error {
+	exists, err := tableExists(db, "external_keys")
+	if err != nil {
+		return fmt.Errorf("failed to check external_keys existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(externalKeysSchema); err != nil {
+		return fmt.Errorf("failed to create external_keys table: %w", err)
+	}
+	return nil
+}
+
+const externalKeysSchema = `CREATE TABLE external_keys (
+    ext_key VARCHAR(255) PRIMARY KEY,
+    issue_id VARCHAR(255) NOT NULL,
+    source VARCHAR(64) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_external_keys_issue (issue_id),
+    CONSTRAINT fk_external_keys_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+)`
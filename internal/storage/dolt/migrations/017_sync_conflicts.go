@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateSyncConflictsTable creates the sync_conflicts table, which queues
+// issues changed both locally and in an external tracker (see bd sync
+// conflicts).
+func MigrateSyncConflictsTable(db *sql.DB) error {
+	exists, err := tableExists(db, "sync_conflicts")
+	if err != nil {
+		return fmt.Errorf("failed to check sync_conflicts existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(syncConflictsSchema); err != nil {
+		return fmt.Errorf("failed to create sync_conflicts table: %w", err)
+	}
+	return nil
+}
+
+const syncConflictsSchema = `CREATE TABLE sync_conflicts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    issue_id VARCHAR(255) NOT NULL,
+    tracker VARCHAR(64) NOT NULL,
+    external_ref TEXT NOT NULL,
+    external_id VARCHAR(255) NOT NULL,
+    local_fields JSON NOT NULL,
+    external_fields JSON NOT NULL,
+    local_updated DATETIME NOT NULL,
+    external_updated DATETIME NOT NULL,
+    detected_at DATETIME NOT NULL,
+    resolution VARCHAR(16) NOT NULL DEFAULT '',
+    resolved_by VARCHAR(255) NOT NULL DEFAULT '',
+    resolved_at DATETIME,
+    pending_push BOOLEAN NOT NULL DEFAULT FALSE,
+    INDEX idx_sync_conflicts_issue (issue_id),
+    CONSTRAINT fk_sync_conflicts_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+)`
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateIssueJournalTable creates the issue_journal table, which holds the
+// work-log entries added with bd journal.
+func MigrateIssueJournalTable(db *sql.DB) error {
+	exists, err := tableExists(db, "issue_journal")
+	if err != nil {
+		return fmt.Errorf("failed to check issue_journal existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(issueJournalSchema); err != nil {
+		return fmt.Errorf("failed to create issue_journal table: %w", err)
+	}
+	return nil
+}
+
+const issueJournalSchema = `CREATE TABLE issue_journal (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    issue_id VARCHAR(255) NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    text TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_issue_journal_issue (issue_id),
+    CONSTRAINT fk_issue_journal_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+)`
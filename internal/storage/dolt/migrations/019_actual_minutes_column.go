@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateActualMinutesColumn adds the actual_minutes column, the time spent
+// on an issue (bd time log), to the issues and wisps tables. New databases
+// already have it from the schema definition; the wisps table exists by now
+// (MigrateWispsTable runs first).
+func MigrateActualMinutesColumn(db *sql.DB) error {
+	for _, table := range []string{"issues", "wisps"} {
+		exists, err := columnExists(db, table, "actual_minutes")
+		if err != nil {
+			return fmt.Errorf("failed to check actual_minutes column on %s: %w", table, err)
+		}
+		if exists {
+			continue
+		}
+		//nolint:gosec // G201: table is one of the hardcoded names above
+		if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN actual_minutes INT", table)); err != nil {
+			return fmt.Errorf("failed to add actual_minutes column to %s: %w", table, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateMilestonesTable creates the milestones table, which holds the
+// sprints and milestones managed with bd milestone.
+func MigrateMilestonesTable(db *sql.DB) error {
+	exists, err := tableExists(db, "milestones")
+	if err != nil {
+		return fmt.Errorf("failed to check milestones existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(milestonesSchema); err != nil {
+		return fmt.Errorf("failed to create milestones table: %w", err)
+	}
+	return nil
+}
+
+const milestonesSchema = `CREATE TABLE milestones (
+    name VARCHAR(255) PRIMARY KEY,
+    title VARCHAR(500) NOT NULL,
+    description TEXT NOT NULL,
+    due_at DATETIME,
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    created_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    closed_at DATETIME
+)`
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateMilestoneBaselinesTable creates the milestone_baselines table,
+// which holds the committed issue sets recorded by bd milestone baseline.
+func MigrateMilestoneBaselinesTable(db *sql.DB) error {
+	exists, err := tableExists(db, "milestone_baselines")
+	if err != nil {
+		return fmt.Errorf("failed to check milestone_baselines existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(milestoneBaselinesSchema); err != nil {
+		return fmt.Errorf("failed to create milestone_baselines table: %w", err)
+	}
+	return nil
+}
+
+const milestoneBaselinesSchema = `CREATE TABLE milestone_baselines (
+    milestone VARCHAR(255) NOT NULL,
+    issue_id VARCHAR(255) NOT NULL,
+    title VARCHAR(500) NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    estimated_minutes INT,
+    taken_by VARCHAR(255) NOT NULL,
+    taken_at DATETIME NOT NULL,
+    PRIMARY KEY (milestone, issue_id),
+    INDEX idx_milestone_baselines_issue (issue_id)
+)`
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MigrateRecurrencesTable creates the recurrences table, which holds the
+// recurring issue templates managed with bd recur.
+func MigrateRecurrencesTable(db *sql.DB) error {
+	exists, err := tableExists(db, "recurrences")
+	if err != nil {
+		return fmt.Errorf("failed to check recurrences existence: %w", err)
+	}
+	if exists {
+		return nil
+	}
+
+	if _, err := db.Exec(recurrencesSchema); err != nil {
+		return fmt.Errorf("failed to create recurrences table: %w", err)
+	}
+	return nil
+}
+
+const recurrencesSchema = `CREATE TABLE recurrences (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    title VARCHAR(500) NOT NULL,
+    every VARCHAR(32) NOT NULL,
+    description TEXT NOT NULL,
+    design TEXT NOT NULL,
+    acceptance_criteria TEXT NOT NULL,
+    priority INT NOT NULL DEFAULT 2,
+    issue_type VARCHAR(32) NOT NULL DEFAULT 'task',
+    assignee VARCHAR(255) NOT NULL DEFAULT '',
+    labels TEXT NOT NULL,
+    next_at DATETIME NOT NULL,
+    last_issue_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL
+)`
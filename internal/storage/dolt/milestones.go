@@ -0,0 +1,146 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CreateMilestone creates milestone m, failing if one of the same name
+// exists.
+func (s *DoltStore) CreateMilestone(ctx context.Context, m *types.Milestone) error {
+	if err := s.authorize(m.CreatedBy, permissions.Update); err != nil {
+		return err
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now().UTC()
+	}
+	m.Status = types.MilestoneOpen
+	_, err := s.execContext(ctx, `
+		INSERT INTO milestones (name, title, description, due_at, status, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, m.Name, m.Title, m.Description, m.DueAt, m.Status, m.CreatedBy, m.CreatedAt)
+	if isDuplicateKey(err) {
+		return fmt.Errorf("milestone %s already exists", m.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create milestone %s: %w", m.Name, err)
+	}
+	return nil
+}
+
+// CloseMilestone marks the named milestone closed, reporting whether it
+// existed.
+func (s *DoltStore) CloseMilestone(ctx context.Context, name string) (bool, error) {
+	if err := s.authorize("", permissions.Update); err != nil {
+		return false, err
+	}
+	result, err := s.execContext(ctx, `UPDATE milestones SET status = ?, closed_at = ? WHERE name = ?`,
+		types.MilestoneClosed, time.Now().UTC(), name)
+	if err != nil {
+		return false, fmt.Errorf("failed to close milestone %s: %w", name, err)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
+
+// GetMilestone returns the named milestone, or nil if there is none.
+func (s *DoltStore) GetMilestone(ctx context.Context, name string) (*types.Milestone, error) {
+	milestones, err := s.getMilestones(ctx, "WHERE name = ?", name)
+	if err != nil || len(milestones) == 0 {
+		return nil, err
+	}
+	return milestones[0], nil
+}
+
+// GetMilestones returns every milestone, soonest due first; those without
+// a due date come last, by name.
+func (s *DoltStore) GetMilestones(ctx context.Context) ([]*types.Milestone, error) {
+	return s.getMilestones(ctx, "")
+}
+
+func (s *DoltStore) getMilestones(ctx context.Context, where string, args ...interface{}) ([]*types.Milestone, error) {
+	rows, err := s.queryContext(ctx, `
+		SELECT name, title, description, due_at, status, created_by, created_at, closed_at
+		FROM milestones `+where+`
+		ORDER BY due_at IS NULL, due_at, name`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get milestones: %w", err)
+	}
+	defer rows.Close()
+
+	var milestones []*types.Milestone
+	for rows.Next() {
+		var m types.Milestone
+		var due, closed sql.NullTime
+		if err := rows.Scan(&m.Name, &m.Title, &m.Description, &due, &m.Status, &m.CreatedBy, &m.CreatedAt, &closed); err != nil {
+			return nil, fmt.Errorf("failed to scan milestone: %w", err)
+		}
+		if due.Valid {
+			m.DueAt = &due.Time
+		}
+		if closed.Valid {
+			m.ClosedAt = &closed.Time
+		}
+		milestones = append(milestones, &m)
+	}
+	return milestones, rows.Err()
+}
+
+// SetMilestoneBaseline records items as the named milestone's committed
+// issue set, replacing any earlier baseline.
+func (s *DoltStore) SetMilestoneBaseline(ctx context.Context, name string, items []*types.MilestoneBaselineItem) error {
+	if err := s.authorize("", permissions.Update); err != nil {
+		return err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // No-op after successful commit
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM milestone_baselines WHERE milestone = ?`, name); err != nil {
+		return fmt.Errorf("failed to clear baseline of %s: %w", name, err)
+	}
+	for _, item := range items {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO milestone_baselines (milestone, issue_id, title, status, estimated_minutes, taken_by, taken_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, name, item.IssueID, item.Title, item.Status, item.EstimatedMinutes, item.TakenBy, item.TakenAt)
+		if err != nil {
+			return fmt.Errorf("failed to record %s in baseline of %s: %w", item.IssueID, name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetMilestoneBaseline returns the named milestone's baseline by issue ID,
+// or nil if none was taken.
+func (s *DoltStore) GetMilestoneBaseline(ctx context.Context, name string) ([]*types.MilestoneBaselineItem, error) {
+	rows, err := s.queryContext(ctx, `
+		SELECT milestone, issue_id, title, status, estimated_minutes, taken_by, taken_at
+		FROM milestone_baselines WHERE milestone = ? ORDER BY issue_id`, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get baseline of %s: %w", name, err)
+	}
+	defer rows.Close()
+
+	var items []*types.MilestoneBaselineItem
+	for rows.Next() {
+		var item types.MilestoneBaselineItem
+		var estimate sql.NullInt64
+		if err := rows.Scan(&item.Milestone, &item.IssueID, &item.Title, &item.Status, &estimate, &item.TakenBy, &item.TakenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan baseline item: %w", err)
+		}
+		if estimate.Valid {
+			n := int(estimate.Int64)
+			item.EstimatedMinutes = &n
+		}
+		items = append(items, &item)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,91 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestMilestones(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	due := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)
+	for _, m := range []*types.Milestone{
+		{Name: "backlog", Title: "Someday", CreatedBy: "alice"},
+		{Name: "sprint-12", Title: "Sprint 12", DueAt: &due, CreatedBy: "alice"},
+	} {
+		if err := store.CreateMilestone(ctx, m); err != nil {
+			t.Fatalf("CreateMilestone: %v", err)
+		}
+	}
+	if err := store.CreateMilestone(ctx, &types.Milestone{Name: "sprint-12", CreatedBy: "bob"}); err == nil {
+		t.Error("CreateMilestone accepted a duplicate name")
+	}
+
+	milestones, err := store.GetMilestones(ctx)
+	if err != nil {
+		t.Fatalf("GetMilestones: %v", err)
+	}
+	if len(milestones) != 2 || milestones[0].Name != "sprint-12" || milestones[0].DueAt == nil || !milestones[0].DueAt.Equal(due) {
+		t.Fatalf("GetMilestones = %+v, want sprint-12 (due) before backlog", milestones)
+	}
+
+	if ok, err := store.CloseMilestone(ctx, "sprint-12"); err != nil || !ok {
+		t.Fatalf("CloseMilestone = %v, %v", ok, err)
+	}
+	m, err := store.GetMilestone(ctx, "sprint-12")
+	if err != nil || m == nil || m.Status != types.MilestoneClosed || m.ClosedAt == nil {
+		t.Errorf("closed milestone = %+v, %v", m, err)
+	}
+	if ok, _ := store.CloseMilestone(ctx, "missing"); ok {
+		t.Error("CloseMilestone reported closing a missing milestone")
+	}
+	if m, err := store.GetMilestone(ctx, "missing"); m != nil || err != nil {
+		t.Errorf("GetMilestone(missing) = %+v, %v", m, err)
+	}
+}
+
+func TestMilestoneBaseline(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	if items, err := store.GetMilestoneBaseline(ctx, "sprint-12"); err != nil || items != nil {
+		t.Fatalf("GetMilestoneBaseline before any baseline = %v, %v", items, err)
+	}
+
+	estimate := 90
+	taken := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	first := []*types.MilestoneBaselineItem{
+		{IssueID: "bd-1", Title: "One", Status: types.StatusOpen, EstimatedMinutes: &estimate, TakenBy: "alice", TakenAt: taken},
+		{IssueID: "bd-2", Title: "Two", Status: types.StatusInProgress, TakenBy: "alice", TakenAt: taken},
+	}
+	if err := store.SetMilestoneBaseline(ctx, "sprint-12", first); err != nil {
+		t.Fatalf("SetMilestoneBaseline: %v", err)
+	}
+	items, err := store.GetMilestoneBaseline(ctx, "sprint-12")
+	if err != nil || len(items) != 2 {
+		t.Fatalf("GetMilestoneBaseline = %v, %v", items, err)
+	}
+	if items[0].IssueID != "bd-1" || items[0].EstimatedMinutes == nil || *items[0].EstimatedMinutes != 90 || items[1].EstimatedMinutes != nil {
+		t.Errorf("baseline items = %+v, %+v", items[0], items[1])
+	}
+
+	// Taking it again replaces the set
+	again := []*types.MilestoneBaselineItem{{IssueID: "bd-3", Title: "Three", Status: types.StatusOpen, TakenBy: "bob", TakenAt: taken.Add(time.Hour)}}
+	if err := store.SetMilestoneBaseline(ctx, "sprint-12", again); err != nil {
+		t.Fatalf("SetMilestoneBaseline again: %v", err)
+	}
+	if items, _ := store.GetMilestoneBaseline(ctx, "sprint-12"); len(items) != 1 || items[0].IssueID != "bd-3" {
+		t.Errorf("replaced baseline = %+v", items)
+	}
+}
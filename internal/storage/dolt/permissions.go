@@ -0,0 +1,53 @@
+package dolt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// authorize returns a *permissions.DeniedError if actor lacks capability c
+// under the store's Config.Policy. An empty actor is the store's
+// Config.PolicyActor, for writes whose signature names no actor.
+func (s *DoltStore) authorize(actor string, c permissions.Capability) error {
+	if s.policy == nil {
+		return nil
+	}
+	if actor == "" {
+		actor = s.policyActor
+	}
+	return s.policy.Check(actor, c)
+}
+
+// authorize checks actor's capability within the transaction.
+func (t *doltTransaction) authorize(actor string, c permissions.Capability) error {
+	return t.store.authorize(actor, c)
+}
+
+// updateCapability is the capability an update of an issue in status old
+// needs: close to change the status to or from closed, update otherwise.
+func updateCapability(old types.Status, updates map[string]interface{}) permissions.Capability {
+	if status, ok := updates["status"]; ok {
+		if types.Status(fmt.Sprint(status)) == types.StatusClosed || old == types.StatusClosed {
+			return permissions.Close
+		}
+	}
+	return permissions.Update
+}
+
+// configCapability is the capability setting config key needs: update for
+// bd kv's keys, federation for tracker sync bookkeeping, and admin for the
+// rest, which changes how bd behaves for everyone (issue_prefix, id_scheme,
+// custom statuses).
+func configCapability(key string) permissions.Capability {
+	switch {
+	case strings.HasPrefix(key, "kv."):
+		return permissions.Update
+	case strings.HasSuffix(key, ".last_sync"), strings.HasSuffix(key, ".sync_state"), key == "linear.webhook_last":
+		return permissions.Federation
+	default:
+		return permissions.Admin
+	}
+}
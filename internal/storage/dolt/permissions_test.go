@@ -0,0 +1,130 @@
+package dolt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestUpdateCapability(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		old     types.Status
+		updates map[string]interface{}
+		want    permissions.Capability
+	}{
+		{"title", types.StatusOpen, map[string]interface{}{"title": "x"}, permissions.Update},
+		{"start work", types.StatusOpen, map[string]interface{}{"status": "in_progress"}, permissions.Update},
+		{"close", types.StatusOpen, map[string]interface{}{"status": types.StatusClosed}, permissions.Close},
+		{"reopen", types.StatusClosed, map[string]interface{}{"status": "open"}, permissions.Close},
+		{"edit closed", types.StatusClosed, map[string]interface{}{"notes": "x"}, permissions.Update},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := updateCapability(tt.old, tt.updates); got != tt.want {
+				t.Errorf("updateCapability(%s, %v) = %s, want %s", tt.old, tt.updates, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Parallel()
+
+	if err := (&DoltStore{}).authorize("bob", permissions.Admin); err != nil {
+		t.Errorf("authorize without a policy = %v", err)
+	}
+
+	policy, err := permissions.Parse([]byte("default: [update]\nactors:\n  alice: [admin]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &DoltStore{policy: policy, policyActor: "alice"}
+	if err := s.authorize("", permissions.Delete); err != nil {
+		t.Errorf("authorize with the store's actor = %v", err)
+	}
+	if err := s.authorize("bob", permissions.Delete); !errors.Is(err, permissions.ErrDenied) {
+		t.Errorf("authorize(bob, delete) = %v, want ErrDenied", err)
+	}
+}
+
+func TestConfigCapability(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]permissions.Capability{
+		"issue_prefix":        permissions.Admin,
+		"id_scheme":           permissions.Admin,
+		"status.custom":       permissions.Admin,
+		"kv.deploy_target":    permissions.Update,
+		"linear.last_sync":    permissions.Federation,
+		"jira.sync_state":     permissions.Federation,
+		"linear.webhook_last": permissions.Federation,
+	}
+	for key, want := range tests {
+		if got := configCapability(key); got != want {
+			t.Errorf("configCapability(%q) = %s, want %s", key, got, want)
+		}
+	}
+}
+
+// A restricted actor is refused before anything is written, so these need
+// no database.
+func TestRestrictedActorWrites(t *testing.T) {
+	t.Parallel()
+
+	policy, err := permissions.Parse([]byte("default: []\nactors:\n  triage-bot: [update]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	// A triage actor may edit issues, but not change how bd behaves
+	triage := &DoltStore{policy: policy, policyActor: "triage-bot"}
+	triageTx := &doltTransaction{store: triage}
+	_, squashErr := triage.SquashHistory(ctx, "squash")
+	_, gcErr := triage.GC(ctx, true)
+	for name, err := range map[string]error{
+		"SetConfig":            triage.SetConfig(ctx, "issue_prefix", "evil"),
+		"DeleteConfig":         triage.DeleteConfig(ctx, "id_scheme"),
+		"SetMetadata":          triage.SetMetadata(ctx, "repo_id", "x"),
+		"SetConfig sync state": triage.SetConfig(ctx, "linear.last_sync", "2026-01-01T00:00:00Z"),
+		"tx SetConfig":         triageTx.SetConfig(ctx, "issue_prefix", "evil"),
+		"tx SetMetadata":       triageTx.SetMetadata(ctx, "repo_id", "x"),
+		"SquashHistory":        squashErr,
+		"GC":                   gcErr,
+	} {
+		if !errors.Is(err, permissions.ErrDenied) {
+			t.Errorf("%s by a triage actor = %v, want ErrDenied", name, err)
+		}
+	}
+
+	// A read-only actor may write nothing
+	viewer := &DoltStore{policy: policy, policyActor: "viewer"}
+	_, removeErr := viewer.RemoveAlert(ctx, "p0")
+	_, closeMilestoneErr := viewer.CloseMilestone(ctx, "v1")
+	_, removeRecurrenceErr := viewer.RemoveRecurrence(ctx, 1)
+	_, claimErr := viewer.ClaimRecurrence(ctx, 1, time.Now(), time.Now().Add(time.Hour))
+	for name, err := range map[string]error{
+		"SetConfig kv":           viewer.SetConfig(ctx, "kv.target", "prod"),
+		"SaveAlert":              viewer.SaveAlert(ctx, &types.Alert{Name: "p0", Query: "priority=0"}),
+		"RemoveAlert":            removeErr,
+		"RecordAlertCheck":       viewer.RecordAlertCheck(ctx, "p0", nil, time.Now(), false),
+		"CreateMilestone":        viewer.CreateMilestone(ctx, &types.Milestone{Name: "v1"}),
+		"CloseMilestone":         closeMilestoneErr,
+		"SetMilestoneBaseline":   viewer.SetMilestoneBaseline(ctx, "v1", nil),
+		"CreateRecurrence":       viewer.CreateRecurrence(ctx, &types.Recurrence{Title: "Rotate keys", Every: "weekly"}),
+		"RemoveRecurrence":       removeRecurrenceErr,
+		"ClaimRecurrence":        claimErr,
+		"SetRecurrenceLastIssue": viewer.SetRecurrenceLastIssue(ctx, 1, "bd-1"),
+	} {
+		if !errors.Is(err, permissions.ErrDenied) {
+			t.Errorf("%s by a read-only actor = %v, want ErrDenied", name, err)
+		}
+	}
+}
@@ -4,15 +4,24 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
+	"unicode"
 
+	"github.com/steveyegge/beads/internal/schedule"
 	"github.com/steveyegge/beads/internal/types"
 )
 
 // SearchIssues finds issues matching query and filters
 func (s *DoltStore) SearchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
+	return s.cachedQuery(ctx,
+		func() (string, bool) { return searchCacheKey(query, filter) },
+		func() ([]*types.Issue, error) { return s.searchIssues(ctx, query, filter) })
+}
+
+func (s *DoltStore) searchIssues(ctx context.Context, query string, filter types.IssueFilter) ([]*types.Issue, error) {
 	// Route ephemeral-only queries to wisps table
 	if filter.Ephemeral != nil && *filter.Ephemeral {
 		return s.searchWisps(ctx, query, filter)
@@ -30,9 +39,18 @@ func (s *DoltStore) SearchIssues(ctx context.Context, query string, filter types
 	args := []interface{}{}
 
 	if query != "" {
-		whereClauses = append(whereClauses, "(title LIKE ? OR description LIKE ? OR id LIKE ?)")
 		pattern := "%" + query + "%"
-		args = append(args, pattern, pattern, pattern)
+		descClause, descArgs := descriptionSearchClause(pattern)
+		whereClauses = append(whereClauses, "(title LIKE ? OR "+descClause+" OR id LIKE ?)")
+		args = append(args, pattern)
+		args = append(args, descArgs...)
+		args = append(args, pattern)
+	}
+
+	if filter.FullText != "" {
+		clause, textArgs := fullTextClause(filter.FullText, "comments", true)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, textArgs...)
 	}
 
 	if filter.TitleSearch != "" {
@@ -45,8 +63,9 @@ func (s *DoltStore) SearchIssues(ctx context.Context, query string, filter types
 		args = append(args, "%"+filter.TitleContains+"%")
 	}
 	if filter.DescriptionContains != "" {
-		whereClauses = append(whereClauses, "description LIKE ?")
-		args = append(args, "%"+filter.DescriptionContains+"%")
+		descClause, descArgs := descriptionSearchClause("%" + filter.DescriptionContains + "%")
+		whereClauses = append(whereClauses, descClause)
+		args = append(args, descArgs...)
 	}
 	if filter.NotesContains != "" {
 		whereClauses = append(whereClauses, "notes LIKE ?")
@@ -312,17 +331,37 @@ func (s *DoltStore) SearchIssues(ctx context.Context, query string, filter types
 	return doltResults, nil
 }
 
+// readyExcludedTypes are the issue types GetReadyWork leaves out unless
+// asked for by type (see getReadyWork).
+var readyExcludedTypes = []string{"merge-request", "gate", "molecule", "message", "agent", "role", "rig"}
+
 // GetReadyWork returns issues that are ready to work on (not blocked)
 func (s *DoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
+	return s.cachedQuery(ctx,
+		func() (string, bool) { return readyCacheKey(filter) },
+		func() ([]*types.Issue, error) { return s.getReadyWork(ctx, filter) })
+}
+
+func (s *DoltStore) getReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	// Status filtering: default to open OR in_progress (matches memory storage)
+	// Status filtering: default to the ready-eligible statuses (status.ready,
+	// open and in_progress unless configured)
+	args := []interface{}{}
 	var statusClause string
 	if filter.Status != "" {
 		statusClause = "status = ?"
+		args = append(args, string(filter.Status))
 	} else {
-		statusClause = "status IN ('open', 'in_progress')"
+		readyStatuses, err := s.GetReadyStatuses(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ready statuses: %w", err)
+		}
+		statusClause = "status IN (" + placeholders(len(readyStatuses)) + ")"
+		for _, status := range readyStatuses {
+			args = append(args, status)
+		}
 	}
 	whereClauses := []string{
 		statusClause,
@@ -331,20 +370,11 @@ func (s *DoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) (
 	if !filter.IncludeEphemeral {
 		whereClauses = append(whereClauses, "(ephemeral = 0 OR ephemeral IS NULL)")
 	}
-	args := []interface{}{}
-	if filter.Status != "" {
-		args = append(args, string(filter.Status))
-	}
 
-	if filter.Priority != nil {
-		whereClauses = append(whereClauses, "priority = ?")
-		args = append(args, *filter.Priority)
-	}
-	// Use subquery for type filter to prevent Dolt mergeJoinIter panic (see SearchIssues).
-	if filter.Type != "" {
-		whereClauses = append(whereClauses, "id IN (SELECT id FROM issues WHERE issue_type = ?)")
-		args = append(args, filter.Type)
-	} else {
+	filterClauses, filterArgs := workFilterClauses(filter)
+	whereClauses = append(whereClauses, filterClauses...)
+	args = append(args, filterArgs...)
+	if filter.Type == "" {
 		// Exclude workflow/identity types from ready work by default.
 		// These are internal items, not actionable work for agents to claim:
 		// - merge-request: processed by Refinery
@@ -354,21 +384,13 @@ func (s *DoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) (
 		// - agent: identity/state tracking beads
 		// - role: agent role definitions (reference metadata)
 		// - rig: rig identity beads (reference metadata)
-		excludeTypes := []string{"merge-request", "gate", "molecule", "message", "agent", "role", "rig"}
-		placeholders := make([]string, len(excludeTypes))
-		for i, t := range excludeTypes {
+		placeholders := make([]string, len(readyExcludedTypes))
+		for i, t := range readyExcludedTypes {
 			placeholders[i] = "?"
 			args = append(args, t)
 		}
 		whereClauses = append(whereClauses, fmt.Sprintf("id IN (SELECT id FROM issues WHERE issue_type NOT IN (%s))", strings.Join(placeholders, ",")))
 	}
-	// Unassigned takes precedence over Assignee filter (matches memory storage)
-	if filter.Unassigned {
-		whereClauses = append(whereClauses, "(assignee IS NULL OR assignee = '')")
-	} else if filter.Assignee != nil {
-		whereClauses = append(whereClauses, "assignee = ?")
-		args = append(args, *filter.Assignee)
-	}
 	// Exclude future-deferred issues unless IncludeDeferred is set
 	if !filter.IncludeDeferred {
 		whereClauses = append(whereClauses, "(defer_until IS NULL OR defer_until <= NOW())")
@@ -386,13 +408,6 @@ func (s *DoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) (
 			)
 		`)
 	}
-	if len(filter.Labels) > 0 {
-		for _, label := range filter.Labels {
-			whereClauses = append(whereClauses, "id IN (SELECT issue_id FROM labels WHERE label = ?)")
-			args = append(args, label)
-		}
-	}
-
 	// Exclude blocked issues: pre-compute blocked set using separate single-table
 	// queries to avoid Dolt's joinIter panic (join_iters.go:192).
 	// Correlated EXISTS/NOT EXISTS subqueries across tables trigger the same panic.
@@ -408,18 +423,29 @@ func (s *DoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) (
 
 	whereSQL := "WHERE " + strings.Join(whereClauses, " AND ")
 
+	// Votes live in another table; sort them in Go (see GetBlockedIssues)
+	// and apply the limit afterwards. Aged priorities depend on the clock
+	// and config, so they are sorted in Go as well.
+	// Fairness interleaves the sorted results, so it needs them all too.
+	byVotes := filter.SortPolicy == types.SortPolicyVotes
+	byAge := filter.SortPolicy == types.SortPolicyHybrid
+	limitInGo := byVotes || byAge || filter.Fairness != types.FairnessNone
 	limitSQL := ""
-	if filter.Limit > 0 {
+	if filter.Limit > 0 && !limitInGo {
 		limitSQL = fmt.Sprintf(" LIMIT %d", filter.Limit)
 	}
+	orderSQL := "priority ASC, created_at DESC"
+	if filter.SortPolicy == types.SortPolicyOldest {
+		orderSQL = "created_at ASC"
+	}
 
-	// nolint:gosec // G201: whereSQL contains column comparisons with ?, limitSQL is a safe integer
+	// nolint:gosec // G201: whereSQL contains column comparisons with ?, orderSQL is constant, limitSQL is a safe integer
 	query := fmt.Sprintf(`
 		SELECT id FROM issues
 		%s
-		ORDER BY priority ASC, created_at DESC
+		ORDER BY %s
 		%s
-	`, whereSQL, limitSQL)
+	`, whereSQL, orderSQL, limitSQL)
 
 	rows, err := s.queryContext(ctx, query, args...)
 	if err != nil {
@@ -432,6 +458,31 @@ func (s *DoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) (
 		return nil, err
 	}
 
+	if byVotes {
+		ids := make([]string, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+		votes, err := s.GetVoteCounts(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		sortByVotes(issues, votes)
+	}
+	if byAge {
+		interval, maxBoost, err := s.agingCurve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sortByAgedPriority(issues, time.Now(), interval, maxBoost)
+	}
+	if err := s.applyFairness(ctx, issues, filter.Fairness); err != nil {
+		return nil, err
+	}
+	if limitInGo && filter.Limit > 0 && len(issues) > filter.Limit {
+		issues = issues[:filter.Limit]
+	}
+
 	// When IncludeEphemeral is set, also query the wisps table for ready work.
 	if filter.IncludeEphemeral {
 		wispFilter := types.IssueFilter{Limit: filter.Limit}
@@ -448,7 +499,49 @@ func (s *DoltStore) GetReadyWork(ctx context.Context, filter types.WorkFilter) (
 	return issues, nil
 }
 
-// GetBlockedIssues returns issues that are blocked by other issues.
+// workFilterClauses returns the WHERE clauses, and their args, that select
+// issues matching filter's type, priority, assignee, labels and parent.
+// Subqueries stand in for joins, which trigger Dolt's mergeJoinIter panic
+// (see SearchIssues).
+func workFilterClauses(filter types.WorkFilter) ([]string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+	if filter.Priority != nil {
+		clauses = append(clauses, "priority = ?")
+		args = append(args, *filter.Priority)
+	}
+	if filter.Type != "" {
+		clauses = append(clauses, "id IN (SELECT id FROM issues WHERE issue_type = ?)")
+		args = append(args, filter.Type)
+	}
+	// Unassigned takes precedence over Assignee filter (matches memory storage)
+	if filter.Unassigned {
+		clauses = append(clauses, "(assignee IS NULL OR assignee = '')")
+	} else if filter.Assignee != nil {
+		clauses = append(clauses, "assignee = ?")
+		args = append(args, *filter.Assignee)
+	}
+	for _, label := range filter.Labels {
+		clauses = append(clauses, "id IN (SELECT issue_id FROM labels WHERE label = ?)")
+		args = append(args, label)
+	}
+	if len(filter.LabelsAny) > 0 {
+		clauses = append(clauses, fmt.Sprintf("id IN (SELECT issue_id FROM labels WHERE label IN (%s))", placeholders(len(filter.LabelsAny))))
+		for _, label := range filter.LabelsAny {
+			args = append(args, label)
+		}
+	}
+	// Also includes dotted-ID children (e.g., "parent.1.2" is child of "parent")
+	if filter.ParentID != nil {
+		clauses = append(clauses, "(id IN (SELECT issue_id FROM dependencies WHERE type = 'parent-child' AND depends_on_id = ?) OR id LIKE CONCAT(?, '.%'))")
+		args = append(args, *filter.ParentID, *filter.ParentID)
+	}
+	return clauses, args
+}
+
+// GetBlockedIssues returns issues that are blocked by other issues, narrowed
+// by filter's status, type, priority, assignee, labels and parent and capped
+// at its Limit; its sort and fairness options don't apply.
 // Uses separate single-table queries with Go-level filtering to avoid
 // correlated EXISTS subqueries that trigger Dolt's joinIter panic
 // (slice bounds out of range at join_iters.go:192).
@@ -457,47 +550,127 @@ func (s *DoltStore) GetBlockedIssues(ctx context.Context, filter types.WorkFilte
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	// Step 1: Get all open/active issue IDs into a set (single-table scan)
-	activeIDs := make(map[string]bool)
-	activeRows, err := s.queryContext(ctx, `
-		SELECT id FROM issues
-		WHERE status IN ('open', 'in_progress', 'blocked', 'deferred', 'hooked')
-	`)
+	blockerMap, err := s.openBlockers(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get active issues: %w", err)
+		return nil, err
+	}
+
+	// Batch-fetch all blocked issues and build results
+	blockedIDs := make([]string, 0, len(blockerMap))
+	for id := range blockerMap {
+		blockedIDs = append(blockedIDs, id)
+	}
+	if blockedIDs, err = s.filterBlockedIDs(ctx, blockedIDs, filter); err != nil {
+		return nil, err
+	}
+	issues, err := s.GetIssuesByIDs(ctx, blockedIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch-fetch blocked issues: %w", err)
+	}
+	issueMap := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		issueMap[issue.ID] = issue
+	}
+
+	var results []*types.BlockedIssue
+	for id, blockerIDs := range blockerMap {
+		issue, ok := issueMap[id]
+		if !ok || issue == nil {
+			continue
+		}
+
+		results = append(results, &types.BlockedIssue{
+			Issue:          *issue,
+			BlockedByCount: len(blockerIDs),
+			BlockedBy:      blockerIDs,
+		})
+	}
+
+	// Sort by priority ASC, then created_at DESC (matching original SQL ORDER BY)
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Issue.Priority != results[j].Issue.Priority {
+			return results[i].Issue.Priority < results[j].Issue.Priority
+		}
+		return results[i].Issue.CreatedAt.After(results[j].Issue.CreatedAt)
+	})
+	if filter.Limit > 0 && len(results) > filter.Limit {
+		results = results[:filter.Limit]
+	}
+
+	return results, nil
+}
+
+// filterBlockedIDs narrows blocked issue IDs to those matching filter's
+// status, type, priority, assignee, labels and parent. Filtering looks in
+// the issues table, so wisps only remain with an empty filter.
+// Caller must hold s.mu (at least RLock).
+func (s *DoltStore) filterBlockedIDs(ctx context.Context, ids []string, filter types.WorkFilter) ([]string, error) {
+	clauses, args := workFilterClauses(filter)
+	if filter.Status != "" {
+		clauses = append(clauses, "status = ?")
+		args = append(args, string(filter.Status))
+	}
+	if len(clauses) == 0 || len(ids) == 0 {
+		return ids, nil
+	}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+	clauses = append(clauses, fmt.Sprintf("id IN (%s)", placeholders(len(ids))))
+	// nolint:gosec // G201: clauses are constant SQL with ? placeholders
+	rows, err := s.queryContext(ctx, "SELECT id FROM issues WHERE "+strings.Join(clauses, " AND "), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter blocked issues: %w", err)
 	}
-	for activeRows.Next() {
+	defer rows.Close()
+	var matched []string
+	for rows.Next() {
 		var id string
-		if err := activeRows.Scan(&id); err != nil {
-			_ = activeRows.Close() // Best effort cleanup on error path
+		if err := rows.Scan(&id); err != nil {
 			return nil, err
 		}
-		activeIDs[id] = true
+		matched = append(matched, id)
 	}
-	_ = activeRows.Close() // Redundant close for safety (rows already iterated)
-	if err := activeRows.Err(); err != nil {
-		return nil, err
+	return matched, rows.Err()
+}
+
+// openBlockers maps each blocked issue to the IDs of the issues blocking it.
+// Caller must hold s.mu (at least RLock).
+func (s *DoltStore) openBlockers(ctx context.Context) (map[string][]string, error) {
+	// Step 1: Get the active and blocking issue IDs (single-table scan)
+	activeIDs, blockingIDs, err := s.activeAndBlockingIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active issues: %w", err)
 	}
 
 	// Step 2: Get all blocking dependencies (single-table scan)
 	depRows, err := s.queryContext(ctx, `
-		SELECT issue_id, depends_on_id FROM dependencies
+		SELECT issue_id, depends_on_id, metadata FROM dependencies
 		WHERE type = 'blocks'
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get blocking dependencies: %w", err)
 	}
 
-	// Step 3: Filter in Go — both sides must be active
+	// Step 3: Filter in Go — the issue must be active and the blocker in a
+	// blocking status. Dependencies with scheduling semantics (relation,
+	// lag) are evaluated separately.
 	// blockerMap: blocked_issue_id -> list of active blocker IDs
 	blockerMap := make(map[string][]string)
+	var scheduled []scheduledDep
 	for depRows.Next() {
 		var issueID, blockerID string
-		if err := depRows.Scan(&issueID, &blockerID); err != nil {
+		var metadata sql.NullString
+		if err := depRows.Scan(&issueID, &blockerID, &metadata); err != nil {
 			_ = depRows.Close() // Best effort cleanup on error path
 			return nil, err
 		}
-		if activeIDs[issueID] && activeIDs[blockerID] {
+		if !activeIDs[issueID] {
+			continue
+		}
+		if meta := types.ParseScheduleMeta(metadata.String); !meta.IsZero() {
+			scheduled = append(scheduled, scheduledDep{issueID, blockerID, meta})
+		} else if blockingIDs[blockerID] {
 			blockerMap[issueID] = append(blockerMap[issueID], blockerID)
 		}
 	}
@@ -505,44 +678,105 @@ func (s *DoltStore) GetBlockedIssues(ctx context.Context, filter types.WorkFilte
 	if err := depRows.Err(); err != nil {
 		return nil, err
 	}
+	unsatisfied, err := s.unsatisfiedScheduledDeps(ctx, scheduled, schedule.Start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate scheduled dependencies: %w", err)
+	}
+	for _, d := range unsatisfied {
+		blockerMap[d.issueID] = append(blockerMap[d.issueID], d.blockerID)
+	}
+	return blockerMap, nil
+}
 
-	// Step 4: Batch-fetch all blocked issues and build results
-	blockedIDs := make([]string, 0, len(blockerMap))
-	for id := range blockerMap {
-		blockedIDs = append(blockedIDs, id)
+// GetBlockingChain explains why an issue is not ready work: the open issues
+// blocking it, directly or through other blockers, its deferral or its
+// parent's, and anything else GetReadyWork filters on, such as its status.
+func (s *DoltStore) GetBlockingChain(ctx context.Context, issueID string) (*types.BlockingChain, error) {
+	issue, err := s.GetIssue(ctx, issueID)
+	if err != nil {
+		return nil, err
 	}
-	issues, err := s.GetIssuesByIDs(ctx, blockedIDs)
+	chain := &types.BlockingChain{IssueID: issue.ID, Blockers: []*types.Blocker{}}
+
+	readyStatuses, err := s.GetReadyStatuses(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to batch-fetch blocked issues: %w", err)
+		return nil, fmt.Errorf("failed to get ready statuses: %w", err)
 	}
-	issueMap := make(map[string]*types.Issue, len(issues))
-	for _, issue := range issues {
-		issueMap[issue.ID] = issue
+	if !slices.Contains(readyStatuses, string(issue.Status)) {
+		chain.Reasons = append(chain.Reasons, fmt.Sprintf("status is %s", issue.Status))
+	}
+	if issue.Pinned {
+		chain.Reasons = append(chain.Reasons, "pinned (a context marker, not work)")
+	}
+	if issue.Ephemeral {
+		chain.Reasons = append(chain.Reasons, "ephemeral (listed only with --include-ephemeral)")
+	}
+	if slices.Contains(readyExcludedTypes, string(issue.IssueType)) {
+		chain.Reasons = append(chain.Reasons, fmt.Sprintf("%s issues are not ready work", issue.IssueType))
 	}
 
-	var results []*types.BlockedIssue
-	for id, blockerIDs := range blockerMap {
-		issue, ok := issueMap[id]
-		if !ok || issue == nil {
-			continue
+	// Deferral, the issue's own first, then its parent's (see getReadyWork)
+	now := time.Now()
+	if issue.DeferUntil != nil && issue.DeferUntil.After(now) {
+		chain.DeferUntil, chain.DeferredBy = issue.DeferUntil, issue.ID
+	} else {
+		deps, err := s.GetDependencyRecords(ctx, issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies: %w", err)
+		}
+		for _, dep := range deps {
+			if dep.Type != types.DepParentChild {
+				continue
+			}
+			parent, err := s.GetIssue(ctx, dep.DependsOnID)
+			if err != nil {
+				continue // Parent missing (e.g. external): nothing to wait for
+			}
+			if parent.DeferUntil != nil && parent.DeferUntil.After(now) {
+				chain.DeferUntil, chain.DeferredBy = parent.DeferUntil, parent.ID
+				break
+			}
 		}
+	}
 
-		results = append(results, &types.BlockedIssue{
-			Issue:          *issue,
-			BlockedByCount: len(blockerIDs),
-			BlockedBy:      blockerIDs,
-		})
+	s.mu.RLock()
+	blockerMap, err := s.openBlockers(ctx)
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, err
 	}
 
-	// Sort by priority ASC, then created_at DESC (matching original SQL ORDER BY)
-	sort.Slice(results, func(i, j int) bool {
-		if results[i].Issue.Priority != results[j].Issue.Priority {
-			return results[i].Issue.Priority < results[j].Issue.Priority
+	// Walk the blockers breadth-first so each is listed at its shallowest
+	depth := map[string]int{issue.ID: 0}
+	blocks := make(map[string]string)
+	var ids []string
+	for queue := []string{issue.ID}; len(queue) > 0; queue = queue[1:] {
+		id := queue[0]
+		for _, blockerID := range blockerMap[id] {
+			if _, seen := depth[blockerID]; seen {
+				continue
+			}
+			depth[blockerID], blocks[blockerID] = depth[id]+1, id
+			ids = append(ids, blockerID)
+			queue = append(queue, blockerID)
 		}
-		return results[i].Issue.CreatedAt.After(results[j].Issue.CreatedAt)
-	})
+	}
+	blockerIssues, err := s.GetIssuesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to batch-fetch blockers: %w", err)
+	}
+	byID := make(map[string]*types.Issue, len(blockerIssues))
+	for _, blocker := range blockerIssues {
+		byID[blocker.ID] = blocker
+	}
+	for _, id := range ids {
+		if blocker := byID[id]; blocker != nil {
+			chain.Blockers = append(chain.Blockers, &types.Blocker{Issue: blocker, Blocks: blocks[id], Depth: depth[id]})
+		}
+	}
 
-	return results, nil
+	chain.Ready = len(chain.Reasons) == 0 && chain.DeferUntil == nil && len(chain.Blockers) == 0
+	return chain, nil
 }
 
 // GetEpicsEligibleForClosure returns epics whose children are all closed
@@ -760,6 +994,83 @@ func (s *DoltStore) GetStatistics(ctx context.Context) (*types.Statistics, error
 	return stats, nil
 }
 
+// GetCloseReasonCounts returns how many closed issues have each close reason.
+func (s *DoltStore) GetCloseReasonCounts(ctx context.Context) (map[string]int, error) {
+	rows, err := s.queryContext(ctx, `
+		SELECT COALESCE(close_reason, ''), COUNT(*) FROM issues
+		WHERE status = ?
+		GROUP BY close_reason
+	`, types.StatusClosed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count close reasons: %w", err)
+	}
+	defer rows.Close()
+	counts := make(map[string]int)
+	for rows.Next() {
+		var reason string
+		var n int
+		if err := rows.Scan(&reason, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan close reason count: %w", err)
+		}
+		counts[reason] += n
+	}
+	return counts, rows.Err()
+}
+
+// activeAndBlockingIDs returns the IDs of issues that can be blocked (in a
+// blocking or ready-eligible status) and of those that block their
+// dependents (in a status.blocking status).
+func (s *DoltStore) activeAndBlockingIDs(ctx context.Context) (active, blocking map[string]bool, err error) {
+	blockingStatuses, err := s.GetBlockingStatuses(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	readyStatuses, err := s.GetReadyStatuses(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	isBlocking := make(map[string]bool, len(blockingStatuses))
+	for _, status := range blockingStatuses {
+		isBlocking[status] = true
+	}
+	seen := make(map[string]bool)
+	var args []interface{}
+	for _, status := range append(append([]string{}, blockingStatuses...), readyStatuses...) {
+		if !seen[status] {
+			seen[status] = true
+			args = append(args, status)
+		}
+	}
+
+	// nolint:gosec // G201: only placeholders are interpolated
+	rows, err := s.queryContext(ctx, `
+		SELECT id, status FROM issues
+		WHERE status IN (`+placeholders(len(args))+`)
+	`, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	active = make(map[string]bool)
+	blocking = make(map[string]bool)
+	for rows.Next() {
+		var id, status string
+		if err := rows.Scan(&id, &status); err != nil {
+			return nil, nil, err
+		}
+		active[id] = true
+		if isBlocking[status] {
+			blocking[id] = true
+		}
+	}
+	return active, blocking, rows.Err()
+}
+
+// placeholders returns n comma-separated SQL placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
 // computeBlockedIDs returns the set of issue IDs that are blocked by active issues.
 // Uses separate single-table queries with Go-level filtering to avoid Dolt's
 // joinIter panic (slice bounds out of range at join_iters.go:192).
@@ -775,46 +1086,39 @@ func (s *DoltStore) computeBlockedIDs(ctx context.Context) ([]string, error) {
 	}
 	s.cacheMu.Unlock()
 
-	// Step 1: Get all active issue IDs (single-table scan)
-	activeIDs := make(map[string]bool)
-	activeRows, err := s.queryContext(ctx, `
-		SELECT id FROM issues
-		WHERE status IN ('open', 'in_progress', 'blocked', 'deferred', 'hooked')
-	`)
+	// Step 1: Get the active and blocking issue IDs (single-table scan)
+	activeIDs, blockingIDs, err := s.activeAndBlockingIDs(ctx)
 	if err != nil {
 		return nil, err
 	}
-	for activeRows.Next() {
-		var id string
-		if err := activeRows.Scan(&id); err != nil {
-			_ = activeRows.Close() // Best effort cleanup on error path
-			return nil, err
-		}
-		activeIDs[id] = true
-	}
-	_ = activeRows.Close() // Redundant close for safety (rows already iterated)
-	if err := activeRows.Err(); err != nil {
-		return nil, err
-	}
 
 	// Step 2: Get all blocking dependencies (single-table scan)
 	depRows, err := s.queryContext(ctx, `
-		SELECT issue_id, depends_on_id FROM dependencies
+		SELECT issue_id, depends_on_id, metadata FROM dependencies
 		WHERE type = 'blocks'
 	`)
 	if err != nil {
 		return nil, err
 	}
 
-	// Step 3: Filter in Go — both sides must be active
+	// Step 3: Filter in Go — the issue must be active and the blocker in a
+	// blocking status. Dependencies with scheduling semantics (relation,
+	// lag) are evaluated separately.
 	blockedSet := make(map[string]bool)
+	var scheduled []scheduledDep
 	for depRows.Next() {
 		var issueID, blockerID string
-		if err := depRows.Scan(&issueID, &blockerID); err != nil {
+		var metadata sql.NullString
+		if err := depRows.Scan(&issueID, &blockerID, &metadata); err != nil {
 			_ = depRows.Close() // Best effort cleanup on error path
 			return nil, err
 		}
-		if activeIDs[issueID] && activeIDs[blockerID] {
+		if !activeIDs[issueID] {
+			continue
+		}
+		if meta := types.ParseScheduleMeta(metadata.String); !meta.IsZero() {
+			scheduled = append(scheduled, scheduledDep{issueID, blockerID, meta})
+		} else if blockingIDs[blockerID] {
 			blockedSet[issueID] = true
 		}
 	}
@@ -822,6 +1126,13 @@ func (s *DoltStore) computeBlockedIDs(ctx context.Context) ([]string, error) {
 	if err := depRows.Err(); err != nil {
 		return nil, err
 	}
+	unsatisfied, err := s.unsatisfiedScheduledDeps(ctx, scheduled, schedule.Start)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range unsatisfied {
+		blockedSet[d.issueID] = true
+	}
 
 	result := make([]string, 0, len(blockedSet))
 	for id := range blockedSet {
@@ -931,7 +1242,9 @@ func (s *DoltStore) GetMoleculeProgress(ctx context.Context, moleculeID string)
 	return stats, nil
 }
 
-// GetNextChildID returns the next available child ID for a parent
+// GetNextChildID returns the next available child ID for a parent.
+// Stores with a town tag allocate from the town's own range (see
+// town_ids.go).
 func (s *DoltStore) GetNextChildID(ctx context.Context, parentID string) (string, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -939,6 +1252,14 @@ func (s *DoltStore) GetNextChildID(ctx context.Context, parentID string) (string
 	}
 	defer tx.Rollback()
 
+	town, err := idTownTx(ctx, tx, s.idTown)
+	if err != nil {
+		return "", err
+	}
+	if town != "" {
+		return nextTownChildIDTx(ctx, tx, parentID, town)
+	}
+
 	// Get or create counter
 	var lastChild int
 	err = tx.QueryRowContext(ctx, "SELECT last_child FROM child_counters WHERE parent_id = ?", parentID).Scan(&lastChild)
@@ -964,3 +1285,55 @@ func (s *DoltStore) GetNextChildID(ctx context.Context, parentID string) (string
 
 	return fmt.Sprintf("%s.%d", parentID, nextChild), nil
 }
+
+// fullTextClause builds the condition for IssueFilter.FullText: every term
+// of query must appear in the title, description, ID, or one of the issue's
+// comments in commentsTable. With blobs, descriptions stored in
+// description_blobs are searched by content.
+func fullTextClause(query, commentsTable string, blobs bool) (string, []any) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		terms = []string{strings.TrimSpace(query)}
+	}
+	clauses := make([]string, 0, len(terms))
+	var args []any
+	for _, term := range terms {
+		pattern := "%" + term + "%"
+		descClause, descArgs := "description LIKE ?", []any{pattern}
+		if blobs {
+			descClause, descArgs = descriptionSearchClause(pattern)
+		}
+		clauses = append(clauses, "(title LIKE ? OR "+descClause+" OR id LIKE ? OR id IN (SELECT issue_id FROM "+commentsTable+" WHERE text LIKE ?))")
+		args = append(args, pattern)
+		args = append(args, descArgs...)
+		args = append(args, pattern, pattern)
+	}
+	return "(" + strings.Join(clauses, " AND ") + ")", args
+}
+
+// searchTerms splits a search query into words, keeping "quoted phrases"
+// together. An unterminated quote runs to the end of the query.
+func searchTerms(query string) []string {
+	var terms []string
+	var term strings.Builder
+	quoted := false
+	flush := func() {
+		if term.Len() > 0 {
+			terms = append(terms, term.String())
+			term.Reset()
+		}
+	}
+	for _, r := range query {
+		switch {
+		case r == '"':
+			flush()
+			quoted = !quoted
+		case unicode.IsSpace(r) && !quoted:
+			flush()
+		default:
+			term.WriteRune(r)
+		}
+	}
+	flush()
+	return terms
+}
@@ -4,6 +4,7 @@ package dolt
 
 import (
 	"fmt"
+	"sort"
 	"testing"
 	"time"
 
@@ -359,6 +360,75 @@ func TestGetReadyWork_TypeFilter(t *testing.T) {
 // GetBlockedIssues tests
 // =============================================================================
 
+func TestGetReadyWork_ConfiguredStatuses(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	if err := store.SetConfig(ctx, "status.custom", "in_review"); err != nil {
+		t.Fatalf("failed to set custom statuses: %v", err)
+	}
+	reviewing := &types.Issue{
+		ID:        "rw-review",
+		Title:     "In Review",
+		Status:    types.Status("in_review"),
+		Priority:  1,
+		IssueType: types.TypeTask,
+	}
+	waiting := &types.Issue{
+		ID:        "rw-waiting",
+		Title:     "Waits on the review",
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeTask,
+	}
+	for _, iss := range []*types.Issue{reviewing, waiting} {
+		if err := store.CreateIssue(ctx, iss, "tester"); err != nil {
+			t.Fatalf("failed to create issue %s: %v", iss.ID, err)
+		}
+	}
+	dep := &types.Dependency{IssueID: waiting.ID, DependsOnID: reviewing.ID, Type: types.DepBlocks}
+	if err := store.AddDependency(ctx, dep, "tester"); err != nil {
+		t.Fatalf("failed to add dependency: %v", err)
+	}
+
+	readyIDs := func() map[string]bool {
+		t.Helper()
+		work, err := store.GetReadyWork(ctx, types.WorkFilter{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids := make(map[string]bool)
+		for _, w := range work {
+			ids[w.ID] = true
+		}
+		return ids
+	}
+
+	// By default a custom status is neither ready nor blocking
+	if ids := readyIDs(); ids[reviewing.ID] || !ids[waiting.ID] {
+		t.Errorf("default statuses: ready = %v, want only %s", ids, waiting.ID)
+	}
+
+	// in_review still blocks its dependents
+	if err := store.SetConfig(ctx, "status.blocking", "open,in_progress,blocked,deferred,hooked,in_review"); err != nil {
+		t.Fatalf("failed to set status.blocking: %v", err)
+	}
+	if ids := readyIDs(); ids[waiting.ID] {
+		t.Error("issue blocked by an in_review issue should not be ready")
+	}
+
+	// in_review issues are ready work themselves
+	if err := store.SetConfig(ctx, "status.ready", "open,in_review"); err != nil {
+		t.Fatalf("failed to set status.ready: %v", err)
+	}
+	if ids := readyIDs(); !ids[reviewing.ID] || ids[waiting.ID] {
+		t.Errorf("configured statuses: ready = %v, want only %s", ids, reviewing.ID)
+	}
+}
+
 func TestGetBlockedIssues_EmptyStore(t *testing.T) {
 	store, cleanup := setupTestStore(t)
 	defer cleanup()
@@ -548,6 +618,76 @@ func TestGetBlockedIssues_MultipleBlockers(t *testing.T) {
 	}
 }
 
+func TestGetBlockingChain(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	later := time.Now().Add(48 * time.Hour)
+	issues := []*types.Issue{
+		{ID: "bc-parent", Title: "Deferred epic", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeEpic, DeferUntil: &later},
+		{ID: "bc-target", Title: "Target", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+		{ID: "bc-direct", Title: "Direct blocker", Status: types.StatusInProgress, Priority: 1, IssueType: types.TypeTask},
+		{ID: "bc-deep", Title: "Blocker's blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{ID: "bc-done", Title: "Closed blocker", Status: types.StatusOpen, Priority: 1, IssueType: types.TypeTask},
+		{ID: "bc-free", Title: "Nothing in the way", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask},
+	}
+	for _, iss := range issues {
+		if err := store.CreateIssue(ctx, iss, "tester"); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+	}
+	for _, dep := range []*types.Dependency{
+		{IssueID: "bc-target", DependsOnID: "bc-parent", Type: types.DepParentChild},
+		{IssueID: "bc-target", DependsOnID: "bc-direct", Type: types.DepBlocks},
+		{IssueID: "bc-target", DependsOnID: "bc-done", Type: types.DepBlocks},
+		{IssueID: "bc-direct", DependsOnID: "bc-deep", Type: types.DepBlocks},
+	} {
+		if err := store.AddDependency(ctx, dep, "tester"); err != nil {
+			t.Fatalf("failed to add dependency: %v", err)
+		}
+	}
+	if err := store.CloseIssue(ctx, "bc-done", "done", "tester", ""); err != nil {
+		t.Fatalf("failed to close issue: %v", err)
+	}
+
+	chain, err := store.GetBlockingChain(ctx, "bc-target")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if chain.Ready {
+		t.Error("blocked, deferred issue should not be ready")
+	}
+	if chain.DeferredBy != "bc-parent" || chain.DeferUntil == nil {
+		t.Errorf("deferral = %v by %q, want the parent's", chain.DeferUntil, chain.DeferredBy)
+	}
+	var got []string
+	for _, b := range chain.Blockers {
+		got = append(got, fmt.Sprintf("%s>%s@%d", b.Issue.ID, b.Blocks, b.Depth))
+	}
+	if want := []string{"bc-direct>bc-target@1", "bc-deep>bc-direct@2"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("blockers = %v, want %v", got, want)
+	}
+
+	chain, err = store.GetBlockingChain(ctx, "bc-free")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !chain.Ready || len(chain.Blockers) != 0 || len(chain.Reasons) != 0 {
+		t.Errorf("unblocked issue: %+v, want ready", chain)
+	}
+
+	chain, err = store.GetBlockingChain(ctx, "bc-done")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if chain.Ready || len(chain.Reasons) != 1 {
+		t.Errorf("closed issue: reasons = %v, want its status", chain.Reasons)
+	}
+}
+
 // =============================================================================
 // SearchIssues tests
 // =============================================================================
@@ -915,6 +1055,83 @@ func TestSearchIssues_IssueTypeFilter(t *testing.T) {
 	}
 }
 
+func TestSearchIssues_FullText(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	crash := &types.Issue{
+		ID:          "si-fts1",
+		Title:       "Login crash",
+		Description: "App exits when the session token expires",
+		Status:      types.StatusOpen,
+		Priority:    2,
+		IssueType:   types.TypeBug,
+	}
+	other := &types.Issue{
+		ID:        "si-fts2",
+		Title:     "Login page copy",
+		Status:    types.StatusOpen,
+		Priority:  2,
+		IssueType: types.TypeTask,
+	}
+	for _, issue := range []*types.Issue{crash, other} {
+		if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+	}
+	if _, err := store.AddIssueComment(ctx, other.ID, "tester", "Reworded after the kerberos migration"); err != nil {
+		t.Fatalf("failed to add comment: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"login", []string{crash.ID, other.ID}},
+		{"login expires", []string{crash.ID}}, // Words may be in different fields
+		{"token session", []string{crash.ID}}, // ...and in any order
+		{`"token session"`, nil},              // Phrases must match as written
+		{`"session token"`, []string{crash.ID}},
+		{"kerberos", []string{other.ID}}, // Comments are searched
+		{"fts2", []string{other.ID}},     // So are IDs
+	}
+	for _, tt := range tests {
+		results, err := store.SearchIssues(ctx, "", types.IssueFilter{FullText: tt.query})
+		if err != nil {
+			t.Fatalf("SearchIssues(%q): %v", tt.query, err)
+		}
+		var got []string
+		for _, issue := range results {
+			got = append(got, issue.ID)
+		}
+		sort.Strings(got)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("FullText %q = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"login crash", []string{"login", "crash"}},
+		{"  login\tcrash  ", []string{"login", "crash"}},
+		{`login "session expired" now`, []string{"login", "session expired", "now"}},
+		{`"unterminated phrase`, []string{"unterminated phrase"}},
+		{`""`, nil},
+	}
+	for _, tt := range tests {
+		if got := searchTerms(tt.query); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("searchTerms(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
 // =============================================================================
 // GetStatistics tests
 // =============================================================================
@@ -0,0 +1,217 @@
+package dolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/beads/internal/debug"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// DefaultQueryCacheSize is the number of distinct queries EnableQueryCache
+// keeps when given a non-positive size.
+const DefaultQueryCacheSize = 64
+
+// queryCacheTTL bounds how long an entry is reused without a write. Some
+// results depend on the clock (deferred and overdue issues), not just data.
+const queryCacheTTL = time.Minute
+
+type queryCacheEntry struct {
+	issues   []*types.Issue
+	loadedAt time.Time
+}
+
+// queryCache holds SearchIssues/GetReadyWork results for long-lived
+// processes that poll the same queries (list --watch, TUIs, API servers).
+//
+// Entries are valid for one data version: the hash of the database working
+// set (DOLT_HASHOF_DB), which changes with every write from any client, and
+// for at most queryCacheTTL. A write through this store also drops all
+// entries immediately.
+type queryCache struct {
+	mu          sync.Mutex
+	maxEntries  int
+	version     string // Data version the entries belong to
+	entries     map[string]queryCacheEntry
+	unsupported bool // Server can't report a data version; cache disabled
+}
+
+func newQueryCache(maxEntries int) *queryCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultQueryCacheSize
+	}
+	return &queryCache{maxEntries: maxEntries, entries: make(map[string]queryCacheEntry)}
+}
+
+// EnableQueryCache turns on in-process caching of SearchIssues and
+// GetReadyWork results, keeping up to maxEntries distinct queries.
+// Each cached call costs one lightweight version query instead of the full
+// search, so it only pays off for callers that repeat the same queries.
+func (s *DoltStore) EnableQueryCache(maxEntries int) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	s.queryCache = newQueryCache(maxEntries)
+}
+
+// invalidateQueryCache drops all cached query results.
+// Called after writes made through this store.
+func (s *DoltStore) invalidateQueryCache() {
+	s.cacheMu.Lock()
+	qc := s.queryCache
+	s.cacheMu.Unlock()
+	if qc == nil {
+		return
+	}
+	qc.mu.Lock()
+	qc.version = ""
+	clear(qc.entries)
+	qc.mu.Unlock()
+}
+
+// cachedQuery returns the cached result for the query identified by keyFn if
+// the data hasn't changed, otherwise runs load and caches its result. Without
+// a cache (or when reads are routed to a replica) it just runs load.
+func (s *DoltStore) cachedQuery(ctx context.Context, keyFn func() (string, bool), load func() ([]*types.Issue, error)) ([]*types.Issue, error) {
+	s.cacheMu.Lock()
+	qc := s.queryCache
+	s.cacheMu.Unlock()
+	if qc == nil || s.reader(ctx) != s.db {
+		return load()
+	}
+	key, ok := keyFn()
+	if !ok {
+		return load()
+	}
+
+	version, ok := s.dataVersion(ctx, qc)
+	if !ok {
+		return load()
+	}
+
+	qc.mu.Lock()
+	if qc.version != version {
+		// Someone wrote since the entries were cached. Derived caches keyed
+		// on store lifetime are stale too.
+		if qc.version != "" {
+			s.invalidateBlockedIDsCache()
+		}
+		qc.version = version
+		clear(qc.entries)
+	} else if cached, hit := qc.entries[key]; hit && time.Since(cached.loadedAt) < queryCacheTTL {
+		qc.mu.Unlock()
+		return copyIssues(cached.issues), nil
+	}
+	qc.mu.Unlock()
+
+	issues, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	qc.mu.Lock()
+	// Only cache if no write slipped in while loading.
+	if qc.version == version {
+		if len(qc.entries) >= qc.maxEntries {
+			for k := range qc.entries {
+				delete(qc.entries, k) // Evict an arbitrary entry
+				break
+			}
+		}
+		qc.entries[key] = queryCacheEntry{issues: copyIssues(issues), loadedAt: time.Now()}
+	}
+	qc.mu.Unlock()
+	return issues, nil
+}
+
+// dataVersion returns the hash of the current working set, or ok=false if
+// the server can't report one.
+func (s *DoltStore) dataVersion(ctx context.Context, qc *queryCache) (string, bool) {
+	qc.mu.Lock()
+	unsupported := qc.unsupported
+	qc.mu.Unlock()
+	if unsupported {
+		return "", false
+	}
+
+	version, err := s.DataVersion(ctx)
+	if err != nil {
+		if ctx.Err() == nil && !isRetryableError(err) {
+			debug.Logf("query cache disabled: cannot read data version: %v\n", err)
+			qc.mu.Lock()
+			qc.unsupported = true
+			qc.mu.Unlock()
+		}
+		return "", false
+	}
+	return version, true
+}
+
+// DataVersion returns the hash of the database working set, which changes
+// with every write from any client. Pollers such as bd ready --watch
+// compare it between polls to tell whether anything changed.
+func (s *DoltStore) DataVersion(ctx context.Context) (string, error) {
+	var version string
+	if err := s.db.QueryRowContext(ctx, "SELECT DOLT_HASHOF_DB()").Scan(&version); err != nil {
+		return "", fmt.Errorf("failed to read data version: %w", err)
+	}
+	return version, nil
+}
+
+// searchCacheKey identifies a SearchIssues call.
+func searchCacheKey(query string, filter types.IssueFilter) (string, bool) {
+	data, err := json.Marshal(struct {
+		Query  string
+		Filter types.IssueFilter
+	}{query, filter})
+	if err != nil {
+		return "", false
+	}
+	return "search:" + string(data), true
+}
+
+// readyCacheKey identifies a GetReadyWork call.
+func readyCacheKey(filter types.WorkFilter) (string, bool) {
+	data, err := json.Marshal(filter)
+	if err != nil {
+		return "", false
+	}
+	return "ready:" + string(data), true
+}
+
+// copyIssues returns a copy of the slice and the issues in it, down to their
+// labels, dependencies, comments and other slices, so callers can sort or
+// modify results without touching cached entries.
+func copyIssues(issues []*types.Issue) []*types.Issue {
+	if issues == nil {
+		return nil
+	}
+	out := make([]*types.Issue, len(issues))
+	for i, issue := range issues {
+		cp := *issue
+		cp.Labels = slices.Clone(issue.Labels)
+		cp.Dependencies = copyPointers(issue.Dependencies)
+		cp.Comments = copyPointers(issue.Comments)
+		cp.ExternalKeys = copyPointers(issue.ExternalKeys)
+		cp.BondedFrom = slices.Clone(issue.BondedFrom)
+		cp.Validations = slices.Clone(issue.Validations)
+		out[i] = &cp
+	}
+	return out
+}
+
+// copyPointers returns a slice of copies of what items point to.
+func copyPointers[T any](items []*T) []*T {
+	if items == nil {
+		return nil
+	}
+	out := make([]*T, len(items))
+	for i, item := range items {
+		cp := *item
+		out[i] = &cp
+	}
+	return out
+}
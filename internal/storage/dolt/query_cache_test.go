@@ -0,0 +1,104 @@
+package dolt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCacheKeysDistinguishQueries(t *testing.T) {
+	open := types.StatusOpen
+	closed := types.StatusClosed
+
+	a, _ := searchCacheKey("auth", types.IssueFilter{Status: &open})
+	b, _ := searchCacheKey("auth", types.IssueFilter{Status: &closed})
+	c, _ := searchCacheKey("login", types.IssueFilter{Status: &open})
+	again, _ := searchCacheKey("auth", types.IssueFilter{Status: &open})
+	if a == b || a == c {
+		t.Errorf("different searches share a key: %q %q %q", a, b, c)
+	}
+	if a != again {
+		t.Errorf("same search produced different keys: %q vs %q", a, again)
+	}
+
+	ready, _ := readyCacheKey(types.WorkFilter{})
+	search, _ := searchCacheKey("", types.IssueFilter{})
+	if ready == search {
+		t.Errorf("ready and search share key %q", ready)
+	}
+}
+
+func TestCopyIssuesIsolatesCache(t *testing.T) {
+	cached := []*types.Issue{{ID: "bd-1", Title: "original"}}
+	out := copyIssues(cached)
+	out[0].Title = "changed"
+	out[0] = &types.Issue{ID: "bd-2"}
+	if cached[0].ID != "bd-1" || cached[0].Title != "original" {
+		t.Errorf("cached issue modified through copy: %+v", cached[0])
+	}
+
+	cached = []*types.Issue{{
+		ID:           "bd-1",
+		Labels:       []string{"ui"},
+		Dependencies: []*types.Dependency{{IssueID: "bd-1", DependsOnID: "bd-2"}},
+		Comments:     []*types.Comment{{Text: "original"}},
+	}}
+	out = copyIssues(cached)
+	out[0].Labels[0] = "changed"
+	out[0].Dependencies[0].DependsOnID = "bd-3"
+	out[0].Comments[0].Text = "changed"
+	if c := cached[0]; c.Labels[0] != "ui" || c.Dependencies[0].DependsOnID != "bd-2" || c.Comments[0].Text != "original" {
+		t.Errorf("cached labels, dependencies or comments modified through copy: %v %+v %+v", c.Labels, c.Dependencies[0], c.Comments[0])
+	}
+	if copyIssues(nil) != nil {
+		t.Error("copyIssues(nil) should be nil")
+	}
+}
+
+func TestCachedQueryWithoutVersionLoads(t *testing.T) {
+	ctx := context.Background()
+	loads := 0
+	load := func() ([]*types.Issue, error) {
+		loads++
+		return []*types.Issue{{ID: "bd-1"}}, nil
+	}
+	key := func() (string, bool) { return "k", true }
+
+	// Disabled cache: every call loads.
+	s := &DoltStore{}
+	for i := 0; i < 2; i++ {
+		if _, err := s.cachedQuery(ctx, key, load); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if loads != 2 {
+		t.Errorf("disabled cache: loads = %d, want 2", loads)
+	}
+
+	// Server without a data version: cache stays out of the way.
+	s.EnableQueryCache(0)
+	s.queryCache.unsupported = true
+	loads = 0
+	for i := 0; i < 2; i++ {
+		if _, err := s.cachedQuery(ctx, key, load); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if loads != 2 {
+		t.Errorf("unsupported version: loads = %d, want 2", loads)
+	}
+}
+
+func TestInvalidateQueryCache(t *testing.T) {
+	s := &DoltStore{}
+	s.invalidateQueryCache() // no cache: no-op
+
+	s.EnableQueryCache(2)
+	s.queryCache.version = "v1"
+	s.queryCache.entries["k"] = queryCacheEntry{issues: []*types.Issue{{ID: "bd-1"}}}
+	s.invalidateQueryCache()
+	if s.queryCache.version != "" || len(s.queryCache.entries) != 0 {
+		t.Errorf("cache not cleared: version=%q entries=%d", s.queryCache.version, len(s.queryCache.entries))
+	}
+}
@@ -0,0 +1,134 @@
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// AddReaction records actor's reaction to an issue. Reacting twice with the
+// same reaction is a no-op; added reports whether a new reaction was stored.
+func (s *DoltStore) AddReaction(ctx context.Context, issueID, actor, reaction string) (added bool, err error) {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return false, err
+	}
+
+	if s.isActiveWisp(ctx, issueID) {
+		return false, fmt.Errorf("cannot react to ephemeral issue %s", issueID)
+	}
+	result, err := s.execContext(ctx, `
+		INSERT IGNORE INTO reactions (issue_id, actor, reaction) VALUES (?, ?, ?)
+	`, issueID, actor, reaction)
+	if err != nil {
+		return false, fmt.Errorf("failed to add reaction: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return false, nil
+	}
+	_, err = s.execContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, new_value)
+		VALUES (?, ?, ?, ?)
+	`, issueID, types.EventReactionAdded, actor, reaction)
+	if err != nil {
+		return true, fmt.Errorf("failed to record reaction event: %w", err)
+	}
+	return true, nil
+}
+
+// RemoveReaction removes actor's reaction from an issue; removed reports
+// whether there was one.
+func (s *DoltStore) RemoveReaction(ctx context.Context, issueID, actor, reaction string) (removed bool, err error) {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return false, err
+	}
+
+	result, err := s.execContext(ctx, `
+		DELETE FROM reactions WHERE issue_id = ? AND actor = ? AND reaction = ?
+	`, issueID, actor, reaction)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove reaction: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return false, nil
+	}
+	_, err = s.execContext(ctx, `
+		INSERT INTO events (issue_id, event_type, actor, old_value)
+		VALUES (?, ?, ?, ?)
+	`, issueID, types.EventReactionRemoved, actor, reaction)
+	if err != nil {
+		return true, fmt.Errorf("failed to record reaction event: %w", err)
+	}
+	return true, nil
+}
+
+// GetReactions returns an issue's reactions, oldest first.
+func (s *DoltStore) GetReactions(ctx context.Context, issueID string) ([]*types.Reaction, error) {
+	rows, err := s.queryContext(ctx, `
+		SELECT issue_id, actor, reaction, created_at FROM reactions
+		WHERE issue_id = ?
+		ORDER BY created_at, actor
+	`, issueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reactions: %w", err)
+	}
+	defer rows.Close()
+
+	var reactions []*types.Reaction
+	for rows.Next() {
+		var r types.Reaction
+		if err := rows.Scan(&r.IssueID, &r.Actor, &r.Reaction, &r.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction: %w", err)
+		}
+		reactions = append(reactions, &r)
+	}
+	return reactions, rows.Err()
+}
+
+// GetVoteCounts returns the number of votes on each of the given issues.
+// Issues without votes are omitted.
+func (s *DoltStore) GetVoteCounts(ctx context.Context, issueIDs []string) (map[string]int, error) {
+	counts := make(map[string]int)
+	if len(issueIDs) == 0 {
+		return counts, nil
+	}
+	placeholders := make([]string, len(issueIDs))
+	args := make([]interface{}, 0, len(issueIDs)+1)
+	args = append(args, types.VoteReaction)
+	for i, id := range issueIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	// nolint:gosec // G201: placeholders contains only ? markers, actual values passed via args
+	query := fmt.Sprintf(`
+		SELECT issue_id, COUNT(*) FROM reactions
+		WHERE reaction = ? AND issue_id IN (%s)
+		GROUP BY issue_id
+	`, strings.Join(placeholders, ","))
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vote counts: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan vote count: %w", err)
+		}
+		counts[id] = n
+	}
+	return counts, rows.Err()
+}
+
+// sortByVotes orders issues by vote count (most first), keeping the existing
+// order (priority, then newest) among issues with equal votes.
+func sortByVotes(issues []*types.Issue, votes map[string]int) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		return votes[issues[i].ID] > votes[issues[j].ID]
+	})
+}
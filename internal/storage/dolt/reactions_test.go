@@ -0,0 +1,83 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSortByVotes(t *testing.T) {
+	issues := []*types.Issue{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+	sortByVotes(issues, map[string]int{"c": 2, "d": 2, "b": 1})
+	var ids []string
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	// Ties keep the incoming (priority) order
+	if got := strings.Join(ids, ","); got != "c,d,b,a" {
+		t.Errorf("order = %s", got)
+	}
+}
+
+func TestReactions(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	var ids []string
+	for _, title := range []string{"Popular", "Quiet"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	popular, quiet := ids[0], ids[1]
+
+	for _, actor := range []string{"alice", "bob", "alice"} {
+		if _, err := store.AddReaction(ctx, popular, actor, types.VoteReaction); err != nil {
+			t.Fatalf("AddReaction: %v", err)
+		}
+	}
+	if added, _ := store.AddReaction(ctx, popular, "alice", types.VoteReaction); added {
+		t.Error("repeat vote from the same actor should be a no-op")
+	}
+	if _, err := store.AddReaction(ctx, popular, "carol", "eyes"); err != nil {
+		t.Fatalf("AddReaction: %v", err)
+	}
+
+	counts, err := store.GetVoteCounts(ctx, ids)
+	if err != nil {
+		t.Fatalf("GetVoteCounts: %v", err)
+	}
+	if counts[popular] != 2 || counts[quiet] != 0 {
+		t.Errorf("counts = %v", counts)
+	}
+
+	reactions, err := store.GetReactions(ctx, popular)
+	if err != nil || len(reactions) != 3 {
+		t.Fatalf("reactions = %v, err = %v", reactions, err)
+	}
+
+	// Votes order the ready queue ahead of equal priorities
+	ready, err := store.GetReadyWork(ctx, types.WorkFilter{SortPolicy: types.SortPolicyVotes, Limit: 1})
+	if err != nil || len(ready) != 1 || ready[0].ID != popular {
+		t.Errorf("ready = %v, err = %v", ready, err)
+	}
+
+	if removed, err := store.RemoveReaction(ctx, popular, "bob", types.VoteReaction); err != nil || !removed {
+		t.Fatalf("RemoveReaction: removed=%v err=%v", removed, err)
+	}
+	if removed, _ := store.RemoveReaction(ctx, popular, "bob", types.VoteReaction); removed {
+		t.Error("removing a missing reaction should report false")
+	}
+	counts, _ = store.GetVoteCounts(ctx, ids)
+	if counts[popular] != 1 {
+		t.Errorf("counts after removal = %v", counts)
+	}
+}
@@ -0,0 +1,100 @@
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// CreateRecurrence saves recurring issue template r, setting its ID.
+func (s *DoltStore) CreateRecurrence(ctx context.Context, r *types.Recurrence) error {
+	// A recurrence creates issues on its own schedule, so it needs the
+	// capability to create them
+	if err := s.authorize(r.CreatedBy, permissions.Create); err != nil {
+		return err
+	}
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = time.Now().UTC()
+	}
+	result, err := s.execContext(ctx, `
+		INSERT INTO recurrences (title, every, description, design, acceptance_criteria, priority,
+			issue_type, assignee, labels, next_at, last_issue_id, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, r.Title, r.Every, r.Description, r.Design, r.AcceptanceCriteria, r.Priority,
+		r.IssueType, r.Assignee, formatJSONStringArray(r.Labels), r.NextAt, r.LastIssueID, r.CreatedBy, r.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create recurrence %q: %w", r.Title, err)
+	}
+	if r.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("failed to get recurrence ID: %w", err)
+	}
+	return nil
+}
+
+// RemoveRecurrence deletes a recurrence, reporting whether it existed.
+// Issues it already created are kept.
+func (s *DoltStore) RemoveRecurrence(ctx context.Context, id int64) (bool, error) {
+	if err := s.authorize("", permissions.Update); err != nil {
+		return false, err
+	}
+	result, err := s.execContext(ctx, "DELETE FROM recurrences WHERE id = ?", id)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove recurrence %d: %w", id, err)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
+
+// GetRecurrences returns every recurrence, soonest due first.
+func (s *DoltStore) GetRecurrences(ctx context.Context) ([]*types.Recurrence, error) {
+	rows, err := s.queryContext(ctx, `
+		SELECT id, title, every, description, design, acceptance_criteria, priority,
+			issue_type, assignee, labels, next_at, last_issue_id, created_by, created_at
+		FROM recurrences ORDER BY next_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurrences: %w", err)
+	}
+	defer rows.Close()
+
+	var recurrences []*types.Recurrence
+	for rows.Next() {
+		var r types.Recurrence
+		var labels string
+		if err := rows.Scan(&r.ID, &r.Title, &r.Every, &r.Description, &r.Design, &r.AcceptanceCriteria, &r.Priority,
+			&r.IssueType, &r.Assignee, &labels, &r.NextAt, &r.LastIssueID, &r.CreatedBy, &r.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan recurrence: %w", err)
+		}
+		r.Labels = parseJSONStringArray(labels)
+		recurrences = append(recurrences, &r)
+	}
+	return recurrences, rows.Err()
+}
+
+// ClaimRecurrence moves a recurrence that came due at due on to next,
+// reporting false if another process already moved it, so each occurrence
+// is materialized once.
+func (s *DoltStore) ClaimRecurrence(ctx context.Context, id int64, due, next time.Time) (bool, error) {
+	if err := s.authorize("", permissions.Create); err != nil {
+		return false, err
+	}
+	result, err := s.execContext(ctx, `UPDATE recurrences SET next_at = ? WHERE id = ? AND next_at = ?`, next, id, due)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim recurrence %d: %w", id, err)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
+
+// SetRecurrenceLastIssue records the issue a recurrence created last.
+func (s *DoltStore) SetRecurrenceLastIssue(ctx context.Context, id int64, issueID string) error {
+	if err := s.authorize("", permissions.Create); err != nil {
+		return err
+	}
+	if _, err := s.execContext(ctx, `UPDATE recurrences SET last_issue_id = ? WHERE id = ?`, issueID, id); err != nil {
+		return fmt.Errorf("failed to update recurrence %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRecurrences(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	due := time.Date(2026, 10, 5, 9, 0, 0, 0, time.UTC)
+	r := &types.Recurrence{
+		Title: "Weekly dependency audit", Every: "7d", Description: "Run govulncheck",
+		Priority: 2, IssueType: types.TypeChore, Labels: []string{"maintenance"},
+		NextAt: due, CreatedBy: "alice",
+	}
+	if err := store.CreateRecurrence(ctx, r); err != nil {
+		t.Fatalf("CreateRecurrence: %v", err)
+	}
+	if r.ID == 0 {
+		t.Fatal("CreateRecurrence did not set the ID")
+	}
+
+	got, err := store.GetRecurrences(ctx)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("GetRecurrences = %v, %v", got, err)
+	}
+	if got[0].Title != r.Title || !got[0].NextAt.Equal(due) || !reflect.DeepEqual(got[0].Labels, []string{"maintenance"}) {
+		t.Errorf("recurrence = %+v", got[0])
+	}
+
+	// Only the first claim of an occurrence wins
+	next := due.AddDate(0, 0, 7)
+	if ok, err := store.ClaimRecurrence(ctx, r.ID, got[0].NextAt, next); err != nil || !ok {
+		t.Fatalf("ClaimRecurrence = %v, %v", ok, err)
+	}
+	if ok, _ := store.ClaimRecurrence(ctx, r.ID, got[0].NextAt, next); ok {
+		t.Error("ClaimRecurrence claimed the same occurrence twice")
+	}
+	if err := store.SetRecurrenceLastIssue(ctx, r.ID, "bd-7"); err != nil {
+		t.Fatalf("SetRecurrenceLastIssue: %v", err)
+	}
+	got, _ = store.GetRecurrences(ctx)
+	if !got[0].NextAt.Equal(next) || got[0].LastIssueID != "bd-7" {
+		t.Errorf("after claim: next %v, last %q", got[0].NextAt, got[0].LastIssueID)
+	}
+
+	if ok, err := store.RemoveRecurrence(ctx, r.ID); err != nil || !ok {
+		t.Fatalf("RemoveRecurrence = %v, %v", ok, err)
+	}
+	if ok, _ := store.RemoveRecurrence(ctx, r.ID); ok {
+		t.Error("RemoveRecurrence removed a missing recurrence")
+	}
+}
@@ -0,0 +1,147 @@
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/query"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// RelabelReport lists what RelabelIssues rewrote.
+type RelabelReport struct {
+	From   string   `json:"from"`
+	To     []string `json:"to"`
+	Issues []string `json:"issues"` // Issues that carried From, wisps included
+	Merged []string `json:"merged"` // Of those, issues that already had a To label
+	Alerts []string `json:"alerts"` // Alerts whose queries were rewritten
+}
+
+// RelabelIssues replaces label from with the labels to on every issue and
+// wisp that carries it, and rewrites label comparisons on from in alert
+// queries (see query.RenameLabel). One label renames; several split. A
+// target label already in use is refused unless merge is set, since the
+// two labels' issues could no longer be told apart. Everything happens in
+// one transaction; with dryRun it is rolled back, so the report shows what
+// would change.
+func (s *DoltStore) RelabelIssues(ctx context.Context, from string, to []string, merge, dryRun bool, actor string) (*RelabelReport, error) {
+	if len(to) == 0 {
+		return nil, fmt.Errorf("%w: no label to relabel %s to", storage.ErrValidation, from)
+	}
+	for _, label := range to {
+		if label == from {
+			return nil, fmt.Errorf("%w: cannot relabel %s to itself", storage.ErrValidation, from)
+		}
+	}
+	if !dryRun {
+		if err := s.authorize(actor, permissions.Update); err != nil {
+			return nil, err
+		}
+	}
+	report := &RelabelReport{From: from, To: to, Issues: []string{}, Merged: []string{}, Alerts: []string{}}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // No-op after successful commit
+
+	inClause, inArgs := doltBuildSQLInClause(to)
+	for _, table := range []string{"labels", "wisp_labels"} {
+		ids, err := queryStrings(ctx, tx, "SELECT issue_id FROM "+table+" WHERE label = ?", from) //nolint:gosec // G202: table is a constant
+		if err != nil {
+			return nil, fmt.Errorf("failed to find %s labels: %w", table, err)
+		}
+		if !merge {
+			inUse, err := queryStrings(ctx, tx, "SELECT label FROM "+table+" WHERE label IN ("+inClause+") LIMIT 1", inArgs...) //nolint:gosec // G202: placeholders only
+			if err != nil {
+				return nil, fmt.Errorf("failed to check %s labels: %w", table, err)
+			}
+			if len(inUse) > 0 {
+				return nil, fmt.Errorf("%w: label %s is already in use (use --merge to combine the labels)", storage.ErrValidation, inUse[0])
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		if !s.lockOverride {
+			for _, id := range ids {
+				if err := checkIssueLock(ctx, tx, id, actor); err != nil {
+					return nil, err
+				}
+			}
+		}
+		merged, err := queryStrings(ctx, tx, "SELECT DISTINCT issue_id FROM "+table+" WHERE label IN ("+inClause+") AND issue_id IN (SELECT issue_id FROM "+table+" WHERE label = ?)", //nolint:gosec // G202: placeholders only
+			append(append([]interface{}{}, inArgs...), from)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find %s labels: %w", table, err)
+		}
+
+		for _, label := range to {
+			if _, err := tx.ExecContext(ctx, "INSERT IGNORE INTO "+table+" (issue_id, label) SELECT issue_id, ? FROM "+table+" WHERE label = ?", label, from); err != nil { //nolint:gosec // G202: table is a constant
+				return nil, fmt.Errorf("failed to add label %s: %w", label, err)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE label = ?", from); err != nil { //nolint:gosec // G202: table is a constant
+			return nil, fmt.Errorf("failed to remove label %s: %w", from, err)
+		}
+		if table == "labels" {
+			for _, id := range ids {
+				if _, err := tx.ExecContext(ctx, `
+					INSERT INTO events (issue_id, event_type, actor, comment) VALUES (?, ?, ?, ?)
+				`, id, types.EventLabelRemoved, actor, "Removed label: "+from); err != nil {
+					return nil, fmt.Errorf("failed to record label event: %w", err)
+				}
+				for _, label := range to {
+					if _, err := tx.ExecContext(ctx, `
+						INSERT INTO events (issue_id, event_type, actor, comment) VALUES (?, ?, ?, ?)
+					`, id, types.EventLabelAdded, actor, "Added label: "+label); err != nil {
+						return nil, fmt.Errorf("failed to record label event: %w", err)
+					}
+				}
+			}
+		}
+		report.Issues = append(report.Issues, ids...)
+		report.Merged = append(report.Merged, merged...)
+	}
+	sort.Strings(report.Issues)
+	sort.Strings(report.Merged)
+
+	rows, err := tx.QueryContext(ctx, "SELECT name, query FROM alerts")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alerts: %w", err)
+	}
+	rewritten := make(map[string]string)
+	for rows.Next() {
+		var name, expr string
+		if err := rows.Scan(&name, &expr); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan alert: %w", err)
+		}
+		if updated, changed := query.RenameLabel(expr, from, to); changed {
+			rewritten[name] = updated
+		}
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read alerts: %w", err)
+	}
+	for name, expr := range rewritten {
+		if _, err := tx.ExecContext(ctx, "UPDATE alerts SET query = ? WHERE name = ?", expr, name); err != nil {
+			return nil, fmt.Errorf("failed to rewrite alert %s: %w", name, err)
+		}
+		report.Alerts = append(report.Alerts, name)
+	}
+	sort.Strings(report.Alerts)
+
+	if dryRun {
+		return report, nil // Deferred rollback discards the changes
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit relabel: %w", err)
+	}
+	return report, nil
+}
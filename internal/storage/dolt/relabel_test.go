@@ -0,0 +1,74 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestRelabelIssues(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	var ids []string
+	for _, title := range []string{"Button", "Menu", "API"} {
+		issue := &types.Issue{Title: title, Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+			t.Fatalf("failed to create issue: %v", err)
+		}
+		ids = append(ids, issue.ID)
+	}
+	for _, l := range []struct{ id, label string }{{ids[0], "ui"}, {ids[1], "ui"}, {ids[1], "web"}, {ids[2], "api"}} {
+		if err := store.AddLabel(ctx, l.id, l.label, "tester"); err != nil {
+			t.Fatalf("AddLabel: %v", err)
+		}
+	}
+	if err := store.SaveAlert(ctx, &types.Alert{Name: "ui-p0", Query: "label=ui AND priority=0", CreatedBy: "tester"}); err != nil {
+		t.Fatalf("SaveAlert: %v", err)
+	}
+
+	// web is in use, so renaming ui to it needs --merge
+	if _, err := store.RelabelIssues(ctx, "ui", []string{"web"}, false, false, "tester"); !errors.Is(err, storage.ErrValidation) {
+		t.Fatalf("relabel onto a used label = %v, want ErrValidation", err)
+	}
+
+	// A dry run reports without changing anything
+	report, err := store.RelabelIssues(ctx, "ui", []string{"web"}, true, true, "tester")
+	if err != nil {
+		t.Fatalf("RelabelIssues (dry run): %v", err)
+	}
+	if len(report.Issues) != 2 || len(report.Merged) != 1 || report.Merged[0] != ids[1] || len(report.Alerts) != 1 {
+		t.Errorf("dry run report = %+v", report)
+	}
+	if labels, _ := store.GetLabels(ctx, ids[0]); len(labels) != 1 || labels[0] != "ui" {
+		t.Fatalf("dry run changed labels: %v", labels)
+	}
+
+	if _, err := store.RelabelIssues(ctx, "ui", []string{"web"}, true, false, "tester"); err != nil {
+		t.Fatalf("RelabelIssues (merge): %v", err)
+	}
+	for _, id := range ids[:2] {
+		if labels, _ := store.GetLabels(ctx, id); len(labels) != 1 || labels[0] != "web" {
+			t.Errorf("labels of %s = %v, want [web]", id, labels)
+		}
+	}
+	alerts, _ := store.GetAlerts(ctx)
+	if len(alerts) != 1 || alerts[0].Query != "label=web AND priority=0" {
+		t.Errorf("alert query = %+v", alerts)
+	}
+
+	// Splitting gives each issue every new label
+	if _, err := store.RelabelIssues(ctx, "api", []string{"backend", "http"}, false, false, "tester"); err != nil {
+		t.Fatalf("RelabelIssues (split): %v", err)
+	}
+	if labels, _ := store.GetLabels(ctx, ids[2]); len(labels) != 2 || labels[0] != "backend" || labels[1] != "http" {
+		t.Errorf("labels after split = %v", labels)
+	}
+}
@@ -0,0 +1,293 @@
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// Hub-and-spoke relay.
+// A hub town forwards changes between spokes that cannot reach each other:
+// it merges every spoke's branch, then pushes the combined result back out.
+// Each relay merge commit carries trailers recording where the change came
+// from and which hubs it passed through, which provides provenance and lets
+// hubs refuse to re-relay changes that already went through them.
+
+// Trailers written on relay merge commits.
+const (
+	relayOriginTrailer = "Relay-Origin"
+	relayPathTrailer   = "Relay-Path"
+)
+
+// RelayProvenance describes where a relayed commit came from.
+type RelayProvenance struct {
+	Origin string   // Spoke the change was merged from
+	Path   []string // Hubs the change passed through, in order
+}
+
+// formatRelayMessage builds a relay merge commit message with provenance trailers.
+func formatRelayMessage(spoke string, path []string) string {
+	return fmt.Sprintf("relay: merge from %s\n\n%s: %s\n%s: %s",
+		spoke, relayOriginTrailer, spoke, relayPathTrailer, strings.Join(path, ","))
+}
+
+// parseRelayProvenance extracts relay trailers from a commit message.
+// Returns nil if the commit was not produced by a relay.
+func parseRelayProvenance(message string) *RelayProvenance {
+	var prov RelayProvenance
+	found := false
+	for _, line := range strings.Split(message, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case relayOriginTrailer:
+			prov.Origin = value
+			found = true
+		case relayPathTrailer:
+			for _, hop := range strings.Split(value, ",") {
+				if hop = strings.TrimSpace(hop); hop != "" {
+					prov.Path = append(prov.Path, hop)
+				}
+			}
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &prov
+}
+
+// relayPathFor computes the relay path for merging commits with the given
+// messages through hubID. It returns an error if the change has already
+// passed through hubID (a relay loop) or would exceed maxHops.
+func relayPathFor(hubID string, maxHops int, messages []string) ([]string, error) {
+	var longest []string
+	for _, msg := range messages {
+		prov := parseRelayProvenance(msg)
+		if prov == nil {
+			continue
+		}
+		for _, hop := range prov.Path {
+			if hop == hubID {
+				return nil, fmt.Errorf("relay loop: changes already relayed by %s", hubID)
+			}
+		}
+		if len(prov.Path) > len(longest) {
+			longest = prov.Path
+		}
+	}
+	if maxHops > 0 && len(longest)+1 > maxHops {
+		return nil, fmt.Errorf("relay hop limit reached (%d hubs: %s)", len(longest), strings.Join(longest, ","))
+	}
+	path := make([]string, 0, len(longest)+1)
+	path = append(path, longest...)
+	return append(path, hubID), nil
+}
+
+// RelayOptions configures a relay run.
+type RelayOptions struct {
+	HubID    string   // Identity of this hub, recorded in provenance trailers
+	Spokes   []string // Spokes to relay between
+	Strategy string   // Conflict strategy: "ours", "theirs", or "" (stop on conflict)
+	MaxHops  int      // Maximum hubs a change may pass through (0 = unlimited)
+}
+
+// RelayResult contains the outcome of a relay run.
+type RelayResult struct {
+	Pulled  []*SyncResult     // Merge results per spoke
+	Skipped map[string]string // Spokes not merged, with the reason (loop, hop limit)
+	Pushed  []string          // Spokes that received the combined changes
+	Failed  map[string]string // Spokes that could not be pushed to, with the error
+}
+
+// Relay merges changes from every spoke and forwards the combined result to
+// all spokes. Merges that would form a relay loop or exceed the hop limit
+// are skipped. Returns an error only if no spoke could be processed at all.
+func (s *DoltStore) Relay(ctx context.Context, opts RelayOptions) (*RelayResult, error) {
+	if opts.HubID == "" {
+		return nil, fmt.Errorf("relay hub ID is required")
+	}
+	if len(opts.Spokes) < 2 {
+		return nil, fmt.Errorf("relay needs at least two spokes, got %d", len(opts.Spokes))
+	}
+
+	result := &RelayResult{
+		Skipped: make(map[string]string),
+		Failed:  make(map[string]string),
+	}
+
+	// Phase 1: collect every spoke's changes into the hub
+	for _, spoke := range opts.Spokes {
+		pulled, skipReason := s.relayPull(ctx, spoke, opts)
+		if skipReason != "" {
+			result.Skipped[spoke] = skipReason
+		}
+		if pulled != nil {
+			result.Pulled = append(result.Pulled, pulled)
+		}
+	}
+
+	// Phase 2: forward the combined state to every spoke
+	for _, spoke := range opts.Spokes {
+		if err := s.PushTo(ctx, spoke); err != nil {
+			result.Failed[spoke] = err.Error()
+			continue
+		}
+		result.Pushed = append(result.Pushed, spoke)
+	}
+
+	if len(result.Pushed) == 0 && len(result.Pulled) == 0 {
+		return result, fmt.Errorf("relay failed for all %d spokes", len(opts.Spokes))
+	}
+	return result, nil
+}
+
+// relayPull fetches a spoke and merges its new commits with a provenance-
+// tagged merge commit. Returns a skip reason when the merge was refused by
+// loop prevention; the SyncResult is nil in that case.
+func (s *DoltStore) relayPull(ctx context.Context, spoke string, opts RelayOptions) (*SyncResult, string) {
+	result := &SyncResult{
+		Peer:      spoke,
+		StartTime: time.Now(),
+	}
+	skipped := false
+	defer func() {
+		if !skipped {
+			_ = s.recordSyncResult(ctx, result) // Best effort: history is for monitoring and must not fail the relay
+		}
+	}()
+
+	if err := s.Fetch(ctx, spoke); err != nil {
+		result.Error = fmt.Errorf("fetch failed: %w", err)
+		return result, ""
+	}
+	result.Fetched = true
+
+	remoteBranch := fmt.Sprintf("%s/%s", spoke, s.branch)
+	messages, err := s.incomingCommitMessages(ctx, remoteBranch)
+	if err != nil {
+		result.Error = err
+		return result, ""
+	}
+	if len(messages) == 0 {
+		// Nothing new from this spoke
+		result.Merged = true
+		result.EndTime = time.Now()
+		return result, ""
+	}
+
+	path, err := relayPathFor(opts.HubID, opts.MaxHops, messages)
+	if err != nil {
+		skipped = true
+		return nil, err.Error()
+	}
+
+	conflicts, err := s.relayMerge(ctx, remoteBranch, formatRelayMessage(spoke, path))
+	if err != nil {
+		result.Error = fmt.Errorf("merge failed: %w", err)
+		return result, ""
+	}
+	if len(conflicts) > 0 {
+		result.Conflicts = conflicts
+		if opts.Strategy == "" {
+			result.Error = fmt.Errorf("merge conflicts require resolution (use --strategy ours|theirs)")
+			return result, ""
+		}
+		for _, c := range conflicts {
+			if err := s.ResolveConflicts(ctx, c.Field, opts.Strategy); err != nil {
+				result.Error = fmt.Errorf("conflict resolution failed for %s: %w", c.Field, err)
+				return result, ""
+			}
+		}
+		result.ConflictsResolved = true
+		if err := s.Commit(ctx, formatRelayMessage(spoke, path)); err != nil {
+			result.Error = fmt.Errorf("failed to commit conflict resolution: %w", err)
+			return result, ""
+		}
+	}
+
+	result.Merged = true
+	result.PulledCommits = len(messages)
+	result.EndTime = time.Now()
+	return result, ""
+}
+
+// incomingCommitMessages returns the messages of commits on branch that are
+// not yet in the local history.
+func (s *DoltStore) incomingCommitMessages(ctx context.Context, branch string) ([]string, error) {
+	rows, err := s.queryContext(ctx, `
+		SELECT message FROM dolt_log AS OF ?
+		WHERE commit_hash NOT IN (SELECT commit_hash FROM dolt_log)
+	`, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incoming commits from %s: %w", branch, err)
+	}
+	defer rows.Close()
+
+	var messages []string
+	for rows.Next() {
+		var msg string
+		if err := rows.Scan(&msg); err != nil {
+			return nil, fmt.Errorf("failed to scan incoming commit: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages, rows.Err()
+}
+
+// relayMerge merges branch with an explicit merge commit (never fast-forward)
+// so that provenance trailers are always recorded.
+func (s *DoltStore) relayMerge(ctx context.Context, branch, message string) ([]storage.Conflict, error) {
+	_, err := s.db.ExecContext(ctx, "CALL DOLT_MERGE('--no-ff', '-m', ?, '--author', ?, ?)",
+		message, s.commitAuthorString(), branch)
+	if err != nil {
+		conflicts, conflictErr := s.GetConflicts(ctx)
+		if conflictErr == nil && len(conflicts) > 0 {
+			return conflicts, nil
+		}
+		return nil, fmt.Errorf("failed to merge branch %s: %w", branch, err)
+	}
+	return nil, nil
+}
+
+// RelayedCommit is a relay merge commit found in the local history.
+type RelayedCommit struct {
+	Hash       string
+	Date       time.Time
+	Provenance RelayProvenance
+}
+
+// RelayLog returns the most recent relay merge commits in the local history.
+func (s *DoltStore) RelayLog(ctx context.Context, limit int) ([]RelayedCommit, error) {
+	rows, err := s.queryContext(ctx, `
+		SELECT commit_hash, date, message FROM dolt_log
+		WHERE message LIKE 'relay: merge from %'
+		ORDER BY date DESC
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query relay log: %w", err)
+	}
+	defer rows.Close()
+
+	var commits []RelayedCommit
+	for rows.Next() {
+		var c RelayedCommit
+		var message string
+		if err := rows.Scan(&c.Hash, &c.Date, &message); err != nil {
+			return nil, fmt.Errorf("failed to scan relay commit: %w", err)
+		}
+		if prov := parseRelayProvenance(message); prov != nil {
+			c.Provenance = *prov
+		}
+		commits = append(commits, c)
+	}
+	return commits, rows.Err()
+}
@@ -0,0 +1,64 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRelayProvenanceRoundTrip(t *testing.T) {
+	msg := formatRelayMessage("spoke-a", []string{"hub-1", "hub-2"})
+	prov := parseRelayProvenance(msg)
+	if prov == nil {
+		t.Fatal("expected provenance, got nil")
+	}
+	if prov.Origin != "spoke-a" {
+		t.Errorf("Origin = %q, want spoke-a", prov.Origin)
+	}
+	if !reflect.DeepEqual(prov.Path, []string{"hub-1", "hub-2"}) {
+		t.Errorf("Path = %v, want [hub-1 hub-2]", prov.Path)
+	}
+
+	if parseRelayProvenance("bd: create issue bd-123") != nil {
+		t.Error("expected nil provenance for ordinary commit")
+	}
+}
+
+func TestRelayPathFor(t *testing.T) {
+	plain := []string{"bd: update bd-1", "bd: create bd-2"}
+	viaOther := append(plain, formatRelayMessage("spoke-x", []string{"hub-2"}))
+	viaSelf := append(plain, formatRelayMessage("spoke-x", []string{"hub-2", "hub-1"}))
+
+	tests := []struct {
+		name     string
+		messages []string
+		maxHops  int
+		want     []string
+		errMatch string
+	}{
+		{"direct spoke changes", plain, 3, []string{"hub-1"}, ""},
+		{"relayed by another hub", viaOther, 3, []string{"hub-2", "hub-1"}, ""},
+		{"loop through self", viaSelf, 3, nil, "relay loop"},
+		{"hop limit", viaOther, 1, nil, "hop limit"},
+		{"unlimited hops", viaOther, 0, []string{"hub-2", "hub-1"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := relayPathFor("hub-1", tt.maxHops, tt.messages)
+			if tt.errMatch != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMatch) {
+					t.Fatalf("error = %v, want match %q", err, tt.errMatch)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("path = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,9 +2,11 @@ package dolt
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
+	"github.com/steveyegge/beads/internal/permissions"
 	"github.com/steveyegge/beads/internal/types"
 )
 
@@ -12,25 +14,42 @@ import (
 // Disables FK checks to allow updating the primary key in issues while
 // child tables (dependencies, etc.) still reference the old ID.
 func (s *DoltStore) UpdateIssueID(ctx context.Context, oldID, newID string, issue *types.Issue, actor string) error {
+	if err := s.authorize(actor, permissions.Admin); err != nil {
+		return err
+	}
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := updateIssueIDTx(ctx, tx, oldID, newID, issue, actor); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// updateIssueIDTx renames an issue and its references within tx.
+func updateIssueIDTx(ctx context.Context, tx *sql.Tx, oldID, newID string, issue *types.Issue, actor string) error {
 	// Disable foreign key checks to allow PK update on issues table
 	// (child tables like dependencies, events, etc. reference issues.id)
-	_, err = tx.ExecContext(ctx, `SET FOREIGN_KEY_CHECKS = 0`)
+	_, err := tx.ExecContext(ctx, `SET FOREIGN_KEY_CHECKS = 0`)
 	if err != nil {
 		return fmt.Errorf("failed to disable foreign key checks: %w", err)
 	}
 
+	description, err := storeDescription(ctx, tx, issue.Description)
+	if err != nil {
+		return err
+	}
+
 	// Update the issue itself
 	result, err := tx.ExecContext(ctx, `
 		UPDATE issues
 		SET id = ?, title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, updated_at = ?
 		WHERE id = ?
-	`, newID, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes, time.Now().UTC(), oldID)
+	`, newID, issue.Title, description, issue.Design, issue.AcceptanceCriteria, issue.Notes, time.Now().UTC(), oldID)
 	if err != nil {
 		return fmt.Errorf("failed to update issue ID: %w", err)
 	}
@@ -72,6 +91,42 @@ func (s *DoltStore) UpdateIssueID(ctx context.Context, oldID, newID string, issu
 		return fmt.Errorf("failed to update comments: %w", err)
 	}
 
+	// Update references in reactions
+	_, err = tx.ExecContext(ctx, `UPDATE reactions SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update reactions: %w", err)
+	}
+
+	// Update references in issue_embeddings
+	_, err = tx.ExecContext(ctx, `UPDATE issue_embeddings SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update issue_embeddings: %w", err)
+	}
+
+	// Update references in issue_locks
+	_, err = tx.ExecContext(ctx, `UPDATE issue_locks SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update issue_locks: %w", err)
+	}
+
+	// Update references in external_keys
+	_, err = tx.ExecContext(ctx, `UPDATE external_keys SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update external_keys: %w", err)
+	}
+
+	// Update references in issue_journal
+	_, err = tx.ExecContext(ctx, `UPDATE issue_journal SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update issue_journal: %w", err)
+	}
+
+	// Update references in milestone_baselines
+	_, err = tx.ExecContext(ctx, `UPDATE milestone_baselines SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
+	if err != nil {
+		return fmt.Errorf("failed to update milestone_baselines: %w", err)
+	}
+
 	// Update references in issue_snapshots
 	_, err = tx.ExecContext(ctx, `UPDATE issue_snapshots SET issue_id = ? WHERE issue_id = ?`, newID, oldID)
 	if err != nil {
@@ -104,8 +159,7 @@ func (s *DoltStore) UpdateIssueID(ctx context.Context, oldID, newID string, issu
 	if err != nil {
 		return fmt.Errorf("failed to re-enable foreign key checks: %w", err)
 	}
-
-	return tx.Commit()
+	return nil
 }
 
 // RenameDependencyPrefix updates the prefix in all dependency records
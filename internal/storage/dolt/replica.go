@@ -0,0 +1,203 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// readReplica is a read-only dolt sql-server that receives the primary's
+// commits through Dolt replication (read_replica_remote / pulls).
+type readReplica struct {
+	addr string
+	db   *sql.DB
+}
+
+// ReadInfo describes which server answered a routed read and how far its data
+// was behind the primary at the time.
+type ReadInfo struct {
+	Source    string        `json:"source"`             // "primary" or the replica address
+	Staleness time.Duration `json:"staleness_ns"`       // Age of the oldest primary commit the replica lacks
+	Fallback  string        `json:"fallback,omitempty"` // Why no replica was used (empty if one was)
+}
+
+// FromReplica reports whether the read was served by a replica.
+func (r *ReadInfo) FromReplica() bool {
+	return r != nil && r.Source != "" && r.Source != "primary"
+}
+
+// readerKey is the context key holding the *sql.DB chosen for routed reads.
+type readerKey struct{}
+
+// openReadReplicas creates connection pools for the configured replicas.
+// Connections are lazy; unreachable replicas are skipped at read time.
+func openReadReplicas(cfg *Config) ([]*readReplica, error) {
+	var replicas []*readReplica
+	for _, addr := range cfg.ReadReplicas {
+		host, portStr, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid read replica address %q: %w", addr, err)
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid read replica port in %q: %w", addr, err)
+		}
+
+		replicaCfg := *cfg
+		replicaCfg.ServerHost = host
+		replicaCfg.ServerPort = port
+		db, err := sql.Open("mysql", buildServerDSN(&replicaCfg, cfg.Database))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open read replica %s: %w", addr, err)
+		}
+		db.SetMaxOpenConns(5)
+		db.SetMaxIdleConns(2)
+		db.SetConnMaxLifetime(5 * time.Minute)
+		replicas = append(replicas, &readReplica{addr: addr, db: db})
+	}
+	return replicas, nil
+}
+
+// WithReadReplica returns a context whose read queries are served by a read
+// replica, if one is configured and its data is within the staleness bound.
+// Otherwise the context is returned unchanged and reads go to the primary.
+//
+// Replicas only receive commits, so nothing is routed while the primary has
+// uncommitted changes. A replica at the primary's HEAD is current; one
+// behind it lags by the age of the oldest primary commit it lacks.
+func (s *DoltStore) WithReadReplica(ctx context.Context) (context.Context, *ReadInfo) {
+	info := &ReadInfo{Source: "primary"}
+	if len(s.replicas) == 0 {
+		return ctx, info
+	}
+	// Replicas follow the default branch; branch-per-polecat stores must
+	// read their own branch from the primary.
+	if s.branch != "main" {
+		info.Fallback = "store is on branch " + s.branch
+		return ctx, info
+	}
+
+	dirty, err := workingSetDirty(ctx, s.db)
+	if err != nil {
+		info.Fallback = "cannot read primary status: " + err.Error()
+		return ctx, info
+	}
+	if dirty {
+		info.Fallback = "primary has uncommitted changes"
+		return ctx, info
+	}
+	primaryHead, err := headCommit(ctx, s.db)
+	if err != nil {
+		info.Fallback = "cannot read primary HEAD: " + err.Error()
+		return ctx, info
+	}
+
+	// Round-robin over replicas, taking the first one within bounds.
+	start := int(s.replicaNext.Add(1))
+	now := time.Now()
+	var reasons []string
+	for i := range s.replicas {
+		r := s.replicas[(start+i)%len(s.replicas)]
+		staleness, err := s.replicaStaleness(ctx, r.db, primaryHead, now)
+		if err != nil {
+			reasons = append(reasons, fmt.Sprintf("%s: %v", r.addr, err))
+			continue
+		}
+		if staleness > s.replicaMaxStaleness {
+			reasons = append(reasons, fmt.Sprintf("%s: %s behind (max %s)", r.addr,
+				staleness.Round(time.Millisecond), s.replicaMaxStaleness))
+			continue
+		}
+		return context.WithValue(ctx, readerKey{}, r.db), &ReadInfo{Source: r.addr, Staleness: staleness}
+	}
+
+	info.Fallback = "no replica within staleness bound"
+	if len(reasons) > 0 {
+		info.Fallback += " (" + strings.Join(reasons, "; ") + ")"
+	}
+	return ctx, info
+}
+
+// reader returns the database for read queries in ctx: the replica chosen
+// by WithReadReplica, or the primary.
+func (s *DoltStore) reader(ctx context.Context) *sql.DB {
+	if db, ok := ctx.Value(readerKey{}).(*sql.DB); ok && db != nil {
+		return db
+	}
+	return s.db
+}
+
+// headCommit returns the hash of the current HEAD commit.
+func headCommit(ctx context.Context, db *sql.DB) (string, error) {
+	var hash string
+	err := db.QueryRowContext(ctx, "SELECT commit_hash FROM dolt_log LIMIT 1").Scan(&hash)
+	return hash, err
+}
+
+// workingSetDirty reports whether the database has uncommitted changes.
+func workingSetDirty(ctx context.Context, db *sql.DB) (bool, error) {
+	var n int
+	err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM dolt_status").Scan(&n)
+	return n > 0, err
+}
+
+// replicaStaleness measures how far a replica trails the primary's HEAD,
+// looking up the commits it lacks on the primary.
+func (s *DoltStore) replicaStaleness(ctx context.Context, replica *sql.DB, primaryHead string, now time.Time) (time.Duration, error) {
+	probeCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	head, err := headCommit(probeCtx, replica)
+	if err != nil {
+		return 0, err
+	}
+	if head == primaryHead {
+		return 0, nil
+	}
+	if err := validateRef(head); err != nil {
+		return 0, fmt.Errorf("replica HEAD: %w", err)
+	}
+	if err := validateRef(primaryHead); err != nil {
+		return 0, fmt.Errorf("primary HEAD: %w", err)
+	}
+	var oldestMissing sql.NullTime
+	// nolint:gosec // G201: hashes validated by validateRef() above
+	query := fmt.Sprintf("SELECT MIN(date) FROM dolt_log('%s..%s')", head, primaryHead)
+	if err := s.db.QueryRowContext(probeCtx, query).Scan(&oldestMissing); err != nil {
+		return 0, fmt.Errorf("replica HEAD %s is not in the primary's history: %w", shortHash(head), err)
+	}
+	return commitLag(head, oldestMissing, now)
+}
+
+// commitLag computes replica staleness from the oldest primary commit the
+// replica lacks: the replica has been missing data since that commit.
+func commitLag(replicaHead string, oldestMissing sql.NullTime, now time.Time) (time.Duration, error) {
+	if !oldestMissing.Valid {
+		// The primary has no commit the replica lacks, yet their HEADs
+		// differ: the replica is ahead, so its data can't be trusted.
+		return 0, fmt.Errorf("replica HEAD %s is ahead of primary", shortHash(replicaHead))
+	}
+	if lag := now.Sub(oldestMissing.Time); lag > 0 {
+		return lag, nil
+	}
+	return 0, nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
+
+// closeReadReplicas closes replica connection pools.
+func (s *DoltStore) closeReadReplicas() {
+	for _, r := range s.replicas {
+		_ = r.db.Close() // Best effort cleanup
+	}
+	s.replicas = nil
+}
@@ -0,0 +1,58 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestCommitLag(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		oldestMissing sql.NullTime
+		want          time.Duration
+		wantErr       bool
+	}{
+		{"missing commit", sql.NullTime{Time: now.Add(-3 * time.Second), Valid: true}, 3 * time.Second, false},
+		{"missing commit dated ahead", sql.NullTime{Time: now.Add(time.Second), Valid: true}, 0, false},
+		{"ahead of primary", sql.NullTime{}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := commitLag("def456", tt.oldestMissing, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("commitLag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("commitLag() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithReadReplicaNoReplicas(t *testing.T) {
+	s := &DoltStore{branch: "main"}
+	ctx := context.Background()
+
+	readCtx, info := s.WithReadReplica(ctx)
+	if readCtx != ctx {
+		t.Error("expected unchanged context without replicas")
+	}
+	if info.FromReplica() || info.Source != "primary" {
+		t.Errorf("expected primary source, got %+v", info)
+	}
+	if s.reader(readCtx) != s.db {
+		t.Error("expected reads to go to the primary")
+	}
+}
+
+func TestOpenReadReplicasInvalidAddress(t *testing.T) {
+	for _, addr := range []string{"replica-without-port", "replica:port"} {
+		if _, err := openReadReplicas(&Config{ReadReplicas: []string{addr}}); err == nil {
+			t.Errorf("openReadReplicas(%q) expected error", addr)
+		}
+	}
+}
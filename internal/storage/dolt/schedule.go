@@ -0,0 +1,134 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/calendar"
+	"github.com/steveyegge/beads/internal/schedule"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// scheduledDep is a blocks dependency with scheduling semantics: a relation
+// other than finish-to-start, or a lag. Plain blocks dependencies are
+// evaluated by status alone, as before.
+type scheduledDep struct {
+	issueID   string
+	blockerID string
+	meta      types.ScheduleMeta
+}
+
+// unsatisfiedScheduledDeps returns the deps that still gate phase now.
+// Uses single-table queries (see computeBlockedIDs).
+func (s *DoltStore) unsatisfiedScheduledDeps(ctx context.Context, deps []scheduledDep, phase schedule.Phase) ([]scheduledDep, error) {
+	if len(deps) == 0 {
+		return nil, nil
+	}
+	seen := make(map[string]bool)
+	var ids []string
+	for _, d := range deps {
+		if !seen[d.blockerID] {
+			seen[d.blockerID] = true
+			ids = append(ids, d.blockerID)
+		}
+	}
+	states, err := s.blockerStates(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	now, cal := time.Now(), calendar.LoadOrDefault()
+	var out []scheduledDep
+	for _, d := range deps {
+		st, ok := states[d.blockerID]
+		if !ok {
+			continue // Blocker missing (e.g. external): nothing to wait for
+		}
+		if ok, _ := schedule.Satisfied(d.meta, st, phase, now, cal); !ok {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+// blockerStates loads the scheduling state of the given issues.
+func (s *DoltStore) blockerStates(ctx context.Context, ids []string) (map[string]schedule.State, error) {
+	inClause, args := doltBuildSQLInClause(ids)
+	//nolint:gosec // G201: inClause contains only ? placeholders
+	rows, err := s.queryContext(ctx, fmt.Sprintf(`
+		SELECT id, status, closed_at, due_at, defer_until FROM issues WHERE id IN (%s)
+	`, inClause), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocker states: %w", err)
+	}
+	var issues []*types.Issue
+	for rows.Next() {
+		var issue types.Issue
+		var closedAt, dueAt, deferUntil sql.NullTime
+		if err := rows.Scan(&issue.ID, &issue.Status, &closedAt, &dueAt, &deferUntil); err != nil {
+			_ = rows.Close() // Best effort cleanup on error path
+			return nil, err
+		}
+		if closedAt.Valid {
+			issue.ClosedAt = &closedAt.Time
+		}
+		if dueAt.Valid {
+			issue.DueAt = &dueAt.Time
+		}
+		if deferUntil.Valid {
+			issue.DeferUntil = &deferUntil.Time
+		}
+		issues = append(issues, &issue)
+	}
+	_ = rows.Close() // Redundant close for safety (rows already iterated)
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	started, err := s.StartTimes(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	states := make(map[string]schedule.State, len(issues))
+	for _, issue := range issues {
+		states[issue.ID] = schedule.StateOf(issue, started[issue.ID])
+	}
+	return states, nil
+}
+
+// StartTimes returns when work began on each of the given issues that has
+// been started: the first claim or change to in_progress in its event
+// history. Issues never started, or started before events were recorded,
+// are absent.
+func (s *DoltStore) StartTimes(ctx context.Context, ids []string) (map[string]time.Time, error) {
+	result := make(map[string]time.Time)
+	if len(ids) == 0 {
+		return result, nil
+	}
+	inClause, args := doltBuildSQLInClause(ids)
+	//nolint:gosec // G201: inClause contains only ? placeholders
+	rows, err := s.queryContext(ctx, fmt.Sprintf(`
+		SELECT issue_id, MIN(created_at) FROM events
+		WHERE issue_id IN (%s)
+		  AND (event_type = 'claimed'
+		       OR (event_type = ? AND new_value LIKE '%%"status":"in_progress"%%'))
+		GROUP BY issue_id
+	`, inClause), append(args, string(types.EventStatusChanged))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get start times: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var at sql.NullTime
+		if err := rows.Scan(&id, &at); err != nil {
+			return nil, err
+		}
+		if at.Valid {
+			result[id] = at.Time
+		}
+	}
+	return result, rows.Err()
+}
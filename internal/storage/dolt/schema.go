@@ -3,7 +3,7 @@ package dolt
 // currentSchemaVersion is bumped whenever the schema or migrations change.
 // initSchemaOnDB checks this against the stored version and skips re-initialization
 // when they match, avoiding ~20 DDL statements per bd invocation.
-const currentSchemaVersion = 4
+const currentSchemaVersion = 21
 
 // schema defines the MySQL-compatible database schema for Dolt.
 const schema = `
@@ -21,6 +21,7 @@ CREATE TABLE IF NOT EXISTS issues (
     issue_type VARCHAR(32) NOT NULL DEFAULT 'task',
     assignee VARCHAR(255),
     estimated_minutes INT,
+    actual_minutes INT,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     created_by VARCHAR(255) DEFAULT '',
     owner VARCHAR(255) DEFAULT '',
@@ -238,6 +239,184 @@ CREATE TABLE IF NOT EXISTS federation_peers (
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     INDEX idx_federation_peers_sovereignty (sovereignty)
 );
+
+-- Federation groups table
+-- Named sets of peers sharing a sync schedule, redaction policy, and sovereignty tier
+CREATE TABLE IF NOT EXISTS federation_groups (
+    name VARCHAR(255) PRIMARY KEY,
+    peers JSON DEFAULT (JSON_ARRAY()),
+    sync_interval_ns BIGINT DEFAULT 0,
+    redact_fields TEXT DEFAULT '',
+    sovereignty VARCHAR(8) DEFAULT '',
+    last_sync DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Description blobs table
+-- Content-addressed storage for large issue descriptions (see blobs.go)
+CREATE TABLE IF NOT EXISTS description_blobs (
+    hash CHAR(64) PRIMARY KEY,
+    content LONGTEXT NOT NULL,
+    size INT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Reactions table
+-- One row per actor and reaction; votes are the '+1' reactions
+CREATE TABLE IF NOT EXISTS reactions (
+    issue_id VARCHAR(255) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    reaction VARCHAR(64) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issue_id, actor, reaction),
+    INDEX idx_reactions_reaction (reaction),
+    CONSTRAINT fk_reactions_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Issue locks table
+-- One row per locked issue; expired rows are ignored and replaced on relock
+CREATE TABLE IF NOT EXISTS issue_locks (
+    issue_id VARCHAR(255) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    locked_at DATETIME NOT NULL,
+    expires_at DATETIME,
+    CONSTRAINT fk_issue_locks_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Availability table
+-- One row per period a person is away; dates are YYYY-MM-DD, inclusive
+CREATE TABLE IF NOT EXISTS availability (
+    person VARCHAR(255) NOT NULL,
+    start_date CHAR(10) NOT NULL,
+    end_date CHAR(10) NOT NULL,
+    note TEXT NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (person, start_date),
+    INDEX idx_availability_end (end_date)
+);
+
+-- Issue evidence table
+-- Verification evidence attached on close; file contents live in description_blobs
+CREATE TABLE IF NOT EXISTS issue_evidence (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    issue_id VARCHAR(255) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    ref TEXT NOT NULL,
+    content_hash CHAR(64),
+    size INT NOT NULL DEFAULT 0,
+    added_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_issue_evidence_issue (issue_id),
+    CONSTRAINT fk_issue_evidence_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Alerts table
+-- Saved queries checked by bd alert check; last_ids holds the matches at the last check
+CREATE TABLE IF NOT EXISTS alerts (
+    name VARCHAR(255) PRIMARY KEY,
+    query TEXT NOT NULL,
+    notify VARCHAR(255) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    last_ids TEXT NOT NULL,
+    last_checked_at DATETIME,
+    last_fired_at DATETIME
+);
+
+-- External keys table
+-- Foreign identifiers (JIRA-123, LIN-789, GH#456) that resolve to an issue
+CREATE TABLE IF NOT EXISTS external_keys (
+    ext_key VARCHAR(255) PRIMARY KEY,
+    issue_id VARCHAR(255) NOT NULL,
+    source VARCHAR(64) NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_external_keys_issue (issue_id),
+    CONSTRAINT fk_external_keys_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Sync conflicts table
+-- Issues changed both locally and in an external tracker, held for bd sync conflicts
+CREATE TABLE IF NOT EXISTS sync_conflicts (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    issue_id VARCHAR(255) NOT NULL,
+    tracker VARCHAR(64) NOT NULL,
+    external_ref TEXT NOT NULL,
+    external_id VARCHAR(255) NOT NULL,
+    local_fields JSON NOT NULL,
+    external_fields JSON NOT NULL,
+    local_updated DATETIME NOT NULL,
+    external_updated DATETIME NOT NULL,
+    detected_at DATETIME NOT NULL,
+    resolution VARCHAR(16) NOT NULL DEFAULT '',
+    resolved_by VARCHAR(255) NOT NULL DEFAULT '',
+    resolved_at DATETIME,
+    pending_push BOOLEAN NOT NULL DEFAULT FALSE,
+    INDEX idx_sync_conflicts_issue (issue_id),
+    CONSTRAINT fk_sync_conflicts_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Issue journal table
+-- Timestamped work-log entries (what was tried, what failed), kept apart from comments
+CREATE TABLE IF NOT EXISTS issue_journal (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    issue_id VARCHAR(255) NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    text TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_issue_journal_issue (issue_id),
+    CONSTRAINT fk_issue_journal_issue FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+-- Milestones table
+-- Sprints and milestones with a due date; issues join one through a milestone:<name> label
+CREATE TABLE IF NOT EXISTS milestones (
+    name VARCHAR(255) PRIMARY KEY,
+    title VARCHAR(500) NOT NULL,
+    description TEXT NOT NULL,
+    due_at DATETIME,
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    created_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    closed_at DATETIME
+);
+
+-- Milestone baselines table
+-- The issues committed to a milestone when its baseline was taken (bd milestone baseline);
+-- no foreign key, so dropped and deleted issues stay in the snapshot
+CREATE TABLE IF NOT EXISTS milestone_baselines (
+    milestone VARCHAR(255) NOT NULL,
+    issue_id VARCHAR(255) NOT NULL,
+    title VARCHAR(500) NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    estimated_minutes INT,
+    taken_by VARCHAR(255) NOT NULL,
+    taken_at DATETIME NOT NULL,
+    PRIMARY KEY (milestone, issue_id),
+    INDEX idx_milestone_baselines_issue (issue_id)
+);
+
+-- Recurrences table
+-- Recurring issue templates that bd recur materializes as fresh open issues on schedule
+CREATE TABLE IF NOT EXISTS recurrences (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    title VARCHAR(500) NOT NULL,
+    every VARCHAR(32) NOT NULL,
+    description TEXT NOT NULL,
+    design TEXT NOT NULL,
+    acceptance_criteria TEXT NOT NULL,
+    priority INT NOT NULL DEFAULT 2,
+    issue_type VARCHAR(32) NOT NULL DEFAULT 'task',
+    assignee VARCHAR(255) NOT NULL DEFAULT '',
+    labels TEXT NOT NULL,
+    next_at DATETIME NOT NULL,
+    last_issue_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL
+);
 `
 
 // defaultConfig contains the default configuration values
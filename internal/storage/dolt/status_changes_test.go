@@ -0,0 +1,37 @@
+package dolt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestStatusChangeOf(t *testing.T) {
+	at := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name  string
+		event types.Event
+		want  types.StatusChange
+		ok    bool
+	}{
+		{"update", types.Event{EventType: types.EventStatusChanged, OldValue: str(`{"status":"open"}`), NewValue: str(`{"status":"deferred"}`)},
+			types.StatusChange{At: at, From: types.StatusOpen, To: types.StatusDeferred}, true},
+		{"claim", types.Event{EventType: "claimed", OldValue: str(`{"status":"open"}`), NewValue: str(`{"assignee":"a","status":"in_progress"}`)},
+			types.StatusChange{At: at, From: types.StatusOpen, To: types.StatusInProgress}, true},
+		{"close", types.Event{EventType: types.EventClosed, NewValue: str("done")},
+			types.StatusChange{At: at, To: types.StatusClosed}, true},
+		{"reopen", types.Event{EventType: types.EventReopened, OldValue: str(`{"status":"closed"}`), NewValue: str(`{"status":"open"}`)},
+			types.StatusChange{At: at, From: types.StatusClosed, To: types.StatusOpen}, true},
+		{"no status", types.Event{EventType: types.EventStatusChanged, NewValue: str(`{"title":"x"}`)},
+			types.StatusChange{}, false},
+	}
+	for _, tt := range tests {
+		tt.event.CreatedAt = at
+		got, ok := statusChangeOf(&tt.event)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("%s: statusChangeOf = %+v, %v; want %+v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
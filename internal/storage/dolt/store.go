@@ -31,6 +31,8 @@ import (
 	// Import MySQL driver for server mode connections
 	_ "github.com/go-sql-driver/mysql"
 
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/resolution"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/storage/doltutil"
 )
@@ -39,11 +41,18 @@ import (
 type DoltStore struct {
 	db       *sql.DB
 	dbPath   string       // Path to Dolt database directory
+	dataDir  string       // Local Dolt data directory (for disk usage reporting; may be empty)
 	closed   atomic.Bool  // Tracks whether Close() has been called
 	connStr  string       // Connection string for reconnection
 	mu       sync.RWMutex // Protects concurrent access
 	readOnly bool         // True if opened in read-only mode
 
+	lockOverride bool                // Ignore other actors' issue locks (see locks.go)
+	idTown       string              // Town tag new IDs are allocated under (see town_ids.go)
+	policy       *permissions.Policy // Actor capabilities checked on writes (see permissions.go)
+	policyActor  string              // Actor for writes that don't name one
+	closeRules   *resolution.Rules   // Close categories and the priorities that need one
+
 	// Watchdog for server mode auto-recovery
 	watchdogCancel context.CancelFunc
 	watchdogDone   chan struct{}
@@ -55,7 +64,8 @@ type DoltStore struct {
 	customTypeCached   bool     // true once customTypeCache has been populated
 	blockedIDsCache    []string // cached result of computeBlockedIDs
 	blockedIDsCacheMap map[string]bool
-	blockedIDsCached   bool // true once blockedIDsCache has been populated
+	blockedIDsCached   bool        // true once blockedIDsCache has been populated
+	queryCache         *queryCache // Opt-in query result cache (nil = disabled; see query_cache.go)
 	cacheMu            sync.Mutex
 
 	// Version control config
@@ -65,16 +75,40 @@ type DoltStore struct {
 	branch         string // Current branch
 	remoteUser     string // Remote auth user for Hosted Dolt push/pull (optional)
 	remotePassword string // Remote auth password for Hosted Dolt push/pull (optional)
+
+	// Read replicas for routed reads (see replica.go)
+	replicas            []*readReplica
+	replicaNext         atomic.Uint32 // Round-robin cursor
+	replicaMaxStaleness time.Duration
 }
 
 // Config holds Dolt database configuration
 type Config struct {
-	Path           string        // Path to Dolt database directory
-	CommitterName  string        // Git-style committer name
-	CommitterEmail string        // Git-style committer email
-	Remote         string        // Default remote name (e.g., "origin")
-	Database       string        // Database name within Dolt (default: "beads")
-	ReadOnly       bool          // Open in read-only mode (skip schema init)
+	Path           string // Path to Dolt database directory
+	CommitterName  string // Git-style committer name
+	CommitterEmail string // Git-style committer email
+	Remote         string // Default remote name (e.g., "origin")
+	Database       string // Database name within Dolt (default: "beads")
+	ReadOnly       bool   // Open in read-only mode (skip schema init)
+
+	// LockOverride lets this store modify issues other actors have locked
+	// and break their locks (for lock.admins; see locks.go).
+	LockOverride bool
+
+	// IDTown is this town's tag for distributed IDs (see idgen.ValidateTownTag).
+	// When set, new issue and child IDs are allocated in the town's own
+	// namespace, so federated towns never allocate the same ID offline.
+	IDTown string
+
+	// Policy grants actors capabilities, checked on every write (nil allows
+	// everything). PolicyActor is checked for writes whose signature names
+	// no actor, such as DeleteIssue.
+	Policy      *permissions.Policy
+	PolicyActor string
+
+	// CloseRules are the allowed close categories and the priorities that
+	// must close with one (nil requires none).
+	CloseRules *resolution.Rules
 
 	// Server connection options
 	ServerHost     string // Server host (default: 127.0.0.1)
@@ -90,6 +124,18 @@ type Config struct {
 
 	// Watchdog options
 	DisableWatchdog bool // Disable server health monitoring (default: enabled in server mode)
+
+	// AutoStart starts a managed dolt sql-server for Path when no server is
+	// reachable at a loopback ServerHost. Ignored for non-local hosts.
+	AutoStart bool
+
+	// QueryCacheSize enables the in-process query result cache with this
+	// many entries (0 = disabled). See EnableQueryCache.
+	QueryCacheSize int
+
+	// Read replica options (see replica.go)
+	ReadReplicas        []string      // host:port of read-only replica servers
+	ReplicaMaxStaleness time.Duration // Max replica lag for routed reads (0: replica HEAD must match)
 }
 
 // Retry configuration for transient connection errors (stale pool connections,
@@ -224,6 +270,7 @@ func (s *DoltStore) execContext(ctx context.Context, query string, args ...any)
 		}
 		return tx.Commit()
 	})
+	s.invalidateQueryCache()
 	return result, wrapLockError(err)
 }
 
@@ -232,7 +279,7 @@ func (s *DoltStore) queryContext(ctx context.Context, query string, args ...any)
 	var rows *sql.Rows
 	err := s.withRetry(ctx, func() error {
 		var queryErr error
-		rows, queryErr = s.db.QueryContext(ctx, query, args...)
+		rows, queryErr = s.reader(ctx).QueryContext(ctx, query, args...)
 		return queryErr
 	})
 	return rows, wrapLockError(err)
@@ -242,7 +289,7 @@ func (s *DoltStore) queryContext(ctx context.Context, query string, args ...any)
 // The scan function receives the *sql.Row and should call .Scan() on it.
 func (s *DoltStore) queryRowContext(ctx context.Context, scan func(*sql.Row) error, query string, args ...any) error {
 	return wrapLockError(s.withRetry(ctx, func() error {
-		row := s.db.QueryRowContext(ctx, query, args...)
+		row := s.reader(ctx).QueryRowContext(ctx, query, args...)
 		return scan(row)
 	}))
 }
@@ -310,6 +357,14 @@ func applyConfigDefaults(cfg *Config) {
 	}
 }
 
+// ServerAddress returns the host and port cfg connects to once defaults
+// (including the BEADS_DOLT_PORT override) are applied.
+func (cfg *Config) ServerAddress() (string, int) {
+	c := *cfg
+	applyConfigDefaults(&c)
+	return c.ServerHost, c.ServerPort
+}
+
 // New creates a new Dolt storage backend.
 // Connects to a running dolt sql-server via MySQL protocol (pure Go).
 func New(ctx context.Context, cfg *Config) (*DoltStore, error) {
@@ -319,6 +374,17 @@ func New(ctx context.Context, cfg *Config) (*DoltStore, error) {
 
 	applyConfigDefaults(cfg)
 
+	if err := checkServerOwner(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.AutoStart {
+		if err := ensureLocalServer(ctx, cfg); err != nil {
+			// Fall through: newServerMode reports the unreachable server with
+			// remediation hints; the auto-start failure adds the cause.
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
 	return newServerMode(ctx, cfg)
 }
 
@@ -358,6 +424,17 @@ func newServerMode(ctx context.Context, cfg *Config) (*DoltStore, error) {
 		remoteUser:     cfg.RemoteUser,
 		remotePassword: cfg.RemotePassword,
 		readOnly:       cfg.ReadOnly,
+		lockOverride:   cfg.LockOverride,
+		idTown:         cfg.IDTown,
+		policy:         cfg.Policy,
+		policyActor:    cfg.PolicyActor,
+		closeRules:     cfg.CloseRules,
+		dataDir:        cfg.Path,
+
+		replicaMaxStaleness: cfg.ReplicaMaxStaleness,
+	}
+	if cfg.QueryCacheSize > 0 {
+		store.queryCache = newQueryCache(cfg.QueryCacheSize)
 	}
 
 	// Schema initialization for server mode (idempotent).
@@ -416,6 +493,27 @@ func newServerMode(ctx context.Context, cfg *Config) (*DoltStore, error) {
 		}
 	}
 
+	// Crash recovery: resolve operations left half-done by a process that
+	// died mid-way (see intent_log.go). Best effort: never blocks opening.
+	if !cfg.ReadOnly {
+		if recovered, err := store.RecoverIntents(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: intent log recovery failed: %v\n", err)
+		} else if len(recovered) > 0 {
+			fmt.Fprintf(os.Stderr, "Recovered %d interrupted operation(s); run 'bd doctor' for details\n", len(recovered))
+		}
+	}
+
+	// Read replicas only serve default-branch reads, so skip them entirely
+	// for branch-per-polecat stores.
+	if len(cfg.ReadReplicas) > 0 && store.branch == "main" {
+		replicas, err := openReadReplicas(cfg)
+		if err != nil {
+			_ = store.Close()
+			return nil, err
+		}
+		store.replicas = replicas
+	}
+
 	// Start watchdog for server mode auto-recovery
 	store.startWatchdog(cfg)
 
@@ -685,6 +783,7 @@ func (s *DoltStore) Close() error {
 	s.stopWatchdog()
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.closeReadReplicas()
 	var err error
 	if s.db != nil {
 		if cerr := doltutil.CloseWithTimeout("db", s.db.Close); cerr != nil {
@@ -842,6 +941,10 @@ func (s *DoltStore) buildBatchCommitMessage(ctx context.Context, actor string) s
 // When remote credentials are configured (for Hosted Dolt), sets DOLT_REMOTE_PASSWORD
 // env var and passes --user flag to authenticate.
 func (s *DoltStore) Push(ctx context.Context) error {
+	if err := s.authorize("", permissions.Federation); err != nil {
+		return err
+	}
+
 	if s.remoteUser != "" {
 		federationEnvMutex.Lock()
 		cleanup := setFederationCredentials(s.remoteUser, s.remotePassword)
@@ -865,6 +968,10 @@ func (s *DoltStore) Push(ctx context.Context) error {
 // ForcePush force-pushes commits to the remote, overwriting remote changes.
 // Use when the remote has uncommitted changes in its working set.
 func (s *DoltStore) ForcePush(ctx context.Context) error {
+	if err := s.authorize("", permissions.Federation); err != nil {
+		return err
+	}
+
 	if s.remoteUser != "" {
 		federationEnvMutex.Lock()
 		cleanup := setFederationCredentials(s.remoteUser, s.remotePassword)
@@ -890,6 +997,10 @@ func (s *DoltStore) ForcePush(ctx context.Context) error {
 // When remote credentials are configured (for Hosted Dolt), sets DOLT_REMOTE_PASSWORD
 // env var and passes --user flag to authenticate.
 func (s *DoltStore) Pull(ctx context.Context) error {
+	if err := s.authorize("", permissions.Federation); err != nil {
+		return err
+	}
+
 	if s.remoteUser != "" {
 		federationEnvMutex.Lock()
 		cleanup := setFederationCredentials(s.remoteUser, s.remotePassword)
@@ -1019,6 +1130,10 @@ func (s *DoltStore) HasRemote(ctx context.Context, name string) (bool, error) {
 
 // AddRemote adds a Dolt remote
 func (s *DoltStore) AddRemote(ctx context.Context, name, url string) error {
+	if err := s.authorize("", permissions.Federation); err != nil {
+		return err
+	}
+
 	_, err := s.db.ExecContext(ctx, "CALL DOLT_REMOTE('add', ?, ?)", name, url)
 	if err != nil {
 		return fmt.Errorf("failed to add remote %s: %w", name, err)
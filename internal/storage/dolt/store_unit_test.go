@@ -175,3 +175,20 @@ func TestGetAdaptiveIDLength_QueryError(t *testing.T) {
 		t.Errorf("expected fallback length 4, got %d", got)
 	}
 }
+
+func TestIsLoopbackHost(t *testing.T) {
+	tests := map[string]bool{
+		"127.0.0.1":   true,
+		"localhost":   true,
+		"LOCALHOST":   true,
+		"::1":         true,
+		"10.0.0.5":    false,
+		"db.internal": false,
+		"":            false,
+	}
+	for host, want := range tests {
+		if got := isLoopbackHost(host); got != want {
+			t.Errorf("isLoopbackHost(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+const syncConflictColumns = `id, issue_id, tracker, external_ref, external_id, local_fields, external_fields,
+	local_updated, external_updated, detected_at, resolution, resolved_by, resolved_at, pending_push`
+
+// RecordSyncConflict queues c, an issue changed both locally and in c.Tracker.
+// If the issue already has an open conflict with that tracker, its versions
+// and timestamps are refreshed instead, and c takes its ID.
+func (s *DoltStore) RecordSyncConflict(ctx context.Context, c *types.SyncConflict, actor string) error {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+	local, err := json.Marshal(c.Local)
+	if err != nil {
+		return fmt.Errorf("failed to encode local version: %w", err)
+	}
+	external, err := json.Marshal(c.External)
+	if err != nil {
+		return fmt.Errorf("failed to encode external version: %w", err)
+	}
+	if c.DetectedAt.IsZero() {
+		c.DetectedAt = time.Now().UTC()
+	}
+
+	var id int64
+	err = s.db.QueryRowContext(ctx, `
+		SELECT id FROM sync_conflicts WHERE issue_id = ? AND tracker = ? AND resolution = ''
+		ORDER BY id LIMIT 1
+	`, c.IssueID, c.Tracker).Scan(&id)
+	switch {
+	case err == nil:
+		if _, err := s.execContext(ctx, `
+			UPDATE sync_conflicts SET external_ref = ?, external_id = ?, local_fields = ?, external_fields = ?,
+				local_updated = ?, external_updated = ?
+			WHERE id = ?
+		`, c.ExternalRef, c.ExternalID, string(local), string(external), c.LocalUpdated.UTC(), c.ExternalUpdated.UTC(), id); err != nil {
+			return fmt.Errorf("failed to update sync conflict %d: %w", id, err)
+		}
+		c.ID = id
+		return nil
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("failed to check sync conflicts for %s: %w", c.IssueID, err)
+	}
+
+	result, err := s.execContext(ctx, `
+		INSERT INTO sync_conflicts (issue_id, tracker, external_ref, external_id, local_fields, external_fields,
+			local_updated, external_updated, detected_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, c.IssueID, c.Tracker, c.ExternalRef, c.ExternalID, string(local), string(external),
+		c.LocalUpdated.UTC(), c.ExternalUpdated.UTC(), c.DetectedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record sync conflict for %s: %w", c.IssueID, err)
+	}
+	c.ID, _ = result.LastInsertId()
+	return nil
+}
+
+// GetSyncConflict returns a queued conflict by ID, or an error matching
+// storage.ErrNotFound.
+func (s *DoltStore) GetSyncConflict(ctx context.Context, id int64) (*types.SyncConflict, error) {
+	conflicts, err := s.querySyncConflicts(ctx, `SELECT `+syncConflictColumns+` FROM sync_conflicts WHERE id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) == 0 {
+		return nil, fmt.Errorf("%w: sync conflict %d", storage.ErrNotFound, id)
+	}
+	return conflicts[0], nil
+}
+
+// ListSyncConflicts returns queued conflicts, oldest first: those still open
+// or waiting to be pushed, or with all, resolved ones too. A non-empty
+// tracker limits them to that tracker's.
+func (s *DoltStore) ListSyncConflicts(ctx context.Context, tracker string, all bool) ([]*types.SyncConflict, error) {
+	query := `SELECT ` + syncConflictColumns + ` FROM sync_conflicts WHERE 1 = 1`
+	var args []interface{}
+	if tracker != "" {
+		query += ` AND tracker = ?`
+		args = append(args, tracker)
+	}
+	if !all {
+		query += ` AND (resolution = '' OR pending_push)`
+	}
+	return s.querySyncConflicts(ctx, query+` ORDER BY id`, args...)
+}
+
+// ResolveSyncConflict settles an open conflict. Keeping the external
+// version copies it into the issue now; keeping the local one leaves the
+// conflict pending until the next sync pushes the issue (see
+// MarkSyncConflictPushed).
+func (s *DoltStore) ResolveSyncConflict(ctx context.Context, id int64, resolution, actor string) error {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+	if resolution != types.SyncKeepLocal && resolution != types.SyncKeepExternal {
+		return fmt.Errorf("%w: resolution must be %s or %s, not %q",
+			storage.ErrValidation, types.SyncKeepLocal, types.SyncKeepExternal, resolution)
+	}
+	c, err := s.GetSyncConflict(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.Resolution != "" {
+		return fmt.Errorf("%w: sync conflict %d was already resolved (%s)", storage.ErrValidation, id, c.Resolution)
+	}
+
+	if resolution == types.SyncKeepExternal {
+		updates := map[string]interface{}{
+			"title":       c.External.Title,
+			"description": c.External.Description,
+			"priority":    c.External.Priority,
+			"status":      string(c.External.Status),
+		}
+		if err := s.UpdateIssue(ctx, c.IssueID, updates, actor); err != nil {
+			return fmt.Errorf("failed to apply %s version of %s: %w", c.Tracker, c.IssueID, err)
+		}
+	}
+
+	if _, err := s.execContext(ctx, `
+		UPDATE sync_conflicts SET resolution = ?, resolved_by = ?, resolved_at = ?, pending_push = ?
+		WHERE id = ?
+	`, resolution, actor, time.Now().UTC(), resolution == types.SyncKeepLocal, id); err != nil {
+		return fmt.Errorf("failed to resolve sync conflict %d: %w", id, err)
+	}
+	return nil
+}
+
+// MarkSyncConflictPushed records that a sync pushed the local version of a
+// conflict resolved in its favor.
+func (s *DoltStore) MarkSyncConflictPushed(ctx context.Context, id int64, actor string) error {
+	if err := s.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+	if _, err := s.execContext(ctx, `UPDATE sync_conflicts SET pending_push = FALSE WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to update sync conflict %d: %w", id, err)
+	}
+	return nil
+}
+
+func (s *DoltStore) querySyncConflicts(ctx context.Context, query string, args ...interface{}) ([]*types.SyncConflict, error) {
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	var conflicts []*types.SyncConflict
+	for rows.Next() {
+		c := &types.SyncConflict{}
+		var local, external string
+		var resolvedAt sql.NullTime
+		if err := rows.Scan(&c.ID, &c.IssueID, &c.Tracker, &c.ExternalRef, &c.ExternalID, &local, &external,
+			&c.LocalUpdated, &c.ExternalUpdated, &c.DetectedAt, &c.Resolution, &c.ResolvedBy, &resolvedAt,
+			&c.PendingPush); err != nil {
+			return nil, fmt.Errorf("failed to scan sync conflict: %w", err)
+		}
+		if err := json.Unmarshal([]byte(local), &c.Local); err != nil {
+			return nil, fmt.Errorf("sync conflict %d: invalid local version: %w", c.ID, err)
+		}
+		if err := json.Unmarshal([]byte(external), &c.External); err != nil {
+			return nil, fmt.Errorf("sync conflict %d: invalid external version: %w", c.ID, err)
+		}
+		if resolvedAt.Valid {
+			c.ResolvedAt = &resolvedAt.Time
+		}
+		conflicts = append(conflicts, c)
+	}
+	return conflicts, rows.Err()
+}
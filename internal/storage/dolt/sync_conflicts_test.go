@@ -0,0 +1,94 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestSyncConflicts(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	issue := &types.Issue{Title: "Local title", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+		t.Fatalf("failed to create issue: %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	c := &types.SyncConflict{
+		IssueID:         issue.ID,
+		Tracker:         "jira",
+		ExternalRef:     "https://example.atlassian.net/browse/PROJ-1",
+		ExternalID:      "PROJ-1",
+		Local:           types.SyncedFields{Title: "Local title", Priority: 2, Status: types.StatusOpen},
+		External:        types.SyncedFields{Title: "Jira title", Description: "From Jira", Priority: 1, Status: types.StatusInProgress},
+		LocalUpdated:    now.Add(-time.Minute),
+		ExternalUpdated: now,
+	}
+	if err := store.RecordSyncConflict(ctx, c, "tester"); err != nil {
+		t.Fatalf("RecordSyncConflict: %v", err)
+	}
+
+	// Recording it again refreshes the open conflict rather than adding one.
+	again := *c
+	again.ID = 0
+	again.External.Title = "Newer Jira title"
+	if err := store.RecordSyncConflict(ctx, &again, "tester"); err != nil {
+		t.Fatalf("RecordSyncConflict: %v", err)
+	}
+	if again.ID != c.ID {
+		t.Errorf("re-recorded conflict got ID %d, want %d", again.ID, c.ID)
+	}
+
+	open, err := store.ListSyncConflicts(ctx, "jira", false)
+	if err != nil {
+		t.Fatalf("ListSyncConflicts: %v", err)
+	}
+	if len(open) != 1 || open[0].External.Title != "Newer Jira title" || open[0].ExternalID != "PROJ-1" {
+		t.Fatalf("open conflicts = %+v", open)
+	}
+	if others, _ := store.ListSyncConflicts(ctx, "linear", false); len(others) != 0 {
+		t.Errorf("linear conflicts = %+v, want none", others)
+	}
+
+	if err := store.ResolveSyncConflict(ctx, c.ID, "mine", "tester"); !errors.Is(err, storage.ErrValidation) {
+		t.Errorf("resolving as mine = %v, want a validation error", err)
+	}
+	if err := store.ResolveSyncConflict(ctx, 9999, types.SyncKeepExternal, "tester"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("resolving a missing conflict = %v, want not found", err)
+	}
+
+	if err := store.ResolveSyncConflict(ctx, c.ID, types.SyncKeepExternal, "tester"); err != nil {
+		t.Fatalf("ResolveSyncConflict: %v", err)
+	}
+	got, err := store.GetIssue(ctx, issue.ID)
+	if err != nil {
+		t.Fatalf("GetIssue: %v", err)
+	}
+	if got.Title != "Newer Jira title" || got.Description != "From Jira" || got.Priority != 1 || got.Status != types.StatusInProgress {
+		t.Errorf("issue after keeping external = %+v", got)
+	}
+	if err := store.ResolveSyncConflict(ctx, c.ID, types.SyncKeepLocal, "tester"); !errors.Is(err, storage.ErrValidation) {
+		t.Errorf("resolving twice = %v, want a validation error", err)
+	}
+
+	if open, _ := store.ListSyncConflicts(ctx, "", false); len(open) != 0 {
+		t.Errorf("open conflicts after resolving = %+v", open)
+	}
+	all, err := store.ListSyncConflicts(ctx, "", true)
+	if err != nil || len(all) != 1 {
+		t.Fatalf("ListSyncConflicts(all) = %+v, %v", all, err)
+	}
+	if all[0].Resolution != types.SyncKeepExternal || all[0].ResolvedBy != "tester" || all[0].ResolvedAt == nil || all[0].PendingPush {
+		t.Errorf("resolved conflict = %+v", all[0])
+	}
+}
@@ -0,0 +1,81 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// recordSyncResult persists a sync outcome to the sync_history table.
+// Called for both successful and failed syncs so that failure rates can be
+// reported per peer.
+func (s *DoltStore) recordSyncResult(ctx context.Context, result *SyncResult) error {
+	end := result.EndTime
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	var errMsg, pushErrMsg sql.NullString
+	if result.Error != nil {
+		errMsg = sql.NullString{String: result.Error.Error(), Valid: true}
+	}
+	if result.PushError != nil {
+		pushErrMsg = sql.NullString{String: result.PushError.Error(), Valid: true}
+	}
+
+	_, err := s.execContext(ctx, `
+		INSERT INTO sync_history (peer, started_at, duration_ms, fetched, merged, pushed,
+			pulled_commits, pushed_commits, conflicts, error, push_error)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, result.Peer, result.StartTime.UTC(), end.Sub(result.StartTime).Milliseconds(),
+		result.Fetched, result.Merged, result.Pushed,
+		result.PulledCommits, result.PushedCommits, len(result.Conflicts), errMsg, pushErrMsg)
+	if err != nil {
+		return fmt.Errorf("failed to record sync history: %w", err)
+	}
+	return nil
+}
+
+// GetSyncHistory returns recorded federation syncs, newest first.
+// If peer is empty, history for all peers is returned.
+// If limit is 0, all matching entries are returned.
+func (s *DoltStore) GetSyncHistory(ctx context.Context, peer string, limit int) ([]*storage.SyncHistoryEntry, error) {
+	query := `
+		SELECT id, peer, started_at, duration_ms, fetched, merged, pushed,
+			pulled_commits, pushed_commits, conflicts, error, push_error
+		FROM sync_history`
+	var args []any
+	if peer != "" {
+		query += " WHERE peer = ?"
+		args = append(args, peer)
+	}
+	query += " ORDER BY started_at DESC, id DESC"
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sync history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*storage.SyncHistoryEntry
+	for rows.Next() {
+		var e storage.SyncHistoryEntry
+		var durationMs int64
+		var errMsg, pushErrMsg sql.NullString
+		if err := rows.Scan(&e.ID, &e.Peer, &e.StartedAt, &durationMs, &e.Fetched, &e.Merged, &e.Pushed,
+			&e.PulledCommits, &e.PushedCommits, &e.Conflicts, &errMsg, &pushErrMsg); err != nil {
+			return nil, fmt.Errorf("failed to scan sync history: %w", err)
+		}
+		e.Duration = time.Duration(durationMs) * time.Millisecond
+		e.Error = errMsg.String
+		e.PushError = pushErrMsg.String
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
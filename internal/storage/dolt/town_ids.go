@@ -0,0 +1,121 @@
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/beads/internal/idgen"
+	"github.com/steveyegge/beads/internal/permissions"
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// idTownTx returns the town tag to allocate new IDs under: the store's
+// town, if set. Databases migrated to distributed IDs (id_scheme config)
+// require one, so that no town allocates outside its own namespace.
+func idTownTx(ctx context.Context, tx *sql.Tx, town string) (string, error) {
+	if town != "" {
+		return town, nil
+	}
+	var scheme string
+	err := tx.QueryRowContext(ctx, "SELECT value FROM config WHERE `key` = ?", "id_scheme").Scan(&scheme)
+	if err != nil && err != sql.ErrNoRows {
+		return "", fmt.Errorf("failed to read id_scheme config: %w", err)
+	}
+	if scheme == idgen.SchemeDistributed {
+		return "", fmt.Errorf("this database uses distributed IDs: set id.town in config.yaml to this town's tag (e.g. id.town: k3) before creating issues")
+	}
+	return "", nil
+}
+
+// nextTownChildIDTx returns the next child ID town allocates under
+// parentID. Only this town allocates in its range of child IDs, so the
+// highest one in the local database is the last one allocated. Child
+// counters aren't used: they are shared rows that federated towns would
+// update concurrently.
+func nextTownChildIDTx(ctx context.Context, tx *sql.Tx, parentID, town string) (string, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM issues WHERE id LIKE ?`, parentID+"."+town+"%")
+	if err != nil {
+		return "", fmt.Errorf("failed to list child IDs: %w", err)
+	}
+	defer rows.Close()
+
+	last := 0
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return "", fmt.Errorf("failed to scan child ID: %w", err)
+		}
+		if n, ok := idgen.TownChildNumber(id, parentID, town); ok && n > last {
+			last = n
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("failed to list child IDs: %w", err)
+	}
+	return idgen.TownChildID(parentID, town, last+1), nil
+}
+
+// IssueRewrite is one issue's change in MigrateIssueIDs: Issue holds its
+// new ID (OldID if it keeps its ID) and its rewritten text fields.
+type IssueRewrite struct {
+	OldID string
+	Issue *types.Issue
+}
+
+// MigrateIssueIDs switches the database to distributed IDs in one
+// transaction: it renames issues and rewrites the ID references in their
+// text fields as given, then sets the id_scheme config.
+func (s *DoltStore) MigrateIssueIDs(ctx context.Context, rewrites []IssueRewrite, actor string) error {
+	if err := s.authorize(actor, permissions.Admin); err != nil {
+		return err
+	}
+
+	defer s.invalidateQueryCache()
+
+	// Rename longer IDs first: a legacy ID's new ID is two characters
+	// longer, so one that still exists is renamed out of the way first.
+	sorted := append([]IssueRewrite(nil), rewrites...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if len(sorted[i].OldID) != len(sorted[j].OldID) {
+			return len(sorted[i].OldID) > len(sorted[j].OldID)
+		}
+		return sorted[i].OldID < sorted[j].OldID
+	})
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, r := range sorted {
+		if r.Issue.ID != r.OldID {
+			if err := updateIssueIDTx(ctx, tx, r.OldID, r.Issue.ID, r.Issue, actor); err != nil {
+				return fmt.Errorf("renaming %s: %w", r.OldID, err)
+			}
+			continue
+		}
+		description, err := storeDescription(ctx, tx, r.Issue.Description)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE issues
+			SET title = ?, description = ?, design = ?, acceptance_criteria = ?, notes = ?, updated_at = ?
+			WHERE id = ?
+		`, r.Issue.Title, description, r.Issue.Design, r.Issue.AcceptanceCriteria, r.Issue.Notes, time.Now().UTC(), r.OldID); err != nil {
+			return fmt.Errorf("rewriting references in %s: %w", r.OldID, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO config (`+"`key`"+`, value) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE value = VALUES(value)
+	`, "id_scheme", idgen.SchemeDistributed); err != nil {
+		return fmt.Errorf("failed to set id_scheme: %w", err)
+	}
+	return tx.Commit()
+}
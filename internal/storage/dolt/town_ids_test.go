@@ -0,0 +1,84 @@
+//go:build cgo
+
+package dolt
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestTownIDs(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	store.idTown = "k3"
+	parent := &types.Issue{Title: "Town issue", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeEpic}
+	if err := store.CreateIssue(ctx, parent, "tester"); err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if !strings.HasPrefix(parent.ID, "test-k3") {
+		t.Errorf("ID %s isn't in town k3's namespace", parent.ID)
+	}
+
+	for want := 1; want <= 2; want++ {
+		childID, err := store.GetNextChildID(ctx, parent.ID)
+		if err != nil {
+			t.Fatalf("GetNextChildID: %v", err)
+		}
+		if wantID := fmt.Sprintf("%s.k3%d", parent.ID, want); childID != wantID {
+			t.Fatalf("child ID = %s, want %s", childID, wantID)
+		}
+		child := &types.Issue{ID: childID, Title: "Child", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+		if err := store.CreateIssue(ctx, child, "tester"); err != nil {
+			t.Fatalf("CreateIssue(child): %v", err)
+		}
+	}
+}
+
+func TestMigrateIssueIDs(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	a := &types.Issue{ID: "test-abc", Title: "A", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	b := &types.Issue{ID: "test-00abc", Title: "B", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	for _, issue := range []*types.Issue{a, b} {
+		if err := store.CreateIssue(ctx, issue, "tester"); err != nil {
+			t.Fatalf("CreateIssue: %v", err)
+		}
+	}
+	if err := store.AddDependency(ctx, &types.Dependency{IssueID: b.ID, DependsOnID: a.ID, Type: types.DepBlocks}, "tester"); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+
+	newA, newB := *a, *b
+	newA.ID, newB.ID = "test-00abc", "test-0000abc"
+	newB.Description = "After test-00abc"
+	rewrites := []IssueRewrite{{OldID: a.ID, Issue: &newA}, {OldID: b.ID, Issue: &newB}}
+	if err := store.MigrateIssueIDs(ctx, rewrites, "tester"); err != nil {
+		t.Fatalf("MigrateIssueIDs: %v", err)
+	}
+
+	got, err := store.GetIssue(ctx, "test-0000abc")
+	if err != nil || got.Title != "B" || got.Description != "After test-00abc" {
+		t.Fatalf("migrated B = %+v, %v", got, err)
+	}
+	deps, err := store.GetDependencyRecords(ctx, "test-0000abc")
+	if err != nil || len(deps) != 1 || deps[0].DependsOnID != "test-00abc" {
+		t.Errorf("dependency not rewritten: %+v, %v", deps, err)
+	}
+
+	// New issues need a town once the database uses distributed IDs
+	issue := &types.Issue{Title: "No town", Status: types.StatusOpen, Priority: 2, IssueType: types.TypeTask}
+	if err := store.CreateIssue(ctx, issue, "tester"); err == nil || !strings.Contains(err.Error(), "id.town") {
+		t.Errorf("CreateIssue without a town = %v, want id.town error", err)
+	}
+}
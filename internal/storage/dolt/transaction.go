@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"strings"
 	"time"
 
+	"github.com/steveyegge/beads/internal/permissions"
 	"github.com/steveyegge/beads/internal/storage"
 	"github.com/steveyegge/beads/internal/types"
 )
@@ -50,12 +52,17 @@ func (s *DoltStore) runDoltTransaction(ctx context.Context, fn func(tx storage.T
 		return err
 	}
 
+	defer s.invalidateQueryCache()
 	return sqlTx.Commit()
 }
 
 // CreateIssue creates an issue within the transaction.
 // Routes ephemeral issues to the wisps table.
 func (t *doltTransaction) CreateIssue(ctx context.Context, issue *types.Issue, actor string) error {
+	if err := t.authorize(actor, permissions.Create); err != nil {
+		return err
+	}
+
 	now := time.Now().UTC()
 	if issue.CreatedAt.IsZero() {
 		issue.CreatedAt = now
@@ -94,7 +101,7 @@ func (t *doltTransaction) CreateIssue(ctx context.Context, issue *types.Issue, a
 			}
 		}
 
-		generatedID, err := generateIssueIDInTable(ctx, t.tx, table, prefix, issue, actor)
+		generatedID, err := generateIssueIDInTable(ctx, t.tx, table, prefix, t.store.idTown, issue, actor)
 		if err != nil {
 			return fmt.Errorf("failed to generate issue ID: %w", err)
 		}
@@ -135,9 +142,24 @@ func (t *doltTransaction) SearchIssues(ctx context.Context, query string, filter
 	args := []interface{}{}
 
 	if query != "" {
-		whereClauses = append(whereClauses, "(title LIKE ? OR description LIKE ? OR id LIKE ?)")
 		pattern := "%" + query + "%"
-		args = append(args, pattern, pattern, pattern)
+		descClause, descArgs := "description LIKE ?", []any{pattern}
+		if table == "issues" {
+			descClause, descArgs = descriptionSearchClause(pattern)
+		}
+		whereClauses = append(whereClauses, "(title LIKE ? OR "+descClause+" OR id LIKE ?)")
+		args = append(args, pattern)
+		args = append(args, descArgs...)
+		args = append(args, pattern)
+	}
+	if filter.FullText != "" {
+		commentsTable := "comments"
+		if table == "wisps" {
+			commentsTable = "wisp_comments"
+		}
+		clause, textArgs := fullTextClause(filter.FullText, commentsTable, table == "issues")
+		whereClauses = append(whereClauses, clause)
+		args = append(args, textArgs...)
 	}
 
 	if filter.ParentID != nil {
@@ -207,10 +229,26 @@ func (t *doltTransaction) UpdateIssue(ctx context.Context, id string, updates ma
 	table := "issues"
 	if IsEphemeralID(id) {
 		table = "wisps"
+	} else if err := t.checkIssueLock(ctx, id, actor); err != nil {
+		return err
+	}
+
+	oldIssue, err := t.GetIssue(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get issue for update: %w", err)
+	}
+	if err := t.authorize(actor, updateCapability(oldIssue.Status, updates)); err != nil {
+		return err
+	}
+	if table == "issues" {
+		if err := checkCloseCategoryOnUpdate(t.store.closeRules, oldIssue, updates); err != nil {
+			return err
+		}
 	}
 
 	setClauses := []string{"updated_at = ?"}
 	args := []interface{}{time.Now().UTC()}
+	eventUpdates := updates
 
 	for key, value := range updates {
 		if !isAllowedUpdateField(key) {
@@ -233,23 +271,62 @@ func (t *doltTransaction) UpdateIssue(ctx context.Context, id string, updates ma
 				return fmt.Errorf("invalid metadata: %w", err)
 			}
 			args = append(args, metadataStr)
+		} else if desc, ok := value.(string); ok && key == "description" && table == "issues" {
+			stored, err := storeDescription(ctx, t.tx, desc)
+			if err != nil {
+				return err
+			}
+			if stored != desc {
+				eventUpdates = maps.Clone(updates)
+				eventUpdates["description"] = stored
+			}
+			args = append(args, stored)
 		} else {
 			args = append(args, value)
 		}
 	}
 
+	// Auto-manage closed_at, as the non-transactional UpdateIssue does
+	setClauses, args = manageClosedAt(oldIssue, updates, setClauses, args)
+
 	args = append(args, id)
 	//nolint:gosec // G201: table is hardcoded, setClauses contains only column names
 	querySQL := fmt.Sprintf("UPDATE %s SET %s WHERE id = ?", table, strings.Join(setClauses, ", "))
-	_, err := t.tx.ExecContext(ctx, querySQL, args...)
-	return err
+	if _, err := t.tx.ExecContext(ctx, querySQL, args...); err != nil {
+		return err
+	}
+
+	eventTable := "events"
+	if table == "wisps" {
+		eventTable = "wisp_events"
+	}
+	oldData, _ := json.Marshal(eventSnapshot(oldIssue))
+	newData, _ := json.Marshal(eventUpdates)
+	if err := recordEventInTable(ctx, t.tx, eventTable, id, determineEventType(oldIssue, updates), actor, string(oldData), string(newData)); err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+	return nil
 }
 
 // CloseIssue closes an issue within the transaction
 func (t *doltTransaction) CloseIssue(ctx context.Context, id string, reason string, actor string, session string) error {
+	if err := t.authorize(actor, permissions.Close); err != nil {
+		return err
+	}
+
 	table := "issues"
 	if IsEphemeralID(id) {
 		table = "wisps"
+	} else if err := t.checkIssueLock(ctx, id, actor); err != nil {
+		return err
+	} else if rules := t.store.closeRules; rules != nil && rules.MaxPriority >= 0 {
+		issue, err := t.GetIssue(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkCloseCategory(rules, issue, reason); err != nil {
+			return err
+		}
 	}
 
 	now := time.Now().UTC()
@@ -263,9 +340,15 @@ func (t *doltTransaction) CloseIssue(ctx context.Context, id string, reason stri
 
 // DeleteIssue deletes an issue within the transaction
 func (t *doltTransaction) DeleteIssue(ctx context.Context, id string) error {
+	if err := t.authorize("", permissions.Delete); err != nil {
+		return err
+	}
+
 	table := "issues"
 	if IsEphemeralID(id) {
 		table = "wisps"
+	} else if err := t.checkIssueLock(ctx, id, ""); err != nil {
+		return err
 	}
 
 	//nolint:gosec // G201: table is hardcoded
@@ -275,9 +358,17 @@ func (t *doltTransaction) DeleteIssue(ctx context.Context, id string) error {
 
 // AddDependency adds a dependency within the transaction
 func (t *doltTransaction) AddDependency(ctx context.Context, dep *types.Dependency, actor string) error {
+	if err := t.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
 	table := "dependencies"
 	if IsEphemeralID(dep.IssueID) {
 		table = "wisp_dependencies"
+	} else if dep.Type == types.DepBlocks {
+		if err := checkBlockingCycle(ctx, t.tx, dep.IssueID, dep.DependsOnID); err != nil {
+			return err
+		}
 	}
 
 	//nolint:gosec // G201: table is hardcoded
@@ -327,6 +418,10 @@ func (t *doltTransaction) GetDependencyRecords(ctx context.Context, issueID stri
 
 // RemoveDependency removes a dependency within the transaction
 func (t *doltTransaction) RemoveDependency(ctx context.Context, issueID, dependsOnID string, actor string) error {
+	if err := t.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
 	table := "dependencies"
 	if IsEphemeralID(issueID) {
 		table = "wisp_dependencies"
@@ -341,9 +436,15 @@ func (t *doltTransaction) RemoveDependency(ctx context.Context, issueID, depends
 
 // AddLabel adds a label within the transaction
 func (t *doltTransaction) AddLabel(ctx context.Context, issueID, label, actor string) error {
+	if err := t.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
 	table := "labels"
 	if IsEphemeralID(issueID) {
 		table = "wisp_labels"
+	} else if err := t.checkIssueLock(ctx, issueID, actor); err != nil {
+		return err
 	}
 
 	//nolint:gosec // G201: table is hardcoded
@@ -378,9 +479,15 @@ func (t *doltTransaction) GetLabels(ctx context.Context, issueID string) ([]stri
 
 // RemoveLabel removes a label within the transaction
 func (t *doltTransaction) RemoveLabel(ctx context.Context, issueID, label, actor string) error {
+	if err := t.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
 	table := "labels"
 	if IsEphemeralID(issueID) {
 		table = "wisp_labels"
+	} else if err := t.checkIssueLock(ctx, issueID, actor); err != nil {
+		return err
 	}
 
 	//nolint:gosec // G201: table is hardcoded
@@ -392,6 +499,9 @@ func (t *doltTransaction) RemoveLabel(ctx context.Context, issueID, label, actor
 
 // SetConfig sets a config value within the transaction
 func (t *doltTransaction) SetConfig(ctx context.Context, key, value string) error {
+	if err := t.authorize("", configCapability(key)); err != nil {
+		return err
+	}
 	_, err := t.tx.ExecContext(ctx, `
 		INSERT INTO config (`+"`key`"+`, value) VALUES (?, ?)
 		ON DUPLICATE KEY UPDATE value = VALUES(value)
@@ -411,6 +521,9 @@ func (t *doltTransaction) GetConfig(ctx context.Context, key string) (string, er
 
 // SetMetadata sets a metadata value within the transaction
 func (t *doltTransaction) SetMetadata(ctx context.Context, key, value string) error {
+	if err := t.authorize("", permissions.Admin); err != nil {
+		return err
+	}
 	_, err := t.tx.ExecContext(ctx, `
 		INSERT INTO metadata (`+"`key`"+`, value) VALUES (?, ?)
 		ON DUPLICATE KEY UPDATE value = VALUES(value)
@@ -429,6 +542,10 @@ func (t *doltTransaction) GetMetadata(ctx context.Context, key string) (string,
 }
 
 func (t *doltTransaction) ImportIssueComment(ctx context.Context, issueID, author, text string, createdAt time.Time) (*types.Comment, error) {
+	if err := t.authorize("", permissions.Update); err != nil {
+		return nil, err
+	}
+
 	_, err := t.GetIssue(ctx, issueID)
 	if err != nil {
 		return nil, err
@@ -486,6 +603,10 @@ func (t *doltTransaction) GetIssueComments(ctx context.Context, issueID string)
 
 // AddComment adds a comment within the transaction
 func (t *doltTransaction) AddComment(ctx context.Context, issueID, actor, comment string) error {
+	if err := t.authorize(actor, permissions.Update); err != nil {
+		return err
+	}
+
 	table := "events"
 	if IsEphemeralID(issueID) {
 		table = "wisp_events"
@@ -2,7 +2,10 @@ package dolt
 
 import (
 	"database/sql"
+	"errors"
 	"time"
+
+	"github.com/go-sql-driver/mysql"
 )
 
 // parseTimeString parses a time string from database TEXT columns (non-nullable).
@@ -36,3 +39,10 @@ func parseNullableTimeString(ns sql.NullString) *time.Time {
 	}
 	return nil // Unparseable - shouldn't happen with valid data
 }
+
+// isDuplicateKey reports whether err is the server refusing a row whose
+// primary or unique key is already taken.
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 // ER_DUP_ENTRY
+}
@@ -46,10 +46,19 @@ func wispCommentTable(issueID string) string {
 //
 //nolint:gosec // G201: table is a hardcoded constant from wispIssueTable
 func insertIssueIntoTable(ctx context.Context, tx *sql.Tx, table string, issue *types.Issue) error {
+	// Wisps are short-lived and dolt-ignored; only versioned issues move
+	// large descriptions into blobs.
+	description := issue.Description
+	if table == "issues" {
+		var err error
+		if description, err = storeDescription(ctx, tx, description); err != nil {
+			return err
+		}
+	}
 	_, err := tx.ExecContext(ctx, fmt.Sprintf(`
 		INSERT INTO %s (
 			id, content_hash, title, description, design, acceptance_criteria, notes,
-			status, priority, issue_type, assignee, estimated_minutes,
+			status, priority, issue_type, assignee, estimated_minutes, actual_minutes,
 			created_at, created_by, owner, updated_at, closed_at, external_ref, spec_id,
 			compaction_level, compacted_at, compacted_at_commit, original_size,
 			sender, ephemeral, wisp_type, pinned, is_template, crystallizes,
@@ -60,7 +69,7 @@ func insertIssueIntoTable(ctx context.Context, tx *sql.Tx, table string, issue *
 			due_at, defer_until, metadata
 		) VALUES (
 			?, ?, ?, ?, ?, ?, ?,
-			?, ?, ?, ?, ?,
+			?, ?, ?, ?, ?, ?,
 			?, ?, ?, ?, ?, ?, ?,
 			?, ?, ?, ?,
 			?, ?, ?, ?, ?, ?,
@@ -71,8 +80,8 @@ func insertIssueIntoTable(ctx context.Context, tx *sql.Tx, table string, issue *
 			?, ?, ?
 		)
 	`, table),
-		issue.ID, issue.ContentHash, issue.Title, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes,
-		issue.Status, issue.Priority, issue.IssueType, nullString(issue.Assignee), nullInt(issue.EstimatedMinutes),
+		issue.ID, issue.ContentHash, issue.Title, description, issue.Design, issue.AcceptanceCriteria, issue.Notes,
+		issue.Status, issue.Priority, issue.IssueType, nullString(issue.Assignee), nullInt(issue.EstimatedMinutes), nullInt(issue.ActualMinutes),
 		issue.CreatedAt, issue.CreatedBy, issue.Owner, issue.UpdatedAt, issue.ClosedAt, nullStringPtr(issue.ExternalRef), issue.SpecID,
 		issue.CompactionLevel, issue.CompactedAt, nullStringPtr(issue.CompactedAtCommit), nullIntVal(issue.OriginalSize),
 		issue.Sender, issue.Ephemeral, issue.WispType, issue.Pinned, issue.IsTemplate, issue.Crystallizes,
@@ -102,6 +111,9 @@ func scanIssueFromTable(ctx context.Context, db *sql.DB, table, id string) (*typ
 	if err != nil {
 		return nil, fmt.Errorf("failed to get issue from %s: %w", table, err)
 	}
+	if err := resolveDescriptions(ctx, db, issue); err != nil {
+		return nil, err
+	}
 	return issue, nil
 }
 
@@ -120,10 +132,13 @@ func recordEventInTable(ctx context.Context, tx *sql.Tx, table, issueID string,
 // in the specified table.
 //
 //nolint:gosec // G201: table is a hardcoded constant
-func generateIssueIDInTable(ctx context.Context, tx *sql.Tx, table, prefix string, issue *types.Issue, actor string) (string, error) {
+func generateIssueIDInTable(ctx context.Context, tx *sql.Tx, table, prefix, town string, issue *types.Issue, actor string) (string, error) {
+	town, err := idTownTx(ctx, tx, town)
+	if err != nil {
+		return "", err
+	}
 	baseLength := getAdaptiveIDLengthFromTable(ctx, tx, table, prefix)
 
-	var err error
 	maxLength := 8
 	if baseLength > maxLength {
 		baseLength = maxLength
@@ -131,7 +146,7 @@ func generateIssueIDInTable(ctx context.Context, tx *sql.Tx, table, prefix strin
 
 	for length := baseLength; length <= maxLength; length++ {
 		for nonce := 0; nonce < 10; nonce++ {
-			candidate := generateHashID(prefix, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
+			candidate := generateHashID(prefix, town, issue.Title, issue.Description, actor, issue.CreatedAt, length, nonce)
 
 			var count int
 			err = tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE id = ?`, table), candidate).Scan(&count) //nolint:gosec // G201
@@ -191,6 +206,9 @@ func scanIssueTxFromTable(ctx context.Context, tx *sql.Tx, table, id string) (*t
 	if err != nil {
 		return nil, err
 	}
+	if err := resolveDescriptions(ctx, tx, issue); err != nil {
+		return nil, err
+	}
 	return issue, nil
 }
 
@@ -253,7 +271,7 @@ func (s *DoltStore) createWisp(ctx context.Context, issue *types.Issue, actor st
 	// Generate wisp ID if not provided
 	if issue.ID == "" {
 		prefix := wispPrefix(configPrefix, issue)
-		generatedID, err := generateIssueIDInTable(ctx, tx, "wisps", prefix, issue, actor)
+		generatedID, err := generateIssueIDInTable(ctx, tx, "wisps", prefix, s.idTown, issue, actor)
 		if err != nil {
 			return fmt.Errorf("failed to generate wisp ID: %w", err)
 		}
@@ -555,6 +573,12 @@ func (s *DoltStore) searchWisps(ctx context.Context, query string, filter types.
 		args = append(args, string(*filter.WispType))
 	}
 
+	if filter.FullText != "" {
+		clause, textArgs := fullTextClause(filter.FullText, "wisp_comments", false)
+		whereClauses = append(whereClauses, clause)
+		args = append(args, textArgs...)
+	}
+
 	if filter.TitleSearch != "" {
 		whereClauses = append(whereClauses, "title LIKE ?")
 		args = append(args, "%"+filter.TitleSearch+"%")
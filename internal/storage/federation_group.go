@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RedactableFields lists the issue fields a federation group may withhold
+// from its peers.
+var RedactableFields = []string{
+	"description",
+	"design",
+	"acceptance_criteria",
+	"notes",
+	"assignee",
+	"owner",
+	"close_reason",
+	"comments",
+}
+
+// ValidateRedactFields normalizes a list of redaction field names, rejecting
+// any that are not in RedactableFields. The result is sorted and deduplicated.
+func ValidateRedactFields(fields []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var result []string
+	for _, f := range fields {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" || seen[f] {
+			continue
+		}
+		valid := false
+		for _, r := range RedactableFields {
+			if f == r {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid redaction field %q (valid: %s)", f, strings.Join(RedactableFields, ", "))
+		}
+		seen[f] = true
+		result = append(result, f)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// HasPeer reports whether the named peer is a member of the group.
+func (g *FederationGroup) HasPeer(name string) bool {
+	for _, p := range g.Peers {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// AddPeers adds peers to the group, ignoring duplicates, and keeps the
+// member list sorted. Returns the number of peers actually added.
+func (g *FederationGroup) AddPeers(names ...string) int {
+	added := 0
+	for _, n := range names {
+		if n == "" || g.HasPeer(n) {
+			continue
+		}
+		g.Peers = append(g.Peers, n)
+		added++
+	}
+	sort.Strings(g.Peers)
+	return added
+}
+
+// RemovePeers removes peers from the group. Returns the number removed.
+func (g *FederationGroup) RemovePeers(names ...string) int {
+	drop := make(map[string]bool, len(names))
+	for _, n := range names {
+		drop[n] = true
+	}
+	kept := g.Peers[:0]
+	for _, p := range g.Peers {
+		if !drop[p] {
+			kept = append(kept, p)
+		}
+	}
+	removed := len(g.Peers) - len(kept)
+	g.Peers = kept
+	return removed
+}
+
+// SyncDue reports whether a scheduled sync of the group is due at now.
+// Groups without a sync interval are only synced on demand and are never due;
+// groups that have never synced are always due.
+func (g *FederationGroup) SyncDue(now time.Time) bool {
+	if g.SyncInterval <= 0 {
+		return false
+	}
+	if g.LastSync == nil {
+		return true
+	}
+	return !now.Before(g.LastSync.Add(g.SyncInterval))
+}
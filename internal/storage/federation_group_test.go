@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateRedactFields(t *testing.T) {
+	got, err := ValidateRedactFields([]string{"Notes", " description ", "notes", ""})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"description", "notes"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateRedactFields() = %v, want %v", got, want)
+	}
+
+	if _, err := ValidateRedactFields([]string{"title"}); err == nil {
+		t.Error("expected error for non-redactable field")
+	}
+}
+
+func TestFederationGroupPeers(t *testing.T) {
+	g := &FederationGroup{Name: "partners"}
+	if n := g.AddPeers("gamma", "beta", "gamma"); n != 2 {
+		t.Errorf("AddPeers() added %d, want 2", n)
+	}
+	if !reflect.DeepEqual(g.Peers, []string{"beta", "gamma"}) {
+		t.Errorf("Peers = %v, want sorted [beta gamma]", g.Peers)
+	}
+	if !g.HasPeer("beta") || g.HasPeer("alpha") {
+		t.Errorf("HasPeer() mismatch for %v", g.Peers)
+	}
+	if n := g.RemovePeers("beta", "alpha"); n != 1 {
+		t.Errorf("RemovePeers() removed %d, want 1", n)
+	}
+	if !reflect.DeepEqual(g.Peers, []string{"gamma"}) {
+		t.Errorf("Peers = %v, want [gamma]", g.Peers)
+	}
+}
+
+func TestFederationGroupSyncDue(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-10 * time.Minute)
+	old := now.Add(-2 * time.Hour)
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+		lastSync *time.Time
+		want     bool
+	}{
+		{"on demand only", 0, nil, false},
+		{"never synced", time.Hour, nil, true},
+		{"synced recently", time.Hour, &recent, false},
+		{"interval elapsed", time.Hour, &old, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &FederationGroup{SyncInterval: tt.interval, LastSync: tt.lastSync}
+			if got := g.SyncDue(now); got != tt.want {
+				t.Errorf("SyncDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// IntentOp identifies a multi-statement operation recorded in the intent log.
+type IntentOp string
+
+const (
+	// IntentCreate covers creating an issue together with its labels and
+	// dependencies (payload: CreateIntent).
+	IntentCreate IntentOp = "create"
+	// IntentClose covers closing a batch of issues (payload: CloseIntent).
+	IntentClose IntentOp = "close"
+	// IntentSync covers a federation sync: fetch, merge, push and the
+	// last-sync bookkeeping (payload: SyncIntent).
+	IntentSync IntentOp = "sync"
+)
+
+// IntentStatus is the lifecycle state of an intent log entry.
+type IntentStatus string
+
+const (
+	// IntentPending means the operation started but has not finished.
+	// Completed intents are removed, so a pending intent left behind by a
+	// dead process marks an interrupted operation.
+	IntentPending IntentStatus = "pending"
+	// IntentRolledForward means recovery completed the remaining steps.
+	IntentRolledForward IntentStatus = "rolled_forward"
+	// IntentRolledBack means recovery found the operation never took effect
+	// (or undid its partial effects).
+	IntentRolledBack IntentStatus = "rolled_back"
+	// IntentFailed means recovery was attempted but could not resolve the
+	// intent; it needs manual attention.
+	IntentFailed IntentStatus = "failed"
+)
+
+// Intent is one entry in the write-ahead intent log.
+type Intent struct {
+	ID         int64
+	Op         IntentOp
+	Target     string // Primary object (issue ID, peer name); may be empty until known
+	Payload    string // JSON-encoded CreateIntent, CloseIntent, or SyncIntent
+	Actor      string
+	Host       string // Hostname of the process that recorded the intent
+	PID        int    // PID of the process that recorded the intent
+	Status     IntentStatus
+	Resolution string // What recovery did (or why it failed)
+	CreatedAt  time.Time
+	ResolvedAt *time.Time
+}
+
+// CreateIntent is the payload of an IntentCreate entry.
+// Dependencies with an empty IssueID or DependsOnID refer to the new issue,
+// whose ID may not be known when the intent is recorded.
+type CreateIntent struct {
+	Title        string              `json:"title"`
+	Labels       []string            `json:"labels,omitempty"`
+	Dependencies []*types.Dependency `json:"dependencies,omitempty"`
+}
+
+// CloseIntent is the payload of an IntentClose entry.
+type CloseIntent struct {
+	IssueIDs []string `json:"issue_ids"`
+	Reason   string   `json:"reason,omitempty"`
+	Session  string   `json:"session,omitempty"`
+}
+
+// SyncIntent is the payload of an IntentSync entry.
+type SyncIntent struct {
+	Peer string `json:"peer"`
+}
+
+// DecodePayload unmarshals the intent payload into v.
+func (i *Intent) DecodePayload(v any) error {
+	if i.Payload == "" {
+		return fmt.Errorf("intent %d has no payload", i.ID)
+	}
+	if err := json.Unmarshal([]byte(i.Payload), v); err != nil {
+		return fmt.Errorf("intent %d: invalid %s payload: %w", i.ID, i.Op, err)
+	}
+	return nil
+}
+
+// ResolveDependency returns a copy of dep with references to the new issue
+// (empty IssueID or DependsOnID) filled in with issueID.
+func (c *CreateIntent) ResolveDependency(dep *types.Dependency, issueID string) *types.Dependency {
+	resolved := *dep
+	if resolved.IssueID == "" {
+		resolved.IssueID = issueID
+	}
+	if resolved.DependsOnID == "" {
+		resolved.DependsOnID = issueID
+	}
+	return &resolved
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestCreateIntentResolveDependency(t *testing.T) {
+	intent := &CreateIntent{Title: "new"}
+
+	// New issue depends on bd-parent
+	dep := intent.ResolveDependency(&types.Dependency{DependsOnID: "bd-parent", Type: types.DepParentChild}, "bd-new")
+	if dep.IssueID != "bd-new" || dep.DependsOnID != "bd-parent" {
+		t.Errorf("got %s -> %s, want bd-new -> bd-parent", dep.IssueID, dep.DependsOnID)
+	}
+
+	// "blocks:bd-x": bd-x depends on the new issue
+	orig := &types.Dependency{IssueID: "bd-x", Type: types.DepBlocks}
+	dep = intent.ResolveDependency(orig, "bd-new")
+	if dep.IssueID != "bd-x" || dep.DependsOnID != "bd-new" {
+		t.Errorf("got %s -> %s, want bd-x -> bd-new", dep.IssueID, dep.DependsOnID)
+	}
+	if orig.DependsOnID != "" {
+		t.Error("ResolveDependency must not modify the recorded dependency")
+	}
+}
+
+func TestIntentDecodePayload(t *testing.T) {
+	in := &Intent{ID: 7, Op: IntentClose, Payload: `{"issue_ids":["bd-1","bd-2"],"reason":"done"}`}
+	var payload CloseIntent
+	if err := in.DecodePayload(&payload); err != nil {
+		t.Fatalf("DecodePayload: %v", err)
+	}
+	if len(payload.IssueIDs) != 2 || payload.Reason != "done" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+
+	if err := (&Intent{ID: 8, Op: IntentSync}).DecodePayload(&payload); err == nil {
+		t.Error("expected error for empty payload")
+	}
+	if err := (&Intent{ID: 9, Op: IntentSync, Payload: "{"}).DecodePayload(&payload); err == nil {
+		t.Error("expected error for invalid payload")
+	}
+}
@@ -8,6 +8,8 @@ package storage
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/beads/internal/types"
@@ -27,6 +29,26 @@ var ErrNotInitialized = errors.New("database not initialized")
 // ErrPrefixMismatch is returned when an issue ID does not match the configured prefix.
 var ErrPrefixMismatch = errors.New("prefix mismatch")
 
+// ErrValidation is returned (wrapped) when an issue fails validation.
+var ErrValidation = errors.New("validation failed")
+
+// ErrCycle is returned when adding a dependency would create a cycle.
+var ErrCycle = errors.New("adding dependency would create a cycle")
+
+// CycleError is the ErrCycle returned by AddDependency. Path is the cycle
+// the dependency would close, from the dependent issue back to itself.
+type CycleError struct {
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrCycle, strings.Join(e.Path, " → "))
+}
+
+func (e *CycleError) Unwrap() error {
+	return ErrCycle
+}
+
 // Storage is the interface satisfied by *dolt.DoltStore.
 // Consumers depend on this interface rather than on the concrete type so that
 // alternative implementations (mocks, proxies, etc.) can be substituted.
@@ -57,9 +79,19 @@ type Storage interface {
 	GetLabels(ctx context.Context, issueID string) ([]string, error)
 	GetIssuesByLabel(ctx context.Context, label string) ([]*types.Issue, error)
 
+	// External keys (aliases such as JIRA-123)
+	SetExternalKey(ctx context.Context, issueID, key, source, actor string) error
+	ResolveExternalKey(ctx context.Context, key string) (string, error)
+
+	// Sync conflicts (changed both locally and in an external tracker)
+	RecordSyncConflict(ctx context.Context, c *types.SyncConflict, actor string) error
+	ListSyncConflicts(ctx context.Context, tracker string, all bool) ([]*types.SyncConflict, error)
+	MarkSyncConflictPushed(ctx context.Context, id int64, actor string) error
+
 	// Work queries
 	GetReadyWork(ctx context.Context, filter types.WorkFilter) ([]*types.Issue, error)
 	GetBlockedIssues(ctx context.Context, filter types.WorkFilter) ([]*types.BlockedIssue, error)
+	GetBlockingChain(ctx context.Context, issueID string) (*types.BlockingChain, error)
 	GetEpicsEligibleForClosure(ctx context.Context) ([]*types.EpicStatus, error)
 
 	// Comments and events
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// SyncStats aggregates federation sync history for one peer.
+// Used to spot flaky peers: low success rates or long tail durations.
+type SyncStats struct {
+	Peer        string        `json:"peer"`
+	Total       int           `json:"total"`
+	Succeeded   int           `json:"succeeded"`
+	Failed      int           `json:"failed"`
+	SuccessRate float64       `json:"success_rate"` // 0.0-1.0
+	Conflicts   int           `json:"conflicts"`
+	Pulled      int           `json:"pulled_commits"`
+	Pushed      int           `json:"pushed_commits"`
+	AvgDuration time.Duration `json:"avg_duration"`
+	P95Duration time.Duration `json:"p95_duration"`
+	LastSuccess *time.Time    `json:"last_success,omitempty"`
+	LastFailure *time.Time    `json:"last_failure,omitempty"`
+	LastError   string        `json:"last_error,omitempty"`
+}
+
+// ComputeSyncStats aggregates history entries by peer.
+// The result is sorted by peer name.
+func ComputeSyncStats(entries []*SyncHistoryEntry) []*SyncStats {
+	byPeer := make(map[string][]*SyncHistoryEntry)
+	for _, e := range entries {
+		byPeer[e.Peer] = append(byPeer[e.Peer], e)
+	}
+
+	stats := make([]*SyncStats, 0, len(byPeer))
+	for peer, peerEntries := range byPeer {
+		stats = append(stats, computePeerSyncStats(peer, peerEntries))
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Peer < stats[j].Peer })
+	return stats
+}
+
+func computePeerSyncStats(peer string, entries []*SyncHistoryEntry) *SyncStats {
+	s := &SyncStats{Peer: peer, Total: len(entries)}
+	durations := make([]time.Duration, 0, len(entries))
+	var total time.Duration
+
+	for _, e := range entries {
+		durations = append(durations, e.Duration)
+		total += e.Duration
+		s.Conflicts += e.Conflicts
+		s.Pulled += e.PulledCommits
+		s.Pushed += e.PushedCommits
+
+		started := e.StartedAt
+		if e.Succeeded() {
+			s.Succeeded++
+			if s.LastSuccess == nil || started.After(*s.LastSuccess) {
+				s.LastSuccess = &started
+			}
+		} else {
+			s.Failed++
+			if s.LastFailure == nil || started.After(*s.LastFailure) {
+				s.LastFailure = &started
+				s.LastError = e.Error
+			}
+		}
+	}
+
+	if s.Total > 0 {
+		s.SuccessRate = float64(s.Succeeded) / float64(s.Total)
+		s.AvgDuration = total / time.Duration(s.Total)
+		s.P95Duration = percentileDuration(durations, 0.95)
+	}
+	return s
+}
+
+// percentileDuration returns the p-th percentile (0.0-1.0) of durations
+// using the nearest-rank method. durations is sorted in place.
+func percentileDuration(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	rank := int(math.Ceil(p*float64(len(durations)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(durations) {
+		rank = len(durations) - 1
+	}
+	return durations[rank]
+}
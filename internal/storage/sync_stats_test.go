@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeSyncStats(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var entries []*SyncHistoryEntry
+	for i := 1; i <= 20; i++ {
+		e := &SyncHistoryEntry{
+			Peer:          "beta",
+			StartedAt:     base.Add(time.Duration(i) * time.Hour),
+			Duration:      time.Duration(i) * time.Second,
+			PulledCommits: 1,
+		}
+		if i%5 == 0 {
+			e.Error = "fetch failed"
+		}
+		entries = append(entries, e)
+	}
+	entries = append(entries, &SyncHistoryEntry{Peer: "alpha", StartedAt: base, Duration: time.Second})
+
+	stats := ComputeSyncStats(entries)
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 peers, got %d", len(stats))
+	}
+	if stats[0].Peer != "alpha" || stats[1].Peer != "beta" {
+		t.Errorf("stats not sorted by peer: %s, %s", stats[0].Peer, stats[1].Peer)
+	}
+
+	beta := stats[1]
+	if beta.Total != 20 || beta.Succeeded != 16 || beta.Failed != 4 {
+		t.Errorf("counts = %d/%d/%d, want 20/16/4", beta.Total, beta.Succeeded, beta.Failed)
+	}
+	if beta.SuccessRate != 0.8 {
+		t.Errorf("SuccessRate = %v, want 0.8", beta.SuccessRate)
+	}
+	if beta.P95Duration != 19*time.Second {
+		t.Errorf("P95Duration = %v, want 19s", beta.P95Duration)
+	}
+	if beta.AvgDuration != 10500*time.Millisecond {
+		t.Errorf("AvgDuration = %v, want 10.5s", beta.AvgDuration)
+	}
+	if beta.Pulled != 20 {
+		t.Errorf("Pulled = %d, want 20", beta.Pulled)
+	}
+	if beta.LastFailure == nil || !beta.LastFailure.Equal(base.Add(20*time.Hour)) {
+		t.Errorf("LastFailure = %v, want %v", beta.LastFailure, base.Add(20*time.Hour))
+	}
+	if beta.LastSuccess == nil || !beta.LastSuccess.Equal(base.Add(19*time.Hour)) {
+		t.Errorf("LastSuccess = %v, want %v", beta.LastSuccess, base.Add(19*time.Hour))
+	}
+}
+
+func TestPercentileDuration(t *testing.T) {
+	if got := percentileDuration(nil, 0.95); got != 0 {
+		t.Errorf("empty percentile = %v, want 0", got)
+	}
+	if got := percentileDuration([]time.Duration{3 * time.Second}, 0.95); got != 3*time.Second {
+		t.Errorf("single percentile = %v, want 3s", got)
+	}
+}
@@ -58,3 +58,37 @@ type FederationPeer struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
+
+// FederationGroup is a named set of federation peers that share a sync
+// schedule, redaction policy, and sovereignty tier.
+type FederationGroup struct {
+	Name         string        // Unique group name (e.g., "partners")
+	Peers        []string      // Member peer (remote) names
+	SyncInterval time.Duration // Minimum time between scheduled syncs (0 = on demand only)
+	Redact       []string      // Issue fields withheld from peers in this group
+	Sovereignty  string        // Sovereignty tier applied to the group: T1, T2, T3, T4
+	LastSync     *time.Time    // Last successful group sync time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// SyncHistoryEntry is a persisted record of a single federation sync attempt.
+type SyncHistoryEntry struct {
+	ID            int64         // Auto-increment row ID
+	Peer          string        // Peer synced with
+	StartedAt     time.Time     // When the sync started
+	Duration      time.Duration // Wall-clock duration of the sync
+	Fetched       bool          // Whether the fetch step succeeded
+	Merged        bool          // Whether the merge step succeeded
+	Pushed        bool          // Whether local changes were pushed
+	PulledCommits int           // Commits pulled from the peer
+	PushedCommits int           // Commits pushed to the peer
+	Conflicts     int           // Number of conflicts encountered
+	Error         string        // Fatal sync error, if any
+	PushError     string        // Non-fatal push error, if any
+}
+
+// Succeeded reports whether the sync completed without a fatal error.
+func (e *SyncHistoryEntry) Succeeded() bool {
+	return e.Error == ""
+}
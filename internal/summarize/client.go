@@ -0,0 +1,138 @@
+package summarize
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/anthropics/anthropic-sdk-go/option"
+)
+
+// anthropicClient calls the Anthropic Messages API.
+type anthropicClient struct {
+	client    anthropic.Client
+	model     string
+	maxTokens int
+}
+
+func newAnthropicClient(cfg Config) *anthropicClient {
+	opts := []option.RequestOption{
+		option.WithAPIKey(cfg.APIKey),
+		option.WithRequestTimeout(DefaultTimeout),
+	}
+	if cfg.URL != "" {
+		opts = append(opts, option.WithBaseURL(cfg.URL))
+	}
+	return &anthropicClient{
+		client:    anthropic.NewClient(opts...),
+		model:     cfg.Model,
+		maxTokens: cfg.MaxTokens,
+	}
+}
+
+func (c *anthropicClient) Model() string {
+	return "anthropic/" + c.model
+}
+
+func (c *anthropicClient) Complete(ctx context.Context, prompt string) (string, error) {
+	message, err := c.client.Messages.New(ctx, anthropic.MessageNewParams{
+		Model:     anthropic.Model(c.model),
+		MaxTokens: int64(c.maxTokens),
+		Messages: []anthropic.MessageParam{
+			anthropic.NewUserMessage(anthropic.NewTextBlock(prompt)),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("summarizer request failed: %w", err)
+	}
+	var parts []string
+	for _, block := range message.Content {
+		if block.Type == "text" {
+			parts = append(parts, block.Text)
+		}
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("unexpected response format: no text content")
+	}
+	return strings.TrimSpace(strings.Join(parts, "")), nil
+}
+
+// openAIClient calls an OpenAI-compatible /chat/completions endpoint, which
+// also covers local servers such as Ollama, llama.cpp, and vLLM.
+type openAIClient struct {
+	url       string
+	model     string
+	apiKey    string
+	maxTokens int
+	client    *http.Client
+}
+
+func newOpenAIClient(cfg Config) *openAIClient {
+	url := cfg.URL
+	if url == "" {
+		url = "https://api.openai.com/v1"
+	}
+	return &openAIClient{
+		url:       strings.TrimRight(url, "/"),
+		model:     cfg.Model,
+		apiKey:    cfg.APIKey,
+		maxTokens: cfg.MaxTokens,
+		client:    &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+func (c *openAIClient) Model() string {
+	return "openai/" + c.model
+}
+
+func (c *openAIClient) Complete(ctx context.Context, prompt string) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"model":      c.model,
+		"max_tokens": c.maxTokens,
+		"messages":   []map[string]string{{"role": "user", "content": prompt}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode summarizer request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create summarizer request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("summarizer request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read summarizer response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("summarizer API error (status %d): %s", resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+
+	var result struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to parse summarizer response: %w", err)
+	}
+	if len(result.Choices) == 0 || strings.TrimSpace(result.Choices[0].Message.Content) == "" {
+		return "", fmt.Errorf("unexpected response format: no completion")
+	}
+	return strings.TrimSpace(result.Choices[0].Message.Content), nil
+}
@@ -0,0 +1,158 @@
+package summarize
+
+import (
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// Caps on how much source text goes into a prompt, in bytes. Long threads
+// keep their most recent comments.
+const (
+	maxFieldBytes    = 20000
+	maxCommentBytes  = 2000
+	maxCommentsTotal = 20000
+	maxChildBytes    = 600
+)
+
+type promptComment struct {
+	Author string
+	Date   string
+	Text   string
+}
+
+type promptChild struct {
+	ID       string
+	Title    string
+	Status   string
+	Priority int
+	Summary  string
+}
+
+type promptData struct {
+	ID                 string
+	Title              string
+	Type               string
+	Status             string
+	Description        string
+	Design             string
+	AcceptanceCriteria string
+	Notes              string
+	Comments           []promptComment
+	Children           []promptChild
+}
+
+var (
+	issueTemplate = template.Must(template.New("issue").Parse(issuePromptTemplate))
+	epicTemplate  = template.Must(template.New("epic").Parse(epicPromptTemplate))
+)
+
+// IssuePrompt builds the prompt for summarizing an issue and its comments.
+func IssuePrompt(issue *types.Issue, comments []*types.Comment) string {
+	return render(issueTemplate, newPromptData(issue, comments))
+}
+
+// EpicPrompt builds the prompt for summarizing an epic from its own text,
+// its comments, and its children. Children that have a summary contribute
+// it; the rest contribute the start of their description.
+func EpicPrompt(epic *types.Issue, children []*types.Issue, comments []*types.Comment) string {
+	data := newPromptData(epic, comments)
+	for _, child := range children {
+		text := child.Description
+		if s := FromMetadata(child.Metadata); s != nil {
+			text = s.Text
+		}
+		data.Children = append(data.Children, promptChild{
+			ID:       child.ID,
+			Title:    child.Title,
+			Status:   string(child.Status),
+			Priority: child.Priority,
+			Summary:  truncate(strings.TrimSpace(text), maxChildBytes),
+		})
+	}
+	return render(epicTemplate, data)
+}
+
+func newPromptData(issue *types.Issue, comments []*types.Comment) promptData {
+	data := promptData{
+		ID:                 issue.ID,
+		Title:              issue.Title,
+		Type:               string(issue.IssueType),
+		Status:             string(issue.Status),
+		Description:        truncate(issue.Description, maxFieldBytes),
+		Design:             truncate(issue.Design, maxFieldBytes),
+		AcceptanceCriteria: truncate(issue.AcceptanceCriteria, maxFieldBytes),
+		Notes:              truncate(issue.Notes, maxFieldBytes),
+	}
+	total := 0
+	for i := len(comments) - 1; i >= 0 && total < maxCommentsTotal; i-- {
+		c := comments[i]
+		text := truncate(c.Text, maxCommentBytes)
+		total += len(text)
+		data.Comments = append([]promptComment{{
+			Author: c.Author,
+			Date:   c.CreatedAt.Format("2006-01-02"),
+			Text:   text,
+		}}, data.Comments...)
+	}
+	return data
+}
+
+func render(tmpl *template.Template, data promptData) string {
+	var sb strings.Builder
+	_ = tmpl.Execute(&sb, data) // Templates are static and data is plain strings
+	return sb.String()
+}
+
+// truncate shortens s to at most n bytes on a rune boundary, marking the cut.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	cut := n
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + " […]"
+}
+
+const issuePromptTemplate = `Summarize this software issue for a teammate who has not read it. Write 2-4 plain sentences: the problem or goal, the current state or decisions reached (including any from the comments), and what remains. Do not restate the title, and reply with the summary only.
+
+**{{.ID}}: {{.Title}}** ({{.Type}}, {{.Status}})
+{{if .Description}}
+**Description:**
+{{.Description}}
+{{end}}{{if .Design}}
+**Design:**
+{{.Design}}
+{{end}}{{if .AcceptanceCriteria}}
+**Acceptance Criteria:**
+{{.AcceptanceCriteria}}
+{{end}}{{if .Notes}}
+**Notes:**
+{{.Notes}}
+{{end}}{{if .Comments}}
+**Comments (oldest first):**
+{{range .Comments}}
+- {{.Author}} ({{.Date}}): {{.Text}}
+{{end}}{{end}}`
+
+const epicPromptTemplate = `Summarize this epic for a teammate who has not read it. Write 3-5 plain sentences: the overall goal, what has been completed, what is in progress or blocked, and what remains. Refer to child issues by ID where useful, and reply with the summary only.
+
+**{{.ID}}: {{.Title}}** (epic, {{.Status}})
+{{if .Description}}
+**Description:**
+{{.Description}}
+{{end}}{{if .Comments}}
+**Comments (oldest first):**
+{{range .Comments}}
+- {{.Author}} ({{.Date}}): {{.Text}}
+{{end}}{{end}}
+**Children:**
+{{range .Children}}
+- {{.ID}} [{{.Status}}, P{{.Priority}}] {{.Title}}{{if .Summary}}: {{.Summary}}{{end}}
+{{else}}
+(none)
+{{end}}`
@@ -0,0 +1,114 @@
+// Package summarize produces short LLM summaries of issues and epics.
+//
+// Summaries are stored in the issue's metadata under MetadataKey together
+// with a hash of the text they were generated from, so a summary is only
+// regenerated when that text changes.
+package summarize
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MetadataKey is the issue metadata field holding the summary.
+const MetadataKey = "summary"
+
+// Provider names accepted by New.
+const (
+	ProviderAnthropic = "anthropic"
+	ProviderOpenAI    = "openai"
+)
+
+// DefaultTimeout bounds each request to the LLM endpoint.
+const DefaultTimeout = 2 * time.Minute
+
+// Summary is a generated summary as stored in issue metadata.
+type Summary struct {
+	Text        string    `json:"text"`
+	SourceHash  string    `json:"source_hash"` // SourceHash of the prompt it was generated from
+	Model       string    `json:"model"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// Client sends a prompt to an LLM and returns its text reply.
+type Client interface {
+	// Model identifies the provider and model, e.g. "anthropic/claude-haiku-4-5".
+	Model() string
+
+	// Complete returns the model's reply to prompt.
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// Config selects and configures a Client.
+type Config struct {
+	Provider  string // anthropic (default) or openai (any OpenAI-compatible chat API)
+	Model     string // Model name; required
+	URL       string // Base URL; empty for the provider default
+	APIKey    string // API key; optional for openai-compatible local servers
+	MaxTokens int    // Reply length cap; 0 for 512
+}
+
+// New returns the Client described by cfg.
+func New(cfg Config) (Client, error) {
+	if cfg.Model == "" {
+		return nil, errors.New("no summarizer model configured (set summarize.model or ai.model)")
+	}
+	if cfg.MaxTokens <= 0 {
+		cfg.MaxTokens = 512
+	}
+	switch strings.ToLower(strings.TrimSpace(cfg.Provider)) {
+	case "", ProviderAnthropic:
+		if cfg.APIKey == "" {
+			return nil, errors.New("the anthropic summarizer requires an API key (set ANTHROPIC_API_KEY)")
+		}
+		return newAnthropicClient(cfg), nil
+	case ProviderOpenAI:
+		return newOpenAIClient(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown summarizer provider %q (use: %s, %s)", cfg.Provider, ProviderAnthropic, ProviderOpenAI)
+	}
+}
+
+// SourceHash returns the cache key of a prompt. The prompt embeds all of
+// the source text, so any edit to it (or to the prompt template) changes
+// the hash.
+func SourceHash(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+// FromMetadata returns the summary stored in issue metadata, or nil.
+func FromMetadata(metadata json.RawMessage) *Summary {
+	var md map[string]json.RawMessage
+	if json.Unmarshal(metadata, &md) != nil || md[MetadataKey] == nil {
+		return nil
+	}
+	var s Summary
+	if json.Unmarshal(md[MetadataKey], &s) != nil || s.Text == "" {
+		return nil
+	}
+	return &s
+}
+
+// WithSummary returns metadata with the summary set, keeping other fields.
+func WithSummary(metadata json.RawMessage, s *Summary) (json.RawMessage, error) {
+	md := make(map[string]json.RawMessage)
+	if trimmed := bytes.TrimSpace(metadata); len(trimmed) > 0 && string(trimmed) != "null" {
+		if err := json.Unmarshal(metadata, &md); err != nil {
+			return nil, errors.New("issue metadata is not a JSON object; cannot store summary")
+		}
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	md[MetadataKey] = data
+	return json.Marshal(md)
+}
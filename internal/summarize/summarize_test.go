@@ -0,0 +1,107 @@
+package summarize
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestMetadataRoundTrip(t *testing.T) {
+	s := &Summary{Text: "Short.", SourceHash: "abc", Model: "openai/m", GeneratedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}
+	md, err := WithSummary(json.RawMessage(`{"plan_key":"a"}`), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(md), `"plan_key":"a"`) {
+		t.Errorf("other metadata fields should be kept: %s", md)
+	}
+	got := FromMetadata(md)
+	if got == nil || got.Text != "Short." || got.SourceHash != "abc" {
+		t.Errorf("FromMetadata = %+v", got)
+	}
+	if FromMetadata(nil) != nil || FromMetadata(json.RawMessage(`{"summary":{"text":""}}`)) != nil {
+		t.Error("missing or empty summaries should read as nil")
+	}
+	if _, err := WithSummary(json.RawMessage(`[1]`), s); err == nil {
+		t.Error("expected error for non-object metadata")
+	}
+}
+
+func TestPrompts(t *testing.T) {
+	issue := &types.Issue{ID: "bd-1", Title: "Login", IssueType: types.TypeBug, Status: types.StatusOpen, Description: "It crashes."}
+	comments := []*types.Comment{{Author: "alice", Text: "Repro on Safari", CreatedAt: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)}}
+	p := IssuePrompt(issue, comments)
+	for _, want := range []string{"bd-1: Login", "It crashes.", "alice (2026-03-04): Repro on Safari"} {
+		if !strings.Contains(p, want) {
+			t.Errorf("issue prompt missing %q:\n%s", want, p)
+		}
+	}
+	if SourceHash(p) != SourceHash(IssuePrompt(issue, comments)) {
+		t.Error("prompts should be deterministic")
+	}
+	issue.Description = "It crashes on submit."
+	if SourceHash(p) == SourceHash(IssuePrompt(issue, comments)) {
+		t.Error("editing the description should change the source hash")
+	}
+
+	md, _ := WithSummary(nil, &Summary{Text: "Child summary."})
+	children := []*types.Issue{
+		{ID: "bd-2", Title: "Fix", Status: types.StatusClosed, Priority: 1, Metadata: md, Description: "long text"},
+		{ID: "bd-3", Title: "Test", Status: types.StatusOpen, Priority: 2, Description: strings.Repeat("é", 1000)},
+	}
+	p = EpicPrompt(&types.Issue{ID: "bd-0", Title: "Auth", Status: types.StatusOpen}, children, nil)
+	if !strings.Contains(p, "bd-2 [closed, P1] Fix: Child summary.") || strings.Contains(p, "long text") {
+		t.Errorf("children should contribute their summaries:\n%s", p)
+	}
+	if !strings.Contains(p, "é […]") {
+		t.Errorf("long child descriptions should be truncated on a rune boundary:\n%s", p)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(Config{Provider: ProviderAnthropic}); err == nil {
+		t.Error("expected error without a model")
+	}
+	if _, err := New(Config{Provider: ProviderAnthropic, Model: "m"}); err == nil {
+		t.Error("expected error for anthropic without an API key")
+	}
+	if _, err := New(Config{Provider: "bogus", Model: "m"}); err == nil {
+		t.Error("expected error for unknown provider")
+	}
+}
+
+func TestOpenAIClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Model    string `json:"model"`
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/v1/chat/completions" || req.Model != "llama3" || len(req.Messages) != 1 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"  A summary.\n"}}]}`))
+	}))
+	defer srv.Close()
+
+	c, err := New(Config{Provider: ProviderOpenAI, Model: "llama3", URL: srv.URL + "/v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Model() != "openai/llama3" {
+		t.Errorf("Model() = %q", c.Model())
+	}
+	got, err := c.Complete(context.Background(), "prompt")
+	if err != nil || got != "A summary." {
+		t.Errorf("Complete = %q, %v", got, err)
+	}
+}
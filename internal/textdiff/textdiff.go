@@ -0,0 +1,238 @@
+// Package textdiff computes line-based unified diffs of issue text fields.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OpKind is the kind of a line edit.
+type OpKind int
+
+const (
+	// Equal means the line is present in both texts.
+	Equal OpKind = iota
+	// Delete means the line is only in the old text.
+	Delete
+	// Insert means the line is only in the new text.
+	Insert
+)
+
+// Edit is one line of an edit script.
+type Edit struct {
+	Kind OpKind
+	Line string
+}
+
+// maxEditDistance caps the Myers search. Beyond it, the differing middle of
+// the texts is reported as a wholesale replacement, which is still a correct
+// (if less minimal) diff and keeps memory bounded for huge rewrites.
+const maxEditDistance = 2000
+
+// Lines returns an edit script turning a into b.
+func Lines(a, b []string) []Edit {
+	// Common prefix and suffix are cheap to strip and cover most edits.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]Edit, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		edits = append(edits, Edit{Equal, line})
+	}
+	edits = append(edits, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, Edit{Equal, line})
+	}
+	return edits
+}
+
+// myers computes a shortest edit script with Myers' O(ND) algorithm.
+func myers(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return replace(a, b)
+	}
+
+	maxD := n + m
+	if maxD > maxEditDistance {
+		maxD = maxEditDistance
+	}
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	// trace[d] holds v[-d..d] as it was before step d, for backtracking.
+	var trace [][]int
+
+	for d := 0; d <= maxD; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // Step down: insertion
+			} else {
+				x = v[offset+k-1] + 1 // Step right: deletion
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+	}
+	return replace(a, b)
+}
+
+// backtrack walks the Myers trace from the end to recover the edit script.
+func backtrack(a, b []string, trace [][]int) []Edit {
+	var rev []Edit
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		at := func(k int) int { return trace[d][k+d] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := 0
+		if d > 0 {
+			prevX = at(prevK)
+		}
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			rev = append(rev, Edit{Equal, a[x]})
+		}
+		if d > 0 {
+			if x == prevX {
+				rev = append(rev, Edit{Insert, b[prevY]})
+			} else {
+				rev = append(rev, Edit{Delete, a[prevX]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	edits := make([]Edit, len(rev))
+	for i, e := range rev {
+		edits[len(rev)-1-i] = e
+	}
+	return edits
+}
+
+func replace(a, b []string) []Edit {
+	edits := make([]Edit, 0, len(a)+len(b))
+	for _, line := range a {
+		edits = append(edits, Edit{Delete, line})
+	}
+	for _, line := range b {
+		edits = append(edits, Edit{Insert, line})
+	}
+	return edits
+}
+
+// SplitLines splits text into lines without their terminators. A trailing
+// newline does not produce an empty final line, and empty text has no lines.
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Unified returns a unified diff of oldText and newText with the given number
+// of context lines, or "" if they are equal. fromName and toName label the
+// "---" and "+++" header lines.
+func Unified(fromName, toName, oldText, newText string, context int) string {
+	if oldText == newText {
+		return ""
+	}
+	edits := Lines(SplitLines(oldText), SplitLines(newText))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+
+	// Line numbers (1-based) of each edit in the old and new text.
+	oldLine := make([]int, len(edits)+1)
+	newLine := make([]int, len(edits)+1)
+	o, n := 1, 1
+	for i, e := range edits {
+		oldLine[i], newLine[i] = o, n
+		if e.Kind != Insert {
+			o++
+		}
+		if e.Kind != Delete {
+			n++
+		}
+	}
+	oldLine[len(edits)], newLine[len(edits)] = o, n
+
+	for i := 0; i < len(edits); {
+		if edits[i].Kind == Equal {
+			i++
+			continue
+		}
+		// Grow the hunk while changes are within 2*context lines of each other.
+		start := max(i-context, 0)
+		end := i
+		for j := i; j < len(edits); j++ {
+			if edits[j].Kind != Equal {
+				end = j + 1
+			} else if j-end >= 2*context {
+				break
+			}
+		}
+		end = min(end+context, len(edits))
+
+		oldCount, newCount := 0, 0
+		for _, e := range edits[start:end] {
+			if e.Kind != Insert {
+				oldCount++
+			}
+			if e.Kind != Delete {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n",
+			hunkRange(oldLine[start], oldCount), hunkRange(newLine[start], newCount))
+		for _, e := range edits[start:end] {
+			switch e.Kind {
+			case Equal:
+				sb.WriteString(" ")
+			case Delete:
+				sb.WriteString("-")
+			case Insert:
+				sb.WriteString("+")
+			}
+			sb.WriteString(e.Line)
+			sb.WriteString("\n")
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+// hunkRange formats a hunk range as GNU diff does: an empty range is
+// reported at the line before it.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	default:
+		return fmt.Sprintf("%d,%d", start, count)
+	}
+}
@@ -0,0 +1,102 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLinesRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"identical", "a\nb\nc", "a\nb\nc"},
+		{"insert middle", "a\nc", "a\nb\nc"},
+		{"delete middle", "a\nb\nc", "a\nc"},
+		{"replace", "a\nb\nc", "a\nx\nc"},
+		{"from empty", "", "a\nb"},
+		{"to empty", "a\nb", ""},
+		{"interleaved", "a\nb\nc\nd\ne\nf", "b\nc\nx\ne\nf\ng"},
+		{"reordered", "1\n2\n3\n4", "4\n3\n2\n1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := SplitLines(tt.a), SplitLines(tt.b)
+			var gotA, gotB []string
+			for _, e := range Lines(a, b) {
+				if e.Kind != Insert {
+					gotA = append(gotA, e.Line)
+				}
+				if e.Kind != Delete {
+					gotB = append(gotB, e.Line)
+				}
+			}
+			if strings.Join(gotA, "\n") != strings.Join(a, "\n") {
+				t.Errorf("edit script does not reproduce old text: %q", gotA)
+			}
+			if strings.Join(gotB, "\n") != strings.Join(b, "\n") {
+				t.Errorf("edit script does not reproduce new text: %q", gotB)
+			}
+		})
+	}
+}
+
+func TestLinesMinimal(t *testing.T) {
+	edits := Lines(SplitLines("a\nb\nc\nd"), SplitLines("a\nx\nc\nd"))
+	changes := 0
+	for _, e := range edits {
+		if e.Kind != Equal {
+			changes++
+		}
+	}
+	if changes != 2 {
+		t.Errorf("expected 2 changed lines (one delete, one insert), got %d: %+v", changes, edits)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	if got := Unified("a", "b", "same\n", "same\n", 3); got != "" {
+		t.Errorf("expected empty diff for equal text, got %q", got)
+	}
+
+	old := "# Spec\n\nUse a cache.\nKey by filter.\n"
+	updated := "# Spec\n\nUse a cache.\nKey by filter and version.\nInvalidate on writes.\n"
+	want := `--- old
++++ new
+@@ -2,3 +2,4 @@
+ 
+ Use a cache.
+-Key by filter.
++Key by filter and version.
++Invalidate on writes.
+`
+	if got := Unified("old", "new", old, updated, 2); got != want {
+		t.Errorf("Unified() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestUnifiedSeparateHunks(t *testing.T) {
+	var oldLines, newLines []string
+	for i := 0; i < 30; i++ {
+		line := string(rune('a' + i%26))
+		oldLines = append(oldLines, line)
+		newLines = append(newLines, line)
+	}
+	newLines[2] = "CHANGED-TOP"
+	newLines[27] = "CHANGED-BOTTOM"
+
+	got := Unified("old", "new", strings.Join(oldLines, "\n"), strings.Join(newLines, "\n"), 3)
+	if n := strings.Count(got, "@@ -"); n != 2 {
+		t.Errorf("expected 2 hunks, got %d:\n%s", n, got)
+	}
+	if !strings.Contains(got, "@@ -1,6 +1,6 @@") || !strings.Contains(got, "@@ -25,6 +25,6 @@") {
+		t.Errorf("unexpected hunk headers:\n%s", got)
+	}
+}
+
+func TestUnifiedEmptySide(t *testing.T) {
+	got := Unified("old", "new", "", "first\nsecond\n", 3)
+	if !strings.Contains(got, "@@ -0,0 +1,2 @@") {
+		t.Errorf("unexpected header for added text:\n%s", got)
+	}
+}
@@ -3,6 +3,8 @@ package timeparsing
 import (
 	"testing"
 	"time"
+
+	"github.com/steveyegge/beads/internal/calendar"
 )
 
 // TestParseNaturalLanguage tests the NLP parser wrapper.
@@ -285,3 +287,41 @@ func TestParseRelativeTime_LayerPrecedence(t *testing.T) {
 		t.Errorf("ParseRelativeTime(\"2025-01-20\") = %v, want Jan 20, 2025", t2)
 	}
 }
+
+// TestParseRelativeTimeIn_BusinessDays tests business-day offsets against a
+// calendar with a holiday.
+func TestParseRelativeTimeIn_BusinessDays(t *testing.T) {
+	// Thursday, January 16, 2025, 10:00:00 AM; Monday the 20th is a holiday
+	now := time.Date(2025, 1, 16, 10, 0, 0, 0, time.Local)
+	cal, err := calendar.New([]string{"saturday", "sunday"}, []string{"2025-01-20"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		input   string
+		wantDay int
+	}{
+		{"+1 business day", 17},
+		{"+3 business days", 22},
+		{"3 working days", 22},
+		{"+2bd", 21},
+		{"-2 business days", 14},
+		{"next business day", 17},
+		{"Next Working Day", 17},
+	}
+	for _, tt := range tests {
+		got, err := ParseRelativeTimeIn(tt.input, now, cal)
+		if err != nil {
+			t.Errorf("ParseRelativeTimeIn(%q) error = %v", tt.input, err)
+			continue
+		}
+		if got.Day() != tt.wantDay || got.Hour() != 10 {
+			t.Errorf("ParseRelativeTimeIn(%q) = %v, want Jan %d 10:00", tt.input, got, tt.wantDay)
+		}
+	}
+
+	if _, err := ParseRelativeTimeIn("3 business", now, cal); err == nil {
+		t.Error("expected error for incomplete business-day offset")
+	}
+}
@@ -1,7 +1,7 @@
 // Package timeparsing provides layered time parsing for relative date/time expressions.
 //
 // The parsing follows a layered architecture (ADR-001):
-//  1. Compact duration (+6h, -1d, +2w)
+//  1. Compact duration (+6h, -1d, +2w) and business days (+3 business days)
 //  2. Natural language (tomorrow, next monday)
 //  3. Absolute timestamp (RFC3339, date-only)
 package timeparsing
@@ -10,11 +10,13 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/olebedev/when"
 	"github.com/olebedev/when/rules/common"
 	"github.com/olebedev/when/rules/en"
+	"github.com/steveyegge/beads/internal/calendar"
 )
 
 // compactDurationRe matches compact duration patterns: [+-]?(\d+)([hdwmy])
@@ -88,6 +90,31 @@ func isCompactDuration(s string) bool {
 	return compactDurationRe.MatchString(s)
 }
 
+// businessDaysRe matches business-day offsets: "+3 business days",
+// "2 working days", "-1 business day", "+3bd".
+var businessDaysRe = regexp.MustCompile(`^([+-]?)\s*(\d+)\s*(?:bd|(?:business|working)\s+days?)$`)
+
+// parseBusinessDays parses a business-day offset, or "next business day",
+// counting working days on cal and keeping the time of day.
+func parseBusinessDays(s string, now time.Time, cal *calendar.Calendar) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "next business day" || s == "next working day" {
+		return cal.AddBusinessDays(now, 1), nil
+	}
+	matches := businessDaysRe.FindStringSubmatch(s)
+	if matches == nil {
+		return time.Time{}, fmt.Errorf("not a business-day offset: %q", s)
+	}
+	amount, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid business-day amount: %q", matches[2])
+	}
+	if matches[1] == "-" {
+		amount = -amount
+	}
+	return cal.AddBusinessDays(now, amount), nil
+}
+
 // nlpParser is the singleton natural language parser (olebedev/when).
 // Initialized lazily on first use.
 var nlpParser *when.Parser
@@ -132,19 +159,30 @@ func parseNaturalLanguage(s string, now time.Time) (time.Time, error) {
 // dateOnlyRe matches date-only format YYYY-MM-DD to avoid NLP misinterpretation.
 var dateOnlyRe = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
 
-// ParseRelativeTime parses a time expression using the layered architecture (ADR-001).
+// ParseRelativeTime parses a time expression using the layered architecture
+// (ADR-001), counting business days on the default Monday-to-Friday calendar.
+// See ParseRelativeTimeIn.
+func ParseRelativeTime(s string, now time.Time) (time.Time, error) {
+	return ParseRelativeTimeIn(s, now, calendar.Default())
+}
+
+// ParseRelativeTimeIn parses a time expression using the layered architecture
+// (ADR-001), counting business days on cal.
 //
 // Parsing order:
-//  1. Compact duration (+6h, -1d, +2w)
+//  1. Compact duration (+6h, -1d, +2w) and business days (+3 business days)
 //  2. Absolute formats (date-only, RFC3339) - checked before NLP to avoid misinterpretation
 //  3. Natural language (tomorrow, next monday)
 //
 // Returns the parsed time or an error if no layer could parse the input.
-func ParseRelativeTime(s string, now time.Time) (time.Time, error) {
+func ParseRelativeTimeIn(s string, now time.Time, cal *calendar.Calendar) (time.Time, error) {
 	// Layer 1: Compact duration
 	if t, err := ParseCompactDuration(s, now); err == nil {
 		return t, nil
 	}
+	if t, err := parseBusinessDays(s, now, cal); err == nil {
+		return t, nil
+	}
 
 	// Layer 2: Absolute formats (must be checked before NLP to avoid misinterpretation)
 	// NLP parser can incorrectly parse "2025-02-01" as a time, so we check date formats first.
@@ -176,5 +214,5 @@ func ParseRelativeTime(s string, now time.Time) (time.Time, error) {
 		return t, nil
 	}
 
-	return time.Time{}, fmt.Errorf("cannot parse time expression: %q (examples: +6h, tomorrow, 2025-01-15)", s)
+	return time.Time{}, fmt.Errorf("cannot parse time expression: %q (examples: +6h, +3 business days, tomorrow, 2025-01-15)", s)
 }
@@ -73,6 +73,14 @@ type Engine struct {
 	// stateCache holds the opaque value from PushHooks.BuildStateCache during a push.
 	// Tracker adapters access it via ResolveState().
 	stateCache interface{}
+
+	// recordKeys is whether synced issues get their tracker keys as aliases
+	// (<prefix>.external_keys, default true).
+	recordKeys bool
+
+	// queued holds the unsettled conflicts with this tracker, open or
+	// waiting to be pushed, by issue ID.
+	queued map[string]*types.SyncConflict
 }
 
 // NewEngine creates a new sync engine for the given tracker and storage.
@@ -95,10 +103,28 @@ func (e *Engine) Sync(ctx context.Context, opts SyncOptions) (*SyncResult, error
 		opts.Push = true
 	}
 
+	keysSetting, _ := e.Store.GetConfig(ctx, e.Tracker.ConfigPrefix()+".external_keys")
+	e.recordKeys = keysSetting != "false"
+
 	// Track IDs to skip/force during push based on conflict resolution
 	skipPushIDs := make(map[string]bool)
 	forcePushIDs := make(map[string]bool)
 
+	// Issues with open queued conflicts are left alone until resolved, whatever
+	// the strategy; those resolved in favor of the local version are pushed.
+	if err := e.loadQueuedConflicts(ctx); err != nil {
+		result.Success = false
+		result.Error = fmt.Sprintf("loading queued conflicts: %v", err)
+		return result, err
+	}
+	for id, c := range e.queued {
+		if c.PendingPush {
+			forcePushIDs[id] = true
+		} else {
+			skipPushIDs[id] = true
+		}
+	}
+
 	// Phase 1: Pull
 	if opts.Pull {
 		pullStats, err := e.doPull(ctx, opts)
@@ -120,7 +146,7 @@ func (e *Engine) Sync(ctx context.Context, opts SyncOptions) (*SyncResult, error
 			e.warn("Failed to detect conflicts: %v", err)
 		} else if len(conflicts) > 0 {
 			result.Stats.Conflicts = len(conflicts)
-			e.resolveConflicts(ctx, opts, conflicts, skipPushIDs, forcePushIDs)
+			result.Stats.Queued = e.resolveConflicts(ctx, opts, conflicts, skipPushIDs, forcePushIDs)
 		}
 	}
 
@@ -237,100 +263,149 @@ func (e *Engine) doPull(ctx context.Context, opts SyncOptions) (*PullStats, erro
 
 	e.msg("Fetched %d issues from %s", len(extIssues), e.Tracker.DisplayName())
 
-	mapper := e.Tracker.FieldMapper()
 	var pendingDeps []DependencyInfo
+	for i := range extIssues {
+		pendingDeps = append(pendingDeps, e.pullIssue(ctx, &extIssues[i], opts, lastSync, stats)...)
+	}
 
-	for _, extIssue := range extIssues {
-		// ShouldImport hook: filter before conversion
-		if e.PullHooks != nil && e.PullHooks.ShouldImport != nil {
-			if !e.PullHooks.ShouldImport(&extIssue) {
-				stats.Skipped++
-				continue
-			}
-		}
+	// Create dependencies after all issues are imported
+	e.createDependencies(ctx, pendingDeps)
 
-		if opts.DryRun {
-			e.msg("[dry-run] Would import: %s - %s", extIssue.Identifier, extIssue.Title)
-			stats.Created++
-			continue
+	return stats, nil
+}
+
+// PullIssues imports issues the caller already has, such as those named by
+// a webhook, the way Sync's pull phase would. It neither pushes nor moves
+// the last_sync cursor, so the next Sync still fetches everything changed
+// since the previous one.
+func (e *Engine) PullIssues(ctx context.Context, extIssues []TrackerIssue) (*PullStats, error) {
+	keysSetting, _ := e.Store.GetConfig(ctx, e.Tracker.ConfigPrefix()+".external_keys")
+	e.recordKeys = keysSetting != "false"
+	if err := e.loadQueuedConflicts(ctx); err != nil {
+		return nil, fmt.Errorf("loading queued conflicts: %w", err)
+	}
+	var lastSync *time.Time
+	if lastSyncStr, err := e.Store.GetConfig(ctx, e.Tracker.ConfigPrefix()+".last_sync"); err == nil && lastSyncStr != "" {
+		if t, err := time.Parse(time.RFC3339, lastSyncStr); err == nil {
+			lastSync = &t
 		}
+	}
 
-		// Check if we already have this issue
-		ref := e.Tracker.BuildExternalRef(&extIssue)
-		existing, _ := e.Store.GetIssueByExternalRef(ctx, ref)
+	stats := &PullStats{}
+	var pendingDeps []DependencyInfo
+	for i := range extIssues {
+		pendingDeps = append(pendingDeps, e.pullIssue(ctx, &extIssues[i], SyncOptions{}, lastSync, stats)...)
+	}
+	e.createDependencies(ctx, pendingDeps)
+	return stats, nil
+}
 
-		conv := mapper.IssueToBeads(&extIssue)
-		if conv == nil || conv.Issue == nil {
+// pullIssue imports one external issue, creating or updating its beads
+// counterpart, and returns the dependencies to create once every issue is
+// in. Issues changed locally since lastSync are left for conflict detection.
+func (e *Engine) pullIssue(ctx context.Context, extIssue *TrackerIssue, opts SyncOptions, lastSync *time.Time, stats *PullStats) []DependencyInfo {
+	mapper := e.Tracker.FieldMapper()
+
+	// ShouldImport hook: filter before conversion
+	if e.PullHooks != nil && e.PullHooks.ShouldImport != nil {
+		if !e.PullHooks.ShouldImport(extIssue) {
 			stats.Skipped++
-			continue
+			return nil
 		}
+	}
+
+	if opts.DryRun {
+		e.msg("[dry-run] Would import: %s - %s", extIssue.Identifier, extIssue.Title)
+		stats.Created++
+		return nil
+	}
+
+	// Check if we already have this issue
+	ref := e.Tracker.BuildExternalRef(extIssue)
+	existing, _ := e.Store.GetIssueByExternalRef(ctx, ref)
+
+	conv := mapper.IssueToBeads(extIssue)
+	if conv == nil || conv.Issue == nil {
+		stats.Skipped++
+		return nil
+	}
 
-		// TransformIssue hook: description formatting, field normalization
-		if e.PullHooks != nil && e.PullHooks.TransformIssue != nil {
-			e.PullHooks.TransformIssue(conv.Issue)
+	// TransformIssue hook: description formatting, field normalization
+	if e.PullHooks != nil && e.PullHooks.TransformIssue != nil {
+		e.PullHooks.TransformIssue(conv.Issue)
+	}
+
+	// GenerateID hook: hash-based ID generation
+	if e.PullHooks != nil && e.PullHooks.GenerateID != nil {
+		if err := e.PullHooks.GenerateID(ctx, conv.Issue); err != nil {
+			e.warn("Failed to generate ID for %s: %v", extIssue.Identifier, err)
+			stats.Skipped++
+			return nil
 		}
+	}
 
-		// GenerateID hook: hash-based ID generation
-		if e.PullHooks != nil && e.PullHooks.GenerateID != nil {
-			if err := e.PullHooks.GenerateID(ctx, conv.Issue); err != nil {
-				e.warn("Failed to generate ID for %s: %v", extIssue.Identifier, err)
-				stats.Skipped++
-				continue
+	if existing != nil {
+		// Queued conflicts keep the local version until resolved; an
+		// open one gets the tracker's latest version for its diff.
+		if c := e.queued[existing.ID]; c != nil {
+			if c.Resolution == "" {
+				c.Local, c.External = syncedFields(existing), syncedFields(conv.Issue)
+				c.LocalUpdated, c.ExternalUpdated = existing.UpdatedAt, extIssue.UpdatedAt
+				if err := e.Store.RecordSyncConflict(ctx, c, e.Actor); err != nil {
+					e.warn("Failed to update queued conflict on %s: %v", existing.ID, err)
+				}
 			}
+			stats.Skipped++
+			return nil
 		}
 
-		if existing != nil {
-			// Conflict-aware pull: skip updating issues that were locally
-			// modified since last sync. Conflict detection (Phase 2) will
-			// handle these per the configured resolution strategy.
-			// Without this guard, pull silently overwrites local changes
-			// before conflict detection can compare timestamps.
-			if lastSync != nil && existing.UpdatedAt.After(*lastSync) {
-				stats.Skipped++
-				continue
-			}
+		// Conflict-aware pull: skip updating issues that were locally
+		// modified since last sync. Conflict detection (Phase 2) will
+		// handle these per the configured resolution strategy.
+		// Without this guard, pull silently overwrites local changes
+		// before conflict detection can compare timestamps.
+		if lastSync != nil && existing.UpdatedAt.After(*lastSync) {
+			stats.Skipped++
+			return nil
+		}
 
-			// Update existing issue
-			updates := make(map[string]interface{})
-			updates["title"] = conv.Issue.Title
-			updates["description"] = conv.Issue.Description
-			updates["priority"] = conv.Issue.Priority
-			updates["status"] = string(conv.Issue.Status)
-
-			// Preserve metadata from tracker
-			if extIssue.Metadata != nil {
-				if raw, err := json.Marshal(extIssue.Metadata); err == nil {
-					updates["metadata"] = json.RawMessage(raw)
-				}
+		// Update existing issue
+		updates := make(map[string]interface{})
+		updates["title"] = conv.Issue.Title
+		updates["description"] = conv.Issue.Description
+		updates["priority"] = conv.Issue.Priority
+		updates["status"] = string(conv.Issue.Status)
+
+		// Preserve metadata from tracker
+		if extIssue.Metadata != nil {
+			if raw, err := json.Marshal(extIssue.Metadata); err == nil {
+				updates["metadata"] = json.RawMessage(raw)
 			}
+		}
 
-			if err := e.Store.UpdateIssue(ctx, existing.ID, updates, e.Actor); err != nil {
-				e.warn("Failed to update %s: %v", existing.ID, err)
-				continue
-			}
-			stats.Updated++
-		} else {
-			// Create new issue
-			conv.Issue.ExternalRef = strPtr(ref)
-			if extIssue.Metadata != nil {
-				if raw, err := json.Marshal(extIssue.Metadata); err == nil {
-					conv.Issue.Metadata = json.RawMessage(raw)
-				}
-			}
-			if err := e.Store.CreateIssue(ctx, conv.Issue, e.Actor); err != nil {
-				e.warn("Failed to create issue for %s: %v", extIssue.Identifier, err)
-				continue
+		if err := e.Store.UpdateIssue(ctx, existing.ID, updates, e.Actor); err != nil {
+			e.warn("Failed to update %s: %v", existing.ID, err)
+			return nil
+		}
+		e.recordExternalKey(ctx, existing.ID, extIssue)
+		stats.Updated++
+	} else {
+		// Create new issue
+		conv.Issue.ExternalRef = strPtr(ref)
+		if extIssue.Metadata != nil {
+			if raw, err := json.Marshal(extIssue.Metadata); err == nil {
+				conv.Issue.Metadata = json.RawMessage(raw)
 			}
-			stats.Created++
 		}
-
-		pendingDeps = append(pendingDeps, conv.Dependencies...)
+		if err := e.Store.CreateIssue(ctx, conv.Issue, e.Actor); err != nil {
+			e.warn("Failed to create issue for %s: %v", extIssue.Identifier, err)
+			return nil
+		}
+		e.recordExternalKey(ctx, conv.Issue.ID, extIssue)
+		stats.Created++
 	}
 
-	// Create dependencies after all issues are imported
-	e.createDependencies(ctx, pendingDeps)
-
-	return stats, nil
+	return conv.Dependencies
 }
 
 // doPush exports beads issues to the external tracker.
@@ -412,6 +487,7 @@ func (e *Engine) doPush(ctx context.Context, opts SyncOptions, skipIDs, forceIDs
 			if err := e.Store.UpdateIssue(ctx, issue.ID, updates, e.Actor); err != nil {
 				e.warn("Failed to update external_ref for %s: %v", issue.ID, err)
 			}
+			e.recordExternalKey(ctx, issue.ID, created)
 			stats.Created++
 		} else if !opts.CreateOnly || forceIDs[issue.ID] {
 			// Update existing external issue
@@ -443,6 +519,11 @@ func (e *Engine) doPush(ctx context.Context, opts SyncOptions, skipIDs, forceIDs
 				stats.Errors++
 				continue
 			}
+			if c := e.queued[issue.ID]; c != nil && c.PendingPush {
+				if err := e.Store.MarkSyncConflictPushed(ctx, c.ID, e.Actor); err != nil {
+					e.warn("Failed to settle conflict on %s: %v", issue.ID, err)
+				}
+			}
 			stats.Updated++
 		} else {
 			stats.Skipped++
@@ -452,10 +533,35 @@ func (e *Engine) doPush(ctx context.Context, opts SyncOptions, skipIDs, forceIDs
 	return stats, nil
 }
 
-// resolveConflicts applies the configured conflict resolution strategy.
-func (e *Engine) resolveConflicts(ctx context.Context, opts SyncOptions, conflicts []Conflict, skipIDs, forceIDs map[string]bool) {
+// resolveConflicts applies the configured conflict resolution strategy and
+// returns how many conflicts it queued.
+func (e *Engine) resolveConflicts(ctx context.Context, opts SyncOptions, conflicts []Conflict, skipIDs, forceIDs map[string]bool) int {
+	queued := 0
 	for _, c := range conflicts {
+		// Already queued: the resolution recorded there applies.
+		if q := e.queued[c.IssueID]; q != nil {
+			if q.Resolution == "" && !opts.DryRun {
+				if err := e.queueConflict(ctx, c); err != nil {
+					e.warn("Failed to update queued conflict on %s: %v", c.IssueID, err)
+				}
+			}
+			continue
+		}
+
 		switch opts.ConflictResolution {
+		case ConflictQueue:
+			skipIDs[c.IssueID] = true
+			if opts.DryRun {
+				e.msg("[dry-run] Would queue conflict on %s", c.IssueID)
+				continue
+			}
+			if err := e.queueConflict(ctx, c); err != nil {
+				e.warn("Failed to queue conflict on %s, leaving both versions: %v", c.IssueID, err)
+				continue
+			}
+			queued++
+			e.msg("Conflict on %s: queued, see bd sync conflicts list", c.IssueID)
+
 		case ConflictLocal:
 			forceIDs[c.IssueID] = true
 			e.msg("Conflict on %s: keeping local version", c.IssueID)
@@ -476,6 +582,82 @@ func (e *Engine) resolveConflicts(ctx context.Context, opts SyncOptions, conflic
 			}
 		}
 	}
+	return queued
+}
+
+// loadQueuedConflicts loads the unsettled conflicts with this tracker.
+func (e *Engine) loadQueuedConflicts(ctx context.Context) error {
+	conflicts, err := e.Store.ListSyncConflicts(ctx, e.Tracker.Name(), false)
+	if err != nil {
+		return err
+	}
+	e.queued = make(map[string]*types.SyncConflict, len(conflicts))
+	for _, c := range conflicts {
+		e.queued[c.IssueID] = c
+	}
+	return nil
+}
+
+// queueConflict records c, with both versions of its synced fields, for
+// someone to resolve with bd sync conflicts.
+func (e *Engine) queueConflict(ctx context.Context, c Conflict) error {
+	local, err := e.Store.GetIssue(ctx, c.IssueID)
+	if err != nil {
+		return err
+	}
+	extIssue, err := e.Tracker.FetchIssue(ctx, c.ExternalIdentifier)
+	if err != nil {
+		return err
+	}
+	if extIssue == nil {
+		return fmt.Errorf("%s not found in %s", c.ExternalIdentifier, e.Tracker.DisplayName())
+	}
+	conv := e.Tracker.FieldMapper().IssueToBeads(extIssue)
+	if conv == nil || conv.Issue == nil {
+		return fmt.Errorf("cannot convert %s", c.ExternalIdentifier)
+	}
+	if e.PullHooks != nil && e.PullHooks.TransformIssue != nil {
+		e.PullHooks.TransformIssue(conv.Issue)
+	}
+
+	return e.Store.RecordSyncConflict(ctx, &types.SyncConflict{
+		IssueID:         c.IssueID,
+		Tracker:         e.Tracker.Name(),
+		ExternalRef:     c.ExternalRef,
+		ExternalID:      c.ExternalIdentifier,
+		Local:           syncedFields(local),
+		External:        syncedFields(conv.Issue),
+		LocalUpdated:    c.LocalUpdated,
+		ExternalUpdated: c.ExternalUpdated,
+	}, e.Actor)
+}
+
+// syncedFields returns the fields of issue that syncs update.
+func syncedFields(issue *types.Issue) types.SyncedFields {
+	return types.SyncedFields{
+		Title:       issue.Title,
+		Description: issue.Description,
+		Priority:    issue.Priority,
+		Status:      issue.Status,
+	}
+}
+
+// recordExternalKey makes the tracker's key for extIssue (e.g. JIRA-123)
+// an alias of issueID, so commands accept it in place of the beads ID.
+func (e *Engine) recordExternalKey(ctx context.Context, issueID string, extIssue *TrackerIssue) {
+	if !e.recordKeys {
+		return
+	}
+	key := extIssue.Identifier
+	if k, ok := e.Tracker.(ExternalKeyer); ok {
+		key = k.ExternalKey(extIssue)
+	}
+	if key == "" {
+		return
+	}
+	if err := e.Store.SetExternalKey(ctx, issueID, key, e.Tracker.Name(), e.Actor); err != nil {
+		e.warn("Failed to record %s as an alias of %s: %v", key, issueID, err)
+	}
 }
 
 // reimportIssue fetches the external version and updates the local issue.
@@ -180,6 +180,96 @@ func TestEnginePullOnly(t *testing.T) {
 	}
 }
 
+func TestEnginePullIssues(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	defer store.Close()
+
+	tracker := newMockTracker("test")
+	engine := NewEngine(tracker, store, "test-actor")
+
+	issue := TrackerIssue{ID: "1", Identifier: "TEST-1", Title: "First issue", UpdatedAt: time.Now()}
+	stats, err := engine.PullIssues(ctx, []TrackerIssue{issue})
+	if err != nil {
+		t.Fatalf("PullIssues() error: %v", err)
+	}
+	if stats.Created != 1 {
+		t.Errorf("Created = %d, want 1", stats.Created)
+	}
+
+	issue.Title = "Retitled in tracker"
+	stats, err = engine.PullIssues(ctx, []TrackerIssue{issue})
+	if err != nil {
+		t.Fatalf("PullIssues() error: %v", err)
+	}
+	if stats.Updated != 1 {
+		t.Errorf("Updated = %d, want 1", stats.Updated)
+	}
+	issues, err := store.SearchIssues(ctx, "", types.IssueFilter{})
+	if err != nil {
+		t.Fatalf("SearchIssues() error: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Title != "Retitled in tracker" {
+		t.Errorf("stored issues = %+v, want the retitled one", issues)
+	}
+
+	// Only Sync moves the cursor.
+	if lastSync, _ := store.GetConfig(ctx, "test.last_sync"); lastSync != "" {
+		t.Errorf("last_sync = %q, want unset", lastSync)
+	}
+}
+
+func TestEnginePullRecordsExternalKeys(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	defer store.Close()
+
+	tracker := newMockTracker("test")
+	tracker.issues = []TrackerIssue{
+		{ID: "1", Identifier: "TEST-1", Title: "First issue", UpdatedAt: time.Now()},
+	}
+
+	engine := NewEngine(tracker, store, "test-actor")
+	if _, err := engine.Sync(ctx, SyncOptions{Pull: true}); err != nil {
+		t.Fatalf("Sync() error: %v", err)
+	}
+
+	id, err := store.ResolveExternalKey(ctx, "TEST-1")
+	if err != nil {
+		t.Fatalf("ResolveExternalKey() error: %v", err)
+	}
+	issue, err := store.GetIssue(ctx, id)
+	if err != nil || issue.Title != "First issue" {
+		t.Errorf("TEST-1 resolves to %s (%v)", id, err)
+	}
+	keys, _ := store.GetExternalKeys(ctx, id)
+	if len(keys) != 1 || keys[0].Source != "test" {
+		t.Errorf("keys = %+v, want one from source test", keys)
+	}
+}
+
+func TestEnginePullExternalKeysDisabled(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	defer store.Close()
+	if err := store.SetConfig(ctx, "test.external_keys", "false"); err != nil {
+		t.Fatalf("SetConfig() error: %v", err)
+	}
+
+	tracker := newMockTracker("test")
+	tracker.issues = []TrackerIssue{
+		{ID: "1", Identifier: "TEST-1", Title: "First issue", UpdatedAt: time.Now()},
+	}
+
+	engine := NewEngine(tracker, store, "test-actor")
+	if _, err := engine.Sync(ctx, SyncOptions{Pull: true}); err != nil {
+		t.Fatalf("Sync() error: %v", err)
+	}
+	if id, err := store.ResolveExternalKey(ctx, "TEST-1"); err == nil {
+		t.Errorf("TEST-1 resolves to %s with external_keys disabled", id)
+	}
+}
+
 func TestEnginePushOnly(t *testing.T) {
 	ctx := context.Background()
 	store := newTestStore(t)
@@ -341,6 +431,72 @@ func TestEngineConflictResolution(t *testing.T) {
 	}
 }
 
+func TestEngineQueuesConflicts(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+	defer store.Close()
+
+	lastSync := time.Now().UTC().Add(-1 * time.Hour)
+	if err := store.SetConfig(ctx, "test.last_sync", lastSync.Format(time.RFC3339)); err != nil {
+		t.Fatalf("SetConfig() error: %v", err)
+	}
+	issue := &types.Issue{
+		ID:          "bd-conflict1",
+		Title:       "Local version",
+		Status:      types.StatusOpen,
+		IssueType:   types.TypeTask,
+		Priority:    2,
+		ExternalRef: strPtr("https://test.test/EXT-1"),
+		UpdatedAt:   time.Now().UTC().Add(-30 * time.Minute),
+	}
+	if err := store.CreateIssue(ctx, issue, "test-actor"); err != nil {
+		t.Fatalf("CreateIssue() error: %v", err)
+	}
+
+	tracker := newMockTracker("test")
+	tracker.issues = []TrackerIssue{
+		{ID: "EXT-1", Identifier: "EXT-1", Title: "External version", UpdatedAt: time.Now().UTC().Add(-15 * time.Minute)},
+	}
+	engine := NewEngine(tracker, store, "test-actor")
+
+	result, err := engine.Sync(ctx, SyncOptions{ConflictResolution: ConflictQueue})
+	if err != nil {
+		t.Fatalf("Sync() error: %v", err)
+	}
+	if result.Stats.Queued != 1 {
+		t.Errorf("Queued = %d, want 1", result.Stats.Queued)
+	}
+	if got, _ := store.GetIssue(ctx, "bd-conflict1"); got.Title != "Local version" {
+		t.Errorf("local title = %q, want it kept", got.Title)
+	}
+	if len(tracker.updated) != 0 {
+		t.Errorf("pushed %v while the conflict is open", tracker.updated)
+	}
+
+	conflicts, err := store.ListSyncConflicts(ctx, "test", false)
+	if err != nil || len(conflicts) != 1 {
+		t.Fatalf("ListSyncConflicts() = %v, %v; want one conflict", conflicts, err)
+	}
+	c := conflicts[0]
+	if c.IssueID != "bd-conflict1" || c.Local.Title != "Local version" || c.External.Title != "External version" {
+		t.Errorf("conflict = %+v", c)
+	}
+
+	// Resolved for the local version, the next sync pushes it and settles it.
+	if err := store.ResolveSyncConflict(ctx, c.ID, types.SyncKeepLocal, "test-actor"); err != nil {
+		t.Fatalf("ResolveSyncConflict() error: %v", err)
+	}
+	if _, err := engine.Sync(ctx, SyncOptions{ConflictResolution: ConflictQueue}); err != nil {
+		t.Fatalf("Sync() error: %v", err)
+	}
+	if pushed := tracker.updated["EXT-1"]; pushed == nil || pushed.Title != "Local version" {
+		t.Errorf("pushed %+v, want the local version", pushed)
+	}
+	if open, _ := store.ListSyncConflicts(ctx, "test", false); len(open) != 0 {
+		t.Errorf("unsettled conflicts after push: %+v", open)
+	}
+}
+
 func TestEnginePullWithShouldImport(t *testing.T) {
 	ctx := context.Background()
 	store := newTestStore(t)
@@ -0,0 +1,140 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/storage"
+)
+
+// Failed scheduled syncs are retried with exponential backoff between these
+// bounds, jittered by ±20% so machines syncing the same tracker spread out.
+const (
+	minSyncBackoff = time.Minute
+	maxSyncBackoff = time.Hour
+	syncJitter     = 0.2
+)
+
+// RateLimitError reports that a tracker kept rejecting requests for
+// exceeding its rate limit. RetryAfter is how long it asked callers to
+// wait, or zero if it didn't say.
+type RateLimitError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%v (retry after %v)", e.Err, e.RetryAfter)
+	}
+	return e.Err.Error()
+}
+
+func (e *RateLimitError) Unwrap() error { return e.Err }
+
+// ParseRetryAfter converts a Retry-After header, given in seconds or as an
+// HTTP date, into a wait from now. Missing or malformed values give zero.
+func ParseRetryAfter(header string, now time.Time) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(header); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// SyncState is the schedule of a tracker's unattended syncs (bd sync run),
+// kept as JSON in the <prefix>.sync_state config key. The incremental
+// cursor stays in <prefix>.last_sync, which Sync maintains.
+type SyncState struct {
+	LastAttempt time.Time  `json:"last_attempt"`
+	LastSuccess time.Time  `json:"last_success"`
+	NextAt      time.Time  `json:"next_at"`
+	Failures    int        `json:"failures"` // Consecutive failed syncs
+	LastError   string     `json:"last_error,omitempty"`
+	LastStats   *SyncStats `json:"last_stats,omitempty"`
+}
+
+// Due reports whether the next scheduled sync should run at now.
+func (s *SyncState) Due(now time.Time) bool {
+	return !now.Before(s.NextAt)
+}
+
+// Record notes the outcome of a sync attempted at now and schedules the
+// next one: an interval later after a success, or after a failure, a
+// backoff that doubles with each consecutive failure and never undercuts
+// a rate limit's Retry-After. jitter is a random number in [0, 1).
+func (s *SyncState) Record(now time.Time, interval time.Duration, result *SyncResult, err error, jitter float64) {
+	s.LastAttempt = now
+	if result != nil {
+		stats := result.Stats
+		s.LastStats = &stats
+	}
+	if err == nil {
+		s.LastSuccess = now
+		s.Failures = 0
+		s.LastError = ""
+		s.NextAt = now.Add(interval)
+		return
+	}
+	s.Failures++
+	s.LastError = err.Error()
+	s.NextAt = now.Add(SyncBackoff(s.Failures, err, jitter))
+}
+
+// SyncBackoff returns how long to wait after the given number of
+// consecutive failed syncs, the last of which failed with err.
+func SyncBackoff(failures int, err error, jitter float64) time.Duration {
+	backoff := maxSyncBackoff
+	if failures < 8 {
+		backoff = min(minSyncBackoff<<max(failures-1, 0), maxSyncBackoff)
+	}
+	backoff = time.Duration(float64(backoff) * (1 - syncJitter + 2*syncJitter*jitter))
+
+	var rateLimited *RateLimitError
+	if errors.As(err, &rateLimited) && rateLimited.RetryAfter > backoff {
+		backoff = rateLimited.RetryAfter
+	}
+	return backoff
+}
+
+// LoadSyncState reads the scheduled sync state for the tracker with the
+// given config prefix. A tracker never synced on a schedule is due now.
+func LoadSyncState(ctx context.Context, store storage.Storage, prefix string) (*SyncState, error) {
+	state := &SyncState{}
+	raw, err := store.GetConfig(ctx, prefix+".sync_state")
+	if err != nil {
+		return nil, fmt.Errorf("reading %s.sync_state: %w", prefix, err)
+	}
+	if raw == "" {
+		return state, nil
+	}
+	if err := json.Unmarshal([]byte(raw), state); err != nil {
+		return nil, fmt.Errorf("invalid %s.sync_state: %w", prefix, err)
+	}
+	return state, nil
+}
+
+// SaveSyncState stores the scheduled sync state for the tracker with the
+// given config prefix.
+func SaveSyncState(ctx context.Context, store storage.Storage, prefix string, state *SyncState) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encoding %s.sync_state: %w", prefix, err)
+	}
+	if err := store.SetConfig(ctx, prefix+".sync_state", string(raw)); err != nil {
+		return fmt.Errorf("writing %s.sync_state: %w", prefix, err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"30", 30 * time.Second},
+		{" 120 ", 2 * time.Minute},
+		{"-5", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := ParseRetryAfter(tt.header, now); got != tt.want {
+			t.Errorf("ParseRetryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestSyncBackoff(t *testing.T) {
+	failed := errors.New("connection refused")
+	tests := []struct {
+		failures int
+		err      error
+		jitter   float64
+		want     time.Duration
+	}{
+		{1, failed, 0.5, time.Minute},
+		{2, failed, 0.5, 2 * time.Minute},
+		{4, failed, 0.5, 8 * time.Minute},
+		{7, failed, 0.5, time.Hour},
+		{40, failed, 0.5, time.Hour},
+		{1, failed, 0, 48 * time.Second},
+		{1, failed, 1, 72 * time.Second},
+		{1, &RateLimitError{RetryAfter: 10 * time.Minute, Err: failed}, 0.5, 10 * time.Minute},
+		{6, fmt.Errorf("pull failed: %w", &RateLimitError{RetryAfter: 10 * time.Minute, Err: failed}), 0.5, 32 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := SyncBackoff(tt.failures, tt.err, tt.jitter); got != tt.want {
+			t.Errorf("SyncBackoff(%d, %v, %v) = %v, want %v", tt.failures, tt.err, tt.jitter, got, tt.want)
+		}
+	}
+}
+
+func TestSyncStateRecord(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	state := &SyncState{}
+	if !state.Due(now) {
+		t.Fatal("a tracker never synced should be due")
+	}
+
+	state.Record(now, 15*time.Minute, nil, errors.New("boom"), 0.5)
+	state.Record(now, 15*time.Minute, nil, errors.New("boom again"), 0.5)
+	if state.Failures != 2 || state.LastError != "boom again" || !state.NextAt.Equal(now.Add(2*time.Minute)) {
+		t.Fatalf("after two failures: %+v", state)
+	}
+	if state.Due(now.Add(time.Minute)) || !state.Due(now.Add(2*time.Minute)) {
+		t.Errorf("Due around NextAt %v is wrong", state.NextAt)
+	}
+
+	later := now.Add(5 * time.Minute)
+	state.Record(later, 15*time.Minute, &SyncResult{Success: true, Stats: SyncStats{Pulled: 3}}, nil, 0.5)
+	if state.Failures != 0 || state.LastError != "" || !state.LastSuccess.Equal(later) ||
+		!state.NextAt.Equal(later.Add(15*time.Minute)) || state.LastStats == nil || state.LastStats.Pulled != 3 {
+		t.Errorf("after a success: %+v", state)
+	}
+}
@@ -0,0 +1,170 @@
+package tracker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// StatusDirection limits which way a StatusRule applies.
+type StatusDirection string
+
+const (
+	StatusBoth StatusDirection = "both" // Pull and push
+	StatusPull StatusDirection = "pull" // External state -> beads status only
+	StatusPush StatusDirection = "push" // Beads status -> external state only
+)
+
+// StatusRule maps one external state to a beads status.
+type StatusRule struct {
+	State     string // External state; see SameState
+	Status    types.Status
+	Direction StatusDirection
+}
+
+func (r StatusRule) pulls() bool  { return r.Direction != StatusPush }
+func (r StatusRule) pushes() bool { return r.Direction != StatusPull }
+
+// ParseStatusRule parses a status_map config value for state:
+// "<status>" or "<status>:<direction>", e.g. "closed:pull".
+func ParseStatusRule(state, value string) (StatusRule, error) {
+	status, dir, _ := strings.Cut(strings.TrimSpace(value), ":")
+	rule := StatusRule{
+		State:     strings.TrimSpace(state),
+		Status:    types.Status(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(status)), "-", "_")),
+		Direction: StatusDirection(strings.ToLower(strings.TrimSpace(dir))),
+	}
+	if rule.Direction == "" {
+		rule.Direction = StatusBoth
+	}
+	if rule.State == "" {
+		return rule, fmt.Errorf("status_map entry has no state")
+	}
+	if !rule.Status.IsValid() {
+		return rule, fmt.Errorf("status_map.%s: unknown status %q", state, status)
+	}
+	switch rule.Direction {
+	case StatusBoth, StatusPull, StatusPush:
+	default:
+		return rule, fmt.Errorf("status_map.%s: direction must be both, pull, or push, not %q", state, dir)
+	}
+	return rule, nil
+}
+
+// StatusMap maps beads statuses to a tracker's states and back. Rules are
+// kept in precedence order: when several match, the first wins. That
+// decides, for instance, which of "Done" and "Closed" a closed issue is
+// pushed as.
+type StatusMap struct {
+	rules []StatusRule
+}
+
+// NewStatusMap creates a map from rules in precedence order.
+func NewStatusMap(rules ...StatusRule) *StatusMap {
+	return &StatusMap{rules: rules}
+}
+
+// Rules returns the rules in precedence order.
+func (m *StatusMap) Rules() []StatusRule {
+	return append([]StatusRule(nil), m.rules...)
+}
+
+// WithConfig returns m with the rules configured under <prefix>.status_map.
+// in config (as returned by GetAllConfig) taking precedence. A configured
+// state replaces every default rule for that state. Among configured rules,
+// push-only ones come first, so one can pick the state a status is pushed
+// as when several map to it. An entry keyed by a status instead, like
+// jira.status_map.open = "To Do", maps both ways. Invalid entries are
+// skipped and returned as errors.
+func (m *StatusMap) WithConfig(config map[string]string, prefix string) (*StatusMap, []error) {
+	keyPrefix := prefix + ".status_map."
+	var configured []StatusRule
+	var errs []error
+	for key, value := range config {
+		if !strings.HasPrefix(key, keyPrefix) {
+			continue
+		}
+		state := strings.TrimPrefix(key, keyPrefix)
+		rule, err := ParseStatusRule(state, value)
+		if err != nil {
+			// Also accept <status> = <state>, the form older docs showed.
+			if reversed, rerr := ParseStatusRule(value, state); rerr == nil {
+				rule, err = reversed, nil
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", prefix, err))
+			continue
+		}
+		configured = append(configured, rule)
+	}
+	sort.Slice(configured, func(i, j int) bool {
+		pi, pj := configured[i].Direction == StatusPush, configured[j].Direction == StatusPush
+		if pi != pj {
+			return pi
+		}
+		return strings.ToLower(configured[i].State) < strings.ToLower(configured[j].State)
+	})
+	return m.withRules(configured), errs
+}
+
+// withRules returns m with rules first, replacing m's rules for their states.
+func (m *StatusMap) withRules(rules []StatusRule) *StatusMap {
+	if len(rules) == 0 {
+		return m
+	}
+	replaced := make(map[string]bool, len(rules))
+	for _, r := range rules {
+		replaced[stateKey(r.State)] = true
+	}
+	merged := append([]StatusRule(nil), rules...)
+	for _, r := range m.rules {
+		if !replaced[stateKey(r.State)] {
+			merged = append(merged, r)
+		}
+	}
+	return &StatusMap{rules: merged}
+}
+
+// ToBeads returns the beads status for an external state. Trackers that
+// describe a state several ways (a custom name and a category, say) pass
+// them most specific first; the first one with a pull rule decides.
+func (m *StatusMap) ToBeads(states ...string) (types.Status, bool) {
+	for _, state := range states {
+		if state == "" {
+			continue
+		}
+		for _, r := range m.rules {
+			if r.pulls() && SameState(r.State, state) {
+				return r.Status, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ToTracker returns the external state a beads status is pushed as, as
+// configured; compare it to the tracker's states with SameState.
+func (m *StatusMap) ToTracker(status types.Status) (string, bool) {
+	for _, r := range m.rules {
+		if r.pushes() && r.Status == status {
+			return r.State, true
+		}
+	}
+	return "", false
+}
+
+// SameState reports whether two external state names match the way status
+// map rules do.
+func SameState(a, b string) bool {
+	return stateKey(a) == stateKey(b)
+}
+
+// stateKey normalizes a state for matching: case-insensitive, with
+// underscores standing for spaces, since config keys are often written
+// that way (status_map.in_review for "In Review").
+func stateKey(state string) string {
+	return strings.ToLower(strings.ReplaceAll(state, "_", " "))
+}
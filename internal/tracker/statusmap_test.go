@@ -0,0 +1,95 @@
+package tracker
+
+import (
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseStatusRule(t *testing.T) {
+	tests := []struct {
+		state, value string
+		want         StatusRule
+		wantErr      bool
+	}{
+		{"Done", "closed", StatusRule{"Done", types.StatusClosed, StatusBoth}, false},
+		{"Review", " In-Progress : PULL ", StatusRule{"Review", types.StatusInProgress, StatusPull}, false},
+		{"started", "blocked:push", StatusRule{"started", types.StatusBlocked, StatusPush}, false},
+		{"Done", "finished", StatusRule{}, true},
+		{"Done", "closed:sideways", StatusRule{}, true},
+		{" ", "open", StatusRule{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseStatusRule(tt.state, tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseStatusRule(%q, %q) error = %v, wantErr %v", tt.state, tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseStatusRule(%q, %q) = %+v, want %+v", tt.state, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestStatusMap(t *testing.T) {
+	defaults := NewStatusMap(
+		StatusRule{"To Do", types.StatusOpen, StatusBoth},
+		StatusRule{"Backlog", types.StatusOpen, StatusPull},
+		StatusRule{"Done", types.StatusClosed, StatusBoth},
+		StatusRule{"Closed", types.StatusClosed, StatusPull},
+	)
+	m, errs := defaults.WithConfig(map[string]string{
+		"jira.status_map.Closed":    "closed:push", // Replaces the default pull rule
+		"jira.status_map.Review":    "in_progress",
+		"jira.status_map.Wontfix":   "closed:pull",
+		"jira.status_map.Bad":       "nope",
+		"jira.status_map.deferred":  "Later", // Keyed by status
+		"linear.status_map.Ignored": "open",
+	}, "jira")
+	if len(errs) != 1 {
+		t.Errorf("WithConfig errors = %v, want one for Bad", errs)
+	}
+
+	pulls := map[string]types.Status{
+		"to do":   types.StatusOpen,
+		"BACKLOG": types.StatusOpen,
+		"Review":  types.StatusInProgress,
+		"to_do":   types.StatusOpen,
+		"Wontfix": types.StatusClosed,
+		"Done":    types.StatusClosed,
+		"later":   types.StatusDeferred,
+	}
+	for state, want := range pulls {
+		if got, ok := m.ToBeads(state); !ok || got != want {
+			t.Errorf("ToBeads(%q) = %q, %v; want %q", state, got, ok, want)
+		}
+	}
+	for _, state := range []string{"Closed", "Ignored", ""} {
+		if got, ok := m.ToBeads(state); ok {
+			t.Errorf("ToBeads(%q) = %q, want no match", state, got)
+		}
+	}
+	if got, _ := m.ToBeads("Unknown", "Review", "Done"); got != types.StatusInProgress {
+		t.Errorf("ToBeads(candidates) = %q, want the first match, in_progress", got)
+	}
+
+	pushes := map[types.Status]string{
+		types.StatusOpen:       "To Do",
+		types.StatusClosed:     "Closed", // Push-only config comes first
+		types.StatusInProgress: "Review",
+		types.StatusDeferred:   "Later",
+	}
+	for status, want := range pushes {
+		if got, ok := m.ToTracker(status); !ok || got != want {
+			t.Errorf("ToTracker(%q) = %q, %v; want %q", status, got, ok, want)
+		}
+	}
+	if got, ok := m.ToTracker(types.StatusBlocked); ok {
+		t.Errorf("ToTracker(blocked) = %q, want no match", got)
+	}
+
+	// The defaults are left alone.
+	if got, _ := defaults.ToTracker(types.StatusClosed); got != "Done" {
+		t.Errorf("defaults ToTracker(closed) = %q, want Done", got)
+	}
+}
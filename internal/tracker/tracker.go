@@ -58,6 +58,14 @@ type IssueTracker interface {
 	BuildExternalRef(issue *TrackerIssue) string
 }
 
+// ExternalKeyer is implemented by trackers whose identifiers don't name
+// their issues unambiguously on their own (e.g. GitLab's bare "42"). The
+// engine records each synced issue's key as an alias of its beads issue;
+// without ExternalKeyer the key is the issue's Identifier (e.g. "TEAM-123").
+type ExternalKeyer interface {
+	ExternalKey(issue *TrackerIssue) string
+}
+
 // FieldMapper handles bidirectional conversion of issue fields between
 // an external tracker and beads. Each tracker provides its own mapper.
 type FieldMapper interface {
@@ -104,6 +104,7 @@ type SyncStats struct {
 	Skipped   int `json:"skipped"`
 	Errors    int `json:"errors"`
 	Conflicts int `json:"conflicts"`
+	Queued    int `json:"queued"` // Conflicts queued for bd sync conflicts
 }
 
 // PullStats tracks pull operation results.
@@ -143,6 +144,9 @@ const (
 	ConflictLocal ConflictResolution = "local"
 	// ConflictExternal always keeps the external tracker's version.
 	ConflictExternal ConflictResolution = "external"
+	// ConflictQueue keeps both versions as they are and queues the conflict
+	// for someone to resolve with bd sync conflicts.
+	ConflictQueue ConflictResolution = "queue"
 )
 
 // IssueConversion holds the result of converting an external tracker issue to beads.
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -34,6 +36,7 @@ type Issue struct {
 	Assignee         string `json:"assignee,omitempty"`
 	Owner            string `json:"owner,omitempty"` // Human owner for CV attribution (git author email)
 	EstimatedMinutes *int   `json:"estimated_minutes,omitempty"`
+	ActualMinutes    *int   `json:"actual_minutes,omitempty"` // Time spent, from bd time log or --actual
 
 	// ===== Timestamps =====
 	CreatedAt       time.Time  `json:"created_at"`
@@ -68,9 +71,10 @@ type Issue struct {
 	PrefixOverride string `json:"-"` // Completely replace config prefix (for cross-rig creation)
 
 	// ===== Relational Data (populated for export/import) =====
-	Labels       []string      `json:"labels,omitempty"`
-	Dependencies []*Dependency `json:"dependencies,omitempty"`
-	Comments     []*Comment    `json:"comments,omitempty"`
+	Labels       []string       `json:"labels,omitempty"`
+	Dependencies []*Dependency  `json:"dependencies,omitempty"`
+	Comments     []*Comment     `json:"comments,omitempty"`
+	ExternalKeys []*ExternalKey `json:"external_keys,omitempty"`
 
 	// ===== Messaging Fields (inter-agent communication) =====
 	Sender    string   `json:"sender,omitempty"`    // Who sent this (for messages)
@@ -292,6 +296,9 @@ func (i *Issue) ValidateWithCustom(customStatuses, customTypes []string) error {
 	if i.EstimatedMinutes != nil && *i.EstimatedMinutes < 0 {
 		return fmt.Errorf("estimated_minutes cannot be negative")
 	}
+	if i.ActualMinutes != nil && *i.ActualMinutes < 0 {
+		return fmt.Errorf("actual_minutes cannot be negative")
+	}
 	// Enforce closed_at invariant: closed_at should be set if and only if status is closed
 	if i.Status == StatusClosed && i.ClosedAt == nil {
 		return fmt.Errorf("closed issues must have closed_at timestamp")
@@ -340,6 +347,9 @@ func (i *Issue) ValidateForImport(customStatuses []string) error {
 	if i.EstimatedMinutes != nil && *i.EstimatedMinutes < 0 {
 		return fmt.Errorf("estimated_minutes cannot be negative")
 	}
+	if i.ActualMinutes != nil && *i.ActualMinutes < 0 {
+		return fmt.Errorf("actual_minutes cannot be negative")
+	}
 	// Enforce closed_at invariant
 	if i.Status == StatusClosed && i.ClosedAt == nil {
 		return fmt.Errorf("closed issues must have closed_at timestamp")
@@ -648,6 +658,7 @@ type DependencyCounts struct {
 type IssueWithDependencyMetadata struct {
 	Issue
 	DependencyType DependencyType `json:"dependency_type"`
+	Schedule       *ScheduleMeta  `json:"schedule,omitempty"` // Relation and lag of a blocks dependency, if set
 }
 
 // IssueWithCounts extends Issue with dependency relationship counts
@@ -656,6 +667,7 @@ type IssueWithCounts struct {
 	DependencyCount int     `json:"dependency_count"`
 	DependentCount  int     `json:"dependent_count"`
 	CommentCount    int     `json:"comment_count"`
+	VoteCount       int     `json:"vote_count"`
 	Parent          *string `json:"parent,omitempty"` // Computed parent from parent-child dep (bd-ym8c)
 }
 
@@ -667,6 +679,9 @@ type IssueDetails struct {
 	Dependencies []*IssueWithDependencyMetadata `json:"dependencies,omitempty"`
 	Dependents   []*IssueWithDependencyMetadata `json:"dependents,omitempty"`
 	Comments     []*Comment                     `json:"comments,omitempty"`
+	Reactions    []*ReactionSummary             `json:"reactions,omitempty"`
+	Lock         *IssueLock                     `json:"lock,omitempty"`
+	Journal      []*JournalEntry                `json:"journal,omitempty"`
 	Parent       *string                        `json:"parent,omitempty"`
 }
 
@@ -751,6 +766,89 @@ const (
 	WaitsForAnyChildren = "any-children" // Proceed when first child completes (future)
 )
 
+// ScheduleMeta holds scheduling semantics for blocks dependencies.
+// Stored as JSON in the Dependency.Metadata field. The zero value is a plain
+// finish-to-start block: the dependent can start once the blocker closes.
+type ScheduleMeta struct {
+	// Relation: "finish-to-start" (default), "start-to-start", or "finish-to-finish"
+	Relation string `json:"relation,omitempty"`
+	// Lag delays the dependent after the blocker's start or finish, e.g. "2d",
+	// "4h", "1w". A negative lag ("-1d") is a lead.
+	Lag string `json:"lag,omitempty"`
+}
+
+// Schedule relation constants
+const (
+	RelationFinishToStart  = "finish-to-start"  // Dependent starts after blocker finishes
+	RelationStartToStart   = "start-to-start"   // Dependent starts after blocker starts
+	RelationFinishToFinish = "finish-to-finish" // Dependent finishes after blocker finishes
+)
+
+// IsZero reports whether m is a plain finish-to-start block with no lag.
+func (m ScheduleMeta) IsZero() bool {
+	return (m.Relation == "" || m.Relation == RelationFinishToStart) && m.LagDuration() == 0
+}
+
+// RelationOrDefault returns the relation, defaulting to finish-to-start.
+func (m ScheduleMeta) RelationOrDefault() string {
+	if m.Relation == "" {
+		return RelationFinishToStart
+	}
+	return m.Relation
+}
+
+// LagDuration returns the parsed lag, or 0 if it is empty or invalid.
+func (m ScheduleMeta) LagDuration() time.Duration {
+	d, _ := ParseLag(m.Lag)
+	return d
+}
+
+// Validate checks the relation and lag.
+func (m ScheduleMeta) Validate() error {
+	switch m.Relation {
+	case "", RelationFinishToStart, RelationStartToStart, RelationFinishToFinish:
+	default:
+		return fmt.Errorf("invalid relation %q (must be finish-to-start, start-to-start, or finish-to-finish)", m.Relation)
+	}
+	_, err := ParseLag(m.Lag)
+	return err
+}
+
+// ParseScheduleMeta reads the scheduling fields from a dependency's metadata.
+// Metadata that isn't a JSON object yields the zero value.
+func ParseScheduleMeta(metadata string) ScheduleMeta {
+	var m ScheduleMeta
+	if metadata != "" {
+		_ = json.Unmarshal([]byte(metadata), &m)
+	}
+	return m
+}
+
+// ParseLag parses a dependency lag: an optional sign, a number, and a unit
+// of h (hours), d (days), or w (weeks), e.g. "2d", "-4h". Empty is zero.
+func ParseLag(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	m := lagRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid lag %q (use e.g. 2d, 4h, 1w, or -1d for a lead)", s)
+	}
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return 0, fmt.Errorf("invalid lag %q: %w", s, err)
+	}
+	unit := map[string]time.Duration{"h": time.Hour, "d": 24 * time.Hour, "w": 7 * 24 * time.Hour}[m[3]]
+	d := time.Duration(n) * unit
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+var lagRe = regexp.MustCompile(`^([+-]?)(\d+)([hdw])$`)
+
 // AttestsMeta holds metadata for attests dependencies (skill attestations).
 // Stored as JSON in the Dependency.Metadata field.
 // Enables: Entity X attests that Entity Y has skill Z at level N.
@@ -814,6 +912,222 @@ type Comment struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// VoteReaction is the reaction recorded by bd vote. Votes are counted from it.
+const VoteReaction = "+1"
+
+// Reaction is one actor's reaction to an issue. An actor can give each
+// reaction to an issue at most once.
+type Reaction struct {
+	IssueID   string    `json:"issue_id"`
+	Actor     string    `json:"actor"`
+	Reaction  string    `json:"reaction"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExternalKey is a foreign system's identifier for an issue, such as
+// JIRA-123, LIN-789 or GH#456. Keys are unique: each names one issue, and
+// commands taking an issue ID accept them too.
+type ExternalKey struct {
+	Key       string    `json:"key"`
+	IssueID   string    `json:"issue_id"`
+	Source    string    `json:"source"` // System the key comes from, e.g. "jira", or "manual"
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Resolutions of a SyncConflict.
+const (
+	SyncKeepLocal    = "local"    // Push the beads version to the tracker
+	SyncKeepExternal = "external" // Take the tracker's version into beads
+)
+
+// SyncConflict is an issue changed both in beads and in an external
+// tracker since the last sync, held until someone picks a side instead of
+// one overwriting the other. It is open while Resolution is empty.
+type SyncConflict struct {
+	ID              int64        `json:"id"`
+	IssueID         string       `json:"issue_id"`
+	Tracker         string       `json:"tracker"` // e.g. "linear"
+	ExternalRef     string       `json:"external_ref"`
+	ExternalID      string       `json:"external_id"` // Tracker's identifier, e.g. TEAM-123
+	Local           SyncedFields `json:"local"`
+	External        SyncedFields `json:"external"`
+	LocalUpdated    time.Time    `json:"local_updated"`
+	ExternalUpdated time.Time    `json:"external_updated"`
+	DetectedAt      time.Time    `json:"detected_at"`
+	Resolution      string       `json:"resolution,omitempty"` // SyncKeepLocal or SyncKeepExternal
+	ResolvedBy      string       `json:"resolved_by,omitempty"`
+	ResolvedAt      *time.Time   `json:"resolved_at,omitempty"`
+	PendingPush     bool         `json:"pending_push,omitempty"` // Kept local, not yet pushed
+}
+
+// SyncedFields are the fields tracker syncs update, as one side of a
+// SyncConflict had them.
+type SyncedFields struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Priority    int    `json:"priority"`
+	Status      Status `json:"status"`
+}
+
+// ReactionSummary groups an issue's reactions of one kind.
+type ReactionSummary struct {
+	Reaction string   `json:"reaction"`
+	Count    int      `json:"count"`
+	Actors   []string `json:"actors"`
+}
+
+// IssueLock keeps actors other than its holder from modifying an issue
+// until it is released or expires (see bd lock).
+type IssueLock struct {
+	IssueID   string     `json:"issue_id"`
+	Holder    string     `json:"holder"`
+	Reason    string     `json:"reason,omitempty"`
+	LockedAt  time.Time  `json:"locked_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // nil: held until released
+}
+
+// Expired reports whether the lock has expired at now.
+func (l *IssueLock) Expired(now time.Time) bool {
+	return l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
+
+// Unavailability is a stretch of whole days when a person is away and
+// shouldn't be counted on for work (see bd availability).
+type Unavailability struct {
+	Person    string    `json:"person"`
+	Start     string    `json:"start"` // YYYY-MM-DD, inclusive
+	End       string    `json:"end"`   // YYYY-MM-DD, inclusive
+	Note      string    `json:"note,omitempty"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Covers reports whether day (YYYY-MM-DD) falls within the period.
+func (u *Unavailability) Covers(day string) bool {
+	return u.Start <= day && day <= u.End
+}
+
+// StatusChange is one change of an issue's status in its event history.
+// From is empty when the event doesn't record the previous status.
+type StatusChange struct {
+	At   time.Time `json:"at"`
+	From Status    `json:"from,omitempty"`
+	To   Status    `json:"to"`
+}
+
+// ReopenHistory counts how often an issue was reopened, from its event
+// history. Issues that bounce between closed and open often had unclear
+// acceptance criteria.
+type ReopenHistory struct {
+	Reopens        int        `json:"reopens"`
+	LastClosedBy   string     `json:"last_closed_by,omitempty"` // Actor of the most recent close
+	LastReopenedAt *time.Time `json:"last_reopened_at,omitempty"`
+}
+
+// Evidence kinds.
+const (
+	EvidenceURL  = "url"  // A link, such as a CI run
+	EvidenceFile = "file" // A file's content, such as a JUnit report
+)
+
+// Evidence is verification evidence attached to an issue when it was
+// closed (see bd close --evidence-url and --evidence-file).
+type Evidence struct {
+	ID          int64     `json:"id"`
+	IssueID     string    `json:"issue_id"`
+	Kind        string    `json:"kind"`                   // EvidenceURL or EvidenceFile
+	Ref         string    `json:"ref"`                    // The URL, or the file's base name
+	ContentHash string    `json:"content_hash,omitempty"` // SHA-256 of a file's content
+	Size        int       `json:"size,omitempty"`         // A file's size in bytes
+	AddedBy     string    `json:"added_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// JournalEntry is a timestamped work-log entry on an issue: what was tried
+// and how it went (see bd journal). Unlike comments, entries are meant for
+// whoever picks up the work next.
+type JournalEntry struct {
+	ID        int64     `json:"id"`
+	IssueID   string    `json:"issue_id"`
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Milestone is a sprint or release that issues are attached to, through a
+// milestone:<name> label (see bd milestone).
+type Milestone struct {
+	Name        string          `json:"name"`
+	Title       string          `json:"title,omitempty"`
+	Description string          `json:"description,omitempty"`
+	DueAt       *time.Time      `json:"due_at,omitempty"`
+	Status      MilestoneStatus `json:"status"`
+	CreatedBy   string          `json:"created_by"`
+	CreatedAt   time.Time       `json:"created_at"`
+	ClosedAt    *time.Time      `json:"closed_at,omitempty"`
+}
+
+// MilestoneStatus is whether a milestone is still being worked toward.
+type MilestoneStatus string
+
+// Milestone statuses
+const (
+	MilestoneOpen   MilestoneStatus = "open"
+	MilestoneClosed MilestoneStatus = "closed"
+)
+
+// MilestoneBaselineItem is an issue committed to a milestone, as it stood
+// when the milestone's baseline was taken (see bd milestone baseline).
+type MilestoneBaselineItem struct {
+	Milestone        string    `json:"milestone"`
+	IssueID          string    `json:"issue_id"`
+	Title            string    `json:"title"`
+	Status           Status    `json:"status"`
+	EstimatedMinutes *int      `json:"estimated_minutes,omitempty"`
+	TakenBy          string    `json:"taken_by"`
+	TakenAt          time.Time `json:"taken_at"`
+}
+
+// Recurrence is a recurring issue template, materialized as a fresh open
+// issue each time it comes due (see bd recur).
+type Recurrence struct {
+	ID                 int64     `json:"id"`
+	Title              string    `json:"title"`
+	Every              string    `json:"every"` // Compact duration, e.g. 7d, 2w, 1m
+	Description        string    `json:"description,omitempty"`
+	Design             string    `json:"design,omitempty"`
+	AcceptanceCriteria string    `json:"acceptance_criteria,omitempty"`
+	Priority           int       `json:"priority"`
+	IssueType          IssueType `json:"issue_type"`
+	Assignee           string    `json:"assignee,omitempty"`
+	Labels             []string  `json:"labels,omitempty"`
+	NextAt             time.Time `json:"next_at"`
+	LastIssueID        string    `json:"last_issue_id,omitempty"` // Issue created last time it came due
+	CreatedBy          string    `json:"created_by"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// Alert is a saved query whose matches are announced when they change
+// (see bd alert).
+type Alert struct {
+	Name          string     `json:"name"`
+	Query         string     `json:"query"`  // bd query expression
+	Notify        string     `json:"notify"` // Target for the on_alert hook, e.g. slack:#oncall
+	CreatedBy     string     `json:"created_by"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastIDs       []string   `json:"last_ids,omitempty"` // Matches at the last check, sorted
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty"`
+	LastFiredAt   *time.Time `json:"last_fired_at,omitempty"`
+}
+
+// EscalationState is where an issue stands in its escalation chain.
+type EscalationState struct {
+	Level          int        `json:"level"` // Chain steps already notified
+	AcknowledgedBy string     `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+}
+
 // Event represents an audit trail entry
 type Event struct {
 	ID        int64     `json:"id"`
@@ -842,6 +1156,13 @@ const (
 	EventLabelAdded        EventType = "label_added"
 	EventLabelRemoved      EventType = "label_removed"
 	EventCompacted         EventType = "compacted"
+	EventReactionAdded     EventType = "reaction_added"
+	EventReactionRemoved   EventType = "reaction_removed"
+	EventLocked            EventType = "locked"
+	EventUnlocked          EventType = "unlocked"
+	EventHandedOff         EventType = "handed_off"
+	EventAcknowledged      EventType = "acknowledged"
+	EventEscalated         EventType = "escalated"
 )
 
 // BlockedIssue extends Issue with blocking information
@@ -851,6 +1172,23 @@ type BlockedIssue struct {
 	BlockedBy      []string `json:"blocked_by"`
 }
 
+// BlockingChain explains why an issue is not ready work
+type BlockingChain struct {
+	IssueID    string     `json:"issue_id"`
+	Ready      bool       `json:"ready"`
+	Blockers   []*Blocker `json:"blockers"`              // Open blockers, direct and transitive
+	DeferUntil *time.Time `json:"defer_until,omitempty"` // Set while it, or its parent, is deferred
+	DeferredBy string     `json:"deferred_by,omitempty"` // The issue or parent whose defer_until applies
+	Reasons    []string   `json:"reasons,omitempty"`     // Anything else, e.g. its status
+}
+
+// Blocker is an open issue in a BlockingChain
+type Blocker struct {
+	Issue  *Issue `json:"issue"`
+	Blocks string `json:"blocks"` // The issue it holds up: the one asked about or another blocker
+	Depth  int    `json:"depth"`  // 1 for direct blockers
+}
+
 // TreeNode represents a node in a dependency tree
 type TreeNode struct {
 	Issue
@@ -897,6 +1235,7 @@ type IssueFilter struct {
 	LabelPattern string   // Glob pattern for label matching (e.g., "tech-*")
 	LabelRegex   string   // Regex pattern for label matching (e.g., "tech-(debt|legacy)")
 	TitleSearch  string
+	FullText     string   // Words (or "quoted phrases") that must all appear in the title, description, ID, or a comment
 	IDs          []string // Filter by specific issue IDs
 	IDPrefix     string   // Filter by ID prefix (e.g., "bd-" to match "bd-abc123")
 	SpecIDPrefix string   // Filter by spec_id prefix
@@ -966,9 +1305,8 @@ type SortPolicy string
 
 // Sort policy constants
 const (
-	// SortPolicyHybrid prioritizes recent issues by priority, older by age
-	// Recent = created within 48 hours
-	// This is the default for backwards compatibility
+	// SortPolicyHybrid sorts by priority, aging waiting issues up a level at a time
+	// Use to keep a backlog from starving (see ready.aging.interval)
 	SortPolicyHybrid SortPolicy = "hybrid"
 
 	// SortPolicyPriority always sorts by priority first, then creation date
@@ -978,12 +1316,41 @@ const (
 	// SortPolicyOldest always sorts by creation date (oldest first)
 	// Use for backlog clearing, preventing issue starvation
 	SortPolicyOldest SortPolicy = "oldest"
+
+	// SortPolicyVotes sorts by vote count (most first), then priority
+	// Use to let stakeholder demand drive the queue
+	SortPolicyVotes SortPolicy = "votes"
 )
 
 // IsValid checks if the sort policy value is valid
 func (s SortPolicy) IsValid() bool {
 	switch s {
-	case SortPolicyHybrid, SortPolicyPriority, SortPolicyOldest, "":
+	case SortPolicyHybrid, SortPolicyPriority, SortPolicyOldest, SortPolicyVotes, "":
+		return true
+	}
+	return false
+}
+
+// Fairness spreads the top of the ready queue across epics or labels, so one
+// large epic or busy label can't fill it and starve everything else.
+type Fairness string
+
+// Fairness constants
+const (
+	// FairnessNone keeps the sort policy's order
+	FairnessNone Fairness = ""
+
+	// FairnessEpic interleaves issues round-robin by top-level parent
+	FairnessEpic Fairness = "epic"
+
+	// FairnessLabel interleaves issues round-robin by label
+	FairnessLabel Fairness = "label"
+)
+
+// IsValid checks if the fairness value is valid
+func (f Fairness) IsValid() bool {
+	switch f {
+	case FairnessNone, FairnessEpic, FairnessLabel:
 		return true
 	}
 	return false
@@ -1002,6 +1369,7 @@ type WorkFilter struct {
 	LabelRegex   string   // Regex pattern for label matching (e.g., "tech-(debt|legacy)")
 	Limit        int
 	SortPolicy   SortPolicy
+	Fairness     Fairness // Interleave the sorted queue by epic or label before the limit
 
 	// Parent filtering: filter to descendants of a bead/epic (recursive)
 	ParentID *string // Show all descendants of this issue
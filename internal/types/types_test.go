@@ -120,6 +120,19 @@ func TestIssueValidation(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "negative actual minutes",
+			issue: Issue{
+				ID:            "test-1",
+				Title:         "Test",
+				Status:        StatusOpen,
+				Priority:      2,
+				IssueType:     TypeFeature,
+				ActualMinutes: intPtr(-5),
+			},
+			wantErr: true,
+			errMsg:  "actual_minutes cannot be negative",
+		},
 		{
 			name: "closed issue without closed_at",
 			issue: Issue{
@@ -1343,3 +1356,37 @@ func TestComputeContentHashWithValidations(t *testing.T) {
 		t.Error("Expected different hash when Score is added")
 	}
 }
+
+func TestParseLag(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":    0,
+		"2d":  48 * time.Hour,
+		"+4h": 4 * time.Hour,
+		"1w":  7 * 24 * time.Hour,
+		"-1d": -24 * time.Hour,
+	}
+	for in, want := range tests {
+		got, err := ParseLag(in)
+		if err != nil || got != want {
+			t.Errorf("ParseLag(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"2", "2m", "d", "2 days", "1.5d"} {
+		if _, err := ParseLag(bad); err == nil {
+			t.Errorf("ParseLag(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestScheduleMeta(t *testing.T) {
+	if m := ParseScheduleMeta(`{"gate":"all-children"}`); !m.IsZero() {
+		t.Errorf("unrelated metadata parsed as %+v", m)
+	}
+	m := ParseScheduleMeta(`{"relation":"start-to-start","lag":"2d"}`)
+	if m.IsZero() || m.RelationOrDefault() != RelationStartToStart || m.LagDuration() != 48*time.Hour {
+		t.Errorf("ParseScheduleMeta = %+v", m)
+	}
+	if err := (ScheduleMeta{Relation: "start-to-finish"}).Validate(); err == nil {
+		t.Error("Validate accepted start-to-finish")
+	}
+}
@@ -0,0 +1,283 @@
+// Package typeschema applies soft schemas to issue types: fields specific
+// to a type (an incident's severity, an experiment's hypothesis) that its
+// issues must fill in, and a template for showing them. Field values are
+// stored in the issue's metadata under MetadataKey; issues of types without
+// a schema, and fields a schema doesn't list, are left alone.
+package typeschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+// MetadataKey is the issue metadata field holding type-specific fields.
+const MetadataKey = "fields"
+
+// Field is one type-specific field.
+type Field struct {
+	Name     string   `json:"name"`
+	Required bool     `json:"required"`
+	Values   []string `json:"values,omitempty"` // Allowed values; empty allows any
+}
+
+// Schema lists the fields of one issue type.
+type Schema struct {
+	Type     string  `json:"type"`
+	Fields   []Field `json:"fields"`
+	Template string  `json:"template,omitempty"` // Display template, e.g. "{severity}: {impact}"
+}
+
+var (
+	fieldNameRegex   = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+	placeholderRegex = regexp.MustCompile(`\{([^{}]*)\}`)
+)
+
+// templateBuiltins are the issue fields templates may use besides the
+// schema's own.
+var templateBuiltins = []string{"id", "title", "status", "priority", "assignee"}
+
+// ParseSchemas parses the type-schemas config, which maps issue types to
+// comma-separated field specs such as "severity=sev1|sev2|sev3, impact,
+// customer?": fields are required unless marked with "?", and "=" lists
+// the allowed values. templates (the type-templates config) maps issue
+// types to display templates. Schemas are returned sorted by type.
+func ParseSchemas(fields, templates map[string]string) ([]Schema, error) {
+	schemas := make([]Schema, 0, len(fields))
+	for issueType, spec := range fields {
+		issueType = strings.ToLower(strings.TrimSpace(issueType))
+		schema := Schema{Type: issueType}
+		for _, part := range strings.Split(spec, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			name, values, hasValues := strings.Cut(part, "=")
+			name = strings.TrimSpace(name)
+			field := Field{Required: !strings.HasSuffix(name, "?")}
+			field.Name = strings.TrimSuffix(name, "?")
+			if !fieldNameRegex.MatchString(field.Name) {
+				return nil, fmt.Errorf("type-schemas.%s: invalid field name %q", issueType, field.Name)
+			}
+			if schema.Field(field.Name) != nil {
+				return nil, fmt.Errorf("type-schemas.%s: field %q is listed twice", issueType, field.Name)
+			}
+			if hasValues {
+				for _, value := range strings.Split(values, "|") {
+					if value = strings.TrimSpace(value); value != "" {
+						field.Values = append(field.Values, value)
+					}
+				}
+				if len(field.Values) == 0 {
+					return nil, fmt.Errorf("type-schemas.%s: field %q has no values after '='", issueType, field.Name)
+				}
+			}
+			schema.Fields = append(schema.Fields, field)
+		}
+		if len(schema.Fields) == 0 {
+			return nil, fmt.Errorf("type-schemas.%s: no fields", issueType)
+		}
+		schemas = append(schemas, schema)
+	}
+
+	for issueType, tmpl := range templates {
+		issueType = strings.ToLower(strings.TrimSpace(issueType))
+		i := slices.IndexFunc(schemas, func(s Schema) bool { return s.Type == issueType })
+		if i < 0 {
+			return nil, fmt.Errorf("type-templates.%s: no schema for type %q in type-schemas", issueType, issueType)
+		}
+		for _, m := range placeholderRegex.FindAllStringSubmatch(tmpl, -1) {
+			if schemas[i].Field(m[1]) == nil && !slices.Contains(templateBuiltins, m[1]) {
+				return nil, fmt.Errorf("type-templates.%s: unknown field {%s}", issueType, m[1])
+			}
+		}
+		schemas[i].Template = tmpl
+	}
+
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Type < schemas[j].Type })
+	return schemas, nil
+}
+
+// Find returns the schema for issueType, or nil.
+func Find(schemas []Schema, issueType types.IssueType) *Schema {
+	for i := range schemas {
+		if schemas[i].Type == string(issueType) {
+			return &schemas[i]
+		}
+	}
+	return nil
+}
+
+// Field returns the named field, or nil.
+func (s *Schema) Field(name string) *Field {
+	for i := range s.Fields {
+		if s.Fields[i].Name == name {
+			return &s.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Check returns the problems with an issue's field values: required fields
+// that are missing and values that aren't allowed. Fields the schema
+// doesn't list are accepted.
+func (s *Schema) Check(values map[string]string) []string {
+	var problems []string
+	for _, field := range s.Fields {
+		value, ok := values[field.Name]
+		switch {
+		case !ok || value == "":
+			if field.Required {
+				problems = append(problems, fmt.Sprintf("%s issues require field %q", s.Type, field.Name))
+			}
+		case len(field.Values) > 0 && !slices.Contains(field.Values, value):
+			problems = append(problems, fmt.Sprintf("field %q must be one of %s (got %q)",
+				field.Name, strings.Join(field.Values, ", "), value))
+		}
+	}
+	return problems
+}
+
+// Render fills in the schema's template for issue, or returns "" if the
+// schema has none. Missing fields render as empty.
+func (s *Schema) Render(issue *types.Issue) string {
+	if s.Template == "" {
+		return ""
+	}
+	values := FromMetadata(issue.Metadata)
+	return placeholderRegex.ReplaceAllStringFunc(s.Template, func(m string) string {
+		switch name := m[1 : len(m)-1]; name {
+		case "id":
+			return issue.ID
+		case "title":
+			return issue.Title
+		case "status":
+			return string(issue.Status)
+		case "priority":
+			return fmt.Sprintf("P%d", issue.Priority)
+		case "assignee":
+			return issue.Assignee
+		default:
+			return values[name]
+		}
+	})
+}
+
+// ParseAssignments parses --field flags of the form name=value. An empty
+// value removes the field.
+func ParseAssignments(args []string) (map[string]string, error) {
+	values := make(map[string]string, len(args))
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !fieldNameRegex.MatchString(name) {
+			return nil, fmt.Errorf("invalid field %q: want name=value", arg)
+		}
+		values[name] = strings.TrimSpace(value)
+	}
+	return values, nil
+}
+
+// FromMetadata returns the type-specific fields stored in issue metadata.
+// Metadata without fields yields an empty map.
+func FromMetadata(metadata json.RawMessage) map[string]string {
+	var md struct {
+		Fields map[string]string `json:"fields"`
+	}
+	if len(metadata) == 0 || json.Unmarshal(metadata, &md) != nil || md.Fields == nil {
+		return map[string]string{}
+	}
+	return md.Fields
+}
+
+// WithFields returns metadata with values merged into its fields: empty
+// values remove a field. Other metadata is preserved; metadata left with
+// nothing in it is an empty object.
+func WithFields(metadata json.RawMessage, values map[string]string) (json.RawMessage, error) {
+	md := make(map[string]json.RawMessage)
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &md); err != nil {
+			return nil, fmt.Errorf("issue metadata is not a JSON object: %w", err)
+		}
+		if md == nil { // "null"
+			md = make(map[string]json.RawMessage)
+		}
+	}
+	fields := FromMetadata(metadata)
+	for name, value := range values {
+		if value == "" {
+			delete(fields, name)
+		} else {
+			fields[name] = value
+		}
+	}
+	if len(fields) == 0 {
+		delete(md, MetadataKey)
+	} else {
+		data, err := json.Marshal(fields)
+		if err != nil {
+			return nil, err
+		}
+		md[MetadataKey] = data
+	}
+	return json.Marshal(md)
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) for issues as bd prints
+// them with --json, requiring each type's fields in metadata.fields.
+// issueTypes are the valid issue types.
+func JSONSchema(schemas []Schema, issueTypes []string) map[string]interface{} {
+	var conditions []interface{}
+	for _, schema := range schemas {
+		properties := make(map[string]interface{}, len(schema.Fields))
+		required := []string{}
+		for _, field := range schema.Fields {
+			property := map[string]interface{}{"type": "string", "minLength": 1}
+			if len(field.Values) > 0 {
+				property["enum"] = field.Values
+			}
+			properties[field.Name] = property
+			if field.Required {
+				required = append(required, field.Name)
+			}
+		}
+		fields := map[string]interface{}{"type": "object", "properties": properties, "required": required}
+		metadata := map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{MetadataKey: fields},
+		}
+		then := map[string]interface{}{"properties": map[string]interface{}{"metadata": metadata}}
+		if len(required) > 0 {
+			metadata["required"] = []string{MetadataKey}
+			then["required"] = []string{"metadata"}
+		}
+		conditions = append(conditions, map[string]interface{}{
+			"if":   map[string]interface{}{"properties": map[string]interface{}{"issue_type": map[string]interface{}{"const": schema.Type}}},
+			"then": then,
+		})
+	}
+
+	doc := map[string]interface{}{
+		"$schema":  "https://json-schema.org/draft/2020-12/schema",
+		"title":    "beads issue",
+		"type":     "object",
+		"required": []string{"id", "title", "status", "priority", "issue_type"},
+		"properties": map[string]interface{}{
+			"id":         map[string]interface{}{"type": "string"},
+			"title":      map[string]interface{}{"type": "string", "maxLength": 500},
+			"status":     map[string]interface{}{"type": "string"},
+			"priority":   map[string]interface{}{"type": "integer", "minimum": 0, "maximum": 4},
+			"issue_type": map[string]interface{}{"type": "string", "enum": issueTypes},
+			"metadata":   map[string]interface{}{"type": "object"},
+		},
+	}
+	if len(conditions) > 0 {
+		doc["allOf"] = conditions
+	}
+	return doc
+}
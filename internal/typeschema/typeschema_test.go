@@ -0,0 +1,147 @@
+package typeschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+)
+
+func TestParseSchemas(t *testing.T) {
+	schemas, err := ParseSchemas(map[string]string{
+		"Incident":   "severity=sev1|sev2|sev3, impact, customer?",
+		"experiment": "hypothesis",
+	}, map[string]string{
+		"incident": "{severity} ({status}): {impact}",
+	})
+	if err != nil {
+		t.Fatalf("ParseSchemas: %v", err)
+	}
+	want := []Schema{
+		{Type: "experiment", Fields: []Field{{Name: "hypothesis", Required: true}}},
+		{Type: "incident", Fields: []Field{
+			{Name: "severity", Required: true, Values: []string{"sev1", "sev2", "sev3"}},
+			{Name: "impact", Required: true},
+			{Name: "customer"},
+		}, Template: "{severity} ({status}): {impact}"},
+	}
+	if !reflect.DeepEqual(schemas, want) {
+		t.Errorf("ParseSchemas = %+v, want %+v", schemas, want)
+	}
+
+	for _, bad := range []struct{ fields, templates map[string]string }{
+		{fields: map[string]string{"incident": ""}},
+		{fields: map[string]string{"incident": "Severity"}},
+		{fields: map[string]string{"incident": "impact, impact?"}},
+		{fields: map[string]string{"incident": "severity="}},
+		{fields: map[string]string{"incident": "impact"}, templates: map[string]string{"bug": "{impact}"}},
+		{fields: map[string]string{"incident": "impact"}, templates: map[string]string{"incident": "{cause}"}},
+	} {
+		if _, err := ParseSchemas(bad.fields, bad.templates); err == nil {
+			t.Errorf("ParseSchemas(%v, %v) succeeded", bad.fields, bad.templates)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	schemas, err := ParseSchemas(map[string]string{"incident": "severity=sev1|sev2, impact, customer?"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := Find(schemas, "incident")
+	if schema == nil || Find(schemas, types.TypeBug) != nil {
+		t.Fatalf("Find returned the wrong schemas")
+	}
+
+	if problems := schema.Check(map[string]string{"severity": "sev1", "impact": "checkout down", "extra": "x"}); len(problems) != 0 {
+		t.Errorf("Check(valid) = %v", problems)
+	}
+	problems := schema.Check(map[string]string{"severity": "sev9", "impact": ""})
+	want := []string{
+		`field "severity" must be one of sev1, sev2 (got "sev9")`,
+		`incident issues require field "impact"`,
+	}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("Check = %q, want %q", problems, want)
+	}
+}
+
+func TestMetadataFields(t *testing.T) {
+	md, err := WithFields(json.RawMessage(`{"summary":{"text":"s"}}`), map[string]string{"severity": "sev1", "impact": "all"})
+	if err != nil {
+		t.Fatalf("WithFields: %v", err)
+	}
+	if got := FromMetadata(md); !reflect.DeepEqual(got, map[string]string{"severity": "sev1", "impact": "all"}) {
+		t.Errorf("FromMetadata = %v", got)
+	}
+
+	md, err = WithFields(md, map[string]string{"severity": "", "impact": ""})
+	if err != nil {
+		t.Fatalf("WithFields: %v", err)
+	}
+	if string(md) != `{"summary":{"text":"s"}}` {
+		t.Errorf("clearing fields left %s", md)
+	}
+	if md, err := WithFields(nil, map[string]string{"impact": ""}); err != nil || string(md) != "{}" {
+		t.Errorf("WithFields(nil) = %s, %v", md, err)
+	}
+	if _, err := WithFields(json.RawMessage(`[1]`), map[string]string{"impact": "x"}); err == nil {
+		t.Errorf("WithFields accepted non-object metadata")
+	}
+}
+
+func TestRender(t *testing.T) {
+	schema := Schema{Type: "incident", Template: "{severity} {id} {priority}: {impact} {customer}"}
+	issue := &types.Issue{ID: "bd-1", Priority: 1, Metadata: json.RawMessage(`{"fields":{"severity":"sev1","impact":"down"}}`)}
+	if got := schema.Render(issue); got != "sev1 bd-1 P1: down " {
+		t.Errorf("Render = %q", got)
+	}
+}
+
+func TestParseAssignments(t *testing.T) {
+	got, err := ParseAssignments([]string{"severity=sev1", "impact = a=b", "customer="})
+	if err != nil {
+		t.Fatalf("ParseAssignments: %v", err)
+	}
+	if want := map[string]string{"severity": "sev1", "impact": "a=b", "customer": ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseAssignments = %v, want %v", got, want)
+	}
+	for _, bad := range []string{"severity", "=sev1", "Sev=1"} {
+		if _, err := ParseAssignments([]string{bad}); err == nil {
+			t.Errorf("ParseAssignments(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	schemas := []Schema{{Type: "incident", Fields: []Field{{Name: "severity", Required: true, Values: []string{"sev1"}}}}}
+	data, err := json.Marshal(JSONSchema(schemas, []string{"task", "incident"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		AllOf []struct {
+			If   json.RawMessage `json:"if"`
+			Then struct {
+				Required   []string `json:"required"`
+				Properties struct {
+					Metadata struct {
+						Properties struct {
+							Fields struct {
+								Required []string `json:"required"`
+							} `json:"fields"`
+						} `json:"properties"`
+					} `json:"metadata"`
+				} `json:"properties"`
+			} `json:"then"`
+		} `json:"allOf"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.AllOf) != 1 || !reflect.DeepEqual(doc.AllOf[0].Then.Required, []string{"metadata"}) ||
+		!reflect.DeepEqual(doc.AllOf[0].Then.Properties.Metadata.Properties.Fields.Required, []string{"severity"}) {
+		t.Errorf("JSONSchema = %s", data)
+	}
+}
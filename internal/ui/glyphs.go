@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"unicode"
+)
+
+// glyphsEnabled reports whether Unicode symbols and emoji are written as-is.
+// BD_NO_EMOJI turns them off from the start; the CLI also turns them off
+// when display.glyphs is false.
+var glyphsEnabled = os.Getenv("BD_NO_EMOJI") == ""
+
+// SetGlyphs turns Unicode symbols and emoji in rendered output on or off.
+// When off, Glyph rewrites them as plain ASCII, for terminals and CI logs
+// that show them as boxes or at the wrong width.
+func SetGlyphs(enabled bool) {
+	glyphsEnabled = enabled
+}
+
+// GlyphsEnabled reports whether Unicode symbols and emoji are shown.
+func GlyphsEnabled() bool {
+	return glyphsEnabled
+}
+
+// asciiGlyphs maps the symbols used across bd's output to ASCII stand-ins.
+// Single-column symbols map to a single character so alignment holds.
+var asciiGlyphs = strings.NewReplacer(
+	// Status and check marks
+	"✓", "+", "✔", "+", "✖", "x", "✗", "x", "✘", "x",
+	"⚠", "!", "ℹ", "i",
+	"○", "o", "◐", "~", "●", "*", "◊", "*", "❄", "-", "•", "*", "·", "-",
+	"📌", "^",
+	// Arrows
+	"→", "->", "←", "<-", "↔", "<->", "↑", "^", "↓", "v", "↳", "`-",
+	"▶", ">", "▼", "v",
+	// Box drawing and tree connectors
+	"─", "-", "━", "-", "═", "=", "│", "|", "┃", "|", "⎿", "`",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+", "├", "+", "┤", "+",
+	"┬", "+", "┴", "+", "┼", "+", "╭", "+", "╮", "+", "╰", "+", "╯", "+",
+	"…", "...",
+)
+
+// Glyph returns s unchanged when glyphs are enabled. Otherwise it replaces
+// known symbols with ASCII stand-ins and any other emoji with "*", leaving
+// letters in every script (including CJK) alone.
+func Glyph(s string) string {
+	if glyphsEnabled || isASCII(s) {
+		return s
+	}
+	s = asciiGlyphs.Replace(s)
+	if isASCII(s) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\uFE0F' || r == '\u200D': // emoji presentation selector, joiner
+		case isEmoji(r):
+			b.WriteByte('*')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// isEmoji reports whether r is a pictographic symbol rather than text.
+func isEmoji(r rune) bool {
+	return (r >= 0x1F000 && r <= 0x1FAFF) ||
+		(r >= 0x2600 && r <= 0x27BF && unicode.Is(unicode.So, r)) ||
+		(r >= 0x2300 && r <= 0x23FF && unicode.Is(unicode.So, r)) ||
+		(r >= 0x2B00 && r <= 0x2BFF && unicode.Is(unicode.So, r))
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package ui
+
+import "testing"
+
+func TestGlyph(t *testing.T) {
+	defer SetGlyphs(GlyphsEnabled())
+
+	SetGlyphs(true)
+	if got := Glyph("✓ done"); got != "✓ done" {
+		t.Errorf("Glyph with glyphs enabled = %q", got)
+	}
+
+	SetGlyphs(false)
+	tests := map[string]string{
+		"✓ Created bd-1":         "+ Created bd-1",
+		"○ ◐ ● ❄ 📌":              "o ~ * - ^",
+		"├── └── │":              "+-- +-- |",
+		"blocks → bd-2":          "blocks -> bd-2",
+		"📊 Graph 🗜️":             "* Graph *",
+		"日本語のタイトル…":              "日本語のタイトル...",
+		"plain ascii stays same": "plain ascii stays same",
+	}
+	for in, want := range tests {
+		if got := Glyph(in); got != want {
+			t.Errorf("Glyph(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if got := GetStatusIcon("in_progress"); got != "~" {
+		t.Errorf("GetStatusIcon with glyphs disabled = %q", got)
+	}
+	if got := Truncate("truncate me", 8); got != "trunc..." {
+		t.Errorf("Truncate with glyphs disabled = %q", got)
+	}
+}
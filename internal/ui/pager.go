@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
 	"golang.org/x/term"
 )
 
@@ -14,6 +18,9 @@ import (
 type PagerOptions struct {
 	// NoPager disables pager for this command (--no-pager flag)
 	NoPager bool
+	// Command is the configured pager (pager in config.yaml). BD_PAGER
+	// still takes precedence; empty falls back to PAGER, then less.
+	Command string
 }
 
 // shouldUsePager determines if output should be piped to a pager.
@@ -39,11 +46,14 @@ func shouldUsePager(opts PagerOptions) bool {
 }
 
 // getPagerCommand returns the pager command to use.
-// Checks BD_PAGER, then PAGER, defaults to "less".
-func getPagerCommand() string {
+// Checks BD_PAGER, then the configured pager, then PAGER, defaults to "less".
+func getPagerCommand(opts PagerOptions) string {
 	if pager := os.Getenv("BD_PAGER"); pager != "" {
 		return pager
 	}
+	if opts.Command != "" {
+		return opts.Command
+	}
 	if pager := os.Getenv("PAGER"); pager != "" {
 		return pager
 	}
@@ -91,29 +101,133 @@ func ToPager(content string, opts PagerOptions) error {
 	}
 
 	// Use pager
-	pagerCmd := getPagerCommand()
+	cmd := pagerCommand(opts)
+	if cmd == nil {
+		fmt.Print(content)
+		return nil
+	}
+	if !pagerShowsColor(cmd) {
+		content = ansi.Strip(content)
+	}
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+// StartPager sends everything the command writes to stdout through a pager
+// until the returned stop function is called, like git does. It is for
+// commands that print as they go instead of building their output first;
+// the pager itself decides whether the output fits on one screen (less -F).
+// If the pager should not be used or fails to start, stop does nothing.
+// Call stop before os.Exit, or the pager is cut off.
+func StartPager(opts PagerOptions) (stop func()) {
+	noop := func() {}
+	if !shouldUsePager(opts) {
+		return noop
+	}
+	cmd := pagerCommand(opts)
+	if cmd == nil {
+		return noop
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return noop
+	}
+	stdout := os.Stdout
+	cmd.Stdin = r
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		_ = r.Close()
+		_ = w.Close()
+		return noop
+	}
+	_ = r.Close() // The pager holds its own copy
+
+	// Styles render as they are printed, so turning color off here keeps
+	// escape codes away from pagers that would show them literally.
+	profile := lipgloss.ColorProfile()
+	if !pagerShowsColor(cmd) {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	os.Stdout = w
 
+	return func() {
+		os.Stdout = stdout
+		lipgloss.SetColorProfile(profile)
+		_ = w.Close()
+		_ = cmd.Wait()
+	}
+}
+
+// pagerCommand builds the pager process, or returns nil if no pager is set.
+func pagerCommand(opts PagerOptions) *exec.Cmd {
 	// Parse pager command (may include arguments like "less -R")
-	parts := strings.Fields(pagerCmd)
+	parts := strings.Fields(getPagerCommand(opts))
 	if len(parts) == 0 {
-		fmt.Print(content)
 		return nil
 	}
 
 	cmd := exec.Command(parts[0], parts[1:]...) // #nosec G204 - pager command is user-configurable by design
-	cmd.Stdin = strings.NewReader(content)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
 
 	// Set LESS environment variable for sensible defaults if not already set
 	// -R: Allow ANSI color codes
 	// -F: Quit if content fits on one screen
 	// -X: Don't clear screen on exit
+	// LV=-c does the same for lv's color handling.
+	cmd.Env = os.Environ()
 	if os.Getenv("LESS") == "" {
-		cmd.Env = append(os.Environ(), "LESS=-RFX")
-	} else {
-		cmd.Env = os.Environ()
+		cmd.Env = append(cmd.Env, "LESS=-RFX")
+	}
+	if os.Getenv("LV") == "" {
+		cmd.Env = append(cmd.Env, "LV=-c")
 	}
+	return cmd
+}
 
-	return cmd.Run()
+// colorPagers are pagers that display ANSI colors without extra options.
+var colorPagers = map[string]bool{
+	"bat": true, "delta": true, "lv": true, "moar": true, "moor": true, "most": true, "ov": true,
+}
+
+// pagerShowsColor reports whether the pager displays ANSI colors rather
+// than printing the escape codes. less does when -R (or -r) is in its
+// arguments or in LESS; unknown pagers are assumed not to.
+func pagerShowsColor(cmd *exec.Cmd) bool {
+	name := strings.TrimSuffix(filepath.Base(cmd.Path), ".exe")
+	if colorPagers[name] {
+		return true
+	}
+	if name != "less" {
+		return false
+	}
+	less := os.Getenv("LESS")
+	if less == "" {
+		return true // We set LESS=-RFX
+	}
+	// The leading dash is optional in LESS (git sets LESS=FRX)
+	envOpts := strings.Fields(less)
+	for i, opt := range envOpts {
+		if !strings.HasPrefix(opt, "-") {
+			envOpts[i] = "-" + opt
+		}
+	}
+	return lessRawControlChars(cmd.Args[1:]) || lessRawControlChars(envOpts)
+}
+
+// lessRawControlChars reports whether less options include -R or -r,
+// alone or grouped with other single-letter options, or their long forms.
+func lessRawControlChars(opts []string) bool {
+	for _, opt := range opts {
+		switch {
+		case strings.EqualFold(opt, "--raw-control-chars"):
+			return true
+		case strings.HasPrefix(opt, "--"):
+		case strings.HasPrefix(opt, "-") && strings.ContainsAny(opt, "Rr"):
+			return true
+		}
+	}
+	return false
 }
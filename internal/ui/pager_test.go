@@ -90,6 +90,7 @@ func TestGetPagerCommand(t *testing.T) {
 	tests := []struct {
 		name      string
 		envVars   map[string]string
+		opts      PagerOptions
 		wantPager string
 	}{
 		{
@@ -112,6 +113,18 @@ func TestGetPagerCommand(t *testing.T) {
 			envVars:   map[string]string{"BD_PAGER": "more", "PAGER": "cat"},
 			wantPager: "more",
 		},
+		{
+			name:      "configured pager takes precedence over PAGER",
+			envVars:   map[string]string{"PAGER": "cat"},
+			opts:      PagerOptions{Command: "less -S"},
+			wantPager: "less -S",
+		},
+		{
+			name:      "BD_PAGER takes precedence over configured pager",
+			envVars:   map[string]string{"BD_PAGER": "more"},
+			opts:      PagerOptions{Command: "less -S"},
+			wantPager: "more",
+		},
 	}
 
 	for _, tt := range tests {
@@ -135,7 +148,7 @@ func TestGetPagerCommand(t *testing.T) {
 				os.Setenv(k, v)
 			}
 
-			got := getPagerCommand()
+			got := getPagerCommand(tt.opts)
 			if got != tt.wantPager {
 				t.Errorf("getPagerCommand() = %q, want %q", got, tt.wantPager)
 			}
@@ -172,3 +185,32 @@ func TestToPagerWithBdNoPagerEnv(t *testing.T) {
 		t.Errorf("ToPager() returned error: %v", err)
 	}
 }
+
+func TestPagerShowsColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		less    string
+		want    bool
+	}{
+		{name: "less with our LESS default", command: "less", want: true},
+		{name: "less with -R argument", command: "less -SR", less: "-F", want: true},
+		{name: "LESS with R", command: "less", less: "FRX", want: true},
+		{name: "LESS without R", command: "less", less: "-FX", want: false},
+		{name: "LESS long option containing r", command: "less", less: "--quit-if-one-screen", want: false},
+		{name: "LESS long raw option", command: "less", less: "--RAW-CONTROL-CHARS", want: true},
+		{name: "color pager", command: "bat --paging=always", want: true},
+		{name: "unknown pager", command: "more", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BD_PAGER", "")
+			t.Setenv("LESS", tt.less)
+			cmd := pagerCommand(PagerOptions{Command: tt.command})
+			if got := pagerShowsColor(cmd); got != tt.want {
+				t.Errorf("pagerShowsColor(%q, LESS=%q) = %v, want %v", tt.command, tt.less, got, tt.want)
+			}
+		})
+	}
+}
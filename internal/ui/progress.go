@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// progressInterval is how often a Progress redraws.
+const progressInterval = 100 * time.Millisecond
+
+var (
+	spinnerFrames      = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	asciiSpinnerFrames = []string{"|", "/", "-", "\\"}
+)
+
+// Progress reports the progress of a long operation on one line of stderr:
+// a bar with counts when the total is known, a spinner with a count when
+// it isn't. It redraws on its own, so the spinner keeps turning while a
+// single step blocks. When stderr isn't a terminal it draws nothing, so
+// logs and --json output are unaffected.
+//
+// A Progress is safe for concurrent use. Call Done when the operation ends,
+// however it ends, to clear the line.
+type Progress struct {
+	mu    sync.Mutex
+	w     io.Writer
+	label string
+	total int
+	count int
+	frame int
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// NewProgress starts reporting progress of the operation named by label.
+// total is the number of steps, or 0 when it isn't known.
+func NewProgress(label string, total int) *Progress {
+	var w io.Writer
+	if term.IsTerminal(int(os.Stderr.Fd())) {
+		w = os.Stderr
+	}
+	return NewProgressTo(w, label, total)
+}
+
+// NewProgressTo is NewProgress drawing on w, or nowhere if w is nil: the
+// Progress then only counts.
+func NewProgressTo(w io.Writer, label string, total int) *Progress {
+	p := &Progress{w: w, label: label, total: total}
+	if w != nil {
+		p.stop, p.done = make(chan struct{}), make(chan struct{})
+		go p.run(p.stop)
+	}
+	return p
+}
+
+func (p *Progress) run(stop <-chan struct{}) {
+	defer close(p.done)
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	for {
+		p.draw()
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// SetLabel changes the operation shown, e.g. to the step now running.
+func (p *Progress) SetLabel(label string) {
+	p.mu.Lock()
+	p.label = label
+	p.mu.Unlock()
+}
+
+// Add records n more completed steps.
+func (p *Progress) Add(n int) {
+	p.mu.Lock()
+	p.count += n
+	p.mu.Unlock()
+}
+
+// Count returns the number of completed steps.
+func (p *Progress) Count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.count
+}
+
+// Done stops reporting and clears the progress line. It is safe to call
+// more than once.
+func (p *Progress) Done() {
+	if p.w == nil {
+		return
+	}
+	p.mu.Lock()
+	stop := p.stop
+	p.stop = nil
+	p.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-p.done
+	_, _ = fmt.Fprint(p.w, "\r\033[K")
+}
+
+func (p *Progress) draw() {
+	p.mu.Lock()
+	line := p.line()
+	p.frame++
+	p.mu.Unlock()
+	_, _ = fmt.Fprint(p.w, "\r\033[K"+line)
+}
+
+// line renders the progress line, without the line reset.
+func (p *Progress) line() string {
+	if p.total <= 0 {
+		frames := spinnerFrames
+		if !GlyphsEnabled() {
+			frames = asciiSpinnerFrames
+		}
+		line := frames[p.frame%len(frames)] + " " + p.label
+		if p.count > 0 {
+			line += fmt.Sprintf(" %d", p.count)
+		}
+		return line
+	}
+	return fmt.Sprintf("%s %s %d/%d", p.label, progressBar(p.count, p.total, 30), p.count, p.total)
+}
+
+// progressBar renders count out of total as a bar of width cells.
+func progressBar(count, total, width int) string {
+	filled := width
+	if count < total {
+		filled = count * width / total
+	}
+	full, empty := "█", "░"
+	if !GlyphsEnabled() {
+		full, empty = "#", "."
+	}
+	return "[" + strings.Repeat(full, filled) + strings.Repeat(empty, width-filled) + "]"
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestProgressLine(t *testing.T) {
+	defer SetGlyphs(GlyphsEnabled())
+	SetGlyphs(false)
+
+	p := &Progress{label: "Exporting", total: 40}
+	p.Add(10)
+	if got, want := p.line(), "Exporting [#######.......................] 10/40"; got != want {
+		t.Errorf("bar line = %q, want %q", got, want)
+	}
+	p.Add(30)
+	if got := p.line(); !strings.HasSuffix(got, "[##############################] 40/40") {
+		t.Errorf("full bar line = %q", got)
+	}
+
+	s := &Progress{label: "Syncing with town-beta"}
+	if got := s.line(); got != "| Syncing with town-beta" {
+		t.Errorf("spinner line = %q", got)
+	}
+	s.frame, s.count = 1, 3
+	if got := s.line(); got != "/ Syncing with town-beta 3" {
+		t.Errorf("spinner line with count = %q", got)
+	}
+}
+
+func TestProgressDone(t *testing.T) {
+	var out lockedBuffer
+	p := NewProgressTo(&out, "Importing", 2)
+	p.Add(2)
+	p.Done()
+	p.Done()
+	if got := out.String(); !strings.HasPrefix(got, "\r\033[KImporting") || !strings.HasSuffix(got, "\r\033[K") {
+		t.Errorf("output = %q", got)
+	}
+	if p.Count() != 2 {
+		t.Errorf("Count() = %d", p.Count())
+	}
+
+	// Without a terminal nothing is drawn, but counts are still kept.
+	quiet := NewProgressTo(nil, "Importing", 2)
+	quiet.Add(1)
+	quiet.Done()
+	if quiet.Count() != 1 {
+		t.Errorf("Count() = %d", quiet.Count())
+	}
+}
@@ -225,17 +225,17 @@ const PriorityIcon = "●"
 func RenderStatusIcon(status string) string {
 	switch status {
 	case "open":
-		return StatusIconOpen // no color - available but not urgent
+		return Glyph(StatusIconOpen) // no color - available but not urgent
 	case "in_progress":
-		return StatusInProgressStyle.Render(StatusIconInProgress)
+		return StatusInProgressStyle.Render(Glyph(StatusIconInProgress))
 	case "blocked":
-		return StatusBlockedStyle.Render(StatusIconBlocked)
+		return StatusBlockedStyle.Render(Glyph(StatusIconBlocked))
 	case "closed":
-		return StatusClosedStyle.Render(StatusIconClosed)
+		return StatusClosedStyle.Render(Glyph(StatusIconClosed))
 	case "deferred":
-		return MutedStyle.Render(StatusIconDeferred)
+		return MutedStyle.Render(Glyph(StatusIconDeferred))
 	case "pinned":
-		return StatusPinnedStyle.Render(StatusIconPinned)
+		return StatusPinnedStyle.Render(Glyph(StatusIconPinned))
 	default:
 		return "?" // unknown status
 	}
@@ -246,17 +246,17 @@ func RenderStatusIcon(status string) string {
 func GetStatusIcon(status string) string {
 	switch status {
 	case "open":
-		return StatusIconOpen
+		return Glyph(StatusIconOpen)
 	case "in_progress":
-		return StatusIconInProgress
+		return Glyph(StatusIconInProgress)
 	case "blocked":
-		return StatusIconBlocked
+		return Glyph(StatusIconBlocked)
 	case "closed":
-		return StatusIconClosed
+		return Glyph(StatusIconClosed)
 	case "deferred":
-		return StatusIconDeferred
+		return Glyph(StatusIconDeferred)
 	case "pinned":
-		return StatusIconPinned
+		return Glyph(StatusIconPinned)
 	default:
 		return "?"
 	}
@@ -299,27 +299,27 @@ const (
 
 // RenderPass renders text with pass (green) styling
 func RenderPass(s string) string {
-	return PassStyle.Render(s)
+	return PassStyle.Render(Glyph(s))
 }
 
 // RenderWarn renders text with warning (yellow) styling
 func RenderWarn(s string) string {
-	return WarnStyle.Render(s)
+	return WarnStyle.Render(Glyph(s))
 }
 
 // RenderFail renders text with fail (red) styling
 func RenderFail(s string) string {
-	return FailStyle.Render(s)
+	return FailStyle.Render(Glyph(s))
 }
 
 // RenderMuted renders text with muted (gray) styling
 func RenderMuted(s string) string {
-	return MutedStyle.Render(s)
+	return MutedStyle.Render(Glyph(s))
 }
 
 // RenderAccent renders text with accent (blue) styling
 func RenderAccent(s string) string {
-	return AccentStyle.Render(s)
+	return AccentStyle.Render(Glyph(s))
 }
 
 // RenderCategory renders a category header in uppercase with accent color
@@ -329,32 +329,32 @@ func RenderCategory(s string) string {
 
 // RenderSeparator renders the light separator line in muted color
 func RenderSeparator() string {
-	return MutedStyle.Render(SeparatorLight)
+	return MutedStyle.Render(Glyph(SeparatorLight))
 }
 
 // RenderPassIcon renders the pass icon with styling
 func RenderPassIcon() string {
-	return PassStyle.Render(IconPass)
+	return PassStyle.Render(Glyph(IconPass))
 }
 
 // RenderWarnIcon renders the warning icon with styling
 func RenderWarnIcon() string {
-	return WarnStyle.Render(IconWarn)
+	return WarnStyle.Render(Glyph(IconWarn))
 }
 
 // RenderFailIcon renders the fail icon with styling
 func RenderFailIcon() string {
-	return FailStyle.Render(IconFail)
+	return FailStyle.Render(Glyph(IconFail))
 }
 
 // RenderSkipIcon renders the skip icon with styling
 func RenderSkipIcon() string {
-	return MutedStyle.Render(IconSkip)
+	return MutedStyle.Render(Glyph(IconSkip))
 }
 
 // RenderInfoIcon renders the info icon with styling
 func RenderInfoIcon() string {
-	return AccentStyle.Render(IconInfo)
+	return AccentStyle.Render(Glyph(IconInfo))
 }
 
 // === Issue Component Renderers ===
@@ -387,7 +387,7 @@ func RenderStatus(status string) string {
 // Format: ● P0 (icon + label)
 // P0/P1 get color; P2/P3/P4 use standard text
 func RenderPriority(priority int) string {
-	label := fmt.Sprintf("%s P%d", PriorityIcon, priority)
+	label := fmt.Sprintf("%s P%d", Glyph(PriorityIcon), priority)
 	switch priority {
 	case 0:
 		return PriorityP0Style.Render(label)
@@ -481,7 +481,7 @@ func RenderTypeForStatus(issueType, status string) string {
 
 // RenderClosedLine renders an entire line in the closed/dimmed style
 func RenderClosedLine(line string) string {
-	return StatusClosedStyle.Render(line)
+	return StatusClosedStyle.Render(Glyph(line))
 }
 
 // BoldStyle for emphasis
@@ -40,10 +40,10 @@ func ShouldUseColor() bool {
 
 // ShouldUseEmoji determines if emoji decorations should be used.
 // Disabled in non-TTY mode to keep output machine-readable.
-// Can be controlled with BD_NO_EMOJI environment variable.
+// Can be controlled with BD_NO_EMOJI environment variable or display.glyphs.
 func ShouldUseEmoji() bool {
 	// Explicit disable
-	if os.Getenv("BD_NO_EMOJI") != "" {
+	if os.Getenv("BD_NO_EMOJI") != "" || !GlyphsEnabled() {
 		return false
 	}
 
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// DisplayWidth returns the number of terminal columns s occupies.
+// ANSI escape codes take no space; wide characters (CJK, most emoji)
+// take two columns. Use it instead of len or rune counts when aligning.
+func DisplayWidth(s string) int {
+	return ansi.StringWidth(s)
+}
+
+// Truncate shortens s to at most width columns, ending it with an
+// ellipsis when anything was cut. It never splits a wide character or an
+// ANSI escape code.
+func Truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	tail := Glyph("…")
+	if DisplayWidth(tail) >= width {
+		tail = ""
+	}
+	return ansi.Truncate(s, width, tail)
+}
+
+// PadRight pads s with spaces to exactly width columns, cutting it (without
+// an ellipsis) if it is wider.
+func PadRight(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	s = ansi.Truncate(s, width, "")
+	if w := DisplayWidth(s); w < width {
+		s += strings.Repeat(" ", width-w)
+	}
+	return s
+}
@@ -0,0 +1,55 @@
+package ui
+
+import "testing"
+
+func TestDisplayWidth(t *testing.T) {
+	tests := map[string]int{
+		"abc":                     3,
+		"日本語":                     6,
+		"📌":                       2,
+		PassStyle.Render("ok"):    2,
+		"\x1b[31mred\x1b[0m text": 8,
+	}
+	for s, want := range tests {
+		if got := DisplayWidth(s); got != want {
+			t.Errorf("DisplayWidth(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"truncate me", 8, "truncat…"},
+		{"日本語タイトル", 6, "日本…"},
+		{"abc", 1, "a"},
+		{"abc", 0, ""},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.s, tt.width); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestPadRight(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"ab", 4, "ab  "},
+		{"日本", 5, "日本 "},
+		{"日本語", 5, "日本 "},
+		{"toolong", 4, "tool"},
+	}
+	for _, tt := range tests {
+		if got := PadRight(tt.s, tt.width); got != tt.want {
+			t.Errorf("PadRight(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
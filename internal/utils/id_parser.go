@@ -32,6 +32,7 @@ func parseIssueID(input string, prefix string) string {
 // - Without hyphen: "bda3f8e9" or "wya3f8e9" → "bd-a3f8e9"
 // - Partial IDs: "a3f8" → "bd-a3f8e9" (if unique match)
 // - Hierarchical: "a3f8e9.1" → "bd-a3f8e9.1"
+// - External keys: "JIRA-123" → the issue it is an alias of
 //
 // Returns an error if:
 // - No issue found matching the ID
@@ -50,6 +51,11 @@ func ResolvePartialID(ctx context.Context, store storage.Storage, input string)
 		return issues[0].ID, nil
 	}
 
+	// External keys (JIRA-123, LIN-789) resolve to the issues they alias.
+	if id, err := store.ResolveExternalKey(ctx, input); err == nil {
+		return id, nil
+	}
+
 	// Get the configured prefix
 	prefix, err := store.GetConfig(ctx, "issue_prefix")
 	if err != nil || prefix == "" {
@@ -137,7 +143,7 @@ func ResolvePartialID(ctx context.Context, store storage.Storage, input string)
 	}
 
 	if len(matches) == 0 {
-		return "", fmt.Errorf("no issue found matching %q", input)
+		return "", &NoMatchError{Input: input}
 	}
 
 	if len(matches) > 1 {
@@ -147,6 +153,20 @@ func ResolvePartialID(ctx context.Context, store storage.Storage, input string)
 	return matches[0], nil
 }
 
+// NoMatchError is returned by ResolvePartialID when no issue matches the
+// input. It matches storage.ErrNotFound with errors.Is.
+type NoMatchError struct {
+	Input string
+}
+
+func (e *NoMatchError) Error() string {
+	return fmt.Sprintf("no issue found matching %q", e.Input)
+}
+
+func (e *NoMatchError) Unwrap() error {
+	return storage.ErrNotFound
+}
+
 // ResolvePartialIDs resolves multiple potentially partial issue IDs.
 // Returns the resolved IDs and any errors encountered.
 func ResolvePartialIDs(ctx context.Context, store storage.Storage, inputs []string) ([]string, error) {
@@ -0,0 +1,298 @@
+package validation
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/typeschema"
+)
+
+// Spec rule names, reported in Violation.Rule.
+const (
+	RuleInvalidField      = "invalid-field"
+	RuleRequiredField     = "required-field"
+	RuleEstimate          = "estimate"
+	RuleCycle             = "cycle"
+	RuleMissingParent     = "missing-parent"
+	RuleParentType        = "parent-type"
+	RuleMissingDependency = "missing-dependency"
+	RuleLabelTaxonomy     = "label-taxonomy"
+	RuleTypeField         = "type-field"
+)
+
+// Violation severities.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// SpecFields are the field names SpecRules.RequiredFields may list.
+var SpecFields = []string{
+	"description", "design", "acceptance_criteria", "notes",
+	"assignee", "estimate", "external_ref", "labels",
+}
+
+// SpecRules configures CheckSpecs.
+type SpecRules struct {
+	// RequiredFields lists the fields (see SpecFields) that must be set, by
+	// issue type. The "*" entry applies to every type.
+	RequiredFields map[string][]string
+	// EstimateMaxPriority requires an estimate on issues with this priority
+	// or more urgent (1 = P0 and P1). Negative disables the check.
+	EstimateMaxPriority int
+	// LabelPatterns is the label taxonomy: every label must match one of
+	// these path.Match patterns (e.g. "area:*"). Empty allows any label.
+	LabelPatterns []string
+	// TypeSchemas are the type-specific fields issues of each type must
+	// fill in (see typeschema).
+	TypeSchemas []typeschema.Schema
+	// CustomStatuses and CustomTypes are accepted in addition to built-ins.
+	CustomStatuses []string
+	CustomTypes    []string
+}
+
+// Violation is one broken invariant.
+type Violation struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	IssueID  string `json:"issue_id,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Message  string `json:"message"`
+}
+
+// CheckSpecs checks issues (with their Labels and Dependencies populated)
+// against rules. Dependency targets outside issues are resolved with lookup,
+// which may be nil; issues it can't find are reported as missing.
+// Violations are sorted by issue ID.
+func CheckSpecs(issues []*types.Issue, lookup func(id string) *types.Issue, rules SpecRules) []Violation {
+	byID := make(map[string]*types.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+	find := func(id string) *types.Issue {
+		if issue, ok := byID[id]; ok {
+			return issue
+		}
+		if lookup != nil {
+			return lookup(id)
+		}
+		return nil
+	}
+
+	var out []Violation
+	add := func(v Violation) {
+		if v.Severity == "" {
+			v.Severity = SeverityError
+		}
+		out = append(out, v)
+	}
+
+	for _, issue := range issues {
+		if err := issue.ValidateWithCustom(rules.CustomStatuses, rules.CustomTypes); err != nil {
+			add(Violation{Rule: RuleInvalidField, IssueID: issue.ID, Message: err.Error()})
+		}
+
+		required := append(append([]string(nil), rules.RequiredFields["*"]...), rules.RequiredFields[string(issue.IssueType)]...)
+		slices.Sort(required)
+		for _, field := range slices.Compact(required) {
+			if !specFieldSet(issue, field) {
+				add(Violation{Rule: RuleRequiredField, IssueID: issue.ID, Field: field,
+					Message: fmt.Sprintf("%s issues require %s", issue.IssueType, field)})
+			}
+		}
+
+		if schema := typeschema.Find(rules.TypeSchemas, issue.IssueType); schema != nil {
+			for _, problem := range schema.Check(typeschema.FromMetadata(issue.Metadata)) {
+				add(Violation{Rule: RuleTypeField, IssueID: issue.ID, Field: typeschema.MetadataKey, Message: problem})
+			}
+		}
+
+		if rules.EstimateMaxPriority >= 0 && issue.Priority <= rules.EstimateMaxPriority &&
+			issue.Status != types.StatusClosed && !specFieldSet(issue, "estimate") {
+			add(Violation{Rule: RuleEstimate, IssueID: issue.ID, Field: "estimate",
+				Message: fmt.Sprintf("P%d issues require an estimate", issue.Priority)})
+		}
+
+		for _, label := range issue.Labels {
+			if !labelAllowed(label, rules.LabelPatterns) {
+				add(Violation{Rule: RuleLabelTaxonomy, IssueID: issue.ID, Field: "labels",
+					Message: fmt.Sprintf("label %q is not in the taxonomy (%s)", label, strings.Join(rules.LabelPatterns, ", "))})
+			}
+		}
+
+		for _, dep := range issue.Dependencies {
+			if dep.IssueID != "" && dep.IssueID != issue.ID {
+				continue
+			}
+			if strings.HasPrefix(dep.DependsOnID, "external:") {
+				continue // Resolved across projects at query time
+			}
+			target := find(dep.DependsOnID)
+			switch {
+			case dep.Type == types.DepParentChild && target == nil:
+				add(Violation{Rule: RuleMissingParent, IssueID: issue.ID, Field: "parent",
+					Message: fmt.Sprintf("parent %s does not exist", dep.DependsOnID)})
+			case dep.Type == types.DepParentChild && target.IssueType != types.TypeEpic:
+				add(Violation{Rule: RuleParentType, Severity: SeverityWarning, IssueID: issue.ID, Field: "parent",
+					Message: fmt.Sprintf("parent %s is a %s, not an epic", dep.DependsOnID, target.IssueType)})
+			case target == nil:
+				add(Violation{Rule: RuleMissingDependency, IssueID: issue.ID, Field: "dependencies",
+					Message: fmt.Sprintf("%s dependency on %s, which does not exist", dep.Type, dep.DependsOnID)})
+			}
+		}
+	}
+
+	for _, cycle := range findCycles(issues) {
+		add(Violation{Rule: RuleCycle, IssueID: cycle[0], Field: "dependencies",
+			Message: "dependency cycle: " + strings.Join(append(cycle, cycle[0]), " → ")})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].IssueID < out[j].IssueID })
+	return out
+}
+
+// ValidSpecField reports whether name may be used in SpecRules.RequiredFields.
+func ValidSpecField(name string) bool {
+	return slices.Contains(SpecFields, name)
+}
+
+func specFieldSet(issue *types.Issue, field string) bool {
+	switch field {
+	case "description":
+		return strings.TrimSpace(issue.Description) != ""
+	case "design":
+		return strings.TrimSpace(issue.Design) != ""
+	case "acceptance_criteria":
+		return strings.TrimSpace(issue.AcceptanceCriteria) != ""
+	case "notes":
+		return strings.TrimSpace(issue.Notes) != ""
+	case "assignee":
+		return issue.Assignee != ""
+	case "estimate":
+		return issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0
+	case "external_ref":
+		return issue.ExternalRef != nil && *issue.ExternalRef != ""
+	case "labels":
+		return len(issue.Labels) > 0
+	}
+	return true
+}
+
+func labelAllowed(label string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, label); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// findCycles returns the cycles formed by blocking dependencies among issues,
+// one per strongly connected component, each starting at its smallest ID.
+func findCycles(issues []*types.Issue) [][]string {
+	edges := make(map[string][]string)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep.Type.AffectsReadyWork() && (dep.IssueID == "" || dep.IssueID == issue.ID) {
+				edges[issue.ID] = append(edges[issue.ID], dep.DependsOnID)
+			}
+		}
+	}
+	ids := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		sort.Strings(edges[id])
+	}
+
+	// Tarjan's strongly connected components
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+	var visit func(id string)
+	visit = func(id string) {
+		index[id] = len(index)
+		low[id] = index[id]
+		stack = append(stack, id)
+		onStack[id] = true
+		for _, next := range edges[id] {
+			if _, seen := index[next]; !seen {
+				visit(next)
+				low[id] = min(low[id], low[next])
+			} else if onStack[next] {
+				low[id] = min(low[id], index[next])
+			}
+		}
+		if low[id] != index[id] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		selfLoop := len(component) == 1 && slices.Contains(edges[id], id)
+		if len(component) > 1 || selfLoop {
+			cycles = append(cycles, cyclePath(component, edges))
+		}
+	}
+	for _, id := range ids {
+		if _, seen := index[id]; !seen {
+			visit(id)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// cyclePath walks a strongly connected component from its smallest ID back
+// to itself, returning the IDs along the way.
+func cyclePath(component []string, edges map[string][]string) []string {
+	members := make(map[string]bool, len(component))
+	for _, id := range component {
+		members[id] = true
+	}
+	sort.Strings(component)
+	start := component[0]
+
+	// Breadth-first search for the shortest path back to start
+	prev := map[string]string{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range edges[id] {
+			if !members[next] {
+				continue
+			}
+			if next == start {
+				path := []string{id}
+				for path[0] != start {
+					path = append([]string{prev[path[0]]}, path...)
+				}
+				return path
+			}
+			if _, seen := prev[next]; !seen {
+				prev[next] = id
+				queue = append(queue, next)
+			}
+		}
+	}
+	return component
+}
@@ -0,0 +1,127 @@
+package validation
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/typeschema"
+)
+
+func specIssue(id string, issueType types.IssueType, priority int, deps ...*types.Dependency) *types.Issue {
+	return &types.Issue{ID: id, Title: id, Status: types.StatusOpen, Priority: priority, IssueType: issueType, Dependencies: deps}
+}
+
+func dep(from, to string, depType types.DependencyType) *types.Dependency {
+	return &types.Dependency{IssueID: from, DependsOnID: to, Type: depType}
+}
+
+func violationKeys(vs []Violation) []string {
+	var keys []string
+	for _, v := range vs {
+		keys = append(keys, v.IssueID+":"+v.Rule)
+	}
+	return keys
+}
+
+func TestCheckSpecs(t *testing.T) {
+	est := 30
+	epic := specIssue("bd-1", types.TypeEpic, 2)
+	epic.Description = "Goal"
+	estimated := specIssue("bd-2", types.TypeTask, 1, dep("bd-2", "bd-1", types.DepParentChild))
+	estimated.EstimatedMinutes = &est
+
+	issues := []*types.Issue{
+		epic,
+		estimated,
+		specIssue("bd-3", types.TypeBug, 2),  // No description
+		specIssue("bd-4", types.TypeTask, 0), // P0 without estimate
+		specIssue("bd-5", types.TypeTask, 2, dep("bd-5", "bd-9", types.DepParentChild)), // Missing parent
+		specIssue("bd-6", types.TypeTask, 2, dep("bd-6", "bd-2", types.DepParentChild)), // Parent not an epic
+		specIssue("bd-7", types.TypeTask, 2, dep("bd-7", "bd-8", types.DepBlocks)),      // Missing target
+		specIssue("bd-x", types.TypeTask, 2, dep("bd-x", "external:other:cap", types.DepBlocks)),
+	}
+	issues[2].Labels = []string{"area:ui", "urgent"}
+
+	rules := SpecRules{
+		RequiredFields:      map[string][]string{"bug": {"description"}},
+		EstimateMaxPriority: 1,
+		LabelPatterns:       []string{"area:*"},
+	}
+
+	got := strings.Join(violationKeys(CheckSpecs(issues, nil, rules)), ",")
+	want := "bd-3:required-field,bd-3:label-taxonomy,bd-4:estimate,bd-5:missing-parent,bd-6:parent-type,bd-7:missing-dependency"
+	if got != want {
+		t.Errorf("violations:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestCheckSpecsLookup(t *testing.T) {
+	issues := []*types.Issue{specIssue("bd-2", types.TypeTask, 2, dep("bd-2", "bd-1", types.DepParentChild))}
+	lookup := func(id string) *types.Issue {
+		if id == "bd-1" {
+			return specIssue("bd-1", types.TypeEpic, 2)
+		}
+		return nil
+	}
+	if vs := CheckSpecs(issues, lookup, SpecRules{EstimateMaxPriority: -1}); len(vs) != 0 {
+		t.Errorf("unexpected violations: %v", vs)
+	}
+}
+
+func TestCheckSpecsInvalidField(t *testing.T) {
+	issue := specIssue("bd-1", types.IssueType("story"), 2)
+	issue.Title = ""
+	vs := CheckSpecs([]*types.Issue{issue}, nil, SpecRules{EstimateMaxPriority: -1})
+	if len(vs) != 1 || vs[0].Rule != RuleInvalidField {
+		t.Errorf("got %v", vs)
+	}
+	vs = CheckSpecs([]*types.Issue{specIssue("bd-2", types.IssueType("story"), 2)}, nil,
+		SpecRules{EstimateMaxPriority: -1, CustomTypes: []string{"story"}})
+	if len(vs) != 0 {
+		t.Errorf("custom type should be accepted: %v", vs)
+	}
+}
+
+func TestCheckSpecsTypeFields(t *testing.T) {
+	filled := specIssue("bd-1", types.IssueType("incident"), 2)
+	filled.Metadata = json.RawMessage(`{"fields":{"severity":"sev1"}}`)
+	issues := []*types.Issue{filled, specIssue("bd-2", types.IssueType("incident"), 2), specIssue("bd-3", types.TypeTask, 2)}
+	rules := SpecRules{
+		EstimateMaxPriority: -1,
+		CustomTypes:         []string{"incident"},
+		TypeSchemas:         []typeschema.Schema{{Type: "incident", Fields: []typeschema.Field{{Name: "severity", Required: true}}}},
+	}
+	vs := CheckSpecs(issues, nil, rules)
+	if got := strings.Join(violationKeys(vs), " "); got != "bd-2:type-field" {
+		t.Errorf("violations = %s (%v)", got, vs)
+	}
+}
+
+func TestFindCycles(t *testing.T) {
+	issues := []*types.Issue{
+		specIssue("a", types.TypeTask, 2, dep("a", "b", types.DepBlocks)),
+		specIssue("b", types.TypeTask, 2, dep("b", "c", types.DepBlocks)),
+		specIssue("c", types.TypeTask, 2, dep("c", "a", types.DepConditionalBlocks)),
+		specIssue("d", types.TypeTask, 2, dep("d", "d", types.DepBlocks)),
+		specIssue("e", types.TypeTask, 2, dep("e", "a", types.DepBlocks)),
+		specIssue("f", types.TypeTask, 2, dep("f", "e", types.DepRelated)),
+		specIssue("g", types.TypeTask, 2, dep("g", "f", types.DepRelated)),
+	}
+	// Non-blocking edges don't form cycles
+	issues[4].Dependencies = append(issues[4].Dependencies, dep("e", "g", types.DepRelated))
+
+	var got []string
+	for _, c := range findCycles(issues) {
+		got = append(got, strings.Join(c, ">"))
+	}
+	if strings.Join(got, " ") != "a>b>c d" {
+		t.Errorf("cycles = %v", got)
+	}
+
+	vs := CheckSpecs(issues, nil, SpecRules{EstimateMaxPriority: -1})
+	if len(vs) != 2 || vs[0].Message != "dependency cycle: a → b → c → a" {
+		t.Errorf("violations = %+v", vs)
+	}
+}
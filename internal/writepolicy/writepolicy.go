@@ -0,0 +1,184 @@
+// Package writepolicy applies the write policies configured in config.yaml:
+// secret scanning, type-specific fields, acceptance criteria and evidence on
+// close, and quiet hours. Both bd's commands and its RPC server call these
+// checks, so they return errors rather than printing or exiting, and leave
+// flag hints to the caller. Checks that can run in warn mode return the
+// problem as a warning instead of an error.
+package writepolicy
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/beads/internal/acceptance"
+	"github.com/steveyegge/beads/internal/config"
+	"github.com/steveyegge/beads/internal/quiethours"
+	"github.com/steveyegge/beads/internal/resolution"
+	"github.com/steveyegge/beads/internal/secretscan"
+	"github.com/steveyegge/beads/internal/types"
+	"github.com/steveyegge/beads/internal/typeschema"
+)
+
+// Values for scan.mode, which controls what happens when text written to
+// an issue looks like it contains secrets or PII.
+const (
+	ScanModeOff        = "off"        // Don't scan (default)
+	ScanModeWarn       = "warn"       // Report the findings and write anyway
+	ScanModeQuarantine = "quarantine" // Warn and add scan.quarantine-label to the issue
+	ScanModeBlock      = "block"      // Refuse the write
+)
+
+// NewSecretScanner returns a scanner for the built-in secret rules plus the
+// PII patterns in scan.pii-patterns.
+func NewSecretScanner() (*secretscan.Scanner, error) {
+	return secretscan.New(config.GetStringMapString("scan.pii-patterns"))
+}
+
+// TextScan is what ScanText found in the text a write is about to store.
+type TextScan struct {
+	Findings []secretscan.Finding
+	Label    string // Label to add to the issue when the text is quarantined, or ""
+	Warning  string // Set when scan.pii-patterns is invalid and only secrets were looked for
+}
+
+// ScanText scans the text fields a write is about to store, according to
+// scan.mode. In block mode it returns an error listing the findings.
+func ScanText(fields map[string]string) (TextScan, error) {
+	var scan TextScan
+	mode := config.GetString("scan.mode")
+	if mode == "" || mode == ScanModeOff {
+		return scan, nil
+	}
+	scanner, err := NewSecretScanner()
+	if err != nil {
+		scan.Warning = fmt.Sprintf("scan.pii-patterns: %v (scanning for secrets only)", err)
+		scanner = secretscan.Default()
+	}
+	scan.Findings = scanner.ScanFields(fields)
+	if len(scan.Findings) == 0 {
+		return scan, nil
+	}
+	if mode == ScanModeBlock {
+		return scan, fmt.Errorf("text looks like it contains secrets or PII:\n%s", FormatFindings(scan.Findings))
+	}
+	if mode == ScanModeQuarantine {
+		scan.Label = config.GetString("scan.quarantine-label")
+	}
+	return scan, nil
+}
+
+// FormatFindings lists findings one per line, with masked matches.
+func FormatFindings(findings []secretscan.Finding) string {
+	lines := make([]string, len(findings))
+	for i, f := range findings {
+		lines[i] = fmt.Sprintf("  - %s: %s (%s)", f.Field, f.Rule, f.Match)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TypeSchemas parses the type-schemas and type-templates config.
+func TypeSchemas() ([]typeschema.Schema, error) {
+	return typeschema.ParseSchemas(config.GetStringMapString("type-schemas"), config.GetStringMapString("type-templates"))
+}
+
+// CheckTypeFields checks an issue's type-specific field values against the
+// schema for its type, per validation.type-fields: in error mode the
+// problems are returned as an error, in warn mode as a warning.
+func CheckTypeFields(issueType types.IssueType, values map[string]string) (warning string, err error) {
+	mode := config.GetString("validation.type-fields")
+	if mode == "none" {
+		return "", nil
+	}
+	schemas, err := TypeSchemas()
+	if err != nil {
+		return fmt.Sprintf("%v (not checking type fields)", err), nil
+	}
+	schema := typeschema.Find(schemas, issueType)
+	if schema == nil {
+		return "", nil
+	}
+	problems := schema.Check(values)
+	if len(problems) == 0 {
+		return "", nil
+	}
+	if mode == "error" {
+		return "", fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return strings.Join(problems, "; "), nil
+}
+
+// CheckAcceptanceOnClose checks that an issue's acceptance criteria all
+// pass before it is closed, per validation.acceptance-on-close: in error
+// mode a shortfall is returned as an error, in warn mode as a warning.
+func CheckAcceptanceOnClose(issue *types.Issue) (warning string, err error) {
+	mode := config.GetString("validation.acceptance-on-close")
+	if issue == nil || (mode != "warn" && mode != "error") {
+		return "", nil
+	}
+	criteria := acceptance.Parse(issue.AcceptanceCriteria)
+	if acceptance.AllPass(criteria) {
+		return "", nil
+	}
+	msg := fmt.Sprintf("acceptance criteria not all passing (%s)", acceptance.Describe(acceptance.Count(criteria)))
+	if mode == "warn" {
+		return msg, nil
+	}
+	return "", fmt.Errorf("%s", msg)
+}
+
+// CloseRules returns the close categories and required-category priority
+// from close.categories and close.require-category-max-priority.
+func CloseRules() (*resolution.Rules, error) {
+	rules, err := resolution.NewRules(config.GetStringSlice("close.categories"), config.GetInt("close.require-category-max-priority"))
+	if err != nil {
+		return nil, fmt.Errorf("close.categories: %w", err)
+	}
+	return rules, nil
+}
+
+// EvidenceRequired reports whether closing an issue of issueType with reason
+// needs evidence under close.require-evidence-types. Closing as anything
+// but completed (wontfix, duplicate, ...) fixes nothing, so needs none.
+func EvidenceRequired(requiredTypes []string, issueType types.IssueType, reason string, rules *resolution.Rules) bool {
+	if c := rules.Parse(reason).Category; c != "" && c != resolution.Completed {
+		return false
+	}
+	for _, t := range requiredTypes {
+		if strings.EqualFold(strings.TrimSpace(t), string(issueType)) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckCloseEvidence returns an error if issue can't be closed with reason
+// because close.require-evidence-types wants evidence and none is given.
+func CheckCloseEvidence(issue *types.Issue, reason string, rules *resolution.Rules, hasEvidence bool) error {
+	if hasEvidence || !EvidenceRequired(config.GetStringSlice("close.require-evidence-types"), issue.IssueType, reason, rules) {
+		return nil
+	}
+	return fmt.Errorf("%s issues need verification evidence", issue.IssueType)
+}
+
+// QuietHours returns the do-not-start windows configured in quiet-hours.
+func QuietHours() ([]quiethours.Window, error) {
+	windows, err := quiethours.Parse(config.GetStringSlice("quiet-hours"))
+	if err != nil {
+		return nil, fmt.Errorf("quiet-hours: %w", err)
+	}
+	return windows, nil
+}
+
+// CheckQuietHours refuses to start issue, by claiming it or moving it to
+// in_progress, during a window that holds back work of its priority.
+// Issues already in progress can be claimed as usual.
+func CheckQuietHours(windows []quiethours.Window, issue *types.Issue, now time.Time) error {
+	if issue.Status == types.StatusInProgress {
+		return nil
+	}
+	if w := quiethours.Active(windows, issue.Priority, now); w != nil {
+		return fmt.Errorf("not starting %s (P%d) during quiet hours %q", issue.ID, issue.Priority, w.Spec)
+	}
+	return nil
+}